@@ -99,11 +99,11 @@ func bidOnLot(c *cli.Context) error {
 	} else if c.String("amount") != "" {
 
 		// Parse amount
-		bidAmount, err := strconv.ParseFloat(c.String("amount"), 64)
+		bidAmount, err := cliutils.ParseAmount(c.String("amount"), cliutils.AmountUnit_Eth)
 		if err != nil {
 			return fmt.Errorf("Invalid bid amount '%s': %w", c.String("amount"), err)
 		}
-		amountWei = eth.EthToWei(bidAmount)
+		amountWei = bidAmount
 
 	} else {
 
@@ -119,12 +119,7 @@ func bidOnLot(c *cli.Context) error {
 		} else {
 
 			// Prompt for custom amount
-			inputAmount := cliutils.Prompt("Please enter an amount of ETH to bid:", "^\\d+(\\.\\d+)?$", "Invalid amount")
-			bidAmount, err := strconv.ParseFloat(inputAmount, 64)
-			if err != nil {
-				return fmt.Errorf("Invalid bid amount '%s': %w", inputAmount, err)
-			}
-			amountWei = eth.EthToWei(bidAmount)
+			amountWei = cliutils.PromptAmount("Please enter an amount of ETH to bid:", cliutils.AmountUnit_Eth)
 
 		}
 
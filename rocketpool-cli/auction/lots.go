@@ -34,6 +34,11 @@ func getLots(c *cli.Context) error {
 		return err
 	}
 
+	// Print the response as JSON if requested
+	if cliutils.IsJsonOutput() {
+		return cliutils.PrintJson(lots)
+	}
+
 	// Get lots by status
 	openLots := []api.LotDetails{}
 	clearedLots := []api.LotDetails{}
@@ -32,6 +32,11 @@ func getStatus(c *cli.Context) error {
 		return err
 	}
 
+	// Print the response as JSON if requested
+	if cliutils.IsJsonOutput() {
+		return cliutils.PrintJson(status)
+	}
+
 	// Print & return
 	fmt.Printf(
 		"A total of %.6f RPL is up for auction, with %.6f RPL currently allotted and %.6f RPL remaining.\n",
@@ -33,6 +33,11 @@ func getStatus(c *cli.Context) error {
 		return err
 	}
 
+	// Print the response as JSON if requested
+	if cliutils.IsJsonOutput() {
+		return cliutils.PrintJson(status)
+	}
+
 	// Print status & return
 	fmt.Printf("The faucet has a balance of %.6f legacy RPL.\n", math.RoundDown(eth.WeiToEth(status.Balance), 6))
 	if status.WithdrawableAmount.Cmp(big.NewInt(0)) > 0 {
@@ -146,6 +146,9 @@ func closeMinipools(c *cli.Context) error {
 		}
 
 		fmt.Printf("Closing minipool %s...\n", minipool.Address.Hex())
+		if response.KeyUnloadError != "" {
+			fmt.Printf("%sWARNING: Could not remove the minipool's validator key from your Validator Client: %s%s\n", colorYellow, response.KeyUnloadError, colorReset)
+		}
 		cliutils.PrintTransactionHash(rp, response.TxHash)
 		if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
 			fmt.Printf("Could not close minipool %s: %s.\n", minipool.Address.Hex(), err)
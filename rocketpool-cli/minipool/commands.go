@@ -138,7 +138,11 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 					},
 					cli.StringFlag{
 						Name:  "minipool, m",
-						Usage: "The minipool/s to exit (address or 'all')",
+						Usage: "The minipool/s to exit (address, comma-separated addresses, or 'all')",
+					},
+					cli.Uint64Flag{
+						Name:  "schedule, s",
+						Usage: "The epoch to exit at; the exits are signed now and the node daemon broadcasts them when the epoch arrives",
 					},
 				},
 				Action: func(c *cli.Context) error {
@@ -150,7 +154,7 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 
 					// Validate flags
 					if c.String("minipool") != "" && c.String("minipool") != "all" {
-						if _, err := cliutils.ValidateAddress("minipool address", c.String("minipool")); err != nil {
+						if _, err := cliutils.ValidateAddresses("minipool addresses", c.String("minipool")); err != nil {
 							return err
 						}
 					}
@@ -160,6 +164,110 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 
 				},
 			},
+			{
+				Name:      "export-exit-messages",
+				Aliases:   []string{"xm"},
+				Usage:     "Export encrypted signed exits for all of your staking minipools, so their validators can be exited later without the node wallet",
+				UsageText: "rocketpool minipool export-exit-messages [options]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "output, o",
+						Usage: "The file to save the encrypted exits to",
+						Value: "rocketpool-exit-messages.json",
+					},
+					cli.StringFlag{
+						Name:  "decrypt, d",
+						Usage: "Decrypt an exported exits file and print its exits instead of exporting them; this doesn't need the node",
+					},
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm the export",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return exportExitMessages(c)
+
+				},
+			},
+			{
+				Name:      "begin-bond-reduction",
+				Aliases:   []string{"bbr"},
+				Usage:     "Begin reducing the bond of 16 ETH minipools, so they can become 8 ETH minipools once the Oracle DAO has checked them",
+				UsageText: "rocketpool minipool begin-bond-reduction [options]",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm beginning the bond reduction",
+					},
+					cli.StringFlag{
+						Name:  "minipool, m",
+						Usage: "The minipool/s to reduce the bond of (address or 'all')",
+					},
+					cli.StringFlag{
+						Name:  "amount, a",
+						Usage: "The new bond amount in ETH (defaults to 8)",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Validate flags
+					if c.String("minipool") != "" && c.String("minipool") != "all" {
+						if _, err := cliutils.ValidateAddress("minipool address", c.String("minipool")); err != nil {
+							return err
+						}
+					}
+
+					// Run
+					return beginReduceBondAmount(c)
+
+				},
+			},
+			{
+				Name:      "reduce-bond",
+				Aliases:   []string{"rb"},
+				Usage:     "Complete the bond reduction of minipools whose reduction window is open",
+				UsageText: "rocketpool minipool reduce-bond [options]",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm reducing the bond",
+					},
+					cli.StringFlag{
+						Name:  "minipool, m",
+						Usage: "The minipool/s to reduce the bond of (address or 'all')",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Validate flags
+					if c.String("minipool") != "" && c.String("minipool") != "all" {
+						if _, err := cliutils.ValidateAddress("minipool address", c.String("minipool")); err != nil {
+							return err
+						}
+					}
+
+					// Run
+					return reduceBondAmount(c)
+
+				},
+			},
 			/*
 			   REMOVED UNTIL BEACON WITHDRAWALS
 			   cli.Command{
@@ -296,6 +404,14 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 						Name:  "prefix, p",
 						Usage: "The prefix of the address to search for (must start with 0x)",
 					},
+					cli.StringFlag{
+						Name:  "suffix, x",
+						Usage: "The suffix of the address to search for, in hex",
+					},
+					cli.StringFlag{
+						Name:  "contains, c",
+						Usage: "A hex string the address must contain anywhere in it",
+					},
 					cli.StringFlag{
 						Name:  "salt, s",
 						Usage: "The salt to start searching from (must start with 0x)",
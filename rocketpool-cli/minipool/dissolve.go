@@ -145,6 +145,9 @@ func dissolveMinipools(c *cli.Context) error {
 		}
 
 		fmt.Printf("Closing minipool %s...\n", minipool.Address.Hex())
+		if closeResponse.KeyUnloadError != "" {
+			fmt.Printf("%sWARNING: Could not remove the minipool's validator key from your Validator Client: %s%s\n", colorYellow, closeResponse.KeyUnloadError, colorReset)
+		}
 		cliutils.PrintTransactionHash(rp, closeResponse.TxHash)
 		if _, err = rp.WaitForTransaction(closeResponse.TxHash); err != nil {
 			fmt.Printf("Could not close minipool %s: %s.\n", minipool.Address.Hex(), err)
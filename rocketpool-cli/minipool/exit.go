@@ -4,7 +4,6 @@ import (
 	"bytes"
 	"fmt"
 
-	"github.com/ethereum/go-ethereum/common"
 	"github.com/rocket-pool/rocketpool-go/types"
 	"github.com/urfave/cli"
 
@@ -53,18 +52,26 @@ func exitMinipools(c *cli.Context) error {
 	if c.String("minipool") == "" {
 
 		// Prompt for minipool selection
-		options := make([]string, len(activeMinipools)+1)
+		options := make([]string, len(activeMinipools)+2)
 		options[0] = "All available minipools"
+		options[1] = "Multiple minipools (enter their addresses)"
 		for mi, minipool := range activeMinipools {
-			options[mi+1] = fmt.Sprintf("%s (staking since %s)", minipool.Address.Hex(), minipool.Status.StatusTime.Format(TimeFormat))
+			options[mi+2] = fmt.Sprintf("%s (staking since %s)", minipool.Address.Hex(), minipool.Status.StatusTime.Format(TimeFormat))
 		}
 		selected, _ := cliutils.Select("Please select a minipool to exit:", options)
 
 		// Get minipools
-		if selected == 0 {
+		switch selected {
+		case 0:
 			selectedMinipools = activeMinipools
-		} else {
-			selectedMinipools = []api.MinipoolDetails{activeMinipools[selected-1]}
+		case 1:
+			addresses := cliutils.Prompt("Please enter the addresses of the minipools to exit, separated by commas:", "^0x[0-9a-fA-F]{40}(\\s*,\\s*0x[0-9a-fA-F]{40})*$", "Invalid minipool addresses")
+			selectedMinipools, err = getMinipoolsToExit(activeMinipools, addresses)
+			if err != nil {
+				return err
+			}
+		default:
+			selectedMinipools = []api.MinipoolDetails{activeMinipools[selected-2]}
 		}
 
 	} else {
@@ -73,20 +80,19 @@ func exitMinipools(c *cli.Context) error {
 		if c.String("minipool") == "all" {
 			selectedMinipools = activeMinipools
 		} else {
-			selectedAddress := common.HexToAddress(c.String("minipool"))
-			for _, minipool := range activeMinipools {
-				if bytes.Equal(minipool.Address.Bytes(), selectedAddress.Bytes()) {
-					selectedMinipools = []api.MinipoolDetails{minipool}
-					break
-				}
-			}
-			if selectedMinipools == nil {
-				return fmt.Errorf("The minipool %s is not available for exiting.", selectedAddress.Hex())
+			selectedMinipools, err = getMinipoolsToExit(activeMinipools, c.String("minipool"))
+			if err != nil {
+				return err
 			}
 		}
 
 	}
 
+	// Sign the exits now and let the node daemon broadcast them if they're scheduled
+	if c.IsSet("schedule") {
+		return scheduleMinipoolExits(c, rp, selectedMinipools, c.Uint64("schedule"))
+	}
+
 	colorReset := "\033[0m"
 	colorRed := "\033[31m"
 
@@ -122,3 +128,58 @@ func exitMinipools(c *cli.Context) error {
 	return nil
 
 }
+
+// Schedule the exits of minipools at an epoch
+func scheduleMinipoolExits(c *cli.Context, rp *rocketpool.Client, minipools []api.MinipoolDetails, epoch uint64) error {
+
+	colorReset := "\033[0m"
+	colorRed := "\033[31m"
+
+	// Show a warning message
+	fmt.Printf("%s***WARNING***\n", colorRed)
+	fmt.Printf("You are about to sign the exits of your minipools for epoch %d. The node daemon will broadcast them once that epoch starts, which will tell their validators to stop all activities on the Beacon Chain.\n", epoch)
+	fmt.Printf("Anyone who gets hold of a signed exit can broadcast it, so it can't be taken back once it's been signed.\n\n%s", colorReset)
+
+	// Prompt for confirmation
+	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to schedule the exits of %d minipool(s) for epoch %d? This action cannot be undone!", len(minipools), epoch))) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Schedule the exits
+	for _, minipool := range minipools {
+		if _, err := rp.ScheduleExitMinipool(minipool.Address, epoch); err != nil {
+			fmt.Printf("Could not schedule the exit of minipool %s: %s.\n", minipool.Address.Hex(), err)
+		} else {
+			fmt.Printf("Successfully scheduled the exit of minipool %s for epoch %d.\n", minipool.Address.Hex(), epoch)
+		}
+	}
+	fmt.Println("The node daemon must be running for the exits to be broadcast; you can check on them with `rocketpool minipool status`.")
+
+	// Return
+	return nil
+
+}
+
+// Get the minipools in a comma-separated list of addresses, making sure they can all be exited
+func getMinipoolsToExit(activeMinipools []api.MinipoolDetails, addressList string) ([]api.MinipoolDetails, error) {
+	addresses, err := cliutils.ValidateAddresses("minipool addresses", addressList)
+	if err != nil {
+		return nil, err
+	}
+	selectedMinipools := []api.MinipoolDetails{}
+	for _, address := range addresses {
+		found := false
+		for _, minipool := range activeMinipools {
+			if bytes.Equal(minipool.Address.Bytes(), address.Bytes()) {
+				selectedMinipools = append(selectedMinipools, minipool)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("The minipool %s is not available for exiting.", address.Hex())
+		}
+	}
+	return selectedMinipools, nil
+}
@@ -0,0 +1,200 @@
+package minipool
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/urfave/cli"
+	eth2ks "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+
+	"github.com/rocket-pool/smartnode/shared/services/passwords"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/rocket-pool/smartnode/shared/utils/hex"
+)
+
+// The version of the exported exit messages file
+const exitMessagesFileVersion uint = 1
+
+// An exported exit messages file.
+// The exits are encrypted with the same cipher as EIP-2335 validator keystores, so they can only be used with the file's password.
+type exitMessagesFile struct {
+	Version     uint                   `json:"version"`
+	Description string                 `json:"description"`
+	NodeAddress common.Address         `json:"nodeAddress"`
+	Epoch       uint64                 `json:"epoch"`
+	Crypto      map[string]interface{} `json:"crypto"`
+}
+
+// An exported exit, in the format the Beacon API's voluntary exit pool accepts plus the minipool it belongs to
+type exportedExit struct {
+	MinipoolAddress common.Address        `json:"minipoolAddress"`
+	ValidatorPubkey types.ValidatorPubkey `json:"validatorPubkey"`
+	SignedExit      signedVoluntaryExit   `json:"signedExit"`
+}
+type signedVoluntaryExit struct {
+	Message struct {
+		Epoch          string `json:"epoch"`
+		ValidatorIndex string `json:"validator_index"`
+	} `json:"message"`
+	Signature string `json:"signature"`
+}
+
+func exportExitMessages(c *cli.Context) error {
+
+	// Decrypting an export doesn't need the node
+	if c.String("decrypt") != "" {
+		return decryptExitMessages(c.String("decrypt"))
+	}
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Check and assign the EC status
+	err = cliutils.CheckExecutionClientStatus(rp)
+	if err != nil {
+		return err
+	}
+
+	// Check the output file
+	outputPath := c.String("output")
+	if _, err := os.Stat(outputPath); err == nil {
+		if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("%s already exists. Would you like to overwrite it?", outputPath))) {
+			fmt.Println("Cancelled.")
+			return nil
+		}
+	}
+
+	// Get the node address
+	walletStatus, err := rp.WalletStatus()
+	if err != nil {
+		return err
+	}
+
+	// Show a warning message
+	fmt.Printf("%s***WARNING***\n", colorYellow)
+	fmt.Println("Anyone who can decrypt this file can exit all of your minipools' validators at any time, and exits can't be undone.")
+	fmt.Println("Keep it, and its password, somewhere at least as safe as your wallet's recovery mnemonic.")
+	fmt.Printf("Exits are signed for the current fork, so they may need to be exported again after future network upgrades.%s\n\n", colorReset)
+	if !(c.Bool("yes") || cliutils.Confirm("Are you sure you want to export signed exits for all of your staking minipools?")) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Get the signed exits
+	response, err := rp.GetSignedExitMessages()
+	if err != nil {
+		return err
+	}
+	if len(response.Exits) == 0 {
+		fmt.Println("None of your minipools have validators on the Beacon Chain yet, so there are no exits to export.")
+		return nil
+	}
+	exits := make([]exportedExit, len(response.Exits))
+	for i, exit := range response.Exits {
+		exits[i].MinipoolAddress = exit.MinipoolAddress
+		exits[i].ValidatorPubkey = exit.ValidatorPubkey
+		exits[i].SignedExit.Message.Epoch = strconv.FormatUint(exit.Epoch, 10)
+		exits[i].SignedExit.Message.ValidatorIndex = strconv.FormatUint(exit.ValidatorIndex, 10)
+		exits[i].SignedExit.Signature = hex.AddPrefix(exit.Signature.Hex())
+	}
+	exitsBytes, err := json.Marshal(exits)
+	if err != nil {
+		return fmt.Errorf("Could not serialize the signed exits: %w", err)
+	}
+
+	// Encrypt them
+	password := promptExportPassword()
+	crypto, err := eth2ks.New(eth2ks.WithCipher("scrypt")).Encrypt(exitsBytes, password)
+	if err != nil {
+		return fmt.Errorf("Could not encrypt the signed exits: %w", err)
+	}
+	file := exitMessagesFile{
+		Version:     exitMessagesFileVersion,
+		Description: fmt.Sprintf("Signed exits for the %d minipools of Rocket Pool node %s; decrypt them with `rocketpool minipool export-exit-messages --decrypt`", len(exits), walletStatus.AccountAddress.Hex()),
+		NodeAddress: walletStatus.AccountAddress,
+		Epoch:       response.Epoch,
+		Crypto:      crypto,
+	}
+	fileBytes, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return fmt.Errorf("Could not serialize the exit messages file: %w", err)
+	}
+	if err := ioutil.WriteFile(outputPath, fileBytes, 0600); err != nil {
+		return fmt.Errorf("Could not write the exit messages file: %w", err)
+	}
+
+	// Log & return
+	fmt.Printf("Successfully exported signed exits for %d minipool(s) to %s.\n", len(exits), outputPath)
+	return nil
+
+}
+
+// Decrypt an exported exit messages file and print the exits in the format the Beacon API accepts
+func decryptExitMessages(path string) error {
+
+	// Read the file
+	fileBytes, err := ioutil.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("Could not read the exit messages file: %w", err)
+	}
+	var file exitMessagesFile
+	if err := json.Unmarshal(fileBytes, &file); err != nil {
+		return fmt.Errorf("Could not decode the exit messages file: %w", err)
+	}
+	if file.Version != exitMessagesFileVersion {
+		return fmt.Errorf("Unsupported exit messages file version %d.", file.Version)
+	}
+
+	// Decrypt it
+	password := cliutils.PromptPassword("Please enter the password the exit messages were exported with:", "^.*$", "")
+	exitsBytes, err := eth2ks.New().Decrypt(file.Crypto, password)
+	if err != nil {
+		return fmt.Errorf("Could not decrypt the exit messages file, please check the password: %w", err)
+	}
+	var exits []exportedExit
+	if err := json.Unmarshal(exitsBytes, &exits); err != nil {
+		return fmt.Errorf("Could not decode the exit messages: %w", err)
+	}
+
+	// Print them
+	fmt.Printf("Signed exits for node %s, signed at epoch %d:\n\n", file.NodeAddress.Hex(), file.Epoch)
+	for _, exit := range exits {
+		signedExitBytes, err := json.Marshal(exit.SignedExit)
+		if err != nil {
+			return fmt.Errorf("Could not serialize the exit of minipool %s: %w", exit.MinipoolAddress.Hex(), err)
+		}
+		fmt.Printf("Minipool %s (validator %s):\n", exit.MinipoolAddress.Hex(), hex.AddPrefix(exit.ValidatorPubkey.Hex()))
+		fmt.Printf("%s\n\n", string(signedExitBytes))
+	}
+	fmt.Println("To exit a validator, send its exit to any synced Beacon node, for example:")
+	fmt.Println("curl -X POST -H \"Content-Type: application/json\" -d '<exit>' http://<beacon-node>:5052/eth/v1/beacon/pool/voluntary_exits")
+	return nil
+
+}
+
+// Prompt for the password to encrypt the exported exits with
+func promptExportPassword() string {
+	for {
+		password := cliutils.PromptPassword(
+			"Please enter a password to encrypt the exported exits with:",
+			fmt.Sprintf("^.{%d,}$", passwords.MinPasswordLength),
+			fmt.Sprintf("Your password must be at least %d characters long. Please try again:", passwords.MinPasswordLength),
+		)
+		confirmation := cliutils.PromptPassword("Please confirm your password:", "^.*$", "")
+		if password == confirmation {
+			return password
+		}
+		fmt.Println("Password confirmation does not match.")
+		fmt.Println("")
+	}
+}
@@ -0,0 +1,326 @@
+package minipool
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	rocketpoolapi "github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/gas"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/rocket-pool/smartnode/shared/utils/math"
+)
+
+// Settings
+const (
+	reducibleBondEth  float64 = 16
+	defaultNewBondEth float64 = 8
+)
+
+func beginReduceBondAmount(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Check and assign the EC status
+	err = cliutils.CheckExecutionClientStatus(rp)
+	if err != nil {
+		return err
+	}
+
+	// Get the new bond amount
+	newBondEth := defaultNewBondEth
+	if c.String("amount") != "" {
+		newBondEth, err = cliutils.ValidatePositiveEthAmount("new bond amount", c.String("amount"))
+		if err != nil {
+			return err
+		}
+	}
+	newBond := eth.EthToWei(newBondEth)
+
+	// Get minipool statuses
+	status, err := rp.MinipoolStatus()
+	if err != nil {
+		return err
+	}
+
+	// Get the staking minipools with a bond that can be reduced
+	reducibleBond := eth.EthToWei(reducibleBondEth)
+	reducibleMinipools := []api.MinipoolDetails{}
+	for _, minipool := range status.Minipools {
+		if minipool.Status.Status == types.Staking && minipool.Node.DepositBalance.Cmp(reducibleBond) == 0 {
+			reducibleMinipools = append(reducibleMinipools, minipool)
+		}
+	}
+
+	// Check for reducible minipools
+	if len(reducibleMinipools) == 0 {
+		fmt.Printf("None of your minipools are staking with a %.0f ETH bond, so there are no bonds to reduce.\n", reducibleBondEth)
+		return nil
+	}
+
+	// Get selected minipools
+	selectedMinipools, err := selectBondReductionMinipools(c, reducibleMinipools, "Please select a minipool to begin reducing the bond of:")
+	if err != nil {
+		return err
+	}
+
+	// Check each minipool and get the total gas limit estimate
+	var totalGas uint64 = 0
+	var totalSafeGas uint64 = 0
+	var gasInfo rocketpoolapi.GasInfo
+	readyMinipools := []api.MinipoolDetails{}
+	var lastResponse api.CanBeginReduceBondAmountResponse
+	for _, minipool := range selectedMinipools {
+		canResponse, err := rp.CanBeginReduceBondAmount(minipool.Address, newBond)
+		if err != nil {
+			fmt.Printf("Could not check if minipool %s can reduce its bond: %s.\n", minipool.Address.Hex(), err)
+			continue
+		}
+		if canResponse.NotSupported {
+			fmt.Println("Minipool bond reduction isn't supported by the Rocket Pool contracts on this network yet.")
+			return nil
+		}
+		if !canResponse.CanReduce {
+			fmt.Printf("Cannot begin reducing the bond of minipool %s:\n", minipool.Address.Hex())
+			if canResponse.InvalidStatus {
+				fmt.Println("The minipool is not staking.")
+			}
+			if canResponse.InvalidBond {
+				fmt.Printf("The new bond must be lower than the minipool's current bond of %.6f ETH.\n", math.RoundDown(eth.WeiToEth(canResponse.CurrentBond), 6))
+			}
+			if canResponse.AlreadyStarted {
+				fmt.Println("The minipool has already begun reducing its bond; run `rocketpool minipool reduce-bond` once its reduction window opens.")
+			}
+			if canResponse.InsufficientRplStake {
+				fmt.Printf("Your node needs at least %.6f RPL staked after the reduction, but it only has %.6f RPL staked. Please stake more RPL first.\n", math.RoundDown(eth.WeiToEth(canResponse.MinimumRplStake), 6), math.RoundDown(eth.WeiToEth(canResponse.RplStake), 6))
+			}
+			continue
+		}
+		readyMinipools = append(readyMinipools, minipool)
+		lastResponse = canResponse
+		gasInfo = canResponse.GasInfo
+		totalGas += canResponse.GasInfo.EstGasLimit
+		totalSafeGas += canResponse.GasInfo.SafeGasLimit
+	}
+	if len(readyMinipools) == 0 {
+		return nil
+	}
+	gasInfo.EstGasLimit = totalGas
+	gasInfo.SafeGasLimit = totalSafeGas
+
+	// Explain the process
+	fmt.Printf("Reducing a minipool's bond from %.0f ETH to %.6f ETH takes two steps.\n", reducibleBondEth, newBondEth)
+	fmt.Printf("First you begin the reduction, which gives the Oracle DAO %s to check the minipool's validator and cancel the reduction if it isn't healthy.\n", lastResponse.WindowStart)
+	fmt.Printf("After that you'll have %s to complete it with `rocketpool minipool reduce-bond`; if you miss the window, you'll have to begin again.\n", lastResponse.WindowLength)
+	fmt.Printf("Your node will need at least %.6f RPL staked when you complete it; it currently has %.6f RPL staked.\n\n", math.RoundDown(eth.WeiToEth(lastResponse.MinimumRplStake), 6), math.RoundDown(eth.WeiToEth(lastResponse.RplStake), 6))
+
+	// Assign max fees
+	err = gas.AssignMaxFeeAndLimit(gasInfo, rp, c.Bool("yes"))
+	if err != nil {
+		return err
+	}
+
+	// Prompt for confirmation
+	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to begin reducing the bond of %d minipool(s) to %.6f ETH?", len(readyMinipools), newBondEth))) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Begin the reductions
+	for _, minipool := range readyMinipools {
+		response, err := rp.BeginReduceBondAmount(minipool.Address, newBond)
+		if err != nil {
+			fmt.Printf("Could not begin reducing the bond of minipool %s: %s.\n", minipool.Address.Hex(), err)
+			continue
+		}
+
+		fmt.Printf("Beginning the bond reduction of minipool %s...\n", minipool.Address.Hex())
+		cliutils.PrintTransactionHash(rp, response.TxHash)
+		if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
+			fmt.Printf("Could not begin reducing the bond of minipool %s: %s.\n", minipool.Address.Hex(), err)
+		} else {
+			fmt.Printf("Successfully began the bond reduction of minipool %s; you can complete it after %s.\n", minipool.Address.Hex(), cliutils.FormatTime(time.Now().Add(lastResponse.WindowStart)))
+		}
+	}
+
+	// Return
+	return nil
+
+}
+
+func reduceBondAmount(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Check and assign the EC status
+	err = cliutils.CheckExecutionClientStatus(rp)
+	if err != nil {
+		return err
+	}
+
+	// Get minipool statuses
+	status, err := rp.MinipoolStatus()
+	if err != nil {
+		return err
+	}
+
+	// Get the minipools that have begun a bond reduction and can complete it
+	readyMinipools := []api.MinipoolDetails{}
+	responses := map[common.Address]api.CanReduceBondAmountResponse{}
+	for _, minipool := range status.Minipools {
+		if minipool.Status.Status != types.Staking {
+			continue
+		}
+		canResponse, err := rp.CanReduceBondAmount(minipool.Address)
+		if err != nil {
+			return err
+		}
+		if canResponse.NotSupported {
+			fmt.Println("Minipool bond reduction isn't supported by the Rocket Pool contracts on this network yet.")
+			return nil
+		}
+		if canResponse.NotStarted {
+			continue
+		}
+		if canResponse.Cancelled {
+			fmt.Printf("The bond reduction of minipool %s was cancelled by the Oracle DAO.\n", minipool.Address.Hex())
+			continue
+		}
+		if !canResponse.CanReduce {
+			if canResponse.WindowClosed && time.Now().Before(canResponse.WindowOpenTime) {
+				fmt.Printf("Minipool %s can complete its bond reduction after %s.\n", minipool.Address.Hex(), cliutils.FormatTime(canResponse.WindowOpenTime))
+			} else if canResponse.WindowClosed {
+				fmt.Printf("The bond reduction window of minipool %s closed at %s; run `rocketpool minipool begin-bond-reduction` to begin again.\n", minipool.Address.Hex(), cliutils.FormatTime(canResponse.WindowCloseTime))
+			}
+			if canResponse.InsufficientRplStake {
+				fmt.Printf("Minipool %s can't complete its bond reduction until your node has at least %.6f RPL staked; it only has %.6f RPL staked.\n", minipool.Address.Hex(), math.RoundDown(eth.WeiToEth(canResponse.MinimumRplStake), 6), math.RoundDown(eth.WeiToEth(canResponse.RplStake), 6))
+			}
+			continue
+		}
+		readyMinipools = append(readyMinipools, minipool)
+		responses[minipool.Address] = canResponse
+	}
+
+	// Check for ready minipools
+	if len(readyMinipools) == 0 {
+		fmt.Println("No minipools can complete a bond reduction right now.")
+		return nil
+	}
+
+	// Get selected minipools
+	selectedMinipools, err := selectBondReductionMinipools(c, readyMinipools, "Please select a minipool to complete the bond reduction of:")
+	if err != nil {
+		return err
+	}
+
+	// Get the total gas limit estimate
+	var totalGas uint64 = 0
+	var totalSafeGas uint64 = 0
+	var gasInfo rocketpoolapi.GasInfo
+	totalReduction := big.NewInt(0)
+	for _, minipool := range selectedMinipools {
+		canResponse := responses[minipool.Address]
+		gasInfo = canResponse.GasInfo
+		totalGas += canResponse.GasInfo.EstGasLimit
+		totalSafeGas += canResponse.GasInfo.SafeGasLimit
+		totalReduction.Add(totalReduction, big.NewInt(0).Sub(canResponse.CurrentBond, canResponse.NewBond))
+	}
+	gasInfo.EstGasLimit = totalGas
+	gasInfo.SafeGasLimit = totalSafeGas
+
+	// Assign max fees
+	err = gas.AssignMaxFeeAndLimit(gasInfo, rp, c.Bool("yes"))
+	if err != nil {
+		return err
+	}
+
+	// Prompt for confirmation
+	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to reduce the bond of %d minipool(s)? %.6f ETH of your bonds will be refunded to the minipools.", len(selectedMinipools), math.RoundDown(eth.WeiToEth(totalReduction), 6)))) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Reduce the bonds
+	for _, minipool := range selectedMinipools {
+		response, err := rp.ReduceBondAmount(minipool.Address)
+		if err != nil {
+			fmt.Printf("Could not reduce the bond of minipool %s: %s.\n", minipool.Address.Hex(), err)
+			continue
+		}
+
+		fmt.Printf("Reducing the bond of minipool %s...\n", minipool.Address.Hex())
+		cliutils.PrintTransactionHash(rp, response.TxHash)
+		if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
+			fmt.Printf("Could not reduce the bond of minipool %s: %s.\n", minipool.Address.Hex(), err)
+		} else {
+			fmt.Printf("Successfully reduced the bond of minipool %s to %.6f ETH.\n", minipool.Address.Hex(), math.RoundDown(eth.WeiToEth(responses[minipool.Address].NewBond), 6))
+		}
+	}
+
+	// Check the node's RPL stake now that the reductions are done
+	nodeStatus, err := rp.NodeStatus()
+	if err != nil {
+		return err
+	}
+	fmt.Printf("\nYour node has %.6f RPL staked, and its minimum RPL stake is now %.6f RPL.\n", math.RoundDown(eth.WeiToEth(nodeStatus.RplStake), 6), math.RoundDown(eth.WeiToEth(nodeStatus.MinimumRplStake), 6))
+	if nodeStatus.RplStake.Cmp(nodeStatus.MinimumRplStake) < 0 {
+		fmt.Println("WARNING: your node is below its minimum RPL stake, so it won't earn RPL rewards until you stake more with `rocketpool node stake-rpl`.")
+	}
+
+	// Return
+	return nil
+
+}
+
+// Select the minipools to reduce the bonds of, from the --minipool flag or a prompt
+func selectBondReductionMinipools(c *cli.Context, minipools []api.MinipoolDetails, prompt string) ([]api.MinipoolDetails, error) {
+
+	if c.String("minipool") == "" {
+
+		// Prompt for minipool selection
+		options := make([]string, len(minipools)+1)
+		options[0] = "All available minipools"
+		for mi, minipool := range minipools {
+			options[mi+1] = fmt.Sprintf("%s (%.6f ETH bond)", minipool.Address.Hex(), math.RoundDown(eth.WeiToEth(minipool.Node.DepositBalance), 6))
+		}
+		selected, _ := cliutils.Select(prompt, options)
+
+		// Get minipools
+		if selected == 0 {
+			return minipools, nil
+		}
+		return []api.MinipoolDetails{minipools[selected-1]}, nil
+
+	}
+
+	// Get matching minipools
+	if c.String("minipool") == "all" {
+		return minipools, nil
+	}
+	selectedAddress := common.HexToAddress(c.String("minipool"))
+	for _, minipool := range minipools {
+		if bytes.Equal(minipool.Address.Bytes(), selectedAddress.Bytes()) {
+			return []api.MinipoolDetails{minipool}, nil
+		}
+	}
+	return nil, fmt.Errorf("The minipool %s is not available for bond reduction.", selectedAddress.Hex())
+
+}
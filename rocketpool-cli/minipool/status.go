@@ -39,6 +39,11 @@ func getStatus(c *cli.Context) error {
 		return err
 	}
 
+	// Print the response as JSON if requested
+	if cliutils.IsJsonOutput() {
+		return cliutils.PrintJson(status)
+	}
+
 	// Get minipools by status
 	statusMinipools := map[string][]api.MinipoolDetails{}
 	refundableMinipools := []api.MinipoolDetails{}
@@ -167,6 +172,18 @@ func printMinipoolDetails(minipool api.MinipoolDetails, latestDelegate common.Ad
 		}
 	}
 
+	// Scheduled exit details
+	if minipool.ScheduledExit != nil {
+		switch minipool.ScheduledExit.State {
+		case api.ScheduledExitState_Scheduled:
+			fmt.Printf("Scheduled exit:       epoch %d\n", minipool.ScheduledExit.Epoch)
+		case api.ScheduledExitState_Broadcast:
+			fmt.Printf("Scheduled exit:       epoch %d (broadcast at %s)\n", minipool.ScheduledExit.Epoch, minipool.ScheduledExit.Updated.Format(TimeFormat))
+		case api.ScheduledExitState_Failed:
+			fmt.Printf("%sScheduled exit:       epoch %d (broadcast failed %d time(s), retrying: %s)%s\n", colorYellow, minipool.ScheduledExit.Epoch, minipool.ScheduledExit.Attempts, minipool.ScheduledExit.LastError, colorReset)
+		}
+	}
+
 	// Withdrawal details - withdrawable minipools
 	if minipool.Status.Status == types.Withdrawable {
 		fmt.Printf("Withdrawal available: yes\n")
@@ -33,17 +33,37 @@ func findVanitySalt(c *cli.Context) error {
 		return err
 	}
 
-	// Get the target prefix
+	// Get the target patterns
 	prefix := c.String("prefix")
-	if prefix == "" {
+	suffix := c.String("suffix")
+	contains := c.String("contains")
+	if prefix == "" && suffix == "" && contains == "" {
 		prefix = cliutils.Prompt("Please specify the address prefix you would like to search for (must start with 0x):", "^0x[0-9a-fA-F]+$", "Invalid hex string")
 	}
-	if !strings.HasPrefix(prefix, "0x") {
-		return fmt.Errorf("Prefix must start with 0x.")
+	patterns := []*vanityPattern{}
+	if prefix != "" {
+		if !strings.HasPrefix(prefix, "0x") {
+			return fmt.Errorf("Prefix must start with 0x.")
+		}
+		pattern, err := newVanityPattern(strings.TrimPrefix(prefix, "0x"), vanityMatch_Prefix)
+		if err != nil {
+			return fmt.Errorf("Invalid prefix: %w", err)
+		}
+		patterns = append(patterns, pattern)
 	}
-	targetPrefix, success := big.NewInt(0).SetString(prefix, 0)
-	if !success {
-		return fmt.Errorf("Invalid prefix: %s", prefix)
+	if suffix != "" {
+		pattern, err := newVanityPattern(strings.TrimPrefix(suffix, "0x"), vanityMatch_Suffix)
+		if err != nil {
+			return fmt.Errorf("Invalid suffix: %w", err)
+		}
+		patterns = append(patterns, pattern)
+	}
+	if contains != "" {
+		pattern, err := newVanityPattern(strings.TrimPrefix(contains, "0x"), vanityMatch_Contains)
+		if err != nil {
+			return fmt.Errorf("Invalid contains string: %w", err)
+		}
+		patterns = append(patterns, pattern)
 	}
 
 	// Get the starting salt
@@ -52,6 +72,7 @@ func findVanitySalt(c *cli.Context) error {
 	if saltString == "" {
 		salt = big.NewInt(0)
 	} else {
+		var success bool
 		salt, success = big.NewInt(0).SetString(saltString, 0)
 		if !success {
 			return fmt.Errorf("Invalid starting salt: %s", salt)
@@ -124,7 +145,6 @@ func findVanitySalt(c *cli.Context) error {
 	nodeAddress := vanityArtifacts.NodeAddress.Bytes()
 	minipoolManagerAddress := vanityArtifacts.MinipoolManagerAddress
 	initHash := vanityArtifacts.InitHash.Bytes()
-	shiftAmount := uint(42 - len(prefix))
 
 	// Run the search
 	fmt.Printf("Running with %d threads.\n", threads)
@@ -141,7 +161,7 @@ func findVanitySalt(c *cli.Context) error {
 		workerSalt := big.NewInt(0).Add(salt, saltOffset)
 
 		go func(i int) {
-			foundSalt, foundAddress := runWorker(i == 0, stopPtr, targetPrefix, nodeAddress, minipoolManagerAddress, initHash, workerSalt, int64(threads), shiftAmount)
+			foundSalt, foundAddress := runWorker(i == 0, stopPtr, patterns, nodeAddress, minipoolManagerAddress, initHash, workerSalt, int64(threads))
 			if foundSalt != nil {
 				fmt.Printf("Found on thread %d: salt 0x%x = %s\n", i, foundSalt, foundAddress.Hex())
 				*stopPtr = true
@@ -161,9 +181,10 @@ func findVanitySalt(c *cli.Context) error {
 
 }
 
-func runWorker(report bool, stop *bool, targetPrefix *big.Int, nodeAddress []byte, minipoolManagerAddress common.Address, initHash []byte, salt *big.Int, increment int64, shiftAmount uint) (*big.Int, common.Address) {
+func runWorker(report bool, stop *bool, patterns []*vanityPattern, nodeAddress []byte, minipoolManagerAddress common.Address, initHash []byte, salt *big.Int, increment int64) (*big.Int, common.Address) {
 	saltBytes := [32]byte{}
 	hashInt := big.NewInt(0)
+	scratch := big.NewInt(0)
 	incrementInt := big.NewInt(increment)
 	hasher := crypto.NewKeccakState()
 	nodeSalt := common.Hash{}
@@ -223,8 +244,7 @@ func runWorker(report bool, stop *bool, targetPrefix *big.Int, nodeAddress []byt
 		hasher.Reset()
 
 		hashInt.SetBytes(addressResult[12:])
-		hashInt.Rsh(hashInt, shiftAmount*4)
-		if hashInt.Cmp(targetPrefix) == 0 {
+		if matchesVanityPatterns(hashInt, patterns, scratch) {
 			if report {
 				close(tickerChan)
 			}
@@ -234,3 +254,77 @@ func runWorker(report bool, stop *bool, targetPrefix *big.Int, nodeAddress []byt
 		salt.Add(salt, incrementInt)
 	}
 }
+
+// Where a vanity pattern has to appear in the address
+type vanityMatchType int
+
+const (
+	vanityMatch_Prefix vanityMatchType = iota
+	vanityMatch_Suffix
+	vanityMatch_Contains
+)
+
+// The number of hex characters in an address, excluding the 0x
+const addressHexLength = 40
+
+// A hex string to search for in an address.
+// Each position the string can be in gets a target and a mask for it, shifted into place, so checking a position
+// is an XOR of the address with the target and an AND with the mask - it matches if nothing is left.
+type vanityPattern struct {
+	targets []*big.Int
+	masks   []*big.Int
+}
+
+// Create a vanity pattern from a hex string without the 0x
+func newVanityPattern(hexString string, matchType vanityMatchType) (*vanityPattern, error) {
+	length := len(hexString)
+	if length == 0 || length > addressHexLength {
+		return nil, fmt.Errorf("must be between 1 and %d hex characters", addressHexLength)
+	}
+	target, success := big.NewInt(0).SetString(hexString, 16)
+	if !success {
+		return nil, fmt.Errorf("%s is not a valid hex string", hexString)
+	}
+	mask := big.NewInt(1)
+	mask.Lsh(mask, uint(length*4))
+	mask.Sub(mask, big.NewInt(1))
+
+	// Get the offsets, in hex characters from the end of the address, that the pattern can start at
+	var shifts []int
+	switch matchType {
+	case vanityMatch_Prefix:
+		shifts = []int{addressHexLength - length}
+	case vanityMatch_Suffix:
+		shifts = []int{0}
+	case vanityMatch_Contains:
+		for shift := 0; shift <= addressHexLength-length; shift++ {
+			shifts = append(shifts, shift)
+		}
+	}
+
+	pattern := &vanityPattern{}
+	for _, shift := range shifts {
+		pattern.targets = append(pattern.targets, big.NewInt(0).Lsh(target, uint(shift*4)))
+		pattern.masks = append(pattern.masks, big.NewInt(0).Lsh(mask, uint(shift*4)))
+	}
+	return pattern, nil
+}
+
+// Check if an address matches all of the vanity patterns, using scratch as a working value
+func matchesVanityPatterns(address *big.Int, patterns []*vanityPattern, scratch *big.Int) bool {
+	for _, pattern := range patterns {
+		matched := false
+		for i, target := range pattern.targets {
+			scratch.Xor(address, target)
+			scratch.And(scratch, pattern.masks[i])
+			if scratch.Sign() == 0 {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
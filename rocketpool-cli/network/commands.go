@@ -1,6 +1,8 @@
 package network
 
 import (
+	"fmt"
+
 	"github.com/urfave/cli"
 
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
@@ -85,6 +87,72 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 
 				},
 			},
+
+			{
+				Name:      "generate-rewards-tree",
+				Aliases:   []string{"rt"},
+				Usage:     "Generate the rewards tree for an interval locally from the chain's state and verify it against the canonical Merkle root",
+				UsageText: "rocketpool network generate-rewards-tree --interval index [--execute]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "interval, i",
+						Usage: "The rewards interval to generate the tree for",
+					},
+					cli.BoolFlag{
+						Name:  "execute, e",
+						Usage: "Save the generated tree to the rewards tree folder if it matches the canonical root",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+					if !c.IsSet("interval") {
+						return fmt.Errorf("Please specify the interval to generate the tree for with --interval.")
+					}
+					index, err := cliutils.ValidateUint("interval", c.String("interval"))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					return generateRewardsTree(c, index)
+
+				},
+			},
+
+			{
+				Name:      "download-rewards-tree",
+				Aliases:   []string{"d"},
+				Usage:     "Download the rewards tree for an interval, trying each configured source in order and verifying it against the canonical Merkle root",
+				UsageText: "rocketpool network download-rewards-tree --interval index",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "interval, i",
+						Usage: "The rewards interval to download the tree for",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+					if !c.IsSet("interval") {
+						return fmt.Errorf("Please specify the interval to download the tree for with --interval.")
+					}
+					index, err := cliutils.ValidateUint("interval", c.String("interval"))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					return downloadRewardsTree(c, index)
+
+				},
+			},
 		},
 	})
 }
@@ -0,0 +1,43 @@
+package network
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+func downloadRewardsTree(c *cli.Context, index uint64) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Check and assign the EC status
+	err = cliutils.CheckExecutionClientStatus(rp)
+	if err != nil {
+		return err
+	}
+
+	// Download the tree
+	response, err := rp.DownloadRewardsTree(index)
+	if err != nil {
+		return err
+	}
+
+	// Print the response as JSON if requested
+	if cliutils.IsJsonOutput() {
+		return cliutils.PrintJson(response)
+	}
+
+	// Print & return
+	fmt.Printf("%sDownloaded the rewards tree for interval %d from %s and verified it against the canonical Merkle root.%s\n", colorGreen, index, response.Source, colorReset)
+	fmt.Printf("Saved it to %s.\n", response.Path)
+	return nil
+
+}
@@ -0,0 +1,68 @@
+package network
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+const (
+	colorReset  string = "\033[0m"
+	colorRed    string = "\033[31m"
+	colorGreen  string = "\033[32m"
+	colorYellow string = "\033[33m"
+)
+
+func generateRewardsTree(c *cli.Context, index uint64) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Check and assign the EC status
+	err = cliutils.CheckExecutionClientStatus(rp)
+	if err != nil {
+		return err
+	}
+
+	// Generate the tree
+	execute := c.Bool("execute")
+	if !cliutils.IsJsonOutput() {
+		fmt.Printf("Replaying the chain state for interval %d to generate its rewards tree; this can take a while...\n", index)
+	}
+	response, err := rp.GenerateRewardsTree(index, execute)
+	if err != nil {
+		return err
+	}
+
+	// Print the response as JSON if requested
+	if cliutils.IsJsonOutput() {
+		return cliutils.PrintJson(response)
+	}
+
+	// Print the results
+	fmt.Printf("Execution end block: %d\n", response.ExecutionEndBlock)
+	fmt.Printf("Consensus end block: %d\n", response.ConsensusEndBlock)
+	fmt.Printf("Nodes with rewards:  %d\n", response.NodeCount)
+	fmt.Printf("Generated root:      %s\n", response.MerkleRoot)
+	fmt.Printf("Canonical root:      %s\n\n", response.CanonicalRoot)
+	if !response.RootMatches {
+		fmt.Printf("%sThe generated tree doesn't match the canonical Merkle root for interval %d, so it wasn't saved.%s\n", colorRed, index, colorReset)
+		fmt.Printf("The local generator doesn't track attestation performance, so intervals where some minipools missed attestations while in the smoothing pool can't be reproduced exactly. Download the tree with `rocketpool network download-rewards-tree --interval %d` instead.\n", index)
+		return nil
+	}
+	fmt.Printf("%sThe generated tree matches the canonical Merkle root.%s\n", colorGreen, colorReset)
+	if response.Saved {
+		fmt.Printf("Saved the tree to %s.\n", response.Path)
+	} else {
+		fmt.Printf("%sThis was a dry run; run the command again with --execute to save the tree.%s\n", colorYellow, colorReset)
+	}
+	return nil
+
+}
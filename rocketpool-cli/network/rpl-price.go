@@ -32,6 +32,11 @@ func getRplPrice(c *cli.Context) error {
 		return err
 	}
 
+	// Print the response as JSON if requested
+	if cliutils.IsJsonOutput() {
+		return cliutils.PrintJson(response)
+	}
+
 	// Print & return
 	fmt.Printf("The current network RPL price is %.6f ETH.\n", math.RoundDown(eth.WeiToEth(response.RplPrice), 6))
 	fmt.Printf("Prices last updated at block: %d\n", response.RplPriceBlock)
@@ -29,6 +29,11 @@ func getStats(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
+
+	// Print the response as JSON if requested
+	if cliutils.IsJsonOutput() {
+		return cliutils.PrintJson(response)
+	}
 	activeMinipools := response.InitializedMinipoolCount +
 		response.PrelaunchMinipoolCount +
 		response.StakingMinipoolCount +
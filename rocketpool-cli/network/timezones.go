@@ -31,6 +31,11 @@ func getTimezones(c *cli.Context) error {
 		return err
 	}
 
+	// Print the response as JSON if requested
+	if cliutils.IsJsonOutput() {
+		return cliutils.PrintJson(response)
+	}
+
 	// Sort it by the timezone name
 	var maxNameLength int
 	timezoneNames := make([]string, 0, len(response.TimezoneCounts))
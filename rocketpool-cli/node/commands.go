@@ -50,6 +50,47 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "recommend-gas-thresholds",
+				Usage:     "Recommend the gas thresholds for automatic RPL claims and minipool stakes based on your minipool count and recent gas prices",
+				UsageText: "rocketpool node recommend-gas-thresholds [options]",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm using the recommended thresholds",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return recommendGasThresholds(c)
+
+				},
+			},
+
+			{
+				Name:      "performance",
+				Aliases:   []string{"pf"},
+				Usage:     "Check the attestation performance of the node's validators and get advice on underperforming ones",
+				UsageText: "rocketpool node performance",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return getPerformance(c)
+
+				},
+			},
+
 			{
 				Name:      "register",
 				Aliases:   []string{"r"},
@@ -84,8 +125,18 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 			{
 				Name:      "rewards",
 				Aliases:   []string{"e"},
-				Usage:     "Get the time and your expected RPL rewards of the next checkpoint",
-				UsageText: "rocketpool node rewards",
+				Usage:     "Get the time and your expected RPL rewards of the next checkpoint, or export the rewards your node earned in past intervals",
+				UsageText: "rocketpool node rewards [options]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "interval, i",
+						Usage: "Export the rewards the node earned in this past interval instead of showing the current one",
+					},
+					cli.BoolFlag{
+						Name:  "csv",
+						Usage: "Export the rewards the node earned in each past interval (or the one given with --interval) as CSV, e.g. for tax reporting; use `--output json` for JSON instead",
+					},
+				},
 				Action: func(c *cli.Context) error {
 
 					// Validate args
@@ -93,12 +144,61 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 						return err
 					}
 
+					// Validate flags
+					var interval *uint64
+					if c.String("interval") != "" {
+						index, err := cliutils.ValidateUint("interval", c.String("interval"))
+						if err != nil {
+							return err
+						}
+						interval = &index
+					}
+
 					// Run
+					if interval != nil || c.Bool("csv") {
+						return exportRewardsHistory(c, interval, c.Bool("csv"))
+					}
 					return getRewards(c)
 
 				},
 			},
 
+			{
+				Name:      "rewards-history",
+				Aliases:   []string{"rh"},
+				Usage:     "Get the rewards and APR your node actually earned in each past rewards interval",
+				UsageText: "rocketpool node rewards-history",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return getRewardsHistory(c)
+
+				},
+			},
+
+			{
+				Name:      "tx-queue",
+				Aliases:   []string{"tq"},
+				Usage:     "Show the automatic transactions (minipool stakes and RPL claims) that the node daemon is waiting on or retrying",
+				UsageText: "rocketpool node tx-queue",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return getTxQueue(c)
+
+				},
+			},
+
 			{
 				Name:      "set-withdrawal-address",
 				Aliases:   []string{"w"},
@@ -155,6 +255,54 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "join-smoothing-pool",
+				Aliases:   []string{"js"},
+				Usage:     "Opt the node into the smoothing pool, which shares priority fees and MEV between its members",
+				UsageText: "rocketpool node join-smoothing-pool [options]",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm joining the smoothing pool",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return setSmoothingPoolStatus(c, true)
+
+				},
+			},
+
+			{
+				Name:      "leave-smoothing-pool",
+				Aliases:   []string{"ls"},
+				Usage:     "Opt the node out of the smoothing pool, which takes effect at the end of the current rewards interval",
+				UsageText: "rocketpool node leave-smoothing-pool [options]",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm leaving the smoothing pool",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return setSmoothingPoolStatus(c, false)
+
+				},
+			},
+
 			{
 				Name:      "set-timezone",
 				Aliases:   []string{"t"},
@@ -454,6 +602,117 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 
 				},
 			},
+
+			{
+				Name:  "tx",
+				Usage: "Manage the node account's transactions",
+				Subcommands: []cli.Command{
+
+					{
+						Name:      "doctor",
+						Aliases:   []string{"d"},
+						Usage:     "Detect nonce gaps and stuck transactions from the node account and offer to repair them",
+						UsageText: "rocketpool node tx doctor [options]",
+						Flags: []cli.Flag{
+							cli.BoolFlag{
+								Name:  "yes, y",
+								Usage: "Automatically confirm all repairs",
+							},
+						},
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 0); err != nil {
+								return err
+							}
+
+							// Run
+							return txDoctor(c)
+
+						},
+					},
+				},
+			},
+
+			{
+				Name:      "speed-up-tx",
+				Aliases:   []string{"su"},
+				Usage:     "Resubmit a pending node transaction with a higher max fee",
+				UsageText: "rocketpool node speed-up-tx tx-hash [options]",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm the resubmission",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					hash, err := cliutils.ValidateTxHash("tx-hash", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					return speedUpTx(c, hash)
+
+				},
+			},
+
+			{
+				Name:      "cancel-tx",
+				Aliases:   []string{"ct"},
+				Usage:     "Cancel a pending node transaction by replacing it with a 0 ETH transfer to the node account with the same nonce",
+				UsageText: "rocketpool node cancel-tx tx-hash [options]",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm the cancellation",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					hash, err := cliutils.ValidateTxHash("tx-hash", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					return cancelTx(c, hash)
+
+				},
+			},
+
+			{
+				Name:      "replay-task",
+				Usage:     "Replay a recorded run of an automated node task using the inputs saved in the task journal, without submitting any transactions, to see why it did or didn't act. Valid tasks are claim-rpl-rewards and stake-prelaunch-minipools.",
+				UsageText: "rocketpool node replay-task [options] task",
+				Flags: []cli.Flag{
+					cli.Uint64Flag{
+						Name:  "run, r",
+						Usage: "The run to replay, counting back from the most recent run (which is 1)",
+						Value: 1,
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+
+					// Run
+					return replayTask(c, c.Args().Get(0))
+
+				},
+			},
 		},
 	})
 }
@@ -4,7 +4,6 @@ import (
 	"crypto/rand"
 	"fmt"
 	"math/big"
-	"strconv"
 
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
 	"github.com/urfave/cli"
@@ -115,7 +114,7 @@ func nodeDeposit(c *cli.Context) error {
 	} else if c.String("max-slippage") != "" {
 
 		// Parse max slippage
-		maxNodeFeeSlippagePerc, err := strconv.ParseFloat(c.String("max-slippage"), 64)
+		maxNodeFeeSlippagePerc, err := cliutils.ParseDecimal(c.String("max-slippage"))
 		if err != nil {
 			return fmt.Errorf("Invalid maximum commission rate slippage '%s': %w", c.String("max-slippage"), err)
 		}
@@ -0,0 +1,62 @@
+package node
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// Recommend gas thresholds for the automatic RPL claims and minipool stakes, and apply them if requested
+func recommendGasThresholds(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Check and assign the EC status
+	err = cliutils.CheckExecutionClientStatus(rp)
+	if err != nil {
+		return err
+	}
+
+	// Get the config
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return err
+	}
+	if isNew {
+		return fmt.Errorf("Settings file not found. Please run `rocketpool service config` to set up your Smartnode.")
+	}
+
+	// Get the recommendation
+	fmt.Println("Checking the gas prices over the last week...")
+	recommendation, err := rp.GetGasThresholdRecommendation()
+	if err != nil {
+		return err
+	}
+	fmt.Println()
+	if !cliutils.PrintGasThresholdRecommendation(cfg, recommendation) {
+		fmt.Println("\nYour current thresholds are already close to the recommendation.")
+		return nil
+	}
+	fmt.Println()
+
+	// Apply it
+	if !(c.Bool("yes") || cliutils.Confirm("Would you like to use the recommended thresholds?")) {
+		fmt.Println("Your thresholds have not been changed. You can adjust them yourself in the Smartnode section of `rocketpool service config`.")
+		return nil
+	}
+	cliutils.ApplyGasThresholdRecommendation(cfg, recommendation)
+	if err := rp.SaveConfig(cfg); err != nil {
+		return fmt.Errorf("Error saving the new thresholds: %w", err)
+	}
+	fmt.Println("The new thresholds have been saved. Please run `rocketpool service start` for them to take effect.")
+	return nil
+
+}
@@ -0,0 +1,92 @@
+package node
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+func getPerformance(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the performance of the node's validators
+	performance, err := rp.NodePerformance()
+	if err != nil {
+		return err
+	}
+
+	// Print the response as JSON if requested
+	if cliutils.IsJsonOutput() {
+		return cliutils.PrintJson(performance)
+	}
+
+	colorReset := "\033[0m"
+	colorGreen := "\033[32m"
+	colorYellow := "\033[33m"
+	colorRed := "\033[31m"
+
+	if len(performance.Validators) == 0 {
+		fmt.Println("The node doesn't have any validators on the Beacon chain yet.")
+		return nil
+	}
+	fmt.Printf("Attestation performance from epoch %d to finalized epoch %d, compared to the ideal attestation rewards.\n", performance.StartEpoch, performance.EndEpoch)
+	fmt.Printf("Validators below %.0f%% effectiveness are flagged.\n\n", performance.Threshold)
+
+	// Print each validator
+	flagged := 0
+	totalOpportunityCost := float64(0)
+	for _, validator := range performance.Validators {
+		fmt.Printf("Minipool %s (validator %d):\n", validator.MinipoolAddress.Hex(), validator.Index)
+		switch validator.Advice {
+		case api.PerformanceAdvice_Slashed:
+			fmt.Printf("%sThis validator has been slashed and is being exited by the protocol.%s\n\n", colorRed, colorReset)
+			continue
+		case api.PerformanceAdvice_Inactive:
+			fmt.Print("This validator isn't active on the Beacon chain.\n\n")
+			continue
+		case api.PerformanceAdvice_InsufficientData:
+			fmt.Printf("This validator has only been active for %d epochs, which isn't enough to judge its performance yet.\n\n", validator.Epochs)
+			continue
+		}
+
+		fmt.Printf("\tEffectiveness:    %.1f%% over %d epochs\n", validator.Effectiveness, validator.Epochs)
+		fmt.Printf("\tRewards:          %.6f ETH (ideal: %.6f ETH)\n", float64(validator.ActualRewards)/1e9, float64(validator.ExpectedRewards)/1e9)
+		if validator.AnnualOpportunityCost > 0 {
+			fmt.Printf("\tOpportunity cost: %.6f ETH per year at this rate\n", validator.AnnualOpportunityCost)
+		}
+		switch validator.Advice {
+		case api.PerformanceAdvice_Ok:
+			fmt.Printf("%sThis validator is performing well.%s\n", colorGreen, colorReset)
+		case api.PerformanceAdvice_Remediate:
+			flagged++
+			totalOpportunityCost += validator.AnnualOpportunityCost
+			fmt.Printf("%sThis validator is missing attestations or getting them included late.\n", colorYellow)
+			fmt.Printf("Check that your Execution and Beacon clients are synced and have enough peers, that your system clock is accurate, and that your machine isn't overloaded.%s\n", colorReset)
+		case api.PerformanceAdvice_Exit:
+			flagged++
+			totalOpportunityCost += validator.AnnualOpportunityCost
+			fmt.Printf("%sThis validator lost balance over the window, so it's most likely offline.\n", colorRed)
+			fmt.Printf("Check that your validator client is running and has this validator's key loaded. If you can't keep it online, consider exiting it with `rocketpool minipool exit` to stop the penalties.%s\n", colorReset)
+		}
+		fmt.Println()
+	}
+
+	// Print a summary
+	if flagged == 0 {
+		fmt.Printf("%sAll of your active validators are above the threshold.%s\n", colorGreen, colorReset)
+	} else {
+		fmt.Printf("%s%d of your validators are below the threshold, costing about %.6f ETH per year in missed rewards.%s\n", colorYellow, flagged, totalOpportunityCost, colorReset)
+	}
+	return nil
+
+}
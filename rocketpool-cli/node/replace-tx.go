@@ -0,0 +1,125 @@
+package node
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/gas"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+func speedUpTx(c *cli.Context, hash common.Hash) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Check and assign the EC status
+	err = cliutils.CheckExecutionClientStatus(rp)
+	if err != nil {
+		return err
+	}
+
+	// Check the transaction can be sped up
+	canReplace, err := rp.CanSpeedUpTx(hash)
+	if err != nil {
+		return err
+	}
+	printReplacementInfo(hash, canReplace)
+
+	// Assign max fees
+	err = gas.AssignMaxFeeAndLimit(canReplace.GasInfo, rp, c.Bool("yes"))
+	if err != nil {
+		return err
+	}
+
+	// Prompt for confirmation
+	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to resubmit transaction %s with a higher fee?", hash.Hex()))) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Speed it up
+	response, err := rp.SpeedUpTx(hash)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Resubmitting the transaction with nonce %d...\n", canReplace.Nonce)
+	cliutils.PrintTransactionHash(rp, response.TxHash)
+	if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
+		return err
+	}
+
+	// Log & return
+	fmt.Println("Successfully sped up the transaction.")
+	return nil
+
+}
+
+func cancelTx(c *cli.Context, hash common.Hash) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Check and assign the EC status
+	err = cliutils.CheckExecutionClientStatus(rp)
+	if err != nil {
+		return err
+	}
+
+	// Check the transaction can be cancelled
+	canReplace, err := rp.CanCancelTx(hash)
+	if err != nil {
+		return err
+	}
+	printReplacementInfo(hash, canReplace)
+
+	// Assign max fees
+	err = gas.AssignMaxFeeAndLimit(canReplace.GasInfo, rp, c.Bool("yes"))
+	if err != nil {
+		return err
+	}
+
+	// Prompt for confirmation
+	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to cancel transaction %s by replacing it with a 0 ETH transfer to the node account?", hash.Hex()))) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Cancel it
+	response, err := rp.CancelTx(hash)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Replacing the transaction with nonce %d...\n", canReplace.Nonce)
+	cliutils.PrintTransactionHash(rp, response.TxHash)
+	if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
+		return err
+	}
+
+	// Log & return
+	fmt.Println("Successfully cancelled the transaction. If the original was mined first instead, it will show as failed or replaced in your block explorer.")
+	return nil
+
+}
+
+// Print the fees of the transaction being replaced and the minimum fees for its replacement
+func printReplacementInfo(hash common.Hash, canReplace api.NodeCanReplaceTxResponse) {
+	colorReset := "\033[0m"
+	colorYellow := "\033[33m"
+
+	fmt.Printf("Transaction %s has nonce %d, a max fee of %.2f gwei and a priority fee of %.2f gwei.\n", hash.Hex(), canReplace.Nonce, eth.WeiToGwei(canReplace.OriginalMaxFee), eth.WeiToGwei(canReplace.OriginalMaxPriorityFee))
+	fmt.Printf("%sExecution clients only accept a replacement with a max fee of at least %.2f gwei and a priority fee of at least %.2f gwei; lower fees will be raised to these.%s\n\n", colorYellow, eth.WeiToGwei(canReplace.MinMaxFee), eth.WeiToGwei(canReplace.MinMaxPriorityFee), colorReset)
+}
@@ -0,0 +1,21 @@
+package node
+
+import (
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+)
+
+func replayTask(c *cli.Context, task string) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Replay the task
+	return rp.ReplayNodeTask(task, c.Uint64("run"))
+
+}
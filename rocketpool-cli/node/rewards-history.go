@@ -0,0 +1,168 @@
+package node
+
+import (
+	"encoding/csv"
+	"fmt"
+	"math/big"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+func getRewardsHistory(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Check and assign the EC status
+	err = cliutils.CheckExecutionClientStatus(rp)
+	if err != nil {
+		return err
+	}
+
+	// Get the node's rewards history
+	history, err := rp.NodeRewardsHistory()
+	if err != nil {
+		return err
+	}
+
+	// Print the response as JSON if requested
+	if cliutils.IsJsonOutput() {
+		return cliutils.PrintJson(history)
+	}
+
+	colorReset := "\033[0m"
+	colorYellow := "\033[33m"
+
+	if len(history.Intervals) == 0 {
+		fmt.Println("The node hasn't earned rewards in any past interval yet, so there's no history to show.")
+		return nil
+	}
+
+	// Print each interval
+	for _, interval := range history.Intervals {
+		claimed := "unclaimed"
+		if interval.Claimed {
+			claimed = "claimed"
+		}
+		fmt.Printf("=== Interval %d (%s to %s, %s) ===\n", interval.Index, cliutils.GetDateTimeString(uint64(interval.StartTime.Unix())), cliutils.GetDateTimeString(uint64(interval.EndTime.Unix())), claimed)
+		if interval.TreeMissing {
+			fmt.Printf("%sThe node hasn't claimed this interval and doesn't have its rewards tree file, so its rewards aren't known. Run `rocketpool network download-rewards-tree --interval %d` to get it.%s\n", colorYellow, interval.Index, colorReset)
+		}
+		fmt.Printf("RPL: %.6f RPL on a stake of %.6f RPL, %.2f%% APR\n", eth.WeiToEth(interval.RplRewards), eth.WeiToEth(interval.RplStake), interval.RplApr)
+		if interval.BalancesError != "" {
+			fmt.Printf("%sETH: unavailable, the Beacon balances at the interval's boundaries couldn't be retrieved (%s).%s\n\n", colorYellow, interval.BalancesError, colorReset)
+			continue
+		}
+		fmt.Printf("ETH: %.6f ETH from the Beacon chain and %.6f ETH from the Smoothing Pool on a deposit of %.6f ETH, %.2f%% APR\n\n", eth.WeiToEth(interval.BeaconRewards), eth.WeiToEth(interval.SmoothingPoolEth), eth.WeiToEth(interval.NodeDeposit), interval.EthApr)
+	}
+
+	fmt.Println("These are the rewards the node actually earned in each interval; the ETH APR only includes your share of your minipools' Beacon balances and Smoothing Pool rewards.")
+	return nil
+
+}
+
+// Export the node's rewards from the past intervals, or from a single interval, as CSV or JSON for record keeping
+func exportRewardsHistory(c *cli.Context, interval *uint64, asCsv bool) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Check and assign the EC status
+	err = cliutils.CheckExecutionClientStatus(rp)
+	if err != nil {
+		return err
+	}
+
+	// Get the node's rewards history
+	history, err := rp.NodeRewardsHistory()
+	if err != nil {
+		return err
+	}
+	intervals := history.Intervals
+	if interval != nil {
+		intervals = []api.NodeIntervalRewards{}
+		for _, rewards := range history.Intervals {
+			if rewards.Index == *interval {
+				intervals = append(intervals, rewards)
+			}
+		}
+		if len(intervals) == 0 {
+			return fmt.Errorf("The node doesn't have any rewards history for interval %d.", *interval)
+		}
+	}
+
+	// Print the intervals as JSON if requested or CSV is not
+	if !asCsv {
+		return cliutils.PrintJson(api.NodeRewardsHistoryResponse{
+			Status:    history.Status,
+			Intervals: intervals,
+		})
+	}
+
+	// Print them as CSV
+	writer := csv.NewWriter(os.Stdout)
+	err = writer.Write([]string{
+		"interval", "start_time", "end_time", "claimed",
+		"rpl_earned", "rpl_stake", "rpl_apr",
+		"smoothing_pool_eth", "smoothing_pool_share", "beacon_eth", "eth_earned", "node_deposit", "eth_apr",
+		"balances_error", "tree_missing",
+	})
+	if err != nil {
+		return err
+	}
+	for _, rewards := range intervals {
+		ethEarned := new(big.Int).Add(rewards.BeaconRewards, rewards.SmoothingPoolEth)
+		err := writer.Write([]string{
+			strconv.FormatUint(rewards.Index, 10),
+			rewards.StartTime.UTC().Format(time.RFC3339),
+			rewards.EndTime.UTC().Format(time.RFC3339),
+			strconv.FormatBool(rewards.Claimed),
+			formatWeiAsEth(rewards.RplRewards),
+			formatWeiAsEth(rewards.RplStake),
+			strconv.FormatFloat(rewards.RplApr, 'f', 4, 64),
+			formatWeiAsEth(rewards.SmoothingPoolEth),
+			strconv.FormatFloat(rewards.SmoothingPoolShare, 'f', 6, 64),
+			formatWeiAsEth(rewards.BeaconRewards),
+			formatWeiAsEth(ethEarned),
+			formatWeiAsEth(rewards.NodeDeposit),
+			strconv.FormatFloat(rewards.EthApr, 'f', 4, 64),
+			rewards.BalancesError,
+			strconv.FormatBool(rewards.TreeMissing),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+
+}
+
+// Format a Wei amount as an exact decimal amount of ETH (or RPL), so exported records don't lose precision
+func formatWeiAsEth(wei *big.Int) string {
+	if wei == nil {
+		return "0"
+	}
+	sign := ""
+	if wei.Sign() < 0 {
+		sign = "-"
+	}
+	whole, fraction := new(big.Int).QuoRem(new(big.Int).Abs(wei), big.NewInt(1e18), new(big.Int))
+	return fmt.Sprintf("%s%s.%018s", sign, whole.String(), fraction.String())
+}
@@ -31,6 +31,11 @@ func getRewards(c *cli.Context) error {
 		return err
 	}
 
+	// Print the response as JSON if requested
+	if cliutils.IsJsonOutput() {
+		return cliutils.PrintJson(rewards)
+	}
+
 	if !rewards.Registered {
 		fmt.Printf("This node is not currently registered.\n")
 		return nil
@@ -79,7 +84,8 @@ func getRewards(c *cli.Context) error {
 
 	fmt.Println()
 	fmt.Println("These rewards will be claimed automatically when the checkpoint ends, unless you have disabled auto-claims.")
-	fmt.Printf("Refer to the Claiming Node Operator Rewards guide at %s for more information.", docsUrl)
+	fmt.Printf("Refer to the Claiming Node Operator Rewards guide at %s for more information.\n", docsUrl)
+	fmt.Println("Run `rocketpool node rewards-history` to see the rewards and APR your node actually earned in past intervals.")
 
 	// Return
 	return nil
@@ -0,0 +1,99 @@
+package node
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/gas"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// Opt the node into or out of the smoothing pool
+func setSmoothingPoolStatus(c *cli.Context, join bool) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Check and assign the EC status
+	err = cliutils.CheckExecutionClientStatus(rp)
+	if err != nil {
+		return err
+	}
+
+	// Check if the node can change its status
+	var canResponse api.CanSetSmoothingPoolStatusResponse
+	if join {
+		canResponse, err = rp.CanJoinSmoothingPool()
+	} else {
+		canResponse, err = rp.CanLeaveSmoothingPool()
+	}
+	if err != nil {
+		return err
+	}
+	if !canResponse.CanSet {
+		if canResponse.AlreadySet {
+			if join {
+				fmt.Println("The node is already opted into the smoothing pool.")
+			} else {
+				fmt.Println("The node is not opted into the smoothing pool.")
+			}
+		} else if canResponse.InCooldown {
+			fmt.Printf("The node can only change its smoothing pool status once per rewards interval. It can change it again at %s (in %s).\n", cliutils.FormatTime(canResponse.NextChangeTime), time.Until(canResponse.NextChangeTime).Round(time.Second))
+		}
+		return nil
+	}
+
+	// Assign max fees
+	err = gas.AssignMaxFeeAndLimit(canResponse.GasInfo, rp, c.Bool("yes"))
+	if err != nil {
+		return err
+	}
+
+	// Prompt for confirmation
+	var prompt string
+	if join {
+		prompt = "Are you sure you want to join the smoothing pool? You won't be able to leave it until the next rewards interval."
+	} else {
+		prompt = fmt.Sprintf("Are you sure you want to leave the smoothing pool? You'll still be a member until the current rewards interval ends at %s, and you won't be able to rejoin until the next interval.", cliutils.FormatTime(canResponse.IntervalEnd))
+	}
+	if !(c.Bool("yes") || cliutils.Confirm(prompt)) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Change the node's status
+	var response api.SetSmoothingPoolStatusResponse
+	if join {
+		response, err = rp.JoinSmoothingPool()
+	} else {
+		response, err = rp.LeaveSmoothingPool()
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Updating the node's smoothing pool status...\n")
+	cliutils.PrintTransactionHash(rp, response.TxHash)
+	if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
+		return err
+	}
+
+	// Log & return
+	if join {
+		fmt.Println("The node has joined the smoothing pool.")
+		fmt.Printf("Set your validator client's fee recipient to the smoothing pool at %s so your minipools aren't penalized.\n", canResponse.PoolAddress.Hex())
+	} else {
+		fmt.Println("The node has left the smoothing pool.")
+		fmt.Printf("Keep your validator client's fee recipient set to the smoothing pool at %s until the current rewards interval ends at %s.\n", canResponse.PoolAddress.Hex(), cliutils.FormatTime(canResponse.IntervalEnd))
+	}
+	return nil
+
+}
@@ -3,7 +3,6 @@ package node
 import (
 	"fmt"
 	"math/big"
-	"strconv"
 
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
 	"github.com/urfave/cli"
@@ -184,11 +183,11 @@ func nodeStakeRpl(c *cli.Context) error {
 	} else if c.String("amount") != "" {
 
 		// Parse amount
-		stakeAmount, err := strconv.ParseFloat(c.String("amount"), 64)
+		stakeAmount, err := cliutils.ParseAmount(c.String("amount"), cliutils.AmountUnit_Eth)
 		if err != nil {
 			return fmt.Errorf("Invalid stake amount '%s': %w", c.String("amount"), err)
 		}
-		amountWei = eth.EthToWei(stakeAmount)
+		amountWei = stakeAmount
 
 	} else {
 
@@ -219,12 +218,7 @@ func nodeStakeRpl(c *cli.Context) error {
 
 		// Prompt for custom amount
 		if amountWei == nil {
-			inputAmount := cliutils.Prompt("Please enter an amount of RPL to stake:", "^\\d+(\\.\\d+)?$", "Invalid amount")
-			stakeAmount, err := strconv.ParseFloat(inputAmount, 64)
-			if err != nil {
-				return fmt.Errorf("Invalid stake amount '%s': %w", inputAmount, err)
-			}
-			amountWei = eth.EthToWei(stakeAmount)
+			amountWei = cliutils.PromptAmount("Please enter an amount of RPL to stake:", cliutils.AmountUnit_Eth)
 		}
 
 	}
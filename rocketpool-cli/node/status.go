@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	"math/big"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
@@ -41,6 +42,11 @@ func getStatus(c *cli.Context) error {
 		return err
 	}
 
+	// Print the response as JSON if requested
+	if cliutils.IsJsonOutput() {
+		return cliutils.PrintJson(status)
+	}
+
 	// Account address & balances
 	fmt.Printf(
 		"The node %s has a balance of %.6f ETH and %.6f RPL.\n",
@@ -90,6 +96,27 @@ func getStatus(c *cli.Context) error {
 			fmt.Println("")
 		}
 
+		// Smoothing pool status
+		if status.SmoothingPool.Error != "" {
+			fmt.Printf("The node's smoothing pool status is unavailable: %s\n", status.SmoothingPool.Error)
+		} else {
+			if status.SmoothingPool.Registered {
+				fmt.Printf("The node is opted into the smoothing pool (%s), so its priority fees and MEV are shared with the other members.\n", status.SmoothingPool.Address.Hex())
+			} else if status.SmoothingPool.OptOutPending {
+				fmt.Printf("%sThe node has opted out of the smoothing pool; this takes effect when the current rewards interval ends at %s (in %s).\n", colorYellow, cliutils.FormatTime(status.SmoothingPool.IntervalEnd), time.Until(status.SmoothingPool.IntervalEnd).Round(time.Second))
+				fmt.Printf("Until then, keep the smoothing pool as the node's fee recipient.%s\n", colorReset)
+			} else {
+				fmt.Println("The node is not opted into the smoothing pool. You can join it with `rocketpool node join-smoothing-pool`.")
+			}
+			if time.Now().Before(status.SmoothingPool.NextChangeTime) {
+				fmt.Printf("The node changed its smoothing pool status on %s and can't change it again until %s (in %s).\n", cliutils.FormatTime(status.SmoothingPool.Changed), cliutils.FormatTime(status.SmoothingPool.NextChangeTime), time.Until(status.SmoothingPool.NextChangeTime).Round(time.Second))
+			}
+			for _, penalized := range status.SmoothingPool.PenalizedMinipools {
+				fmt.Printf("%sMinipool %s has a penalty of %.2f%% applied to its rewards for using the wrong fee recipient.%s\n", colorYellow, penalized.Address.Hex(), eth.WeiToEth(penalized.PenaltyRate)*100, colorReset)
+			}
+		}
+		fmt.Println("")
+
 		// RPL stake details
 		fmt.Printf(
 			"The node has a total stake of %.6f RPL and an effective stake of %.6f RPL, allowing it to run %d minipool(s) in total.\n",
@@ -3,7 +3,6 @@ package node
 import (
 	"fmt"
 	"math/big"
-	"strconv"
 
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
 	"github.com/urfave/cli"
@@ -43,11 +42,11 @@ func nodeSwapRpl(c *cli.Context) error {
 	} else if c.String("amount") != "" {
 
 		// Parse amount
-		swapAmount, err := strconv.ParseFloat(c.String("amount"), 64)
+		swapAmount, err := cliutils.ParseAmount(c.String("amount"), cliutils.AmountUnit_Eth)
 		if err != nil {
 			return fmt.Errorf("Invalid swap amount '%s': %w", c.String("amount"), err)
 		}
-		amountWei = eth.EthToWei(swapAmount)
+		amountWei = swapAmount
 
 	} else {
 
@@ -64,12 +63,7 @@ func nodeSwapRpl(c *cli.Context) error {
 		} else {
 
 			// Prompt for custom amount
-			inputAmount := cliutils.Prompt("Please enter an amount of old RPL to swap:", "^\\d+(\\.\\d+)?$", "Invalid amount")
-			swapAmount, err := strconv.ParseFloat(inputAmount, 64)
-			if err != nil {
-				return fmt.Errorf("Invalid swap amount '%s': %w", inputAmount, err)
-			}
-			amountWei = eth.EthToWei(swapAmount)
+			amountWei = cliutils.PromptAmount("Please enter an amount of old RPL to swap:", cliutils.AmountUnit_Eth)
 
 		}
 
@@ -7,6 +7,7 @@ import (
 	"github.com/urfave/cli"
 
 	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
 )
 
@@ -33,6 +34,21 @@ func getSyncProgress(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
+
+	// Print the responses as JSON if requested
+	if cliutils.IsJsonOutput() {
+		status, err := rp.NodeSync()
+		if err != nil {
+			return err
+		}
+		return cliutils.PrintJson(struct {
+			DepositContractInfo api.DepositContractInfoResponse `json:"depositContractInfo"`
+			Sync                api.NodeSyncProgressResponse    `json:"sync"`
+		}{
+			DepositContractInfo: depositContractInfo,
+			Sync:                status,
+		})
+	}
 	if !depositContractInfo.SufficientSync {
 		colorReset := "\033[0m"
 		colorYellow := "\033[33m"
@@ -84,6 +100,41 @@ func getSyncProgress(c *cli.Context) error {
 		fmt.Printf("You do not have a fallback execution client enabled.\n")
 	}
 
+	// Print read EC status
+	if status.EcStatus.ReadEcEnabled {
+		if status.EcStatus.ReadEcStatus.Error != "" {
+			fmt.Printf("Your read execution client is unavailable (%s), so heavy reads are using your other clients.\n", status.EcStatus.ReadEcStatus.Error)
+		} else if status.EcStatus.ReadEcStatus.IsSynced {
+			fmt.Print("Your read execution client is fully synced.\n")
+		} else {
+			fmt.Printf("Your read execution client is still syncing (%0.2f%%), so heavy reads are using your other clients.\n", status.EcStatus.ReadEcStatus.SyncProgress*100)
+		}
+	}
+
+	// Print extra EC status
+	if status.EcStatus.ExtraEcEnabled {
+		if status.EcStatus.ExtraEcStatus.Error != "" {
+			fmt.Printf("Your extra execution client endpoints are unavailable (%s).\n", status.EcStatus.ExtraEcStatus.Error)
+		} else if status.EcStatus.ExtraEcStatus.IsSynced {
+			fmt.Print("Your extra execution client endpoints are fully synced.\n")
+		} else {
+			fmt.Printf("Your extra execution client endpoints are still syncing (%0.2f%%).\n", status.EcStatus.ExtraEcStatus.SyncProgress*100)
+		}
+	}
+
+	// Print the endpoint health scores when there's more than one to choose from
+	if len(status.EcStatus.Endpoints) > 1 {
+		fmt.Println("\nExecution client endpoint health (lower scores are preferred):")
+		for _, endpoint := range status.EcStatus.Endpoints {
+			readiness := "ready"
+			if !endpoint.Ready {
+				readiness = "not ready"
+			}
+			fmt.Printf("\t%s %s: score %.0f (%.0f ms, %d blocks behind, %.1f recent failures), %s\n", endpoint.Client, endpoint.Name, endpoint.Score, endpoint.LatencyMs, endpoint.BlocksBehind, endpoint.Failures, readiness)
+		}
+		fmt.Println()
+	}
+
 	// Print eth2 status
 	if status.Eth2Synced {
 		fmt.Print("Your consensus client is fully synced.\n")
@@ -0,0 +1,144 @@
+package node
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/gas"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+func txDoctor(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Check and assign the EC status
+	err = cliutils.CheckExecutionClientStatus(rp)
+	if err != nil {
+		return err
+	}
+
+	colorReset := "\033[0m"
+	colorYellow := "\033[33m"
+	colorGreen := "\033[32m"
+
+	// Get the node's transaction status
+	status, err := rp.NodeTxDoctorStatus()
+	if err != nil {
+		return err
+	}
+
+	// Print the nonces
+	fmt.Printf("Next nonce to be mined: %d\n", status.LatestNonce)
+	fmt.Printf("Next available nonce:   %d\n", status.PendingNonce)
+	if status.BaseFee != nil {
+		fmt.Printf("Current base fee:       %.2f gwei\n", eth.WeiToGwei(status.BaseFee))
+	}
+	fmt.Println()
+
+	if !status.TxPoolAvailable {
+		fmt.Printf("%sYour execution client doesn't provide access to its mempool (the `txpool` API), so nonce gaps and stuck transactions can't be detected.%s\n", colorYellow, colorReset)
+		if status.PendingNonce > status.LatestNonce {
+			fmt.Printf("The node has %d transaction(s) waiting to be mined.\n", status.PendingNonce-status.LatestNonce)
+		}
+		return nil
+	}
+
+	// Print the node's transactions in the mempool
+	stuckNonces := []uint64{}
+	if len(status.Transactions) == 0 {
+		fmt.Println("The node doesn't have any transactions in the mempool.")
+	} else {
+		fmt.Println("Transactions in the mempool:")
+		for _, tx := range status.Transactions {
+			state := "pending"
+			if tx.Queued {
+				state = "queued behind a nonce gap"
+			} else if tx.Stuck {
+				state = "stuck (max fee is below the base fee)"
+				stuckNonces = append(stuckNonces, tx.Nonce)
+			}
+			to := "contract creation"
+			if tx.To != nil {
+				to = tx.To.Hex()
+			}
+			fmt.Printf("\tNonce %d: %s to %s, max fee %.2f gwei, priority fee %.2f gwei - %s\n", tx.Nonce, tx.Hash.Hex(), to, eth.WeiToGwei(tx.MaxFee), eth.WeiToGwei(tx.MaxPriorityFee), state)
+		}
+	}
+	fmt.Println()
+
+	// Check for problems
+	if len(status.MissingNonces) == 0 && len(stuckNonces) == 0 {
+		fmt.Printf("%sNo nonce gaps or stuck transactions were found.%s\n", colorGreen, colorReset)
+		return nil
+	}
+	if len(status.MissingNonces) > 0 {
+		fmt.Printf("%sMissing nonces: %v\nTransactions after a missing nonce can't be mined until it has been filled.%s\n\n", colorYellow, status.MissingNonces, colorReset)
+	}
+	if len(stuckNonces) > 0 {
+		fmt.Printf("%s%d transaction(s) can't be mined at the current base fee.%s\n\n", colorYellow, len(stuckNonces), colorReset)
+	}
+
+	// Assign max fees
+	err = gas.AssignMaxFeeAndLimit(status.GasInfo, rp, c.Bool("yes"))
+	if err != nil {
+		return err
+	}
+
+	hashes := []common.Hash{}
+
+	// Fill the nonce gaps
+	if len(status.MissingNonces) > 0 {
+		if c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Would you like to fill the %d missing nonce(s) with 0 ETH transfers to the node account?", len(status.MissingNonces))) {
+			for _, nonce := range status.MissingNonces {
+				response, err := rp.NodeFillNonceGap(nonce)
+				if err != nil {
+					return err
+				}
+				fmt.Printf("Filling nonce %d...\n", nonce)
+				hashes = append(hashes, response.TxHash)
+			}
+		}
+	}
+
+	// Replace the stuck transactions
+	if len(stuckNonces) > 0 {
+		if c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Would you like to resubmit the %d stuck transaction(s) with a higher fee?", len(stuckNonces))) {
+			for _, nonce := range stuckNonces {
+				response, err := rp.NodeReplaceTx(nonce)
+				if err != nil {
+					return err
+				}
+				fmt.Printf("Replacing the transaction with nonce %d...\n", nonce)
+				hashes = append(hashes, response.TxHash)
+			}
+		}
+	}
+
+	if len(hashes) == 0 {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Wait for the transactions to be mined
+	for _, hash := range hashes {
+		cliutils.PrintTransactionHash(rp, hash)
+		if _, err = rp.WaitForTransaction(hash); err != nil {
+			return err
+		}
+	}
+
+	// Log & return
+	fmt.Println("Successfully repaired the node's transactions.")
+	return nil
+
+}
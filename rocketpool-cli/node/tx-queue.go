@@ -0,0 +1,80 @@
+package node
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+func getTxQueue(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the queue
+	queue, err := rp.NodeTxQueue()
+	if err != nil {
+		return err
+	}
+
+	// Print the response as JSON if requested
+	if cliutils.IsJsonOutput() {
+		return cliutils.PrintJson(queue)
+	}
+
+	colorReset := "\033[0m"
+	colorGreen := "\033[32m"
+	colorYellow := "\033[33m"
+	colorBlue := "\033[36m"
+
+	if len(queue.Transactions) == 0 {
+		fmt.Println("The node daemon doesn't have any automatic transactions queued.")
+		return nil
+	}
+
+	// Print each transaction
+	for _, tx := range queue.Transactions {
+		switch tx.State {
+		case api.TxQueueState_Waiting:
+			fmt.Printf("%sWaiting:%s   %s\n", colorYellow, colorReset, tx.Description)
+			fmt.Printf("\tReason:       %s\n", tx.LastError)
+		case api.TxQueueState_Submitted:
+			fmt.Printf("%sSubmitted:%s %s\n", colorBlue, colorReset, tx.Description)
+			fmt.Printf("\tTransaction:  %s (nonce %d)\n", tx.Hash.Hex(), tx.Nonce)
+			fmt.Printf("\tFees:         %.2f Gwei max fee, %.2f Gwei priority fee\n", eth.WeiToGwei(tx.MaxFee), eth.WeiToGwei(tx.PriorityFee))
+			fmt.Printf("\tReplacement:  %s, if it hasn't been mined by then\n", getRetryTime(tx.NextAttempt))
+		case api.TxQueueState_Retrying:
+			fmt.Printf("%sRetrying:%s  %s\n", colorYellow, colorReset, tx.Description)
+			fmt.Printf("\tLast error:   %s\n", tx.LastError)
+			fmt.Printf("\tNext attempt: %s\n", getRetryTime(tx.NextAttempt))
+		case api.TxQueueState_Mined:
+			fmt.Printf("%sMined:%s     %s\n", colorGreen, colorReset, tx.Description)
+			fmt.Printf("\tTransaction:  %s\n", tx.Hash.Hex())
+		}
+		if tx.State != api.TxQueueState_Mined && tx.State != api.TxQueueState_Submitted && tx.Attempts > 0 {
+			fmt.Printf("\tAttempts:     %d\n", tx.Attempts)
+		}
+		fmt.Printf("\tQueued:       %s (updated %s)\n\n", cliutils.FormatTime(tx.Created), cliutils.FormatTime(tx.Updated))
+	}
+
+	return nil
+
+}
+
+// Format the time of a transaction's next attempt
+func getRetryTime(nextAttempt time.Time) string {
+	if time.Now().After(nextAttempt) {
+		return "the next node daemon run"
+	}
+	return fmt.Sprintf("%s (in %s)", cliutils.FormatTime(nextAttempt), time.Until(nextAttempt).Round(time.Second))
+}
@@ -6,7 +6,6 @@ import (
 	"io/ioutil"
 	"net/http"
 	"os/exec"
-	"strconv"
 	"strings"
 
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
@@ -91,10 +90,10 @@ func promptMinNodeFee(networkCurrentNodeFee, networkMinNodeFee float64) float64
 	for {
 
 		// Get max slippage
-		maxNodeFeeSlippagePercStr := cliutils.Prompt("Please enter a maximum commission rate slippage % for your deposit:", "^\\d+(\\.\\d+)?$", "Invalid maximum commission rate slippage")
-		maxNodeFeeSlippagePerc, _ := strconv.ParseFloat(maxNodeFeeSlippagePercStr, 64)
+		maxNodeFeeSlippagePercStr := cliutils.Prompt("Please enter a maximum commission rate slippage % for your deposit:", "^\\d+([.,]\\d+)?$", "Invalid maximum commission rate slippage")
+		maxNodeFeeSlippagePerc, err := cliutils.ParseDecimal(maxNodeFeeSlippagePercStr)
 		maxNodeFeeSlippage := maxNodeFeeSlippagePerc / 100
-		if maxNodeFeeSlippage < 0 || maxNodeFeeSlippage > 1 {
+		if err != nil || maxNodeFeeSlippage < 0 || maxNodeFeeSlippage > 1 {
 			fmt.Println("Invalid maximum commission rate slippage")
 			fmt.Println("")
 			continue
@@ -3,7 +3,6 @@ package node
 import (
 	"fmt"
 	"math/big"
-	"strconv"
 
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
 	"github.com/urfave/cli"
@@ -49,11 +48,11 @@ func nodeWithdrawRpl(c *cli.Context) error {
 	} else if c.String("amount") != "" {
 
 		// Parse amount
-		withdrawalAmount, err := strconv.ParseFloat(c.String("amount"), 64)
+		withdrawalAmount, err := cliutils.ParseAmount(c.String("amount"), cliutils.AmountUnit_Eth)
 		if err != nil {
 			return fmt.Errorf("Invalid withdrawal amount '%s': %w", c.String("amount"), err)
 		}
-		amountWei = eth.EthToWei(withdrawalAmount)
+		amountWei = withdrawalAmount
 
 	} else {
 
@@ -73,12 +72,7 @@ func nodeWithdrawRpl(c *cli.Context) error {
 			} else {
 
 				// Prompt for custom amount
-				inputAmount := cliutils.Prompt("Please enter an amount of staked RPL to withdraw:", "^\\d+(\\.\\d+)?$", "Invalid amount")
-				withdrawalAmount, err := strconv.ParseFloat(inputAmount, 64)
-				if err != nil {
-					return fmt.Errorf("Invalid withdrawal amount '%s': %w", inputAmount, err)
-				}
-				amountWei = eth.EthToWei(withdrawalAmount)
+				amountWei = cliutils.PromptAmount("Please enter an amount of staked RPL to withdraw:", cliutils.AmountUnit_Eth)
 
 			}
 		} else {
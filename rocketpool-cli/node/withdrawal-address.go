@@ -2,7 +2,6 @@ package node
 
 import (
 	"fmt"
-	"strconv"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/urfave/cli"
@@ -55,12 +54,7 @@ func setWithdrawalAddress(c *cli.Context, withdrawalAddress common.Address) erro
 	if confirm {
 		// Prompt for a test transaction
 		if cliutils.Confirm("Would you like to send a test transaction to make sure you have the correct address?") {
-			inputAmount := cliutils.Prompt(fmt.Sprintf("Please enter an amount of ETH to send to %s:", withdrawalAddress), "^\\d+(\\.\\d+)?$", "Invalid amount")
-			testAmount, err := strconv.ParseFloat(inputAmount, 64)
-			if err != nil {
-				return fmt.Errorf("Invalid test amount '%s': %w\n", inputAmount, err)
-			}
-			amountWei := eth.EthToWei(testAmount)
+			amountWei := cliutils.PromptAmount(fmt.Sprintf("Please enter an amount of ETH to send to %s:", withdrawalAddress), cliutils.AmountUnit_Eth)
 			canSendResponse, err := rp.CanNodeSend(amountWei, "eth")
 			if err != nil {
 				return err
@@ -72,7 +66,7 @@ func setWithdrawalAddress(c *cli.Context, withdrawalAddress common.Address) erro
 				return err
 			}
 
-			if !cliutils.Confirm(fmt.Sprintf("Please confirm you want to send %f ETH to %s.", testAmount, withdrawalAddress)) {
+			if !cliutils.Confirm(fmt.Sprintf("Please confirm you want to send %f ETH to %s.", eth.WeiToEth(amountWei), withdrawalAddress)) {
 				fmt.Println("Cancelled.")
 				return nil
 			}
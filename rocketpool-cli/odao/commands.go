@@ -259,7 +259,7 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 							{
 								Name:      "proposal-cooldown",
 								Aliases:   []string{"c"},
-								Usage:     "Propose updating the proposal.cooldown.time setting - format is e.g. 1h30m45s",
+								Usage:     "Propose updating the proposal.cooldown.time setting - format is e.g. 1h30m45s, 36h or 2d",
 								UsageText: "rocketpool odao propose setting proposal-cooldown value",
 								Action: func(c *cli.Context) error {
 
@@ -276,7 +276,7 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 							{
 								Name:      "proposal-vote-timespan",
 								Aliases:   []string{"v"},
-								Usage:     "Propose updating the proposal.vote.time setting - format is e.g. 1h30m45s",
+								Usage:     "Propose updating the proposal.vote.time setting - format is e.g. 1h30m45s, 36h or 2d",
 								UsageText: "rocketpool odao propose setting proposal-vote-timespan value",
 								Action: func(c *cli.Context) error {
 
@@ -293,7 +293,7 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 							{
 								Name:      "proposal-vote-delay-timespan",
 								Aliases:   []string{"d"},
-								Usage:     "Propose updating the proposal.vote.delay.time setting - format is e.g. 1h30m45s",
+								Usage:     "Propose updating the proposal.vote.delay.time setting - format is e.g. 1h30m45s, 36h or 2d",
 								UsageText: "rocketpool odao propose setting proposal-vote-delay-timespan value",
 								Action: func(c *cli.Context) error {
 
@@ -310,7 +310,7 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 							{
 								Name:      "proposal-execute-timespan",
 								Aliases:   []string{"x"},
-								Usage:     "Propose updating the proposal.execute.time setting - format is e.g. 1h30m45s",
+								Usage:     "Propose updating the proposal.execute.time setting - format is e.g. 1h30m45s, 36h or 2d",
 								UsageText: "rocketpool odao propose setting proposal-execute-timespan value",
 								Action: func(c *cli.Context) error {
 
@@ -327,7 +327,7 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 							{
 								Name:      "proposal-action-timespan",
 								Aliases:   []string{"a"},
-								Usage:     "Propose updating the proposal.action.time setting - format is e.g. 1h30m45s",
+								Usage:     "Propose updating the proposal.action.time setting - format is e.g. 1h30m45s, 36h or 2d",
 								UsageText: "rocketpool odao propose setting proposal-action-timespan value",
 								Action: func(c *cli.Context) error {
 
@@ -344,7 +344,7 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 							{
 								Name:      "scrub-period",
 								Aliases:   []string{"s"},
-								Usage:     "Propose updating the minipool.scrub.period setting - format is e.g. 1h30m45s",
+								Usage:     "Propose updating the minipool.scrub.period setting - format is e.g. 1h30m45s, 36h or 2d",
 								UsageText: "rocketpool odao propose setting scrub-period value",
 								Action: func(c *cli.Context) error {
 
@@ -525,6 +525,110 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:    "emergency",
+				Aliases: []string{"e"},
+				Usage:   "Take emergency actions against malicious minipool operations; every action is recorded in the emergency audit log",
+				Subcommands: []cli.Command{
+
+					{
+						Name:      "cancel-bond-reduction",
+						Aliases:   []string{"c"},
+						Usage:     "Vote to cancel a minipool's bond reduction",
+						UsageText: "rocketpool odao emergency cancel-bond-reduction [options]",
+						Flags: []cli.Flag{
+							cli.StringFlag{
+								Name:  "minipool, m",
+								Usage: "The address of the minipool",
+							},
+							cli.StringFlag{
+								Name:  "reason, r",
+								Usage: "The reason for the action, which is recorded in the emergency audit log",
+							},
+							cli.StringFlag{
+								Name:  "confirm-address",
+								Usage: "Confirm the action without prompting by repeating the full address of the minipool; requires --reason",
+							},
+						},
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 0); err != nil {
+								return err
+							}
+
+							// Validate flags
+							if c.String("minipool") != "" {
+								if _, err := cliutils.ValidateAddress("minipool address", c.String("minipool")); err != nil {
+									return err
+								}
+							}
+
+							// Run
+							return emergencyCancelBondReduction(c)
+
+						},
+					},
+
+					{
+						Name:      "scrub-minipool",
+						Aliases:   []string{"s"},
+						Usage:     "Vote to scrub a prelaunch minipool",
+						UsageText: "rocketpool odao emergency scrub-minipool [options]",
+						Flags: []cli.Flag{
+							cli.StringFlag{
+								Name:  "minipool, m",
+								Usage: "The address of the minipool",
+							},
+							cli.StringFlag{
+								Name:  "reason, r",
+								Usage: "The reason for the action, which is recorded in the emergency audit log",
+							},
+							cli.StringFlag{
+								Name:  "confirm-address",
+								Usage: "Confirm the action without prompting by repeating the full address of the minipool; requires --reason",
+							},
+						},
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 0); err != nil {
+								return err
+							}
+
+							// Validate flags
+							if c.String("minipool") != "" {
+								if _, err := cliutils.ValidateAddress("minipool address", c.String("minipool")); err != nil {
+									return err
+								}
+							}
+
+							// Run
+							return emergencyScrubMinipool(c)
+
+						},
+					},
+
+					{
+						Name:      "audit-log",
+						Aliases:   []string{"l"},
+						Usage:     "Show the emergency actions the node has taken, including the automatic ones by the watchtower",
+						UsageText: "rocketpool odao emergency audit-log",
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 0); err != nil {
+								return err
+							}
+
+							// Run
+							return getEmergencyAuditLog(c)
+
+						},
+					},
+				},
+			},
+
 			{
 				Name:      "join",
 				Aliases:   []string{"j"},
@@ -0,0 +1,241 @@
+package odao
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/gas"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// Settings
+const (
+	colorReset  string = "\033[0m"
+	colorRed    string = "\033[31m"
+	colorYellow string = "\033[33m"
+	TimeFormat         = "2006-01-02, 15:04 -0700 MST"
+)
+
+func emergencyCancelBondReduction(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Check and assign the EC status
+	err = cliutils.CheckExecutionClientStatus(rp)
+	if err != nil {
+		return err
+	}
+
+	// Get the minipool
+	minipoolAddress, err := getEmergencyMinipool(c)
+	if err != nil {
+		return err
+	}
+
+	// Check the bond reduction can be cancelled
+	canResponse, err := rp.CanCancelBondReduction(minipoolAddress)
+	if err != nil {
+		return err
+	}
+	if !canResponse.CanCancel {
+		fmt.Printf("Cannot vote to cancel the bond reduction of minipool %s:\n", minipoolAddress.Hex())
+		if canResponse.NotSupported {
+			fmt.Println("The Rocket Pool contracts on this network don't support bond reduction yet.")
+		}
+		if canResponse.NotStarted {
+			fmt.Println("The minipool hasn't started a bond reduction.")
+		}
+		if canResponse.AlreadyCancelled {
+			fmt.Println("The minipool's bond reduction has already been cancelled.")
+		}
+		return nil
+	}
+	fmt.Printf("Minipool %s started reducing its bond to %.6f ETH at %s.\n\n", minipoolAddress.Hex(), eth.WeiToEth(canResponse.NewBond), canResponse.StartTime.Format(TimeFormat))
+
+	// Get the reason and confirm the action
+	reason, confirmed := confirmEmergencyAction(c, minipoolAddress, "Voting to cancel a bond reduction stops the node operator from reducing their bond once enough Oracle DAO members agree, and it can't be undone.")
+	if !confirmed {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Assign max fees
+	err = gas.AssignMaxFeeAndLimit(canResponse.GasInfo, rp, c.String("confirm-address") != "")
+	if err != nil {
+		return err
+	}
+
+	// Vote to cancel the bond reduction
+	response, err := rp.CancelBondReduction(minipoolAddress, reason)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Voting to cancel the bond reduction...\n")
+	cliutils.PrintTransactionHash(rp, response.TxHash)
+	if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
+		return err
+	}
+
+	// Log & return
+	fmt.Printf("Successfully voted to cancel the bond reduction of minipool %s. The vote has been recorded in the emergency audit log.\n", minipoolAddress.Hex())
+	return nil
+
+}
+
+func emergencyScrubMinipool(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Check and assign the EC status
+	err = cliutils.CheckExecutionClientStatus(rp)
+	if err != nil {
+		return err
+	}
+
+	// Get the minipool
+	minipoolAddress, err := getEmergencyMinipool(c)
+	if err != nil {
+		return err
+	}
+
+	// Check the minipool can be scrubbed
+	canResponse, err := rp.CanScrubMinipool(minipoolAddress)
+	if err != nil {
+		return err
+	}
+	if !canResponse.CanScrub {
+		fmt.Printf("Cannot vote to scrub minipool %s:\n", minipoolAddress.Hex())
+		if canResponse.InvalidStatus {
+			fmt.Println("Only minipools in prelaunch can be scrubbed.")
+		}
+		return nil
+	}
+
+	// Get the reason and confirm the action
+	reason, confirmed := confirmEmergencyAction(c, minipoolAddress, "Voting to scrub a minipool dissolves it once enough Oracle DAO members agree, and may penalize the node operator's RPL. It can't be undone.")
+	if !confirmed {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Assign max fees
+	err = gas.AssignMaxFeeAndLimit(canResponse.GasInfo, rp, c.String("confirm-address") != "")
+	if err != nil {
+		return err
+	}
+
+	// Vote to scrub the minipool
+	response, err := rp.ScrubMinipool(minipoolAddress, reason)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Voting to scrub the minipool...\n")
+	cliutils.PrintTransactionHash(rp, response.TxHash)
+	if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
+		return err
+	}
+
+	// Log & return
+	fmt.Printf("Successfully voted to scrub minipool %s. The vote has been recorded in the emergency audit log.\n", minipoolAddress.Hex())
+	return nil
+
+}
+
+func getEmergencyAuditLog(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the audit log
+	response, err := rp.GetEmergencyAuditLog()
+	if err != nil {
+		return err
+	}
+	if len(response.Entries) == 0 {
+		fmt.Println("The node hasn't taken any emergency actions.")
+		return nil
+	}
+
+	// Print it
+	for _, entry := range response.Entries {
+		fmt.Printf("%s  %s by %s\n", entry.Time.Local().Format(TimeFormat), getEmergencyActionDescription(entry.Action), entry.Source)
+		fmt.Printf("    Minipool: %s\n", entry.MinipoolAddress.Hex())
+		fmt.Printf("    Node:     %s\n", entry.NodeAddress.Hex())
+		fmt.Printf("    TX:       %s\n", entry.TxHash.Hex())
+		fmt.Printf("    Reason:   %s\n\n", entry.Reason)
+	}
+	return nil
+
+}
+
+// Get the minipool an emergency action is for from the flags, or prompt for it
+func getEmergencyMinipool(c *cli.Context) (common.Address, error) {
+	if c.String("minipool") != "" {
+		return cliutils.ValidateAddress("minipool address", c.String("minipool"))
+	}
+	address := cliutils.Prompt("Please enter the address of the minipool:", "^0x[0-9a-fA-F]{40}$", "Invalid minipool address")
+	return common.HexToAddress(address), nil
+}
+
+// Get the reason for an emergency action, and confirm it by having the address of the minipool typed out in full.
+// In scripts, the address can be provided with --confirm-address instead.
+func confirmEmergencyAction(c *cli.Context, minipoolAddress common.Address, warning string) (string, bool) {
+
+	fmt.Printf("%sWARNING: %s%s\n\n", colorRed, warning, colorReset)
+
+	// Get the reason, which is recorded in the audit log
+	reason := strings.TrimSpace(c.String("reason"))
+	if reason == "" {
+		if c.String("confirm-address") != "" {
+			fmt.Println("A reason is required with --confirm-address.")
+			return "", false
+		}
+		reason = strings.TrimSpace(cliutils.Prompt("Please enter the reason for this action, which will be recorded in the emergency audit log:", "^.*\\S.*$", "Please enter a reason"))
+	}
+
+	// Check the confirmation
+	confirmation := c.String("confirm-address")
+	if confirmation == "" {
+		fmt.Printf("%sTo confirm, type the full address of the minipool (%s).%s\n", colorYellow, minipoolAddress.Hex(), colorReset)
+		confirmation = cliutils.Prompt("Minipool address:", "^.*$", "")
+	}
+	if !strings.EqualFold(strings.TrimSpace(confirmation), minipoolAddress.Hex()) {
+		fmt.Println("The address doesn't match the minipool.")
+		return "", false
+	}
+	return reason, true
+
+}
+
+// Get a description of an emergency action
+func getEmergencyActionDescription(action api.EmergencyAction) string {
+	switch action {
+	case api.EmergencyAction_CancelBondReduction:
+		return "Voted to cancel a bond reduction"
+	case api.EmergencyAction_ScrubMinipool:
+		return "Voted to scrub a minipool"
+	}
+	return string(action)
+}
@@ -32,6 +32,11 @@ func getMemberSettings(c *cli.Context) error {
 		return err
 	}
 
+	// Print the response as JSON if requested
+	if cliutils.IsJsonOutput() {
+		return cliutils.PrintJson(response)
+	}
+
 	// Log & return
 	fmt.Printf("ODAO Voting Quorum Threshold: %f%%\n", response.Quorum*100)
 	fmt.Printf("Required Member RPL Bond: %f RPL\n", eth.WeiToEth(response.RPLBond))
@@ -64,6 +69,11 @@ func getProposalSettings(c *cli.Context) error {
 		return err
 	}
 
+	// Print the response as JSON if requested
+	if cliutils.IsJsonOutput() {
+		return cliutils.PrintJson(response)
+	}
+
 	// Log & return
 	fmt.Printf("Cooldown Between Proposals: %s\n", time.Duration(response.Cooldown*1000000000))
 	fmt.Printf("Proposal Voting Window: %s\n", time.Duration(response.VoteTime*1000000000))
@@ -95,6 +105,11 @@ func getMinipoolSettings(c *cli.Context) error {
 		return err
 	}
 
+	// Print the response as JSON if requested
+	if cliutils.IsJsonOutput() {
+		return cliutils.PrintJson(response)
+	}
+
 	// Log & return
 	fmt.Printf("Scrub Period: %s\n", time.Duration(response.ScrubPeriod*1000000000))
 	return nil
@@ -32,6 +32,11 @@ func getMembers(c *cli.Context) error {
 		return err
 	}
 
+	// Print the response as JSON if requested
+	if cliutils.IsJsonOutput() {
+		return cliutils.PrintJson(members)
+	}
+
 	// Print & return
 	if len(members.Members) > 0 {
 		fmt.Printf("The oracle DAO has %d members:\n", len(members.Members))
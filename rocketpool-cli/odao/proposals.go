@@ -52,6 +52,17 @@ func getProposals(c *cli.Context, stateFilter string) error {
 		return err
 	}
 
+	// Print the matching proposals as JSON if requested
+	if cliutils.IsJsonOutput() {
+		proposals := []dao.ProposalDetails{}
+		for _, proposal := range allProposals.Proposals {
+			if !filterProposalState(strings.ToLower(proposal.State.String()), stateFilter) {
+				proposals = append(proposals, proposal)
+			}
+		}
+		return cliutils.PrintJson(proposals)
+	}
+
 	// Get proposals by state
 	stateProposals := map[string][]dao.ProposalDetails{}
 	for _, proposal := range allProposals.Proposals {
@@ -134,6 +145,11 @@ func getProposal(c *cli.Context, id uint64) error {
 		return nil
 	}
 
+	// Print the proposal as JSON if requested
+	if cliutils.IsJsonOutput() {
+		return cliutils.PrintJson(proposal)
+	}
+
 	// Main details
 	fmt.Printf("Proposal ID:          %d\n", proposal.ID)
 	fmt.Printf("Message:              %s\n", proposal.Message)
@@ -4,7 +4,6 @@ import (
 	"bytes"
 	"fmt"
 	"math/big"
-	"strconv"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/rocket-pool/rocketpool-go/dao/trustednode"
@@ -76,21 +75,16 @@ func proposeKick(c *cli.Context) error {
 	} else if c.String("fine") != "" {
 
 		// Parse amount
-		fineAmount, err := strconv.ParseFloat(c.String("fine"), 64)
+		fineAmount, err := cliutils.ParseAmount(c.String("fine"), cliutils.AmountUnit_Eth)
 		if err != nil {
 			return fmt.Errorf("Invalid fine amount '%s': %w", c.String("fine"), err)
 		}
-		fineAmountWei = eth.EthToWei(fineAmount)
+		fineAmountWei = fineAmount
 
 	} else {
 
 		// Prompt for custom amount
-		inputAmount := cliutils.Prompt(fmt.Sprintf("Please enter an RPL fine amount to propose (max %.6f RPL):", math.RoundDown(eth.WeiToEth(selectedMember.RPLBondAmount), 6)), "^\\d+(\\.\\d+)?$", "Invalid amount")
-		fineAmount, err := strconv.ParseFloat(inputAmount, 64)
-		if err != nil {
-			return fmt.Errorf("Invalid fine amount '%s': %w", inputAmount, err)
-		}
-		fineAmountWei = eth.EthToWei(fineAmount)
+		fineAmountWei = cliutils.PromptAmount(fmt.Sprintf("Please enter an RPL fine amount to propose (max %.6f RPL):", math.RoundDown(eth.WeiToEth(selectedMember.RPLBondAmount), 6)), cliutils.AmountUnit_Eth)
 
 	}
 
@@ -2,7 +2,6 @@ package odao
 
 import (
 	"fmt"
-	"time"
 
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
 	"github.com/urfave/cli"
@@ -202,7 +201,7 @@ func proposeSettingProposalCooldown(c *cli.Context, proposalCooldownTimespan str
 	}
 
 	// Parse the timespan
-	timespan, err := time.ParseDuration(proposalCooldownTimespan)
+	timespan, err := cliutils.ParseDuration(proposalCooldownTimespan)
 	if err != nil {
 		return fmt.Errorf("Error parsing time: %w\n", err)
 	}
@@ -267,7 +266,7 @@ func proposeSettingProposalVoteTimespan(c *cli.Context, proposalVoteTimespan str
 	}
 
 	// Parse the timespan
-	timespan, err := time.ParseDuration(proposalVoteTimespan)
+	timespan, err := cliutils.ParseDuration(proposalVoteTimespan)
 	if err != nil {
 		return fmt.Errorf("Error parsing time: %w\n", err)
 	}
@@ -332,7 +331,7 @@ func proposeSettingProposalVoteDelayTimespan(c *cli.Context, proposalDelayTimesp
 	}
 
 	// Parse the timespan
-	timespan, err := time.ParseDuration(proposalDelayTimespan)
+	timespan, err := cliutils.ParseDuration(proposalDelayTimespan)
 	if err != nil {
 		return fmt.Errorf("Error parsing time: %w\n", err)
 	}
@@ -397,7 +396,7 @@ func proposeSettingProposalExecuteTimespan(c *cli.Context, proposalExecuteTimesp
 	}
 
 	// Parse the timespan
-	timespan, err := time.ParseDuration(proposalExecuteTimespan)
+	timespan, err := cliutils.ParseDuration(proposalExecuteTimespan)
 	if err != nil {
 		return fmt.Errorf("Error parsing time: %w\n", err)
 	}
@@ -462,7 +461,7 @@ func proposeSettingProposalActionTimespan(c *cli.Context, proposalActionTimespan
 	}
 
 	// Parse the timespan
-	timespan, err := time.ParseDuration(proposalActionTimespan)
+	timespan, err := cliutils.ParseDuration(proposalActionTimespan)
 	if err != nil {
 		return fmt.Errorf("Error parsing time: %w\n", err)
 	}
@@ -527,7 +526,7 @@ func proposeSettingScrubPeriod(c *cli.Context, scrubPeriod string) error {
 	}
 
 	// Parse the timespan
-	timespan, err := time.ParseDuration(scrubPeriod)
+	timespan, err := cliutils.ParseDuration(scrubPeriod)
 	if err != nil {
 		return fmt.Errorf("Error parsing time: %w\n", err)
 	}
@@ -3,6 +3,7 @@ package main
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/mitchellh/go-homedir"
 	"github.com/urfave/cli"
@@ -15,13 +16,46 @@ import (
 	"github.com/rocket-pool/smartnode/rocketpool-cli/odao"
 	"github.com/rocket-pool/smartnode/rocketpool-cli/queue"
 	"github.com/rocket-pool/smartnode/rocketpool-cli/service"
+	"github.com/rocket-pool/smartnode/rocketpool-cli/todo"
 	"github.com/rocket-pool/smartnode/rocketpool-cli/wallet"
+	"github.com/rocket-pool/smartnode/rocketpool-cli/watchtower"
 	"github.com/rocket-pool/smartnode/shared"
 	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
 	"github.com/rocket-pool/smartnode/shared/utils/rp"
 )
 
+// The commands that can print their response as JSON with --output json
+var jsonOutputCommands = map[string]bool{
+	"auction status":                true,
+	"auction lots":                  true,
+	"faucet status":                 true,
+	"minipool status":               true,
+	"network stats":                 true,
+	"network timezone-map":          true,
+	"network node-fee":              true,
+	"network rpl-price":             true,
+	"network generate-rewards-tree": true,
+	"network download-rewards-tree": true,
+	"node status":                   true,
+	"node sync":                     true,
+	"node performance":              true,
+	"node rewards":                  true,
+	"node rewards-history":          true,
+	"node tx-queue":                 true,
+	"odao status":                   true,
+	"odao members":                  true,
+	"odao member-settings":          true,
+	"odao proposal-settings":        true,
+	"odao minipool-settings":        true,
+	"odao proposals list":           true,
+	"odao proposals details":        true,
+	"queue status":                  true,
+	"service events":                true,
+	"todo":                          true,
+	"wallet status":                 true,
+}
+
 // Run
 func main() {
 
@@ -70,9 +104,13 @@ ______           _        _    ______           _
 			Usage: "Allow rocketpool to be run as the root user",
 		},
 		cli.StringFlag{
-			Name:  "config-path, c",
-			Usage: "Rocket Pool config asset `path`",
-			Value: "~/.rocketpool",
+			Name:  "config-path, config-dir, c",
+			Usage: "Rocket Pool config asset `path`. Use a different path for each Smartnode instance you run on this machine",
+			Value: rocketpool.DefaultConfigPath,
+		},
+		cli.StringFlag{
+			Name:  "profile",
+			Usage: "Manage the Smartnode configuration of the named `profile` instead of the default one. Each profile has its own settings, data, network and Docker containers, stored in the profiles folder of the config path",
 		},
 		cli.StringFlag{
 			Name:  "daemon-path, d",
@@ -103,21 +141,34 @@ ______           _        _    ______           _
 			Usage: "Some commands may print sensitive information to your terminal. " +
 				"Use this flag when nobody can see your screen to allow sensitive data to be printed without prompting",
 		},
+		cli.StringFlag{
+			Name:  "output, o",
+			Usage: "The `format` to print command output in: 'text' or 'json'. In JSON mode, the commands that support it (marked in their help) print their response as JSON to stdout and all other messages to stderr; the other commands fail with an error",
+			Value: cliutils.OutputFormat_Text,
+		},
+		cli.BoolFlag{
+			Name:  "utc",
+			Usage: "Display times in UTC instead of the configured display timezone",
+		},
+		cli.StringFlag{
+			Name:  "node-address",
+			Usage: "Run in read-only observer mode for the node at this `address`, without loading the node wallet. Status commands such as node status, minipool status, node rewards and network stats work as usual; commands that send transactions or need keys will fail",
+		},
 	}
 
 	// Register commands
 	auction.RegisterCommands(app, "auction", []string{"a"})
 
-	// Get the config path from the arguments (or use the default)
-	configPath := "~/.rocketpool"
-	for index, arg := range os.Args {
-		if arg == "-c" || arg == "--config-path" {
-			if len(os.Args)-1 == index {
-				fmt.Fprintf(os.Stderr, "Expected config path after %s but none was given.\n", arg)
-				os.Exit(1)
-			}
-			configPath = os.Args[index+1]
+	// Get the config path and profile from the arguments (or use the default)
+	configPath := getGlobalArg(rocketpool.DefaultConfigPath, "config path", "-c", "--config-path", "--config-dir")
+	profile := getGlobalArg("", "profile name", "--profile")
+	if profile != "" {
+		profilePath, err := rocketpool.GetProfileConfigPath(configPath, profile)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
+			os.Exit(1)
 		}
+		configPath = profilePath
 	}
 
 	// Get and parse the config file
@@ -128,6 +179,8 @@ ______           _        _    ______           _
 		os.Exit(1)
 	}
 	// Stop if the config file doesn't exist yet
+	displayTimezone := ""
+	showPendingItems := false
 	_, err = os.Stat(expandedPath)
 	if !os.IsNotExist(err) {
 		cfg, err := rp.LoadConfigFromFile(expandedPath)
@@ -136,6 +189,9 @@ ______           _        _    ______           _
 			os.Exit(1)
 		}
 
+		displayTimezone = cfg.Smartnode.GetDisplayTimezone()
+		showPendingItems = cfg.Smartnode.GetShowPendingItems()
+
 		// Add the faucet if we're on a testnet and it has a contract address
 		if cfg.Smartnode.GetRplFaucetAddress() != "" {
 			faucet.RegisterCommands(app, "faucet", []string{"f"})
@@ -148,7 +204,10 @@ ______           _        _    ______           _
 	odao.RegisterCommands(app, "odao", []string{"o"})
 	queue.RegisterCommands(app, "queue", []string{"q"})
 	service.RegisterCommands(app, "service", []string{"s"})
+	todo.RegisterCommands(app, "todo", []string{})
 	wallet.RegisterCommands(app, "wallet", []string{"w"})
+	watchtower.RegisterCommands(app, "watchtower", []string{"t"})
+	cliutils.RestrictJsonOutput(app.Commands, jsonOutputCommands)
 
 	app.Before = func(c *cli.Context) error {
 		// Check user ID
@@ -158,14 +217,68 @@ ______           _        _    ______           _
 			os.Exit(1)
 		}
 
+		// Use the profile's config path; everything that reads the config path picks it up from here
+		if profile := c.GlobalString("profile"); profile != "" {
+			profilePath, err := rocketpool.GetProfileConfigPath(c.GlobalString("config-path"), profile)
+			if err != nil {
+				return err
+			}
+			if err := c.GlobalSet("config-path", profilePath); err != nil {
+				return err
+			}
+		}
+
+		// Check the address to observe
+		if address := c.GlobalString("node-address"); address != "" {
+			if _, err := cliutils.ValidateAddress("node address", address); err != nil {
+				return err
+			}
+		}
+
+		// Set the timezone times are displayed in
+		cliutils.SetDisplayTimezone(displayTimezone, c.GlobalBool("utc"))
+
+		// Set the output format
+		if err := cliutils.SetOutputFormat(c.GlobalString("output")); err != nil {
+			return err
+		}
+
+		// Show the pending items before the command runs
+		if showPendingItems {
+			todo.PrintBanner(c)
+		}
 		return nil
 	}
 
 	// Run application
 	fmt.Println("")
 	if err := app.Run(os.Args); err != nil {
-		cliutils.PrettyPrintError(err)
+		if cliutils.IsJsonOutput() {
+			cliutils.PrintJsonError(err)
+		} else {
+			cliutils.PrettyPrintError(err)
+		}
 	}
 	fmt.Println("")
 
 }
+
+// Get the value of a global option straight from the arguments, since it's needed before they're parsed.
+// Both `--option value` and `--option=value` are supported; the last occurrence wins, like it does for the parsed flags.
+func getGlobalArg(defaultValue string, description string, names ...string) string {
+	value := defaultValue
+	for index, arg := range os.Args {
+		for _, name := range names {
+			if arg == name {
+				if len(os.Args)-1 == index {
+					fmt.Fprintf(os.Stderr, "Expected %s after %s but none was given.\n", description, arg)
+					os.Exit(1)
+				}
+				value = os.Args[index+1]
+			} else if strings.HasPrefix(arg, name+"=") {
+				value = strings.TrimPrefix(arg, name+"=")
+			}
+		}
+	}
+	return value
+}
@@ -0,0 +1,540 @@
+package service
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/mitchellh/go-homedir"
+	"github.com/urfave/cli"
+	"golang.org/x/crypto/scrypt"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/passwords"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// Backup settings
+const (
+	backupMagic          string = "rocketpool-backup-v1"
+	backupSaltLength     int    = 16
+	backupKeyLength      int    = 32
+	backupScryptN        int    = 1 << 15
+	backupScryptR        int    = 8
+	backupScryptP        int    = 1
+	backupFileMode              = 0600
+	backupDataFolder     string = "data"
+	backupSettingsFolder string = "settings"
+	validatorsFolder     string = "validators"
+)
+
+// The items in the data folder that are included in a backup
+var backupDataItems = []string{
+	"wallet",
+	"password",
+	"settings-passphrase",
+	validatorsFolder,
+	"custom-keys",
+	"custom-key-passwords",
+}
+
+// Where each Validator Client keeps its keys and its slashing protection history, relative to the validators folder
+type validatorClientFiles struct {
+	name              string
+	folder            string
+	keysFolder        string
+	isSlashingHistory func(relativePath string) bool
+}
+
+var validatorClients = []validatorClientFiles{
+	{
+		name:       "Lighthouse",
+		folder:     "lighthouse",
+		keysFolder: "validators",
+		isSlashingHistory: func(relativePath string) bool {
+			return strings.HasPrefix(path.Base(relativePath), "slashing_protection.sqlite")
+		},
+	},
+	{
+		name:       "Nimbus",
+		folder:     "nimbus",
+		keysFolder: "validators",
+		isSlashingHistory: func(relativePath string) bool {
+			return strings.HasPrefix(path.Base(relativePath), "slashing_protection.sqlite")
+		},
+	},
+	{
+		name:       "Prysm",
+		folder:     "prysm-non-hd",
+		keysFolder: "direct/accounts",
+		isSlashingHistory: func(relativePath string) bool {
+			return path.Base(relativePath) == "validator.db"
+		},
+	},
+	{
+		name:       "Teku",
+		folder:     "teku",
+		keysFolder: "keys",
+		isSlashingHistory: func(relativePath string) bool {
+			return strings.HasPrefix(relativePath, "slashprotection/")
+		},
+	},
+}
+
+// A file stored in a backup
+type backupFile struct {
+	header *tar.Header
+	data   []byte
+}
+
+// Back up the wallet, validator keys, and settings to an encrypted archive
+func backupService(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the config
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return err
+	}
+	if isNew {
+		return fmt.Errorf("Settings file not found. Please run `rocketpool service config` to set up your Smartnode.")
+	}
+	configPath, dataPath, err := getBackupPaths(c, cfg)
+	if err != nil {
+		return err
+	}
+
+	// Get the target file
+	targetFile := c.String("file")
+	if targetFile == "" {
+		targetFile = fmt.Sprintf("rocketpool-backup-%s.tar.gz.enc", time.Now().Format("20060102-150405"))
+	}
+	targetFile, err = filepath.Abs(targetFile)
+	if err != nil {
+		return fmt.Errorf("Error converting to absolute path: %w", err)
+	}
+	if _, err := os.Stat(targetFile); err == nil {
+		return fmt.Errorf("The backup file [%s] already exists.", targetFile)
+	}
+
+	fmt.Println("This will back up your node wallet, its password, your validator keys and their slashing protection history, your custom keys, and your Smartnode settings to an encrypted file.")
+	fmt.Printf("%sAnyone with this file and its passphrase can take control of your node wallet and validators, so store it somewhere safe.%s\n\n", colorYellow, colorReset)
+	fmt.Printf("%sNOTE: Your Validator Client keeps updating its slashing protection history while it runs. For the backup to have a complete history, stop the Smartnode with `rocketpool service stop` before taking it.%s\n\n", colorYellow, colorReset)
+
+	// Prompt for confirmation
+	if !(c.Bool("yes") || cliutils.Confirm("Are you sure you want to create a backup?")) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Collect the files
+	files := []backupFile{}
+	settingsFile, err := readBackupFile(filepath.Join(configPath, rocketpool.SettingsFile), path.Join(backupSettingsFolder, rocketpool.SettingsFile))
+	if err != nil {
+		return err
+	}
+	files = append(files, settingsFile)
+	for _, item := range backupDataItems {
+		itemFiles, err := readBackupItem(dataPath, item)
+		if err != nil {
+			return err
+		}
+		files = append(files, itemFiles...)
+	}
+
+	// Warn about validator keys without slashing protection history
+	for _, clientName := range getClientsMissingSlashingHistory(files) {
+		fmt.Printf("%sWARNING: The backup has %s validator keys but no slashing protection history for them. It will refuse to restore these keys.%s\n", colorRed, clientName, colorReset)
+	}
+
+	// Encrypt the archive
+	archive, err := createBackupArchive(files)
+	if err != nil {
+		return err
+	}
+	passphrase := promptBackupPassphrase()
+	encryptedArchive, err := encryptBackup(archive, passphrase)
+	if err != nil {
+		return err
+	}
+
+	// Save it
+	if err := ioutil.WriteFile(targetFile, encryptedArchive, backupFileMode); err != nil {
+		return fmt.Errorf("Error saving backup file: %w", err)
+	}
+	fmt.Printf("\nDone! Your Smartnode has been backed up to %s (%d files).\n", targetFile, len(files))
+	return nil
+
+}
+
+// Restore the wallet, validator keys, and settings from an encrypted archive
+func restoreService(c *cli.Context, sourceFile string) error {
+
+	// Read the backup
+	sourceFile, err := filepath.Abs(sourceFile)
+	if err != nil {
+		return fmt.Errorf("Error converting to absolute path: %w", err)
+	}
+	encryptedArchive, err := ioutil.ReadFile(sourceFile)
+	if err != nil {
+		return fmt.Errorf("Error reading backup file: %w", err)
+	}
+	passphrase := cliutils.PromptPassword("Please enter the passphrase for this backup:", "^.*$", "")
+	archive, err := decryptBackup(encryptedArchive, passphrase)
+	if err != nil {
+		return err
+	}
+	files, err := readBackupArchive(archive)
+	if err != nil {
+		return err
+	}
+
+	// Refuse to restore validator keys without their slashing protection history
+	skipValidatorKeys := c.Bool("no-validator-keys")
+	missingHistory := getClientsMissingSlashingHistory(files)
+	if len(missingHistory) > 0 && !skipValidatorKeys {
+		return fmt.Errorf("The backup has validator keys for %s without any slashing protection history, so restoring them could get your validators slashed.\n"+
+			"Use `--no-validator-keys` to restore everything else. You can regenerate your validator keys later with `rocketpool wallet rebuild` once you're certain they are no longer running anywhere else.",
+			strings.Join(missingHistory, ", "))
+	}
+
+	// Get the folders to restore to, using the data folder from the backed up settings
+	configPath, err := homedir.Expand(c.GlobalString("config-path"))
+	if err != nil {
+		return fmt.Errorf("error expanding config path [%s]: %w", c.GlobalString("config-path"), err)
+	}
+	dataPath, err := getBackupDataPath(configPath, files)
+	if err != nil {
+		return err
+	}
+
+	// Find the items that will be overwritten
+	existingItems := []string{}
+	settingsPath := filepath.Join(configPath, rocketpool.SettingsFile)
+	if _, err := os.Stat(settingsPath); err == nil {
+		existingItems = append(existingItems, settingsPath)
+	}
+	for _, item := range backupDataItems {
+		if item == validatorsFolder && skipValidatorKeys {
+			continue
+		}
+		itemPath := filepath.Join(dataPath, item)
+		if _, err := os.Stat(itemPath); err == nil {
+			existingItems = append(existingItems, itemPath)
+		}
+	}
+
+	fmt.Println("This will restore your node wallet, validator keys, custom keys, and Smartnode settings from the backup.")
+	fmt.Printf("%sMake sure the Smartnode is stopped on this machine with `rocketpool service stop`, and that the validators from this backup are permanently stopped on the machine it came from. Running them in two places at once will get them slashed.%s\n\n", colorRed, colorReset)
+	if len(existingItems) > 0 {
+		fmt.Printf("%sThe following items already exist and will be overwritten:%s\n", colorYellow, colorReset)
+		for _, item := range existingItems {
+			fmt.Printf("\t%s\n", item)
+		}
+		fmt.Println()
+	}
+
+	// Prompt for confirmation
+	if !(c.Bool("yes") || cliutils.Confirm("Are you sure you want to restore this backup?")) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Restore the files
+	restoredCount := 0
+	for _, file := range files {
+		var folder string
+		var relativePath string
+		if strings.HasPrefix(file.header.Name, backupDataFolder+"/") {
+			folder = dataPath
+			relativePath = strings.TrimPrefix(file.header.Name, backupDataFolder+"/")
+			if skipValidatorKeys && strings.HasPrefix(relativePath, validatorsFolder+"/") {
+				continue
+			}
+		} else if strings.HasPrefix(file.header.Name, backupSettingsFolder+"/") {
+			folder = configPath
+			relativePath = strings.TrimPrefix(file.header.Name, backupSettingsFolder+"/")
+		} else {
+			continue
+		}
+		if err := writeBackupFile(folder, relativePath, file); err != nil {
+			return err
+		}
+		restoredCount++
+	}
+
+	fmt.Printf("\nDone! %d files have been restored.\n", restoredCount)
+	fmt.Println("Please wait at least 15 minutes after the validators stopped on the old machine before starting the Smartnode here with `rocketpool service start`.")
+	return nil
+
+}
+
+// Get the host paths of the config and data folders
+func getBackupPaths(c *cli.Context, cfg *config.RocketPoolConfig) (string, string, error) {
+	configPath, err := homedir.Expand(c.GlobalString("config-path"))
+	if err != nil {
+		return "", "", fmt.Errorf("error expanding config path [%s]: %w", c.GlobalString("config-path"), err)
+	}
+	dataPath, err := homedir.Expand(os.ExpandEnv(cfg.Smartnode.GetDataPath()))
+	if err != nil {
+		return "", "", fmt.Errorf("error expanding data path [%s]: %w", cfg.Smartnode.GetDataPath(), err)
+	}
+	return configPath, dataPath, nil
+}
+
+// Get the host path of the data folder configured in a backup's settings file.
+// The data path isn't a sensitive setting, so this works even if the rest of the settings are encrypted.
+func getBackupDataPath(configPath string, files []backupFile) (string, error) {
+	cfg := config.NewRocketPoolConfig(configPath, false)
+	settingsName := path.Join(backupSettingsFolder, rocketpool.SettingsFile)
+	for _, file := range files {
+		if file.header.Name != settingsName {
+			continue
+		}
+		settings, err := config.UnmarshalPlainSettings(file.data)
+		if err != nil {
+			return "", fmt.Errorf("Error reading the settings file in the backup: %w", err)
+		}
+		if dataPath := settings["smartnode"][cfg.Smartnode.DataPath.ID]; dataPath != "" {
+			cfg.Smartnode.DataPath.Value = dataPath
+		}
+		dataPath, err := homedir.Expand(os.ExpandEnv(cfg.Smartnode.GetDataPath()))
+		if err != nil {
+			return "", fmt.Errorf("error expanding data path [%s]: %w", cfg.Smartnode.GetDataPath(), err)
+		}
+		return dataPath, nil
+	}
+	return "", fmt.Errorf("The backup doesn't have a settings file.")
+}
+
+// Read a file or folder in the data folder into backup files, if it exists
+func readBackupItem(dataPath string, item string) ([]backupFile, error) {
+	files := []backupFile{}
+	err := filepath.Walk(filepath.Join(dataPath, item), func(filePath string, info os.FileInfo, err error) error {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("Error reading %s: %w", filePath, err)
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		relativePath, err := filepath.Rel(dataPath, filePath)
+		if err != nil {
+			return err
+		}
+		file, err := readBackupFile(filePath, path.Join(backupDataFolder, filepath.ToSlash(relativePath)))
+		if err != nil {
+			return err
+		}
+		files = append(files, file)
+		return nil
+	})
+	return files, err
+}
+
+// Read a file into a backup file with the provided name
+func readBackupFile(filePath string, name string) (backupFile, error) {
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return backupFile{}, fmt.Errorf("Error reading %s: %w", filePath, err)
+	}
+	data, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return backupFile{}, fmt.Errorf("Error reading %s: %w", filePath, err)
+	}
+	return backupFile{
+		header: &tar.Header{
+			Name:    name,
+			Mode:    int64(info.Mode().Perm()),
+			Size:    int64(len(data)),
+			ModTime: info.ModTime(),
+		},
+		data: data,
+	}, nil
+}
+
+// Write a backup file to a folder
+func writeBackupFile(folder string, relativePath string, file backupFile) error {
+	filePath := filepath.Join(folder, filepath.FromSlash(relativePath))
+	if !strings.HasPrefix(filePath, filepath.Clean(folder)+string(os.PathSeparator)) {
+		return fmt.Errorf("The backup has an invalid file path [%s].", file.header.Name)
+	}
+	if err := os.MkdirAll(filepath.Dir(filePath), 0700); err != nil {
+		return fmt.Errorf("Error creating folder for %s: %w", filePath, err)
+	}
+	if err := ioutil.WriteFile(filePath, file.data, os.FileMode(file.header.Mode).Perm()); err != nil {
+		return fmt.Errorf("Error restoring %s: %w", filePath, err)
+	}
+	return nil
+}
+
+// Get the Validator Clients that have keys in the backup but no slashing protection history
+func getClientsMissingSlashingHistory(files []backupFile) []string {
+	missing := []string{}
+	for _, client := range validatorClients {
+		clientPrefix := path.Join(backupDataFolder, validatorsFolder, client.folder) + "/"
+		keysPrefix := clientPrefix + client.keysFolder + "/"
+		hasKeys := false
+		hasHistory := false
+		for _, file := range files {
+			if strings.HasPrefix(file.header.Name, keysPrefix) {
+				hasKeys = true
+			}
+			if strings.HasPrefix(file.header.Name, clientPrefix) && client.isSlashingHistory(strings.TrimPrefix(file.header.Name, clientPrefix)) {
+				hasHistory = true
+			}
+		}
+		if hasKeys && !hasHistory {
+			missing = append(missing, client.name)
+		}
+	}
+	return missing
+}
+
+// Create a compressed archive of backup files
+func createBackupArchive(files []backupFile) ([]byte, error) {
+	var buffer bytes.Buffer
+	gzipWriter := gzip.NewWriter(&buffer)
+	tarWriter := tar.NewWriter(gzipWriter)
+	for _, file := range files {
+		if err := tarWriter.WriteHeader(file.header); err != nil {
+			return nil, fmt.Errorf("Error adding %s to the backup: %w", file.header.Name, err)
+		}
+		if _, err := tarWriter.Write(file.data); err != nil {
+			return nil, fmt.Errorf("Error adding %s to the backup: %w", file.header.Name, err)
+		}
+	}
+	if err := tarWriter.Close(); err != nil {
+		return nil, fmt.Errorf("Error creating the backup archive: %w", err)
+	}
+	if err := gzipWriter.Close(); err != nil {
+		return nil, fmt.Errorf("Error compressing the backup archive: %w", err)
+	}
+	return buffer.Bytes(), nil
+}
+
+// Read the files in a compressed backup archive
+func readBackupArchive(archive []byte) ([]backupFile, error) {
+	gzipReader, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		return nil, fmt.Errorf("Error decompressing the backup archive: %w", err)
+	}
+	tarReader := tar.NewReader(gzipReader)
+	files := []backupFile{}
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("Error reading the backup archive: %w", err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		data, err := ioutil.ReadAll(tarReader)
+		if err != nil {
+			return nil, fmt.Errorf("Error reading %s from the backup: %w", header.Name, err)
+		}
+		files = append(files, backupFile{
+			header: header,
+			data:   data,
+		})
+	}
+	return files, nil
+}
+
+// Prompt for the passphrase to encrypt a backup with
+func promptBackupPassphrase() string {
+	for {
+		passphrase := cliutils.PromptPassword(
+			"Please enter a passphrase to encrypt the backup with:",
+			fmt.Sprintf("^.{%d,}$", passwords.MinPasswordLength),
+			fmt.Sprintf("Your passphrase must be at least %d characters long. Please try again:", passwords.MinPasswordLength),
+		)
+		confirmation := cliutils.PromptPassword("Please confirm your passphrase:", "^.*$", "")
+		if passphrase == confirmation {
+			return passphrase
+		}
+		fmt.Println("Passphrase confirmation does not match.")
+		fmt.Println("")
+	}
+}
+
+// Create a cipher for a backup from its passphrase and salt
+func newBackupCipher(passphrase string, salt []byte) (cipher.AEAD, error) {
+	key, err := scrypt.Key([]byte(passphrase), salt, backupScryptN, backupScryptR, backupScryptP, backupKeyLength)
+	if err != nil {
+		return nil, fmt.Errorf("Error deriving backup encryption key: %w", err)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// Encrypt a backup archive with a passphrase.
+// The encrypted file is the magic header, the salt, the nonce, and then the ciphertext.
+func encryptBackup(archive []byte, passphrase string) ([]byte, error) {
+	salt := make([]byte, backupSaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("Error generating backup encryption salt: %w", err)
+	}
+	aead, err := newBackupCipher(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("Error generating nonce: %w", err)
+	}
+	header := append([]byte(backupMagic), salt...)
+	header = append(header, nonce...)
+	return aead.Seal(header, nonce, archive, []byte(backupMagic)), nil
+}
+
+// Decrypt a backup archive with its passphrase
+func decryptBackup(encryptedArchive []byte, passphrase string) ([]byte, error) {
+	if !bytes.HasPrefix(encryptedArchive, []byte(backupMagic)) {
+		return nil, fmt.Errorf("This is not a Smartnode backup file.")
+	}
+	encryptedArchive = encryptedArchive[len(backupMagic):]
+	if len(encryptedArchive) < backupSaltLength {
+		return nil, fmt.Errorf("The backup file is corrupted.")
+	}
+	salt := encryptedArchive[:backupSaltLength]
+	aead, err := newBackupCipher(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+	encryptedArchive = encryptedArchive[backupSaltLength:]
+	if len(encryptedArchive) < aead.NonceSize() {
+		return nil, fmt.Errorf("The backup file is corrupted.")
+	}
+	archive, err := aead.Open(nil, encryptedArchive[:aead.NonceSize()], encryptedArchive[aead.NonceSize():], []byte(backupMagic))
+	if err != nil {
+		return nil, fmt.Errorf("Could not decrypt the backup; the passphrase may be incorrect.")
+	}
+	return archive, nil
+}
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/utils/net"
+)
+
+// Settings
+const ntpRequestTimeout = 5 * time.Second
+
+// Compare the system clock with each of the configured NTP servers
+func checkTime(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the config
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return err
+	}
+	if isNew {
+		return fmt.Errorf("Settings file not found. Please run `rocketpool service config` to set up your Smartnode.")
+	}
+
+	// Check the clock against each server
+	maxOffset := cfg.Smartnode.GetMaxClockOffset()
+	reachable := false
+	inSync := true
+	for _, server := range cfg.Smartnode.GetNtpServers() {
+		offset, err := net.GetClockOffset(server, ntpRequestTimeout)
+		if err != nil {
+			fmt.Printf("%s%s: %s%s\n", colorYellow, server, err.Error(), colorReset)
+			continue
+		}
+		reachable = true
+		if offset > maxOffset || offset < -maxOffset {
+			inSync = false
+			fmt.Printf("%s%s: your clock is off by %s%s\n", colorRed, server, offset.Round(time.Millisecond), colorReset)
+		} else {
+			fmt.Printf("%s%s: your clock is off by %s%s\n", colorGreen, server, offset.Round(time.Millisecond), colorReset)
+		}
+	}
+	fmt.Println()
+
+	// Explain the result
+	switch {
+	case !reachable:
+		return fmt.Errorf("None of the NTP servers could be reached. Make sure UDP port 123 isn't blocked by your firewall, or change the NTP Servers in `rocketpool service config`.")
+	case inSync:
+		fmt.Printf("Your system clock is within %s of NTP time.\n", maxOffset)
+	case cfg.Smartnode.GetTimeSyncMode() == config.TimeSyncMode_Chrony:
+		fmt.Printf("%sYour system clock is more than %s off, even though the Chrony container is enabled. Check its logs with `rocketpool service logs %s`, and make sure your operating system's own time sync service is disabled so the two don't fight over the clock.%s\n", colorRed, maxOffset, config.ChronyContainerName, colorReset)
+	default:
+		fmt.Printf("%sYour system clock is more than %s off, which can cause your validators to miss attestations.\nMake sure your operating system's time sync service (such as systemd-timesyncd, chrony, or ntpd) is installed and running - `timedatectl` will show its status on most systems.\nIf you'd rather the Smartnode keep your clock in sync, set Time Sync to Chrony Container in the Smartnode section of `rocketpool service config`.%s\n", colorRed, maxOffset, colorReset)
+	}
+	return nil
+
+}
@@ -0,0 +1,111 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/dustin/go-humanize"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// Remove the containers, volumes and images the Rocket Pool service no longer uses
+func cleanupService(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the config
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return err
+	}
+	if isNew {
+		return fmt.Errorf("Settings file not found. Please run `rocketpool service config` to set up your Smartnode.")
+	}
+
+	// Find the unused resources
+	fmt.Println("Looking for unused Docker resources...")
+	cleanup, err := rp.GetServiceCleanup(getComposeFiles(c), fmt.Sprint(cfg.Smartnode.ProjectName.Value))
+	if err != nil {
+		return err
+	}
+	if len(cleanup.Containers)+len(cleanup.Volumes)+len(cleanup.Images) == 0 {
+		fmt.Println("There is nothing to clean up.")
+		return nil
+	}
+
+	// Print them
+	printCleanupResources("Stopped containers", cleanup.Containers)
+	printCleanupResources("Dangling volumes", cleanup.Volumes)
+	printCleanupResources("Old images", cleanup.Images)
+	fmt.Printf("\nRemoving these will reclaim about %s of disk space.\n", humanize.Bytes(cleanup.GetTotalSize()))
+	if len(cleanup.Volumes) > 0 {
+		fmt.Printf("%sNOTE: Dangling volumes may hold chain data from a client you no longer use. If you plan to switch back to it, keeping its volume will save you from resyncing.%s\n", colorYellow, colorReset)
+	}
+	fmt.Println()
+
+	// Prompt for confirmation
+	if !(c.Bool("yes") || cliutils.Confirm("Are you sure you want to remove these?")) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Remove them; containers go first since they hold on to their volumes and images
+	failed := 0
+	for _, container := range cleanup.Containers {
+		fmt.Printf("Removing container %s... ", container.Name)
+		if _, err := rp.RemoveContainer(container.ID); err != nil {
+			fmt.Printf("%serror: %s%s\n", colorRed, err.Error(), colorReset)
+			failed++
+			continue
+		}
+		fmt.Println("done!")
+	}
+	for _, volume := range cleanup.Volumes {
+		fmt.Printf("Removing volume %s... ", volume.Name)
+		if _, err := rp.DeleteVolume(volume.ID); err != nil {
+			fmt.Printf("%serror: %s%s\n", colorRed, err.Error(), colorReset)
+			failed++
+			continue
+		}
+		fmt.Println("done!")
+	}
+	for _, image := range cleanup.Images {
+		fmt.Printf("Removing image %s... ", image.Name)
+		if _, err := rp.RemoveImage(image.ID); err != nil {
+			fmt.Printf("%serror: %s%s\n", colorRed, err.Error(), colorReset)
+			failed++
+			continue
+		}
+		fmt.Println("done!")
+	}
+
+	if failed > 0 {
+		fmt.Printf("\n%s%d items couldn't be removed.%s\n", colorYellow, failed, colorReset)
+	} else {
+		fmt.Println("\nCleanup complete.")
+	}
+	return nil
+
+}
+
+// Print a group of unused resources
+func printCleanupResources(title string, resources []rocketpool.DockerResource) {
+	if len(resources) == 0 {
+		return
+	}
+	fmt.Printf("\n%s:\n", title)
+	for _, resource := range resources {
+		if resource.Size > 0 {
+			fmt.Printf("\t%s (%s): %s\n", resource.Name, humanize.Bytes(resource.Size), resource.Reason)
+		} else {
+			fmt.Printf("\t%s: %s\n", resource.Name, resource.Reason)
+		}
+	}
+}
@@ -81,9 +81,22 @@ func createFlagsFromConfigParams(sectionName string, params []*config.Parameter,
 // Register commands
 func RegisterCommands(app *cli.App, name string, aliases []string) {
 
-	configFlags := []cli.Flag{}
+	configFlags := []cli.Flag{
+		cli.StringFlag{
+			Name:  "file, f",
+			Usage: "Apply a complete settings file (such as a user-settings.yml from another node) without using the interactive configuration",
+		},
+		cli.BoolFlag{
+			Name:  "export-schema",
+			Usage: "Print a JSON description of every setting, including its type, defaults and options, and exit",
+		},
+		cli.BoolFlag{
+			Name:  "preview-compose",
+			Usage: "Print the Docker Compose files rendered from the current settings, without deploying them, and exit",
+		},
+	}
 	cfgTemplate := config.NewRocketPoolConfig("", false)
-	network := cfgTemplate.Smartnode.Network.Value.(config.Network)
+	network := cfgTemplate.Smartnode.GetNetwork()
 
 	// Root params
 	configFlags = createFlagsFromConfigParams("", cfgTemplate.GetParameters(), configFlags, network)
@@ -136,6 +149,10 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 						Usage: "The smart node package version to install",
 						Value: fmt.Sprintf("v%s", shared.RocketPoolVersion),
 					},
+					cli.BoolFlag{
+						Name:  "native",
+						Usage: "Install systemd services for the node, watchtower and any clients with commands in the Native settings instead of the Docker service (requires '--daemon-path')",
+					},
 				},
 				Action: func(c *cli.Context) error {
 
@@ -145,6 +162,9 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 					}
 
 					// Run command
+					if c.Bool("native") {
+						return installNativeService(c)
+					}
 					return installService(c)
 
 				},
@@ -187,6 +207,23 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "profiles",
+				Usage:     "List the Smartnode configurations this CLI manages, including each profile created with the --profile flag",
+				UsageText: "rocketpool service profiles",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run command
+					return listProfiles(c)
+
+				},
+			},
+
 			{
 				Name:      "start",
 				Aliases:   []string{"s"},
@@ -201,6 +238,10 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 						Name:  "yes, y",
 						Usage: "Ignore service config prompt after upgrading",
 					},
+					cli.BoolFlag{
+						Name:  "full",
+						Usage: "Update every container, instead of only the ones affected by settings changes since the service was last started",
+					},
 				},
 				Action: func(c *cli.Context) error {
 
@@ -338,7 +379,7 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 			{
 				Name:      "prune-eth1",
 				Aliases:   []string{"n"},
-				Usage:     "Shuts down the main ETH1 client and prunes its database, freeing up disk space, then restarts it when it's done.",
+				Usage:     "Prunes the main ETH1 client's database, freeing up disk space. Geth is shut down while it prunes and restarted when it's done; Nethermind keeps running while it prunes.",
 				UsageText: "rocketpool service prune-eth1",
 				Action: func(c *cli.Context) error {
 
@@ -353,6 +394,46 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "cleanup",
+				Usage:     "Find and remove the stopped containers, dangling volumes and old images left behind by client switches and upgrades",
+				UsageText: "rocketpool service cleanup [options]",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm removal of the unused resources",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run command
+					return cleanupService(c)
+
+				},
+			},
+
+			{
+				Name:      "disk",
+				Usage:     "Show how much disk space each of the Smartnode's volumes and data folders uses, how fast they're growing, and how long the free space will last",
+				UsageText: "rocketpool service disk",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run command
+					return serviceDisk(c)
+
+				},
+			},
+
 			{
 				Name:      "install-update-tracker",
 				Aliases:   []string{"d"},
@@ -439,6 +520,224 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "validator-shards",
+				Usage:     "Show which validator keys each Validator client shard runs, if your keys are split across several Validator clients",
+				UsageText: "rocketpool service validator-shards",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run command
+					return printValidatorShards(c)
+
+				},
+			},
+
+			{
+				Name:      "check-time",
+				Usage:     "Check that the system clock is in sync with the NTP servers in the Smartnode settings",
+				UsageText: "rocketpool service check-time",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run command
+					return checkTime(c)
+
+				},
+			},
+
+			{
+				Name:      "sync-from-snapshot",
+				Usage:     "Replaces the execution client (eth1) chain data with a snapshot from a trusted provider, so it doesn't have to sync from scratch. Snapshots can be set for each client in the Smartnode settings.",
+				UsageText: "rocketpool service sync-from-snapshot [options]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "url",
+						Usage: "The URL of the snapshot to download, instead of the one in the Smartnode settings",
+					},
+					cli.StringFlag{
+						Name:  "sha256",
+						Usage: "The SHA256 checksum of the snapshot, if the provider doesn't publish it next to the snapshot",
+					},
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm deleting the existing chain data",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run command
+					return syncFromSnapshot(c)
+
+				},
+			},
+
+			{
+				Name:      "backup",
+				Usage:     "Back up the node wallet, validator keys and their slashing protection history, custom keys, and Smartnode settings to an encrypted file",
+				UsageText: "rocketpool service backup [options]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "file, f",
+						Usage: "The path of the backup file to create (defaults to a timestamped file in the current directory)",
+					},
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm creating the backup",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run command
+					return backupService(c)
+
+				},
+			},
+
+			{
+				Name:      "restore",
+				Usage:     "Restore the node wallet, validator keys, custom keys, and Smartnode settings from a backup file created with `rocketpool service backup`",
+				UsageText: "rocketpool service restore [options] backup-file",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "no-validator-keys",
+						Usage: "Restore everything except the validator keys and their slashing protection history",
+					},
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm restoring the backup",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					sourceFile := c.Args().Get(0)
+
+					// Run command
+					return restoreService(c, sourceFile)
+
+				},
+			},
+
+			{
+				Name:      "doctor",
+				Usage:     "Check the ETH1 or ETH2 client's recent logs for known sync failures, explain what went wrong, and offer to fix it",
+				UsageText: "rocketpool service doctor [options] eth1|eth2",
+				Flags: []cli.Flag{
+					cli.Uint64Flag{
+						Name:  "lines, n",
+						Usage: "The number of recent log lines to inspect",
+						Value: 2000,
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+
+					// Run command
+					return serviceDoctor(c, c.Args().Get(0))
+
+				},
+			},
+
+			{
+				Name:      "repair-eth1",
+				Usage:     "Check Geth's logs and state for database corruption and recover from it by rewinding, regenerating the snapshot, or resyncing",
+				UsageText: "rocketpool service repair-eth1 [options]",
+				Flags: []cli.Flag{
+					cli.Uint64Flag{
+						Name:  "lines, n",
+						Usage: "The number of recent log lines to inspect",
+						Value: 2000,
+					},
+					cli.Uint64Flag{
+						Name:  "rewind-blocks, r",
+						Usage: "The number of blocks to rewind Geth's head by if its recent state is missing",
+						Value: defaultGethRewindBlocks,
+					},
+					cli.BoolFlag{
+						Name:  "auto, a",
+						Usage: "Run the matching recovery without asking for confirmation, unless it deletes the chain data (a resync is always confirmed)",
+					},
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm the recovery",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run command
+					return repairEth1(c)
+
+				},
+			},
+
+			{
+				Name:      "events",
+				Usage:     "Show a condensed list of the sync milestones, peer drops, and errors in the ETH1 and ETH2 clients' recent logs",
+				UsageText: "rocketpool service events [options] [eth1|eth2]",
+				Flags: []cli.Flag{
+					cli.Uint64Flag{
+						Name:  "lines, n",
+						Usage: "The number of recent log lines to read from each client",
+						Value: 5000,
+					},
+					cli.StringFlag{
+						Name:  "since, s",
+						Usage: "Only read the lines logged after this timestamp or duration (e.g. 2h)",
+					},
+					cli.StringFlag{
+						Name:  "kind, k",
+						Usage: "Only show one kind of event: 'sync', 'peers', or 'error'",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if c.NArg() > 1 {
+						return cliutils.ValidateArgCount(c, 1)
+					}
+					switch kind := c.String("kind"); kind {
+					case "", "sync", "peers", "error":
+					default:
+						return fmt.Errorf("Invalid event kind '%s'; valid kinds are 'sync', 'peers', and 'error'.", kind)
+					}
+
+					// Run command
+					return serviceEvents(c, c.Args())
+
+				},
+			},
+
 			{
 				Name:      "resync-eth1",
 				Usage:     fmt.Sprintf("%sDeletes the main ETH1 client's chain data and resyncs it from scratch. Only use this as a last resort!%s", colorRed, colorReset),
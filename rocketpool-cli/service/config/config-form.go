@@ -30,6 +30,44 @@ func registerEnableCheckbox(param *config.Parameter, checkbox *tview.Checkbox, f
 	})
 }
 
+// Only show the items for parameters that are relevant, and update them whenever one of the checkboxes they depend on changes
+func registerDependentItems(layout *standardLayout, items []*parameterizedFormItem) {
+	rebuild := func(focusedItem tview.FormItem) {
+		layout.form.Clear(true)
+		layout.addRelevantFormItems(items)
+		for i := 0; i < layout.form.GetFormItemCount(); i++ {
+			if layout.form.GetFormItem(i) == focusedItem {
+				layout.form.SetFocus(i)
+				break
+			}
+		}
+	}
+
+	for _, item := range items {
+		param := item.parameter
+		if param.Type != config.ParameterType_Bool || !hasDependentItems(param, items) {
+			continue
+		}
+		checkbox := item.item.(*tview.Checkbox)
+		checkbox.SetChangedFunc(func(checked bool) {
+			param.Value = checked
+			rebuild(checkbox)
+		})
+	}
+}
+
+// Check if any of the form items depend on the provided parameter
+func hasDependentItems(param *config.Parameter, items []*parameterizedFormItem) bool {
+	for _, item := range items {
+		for _, dependency := range item.parameter.Dependencies {
+			if dependency.Parameter == param {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // Create a list of form items based on a set of parameters
 func createParameterizedFormItems(params []*config.Parameter, descriptionBox *tview.TextView) []*parameterizedFormItem {
 	formItems := []*parameterizedFormItem{}
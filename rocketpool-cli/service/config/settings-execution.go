@@ -17,6 +17,7 @@ type ExecutionConfigPage struct {
 	gethItems       []*parameterizedFormItem
 	nethermindItems []*parameterizedFormItem
 	besuItems       []*parameterizedFormItem
+	erigonItems     []*parameterizedFormItem
 	infuraItems     []*parameterizedFormItem
 	pocketItems     []*parameterizedFormItem
 	externalEcItems []*parameterizedFormItem
@@ -81,6 +82,7 @@ func (configPage *ExecutionConfigPage) createContent() {
 	configPage.gethItems = createParameterizedFormItems(configPage.masterConfig.Geth.GetParameters(), configPage.layout.descriptionBox)
 	configPage.nethermindItems = createParameterizedFormItems(configPage.masterConfig.Nethermind.GetParameters(), configPage.layout.descriptionBox)
 	configPage.besuItems = createParameterizedFormItems(configPage.masterConfig.Besu.GetParameters(), configPage.layout.descriptionBox)
+	configPage.erigonItems = createParameterizedFormItems(configPage.masterConfig.Erigon.GetParameters(), configPage.layout.descriptionBox)
 	configPage.infuraItems = createParameterizedFormItems(configPage.masterConfig.Infura.GetParameters(), configPage.layout.descriptionBox)
 	configPage.pocketItems = createParameterizedFormItems(configPage.masterConfig.Pocket.GetParameters(), configPage.layout.descriptionBox)
 	configPage.externalEcItems = createParameterizedFormItems(configPage.masterConfig.ExternalExecution.GetParameters(), configPage.layout.descriptionBox)
@@ -91,6 +93,7 @@ func (configPage *ExecutionConfigPage) createContent() {
 	configPage.layout.mapParameterizedFormItems(configPage.gethItems...)
 	configPage.layout.mapParameterizedFormItems(configPage.nethermindItems...)
 	configPage.layout.mapParameterizedFormItems(configPage.besuItems...)
+	configPage.layout.mapParameterizedFormItems(configPage.erigonItems...)
 	configPage.layout.mapParameterizedFormItems(configPage.infuraItems...)
 	configPage.layout.mapParameterizedFormItems(configPage.pocketItems...)
 	configPage.layout.mapParameterizedFormItems(configPage.externalEcItems...)
@@ -150,6 +153,8 @@ func (configPage *ExecutionConfigPage) handleLocalEcChanged() {
 		configPage.layout.addFormItemsWithCommonParams(configPage.ecCommonItems, configPage.nethermindItems, configPage.masterConfig.Nethermind.UnsupportedCommonParams)
 	case config.ExecutionClient_Besu:
 		configPage.layout.addFormItemsWithCommonParams(configPage.ecCommonItems, configPage.besuItems, configPage.masterConfig.Besu.UnsupportedCommonParams)
+	case config.ExecutionClient_Erigon:
+		configPage.layout.addFormItemsWithCommonParams(configPage.ecCommonItems, configPage.erigonItems, configPage.masterConfig.Erigon.UnsupportedCommonParams)
 	case config.ExecutionClient_Infura:
 		configPage.layout.addFormItemsWithCommonParams(configPage.ecCommonItems, configPage.infuraItems, configPage.masterConfig.Infura.UnsupportedCommonParams)
 	case config.ExecutionClient_Pocket:
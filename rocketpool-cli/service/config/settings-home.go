@@ -11,19 +11,22 @@ const settingsHomeID string = "settings-home"
 
 // This is a container for the primary settings category selection home screen.
 type settingsHome struct {
-	homePage         *page
-	saveButton       *tview.Button
-	wizardButton     *tview.Button
-	smartnodePage    *SmartnodeConfigPage
-	ecPage           *ExecutionConfigPage
-	fallbackEcPage   *FallbackExecutionConfigPage
-	ccPage           *ConsensusConfigPage
-	metricsPage      *MetricsConfigPage
-	addonsPage       *AddonsPage
-	categoryList     *tview.List
-	settingsSubpages []settingsPage
-	content          tview.Primitive
-	md               *mainDisplay
+	homePage          *page
+	saveButton        *tview.Button
+	wizardButton      *tview.Button
+	smartnodePage     *SmartnodeConfigPage
+	ecPage            *ExecutionConfigPage
+	fallbackEcPage    *FallbackExecutionConfigPage
+	ccPage            *ConsensusConfigPage
+	metricsPage       *MetricsConfigPage
+	signerPage        *RemoteSignerConfigPage
+	notificationsPage *NotificationsConfigPage
+	schedulePage      *ScheduleConfigPage
+	addonsPage        *AddonsPage
+	categoryList      *tview.List
+	settingsSubpages  []settingsPage
+	content           tview.Primitive
+	md                *mainDisplay
 }
 
 // Creates a new SettingsHome instance and adds (and its subpages) it to the main display.
@@ -43,6 +46,9 @@ func newSettingsHome(md *mainDisplay) *settingsHome {
 	home.fallbackEcPage = NewFallbackExecutionConfigPage(home)
 	home.ccPage = NewConsensusConfigPage(home)
 	home.metricsPage = NewMetricsConfigPage(home)
+	home.signerPage = NewRemoteSignerConfigPage(home)
+	home.notificationsPage = NewNotificationsConfigPage(home)
+	home.schedulePage = NewScheduleConfigPage(home)
 	home.addonsPage = NewAddonsPage(home.md)
 	settingsSubpages := []settingsPage{
 		home.smartnodePage,
@@ -50,6 +56,9 @@ func newSettingsHome(md *mainDisplay) *settingsHome {
 		home.fallbackEcPage,
 		home.ccPage,
 		home.metricsPage,
+		home.signerPage,
+		home.notificationsPage,
+		home.schedulePage,
 		home.addonsPage,
 	}
 	home.settingsSubpages = settingsSubpages
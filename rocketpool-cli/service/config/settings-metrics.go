@@ -24,6 +24,8 @@ type MetricsConfigPage struct {
 	exporterItems              []*parameterizedFormItem
 	enableBitflyNodeMetricsBox *parameterizedFormItem
 	bitflyNodeMetricsItems     []*parameterizedFormItem
+	enableAlertingBox          *parameterizedFormItem
+	alertmanagerItems          []*parameterizedFormItem
 }
 
 // Creates a new page for the metrics / stats settings
@@ -91,6 +93,8 @@ func (configPage *MetricsConfigPage) createContent() {
 	configPage.exporterItems = createParameterizedFormItems(configPage.masterConfig.Exporter.GetParameters(), configPage.layout.descriptionBox)
 	configPage.enableBitflyNodeMetricsBox = createParameterizedCheckbox(&configPage.masterConfig.EnableBitflyNodeMetrics)
 	configPage.bitflyNodeMetricsItems = createParameterizedFormItems(configPage.masterConfig.BitflyNodeMetrics.GetParameters(), configPage.layout.descriptionBox)
+	configPage.enableAlertingBox = createParameterizedCheckbox(&configPage.masterConfig.EnableAlerting)
+	configPage.alertmanagerItems = createParameterizedFormItems(configPage.masterConfig.Alertmanager.GetParameters(), configPage.layout.descriptionBox)
 
 	// Map the parameters to the form items in the layout
 	configPage.layout.mapParameterizedFormItems(configPage.enableMetricsBox, configPage.ecMetricsPortBox, configPage.bnMetricsPortBox, configPage.vcMetricsPortBox, configPage.nodeMetricsPortBox, configPage.exporterMetricsPortBox, configPage.watchtowerMetricsPortBox)
@@ -99,6 +103,8 @@ func (configPage *MetricsConfigPage) createContent() {
 	configPage.layout.mapParameterizedFormItems(configPage.exporterItems...)
 	configPage.layout.mapParameterizedFormItems(configPage.enableBitflyNodeMetricsBox)
 	configPage.layout.mapParameterizedFormItems(configPage.bitflyNodeMetricsItems...)
+	configPage.layout.mapParameterizedFormItems(configPage.enableAlertingBox)
+	configPage.layout.mapParameterizedFormItems(configPage.alertmanagerItems...)
 
 	// Set up the setting callbacks
 	configPage.enableMetricsBox.item.(*tview.Checkbox).SetChangedFunc(func(checked bool) {
@@ -115,6 +121,13 @@ func (configPage *MetricsConfigPage) createContent() {
 		configPage.masterConfig.EnableBitflyNodeMetrics.Value = checked
 		configPage.handleLayoutChanged()
 	})
+	configPage.enableAlertingBox.item.(*tview.Checkbox).SetChangedFunc(func(checked bool) {
+		if configPage.masterConfig.EnableAlerting.Value == checked {
+			return
+		}
+		configPage.masterConfig.EnableAlerting.Value = checked
+		configPage.handleLayoutChanged()
+	})
 
 	// Do the initial draw
 	configPage.handleLayoutChanged()
@@ -130,6 +143,10 @@ func (configPage *MetricsConfigPage) handleLayoutChanged() {
 		configPage.layout.addFormItems(configPage.grafanaItems)
 		configPage.layout.addFormItems(configPage.prometheusItems)
 		configPage.layout.addFormItems(configPage.exporterItems)
+		configPage.layout.form.AddFormItem(configPage.enableAlertingBox.item)
+		if configPage.masterConfig.EnableAlerting.Value == true {
+			configPage.layout.addFormItems(configPage.alertmanagerItems)
+		}
 	}
 
 	switch configPage.masterConfig.ConsensusClient.Value.(config.ConsensusClient) {
@@ -67,6 +67,11 @@ func (configPage *NativeSmartnodeConfigPage) createContent() {
 			// Ignore the project name ID since it doesn't apply to native mode
 			continue
 		}
+		switch formItem.parameter {
+		case &masterConfig.Smartnode.DockerNetwork, &masterConfig.Smartnode.DockerNetworkSubnet, &masterConfig.Smartnode.EnableIpv6, &masterConfig.Smartnode.DockerNetworkIpv6Subnet:
+			// Ignore the Docker network settings since they don't apply to native mode
+			continue
+		}
 
 		layout.form.AddFormItem(formItem.item)
 		layout.parameters[formItem.item] = formItem
@@ -0,0 +1,75 @@
+package config
+
+import (
+	"github.com/gdamore/tcell/v2"
+)
+
+// The page wrapper for the notifications config
+type NotificationsConfigPage struct {
+	home      *settingsHome
+	page      *page
+	layout    *standardLayout
+	formItems []*parameterizedFormItem
+}
+
+// Creates a new page for the notification settings
+func NewNotificationsConfigPage(home *settingsHome) *NotificationsConfigPage {
+
+	configPage := &NotificationsConfigPage{
+		home: home,
+	}
+
+	configPage.createContent()
+	configPage.page = newPage(
+		home.homePage,
+		"settings-notifications",
+		"Notifications",
+		"Select this to have the Smartnode send notifications about important events, such as your Execution client going down, to a webhook, Discord, or Telegram.",
+		configPage.layout.grid,
+	)
+
+	return configPage
+
+}
+
+// Get the underlying page
+func (configPage *NotificationsConfigPage) getPage() *page {
+	return configPage.page
+}
+
+// Creates the content for the notifications settings page
+func (configPage *NotificationsConfigPage) createContent() {
+
+	// Create the layout
+	masterConfig := configPage.home.md.Config
+	layout := newStandardLayout()
+	configPage.layout = layout
+	layout.createForm(&masterConfig.Smartnode.Network, "Notification Settings")
+
+	// Return to the home page after pressing Escape
+	layout.form.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEsc {
+			configPage.home.md.setPage(configPage.home.homePage)
+			return nil
+		}
+		return event
+	})
+
+	// Set up the form items
+	enableBox := createParameterizedCheckbox(&masterConfig.EnableNotifications)
+	notificationItems := createParameterizedFormItems(masterConfig.Notifications.GetParameters(), layout.descriptionBox)
+	configPage.formItems = append([]*parameterizedFormItem{enableBox}, notificationItems...)
+	layout.mapParameterizedFormItems(configPage.formItems...)
+	registerDependentItems(layout, configPage.formItems)
+
+	// Do the initial draw
+	configPage.handleLayoutChanged()
+
+}
+
+// Handle a bulk redraw request
+func (configPage *NotificationsConfigPage) handleLayoutChanged() {
+	configPage.layout.form.Clear(true)
+	configPage.layout.addRelevantFormItems(configPage.formItems)
+	configPage.layout.refresh()
+}
@@ -0,0 +1,75 @@
+package config
+
+import (
+	"github.com/gdamore/tcell/v2"
+)
+
+// The page wrapper for the remote signer config
+type RemoteSignerConfigPage struct {
+	home      *settingsHome
+	page      *page
+	layout    *standardLayout
+	formItems []*parameterizedFormItem
+}
+
+// Creates a new page for the remote signer settings
+func NewRemoteSignerConfigPage(home *settingsHome) *RemoteSignerConfigPage {
+
+	configPage := &RemoteSignerConfigPage{
+		home: home,
+	}
+
+	configPage.createContent()
+	configPage.page = newPage(
+		home.homePage,
+		"settings-remote-signer",
+		"Remote Signer",
+		"Select this to keep your validator keys in a remote signer such as Web3Signer instead of on this machine.",
+		configPage.layout.grid,
+	)
+
+	return configPage
+
+}
+
+// Get the underlying page
+func (configPage *RemoteSignerConfigPage) getPage() *page {
+	return configPage.page
+}
+
+// Creates the content for the remote signer settings page
+func (configPage *RemoteSignerConfigPage) createContent() {
+
+	// Create the layout
+	masterConfig := configPage.home.md.Config
+	layout := newStandardLayout()
+	configPage.layout = layout
+	layout.createForm(&masterConfig.Smartnode.Network, "Remote Signer Settings")
+
+	// Return to the home page after pressing Escape
+	layout.form.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEsc {
+			configPage.home.md.setPage(configPage.home.homePage)
+			return nil
+		}
+		return event
+	})
+
+	// Set up the form items
+	enableBox := createParameterizedCheckbox(&masterConfig.UseRemoteSigner)
+	signerItems := createParameterizedFormItems(masterConfig.RemoteSigner.GetParameters(), layout.descriptionBox)
+	configPage.formItems = append([]*parameterizedFormItem{enableBox}, signerItems...)
+	layout.mapParameterizedFormItems(configPage.formItems...)
+	registerDependentItems(layout, configPage.formItems)
+
+	// Do the initial draw
+	configPage.handleLayoutChanged()
+
+}
+
+// Handle a bulk redraw request
+func (configPage *RemoteSignerConfigPage) handleLayoutChanged() {
+	configPage.layout.form.Clear(true)
+	configPage.layout.addRelevantFormItems(configPage.formItems)
+	configPage.layout.refresh()
+}
@@ -0,0 +1,72 @@
+package config
+
+import (
+	"github.com/gdamore/tcell/v2"
+)
+
+// The page wrapper for the schedule config
+type ScheduleConfigPage struct {
+	home      *settingsHome
+	page      *page
+	layout    *standardLayout
+	formItems []*parameterizedFormItem
+}
+
+// Creates a new page for the schedule settings
+func NewScheduleConfigPage(home *settingsHome) *ScheduleConfigPage {
+
+	configPage := &ScheduleConfigPage{
+		home: home,
+	}
+
+	configPage.createContent()
+	configPage.page = newPage(
+		home.homePage,
+		"settings-schedule",
+		"Schedule",
+		"Select this to set up blackout windows, such as hours with expensive electricity, when the Smartnode holds off on non-critical automation and alerts.",
+		configPage.layout.grid,
+	)
+
+	return configPage
+
+}
+
+// Get the underlying page
+func (configPage *ScheduleConfigPage) getPage() *page {
+	return configPage.page
+}
+
+// Creates the content for the schedule settings page
+func (configPage *ScheduleConfigPage) createContent() {
+
+	// Create the layout
+	masterConfig := configPage.home.md.Config
+	layout := newStandardLayout()
+	configPage.layout = layout
+	layout.createForm(&masterConfig.Smartnode.Network, "Schedule Settings")
+
+	// Return to the home page after pressing Escape
+	layout.form.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEsc {
+			configPage.home.md.setPage(configPage.home.homePage)
+			return nil
+		}
+		return event
+	})
+
+	// Set up the form items
+	configPage.formItems = createParameterizedFormItems(masterConfig.Schedule.GetParameters(), layout.descriptionBox)
+	layout.mapParameterizedFormItems(configPage.formItems...)
+
+	// Do the initial draw
+	configPage.handleLayoutChanged()
+
+}
+
+// Handle a bulk redraw request
+func (configPage *ScheduleConfigPage) handleLayoutChanged() {
+	configPage.layout.form.Clear(true)
+	configPage.layout.addRelevantFormItems(configPage.formItems)
+	configPage.layout.refresh()
+}
@@ -68,7 +68,6 @@ func (configPage *SmartnodeConfigPage) createContent() {
 	// Set up the form items
 	formItems := createParameterizedFormItems(masterConfig.Smartnode.GetParameters(), layout.descriptionBox)
 	for _, formItem := range formItems {
-		layout.form.AddFormItem(formItem.item)
 		layout.parameters[formItem.item] = formItem
 		if formItem.parameter.ID == config.NetworkID {
 			dropDown := formItem.item.(*DropDown)
@@ -79,6 +78,8 @@ func (configPage *SmartnodeConfigPage) createContent() {
 			})
 		}
 	}
+	layout.addRelevantFormItems(formItems)
+	registerDependentItems(layout, formItems)
 	layout.refresh()
 
 }
@@ -174,6 +174,15 @@ func (layout *standardLayout) addFormItems(params []*parameterizedFormItem) {
 	}
 }
 
+// Add the form items that are relevant to the current configuration to this layout's form
+func (layout *standardLayout) addRelevantFormItems(params []*parameterizedFormItem) {
+	for _, param := range params {
+		if param.parameter.IsRelevant() {
+			layout.form.AddFormItem(param.item)
+		}
+	}
+}
+
 // Add a collection of "common" and "specific" form items to this layout's form, where some of the common
 // items may not be valid and should be excluded
 func (layout *standardLayout) addFormItemsWithCommonParams(commonParams []*parameterizedFormItem, specificParams []*parameterizedFormItem, unsupportedCommonParams []string) {
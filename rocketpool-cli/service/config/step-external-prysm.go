@@ -8,9 +8,8 @@ func createExternalPrysmStep(wiz *wizard, currentStep int, totalSteps int) *text
 
 	// Create the labels
 	httpUrlLabel := wiz.md.Config.ExternalPrysm.HttpUrl.Name
-	jsonRpcUrlLabel := wiz.md.Config.ExternalPrysm.JsonRpcUrl.Name
 
-	helperText := "Please provide the URL of your Prysm client's HTTP API (for example: `http://192.168.1.40:5052`) and the URL of its JSON RPC API (e.g., `http://192.168.1.40:5053`).\n\nNote that if you're running it on the same machine as the Smartnode, you cannot use `localhost` or `127.0.0.1`; you must use your machine's LAN IP address."
+	helperText := "Please provide the URL of your Prysm client's HTTP API (for example: `http://192.168.1.40:5052`). Prysm's validator client will connect to it over the standard Beacon API, so its gRPC port doesn't need to be exposed.\n\nNote that if you're running it on the same machine as the Smartnode, you cannot use `localhost` or `127.0.0.1`; you must use your machine's LAN IP address."
 
 	show := func(modal *textBoxModalLayout) {
 		wiz.md.setPage(modal.page)
@@ -26,7 +25,6 @@ func createExternalPrysmStep(wiz *wizard, currentStep int, totalSteps int) *text
 
 	done := func(text map[string]string) {
 		wiz.md.Config.ExternalPrysm.HttpUrl.Value = text[httpUrlLabel]
-		wiz.md.Config.ExternalPrysm.JsonRpcUrl.Value = text[jsonRpcUrlLabel]
 		wiz.externalGraffitiModal.show()
 	}
 
@@ -41,9 +39,9 @@ func createExternalPrysmStep(wiz *wizard, currentStep int, totalSteps int) *text
 		helperText,
 		70,
 		"Consensus Client (External) > Settings",
-		[]string{httpUrlLabel, jsonRpcUrlLabel},
-		[]int{wiz.md.Config.ExternalPrysm.HttpUrl.MaxLength, wiz.md.Config.ExternalPrysm.JsonRpcUrl.MaxLength},
-		[]string{wiz.md.Config.ExternalPrysm.HttpUrl.Regex, wiz.md.Config.ExternalPrysm.JsonRpcUrl.Regex},
+		[]string{httpUrlLabel},
+		[]int{wiz.md.Config.ExternalPrysm.HttpUrl.MaxLength},
+		[]string{wiz.md.Config.ExternalPrysm.HttpUrl.Regex},
 		show,
 		done,
 		back,
@@ -0,0 +1,279 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/mitchellh/go-homedir"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// Settings
+const (
+	diskUsageHistoryFile   string        = "disk-usage-history.json"
+	diskUsageHistoryWindow time.Duration = 30 * 24 * time.Hour
+	diskUsageMinSampleAge  time.Duration = time.Hour
+	rewardsTreesFolder     string        = "rewards-trees"
+)
+
+// Descriptions of the data each container keeps in its volumes
+var volumeDescriptions = map[string]string{
+	ExecutionContainerSuffix: "Execution client chain data",
+	BeaconContainerSuffix:    "Consensus client database",
+	ValidatorContainerSuffix: "Validator client data",
+	"_prometheus":            "Prometheus metrics",
+	"_grafana":               "Grafana data",
+}
+
+// A disk usage measurement of everything the Smartnode stores
+type diskUsageSample struct {
+	Time  time.Time         `json:"time"`
+	Sizes map[string]uint64 `json:"sizes"`
+}
+
+// An item of the disk usage breakdown
+type diskUsageItem struct {
+	key         string
+	description string
+	size        uint64
+}
+
+// Print the disk usage of each of the Smartnode's volumes and data folders
+func serviceDisk(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the config
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return err
+	}
+	if isNew {
+		return fmt.Errorf("Settings file not found. Please run `rocketpool service config` to set up your Smartnode.")
+	}
+
+	// Measure the Docker volumes
+	items := []diskUsageItem{}
+	usagePath := ""
+	if !cfg.IsNativeMode {
+		volumes, err := rp.GetServiceVolumes(cfg.Smartnode.GetProjectName())
+		if err != nil {
+			return err
+		}
+		for _, volume := range volumes {
+			items = append(items, diskUsageItem{
+				key:         volume.Name,
+				description: getVolumeDescription(cfg, volume),
+				size:        volume.Size,
+			})
+		}
+		usagePath, err = rp.GetDockerRootDir()
+		if err != nil {
+			return err
+		}
+	}
+
+	// Measure the data folder, with the rewards trees broken out since they grow every interval
+	dataPath, err := homedir.Expand(os.ExpandEnv(cfg.Smartnode.GetDataPath()))
+	if err != nil {
+		return fmt.Errorf("error expanding data path [%s]: %w", cfg.Smartnode.GetDataPath(), err)
+	}
+	dataSize, err := getFolderSize(dataPath)
+	if err != nil {
+		fmt.Printf("%sCouldn't measure the data folder %s: %s%s\n", colorYellow, dataPath, err.Error(), colorReset)
+	} else {
+		rewardsSize, err := getFolderSize(filepath.Join(dataPath, rewardsTreesFolder))
+		if err == nil && rewardsSize > 0 {
+			items = append(items, diskUsageItem{
+				key:         rewardsTreesFolder,
+				description: "Rewards trees",
+				size:        rewardsSize,
+			})
+			dataSize -= rewardsSize
+		}
+		items = append(items, diskUsageItem{
+			key:         "data",
+			description: "Smartnode data folder (wallet, keys and other files)",
+			size:        dataSize,
+		})
+	}
+	if usagePath == "" {
+		usagePath = dataPath
+	}
+
+	// Record the measurement and get the one to estimate growth from
+	sample := diskUsageSample{
+		Time:  time.Now(),
+		Sizes: map[string]uint64{},
+	}
+	for _, item := range items {
+		sample.Sizes[item.key] = item.size
+	}
+	historyPath, err := getDiskUsageHistoryPath(c)
+	if err != nil {
+		return err
+	}
+	previous, err := updateDiskUsageHistory(historyPath, sample)
+	if err != nil {
+		fmt.Printf("%sCouldn't update the disk usage history, so growth can't be estimated: %s%s\n\n", colorYellow, err.Error(), colorReset)
+	}
+
+	// Print the breakdown
+	var elapsedDays float64
+	if previous != nil {
+		elapsedDays = sample.Time.Sub(previous.Time).Hours() / 24
+	}
+	total := uint64(0)
+	totalGrowth := float64(0)
+	fmt.Printf("%sDisk usage:%s\n", colorBold, colorReset)
+	for _, item := range items {
+		total += item.size
+		growth := ""
+		if previous != nil {
+			if oldSize, exists := previous.Sizes[item.key]; exists {
+				rate := (float64(item.size) - float64(oldSize)) / elapsedDays
+				totalGrowth += rate
+				growth = formatGrowthRate(rate)
+			}
+		}
+		fmt.Printf("\t%-55s %10s  %s\n", item.description, humanize.Bytes(item.size), growth)
+	}
+	fmt.Printf("\t%-55s %10s  ", "Total", humanize.Bytes(total))
+	if previous != nil {
+		fmt.Println(formatGrowthRate(totalGrowth))
+	} else {
+		fmt.Println()
+	}
+	fmt.Println()
+
+	// Print the free space and how long it will last
+	freeSpace, err := getPartitionFreeSpace(rp, usagePath)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Free space on the disk holding %s: %s\n", usagePath, humanize.Bytes(freeSpace))
+	if previous == nil {
+		fmt.Printf("Growth rates will be shown once this command has been run again at least %s from now; run it regularly for better estimates.\n", diskUsageMinSampleAge)
+	} else if totalGrowth > 0 {
+		daysLeft := float64(freeSpace) / totalGrowth
+		color := colorGreen
+		if daysLeft < 30 {
+			color = colorRed
+		} else if daysLeft < 90 {
+			color = colorYellow
+		}
+		fmt.Printf("%sAt the current rate, the disk will be full in about %.0f days (around %s).%s\n", color, daysLeft, cliutils.FormatDate(time.Now().Add(time.Duration(daysLeft*24)*time.Hour)), colorReset)
+		fmt.Printf("This estimate is based on the growth since %s.\n", cliutils.FormatTime(previous.Time))
+	} else {
+		fmt.Printf("The Smartnode's disk usage hasn't grown since %s.\n", cliutils.FormatTime(previous.Time))
+	}
+	return nil
+
+}
+
+// Get a description of a volume from the containers that mount it
+func getVolumeDescription(cfg *config.RocketPoolConfig, volume rocketpool.ServiceVolume) string {
+	prefix := cfg.Smartnode.GetProjectName()
+	for _, container := range volume.Containers {
+		if description, exists := volumeDescriptions[strings.TrimPrefix(container, prefix)]; exists {
+			return fmt.Sprintf("%s (%s)", description, volume.Name)
+		}
+	}
+	if len(volume.Containers) == 0 {
+		return fmt.Sprintf("%s (unused)", volume.Name)
+	}
+	return volume.Name
+}
+
+// Get the path of the disk usage history file
+func getDiskUsageHistoryPath(c *cli.Context) (string, error) {
+	configPath, err := homedir.Expand(c.GlobalString("config-path"))
+	if err != nil {
+		return "", fmt.Errorf("error expanding config path [%s]: %w", c.GlobalString("config-path"), err)
+	}
+	return filepath.Join(configPath, diskUsageHistoryFile), nil
+}
+
+// Add a sample to the disk usage history, dropping the ones that are too old.
+// Returns the oldest sample that is old enough to estimate growth from, or nil if there isn't one.
+func updateDiskUsageHistory(path string, sample diskUsageSample) (*diskUsageSample, error) {
+
+	// Load the history
+	history := []diskUsageSample{}
+	historyBytes, err := ioutil.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	if err == nil {
+		if err := json.Unmarshal(historyBytes, &history); err != nil {
+			return nil, fmt.Errorf("error parsing %s: %w", path, err)
+		}
+	}
+
+	// Drop the old samples and find the one to compare against
+	var previous *diskUsageSample
+	newHistory := []diskUsageSample{}
+	for i, oldSample := range history {
+		age := sample.Time.Sub(oldSample.Time)
+		if age > diskUsageHistoryWindow {
+			continue
+		}
+		newHistory = append(newHistory, oldSample)
+		if previous == nil && age >= diskUsageMinSampleAge {
+			previous = &history[i]
+		}
+	}
+	newHistory = append(newHistory, sample)
+
+	// Save it
+	historyBytes, err = json.Marshal(newHistory)
+	if err != nil {
+		return previous, fmt.Errorf("error serializing disk usage history: %w", err)
+	}
+	if err := ioutil.WriteFile(path, historyBytes, 0644); err != nil {
+		return previous, err
+	}
+	return previous, nil
+
+}
+
+// Format a growth rate in bytes per day
+func formatGrowthRate(rate float64) string {
+	if rate < 0 {
+		return fmt.Sprintf("-%s/day", humanize.Bytes(uint64(-rate)))
+	}
+	return fmt.Sprintf("+%s/day", humanize.Bytes(uint64(rate)))
+}
+
+// Get the total size of the files in a folder
+func getFolderSize(path string) (uint64, error) {
+	size := uint64(0)
+	err := filepath.Walk(path, func(_ string, info os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsPermission(err) {
+				return nil
+			}
+			return err
+		}
+		if info.Mode().IsRegular() {
+			size += uint64(info.Size())
+		}
+		return nil
+	})
+	return size, err
+}
@@ -0,0 +1,228 @@
+package service
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// The clients that can be diagnosed
+const (
+	doctorTarget_Eth1 string = "eth1"
+	doctorTarget_Eth2 string = "eth2"
+)
+
+// A known failure and how to recognize it in a client's logs
+type logSignature struct {
+	name        string
+	patterns    []*regexp.Regexp
+	explanation string
+
+	// The command that fixes the issue, if there is one, and a function that runs it
+	fixCommand string
+	fix        func(c *cli.Context) error
+
+	// Whether the fix deletes data, so it's never run without the user confirming it
+	destructive bool
+}
+
+// The failure signatures for each client, most severe first
+func getLogSignatures(target string, cfg *config.RocketPoolConfig) []logSignature {
+
+	diskFull := logSignature{
+		name:        "Disk full",
+		patterns:    compilePatterns(`no space left on device`, `disk (is )?full`, `not enough (free )?(disk )?space`),
+		explanation: "The disk holding the chain data is full, so the client can't write new blocks. Free up space before anything else; the client may also have corrupted its database when it ran out.",
+		fixCommand:  "rocketpool service cleanup",
+		fix:         cleanupService,
+	}
+	if target == doctorTarget_Eth1 {
+		ec, _ := cfg.ExecutionClient.Value.(config.ExecutionClient)
+		if ec == config.ExecutionClient_Geth || ec == config.ExecutionClient_Nethermind {
+			diskFull.fixCommand = "rocketpool service prune-eth1"
+			diskFull.fix = pruneExecutionClient
+		}
+	}
+
+	// Geth can often be repaired without a full resync
+	eth1CorruptionFixCommand := "rocketpool service resync-eth1"
+	eth1CorruptionFix := resyncEth1
+	if ec, _ := cfg.ExecutionClient.Value.(config.ExecutionClient); ec == config.ExecutionClient_Geth {
+		eth1CorruptionFixCommand = "rocketpool service repair-eth1"
+		eth1CorruptionFix = repairEth1
+	}
+
+	switch target {
+	case doctorTarget_Eth1:
+		return []logSignature{
+			diskFull,
+			{
+				name: "Corrupt database",
+				patterns: compilePatterns(
+					`database corrupt`, `corrupted`, `corruption`, `missing trie node`, `head state missing`,
+					`bad block.*unknown ancestor`, `rocksdb.*(error|exception)`, `invalid merkle root`,
+				),
+				explanation: "The Execution client's database is damaged, usually because the client was killed or the machine lost power while it was writing. It won't recover by itself, so the chain data has to be deleted and resynced (your fallback client will keep Rocket Pool running meanwhile).",
+				fixCommand:  eth1CorruptionFixCommand,
+				fix:         eth1CorruptionFix,
+			},
+			{
+				name:        "No peers",
+				patterns:    compilePatterns(`peercount=0\b`, `peers:? 0\b`, `\b0 peers`, `looking for peers.*count=0`),
+				explanation: fmt.Sprintf("The Execution client isn't connected to any peers, so it can't sync. Make sure port %v (TCP and UDP) is open in your firewall and forwarded by your router, and that your machine's clock and network connection are working.", cfg.ExecutionCommon.P2pPort.Value),
+				fixCommand:  "rocketpool service config",
+				fix:         configureService,
+			},
+		}
+	case doctorTarget_Eth2:
+		return []logSignature{
+			diskFull,
+			{
+				name: "Bad checkpoint",
+				patterns: compilePatterns(
+					`checkpoint sync.*(fail|error)`, `(fail|error).*checkpoint`, `weak subjectivity.*(mismatch|fail|error)`,
+					`remote.*checkpoint.*(unavailable|invalid)`, `unable to (download|fetch) (the )?(finalized )?state`,
+				),
+				explanation: "The Consensus client couldn't start from its checkpoint sync provider, either because the URL is wrong or unreachable or because it served a state for a different network. Check the Checkpoint Sync URL in the Consensus client settings, then resync the client so it starts from a fresh checkpoint.",
+				fixCommand:  "rocketpool service resync-eth2",
+				fix:         resyncEth2,
+			},
+			{
+				name: "Corrupt database",
+				patterns: compilePatterns(
+					`database corrupt`, `corrupted`, `corruption`, `unable to open database`, `db.*(unclean shutdown|is locked)`,
+					`failed to (load|open) (the )?(database|db)`,
+				),
+				explanation: "The Consensus client's database is damaged, usually because the client was killed or the machine lost power while it was writing. The chain data has to be deleted and resynced; with checkpoint sync this only takes a few minutes.",
+				fixCommand:  "rocketpool service resync-eth2",
+				fix:         resyncEth2,
+			},
+			{
+				name:        "No peers",
+				patterns:    compilePatterns(`peers:? 0\b`, `peer_count: 0\b`, `peers=0\b`, `\b0 peers`, `low peer count`),
+				explanation: fmt.Sprintf("The Consensus client isn't connected to any peers, so it can't follow the chain. Make sure port %v (TCP and UDP) is open in your firewall and forwarded by your router, and that your machine's clock and network connection are working.", cfg.ConsensusCommon.P2pPort.Value),
+				fixCommand:  "rocketpool service config",
+				fix:         configureService,
+			},
+		}
+	}
+	return nil
+
+}
+
+// Inspect a client's recent logs for known failures, explain them, and offer to run the fix
+func serviceDoctor(c *cli.Context, target string) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the config
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return err
+	}
+	if isNew {
+		return fmt.Errorf("Settings file not found. Please run `rocketpool service config` to set up your Smartnode.")
+	}
+
+	// Get the container to inspect
+	var containerSuffix string
+	var mode config.Mode
+	switch target {
+	case doctorTarget_Eth1:
+		containerSuffix = ExecutionContainerSuffix
+		mode = cfg.GetExecutionClientMode()
+	case doctorTarget_Eth2:
+		containerSuffix = BeaconContainerSuffix
+		mode = cfg.GetConsensusClientMode()
+	default:
+		return fmt.Errorf("Unknown client '%s'; valid clients are '%s' and '%s'.", target, doctorTarget_Eth1, doctorTarget_Eth2)
+	}
+	if cfg.IsNativeMode || mode == config.Mode_External {
+		fmt.Printf("Your %s client isn't managed by the Smartnode, so its logs can't be inspected. Check them with the tools for your setup instead.\n", target)
+		return nil
+	}
+	prefix, err := getContainerPrefix(rp)
+	if err != nil {
+		return fmt.Errorf("Error getting container prefix: %w", err)
+	}
+	container := prefix + containerSuffix
+
+	// Get the logs
+	logs, err := rp.GetContainerLogs(container, c.Uint64("lines"))
+	if err != nil {
+		return err
+	}
+	lines := strings.Split(string(logs), "\n")
+	fmt.Printf("Inspecting the last %d lines of the %s logs...\n\n", len(lines), container)
+
+	// Check them for each signature
+	var matched []logSignature
+	matchedLines := map[string]string{}
+	for _, signature := range getLogSignatures(target, cfg) {
+		for i := len(lines) - 1; i >= 0; i-- {
+			if signature.matches(lines[i]) {
+				matched = append(matched, signature)
+				matchedLines[signature.name] = strings.TrimSpace(lines[i])
+				break
+			}
+		}
+	}
+	if len(matched) == 0 {
+		fmt.Printf("%sNo known issues were found in the %s logs.%s\n", colorGreen, container, colorReset)
+		fmt.Printf("If the client still isn't syncing, look through the full logs with `rocketpool service logs %s`.\n", target)
+		return nil
+	}
+
+	// Explain the issues
+	for _, signature := range matched {
+		fmt.Printf("%s=== %s ===%s\n", colorYellow, signature.name, colorReset)
+		fmt.Printf("Matched log line:\n\t%s\n\n", matchedLines[signature.name])
+		fmt.Println(signature.explanation)
+		if signature.fixCommand != "" {
+			fmt.Printf("Suggested fix: `%s`\n", signature.fixCommand)
+		}
+		fmt.Println()
+	}
+
+	// Offer to run the fix for the most severe issue
+	fix := matched[0]
+	if fix.fix == nil {
+		return nil
+	}
+	if !cliutils.Confirm(fmt.Sprintf("Would you like to run `%s` now to fix the %s issue?", fix.fixCommand, strings.ToLower(fix.name))) {
+		fmt.Printf("You can run it yourself later with `%s`.\n", fix.fixCommand)
+		return nil
+	}
+	return fix.fix(c)
+
+}
+
+// Check if a log line matches one of a signature's patterns
+func (s logSignature) matches(line string) bool {
+	for _, pattern := range s.patterns {
+		if pattern.MatchString(line) {
+			return true
+		}
+	}
+	return false
+}
+
+// Compile case-insensitive log patterns
+func compilePatterns(patterns ...string) []*regexp.Regexp {
+	compiled := make([]*regexp.Regexp, 0, len(patterns))
+	for _, pattern := range patterns {
+		compiled = append(compiled, regexp.MustCompile("(?i)"+pattern))
+	}
+	return compiled
+}
@@ -0,0 +1,133 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/clientlogs"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// The events read from one client's logs
+type clientEvents struct {
+	Target    string             `json:"target"`
+	Container string             `json:"container"`
+	Client    string             `json:"client"`
+	Events    []clientlogs.Event `json:"events"`
+}
+
+// Show a condensed list of the sync milestones, peer drops, and errors in the ETH1 and ETH2 clients' recent logs
+func serviceEvents(c *cli.Context, targets []string) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the config
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return err
+	}
+	if isNew {
+		return fmt.Errorf("Settings file not found. Please run `rocketpool service config` to set up your Smartnode.")
+	}
+	if cfg.IsNativeMode {
+		return fmt.Errorf("The clients aren't managed by the Smartnode in Native mode, so their logs can't be read.")
+	}
+	prefix, err := getContainerPrefix(rp)
+	if err != nil {
+		return fmt.Errorf("Error getting container prefix: %w", err)
+	}
+	if len(targets) == 0 {
+		targets = []string{doctorTarget_Eth1, doctorTarget_Eth2}
+	}
+
+	// Read the events from each client
+	results := []clientEvents{}
+	for _, target := range targets {
+		var parser *clientlogs.Parser
+		var containerSuffix string
+		var mode config.Mode
+		switch target {
+		case doctorTarget_Eth1:
+			ec, _ := cfg.ExecutionClient.Value.(config.ExecutionClient)
+			parser = clientlogs.NewExecutionParser(ec)
+			containerSuffix = ExecutionContainerSuffix
+			mode = cfg.GetExecutionClientMode()
+		case doctorTarget_Eth2:
+			parser = clientlogs.NewConsensusParser(cfg.GetSelectedConsensusClient())
+			containerSuffix = BeaconContainerSuffix
+			mode = cfg.GetConsensusClientMode()
+		default:
+			return fmt.Errorf("Unknown client '%s'; valid clients are '%s' and '%s'.", target, doctorTarget_Eth1, doctorTarget_Eth2)
+		}
+		if mode == config.Mode_External {
+			if !cliutils.IsJsonOutput() {
+				fmt.Printf("Your %s client isn't managed by the Smartnode, so its logs can't be read.\n\n", target)
+			}
+			continue
+		}
+
+		container := prefix + containerSuffix
+		logs, err := rp.GetTimestampedContainerLogs(container, c.Uint64("lines"), c.String("since"))
+		if err != nil {
+			return err
+		}
+		results = append(results, clientEvents{
+			Target:    target,
+			Container: container,
+			Client:    parser.Client(),
+			Events:    filterEvents(parser.Parse(logs), c.String("kind")),
+		})
+	}
+
+	// Print the events
+	if cliutils.IsJsonOutput() {
+		return cliutils.PrintJson(results)
+	}
+	for _, result := range results {
+		fmt.Printf("%s=== %s (%s) ===%s\n", colorBold, result.Container, result.Client, colorReset)
+		if len(result.Events) == 0 {
+			fmt.Println("No notable events in the selected logs.")
+			fmt.Println()
+			continue
+		}
+		for _, event := range result.Events {
+			eventColor := colorLightBlue
+			switch event.Kind {
+			case clientlogs.EventKind_PeerDrop:
+				eventColor = colorYellow
+			case clientlogs.EventKind_Error:
+				eventColor = colorRed
+			}
+			repeats := ""
+			if event.Count > 1 {
+				repeats = fmt.Sprintf(" (x%d, since %s)", event.Count, cliutils.FormatTime(event.FirstTime))
+			}
+			fmt.Printf("%s  %s%-5s%s  %s%s\n", cliutils.FormatTime(event.Time), eventColor, event.Kind, colorReset, event.Summary, repeats)
+		}
+		fmt.Println()
+	}
+	return nil
+
+}
+
+// Only keep the events of one kind, if a kind was provided
+func filterEvents(events []clientlogs.Event, kind string) []clientlogs.Event {
+	if kind == "" {
+		return events
+	}
+	filtered := []clientlogs.Event{}
+	for _, event := range events {
+		if string(event.Kind) == kind {
+			filtered = append(filtered, event)
+		}
+	}
+	return filtered
+}
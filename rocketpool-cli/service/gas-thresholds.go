@@ -0,0 +1,54 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// Offer gas thresholds suited to the node once its config has been saved, if it's still using the defaults.
+// Returns the containers that need to be restarted if the recommendation was accepted.
+func offerGasThresholdRecommendation(rp *rocketpool.Client, cfg *config.RocketPoolConfig) []config.ContainerID {
+
+	// Leave thresholds the user has chosen alone
+	for _, param := range []*config.Parameter{&cfg.Smartnode.RplClaimGasThreshold, &cfg.Smartnode.MinipoolStakeGasThreshold} {
+		if param.Value != param.Default[config.Network_All] {
+			return nil
+		}
+	}
+
+	// The recommendation needs the node's services, so skip it quietly if they aren't running yet
+	recommendation, err := rp.GetGasThresholdRecommendation()
+	if err != nil {
+		return nil
+	}
+	fmt.Printf("\nYou're using the default gas thresholds for automatic RPL claims and minipool stakes. Based on your node and recent gas prices:\n\n")
+	if !cliutils.PrintGasThresholdRecommendation(cfg, recommendation) {
+		return nil
+	}
+	fmt.Println()
+	if !cliutils.Confirm("Would you like to use the recommended thresholds?") {
+		fmt.Println("You can get a new recommendation later with `rocketpool node recommend-gas-thresholds`.")
+		return nil
+	}
+	containers := cliutils.ApplyGasThresholdRecommendation(cfg, recommendation)
+	if err := rp.SaveConfig(cfg); err != nil {
+		fmt.Printf("%sWARNING: Couldn't save the recommended thresholds: %s%s\n", colorYellow, err.Error(), colorReset)
+		return nil
+	}
+	fmt.Printf("The recommended thresholds have been saved.\n\n")
+	return containers
+
+}
+
+// Check if a container is in a list
+func containsContainer(containers []config.ContainerID, container config.ContainerID) bool {
+	for _, candidate := range containers {
+		if candidate == container {
+			return true
+		}
+	}
+	return false
+}
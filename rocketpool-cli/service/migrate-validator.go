@@ -0,0 +1,151 @@
+package service
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/mitchellh/go-homedir"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+)
+
+// Settings
+const (
+	slashingProtectionMigrationFile string = "slashing-protection-migration.json"
+	slashingProtectionContainerPath string = "/slashing-protection-migration.json"
+)
+
+// Check if the selected Validator Client is different from the one the previous config used
+func isValidatorClientChanged(previousCfg *config.RocketPoolConfig, cfg *config.RocketPoolConfig) (bool, error) {
+	previousClientConfig, err := previousCfg.GetSelectedConsensusClientConfig()
+	if err != nil {
+		return false, err
+	}
+	clientConfig, err := cfg.GetSelectedConsensusClientConfig()
+	if err != nil {
+		return false, err
+	}
+	previousName, err := getDockerImageName(previousClientConfig.GetValidatorImage())
+	if err != nil {
+		return false, err
+	}
+	name, err := getDockerImageName(clientConfig.GetValidatorImage())
+	if err != nil {
+		return false, err
+	}
+	return previousName != name, nil
+}
+
+// Export the slashing protection history from the Validator Client that is being replaced, removing its keys so it stops validating.
+// The history is saved in the data folder, where `service start` picks it up and imports it into the new client before starting it.
+func exportMigratedSlashingProtection(rp *rocketpool.Client, cfg *config.RocketPoolConfig, currentValidatorName string) error {
+
+	migrationPath, err := getDataFilePath(cfg, slashingProtectionMigrationFile)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(migrationPath); err == nil {
+		return fmt.Errorf("the slashing protection history from a previous client change in %s hasn't been imported yet", migrationPath)
+	}
+
+	fmt.Printf("Exporting the slashing protection history from %s...\n", currentValidatorName)
+	response, err := rp.ExportSlashingProtection()
+	if err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(migrationPath, []byte(response.SlashingProtection), 0600); err != nil {
+		return fmt.Errorf("error saving the slashing protection history: %w\nIt is printed below; please save it and import it with `rocketpool wallet import-slashing-protection` once the new client is running.\n\n%s", err, response.SlashingProtection)
+	}
+	for _, key := range response.Keys {
+		if key.Status != "deleted" && key.Status != "not_active" {
+			fmt.Printf("%sWARNING: %s could not remove validator key %s (%s: %s), so it keeps validating with it until it's stopped.%s\n", colorYellow, currentValidatorName, key.Pubkey.Hex(), key.Status, key.Message, colorReset)
+		}
+	}
+	fmt.Printf("Removed %d validator keys from %s and saved their slashing protection history to %s.\n", len(response.Keys), currentValidatorName, migrationPath)
+	fmt.Printf("If you switch back to %s later, run `rocketpool wallet rebuild` to restore its keys.\n\n", currentValidatorName)
+	return nil
+
+}
+
+// Import the slashing protection history exported from the old Validator Client into the new one, if there is any.
+// This runs the new client's own import tool before the client is started, so it never signs anything without the history;
+// if the import fails, an error is returned and the client must not be started.
+// Once it's imported, the history is kept in the data folder as a backup.
+func importMigratedSlashingProtection(rp *rocketpool.Client, cfg *config.RocketPoolConfig) error {
+
+	if cfg.IsNativeMode {
+		return nil
+	}
+	migrationPath, err := getDataFilePath(cfg, slashingProtectionMigrationFile)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(migrationPath); os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("error checking for the slashing protection history in %s: %w", migrationPath, err)
+	}
+
+	// Get the new client's import tool
+	clientConfig, err := cfg.GetSelectedConsensusClientConfig()
+	if err != nil {
+		return err
+	}
+	entrypoint, args, err := getSlashingProtectionImportCommand(cfg)
+	if err != nil {
+		return fmt.Errorf("%w\nPlease import %s into your Validator Client manually before starting it, then delete the file", err, migrationPath)
+	}
+
+	// Import the history into every Validator Client's database
+	fmt.Println("Importing the slashing protection history from your previous Validator Client into the new one...")
+	for shard := uint64(0); shard < cfg.GetValidatorShardCount(); shard++ {
+		keychainPath := cfg.GetValidatorShardKeychainHostPath(shard)
+		err := rp.RunSlashingProtectionImport(clientConfig.GetValidatorImage(), entrypoint, keychainPath, migrationPath, slashingProtectionContainerPath, args)
+		if err != nil {
+			return fmt.Errorf("error importing the slashing protection history into the Validator Client database in %s: %w\nYour Validator Client has not been started. Please import %s into it manually, then delete the file and run `rocketpool service start` again", keychainPath, err, migrationPath)
+		}
+	}
+	fmt.Printf("%sImported the slashing protection history.%s\n", colorGreen, colorReset)
+
+	backupPath := filepath.Join(filepath.Dir(migrationPath), fmt.Sprintf("slashing-protection-%s.json", time.Now().Format("20060102-150405")))
+	if err := os.Rename(migrationPath, backupPath); err != nil {
+		fmt.Printf("%sWARNING: Couldn't move %s to %s: %s%s\n", colorYellow, migrationPath, backupPath, err.Error(), colorReset)
+	}
+	return nil
+
+}
+
+// Get the entrypoint and arguments of the selected Validator Client's tool for importing a slashing protection interchange file.
+// The paths match the ones the Validator Client container uses for its databases.
+func getSlashingProtectionImportCommand(cfg *config.RocketPoolConfig) (string, []string, error) {
+	network := string(cfg.Smartnode.GetNetwork())
+	switch client := cfg.GetSelectedConsensusClient(); client {
+	case config.ConsensusClient_Lighthouse:
+		return "lighthouse", []string{"account", "validator", "slashing-protection", "import", slashingProtectionContainerPath, "--datadir", "/validators/lighthouse", "--network", network}, nil
+	case config.ConsensusClient_Nimbus:
+		return "/home/user/nimbus-eth2/build/nimbus_beacon_node", []string{"slashingdb", "import", slashingProtectionContainerPath, "--data-dir=/validators/nimbus", "--validators-dir=/validators/nimbus/validators"}, nil
+	case config.ConsensusClient_Prysm:
+		args := []string{"slashing-protection-history", "import", "--datadir=/validators/prysm-non-hd/direct", "--slashing-protection-json-file=" + slashingProtectionContainerPath, "--accept-terms-of-use"}
+		if cfg.Smartnode.GetNetwork() != config.Network_Mainnet {
+			args = append(args, "--"+network)
+		}
+		return "/app/cmd/validator/validator", args, nil
+	case config.ConsensusClient_Teku:
+		return "/opt/teku/bin/teku", []string{"slashing-protection", "import", "--data-path=/validators/teku", "--from=" + slashingProtectionContainerPath}, nil
+	default:
+		return "", nil, fmt.Errorf("the slashing protection history can't be imported automatically into %s", client)
+	}
+}
+
+// Get the host path of a file in the data folder
+func getDataFilePath(cfg *config.RocketPoolConfig, filename string) (string, error) {
+	dataPath, err := homedir.Expand(os.ExpandEnv(cfg.Smartnode.GetDataPath()))
+	if err != nil {
+		return "", fmt.Errorf("error expanding data path [%s]: %w", cfg.Smartnode.GetDataPath(), err)
+	}
+	return filepath.Join(dataPath, filename), nil
+}
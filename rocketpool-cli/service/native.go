@@ -0,0 +1,57 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// Install the systemd services for Native mode
+func installNativeService(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Prompt for confirmation
+	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("The Rocket Pool systemd services will be installed to %s, replacing any that are already there. This requires sudo.\nAre you sure you want to continue?", rocketpool.NativeUnitPath))) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Install the services
+	units, err := rp.InstallNativeServices()
+	if err != nil {
+		return err
+	}
+
+	// Print success message
+	fmt.Println("")
+	fmt.Println("The Rocket Pool systemd services were successfully installed:")
+	hasValidator := false
+	for _, unit := range units {
+		fmt.Printf("\t%s (%s)\n", unit.GetFilename(), unit.Description)
+		if unit.Name == config.NativeValidatorServiceName {
+			hasValidator = true
+		}
+	}
+
+	// Report next steps
+	fmt.Printf("%s\n=== Next Steps ===\n", colorLightBlue)
+	fmt.Println("Run 'rocketpool service start' to start them, or 'rocketpool service stop' to stop them.")
+	if hasValidator {
+		fmt.Printf("Your Validator client is now managed by the %s service, so make sure your VC restart script runs `sudo systemctl restart %s`.\n", config.NativeValidatorServiceName, config.NativeValidatorServiceName)
+	} else {
+		fmt.Println("No VC command is set in the Native settings, so you'll need to keep running your Validator client yourself.")
+	}
+	fmt.Printf("Run 'rocketpool service install --native' again whenever you change the Native settings.%s\n", colorReset)
+	return nil
+
+}
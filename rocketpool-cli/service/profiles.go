@@ -0,0 +1,117 @@
+package service
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/mitchellh/go-homedir"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/utils/rp"
+)
+
+// List the Smartnode configurations managed by this CLI
+func listProfiles(c *cli.Context) error {
+
+	// Get the base config path; when a profile is selected, the config path has already been switched to its folder
+	basePath, err := homedir.Expand(c.GlobalString("config-path"))
+	if err != nil {
+		return fmt.Errorf("error expanding config path [%s]: %w", c.GlobalString("config-path"), err)
+	}
+	if c.GlobalString("profile") != "" {
+		basePath = filepath.Dir(filepath.Dir(basePath))
+	}
+
+	// Print the default configuration
+	fmt.Println("Default configuration:")
+	instances := map[string]*config.RocketPoolConfig{}
+	if cfg := printProfile(basePath); cfg != nil {
+		instances["the default configuration"] = cfg
+	}
+
+	// Print the profiles
+	profilesPath := filepath.Join(basePath, rocketpool.ProfilesDir)
+	profiles, err := ioutil.ReadDir(profilesPath)
+	if os.IsNotExist(err) {
+		profiles = nil
+	} else if err != nil {
+		return fmt.Errorf("error reading the profiles folder [%s]: %w", profilesPath, err)
+	}
+	count := 0
+	for _, profile := range profiles {
+		if !profile.IsDir() {
+			continue
+		}
+		fmt.Printf("\nProfile '%s':\n", profile.Name())
+		if cfg := printProfile(filepath.Join(profilesPath, profile.Name())); cfg != nil {
+			instances[fmt.Sprintf("profile '%s'", profile.Name())] = cfg
+		}
+		count++
+	}
+	if count == 0 {
+		fmt.Printf("\nThere are no profiles yet. Run `rocketpool --profile <name> service install` to create one.\n")
+	}
+
+	printInstanceConflicts(instances)
+	return nil
+
+}
+
+// Warn about configurations that can't run side by side because they share Docker projects or ports
+func printInstanceConflicts(instances map[string]*config.RocketPoolConfig) {
+	names := make([]string, 0, len(instances))
+	for name := range instances {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for i, name := range names {
+		for _, otherName := range names[i+1:] {
+			cfg, otherCfg := instances[name], instances[otherName]
+			if cfg.Smartnode.GetProjectName() == otherCfg.Smartnode.GetProjectName() {
+				fmt.Printf("\n%sWARNING: %s and %s both use the Docker project name '%s', so they will replace each other's containers.%s\n", colorYellow, name, otherName, cfg.Smartnode.GetProjectName(), colorReset)
+			}
+			otherPorts := otherCfg.GetPorts()
+			sharedPorts := []int{}
+			for port := range cfg.GetPorts() {
+				if otherPorts[port] {
+					sharedPorts = append(sharedPorts, int(port))
+				}
+			}
+			if len(sharedPorts) > 0 {
+				sort.Ints(sharedPorts)
+				portList := make([]string, len(sharedPorts))
+				for j, port := range sharedPorts {
+					portList[j] = fmt.Sprint(port)
+				}
+				fmt.Printf("\n%sWARNING: %s and %s both use ports %s, so they can't run at the same time. Change them in one of them with `rocketpool service config`.%s\n", colorYellow, name, otherName, strings.Join(portList, ", "), colorReset)
+			}
+		}
+	}
+}
+
+// Print the details of the configuration in a config folder, returning it if it's been configured
+func printProfile(configPath string) *config.RocketPoolConfig {
+	fmt.Printf("\tPath:         %s\n", configPath)
+	cfg, err := rp.LoadConfigFromFile(filepath.Join(configPath, rocketpool.SettingsFile))
+	if err != nil {
+		fmt.Printf("\t%sCould not load the settings: %s%s\n", colorYellow, err.Error(), colorReset)
+		return nil
+	}
+	if cfg == nil {
+		fmt.Println("\tNot configured yet")
+		return nil
+	}
+	fmt.Printf("\tNetwork:      %s\n", cfg.Smartnode.GetNetwork())
+	fmt.Printf("\tProject name: %s\n", cfg.Smartnode.ProjectName.Value)
+	fmt.Printf("\tData path:    %s\n", cfg.Smartnode.GetDataPath())
+	fmt.Printf("\tEC ports:     %d (HTTP), %d (P2P)\n", cfg.ExecutionCommon.HttpPort.GetUint16OrDefault(cfg.Smartnode.GetNetwork()), cfg.ExecutionCommon.P2pPort.GetUint16OrDefault(cfg.Smartnode.GetNetwork()))
+	fmt.Printf("\tBN ports:     %d (API), %d (P2P)\n", cfg.ConsensusCommon.ApiPort.GetUint16OrDefault(cfg.Smartnode.GetNetwork()), cfg.ConsensusCommon.P2pPort.GetUint16OrDefault(cfg.Smartnode.GetNetwork()))
+	return cfg
+}
@@ -0,0 +1,158 @@
+package service
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// Settings
+const (
+	// Nethermind copies the live state into a new numbered folder next to the current one while it prunes
+	nethermindStateDbPattern string = "/ethclient/nethermind/nethermind_db/*/state/*"
+
+	nethermindPruneCheckInterval = time.Minute
+	nethermindPruneLogTail       = 10000
+)
+
+// The lines Nethermind logs about a full prune
+var (
+	nethermindPruneProgressRegex = regexp.MustCompile(`Full Pruning In Progress: \S+ ([0-9.,]+) mln nodes mirrored`)
+	nethermindPruneFinishedRegex = regexp.MustCompile(`Full Pruning Finished: \S+ ([0-9.,]+) mln nodes mirrored`)
+	nethermindPruneFailedRegex   = regexp.MustCompile(`(?i)full pruning (failed|cancel)`)
+)
+
+// The state of a full prune, according to Nethermind's logs
+type nethermindPruneStatus struct {
+	started       bool
+	finished      bool
+	failed        string
+	nodesMirrored string
+}
+
+// Starts a full prune of Nethermind's database through its admin RPC, then follows its progress until it's done.
+// Unlike Geth, Nethermind keeps running while it prunes, so it doesn't need to be stopped first.
+func pruneNethermind(rp *rocketpool.Client, executionContainerName string) error {
+
+	// Get the size of the state database before pruning, so the remaining time can be estimated
+	initialSizes, err := rp.GetContainerFolderSizes(executionContainerName, nethermindStateDbPattern)
+	if err != nil {
+		fmt.Printf("%sCouldn't get the size of Nethermind's state database, so the remaining time can't be estimated: %s%s\n", colorYellow, err.Error(), colorReset)
+		initialSizes = map[string]uint64{}
+	}
+
+	// Start pruning
+	fmt.Println("Starting the full prune...")
+	startTime := time.Now()
+	err = rp.RunNethermindPruneStarter(executionContainerName)
+	if err != nil {
+		return fmt.Errorf("Error starting the full prune: %w", err)
+	}
+
+	fmt.Printf("\nNethermind is now pruning. Its progress will be shown below every minute; you can press Ctrl+C at any time to stop following it, and pruning will continue in the background.\n")
+	fmt.Printf("You can also follow its progress with `rocketpool service logs eth1`.\n")
+	fmt.Printf("%sNOTE: Restarting Nethermind while it prunes will cancel the prune, and you'll have to start it again.%s\n\n", colorYellow, colorReset)
+
+	return followNethermindPrune(rp, executionContainerName, startTime, initialSizes)
+
+}
+
+// Print the progress of a full prune until Nethermind reports it's done
+func followNethermindPrune(rp *rocketpool.Client, executionContainerName string, startTime time.Time, initialSizes map[string]uint64) error {
+
+	// Include a little of the time before the prune started, in case the clocks don't quite match
+	since := startTime.Add(-time.Minute).UTC().Format(time.RFC3339)
+	for {
+		time.Sleep(nethermindPruneCheckInterval)
+
+		logs, err := rp.GetTimestampedContainerLogs(executionContainerName, nethermindPruneLogTail, since)
+		if err != nil {
+			return fmt.Errorf("Error checking the progress of the full prune: %w", err)
+		}
+		status := parseNethermindPruneStatus(string(logs))
+		elapsed := time.Since(startTime).Round(time.Second)
+
+		switch {
+		case status.failed != "":
+			return fmt.Errorf("Nethermind's full prune didn't complete: %s", status.failed)
+
+		case status.finished:
+			fmt.Printf("%sDone! Nethermind finished pruning after %s, with %s million state nodes kept.%s\n", colorGreen, elapsed, status.nodesMirrored, colorReset)
+			return nil
+
+		case !status.started:
+			fmt.Printf("[%s] Waiting for Nethermind to start pruning...\n", elapsed)
+
+		default:
+			progress := fmt.Sprintf("[%s] Pruning", elapsed)
+			if status.nodesMirrored != "" {
+				progress += fmt.Sprintf(", %s million state nodes copied so far", status.nodesMirrored)
+			}
+			fmt.Printf("%s%s\n", progress, estimateNethermindPruneCompletion(rp, executionContainerName, startTime, initialSizes))
+		}
+	}
+
+}
+
+// Read the state of the latest full prune from Nethermind's logs
+func parseNethermindPruneStatus(logs string) nethermindPruneStatus {
+	status := nethermindPruneStatus{}
+	for _, line := range strings.Split(logs, "\n") {
+		if !strings.Contains(strings.ToLower(line), "full pruning") {
+			continue
+		}
+		status.started = true
+		if matches := nethermindPruneFinishedRegex.FindStringSubmatch(line); matches != nil {
+			status.finished = true
+			status.nodesMirrored = matches[1]
+		} else if matches := nethermindPruneProgressRegex.FindStringSubmatch(line); matches != nil {
+			status.nodesMirrored = matches[1]
+		} else if nethermindPruneFailedRegex.MatchString(line) {
+			status.failed = strings.TrimSpace(line)
+		}
+	}
+	return status
+}
+
+// Estimate when a full prune will be done from how quickly the new state database is growing. The new database only holds the live
+// state, so it ends up smaller than the old one; the estimate is when it would finish copying everything, so pruning should be done by then.
+func estimateNethermindPruneCompletion(rp *rocketpool.Client, executionContainerName string, startTime time.Time, initialSizes map[string]uint64) string {
+
+	var initialSize uint64
+	for _, size := range initialSizes {
+		initialSize += size
+	}
+	if initialSize == 0 {
+		return ""
+	}
+	sizes, err := rp.GetContainerFolderSizes(executionContainerName, nethermindStateDbPattern)
+	if err != nil {
+		return ""
+	}
+
+	// The new database is the folder that wasn't there before pruning
+	var copied uint64
+	for folder, size := range sizes {
+		if _, exists := initialSizes[folder]; !exists {
+			copied += size
+		}
+	}
+	elapsed := time.Since(startTime)
+	if copied == 0 || elapsed <= 0 {
+		return ""
+	}
+	if copied >= initialSize {
+		return fmt.Sprintf(" (%s written); it should be done soon", humanize.IBytes(copied))
+	}
+
+	rate := float64(copied) / elapsed.Seconds()
+	remaining := time.Duration(float64(initialSize-copied)/rate) * time.Second
+	return fmt.Sprintf(" (%s written); it should be done by %s at the latest", humanize.IBytes(copied), cliutils.FormatTime(time.Now().Add(remaining)))
+
+}
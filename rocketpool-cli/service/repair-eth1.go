@@ -0,0 +1,251 @@
+package service
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// Settings
+const (
+	gethIpcPath string = "/ethclient/geth/geth.ipc"
+
+	// The default number of blocks to rewind Geth by when its recent state is missing
+	defaultGethRewindBlocks uint64 = 128
+
+	// An address whose balance is read to check that Geth can still serve the state at its head
+	gethStateProbeAddress string = "0x0000000000000000000000000000000000000000"
+)
+
+// The ways Geth's database can be damaged and how to recover from each, most severe first
+func getGethCorruptionSignatures(rewindBlocks uint64) []logSignature {
+	return []logSignature{
+		{
+			name: "Corrupt database",
+			patterns: compilePatterns(
+				`database corrupt`, `leveldb.*corrupt`, `corrupted (block|header|receipt|freezer)`, `freezer.*(corrupt|mismatch|missing)`,
+				`invalid merkle root`, `bad block.*unknown ancestor`, `fatal: failed to (open|load) database`,
+			),
+			explanation: "Geth's block database or ancient store is damaged, so it can't be repaired in place. The chain data has to be deleted and resynced (your fallback client will keep Rocket Pool running meanwhile).",
+			fixCommand:  "rocketpool service resync-eth1",
+			fix:         resyncEth1,
+			destructive: true,
+		},
+		{
+			name:        "Missing state",
+			patterns:    compilePatterns(`missing trie node`, `head state missing`, `state (is )?not available`, `required historical state unavailable`),
+			explanation: fmt.Sprintf("Geth lost the state for its most recent blocks, usually because it was killed or the machine lost power before it could flush its caches. Rewinding its head by %d blocks makes it re-execute them and rebuild the missing state.", rewindBlocks),
+			fixCommand:  fmt.Sprintf("rocketpool service repair-eth1 --rewind-blocks %d", rewindBlocks),
+			fix: func(c *cli.Context) error {
+				return rewindGeth(c, rewindBlocks)
+			},
+		},
+		{
+			name: "Broken snapshot",
+			patterns: compilePatterns(
+				`snapshot.*(corrupt|missing|not found|mismatch)`, `aborting state snapshot generation`, `failed to (load|journal) (state )?snapshot`,
+				`snapshot extension registration failed`,
+			),
+			explanation: "Geth's state snapshot doesn't match its database. Geth checks the snapshot when it starts and regenerates it in the background if it's broken, so restarting the client is enough; it will keep syncing while the snapshot is rebuilt.",
+			fixCommand:  "rocketpool service repair-eth1",
+			fix:         restartGeth,
+		},
+	}
+}
+
+// Check Geth's logs and state for database corruption, and offer to run the matching recovery (or run it automatically if it doesn't delete any data)
+func repairEth1(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the config
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return err
+	}
+	if isNew {
+		return fmt.Errorf("Settings file not found. Please run `rocketpool service config` to set up your Smartnode.")
+	}
+
+	// Sanity checks
+	if cfg.IsNativeMode || cfg.GetExecutionClientMode() == config.Mode_External {
+		fmt.Println("Your Execution client isn't managed by the Smartnode, so it can't be repaired automatically.")
+		return nil
+	}
+	if ec, _ := cfg.ExecutionClient.Value.(config.ExecutionClient); ec != config.ExecutionClient_Geth {
+		fmt.Printf("You are using %v as your Execution client. Automatic database repair is only available for Geth; use `rocketpool service doctor eth1` instead.\n", cfg.ExecutionClient.Value)
+		return nil
+	}
+	prefix, err := getContainerPrefix(rp)
+	if err != nil {
+		return fmt.Errorf("Error getting container prefix: %w", err)
+	}
+	container := prefix + ExecutionContainerSuffix
+
+	// Check the logs
+	rewindBlocks := c.Uint64("rewind-blocks")
+	if rewindBlocks == 0 {
+		rewindBlocks = defaultGethRewindBlocks
+	}
+	signatures := getGethCorruptionSignatures(rewindBlocks)
+	logs, err := rp.GetContainerLogs(container, c.Uint64("lines"))
+	if err != nil {
+		return err
+	}
+	lines := strings.Split(string(logs), "\n")
+	var matched *logSignature
+	var matchedLine string
+	for i := range signatures {
+		for j := len(lines) - 1; j >= 0; j-- {
+			if signatures[i].matches(lines[j]) {
+				matched = &signatures[i]
+				matchedLine = strings.TrimSpace(lines[j])
+				break
+			}
+		}
+		if matched != nil {
+			break
+		}
+	}
+
+	// Probe the state at the head over RPC if the logs didn't reveal anything
+	if matched == nil {
+		fmt.Println("No corruption was found in Geth's logs; checking whether it can still read its state...")
+		output, err := rp.RunGethConsoleCommand(container, gethIpcPath, fmt.Sprintf("eth.getBalance(\"%s\", \"latest\")", gethStateProbeAddress))
+		if err != nil {
+			fmt.Printf("%sCouldn't query Geth: %s%s\n", colorYellow, err.Error(), colorReset)
+		}
+		for i := range signatures {
+			if signatures[i].matches(output) {
+				matched = &signatures[i]
+				matchedLine = output
+				break
+			}
+		}
+	}
+	if matched == nil {
+		fmt.Printf("%sGeth's database looks healthy.%s\n", colorGreen, colorReset)
+		fmt.Println("If it still isn't syncing, run `rocketpool service doctor eth1` to check for other issues.")
+		return nil
+	}
+
+	// Explain the issue
+	fmt.Printf("%s=== %s ===%s\n", colorYellow, matched.name, colorReset)
+	fmt.Printf("Detected from:\n\t%s\n\n", matchedLine)
+	fmt.Println(matched.explanation)
+	fmt.Println()
+
+	// Run the recovery; a resync deletes the chain data, so it always needs to be confirmed
+	if matched.destructive {
+		if !cliutils.Confirm(fmt.Sprintf("%sThis recovery deletes Geth's chain data and resyncs it from scratch.%s Would you like to run `%s` now?", colorRed, colorReset, matched.fixCommand)) {
+			fmt.Printf("You can recover later with `%s`.\n", matched.fixCommand)
+			return nil
+		}
+		return matched.fix(c)
+	}
+	if c.Bool("auto") {
+		fmt.Printf("Running the recovery automatically (`%s`)...\n", matched.fixCommand)
+		if err := c.Set("yes", "true"); err != nil {
+			return err
+		}
+		return matched.fix(c)
+	}
+	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Would you like to recover from the %s issue now?", strings.ToLower(matched.name)))) {
+		fmt.Printf("You can recover later with `%s`.\n", matched.fixCommand)
+		return nil
+	}
+	return matched.fix(c)
+
+}
+
+// Rewind Geth's head by a number of blocks so it re-executes them and rebuilds their state
+func rewindGeth(c *cli.Context, rewindBlocks uint64) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+	prefix, err := getContainerPrefix(rp)
+	if err != nil {
+		return fmt.Errorf("Error getting container prefix: %w", err)
+	}
+	container := prefix + ExecutionContainerSuffix
+
+	// Get the current head
+	output, err := rp.RunGethConsoleCommand(container, gethIpcPath, "eth.blockNumber")
+	if err != nil {
+		return err
+	}
+	head, err := strconv.ParseUint(output, 10, 64)
+	if err != nil {
+		return fmt.Errorf("Unexpected output getting Geth's head block: %s", output)
+	}
+	if head <= rewindBlocks {
+		return fmt.Errorf("Geth is only at block %d, so it can't be rewound by %d blocks; resync it with `rocketpool service resync-eth1` instead.", head, rewindBlocks)
+	}
+	target := head - rewindBlocks
+
+	// Rewind it
+	fmt.Printf("Rewinding Geth from block %d to block %d...\n", head, target)
+	output, err = rp.RunGethConsoleCommand(container, gethIpcPath, fmt.Sprintf("debug.setHead(\"0x%x\")", target))
+	if err != nil {
+		return err
+	}
+	if output != "null" && output != "" {
+		return fmt.Errorf("Unexpected output rewinding Geth: %s", output)
+	}
+	fmt.Printf("\nDone! Geth is re-executing the blocks after %d. You can follow its progress with `rocketpool service logs eth1`.\n", target)
+	fmt.Println("If the state is still missing once it catches up, resync it with `rocketpool service resync-eth1`.")
+	return nil
+
+}
+
+// Restart Geth so it checks its snapshot and regenerates it if it's broken
+func restartGeth(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+	prefix, err := getContainerPrefix(rp)
+	if err != nil {
+		return fmt.Errorf("Error getting container prefix: %w", err)
+	}
+	container := prefix + ExecutionContainerSuffix
+
+	// Restart it
+	fmt.Printf("Stopping %s...\n", container)
+	result, err := rp.StopContainer(container)
+	if err != nil {
+		return fmt.Errorf("Error stopping main ETH1 container: %w", err)
+	}
+	if result != container {
+		return fmt.Errorf("Unexpected output while stopping main ETH1 container: %s", result)
+	}
+	fmt.Printf("Starting %s...\n", container)
+	result, err = rp.StartContainer(container)
+	if err != nil {
+		return fmt.Errorf("Error starting main ETH1 container: %w", err)
+	}
+	if result != container {
+		return fmt.Errorf("Unexpected output while starting main ETH1 container: %s", result)
+	}
+	fmt.Printf("\nDone! Geth will regenerate its snapshot in the background. You can follow its progress with `rocketpool service logs eth1`.\n")
+	return nil
+
+}
@@ -1,7 +1,11 @@
 package service
 
 import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"regexp"
@@ -18,6 +22,7 @@ import (
 	cliconfig "github.com/rocket-pool/smartnode/rocketpool-cli/service/config"
 	"github.com/rocket-pool/smartnode/shared"
 	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/grafana"
 	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
 	"github.com/shirou/gopsutil/v3/disk"
@@ -70,8 +75,17 @@ func installService(c *cli.Context) error {
 	}
 	defer rp.Close()
 
+	// Install profiles into their own folder unless a path was given
+	installPath := c.String("path")
+	if installPath == "" && rp.GetProfile() != "" {
+		installPath, err = homedir.Expand(c.GlobalString("config-path"))
+		if err != nil {
+			return fmt.Errorf("error expanding config path [%s]: %w", c.GlobalString("config-path"), err)
+		}
+	}
+
 	// Install service
-	err = rp.InstallService(c.Bool("verbose"), c.Bool("no-deps"), c.String("network"), c.String("version"), c.String("path"))
+	err = rp.InstallService(c.Bool("verbose"), c.Bool("no-deps"), c.String("network"), c.String("version"), installPath)
 	if err != nil {
 		return err
 	}
@@ -203,14 +217,88 @@ func serviceStatus(c *cli.Context) error {
 		return err
 	}
 
+	// Native mode runs the services with systemd instead of Docker
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return err
+	}
+	if !isNew && cfg.IsNativeMode {
+		return rp.PrintNativeServiceStatus()
+	}
+
 	// Print service status
-	return rp.PrintServiceStatus(getComposeFiles(c))
+	composeFiles := getComposeFiles(c)
+	if err := rp.PrintServiceStatus(composeFiles); err != nil {
+		return err
+	}
+
+	// Print the images the containers are running
+	if isNew {
+		return nil
+	}
+	images, err := rp.GetServiceImageStatus(composeFiles, fmt.Sprint(cfg.Smartnode.ProjectName.Value))
+	if err != nil {
+		return err
+	}
+	fmt.Println()
+	printServiceImages(images)
+	return nil
+
+}
+
+// Print the image and digest of each service container, flagging containers that aren't running the image their service is configured to use
+func printServiceImages(images []rocketpool.ServiceImageStatus) {
+
+	fmt.Println("Container images:")
+	drifted := false
+	for _, image := range images {
+		fmt.Printf("%s (%s)\n", image.Service, image.Container)
+		if image.ContainerImage == "" {
+			fmt.Printf("\tImage:  %s\n", image.ConfiguredImage)
+			fmt.Printf("\t%sThe container hasn't been created yet.%s\n", colorYellow, colorReset)
+			drifted = true
+			continue
+		}
+		fmt.Printf("\tImage:  %s\n", image.ContainerImage)
+		if image.ContainerDigest != "" {
+			fmt.Printf("\tDigest: %s\n", image.ContainerDigest)
+		} else {
+			fmt.Printf("\tDigest: none (local image %s)\n", image.ContainerImageID)
+		}
+
+		// Check for drift
+		switch {
+		case image.ContainerImage != image.ConfiguredImage:
+			fmt.Printf("\t%sThe container is running %s, but the service is configured to use %s.%s\n", colorYellow, image.ContainerImage, image.ConfiguredImage, colorReset)
+			drifted = true
+		case image.LocalImageID == "":
+			fmt.Printf("\t%sThe image %s is no longer present locally.%s\n", colorYellow, image.ConfiguredImage, colorReset)
+			drifted = true
+		case image.LocalImageID != image.ContainerImageID:
+			fmt.Printf("\t%sThe image %s has been pulled again since the container was created, so the container is running a stale copy of it.%s\n", colorYellow, image.ConfiguredImage, colorReset)
+			drifted = true
+		}
+	}
+
+	if drifted {
+		fmt.Printf("\n%sSome containers aren't running the images they're configured to use. Run `rocketpool service start` to recreate them.%s\n", colorYellow, colorReset)
+	}
 
 }
 
 // Configure the service
 func configureService(c *cli.Context) error {
 
+	// Print the schema and exit
+	if c.Bool("export-schema") {
+		return exportConfigSchema()
+	}
+
+	// Print the rendered compose files and exit
+	if c.Bool("preview-compose") {
+		return previewComposeFiles(c)
+	}
+
 	// Make sure the config directory exists first
 	configPath := c.GlobalString("config-path")
 	path, err := homedir.Expand(configPath)
@@ -219,6 +307,10 @@ func configureService(c *cli.Context) error {
 	}
 	_, err = os.Stat(path)
 	if os.IsNotExist(err) {
+		if profile := c.GlobalString("profile"); profile != "" {
+			fmt.Printf("%sThe directory for profile '%s' [%s] does not exist.\nPlease run `rocketpool --profile %s service install` to install the Smartnode for it.%s\n", colorYellow, profile, path, profile, colorReset)
+			return nil
+		}
 		fmt.Printf("%sYour configured Rocket Pool directory of [%s] does not exist.\nPlease follow the instructions at https://docs.rocketpool.net/guides/node/docker.html to install the Smartnode.%s\n", colorYellow, path, colorReset)
 		return nil
 	}
@@ -267,6 +359,11 @@ func configureService(c *cli.Context) error {
 		}
 	}
 
+	// Apply the provided settings file and exit
+	if c.IsSet("file") {
+		return configureFromFile(c, rp, cfg, isNew)
+	}
+
 	// Save the config and exit in headless mode
 	if c.NumFlags() > 0 {
 		err := configureHeadless(c, cfg)
@@ -291,6 +388,7 @@ func configureService(c *cli.Context) error {
 		// Save the config
 		rp.SaveConfig(md.Config)
 		fmt.Println("Your changes have been saved!")
+		annotateDashboards(md.Config, "Smartnode settings changed", "config")
 
 		// Exit immediately if we're in native mode
 		if isNative {
@@ -319,6 +417,15 @@ func configureService(c *cli.Context) error {
 			return nil
 		}
 
+		// Offer gas thresholds suited to the node
+		if !isNew {
+			for _, container := range offerGasThresholdRecommendation(rp, md.Config) {
+				if !containsContainer(md.ContainersToRestart, container) {
+					md.ContainersToRestart = append(md.ContainersToRestart, container)
+				}
+			}
+		}
+
 		// Query for service start if this is a new installation
 		if isNew {
 			if !cliutils.Confirm("Would you like to start the Smartnode services automatically now?") {
@@ -340,14 +447,29 @@ func configureService(c *cli.Context) error {
 				return nil
 			}
 
+			// Export the slashing protection history from the old Validator Client before it's stopped
+			validatorChanged, err := isValidatorClientChanged(md.PreviousConfig, md.Config)
+			if err != nil {
+				fmt.Printf("%sWARNING: Couldn't check if your Validator Client has changed: %s%s\n", colorYellow, err.Error(), colorReset)
+			} else if validatorChanged {
+				previousClientConfig, _ := md.PreviousConfig.GetSelectedConsensusClientConfig()
+				previousName, _ := getDockerImageName(previousClientConfig.GetValidatorImage())
+				if err := exportMigratedSlashingProtection(rp, md.PreviousConfig, previousName); err != nil {
+					fmt.Printf("%sWARNING: Couldn't export the slashing protection history from %s: %s\nYour new Validator Client will start without it.%s\n\n", colorYellow, previousName, err.Error(), colorReset)
+				}
+			}
+
 			fmt.Println()
+			restarted := []string{}
 			for _, container := range md.ContainersToRestart {
 				fullName := fmt.Sprintf("%s_%s", prefix, container)
 				fmt.Printf("Stopping %s... ", fullName)
 				rp.StopContainer(fullName)
 				fmt.Print("done!\n")
+				restarted = append(restarted, string(container))
 			}
 
+			annotateDashboards(md.Config, fmt.Sprintf("Restarting containers: %s", strings.Join(restarted, ", ")), "restart")
 			fmt.Println()
 			fmt.Println("Applying changes and restarting containers...")
 			return startService(c, true)
@@ -385,6 +507,105 @@ func configureHeadless(c *cli.Context, cfg *config.RocketPoolConfig) error {
 
 }
 
+// Print the compose files rendered from the current settings
+func previewComposeFiles(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Render the compose files
+	files, err := rp.PreviewComposeFiles()
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		fmt.Printf("%s# ===== %s =====%s\n", colorGreen, file.Filename, colorReset)
+		fmt.Println(strings.TrimRight(string(file.Contents), "\n"))
+		fmt.Println()
+	}
+	return nil
+
+}
+
+// Prints the schema of every setting
+func exportConfigSchema() error {
+	schema := config.NewRocketPoolConfig("", false).GetSchema()
+	bytes, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error serializing config schema: %w", err)
+	}
+	fmt.Println(string(bytes))
+	return nil
+}
+
+// Replaces the configuration with the one in the provided settings file
+func configureFromFile(c *cli.Context, rp *rocketpool.Client, cfg *config.RocketPoolConfig, isNew bool) error {
+
+	// Load the new settings, keeping this installation's directory and mode
+	newCfg, err := config.ImportFromFile(c.String("file"), cfg.RocketPoolDirectory, c.GlobalIsSet("daemon-path"))
+	if err != nil {
+		return err
+	}
+
+	// Validate the new settings
+	errors := newCfg.Validate()
+	if len(errors) > 0 {
+		fmt.Printf("%sThe provided settings file has the following errors:%s\n", colorRed, colorReset)
+		for _, err := range errors {
+			fmt.Printf("\t%s\n", err)
+		}
+		return fmt.Errorf("the settings file is invalid, so the configuration has not been changed")
+	}
+
+	// Print the changes
+	changedSettings, totalAffectedContainers, changeNetworks := newCfg.GetChanges(cfg)
+	hasChanges := false
+	for categoryName, changedSettingsList := range changedSettings {
+		if len(changedSettingsList) > 0 {
+			hasChanges = true
+			fmt.Println(categoryName)
+			for _, pair := range changedSettingsList {
+				fmt.Printf("\t%s: %s => %s\n", pair.Name, pair.OldValue, pair.NewValue)
+			}
+			fmt.Println()
+		}
+	}
+	if !hasChanges && !isNew {
+		fmt.Println("The settings file matches your current configuration; nothing to do.")
+		return nil
+	}
+
+	// Network changes delete the chain data and wallet, so they need to be confirmed in the interactive configuration
+	if changeNetworks && !isNew {
+		return fmt.Errorf("the settings file changes the network from %v to %v; please use `rocketpool service config` without `--file` to change networks", cfg.Smartnode.GetNetwork(), newCfg.Smartnode.GetNetwork())
+	}
+
+	// Save the config
+	err = rp.SaveConfig(newCfg)
+	if err != nil {
+		return fmt.Errorf("error saving config: %w", err)
+	}
+	fmt.Println("Your changes have been saved!")
+
+	// Print the containers to restart
+	if isNew {
+		fmt.Println("Please run `rocketpool service start` when you are ready to launch.")
+	} else if len(totalAffectedContainers) > 0 {
+		prefix := newCfg.Smartnode.GetProjectName()
+		fmt.Println("The following containers must be restarted for the changes to take effect:")
+		for container := range totalAffectedContainers {
+			fmt.Printf("\t%s_%s\n", prefix, container)
+		}
+		fmt.Println("Please run `rocketpool service start` when you are ready to apply the changes.")
+	}
+	return nil
+
+}
+
 // Updates a config parameter from a CLI flag
 func updateConfigParamFromCliArg(c *cli.Context, sectionName string, param *config.Parameter, cfg *config.RocketPoolConfig) error {
 
@@ -551,13 +772,34 @@ func startService(c *cli.Context, ignoreConfigSuggestion bool) error {
 		}
 	}
 
+	// Native mode runs the services with systemd instead of Docker
+	if cfg.IsNativeMode {
+		err = rp.StartNativeServices()
+		if err != nil {
+			return err
+		}
+		return rp.RemoveUpgradeFlagFile()
+	}
+
 	// Update the Prometheus template with the assigned ports
 	metricsEnabled := cfg.EnableMetrics.Value.(bool)
 	if metricsEnabled {
-		err := rp.UpdatePrometheusConfiguration(cfg.GenerateEnvironmentVariables())
+		err := rp.UpdatePrometheusConfiguration(cfg)
 		if err != nil {
 			return err
 		}
+		if cfg.EnableAlerting.Value == true {
+			err = rp.UpdateAlertingConfiguration(cfg)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	// Make sure the Validator Client has a token for its Keymanager API
+	err = createKeymanagerToken(cfg)
+	if err != nil {
+		return err
 	}
 
 	if !c.Bool("ignore-slash-timer") {
@@ -579,8 +821,8 @@ func startService(c *cli.Context, ignoreConfigSuggestion bool) error {
 	}
 
 	// Force a delay if using Teku and upgrading from v1.3.0 or below because of the slashing protection DB migration in v1.3.1+
-	isLocalTeku := (cfg.ConsensusClientMode.Value.(config.Mode) == config.Mode_Local && cfg.ConsensusClient.Value.(config.ConsensusClient) == config.ConsensusClient_Teku)
-	isExternalTeku := (cfg.ConsensusClientMode.Value.(config.Mode) == config.Mode_External && cfg.ExternalConsensusClient.Value.(config.ConsensusClient) == config.ConsensusClient_Teku)
+	isLocalTeku := (cfg.GetConsensusClientMode() == config.Mode_Local && cfg.ConsensusClient.Value.(config.ConsensusClient) == config.ConsensusClient_Teku)
+	isExternalTeku := (cfg.GetConsensusClientMode() == config.Mode_External && cfg.ExternalConsensusClient.Value.(config.ConsensusClient) == config.ConsensusClient_Teku)
 	if isUpdate && !isNew && !cfg.IsNativeMode && (isLocalTeku || isExternalTeku) && !c.Bool("ignore-slash-timer") {
 		previousVersion := "0.0.0"
 		backupCfg, err := rp.LoadBackupConfig()
@@ -614,24 +856,109 @@ func startService(c *cli.Context, ignoreConfigSuggestion bool) error {
 	}
 
 	// Warn about light ECs
-	if cfg.ExecutionClientMode.Value.(config.Mode) == config.Mode_Local && (cfg.ExecutionClient.Value.(config.ExecutionClient) == config.ExecutionClient_Infura || cfg.ExecutionClient.Value.(config.ExecutionClient) == config.ExecutionClient_Pocket) {
+	if cfg.GetExecutionClientMode() == config.Mode_Local && (cfg.ExecutionClient.Value.(config.ExecutionClient) == config.ExecutionClient_Infura || cfg.ExecutionClient.Value.(config.ExecutionClient) == config.ExecutionClient_Pocket) {
 		fmt.Printf("==========\n%sWARNING: you are using a light client (Infura or Pocket) as your primary Execution client.\nLight clients are NOT COMPATIBLE with the upcoming Ethereum Merge, and will be removed in a future version of the Smartnode.\n\nPlease switch to a full client such as Geth, Nethermind, or Besu as soon as possible.\n\nThis can be done via the `rocketpool service config` Terminal UI by simply selecting a different client from the Execution Client drop-down menu in the Execution Client (ETH1) section.%s\n==========\n\n", colorRed, colorReset)
 	}
-	if cfg.UseFallbackExecutionClient.Value == true && cfg.FallbackExecutionClientMode.Value.(config.Mode) == config.Mode_Local && (cfg.FallbackExecutionClient.Value.(config.ExecutionClient) == config.ExecutionClient_Infura || cfg.FallbackExecutionClient.Value.(config.ExecutionClient) == config.ExecutionClient_Pocket) {
+	if cfg.UseFallbackExecutionClient.Value == true && cfg.GetFallbackExecutionClientMode() == config.Mode_Local && (cfg.FallbackExecutionClient.Value.(config.ExecutionClient) == config.ExecutionClient_Infura || cfg.FallbackExecutionClient.Value.(config.ExecutionClient) == config.ExecutionClient_Pocket) {
 		fmt.Printf("==========\n%sWARNING: you are using a light client (Infura or Pocket) as your fallback Execution client.\nLight clients are NOT COMPATIBLE with the upcoming Ethereum Merge, and will be removed in a future version of the Smartnode.\n\nIf you wish to continue using a fallback Execution client after light clients have been removed, you will need to run one on a separate machine and use Externally Managed mode for your fallback Execution client in the `rocketpool service config` Terminal UI.%s\n==========\n\n", colorRed, colorReset)
 	}
 
+	// Import the slashing protection history from the previous Validator Client into the new one before it starts
+	err = importMigratedSlashingProtection(rp, cfg)
+	if err != nil {
+		return err
+	}
+
 	// Start service
-	err = rp.StartService(getComposeFiles(c))
+	err = startContainers(c, rp, cfg, isUpdate)
 	if err != nil {
 		return err
 	}
+	annotateDashboards(cfg, "Smartnode services started", "start")
 
 	// Remove the upgrade flag if it's there
 	return rp.RemoveUpgradeFlagFile()
 
 }
 
+// Start the service's containers, only recreating the ones affected by the changes since it was last started when possible.
+// A full `docker compose up` can recreate unrelated containers when their definitions render slightly differently, so it's
+// only used when the set of containers changes, after upgrades, with custom compose files, or when explicitly requested.
+func startContainers(c *cli.Context, rp *rocketpool.Client, cfg *config.RocketPoolConfig, isUpdate bool) error {
+
+	containers, err := getContainersToStart(c, rp, cfg, isUpdate)
+	if err != nil {
+		fmt.Printf("%sCouldn't determine which containers need to be restarted, so all of them will be updated: %s%s\n", colorYellow, err.Error(), colorReset)
+	}
+
+	switch {
+	case containers == nil:
+		err = rp.StartService(getComposeFiles(c))
+	case len(containers) == 0:
+		fmt.Println("All of the Smartnode's containers are already running with your current settings.")
+	default:
+		fmt.Printf("Starting %s...\n", strings.Join(containers, ", "))
+		err = rp.StartServiceContainers(getComposeFiles(c), containers)
+	}
+	if err != nil {
+		return err
+	}
+
+	// Record the settings the containers are running with
+	err = rp.SaveAppliedConfig(cfg)
+	if err != nil {
+		fmt.Printf("%sWarning: couldn't record the settings the service was started with, so the next start will update all of the containers: %s%s\n", colorYellow, err.Error(), colorReset)
+	}
+	return nil
+
+}
+
+// Get the containers that need to be started or recreated for the current settings to take effect, or nil if they all need to be updated
+func getContainersToStart(c *cli.Context, rp *rocketpool.Client, cfg *config.RocketPoolConfig, isUpdate bool) ([]string, error) {
+
+	if c.Bool("full") || isUpdate || len(getComposeFiles(c)) > 0 {
+		return nil, nil
+	}
+
+	// Get the settings the service was last started with
+	appliedCfg, err := rp.LoadAppliedConfig()
+	if err != nil {
+		return nil, err
+	}
+	if appliedCfg == nil {
+		return nil, nil
+	}
+
+	// Containers being added or removed need a full update so orphans are cleaned up
+	_, affectedContainers, changeNetworks := cfg.GetChanges(appliedCfg)
+	composeContainers := cfg.GetComposeContainers()
+	appliedContainers := appliedCfg.GetComposeContainers()
+	if changeNetworks || cfg.Smartnode.GetProjectName() != appliedCfg.Smartnode.GetProjectName() || len(composeContainers) != len(appliedContainers) {
+		return nil, nil
+	}
+	for i, container := range composeContainers {
+		if appliedContainers[i] != container {
+			return nil, nil
+		}
+	}
+
+	// Start the containers with changed settings, along with any that aren't running
+	prefix := cfg.Smartnode.GetProjectName()
+	containers := []string{}
+	for _, container := range composeContainers {
+		if affectedContainers[config.GetComposeContainerID(container)] {
+			containers = append(containers, container)
+			continue
+		}
+		status, err := rp.GetDockerStatus(fmt.Sprintf("%s_%s", prefix, container))
+		if err != nil || status != "running" {
+			containers = append(containers, container)
+		}
+	}
+	return containers, nil
+
+}
+
 // Versions prior to v1.3.1 didn't preserve Teku's slashing DB, so force a delay when upgrading to ensure the user doesn't get slashed by accident
 func handleTekuSlashProtectionMigrationDelay(rp *rocketpool.Client, cfg *config.RocketPoolConfig) error {
 
@@ -758,6 +1085,13 @@ func checkForValidatorChange(rp *rocketpool.Client, cfg *config.RocketPoolConfig
 			return fmt.Errorf("Error getting container [%s] status: %w", validatorDutyContainerName, err)
 		}
 		if validatorFinishTime == zeroTime || status == "running" {
+			// Export the slashing protection history while the old client is still running
+			if status == "running" {
+				if err := exportMigratedSlashingProtection(rp, cfg, currentValidatorName); err != nil {
+					fmt.Printf("%sWARNING: Couldn't export the slashing protection history from %s: %s\nYour new Validator Client will start without it.%s\n\n", colorYellow, currentValidatorName, err.Error(), colorReset)
+				}
+			}
+
 			fmt.Printf("%sValidator is currently running, stopping it...%s\n", colorYellow, colorReset)
 			response, err := rp.StopContainer(validatorDutyContainerName)
 			validatorFinishTime = time.Now()
@@ -867,7 +1201,7 @@ func getContainerPrefix(rp *rocketpool.Client) (string, error) {
 		return "", fmt.Errorf("Settings file not found. Please run `rocketpool service config` to set up your Smartnode.")
 	}
 
-	return cfg.Smartnode.ProjectName.Value.(string), nil
+	return cfg.Smartnode.GetProjectName(), nil
 }
 
 // Prepares the execution client for pruning
@@ -890,7 +1224,7 @@ func pruneExecutionClient(c *cli.Context) error {
 	}
 
 	// Sanity checks
-	if cfg.ExecutionClientMode.Value.(config.Mode) == config.Mode_External {
+	if cfg.GetExecutionClientMode() == config.Mode_External {
 		fmt.Println("You are using an externally managed Execution client.\nThe Smartnode cannot prune it for you.")
 		return nil
 	}
@@ -902,21 +1236,29 @@ func pruneExecutionClient(c *cli.Context) error {
 	case config.ExecutionClient_Besu:
 		fmt.Println("You are using Besu as your Execution client.\nBesu does not need pruning.")
 		return nil
+	case config.ExecutionClient_Erigon:
+		fmt.Println("You are using Erigon as your Execution client.\nErigon prunes itself automatically based on its Pruning Mode setting.")
+		return nil
 	}
 
-	fmt.Println("This will shut down your main execution client and prune its database, freeing up disk space.")
-	fmt.Println("Once pruning is complete, your execution client will restart automatically.\n")
-
-	if cfg.UseFallbackExecutionClient.Value == false {
-		fmt.Printf("%sYou do not have a fallback execution client configured.\nYou will continue attesting while it prunes, but block proposals and most of Rocket Pool's commands will not work.\nPlease configure a fallback client with `rocketpool service config` before running this.%s\n", colorRed, colorReset)
+	if selectedEc == config.ExecutionClient_Nethermind {
+		fmt.Println("This will start a full prune of Nethermind's database, freeing up disk space.")
+		fmt.Printf("Nethermind keeps running while it prunes, though it may fall behind the chain on slower machines until it's done.\n\n")
 	} else {
-		var fallbackClientName string
-		if cfg.FallbackExecutionClientMode.Value.(config.Mode) == config.Mode_External {
-			fallbackClientName = cfg.FallbackExternalExecution.HttpUrl.Value.(string)
+		fmt.Println("This will shut down your main execution client and prune its database, freeing up disk space.")
+		fmt.Println("Once pruning is complete, your execution client will restart automatically.\n")
+
+		if cfg.UseFallbackExecutionClient.Value == false {
+			fmt.Printf("%sYou do not have a fallback execution client configured.\nYou will continue attesting while it prunes, but block proposals and most of Rocket Pool's commands will not work.\nPlease configure a fallback client with `rocketpool service config` before running this.%s\n", colorRed, colorReset)
 		} else {
-			fallbackClientName = fmt.Sprint(cfg.FallbackExecutionClient.Value.(config.ExecutionClient))
+			var fallbackClientName string
+			if cfg.GetFallbackExecutionClientMode() == config.Mode_External {
+				fallbackClientName = cfg.FallbackExternalExecution.GetHttpUrl()
+			} else {
+				fallbackClientName = fmt.Sprint(cfg.FallbackExecutionClient.Value.(config.ExecutionClient))
+			}
+			fmt.Printf("You have a fallback execution client configured (%s). Rocket Pool (and your consensus client) will use that while the main client is pruning.\n", fallbackClientName)
 		}
-		fmt.Printf("You have a fallback execution client configured (%s). Rocket Pool (and your consensus client) will use that while the main client is pruning.\n", fallbackClientName)
 	}
 
 	// Get the container prefix
@@ -976,6 +1318,11 @@ func pruneExecutionClient(c *cli.Context) error {
 		fmt.Printf("Your disk has %s free, which is enough to prune.\n", freeSpaceHuman)
 	}
 
+	// Nethermind prunes while it's running
+	if selectedEc == config.ExecutionClient_Nethermind {
+		return pruneNethermind(rp, executionContainerName)
+	}
+
 	fmt.Printf("Stopping %s...\n", executionContainerName)
 	result, err := rp.StopContainer(executionContainerName)
 	if err != nil {
@@ -1008,13 +1355,6 @@ func pruneExecutionClient(c *cli.Context) error {
 		return fmt.Errorf("Unexpected output while starting main execution client: %s", result)
 	}
 
-	if selectedEc == config.ExecutionClient_Nethermind {
-		err = rp.RunNethermindPruneStarter(executionContainerName)
-		if err != nil {
-			return err
-		}
-	}
-
 	fmt.Printf("\nDone! Your main execution client is now pruning. You can follow its progress with `rocketpool service logs eth1`.\n")
 	fmt.Println("Once it's done, it will restart automatically and resume normal operation.")
 
@@ -1055,6 +1395,10 @@ func pauseService(c *cli.Context) error {
 	}
 
 	// Pause service
+	if cfg.IsNativeMode {
+		return rp.StopNativeServices()
+	}
+	annotateDashboards(cfg, "Smartnode services paused", "pause")
 	return rp.PauseService(getComposeFiles(c))
 
 }
@@ -1166,7 +1510,7 @@ func serviceVersion(c *cli.Context) error {
 
 	// Get the execution client string
 	var eth1ClientString string
-	eth1ClientMode := cfg.ExecutionClientMode.Value.(config.Mode)
+	eth1ClientMode := cfg.GetExecutionClientMode()
 	switch eth1ClientMode {
 	case config.Mode_Local:
 		eth1Client := cfg.ExecutionClient.Value.(config.ExecutionClient)
@@ -1178,6 +1522,8 @@ func serviceVersion(c *cli.Context) error {
 			eth1ClientString = fmt.Sprintf(format, "Nethermind", cfg.Nethermind.ContainerTag.Value.(string))
 		case config.ExecutionClient_Besu:
 			eth1ClientString = fmt.Sprintf(format, "Besu", cfg.Besu.ContainerTag.Value.(string))
+		case config.ExecutionClient_Erigon:
+			eth1ClientString = fmt.Sprintf(format, "Erigon", cfg.Erigon.ContainerTag.Value.(string))
 		case config.ExecutionClient_Infura:
 			eth1ClientString = fmt.Sprintf(format, "Infura", cfg.Smartnode.GetPowProxyContainerTag())
 		case config.ExecutionClient_Pocket:
@@ -1195,7 +1541,7 @@ func serviceVersion(c *cli.Context) error {
 
 	// Get the consensus client string
 	var eth2ClientString string
-	eth2ClientMode := cfg.ConsensusClientMode.Value.(config.Mode)
+	eth2ClientMode := cfg.GetConsensusClientMode()
 	switch eth2ClientMode {
 	case config.Mode_Local:
 		eth2Client := cfg.ConsensusClient.Value.(config.ConsensusClient)
@@ -1373,7 +1719,7 @@ func resyncEth2(c *cli.Context) error {
 	// Get the parameters that the selected client doesn't support
 	var unsupportedParams []string
 	var clientName string
-	eth2ClientMode := cfg.ConsensusClientMode.Value.(config.Mode)
+	eth2ClientMode := cfg.GetConsensusClientMode()
 	switch eth2ClientMode {
 	case config.Mode_Local:
 		selectedClientConfig, err := cfg.GetSelectedConsensusClientConfig()
@@ -1528,8 +1874,8 @@ func exportEcData(c *cli.Context, targetDir string) error {
 		fmt.Printf("%sYou do not have a fallback execution client configured.\nYou will continue attesting while exporting the chain data, but block proposals and most of Rocket Pool's commands will not work.\nPlease configure a fallback client with `rocketpool service config` before running this.%s\n\n", colorRed, colorReset)
 	} else {
 		var fallbackClientName string
-		if cfg.FallbackExecutionClientMode.Value.(config.Mode) == config.Mode_External {
-			fallbackClientName = cfg.FallbackExternalExecution.HttpUrl.Value.(string)
+		if cfg.GetFallbackExecutionClientMode() == config.Mode_External {
+			fallbackClientName = cfg.FallbackExternalExecution.GetHttpUrl()
 		} else {
 			fallbackClientName = fmt.Sprint(cfg.FallbackExecutionClient.Value.(config.ExecutionClient))
 		}
@@ -1657,8 +2003,8 @@ func importEcData(c *cli.Context, sourceDir string) error {
 		fmt.Printf("%sYou do not have a fallback execution client configured.\nYou will continue attesting while importing the chain data, but block proposals and most of Rocket Pool's commands will not work.\nPlease configure a fallback client with `rocketpool service config` before running this.%s\n\n", colorRed, colorReset)
 	} else {
 		var fallbackClientName string
-		if cfg.FallbackExecutionClientMode.Value.(config.Mode) == config.Mode_External {
-			fallbackClientName = cfg.FallbackExternalExecution.HttpUrl.Value.(string)
+		if cfg.GetFallbackExecutionClientMode() == config.Mode_External {
+			fallbackClientName = cfg.FallbackExternalExecution.GetHttpUrl()
 		} else {
 			fallbackClientName = fmt.Sprint(cfg.FallbackExecutionClient.Value.(config.ExecutionClient))
 		}
@@ -1771,3 +2117,34 @@ func getPartitionFreeSpace(rp *rocketpool.Client, targetDir string) (uint64, err
 	}
 	return diskUsage.Free, nil
 }
+
+// Create the token for the Validator Client's Keymanager API if it doesn't exist yet
+func createKeymanagerToken(cfg *config.RocketPoolConfig) error {
+	dataPath, err := homedir.Expand(os.ExpandEnv(cfg.Smartnode.GetDataPath()))
+	if err != nil {
+		return fmt.Errorf("error expanding data path [%s]: %w", cfg.Smartnode.GetDataPath(), err)
+	}
+	tokenPath := filepath.Join(dataPath, "validators", "keymanager-token")
+	if _, err := os.Stat(tokenPath); err == nil {
+		return nil
+	}
+
+	token := make([]byte, 32)
+	if _, err := rand.Read(token); err != nil {
+		return fmt.Errorf("error generating Keymanager API token: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(tokenPath), 0755); err != nil {
+		return fmt.Errorf("error creating validators folder: %w", err)
+	}
+	if err := ioutil.WriteFile(tokenPath, []byte(hex.EncodeToString(token)), 0600); err != nil {
+		return fmt.Errorf("error saving Keymanager API token: %w", err)
+	}
+	return nil
+}
+
+// Mark an event on the Grafana dashboards if annotations are enabled
+func annotateDashboards(cfg *config.RocketPoolConfig, text string, tags ...string) {
+	if err := grafana.NewAnnotator(cfg, "localhost").Annotate(text, tags...); err != nil {
+		fmt.Printf("%sWARNING: %s%s\n", colorYellow, err.Error(), colorReset)
+	}
+}
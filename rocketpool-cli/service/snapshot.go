@@ -0,0 +1,254 @@
+package service
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// Settings
+const (
+	EcSnapshotLoaderContainerSuffix string = "_ec_snapshot_loader"
+
+	// Snapshot providers publish the checksum of each archive next to it, in the format sha256sum writes
+	snapshotChecksumSuffix string = ".sha256"
+
+	snapshotRequestTimeout = 30 * time.Second
+)
+
+var sha256Regex = regexp.MustCompile(`^[0-9a-fA-F]{64}$`)
+
+// Replace the Execution client's chain data with a snapshot downloaded from a trusted provider
+func syncFromSnapshot(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the config
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return err
+	}
+	if isNew {
+		return fmt.Errorf("Settings file not found. Please run `rocketpool service config` to set up your Smartnode.")
+	}
+
+	// Sanity checks
+	if cfg.GetExecutionClientMode() == config.Mode_External {
+		fmt.Println("You are using an externally managed Execution client.\nThe Smartnode cannot load a snapshot into it for you.")
+		return nil
+	}
+	if cfg.IsNativeMode {
+		fmt.Println("You are using Native Mode.\nThe Smartnode cannot load a snapshot into your Execution client for you, you'll have to do it manually.")
+		return nil
+	}
+	selectedEc := cfg.ExecutionClient.Value.(config.ExecutionClient)
+	switch selectedEc {
+	case config.ExecutionClient_Geth, config.ExecutionClient_Erigon, config.ExecutionClient_Nethermind:
+	default:
+		fmt.Printf("You are using %s as your Execution client.\nSnapshots are only available for Geth, Erigon, and Nethermind.\n", selectedEc)
+		return nil
+	}
+
+	// Get the snapshot to download
+	snapshotUrl := c.String("url")
+	if snapshotUrl == "" {
+		snapshotUrls, err := cfg.Smartnode.GetEcSnapshotUrls()
+		if err != nil {
+			return err
+		}
+		snapshotUrl = snapshotUrls[selectedEc]
+	}
+	if snapshotUrl == "" {
+		return fmt.Errorf("No snapshot is configured for %s on %s. Add one to the Execution Client Snapshots setting in the Smartnode section of `rocketpool service config`, or provide one with --url.", selectedEc, cfg.Smartnode.Network.Value)
+	}
+	tarFlags, err := getSnapshotTarFlags(snapshotUrl)
+	if err != nil {
+		return err
+	}
+
+	// Get its checksum
+	checksum := c.String("sha256")
+	if checksum == "" {
+		fmt.Println("Getting the snapshot's checksum...")
+		checksum, err = getSnapshotChecksum(snapshotUrl)
+		if err != nil {
+			return fmt.Errorf("Error getting the snapshot's checksum: %w\nIf the provider publishes it somewhere else, provide it with --sha256.", err)
+		}
+	}
+	if !sha256Regex.MatchString(checksum) {
+		return fmt.Errorf("'%s' is not a valid SHA256 checksum.", checksum)
+	}
+
+	// Get the container prefix
+	prefix, err := getContainerPrefix(rp)
+	if err != nil {
+		return fmt.Errorf("Error getting container prefix: %w", err)
+	}
+
+	fmt.Printf("This will replace your %s chain data with the snapshot at %s.\n", selectedEc, snapshotUrl)
+	fmt.Println("Your execution client will be shut down while the snapshot is downloaded and unpacked.")
+	fmt.Printf("Once it's done and the snapshot's checksum has been verified, your execution client will restart automatically.\n\n")
+
+	if cfg.UseFallbackExecutionClient.Value == false {
+		fmt.Printf("%sYou do not have a fallback execution client configured.\nYou will continue attesting while the snapshot is loaded, but block proposals and most of Rocket Pool's commands will not work.\nPlease configure a fallback client with `rocketpool service config` before running this.%s\n\n", colorRed, colorReset)
+	} else {
+		var fallbackClientName string
+		if cfg.GetFallbackExecutionClientMode() == config.Mode_External {
+			fallbackClientName = cfg.FallbackExternalExecution.GetHttpUrl()
+		} else {
+			fallbackClientName = fmt.Sprint(cfg.FallbackExecutionClient.Value.(config.ExecutionClient))
+		}
+		fmt.Printf("You have a fallback execution client configured (%s).\nRocket Pool (and your consensus client) will use that while the main client is offline.\n\n", fallbackClientName)
+	}
+
+	// Get the volume to load the snapshot into
+	executionContainerName := prefix + ExecutionContainerSuffix
+	volume, err := rp.GetClientVolumeName(executionContainerName, clientDataVolumeName)
+	if err != nil {
+		return fmt.Errorf("Error getting execution client volume name: %w", err)
+	}
+
+	// Make sure the target volume has enough space; the existing chain data is removed first, so it counts as free
+	snapshotBytes, err := getSnapshotSize(snapshotUrl)
+	if err != nil {
+		fmt.Printf("%sWARNING: Couldn't check the size of the snapshot: %s\nPlease verify you have enough free space to hold the unpacked chain data before proceeding!%s\n\n", colorRed, err.Error(), colorReset)
+	} else {
+		volumePath, err := rp.GetClientVolumeSource(executionContainerName, clientDataVolumeName)
+		if err != nil {
+			err = fmt.Errorf("error getting execution volume source path: %w", err)
+			fmt.Printf("%sWARNING: Couldn't check the disk space free on the Docker volume partition: %s\nPlease verify you have enough free space to hold the unpacked chain data before proceeding!%s\n\n", colorRed, err.Error(), colorReset)
+		} else {
+			targetFree, err := getPartitionFreeSpace(rp, volumePath)
+			if err != nil {
+				fmt.Printf("%sWARNING: Couldn't check the disk space free on the Docker volume partition: %s\nPlease verify you have enough free space to hold the unpacked chain data before proceeding!%s\n\n", colorRed, err.Error(), colorReset)
+			} else {
+				available := targetFree
+				if currentSize, err := rp.GetVolumeSize(volume); err == nil {
+					if currentBytes, err := humanize.ParseBytes(currentSize); err == nil {
+						available += currentBytes
+					}
+				}
+
+				fmt.Printf("%sSnapshot size (compressed): %s%s\n", colorLightBlue, humanize.IBytes(snapshotBytes), colorReset)
+				fmt.Printf("%sAvailable space:            %s%s\n", colorLightBlue, humanize.IBytes(available), colorReset)
+				if available < snapshotBytes {
+					return fmt.Errorf("%sYour Docker drive does not have enough space to hold the snapshot. Please free up more space and try again.%s", colorRed, colorReset)
+				}
+				fmt.Printf("%sThe unpacked chain data will be larger than the snapshot, so make sure you have room to spare.%s\n\n", colorYellow, colorReset)
+			}
+		}
+	}
+
+	// Prompt for confirmation
+	fmt.Printf("%sNOTE: Loading the snapshot will *delete* your existing chain data!%s\n\n", colorYellow, colorReset)
+	fmt.Printf("%sOnce started, this process *will not stop* until the snapshot is loaded - even if you exit the command with Ctrl+C.\nPlease do not exit until it finishes so you can watch its progress.%s\n\n", colorYellow, colorReset)
+	if !(c.Bool("yes") || cliutils.Confirm("Are you sure you want to delete your existing execution layer chain data and replace it with the snapshot?")) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	fmt.Printf("Stopping %s...\n", executionContainerName)
+	result, err := rp.StopContainer(executionContainerName)
+	if err != nil {
+		return fmt.Errorf("Error stopping main execution container: %w", err)
+	}
+	if result != executionContainerName {
+		return fmt.Errorf("Unexpected output while stopping main execution container: %s", result)
+	}
+
+	// Run the loader, then restart the client whether or not it worked
+	fmt.Printf("Loading the snapshot into volume %s...\n", volume)
+	loaderErr := rp.RunEcSnapshotLoader(prefix+EcSnapshotLoaderContainerSuffix, volume, cfg.Smartnode.GetSnapshotLoaderContainerTag(), snapshotUrl, checksum, tarFlags)
+	if loaderErr != nil {
+		fmt.Printf("%sThe snapshot could not be loaded, so your chain data was removed and your execution client will sync from scratch.%s\n", colorRed, colorReset)
+	}
+
+	// Restart ETH1
+	fmt.Printf("Restarting %s...\n", executionContainerName)
+	result, err = rp.StartContainer(executionContainerName)
+	if err != nil {
+		return fmt.Errorf("Error starting main execution client: %w", err)
+	}
+	if result != executionContainerName {
+		return fmt.Errorf("Unexpected output while starting main execution client: %s", result)
+	}
+	if loaderErr != nil {
+		return fmt.Errorf("Error loading the snapshot: %w", loaderErr)
+	}
+
+	fmt.Printf("\n%sDone! Your execution client is now running from the snapshot and will sync the rest of the chain.%s\n", colorGreen, colorReset)
+	return nil
+
+}
+
+// Get the tar flags needed to unpack a snapshot, based on its file extension
+func getSnapshotTarFlags(snapshotUrl string) (string, error) {
+	path := strings.ToLower(strings.SplitN(snapshotUrl, "?", 2)[0])
+	switch {
+	case strings.HasSuffix(path, ".tar.zst"), strings.HasSuffix(path, ".tzst"):
+		return "--zstd", nil
+	case strings.HasSuffix(path, ".tar.lz4"):
+		return "-I lz4", nil
+	case strings.HasSuffix(path, ".tar.gz"), strings.HasSuffix(path, ".tgz"):
+		return "-z", nil
+	case strings.HasSuffix(path, ".tar"):
+		return "", nil
+	default:
+		return "", fmt.Errorf("Unsupported snapshot format for %s; snapshots must be .tar, .tar.gz, .tar.lz4, or .tar.zst archives.", snapshotUrl)
+	}
+}
+
+// Get a snapshot's checksum from the file published next to it
+func getSnapshotChecksum(snapshotUrl string) (string, error) {
+	client := http.Client{Timeout: snapshotRequestTimeout}
+	checksumUrl := strings.SplitN(snapshotUrl, "?", 2)[0] + snapshotChecksumSuffix
+	response, err := client.Get(checksumUrl)
+	if err != nil {
+		return "", err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("%s returned %s", checksumUrl, response.Status)
+	}
+	body, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return "", fmt.Errorf("error reading %s: %w", checksumUrl, err)
+	}
+	fields := strings.Fields(string(body))
+	if len(fields) == 0 || !sha256Regex.MatchString(fields[0]) {
+		return "", fmt.Errorf("%s doesn't contain a SHA256 checksum", checksumUrl)
+	}
+	return fields[0], nil
+}
+
+// Get the size of a snapshot archive, in bytes
+func getSnapshotSize(snapshotUrl string) (uint64, error) {
+	client := http.Client{Timeout: snapshotRequestTimeout}
+	response, err := client.Head(snapshotUrl)
+	if err != nil {
+		return 0, err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("%s returned %s", snapshotUrl, response.Status)
+	}
+	if response.ContentLength <= 0 {
+		return 0, fmt.Errorf("%s didn't report its size", snapshotUrl)
+	}
+	return uint64(response.ContentLength), nil
+}
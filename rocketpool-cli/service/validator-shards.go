@@ -0,0 +1,66 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+)
+
+// Print the validator keys assigned to each Validator client shard, along with the status of its container
+func printValidatorShards(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the shards
+	response, err := rp.GetValidatorShards()
+	if err != nil {
+		return err
+	}
+	if response.ShardCount == 1 && len(response.Orphaned) == 0 {
+		fmt.Println("Your validator keys aren't split across multiple Validator clients; they all run in the main Validator client.")
+		fmt.Println("You can change this with the Validator Client Shards setting in the Smartnode section of `rocketpool service config`.")
+		return nil
+	}
+
+	// Get the container prefix
+	prefix, err := getContainerPrefix(rp)
+	if err != nil {
+		return fmt.Errorf("Error getting container prefix: %w", err)
+	}
+
+	fmt.Printf("Your validator keys are split across %d Validator clients.\n\n", response.ShardCount)
+	for _, shard := range response.Shards {
+		containerName := fmt.Sprintf("%s_%s", prefix, shard.Container)
+		status, err := rp.GetDockerStatus(containerName)
+		if err != nil {
+			status = fmt.Sprintf("unknown (%s)", err.Error())
+		}
+		color := colorGreen
+		if status != "running" {
+			color = colorRed
+		}
+		fmt.Printf("%sShard %d (%s): %s%s%s, %d assigned key(s)%s\n", colorLightBlue, shard.Shard, containerName, color, status, colorLightBlue, len(shard.Pubkeys), colorReset)
+		for _, pubkey := range shard.Pubkeys {
+			fmt.Printf("\t%s\n", pubkey)
+		}
+		fmt.Println()
+	}
+	fmt.Printf("Keys created before the Validator client was split stay in shard 0, even if they aren't listed above.\n\n")
+
+	for _, shard := range response.Orphaned {
+		fmt.Printf("%sShard %d no longer exists, but these keys are still assigned to it so they aren't being validated:%s\n", colorRed, shard.Shard, colorReset)
+		for _, pubkey := range shard.Pubkeys {
+			fmt.Printf("\t%s\n", pubkey)
+		}
+		fmt.Printf("%sIncrease the number of Validator Client Shards in `rocketpool service config` to %d or more to run them again.%s\n\n", colorRed, shard.Shard+1, colorReset)
+	}
+	return nil
+
+}
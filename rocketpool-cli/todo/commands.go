@@ -0,0 +1,28 @@
+package todo
+
+import (
+	"github.com/urfave/cli"
+
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// Register commands
+func RegisterCommands(app *cli.App, name string, aliases []string) {
+	app.Commands = append(app.Commands, cli.Command{
+		Name:      name,
+		Aliases:   aliases,
+		Usage:     "List the things that need your attention, such as unclaimed rewards, minipools that are ready to stake, settings changes that haven't been applied, and available updates",
+		UsageText: "rocketpool todo",
+		Action: func(c *cli.Context) error {
+
+			// Validate args
+			if err := cliutils.ValidateArgCount(c, 0); err != nil {
+				return err
+			}
+
+			// Run
+			return printTodo(c)
+
+		},
+	})
+}
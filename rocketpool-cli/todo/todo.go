@@ -0,0 +1,187 @@
+package todo
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/rocket-pool/smartnode/shared/utils/math"
+)
+
+// Settings
+const (
+	colorReset  string = "\033[0m"
+	colorYellow string = "\033[33m"
+	colorGreen  string = "\033[32m"
+
+	nodeContainerSuffix string = "_node"
+)
+
+// The commands the banner isn't shown for, because they show the pending items themselves or change the things they're based on
+var bannerExcludedCommands = map[string]bool{
+	"todo":    true,
+	"service": true,
+	"s":       true,
+	"help":    true,
+	"h":       true,
+}
+
+// Something that needs the node operator's attention
+type todoItem struct {
+	Summary string `json:"summary"`
+	Detail  string `json:"detail"`
+	Command string `json:"command"`
+}
+
+// Print the full list of pending items
+func printTodo(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the items
+	items, daemonErr := getTodoItems(rp)
+	if cliutils.IsJsonOutput() {
+		response := struct {
+			Items []todoItem `json:"items"`
+			Error string     `json:"error,omitempty"`
+		}{
+			Items: items,
+		}
+		if daemonErr != nil {
+			response.Error = daemonErr.Error()
+		}
+		return cliutils.PrintJson(response)
+	}
+
+	// Print them
+	if len(items) == 0 {
+		fmt.Printf("%sNothing needs your attention right now.%s\n", colorGreen, colorReset)
+	}
+	for i, item := range items {
+		fmt.Printf("%s%d. %s%s\n", colorYellow, i+1, item.Summary, colorReset)
+		fmt.Printf("   %s\n", item.Detail)
+		if item.Command != "" {
+			fmt.Printf("   Run `%s`.\n", item.Command)
+		}
+		fmt.Println()
+	}
+	if daemonErr != nil {
+		fmt.Printf("Couldn't check your rewards and minipools: %s\n", daemonErr.Error())
+	}
+	return nil
+
+}
+
+// Print a one-line summary of the pending items before a command runs, if there are any.
+// Errors are ignored so the banner never gets in the way of the command itself.
+func PrintBanner(c *cli.Context) {
+
+	if cliutils.IsJsonOutput() || c.NArg() == 0 || bannerExcludedCommands[c.Args().First()] {
+		return
+	}
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return
+	}
+	defer rp.Close()
+
+	// Get the items
+	items, _ := getTodoItems(rp)
+	if len(items) == 0 {
+		return
+	}
+	summaries := make([]string, len(items))
+	for i, item := range items {
+		summaries[i] = item.Summary
+	}
+	fmt.Printf("%sPending: %s (run `rocketpool todo` for details)%s\n\n", colorYellow, strings.Join(summaries, "; "), colorReset)
+
+}
+
+// Get the pending items.
+// The items that can be checked without the daemon are always returned; the error is from getting the ones that need it.
+func getTodoItems(rp *rocketpool.Client) ([]todoItem, error) {
+
+	items := []todoItem{}
+
+	// Check the settings
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return items, err
+	}
+	if isNew {
+		items = append(items, todoItem{
+			Summary: "Smartnode not configured",
+			Detail:  "The Smartnode hasn't been configured yet.",
+			Command: "rocketpool service config",
+		})
+		return items, nil
+	}
+
+	// Check for updates that haven't been applied
+	isUpdate, err := rp.IsFirstRun()
+	if err == nil && isUpdate {
+		items = append(items, todoItem{
+			Summary: "update not applied",
+			Detail:  fmt.Sprintf("The Smartnode was updated to v%s, but the new version hasn't been configured and started yet.", shared.RocketPoolVersion),
+			Command: "rocketpool service config",
+		})
+	} else if serviceVersion, err := rp.GetServiceVersion(); err == nil && strings.TrimPrefix(serviceVersion, "v") != shared.RocketPoolVersion {
+		items = append(items, todoItem{
+			Summary: "service version mismatch",
+			Detail:  fmt.Sprintf("The Smartnode service is running v%s, but the CLI is v%s.", strings.TrimPrefix(serviceVersion, "v"), shared.RocketPoolVersion),
+			Command: "rocketpool service install -d",
+		})
+	}
+
+	// Check for settings changes that haven't been applied
+	if !cfg.IsNativeMode {
+		saveTime, saveErr := rp.GetConfigSaveTime()
+		startTime, startErr := rp.GetDockerContainerStartTime(cfg.Smartnode.GetProjectName() + nodeContainerSuffix)
+		if saveErr == nil && startErr == nil && saveTime.After(startTime) {
+			items = append(items, todoItem{
+				Summary: "settings changes not applied",
+				Detail:  fmt.Sprintf("Your settings were changed at %s, after the Smartnode was last started at %s.", cliutils.FormatTime(saveTime), cliutils.FormatTime(startTime)),
+				Command: "rocketpool service start",
+			})
+		}
+	}
+
+	// Check the node's rewards and minipools
+	status, err := rp.NodeTodo()
+	if err != nil {
+		return items, err
+	}
+	if len(status.UnclaimedIntervals) > 0 {
+		detail := fmt.Sprintf("You have %.6f RPL and %.6f ETH in unclaimed rewards from %d interval(s).", math.RoundDown(eth.WeiToEth(status.UnclaimedRpl), 6), math.RoundDown(eth.WeiToEth(status.UnclaimedEth), 6), len(status.UnclaimedIntervals))
+		if !cfg.Smartnode.GetAutoClaimEnabled() {
+			detail += " Enable automatic claiming in the Smartnode settings to have the node daemon claim them when gas is cheap."
+		}
+		items = append(items, todoItem{
+			Summary: "unclaimed rewards",
+			Detail:  detail,
+			Command: "rocketpool node rewards",
+		})
+	}
+	if len(status.StakeableMinipools) > 0 {
+		items = append(items, todoItem{
+			Summary: fmt.Sprintf("%d minipool(s) ready to stake", len(status.StakeableMinipools)),
+			Detail:  "These minipools have passed the scrub check and are waiting to be staked; the node daemon will stake them once gas is below your threshold.",
+			Command: "rocketpool minipool stake",
+		})
+	}
+	return items, nil
+
+}
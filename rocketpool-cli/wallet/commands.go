@@ -102,6 +102,22 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 						Name:  "address, a",
 						Usage: "If you are recovering a wallet that was not generated by the Smartnode and don't know the derivation path or index of it, enter the address here. The Smartnode will search through its library of paths and indices to try to find it.",
 					},
+					cli.BoolFlag{
+						Name:  "validator-keys-only, o",
+						Usage: "Only regenerate the validator keys for your minipools into the keychain, without saving the node wallet on this machine. Useful when moving your Validator client to a separate machine.",
+					},
+					cli.BoolFlag{
+						Name:  "search-validator-paths, s",
+						Usage: "If your validator keys weren't all created by the Smartnode, search the documented validator key derivation paths of other staking tools (including the Ledger Live and legacy MyEtherWallet layouts) for the ones it can't find.",
+					},
+					cli.StringFlag{
+						Name:  "validator-key-paths, v",
+						Usage: "A comma-separated list of custom validator key derivation paths to search, where %d is the key index (e.g. \"m/12381/3600/%d/0/0\")",
+					},
+					cli.StringFlag{
+						Name:  "validator-key-index-range, r",
+						Usage: "The range of key indices to search on each validator key derivation path, in the form start-end (default \"0-999\")",
+					},
 				},
 				Action: func(c *cli.Context) error {
 
@@ -211,6 +227,134 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 
 				},
 			},
+
+			{
+				Name:      "export-slashing-protection",
+				Usage:     "Remove your validator keys from your Validator Client and save their slashing protection history to a file in EIP-3076 format, so you can switch to a different client",
+				UsageText: "rocketpool wallet export-slashing-protection [options]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "file, f",
+						Usage: "The path of the file to save the slashing protection history to (defaults to a timestamped file in the current directory)",
+					},
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm removing the validator keys from the Validator Client",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return exportSlashingProtection(c)
+
+				},
+			},
+
+			{
+				Name:      "import-slashing-protection",
+				Usage:     "Import your validator keys into your Validator Client along with the slashing protection history from an EIP-3076 file",
+				UsageText: "rocketpool wallet import-slashing-protection [options] file",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm the import",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					sourceFile := c.Args().Get(0)
+
+					// Run
+					return importSlashingProtection(c, sourceFile)
+
+				},
+			},
+
+			{
+				Name:      "connect-hardware",
+				Usage:     "Use an account on a Ledger or Trezor hardware wallet as the node account; validator keys are still derived from the node wallet's mnemonic",
+				UsageText: "rocketpool wallet connect-hardware [options]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "type, t",
+						Usage: "The type of hardware wallet ('ledger' or 'trezor')",
+						Value: "ledger",
+					},
+					cli.StringFlag{
+						Name:  "derivation-path, d",
+						Usage: "Specify the derivation path of the account on the hardware wallet.\nOmit this flag (or leave it blank) for the default of \"m/44'/60'/0'/0/%d\" (where %d is the index).\nSet this to \"ledgerLive\" to use Ledger Live's path of \"m/44'/60'/%d/0/0\".\nSet this to \"mew\" to use MyEtherWallet's path of \"m/44'/60'/0'/%d\".\nFor custom paths, simply enter them here.",
+					},
+					cli.UintFlag{
+						Name:  "wallet-index, i",
+						Usage: "Specify the index to use with the derivation path",
+						Value: 0,
+					},
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm using the hardware wallet's account",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return connectHardwareWallet(c)
+
+				},
+			},
+
+			{
+				Name:      "disconnect-hardware",
+				Usage:     "Go back to using the account derived from the node wallet's mnemonic as the node account",
+				UsageText: "rocketpool wallet disconnect-hardware [options]",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm disconnecting the hardware wallet",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return disconnectHardwareWallet(c)
+
+				},
+			},
+
+			{
+				Name:      "sign-queue",
+				Usage:     "Sign and submit the transactions waiting for the node account's hardware wallet",
+				UsageText: "rocketpool wallet sign-queue",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return signQueue(c)
+
+				},
+			},
 		},
 	})
 }
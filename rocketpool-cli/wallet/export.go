@@ -51,10 +51,15 @@ func exportWallet(c *cli.Context) error {
 	}
 
 	// Print wallet & return
-	fmt.Println("Node account private key:")
-	fmt.Println("")
-	fmt.Println(export.AccountPrivateKey)
-	fmt.Println("")
+	if export.AccountPrivateKey != "" {
+		fmt.Println("Node account private key:")
+		fmt.Println("")
+		fmt.Println(export.AccountPrivateKey)
+		fmt.Println("")
+	} else {
+		fmt.Println("The node account is on a hardware wallet, so its private key can't be exported.")
+		fmt.Println("")
+	}
 	fmt.Println("Wallet password:")
 	fmt.Println("")
 	fmt.Println(export.Password)
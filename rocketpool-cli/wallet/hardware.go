@@ -0,0 +1,294 @@
+package wallet
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/usbwallet"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	hexutils "github.com/rocket-pool/smartnode/shared/utils/hex"
+)
+
+// The derivation path shortcuts the daemon accepts for the node wallet.
+// The shared wallet package isn't used here since it pulls in the BLS library, which needs cgo.
+var derivationPathShortcuts = map[string]string{
+	"":           "m/44'/60'/0'/0/%d",
+	"ledgerLive": "m/44'/60'/%d/0/0",
+	"mew":        "m/44'/60'/0'/%d",
+}
+
+func connectHardwareWallet(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get & check wallet status
+	status, err := rp.WalletStatus()
+	if err != nil {
+		return err
+	}
+	if !status.WalletInitialized {
+		fmt.Println("The node wallet must be initialized first, since your validator keys are still derived from its mnemonic. Please run `rocketpool wallet init` or `rocketpool wallet recover`.")
+		return nil
+	}
+	if status.HardwareWallet != "" {
+		fmt.Printf("The node account is already on a %s hardware wallet (%s).\n", status.HardwareWallet, status.AccountAddress.Hex())
+		return nil
+	}
+
+	// Get the derivation path
+	derivationPath := c.String("derivation-path")
+	if path, exists := derivationPathShortcuts[derivationPath]; exists {
+		derivationPath = path
+	}
+	if strings.Contains(derivationPath, "%d") {
+		derivationPath = fmt.Sprintf(derivationPath, c.Uint("wallet-index"))
+	}
+	path, err := accounts.ParseDerivationPath(derivationPath)
+	if err != nil {
+		return fmt.Errorf("Invalid derivation path '%s': %w", derivationPath, err)
+	}
+
+	// Get the account from the device
+	walletType := c.String("type")
+	device, err := openHardwareWallet(walletType)
+	if err != nil {
+		return err
+	}
+	defer device.Close()
+	account, err := device.Derive(path, false)
+	if err != nil {
+		return fmt.Errorf("Error getting the account at %s from the hardware wallet: %w", derivationPath, err)
+	}
+
+	// Prompt for confirmation
+	fmt.Printf("Found account %s at %s on your %s.\n", account.Address.Hex(), derivationPath, walletType)
+	fmt.Printf("%sThe daemons can't reach your hardware wallet, so they won't claim rewards or stake minipools automatically. Transactions will be added to a signing queue instead, which you sign with `rocketpool wallet sign-queue`.\nThe node account can't be changed once the node is registered.%s\n\n", colorYellow, colorReset)
+	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to use %s as the node account?", account.Address.Hex()))) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Use it
+	response, err := rp.SetHardwareWalletAccount(walletType, account.Address, derivationPath)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("The node account is now %s on your %s.\n", response.AccountAddress.Hex(), walletType)
+	fmt.Println("Please restart the Smartnode with `rocketpool service start` so the daemons use it.")
+	return nil
+
+}
+
+func disconnectHardwareWallet(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Prompt for confirmation
+	if !(c.Bool("yes") || cliutils.Confirm("Are you sure you want to go back to using the account derived from your mnemonic as the node account?")) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Clear the hardware wallet account
+	response, err := rp.ClearHardwareWalletAccount()
+	if err != nil {
+		return err
+	}
+	fmt.Printf("The node account is now %s.\n", response.AccountAddress.Hex())
+	fmt.Println("Any transactions left in the signing queue can no longer be signed; please restart the Smartnode with `rocketpool service start` so the daemons use the new account.")
+	return nil
+
+}
+
+func signQueue(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get & check wallet status
+	status, err := rp.WalletStatus()
+	if err != nil {
+		return err
+	}
+	if status.HardwareWallet == "" {
+		fmt.Println("The node account is not on a hardware wallet.")
+		return nil
+	}
+
+	// Get the queue
+	queue, err := rp.SigningQueue()
+	if err != nil {
+		return err
+	}
+	if len(queue.Transactions) == 0 {
+		fmt.Println("There are no transactions waiting to be signed.")
+		return nil
+	}
+
+	// Open the device
+	device, err := openHardwareWallet(status.HardwareWallet)
+	if err != nil {
+		return err
+	}
+	defer device.Close()
+
+	// Sign and submit each transaction
+	for _, queued := range queue.Transactions {
+
+		// Print the transaction
+		txBytes, err := hex.DecodeString(queued.Tx)
+		if err != nil {
+			return fmt.Errorf("Error parsing queued transaction %s: %w", queued.ID, err)
+		}
+		tx := new(types.Transaction)
+		if err := tx.UnmarshalBinary(txBytes); err != nil {
+			return fmt.Errorf("Error decoding queued transaction %s: %w", queued.ID, err)
+		}
+		fmt.Printf("Transaction %s (queued %s):\n", queued.ID, queued.Created.Format(TimeFormat))
+		if tx.To() != nil {
+			fmt.Printf("\tTo:       %s\n", tx.To().Hex())
+		}
+		fmt.Printf("\tValue:    %.6f ETH\n", eth.WeiToEth(tx.Value()))
+		fmt.Printf("\tGas:      %d\n", tx.Gas())
+		if len(tx.Data()) >= 4 {
+			fmt.Printf("\tFunction: %s\n", hexutils.AddPrefix(hex.EncodeToString(tx.Data()[:4])))
+		}
+		fmt.Println()
+
+		// Prompt for confirmation
+		if !cliutils.Confirm("Do you want to sign and submit this transaction?") {
+			if cliutils.Confirm("Do you want to remove it from the signing queue?") {
+				if _, err := rp.RemoveQueuedTransaction(queued.ID); err != nil {
+					return err
+				}
+				fmt.Printf("Removed transaction %s.\n\n", queued.ID)
+			}
+			continue
+		}
+
+		// Get it with an up-to-date nonce and gas price
+		prepared, err := rp.PrepareQueuedTransaction(queued.ID)
+		if err != nil {
+			return err
+		}
+		unsignedBytes, err := hex.DecodeString(hexutils.RemovePrefix(prepared.Tx))
+		if err != nil {
+			return fmt.Errorf("Error parsing prepared transaction: %w", err)
+		}
+		unsignedTx := new(types.Transaction)
+		if err := unsignedTx.UnmarshalBinary(unsignedBytes); err != nil {
+			return fmt.Errorf("Error decoding prepared transaction: %w", err)
+		}
+		path, err := accounts.ParseDerivationPath(prepared.DerivationPath)
+		if err != nil {
+			return fmt.Errorf("Invalid node account derivation path '%s': %w", prepared.DerivationPath, err)
+		}
+		account, err := device.Derive(path, false)
+		if err != nil {
+			return fmt.Errorf("Error getting the node account from the hardware wallet: %w", err)
+		}
+		if account.Address != prepared.AccountAddress {
+			return fmt.Errorf("The hardware wallet has account %s at %s instead of the node account %s; please connect the right device.", account.Address.Hex(), prepared.DerivationPath, prepared.AccountAddress.Hex())
+		}
+
+		// Sign it on the device
+		fmt.Printf("Gas price: %.2f gwei\n", eth.WeiToGwei(unsignedTx.GasPrice()))
+		fmt.Println("Please review and approve the transaction on your hardware wallet...")
+		signedTx, err := device.SignTx(account, unsignedTx, new(big.Int).SetUint64(prepared.ChainID))
+		if err != nil {
+			return fmt.Errorf("Error signing transaction %s: %w", queued.ID, err)
+		}
+		signedBytes, err := signedTx.MarshalBinary()
+		if err != nil {
+			return fmt.Errorf("Error serializing signed transaction: %w", err)
+		}
+
+		// Submit it
+		response, err := rp.SubmitQueuedTransaction(queued.ID, hexutils.AddPrefix(hex.EncodeToString(signedBytes)))
+		if err != nil {
+			return err
+		}
+		cliutils.PrintTransactionHash(rp, response.TxHash)
+		if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
+			return err
+		}
+		fmt.Printf("Transaction %s has been submitted.\n\n", queued.ID)
+
+	}
+
+	// Return
+	return nil
+
+}
+
+// Open the first connected hardware wallet of the given type
+func openHardwareWallet(walletType string) (accounts.Wallet, error) {
+
+	// Get the hubs for the wallet type
+	var hubs []*usbwallet.Hub
+	switch walletType {
+	case usbwallet.LedgerScheme:
+		hub, err := usbwallet.NewLedgerHub()
+		if err != nil {
+			return nil, fmt.Errorf("Error looking for Ledger devices: %w (hardware wallets require a CLI built with cgo)", err)
+		}
+		hubs = append(hubs, hub)
+	case usbwallet.TrezorScheme:
+		hidHub, err := usbwallet.NewTrezorHubWithHID()
+		if err != nil {
+			return nil, fmt.Errorf("Error looking for Trezor devices: %w (hardware wallets require a CLI built with cgo)", err)
+		}
+		webUsbHub, err := usbwallet.NewTrezorHubWithWebUSB()
+		if err != nil {
+			return nil, fmt.Errorf("Error looking for Trezor devices: %w (hardware wallets require a CLI built with cgo)", err)
+		}
+		hubs = append(hubs, hidHub, webUsbHub)
+	default:
+		return nil, fmt.Errorf("Unknown hardware wallet type '%s'; please use '%s' or '%s'.", walletType, usbwallet.LedgerScheme, usbwallet.TrezorScheme)
+	}
+
+	// Open the first device
+	for _, hub := range hubs {
+		for _, device := range hub.Wallets() {
+			err := device.Open("")
+			if errors.Is(err, usbwallet.ErrTrezorPINNeeded) {
+				fmt.Println("Please enter your PIN using the layout shown on your Trezor (1 is the bottom left, 9 is the top right).")
+				pin := cliutils.PromptPassword("PIN:", "^[1-9]+$", "Please enter the positions of your PIN's digits using the numbers 1-9.")
+				err = device.Open(pin)
+			}
+			if errors.Is(err, usbwallet.ErrTrezorPassphraseNeeded) {
+				passphrase := cliutils.PromptPassword("Please enter your Trezor passphrase:", "^.*$", "")
+				err = device.Open(passphrase)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("Error opening %s: %w", device.URL(), err)
+			}
+			return device, nil
+		}
+	}
+	return nil, fmt.Errorf("No %s device was found. Please connect it, unlock it, and open its Ethereum app.", walletType)
+
+}
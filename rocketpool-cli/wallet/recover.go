@@ -8,7 +8,9 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/urfave/cli"
 
+	"github.com/rocket-pool/smartnode/shared/services/config"
 	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
 )
 
@@ -32,6 +34,9 @@ func recoverWallet(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
+	if c.Bool("validator-keys-only") {
+		return recoverValidatorKeys(c, rp, cfg, status.WalletInitialized)
+	}
 	if status.WalletInitialized {
 		fmt.Println("The node wallet is already initialized.")
 		return nil
@@ -87,6 +92,14 @@ func recoverWallet(c *cli.Context) error {
 		}
 	}
 
+	// Get the validator key search settings
+	searchValidatorPaths := c.Bool("search-validator-paths")
+	validatorKeyPaths := c.String("validator-key-paths")
+	validatorKeyIndexRange := c.String("validator-key-index-range")
+	if !skipValidatorKeyRecovery && (searchValidatorPaths || validatorKeyPaths != "") {
+		fmt.Println("Validator keys that aren't on the Smartnode's derivation path will be searched for on other paths.\nNOTE: this may take several minutes depending on how many paths and indices are searched.")
+	}
+
 	// Check for a search-by-address operation
 	addressString := c.String("address")
 	if addressString != "" {
@@ -107,7 +120,7 @@ func recoverWallet(c *cli.Context) error {
 		}
 
 		// Recover wallet
-		response, err := rp.SearchAndRecoverWallet(mnemonic, address, skipValidatorKeyRecovery)
+		response, err := rp.SearchAndRecoverWallet(mnemonic, address, skipValidatorKeyRecovery, searchValidatorPaths, validatorKeyPaths, validatorKeyIndexRange)
 		if err != nil {
 			return err
 		}
@@ -126,6 +139,7 @@ func recoverWallet(c *cli.Context) error {
 			} else {
 				fmt.Println("No validator keys were found.")
 			}
+			printValidatorKeyPaths(response.ValidatorKeyPaths)
 		}
 
 	} else {
@@ -157,7 +171,7 @@ func recoverWallet(c *cli.Context) error {
 		}
 
 		// Recover wallet
-		response, err := rp.RecoverWallet(mnemonic, skipValidatorKeyRecovery, derivationPath, walletIndex)
+		response, err := rp.RecoverWallet(mnemonic, skipValidatorKeyRecovery, derivationPath, walletIndex, searchValidatorPaths, validatorKeyPaths, validatorKeyIndexRange)
 		if err != nil {
 			return err
 		}
@@ -174,9 +188,110 @@ func recoverWallet(c *cli.Context) error {
 			} else {
 				fmt.Println("No validator keys were found.")
 			}
+			printValidatorKeyPaths(response.ValidatorKeyPaths)
 		}
 	}
 
 	return nil
 
 }
+
+// Regenerate the validator keystores from the mnemonic without creating the node wallet
+func recoverValidatorKeys(c *cli.Context, rp *rocketpool.Client, cfg *config.RocketPoolConfig, walletInitialized bool) error {
+
+	// Check the flags and wallet status
+	if c.Bool("skip-validator-key-recovery") {
+		return fmt.Errorf("--validator-keys-only and --skip-validator-key-recovery can't be used together.")
+	}
+	if c.String("address") != "" {
+		return fmt.Errorf("--validator-keys-only can't be used with --address; use --derivation-path and --wallet-index to select the node wallet instead.")
+	}
+	if walletInitialized {
+		fmt.Println("The node wallet is already initialized on this machine. Use `rocketpool wallet rebuild` to regenerate its validator keys instead.")
+		return nil
+	}
+
+	// Prompt a notice about what will be recovered
+	fmt.Printf("%sNOTE:\nThis command will only regenerate the validator keys for your minipools, which is useful when running your Validator client on a separate machine.\nThe node wallet and password will not be saved on this machine, so it won't be able to send transactions.%s\n\n", colorYellow, colorReset)
+	fmt.Printf("%sMake sure the Validator client on your old machine is stopped and its keys are removed before you start the one on this machine, or your validators will be slashed.%s\n\n", colorRed, colorReset)
+
+	// Prompt for mnemonic
+	var mnemonic string
+	if c.String("mnemonic") != "" {
+		mnemonic = c.String("mnemonic")
+	} else {
+		mnemonic = promptMnemonic()
+	}
+	mnemonic = strings.TrimSpace(mnemonic)
+
+	// Check for custom keys
+	customKeyPasswordFile, err := promptForCustomKeyPasswords(rp, cfg, false)
+	if err != nil {
+		return err
+	}
+	if customKeyPasswordFile != "" {
+		// Defer deleting the custom keystore password file
+		defer func(customKeyPasswordFile string) {
+			_, err := os.Stat(customKeyPasswordFile)
+			if os.IsNotExist(err) {
+				return
+			}
+
+			err = os.Remove(customKeyPasswordFile)
+			if err != nil {
+				fmt.Printf("*** WARNING ***\nAn error occurred while removing the custom keystore password file: %s\n\nThis file contains the passwords to your custom validator keys.\nYou *must* delete it manually as soon as possible so nobody can read it.\n\nThe file is located here:\n\n\t%s\n\n", err.Error(), customKeyPasswordFile)
+			}
+		}(customKeyPasswordFile)
+	}
+
+	// Get the derivation path and wallet index
+	derivationPath := c.String("derivation-path")
+	if derivationPath != "" {
+		fmt.Printf("Using a custom derivation path (%s).\n", derivationPath)
+	}
+	walletIndex := c.Uint("wallet-index")
+	if walletIndex != 0 {
+		fmt.Printf("Using a custom wallet index (%d).\n", walletIndex)
+	}
+	fmt.Println()
+
+	// Check and assign the EC status
+	err = cliutils.CheckExecutionClientStatus(rp)
+	if err != nil {
+		return err
+	}
+	fmt.Println("Recovering validator keys...")
+
+	// Recover the validator keys
+	response, err := rp.RecoverValidatorKeys(mnemonic, derivationPath, walletIndex, c.Bool("search-validator-paths"), c.String("validator-key-paths"), c.String("validator-key-index-range"))
+	if err != nil {
+		return err
+	}
+
+	// Log & return
+	fmt.Println("The validator keys were successfully recovered.")
+	fmt.Printf("Node account: %s\n", response.AccountAddress.Hex())
+	if len(response.ValidatorKeys) > 0 {
+		fmt.Println("Validator keys:")
+		for _, key := range response.ValidatorKeys {
+			fmt.Println(key.Hex())
+		}
+	} else {
+		fmt.Println("No validator keys were found.")
+	}
+	printValidatorKeyPaths(response.ValidatorKeyPaths)
+	fmt.Println("\nRestart your Validator client with `rocketpool service start` to load them.")
+	return nil
+
+}
+
+// Print the derivation paths that validator keys were found on when searching for them
+func printValidatorKeyPaths(paths []api.ValidatorKeyPath) {
+	if len(paths) == 0 {
+		return
+	}
+	fmt.Println("Validator key derivation paths:")
+	for _, path := range paths {
+		fmt.Printf("%s: %s\n", path.Pubkey.Hex(), path.Path)
+	}
+}
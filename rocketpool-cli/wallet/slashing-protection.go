@@ -0,0 +1,142 @@
+package wallet
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/mitchellh/go-homedir"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// The name of the file the slashing protection history is staged in for the daemon to import
+const slashingProtectionImportFile string = "slashing-protection-import.json"
+
+func exportSlashingProtection(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the target file
+	targetFile := c.String("file")
+	if targetFile == "" {
+		targetFile = fmt.Sprintf("slashing-protection-%s.json", time.Now().Format("20060102-150405"))
+	}
+	targetFile, err = filepath.Abs(targetFile)
+	if err != nil {
+		return fmt.Errorf("Error converting to absolute path: %w", err)
+	}
+	if _, err := os.Stat(targetFile); err == nil {
+		return fmt.Errorf("The file [%s] already exists.", targetFile)
+	}
+
+	// Prompt for confirmation
+	fmt.Println("This will remove all of your validator keys from your Validator Client and save their slashing protection history, so you can import it into a different client.")
+	fmt.Printf("%sYour validators will stop attesting and proposing as soon as their keys are removed, and will stay offline until you import them into a new Validator Client.%s\n\n", colorYellow, colorReset)
+	if !(c.Bool("yes") || cliutils.Confirm("Are you sure you want to remove your validator keys from your Validator Client?")) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Export the slashing protection history
+	response, err := rp.ExportSlashingProtection()
+	if err != nil {
+		return err
+	}
+	printSlashingProtectionKeys(response.Keys)
+
+	// Save it
+	if err := ioutil.WriteFile(targetFile, []byte(response.SlashingProtection), 0600); err != nil {
+		return fmt.Errorf("%sError saving the slashing protection history: %w\nIt is printed below; please save it manually before doing anything else.%s\n\n%s", colorRed, err, colorReset, response.SlashingProtection)
+	}
+	fmt.Printf("\nThe slashing protection history has been saved to %s.\n", targetFile)
+	fmt.Println("Once you have switched to your new Validator Client, import it with `rocketpool wallet import-slashing-protection`.")
+	return nil
+
+}
+
+func importSlashingProtection(c *cli.Context, sourceFile string) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get & check wallet status
+	status, err := rp.WalletStatus()
+	if err != nil {
+		return err
+	}
+	if !status.WalletInitialized {
+		fmt.Println("The node wallet is not initialized.")
+		return nil
+	}
+
+	// Get the config
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return err
+	}
+	if isNew {
+		return fmt.Errorf("Settings file not found. Please run `rocketpool service config` to set up your Smartnode.")
+	}
+
+	// Read the file
+	slashingProtection, err := ioutil.ReadFile(sourceFile)
+	if err != nil {
+		return fmt.Errorf("Error reading the slashing protection file: %w", err)
+	}
+
+	// Prompt for confirmation
+	fmt.Println("This will import the validator keys in this file into your Validator Client along with their slashing protection history.")
+	fmt.Printf("%sMake sure these validators are no longer running in any other Validator Client before continuing.%s\n\n", colorYellow, colorReset)
+	if !(c.Bool("yes") || cliutils.Confirm("Are you sure you want to import the slashing protection history?")) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Stage the file in the data folder so the daemon can read it; it is removed once the import finishes
+	dataPath, err := homedir.Expand(os.ExpandEnv(cfg.Smartnode.GetDataPath()))
+	if err != nil {
+		return fmt.Errorf("Error expanding data path [%s]: %w", cfg.Smartnode.GetDataPath(), err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dataPath, slashingProtectionImportFile), slashingProtection, 0600); err != nil {
+		return fmt.Errorf("Error staging the slashing protection file: %w", err)
+	}
+
+	// Import it
+	response, err := rp.ImportSlashingProtection()
+	if err != nil {
+		return err
+	}
+	printSlashingProtectionKeys(response.Keys)
+	for _, pubkey := range response.MissingKeys {
+		fmt.Printf("%sSkipped validator %s because its key isn't in the node wallet.%s\n", colorYellow, pubkey.Hex(), colorReset)
+	}
+	fmt.Println("\nThe slashing protection history has been imported.")
+	return nil
+
+}
+
+// Print the status of each key after an import or export
+func printSlashingProtectionKeys(keys []api.SlashingProtectionKeyStatus) {
+	for _, key := range keys {
+		if key.Message != "" {
+			fmt.Printf("%s: %s (%s)\n", key.Pubkey.Hex(), key.Status, key.Message)
+		} else {
+			fmt.Printf("%s: %s\n", key.Pubkey.Hex(), key.Status)
+		}
+	}
+}
@@ -30,10 +30,18 @@ func getStatus(c *cli.Context) error {
 		return err
 	}
 
+	// Print the response as JSON if requested
+	if cliutils.IsJsonOutput() {
+		return cliutils.PrintJson(status)
+	}
+
 	// Print status & return
 	if status.WalletInitialized {
 		fmt.Println("The node wallet is initialized.")
 		fmt.Printf("Node account: %s\n", status.AccountAddress.Hex())
+		if status.HardwareWallet != "" {
+			fmt.Printf("The node account is on a %s hardware wallet. Transactions the daemons create are added to the signing queue; sign them with `rocketpool wallet sign-queue`.\n", status.HardwareWallet)
+		}
 	} else {
 		fmt.Println("The node wallet has not been initialized.")
 	}
@@ -23,6 +23,7 @@ import (
 
 const bold string = "\033[1m"
 const unbold string = "\033[0m"
+const TimeFormat = "2006-01-02, 15:04 -0700 MST"
 
 // Prompt for a wallet password
 func promptPassword() string {
@@ -105,7 +106,7 @@ func confirmMnemonic(mnemonic string) {
 func promptForCustomKeyPasswords(rp *rocketpool.Client, cfg *config.RocketPoolConfig, testOnly bool) (string, error) {
 
 	// Check for the custom key directory
-	datapath, err := homedir.Expand(cfg.Smartnode.DataPath.Value.(string))
+	datapath, err := homedir.Expand(cfg.Smartnode.GetDataPath())
 	if err != nil {
 		return "", fmt.Errorf("error expanding data directory: %w", err)
 	}
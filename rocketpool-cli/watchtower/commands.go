@@ -0,0 +1,37 @@
+package watchtower
+
+import (
+	"github.com/urfave/cli"
+
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// Register commands
+func RegisterCommands(app *cli.App, name string, aliases []string) {
+	app.Commands = append(app.Commands, cli.Command{
+		Name:    name,
+		Aliases: aliases,
+		Usage:   "Test the oracle DAO watchtower duties",
+		Subcommands: []cli.Command{
+
+			{
+				Name:    "simulate",
+				Aliases: []string{"s"},
+				Usage: "Run a watchtower task against the current chain state without submitting anything, and print exactly what it would submit. " +
+					"Valid tasks are respond-challenges, claim-rpl-rewards, submit-rpl-price, submit-network-balances, submit-withdrawable-minipools, dissolve-timed-out-minipools and submit-scrub-minipools.",
+				UsageText: "rocketpool watchtower simulate task",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+
+					// Run
+					return simulateTask(c, c.Args().Get(0))
+
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,40 @@
+package watchtower
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+func simulateTask(c *cli.Context, task string) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Check and assign the EC status
+	err = cliutils.CheckExecutionClientStatus(rp)
+	if err != nil {
+		return err
+	}
+
+	// Watchtower tasks only do anything for oracle DAO members
+	status, err := rp.TNDAOStatus()
+	if err != nil {
+		return err
+	}
+	if !status.IsMember {
+		fmt.Println("The node is not a member of the oracle DAO, so it doesn't perform watchtower duties.")
+		return nil
+	}
+
+	// Run the simulation
+	return rp.SimulateWatchtowerTask(task)
+
+}
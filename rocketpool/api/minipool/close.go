@@ -91,6 +91,10 @@ func closeMinipool(c *cli.Context, minipoolAddress common.Address) (*api.CloseMi
 	if err != nil {
 		return nil, err
 	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
 
 	// Response
 	response := api.CloseMinipoolResponse{}
@@ -120,6 +124,13 @@ func closeMinipool(c *cli.Context, minipoolAddress common.Address) (*api.CloseMi
 	}
 	response.TxHash = hash
 
+	// Remove the minipool's key from the Validator Client, since it won't validate again
+	if cfg.IsHotKeyLoadingEnabled() {
+		if err := unloadValidatorKey(rp, cfg, minipoolAddress); err != nil {
+			response.KeyUnloadError = err.Error()
+		}
+	}
+
 	// Return response
 	return &response, nil
 
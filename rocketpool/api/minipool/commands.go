@@ -209,6 +209,142 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 
 				},
 			},
+			{
+				Name:      "schedule-exit",
+				Usage:     "Sign a staking minipool's exit and have the node daemon broadcast it at an epoch",
+				UsageText: "rocketpool api minipool schedule-exit minipool-address epoch",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 2); err != nil {
+						return err
+					}
+					minipoolAddress, err := cliutils.ValidateAddress("minipool address", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+					epoch, err := cliutils.ValidateUint("epoch", c.Args().Get(1))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(scheduleExitMinipool(c, minipoolAddress, epoch))
+					return nil
+
+				},
+			},
+			{
+				Name:      "get-signed-exits",
+				Usage:     "Sign the exits of all of the node's staking minipools without broadcasting them",
+				UsageText: "rocketpool api minipool get-signed-exits",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getSignedExitMessages(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "can-begin-reduce-bond",
+				Usage:     "Check whether the minipool can begin reducing its bond",
+				UsageText: "rocketpool api minipool can-begin-reduce-bond minipool-address new-bond-amount-wei",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 2); err != nil {
+						return err
+					}
+					minipoolAddress, err := cliutils.ValidateAddress("minipool address", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+					newBond, err := cliutils.ValidatePositiveWeiAmount("new bond amount", c.Args().Get(1))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(canBeginReduceBondAmount(c, minipoolAddress, newBond))
+					return nil
+
+				},
+			},
+			{
+				Name:      "begin-reduce-bond",
+				Usage:     "Begin reducing the minipool's bond, which can be completed once the Oracle DAO has had time to check it",
+				UsageText: "rocketpool api minipool begin-reduce-bond minipool-address new-bond-amount-wei",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 2); err != nil {
+						return err
+					}
+					minipoolAddress, err := cliutils.ValidateAddress("minipool address", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+					newBond, err := cliutils.ValidatePositiveWeiAmount("new bond amount", c.Args().Get(1))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(beginReduceBondAmount(c, minipoolAddress, newBond))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "can-reduce-bond",
+				Usage:     "Check whether the minipool can complete its bond reduction",
+				UsageText: "rocketpool api minipool can-reduce-bond minipool-address",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					minipoolAddress, err := cliutils.ValidateAddress("minipool address", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(canReduceBondAmount(c, minipoolAddress))
+					return nil
+
+				},
+			},
+			{
+				Name:      "reduce-bond",
+				Usage:     "Complete the minipool's bond reduction",
+				UsageText: "rocketpool api minipool reduce-bond minipool-address",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					minipoolAddress, err := cliutils.ValidateAddress("minipool address", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(reduceBondAmount(c, minipoolAddress))
+					return nil
+
+				},
+			},
 
 			{
 				Name:      "can-close",
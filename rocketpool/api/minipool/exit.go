@@ -1,13 +1,19 @@
 package minipool
 
 import (
+	"fmt"
+	"os"
+
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
 	"github.com/rocket-pool/rocketpool-go/types"
 	"github.com/urfave/cli"
-	eth2types "github.com/wealdtech/go-eth2-types/v2"
 
 	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/exitqueue"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	"github.com/rocket-pool/smartnode/shared/types/api"
 	"github.com/rocket-pool/smartnode/shared/utils/validator"
 )
@@ -83,48 +89,201 @@ func exitMinipool(c *cli.Context, minipoolAddress common.Address) (*api.ExitMini
 	// Response
 	response := api.ExitMinipoolResponse{}
 
-	// Get minipool validator pubkey
-	validatorPubkey, err := minipool.GetMinipoolPubkey(rp, minipoolAddress, nil)
+	// Get beacon head
+	head, err := bc.GetBeaconHead()
 	if err != nil {
 		return nil, err
 	}
 
-	// Get validator private key
-	validatorKey, err := w.GetValidatorKeyByPubkey(validatorPubkey)
+	// Get signed voluntary exit message
+	_, validatorIndex, signature, err := getSignedExit(w, rp, bc, minipoolAddress, head.Epoch)
 	if err != nil {
 		return nil, err
 	}
 
-	// Get beacon head
+	// Broadcast voluntary exit message
+	if err := bc.ExitValidator(validatorIndex, head.Epoch, signature); err != nil {
+		return nil, err
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+func scheduleExitMinipool(c *cli.Context, minipoolAddress common.Address, epoch uint64) (*api.ScheduleExitMinipoolResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireBeaconClientSynced(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.ScheduleExitMinipoolResponse{}
+
+	// Make sure the exit is in the future
 	head, err := bc.GetBeaconHead()
 	if err != nil {
 		return nil, err
 	}
+	response.CurrentEpoch = head.Epoch
+	if epoch <= head.Epoch {
+		return nil, fmt.Errorf("Epoch %d has already started (the current epoch is %d); exit the minipool without a schedule instead.", epoch, head.Epoch)
+	}
 
-	// Get voluntary exit signature domain
-	signatureDomain, err := bc.GetDomainData(eth2types.DomainVoluntaryExit[:], head.Epoch)
+	// Check minipool status
+	mp, err := minipool.NewMinipool(rp, minipoolAddress)
 	if err != nil {
 		return nil, err
 	}
+	status, err := mp.GetStatus(nil)
+	if err != nil {
+		return nil, err
+	}
+	if status != types.Staking {
+		return nil, fmt.Errorf("Minipool %s is not staking.", minipoolAddress.Hex())
+	}
 
-	// Get validator index
-	validatorIndex, err := bc.GetValidatorIndex(validatorPubkey)
+	// Sign the exit for the scheduled epoch, since the Beacon Chain won't process it any earlier
+	validatorPubkey, validatorIndex, signature, err := getSignedExit(w, rp, bc, minipoolAddress, epoch)
 	if err != nil {
 		return nil, err
 	}
 
-	// Get signed voluntary exit message
-	signature, err := validator.GetSignedExitMessage(validatorKey, validatorIndex, head.Epoch, signatureDomain)
+	// Hand the exit to the node daemon
+	err = exitqueue.NewQueue(os.ExpandEnv(cfg.Smartnode.GetExitQueuePath())).Schedule(minipoolAddress, validatorPubkey, validatorIndex, epoch, signature)
 	if err != nil {
 		return nil, err
 	}
 
-	// Broadcast voluntary exit message
-	if err := bc.ExitValidator(validatorIndex, head.Epoch, signature); err != nil {
+	// Return response
+	return &response, nil
+
+}
+
+func getSignedExitMessages(c *cli.Context) (*api.GetSignedExitMessagesResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireBeaconClientSynced(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.GetSignedExitMessagesResponse{}
+
+	// Get the minipools with validators on the Beacon Chain
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	details, err := getNodeMinipoolDetails(rp, bc, nodeAccount.Address, cfg.Smartnode.GetMulticallAddress())
+	if err != nil {
+		return nil, err
+	}
+
+	// Sign the exits for the current epoch, so they can be broadcast any time after it
+	head, err := bc.GetBeaconHead()
+	if err != nil {
 		return nil, err
 	}
+	response.Epoch = head.Epoch
+	response.Exits = []api.SignedExitMessage{}
+	for _, mp := range details {
+		if mp.Status.Status != types.Staking || !mp.Validator.Exists {
+			continue
+		}
+		validatorPubkey, validatorIndex, signature, err := getSignedExit(w, rp, bc, mp.Address, head.Epoch)
+		if err != nil {
+			return nil, fmt.Errorf("Could not sign the exit of minipool %s: %w", mp.Address.Hex(), err)
+		}
+		response.Exits = append(response.Exits, api.SignedExitMessage{
+			MinipoolAddress: mp.Address,
+			ValidatorPubkey: validatorPubkey,
+			ValidatorIndex:  validatorIndex,
+			Epoch:           head.Epoch,
+			Signature:       signature,
+		})
+	}
 
 	// Return response
 	return &response, nil
 
 }
+
+// Get a minipool validator's voluntary exit message for an epoch, signed with its key
+func getSignedExit(w *wallet.Wallet, rp *rocketpool.RocketPool, bc beacon.Client, minipoolAddress common.Address, epoch uint64) (types.ValidatorPubkey, uint64, types.ValidatorSignature, error) {
+
+	// Get minipool validator pubkey
+	validatorPubkey, err := minipool.GetMinipoolPubkey(rp, minipoolAddress, nil)
+	if err != nil {
+		return types.ValidatorPubkey{}, 0, types.ValidatorSignature{}, err
+	}
+
+	// Get validator private key
+	validatorKey, err := w.GetValidatorKeyByPubkey(validatorPubkey)
+	if err != nil {
+		return types.ValidatorPubkey{}, 0, types.ValidatorSignature{}, err
+	}
+
+	// Get voluntary exit signature domain
+	eth2Config, err := bc.GetEth2Config()
+	if err != nil {
+		return types.ValidatorPubkey{}, 0, types.ValidatorSignature{}, err
+	}
+	signatureDomain, err := bc.GetDomainData(eth2Config.DomainVoluntaryExit, epoch)
+	if err != nil {
+		return types.ValidatorPubkey{}, 0, types.ValidatorSignature{}, err
+	}
+
+	// Get validator index
+	validatorIndex, err := bc.GetValidatorIndex(validatorPubkey)
+	if err != nil {
+		return types.ValidatorPubkey{}, 0, types.ValidatorSignature{}, err
+	}
+
+	// Get signed voluntary exit message
+	signature, err := validator.GetSignedExitMessage(validatorKey, validatorIndex, epoch, signatureDomain)
+	if err != nil {
+		return types.ValidatorPubkey{}, 0, types.ValidatorSignature{}, err
+	}
+	return validatorPubkey, validatorIndex, signature, nil
+
+}
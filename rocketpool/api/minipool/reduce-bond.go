@@ -0,0 +1,325 @@
+package minipool
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/node"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/urfave/cli"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/eth1"
+	rputils "github.com/rocket-pool/smartnode/shared/utils/rp"
+)
+
+func canBeginReduceBondAmount(c *cli.Context, minipoolAddress common.Address, newBond *big.Int) (*api.CanBeginReduceBondAmountResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.CanBeginReduceBondAmountResponse{}
+
+	// Create minipool
+	mp, err := minipool.NewMinipool(rp, minipoolAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate minipool owner
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	if err := validateMinipoolOwner(mp, nodeAccount.Address); err != nil {
+		return nil, err
+	}
+
+	// Check that the network supports bond reduction
+	reduction, err := rputils.GetBondReduction(rp, minipoolAddress, nil)
+	if errors.Is(err, rputils.ErrBondReductionNotSupported) {
+		response.NotSupported = true
+		return &response, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	response.AlreadyStarted = reduction.Started && !reduction.Cancelled
+
+	// Data
+	var wg errgroup.Group
+	var status types.MinipoolStatus
+
+	// Check minipool status
+	wg.Go(func() error {
+		var err error
+		status, err = mp.GetStatus(nil)
+		return err
+	})
+
+	// Get the current bond
+	wg.Go(func() error {
+		var err error
+		response.CurrentBond, err = mp.GetNodeDepositBalance(nil)
+		return err
+	})
+
+	// Get the node's RPL stake
+	wg.Go(func() error {
+		var err error
+		response.RplStake, err = node.GetNodeRPLStake(rp, nodeAccount.Address, nil)
+		return err
+	})
+
+	// Get the reduction window
+	wg.Go(func() error {
+		var err error
+		response.WindowStart, response.WindowLength, err = rputils.GetBondReductionWindow(rp, nil)
+		return err
+	})
+
+	// Wait for data
+	if err := wg.Wait(); err != nil {
+		return nil, err
+	}
+	response.InvalidStatus = (status != types.Staking)
+	response.InvalidBond = (newBond.Sign() <= 0 || newBond.Cmp(response.CurrentBond) >= 0)
+
+	// Check the RPL stake the node will need afterwards
+	if !response.InvalidBond {
+		response.MinimumRplStake, err = rputils.GetMinimumRplStakeAfterReduction(rp, nodeAccount.Address, response.CurrentBond, newBond, nil)
+		if err != nil {
+			return nil, err
+		}
+		response.InsufficientRplStake = (response.RplStake.Cmp(response.MinimumRplStake) < 0)
+	}
+
+	// Get gas estimate
+	opts, err := w.GetNodeAccountTransactor()
+	if err != nil {
+		return nil, err
+	}
+	gasInfo, err := rputils.EstimateBeginReduceBondAmountGas(rp, minipoolAddress, newBond, opts)
+	if err == nil {
+		response.GasInfo = gasInfo
+	}
+
+	// Update & return response
+	response.CanReduce = !(response.InvalidStatus || response.InvalidBond || response.AlreadyStarted || response.InsufficientRplStake)
+	return &response, nil
+
+}
+
+func beginReduceBondAmount(c *cli.Context, minipoolAddress common.Address, newBond *big.Int) (*api.BeginReduceBondAmountResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.BeginReduceBondAmountResponse{}
+
+	// Get transactor
+	opts, err := w.GetNodeAccountTransactor()
+	if err != nil {
+		return nil, err
+	}
+
+	// Override the provided pending TX if requested
+	err = eth1.CheckForNonceOverride(c, opts)
+	if err != nil {
+		return nil, fmt.Errorf("Error checking for nonce override: %w", err)
+	}
+
+	// Begin the bond reduction
+	hash, err := rputils.BeginReduceBondAmount(rp, minipoolAddress, newBond, opts)
+	if err != nil {
+		return nil, err
+	}
+	response.TxHash = hash
+
+	// Return response
+	return &response, nil
+
+}
+
+func canReduceBondAmount(c *cli.Context, minipoolAddress common.Address) (*api.CanReduceBondAmountResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.CanReduceBondAmountResponse{}
+
+	// Create minipool
+	mp, err := minipool.NewMinipool(rp, minipoolAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate minipool owner
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	if err := validateMinipoolOwner(mp, nodeAccount.Address); err != nil {
+		return nil, err
+	}
+
+	// Get the bond reduction the minipool started
+	reduction, err := rputils.GetBondReduction(rp, minipoolAddress, nil)
+	if errors.Is(err, rputils.ErrBondReductionNotSupported) {
+		response.NotSupported = true
+		return &response, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	response.NotStarted = !reduction.Started
+	response.Cancelled = reduction.Cancelled
+	response.NewBond = reduction.NewBond
+	if response.NotStarted || response.Cancelled {
+		return &response, nil
+	}
+
+	// Data
+	var wg errgroup.Group
+	var windowStart, windowLength time.Duration
+	var canReduce bool
+
+	// Get the current bond
+	wg.Go(func() error {
+		var err error
+		response.CurrentBond, err = mp.GetNodeDepositBalance(nil)
+		return err
+	})
+
+	// Get the node's RPL stake
+	wg.Go(func() error {
+		var err error
+		response.RplStake, err = node.GetNodeRPLStake(rp, nodeAccount.Address, nil)
+		return err
+	})
+
+	// Get the reduction window
+	wg.Go(func() error {
+		var err error
+		windowStart, windowLength, err = rputils.GetBondReductionWindow(rp, nil)
+		return err
+	})
+
+	// Check if the window is open
+	wg.Go(func() error {
+		var err error
+		canReduce, err = rputils.CanReduceBondAmount(rp, minipoolAddress, nil)
+		return err
+	})
+
+	// Wait for data
+	if err := wg.Wait(); err != nil {
+		return nil, err
+	}
+	response.WindowOpenTime = reduction.StartTime.Add(windowStart)
+	response.WindowCloseTime = response.WindowOpenTime.Add(windowLength)
+	response.WindowClosed = !canReduce
+
+	// Check the RPL stake the node will need afterwards
+	response.MinimumRplStake, err = rputils.GetMinimumRplStakeAfterReduction(rp, nodeAccount.Address, response.CurrentBond, response.NewBond, nil)
+	if err != nil {
+		return nil, err
+	}
+	response.InsufficientRplStake = (response.RplStake.Cmp(response.MinimumRplStake) < 0)
+
+	// Get gas estimate
+	opts, err := w.GetNodeAccountTransactor()
+	if err != nil {
+		return nil, err
+	}
+	gasInfo, err := rputils.EstimateReduceBondAmountGas(rp, minipoolAddress, opts)
+	if err == nil {
+		response.GasInfo = gasInfo
+	}
+
+	// Update & return response
+	response.CanReduce = !(response.WindowClosed || response.InsufficientRplStake)
+	return &response, nil
+
+}
+
+func reduceBondAmount(c *cli.Context, minipoolAddress common.Address) (*api.ReduceBondAmountResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.ReduceBondAmountResponse{}
+
+	// Get transactor
+	opts, err := w.GetNodeAccountTransactor()
+	if err != nil {
+		return nil, err
+	}
+
+	// Override the provided pending TX if requested
+	err = eth1.CheckForNonceOverride(c, opts)
+	if err != nil {
+		return nil, fmt.Errorf("Error checking for nonce override: %w", err)
+	}
+
+	// Reduce the bond
+	hash, err := rputils.ReduceBondAmount(rp, minipoolAddress, opts)
+	if err != nil {
+		return nil, err
+	}
+	response.TxHash = hash
+
+	// Return response
+	return &response, nil
+
+}
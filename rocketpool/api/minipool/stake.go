@@ -198,6 +198,11 @@ func stakeMinipool(c *cli.Context, minipoolAddress common.Address) (*api.StakeMi
 		return nil, err
 	}
 
+	// Make sure the deposit contract will accept the deposit data root
+	if err := validator.VerifyDepositDataRoot(depositData, depositDataRoot); err != nil {
+		return nil, fmt.Errorf("Your deposit data failed the validation safety check: %w\nFor your safety, the minipool will not be staked.\nPLEASE REPORT THIS TO THE ROCKET POOL DEVELOPERS.", err)
+	}
+
 	// Stake the minipool
 	signature := rptypes.BytesToValidatorSignature(depositData.Signature)
 	hash, err := mp.Stake(signature, depositDataRoot, opts)
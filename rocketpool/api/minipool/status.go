@@ -2,10 +2,12 @@ package minipool
 
 import (
 	"fmt"
+	"os"
 
 	"github.com/urfave/cli"
 
 	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/exitqueue"
 	"github.com/rocket-pool/smartnode/shared/types/api"
 )
 
@@ -30,6 +32,10 @@ func getStatus(c *cli.Context) (*api.MinipoolStatusResponse, error) {
 	if err != nil {
 		return nil, err
 	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
 
 	// Response
 	response := api.MinipoolStatusResponse{}
@@ -39,12 +45,25 @@ func getStatus(c *cli.Context) (*api.MinipoolStatusResponse, error) {
 	if err != nil {
 		return nil, err
 	}
-	details, err := getNodeMinipoolDetails(rp, bc, nodeAccount.Address)
+	details, err := getNodeMinipoolDetails(rp, bc, nodeAccount.Address, cfg.Smartnode.GetMulticallAddress())
 	if err != nil {
 		return nil, err
 	}
 	response.Minipools = details
 
+	// Add the exits the node daemon is holding
+	scheduledExits, err := exitqueue.NewQueue(os.ExpandEnv(cfg.Smartnode.GetExitQueuePath())).List()
+	if err != nil {
+		return nil, err
+	}
+	for _, exit := range scheduledExits {
+		for i := range response.Minipools {
+			if response.Minipools[i].Address == exit.MinipoolAddress {
+				response.Minipools[i].ScheduledExit = exit.GetDetails()
+			}
+		}
+	}
+
 	delegate, err := rp.GetContract("rocketMinipoolDelegate")
 	if err != nil {
 		return nil, fmt.Errorf("Error getting latest minipool delegate contract: %w", err)
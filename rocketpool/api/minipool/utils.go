@@ -18,8 +18,11 @@ import (
 	"golang.org/x/sync/errgroup"
 
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/keymanager"
 	"github.com/rocket-pool/smartnode/shared/types/api"
 	"github.com/rocket-pool/smartnode/shared/utils/eth2"
+	"github.com/rocket-pool/smartnode/shared/utils/multicall"
 	rputils "github.com/rocket-pool/smartnode/shared/utils/rp"
 )
 
@@ -38,8 +41,9 @@ func validateMinipoolOwner(mp *minipool.Minipool, nodeAddress common.Address) er
 	return nil
 }
 
-// Get all node minipool details
-func getNodeMinipoolDetails(rp *rocketpool.RocketPool, bc beacon.Client, nodeAddress common.Address) ([]api.MinipoolDetails, error) {
+// Get all node minipool details.
+// If the network has a Multicall3 contract, the minipools' contract calls are batched with it instead of being made one at a time.
+func getNodeMinipoolDetails(rp *rocketpool.RocketPool, bc beacon.Client, nodeAddress common.Address, multicallAddress string) ([]api.MinipoolDetails, error) {
 
 	// Data
 	var wg1 errgroup.Group
@@ -85,12 +89,83 @@ func getNodeMinipoolDetails(rp *rocketpool.RocketPool, bc beacon.Client, nodeAdd
 		return []api.MinipoolDetails{}, err
 	}
 
-	// Get minipool validator statuses
-	validators, err := rputils.GetMinipoolValidators(rp, bc, addresses, nil, nil)
+	// Get minipool validator statuses, and load the contract details at the same time if they can be batched
+	var wg2 errgroup.Group
+	var validators map[common.Address]beacon.ValidatorStatus
+	var details []api.MinipoolDetails
+	var mc *multicall.MultiCaller
+	wg2.Go(func() error {
+		var err error
+		validators, err = rputils.GetMinipoolValidators(rp, bc, addresses, nil, nil)
+		return err
+	})
+	if multicallAddress != "" {
+		wg2.Go(func() error {
+			var err error
+			mc, err = multicall.NewMultiCaller(rp.Client, common.HexToAddress(multicallAddress))
+			if err != nil {
+				return err
+			}
+			details, err = getMinipoolContractDetails(rp, mc, addresses)
+			return err
+		})
+	}
+	if err := wg2.Wait(); err != nil {
+		return []api.MinipoolDetails{}, err
+	}
+
+	// Load the rest of the details
+	var err error
+	if mc != nil {
+		err = setMinipoolValidatorDetails(rp, mc, details, validators, currentEpoch)
+	} else {
+		details, err = getMinipoolDetailsBatched(rp, addresses, validators, eth2Config, currentEpoch, currentBlock)
+	}
 	if err != nil {
 		return []api.MinipoolDetails{}, err
 	}
 
+	// Get the scrub period
+	scrubPeriodSeconds, err := trustednode.GetScrubPeriod(rp, nil)
+	if err != nil {
+		return nil, err
+	}
+	scrubPeriod := time.Duration(scrubPeriodSeconds) * time.Second
+
+	// Get the dissolve timeout
+	timeout, err := protocol.GetMinipoolLaunchTimeout(rp, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the time of the latest block
+	latestEth1Block, err := rp.Client.HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("Can't get the latest block time: %w", err)
+	}
+	latestBlockTime := time.Unix(int64(latestEth1Block.Time), 0)
+
+	// Check the stake status of each minipool
+	for i, mpDetails := range details {
+		if mpDetails.Status.Status == types.Prelaunch {
+			creationTime := mpDetails.Status.StatusTime
+			dissolveTime := creationTime.Add(timeout)
+			remainingTime := creationTime.Add(scrubPeriod).Sub(latestBlockTime)
+			if remainingTime < 0 {
+				details[i].CanStake = true
+				details[i].TimeUntilDissolve = time.Until(dissolveTime)
+			}
+		}
+	}
+
+	// Return
+	return details, nil
+
+}
+
+// Get the details of minipools in batches, making each minipool's calls separately
+func getMinipoolDetailsBatched(rp *rocketpool.RocketPool, addresses []common.Address, validators map[common.Address]beacon.ValidatorStatus, eth2Config beacon.Eth2Config, currentEpoch, currentBlock uint64) ([]api.MinipoolDetails, error) {
+
 	// Load details in batches
 	details := make([]api.MinipoolDetails, len(addresses))
 	for bsi := 0; bsi < len(addresses); bsi += MinipoolDetailsBatchSize {
@@ -121,45 +196,127 @@ func getNodeMinipoolDetails(rp *rocketpool.RocketPool, bc beacon.Client, nodeAdd
 		}
 
 	}
+	return details, nil
 
-	// Get the scrub period
-	scrubPeriodSeconds, err := trustednode.GetScrubPeriod(rp, nil)
+}
+
+// The results of a minipool's calls that need to be converted before they're added to its details
+type minipoolCallResults struct {
+	pubkey                  []byte
+	status                  uint8
+	statusBlock             *big.Int
+	statusTime              *big.Int
+	depositType             uint8
+	nodeFee                 *big.Int
+	userDepositAssignedTime *big.Int
+}
+
+// Get the details of minipools that come from their contracts, batching all of their calls together
+func getMinipoolContractDetails(rp *rocketpool.RocketPool, mc *multicall.MultiCaller, addresses []common.Address) ([]api.MinipoolDetails, error) {
+
+	// Get contracts
+	rocketMinipoolManager, err := rp.GetContract("rocketMinipoolManager")
 	if err != nil {
 		return nil, err
 	}
-	scrubPeriod := time.Duration(scrubPeriodSeconds) * time.Second
-
-	// Get the dissolve timeout
-	timeout, err := protocol.GetMinipoolLaunchTimeout(rp, nil)
+	rocketTokenRETH, err := rp.GetContract("rocketTokenRETH")
 	if err != nil {
 		return nil, err
 	}
-
-	// Get the time of the latest block
-	latestEth1Block, err := rp.Client.HeaderByNumber(context.Background(), nil)
+	rocketTokenRPL, err := rp.GetContract("rocketTokenRPL")
 	if err != nil {
-		return nil, fmt.Errorf("Can't get the latest block time: %w", err)
+		return nil, err
+	}
+	rocketTokenRPLFixedSupply, err := rp.GetContract("rocketTokenRPLFixedSupply")
+	if err != nil {
+		return nil, err
 	}
-	latestBlockTime := time.Unix(int64(latestEth1Block.Time), 0)
 
-	// Check the stake status of each minipool
-	for i, mpDetails := range details {
-		if mpDetails.Status.Status == types.Prelaunch {
-			creationTime := mpDetails.Status.StatusTime
-			dissolveTime := creationTime.Add(timeout)
-			remainingTime := creationTime.Add(scrubPeriod).Sub(latestBlockTime)
-			if remainingTime < 0 {
-				details[i].CanStake = true
-				details[i].TimeUntilDissolve = time.Until(dissolveTime)
-			}
+	// Queue the calls for each minipool
+	details := make([]api.MinipoolDetails, len(addresses))
+	results := make([]minipoolCallResults, len(addresses))
+	for i, address := range addresses {
+		mp, err := minipool.NewMinipool(rp, address)
+		if err != nil {
+			return nil, err
 		}
+		mpDetails := &details[i]
+		mpResults := &results[i]
+		mpDetails.Address = address
+
+		mc.AddCall(rocketMinipoolManager, &mpResults.pubkey, "getMinipoolPubkey", address)
+		mc.AddCall(mp.Contract, &mpResults.status, "getStatus")
+		mc.AddCall(mp.Contract, &mpResults.statusBlock, "getStatusBlock")
+		mc.AddCall(mp.Contract, &mpResults.statusTime, "getStatusTime")
+		mc.AddCall(mp.Contract, &mpResults.depositType, "getDepositType")
+		mc.AddCall(mp.Contract, &mpDetails.Node.Address, "getNodeAddress")
+		mc.AddCall(mp.Contract, &mpResults.nodeFee, "getNodeFee")
+		mc.AddCall(mp.Contract, &mpDetails.Node.DepositBalance, "getNodeDepositBalance")
+		mc.AddCall(mp.Contract, &mpDetails.Node.RefundBalance, "getNodeRefundBalance")
+		mc.AddCall(mp.Contract, &mpDetails.Node.DepositAssigned, "getNodeDepositAssigned")
+		mc.AddCall(mp.Contract, &mpDetails.User.DepositBalance, "getUserDepositBalance")
+		mc.AddCall(mp.Contract, &mpDetails.User.DepositAssigned, "getUserDepositAssigned")
+		mc.AddCall(mp.Contract, &mpResults.userDepositAssignedTime, "getUserDepositAssignedTime")
+		mc.AddEthBalance(address, &mpDetails.Balances.ETH)
+		mc.AddCall(rocketTokenRETH, &mpDetails.Balances.RETH, "balanceOf", address)
+		mc.AddCall(rocketTokenRPL, &mpDetails.Balances.RPL, "balanceOf", address)
+		mc.AddCall(rocketTokenRPLFixedSupply, &mpDetails.Balances.FixedSupplyRPL, "balanceOf", address)
+		mc.AddCall(mp.Contract, &mpDetails.UseLatestDelegate, "getUseLatestDelegate")
+		mc.AddCall(mp.Contract, &mpDetails.Delegate, "getDelegate")
+		mc.AddCall(mp.Contract, &mpDetails.PreviousDelegate, "getPreviousDelegate")
+		mc.AddCall(mp.Contract, &mpDetails.EffectiveDelegate, "getEffectiveDelegate")
+		mc.AddCall(mp.Contract, &mpDetails.Finalised, "getFinalised")
+	}
+	if err := mc.Execute(nil); err != nil {
+		return nil, fmt.Errorf("Could not get minipool details: %w", err)
 	}
 
-	// Return
+	// Convert the rest of the results
+	for i := range details {
+		mpResults := results[i]
+		details[i].ValidatorPubkey = types.BytesToValidatorPubkey(mpResults.pubkey)
+		details[i].Status = minipool.StatusDetails{
+			Status:      types.MinipoolStatus(mpResults.status),
+			StatusBlock: mpResults.statusBlock.Uint64(),
+			StatusTime:  time.Unix(mpResults.statusTime.Int64(), 0),
+		}
+		details[i].DepositType = types.MinipoolDeposit(mpResults.depositType)
+		details[i].Node.Fee = eth.WeiToEth(mpResults.nodeFee)
+		details[i].User.DepositAssignedTime = time.Unix(mpResults.userDepositAssignedTime.Int64(), 0)
+		setMinipoolActions(&details[i])
+	}
 	return details, nil
 
 }
 
+// Set the validator details of staking minipools, batching the calls for the node's share of their balances together
+func setMinipoolValidatorDetails(rp *rocketpool.RocketPool, mc *multicall.MultiCaller, details []api.MinipoolDetails, validators map[common.Address]beacon.ValidatorStatus, currentEpoch uint64) error {
+
+	for i := range details {
+		if details[i].Status.Status != types.Staking {
+			continue
+		}
+		validator := validators[details[i].Address]
+		validatorDetails, validatorActivated := getValidatorStatusDetails(details[i], validator, currentEpoch)
+		details[i].Validator = validatorDetails
+		if validatorActivated {
+			mp, err := minipool.NewMinipool(rp, details[i].Address)
+			if err != nil {
+				return err
+			}
+			mc.AddCall(mp.Contract, &details[i].Validator.NodeBalance, "calculateNodeShare", validatorDetails.Balance)
+		}
+	}
+	if mc.Count() == 0 {
+		return nil
+	}
+	if err := mc.Execute(nil); err != nil {
+		return fmt.Errorf("Could not get minipool node balances: %w", err)
+	}
+	return nil
+
+}
+
 // Get a minipool's details
 func getMinipoolDetails(rp *rocketpool.RocketPool, minipoolAddress common.Address, validator beacon.ValidatorStatus, eth2Config beacon.Eth2Config, currentEpoch, currentBlock uint64) (api.MinipoolDetails, error) {
 
@@ -245,13 +402,18 @@ func getMinipoolDetails(rp *rocketpool.RocketPool, minipoolAddress common.Addres
 	}
 
 	// Update & return
+	setMinipoolActions(&details)
+	return details, nil
+
+}
+
+// Set the actions that are available for a minipool
+func setMinipoolActions(details *api.MinipoolDetails) {
 	details.RefundAvailable = (details.Node.RefundBalance.Cmp(big.NewInt(0)) > 0)
 	details.CloseAvailable = (details.Status.Status == types.Dissolved)
 	if details.Status.Status == types.Withdrawable {
 		details.WithdrawalAvailable = true
 	}
-	return details, nil
-
 }
 
 // Get a minipool's validator details
@@ -263,6 +425,37 @@ func getMinipoolValidatorDetails(rp *rocketpool.RocketPool, minipoolDetails api.
 		return api.ValidatorDetails{}, err
 	}
 
+	// Use deposit balances if validator not activated
+	details, validatorActivated := getValidatorStatusDetails(minipoolDetails, validator, currentEpoch)
+	if !validatorActivated {
+		return details, nil
+	}
+
+	// Get start epoch for expected node balance calculation
+	startEpoch := eth2.EpochAt(eth2Config, uint64(minipoolDetails.User.DepositAssignedTime.Unix()))
+	if startEpoch < validator.ActivationEpoch {
+		startEpoch = validator.ActivationEpoch
+	} else if startEpoch > currentEpoch {
+		startEpoch = currentEpoch
+	}
+
+	// Get expected node balance
+	blockBalance := eth.GweiToWei(float64(validator.Balance))
+	nodeBalance, err := mp.CalculateNodeShare(blockBalance, nil)
+	if err != nil {
+		return api.ValidatorDetails{}, err
+	}
+	details.NodeBalance = nodeBalance
+
+	// Return
+	return details, nil
+
+}
+
+// Get a minipool's validator status details and balance, and whether it's been activated.
+// If it has, the node's share of its balance still needs to be calculated by the minipool.
+func getValidatorStatusDetails(minipoolDetails api.MinipoolDetails, validator beacon.ValidatorStatus, currentEpoch uint64) (api.ValidatorDetails, bool) {
+
 	// Validator details
 	details := api.ValidatorDetails{}
 
@@ -281,29 +474,24 @@ func getMinipoolValidatorDetails(rp *rocketpool.RocketPool, minipoolDetails api.
 		details.Balance.Add(minipoolDetails.Node.DepositBalance, minipoolDetails.User.DepositBalance)
 		details.NodeBalance = new(big.Int)
 		details.NodeBalance.Set(minipoolDetails.Node.DepositBalance)
-		return details, nil
+		return details, false
 	}
 
 	// Set validator balance
 	details.Balance = eth.GweiToWei(float64(validator.Balance))
+	return details, true
 
-	// Get start epoch for expected node balance calculation
-	startEpoch := eth2.EpochAt(eth2Config, uint64(minipoolDetails.User.DepositAssignedTime.Unix()))
-	if startEpoch < validator.ActivationEpoch {
-		startEpoch = validator.ActivationEpoch
-	} else if startEpoch > currentEpoch {
-		startEpoch = currentEpoch
-	}
+}
 
-	// Get expected node balance
-	blockBalance := eth.GweiToWei(float64(validator.Balance))
-	nodeBalance, err := mp.CalculateNodeShare(blockBalance, nil)
+// Remove a minipool's validator key from the Validator Client through its Keymanager API
+func unloadValidatorKey(rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, minipoolAddress common.Address) error {
+	validatorPubkey, err := minipool.GetMinipoolPubkey(rp, minipoolAddress, nil)
 	if err != nil {
-		return api.ValidatorDetails{}, err
+		return err
 	}
-	details.NodeBalance = nodeBalance
-
-	// Return
-	return details, nil
-
+	km, err := keymanager.NewClient(cfg.Smartnode.GetKeymanagerApiUrl(), cfg.Smartnode.GetKeymanagerTokenPath(), cfg.Smartnode.GetKeymanagerCertPath())
+	if err != nil {
+		return err
+	}
+	return km.UnloadKeys([]types.ValidatorPubkey{validatorPubkey})
 }
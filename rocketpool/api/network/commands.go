@@ -90,6 +90,95 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 
 				},
 			},
+
+			{
+				Name:      "gas-prices",
+				Aliases:   []string{"g"},
+				Usage:     "Get the max fee suggestions of the configured gas oracle",
+				UsageText: "rocketpool api network gas-prices",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getGasPrices(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "rate-history",
+				Usage:     "Get the rETH exchange rates and RPL prices the node daemon has recorded since a unix timestamp (0 for all of them)",
+				UsageText: "rocketpool api network rate-history since",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					since, err := cliutils.ValidateUint("since", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getRateHistory(c, since))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "generate-rewards-tree",
+				Usage:     "Generate the rewards tree for an interval from the chain's state and verify it against the canonical Merkle root, saving it if it matches and execute is true",
+				UsageText: "rocketpool api network generate-rewards-tree index execute",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 2); err != nil {
+						return err
+					}
+					index, err := cliutils.ValidateUint("index", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+					execute, err := cliutils.ValidateBool("execute", c.Args().Get(1))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(generateRewardsTree(c, index, execute))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "download-rewards-tree",
+				Usage:     "Download the rewards tree for an interval from the configured sources, verify it against the canonical Merkle root, and save it",
+				UsageText: "rocketpool api network download-rewards-tree index",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					index, err := cliutils.ValidateUint("index", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(downloadRewardsTree(c, index))
+					return nil
+
+				},
+			},
 		},
 	})
 }
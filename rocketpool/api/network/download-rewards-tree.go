@@ -0,0 +1,67 @@
+package network
+
+import (
+	"os"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	apiutils "github.com/rocket-pool/smartnode/shared/utils/api"
+)
+
+func downloadRewardsTree(c *cli.Context, index uint64) (*api.NetworkDownloadRewardsTreeResponse, error) {
+
+	// Get services
+	if err := services.RequireRocketStorage(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireEthClientSynced(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NetworkDownloadRewardsTreeResponse{
+		Index: index,
+	}
+
+	// Get the snapshot event with the interval's CID and canonical root
+	scanner, err := apiutils.GetEventLogScanner(cfg, rp.Client)
+	if err != nil {
+		return nil, err
+	}
+	dataCache, err := services.GetCache(c)
+	if err != nil {
+		return nil, err
+	}
+	event, err := rewards.GetRewardsEvent(rp, string(cfg.Smartnode.GetNetwork()), index, scanner, dataCache)
+	if err != nil {
+		return nil, err
+	}
+
+	// Download and save the file
+	file, source, err := rewards.DownloadRewardsFile(cfg, event)
+	if err != nil {
+		return nil, err
+	}
+	folder := os.ExpandEnv(cfg.Smartnode.GetRewardsTreePath())
+	if err := rewards.SaveRewardsFile(folder, file); err != nil {
+		return nil, err
+	}
+	response.Source = source.Name
+	response.Url = source.Url
+	response.Path = rewards.GetRewardsFilePath(folder, file.Network, index)
+
+	// Return response
+	return &response, nil
+
+}
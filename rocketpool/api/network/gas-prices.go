@@ -0,0 +1,45 @@
+package network
+
+import (
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/gas"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func getGasPrices(c *cli.Context) (*api.GasPricesResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.GasPricesResponse{}
+
+	// Get the suggestions
+	oracle := gas.NewGasOracle(cfg, ec, nil)
+	gasSuggestion, err := oracle.GetGasPrices()
+	if err != nil {
+		return nil, err
+	}
+	response.OracleName = oracle.GetName()
+	response.RapidWei = gasSuggestion.RapidWei
+	response.RapidTime = gasSuggestion.RapidTime
+	response.FastWei = gasSuggestion.FastWei
+	response.FastTime = gasSuggestion.FastTime
+	response.StandardWei = gasSuggestion.StandardWei
+	response.StandardTime = gasSuggestion.StandardTime
+	response.SlowWei = gasSuggestion.SlowWei
+	response.SlowTime = gasSuggestion.SlowTime
+
+	// Return response
+	return &response, nil
+
+}
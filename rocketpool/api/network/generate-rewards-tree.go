@@ -0,0 +1,93 @@
+package network
+
+import (
+	"os"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	apiutils "github.com/rocket-pool/smartnode/shared/utils/api"
+)
+
+func generateRewardsTree(c *cli.Context, index uint64, execute bool) (*api.NetworkGenerateRewardsTreeResponse, error) {
+
+	// Get services
+	if err := services.RequireRocketStorage(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireEthClientSynced(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireBeaconClientSynced(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NetworkGenerateRewardsTreeResponse{
+		Index: index,
+	}
+
+	// Get the snapshot events for the interval and the one before it
+	scanner, err := apiutils.GetEventLogScanner(cfg, rp.Client)
+	if err != nil {
+		return nil, err
+	}
+	dataCache, err := services.GetCache(c)
+	if err != nil {
+		return nil, err
+	}
+	network := string(cfg.Smartnode.GetNetwork())
+	event, err := rewards.GetRewardsEvent(rp, network, index, scanner, dataCache)
+	if err != nil {
+		return nil, err
+	}
+	var previous *rewards.RewardsEvent
+	if index > 0 {
+		previousEvent, err := rewards.GetRewardsEvent(rp, network, index-1, scanner, dataCache)
+		if err != nil {
+			return nil, err
+		}
+		previous = &previousEvent
+	}
+	response.ExecutionEndBlock = event.Submission.ExecutionBlock.Uint64()
+	response.ConsensusEndBlock = event.Submission.ConsensusBlock.Uint64()
+	response.CanonicalRoot = common.Hash(event.Submission.MerkleRoot).Hex()
+
+	// Generate the tree and verify it
+	file, err := rewards.GenerateRewardsFile(rp, bc, network, event, previous, os.ExpandEnv(cfg.Smartnode.GetRewardsSnapshotPath()))
+	if err != nil {
+		return nil, err
+	}
+	response.NodeCount = len(file.NodeRewards)
+	response.MerkleRoot = file.MerkleRoot
+	response.RootMatches = (file.MerkleRoot == response.CanonicalRoot)
+
+	// Only save trees that match the canonical root, since claims with any other tree would fail
+	folder := os.ExpandEnv(cfg.Smartnode.GetRewardsTreePath())
+	response.Path = rewards.GetRewardsFilePath(folder, network, index)
+	if execute && response.RootMatches {
+		if err := rewards.SaveRewardsFile(folder, file); err != nil {
+			return nil, err
+		}
+		response.Saved = true
+	}
+
+	// Return response
+	return &response, nil
+
+}
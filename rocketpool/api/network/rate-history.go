@@ -0,0 +1,37 @@
+package network
+
+import (
+	"os"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/ratehistory"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func getRateHistory(c *cli.Context, since uint64) (*api.NetworkRateHistoryResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NetworkRateHistoryResponse{}
+	response.RetentionDays = cfg.Smartnode.GetRateHistoryRetention()
+
+	// Get the samples the node daemon has recorded
+	history := ratehistory.NewHistory(os.ExpandEnv(cfg.Smartnode.GetRateHistoryPath()))
+	samples, err := history.List(time.Unix(int64(since), 0))
+	if err != nil {
+		return nil, err
+	}
+	response.Samples = samples
+
+	// Return response
+	return &response, nil
+
+}
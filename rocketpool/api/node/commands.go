@@ -34,6 +34,24 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "gas-threshold-recommendation",
+				Usage:     "Recommend the gas thresholds for automatic RPL claims and minipool stakes based on the node's minipool count and recent gas prices",
+				UsageText: "rocketpool api node gas-threshold-recommendation",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getGasThresholdRecommendation(c))
+					return nil
+
+				},
+			},
+
 			{
 				Name:      "sync",
 				Aliases:   []string{"y"},
@@ -53,6 +71,25 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "performance",
+				Aliases:   []string{"p"},
+				Usage:     "Get the attestation performance of the node's validators",
+				UsageText: "rocketpool api node performance",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getPerformance(c))
+					return nil
+
+				},
+			},
+
 			{
 				Name:      "can-register",
 				Usage:     "Check whether the node can be registered with Rocket Pool",
@@ -230,6 +267,76 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "can-join-smoothing-pool",
+				Usage:     "Check whether the node can opt the node into the smoothing pool",
+				UsageText: "rocketpool api node can-join-smoothing-pool",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(canSetSmoothingPoolStatus(c, true))
+					return nil
+
+				},
+			},
+			{
+				Name:      "join-smoothing-pool",
+				Usage:     "Opt the node into the smoothing pool",
+				UsageText: "rocketpool api node join-smoothing-pool",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(setSmoothingPoolStatus(c, true))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "can-leave-smoothing-pool",
+				Usage:     "Check whether the node can opt the node out of the smoothing pool",
+				UsageText: "rocketpool api node can-leave-smoothing-pool",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(canSetSmoothingPoolStatus(c, false))
+					return nil
+
+				},
+			},
+			{
+				Name:      "leave-smoothing-pool",
+				Usage:     "Opt the node out of the smoothing pool",
+				UsageText: "rocketpool api node leave-smoothing-pool",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(setSmoothingPoolStatus(c, false))
+					return nil
+
+				},
+			},
+
 			{
 				Name:      "can-swap-rpl",
 				Usage:     "Check whether the node can swap old RPL for new RPL",
@@ -755,6 +862,60 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "rewards-history",
+				Usage:     "Get the node's realized rewards and APR for each past rewards interval",
+				UsageText: "rocketpool api node rewards-history",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getRewardsHistory(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "todo",
+				Usage:     "Get the node's unclaimed rewards and the minipools that are ready to stake",
+				UsageText: "rocketpool api node todo",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getTodo(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "tx-queue",
+				Usage:     "Get the transactions that the node daemon is submitting or retrying",
+				UsageText: "rocketpool api node tx-queue",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getTxQueue(c))
+					return nil
+
+				},
+			},
+
 			{
 				Name:      "deposit-contract-info",
 				Usage:     "Get information about the deposit contract specified by Rocket Pool and the Beacon Chain client",
@@ -871,6 +1032,150 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 
 				},
 			},
+
+			{
+				Name:      "tx-doctor-status",
+				Usage:     "Get the node account's nonces and transactions in the mempool",
+				UsageText: "rocketpool api node tx-doctor-status",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getTxDoctorStatus(c))
+					return nil
+
+				},
+			},
+			{
+				Name:      "fill-nonce-gap",
+				Usage:     "Fill a missing nonce with a self-transfer of 0 ETH",
+				UsageText: "rocketpool api node fill-nonce-gap nonce",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					nonce, err := cliutils.ValidateUint("nonce", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(fillNonceGap(c, nonce))
+					return nil
+
+				},
+			},
+			{
+				Name:      "replace-tx",
+				Usage:     "Resubmit a pending transaction with a higher fee",
+				UsageText: "rocketpool api node replace-tx nonce",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					nonce, err := cliutils.ValidateUint("nonce", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(replaceTx(c, nonce))
+					return nil
+
+				},
+			},
+			{
+				Name:      "can-speed-up-tx",
+				Usage:     "Check whether a pending node transaction can be sped up",
+				UsageText: "rocketpool api node can-speed-up-tx tx-hash",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					hash, err := cliutils.ValidateTxHash("tx-hash", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(canReplaceTx(c, hash, false))
+					return nil
+
+				},
+			},
+			{
+				Name:      "speed-up-tx",
+				Usage:     "Resubmit a pending node transaction with a higher max fee",
+				UsageText: "rocketpool api node speed-up-tx tx-hash",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					hash, err := cliutils.ValidateTxHash("tx-hash", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(speedUpTx(c, hash))
+					return nil
+
+				},
+			},
+			{
+				Name:      "can-cancel-tx",
+				Usage:     "Check whether a pending node transaction can be cancelled",
+				UsageText: "rocketpool api node can-cancel-tx tx-hash",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					hash, err := cliutils.ValidateTxHash("tx-hash", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(canReplaceTx(c, hash, true))
+					return nil
+
+				},
+			},
+			{
+				Name:      "cancel-tx",
+				Usage:     "Cancel a pending node transaction by replacing it with a self-transfer of 0 ETH",
+				UsageText: "rocketpool api node cancel-tx tx-hash",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					hash, err := cliutils.ValidateTxHash("tx-hash", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(cancelTx(c, hash))
+					return nil
+
+				},
+			},
 		},
 	})
 }
@@ -8,7 +8,6 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/prysmaticlabs/prysm/v2/beacon-chain/core/signing"
 	tndao "github.com/rocket-pool/rocketpool-go/dao/trustednode"
 	"github.com/rocket-pool/rocketpool-go/minipool"
 	"github.com/rocket-pool/rocketpool-go/network"
@@ -26,9 +25,9 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/types/eth2"
 	"github.com/rocket-pool/smartnode/shared/utils/eth1"
 	"github.com/rocket-pool/smartnode/shared/utils/validator"
-	eth2types "github.com/wealdtech/go-eth2-types/v2"
 )
 
 type minipoolCreated struct {
@@ -191,9 +190,9 @@ func canNodeDeposit(c *cli.Context, amountWei *big.Int, minNodeFee float64, salt
 				"\tWithdrawal Credentials: %s\n"+
 				"\tSignature: %s\n",
 				err,
-				hex.EncodeToString(eth2types.DomainDeposit[:]),
+				hex.EncodeToString(eth2Config.DomainDeposit),
 				hex.EncodeToString(eth2Config.GenesisForkVersion),
-				hex.EncodeToString(eth2types.ZeroGenesisValidatorsRoot),
+				hex.EncodeToString(eth2.ZeroGenesisValidatorsRoot),
 				uint64(validator.DepositAmount),
 				pubKey.Hex(),
 				withdrawalCredentials.Hex(),
@@ -389,9 +388,9 @@ func nodeDeposit(c *cli.Context, amountWei *big.Int, minNodeFee float64, salt *b
 			"\tWithdrawal Credentials: %s\n"+
 			"\tSignature: %s\n",
 			err,
-			hex.EncodeToString(eth2types.DomainDeposit[:]),
+			hex.EncodeToString(eth2Config.DomainDeposit),
 			hex.EncodeToString(eth2Config.GenesisForkVersion),
-			hex.EncodeToString(eth2types.ZeroGenesisValidatorsRoot),
+			hex.EncodeToString(eth2.ZeroGenesisValidatorsRoot),
 			uint64(validator.DepositAmount),
 			pubKey.Hex(),
 			withdrawalCredentials.Hex(),
@@ -399,6 +398,13 @@ func nodeDeposit(c *cli.Context, amountWei *big.Int, minNodeFee float64, salt *b
 		)
 	}
 
+	// Make sure the deposit contract will accept the deposit data root
+	if err := validator.VerifyDepositDataRoot(depositData, depositDataRoot); err != nil {
+		return nil, fmt.Errorf("Your deposit failed the validation safety check: %w\n"+
+			"For your safety, this deposit will not be submitted and your ETH will not be staked.\n"+
+			"PLEASE REPORT THIS TO THE ROCKET POOL DEVELOPERS.", err)
+	}
+
 	// Override the provided pending TX if requested
 	err = eth1.CheckForNonceOverride(c, opts)
 	if err != nil {
@@ -428,7 +434,7 @@ func nodeDeposit(c *cli.Context, amountWei *big.Int, minNodeFee float64, salt *b
 func validateDepositInfo(eth2Config beacon.Eth2Config, depositAmount uint64, pubkey rptypes.ValidatorPubkey, withdrawalCredentials common.Hash, signature rptypes.ValidatorSignature) error {
 
 	// Get the deposit domain based on the eth2 config
-	depositDomain, err := signing.ComputeDomain(eth2types.DomainDeposit, eth2Config.GenesisForkVersion, eth2types.ZeroGenesisValidatorsRoot)
+	depositDomain, err := eth2Config.GetDepositDomain()
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,85 @@
+package node
+
+import (
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/node"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/gas"
+	"github.com/rocket-pool/smartnode/shared/services/gas/feehistory"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Settings
+const (
+	// The base fees are sampled from the last week, so the weekend lull and the weekday peaks are both included
+	gasHistoryDays            uint64 = 7
+	gasHistoryBlocksPerDay    uint64 = 7200
+	gasHistorySamplesPerDay   uint64 = 4
+	gasHistoryBlocksPerSample uint64 = 128
+)
+
+// Recommend the gas thresholds for the automatic RPL claims and minipool stakes, based on the node's minipool count and recent gas prices
+func getGasThresholdRecommendation(c *cli.Context) (*api.GasThresholdRecommendationResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireRocketStorage(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.GasThresholdRecommendationResponse{}
+
+	// Get the node's minipool count; nodes that haven't registered yet don't have any
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	exists, err := node.GetNodeExists(rp, nodeAccount.Address, nil)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		response.MinipoolCount, err = minipool.GetNodeMinipoolCount(rp, nodeAccount.Address, nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Get the recent base fees
+	baseFees, err := feehistory.SampleBaseFees(ec, gasHistoryDays*gasHistoryBlocksPerDay, gasHistoryDays*gasHistorySamplesPerDay, gasHistoryBlocksPerSample)
+	if err != nil {
+		return nil, err
+	}
+	summary := gas.SummarizeBaseFees(baseFees)
+	response.SampledBlocks = uint64(len(baseFees))
+	response.SampledDays = gasHistoryDays
+	response.BaseFeeLowGwei = summary.LowGwei
+	response.BaseFeeMedianGwei = summary.MedianGwei
+	response.BaseFeeHighGwei = summary.HighGwei
+
+	// Get the recommendation
+	recommendation := gas.RecommendGasThresholds(response.MinipoolCount, summary)
+	response.RplClaimGasThreshold = recommendation.RplClaimGasThreshold
+	response.MinipoolStakeGasThreshold = recommendation.MinipoolStakeGasThreshold
+
+	// Return response
+	return &response, nil
+
+}
@@ -0,0 +1,233 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func canReplaceTx(c *cli.Context, hash common.Hash, cancel bool) (*api.NodeCanReplaceTxResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get transactor
+	opts, err := w.GetNodeAccountTransactor()
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the pending transaction
+	original, err := getPendingNodeTx(w, ec, opts.From, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NodeCanReplaceTxResponse{
+		Nonce:                  original.Nonce(),
+		To:                     original.To(),
+		Value:                  original.Value(),
+		OriginalMaxFee:         original.GasFeeCap(),
+		OriginalMaxPriorityFee: original.GasTipCap(),
+		MinMaxFee:              getReplacementFee(nil, original.GasFeeCap()),
+		MinMaxPriorityFee:      getReplacementFee(nil, original.GasTipCap()),
+	}
+
+	// Re-estimate the gas, since the chain state may have changed since it was sent
+	if cancel {
+		response.To = &opts.From
+		response.Value = big.NewInt(0)
+		response.GasInfo, err = eth.EstimateSendTransactionGas(ec, opts.From, opts)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		response.GasInfo, err = estimateReplacementGas(ec, opts.From, original)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+func speedUpTx(c *cli.Context, hash common.Hash) (*api.NodeReplaceTxResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NodeReplaceTxResponse{}
+
+	// Get transactor
+	opts, err := w.GetNodeAccountTransactor()
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the pending transaction
+	original, err := getPendingNodeTx(w, ec, opts.From, hash)
+	if err != nil {
+		return nil, err
+	}
+	gasInfo, err := estimateReplacementGas(ec, opts.From, original)
+	if err != nil {
+		return nil, err
+	}
+
+	// Resubmit it with the new fees
+	response.TxHash, err = sendReplacementTx(w, ec, opts, original.Nonce(), original.To(), original.Value(), original.Data(), gasInfo.SafeGasLimit, original.GasFeeCap(), original.GasTipCap())
+	if err != nil {
+		return nil, err
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+func cancelTx(c *cli.Context, hash common.Hash) (*api.NodeReplaceTxResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NodeReplaceTxResponse{}
+
+	// Get transactor
+	opts, err := w.GetNodeAccountTransactor()
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the pending transaction
+	original, err := getPendingNodeTx(w, ec, opts.From, hash)
+	if err != nil {
+		return nil, err
+	}
+	gasInfo, err := eth.EstimateSendTransactionGas(ec, opts.From, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// Replace it with a self-transfer of 0 ETH
+	response.TxHash, err = sendReplacementTx(w, ec, opts, original.Nonce(), &opts.From, big.NewInt(0), nil, gasInfo.SafeGasLimit, original.GasFeeCap(), original.GasTipCap())
+	if err != nil {
+		return nil, err
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+// Get a pending transaction sent by the node account.
+// The node's own record is checked first, since the Execution client may have dropped the transaction from its mempool.
+func getPendingNodeTx(w *wallet.Wallet, ec *services.ExecutionClientManager, nodeAddress common.Address, hash common.Hash) (*types.Transaction, error) {
+
+	// Find the transaction
+	var tx *types.Transaction
+	if tracker := w.GetNonceTracker(); tracker != nil {
+		trackedTx, err := tracker.Get(hash)
+		if err != nil {
+			return nil, err
+		}
+		tx = trackedTx
+	}
+	if tx == nil {
+		ecTx, isPending, err := ec.TransactionByHash(context.Background(), hash)
+		if err == ethereum.NotFound {
+			return nil, fmt.Errorf("Transaction %s wasn't sent by this node and isn't known to the Execution client.", hash.Hex())
+		}
+		if err != nil {
+			return nil, fmt.Errorf("Error getting transaction %s: %w", hash.Hex(), err)
+		}
+		if !isPending {
+			return nil, fmt.Errorf("Transaction %s has already been mined.", hash.Hex())
+		}
+		sender, err := types.Sender(types.LatestSignerForChainID(w.GetChainID()), ecTx)
+		if err != nil {
+			return nil, fmt.Errorf("Error getting the sender of transaction %s: %w", hash.Hex(), err)
+		}
+		if sender != nodeAddress {
+			return nil, fmt.Errorf("Transaction %s wasn't sent by the node account.", hash.Hex())
+		}
+		tx = ecTx
+	}
+
+	// Make sure its nonce hasn't been used yet
+	latestNonce, err := ec.NonceAt(context.Background(), nodeAddress, nil)
+	if err != nil {
+		return nil, fmt.Errorf("Error getting latest nonce: %w", err)
+	}
+	if tx.Nonce() < latestNonce {
+		return nil, fmt.Errorf("Nonce %d of transaction %s has already been mined, so it can't be replaced.", tx.Nonce(), hash.Hex())
+	}
+	return tx, nil
+
+}
+
+// Estimate the gas a transaction needs at the current chain state; the original gas limit is kept if it's higher
+func estimateReplacementGas(ec *services.ExecutionClientManager, nodeAddress common.Address, tx *types.Transaction) (rocketpool.GasInfo, error) {
+	estimate, err := ec.EstimateGas(context.Background(), ethereum.CallMsg{
+		From:  nodeAddress,
+		To:    tx.To(),
+		Value: tx.Value(),
+		Data:  tx.Data(),
+	})
+	if err != nil {
+		return rocketpool.GasInfo{}, fmt.Errorf("Transaction %s would fail if it were mined now: %w", tx.Hash().Hex(), err)
+	}
+	gasInfo := rocketpool.GasInfo{
+		EstGasLimit:  estimate,
+		SafeGasLimit: tx.Gas(),
+	}
+	if estimate > gasInfo.SafeGasLimit {
+		gasInfo.SafeGasLimit = estimate
+	}
+	return gasInfo, nil
+}
@@ -0,0 +1,272 @@
+package node
+
+import (
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/node"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/cache"
+	"github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	apiutils "github.com/rocket-pool/smartnode/shared/utils/api"
+	"github.com/rocket-pool/smartnode/shared/utils/eth2"
+	"github.com/rocket-pool/smartnode/shared/utils/eventlogs"
+)
+
+// The number of hours in a year, for annualizing interval rewards
+const hoursPerYear float64 = 24 * 365
+
+func getRewardsHistory(c *cli.Context) (*api.NodeRewardsHistoryResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+	dataCache, err := services.GetCache(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NodeRewardsHistoryResponse{
+		Intervals: []api.NodeIntervalRewards{},
+	}
+
+	// Get the node's claims, and the tree files of the intervals it hasn't claimed yet
+	scanner, err := apiutils.GetEventLogScanner(cfg, rp.Client)
+	if err != nil {
+		return nil, err
+	}
+	claims, err := rewards.GetClaimedRewards(rp, nodeAccount.Address, scanner, dataCache)
+	if err != nil {
+		return nil, err
+	}
+	network := string(cfg.Smartnode.GetNetwork())
+	files, err := rewards.ListRewardsFiles(os.ExpandEnv(cfg.Smartnode.GetRewardsTreePath()), network)
+	if err != nil {
+		return nil, err
+	}
+	filePaths := map[uint64]string{}
+	for _, file := range files {
+		filePaths[file.Index] = file.Path
+	}
+	rewardIndex, err := rewards.GetRewardIndex(rp, nil)
+	if err != nil {
+		return nil, err
+	}
+	eth2Config, err := bc.GetEth2Config()
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the rewards of every finished interval, so the ones whose tree files have been pruned are included
+	for index := uint64(0); index < rewardIndex; index++ {
+		interval, err := getCachedIntervalRewards(rp, bc, eth2Config, scanner, dataCache, network, nodeAccount.Address, index, claims, filePaths[index])
+		if err != nil {
+			return nil, fmt.Errorf("Error getting the rewards of interval %d: %w", index, err)
+		}
+
+		// Skip the intervals from before the node had anything staked
+		if interval.RplStake.Sign() == 0 && interval.NodeDeposit.Sign() == 0 && interval.RplRewards.Sign() == 0 && interval.SmoothingPoolEth.Sign() == 0 {
+			continue
+		}
+		response.Intervals = append(response.Intervals, interval)
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+// Get the realized rewards of the node for an interval, from the cache if they've been calculated before.
+// Everything but the claim status is read at the interval's boundaries and can't change, so only that is updated.
+func getCachedIntervalRewards(rp *rocketpool.RocketPool, bc beacon.Client, eth2Config beacon.Eth2Config, scanner *eventlogs.Scanner, dataCache *cache.Cache, network string, nodeAddress common.Address, index uint64, claims map[uint64]rewards.ClaimedRewards, filePath string) (api.NodeIntervalRewards, error) {
+
+	key := fmt.Sprintf("%s-%d", nodeAddress.Hex(), index)
+	var interval api.NodeIntervalRewards
+	if dataCache.Get(cache.Bucket_IntervalRewards, key, &interval) {
+		if _, claimed := claims[index]; claimed {
+			interval.Claimed = true
+		}
+		return interval, nil
+	}
+
+	interval, err := getIntervalRewards(rp, bc, eth2Config, scanner, dataCache, network, nodeAddress, index, claims, filePath)
+	if err != nil {
+		return api.NodeIntervalRewards{}, err
+	}
+
+	// Don't cache incomplete results so they're tried again next time
+	if interval.BalancesError == "" && !interval.TreeMissing {
+		_ = dataCache.Set(cache.Bucket_IntervalRewards, key, interval)
+	}
+	return interval, nil
+
+}
+
+// Get the realized rewards of the node for an interval. The interval's boundaries come from its snapshot event, the node's rewards
+// come from its claim or from the interval's tree file if it hasn't claimed them yet, and its balances are read at the boundaries.
+func getIntervalRewards(rp *rocketpool.RocketPool, bc beacon.Client, eth2Config beacon.Eth2Config, scanner *eventlogs.Scanner, dataCache *cache.Cache, network string, nodeAddress common.Address, index uint64, claims map[uint64]rewards.ClaimedRewards, filePath string) (api.NodeIntervalRewards, error) {
+
+	// Get the interval's boundaries
+	event, err := rewards.GetRewardsEvent(rp, network, index, scanner, dataCache)
+	if err != nil {
+		return api.NodeIntervalRewards{}, err
+	}
+	var startBlock uint64
+	if index > 0 {
+		previousEvent, err := rewards.GetRewardsEvent(rp, network, index-1, scanner, dataCache)
+		if err != nil {
+			return api.NodeIntervalRewards{}, err
+		}
+		startBlock = previousEvent.Submission.ExecutionBlock.Uint64() + 1
+	}
+	endBlock := event.Submission.ExecutionBlock.Uint64()
+
+	interval := api.NodeIntervalRewards{
+		Index:            index,
+		StartTime:        event.IntervalStartTime,
+		EndTime:          event.IntervalEndTime,
+		RplRewards:       big.NewInt(0),
+		RplStake:         big.NewInt(0),
+		SmoothingPoolEth: big.NewInt(0),
+		BeaconRewards:    big.NewInt(0),
+		NodeDeposit:      big.NewInt(0),
+	}
+	intervalHours := interval.EndTime.Sub(interval.StartTime).Hours()
+
+	// Get the node's rewards from its claim, or from the tree file if it hasn't claimed them
+	if claim, claimed := claims[index]; claimed {
+		interval.Claimed = true
+		interval.RplRewards.Set(claim.Rpl)
+		interval.SmoothingPoolEth.Set(claim.Eth)
+	} else if filePath != "" {
+		file, err := rewards.ReadRewardsFile(filePath)
+		if err != nil {
+			return api.NodeIntervalRewards{}, err
+		}
+		if nodeRewards, exists := file.NodeRewards[nodeAddress]; exists {
+			if nodeRewards.CollateralRpl != nil {
+				interval.RplRewards.Add(interval.RplRewards, &nodeRewards.CollateralRpl.Int)
+			}
+			if nodeRewards.OracleDaoRpl != nil {
+				interval.RplRewards.Add(interval.RplRewards, &nodeRewards.OracleDaoRpl.Int)
+			}
+			if nodeRewards.SmoothingPoolEth != nil {
+				interval.SmoothingPoolEth.Set(&nodeRewards.SmoothingPoolEth.Int)
+			}
+		}
+	} else {
+		interval.TreeMissing = true
+	}
+
+	// Get the node's share of the Smoothing Pool ETH paid to node operators on every network
+	totalSmoothingPoolEth := big.NewInt(0)
+	for _, amount := range event.Submission.NodeETH {
+		if amount != nil {
+			totalSmoothingPoolEth.Add(totalSmoothingPoolEth, amount)
+		}
+	}
+	if totalSmoothingPoolEth.Sign() > 0 {
+		interval.SmoothingPoolShare = eth.WeiToEth(interval.SmoothingPoolEth) / eth.WeiToEth(totalSmoothingPoolEth) * 100
+	}
+
+	// Get the RPL stake at the end of the interval
+	endOpts := &bind.CallOpts{BlockNumber: new(big.Int).SetUint64(endBlock)}
+	rplStake, err := node.GetNodeRPLStake(rp, nodeAddress, endOpts)
+	if err != nil {
+		return api.NodeIntervalRewards{}, err
+	}
+	interval.RplStake = rplStake
+	if rplStake.Sign() > 0 && intervalHours > 0 {
+		interval.RplApr = eth.WeiToEth(interval.RplRewards) / eth.WeiToEth(rplStake) / intervalHours * hoursPerYear * 100
+	}
+
+	// Get the node's share of its minipool balances at both ends of the interval
+	startOpts := &bind.CallOpts{BlockNumber: new(big.Int).SetUint64(startBlock)}
+	startBalances, err := getNodeBalancesAt(rp, bc, nodeAddress, startOpts, eth2.EpochAt(eth2Config, uint64(interval.StartTime.Unix())))
+	if err != nil {
+		interval.BalancesError = err.Error()
+		return interval, nil
+	}
+	endBalances, err := getNodeBalancesAt(rp, bc, nodeAddress, endOpts, eth2.EpochAt(eth2Config, uint64(interval.EndTime.Unix())))
+	if err != nil {
+		interval.BalancesError = err.Error()
+		return interval, nil
+	}
+
+	// Minipools that were created during the interval start from their deposit
+	for address, end := range endBalances {
+		start, exists := startBalances[address]
+		if !exists {
+			start = end
+			start.NodeBalance = end.NodeDeposit
+		}
+		interval.BeaconRewards.Add(interval.BeaconRewards, new(big.Int).Sub(end.NodeBalance, start.NodeBalance))
+		interval.NodeDeposit.Add(interval.NodeDeposit, end.NodeDeposit)
+	}
+	if interval.NodeDeposit.Sign() > 0 && intervalHours > 0 {
+		ethRewards := new(big.Int).Add(interval.BeaconRewards, interval.SmoothingPoolEth)
+		interval.EthApr = eth.WeiToEth(ethRewards) / eth.WeiToEth(interval.NodeDeposit) / intervalHours * hoursPerYear * 100
+	}
+	return interval, nil
+
+}
+
+// The node's deposit and share of the balance of a minipool
+type nodeMinipoolBalance struct {
+	NodeDeposit *big.Int
+	NodeBalance *big.Int
+}
+
+// Get the node's share of the Beacon balances of its minipools at a block and epoch
+func getNodeBalancesAt(rp *rocketpool.RocketPool, bc beacon.Client, nodeAddress common.Address, opts *bind.CallOpts, epoch uint64) (map[common.Address]nodeMinipoolBalance, error) {
+	addresses, err := minipool.GetNodeMinipoolAddresses(rp, nodeAddress, opts)
+	if err != nil {
+		return nil, err
+	}
+	details, err := eth2.GetBeaconBalances(rp, bc, addresses, beacon.BeaconHead{Epoch: epoch}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("Could not get the minipool balances at epoch %d: %w", epoch, err)
+	}
+	balances := map[common.Address]nodeMinipoolBalance{}
+	for i, address := range addresses {
+		balances[address] = nodeMinipoolBalance{
+			NodeDeposit: details[i].NodeDeposit,
+			NodeBalance: details[i].NodeBalance,
+		}
+	}
+	return balances, nil
+}
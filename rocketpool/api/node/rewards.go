@@ -4,12 +4,14 @@ import (
 	"fmt"
 	"math"
 	"math/big"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/rocket-pool/rocketpool-go/dao/trustednode"
 	"github.com/rocket-pool/rocketpool-go/minipool"
 	"github.com/rocket-pool/rocketpool-go/node"
 	"github.com/rocket-pool/rocketpool-go/rewards"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
 	"github.com/rocket-pool/rocketpool-go/tokens"
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
 	"github.com/urfave/cli"
@@ -20,8 +22,19 @@ import (
 	"github.com/rocket-pool/smartnode/shared/types/api"
 	apiutils "github.com/rocket-pool/smartnode/shared/utils/api"
 	"github.com/rocket-pool/smartnode/shared/utils/eth2"
+	"github.com/rocket-pool/smartnode/shared/utils/multicall"
 )
 
+// The network details used to estimate a node's rewards
+type rewardsEstimateDetails struct {
+	totalEffectiveStake               *big.Int
+	totalRplSupply                    *big.Int
+	inflationInterval                 *big.Int
+	nodeOperatorRewardsPercent        float64
+	odaoSize                          uint64
+	trustedNodeOperatorRewardsPercent float64
+}
+
 func getRewards(c *cli.Context) (*api.NodeRewardsResponse, error) {
 
 	// Get services
@@ -50,6 +63,10 @@ func getRewards(c *cli.Context) (*api.NodeRewardsResponse, error) {
 	if err != nil {
 		return nil, err
 	}
+	mc, err := services.GetMultiCaller(c)
+	if err != nil {
+		return nil, err
+	}
 
 	// Response
 	response := api.NodeRewardsResponse{}
@@ -66,12 +83,7 @@ func getRewards(c *cli.Context) (*api.NodeRewardsResponse, error) {
 		return nil, err
 	}
 
-	var totalEffectiveStake *big.Int
-	var totalRplSupply *big.Int
-	var inflationInterval *big.Int
-	var odaoSize uint64
-	var nodeOperatorRewardsPercent float64
-	var trustedNodeOperatorRewardsPercent float64
+	var estimateDetails rewardsEstimateDetails
 	var totalDepositBalance float64
 	var totalNodeShare float64
 	var addresses []common.Address
@@ -80,38 +92,135 @@ func getRewards(c *cli.Context) (*api.NodeRewardsResponse, error) {
 	// Sync
 	var wg errgroup.Group
 
-	// Check if the node is registered or not
+	// Get the node's rewards details, batching their calls together if the network has a Multicall3 contract
 	wg.Go(func() error {
-		exists, err := node.GetNodeExists(rp, nodeAccount.Address, nil)
+		if mc != nil {
+			return getRewardsDetailsMulticall(rp, mc, nodeAccount.Address, &response, &estimateDetails)
+		}
+		return getRewardsDetails(rp, nodeAccount.Address, &response, &estimateDetails)
+	})
+
+	// Get cumulative rewards
+	wg.Go(func() error {
+		rewards, err := rewards.CalculateLifetimeNodeRewards(rp, nodeAccount.Address, eventLogInterval, nil)
 		if err == nil {
-			response.Registered = exists
+			response.CumulativeRewards = eth.WeiToEth(rewards)
 		}
 		return err
 	})
 
-	// Get the node registration time
+	// Get the list of minipool addresses for this node
+	wg.Go(func() error {
+		_addresses, err := minipool.GetNodeMinipoolAddresses(rp, nodeAccount.Address, nil)
+		if err != nil {
+			return fmt.Errorf("Error getting node minipool addresses: %w", err)
+		}
+		addresses = _addresses
+		return nil
+	})
+
+	// Get the beacon head
+	wg.Go(func() error {
+		_beaconHead, err := bc.GetBeaconHead()
+		if err != nil {
+			return fmt.Errorf("Error getting beacon chain head: %w", err)
+		}
+		beaconHead = _beaconHead
+		return nil
+	})
+
+	// Wait for data
+	if err := wg.Wait(); err != nil {
+		return nil, err
+	}
+
+	// Calculate the total deposits and corresponding beacon chain balance share
+	minipoolDetails, err := eth2.GetBeaconBalances(rp, bc, addresses, beaconHead, nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, minipool := range minipoolDetails {
+		totalDepositBalance += eth.WeiToEth(minipool.NodeDeposit)
+		totalNodeShare += eth.WeiToEth(minipool.NodeBalance)
+	}
+	response.BeaconRewards = totalNodeShare - totalDepositBalance
+
+	// Calculate the estimated rewards
+	rewardsIntervalDays := response.RewardsInterval.Seconds() / (60 * 60 * 24)
+	inflationPerDay := eth.WeiToEth(estimateDetails.inflationInterval)
+	totalRplAtNextCheckpoint := (math.Pow(inflationPerDay, float64(rewardsIntervalDays)) - 1) * eth.WeiToEth(estimateDetails.totalRplSupply)
+	if totalRplAtNextCheckpoint < 0 {
+		totalRplAtNextCheckpoint = 0
+	}
+
+	if estimateDetails.totalEffectiveStake.Cmp(big.NewInt(0)) == 1 {
+		response.EstimatedRewards = response.EffectiveRplStake / eth.WeiToEth(estimateDetails.totalEffectiveStake) * totalRplAtNextCheckpoint * estimateDetails.nodeOperatorRewardsPercent
+	}
+
+	if response.Trusted {
+
+		var wg2 errgroup.Group
+
+		// Get the node's oDAO rewards details
+		wg2.Go(func() error {
+			if mc != nil {
+				return getTrustedRewardsDetailsMulticall(rp, mc, nodeAccount.Address, &response, &estimateDetails)
+			}
+			return getTrustedRewardsDetails(rp, nodeAccount.Address, &response, &estimateDetails)
+		})
+
+		// Get cumulative ODAO rewards
+		wg2.Go(func() error {
+			rewards, err := rewards.CalculateLifetimeTrustedNodeRewards(rp, nodeAccount.Address, eventLogInterval, nil)
+			if err == nil {
+				response.CumulativeTrustedRewards = eth.WeiToEth(rewards)
+			}
+			return err
+		})
+
+		// Wait for data
+		if err := wg2.Wait(); err != nil {
+			return nil, err
+		}
+
+		response.EstimatedTrustedRewards = totalRplAtNextCheckpoint * estimateDetails.trustedNodeOperatorRewardsPercent / float64(estimateDetails.odaoSize)
+
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+// Get the node's rewards details and the network details used to estimate its rewards, making each call separately
+func getRewardsDetails(rp *rocketpool.RocketPool, nodeAddress common.Address, response *api.NodeRewardsResponse, estimateDetails *rewardsEstimateDetails) error {
+
+	// Sync
+	var wg errgroup.Group
+
+	// Check if the node is registered or not
 	wg.Go(func() error {
-		time, err := rewards.GetNodeRegistrationTime(rp, nodeAccount.Address, nil)
+		exists, err := node.GetNodeExists(rp, nodeAddress, nil)
 		if err == nil {
-			response.NodeRegistrationTime = time
+			response.Registered = exists
 		}
 		return err
 	})
 
-	// Get node trusted status
+	// Get the node registration time
 	wg.Go(func() error {
-		trusted, err := trustednode.GetMemberExists(rp, nodeAccount.Address, nil)
+		time, err := rewards.GetNodeRegistrationTime(rp, nodeAddress, nil)
 		if err == nil {
-			response.Trusted = trusted
+			response.NodeRegistrationTime = time
 		}
 		return err
 	})
 
-	// Get cumulative rewards
+	// Get node trusted status
 	wg.Go(func() error {
-		rewards, err := rewards.CalculateLifetimeNodeRewards(rp, nodeAccount.Address, eventLogInterval, nil)
+		trusted, err := trustednode.GetMemberExists(rp, nodeAddress, nil)
 		if err == nil {
-			response.CumulativeRewards = eth.WeiToEth(rewards)
+			response.Trusted = trusted
 		}
 		return err
 	})
@@ -136,7 +245,7 @@ func getRewards(c *cli.Context) (*api.NodeRewardsResponse, error) {
 
 	// Get the node's effective stake
 	wg.Go(func() error {
-		effectiveStake, err := node.GetNodeEffectiveRPLStake(rp, nodeAccount.Address, nil)
+		effectiveStake, err := node.GetNodeEffectiveRPLStake(rp, nodeAddress, nil)
 		if err == nil {
 			response.EffectiveRplStake = eth.WeiToEth(effectiveStake)
 		}
@@ -145,7 +254,7 @@ func getRewards(c *cli.Context) (*api.NodeRewardsResponse, error) {
 
 	// Get the node's total stake
 	wg.Go(func() error {
-		stake, err := node.GetNodeRPLStake(rp, nodeAccount.Address, nil)
+		stake, err := node.GetNodeRPLStake(rp, nodeAddress, nil)
 		if err == nil {
 			response.TotalRplStake = eth.WeiToEth(stake)
 		}
@@ -154,165 +263,178 @@ func getRewards(c *cli.Context) (*api.NodeRewardsResponse, error) {
 
 	// Get the total network effective stake
 	wg.Go(func() error {
-		totalEffectiveStake, err = node.GetTotalEffectiveRPLStake(rp, nil)
-		if err != nil {
-			return err
-		}
-		return nil
+		var err error
+		estimateDetails.totalEffectiveStake, err = node.GetTotalEffectiveRPLStake(rp, nil)
+		return err
 	})
 
 	// Get the total RPL supply
 	wg.Go(func() error {
-		totalRplSupply, err = tokens.GetRPLTotalSupply(rp, nil)
-		if err != nil {
-			return err
-		}
-		return nil
+		var err error
+		estimateDetails.totalRplSupply, err = tokens.GetRPLTotalSupply(rp, nil)
+		return err
 	})
 
 	// Get the RPL inflation interval
 	wg.Go(func() error {
-		inflationInterval, err = tokens.GetRPLInflationIntervalRate(rp, nil)
-		if err != nil {
-			return err
-		}
-		return nil
+		var err error
+		estimateDetails.inflationInterval, err = tokens.GetRPLInflationIntervalRate(rp, nil)
+		return err
 	})
 
 	// Get the node operator rewards percent
 	wg.Go(func() error {
-		nodeOperatorRewardsPercent, err = rewards.GetNodeOperatorRewardsPercent(rp, nil)
-		if err != nil {
-			return err
-		}
-		return nil
+		var err error
+		estimateDetails.nodeOperatorRewardsPercent, err = rewards.GetNodeOperatorRewardsPercent(rp, nil)
+		return err
 	})
 
 	// Check if rewards are currently available from the previous checkpoint
 	wg.Go(func() error {
-		unclaimedRewardsWei, err := rewards.GetNodeClaimRewardsAmount(rp, nodeAccount.Address, nil)
+		unclaimedRewardsWei, err := rewards.GetNodeClaimRewardsAmount(rp, nodeAddress, nil)
 		if err == nil {
 			response.UnclaimedRewards = eth.WeiToEth(unclaimedRewardsWei)
 		}
 		return err
 	})
 
-	// Get the list of minipool addresses for this node
-	wg.Go(func() error {
-		_addresses, err := minipool.GetNodeMinipoolAddresses(rp, nodeAccount.Address, nil)
-		if err != nil {
-			return fmt.Errorf("Error getting node minipool addresses: %w", err)
-		}
-		addresses = _addresses
-		return nil
-	})
+	// Wait for data
+	return wg.Wait()
 
-	// Get the beacon head
-	wg.Go(func() error {
-		_beaconHead, err := bc.GetBeaconHead()
-		if err != nil {
-			return fmt.Errorf("Error getting beacon chain head: %w", err)
-		}
-		beaconHead = _beaconHead
-		return nil
-	})
+}
 
-	// Wait for data
-	if err := wg.Wait(); err != nil {
-		return nil, err
-	}
+// Get the node's rewards details and the network details used to estimate its rewards, batching the calls together
+func getRewardsDetailsMulticall(rp *rocketpool.RocketPool, mc *multicall.MultiCaller, nodeAddress common.Address, response *api.NodeRewardsResponse, estimateDetails *rewardsEstimateDetails) error {
 
-	// Calculate the total deposits and corresponding beacon chain balance share
-	minipoolDetails, err := eth2.GetBeaconBalances(rp, bc, addresses, beaconHead, nil)
+	// Get contracts
+	contracts, err := getContracts(rp, "rocketNodeManager", "rocketDAONodeTrusted", "rocketRewardsPool", "rocketNodeStaking", "rocketTokenRPL", "rocketClaimNode")
 	if err != nil {
-		return nil, err
+		return err
 	}
-	for _, minipool := range minipoolDetails {
-		totalDepositBalance += eth.WeiToEth(minipool.NodeDeposit)
-		totalNodeShare += eth.WeiToEth(minipool.NodeBalance)
+	rocketRewardsPool := contracts["rocketRewardsPool"]
+	rocketNodeStaking := contracts["rocketNodeStaking"]
+	rocketTokenRPL := contracts["rocketTokenRPL"]
+
+	// Load data
+	var registrationTime *big.Int
+	var lastCheckpoint *big.Int
+	var rewardsInterval *big.Int
+	var effectiveStake *big.Int
+	var stake *big.Int
+	var nodeOperatorRewardsPercent *big.Int
+	var unclaimedRewards *big.Int
+	mc.AddCall(contracts["rocketNodeManager"], &response.Registered, "getNodeExists", nodeAddress)
+	mc.AddCall(rocketRewardsPool, &registrationTime, "getClaimingContractUserRegisteredTime", "rocketClaimNode", nodeAddress)
+	mc.AddCall(contracts["rocketDAONodeTrusted"], &response.Trusted, "getMemberIsValid", nodeAddress)
+	mc.AddCall(rocketRewardsPool, &lastCheckpoint, "getClaimIntervalTimeStart")
+	mc.AddCall(rocketRewardsPool, &rewardsInterval, "getClaimIntervalTime")
+	mc.AddCall(rocketNodeStaking, &effectiveStake, "getNodeEffectiveRPLStake", nodeAddress)
+	mc.AddCall(rocketNodeStaking, &stake, "getNodeRPLStake", nodeAddress)
+	mc.AddCall(rocketNodeStaking, &estimateDetails.totalEffectiveStake, "getTotalEffectiveRPLStake")
+	mc.AddCall(rocketTokenRPL, &estimateDetails.totalRplSupply, "totalSupply")
+	mc.AddCall(rocketTokenRPL, &estimateDetails.inflationInterval, "getInflationIntervalRate")
+	mc.AddCall(rocketRewardsPool, &nodeOperatorRewardsPercent, "getClaimingContractPerc", "rocketClaimNode")
+	mc.AddCall(contracts["rocketClaimNode"], &unclaimedRewards, "getClaimRewardsAmount", nodeAddress)
+	if err := mc.Execute(nil); err != nil {
+		return err
 	}
-	response.BeaconRewards = totalNodeShare - totalDepositBalance
 
-	// Calculate the estimated rewards
-	rewardsIntervalDays := response.RewardsInterval.Seconds() / (60 * 60 * 24)
-	inflationPerDay := eth.WeiToEth(inflationInterval)
-	totalRplAtNextCheckpoint := (math.Pow(inflationPerDay, float64(rewardsIntervalDays)) - 1) * eth.WeiToEth(totalRplSupply)
-	if totalRplAtNextCheckpoint < 0 {
-		totalRplAtNextCheckpoint = 0
-	}
+	// Convert the results
+	response.NodeRegistrationTime = time.Unix(registrationTime.Int64(), 0)
+	response.LastCheckpoint = time.Unix(int64(lastCheckpoint.Uint64()), 0)
+	response.RewardsInterval = time.Duration(rewardsInterval.Int64()) * time.Second
+	response.EffectiveRplStake = eth.WeiToEth(effectiveStake)
+	response.TotalRplStake = eth.WeiToEth(stake)
+	estimateDetails.nodeOperatorRewardsPercent = eth.WeiToEth(nodeOperatorRewardsPercent)
+	response.UnclaimedRewards = eth.WeiToEth(unclaimedRewards)
+	return nil
 
-	if totalEffectiveStake.Cmp(big.NewInt(0)) == 1 {
-		response.EstimatedRewards = response.EffectiveRplStake / eth.WeiToEth(totalEffectiveStake) * totalRplAtNextCheckpoint * nodeOperatorRewardsPercent
-	}
+}
 
-	if response.Trusted {
+// Get the node's oDAO rewards details and the network details used to estimate them, making each call separately
+func getTrustedRewardsDetails(rp *rocketpool.RocketPool, nodeAddress common.Address, response *api.NodeRewardsResponse, estimateDetails *rewardsEstimateDetails) error {
 
-		var wg2 errgroup.Group
+	// Sync
+	var wg errgroup.Group
 
-		// Get the node registration time
-		wg2.Go(func() error {
-			time, err := rewards.GetTrustedNodeRegistrationTime(rp, nodeAccount.Address, nil)
-			if err == nil {
-				response.TrustedNodeRegistrationTime = time
-			}
-			return err
-		})
+	// Get the node registration time
+	wg.Go(func() error {
+		time, err := rewards.GetTrustedNodeRegistrationTime(rp, nodeAddress, nil)
+		if err == nil {
+			response.TrustedNodeRegistrationTime = time
+		}
+		return err
+	})
 
-		// Get cumulative ODAO rewards
-		wg2.Go(func() error {
-			rewards, err := rewards.CalculateLifetimeTrustedNodeRewards(rp, nodeAccount.Address, eventLogInterval, nil)
-			if err == nil {
-				response.CumulativeTrustedRewards = eth.WeiToEth(rewards)
-			}
-			return err
-		})
+	// Get the ODAO member count
+	wg.Go(func() error {
+		var err error
+		estimateDetails.odaoSize, err = trustednode.GetMemberCount(rp, nil)
+		return err
+	})
 
-		// Get the ODAO member count
-		wg2.Go(func() error {
-			odaoSize, err = trustednode.GetMemberCount(rp, nil)
-			if err != nil {
-				return err
-			}
-			return nil
-		})
+	// Get the trusted node operator rewards percent
+	wg.Go(func() error {
+		var err error
+		estimateDetails.trustedNodeOperatorRewardsPercent, err = rewards.GetTrustedNodeOperatorRewardsPercent(rp, nil)
+		return err
+	})
 
-		// Get the trusted node operator rewards percent
-		wg2.Go(func() error {
-			trustedNodeOperatorRewardsPercent, err = rewards.GetTrustedNodeOperatorRewardsPercent(rp, nil)
-			if err != nil {
-				return err
-			}
-			return nil
-		})
+	// Get the node's oDAO RPL stake
+	wg.Go(func() error {
+		bond, err := trustednode.GetMemberRPLBondAmount(rp, nodeAddress, nil)
+		if err == nil {
+			response.TrustedRplBond = eth.WeiToEth(bond)
+		}
+		return err
+	})
 
-		// Get the node's oDAO RPL stake
-		wg2.Go(func() error {
-			bond, err := trustednode.GetMemberRPLBondAmount(rp, nodeAccount.Address, nil)
-			if err == nil {
-				response.TrustedRplBond = eth.WeiToEth(bond)
-			}
-			return err
-		})
+	// Check if rewards are currently available from the previous checkpoint for the ODAO
+	wg.Go(func() error {
+		unclaimedRewardsWei, err := rewards.GetTrustedNodeClaimRewardsAmount(rp, nodeAddress, nil)
+		if err == nil {
+			response.UnclaimedTrustedRewards = eth.WeiToEth(unclaimedRewardsWei)
+		}
+		return err
+	})
 
-		// Check if rewards are currently available from the previous checkpoint for the ODAO
-		wg2.Go(func() error {
-			unclaimedRewardsWei, err := rewards.GetTrustedNodeClaimRewardsAmount(rp, nodeAccount.Address, nil)
-			if err == nil {
-				response.UnclaimedTrustedRewards = eth.WeiToEth(unclaimedRewardsWei)
-			}
-			return err
-		})
+	// Wait for data
+	return wg.Wait()
 
-		// Wait for data
-		if err := wg2.Wait(); err != nil {
-			return nil, err
-		}
+}
 
-		response.EstimatedTrustedRewards = totalRplAtNextCheckpoint * trustedNodeOperatorRewardsPercent / float64(odaoSize)
+// Get the node's oDAO rewards details and the network details used to estimate them, batching the calls together
+func getTrustedRewardsDetailsMulticall(rp *rocketpool.RocketPool, mc *multicall.MultiCaller, nodeAddress common.Address, response *api.NodeRewardsResponse, estimateDetails *rewardsEstimateDetails) error {
 
+	// Get contracts
+	contracts, err := getContracts(rp, "rocketRewardsPool", "rocketDAONodeTrusted", "rocketClaimTrustedNode")
+	if err != nil {
+		return err
+	}
+	rocketRewardsPool := contracts["rocketRewardsPool"]
+	rocketDAONodeTrusted := contracts["rocketDAONodeTrusted"]
+
+	// Load data
+	var registrationTime *big.Int
+	var odaoSize *big.Int
+	var trustedNodeOperatorRewardsPercent *big.Int
+	var bond *big.Int
+	var unclaimedRewards *big.Int
+	mc.AddCall(rocketRewardsPool, &registrationTime, "getClaimingContractUserRegisteredTime", "rocketClaimTrustedNode", nodeAddress)
+	mc.AddCall(rocketDAONodeTrusted, &odaoSize, "getMemberCount")
+	mc.AddCall(rocketRewardsPool, &trustedNodeOperatorRewardsPercent, "getClaimingContractPerc", "rocketClaimTrustedNode")
+	mc.AddCall(rocketDAONodeTrusted, &bond, "getMemberRPLBondAmount", nodeAddress)
+	mc.AddCall(contracts["rocketClaimTrustedNode"], &unclaimedRewards, "getClaimRewardsAmount", nodeAddress)
+	if err := mc.Execute(nil); err != nil {
+		return err
 	}
 
-	// Return response
-	return &response, nil
+	// Convert the results
+	response.TrustedNodeRegistrationTime = time.Unix(registrationTime.Int64(), 0)
+	estimateDetails.odaoSize = odaoSize.Uint64()
+	estimateDetails.trustedNodeOperatorRewardsPercent = eth.WeiToEth(trustedNodeOperatorRewardsPercent)
+	response.TrustedRplBond = eth.WeiToEth(bond)
+	response.UnclaimedTrustedRewards = eth.WeiToEth(unclaimedRewards)
+	return nil
 
 }
@@ -0,0 +1,174 @@
+package node
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/rewards"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/eth1"
+)
+
+func canSetSmoothingPoolStatus(c *cli.Context, state bool) (*api.CanSetSmoothingPoolStatusResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.CanSetSmoothingPoolStatusResponse{}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Check the current registration and the cooldown
+	status, err := getSmoothingPoolStatus(rp, nodeAccount.Address, false)
+	if err != nil {
+		return nil, err
+	}
+	response.AlreadySet = (status.Registered == state)
+	response.NextChangeTime = status.NextChangeTime
+	response.IntervalEnd = status.IntervalEnd
+	response.PoolAddress = status.Address
+	response.InCooldown = time.Now().Before(status.NextChangeTime)
+	response.CanSet = !(response.AlreadySet || response.InCooldown)
+	if !response.CanSet {
+		return &response, nil
+	}
+
+	// Get gas estimate
+	opts, err := w.GetNodeAccountTransactor()
+	if err != nil {
+		return nil, err
+	}
+	gasInfo, err := rprewards.EstimateSetSmoothingPoolRegistrationStateGas(rp, state, opts)
+	if err != nil {
+		return nil, err
+	}
+	response.GasInfo = gasInfo
+	return &response, nil
+
+}
+
+func setSmoothingPoolStatus(c *cli.Context, state bool) (*api.SetSmoothingPoolStatusResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.SetSmoothingPoolStatusResponse{}
+
+	// Get transactor
+	opts, err := w.GetNodeAccountTransactor()
+	if err != nil {
+		return nil, err
+	}
+
+	// Override the provided pending TX if requested
+	err = eth1.CheckForNonceOverride(c, opts)
+	if err != nil {
+		return nil, fmt.Errorf("Error checking for nonce override: %w", err)
+	}
+
+	// Set the registration state
+	hash, err := rprewards.SetSmoothingPoolRegistrationState(rp, state, opts)
+	if err != nil {
+		return nil, err
+	}
+	response.TxHash = hash
+
+	// Return response
+	return &response, nil
+
+}
+
+// Get a node's smoothing pool registration, cooldown and minipool penalties
+func getSmoothingPoolStatus(rp *rocketpool.RocketPool, nodeAddress common.Address, includePenalties bool) (api.NodeSmoothingPoolStatus, error) {
+
+	status := api.NodeSmoothingPoolStatus{}
+
+	// Get the smoothing pool address
+	smoothingPoolAddress, err := rp.GetAddress("rocketSmoothingPool")
+	if err != nil {
+		return status, fmt.Errorf("Could not get the smoothing pool address: %w", err)
+	}
+	status.Address = *smoothingPoolAddress
+
+	// Get the registration
+	status.Registered, err = rprewards.GetSmoothingPoolRegistrationState(rp, nodeAddress, nil)
+	if err != nil {
+		return status, err
+	}
+	status.Changed, err = rprewards.GetSmoothingPoolRegistrationChanged(rp, nodeAddress, nil)
+	if err != nil {
+		return status, err
+	}
+
+	// Get the rewards interval, which sets the cooldown and when an opt-out takes effect
+	intervalStart, err := rewards.GetClaimIntervalTimeStart(rp, nil)
+	if err != nil {
+		return status, err
+	}
+	intervalTime, err := rewards.GetClaimIntervalTime(rp, nil)
+	if err != nil {
+		return status, err
+	}
+	status.IntervalEnd = intervalStart.Add(intervalTime)
+	if status.Changed.Unix() > 0 {
+		status.NextChangeTime = status.Changed.Add(intervalTime)
+	}
+	status.OptOutPending = !status.Registered && status.Changed.After(intervalStart)
+
+	// Get the penalties applied to the node's minipools
+	if !includePenalties {
+		return status, nil
+	}
+	addresses, err := minipool.GetNodeMinipoolAddresses(rp, nodeAddress, nil)
+	if err != nil {
+		return status, err
+	}
+	status.PenalizedMinipools = []api.PenalizedMinipool{}
+	for _, address := range addresses {
+		rate, err := rprewards.GetMinipoolPenaltyRate(rp, address, nil)
+		if err != nil {
+			return status, err
+		}
+		if rate.Sign() > 0 {
+			status.PenalizedMinipools = append(status.PenalizedMinipools, api.PenalizedMinipool{
+				Address:     address,
+				PenaltyRate: rate,
+			})
+		}
+	}
+	return status, nil
+
+}
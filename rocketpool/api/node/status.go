@@ -2,18 +2,23 @@ package node
 
 import (
 	"bytes"
+	"math/big"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/rocket-pool/rocketpool-go/dao/trustednode"
 	"github.com/rocket-pool/rocketpool-go/network"
 	"github.com/rocket-pool/rocketpool-go/node"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
 	"github.com/rocket-pool/rocketpool-go/tokens"
 	"github.com/rocket-pool/rocketpool-go/types"
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/rocket-pool/rocketpool-go/utils/strings"
 	"github.com/urfave/cli"
 	"golang.org/x/sync/errgroup"
 
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/multicall"
 )
 
 func getStatus(c *cli.Context) (*api.NodeStatusResponse, error) {
@@ -52,12 +57,59 @@ func getStatus(c *cli.Context) (*api.NodeStatusResponse, error) {
 	}
 	response.AccountAddress = nodeAccount.Address
 
+	// Get the node's details, batching their calls together if the network has a Multicall3 contract
+	mc, err := services.GetMultiCaller(c)
+	if err != nil {
+		return nil, err
+	}
+	var rplPrice *big.Int
+	if mc != nil {
+		rplPrice, err = getStatusDetailsMulticall(rp, mc, nodeAccount.Address, &response)
+	} else {
+		rplPrice, err = getStatusDetails(rp, nodeAccount.Address, &response)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the voting delegate
+	if cfg.Smartnode.GetSnapshotDelegationAddress() != "" {
+		idHash := cfg.Smartnode.GetVotingSnapshotID()
+		response.VotingDelegate, err = s.Delegation(nil, nodeAccount.Address, idHash)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Get the smoothing pool status; it isn't available on every network yet, so errors are reported in the response
+	response.SmoothingPool, err = getSmoothingPoolStatus(rp, nodeAccount.Address, true)
+	if err != nil {
+		response.SmoothingPool.Error = err.Error()
+	}
+
+	// Get the collateral ratio
+	activeMinipools := response.MinipoolCounts.Total - response.MinipoolCounts.Finalised
+	if activeMinipools > 0 {
+		response.CollateralRatio = eth.WeiToEth(rplPrice) * eth.WeiToEth(response.RplStake) / (float64(activeMinipools) * 16.0)
+	} else {
+		response.CollateralRatio = -1
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+// Get the node's details, balances, stake and minipool counts, and the RPL price, making each call separately
+func getStatusDetails(rp *rocketpool.RocketPool, nodeAddress common.Address, response *api.NodeStatusResponse) (*big.Int, error) {
+
 	// Sync
 	var wg errgroup.Group
+	var rplPrice *big.Int
 
 	// Get node trusted status
 	wg.Go(func() error {
-		trusted, err := trustednode.GetMemberExists(rp, nodeAccount.Address, nil)
+		trusted, err := trustednode.GetMemberExists(rp, nodeAddress, nil)
 		if err == nil {
 			response.Trusted = trusted
 		}
@@ -66,7 +118,7 @@ func getStatus(c *cli.Context) (*api.NodeStatusResponse, error) {
 
 	// Get node details
 	wg.Go(func() error {
-		details, err := node.GetNodeDetails(rp, nodeAccount.Address, nil)
+		details, err := node.GetNodeDetails(rp, nodeAddress, nil)
 		if err == nil {
 			response.Registered = details.Exists
 			response.WithdrawalAddress = details.WithdrawalAddress
@@ -79,77 +131,49 @@ func getStatus(c *cli.Context) (*api.NodeStatusResponse, error) {
 	// Get node account balances
 	wg.Go(func() error {
 		var err error
-		response.AccountBalances, err = tokens.GetBalances(rp, nodeAccount.Address, nil)
+		response.AccountBalances, err = tokens.GetBalances(rp, nodeAddress, nil)
 		return err
 	})
 
 	// Get staking details
 	wg.Go(func() error {
 		var err error
-		response.RplStake, err = node.GetNodeRPLStake(rp, nodeAccount.Address, nil)
+		response.RplStake, err = node.GetNodeRPLStake(rp, nodeAddress, nil)
 		return err
 	})
 	wg.Go(func() error {
 		var err error
-		response.EffectiveRplStake, err = node.GetNodeEffectiveRPLStake(rp, nodeAccount.Address, nil)
+		response.EffectiveRplStake, err = node.GetNodeEffectiveRPLStake(rp, nodeAddress, nil)
 		return err
 	})
 	wg.Go(func() error {
 		var err error
-		response.MinimumRplStake, err = node.GetNodeMinimumRPLStake(rp, nodeAccount.Address, nil)
+		response.MinimumRplStake, err = node.GetNodeMinimumRPLStake(rp, nodeAddress, nil)
 		return err
 	})
 	wg.Go(func() error {
 		var err error
-		response.MaximumRplStake, err = node.GetNodeMaximumRPLStake(rp, nodeAccount.Address, nil)
+		response.MaximumRplStake, err = node.GetNodeMaximumRPLStake(rp, nodeAddress, nil)
 		return err
 	})
 	wg.Go(func() error {
 		var err error
-		response.MinipoolLimit, err = node.GetNodeMinipoolLimit(rp, nodeAccount.Address, nil)
+		response.MinipoolLimit, err = node.GetNodeMinipoolLimit(rp, nodeAddress, nil)
 		return err
 	})
+
+	// Get the RPL price
 	wg.Go(func() error {
 		var err error
-		if cfg.Smartnode.GetSnapshotDelegationAddress() != "" {
-			idHash := cfg.Smartnode.GetVotingSnapshotID()
-			response.VotingDelegate, err = s.Delegation(nil, nodeAccount.Address, idHash)
-		}
+		rplPrice, err = network.GetRPLPrice(rp, nil)
 		return err
 	})
 
 	// Get node minipool counts
 	wg.Go(func() error {
-		details, err := getNodeMinipoolCountDetails(rp, nodeAccount.Address)
+		details, err := getNodeMinipoolCountDetails(rp, nodeAddress)
 		if err == nil {
-			response.MinipoolCounts.Total = len(details)
-			for _, mpDetails := range details {
-				if mpDetails.Finalised {
-					response.MinipoolCounts.Finalised++
-				} else {
-					switch mpDetails.Status {
-					case types.Initialized:
-						response.MinipoolCounts.Initialized++
-					case types.Prelaunch:
-						response.MinipoolCounts.Prelaunch++
-					case types.Staking:
-						response.MinipoolCounts.Staking++
-					case types.Withdrawable:
-						response.MinipoolCounts.Withdrawable++
-					case types.Dissolved:
-						response.MinipoolCounts.Dissolved++
-					}
-					if mpDetails.RefundAvailable {
-						response.MinipoolCounts.RefundAvailable++
-					}
-					if mpDetails.WithdrawalAvailable {
-						response.MinipoolCounts.WithdrawalAvailable++
-					}
-					if mpDetails.CloseAvailable {
-						response.MinipoolCounts.CloseAvailable++
-					}
-				}
-			}
+			setMinipoolCounts(response, details)
 		}
 		return err
 	})
@@ -160,7 +184,7 @@ func getStatus(c *cli.Context) (*api.NodeStatusResponse, error) {
 	}
 
 	// Get withdrawal address balances
-	if !bytes.Equal(nodeAccount.Address.Bytes(), response.WithdrawalAddress.Bytes()) {
+	if !bytes.Equal(nodeAddress.Bytes(), response.WithdrawalAddress.Bytes()) {
 		withdrawalBalances, err := tokens.GetBalances(rp, response.WithdrawalAddress, nil)
 		if err != nil {
 			return nil, err
@@ -168,19 +192,102 @@ func getStatus(c *cli.Context) (*api.NodeStatusResponse, error) {
 		response.WithdrawalBalances = withdrawalBalances
 	}
 
-	// Get the collateral ratio
-	rplPrice, err := network.GetRPLPrice(rp, nil)
+	// Return
+	return rplPrice, nil
+
+}
+
+// Get the node's details, balances, stake and minipool counts, and the RPL price, batching the calls together
+func getStatusDetailsMulticall(rp *rocketpool.RocketPool, mc *multicall.MultiCaller, nodeAddress common.Address, response *api.NodeStatusResponse) (*big.Int, error) {
+
+	// Get contracts
+	contracts, err := getContracts(rp, "rocketDAONodeTrusted", "rocketNodeManager", "rocketNodeStaking", "rocketNetworkPrices", "rocketMinipoolManager")
 	if err != nil {
 		return nil, err
 	}
-	activeMinipools := response.MinipoolCounts.Total - response.MinipoolCounts.Finalised
-	if activeMinipools > 0 {
-		response.CollateralRatio = eth.WeiToEth(rplPrice) * eth.WeiToEth(response.RplStake) / (float64(activeMinipools) * 16.0)
-	} else {
-		response.CollateralRatio = -1
+	rocketNodeStaking := contracts["rocketNodeStaking"]
+	rocketMinipoolManager := contracts["rocketMinipoolManager"]
+
+	// Get the node's details
+	var timezoneLocation string
+	var minipoolLimit *big.Int
+	var rplPrice *big.Int
+	var minipoolCount *big.Int
+	mc.AddCall(contracts["rocketDAONodeTrusted"], &response.Trusted, "getMemberIsValid", nodeAddress)
+	mc.AddCall(contracts["rocketNodeManager"], &response.Registered, "getNodeExists", nodeAddress)
+	mc.AddCall(rp.RocketStorageContract, &response.WithdrawalAddress, "getNodeWithdrawalAddress", nodeAddress)
+	mc.AddCall(rp.RocketStorageContract, &response.PendingWithdrawalAddress, "getNodePendingWithdrawalAddress", nodeAddress)
+	mc.AddCall(contracts["rocketNodeManager"], &timezoneLocation, "getNodeTimezoneLocation", nodeAddress)
+	if err := addBalanceCalls(rp, mc, nodeAddress, &response.AccountBalances); err != nil {
+		return nil, err
 	}
+	mc.AddCall(rocketNodeStaking, &response.RplStake, "getNodeRPLStake", nodeAddress)
+	mc.AddCall(rocketNodeStaking, &response.EffectiveRplStake, "getNodeEffectiveRPLStake", nodeAddress)
+	mc.AddCall(rocketNodeStaking, &response.MinimumRplStake, "getNodeMinimumRPLStake", nodeAddress)
+	mc.AddCall(rocketNodeStaking, &response.MaximumRplStake, "getNodeMaximumRPLStake", nodeAddress)
+	mc.AddCall(rocketNodeStaking, &minipoolLimit, "getNodeMinipoolLimit", nodeAddress)
+	mc.AddCall(contracts["rocketNetworkPrices"], &rplPrice, "getRPLPrice")
+	mc.AddCall(rocketMinipoolManager, &minipoolCount, "getNodeMinipoolCount", nodeAddress)
+	if err := mc.Execute(nil); err != nil {
+		return nil, err
+	}
+	response.TimezoneLocation = strings.Sanitize(timezoneLocation)
+	response.MinipoolLimit = minipoolLimit.Uint64()
 
-	// Return response
-	return &response, nil
+	// Get the withdrawal address balances and minipool addresses
+	if !bytes.Equal(nodeAddress.Bytes(), response.WithdrawalAddress.Bytes()) {
+		if err := addBalanceCalls(rp, mc, response.WithdrawalAddress, &response.WithdrawalBalances); err != nil {
+			return nil, err
+		}
+	}
+	addresses := make([]common.Address, minipoolCount.Uint64())
+	for i := range addresses {
+		mc.AddCall(rocketMinipoolManager, &addresses[i], "getNodeMinipoolAt", nodeAddress, big.NewInt(int64(i)))
+	}
+	if err := mc.Execute(nil); err != nil {
+		return nil, err
+	}
+
+	// Get node minipool counts
+	details, err := getMinipoolCountDetailsMulticall(rp, mc, addresses)
+	if err != nil {
+		return nil, err
+	}
+	setMinipoolCounts(response, details)
+
+	// Return
+	return rplPrice, nil
 
 }
+
+// Count the node's minipools by status
+func setMinipoolCounts(response *api.NodeStatusResponse, details []minipoolCountDetails) {
+	response.MinipoolCounts.Total = len(details)
+	for _, mpDetails := range details {
+		if mpDetails.Finalised {
+			response.MinipoolCounts.Finalised++
+		} else {
+			switch mpDetails.Status {
+			case types.Initialized:
+				response.MinipoolCounts.Initialized++
+			case types.Prelaunch:
+				response.MinipoolCounts.Prelaunch++
+			case types.Staking:
+				response.MinipoolCounts.Staking++
+			case types.Withdrawable:
+				response.MinipoolCounts.Withdrawable++
+			case types.Dissolved:
+				response.MinipoolCounts.Dissolved++
+			}
+			if mpDetails.RefundAvailable {
+				response.MinipoolCounts.RefundAvailable++
+			}
+			if mpDetails.WithdrawalAvailable {
+				response.MinipoolCounts.WithdrawalAvailable++
+			}
+			if mpDetails.CloseAvailable {
+				response.MinipoolCounts.CloseAvailable++
+			}
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package node
+
+import (
+	"context"
+	"math/big"
+	"os"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/settings/trustednode"
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
+	"github.com/urfave/cli"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func getTodo(c *cli.Context) (*api.NodeTodoResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NodeTodoResponse{
+		UnclaimedIntervals: []uint64{},
+		UnclaimedRpl:       big.NewInt(0),
+		UnclaimedEth:       big.NewInt(0),
+		StakeableMinipools: []common.Address{},
+	}
+
+	// Get the unclaimed rewards from the intervals the node has tree files for
+	files, err := rewards.ListRewardsFiles(os.ExpandEnv(cfg.Smartnode.GetRewardsTreePath()), string(cfg.Smartnode.GetNetwork()))
+	if err != nil {
+		return nil, err
+	}
+	for _, storedFile := range files {
+		file, err := rewards.ReadRewardsFile(storedFile.Path)
+		if err != nil {
+			return nil, err
+		}
+		nodeRewards, exists := file.NodeRewards[nodeAccount.Address]
+		if !exists {
+			continue
+		}
+		claimed, err := rewards.IsClaimed(rp, file.Index, nodeAccount.Address, nil)
+		if err != nil {
+			return nil, err
+		}
+		if claimed {
+			continue
+		}
+		response.UnclaimedIntervals = append(response.UnclaimedIntervals, file.Index)
+		if nodeRewards.CollateralRpl != nil {
+			response.UnclaimedRpl.Add(response.UnclaimedRpl, &nodeRewards.CollateralRpl.Int)
+		}
+		if nodeRewards.OracleDaoRpl != nil {
+			response.UnclaimedRpl.Add(response.UnclaimedRpl, &nodeRewards.OracleDaoRpl.Int)
+		}
+		if nodeRewards.SmoothingPoolEth != nil {
+			response.UnclaimedEth.Add(response.UnclaimedEth, &nodeRewards.SmoothingPoolEth.Int)
+		}
+	}
+
+	// Get the node's minipool statuses
+	addresses, err := minipool.GetNodeMinipoolAddresses(rp, nodeAccount.Address, nil)
+	if err != nil {
+		return nil, err
+	}
+	statuses := make([]minipool.StatusDetails, len(addresses))
+	var wg errgroup.Group
+	for mi, address := range addresses {
+		mi, address := mi, address
+		wg.Go(func() error {
+			mp, err := minipool.NewMinipool(rp, address)
+			if err != nil {
+				return err
+			}
+			status, err := mp.GetStatusDetails(nil)
+			if err == nil {
+				statuses[mi] = status
+			}
+			return err
+		})
+	}
+	if err := wg.Wait(); err != nil {
+		return nil, err
+	}
+
+	// Get the prelaunch minipools that have passed the scrub check period
+	scrubPeriodSeconds, err := trustednode.GetScrubPeriod(rp, nil)
+	if err != nil {
+		return nil, err
+	}
+	latestBlock, err := rp.Client.HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		return nil, err
+	}
+	latestBlockTime := time.Unix(int64(latestBlock.Time), 0)
+	for mi, status := range statuses {
+		if status.Status == rptypes.Prelaunch && status.StatusTime.Add(time.Duration(scrubPeriodSeconds)*time.Second).Before(latestBlockTime) {
+			response.StakeableMinipools = append(response.StakeableMinipools, addresses[mi])
+		}
+	}
+
+	// Return response
+	return &response, nil
+
+}
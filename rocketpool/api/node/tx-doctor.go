@@ -0,0 +1,294 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// The minimum fee increase required by execution clients to replace a pending transaction, in percent
+const replacementFeeBumpPercent int64 = 10
+
+func getTxDoctorStatus(c *cli.Context) (*api.NodeTxDoctorStatusResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NodeTxDoctorStatusResponse{
+		MissingNonces: []uint64{},
+		Transactions:  []api.NodeTxDoctorTransaction{},
+	}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the nonces
+	response.LatestNonce, err = ec.NonceAt(context.Background(), nodeAccount.Address, nil)
+	if err != nil {
+		return nil, fmt.Errorf("Error getting latest nonce: %w", err)
+	}
+	response.PendingNonce, err = ec.PendingNonceAt(context.Background(), nodeAccount.Address)
+	if err != nil {
+		return nil, fmt.Errorf("Error getting pending nonce: %w", err)
+	}
+
+	// Get the current base fee
+	header, err := ec.HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("Error getting latest block header: %w", err)
+	}
+	response.BaseFee = header.BaseFee
+
+	// Get the gas estimate for a self-transfer, which is used to fill nonce gaps
+	opts, err := w.GetNodeAccountTransactor()
+	if err != nil {
+		return nil, err
+	}
+	gasInfo, err := eth.EstimateSendTransactionGas(ec, nodeAccount.Address, opts)
+	if err != nil {
+		return nil, err
+	}
+	response.GasInfo = gasInfo
+
+	// Get the node's transactions in the mempool; not every client supports this
+	pending, queued, err := ec.GetAccountTxPoolContent(context.Background(), nodeAccount.Address)
+	if err != nil {
+		return &response, nil
+	}
+	response.TxPoolAvailable = true
+
+	for _, tx := range pending {
+		txInfo := getTxDoctorTransaction(tx, false)
+		txInfo.Stuck = (response.BaseFee != nil && txInfo.MaxFee.Cmp(response.BaseFee) < 0)
+		response.Transactions = append(response.Transactions, txInfo)
+	}
+
+	// Queued transactions are blocked by every missing nonce below them
+	queuedNonces := map[uint64]bool{}
+	highestQueuedNonce := uint64(0)
+	for _, tx := range queued {
+		txInfo := getTxDoctorTransaction(tx, true)
+		response.Transactions = append(response.Transactions, txInfo)
+		queuedNonces[txInfo.Nonce] = true
+		if txInfo.Nonce > highestQueuedNonce {
+			highestQueuedNonce = txInfo.Nonce
+		}
+	}
+	for nonce := response.PendingNonce; len(queued) > 0 && nonce < highestQueuedNonce; nonce++ {
+		if !queuedNonces[nonce] {
+			response.MissingNonces = append(response.MissingNonces, nonce)
+		}
+	}
+	sort.Slice(response.Transactions, func(i, j int) bool {
+		return response.Transactions[i].Nonce < response.Transactions[j].Nonce
+	})
+
+	// Return response
+	return &response, nil
+
+}
+
+func fillNonceGap(c *cli.Context, nonce uint64) (*api.NodeFillNonceGapResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NodeFillNonceGapResponse{}
+
+	// Get transactor
+	opts, err := w.GetNodeAccountTransactor()
+	if err != nil {
+		return nil, err
+	}
+
+	// Make sure the nonce hasn't already been mined
+	latestNonce, err := ec.NonceAt(context.Background(), opts.From, nil)
+	if err != nil {
+		return nil, fmt.Errorf("Error getting latest nonce: %w", err)
+	}
+	if nonce < latestNonce {
+		return nil, fmt.Errorf("Can't use nonce %d because it has already been mined.", nonce)
+	}
+
+	// Send 0 ETH to the node itself with the missing nonce
+	opts.Nonce = new(big.Int).SetUint64(nonce)
+	opts.Value = big.NewInt(0)
+	hash, err := eth.SendTransaction(ec, opts.From, w.GetChainID(), opts)
+	if err != nil {
+		return nil, err
+	}
+	response.TxHash = hash
+
+	// Return response
+	return &response, nil
+
+}
+
+func replaceTx(c *cli.Context, nonce uint64) (*api.NodeReplaceTxResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NodeReplaceTxResponse{}
+
+	// Get transactor
+	opts, err := w.GetNodeAccountTransactor()
+	if err != nil {
+		return nil, err
+	}
+
+	// Find the transaction with the provided nonce
+	pending, queued, err := ec.GetAccountTxPoolContent(context.Background(), opts.From)
+	if err != nil {
+		return nil, fmt.Errorf("Error getting the node's transactions from the mempool: %w", err)
+	}
+	var original *services.PendingTransaction
+	for _, tx := range append(pending, queued...) {
+		if uint64(tx.Nonce) == nonce {
+			tx := tx
+			original = &tx
+			break
+		}
+	}
+	if original == nil {
+		return nil, fmt.Errorf("The node doesn't have a pending transaction with nonce %d.", nonce)
+	}
+
+	// Re-sign the original transaction with the new fees
+	originalInfo := getTxDoctorTransaction(*original, false)
+	value := big.NewInt(0)
+	if original.Value != nil {
+		value = original.Value.ToInt()
+	}
+	response.TxHash, err = sendReplacementTx(w, ec, opts, nonce, original.To, value, original.Input, uint64(original.Gas), originalInfo.MaxFee, originalInfo.MaxPriorityFee)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+// Sign and send a transaction that replaces the pending one with the provided nonce.
+// The fees are raised to the minimum the Execution client accepts for a replacement if the requested ones are lower.
+func sendReplacementTx(w *wallet.Wallet, ec *services.ExecutionClientManager, opts *bind.TransactOpts, nonce uint64, to *common.Address, value *big.Int, data []byte, gas uint64, originalMaxFee *big.Int, originalMaxPriorityFee *big.Int) (common.Hash, error) {
+
+	// Make sure the new fees are high enough for the client to accept the replacement
+	maxFee := getReplacementFee(opts.GasFeeCap, originalMaxFee)
+	maxPriorityFee := getReplacementFee(opts.GasTipCap, originalMaxPriorityFee)
+	if maxPriorityFee.Cmp(maxFee) > 0 {
+		maxFee = maxPriorityFee
+	}
+	if opts.GasLimit != 0 {
+		gas = opts.GasLimit
+	}
+
+	// Sign and send it
+	tx := types.NewTx(&types.DynamicFeeTx{
+		ChainID:    w.GetChainID(),
+		Nonce:      nonce,
+		GasTipCap:  maxPriorityFee,
+		GasFeeCap:  maxFee,
+		Gas:        gas,
+		To:         to,
+		Value:      value,
+		Data:       data,
+		AccessList: []types.AccessTuple{},
+	})
+	signedTx, err := opts.Signer(opts.From, tx)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if err := ec.SendTransaction(context.Background(), signedTx); err != nil {
+		return common.Hash{}, err
+	}
+	return signedTx.Hash(), nil
+
+}
+
+// Convert a mempool transaction into its tx doctor representation
+func getTxDoctorTransaction(tx services.PendingTransaction, queued bool) api.NodeTxDoctorTransaction {
+
+	// Legacy transactions only have a gas price
+	maxFee := big.NewInt(0)
+	maxPriorityFee := big.NewInt(0)
+	if tx.GasFeeCap != nil {
+		maxFee = tx.GasFeeCap.ToInt()
+	} else if tx.GasPrice != nil {
+		maxFee = tx.GasPrice.ToInt()
+	}
+	if tx.GasTipCap != nil {
+		maxPriorityFee = tx.GasTipCap.ToInt()
+	} else if tx.GasPrice != nil {
+		maxPriorityFee = tx.GasPrice.ToInt()
+	}
+
+	return api.NodeTxDoctorTransaction{
+		Nonce:          uint64(tx.Nonce),
+		Hash:           tx.Hash,
+		To:             tx.To,
+		MaxFee:         maxFee,
+		MaxPriorityFee: maxPriorityFee,
+		Queued:         queued,
+	}
+
+}
+
+// Get the larger of the requested fee and the minimum fee required to replace a transaction with the original fee
+func getReplacementFee(requestedFee *big.Int, originalFee *big.Int) *big.Int {
+	minimumFee := new(big.Int).Mul(originalFee, big.NewInt(100+replacementFeeBumpPercent))
+	minimumFee.Div(minimumFee, big.NewInt(100))
+	minimumFee.Add(minimumFee, big.NewInt(1))
+	if requestedFee == nil || requestedFee.Cmp(minimumFee) < 0 {
+		return minimumFee
+	}
+	return requestedFee
+}
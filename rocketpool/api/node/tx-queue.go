@@ -0,0 +1,34 @@
+package node
+
+import (
+	"os"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/txqueue"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func getTxQueue(c *cli.Context) (*api.NodeTxQueueResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NodeTxQueueResponse{}
+
+	// Get the node daemon's queued transactions
+	txs, err := txqueue.NewQueue(os.ExpandEnv(cfg.Smartnode.GetTxQueuePath()), services.GetClock(c)).List()
+	if err != nil {
+		return nil, err
+	}
+	response.Transactions = txs
+
+	// Return response
+	return &response, nil
+
+}
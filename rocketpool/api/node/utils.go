@@ -7,8 +7,11 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/rocket-pool/rocketpool-go/minipool"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/tokens"
 	"github.com/rocket-pool/rocketpool-go/types"
 	"golang.org/x/sync/errgroup"
+
+	"github.com/rocket-pool/smartnode/shared/utils/multicall"
 )
 
 // Settings
@@ -125,12 +128,72 @@ func getMinipoolCountDetails(rp *rocketpool.RocketPool, minipoolAddress common.A
 	}
 
 	// Return
+	return newMinipoolCountDetails(status, refundBalance, finalised), nil
+
+}
+
+// Get the count details of minipools, batching all of their calls together
+func getMinipoolCountDetailsMulticall(rp *rocketpool.RocketPool, mc *multicall.MultiCaller, addresses []common.Address) ([]minipoolCountDetails, error) {
+
+	// Load data
+	statuses := make([]uint8, len(addresses))
+	refundBalances := make([]*big.Int, len(addresses))
+	finalised := make([]bool, len(addresses))
+	for i, address := range addresses {
+		mp, err := minipool.NewMinipool(rp, address)
+		if err != nil {
+			return []minipoolCountDetails{}, err
+		}
+		mc.AddCall(mp.Contract, &statuses[i], "getStatus")
+		mc.AddCall(mp.Contract, &refundBalances[i], "getNodeRefundBalance")
+		mc.AddCall(mp.Contract, &finalised[i], "getFinalised")
+	}
+	if err := mc.Execute(nil); err != nil {
+		return []minipoolCountDetails{}, err
+	}
+
+	// Return
+	details := make([]minipoolCountDetails, len(addresses))
+	for i := range addresses {
+		details[i] = newMinipoolCountDetails(types.MinipoolStatus(statuses[i]), refundBalances[i], finalised[i])
+	}
+	return details, nil
+
+}
+
+// Create a minipool's count details from its status
+func newMinipoolCountDetails(status types.MinipoolStatus, refundBalance *big.Int, finalised bool) minipoolCountDetails {
 	return minipoolCountDetails{
 		Status:              status,
 		RefundAvailable:     (refundBalance.Cmp(big.NewInt(0)) > 0),
 		WithdrawalAvailable: (status == types.Withdrawable),
 		CloseAvailable:      (status == types.Dissolved),
 		Finalised:           finalised,
-	}, nil
+	}
+}
 
+// Get a set of network contracts by name
+func getContracts(rp *rocketpool.RocketPool, names ...string) (map[string]*rocketpool.Contract, error) {
+	contracts := make(map[string]*rocketpool.Contract, len(names))
+	for _, name := range names {
+		contract, err := rp.GetContract(name)
+		if err != nil {
+			return nil, err
+		}
+		contracts[name] = contract
+	}
+	return contracts, nil
+}
+
+// Queue the calls for an address's token balances
+func addBalanceCalls(rp *rocketpool.RocketPool, mc *multicall.MultiCaller, address common.Address, balances *tokens.Balances) error {
+	contracts, err := getContracts(rp, "rocketTokenRETH", "rocketTokenRPL", "rocketTokenRPLFixedSupply")
+	if err != nil {
+		return err
+	}
+	mc.AddEthBalance(address, &balances.ETH)
+	mc.AddCall(contracts["rocketTokenRETH"], &balances.RETH, "balanceOf", address)
+	mc.AddCall(contracts["rocketTokenRPL"], &balances.RPL, "balanceOf", address)
+	mc.AddCall(contracts["rocketTokenRPLFixedSupply"], &balances.FixedSupplyRPL, "balanceOf", address)
+	return nil
 }
@@ -11,7 +11,6 @@ import (
 
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
 	"github.com/rocket-pool/smartnode/shared/services"
-	"github.com/rocket-pool/smartnode/shared/services/config"
 	"github.com/rocket-pool/smartnode/shared/services/contracts"
 	"github.com/rocket-pool/smartnode/shared/types/api"
 	"github.com/rocket-pool/smartnode/shared/utils/eth1"
@@ -42,7 +41,7 @@ func estimateSetSnapshotDelegateGas(c *cli.Context, address common.Address) (*ap
 	// Get the snapshot address
 	addressString := cfg.Smartnode.GetSnapshotDelegationAddress()
 	if addressString == "" {
-		return nil, fmt.Errorf("Network [%v] does not have a snapshot delegation contract.", cfg.Smartnode.Network.Value.(config.Network))
+		return nil, fmt.Errorf("Network [%v] does not have a snapshot delegation contract.", cfg.Smartnode.GetNetwork())
 	}
 	snapshotDelegationAddress := common.HexToAddress(addressString)
 
@@ -153,7 +152,7 @@ func estimateClearSnapshotDelegateGas(c *cli.Context) (*api.EstimateClearSnapsho
 	// Get the snapshot address
 	addressString := cfg.Smartnode.GetSnapshotDelegationAddress()
 	if addressString == "" {
-		return nil, fmt.Errorf("Network [%v] does not have a snapshot delegation contract.", cfg.Smartnode.Network.Value.(config.Network))
+		return nil, fmt.Errorf("Network [%v] does not have a snapshot delegation contract.", cfg.Smartnode.GetNetwork())
 	}
 	snapshotDelegationAddress := common.HexToAddress(addressString)
 
@@ -893,6 +893,109 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 
 				},
 			},
+			{
+				Name:      "can-cancel-bond-reduction",
+				Usage:     "Check whether the node can vote to cancel a minipool's bond reduction",
+				UsageText: "rocketpool api odao can-cancel-bond-reduction minipool-address",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					minipoolAddress, err := cliutils.ValidateAddress("minipool address", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(canCancelBondReduction(c, minipoolAddress))
+					return nil
+
+				},
+			},
+			{
+				Name:      "cancel-bond-reduction",
+				Usage:     "Vote to cancel a minipool's bond reduction, recording the reason in the emergency audit log",
+				UsageText: "rocketpool api odao cancel-bond-reduction minipool-address reason",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 2); err != nil {
+						return err
+					}
+					minipoolAddress, err := cliutils.ValidateAddress("minipool address", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+					reason := c.Args().Get(1)
+
+					// Run
+					api.PrintResponse(cancelBondReduction(c, minipoolAddress, reason))
+					return nil
+
+				},
+			},
+			{
+				Name:      "can-scrub-minipool",
+				Usage:     "Check whether the node can vote to scrub a minipool",
+				UsageText: "rocketpool api odao can-scrub-minipool minipool-address",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					minipoolAddress, err := cliutils.ValidateAddress("minipool address", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(canScrubMinipool(c, minipoolAddress))
+					return nil
+
+				},
+			},
+			{
+				Name:      "scrub-minipool",
+				Usage:     "Vote to scrub a prelaunch minipool, recording the reason in the emergency audit log",
+				UsageText: "rocketpool api odao scrub-minipool minipool-address reason",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 2); err != nil {
+						return err
+					}
+					minipoolAddress, err := cliutils.ValidateAddress("minipool address", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+					reason := c.Args().Get(1)
+
+					// Run
+					api.PrintResponse(scrubMinipool(c, minipoolAddress, reason))
+					return nil
+
+				},
+			},
+			{
+				Name:      "get-emergency-audit-log",
+				Usage:     "Get the log of emergency actions the node has taken",
+				UsageText: "rocketpool api odao get-emergency-audit-log",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getEmergencyAuditLog(c))
+					return nil
+
+				},
+			},
 		},
 	})
 }
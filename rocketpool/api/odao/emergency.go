@@ -0,0 +1,265 @@
+package odao
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/auditlog"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/eth1"
+	rputils "github.com/rocket-pool/smartnode/shared/utils/rp"
+)
+
+func canCancelBondReduction(c *cli.Context, minipoolAddress common.Address) (*api.CanCancelBondReductionResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeTrusted(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.CanCancelBondReductionResponse{}
+
+	// Get the bond reduction the minipool started
+	reduction, err := rputils.GetBondReduction(rp, minipoolAddress, nil)
+	if errors.Is(err, rputils.ErrBondReductionNotSupported) {
+		response.NotSupported = true
+		return &response, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	response.NotStarted = !reduction.Started
+	response.AlreadyCancelled = reduction.Cancelled
+	response.NewBond = reduction.NewBond
+	response.StartTime = reduction.StartTime
+	if response.NotStarted || response.AlreadyCancelled {
+		return &response, nil
+	}
+
+	// Get gas estimate
+	opts, err := w.GetNodeAccountTransactor()
+	if err != nil {
+		return nil, err
+	}
+	gasInfo, err := rputils.EstimateVoteCancelReductionGas(rp, minipoolAddress, opts)
+	if err != nil {
+		return nil, err
+	}
+	response.GasInfo = gasInfo
+
+	// Update & return response
+	response.CanCancel = true
+	return &response, nil
+
+}
+
+func cancelBondReduction(c *cli.Context, minipoolAddress common.Address, reason string) (*api.CancelBondReductionResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeTrusted(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.CancelBondReductionResponse{}
+
+	// Get transactor
+	opts, err := w.GetNodeAccountTransactor()
+	if err != nil {
+		return nil, err
+	}
+
+	// Override the provided pending TX if requested
+	err = eth1.CheckForNonceOverride(c, opts)
+	if err != nil {
+		return nil, fmt.Errorf("Error checking for nonce override: %w", err)
+	}
+
+	// Vote to cancel the bond reduction
+	hash, err := rputils.VoteCancelReduction(rp, minipoolAddress, opts)
+	if err != nil {
+		return nil, err
+	}
+	response.TxHash = hash
+
+	// Record it in the audit log
+	err = auditlog.NewLog(os.ExpandEnv(cfg.Smartnode.GetEmergencyAuditLogPath())).Append(api.EmergencyAuditLogEntry{
+		Action:          api.EmergencyAction_CancelBondReduction,
+		Source:          api.EmergencyActionSource_Operator,
+		NodeAddress:     opts.From,
+		MinipoolAddress: minipoolAddress,
+		Reason:          reason,
+		TxHash:          hash,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("The vote to cancel the bond reduction was submitted in transaction %s, but it couldn't be recorded in the audit log: %w", hash.Hex(), err)
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+func canScrubMinipool(c *cli.Context, minipoolAddress common.Address) (*api.CanScrubMinipoolResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeTrusted(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.CanScrubMinipoolResponse{}
+
+	// Check the minipool's status; only prelaunch minipools can be scrubbed
+	mp, err := minipool.NewMinipool(rp, minipoolAddress)
+	if err != nil {
+		return nil, err
+	}
+	status, err := mp.GetStatus(nil)
+	if err != nil {
+		return nil, err
+	}
+	response.InvalidStatus = (status != types.Prelaunch)
+	if response.InvalidStatus {
+		return &response, nil
+	}
+
+	// Get gas estimate
+	opts, err := w.GetNodeAccountTransactor()
+	if err != nil {
+		return nil, err
+	}
+	gasInfo, err := mp.EstimateVoteScrubGas(opts)
+	if err != nil {
+		return nil, err
+	}
+	response.GasInfo = gasInfo
+
+	// Update & return response
+	response.CanScrub = true
+	return &response, nil
+
+}
+
+func scrubMinipool(c *cli.Context, minipoolAddress common.Address, reason string) (*api.ScrubMinipoolResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeTrusted(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.ScrubMinipoolResponse{}
+
+	// Create minipool
+	mp, err := minipool.NewMinipool(rp, minipoolAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get transactor
+	opts, err := w.GetNodeAccountTransactor()
+	if err != nil {
+		return nil, err
+	}
+
+	// Override the provided pending TX if requested
+	err = eth1.CheckForNonceOverride(c, opts)
+	if err != nil {
+		return nil, fmt.Errorf("Error checking for nonce override: %w", err)
+	}
+
+	// Vote to scrub the minipool
+	hash, err := mp.VoteScrub(opts)
+	if err != nil {
+		return nil, err
+	}
+	response.TxHash = hash
+
+	// Record it in the audit log
+	err = auditlog.NewLog(os.ExpandEnv(cfg.Smartnode.GetEmergencyAuditLogPath())).Append(api.EmergencyAuditLogEntry{
+		Action:          api.EmergencyAction_ScrubMinipool,
+		Source:          api.EmergencyActionSource_Operator,
+		NodeAddress:     opts.From,
+		MinipoolAddress: minipoolAddress,
+		Reason:          reason,
+		TxHash:          hash,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("The vote to scrub the minipool was submitted in transaction %s, but it couldn't be recorded in the audit log: %w", hash.Hex(), err)
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+func getEmergencyAuditLog(c *cli.Context) (*api.GetEmergencyAuditLogResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.GetEmergencyAuditLogResponse{}
+
+	// Read the audit log
+	response.Entries, err = auditlog.NewLog(os.ExpandEnv(cfg.Smartnode.GetEmergencyAuditLogPath())).List()
+	if err != nil {
+		return nil, err
+	}
+
+	// Return response
+	return &response, nil
+
+}
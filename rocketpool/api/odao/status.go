@@ -1,13 +1,21 @@
 package odao
 
 import (
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/rocket-pool/rocketpool-go/dao/trustednode"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	tnsettings "github.com/rocket-pool/rocketpool-go/settings/trustednode"
 	rptypes "github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/rocketpool-go/utils/strings"
 	"github.com/urfave/cli"
 	"golang.org/x/sync/errgroup"
 
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/multicall"
 )
 
 func getStatus(c *cli.Context) (*api.TNDAOStatusResponse, error) {
@@ -27,6 +35,10 @@ func getStatus(c *cli.Context) (*api.TNDAOStatusResponse, error) {
 	if err != nil {
 		return nil, err
 	}
+	mc, err := services.GetMultiCaller(c)
+	if err != nil {
+		return nil, err
+	}
 
 	// Response
 	response := api.TNDAOStatusResponse{}
@@ -37,11 +49,29 @@ func getStatus(c *cli.Context) (*api.TNDAOStatusResponse, error) {
 		return nil, err
 	}
 
-	// Get membership status
-	isMember, err := trustednode.GetMemberExists(rp, nodeAccount.Address, nil)
+	// Get the node's membership and the proposal counts, batching their calls together if the network has a Multicall3 contract
+	if mc != nil {
+		err = getStatusDetailsMulticall(rp, mc, nodeAccount.Address, &response)
+	} else {
+		err = getStatusDetails(rp, nodeAccount.Address, &response)
+	}
 	if err != nil {
 		return nil, err
 	}
+
+	// Return response
+	return &response, nil
+
+}
+
+// Get the node's membership and the proposal counts, making each call separately
+func getStatusDetails(rp *rocketpool.RocketPool, nodeAddress common.Address, response *api.TNDAOStatusResponse) error {
+
+	// Get membership status
+	isMember, err := trustednode.GetMemberExists(rp, nodeAddress, nil)
+	if err != nil {
+		return err
+	}
 	response.IsMember = isMember
 
 	// Sync
@@ -52,7 +82,7 @@ func getStatus(c *cli.Context) (*api.TNDAOStatusResponse, error) {
 
 		// Check if node can leave
 		wg.Go(func() error {
-			leaveActionable, err := getProposalIsActionable(rp, nodeAccount.Address, "leave")
+			leaveActionable, err := getProposalIsActionable(rp, nodeAddress, "leave")
 			if err == nil {
 				response.CanLeave = leaveActionable
 			}
@@ -61,7 +91,7 @@ func getStatus(c *cli.Context) (*api.TNDAOStatusResponse, error) {
 
 		// Check if node can replace position
 		wg.Go(func() error {
-			replaceActionable, err := getProposalIsActionable(rp, nodeAccount.Address, "replace")
+			replaceActionable, err := getProposalIsActionable(rp, nodeAddress, "replace")
 			if err == nil {
 				response.CanReplace = replaceActionable
 			}
@@ -72,7 +102,7 @@ func getStatus(c *cli.Context) (*api.TNDAOStatusResponse, error) {
 
 		// Check if node can join
 		wg.Go(func() error {
-			joinActionable, err := getProposalIsActionable(rp, nodeAccount.Address, "invited")
+			joinActionable, err := getProposalIsActionable(rp, nodeAddress, "invited")
 			if err == nil {
 				response.CanJoin = joinActionable
 			}
@@ -94,35 +124,112 @@ func getStatus(c *cli.Context) (*api.TNDAOStatusResponse, error) {
 	wg.Go(func() error {
 		proposalStates, err := getProposalStates(rp)
 		if err == nil {
-			response.ProposalCounts.Total = len(proposalStates)
-			for _, state := range proposalStates {
-				switch state {
-				case rptypes.Pending:
-					response.ProposalCounts.Pending++
-				case rptypes.Active:
-					response.ProposalCounts.Active++
-				case rptypes.Cancelled:
-					response.ProposalCounts.Cancelled++
-				case rptypes.Defeated:
-					response.ProposalCounts.Defeated++
-				case rptypes.Succeeded:
-					response.ProposalCounts.Succeeded++
-				case rptypes.Expired:
-					response.ProposalCounts.Expired++
-				case rptypes.Executed:
-					response.ProposalCounts.Executed++
-				}
-			}
+			setProposalCounts(response, proposalStates)
 		}
 		return err
 	})
 
 	// Wait for data
-	if err := wg.Wait(); err != nil {
-		return nil, err
+	return wg.Wait()
+
+}
+
+// Get the node's membership and the proposal counts, batching the calls together
+func getStatusDetailsMulticall(rp *rocketpool.RocketPool, mc *multicall.MultiCaller, nodeAddress common.Address, response *api.TNDAOStatusResponse) error {
+
+	// Get contracts
+	rocketDAONodeTrusted, err := rp.GetContract("rocketDAONodeTrusted")
+	if err != nil {
+		return err
+	}
+	rocketDAONodeTrustedSettingsProposals, err := rp.GetContract(tnsettings.ProposalsSettingsContractName)
+	if err != nil {
+		return err
+	}
+	rocketDAOProposal, err := rp.GetContract("rocketDAOProposal")
+	if err != nil {
+		return err
 	}
 
-	// Return response
-	return &response, nil
+	// Get membership status, the times of the node's executed proposals and the proposal count
+	var memberCount *big.Int
+	var leaveExecutedTime *big.Int
+	var replaceExecutedTime *big.Int
+	var joinExecutedTime *big.Int
+	var actionTime *big.Int
+	var proposalCount *big.Int
+	mc.AddCall(rocketDAONodeTrusted, &response.IsMember, "getMemberIsValid", nodeAddress)
+	mc.AddCall(rocketDAONodeTrusted, &memberCount, "getMemberCount")
+	mc.AddCall(rocketDAONodeTrusted, &leaveExecutedTime, "getMemberProposalExecutedTime", "leave", nodeAddress)
+	mc.AddCall(rocketDAONodeTrusted, &replaceExecutedTime, "getMemberProposalExecutedTime", "replace", nodeAddress)
+	mc.AddCall(rocketDAONodeTrusted, &joinExecutedTime, "getMemberProposalExecutedTime", "invited", nodeAddress)
+	mc.AddCall(rocketDAONodeTrustedSettingsProposals, &actionTime, "getActionTime")
+	mc.AddCall(rocketDAOProposal, &proposalCount, "getTotal")
+	if err := mc.Execute(nil); err != nil {
+		return err
+	}
+	response.TotalMembers = memberCount.Uint64()
 
+	// Check which proposals are actionable
+	currentTime := uint64(time.Now().Unix())
+	if response.IsMember {
+		response.CanLeave = currentTime < leaveExecutedTime.Uint64()+actionTime.Uint64()
+		response.CanReplace = currentTime < replaceExecutedTime.Uint64()+actionTime.Uint64()
+	} else {
+		response.CanJoin = currentTime < joinExecutedTime.Uint64()+actionTime.Uint64()
+	}
+
+	// Get the DAO of each proposal; proposals are 1-indexed
+	daoNames := make([]string, proposalCount.Uint64())
+	for i := range daoNames {
+		mc.AddCall(rocketDAOProposal, &daoNames[i], "getDAO", big.NewInt(int64(i+1)))
+	}
+	if err := mc.Execute(nil); err != nil {
+		return err
+	}
+
+	// Get the states of the oracle DAO's proposals
+	proposalIds := []int64{}
+	for i, daoName := range daoNames {
+		if strings.Sanitize(daoName) == "rocketDAONodeTrustedProposals" {
+			proposalIds = append(proposalIds, int64(i+1))
+		}
+	}
+	states := make([]uint8, len(proposalIds))
+	for i, proposalId := range proposalIds {
+		mc.AddCall(rocketDAOProposal, &states[i], "getState", big.NewInt(proposalId))
+	}
+	if err := mc.Execute(nil); err != nil {
+		return err
+	}
+	proposalStates := make([]rptypes.ProposalState, len(states))
+	for i, state := range states {
+		proposalStates[i] = rptypes.ProposalState(state)
+	}
+	setProposalCounts(response, proposalStates)
+	return nil
+
+}
+
+// Count the oracle DAO's proposals by state
+func setProposalCounts(response *api.TNDAOStatusResponse, proposalStates []rptypes.ProposalState) {
+	response.ProposalCounts.Total = len(proposalStates)
+	for _, state := range proposalStates {
+		switch state {
+		case rptypes.Pending:
+			response.ProposalCounts.Pending++
+		case rptypes.Active:
+			response.ProposalCounts.Active++
+		case rptypes.Cancelled:
+			response.ProposalCounts.Cancelled++
+		case rptypes.Defeated:
+			response.ProposalCounts.Defeated++
+		case rptypes.Succeeded:
+			response.ProposalCounts.Succeeded++
+		case rptypes.Expired:
+			response.ProposalCounts.Expired++
+		case rptypes.Executed:
+			response.ProposalCounts.Executed++
+		}
+	}
 }
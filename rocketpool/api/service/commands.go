@@ -52,6 +52,24 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 
 				},
 			},
+
+			{
+				Name:      "get-validator-shards",
+				Usage:     "Gets the validator keys assigned to each Validator client shard",
+				UsageText: "rocketpool api service get-validator-shards",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getValidatorShards(c))
+					return nil
+
+				},
+			},
 		},
 	})
 }
@@ -0,0 +1,77 @@
+package service
+
+import (
+	"sort"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Gets the validator keys assigned to each Validator client shard
+func getValidatorShards(c *cli.Context) (*api.ValidatorShardsResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.ValidatorShardsResponse{
+		ShardCount: cfg.GetValidatorShardCount(),
+		Shards:     []api.ValidatorShard{},
+		Orphaned:   []api.ValidatorShard{},
+	}
+	for shard := uint64(0); shard < response.ShardCount; shard++ {
+		response.Shards = append(response.Shards, api.ValidatorShard{
+			Shard:     shard,
+			Container: config.GetValidatorShardContainerName(shard),
+			Pubkeys:   []string{},
+		})
+	}
+
+	// The keys are all in the main Validator client when they aren't split
+	assignments := w.GetShardAssignments()
+	if assignments == nil {
+		return &response, nil
+	}
+	shards, err := assignments.GetAssignments()
+	if err != nil {
+		return nil, err
+	}
+
+	// Sort the keys into their shards, keeping track of the ones in shards that have since been removed
+	orphaned := map[uint64][]string{}
+	for pubkey, shard := range shards {
+		if shard < response.ShardCount {
+			response.Shards[shard].Pubkeys = append(response.Shards[shard].Pubkeys, pubkey)
+		} else {
+			orphaned[shard] = append(orphaned[shard], pubkey)
+		}
+	}
+	for i := range response.Shards {
+		sort.Strings(response.Shards[i].Pubkeys)
+	}
+	for shard, pubkeys := range orphaned {
+		sort.Strings(pubkeys)
+		response.Orphaned = append(response.Orphaned, api.ValidatorShard{
+			Shard:     shard,
+			Container: config.GetValidatorShardContainerName(shard),
+			Pubkeys:   pubkeys,
+		})
+	}
+	sort.Slice(response.Orphaned, func(i, j int) bool {
+		return response.Orphaned[i].Shard < response.Orphaned[j].Shard
+	})
+
+	// Return response
+	return &response, nil
+
+}
@@ -92,6 +92,18 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 						Name:  "skip-validator-key-recovery, k",
 						Usage: "Recover the node wallet, but do not regenerate its validator keys",
 					},
+					cli.BoolFlag{
+						Name:  "search-validator-paths, s",
+						Usage: "Search other validator key derivation paths known to be used by staking tools for keys that aren't on the Smartnode's path",
+					},
+					cli.StringFlag{
+						Name:  "validator-key-paths, p",
+						Usage: "A comma-separated list of custom validator key derivation paths to search, where %d is the key index (e.g. \"m/12381/3600/%d/0/0\")",
+					},
+					cli.StringFlag{
+						Name:  "validator-key-index-range, r",
+						Usage: "The range of key indices to search on each validator key derivation path, in the form start-end",
+					},
 					cli.StringFlag{
 						Name:  "derivation-path, d",
 						Usage: "Specify the derivation path for the wallet.\nOmit this flag (or leave it blank) for the default of \"m/44'/60'/0'/0/%d\" (where %d is the index).\nSet this to \"ledgerLive\" to use Ledger Live's path of \"m/44'/60'/%d/0/0\".\nSet this to \"mew\" to use MyEtherWallet's path of \"m/44'/60'/0'/%d\".\nFor custom paths, simply enter them here.",
@@ -130,6 +142,18 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 						Name:  "skip-validator-key-recovery, k",
 						Usage: "Recover the node wallet, but do not regenerate its validator keys",
 					},
+					cli.BoolFlag{
+						Name:  "search-validator-paths, s",
+						Usage: "Search other validator key derivation paths known to be used by staking tools for keys that aren't on the Smartnode's path",
+					},
+					cli.StringFlag{
+						Name:  "validator-key-paths, p",
+						Usage: "A comma-separated list of custom validator key derivation paths to search, where %d is the key index (e.g. \"m/12381/3600/%d/0/0\")",
+					},
+					cli.StringFlag{
+						Name:  "validator-key-index-range, r",
+						Usage: "The range of key indices to search on each validator key derivation path, in the form start-end",
+					},
 				},
 				Action: func(c *cli.Context) error {
 
@@ -153,6 +177,52 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "recover-validator-keys",
+				Aliases:   []string{"v"},
+				Usage:     "Regenerate the validator keystores for a node's minipools from its mnemonic phrase, without saving the node wallet",
+				UsageText: "rocketpool api wallet recover-validator-keys mnemonic",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "derivation-path, d",
+						Usage: "Specify the derivation path for the wallet.\nOmit this flag (or leave it blank) for the default of \"m/44'/60'/0'/0/%d\" (where %d is the index).\nSet this to \"ledgerLive\" to use Ledger Live's path of \"m/44'/60'/%d/0/0\".\nSet this to \"mew\" to use MyEtherWallet's path of \"m/44'/60'/0'/%d\".\nFor custom paths, simply enter them here.",
+					},
+					cli.UintFlag{
+						Name:  "wallet-index, i",
+						Usage: "Specify the index to use with the derivation path when recovering your wallet",
+						Value: 0,
+					},
+					cli.BoolFlag{
+						Name:  "search-validator-paths, s",
+						Usage: "Search other validator key derivation paths known to be used by staking tools for keys that aren't on the Smartnode's path",
+					},
+					cli.StringFlag{
+						Name:  "validator-key-paths, p",
+						Usage: "A comma-separated list of custom validator key derivation paths to search, where %d is the key index (e.g. \"m/12381/3600/%d/0/0\")",
+					},
+					cli.StringFlag{
+						Name:  "validator-key-index-range, r",
+						Usage: "The range of key indices to search on each validator key derivation path, in the form start-end",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					mnemonic, err := cliutils.ValidateWalletMnemonic("mnemonic", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(recoverValidatorKeys(c, mnemonic))
+					return nil
+
+				},
+			},
+
 			{
 				Name:      "rebuild",
 				Aliases:   []string{"b"},
@@ -261,6 +331,154 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 
 				},
 			},
+
+			{
+				Name:      "export-slashing-protection",
+				Usage:     "Remove the validator keys from the Validator Client and export their slashing protection history in EIP-3076 format",
+				UsageText: "rocketpool api wallet export-slashing-protection",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(exportSlashingProtection(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "import-slashing-protection",
+				Usage:     "Import the validator keys and slashing protection history in the staged EIP-3076 file into the Validator Client",
+				UsageText: "rocketpool api wallet import-slashing-protection",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(importSlashingProtection(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "set-hardware-account",
+				Usage:     "Use an account on a hardware wallet as the node account",
+				UsageText: "rocketpool api wallet set-hardware-account type address derivation-path",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 3); err != nil {
+						return err
+					}
+					address, err := cliutils.ValidateAddress("address", c.Args().Get(1))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(setHardwareWalletAccount(c, c.Args().Get(0), address, c.Args().Get(2)))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "clear-hardware-account",
+				Usage:     "Go back to using the account derived from the mnemonic as the node account",
+				UsageText: "rocketpool api wallet clear-hardware-account",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(clearHardwareWalletAccount(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "signing-queue",
+				Usage:     "Get the transactions waiting to be signed by the hardware wallet",
+				UsageText: "rocketpool api wallet signing-queue",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getSigningQueue(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "prepare-queued-tx",
+				Usage:     "Get a queued transaction with an up-to-date nonce and gas price, ready to be signed by the hardware wallet",
+				UsageText: "rocketpool api wallet prepare-queued-tx id",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(prepareQueuedTransaction(c, c.Args().Get(0)))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "submit-queued-tx",
+				Usage:     "Submit a queued transaction that has been signed by the hardware wallet",
+				UsageText: "rocketpool api wallet submit-queued-tx id signed-tx",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 2); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(submitQueuedTransaction(c, c.Args().Get(0), c.Args().Get(1)))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "remove-queued-tx",
+				Usage:     "Remove a transaction from the signing queue without submitting it",
+				UsageText: "rocketpool api wallet remove-queued-tx id",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(removeQueuedTransaction(c, c.Args().Get(0)))
+					return nil
+
+				},
+			},
 		},
 	})
 }
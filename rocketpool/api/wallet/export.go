@@ -41,12 +41,14 @@ func exportWallet(c *cli.Context) (*api.ExportWalletResponse, error) {
 	}
 	response.Wallet = wallet
 
-	// Get account private key
-	privateKey, err := w.GetNodePrivateKeyBytes()
-	if err != nil {
-		return nil, err
+	// Get account private key; it never leaves a hardware wallet
+	if !w.IsHardwareNodeAccount() {
+		privateKey, err := w.GetNodePrivateKeyBytes()
+		if err != nil {
+			return nil, err
+		}
+		response.AccountPrivateKey = hex.EncodeToString(privateKey)
 	}
-	response.AccountPrivateKey = hex.EncodeToString(privateKey)
 
 	// Return response
 	return &response, nil
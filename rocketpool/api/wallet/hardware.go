@@ -0,0 +1,332 @@
+package wallet
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/rocket-pool/rocketpool-go/node"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	hexutils "github.com/rocket-pool/smartnode/shared/utils/hex"
+)
+
+func setHardwareWalletAccount(c *cli.Context, walletType string, address common.Address, derivationPath string) (*api.SetHardwareWalletAccountResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.SetHardwareWalletAccountResponse{}
+
+	// Use the hardware wallet's account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	if err := checkNodeAccountCanChange(c, nodeAccount.Address, address); err != nil {
+		return nil, err
+	}
+	if err := w.SetHardwareNodeAccount(walletType, address, derivationPath); err != nil {
+		return nil, err
+	}
+	if err := w.Save(); err != nil {
+		return nil, err
+	}
+	response.AccountAddress = address
+
+	// Return response
+	return &response, nil
+
+}
+
+func clearHardwareWalletAccount(c *cli.Context) (*api.ClearHardwareWalletAccountResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	if !w.IsHardwareNodeAccount() {
+		return nil, fmt.Errorf("The node account is not on a hardware wallet.")
+	}
+
+	// Go back to the mnemonic's account
+	hardwareAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	w.ClearHardwareNodeAccount()
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	if err := checkNodeAccountCanChange(c, hardwareAccount.Address, nodeAccount.Address); err != nil {
+		return nil, err
+	}
+	if err := w.Save(); err != nil {
+		return nil, err
+	}
+
+	// Return response
+	return &api.ClearHardwareWalletAccountResponse{
+		AccountAddress: nodeAccount.Address,
+	}, nil
+
+}
+
+func getSigningQueue(c *cli.Context) (*api.SigningQueueResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.SigningQueueResponse{
+		Transactions: []api.QueuedTransaction{},
+	}
+
+	// Get the queued transactions
+	queue, err := w.GetSigningQueue().List()
+	if err != nil {
+		return nil, err
+	}
+	for _, queued := range queue {
+		response.Transactions = append(response.Transactions, api.QueuedTransaction{
+			ID:      queued.ID,
+			Created: queued.Created,
+			Tx:      queued.Tx,
+		})
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+func prepareQueuedTransaction(c *cli.Context, id string) (*api.PrepareQueuedTransactionResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireEthClientSynced(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+	if !w.IsHardwareNodeAccount() {
+		return nil, fmt.Errorf("The node account is not on a hardware wallet.")
+	}
+
+	// Response
+	response := api.PrepareQueuedTransactionResponse{}
+
+	// Get the queued transaction
+	queued, err := w.GetSigningQueue().Get(id)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := queued.Transaction()
+	if err != nil {
+		return nil, err
+	}
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the current nonce, since other transactions may have been sent since this one was queued
+	nonce, err := ec.PendingNonceAt(context.Background(), nodeAccount.Address)
+	if err != nil {
+		return nil, fmt.Errorf("Error getting the node account's nonce: %w", err)
+	}
+
+	// Hardware wallets can only sign legacy transactions, so use the suggested gas price capped at the transaction's max fee
+	gasPrice, err := ec.SuggestGasPrice(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("Error getting the suggested gas price: %w", err)
+	}
+	if maxFee := tx.GasFeeCap(); maxFee != nil && maxFee.Sign() > 0 && gasPrice.Cmp(maxFee) > 0 {
+		gasPrice = maxFee
+	}
+
+	// Rebuild the transaction
+	unsignedTx := types.NewTx(&types.LegacyTx{
+		Nonce:    nonce,
+		GasPrice: gasPrice,
+		Gas:      tx.Gas(),
+		To:       tx.To(),
+		Value:    tx.Value(),
+		Data:     tx.Data(),
+	})
+	txBytes, err := unsignedTx.MarshalBinary()
+	if err != nil {
+		return nil, fmt.Errorf("Error serializing transaction: %w", err)
+	}
+	response.Tx = hexutils.AddPrefix(hex.EncodeToString(txBytes))
+	response.ChainID = w.GetChainID().Uint64()
+	response.AccountAddress = nodeAccount.Address
+	response.HardwareWallet = nodeAccount.URL.Scheme
+	response.DerivationPath = nodeAccount.URL.Path
+
+	// Return response
+	return &response, nil
+
+}
+
+func submitQueuedTransaction(c *cli.Context, id string, signedTx string) (*api.SubmitQueuedTransactionResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireEthClientSynced(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.SubmitQueuedTransactionResponse{}
+
+	// Get the queued transaction
+	queue := w.GetSigningQueue()
+	queued, err := queue.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := queued.Transaction()
+	if err != nil {
+		return nil, err
+	}
+
+	// Decode the signed transaction
+	signedTx = hexutils.RemovePrefix(signedTx)
+	signedBytes, err := hex.DecodeString(signedTx)
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing signed transaction [%s]: %w", signedTx, err)
+	}
+	signed := new(types.Transaction)
+	if err := signed.UnmarshalBinary(signedBytes); err != nil {
+		return nil, fmt.Errorf("Error decoding signed transaction: %w", err)
+	}
+
+	// Make sure it's the queued transaction, signed by the node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	sender, err := types.Sender(types.LatestSignerForChainID(w.GetChainID()), signed)
+	if err != nil {
+		return nil, fmt.Errorf("Error getting the signer of the transaction: %w", err)
+	}
+	if sender != nodeAccount.Address {
+		return nil, fmt.Errorf("The transaction was signed by %s instead of the node account %s.", sender.Hex(), nodeAccount.Address.Hex())
+	}
+	if !isSameCall(tx, signed) {
+		return nil, fmt.Errorf("The signed transaction doesn't match queued transaction %s.", id)
+	}
+
+	// Send it and remove it from the queue
+	if err := ec.SendTransaction(context.Background(), signed); err != nil {
+		return nil, fmt.Errorf("Error submitting transaction: %w", err)
+	}
+	if err := queue.Remove(id); err != nil {
+		return nil, err
+	}
+	response.TxHash = signed.Hash()
+
+	// Return response
+	return &response, nil
+
+}
+
+func removeQueuedTransaction(c *cli.Context, id string) (*api.RemoveQueuedTransactionResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Remove the transaction
+	queue := w.GetSigningQueue()
+	if _, err := queue.Get(id); err != nil {
+		return nil, err
+	}
+	if err := queue.Remove(id); err != nil {
+		return nil, err
+	}
+
+	// Return response
+	return &api.RemoveQueuedTransactionResponse{}, nil
+
+}
+
+// The node account is registered on chain, so it can't be changed once the node is registered
+func checkNodeAccountCanChange(c *cli.Context, currentAddress common.Address, newAddress common.Address) error {
+	if currentAddress == newAddress {
+		return nil
+	}
+	if err := services.RequireRocketStorage(c); err != nil {
+		return err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return err
+	}
+	exists, err := node.GetNodeExists(rp, currentAddress, nil)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return fmt.Errorf("The node is already registered with account %s, so the node account can't be changed to %s.", currentAddress.Hex(), newAddress.Hex())
+	}
+	return nil
+}
+
+// Check if two transactions make the same call
+func isSameCall(a *types.Transaction, b *types.Transaction) bool {
+	if (a.To() == nil) != (b.To() == nil) {
+		return false
+	}
+	if a.To() != nil && *a.To() != *b.To() {
+		return false
+	}
+	return a.Value().Cmp(b.Value()) == 0 && a.Gas() == b.Gas() && bytes.Equal(a.Data(), b.Data())
+}
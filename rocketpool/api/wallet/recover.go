@@ -28,6 +28,10 @@ func recoverWallet(c *cli.Context, mnemonic string) (*api.RecoverWalletResponse,
 	if err != nil {
 		return nil, err
 	}
+	search, err := walletutils.GetValidatorKeySearch(c)
+	if err != nil {
+		return nil, err
+	}
 	var rp *rocketpool.RocketPool
 	if !c.Bool("skip-validator-key-recovery") {
 		if err := services.RequireRocketStorage(c); err != nil {
@@ -74,7 +78,7 @@ func recoverWallet(c *cli.Context, mnemonic string) (*api.RecoverWalletResponse,
 	response.AccountAddress = nodeAccount.Address
 
 	if !c.Bool("skip-validator-key-recovery") {
-		response.ValidatorKeys, err = walletutils.RecoverMinipoolKeys(c, rp, nodeAccount.Address, w, false)
+		response.ValidatorKeys, response.ValidatorKeyPaths, err = walletutils.RecoverMinipoolKeysWithSearch(c, rp, nodeAccount.Address, w, false, search)
 		if err != nil {
 			return nil, err
 		}
@@ -90,6 +94,70 @@ func recoverWallet(c *cli.Context, mnemonic string) (*api.RecoverWalletResponse,
 
 }
 
+func recoverValidatorKeys(c *cli.Context, mnemonic string) (*api.RecoverWalletResponse, error) {
+
+	// Get services
+	if err := services.RequireRocketStorage(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	search, err := walletutils.GetValidatorKeySearch(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.RecoverWalletResponse{}
+
+	// Check if wallet is already initialized
+	if w.IsInitialized() {
+		return nil, errors.New("the node wallet is already initialized; use `rocketpool wallet rebuild` to regenerate its validator keys instead")
+	}
+
+	// Get the derivation path
+	path := c.String("derivation-path")
+	switch path {
+	case "":
+		path = wallet.DefaultNodeKeyPath
+	case "ledgerLive":
+		path = wallet.LedgerLiveNodeKeyPath
+	case "mew":
+		path = wallet.MyEtherWalletNodeKeyPath
+	}
+
+	// Get the wallet index
+	walletIndex := c.Uint("wallet-index")
+
+	// Load the keys into memory only, so the node wallet file and password are never written
+	if err := w.TestRecovery(path, walletIndex, mnemonic); err != nil {
+		return nil, err
+	}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	response.AccountAddress = nodeAccount.Address
+
+	// Store the validator keys in the keychain
+	response.ValidatorKeys, response.ValidatorKeyPaths, err = walletutils.RecoverMinipoolKeysWithSearch(c, rp, nodeAccount.Address, w, false, search)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
 func searchAndRecoverWallet(c *cli.Context, mnemonic string, address common.Address) (*api.SearchAndRecoverWalletResponse, error) {
 
 	// Get services
@@ -100,6 +168,10 @@ func searchAndRecoverWallet(c *cli.Context, mnemonic string, address common.Addr
 	if err != nil {
 		return nil, err
 	}
+	search, err := walletutils.GetValidatorKeySearch(c)
+	if err != nil {
+		return nil, err
+	}
 	var rp *rocketpool.RocketPool
 	if !c.Bool("skip-validator-key-recovery") {
 		if err := services.RequireRocketStorage(c); err != nil {
@@ -172,7 +244,7 @@ func searchAndRecoverWallet(c *cli.Context, mnemonic string, address common.Addr
 	response.AccountAddress = nodeAccount.Address
 
 	if !c.Bool("skip-validator-key-recovery") {
-		response.ValidatorKeys, err = walletutils.RecoverMinipoolKeys(c, rp, nodeAccount.Address, w, false)
+		response.ValidatorKeys, response.ValidatorKeyPaths, err = walletutils.RecoverMinipoolKeysWithSearch(c, rp, nodeAccount.Address, w, false, search)
 		if err != nil {
 			return nil, err
 		}
@@ -0,0 +1,142 @@
+package wallet
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
+	"github.com/urfave/cli"
+	eth2types "github.com/wealdtech/go-eth2-types/v2"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/keymanager"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// The parts of an EIP-3076 slashing protection interchange file needed to find its keys
+type slashingProtectionInterchange struct {
+	Data []struct {
+		Pubkey rptypes.ValidatorPubkey `json:"pubkey"`
+	} `json:"data"`
+}
+
+func exportSlashingProtection(c *cli.Context) (*api.ExportSlashingProtectionResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	km, err := keymanager.NewClient(cfg.Smartnode.GetKeymanagerApiUrl(), cfg.Smartnode.GetKeymanagerTokenPath(), cfg.Smartnode.GetKeymanagerCertPath())
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.ExportSlashingProtectionResponse{}
+
+	// Get the keys loaded by the Validator Client
+	keystores, err := km.ListKeystores()
+	if err != nil {
+		return nil, err
+	}
+	pubkeys := []rptypes.ValidatorPubkey{}
+	for _, keystore := range keystores {
+		if !keystore.ReadOnly {
+			pubkeys = append(pubkeys, keystore.Pubkey)
+		}
+	}
+	if len(pubkeys) == 0 {
+		return nil, fmt.Errorf("Your Validator Client doesn't have any validator keys loaded.")
+	}
+
+	// Remove them and get their slashing protection history
+	statuses, slashingProtection, err := km.DeleteKeystores(pubkeys)
+	if err != nil {
+		return nil, err
+	}
+	for i, status := range statuses {
+		response.Keys = append(response.Keys, api.SlashingProtectionKeyStatus{
+			Pubkey:  pubkeys[i],
+			Status:  status.Status,
+			Message: status.Message,
+		})
+	}
+	response.SlashingProtection = slashingProtection
+
+	// Return response
+	return &response, nil
+
+}
+
+func importSlashingProtection(c *cli.Context) (*api.ImportSlashingProtectionResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	km, err := keymanager.NewClient(cfg.Smartnode.GetKeymanagerApiUrl(), cfg.Smartnode.GetKeymanagerTokenPath(), cfg.Smartnode.GetKeymanagerCertPath())
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.ImportSlashingProtectionResponse{}
+
+	// Read the interchange file
+	importPath := cfg.Smartnode.GetSlashingProtectionImportPath()
+	slashingProtection, err := ioutil.ReadFile(importPath)
+	if err != nil {
+		return nil, fmt.Errorf("Could not read the slashing protection file: %w", err)
+	}
+	defer func() {
+		_ = os.Remove(importPath)
+	}()
+	var interchange slashingProtectionInterchange
+	if err := json.Unmarshal(slashingProtection, &interchange); err != nil {
+		return nil, fmt.Errorf("The slashing protection file is not in the EIP-3076 interchange format: %w", err)
+	}
+
+	// Get the wallet's keys for the validators in it
+	keys := []*eth2types.BLSPrivateKey{}
+	pubkeys := []rptypes.ValidatorPubkey{}
+	for _, data := range interchange.Data {
+		key, err := w.GetValidatorKeyByPubkey(data.Pubkey)
+		if err != nil {
+			response.MissingKeys = append(response.MissingKeys, data.Pubkey)
+			continue
+		}
+		keys = append(keys, key)
+		pubkeys = append(pubkeys, data.Pubkey)
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("None of the validators in the slashing protection file belong to the node wallet.")
+	}
+
+	// Import them into the Validator Client along with their history
+	statuses, err := km.ImportKeys(keys, string(slashingProtection))
+	if err != nil {
+		return nil, err
+	}
+	for i, status := range statuses {
+		response.Keys = append(response.Keys, api.SlashingProtectionKeyStatus{
+			Pubkey:  pubkeys[i],
+			Status:  status.Status,
+			Message: status.Message,
+		})
+	}
+
+	// Return response
+	return &response, nil
+
+}
@@ -35,6 +35,7 @@ func getStatus(c *cli.Context) (*api.WalletStatusResponse, error) {
 			return nil, err
 		}
 		response.AccountAddress = nodeAccount.Address
+		response.HardwareWallet = w.GetHardwareWalletType()
 
 	}
 
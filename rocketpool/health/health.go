@@ -0,0 +1,61 @@
+package health
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/health"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// Settings
+const probeTimeout = 10 * time.Second
+
+// Register the health check command, which Docker runs inside the daemon containers
+func RegisterCommands(app *cli.App, name string, aliases []string) {
+	app.Commands = append(app.Commands, cli.Command{
+		Name:      name,
+		Aliases:   aliases,
+		Usage:     "Check the health of the daemon serving its health check on the given port, exiting with an error if it's unhealthy",
+		UsageText: "rocketpool healthcheck port",
+		Action: func(c *cli.Context) error {
+
+			// Validate args
+			if err := cliutils.ValidateArgCount(c, 1); err != nil {
+				return err
+			}
+			port, err := strconv.ParseUint(c.Args().Get(0), 10, 16)
+			if err != nil {
+				return fmt.Errorf("Invalid port '%s': %w", c.Args().Get(0), err)
+			}
+
+			// Run
+			return probe(uint16(port))
+
+		},
+	})
+}
+
+// Query a daemon's health check
+func probe(port uint16) error {
+	client := http.Client{Timeout: probeTimeout}
+	response, err := client.Get(fmt.Sprintf("http://127.0.0.1:%d%s", port, health.HealthCheckPath))
+	if err != nil {
+		return fmt.Errorf("Error querying the health check: %w", err)
+	}
+	defer response.Body.Close()
+	body, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return fmt.Errorf("Error reading the health check response: %w", err)
+	}
+	fmt.Println(string(body))
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("The daemon is unhealthy (HTTP status %d)", response.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,404 @@
+package node
+
+import (
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/network"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/clock"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	rpgas "github.com/rocket-pool/smartnode/shared/services/gas"
+	"github.com/rocket-pool/smartnode/shared/services/journal"
+	"github.com/rocket-pool/smartnode/shared/services/notifications"
+	"github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/services/txqueue"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/utils/api"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+	"github.com/rocket-pool/smartnode/shared/utils/math"
+)
+
+// The name of the auto-claim rewards task in the journal
+const autoClaimRewardsTaskName string = "auto-claim-rewards"
+
+// The ID of the rewards claim transaction in the transaction queue
+const autoClaimRewardsQueueID string = autoClaimRewardsTaskName
+
+// Auto-claim rewards task
+type autoClaimRewards struct {
+	c              *cli.Context
+	log            log.ColorLogger
+	cfg            *config.RocketPoolConfig
+	w              *wallet.Wallet
+	rp             *rocketpool.RocketPool
+	cm             *services.ChainMonitor
+	n              *notifications.Notifier
+	journal        *journal.Journal
+	txQueue        *txqueue.Queue
+	clock          clock.Clock
+	enabled        bool
+	restakePercent float64
+	gasThreshold   float64
+	gasOracle      rpgas.GasOracle
+	maxFee         *big.Int
+	maxPriorityFee *big.Int
+	gasLimit       uint64
+}
+
+// Create auto-claim rewards task
+func newAutoClaimRewards(c *cli.Context, logger log.ColorLogger) (*autoClaimRewards, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+	cm, err := services.GetChainMonitor(c)
+	if err != nil {
+		return nil, err
+	}
+	n, err := services.GetNotifier(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check if auto-claiming is disabled
+	enabled := cfg.Smartnode.GetAutoClaimEnabled()
+	gasThreshold := cfg.Smartnode.GetRplClaimGasThreshold()
+	if enabled && gasThreshold == 0 {
+		logger.Println("RPL claim gas threshold is set to 0, automatic rewards claims will be disabled.")
+	}
+
+	// Get the user-requested max fee
+	maxFeeGwei := cfg.Smartnode.GetManualMaxFee()
+	var maxFee *big.Int
+	if maxFeeGwei == 0 {
+		maxFee = nil
+	} else {
+		maxFee = eth.GweiToWei(maxFeeGwei)
+	}
+
+	// Get the user-requested max fee
+	priorityFeeGwei := cfg.Smartnode.GetPriorityFee()
+	var priorityFee *big.Int
+	if priorityFeeGwei == 0 {
+		logger.Println("WARNING: priority fee was missing or 0, setting a default of 2.")
+		priorityFee = eth.GweiToWei(2)
+	} else {
+		priorityFee = eth.GweiToWei(priorityFeeGwei)
+	}
+
+	// Return task
+	return &autoClaimRewards{
+		c:              c,
+		log:            logger,
+		cfg:            cfg,
+		w:              w,
+		rp:             rp,
+		cm:             cm,
+		n:              n,
+		journal:        journal.NewJournal(cfg.Smartnode.GetJournalPath(), JournalName),
+		txQueue:        txqueue.NewQueue(os.ExpandEnv(cfg.Smartnode.GetTxQueuePath()), services.GetClock(c)),
+		clock:          services.GetClock(c),
+		enabled:        enabled,
+		restakePercent: cfg.Smartnode.GetAutoRestakePercent(),
+		gasThreshold:   gasThreshold,
+		gasOracle:      rpgas.NewGasOracle(cfg, ec, nil),
+		maxFee:         maxFee,
+		maxPriorityFee: priorityFee,
+		gasLimit:       0,
+	}, nil
+
+}
+
+// Claim the node's Merkle rewards
+func (t *autoClaimRewards) run() error {
+
+	// Check to see if auto-claiming is disabled
+	if !t.enabled || t.gasThreshold == 0 {
+		return nil
+	}
+
+	// Wait for eth client to sync
+	if err := services.WaitEthClientSynced(t.c, true); err != nil {
+		return err
+	}
+
+	// Check if tasks that depend on finality are paused
+	if paused, reason := t.cm.IsPaused(); paused {
+		t.log.Printlnf("Skipping the rewards claim check because %s.", reason)
+		return nil
+	}
+
+	// Claim and record the run
+	run := t.journal.StartRun(autoClaimRewardsTaskName)
+	return run.Finish(t.claim(run))
+
+}
+
+// Claim the node's rewards from every unclaimed interval and restake part of the RPL, if they're worth more than the gas required to claim them
+func (t *autoClaimRewards) claim(run *journal.Run) error {
+
+	// Log
+	t.log.Println("Checking for unclaimed rewards...")
+
+	// Get the settings
+	gasThreshold := t.gasThreshold
+	if err := run.Setting("gasThreshold", &gasThreshold); err != nil {
+		return err
+	}
+	restakePercent := t.restakePercent
+	if err := run.Setting("restakePercent", &restakePercent); err != nil {
+		return err
+	}
+	gasLimit := t.gasLimit
+	if err := run.Setting("gasLimit", &gasLimit); err != nil {
+		return err
+	}
+
+	// Get the node account
+	nodeAccount, err := t.w.GetNodeAccount()
+	if err != nil {
+		return err
+	}
+
+	// Get the unclaimed rewards
+	var params rewards.ClaimParams
+	err = run.Input("claimParams", &params, func() error {
+		files, err := t.getUnclaimedRewardsFiles(nodeAccount.Address)
+		if err != nil {
+			return err
+		}
+		params, err = rewards.GetClaimParams(nodeAccount.Address, files)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	if len(params.Indices) == 0 {
+		run.Decide("No rewards are available to claim.")
+		if !run.IsReplay() {
+			if err := t.txQueue.Prune(autoClaimRewardsTaskName, nil); err != nil {
+				t.log.Printlnf("WARNING: %s", err.Error())
+			}
+		}
+		return nil
+	}
+
+	// Get the totals and the amount to restake
+	totalRplWei := big.NewInt(0)
+	totalEthWei := big.NewInt(0)
+	for i := range params.Indices {
+		totalRplWei.Add(totalRplWei, params.AmountsRpl[i])
+		totalEthWei.Add(totalEthWei, params.AmountsEth[i])
+	}
+	stakeAmountWei := new(big.Int).Mul(totalRplWei, big.NewInt(int64(restakePercent*100)))
+	stakeAmountWei.Div(stakeAmountWei, big.NewInt(100*100))
+	totalRpl := math.RoundDown(eth.WeiToEth(totalRplWei), 6)
+	totalEth := math.RoundDown(eth.WeiToEth(totalEthWei), 6)
+	stakeAmount := math.RoundDown(eth.WeiToEth(stakeAmountWei), 6)
+	t.log.Printlnf("%.6f RPL and %.6f ETH are available to claim from %d interval(s)...", totalRpl, totalEth, len(params.Indices))
+
+	// Check for an earlier attempt in the transaction queue
+	queueDescription := fmt.Sprintf("claim %.6f RPL and %.6f ETH in rewards and restake %.6f RPL", totalRpl, totalEth, stakeAmount)
+	queued, due, err := checkTxQueue(run, t.txQueue, t.rp.Client, t.clock, t.log, "queued", autoClaimRewardsQueueID, queueDescription)
+	if err != nil {
+		return err
+	}
+	if !due {
+		return nil
+	}
+
+	// Get transactor
+	opts, err := t.w.GetNodeAccountTransactor()
+	if err != nil {
+		return err
+	}
+
+	// Get the gas limit
+	var gasInfo rocketpool.GasInfo
+	err = run.Input("gasInfo", &gasInfo, func() (err error) {
+		gasInfo, err = rewards.EstimateClaimAndStakeGas(t.rp, nodeAccount.Address, params, stakeAmountWei, opts)
+		return
+	})
+	if err != nil {
+		return fmt.Errorf("Could not estimate the gas required to claim rewards: %w", err)
+	}
+	var gas *big.Int
+	if gasLimit != 0 {
+		gas = new(big.Int).SetUint64(gasLimit)
+	} else {
+		gas = new(big.Int).SetUint64(gasInfo.SafeGasLimit)
+	}
+
+	// Get the max fee
+	maxFee := t.maxFee
+	err = run.Input("maxFee", &maxFee, func() (err error) {
+		if maxFee == nil || maxFee.Uint64() == 0 {
+			maxFee, err = rpgas.GetHeadlessMaxFeeWei(t.gasOracle)
+		}
+		return
+	})
+	if err != nil {
+		return err
+	}
+
+	// Check the threshold
+	if !api.PrintAndCheckGasInfo(gasInfo, true, gasThreshold, t.log, maxFee, gasLimit) {
+		run.Decide("The max fee of %.2f gwei is above the threshold of %.2f gwei, so the claim was postponed.", eth.WeiToGwei(maxFee), gasThreshold)
+		setTxQueueWaiting(run, t.txQueue, t.log, autoClaimRewardsQueueID, autoClaimRewardsTaskName, queueDescription, fmt.Sprintf("The max fee of %.2f gwei is above the threshold of %.2f gwei", eth.WeiToGwei(maxFee), gasThreshold))
+		return nil
+	}
+
+	// Escalate the fees if earlier attempts failed
+	maxFee, priorityFee := getQueuedTxFees(queued, t.log, maxFee, t.maxPriorityFee, t.maxFee, gasThreshold)
+
+	// Check if it's worth more than the gas to claim it
+	var rplPriceWei *big.Int
+	err = run.Input("rplPrice", &rplPriceWei, func() (err error) {
+		rplPriceWei, err = network.GetRPLPrice(t.rp, nil)
+		return
+	})
+	if err != nil {
+		return err
+	}
+	rewardsInEth := eth.WeiToEth(rplPriceWei)*totalRpl + totalEth
+	totalGasWei := new(big.Int).Mul(maxFee, gas)
+	totalEthCost := math.RoundDown(eth.WeiToEth(totalGasWei), 6)
+	if totalEthCost >= rewardsInEth {
+		t.log.Printlnf("Transaction would cost up to %f ETH in gas but only provide %f ETH worth of rewards. Ignoring until gas is cheaper.", totalEthCost, rewardsInEth)
+		run.Decide("Claiming would cost up to %f ETH in gas but only provide %f ETH worth of rewards, so the claim was postponed.", totalEthCost, rewardsInEth)
+		setTxQueueWaiting(run, t.txQueue, t.log, autoClaimRewardsQueueID, autoClaimRewardsTaskName, queueDescription, fmt.Sprintf("The claim would cost up to %f ETH in gas but only provide %f ETH worth of rewards", totalEthCost, rewardsInEth))
+		return nil
+	}
+
+	opts.GasFeeCap = maxFee
+	opts.GasTipCap = priorityFee
+	opts.GasLimit = gas.Uint64()
+
+	// Claim the rewards
+	if !run.Act("Claim %.6f RPL and %.6f ETH in rewards from %d interval(s) and restake %.6f RPL.", totalRpl, totalEth, len(params.Indices), stakeAmount) {
+		return nil
+	}
+	mined, err := submitQueuedTx(t.txQueue, t.cfg, t.rp, t.log, queued, autoClaimRewardsQueueID, autoClaimRewardsTaskName, queueDescription, opts, func() (common.Hash, error) {
+		return rewards.ClaimAndStake(t.rp, nodeAccount.Address, params, stakeAmountWei, opts)
+	})
+	if err != nil {
+		return err
+	}
+	if !mined {
+		return nil
+	}
+
+	// Log
+	message := fmt.Sprintf("Successfully claimed %.6f RPL and %.6f ETH in rewards, restaking %.6f RPL.", totalRpl, totalEth, stakeAmount)
+	t.log.Println(message)
+
+	// Send a notification
+	if err := t.n.Notify(notifications.EventType_RplClaimed, "Rewards claimed", message); err != nil {
+		t.log.Printlnf("WARNING: %s", err.Error())
+	}
+
+	// Return
+	return nil
+
+}
+
+// Get the tree files of the finished intervals the node hasn't claimed its Mainnet rewards from yet.
+// Claimed intervals are skipped before their files are read, and missing files for intervals in the retention window are downloaded.
+func (t *autoClaimRewards) getUnclaimedRewardsFiles(nodeAddress common.Address) ([]*rewards.RewardsFile, error) {
+
+	currentIndex, err := rewards.GetRewardIndex(t.rp, nil)
+	if err != nil {
+		return nil, err
+	}
+	folder := os.ExpandEnv(t.cfg.Smartnode.GetRewardsTreePath())
+	network := string(t.cfg.Smartnode.GetNetwork())
+	downloadFrom := uint64(0)
+	if retention := t.cfg.Smartnode.GetRewardsTreeRetention(); retention != 0 && currentIndex > retention {
+		downloadFrom = currentIndex - retention
+	}
+
+	files := []*rewards.RewardsFile{}
+	for index := uint64(0); index < currentIndex; index++ {
+
+		// Skip the intervals the node has already claimed, before reading their tree files
+		claimed, err := rewards.IsClaimed(t.rp, index, nodeAddress, nil)
+		if err != nil {
+			return nil, err
+		}
+		if claimed {
+			continue
+		}
+
+		// Get the tree file
+		file, err := rewards.LoadRewardsFile(folder, network, index)
+		if err != nil {
+			return nil, err
+		}
+		if file == nil {
+			if index < downloadFrom {
+				continue
+			}
+			file, err = t.downloadRewardsFile(index)
+			if err != nil {
+				t.log.Printlnf("WARNING: Couldn't get the rewards tree for interval %d, so its rewards won't be claimed yet: %s", index, err.Error())
+				continue
+			}
+		}
+
+		// Check if the node has rewards in it that are claimed on Mainnet
+		nodeRewards, exists := file.NodeRewards[nodeAddress]
+		if !exists || nodeRewards.RewardNetwork != rewards.MainnetRewardNetwork {
+			continue
+		}
+		files = append(files, file)
+
+	}
+	return files, nil
+
+}
+
+// Download and save the tree file for an interval
+func (t *autoClaimRewards) downloadRewardsFile(index uint64) (*rewards.RewardsFile, error) {
+	scanner, err := api.GetEventLogScanner(t.cfg, t.rp.Client)
+	if err != nil {
+		return nil, err
+	}
+	dataCache, err := services.GetCache(t.c)
+	if err != nil {
+		return nil, err
+	}
+	event, err := rewards.GetRewardsEvent(t.rp, string(t.cfg.Smartnode.GetNetwork()), index, scanner, dataCache)
+	if err != nil {
+		return nil, err
+	}
+	file, source, err := rewards.DownloadRewardsFile(t.cfg, event)
+	if err != nil {
+		return nil, err
+	}
+	if err := rewards.SaveRewardsFile(os.ExpandEnv(t.cfg.Smartnode.GetRewardsTreePath()), file); err != nil {
+		return nil, err
+	}
+	t.log.Printlnf("Downloaded the rewards tree for interval %d from %s.", index, source.Name)
+	return file, nil
+}
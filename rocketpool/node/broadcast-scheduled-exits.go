@@ -0,0 +1,100 @@
+package node
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/exitqueue"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Broadcast scheduled exits task
+type broadcastScheduledExits struct {
+	c         *cli.Context
+	log       log.ColorLogger
+	cfg       *config.RocketPoolConfig
+	bc        beacon.Client
+	exitQueue *exitqueue.Queue
+}
+
+// Create broadcast scheduled exits task
+func newBroadcastScheduledExits(c *cli.Context, logger log.ColorLogger) (*broadcastScheduledExits, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	return &broadcastScheduledExits{
+		c:         c,
+		log:       logger,
+		cfg:       cfg,
+		bc:        bc,
+		exitQueue: exitqueue.NewQueue(os.ExpandEnv(cfg.Smartnode.GetExitQueuePath())),
+	}, nil
+
+}
+
+// Broadcast the signed exits whose epochs have arrived
+func (t *broadcastScheduledExits) run() error {
+
+	// Check for scheduled exits before waiting on the Beacon client
+	exits, err := t.exitQueue.List()
+	if err != nil {
+		return err
+	}
+	if len(exits) == 0 {
+		return nil
+	}
+
+	// Wait for the Beacon client to sync
+	if err := services.WaitBeaconClientSynced(t.c, true); err != nil {
+		return err
+	}
+
+	// Get the exits that are due
+	head, err := t.bc.GetBeaconHead()
+	if err != nil {
+		return err
+	}
+	due, err := t.exitQueue.GetDue(head.Epoch)
+	if err != nil {
+		return err
+	}
+	if len(due) == 0 {
+		return nil
+	}
+
+	// Log
+	t.log.Printlnf("%d scheduled minipool exit(s) are due at epoch %d, broadcasting...", len(due), head.Epoch)
+
+	// Broadcast them
+	for _, exit := range due {
+		if err := t.bc.ExitValidator(exit.ValidatorIndex, exit.Epoch, exit.Signature); err != nil {
+			t.log.Println(fmt.Errorf("Could not broadcast the exit of minipool %s, it will be tried again on the next run: %w", exit.MinipoolAddress.Hex(), err))
+			if err := t.exitQueue.SetFailed(exit.MinipoolAddress, err); err != nil {
+				t.log.Printlnf("WARNING: %s", err.Error())
+			}
+			continue
+		}
+		t.log.Printlnf("Successfully broadcast the exit of minipool %s (validator %d).", exit.MinipoolAddress.Hex(), exit.ValidatorIndex)
+		if err := t.exitQueue.SetBroadcast(exit.MinipoolAddress); err != nil {
+			t.log.Printlnf("WARNING: %s", err.Error())
+		}
+	}
+
+	// Return
+	return nil
+
+}
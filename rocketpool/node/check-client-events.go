@@ -0,0 +1,195 @@
+package node
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/client"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/clientlogs"
+	"github.com/rocket-pool/smartnode/shared/services/clock"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/events"
+	"github.com/rocket-pool/smartnode/shared/services/notifications"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+const ExecutionContainerSuffix = "_eth1"
+
+// Check client events task
+type checkClientEvents struct {
+	c     *cli.Context
+	log   log.ColorLogger
+	cfg   *config.RocketPoolConfig
+	d     *client.Client
+	n     *notifications.Notifier
+	clock clock.Clock
+
+	// When the logs were last read, and the last sync milestone reported for each client
+	lastCheck     time.Time
+	lastMilestone map[string]string
+}
+
+// A client whose logs are read for events
+type loggedClient struct {
+	name      string
+	container string
+	parser    *clientlogs.Parser
+}
+
+// Create check client events task
+func newCheckClientEvents(c *cli.Context, logger log.ColorLogger) (*checkClientEvents, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	d, err := services.GetDocker(c)
+	if err != nil {
+		return nil, err
+	}
+	n, err := services.GetNotifier(c)
+	if err != nil {
+		return nil, err
+	}
+	clk := services.GetClock(c)
+
+	// Return task
+	return &checkClientEvents{
+		c:             c,
+		log:           logger,
+		cfg:           cfg,
+		d:             d,
+		n:             n,
+		clock:         clk,
+		lastCheck:     clk.Now(),
+		lastMilestone: map[string]string{},
+	}, nil
+
+}
+
+// Read the events the clients logged since the last check and send notifications about them
+func (t *checkClientEvents) run() error {
+
+	// Check if the check is enabled
+	if !t.n.IsEnabled() || t.cfg.IsNativeMode {
+		return nil
+	}
+
+	// Get the clients managed by the Smartnode
+	clients := []loggedClient{}
+	projectName := t.cfg.Smartnode.GetProjectName()
+	if t.cfg.GetExecutionClientMode() == config.Mode_Local {
+		ec, _ := t.cfg.ExecutionClient.Value.(config.ExecutionClient)
+		clients = append(clients, loggedClient{
+			name:      "Execution client",
+			container: projectName + ExecutionContainerSuffix,
+			parser:    clientlogs.NewExecutionParser(ec),
+		})
+	}
+	if t.cfg.GetConsensusClientMode() == config.Mode_Local {
+		clients = append(clients, loggedClient{
+			name:      "Consensus client",
+			container: projectName + BeaconContainerSuffix,
+			parser:    clientlogs.NewConsensusParser(t.cfg.GetSelectedConsensusClient()),
+		})
+	}
+
+	// Read the logs since the last check
+	since := t.lastCheck
+	now := t.clock.Now()
+	errs := []string{}
+	clientErrors := []string{}
+	peerDrops := []string{}
+	for _, lc := range clients {
+		logs, err := t.getLogs(lc.container, since)
+		if err != nil {
+			errs = append(errs, err.Error())
+			continue
+		}
+		for _, event := range lc.parser.Parse(logs) {
+			events.Publish(events.EventType_ClientLog, "", fmt.Sprintf("%s %s: %s", lc.parser.Client(), event.Kind, event.Summary))
+			summary := event.Summary
+			if event.Count > 1 {
+				summary = fmt.Sprintf("%s (x%d)", summary, event.Count)
+			}
+			switch event.Kind {
+			case clientlogs.EventKind_Error:
+				clientErrors = append(clientErrors, fmt.Sprintf("%s: %s", lc.name, summary))
+			case clientlogs.EventKind_PeerDrop:
+				peerDrops = append(peerDrops, fmt.Sprintf("%s: %s", lc.name, summary))
+			case clientlogs.EventKind_SyncMilestone:
+				if t.lastMilestone[lc.container] == event.Summary {
+					continue
+				}
+				t.lastMilestone[lc.container] = event.Summary
+				t.log.Printlnf("%s (%s): %s", lc.name, lc.parser.Client(), event.Summary)
+				if err := t.n.Notify(notifications.EventType_ClientSyncMilestone, fmt.Sprintf("%s sync", lc.name), fmt.Sprintf("%s (%s): %s", lc.name, lc.parser.Client(), event.Summary)); err != nil {
+					errs = append(errs, err.Error())
+				}
+			}
+		}
+	}
+	t.lastCheck = now
+
+	// Report the problems
+	if len(clientErrors) > 0 {
+		t.log.Printlnf("The clients logged %d kinds of errors since %s.", len(clientErrors), since.Format(time.RFC3339))
+		if err := t.n.Notify(notifications.EventType_ClientError, "Client errors", strings.Join(clientErrors, "\n")); err != nil {
+			errs = append(errs, err.Error())
+		}
+	} else {
+		t.n.Resolve(notifications.EventType_ClientError)
+	}
+	if len(peerDrops) > 0 {
+		t.log.Println("WARNING: the clients are losing their peers.")
+		if err := t.n.Notify(notifications.EventType_ClientPeersLost, "Client peers lost", strings.Join(peerDrops, "\n")); err != nil {
+			errs = append(errs, err.Error())
+		}
+	} else {
+		t.n.Resolve(notifications.EventType_ClientPeersLost)
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("Error checking the client logs: %s", strings.Join(errs, "; "))
+	}
+	return nil
+
+}
+
+// Get a container's logs since a time, with Docker's timestamp at the start of each line
+func (t *checkClientEvents) getLogs(container string, since time.Time) ([]byte, error) {
+	reader, err := t.d.ContainerLogs(context.Background(), container, types.ContainerLogsOptions{
+		ShowStdout: true,
+		ShowStderr: true,
+		Timestamps: true,
+		Since:      since.Format(time.RFC3339Nano),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Could not get the logs of container %s: %w", container, err)
+	}
+	defer reader.Close()
+	logs, err := readContainerLogs(reader)
+	if err != nil {
+		return nil, fmt.Errorf("Could not read the logs of container %s: %w", container, err)
+	}
+	return logs, nil
+}
+
+// Read a container's log stream, which is multiplexed unless the container has a TTY
+func readContainerLogs(reader io.Reader) ([]byte, error) {
+	var buffer bytes.Buffer
+	if _, err := stdcopy.StdCopy(&buffer, &buffer, reader); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
@@ -0,0 +1,87 @@
+package node
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/notifications"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+	"github.com/rocket-pool/smartnode/shared/utils/net"
+)
+
+// Settings
+const ntpRequestTimeout = 5 * time.Second
+
+// Check clock offset task
+type checkClockOffset struct {
+	c   *cli.Context
+	log log.ColorLogger
+	cfg *config.RocketPoolConfig
+	n   *notifications.Notifier
+}
+
+// Create check clock offset task
+func newCheckClockOffset(c *cli.Context, logger log.ColorLogger) (*checkClockOffset, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	n, err := services.GetNotifier(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	return &checkClockOffset{
+		c:   c,
+		log: logger,
+		cfg: cfg,
+		n:   n,
+	}, nil
+
+}
+
+// Compare the system clock with NTP time
+func (t *checkClockOffset) run() error {
+
+	// Check if the check is enabled
+	if t.cfg.Smartnode.GetTimeSyncMode() == config.TimeSyncMode_Disabled {
+		return nil
+	}
+
+	// Get the offset from the first server that responds
+	var offset time.Duration
+	var server string
+	var err error
+	for _, server = range t.cfg.Smartnode.GetNtpServers() {
+		offset, err = net.GetClockOffset(server, ntpRequestTimeout)
+		if err == nil {
+			break
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("Could not check the system clock: %w", err)
+	}
+
+	// Report it
+	maxOffset := t.cfg.Smartnode.GetMaxClockOffset()
+	if offset <= maxOffset && offset >= -maxOffset {
+		t.n.Resolve(notifications.EventType_ClockDrift)
+		return nil
+	}
+	direction := "behind"
+	if offset < 0 {
+		direction = "ahead of"
+		offset = -offset
+	}
+	message := fmt.Sprintf("Your system clock is %s %s %s, which can cause your validators to miss attestations. Make sure your time sync service is running, or set Time Sync to Chrony Container in `rocketpool service config`.", offset.Round(time.Millisecond), direction, server)
+	t.log.Printlnf("WARNING: %s", message)
+	return t.n.Notify(notifications.EventType_ClockDrift, "System clock is out of sync", message)
+
+}
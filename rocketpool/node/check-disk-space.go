@@ -0,0 +1,76 @@
+package node
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/notifications"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Check disk space task
+type checkDiskSpace struct {
+	c   *cli.Context
+	log log.ColorLogger
+	cfg *config.RocketPoolConfig
+	n   *notifications.Notifier
+}
+
+// Create check disk space task
+func newCheckDiskSpace(c *cli.Context, logger log.ColorLogger) (*checkDiskSpace, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	n, err := services.GetNotifier(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	return &checkDiskSpace{
+		c:   c,
+		log: logger,
+		cfg: cfg,
+		n:   n,
+	}, nil
+
+}
+
+// Check the free space on the disk holding the data folder
+func (t *checkDiskSpace) run() error {
+
+	// Check if the check is enabled
+	threshold := t.cfg.Notifications.LowDiskThreshold.GetUintOrDefault(config.Network_All)
+	if !t.n.IsEnabled() || threshold == 0 {
+		return nil
+	}
+
+	// Get the disk usage
+	dataPath := os.ExpandEnv(t.cfg.Smartnode.GetValidatorKeychainPath())
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(dataPath, &stat); err != nil {
+		return fmt.Errorf("Could not check the free disk space of %s: %w", dataPath, err)
+	}
+	if stat.Blocks == 0 {
+		return nil
+	}
+	freePercent := float64(stat.Bavail) / float64(stat.Blocks) * 100
+
+	// Report it
+	if freePercent >= float64(threshold) {
+		t.n.Resolve(notifications.EventType_LowDiskSpace)
+		return nil
+	}
+	freeGb := float64(stat.Bavail) * float64(stat.Bsize) / (1024 * 1024 * 1024)
+	t.log.Printlnf("WARNING: only %.1f%% (%.1f GB) of disk space is left.", freePercent, freeGb)
+	return t.n.Notify(notifications.EventType_LowDiskSpace, "Low disk space", fmt.Sprintf("Only %.1f%% (%.1f GB) of your disk space is left.", freePercent, freeGb))
+
+}
@@ -0,0 +1,338 @@
+package node
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/client"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/clock"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/events"
+	"github.com/rocket-pool/smartnode/shared/services/notifications"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Slashings within this window count towards a surge
+var slashingSurgeWindow, _ = time.ParseDuration("1h")
+
+// How long to remember the client a block proposer was identified as running
+var proposerClientRetention, _ = time.ParseDuration("168h")
+
+// The names clients put in their default graffiti
+var graffitiClientNames = map[string]config.ConsensusClient{
+	"lighthouse": config.ConsensusClient_Lighthouse,
+	"nimbus":     config.ConsensusClient_Nimbus,
+	"prysm":      config.ConsensusClient_Prysm,
+	"teku":       config.ConsensusClient_Teku,
+	"lodestar":   config.ConsensusClient("lodestar"),
+}
+
+// The Smartnode's graffiti starts with RP- and the initials of the clients, with the Consensus client's last
+var smartnodeGraffitiPattern = regexp.MustCompile(`^RP-[A-Z]?([LNPT])\b`)
+var smartnodeGraffitiClients = map[string]config.ConsensusClient{
+	"L": config.ConsensusClient_Lighthouse,
+	"N": config.ConsensusClient_Nimbus,
+	"P": config.ConsensusClient_Prysm,
+	"T": config.ConsensusClient_Teku,
+}
+
+// The state of the slashing safe mode that's kept across restarts
+type slashingSafeModeState struct {
+	PausedUntil time.Time `json:"pausedUntil"`
+}
+
+// The client a block proposer was identified as running, and the slot it was last seen in
+type proposerClient struct {
+	client config.ConsensusClient
+	slot   uint64
+}
+
+// Check network slashings task
+type checkNetworkSlashings struct {
+	c     *cli.Context
+	log   log.ColorLogger
+	cfg   *config.RocketPoolConfig
+	bc    beacon.Client
+	d     *client.Client
+	n     *notifications.Notifier
+	clock clock.Clock
+
+	// The last slot that was checked, and the slot each recently slashed validator was slashed in
+	lastSlot uint64
+	slashed  map[uint64]uint64
+
+	// The clients block proposers were identified as running from their graffiti
+	proposerClients map[uint64]proposerClient
+
+	// When the paused Validator client should be started again, or zero if it isn't paused; it's saved in statePath
+	pausedUntil time.Time
+	statePath   string
+}
+
+// Create check network slashings task
+func newCheckNetworkSlashings(c *cli.Context, logger log.ColorLogger) (*checkNetworkSlashings, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+	d, err := services.GetDocker(c)
+	if err != nil {
+		return nil, err
+	}
+	n, err := services.GetNotifier(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Restore the pause from before the daemon was restarted
+	task := &checkNetworkSlashings{
+		c:               c,
+		log:             logger,
+		cfg:             cfg,
+		bc:              bc,
+		d:               d,
+		n:               n,
+		clock:           services.GetClock(c),
+		slashed:         map[uint64]uint64{},
+		proposerClients: map[uint64]proposerClient{},
+		statePath:       os.ExpandEnv(cfg.Smartnode.GetSlashingSafeModePath()),
+	}
+	if err := task.loadState(); err != nil {
+		logger.Printlnf("WARNING: %s", err.Error())
+	}
+	if !task.pausedUntil.IsZero() {
+		logger.Printlnf("The Validator client was paused by Slashing Safe Mode until %s.", task.pausedUntil.Format(time.RFC1123))
+	}
+
+	// Return task
+	return task, nil
+
+}
+
+// Look for slashings in the blocks since the last check, and go into safe mode if there's a surge of them
+func (t *checkNetworkSlashings) run() error {
+
+	// Check if the check is enabled
+	mode := t.cfg.Smartnode.GetSlashingSafeMode()
+	if mode == config.SlashingSafeMode_Disabled {
+		return nil
+	}
+
+	// Wait for the Beacon client to sync
+	if err := services.WaitBeaconClientSynced(t.c, true); err != nil {
+		return err
+	}
+
+	// Get the slots to check
+	eth2Config, err := t.bc.GetEth2Config()
+	if err != nil {
+		return err
+	}
+	head, exists, err := t.bc.GetBeaconBlockHeader("head")
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("Could not get the head block")
+	}
+	windowSlots := uint64(slashingSurgeWindow.Seconds()) * eth2Config.SlotsPerEpoch / eth2Config.SecondsPerEpoch
+	startSlot := t.lastSlot + 1
+	if head.Slot > windowSlots && startSlot < head.Slot-windowSlots {
+		startSlot = head.Slot - windowSlots
+	}
+
+	// Record the slashings in them, and the clients of their proposers
+	for slot := startSlot; slot <= head.Slot; slot++ {
+		slashed, exists, err := t.bc.GetBeaconBlockSlashings(fmt.Sprint(slot))
+		if err != nil {
+			return err
+		}
+		if !exists {
+			continue
+		}
+		for _, slashing := range slashed {
+			t.slashed[slashing.ValidatorIndex] = slot
+		}
+		proposer, exists, err := t.bc.GetBeaconBlockProposer(fmt.Sprint(slot))
+		if err != nil {
+			return err
+		}
+		if exists {
+			if client := getGraffitiClient(proposer.Graffiti); client != config.ConsensusClient_Unknown {
+				t.proposerClients[proposer.ValidatorIndex] = proposerClient{client: client, slot: slot}
+			}
+		}
+		t.lastSlot = slot
+	}
+
+	// Only keep the recent ones
+	for index, slot := range t.slashed {
+		if slot+windowSlots < head.Slot {
+			delete(t.slashed, index)
+		}
+	}
+	retentionSlots := uint64(proposerClientRetention.Seconds()) * eth2Config.SlotsPerEpoch / eth2Config.SecondsPerEpoch
+	for index, proposer := range t.proposerClients {
+		if proposer.slot+retentionSlots < head.Slot {
+			delete(t.proposerClients, index)
+		}
+	}
+
+	// Only count the slashed validators that could be running the same client as this node
+	slashedCount := t.getRelevantSlashingCount(t.cfg.GetSelectedConsensusClient())
+	threshold := t.cfg.Smartnode.SlashingSafeModeThreshold.GetUintOrDefault(config.Network_All)
+	surge := (threshold > 0 && slashedCount >= threshold)
+
+	// Start the Validator client again once the pause is over, unless the surge is still going on
+	if !t.pausedUntil.IsZero() && t.clock.Now().After(t.pausedUntil) {
+		if surge {
+			if err := t.setPausedUntil(t.clock.Now().Add(t.getPauseDuration())); err != nil {
+				return err
+			}
+			t.log.Printlnf("%d validators have been slashed in the last %s, keeping the Validator client stopped until %s.", slashedCount, slashingSurgeWindow, t.pausedUntil.Format(time.RFC1123))
+		} else {
+			if err := t.startValidator(); err != nil {
+				return err
+			}
+			if err := t.setPausedUntil(time.Time{}); err != nil {
+				return err
+			}
+			t.log.Println("The slashing surge is over, the Validator client has been started again.")
+		}
+	}
+	if !surge {
+		t.n.Resolve(notifications.EventType_SlashingSurge)
+		return nil
+	}
+
+	// Report the surge
+	t.log.Printlnf("WARNING: %d validators that could be running your client have been slashed on the network in the last %s.", slashedCount, slashingSurgeWindow)
+	message := fmt.Sprintf("%d validators that could be running your client have been slashed on the network in the last %s, which usually means a client has a bug. Check your clients' announcement channels to see whether yours is affected, and follow their instructions.", slashedCount, slashingSurgeWindow)
+
+	// Pause the Validator client if requested
+	if mode == config.SlashingSafeMode_Pause && t.pausedUntil.IsZero() {
+		if t.cfg.IsNativeMode || t.bc.GetClientType() != beacon.SplitProcess {
+			t.log.Println("Slashing Safe Mode can only pause the Validator client in Docker mode with a client that has a separate validator process, so it has been left running.")
+			message += " Your Validator client has been left running, because Slashing Safe Mode can't pause it on this setup."
+		} else {
+			if err := t.stopValidator(); err != nil {
+				return err
+			}
+			if err := t.setPausedUntil(t.clock.Now().Add(t.getPauseDuration())); err != nil {
+				return err
+			}
+			t.log.Printlnf("The Validator client has been stopped until %s.", t.pausedUntil.Format(time.RFC1123))
+			message += fmt.Sprintf(" Your Validator client has been stopped until %s, and will be started again then unless the slashings are still going on. Run `rocketpool service start` to start it sooner once you're sure your client is safe.", t.pausedUntil.Format(time.RFC1123))
+		}
+	}
+	events.Publish(events.EventType_Error, "", fmt.Sprintf("%d validators were slashed on the network in the last %s", slashedCount, slashingSurgeWindow))
+	return t.n.Notify(notifications.EventType_SlashingSurge, "Slashing surge", message)
+
+}
+
+// Get the number of recently slashed validators that weren't identified as running a different client than the provided one.
+// Validators are identified by the graffiti of the blocks they proposed, so the ones that haven't proposed one recently always count.
+func (t *checkNetworkSlashings) getRelevantSlashingCount(client config.ConsensusClient) uint64 {
+	count := uint64(0)
+	for index := range t.slashed {
+		proposer, identified := t.proposerClients[index]
+		if client == config.ConsensusClient_Unknown || !identified || proposer.client == client {
+			count++
+		}
+	}
+	return count
+}
+
+// Get the client a block's graffiti says its proposer is running; returns unknown if it doesn't say
+func getGraffitiClient(graffiti string) config.ConsensusClient {
+	if match := smartnodeGraffitiPattern.FindStringSubmatch(graffiti); match != nil {
+		return smartnodeGraffitiClients[match[1]]
+	}
+	graffiti = strings.ToLower(graffiti)
+	found := config.ConsensusClient_Unknown
+	for name, client := range graffitiClientNames {
+		if strings.Contains(graffiti, name) {
+			if found != config.ConsensusClient_Unknown && found != client {
+				return config.ConsensusClient_Unknown
+			}
+			found = client
+		}
+	}
+	return found
+}
+
+// Load the pause from before the daemon was restarted
+func (t *checkNetworkSlashings) loadState() error {
+	bytes, err := ioutil.ReadFile(t.statePath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("Could not read the Slashing Safe Mode state: %w", err)
+	}
+	var state slashingSafeModeState
+	if err := json.Unmarshal(bytes, &state); err != nil {
+		return fmt.Errorf("Could not parse the Slashing Safe Mode state: %w", err)
+	}
+	t.pausedUntil = state.PausedUntil
+	return nil
+}
+
+// Set when the paused Validator client should be started again, saving it so it's kept across restarts
+func (t *checkNetworkSlashings) setPausedUntil(pausedUntil time.Time) error {
+	t.pausedUntil = pausedUntil
+	bytes, err := json.Marshal(slashingSafeModeState{PausedUntil: pausedUntil})
+	if err != nil {
+		return fmt.Errorf("Could not serialize the Slashing Safe Mode state: %w", err)
+	}
+	tempPath := t.statePath + ".tmp"
+	if err := ioutil.WriteFile(tempPath, bytes, 0644); err != nil {
+		return fmt.Errorf("Could not write the Slashing Safe Mode state: %w", err)
+	}
+	if err := os.Rename(tempPath, t.statePath); err != nil {
+		return fmt.Errorf("Could not save the Slashing Safe Mode state: %w", err)
+	}
+	return nil
+}
+
+// Get how long to pause the Validator client for
+func (t *checkNetworkSlashings) getPauseDuration() time.Duration {
+	return time.Duration(t.cfg.Smartnode.SlashingSafeModeDuration.GetUintOrDefault(config.Network_All)) * time.Minute
+}
+
+// Stop the Validator client's containers
+func (t *checkNetworkSlashings) stopValidator() error {
+	for _, containerName := range getValidatorContainerNames(t.cfg) {
+		if err := t.d.ContainerStop(context.Background(), containerName, &validatorStopTimeout); err != nil {
+			return fmt.Errorf("Could not stop the Validator client (%s): %w", containerName, err)
+		}
+	}
+	return nil
+}
+
+// Start the Validator client's containers
+func (t *checkNetworkSlashings) startValidator() error {
+	for _, containerName := range getValidatorContainerNames(t.cfg) {
+		if err := t.d.ContainerStart(context.Background(), containerName, types.ContainerStartOptions{}); err != nil {
+			return fmt.Errorf("Could not start the Validator client (%s): %w", containerName, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package node
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+)
+
+func TestGetGraffitiClient(t *testing.T) {
+	tests := map[string]config.ConsensusClient{
+		"RP-L v1.7.0 (my node)":  config.ConsensusClient_Lighthouse,
+		"RP-GN v1.9.0":           config.ConsensusClient_Nimbus,
+		"RP-NT v1.9.0 (RP-L)":    config.ConsensusClient_Teku,
+		"Lighthouse/v4.0.1-abc":  config.ConsensusClient_Lighthouse,
+		"teku/v23.1.0":           config.ConsensusClient_Teku,
+		"prysm-validator":        config.ConsensusClient_Prysm,
+		"Lodestar-v1.5.0":        config.ConsensusClient("lodestar"),
+		"lighthouse beats prysm": config.ConsensusClient_Unknown,
+		"gm":                     config.ConsensusClient_Unknown,
+		"":                       config.ConsensusClient_Unknown,
+	}
+	for graffiti, expected := range tests {
+		if client := getGraffitiClient(graffiti); client != expected {
+			t.Errorf("expected graffiti %q to be %q, got %q", graffiti, expected, client)
+		}
+	}
+}
+
+func TestRelevantSlashingsExcludeOtherClients(t *testing.T) {
+	task := &checkNetworkSlashings{
+		slashed: map[uint64]uint64{1: 100, 2: 100, 3: 100, 4: 100},
+		proposerClients: map[uint64]proposerClient{
+			1: {client: config.ConsensusClient_Lighthouse},
+			2: {client: config.ConsensusClient_Prysm},
+			3: {client: config.ConsensusClient_Lighthouse},
+		},
+	}
+
+	// Validators running another client don't count, but unidentified ones do
+	if count := task.getRelevantSlashingCount(config.ConsensusClient_Lighthouse); count != 3 {
+		t.Errorf("expected 3 slashings for Lighthouse, got %d", count)
+	}
+	if count := task.getRelevantSlashingCount(config.ConsensusClient_Teku); count != 1 {
+		t.Errorf("expected 1 slashing for Teku, got %d", count)
+	}
+
+	// Everything counts if the node's client isn't known
+	if count := task.getRelevantSlashingCount(config.ConsensusClient_Unknown); count != 4 {
+		t.Errorf("expected 4 slashings for an unknown client, got %d", count)
+	}
+}
+
+func TestSlashingSafeModePauseIsKeptAcrossRestarts(t *testing.T) {
+	dir, err := ioutil.TempDir("", "rp-slashing-safe-mode")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	statePath := filepath.Join(dir, "slashing-safe-mode.json")
+
+	// Nothing is paused before the state is saved
+	task := &checkNetworkSlashings{statePath: statePath}
+	if err := task.loadState(); err != nil {
+		t.Fatal(err)
+	}
+	if !task.pausedUntil.IsZero() {
+		t.Fatalf("expected no pause, got %s", task.pausedUntil)
+	}
+
+	// A pause is restored by the next instance of the task
+	pausedUntil := time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC)
+	if err := task.setPausedUntil(pausedUntil); err != nil {
+		t.Fatal(err)
+	}
+	restarted := &checkNetworkSlashings{statePath: statePath}
+	if err := restarted.loadState(); err != nil {
+		t.Fatal(err)
+	}
+	if !restarted.pausedUntil.Equal(pausedUntil) {
+		t.Fatalf("expected the pause until %s to be restored, got %s", pausedUntil, restarted.pausedUntil)
+	}
+
+	// And so is resuming
+	if err := restarted.setPausedUntil(time.Time{}); err != nil {
+		t.Fatal(err)
+	}
+	task = &checkNetworkSlashings{statePath: statePath}
+	if err := task.loadState(); err != nil {
+		t.Fatal(err)
+	}
+	if !task.pausedUntil.IsZero() {
+		t.Fatalf("expected no pause after resuming, got %s", task.pausedUntil)
+	}
+}
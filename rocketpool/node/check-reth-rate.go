@@ -0,0 +1,94 @@
+package node
+
+import (
+	"fmt"
+
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/contracts"
+	"github.com/rocket-pool/smartnode/shared/services/notifications"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+	rputils "github.com/rocket-pool/smartnode/shared/utils/rp"
+)
+
+// Check rETH rate task
+type checkRethRate struct {
+	c   *cli.Context
+	log log.ColorLogger
+	cfg *config.RocketPoolConfig
+	rp  *rocketpool.RocketPool
+	oio *contracts.OneInchOracle
+	n   *notifications.Notifier
+}
+
+// Create check rETH rate task
+func newCheckRethRate(c *cli.Context, logger log.ColorLogger) (*checkRethRate, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	oio, err := services.GetOneInchOracle(c)
+	if err != nil {
+		return nil, err
+	}
+	n, err := services.GetNotifier(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	return &checkRethRate{
+		c:   c,
+		log: logger,
+		cfg: cfg,
+		rp:  rp,
+		oio: oio,
+		n:   n,
+	}, nil
+
+}
+
+// Compare the secondary-market rETH rate with the protocol rate
+func (t *checkRethRate) run() error {
+
+	// Check if the check is enabled
+	maxDeviation := t.cfg.Smartnode.GetRethMaxDeviation()
+	if t.cfg.Smartnode.GetRethPriceSource() == config.RethPriceSource_Disabled || maxDeviation <= 0 {
+		return nil
+	}
+
+	// Wait for eth client to sync
+	if err := services.WaitEthClientSynced(t.c, true); err != nil {
+		return err
+	}
+
+	// Get the rates
+	rates, err := rputils.GetRethRates(t.rp, t.cfg, t.oio)
+	if err != nil {
+		return err
+	}
+	if rates.Deviation <= maxDeviation && rates.Deviation >= -maxDeviation {
+		t.n.Resolve(notifications.EventType_RethDeviation)
+		return nil
+	}
+
+	// Report it
+	var message string
+	if rates.Deviation > 0 {
+		message = fmt.Sprintf("rETH is trading at %.6f ETH, a %.2f%% premium over the protocol rate of %.6f ETH. There's demand for new minipools, so deposits are likely to be assigned quickly.", rates.MarketRate, rates.Deviation, rates.ProtocolRate)
+	} else {
+		message = fmt.Sprintf("rETH is trading at %.6f ETH, a %.2f%% discount to the protocol rate of %.6f ETH. Holders may be selling rETH on secondary markets rather than burning it.", rates.MarketRate, -rates.Deviation, rates.ProtocolRate)
+	}
+	t.log.Printlnf("WARNING: %s", message)
+	return t.n.Notify(notifications.EventType_RethDeviation, "rETH rate deviation", message)
+
+}
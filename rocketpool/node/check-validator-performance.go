@@ -0,0 +1,131 @@
+package node
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/clock"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/notifications"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/eth2"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Settings
+const validatorPerformanceCheckInterval = time.Hour
+
+// Check validator performance task
+type checkValidatorPerformance struct {
+	c         *cli.Context
+	log       log.ColorLogger
+	cfg       *config.RocketPoolConfig
+	w         *wallet.Wallet
+	rp        *rocketpool.RocketPool
+	bc        beacon.Client
+	n         *notifications.Notifier
+	clock     clock.Clock
+	lastCheck time.Time
+}
+
+// Create check validator performance task
+func newCheckValidatorPerformance(c *cli.Context, logger log.ColorLogger) (*checkValidatorPerformance, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+	n, err := services.GetNotifier(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	return &checkValidatorPerformance{
+		c:     c,
+		log:   logger,
+		cfg:   cfg,
+		w:     w,
+		rp:    rp,
+		bc:    bc,
+		n:     n,
+		clock: services.GetClock(c),
+	}, nil
+
+}
+
+// Alert about validators that are chronically underperforming
+func (t *checkValidatorPerformance) run() error {
+
+	// Check if the check is enabled and due
+	threshold := t.cfg.Smartnode.GetPerformanceThreshold()
+	if !t.n.IsEnabled() || threshold == 0 || t.clock.Since(t.lastCheck) < validatorPerformanceCheckInterval {
+		return nil
+	}
+	t.lastCheck = t.clock.Now()
+
+	// Get the node's minipools
+	nodeAccount, err := t.w.GetNodeAccount()
+	if err != nil {
+		return err
+	}
+	addresses, err := minipool.GetNodeMinipoolAddresses(t.rp, nodeAccount.Address, nil)
+	if err != nil {
+		return fmt.Errorf("Error getting node minipool addresses: %w", err)
+	}
+	if len(addresses) == 0 {
+		return nil
+	}
+
+	// Measure their validators
+	windowEpochs := t.cfg.Smartnode.GetPerformanceWindow()
+	_, _, performance, err := eth2.GetValidatorPerformance(t.rp, t.bc, addresses, windowEpochs, threshold)
+	if err != nil {
+		return err
+	}
+	underperforming := 0
+	offline := 0
+	opportunityCost := float64(0)
+	for _, validator := range performance {
+		switch validator.Advice {
+		case api.PerformanceAdvice_Remediate:
+			underperforming++
+		case api.PerformanceAdvice_Exit:
+			offline++
+		default:
+			continue
+		}
+		opportunityCost += validator.AnnualOpportunityCost
+		t.log.Printlnf("Validator %d (minipool %s) had %.1f%% effectiveness over the last %d epochs.", validator.Index, validator.MinipoolAddress.Hex(), validator.Effectiveness, validator.Epochs)
+	}
+
+	// Report it
+	if underperforming == 0 && offline == 0 {
+		t.n.Resolve(notifications.EventType_Underperforming)
+		return nil
+	}
+	message := fmt.Sprintf("%d of your validators are below %.0f%% effectiveness over the last %d epochs, and %d lost balance and are most likely offline. This is costing about %.4f ETH per year. Run `rocketpool node performance` for advice.", underperforming+offline, threshold, windowEpochs, offline, opportunityCost)
+	t.log.Println(message)
+	return t.n.Notify(notifications.EventType_Underperforming, "Validators underperforming", message)
+
+}
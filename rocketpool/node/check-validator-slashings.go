@@ -0,0 +1,245 @@
+package node
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/clock"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/events"
+	"github.com/rocket-pool/smartnode/shared/services/notifications"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+	rputils "github.com/rocket-pool/smartnode/shared/utils/rp"
+)
+
+// Settings
+const (
+	// How often new blocks are checked for slashings of the node's validators
+	validatorSlashingCheckInterval = time.Minute
+
+	// How often the node's validators are reloaded, which picks up new minipools and catches slashings from while the daemon was down
+	nodeValidatorRefreshInterval = 10 * time.Minute
+)
+
+// One of the node's validators
+type nodeValidator struct {
+	MinipoolAddress common.Address
+	Pubkey          types.ValidatorPubkey
+}
+
+// Check validator slashings task
+type checkValidatorSlashings struct {
+	c     *cli.Context
+	log   log.ColorLogger
+	cfg   *config.RocketPoolConfig
+	w     *wallet.Wallet
+	rp    *rocketpool.RocketPool
+	bc    beacon.Client
+	n     *notifications.Notifier
+	clock clock.Clock
+
+	// The node's validators by index, and when they were last loaded
+	validators  map[uint64]nodeValidator
+	lastRefresh time.Time
+
+	// The last slot that was checked, and the validators whose slashings have already been reported
+	lastSlot uint64
+	reported map[uint64]bool
+}
+
+// Create check validator slashings task
+func newCheckValidatorSlashings(c *cli.Context, logger log.ColorLogger) (*checkValidatorSlashings, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+	n, err := services.GetNotifier(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	return &checkValidatorSlashings{
+		c:          c,
+		log:        logger,
+		cfg:        cfg,
+		w:          w,
+		rp:         rp,
+		bc:         bc,
+		n:          n,
+		clock:      services.GetClock(c),
+		validators: map[uint64]nodeValidator{},
+		reported:   map[uint64]bool{},
+	}, nil
+
+}
+
+// Look for slashings of the node's validators in the blocks since the last check, and raise an alert for each one right away
+func (t *checkValidatorSlashings) run() error {
+
+	// Wait for the Beacon client to sync
+	if err := services.WaitBeaconClientSynced(t.c, true); err != nil {
+		return err
+	}
+
+	// Reload the node's validators, reporting any that the Beacon Chain already shows as slashed
+	if t.clock.Since(t.lastRefresh) >= nodeValidatorRefreshInterval {
+		if err := t.refreshValidators(); err != nil {
+			return err
+		}
+	}
+	if len(t.validators) == 0 {
+		return nil
+	}
+
+	// Get the slots to check; older slashings are picked up from the validator statuses instead
+	eth2Config, err := t.bc.GetEth2Config()
+	if err != nil {
+		return err
+	}
+	head, exists, err := t.bc.GetBeaconBlockHeader("head")
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("Could not get the head block")
+	}
+	startSlot := t.lastSlot + 1
+	if head.Slot > eth2Config.SlotsPerEpoch && startSlot < head.Slot-eth2Config.SlotsPerEpoch {
+		startSlot = head.Slot - eth2Config.SlotsPerEpoch
+	}
+
+	// Check the slashings in them
+	for slot := startSlot; slot <= head.Slot; slot++ {
+		slashings, exists, err := t.bc.GetBeaconBlockSlashings(fmt.Sprint(slot))
+		if err != nil {
+			return err
+		}
+		if !exists {
+			t.lastSlot = slot
+			continue
+		}
+		for _, slashing := range slashings {
+			if _, isNodeValidator := t.validators[slashing.ValidatorIndex]; !isNodeValidator {
+				continue
+			}
+			offence := getSlashingOffence(slashing.Type)
+			if err := t.reportSlashing(slashing.ValidatorIndex, fmt.Sprintf("was slashed for %s in slot %d", offence, slot)); err != nil {
+				return err
+			}
+		}
+		t.lastSlot = slot
+	}
+
+	// Return
+	return nil
+
+}
+
+// Load the node's validators
+func (t *checkValidatorSlashings) refreshValidators() error {
+
+	// Get the node's minipools
+	nodeAccount, err := t.w.GetNodeAccount()
+	if err != nil {
+		return err
+	}
+	addresses, err := minipool.GetNodeMinipoolAddresses(t.rp, nodeAccount.Address, nil)
+	if err != nil {
+		return fmt.Errorf("Error getting node minipool addresses: %w", err)
+	}
+	statuses, err := rputils.GetMinipoolValidators(t.rp, t.bc, addresses, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	// Index their validators
+	validators := map[uint64]nodeValidator{}
+	for _, address := range addresses {
+		status := statuses[address]
+		if !status.Exists {
+			continue
+		}
+		validators[status.Index] = nodeValidator{
+			MinipoolAddress: address,
+			Pubkey:          status.Pubkey,
+		}
+	}
+	t.validators = validators
+	t.lastRefresh = t.clock.Now()
+
+	// Report the ones that have been slashed
+	for _, address := range addresses {
+		status := statuses[address]
+		if status.Exists && status.Slashed {
+			if err := t.reportSlashing(status.Index, "has been slashed on the Beacon Chain"); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+
+}
+
+// Raise an alert about one of the node's validators being slashed, unless it has already been reported
+func (t *checkValidatorSlashings) reportSlashing(index uint64, details string) error {
+
+	if t.reported[index] {
+		return nil
+	}
+	validator := t.validators[index]
+
+	// Log it
+	t.log.Printlnf("CRITICAL: Validator %d (minipool %s) %s!", index, validator.MinipoolAddress.Hex(), details)
+	events.Publish(events.EventType_Error, "", fmt.Sprintf("Validator %d (minipool %s) %s", index, validator.MinipoolAddress.Hex(), details))
+
+	// Send the alert
+	message := fmt.Sprintf("Your validator %d (minipool %s, pubkey %s) %s. "+
+		"It will be forcibly exited and lose part of its balance, and the penalty grows if more validators are slashed around the same time.\n\n"+
+		"Next steps:\n"+
+		"1. Stop your Validator client right away with `rocketpool service stop`.\n"+
+		"2. Make sure none of your validator keys are running anywhere else, such as on a backup or old machine, or in another staking service. Running the same keys twice is by far the most common cause of slashing, and your other validators are at risk until it's fixed.\n"+
+		"3. Only start your Validator client again once you're sure this machine is the only one using the keys, and your slashing protection data is intact.\n"+
+		"4. Check `rocketpool minipool status` to follow the validator's exit; once its balance has been withdrawn, the minipool can be distributed and closed.",
+		index, validator.MinipoolAddress.Hex(), validator.Pubkey.Hex(), details)
+	if err := t.n.Notify(notifications.EventType_ValidatorSlashed, "Validator slashed", message); err != nil {
+		return err
+	}
+	t.reported[index] = true
+	return nil
+
+}
+
+// Get a description of the offence behind a slashing
+func getSlashingOffence(slashingType beacon.SlashingType) string {
+	switch slashingType {
+	case beacon.SlashingType_Proposer:
+		return "proposing two different blocks for the same slot"
+	case beacon.SlashingType_Attester:
+		return "signing conflicting attestations"
+	}
+	return string(slashingType)
+}
@@ -3,7 +3,9 @@ package node
 import (
 	"fmt"
 	"math/big"
+	"os"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/rocket-pool/rocketpool-go/network"
 	"github.com/rocket-pool/rocketpool-go/rewards"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
@@ -11,14 +13,24 @@ import (
 	"github.com/urfave/cli"
 
 	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/clock"
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	rpgas "github.com/rocket-pool/smartnode/shared/services/gas"
+	"github.com/rocket-pool/smartnode/shared/services/journal"
+	"github.com/rocket-pool/smartnode/shared/services/notifications"
+	"github.com/rocket-pool/smartnode/shared/services/txqueue"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	"github.com/rocket-pool/smartnode/shared/utils/api"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
 	"github.com/rocket-pool/smartnode/shared/utils/math"
 )
 
+// The name of the claim RPL rewards task in the journal
+const claimRplRewardsTaskName string = "claim-rpl-rewards"
+
+// The ID of the RPL claim transaction in the transaction queue
+const claimRplRewardsQueueID string = claimRplRewardsTaskName
+
 // Claim RPL rewards task
 type claimRplRewards struct {
 	c              *cli.Context
@@ -26,7 +38,13 @@ type claimRplRewards struct {
 	cfg            *config.RocketPoolConfig
 	w              *wallet.Wallet
 	rp             *rocketpool.RocketPool
+	cm             *services.ChainMonitor
+	n              *notifications.Notifier
+	journal        *journal.Journal
+	txQueue        *txqueue.Queue
+	clock          clock.Clock
 	gasThreshold   float64
+	gasOracle      rpgas.GasOracle
 	maxFee         *big.Int
 	maxPriorityFee *big.Int
 	gasLimit       uint64
@@ -48,15 +66,27 @@ func newClaimRplRewards(c *cli.Context, logger log.ColorLogger) (*claimRplReward
 	if err != nil {
 		return nil, err
 	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+	cm, err := services.GetChainMonitor(c)
+	if err != nil {
+		return nil, err
+	}
+	n, err := services.GetNotifier(c)
+	if err != nil {
+		return nil, err
+	}
 
 	// Check if auto-claiming is disabled
-	gasThreshold := cfg.Smartnode.RplClaimGasThreshold.Value.(float64)
+	gasThreshold := cfg.Smartnode.GetRplClaimGasThreshold()
 	if gasThreshold == 0 {
 		logger.Println("RPL claim gas threshold is set to 0, automatic claims will be disabled.")
 	}
 
 	// Get the user-requested max fee
-	maxFeeGwei := cfg.Smartnode.ManualMaxFee.Value.(float64)
+	maxFeeGwei := cfg.Smartnode.GetManualMaxFee()
 	var maxFee *big.Int
 	if maxFeeGwei == 0 {
 		maxFee = nil
@@ -65,7 +95,7 @@ func newClaimRplRewards(c *cli.Context, logger log.ColorLogger) (*claimRplReward
 	}
 
 	// Get the user-requested max fee
-	priorityFeeGwei := cfg.Smartnode.PriorityFee.Value.(float64)
+	priorityFeeGwei := cfg.Smartnode.GetPriorityFee()
 	var priorityFee *big.Int
 	if priorityFeeGwei == 0 {
 		logger.Println("WARNING: priority fee was missing or 0, setting a default of 2.")
@@ -81,7 +111,13 @@ func newClaimRplRewards(c *cli.Context, logger log.ColorLogger) (*claimRplReward
 		cfg:            cfg,
 		w:              w,
 		rp:             rp,
+		cm:             cm,
+		n:              n,
+		journal:        journal.NewJournal(cfg.Smartnode.GetJournalPath(), JournalName),
+		txQueue:        txqueue.NewQueue(os.ExpandEnv(cfg.Smartnode.GetTxQueuePath()), services.GetClock(c)),
+		clock:          services.GetClock(c),
 		gasThreshold:   gasThreshold,
+		gasOracle:      rpgas.NewGasOracle(cfg, ec, nil),
 		maxFee:         maxFee,
 		maxPriorityFee: priorityFee,
 		gasLimit:       0,
@@ -102,30 +138,68 @@ func (t *claimRplRewards) run() error {
 		return err
 	}
 
+	// Check if tasks that depend on finality are paused
+	if paused, reason := t.cm.IsPaused(); paused {
+		t.log.Printlnf("Skipping the RPL rewards check because %s.", reason)
+		return nil
+	}
+
+	// Claim and record the run
+	run := t.journal.StartRun(claimRplRewardsTaskName)
+	return run.Finish(t.claim(run))
+
+}
+
+// Claim RPL rewards if they're worth more than the gas required to claim them
+func (t *claimRplRewards) claim(run *journal.Run) error {
+
 	// Log
 	t.log.Println("Checking for RPL rewards to claim...")
 
-	// Get node account
-	nodeAccount, err := t.w.GetNodeAccount()
-	if err != nil {
+	// Get the settings
+	gasThreshold := t.gasThreshold
+	if err := run.Setting("gasThreshold", &gasThreshold); err != nil {
+		return err
+	}
+	gasLimit := t.gasLimit
+	if err := run.Setting("gasLimit", &gasLimit); err != nil {
 		return err
 	}
 
 	// Check for rewards
-	rewardsAmountWei, err := rewards.GetNodeClaimRewardsAmount(t.rp, nodeAccount.Address, nil)
+	var rewardsAmountWei *big.Int
+	err := run.Input("rewardsAmount", &rewardsAmountWei, func() error {
+		nodeAccount, err := t.w.GetNodeAccount()
+		if err != nil {
+			return err
+		}
+		rewardsAmountWei, err = rewards.GetNodeClaimRewardsAmount(t.rp, nodeAccount.Address, nil)
+		return err
+	})
 	if err != nil {
 		return err
 	}
 	if rewardsAmountWei.Cmp(big.NewInt(0)) == 0 {
+		run.Decide("No RPL rewards are available to claim.")
+		if !run.IsReplay() {
+			if err := t.txQueue.Prune(claimRplRewardsTaskName, nil); err != nil {
+				t.log.Printlnf("WARNING: %s", err.Error())
+			}
+		}
 		return nil
 	}
 
 	// Don't claim unless the oDAO has claimed first (prevent known issue yet to be patched in smart contracts)
-	trustedNodeClaimed, err := rewards.GetTrustedNodeTotalClaimed(t.rp, nil)
+	var trustedNodeClaimed *big.Int
+	err = run.Input("trustedNodeClaimed", &trustedNodeClaimed, func() (err error) {
+		trustedNodeClaimed, err = rewards.GetTrustedNodeTotalClaimed(t.rp, nil)
+		return
+	})
 	if err != nil {
 		return err
 	}
 	if trustedNodeClaimed.Cmp(big.NewInt(0)) == 0 {
+		run.Decide("The oracle DAO hasn't claimed its rewards yet, so this node won't claim either.")
 		return nil
 	}
 
@@ -133,6 +207,16 @@ func (t *claimRplRewards) run() error {
 	rewardsAmount := math.RoundDown(eth.WeiToEth(rewardsAmountWei), 6)
 	t.log.Printlnf("%.6f RPL is available to claim...", rewardsAmount)
 
+	// Check for an earlier attempt in the transaction queue
+	queueDescription := fmt.Sprintf("claim %.6f RPL in rewards", rewardsAmount)
+	queued, due, err := checkTxQueue(run, t.txQueue, t.rp.Client, t.clock, t.log, "queued", claimRplRewardsQueueID, queueDescription)
+	if err != nil {
+		return err
+	}
+	if !due {
+		return nil
+	}
+
 	// Get transactor
 	opts, err := t.w.GetNodeAccountTransactor()
 	if err != nil {
@@ -140,33 +224,49 @@ func (t *claimRplRewards) run() error {
 	}
 
 	// Get the gas limit
-	gasInfo, err := rewards.EstimateClaimNodeRewardsGas(t.rp, opts)
+	var gasInfo rocketpool.GasInfo
+	err = run.Input("gasInfo", &gasInfo, func() (err error) {
+		gasInfo, err = rewards.EstimateClaimNodeRewardsGas(t.rp, opts)
+		return
+	})
 	if err != nil {
 		return fmt.Errorf("Could not estimate the gas required to claim RPL: %w", err)
 	}
 	var gas *big.Int
-	if t.gasLimit != 0 {
-		gas = new(big.Int).SetUint64(t.gasLimit)
+	if gasLimit != 0 {
+		gas = new(big.Int).SetUint64(gasLimit)
 	} else {
 		gas = new(big.Int).SetUint64(gasInfo.SafeGasLimit)
 	}
 
 	// Get the max fee
 	maxFee := t.maxFee
-	if maxFee == nil || maxFee.Uint64() == 0 {
-		maxFee, err = rpgas.GetHeadlessMaxFeeWei()
-		if err != nil {
-			return err
+	err = run.Input("maxFee", &maxFee, func() (err error) {
+		if maxFee == nil || maxFee.Uint64() == 0 {
+			maxFee, err = rpgas.GetHeadlessMaxFeeWei(t.gasOracle)
 		}
+		return
+	})
+	if err != nil {
+		return err
 	}
 
 	// Check the threshold
-	if !api.PrintAndCheckGasInfo(gasInfo, true, t.gasThreshold, t.log, maxFee, t.gasLimit) {
+	if !api.PrintAndCheckGasInfo(gasInfo, true, gasThreshold, t.log, maxFee, gasLimit) {
+		run.Decide("The max fee of %.2f gwei is above the threshold of %.2f gwei, so the claim was postponed.", eth.WeiToGwei(maxFee), gasThreshold)
+		setTxQueueWaiting(run, t.txQueue, t.log, claimRplRewardsQueueID, claimRplRewardsTaskName, queueDescription, fmt.Sprintf("The max fee of %.2f gwei is above the threshold of %.2f gwei", eth.WeiToGwei(maxFee), gasThreshold))
 		return nil
 	}
 
+	// Escalate the fees if earlier attempts failed
+	maxFee, priorityFee := getQueuedTxFees(queued, t.log, maxFee, t.maxPriorityFee, t.maxFee, gasThreshold)
+
 	// Check if it's worth more than the gas to claim it
-	rplPriceWei, err := network.GetRPLPrice(t.rp, nil)
+	var rplPriceWei *big.Int
+	err = run.Input("rplPrice", &rplPriceWei, func() (err error) {
+		rplPriceWei, err = network.GetRPLPrice(t.rp, nil)
+		return
+	})
 	if err != nil {
 		return err
 	}
@@ -177,27 +277,38 @@ func (t *claimRplRewards) run() error {
 	if totalEthCost >= rewardsInEth {
 		t.log.Printlnf("Transaction would cost up to %f ETH in gas but only provide %f ETH worth of RPL. Ignoring until gas is cheaper.",
 			totalEthCost, rewardsInEth)
+		run.Decide("Claiming would cost up to %f ETH in gas but only provide %f ETH worth of RPL, so the claim was postponed.", totalEthCost, rewardsInEth)
+		setTxQueueWaiting(run, t.txQueue, t.log, claimRplRewardsQueueID, claimRplRewardsTaskName, queueDescription, fmt.Sprintf("The claim would cost up to %f ETH in gas but only provide %f ETH worth of RPL", totalEthCost, rewardsInEth))
 		return nil
 	}
 
 	opts.GasFeeCap = maxFee
-	opts.GasTipCap = t.maxPriorityFee
+	opts.GasTipCap = priorityFee
 	opts.GasLimit = gas.Uint64()
 
 	// Claim rewards
-	hash, err := rewards.ClaimNodeRewards(t.rp, opts)
-	if err != nil {
-		return err
+	if !run.Act("Claim %.6f RPL in rewards.", rewardsAmount) {
+		return nil
 	}
-
-	// Print TX info and wait for it to be mined
-	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, t.log)
+	mined, err := submitQueuedTx(t.txQueue, t.cfg, t.rp, t.log, queued, claimRplRewardsQueueID, claimRplRewardsTaskName, queueDescription, opts, func() (common.Hash, error) {
+		return rewards.ClaimNodeRewards(t.rp, opts)
+	})
 	if err != nil {
 		return err
 	}
+	if !mined {
+		return nil
+	}
 
-	// Log & return
+	// Log
 	t.log.Printlnf("Successfully claimed %.6f RPL in rewards.", rewardsAmount)
+
+	// Send a notification
+	if err := t.n.Notify(notifications.EventType_RplClaimed, "RPL rewards claimed", fmt.Sprintf("Successfully claimed %.6f RPL in rewards.", rewardsAmount)); err != nil {
+		t.log.Printlnf("WARNING: %s", err.Error())
+	}
+
+	// Return
 	return nil
 
 }
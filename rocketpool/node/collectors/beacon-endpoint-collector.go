@@ -0,0 +1,109 @@
+package collectors
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+)
+
+// Represents the collector for the status and latency of each Beacon node behind the multiplexer
+type BeaconEndpointCollector struct {
+	// Whether the Beacon node could be reached at its last check
+	reachable *prometheus.Desc
+
+	// Whether the Beacon node was synced at its last check
+	synced *prometheus.Desc
+
+	// Whether the Beacon node is the one requests are currently sent to
+	active *prometheus.Desc
+
+	// The number of requests sent to the Beacon node
+	requests *prometheus.Desc
+
+	// The number of requests to the Beacon node that failed
+	failures *prometheus.Desc
+
+	// The total time spent waiting for the Beacon node
+	latencyTotal *prometheus.Desc
+
+	// The latency of the last request to the Beacon node
+	latencyLast *prometheus.Desc
+
+	// The Beacon client multiplexer
+	bm *beacon.MultiplexedClient
+}
+
+// Create a new BeaconEndpointCollector instance
+func NewBeaconEndpointCollector(bm *beacon.MultiplexedClient) *BeaconEndpointCollector {
+	subsystem := "bn_endpoint"
+	labels := []string{"endpoint"}
+	return &BeaconEndpointCollector{
+		reachable: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "reachable"),
+			"1 if the Beacon node could be reached at its last check, 0 otherwise",
+			labels, nil,
+		),
+		synced: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "synced"),
+			"1 if the Beacon node was synced at its last check, 0 otherwise",
+			labels, nil,
+		),
+		active: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "active"),
+			"1 if requests are currently sent to the Beacon node, 0 otherwise",
+			labels, nil,
+		),
+		requests: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "requests_total"),
+			"The number of requests sent to the Beacon node",
+			labels, nil,
+		),
+		failures: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "failures_total"),
+			"The number of requests to the Beacon node that failed",
+			labels, nil,
+		),
+		latencyTotal: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "latency_seconds_total"),
+			"The total time spent waiting for the Beacon node to respond, in seconds",
+			labels, nil,
+		),
+		latencyLast: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "latency_seconds_last"),
+			"The time the last request to the Beacon node took, in seconds",
+			labels, nil,
+		),
+		bm: bm,
+	}
+}
+
+// Write metric descriptions to the Prometheus channel
+func (collector *BeaconEndpointCollector) Describe(channel chan<- *prometheus.Desc) {
+	channel <- collector.reachable
+	channel <- collector.synced
+	channel <- collector.active
+	channel <- collector.requests
+	channel <- collector.failures
+	channel <- collector.latencyTotal
+	channel <- collector.latencyLast
+}
+
+// Collect the latest metric values and pass them to Prometheus
+func (collector *BeaconEndpointCollector) Collect(channel chan<- prometheus.Metric) {
+	for _, status := range collector.bm.GetEndpointStatuses() {
+		channel <- prometheus.MustNewConstMetric(
+			collector.reachable, prometheus.GaugeValue, boolToFloat(status.Reachable), status.Name)
+		channel <- prometheus.MustNewConstMetric(
+			collector.synced, prometheus.GaugeValue, boolToFloat(status.Synced), status.Name)
+		channel <- prometheus.MustNewConstMetric(
+			collector.active, prometheus.GaugeValue, boolToFloat(status.Active), status.Name)
+		channel <- prometheus.MustNewConstMetric(
+			collector.requests, prometheus.CounterValue, float64(status.Requests), status.Name)
+		channel <- prometheus.MustNewConstMetric(
+			collector.failures, prometheus.CounterValue, float64(status.Failures), status.Name)
+		channel <- prometheus.MustNewConstMetric(
+			collector.latencyTotal, prometheus.CounterValue, status.TotalLatency.Seconds(), status.Name)
+		channel <- prometheus.MustNewConstMetric(
+			collector.latencyLast, prometheus.GaugeValue, status.LastLatency.Seconds(), status.Name)
+	}
+}
+
+// Convert a flag into a metric value
+func boolToFloat(value bool) float64 {
+	if value {
+		return 1
+	}
+	return 0
+}
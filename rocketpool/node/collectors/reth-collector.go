@@ -0,0 +1,92 @@
+package collectors
+
+import (
+	"log"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/tokens"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/contracts"
+	rputils "github.com/rocket-pool/smartnode/shared/utils/rp"
+)
+
+// Represents the collector for the rETH metrics
+type RethCollector struct {
+	// The rETH exchange rate set by the protocol (in terms of ETH)
+	protocolRate *prometheus.Desc
+
+	// The rETH rate on secondary markets (in terms of ETH)
+	marketRate *prometheus.Desc
+
+	// How far the secondary-market rate is above or below the protocol rate, as a percentage
+	deviation *prometheus.Desc
+
+	// The Rocket Pool contract manager
+	rp *rocketpool.RocketPool
+
+	// The Smartnode config
+	cfg *config.RocketPoolConfig
+
+	// The 1inch oracle contract
+	oio *contracts.OneInchOracle
+}
+
+// Create a new RethCollector instance
+func NewRethCollector(rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, oio *contracts.OneInchOracle) *RethCollector {
+	subsystem := "reth"
+	return &RethCollector{
+		protocolRate: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "protocol_rate"),
+			"The rETH exchange rate set by the protocol (in terms of ETH)",
+			nil, nil,
+		),
+		marketRate: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "market_rate"),
+			"The rETH rate on secondary markets (in terms of ETH)",
+			nil, nil,
+		),
+		deviation: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "deviation"),
+			"How far the secondary-market rETH rate is above or below the protocol rate, as a percentage",
+			nil, nil,
+		),
+		rp:  rp,
+		cfg: cfg,
+		oio: oio,
+	}
+}
+
+// Write metric descriptions to the Prometheus channel
+func (collector *RethCollector) Describe(channel chan<- *prometheus.Desc) {
+	channel <- collector.protocolRate
+	channel <- collector.marketRate
+	channel <- collector.deviation
+}
+
+// Collect the latest metric values and pass them to Prometheus
+func (collector *RethCollector) Collect(channel chan<- prometheus.Metric) {
+
+	// Only the protocol rate is available if the secondary-market rate isn't tracked
+	if collector.cfg.Smartnode.GetRethPriceSource() == config.RethPriceSource_Disabled {
+		protocolRate, err := tokens.GetRETHExchangeRate(collector.rp, nil)
+		if err != nil {
+			log.Printf("Error getting rETH exchange rate: %s\n", err.Error())
+			return
+		}
+		channel <- prometheus.MustNewConstMetric(
+			collector.protocolRate, prometheus.GaugeValue, protocolRate)
+		return
+	}
+
+	rates, err := rputils.GetRethRates(collector.rp, collector.cfg, collector.oio)
+	if err != nil {
+		log.Printf("Error getting rETH rates: %s\n", err.Error())
+		return
+	}
+	channel <- prometheus.MustNewConstMetric(
+		collector.protocolRate, prometheus.GaugeValue, rates.ProtocolRate)
+	channel <- prometheus.MustNewConstMetric(
+		collector.marketRate, prometheus.GaugeValue, rates.MarketRate)
+	channel <- prometheus.MustNewConstMetric(
+		collector.deviation, prometheus.GaugeValue, rates.Deviation)
+
+}
@@ -0,0 +1,118 @@
+package collectors
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Represents the collector for the client sync metrics
+type SyncCollector struct {
+	// Whether the primary execution client is synced
+	ecSynced *prometheus.Desc
+
+	// The sync progress of the primary execution client
+	ecSyncProgress *prometheus.Desc
+
+	// Whether the fallback execution client is synced
+	fallbackEcSynced *prometheus.Desc
+
+	// Whether the consensus client is synced
+	ccSynced *prometheus.Desc
+
+	// The sync progress of the consensus client
+	ccSyncProgress *prometheus.Desc
+
+	// The beacon client
+	bc beacon.Client
+
+	// The execution client manager
+	ec *services.ExecutionClientManager
+}
+
+// Create a new SyncCollector instance
+func NewSyncCollector(bc beacon.Client, ec *services.ExecutionClientManager) *SyncCollector {
+	subsystem := "sync"
+	return &SyncCollector{
+		ecSynced: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "ec_synced"),
+			"1 if the primary execution client is synced, 0 otherwise",
+			nil, nil,
+		),
+		ecSyncProgress: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "ec_progress"),
+			"The sync progress of the primary execution client, from 0 to 1",
+			nil, nil,
+		),
+		fallbackEcSynced: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "fallback_ec_synced"),
+			"1 if the fallback execution client is synced, 0 otherwise",
+			nil, nil,
+		),
+		ccSynced: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "cc_synced"),
+			"1 if the consensus client is synced, 0 otherwise",
+			nil, nil,
+		),
+		ccSyncProgress: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "cc_progress"),
+			"The sync progress of the consensus client, from 0 to 1",
+			nil, nil,
+		),
+		bc: bc,
+		ec: ec,
+	}
+}
+
+// Write metric descriptions to the Prometheus channel
+func (collector *SyncCollector) Describe(channel chan<- *prometheus.Desc) {
+	channel <- collector.ecSynced
+	channel <- collector.ecSyncProgress
+	channel <- collector.fallbackEcSynced
+	channel <- collector.ccSynced
+	channel <- collector.ccSyncProgress
+}
+
+// Collect the latest metric values and pass them to Prometheus
+func (collector *SyncCollector) Collect(channel chan<- prometheus.Metric) {
+
+	// Execution clients
+	ecStatus := collector.ec.CheckStatus(true)
+	channel <- prometheus.MustNewConstMetric(
+		collector.ecSynced, prometheus.GaugeValue, getSyncedValue(ecStatus.PrimaryEcStatus))
+	channel <- prometheus.MustNewConstMetric(
+		collector.ecSyncProgress, prometheus.GaugeValue, ecStatus.PrimaryEcStatus.SyncProgress)
+	if ecStatus.FallbackEnabled {
+		channel <- prometheus.MustNewConstMetric(
+			collector.fallbackEcSynced, prometheus.GaugeValue, getSyncedValue(ecStatus.FallbackEcStatus))
+	}
+
+	// Consensus client
+	ccStatus, err := collector.bc.GetSyncStatus()
+	if err != nil {
+		log.Printf("%s\n", fmt.Errorf("Error getting consensus client sync status: %w", err).Error())
+		channel <- prometheus.MustNewConstMetric(
+			collector.ccSynced, prometheus.GaugeValue, 0)
+		return
+	}
+	ccSynced := float64(0)
+	if !ccStatus.Syncing {
+		ccSynced = 1
+	}
+	ccProgress := ccStatus.Progress
+	if !ccStatus.Syncing {
+		ccProgress = 1
+	}
+	channel <- prometheus.MustNewConstMetric(
+		collector.ccSynced, prometheus.GaugeValue, ccSynced)
+	channel <- prometheus.MustNewConstMetric(
+		collector.ccSyncProgress, prometheus.GaugeValue, ccProgress)
+
+}
+
+// Convert an execution client's status into a metric value
+func getSyncedValue(status api.ExecutionClientStatus) float64 {
+	if status.IsWorking && status.IsSynced {
+		return 1
+	}
+	return 0
+}
@@ -8,11 +8,12 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/rocket-pool/smartnode/rocketpool/node/collectors"
 	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/crash"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
 	"github.com/urfave/cli"
 )
 
-func runMetricsServer(c *cli.Context, logger log.ColorLogger) error {
+func runMetricsServer(c *cli.Context, logger log.ColorLogger, crashReporter *crash.Reporter) error {
 
 	// Get services
 	cfg, err := services.GetConfig(c)
@@ -35,6 +36,14 @@ func runMetricsServer(c *cli.Context, logger log.ColorLogger) error {
 	if err != nil {
 		return err
 	}
+	bm, err := services.GetBeaconMultiplexer(c)
+	if err != nil {
+		return err
+	}
+	oio, err := services.GetOneInchOracle(c)
+	if err != nil {
+		return err
+	}
 
 	// Return if metrics are disabled
 	if cfg.EnableMetrics.Value == false {
@@ -51,10 +60,13 @@ func runMetricsServer(c *cli.Context, logger log.ColorLogger) error {
 	performanceCollector := collectors.NewPerformanceCollector(rp)
 	supplyCollector := collectors.NewSupplyCollector(rp)
 	rplCollector := collectors.NewRplCollector(rp)
+	rethCollector := collectors.NewRethCollector(rp, cfg, oio)
 	odaoCollector := collectors.NewOdaoCollector(rp)
 	nodeCollector := collectors.NewNodeCollector(rp, bc, nodeAccount.Address, cfg)
 	trustedNodeCollector := collectors.NewTrustedNodeCollector(rp, bc, nodeAccount.Address, cfg)
 	beaconCollector := collectors.NewBeaconCollector(rp, bc, ec, nodeAccount.Address)
+	syncCollector := collectors.NewSyncCollector(bc, ec)
+	beaconEndpointCollector := collectors.NewBeaconEndpointCollector(bm)
 
 	// Set up Prometheus
 	registry := prometheus.NewRegistry()
@@ -62,10 +74,14 @@ func runMetricsServer(c *cli.Context, logger log.ColorLogger) error {
 	registry.MustRegister(performanceCollector)
 	registry.MustRegister(supplyCollector)
 	registry.MustRegister(rplCollector)
+	registry.MustRegister(rethCollector)
 	registry.MustRegister(odaoCollector)
 	registry.MustRegister(nodeCollector)
 	registry.MustRegister(trustedNodeCollector)
 	registry.MustRegister(beaconCollector)
+	registry.MustRegister(syncCollector)
+	registry.MustRegister(beaconEndpointCollector)
+	registry.MustRegister(crashReporter)
 	handler := promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
 
 	// Start the HTTP server
@@ -0,0 +1,262 @@
+package node
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/client"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/notifications"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Settings
+const (
+	ecDataPath                      string = "/ethclient"
+	ecPruneLockPath                 string = "/ethclient/prune.lock"
+	pruneProvisionerContainerSuffix string = "_prune_provisioner"
+	pruneFreeSpaceRequired          uint64 = 50 * 1024 * 1024 * 1024
+)
+
+var ecStopTimeout = 5 * time.Minute
+
+// Monitor EC disk space task
+type monitorEcDiskSpace struct {
+	c   *cli.Context
+	log log.ColorLogger
+	cfg *config.RocketPoolConfig
+	d   *client.Client
+	n   *notifications.Notifier
+}
+
+// Create monitor EC disk space task
+func newMonitorEcDiskSpace(c *cli.Context, logger log.ColorLogger) (*monitorEcDiskSpace, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	d, err := services.GetDocker(c)
+	if err != nil {
+		return nil, err
+	}
+	n, err := services.GetNotifier(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	return &monitorEcDiskSpace{
+		c:   c,
+		log: logger,
+		cfg: cfg,
+		d:   d,
+		n:   n,
+	}, nil
+
+}
+
+// Check the free space on the Execution client's chain data volume, and prune Geth if it's running low
+func (t *monitorEcDiskSpace) run() error {
+
+	// Check if the monitor is enabled
+	threshold := t.cfg.Smartnode.EcLowDiskThreshold.GetUintOrDefault(config.Network_All)
+	if threshold == 0 || t.cfg.IsNativeMode || t.cfg.GetExecutionClientMode() != config.Mode_Local {
+		return nil
+	}
+	ecContainer := t.cfg.Smartnode.GetProjectName() + ExecutionContainerSuffix
+
+	// Get the free space on the chain data volume
+	freeBytes, err := t.getFreeSpace(ecContainer)
+	if err != nil {
+		return err
+	}
+	freeGib := float64(freeBytes) / (1024 * 1024 * 1024)
+	if freeBytes >= threshold*1024*1024*1024 {
+		t.n.Resolve(notifications.EventType_EcLowDiskSpace)
+		return nil
+	}
+
+	// Leave it alone if it's already pruning
+	pruning, err := t.isPruning(ecContainer)
+	if err != nil {
+		return err
+	}
+	if pruning {
+		t.log.Printlnf("Only %.1f GiB of space is left for the Execution client, but it's already pruning.", freeGib)
+		return nil
+	}
+
+	// Alert if it can't be pruned automatically
+	message := fmt.Sprintf("Only %.1f GiB of disk space is left for your Execution client's chain data.", freeGib)
+	selectedEc := t.cfg.ExecutionClient.Value.(config.ExecutionClient)
+	var reason string
+	switch {
+	case t.cfg.Smartnode.EcAutoPrune.Value != true:
+		reason = "Run `rocketpool service prune-eth1` or free up some space."
+	case selectedEc != config.ExecutionClient_Geth:
+		reason = fmt.Sprintf("Automatic pruning is only available for Geth, so you'll need to free up space for %s yourself.", selectedEc)
+	case t.cfg.UseFallbackExecutionClient.Value != true:
+		reason = "Automatic pruning requires a fallback Execution client, so your node stays online while Geth prunes. Set one up, or run `rocketpool service prune-eth1` yourself."
+	case freeBytes < pruneFreeSpaceRequired:
+		reason = "Geth needs at least 50 GiB of free space to prune, so it can't be pruned automatically. Please free up some space."
+	}
+	if reason != "" {
+		t.log.Printlnf("WARNING: %s %s", message, reason)
+		return t.n.Notify(notifications.EventType_EcLowDiskSpace, "Execution client low on disk space", fmt.Sprintf("%s %s", message, reason))
+	}
+
+	// Prune it
+	t.log.Printlnf("%s Pruning Geth automatically...", message)
+	if err := t.prune(ecContainer); err != nil {
+		return fmt.Errorf("Could not prune the Execution client: %w", err)
+	}
+	t.log.Println("Geth is now pruning; the fallback Execution client will be used until it's done. Don't restart it until pruning is complete.")
+	return t.n.Notify(notifications.EventType_EcPruneStarted, "Execution client pruning", fmt.Sprintf("%s Geth is now pruning automatically; your fallback Execution client will be used until it's done.", message))
+
+}
+
+// Get the free space on the chain data volume, in bytes
+func (t *monitorEcDiskSpace) getFreeSpace(ecContainer string) (uint64, error) {
+	output, exitCode, err := t.exec(ecContainer, "df", "-Pk", ecDataPath)
+	if err != nil {
+		return 0, fmt.Errorf("Could not check the Execution client's free disk space: %w", err)
+	}
+	if exitCode != 0 {
+		return 0, fmt.Errorf("Could not check the Execution client's free disk space: df exited with code %d: %s", exitCode, strings.TrimSpace(output))
+	}
+
+	// The free space is the fourth column of the last line, in KiB
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	fields := strings.Fields(lines[len(lines)-1])
+	if len(fields) < 4 {
+		return 0, fmt.Errorf("Could not parse the Execution client's free disk space from [%s]", output)
+	}
+	freeKib, err := strconv.ParseUint(fields[3], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("Could not parse the Execution client's free disk space from [%s]: %w", output, err)
+	}
+	return freeKib * 1024, nil
+}
+
+// Check if the Execution client has been set up to prune, or is pruning
+func (t *monitorEcDiskSpace) isPruning(ecContainer string) (bool, error) {
+	_, exitCode, err := t.exec(ecContainer, "test", "-e", ecPruneLockPath)
+	if err != nil {
+		return false, fmt.Errorf("Could not check if the Execution client is pruning: %w", err)
+	}
+	return exitCode == 0, nil
+}
+
+// Run the same steps as `rocketpool service prune-eth1`: stop Geth, mark its volume for pruning, and start it again
+func (t *monitorEcDiskSpace) prune(ecContainer string) error {
+
+	ctx := context.Background()
+
+	// Get the chain data volume
+	info, err := t.d.ContainerInspect(ctx, ecContainer)
+	if err != nil {
+		return fmt.Errorf("error inspecting %s: %w", ecContainer, err)
+	}
+	volume := ""
+	for _, mount := range info.Mounts {
+		if mount.Destination == ecDataPath {
+			volume = mount.Name
+			break
+		}
+	}
+	if volume == "" {
+		return fmt.Errorf("%s doesn't have a chain data volume at %s", ecContainer, ecDataPath)
+	}
+
+	// Pull the prune provisioner before stopping anything
+	image := t.cfg.Smartnode.GetPruneProvisionerContainerTag()
+	reader, err := t.d.ImagePull(ctx, image, types.ImagePullOptions{})
+	if err != nil {
+		return fmt.Errorf("error pulling %s: %w", image, err)
+	}
+	_, _ = io.Copy(ioutil.Discard, reader)
+	reader.Close()
+
+	// Stop the Execution client
+	if err := t.d.ContainerStop(ctx, ecContainer, &ecStopTimeout); err != nil {
+		return fmt.Errorf("error stopping %s: %w", ecContainer, err)
+	}
+
+	// Run the prune provisioner, then start the Execution client again whether or not it worked
+	provisionErr := t.runPruneProvisioner(ctx, image, volume)
+	if err := t.d.ContainerStart(ctx, ecContainer, types.ContainerStartOptions{}); err != nil {
+		return fmt.Errorf("error starting %s: %w", ecContainer, err)
+	}
+	return provisionErr
+
+}
+
+// Run the prune provisioner on the chain data volume
+func (t *monitorEcDiskSpace) runPruneProvisioner(ctx context.Context, image string, volume string) error {
+	name := t.cfg.Smartnode.GetProjectName() + pruneProvisionerContainerSuffix
+	created, err := t.d.ContainerCreate(ctx, &container.Config{
+		Image: image,
+	}, &container.HostConfig{
+		Binds: []string{fmt.Sprintf("%s:%s", volume, ecDataPath)},
+	}, nil, name)
+	if err != nil {
+		return fmt.Errorf("error creating the prune provisioner: %w", err)
+	}
+	defer t.d.ContainerRemove(ctx, created.ID, types.ContainerRemoveOptions{Force: true})
+
+	if err := t.d.ContainerStart(ctx, created.ID, types.ContainerStartOptions{}); err != nil {
+		return fmt.Errorf("error starting the prune provisioner: %w", err)
+	}
+	results, errs := t.d.ContainerWait(ctx, created.ID, container.WaitConditionNotRunning)
+	select {
+	case result := <-results:
+		if result.StatusCode != 0 {
+			return fmt.Errorf("the prune provisioner exited with code %d", result.StatusCode)
+		}
+	case err := <-errs:
+		return fmt.Errorf("error waiting for the prune provisioner: %w", err)
+	}
+	return nil
+}
+
+// Run a command in a container, returning its output and exit code
+func (t *monitorEcDiskSpace) exec(containerName string, cmd ...string) (string, int, error) {
+	ctx := context.Background()
+	execution, err := t.d.ContainerExecCreate(ctx, containerName, types.ExecConfig{
+		AttachStdout: true,
+		AttachStderr: true,
+		Cmd:          cmd,
+	})
+	if err != nil {
+		return "", 0, err
+	}
+	response, err := t.d.ContainerExecAttach(ctx, execution.ID, types.ExecStartCheck{})
+	if err != nil {
+		return "", 0, err
+	}
+	defer response.Close()
+
+	var output bytes.Buffer
+	if _, err := stdcopy.StdCopy(&output, &output, response.Reader); err != nil {
+		return "", 0, err
+	}
+	inspect, err := t.d.ContainerExecInspect(ctx, execution.ID)
+	if err != nil {
+		return "", 0, err
+	}
+	return output.String(), inspect.ExitCode, nil
+}
@@ -1,6 +1,8 @@
 package node
 
 import (
+	"context"
+	"fmt"
 	"net/http"
 	"sync"
 	"time"
@@ -9,21 +11,38 @@ import (
 	"github.com/urfave/cli"
 
 	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/crash"
+	"github.com/rocket-pool/smartnode/shared/services/events"
+	"github.com/rocket-pool/smartnode/shared/services/health"
+	"github.com/rocket-pool/smartnode/shared/services/notifications"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
 )
 
 // Config
 var tasksInterval, _ = time.ParseDuration("5m")
 var taskCooldown, _ = time.ParseDuration("10s")
+var chainMonitorInterval, _ = time.ParseDuration("30s")
 
 const (
 	MaxConcurrentEth1Requests = 200
 
+	// The name of the node daemon's task journal
+	JournalName = "node"
+
 	ClaimRplRewardsColor         = color.FgGreen
 	StakePrelaunchMinipoolsColor = color.FgBlue
 	MetricsColor                 = color.FgHiYellow
 	ErrorColor                   = color.FgRed
 	WarningColor                 = color.FgYellow
+	ReplayColor                  = color.FgHiMagenta
+	PruneRewardsTreesColor       = color.FgHiBlue
+	AutoClaimRewardsColor        = color.FgHiGreen
+	CheckClientEventsColor       = color.FgCyan
+	BroadcastScheduledExitsColor = color.FgHiRed
+	ValidatorWatchdogColor       = color.FgHiCyan
+	RecordRateHistoryColor       = color.FgHiWhite
 )
 
 // Register node command
@@ -35,6 +54,31 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 		Action: func(c *cli.Context) error {
 			return run(c)
 		},
+		Subcommands: []cli.Command{
+			{
+				Name:      "replay",
+				Usage:     "Replay a recorded run of a task from the task journal without submitting any transactions, and compare its decisions with the recorded ones",
+				UsageText: "rocketpool node replay [options] task",
+				Flags: []cli.Flag{
+					cli.Uint64Flag{
+						Name:  "run, r",
+						Usage: "The run to replay, counting back from the most recent run (which is 1)",
+						Value: 1,
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+
+					// Run
+					return replay(c, c.Args().Get(0), c.Uint64("run"))
+
+				},
+			},
+		},
 	})
 }
 
@@ -44,6 +88,11 @@ func run(c *cli.Context) error {
 	// Configure
 	configureHTTP()
 
+	// Serve the health check before waiting for anything, so Docker knows the daemon is up
+	if err := health.Start(c, config.NodeContainerName, true, log.NewColorLogger(ErrorColor)); err != nil {
+		return err
+	}
+
 	// Wait until node is registered
 	if err := services.WaitNodeRegistered(c, true); err != nil {
 		return err
@@ -54,13 +103,124 @@ func run(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
+	autoClaimRewards, err := newAutoClaimRewards(c, log.NewColorLogger(AutoClaimRewardsColor))
+	if err != nil {
+		return err
+	}
 	stakePrelaunchMinipools, err := newStakePrelaunchMinipools(c, log.NewColorLogger(StakePrelaunchMinipoolsColor))
 	if err != nil {
 		return err
 	}
+	checkDiskSpace, err := newCheckDiskSpace(c, log.NewColorLogger(WarningColor))
+	if err != nil {
+		return err
+	}
+	checkClockOffset, err := newCheckClockOffset(c, log.NewColorLogger(WarningColor))
+	if err != nil {
+		return err
+	}
+	checkRethRate, err := newCheckRethRate(c, log.NewColorLogger(WarningColor))
+	if err != nil {
+		return err
+	}
+	recordRateHistory, err := newRecordRateHistory(c, log.NewColorLogger(RecordRateHistoryColor))
+	if err != nil {
+		return err
+	}
+	monitorEcDiskSpace, err := newMonitorEcDiskSpace(c, log.NewColorLogger(WarningColor))
+	if err != nil {
+		return err
+	}
+	checkClientEvents, err := newCheckClientEvents(c, log.NewColorLogger(CheckClientEventsColor))
+	if err != nil {
+		return err
+	}
+	pruneRewardsTrees, err := newPruneRewardsTrees(c, log.NewColorLogger(PruneRewardsTreesColor))
+	if err != nil {
+		return err
+	}
+	checkValidatorPerformance, err := newCheckValidatorPerformance(c, log.NewColorLogger(WarningColor))
+	if err != nil {
+		return err
+	}
+	broadcastScheduledExits, err := newBroadcastScheduledExits(c, log.NewColorLogger(BroadcastScheduledExitsColor))
+	if err != nil {
+		return err
+	}
+	unloadExitedValidators, err := newUnloadExitedValidators(c, log.NewColorLogger(BroadcastScheduledExitsColor))
+	if err != nil {
+		return err
+	}
+	checkNetworkSlashings, err := newCheckNetworkSlashings(c, log.NewColorLogger(WarningColor))
+	if err != nil {
+		return err
+	}
+	validatorWatchdog, err := newValidatorWatchdog(c, log.NewColorLogger(ValidatorWatchdogColor))
+	if err != nil {
+		return err
+	}
+	checkValidatorSlashings, err := newCheckValidatorSlashings(c, log.NewColorLogger(ErrorColor))
+	if err != nil {
+		return err
+	}
+
+	// Initialize notifications
+	notifier, err := services.GetDaemonNotifier(c)
+	if err != nil {
+		return err
+	}
 
 	// Initialize loggers
 	errorLog := log.NewColorLogger(ErrorColor)
+	warningLog := log.NewColorLogger(WarningColor)
+
+	// Transactions for a node account on a hardware wallet have to be signed manually, so the automatic ones are disabled
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return err
+	}
+	if w.IsHardwareNodeAccount() {
+		warningLog.Println("The node account is on a hardware wallet, so automatic RPL claims and minipool staking are disabled. Run `rocketpool node claim-rpl` and `rocketpool minipool stake` yourself, then sign the transactions with `rocketpool wallet sign-queue`.")
+	}
+
+	// Check the Beacon chain for reorgs and finality stalls before running any tasks
+	chainMonitor, err := services.GetChainMonitor(c)
+	if err != nil {
+		return err
+	}
+	if err := chainMonitor.Check(); err != nil {
+		errorLog.Println(err)
+	}
+
+	// Stream the daemon's events over gRPC if enabled
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return err
+	}
+	events.SetDaemon("node")
+	if cfg.Smartnode.EnableEventStream.Value == true {
+		go func() {
+			if err := events.ServeGrpc("0.0.0.0", cfg.Smartnode.GetEventStreamPort()); err != nil {
+				errorLog.Println(err)
+			}
+		}()
+	}
+
+	// Keep scoring the Execution clients between task loops so requests go to the healthiest one
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return err
+	}
+	ec.StartHealthChecks(cfg.GetEcHealthCheckInterval())
+
+	// The daemon's own transactions wait for the private relay instead of handing it off
+	ec.WaitForPrivateRelay()
+
+	// Recover from panics in the daemon's loops so one bad task doesn't take down the whole daemon
+	crashReporter := crash.NewReporter("node", cfg.Smartnode.GetCrashReportPath(), errorLog)
+
+	// Schedule the loops with the daemon's clock
+	clk := services.GetClock(c)
 
 	// Wait group to handle the various threads
 	wg := new(sync.WaitGroup)
@@ -68,31 +228,161 @@ func run(c *cli.Context) error {
 
 	// Run task loop
 	go func() {
-		for {
-			// Check the EC status
-			err := services.WaitEthClientSynced(c, false) // Force refresh the primary / fallback EC status
-			if err != nil {
-				errorLog.Println(err)
-			} else {
-				// Run the rewards check
-				if err := claimRplRewards.run(); err != nil {
+		crashReporter.RunLoop("tasks", func() {
+			inBlackout := false
+			for {
+				// Defer non-critical automation during blackout windows, and send the alerts held back during them once they end
+				if blackout := cfg.Schedule.IsBlackout(clk.Now()); blackout != inBlackout {
+					inBlackout = blackout
+					if inBlackout {
+						warningLog.Println("Entered a blackout window; automatic reward claims, performance checks, and rewards tree pruning are deferred until it ends.")
+					} else {
+						warningLog.Println("The blackout window has ended; resuming deferred automation.")
+					}
+				}
+				if err := notifier.SendQueued(); err != nil {
+					errorLog.Println(err)
+				}
+
+				// Check the EC status
+				err := services.WaitEthClientSynced(c, false) // Force refresh the primary / fallback EC status
+				if err != nil {
+					errorLog.Println(err)
+					events.Publish(events.EventType_Error, "", fmt.Sprintf("No Execution client is available: %s", err.Error()))
+					err = notifier.Notify(notifications.EventType_ExecutionClientDown, "Execution client down", fmt.Sprintf("No Execution client is available: %s", err.Error()))
+					if err != nil {
+						errorLog.Println(err)
+					}
+				} else {
+					notifier.Resolve(notifications.EventType_ExecutionClientDown)
+
+					// These tasks submit transactions automatically
+					if !w.IsHardwareNodeAccount() {
+
+						if !inBlackout {
+							// Run the rewards check
+							if err := events.RunTask("claim-rpl-rewards", claimRplRewards.run); err != nil {
+								errorLog.Println(err)
+							}
+							clk.Sleep(taskCooldown)
+
+							// Run the Merkle rewards claim check
+							if err := events.RunTask("auto-claim-rewards", autoClaimRewards.run); err != nil {
+								errorLog.Println(err)
+							}
+							clk.Sleep(taskCooldown)
+						}
+
+						// Run the minipool stake check
+						if err := events.RunTask("stake-prelaunch-minipools", stakePrelaunchMinipools.run); err != nil {
+							errorLog.Println(err)
+						}
+					}
+				}
+
+				// Send the transactions the CLI gave to the private relay to the public mempool if they weren't included in time
+				if err := events.RunTask("rebroadcast-private-transactions", func() error {
+					return ec.RebroadcastPrivateTransactions(context.Background())
+				}); err != nil {
+					errorLog.Println(err)
+				}
+
+				// Run the scheduled exit check
+				if err := events.RunTask("broadcast-scheduled-exits", broadcastScheduledExits.run); err != nil {
+					errorLog.Println(err)
+				}
+
+				// Remove the keys of exited validators from the validator client
+				if err := events.RunTask("unload-exited-validators", unloadExitedValidators.run); err != nil {
+					errorLog.Println(err)
+				}
+
+				// Run the network slashing check
+				if err := events.RunTask("check-network-slashings", checkNetworkSlashings.run); err != nil {
+					errorLog.Println(err)
+				}
+
+				// Run the disk space check
+				if err := events.RunTask("check-disk-space", checkDiskSpace.run); err != nil {
 					errorLog.Println(err)
 				}
-				time.Sleep(taskCooldown)
 
-				// Run the minipool stake check
-				if err := stakePrelaunchMinipools.run(); err != nil {
+				// Run the clock offset check
+				if err := events.RunTask("check-clock-offset", checkClockOffset.run); err != nil {
 					errorLog.Println(err)
 				}
+
+				// Run the rETH rate check
+				if err := events.RunTask("check-reth-rate", checkRethRate.run); err != nil {
+					errorLog.Println(err)
+				}
+
+				// Record the rETH and RPL rates
+				if err := events.RunTask("record-rate-history", recordRateHistory.run); err != nil {
+					errorLog.Println(err)
+				}
+
+				// Run the Execution client disk space check
+				if err := events.RunTask("monitor-ec-disk-space", monitorEcDiskSpace.run); err != nil {
+					errorLog.Println(err)
+				}
+
+				// Run the client log check
+				if err := events.RunTask("check-client-events", checkClientEvents.run); err != nil {
+					errorLog.Println(err)
+				}
+
+				if !inBlackout {
+					// Run the validator performance check
+					if err := events.RunTask("check-validator-performance", checkValidatorPerformance.run); err != nil {
+						errorLog.Println(err)
+					}
+
+					// Run the rewards tree pruning
+					if err := events.RunTask("prune-rewards-trees", pruneRewardsTrees.run); err != nil {
+						errorLog.Println(err)
+					}
+				}
+				clk.Sleep(cfg.ScaleEcPollingInterval(tasksInterval))
 			}
-			time.Sleep(tasksInterval)
-		}
+		})
 		wg.Done()
 	}()
 
+	// Run chain monitor loop
+	go func() {
+		crashReporter.RunLoop("chain-monitor", func() {
+			for {
+				clk.Sleep(cfg.ScaleEcPollingInterval(chainMonitorInterval))
+				if err := chainMonitor.Check(); err != nil {
+					errorLog.Println(err)
+				}
+			}
+		})
+	}()
+
+	// Run the validator slashing check loop, outside of the task loop so slashings are reported right away
+	go func() {
+		crashReporter.RunLoop("validator-slashings", func() {
+			for {
+				if err := events.RunTask("check-validator-slashings", checkValidatorSlashings.run); err != nil {
+					errorLog.Println(err)
+				}
+				clk.Sleep(cfg.ScaleEcPollingInterval(validatorSlashingCheckInterval))
+			}
+		})
+	}()
+
+	// Run the Validator client watchdog
+	go func() {
+		if err := validatorWatchdog.run(); err != nil {
+			errorLog.Println(err)
+		}
+	}()
+
 	// Run metrics loop
 	go func() {
-		err := runMetricsServer(c, log.NewColorLogger(MetricsColor))
+		err := runMetricsServer(c, log.NewColorLogger(MetricsColor), crashReporter)
 		if err != nil {
 			errorLog.Println(err)
 		}
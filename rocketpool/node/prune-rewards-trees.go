@@ -0,0 +1,134 @@
+package node
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/dao/trustednode"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Prune rewards trees task
+type pruneRewardsTrees struct {
+	c   *cli.Context
+	log log.ColorLogger
+	cfg *config.RocketPoolConfig
+	w   *wallet.Wallet
+	rp  *rocketpool.RocketPool
+}
+
+// Create prune rewards trees task
+func newPruneRewardsTrees(c *cli.Context, logger log.ColorLogger) (*pruneRewardsTrees, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	return &pruneRewardsTrees{
+		c:   c,
+		log: logger,
+		cfg: cfg,
+		w:   w,
+		rp:  rp,
+	}, nil
+
+}
+
+// Delete the rewards tree files of old intervals the node has already claimed
+func (t *pruneRewardsTrees) run() error {
+
+	// Check if pruning is enabled
+	retention := t.cfg.Smartnode.GetRewardsTreeRetention()
+	if retention == 0 {
+		return nil
+	}
+
+	// Get the files outside of the retention window
+	files, err := rewards.ListRewardsFiles(os.ExpandEnv(t.cfg.Smartnode.GetRewardsTreePath()), string(t.cfg.Smartnode.GetNetwork()))
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		return nil
+	}
+	newestIndex := files[len(files)-1].Index
+	if newestIndex < retention {
+		return nil
+	}
+	cutoff := newestIndex - retention + 1
+
+	// Oracle DAO members need every file to build and verify trees
+	nodeAccount, err := t.w.GetNodeAccount()
+	if err != nil {
+		return err
+	}
+	isMember, err := trustednode.GetMemberExists(t.rp, nodeAccount.Address, nil)
+	if err != nil {
+		return fmt.Errorf("Could not check if the node is an Oracle DAO member: %w", err)
+	}
+	if isMember {
+		return nil
+	}
+
+	// Delete the old files of intervals the node has claimed, or has nothing to claim from
+	prunable := map[uint64]bool{}
+	for _, file := range files {
+		if file.Index >= cutoff {
+			break
+		}
+		canPrune, checked := prunable[file.Index]
+		if !checked {
+			canPrune, err = t.canPrune(file, nodeAccount.Address)
+			if err != nil {
+				return err
+			}
+			prunable[file.Index] = canPrune
+		}
+		if !canPrune {
+			continue
+		}
+		if err := os.Remove(file.Path); err != nil {
+			return fmt.Errorf("Could not delete rewards tree file %s: %w", file.Path, err)
+		}
+		t.log.Printlnf("Deleted the rewards tree file for interval %d since the node has no unclaimed rewards in it.", file.Index)
+
+		// The chain state it was generated from isn't needed either
+		snapshotPath := rewards.GetIntervalSnapshotPath(os.ExpandEnv(t.cfg.Smartnode.GetRewardsSnapshotPath()), string(t.cfg.Smartnode.GetNetwork()), file.Index)
+		if err := os.Remove(snapshotPath); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("Could not delete interval snapshot %s: %w", snapshotPath, err)
+		}
+	}
+	return nil
+
+}
+
+// Check if a rewards tree file is no longer needed to claim the node's rewards
+func (t *pruneRewardsTrees) canPrune(file rewards.StoredRewardsFile, nodeAddress common.Address) (bool, error) {
+	rewardsFile, err := rewards.ReadRewardsFile(file.Path)
+	if err != nil {
+		return false, err
+	}
+	if _, exists := rewardsFile.NodeRewards[nodeAddress]; !exists {
+		return true, nil
+	}
+	return rewards.IsClaimed(t.rp, file.Index, nodeAddress, nil)
+}
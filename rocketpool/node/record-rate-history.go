@@ -0,0 +1,125 @@
+package node
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/rocket-pool/rocketpool-go/network"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/tokens"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/clock"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/contracts"
+	"github.com/rocket-pool/smartnode/shared/services/ratehistory"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+	rputils "github.com/rocket-pool/smartnode/shared/utils/rp"
+)
+
+// Settings
+const rateHistoryInterval = time.Hour
+
+// Record rate history task
+type recordRateHistory struct {
+	c       *cli.Context
+	log     log.ColorLogger
+	cfg     *config.RocketPoolConfig
+	rp      *rocketpool.RocketPool
+	oio     *contracts.OneInchOracle
+	history *ratehistory.History
+	clock   clock.Clock
+}
+
+// Create record rate history task
+func newRecordRateHistory(c *cli.Context, logger log.ColorLogger) (*recordRateHistory, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	oio, err := services.GetOneInchOracle(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	return &recordRateHistory{
+		c:       c,
+		log:     logger,
+		cfg:     cfg,
+		rp:      rp,
+		oio:     oio,
+		history: ratehistory.NewHistory(os.ExpandEnv(cfg.Smartnode.GetRateHistoryPath())),
+		clock:   services.GetClock(c),
+	}, nil
+
+}
+
+// Record the rETH exchange rate and RPL price if the last sample is old enough
+func (t *recordRateHistory) run() error {
+
+	// Check if recording is enabled
+	retentionDays := t.cfg.Smartnode.GetRateHistoryRetention()
+	if retentionDays == 0 {
+		return nil
+	}
+
+	// Check if a sample is due
+	latest, err := t.history.GetLatest()
+	if err != nil {
+		return err
+	}
+	if latest != nil && t.clock.Since(latest.Time) < rateHistoryInterval {
+		return nil
+	}
+
+	// Wait for eth client to sync
+	if err := services.WaitEthClientSynced(t.c, true); err != nil {
+		return err
+	}
+
+	// Get the rates
+	blockNumber, err := t.rp.Client.BlockNumber(context.Background())
+	if err != nil {
+		return err
+	}
+	rethRate, err := tokens.GetRETHExchangeRate(t.rp, nil)
+	if err != nil {
+		return err
+	}
+	rplPrice, err := network.GetRPLPrice(t.rp, nil)
+	if err != nil {
+		return err
+	}
+	sample := api.RateSample{
+		Time:     t.clock.Now(),
+		Block:    blockNumber,
+		RethRate: rethRate,
+		RplPrice: eth.WeiToEth(rplPrice),
+	}
+
+	// The market rate is optional, so a sample is still recorded if it can't be retrieved
+	if t.cfg.Smartnode.GetRethPriceSource() != config.RethPriceSource_Disabled {
+		rates, err := rputils.GetRethRates(t.rp, t.cfg, t.oio)
+		if err != nil {
+			t.log.Printlnf("WARNING: Could not get the secondary-market rETH rate: %s", err.Error())
+		} else {
+			sample.RethMarketRate = rates.MarketRate
+		}
+	}
+
+	// Save it
+	retention := time.Duration(retentionDays) * 24 * time.Hour
+	return t.history.Add(sample, retention)
+
+}
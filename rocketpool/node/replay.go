@@ -0,0 +1,115 @@
+package node
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/journal"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// The tasks that can be replayed from the journal, keyed by the name used on the command line
+var replayableTasks = map[string]func(c *cli.Context, run *journal.Run) error{
+	claimRplRewardsTaskName: func(c *cli.Context, run *journal.Run) error {
+		task, err := newClaimRplRewards(c, log.NewColorLogger(ClaimRplRewardsColor))
+		if err != nil {
+			return err
+		}
+		return task.claim(run)
+	},
+	autoClaimRewardsTaskName: func(c *cli.Context, run *journal.Run) error {
+		task, err := newAutoClaimRewards(c, log.NewColorLogger(AutoClaimRewardsColor))
+		if err != nil {
+			return err
+		}
+		return task.claim(run)
+	},
+	stakePrelaunchMinipoolsTaskName: func(c *cli.Context, run *journal.Run) error {
+		task, err := newStakePrelaunchMinipools(c, log.NewColorLogger(StakePrelaunchMinipoolsColor))
+		if err != nil {
+			return err
+		}
+		return task.stake(run)
+	},
+}
+
+// Get the names of the tasks that can be replayed
+func getReplayableTaskNames() []string {
+	names := make([]string, 0, len(replayableTasks))
+	for name := range replayableTasks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Replay a recorded run of a task using the inputs from the journal, without submitting any transactions.
+// The run number counts back from the most recent run, which is 1.
+func replay(c *cli.Context, taskName string, runNumber uint64) error {
+
+	// Get the task
+	replayTask, exists := replayableTasks[taskName]
+	if !exists {
+		return fmt.Errorf("Unknown task '%s'; valid tasks are: %s", taskName, strings.Join(getReplayableTaskNames(), ", "))
+	}
+
+	// Get the recorded run
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return err
+	}
+	taskJournal := journal.NewJournal(cfg.Smartnode.GetJournalPath(), JournalName)
+	entries, err := taskJournal.GetEntries(taskName)
+	if err != nil {
+		return err
+	}
+	if len(entries) == 0 {
+		return fmt.Errorf("The journal at %s doesn't have any recorded runs of the %s task.", taskJournal.GetPath(), taskName)
+	}
+	if runNumber == 0 || runNumber > uint64(len(entries)) {
+		return fmt.Errorf("Invalid run number %d; the journal has %d recorded runs of the %s task.", runNumber, len(entries), taskName)
+	}
+	entry := entries[uint64(len(entries))-runNumber]
+
+	// Replay it
+	configureHTTP()
+	logger := log.NewColorLogger(ReplayColor)
+	logger.Printlnf("Replaying the %s run from %s; no transactions will be submitted.", taskName, entry.Time.Format(time.RFC1123))
+	run := journal.NewReplay(entry)
+	replayErr := replayTask(c, run)
+
+	// Compare the decisions
+	logger.Println("Recorded run:")
+	printReplayResult(logger, run.GetRecordedDecisions(), entry.Error)
+	logger.Println("Replayed run:")
+	replayErrMessage := ""
+	if replayErr != nil {
+		replayErrMessage = replayErr.Error()
+	}
+	printReplayResult(logger, run.GetDecisions(), replayErrMessage)
+	if replayErrMessage == entry.Error && strings.Join(run.GetDecisions(), "\n") == strings.Join(run.GetRecordedDecisions(), "\n") {
+		logger.Println("The replay made the same decisions as the recorded run.")
+	} else {
+		logger.Println("The replay made different decisions from the recorded run.")
+	}
+	return nil
+
+}
+
+// Print the decisions and error of a run
+func printReplayResult(logger log.ColorLogger, decisions []string, errMessage string) {
+	if len(decisions) == 0 && errMessage == "" {
+		logger.Println("\tNo action was needed.")
+	}
+	for _, decision := range decisions {
+		logger.Printlnf("\t%s", decision)
+	}
+	if errMessage != "" {
+		logger.Printlnf("\tError: %s", errMessage)
+	}
+}
@@ -4,27 +4,42 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"math/big"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"time"
 
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/client"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
+	prdeposit "github.com/prysmaticlabs/prysm/v2/contracts/deposit"
+	ethpb "github.com/prysmaticlabs/prysm/v2/proto/prysm/v1alpha1"
 	"github.com/rocket-pool/rocketpool-go/minipool"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
 	"github.com/rocket-pool/rocketpool-go/settings/trustednode"
 	rptypes "github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/rocketpool-go/utils"
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
 	"github.com/urfave/cli"
+	eth2types "github.com/wealdtech/go-eth2-types/v2"
 	"golang.org/x/sync/errgroup"
 
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/clock"
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	rpgas "github.com/rocket-pool/smartnode/shared/services/gas"
+	"github.com/rocket-pool/smartnode/shared/services/journal"
+	"github.com/rocket-pool/smartnode/shared/services/keymanager"
+	"github.com/rocket-pool/smartnode/shared/services/notifications"
+	"github.com/rocket-pool/smartnode/shared/services/sponsor"
+	"github.com/rocket-pool/smartnode/shared/services/txqueue"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/services/wallet/keystore/lighthouse"
+	"github.com/rocket-pool/smartnode/shared/services/wallet/keystore/teku"
 	"github.com/rocket-pool/smartnode/shared/utils/api"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
 	"github.com/rocket-pool/smartnode/shared/utils/validator"
@@ -33,9 +48,63 @@ import (
 // Settings
 const ValidatorContainerSuffix = "_validator"
 const BeaconContainerSuffix = "_eth2"
+const DepositScanBlockOffset = 100000
 
 var validatorRestartTimeout, _ = time.ParseDuration("5s")
 
+// The suffix of the folders stored Lighthouse keystores are moved to while they're imported through the Keymanager API
+const importBackupSuffix = ".import"
+
+// Get the names of the Validator client containers, including the additional shards
+func getValidatorContainerNames(cfg *config.RocketPoolConfig) []string {
+	prefix := cfg.Smartnode.GetProjectName()
+	names := []string{prefix + ValidatorContainerSuffix}
+	for _, shard := range cfg.GetValidatorShardContainerNames() {
+		names = append(names, fmt.Sprintf("%s_%s", prefix, shard))
+	}
+	return names
+}
+
+// Get the names of the Validator client containers that hold any of the provided keys, so only those shards need to be restarted.
+// Keys that haven't been assigned to a shard belong to the main Validator client.
+func getValidatorContainerNamesForKeys(cfg *config.RocketPoolConfig, assignments *wallet.ShardAssignments, pubkeys []rptypes.ValidatorPubkey) ([]string, error) {
+	if assignments == nil || assignments.GetShardCount() < 2 {
+		return getValidatorContainerNames(cfg), nil
+	}
+	shards, err := assignments.GetAssignments()
+	if err != nil {
+		return nil, fmt.Errorf("Could not get the Validator client shards of the validator keys: %w", err)
+	}
+	hasKeys := map[uint64]bool{}
+	for _, pubkey := range pubkeys {
+		hasKeys[shards[pubkey.Hex()]] = true
+	}
+	prefix := cfg.Smartnode.GetProjectName()
+	names := []string{}
+	for shard := uint64(0); shard < assignments.GetShardCount(); shard++ {
+		if hasKeys[shard] {
+			names = append(names, fmt.Sprintf("%s_%s", prefix, config.GetValidatorShardContainerName(shard)))
+		}
+	}
+	return names, nil
+}
+
+// The name of the stake prelaunch minipools task in the journal
+const stakePrelaunchMinipoolsTaskName string = "stake-prelaunch-minipools"
+
+// The status of one of the node's minipools
+type minipoolStatus struct {
+	Address common.Address         `json:"address"`
+	Status  minipool.StatusDetails `json:"status"`
+}
+
+// How close a minipool is to its launch timeout
+type stakeTimeout struct {
+	IsDue                bool          `json:"isDue"`
+	TimeUntilDue         time.Duration `json:"timeUntilDue"`
+	EscalatedPriorityFee *big.Int      `json:"escalatedPriorityFee"`
+}
+
 // Stake prelaunch minipools task
 type stakePrelaunchMinipools struct {
 	c              *cli.Context
@@ -43,9 +112,14 @@ type stakePrelaunchMinipools struct {
 	cfg            *config.RocketPoolConfig
 	w              *wallet.Wallet
 	rp             *rocketpool.RocketPool
+	n              *notifications.Notifier
+	journal        *journal.Journal
+	txQueue        *txqueue.Queue
+	clock          clock.Clock
 	bc             beacon.Client
 	d              *client.Client
 	gasThreshold   float64
+	gasOracle      rpgas.GasOracle
 	maxFee         *big.Int
 	maxPriorityFee *big.Int
 	gasLimit       uint64
@@ -67,6 +141,14 @@ func newStakePrelaunchMinipools(c *cli.Context, logger log.ColorLogger) (*stakeP
 	if err != nil {
 		return nil, err
 	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+	n, err := services.GetNotifier(c)
+	if err != nil {
+		return nil, err
+	}
 	bc, err := services.GetBeaconClient(c)
 	if err != nil {
 		return nil, err
@@ -77,10 +159,10 @@ func newStakePrelaunchMinipools(c *cli.Context, logger log.ColorLogger) (*stakeP
 	}
 
 	// Check if auto-staking is disabled
-	gasThreshold := cfg.Smartnode.MinipoolStakeGasThreshold.Value.(float64)
+	gasThreshold := cfg.Smartnode.GetMinipoolStakeGasThreshold()
 
 	// Get the user-requested max fee
-	maxFeeGwei := cfg.Smartnode.ManualMaxFee.Value.(float64)
+	maxFeeGwei := cfg.Smartnode.GetManualMaxFee()
 	var maxFee *big.Int
 	if maxFeeGwei == 0 {
 		maxFee = nil
@@ -89,7 +171,7 @@ func newStakePrelaunchMinipools(c *cli.Context, logger log.ColorLogger) (*stakeP
 	}
 
 	// Get the user-requested max fee
-	priorityFeeGwei := cfg.Smartnode.PriorityFee.Value.(float64)
+	priorityFeeGwei := cfg.Smartnode.GetPriorityFee()
 	var priorityFee *big.Int
 	if priorityFeeGwei == 0 {
 		logger.Println("WARNING: priority fee was missing or 0, setting a default of 2.")
@@ -105,9 +187,14 @@ func newStakePrelaunchMinipools(c *cli.Context, logger log.ColorLogger) (*stakeP
 		cfg:            cfg,
 		w:              w,
 		rp:             rp,
+		n:              n,
+		journal:        journal.NewJournal(cfg.Smartnode.GetJournalPath(), JournalName),
+		txQueue:        txqueue.NewQueue(os.ExpandEnv(cfg.Smartnode.GetTxQueuePath()), services.GetClock(c)),
+		clock:          services.GetClock(c),
 		bc:             bc,
 		d:              d,
 		gasThreshold:   gasThreshold,
+		gasOracle:      rpgas.NewGasOracle(cfg, ec, nil),
 		maxFee:         maxFee,
 		maxPriorityFee: priorityFee,
 		gasLimit:       0,
@@ -128,26 +215,54 @@ func (t *stakePrelaunchMinipools) run() error {
 		return err
 	}
 
+	// Stake and record the run
+	run := t.journal.StartRun(stakePrelaunchMinipoolsTaskName)
+	return run.Finish(t.stake(run))
+
+}
+
+// Stake the prelaunch minipools that have passed the scrub check
+func (t *stakePrelaunchMinipools) stake(run *journal.Run) error {
+
 	// Log
 	t.log.Println("Checking for minipools to launch...")
 
-	// Get node account
-	nodeAccount, err := t.w.GetNodeAccount()
-	if err != nil {
+	// Get the settings
+	gasThreshold := t.gasThreshold
+	if err := run.Setting("gasThreshold", &gasThreshold); err != nil {
+		return err
+	}
+	gasLimit := t.gasLimit
+	if err := run.Setting("gasLimit", &gasLimit); err != nil {
 		return err
 	}
 
 	// Get prelaunch minipools
-	minipools, err := t.getPrelaunchMinipools(nodeAccount.Address)
+	minipools, err := t.getPrelaunchMinipools(run)
 	if err != nil {
 		return err
 	}
+
+	// Drop queued stake transactions for minipools that no longer need staking
+	if !run.IsReplay() {
+		queueIDs := make([]string, 0, len(minipools))
+		for _, mp := range minipools {
+			queueIDs = append(queueIDs, getStakeQueueID(mp))
+		}
+		if err := t.txQueue.Prune(stakePrelaunchMinipoolsTaskName, queueIDs); err != nil {
+			t.log.Printlnf("WARNING: %s", err.Error())
+		}
+	}
 	if len(minipools) == 0 {
 		return nil
 	}
 
 	// Get eth2 config
-	eth2Config, err := t.bc.GetEth2Config()
+	var eth2Config beacon.Eth2Config
+	err = run.Input("eth2Config", &eth2Config, func() (err error) {
+		eth2Config, err = t.bc.GetEth2Config()
+		return
+	})
 	if err != nil {
 		return err
 	}
@@ -155,95 +270,86 @@ func (t *stakePrelaunchMinipools) run() error {
 	// Log
 	t.log.Printlnf("%d minipool(s) are ready for staking...", len(minipools))
 
-	// Stake minipools
-	successCount := 0
+	// Stake minipools; failed ones are retried from the transaction queue, so they don't hold up the others
+	stakedPubkeys := []rptypes.ValidatorPubkey{}
+	var stakeErr error
 	for _, mp := range minipools {
-		success, err := t.stakeMinipool(mp, eth2Config)
+		validatorKey, err := t.stakeMinipool(run, mp, eth2Config, gasThreshold, gasLimit)
 		if err != nil {
-			t.log.Println(fmt.Errorf("Could not stake minipool %s: %w", mp.Address.Hex(), err))
-			return err
+			stakeErr = fmt.Errorf("Could not stake minipool %s: %w", mp.Address.Hex(), err)
+			t.log.Println(stakeErr)
+			continue
 		}
-		if success {
-			successCount++
+		if validatorKey != nil {
+			stakedPubkeys = append(stakedPubkeys, rptypes.BytesToValidatorPubkey(validatorKey.PublicKey().Marshal()))
 		}
 	}
 
-	// Restart validator process if any minipools were staked successfully
-	if successCount > 0 {
-		if err := t.restartValidator(); err != nil {
+	// Load the new keys into the validator client if any minipools were staked successfully
+	if len(stakedPubkeys) > 0 {
+		if err := t.loadValidatorKeys(run, stakedPubkeys); err != nil {
 			return err
 		}
 	}
 
 	// Return
-	return nil
+	return stakeErr
 
 }
 
 // Get prelaunch minipools
-func (t *stakePrelaunchMinipools) getPrelaunchMinipools(nodeAddress common.Address) ([]*minipool.Minipool, error) {
-
-	// Get node minipool addresses
-	addresses, err := minipool.GetNodeMinipoolAddresses(t.rp, nodeAddress, nil)
+func (t *stakePrelaunchMinipools) getPrelaunchMinipools(run *journal.Run) ([]*minipool.Minipool, error) {
+
+	// Get the node's minipools and their statuses
+	var statuses []minipoolStatus
+	err := run.Input("minipoolStatuses", &statuses, func() (err error) {
+		statuses, err = t.getMinipoolStatuses()
+		return
+	})
 	if err != nil {
 		return []*minipool.Minipool{}, err
 	}
 
-	// Create minipool contracts
-	minipools := make([]*minipool.Minipool, len(addresses))
-	for mi, address := range addresses {
-		mp, err := minipool.NewMinipool(t.rp, address)
-		if err != nil {
-			return []*minipool.Minipool{}, err
-		}
-		minipools[mi] = mp
-	}
-
-	// Data
-	var wg errgroup.Group
-	statuses := make([]minipool.StatusDetails, len(minipools))
-
-	// Load minipool statuses
-	for mi, mp := range minipools {
-		mi, mp := mi, mp
-		wg.Go(func() error {
-			status, err := mp.GetStatusDetails(nil)
-			if err == nil {
-				statuses[mi] = status
-			}
-			return err
-		})
-	}
-
-	// Wait for data
-	if err := wg.Wait(); err != nil {
-		return []*minipool.Minipool{}, err
-	}
-
 	// Get the scrub period
-	scrubPeriodSeconds, err := trustednode.GetScrubPeriod(t.rp, nil)
+	var scrubPeriod time.Duration
+	err = run.Input("scrubPeriod", &scrubPeriod, func() error {
+		scrubPeriodSeconds, err := trustednode.GetScrubPeriod(t.rp, nil)
+		scrubPeriod = time.Duration(scrubPeriodSeconds) * time.Second
+		return err
+	})
 	if err != nil {
 		return []*minipool.Minipool{}, err
 	}
-	scrubPeriod := time.Duration(scrubPeriodSeconds) * time.Second
 
 	// Get the time of the latest block
-	latestEth1Block, err := t.rp.Client.HeaderByNumber(context.Background(), nil)
+	var latestBlockTime time.Time
+	err = run.Input("latestBlockTime", &latestBlockTime, func() error {
+		latestEth1Block, err := t.rp.Client.HeaderByNumber(context.Background(), nil)
+		if err != nil {
+			return fmt.Errorf("Can't get the latest block time: %w", err)
+		}
+		latestBlockTime = time.Unix(int64(latestEth1Block.Time), 0)
+		return nil
+	})
 	if err != nil {
-		return []*minipool.Minipool{}, fmt.Errorf("Can't get the latest block time: %w", err)
+		return []*minipool.Minipool{}, err
 	}
-	latestBlockTime := time.Unix(int64(latestEth1Block.Time), 0)
 
 	// Filter minipools by status
 	prelaunchMinipools := []*minipool.Minipool{}
-	for mi, mp := range minipools {
-		if statuses[mi].Status == rptypes.Prelaunch {
-			creationTime := statuses[mi].StatusTime
+	for _, status := range statuses {
+		if status.Status.Status == rptypes.Prelaunch {
+			creationTime := status.Status.StatusTime
 			remainingTime := creationTime.Add(scrubPeriod).Sub(latestBlockTime)
 			if remainingTime < 0 {
+				mp, err := minipool.NewMinipool(t.rp, status.Address)
+				if err != nil {
+					return []*minipool.Minipool{}, err
+				}
 				prelaunchMinipools = append(prelaunchMinipools, mp)
 			} else {
-				t.log.Printlnf("Minipool %s has %s left until it can be staked.", mp.Address.Hex(), remainingTime)
+				t.log.Printlnf("Minipool %s has %s left until it can be staked.", status.Address.Hex(), remainingTime)
+				run.Decide("Minipool %s has %s left until it can be staked.", status.Address.Hex(), remainingTime)
 			}
 		}
 	}
@@ -253,156 +359,554 @@ func (t *stakePrelaunchMinipools) getPrelaunchMinipools(nodeAddress common.Addre
 
 }
 
-// Stake a minipool
-func (t *stakePrelaunchMinipools) stakeMinipool(mp *minipool.Minipool, eth2Config beacon.Eth2Config) (bool, error) {
+// Get the statuses of the node's minipools
+func (t *stakePrelaunchMinipools) getMinipoolStatuses() ([]minipoolStatus, error) {
+
+	// Get node account
+	nodeAccount, err := t.w.GetNodeAccount()
+	if err != nil {
+		return []minipoolStatus{}, err
+	}
+
+	// Get node minipool addresses
+	addresses, err := minipool.GetNodeMinipoolAddresses(t.rp, nodeAccount.Address, nil)
+	if err != nil {
+		return []minipoolStatus{}, err
+	}
+
+	// Data
+	var wg errgroup.Group
+	statuses := make([]minipoolStatus, len(addresses))
+
+	// Load minipool statuses
+	for mi, address := range addresses {
+		mi, address := mi, address
+		wg.Go(func() error {
+			mp, err := minipool.NewMinipool(t.rp, address)
+			if err != nil {
+				return err
+			}
+			status, err := mp.GetStatusDetails(nil)
+			if err == nil {
+				statuses[mi] = minipoolStatus{
+					Address: address,
+					Status:  status,
+				}
+			}
+			return err
+		})
+	}
+
+	// Wait for data
+	if err := wg.Wait(); err != nil {
+		return []minipoolStatus{}, err
+	}
+	return statuses, nil
+
+}
+
+// Stake a minipool, returning its validator key if it was staked
+func (t *stakePrelaunchMinipools) stakeMinipool(run *journal.Run, mp *minipool.Minipool, eth2Config beacon.Eth2Config, gasThreshold float64, gasLimit uint64) (*eth2types.BLSPrivateKey, error) {
 
 	// Log
 	t.log.Printlnf("Staking minipool %s...", mp.Address.Hex())
+	inputPrefix := mp.Address.Hex()
+
+	// Check for an earlier attempt in the transaction queue
+	queueID := getStakeQueueID(mp)
+	queueDescription := fmt.Sprintf("stake minipool %s", mp.Address.Hex())
+	queued, due, err := checkTxQueue(run, t.txQueue, t.rp.Client, t.clock, t.log, inputPrefix+"/queued", queueID, queueDescription)
+	if err != nil {
+		return nil, err
+	}
+	if !due {
+		return nil, nil
+	}
 
 	// Get minipool withdrawal credentials
-	withdrawalCredentials, err := minipool.GetMinipoolWithdrawalCredentials(t.rp, mp.Address, nil)
+	var withdrawalCredentials common.Hash
+	err = run.Input(inputPrefix+"/withdrawalCredentials", &withdrawalCredentials, func() (err error) {
+		withdrawalCredentials, err = minipool.GetMinipoolWithdrawalCredentials(t.rp, mp.Address, nil)
+		return
+	})
 	if err != nil {
-		return false, err
+		return nil, err
 	}
 
 	// Get the validator key for the minipool
-	validatorPubkey, err := minipool.GetMinipoolPubkey(t.rp, mp.Address, nil)
+	var validatorPubkey rptypes.ValidatorPubkey
+	err = run.Input(inputPrefix+"/validatorPubkey", &validatorPubkey, func() (err error) {
+		validatorPubkey, err = minipool.GetMinipoolPubkey(t.rp, mp.Address, nil)
+		return
+	})
 	if err != nil {
-		return false, err
+		return nil, err
 	}
 	validatorKey, err := t.w.GetValidatorKeyByPubkey(validatorPubkey)
 	if err != nil {
-		return false, err
+		return nil, err
+	}
+
+	// Make sure nothing has tampered with the minipool's deposit before sending the second one
+	var depositSafetyError string
+	err = run.Input(inputPrefix+"/depositSafetyError", &depositSafetyError, func() error {
+		if err := t.verifyDepositSafety(mp, validatorPubkey, withdrawalCredentials, eth2Config); err != nil {
+			depositSafetyError = err.Error()
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if depositSafetyError != "" {
+		t.log.Println("=== UNSAFE MINIPOOL DEPOSIT DETECTED ===")
+		t.log.Printlnf("\tMinipool: %s", mp.Address.Hex())
+		t.log.Printlnf("\tReason: %s", depositSafetyError)
+		t.log.Println("The stake transaction will NOT be submitted for this minipool.")
+		t.log.Println("========================================")
+		run.Decide("Minipool %s failed the deposit safety check (%s), so it won't be staked.", mp.Address.Hex(), depositSafetyError)
+		return nil, nil
 	}
 
 	// Get validator deposit data
 	depositData, depositDataRoot, err := validator.GetDepositData(validatorKey, withdrawalCredentials, eth2Config)
 	if err != nil {
-		return false, err
+		return nil, err
+	}
+	if err := validator.VerifyDepositDataRoot(depositData, depositDataRoot); err != nil {
+		t.log.Printlnf("Minipool %s failed the deposit data validation check (%s), so it won't be staked. PLEASE REPORT THIS TO THE ROCKET POOL DEVELOPERS.", mp.Address.Hex(), err.Error())
+		run.Decide("Minipool %s failed the deposit data validation check (%s), so it won't be staked.", mp.Address.Hex(), err.Error())
+		return nil, nil
 	}
 
 	// Get transactor
 	opts, err := t.w.GetNodeAccountTransactor()
 	if err != nil {
-		return false, err
+		return nil, err
 	}
 
 	// Get the gas limit
 	signature := rptypes.BytesToValidatorSignature(depositData.Signature)
-	gasInfo, err := mp.EstimateStakeGas(signature, depositDataRoot, opts)
+	var gasInfo rocketpool.GasInfo
+	err = run.Input(inputPrefix+"/gasInfo", &gasInfo, func() (err error) {
+		gasInfo, err = mp.EstimateStakeGas(signature, depositDataRoot, opts)
+		return
+	})
 	if err != nil {
-		return false, fmt.Errorf("Could not estimate the gas required to stake the minipool: %w", err)
+		return nil, fmt.Errorf("Could not estimate the gas required to stake the minipool: %w", err)
 	}
 	var gas *big.Int
-	if t.gasLimit != 0 {
-		gas = new(big.Int).SetUint64(t.gasLimit)
+	if gasLimit != 0 {
+		gas = new(big.Int).SetUint64(gasLimit)
 	} else {
 		gas = new(big.Int).SetUint64(gasInfo.SafeGasLimit)
 	}
 
 	// Get the max fee
 	maxFee := t.maxFee
-	if maxFee == nil || maxFee.Uint64() == 0 {
-		maxFee, err = rpgas.GetHeadlessMaxFeeWei()
-		if err != nil {
-			return false, err
+	err = run.Input(inputPrefix+"/maxFee", &maxFee, func() (err error) {
+		if maxFee == nil || maxFee.Uint64() == 0 {
+			maxFee, err = rpgas.GetHeadlessMaxFeeWei(t.gasOracle)
 		}
+		return
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	// Print the gas info
-	if !api.PrintAndCheckGasInfo(gasInfo, true, t.gasThreshold, t.log, maxFee, t.gasLimit) {
+	priorityFee := t.maxPriorityFee
+	if !api.PrintAndCheckGasInfo(gasInfo, true, gasThreshold, t.log, maxFee, gasLimit) {
 		// Check for the timeout buffer
-		prelaunchTime, err := mp.GetStatusTime(nil)
-		if err != nil {
-			t.log.Printlnf("Error checking minipool launch time: %s\nStaking now for safety...", err.Error())
-		}
-		isDue, timeUntilDue, err := api.IsTransactionDue(t.rp, prelaunchTime)
+		var timeout stakeTimeout
+		err = run.Input(inputPrefix+"/timeout", &timeout, func() error {
+			timeout = t.getStakeTimeout(mp, maxFee)
+			return nil
+		})
 		if err != nil {
-			t.log.Printlnf("Error checking if minipool is due: %s\nStaking now for safety...", err.Error())
+			return nil, err
 		}
-		if !isDue {
-			t.log.Printlnf("Time until staking will be forced for safety: %s", timeUntilDue)
-			return false, nil
+		if !timeout.IsDue {
+			t.log.Printlnf("Time until staking will be forced for safety: %s", timeout.TimeUntilDue)
+			setTxQueueWaiting(run, t.txQueue, t.log, queueID, stakePrelaunchMinipoolsTaskName, queueDescription, fmt.Sprintf("The max fee of %.2f gwei is above the threshold of %.2f gwei", eth.WeiToGwei(maxFee), gasThreshold))
+			run.Decide("The max fee of %.2f gwei is not lower than the threshold of %.2f gwei and minipool %s isn't close to its launch timeout, so it wasn't staked.", eth.WeiToGwei(maxFee), gasThreshold, mp.Address.Hex())
+			return nil, nil
 		} else {
 			t.log.Println("NOTICE: The minipool has exceeded half of the timeout period, so it will be force-staked at the current gas price.")
+			run.Decide("Minipool %s is close to its launch timeout, so it will be staked regardless of the gas price.", mp.Address.Hex())
+
+			// Raise the priority fee as the minipool gets closer to being dissolved
+			if timeout.EscalatedPriorityFee != nil && timeout.EscalatedPriorityFee.Cmp(t.maxPriorityFee) > 0 {
+				priorityFee = timeout.EscalatedPriorityFee
+				t.log.Printlnf("Raising the priority fee to %.2f Gwei since the minipool is close to its launch timeout.", eth.WeiToGwei(priorityFee))
+			}
 		}
 	}
 
+	maxFee, priorityFee = getQueuedTxFees(queued, t.log, maxFee, priorityFee, t.maxFee, gasThreshold)
 	opts.GasFeeCap = maxFee
-	opts.GasTipCap = t.maxPriorityFee
+	opts.GasTipCap = priorityFee
 	opts.GasLimit = gas.Uint64()
 
-	// Stake minipool
-	hash, err := mp.Stake(
-		signature,
-		depositDataRoot,
-		opts,
-	)
+	// Check if the node can pay for the transaction itself
+	txCost := new(big.Int).Mul(maxFee, gas)
+	var nodeBalance *big.Int
+	err = run.Input(inputPrefix+"/nodeBalance", &nodeBalance, func() (err error) {
+		nodeBalance, err = t.rp.Client.BalanceAt(context.Background(), opts.From, nil)
+		if err != nil {
+			return fmt.Errorf("Could not get node balance: %w", err)
+		}
+		return nil
+	})
 	if err != nil {
-		return false, err
+		return nil, err
+	}
+	var submit func() (common.Hash, error)
+	if nodeBalance.Cmp(txCost) < 0 && t.cfg.Smartnode.UseTxSponsor.Value == true {
+		t.log.Printlnf("The node only has %.6f ETH but staking may cost up to %.6f ETH, submitting it through the transaction sponsor...", eth.WeiToEth(nodeBalance), eth.WeiToEth(txCost))
+		if !run.Act("Stake minipool %s through the transaction sponsor with a max fee of %.2f gwei.", mp.Address.Hex(), eth.WeiToGwei(maxFee)) {
+			return validatorKey, nil
+		}
+		submit = func() (common.Hash, error) {
+			return t.stakeMinipoolWithSponsor(mp, signature, depositDataRoot, opts)
+		}
+	} else {
+		// Stake minipool
+		if !run.Act("Stake minipool %s with a max fee of %.2f gwei.", mp.Address.Hex(), eth.WeiToGwei(maxFee)) {
+			return validatorKey, nil
+		}
+		submit = func() (common.Hash, error) {
+			return mp.Stake(
+				signature,
+				depositDataRoot,
+				opts,
+			)
+		}
 	}
 
-	// Print TX info and wait for it to be mined
-	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, t.log)
+	// Submit the TX through the queue and wait for it to be mined
+	mined, err := submitQueuedTx(t.txQueue, t.cfg, t.rp, t.log, queued, queueID, stakePrelaunchMinipoolsTaskName, queueDescription, opts, submit)
 	if err != nil {
-		return false, err
+		return nil, err
+	}
+	if !mined {
+		return nil, nil
 	}
 
 	// Log
 	t.log.Printlnf("Successfully staked minipool %s.", mp.Address.Hex())
 
+	// Send a notification
+	if err := t.n.Notify(notifications.EventType_MinipoolStaked, "Minipool staked", fmt.Sprintf("Successfully staked minipool %s.", mp.Address.Hex())); err != nil {
+		t.log.Printlnf("WARNING: %s", err.Error())
+	}
+
 	// Return
-	return true, nil
+	return validatorKey, nil
 
 }
 
-// Restart validator process
-func (t *stakePrelaunchMinipools) restartValidator() error {
+// Get the ID of a minipool's stake transaction in the transaction queue
+func getStakeQueueID(mp *minipool.Minipool) string {
+	return fmt.Sprintf("%s/%s", stakePrelaunchMinipoolsTaskName, mp.Address.Hex())
+}
+
+// Check how close a minipool is to its launch timeout, and the priority fee to use if it's due to be force-staked
+func (t *stakePrelaunchMinipools) getStakeTimeout(mp *minipool.Minipool, maxFee *big.Int) stakeTimeout {
+
+	prelaunchTime, err := mp.GetStatusTime(nil)
+	if err != nil {
+		t.log.Printlnf("Error checking minipool launch time: %s\nStaking now for safety...", err.Error())
+	}
+	isDue, timeUntilDue, err := api.IsTransactionDue(t.rp, prelaunchTime)
+	if err != nil {
+		t.log.Printlnf("Error checking if minipool is due: %s\nStaking now for safety...", err.Error())
+	}
+	timeout := stakeTimeout{
+		IsDue:        isDue,
+		TimeUntilDue: timeUntilDue,
+	}
+	if !isDue {
+		return timeout
+	}
+
+	escalatedFee, err := api.GetEscalatedPriorityFee(t.rp, prelaunchTime, t.maxPriorityFee, maxFee)
+	if err != nil {
+		t.log.Printlnf("Error calculating the escalated priority fee: %s\nUsing the default priority fee...", err.Error())
+	} else {
+		timeout.EscalatedPriorityFee = escalatedFee
+	}
+	return timeout
+
+}
+
+// Sign a minipool stake transaction and submit it through the transaction sponsor, which covers its gas cost
+func (t *stakePrelaunchMinipools) stakeMinipoolWithSponsor(mp *minipool.Minipool, signature rptypes.ValidatorSignature, depositDataRoot common.Hash, opts *bind.TransactOpts) (common.Hash, error) {
+
+	// Only allow this minipool's stake method to be sponsored
+	sponsorClient := sponsor.NewClient(t.cfg.Smartnode.GetTxSponsorUrl(), t.w.GetChainID())
+	sponsorClient.Allow(mp.Address, mp.Contract.ABI.Methods["stake"])
+
+	// Sign the transaction without sending it
+	getSignedTx := sponsorClient.Capture(opts)
+	if _, err := mp.Stake(signature, depositDataRoot, opts); err != nil {
+		return common.Hash{}, err
+	}
+	signedTx := getSignedTx()
+	if signedTx == nil {
+		return common.Hash{}, fmt.Errorf("Could not sign the stake transaction for minipool %s", mp.Address.Hex())
+	}
+
+	// Submit it to the sponsor
+	return sponsorClient.Submit(signedTx, opts.From)
+
+}
+
+// Verify the minipool's on-chain deposit state directly before staking it.
+// This is a defense-in-depth check against deposit contract front-running that doesn't rely on the oDAO scrub check.
+func (t *stakePrelaunchMinipools) verifyDepositSafety(mp *minipool.Minipool, pubkey rptypes.ValidatorPubkey, withdrawalCredentials common.Hash, eth2Config beacon.Eth2Config) error {
+
+	// Make sure the withdrawal credentials point to the minipool itself
+	expectedCreds := common.Hash{}
+	expectedCreds[0] = 0x01
+	copy(expectedCreds[12:], mp.Address.Bytes())
+	if withdrawalCredentials != expectedCreds {
+		return fmt.Errorf("withdrawal credentials %s do not match the minipool address (expected %s)", withdrawalCredentials.Hex(), expectedCreds.Hex())
+	}
+
+	// Make sure the pubkey belongs to this minipool
+	pubkeyMinipool, err := minipool.GetMinipoolByPubkey(t.rp, pubkey, nil)
+	if err != nil {
+		return fmt.Errorf("error getting the minipool for validator %s: %w", pubkey.Hex(), err)
+	}
+	if pubkeyMinipool != mp.Address {
+		return fmt.Errorf("validator %s is registered to minipool %s instead", pubkey.Hex(), pubkeyMinipool.Hex())
+	}
+
+	// Make sure the minipool is still in prelaunch
+	status, err := mp.GetStatusDetails(nil)
+	if err != nil {
+		return fmt.Errorf("error getting minipool status: %w", err)
+	}
+	if status.Status != rptypes.Prelaunch {
+		return fmt.Errorf("minipool is in the %s state instead of prelaunch", rptypes.MinipoolStatuses[status.Status])
+	}
+
+	// Check the withdrawal credentials on the Beacon chain if the deposit has already been processed
+	validatorStatus, err := t.bc.GetValidatorStatus(pubkey, nil)
+	if err != nil {
+		return fmt.Errorf("error getting the Beacon chain status for validator %s: %w", pubkey.Hex(), err)
+	}
+	if validatorStatus.Exists && validatorStatus.WithdrawalCredentials != expectedCreds {
+		return fmt.Errorf("withdrawal credentials on the Beacon chain are %s (expected %s)", validatorStatus.WithdrawalCredentials.Hex(), expectedCreds.Hex())
+	}
+
+	// Check the deposits that the Beacon chain may not have processed yet; the first valid one determines the credentials
+	eventLogInterval, err := api.GetEventLogInterval(t.cfg)
+	if err != nil {
+		return err
+	}
+	depositDomain, err := eth2Config.GetDepositDomain()
+	if err != nil {
+		return fmt.Errorf("error computing the deposit domain: %w", err)
+	}
+	startBlock := big.NewInt(0)
+	if status.StatusBlock > DepositScanBlockOffset {
+		startBlock.SetUint64(status.StatusBlock - DepositScanBlockOffset)
+	}
+	depositMap, err := utils.GetDeposits(t.rp, map[rptypes.ValidatorPubkey]bool{pubkey: true}, startBlock, eventLogInterval, nil)
+	if err != nil {
+		return fmt.Errorf("error getting deposits for validator %s: %w", pubkey.Hex(), err)
+	}
+	for _, deposit := range depositMap[pubkey] {
+		depositData := new(ethpb.Deposit_Data)
+		depositData.Amount = deposit.Amount
+		depositData.PublicKey = deposit.Pubkey.Bytes()
+		depositData.WithdrawalCredentials = deposit.WithdrawalCredentials.Bytes()
+		depositData.Signature = deposit.Signature.Bytes()
+		if err := prdeposit.VerifyDepositSignature(depositData, depositDomain); err != nil {
+			// Invalid deposits are ignored by the Beacon chain
+			continue
+		}
+		if deposit.WithdrawalCredentials != expectedCreds {
+			return fmt.Errorf("deposit in TX %s uses withdrawal credentials %s (expected %s)", deposit.TxHash.Hex(), deposit.WithdrawalCredentials.Hex(), expectedCreds.Hex())
+		}
+		break
+	}
+
+	return nil
+
+}
+
+// Load new validator keys into the validator client, through its Keymanager API if that's enabled or else by restarting it
+func (t *stakePrelaunchMinipools) loadValidatorKeys(run *journal.Run, pubkeys []rptypes.ValidatorPubkey) error {
+
+	// Restart the validator client if the keys can't be loaded while it's running
+	if !t.cfg.IsHotKeyLoadingEnabled() {
+		if !run.Act("Restart the validator client.") {
+			return nil
+		}
+		return t.restartValidator(pubkeys)
+	}
+	if !run.Act("Load %d new validator key(s) into the validator client through its Keymanager API.", len(pubkeys)) {
+		return nil
+	}
+
+	// Import the keys, and fall back to a restart if that doesn't work
+	t.log.Printlnf("Loading %d new validator key(s) into the validator client...", len(pubkeys))
+	if err := t.importStoredKeystores(pubkeys); err != nil {
+		t.log.Printlnf("WARNING: Could not load the new validator keys through the Keymanager API, so the validator client will be restarted instead: %s", err.Error())
+		return t.restartValidator(pubkeys)
+	}
+	t.log.Println("Successfully loaded the new validator keys")
+	return nil
+
+}
+
+// Import the keystores the Smartnode stored for new validator keys into the validator client through its Keymanager API.
+// The validator client keeps its own copy of each imported key, so the Smartnode's copy is removed once the import succeeds.
+func (t *stakePrelaunchMinipools) importStoredKeystores(pubkeys []rptypes.ValidatorPubkey) error {
+
+	// Get the client the keys were stored for
+	var client config.ConsensusClient
+	if t.cfg.IsNativeMode {
+		client, _ = t.cfg.Native.ConsensusClient.Value.(config.ConsensusClient)
+	} else {
+		client = t.cfg.GetSelectedConsensusClient()
+	}
+
+	// Read the stored keystores
+	keychainPath := t.cfg.Smartnode.GetValidatorKeychainPath()
+	keystores := []keymanager.StoredKeystore{}
+	keyFilePaths := []string{}
+	secretFilePaths := []string{}
+	for _, pubkey := range pubkeys {
+		var keyFilePath, secretFilePath string
+		switch client {
+		case config.ConsensusClient_Lighthouse:
+			keyFilePath, secretFilePath = lighthouse.GetValidatorKeyPaths(keychainPath, pubkey)
+		case config.ConsensusClient_Teku:
+			keyFilePath, secretFilePath = teku.GetValidatorKeyPaths(keychainPath, pubkey)
+		default:
+			return fmt.Errorf("Keys can't be loaded into %s through the Keymanager API", client)
+		}
+		keystoreBytes, err := ioutil.ReadFile(keyFilePath)
+		if err != nil {
+			return fmt.Errorf("Could not read the keystore for validator key %s: %w", pubkey.Hex(), err)
+		}
+		password, err := ioutil.ReadFile(secretFilePath)
+		if err != nil {
+			return fmt.Errorf("Could not read the password for validator key %s: %w", pubkey.Hex(), err)
+		}
+		keystores = append(keystores, keymanager.StoredKeystore{
+			Pubkey:   pubkey,
+			Keystore: string(keystoreBytes),
+			Password: string(password),
+		})
+		keyFilePaths = append(keyFilePaths, keyFilePath)
+		secretFilePaths = append(secretFilePaths, secretFilePath)
+	}
+
+	// Lighthouse writes imported keys into the same folders the Smartnode stores them in, so move the stored ones aside during the import
+	if client == config.ConsensusClient_Lighthouse {
+		for i, keyFilePath := range keyFilePaths {
+			keyDir := filepath.Dir(keyFilePath)
+			if err := os.Rename(keyDir, keyDir+importBackupSuffix); err != nil {
+				for _, movedFilePath := range keyFilePaths[:i] {
+					movedDir := filepath.Dir(movedFilePath)
+					_ = os.Rename(movedDir+importBackupSuffix, movedDir)
+				}
+				return fmt.Errorf("Could not move the stored keystore for validator key %s aside: %w", pubkeys[i].Hex(), err)
+			}
+		}
+	}
+
+	// Import the keys
+	km, err := keymanager.NewClient(t.cfg.Smartnode.GetKeymanagerApiUrl(), t.cfg.Smartnode.GetKeymanagerTokenPath(), t.cfg.Smartnode.GetKeymanagerCertPath())
+	var statuses []keymanager.KeyStatus
+	if err == nil {
+		statuses, err = km.LoadKeystores(keystores)
+	}
+
+	// Clean up the Smartnode's copies of the keys the validator client imported, and keep the rest where they were
+	for i := range keystores {
+		imported := (statuses != nil && statuses[i].Status == keymanager.ImportStatusImported)
+		switch client {
+		case config.ConsensusClient_Lighthouse:
+			keyDir := filepath.Dir(keyFilePaths[i])
+			if imported {
+				_ = os.RemoveAll(keyDir + importBackupSuffix)
+				continue
+			}
+			if restoreErr := os.Rename(keyDir+importBackupSuffix, keyDir); restoreErr != nil {
+				t.log.Printlnf("WARNING: Could not restore the stored keystore for validator key %s from %s: %s", pubkeys[i].Hex(), keyDir+importBackupSuffix, restoreErr.Error())
+			}
+		case config.ConsensusClient_Teku:
+			if imported {
+				_ = os.Remove(keyFilePaths[i])
+				_ = os.Remove(secretFilePaths[i])
+			}
+		}
+	}
+	return err
+
+}
+
+// Restart the validator process so it loads the provided new keys
+func (t *stakePrelaunchMinipools) restartValidator(pubkeys []rptypes.ValidatorPubkey) error {
 
 	// Restart validator container
 	if isInsideContainer() {
 
-		// Get validator container name & client type label
-		var containerName string
+		// Get validator container names & client type label; only the Validator client shards that have the new keys are restarted
+		var containerNames []string
 		var clientTypeLabel string
 		if t.cfg.Smartnode.ProjectName.Value == "" {
 			return errors.New("Rocket Pool docker project name not set")
 		}
 		switch clientType := t.bc.GetClientType(); clientType {
 		case beacon.SplitProcess:
-			containerName = t.cfg.Smartnode.ProjectName.Value.(string) + ValidatorContainerSuffix
+			names, err := getValidatorContainerNamesForKeys(t.cfg, t.w.GetShardAssignments(), pubkeys)
+			if err != nil {
+				return err
+			}
+			containerNames = names
 			clientTypeLabel = "validator"
 		case beacon.SingleProcess:
-			containerName = t.cfg.Smartnode.ProjectName.Value.(string) + BeaconContainerSuffix
+			containerNames = []string{t.cfg.Smartnode.GetProjectName() + BeaconContainerSuffix}
 			clientTypeLabel = "beacon"
 		default:
 			return fmt.Errorf("Can't restart the validator, unknown client type '%d'", clientType)
 		}
 
-		// Log
-		t.log.Printlnf("Restarting %s container (%s)...", clientTypeLabel, containerName)
-
 		// Get all containers
 		containers, err := t.d.ContainerList(context.Background(), types.ContainerListOptions{All: true})
 		if err != nil {
 			return fmt.Errorf("Could not get docker containers: %w", err)
 		}
 
-		// Get validator container ID
-		var validatorContainerId string
-		for _, container := range containers {
-			if container.Names[0] == "/"+containerName {
-				validatorContainerId = container.ID
-				break
+		for _, containerName := range containerNames {
+
+			// Log
+			t.log.Printlnf("Restarting %s container (%s)...", clientTypeLabel, containerName)
+
+			// Get validator container ID
+			var validatorContainerId string
+			for _, container := range containers {
+				if container.Names[0] == "/"+containerName {
+					validatorContainerId = container.ID
+					break
+				}
+			}
+			if validatorContainerId == "" {
+				return fmt.Errorf("Validator container %s not found", containerName)
+			}
+
+			// Restart validator container
+			if err := t.d.ContainerRestart(context.Background(), validatorContainerId, &validatorRestartTimeout); err != nil {
+				return fmt.Errorf("Could not restart validator container %s: %w", containerName, err)
 			}
-		}
-		if validatorContainerId == "" {
-			return errors.New("Validator container not found")
-		}
 
-		// Restart validator container
-		if err := t.d.ContainerRestart(context.Background(), validatorContainerId, &validatorRestartTimeout); err != nil {
-			return fmt.Errorf("Could not restart validator container: %w", err)
 		}
 
 		// Restart external validator process
@@ -0,0 +1,65 @@
+package node
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+)
+
+func TestValidatorContainerNamesForKeys(t *testing.T) {
+	cfg := config.NewRocketPoolConfig("/home/node/.rocketpool", false)
+	cfg.Smartnode.ValidatorClientShards.Value = uint64(3)
+	assignments := wallet.NewShardAssignments(filepath.Join(t.TempDir(), "assignments.json"), 3)
+
+	// Each new key goes to the emptiest shard
+	pubkeys := []rptypes.ValidatorPubkey{{1}, {2}, {3}}
+	for i, pubkey := range pubkeys {
+		shard, err := assignments.Assign(pubkey, uint(i))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if shard != uint64(i) {
+			t.Fatalf("expected key %d to be assigned to shard %d, got %d", i, i, shard)
+		}
+	}
+
+	tests := map[string]struct {
+		assignments *wallet.ShardAssignments
+		pubkeys     []rptypes.ValidatorPubkey
+		expected    []string
+	}{
+		"one shard": {
+			assignments: assignments,
+			pubkeys:     []rptypes.ValidatorPubkey{pubkeys[1]},
+			expected:    []string{"rocketpool_validator_shard1"},
+		},
+		"several shards": {
+			assignments: assignments,
+			pubkeys:     []rptypes.ValidatorPubkey{pubkeys[2], pubkeys[0], pubkeys[2]},
+			expected:    []string{"rocketpool_validator", "rocketpool_validator_shard2"},
+		},
+		"unassigned key": {
+			assignments: assignments,
+			pubkeys:     []rptypes.ValidatorPubkey{{4}},
+			expected:    []string{"rocketpool_validator"},
+		},
+		"no sharding": {
+			assignments: nil,
+			pubkeys:     []rptypes.ValidatorPubkey{pubkeys[1]},
+			expected:    []string{"rocketpool_validator", "rocketpool_validator_shard1", "rocketpool_validator_shard2"},
+		},
+	}
+	for name, test := range tests {
+		names, err := getValidatorContainerNamesForKeys(cfg, test.assignments, test.pubkeys)
+		if err != nil {
+			t.Errorf("%s: %s", name, err.Error())
+		} else if !reflect.DeepEqual(names, test.expected) {
+			t.Errorf("%s: expected %v, got %v", name, test.expected, names)
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+
+	"github.com/rocket-pool/smartnode/shared/services/clock"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/journal"
+	"github.com/rocket-pool/smartnode/shared/services/txqueue"
+	apitypes "github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/api"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// How long a task waits for one of its transactions to be mined before leaving it in the queue and moving on
+var queuedTxWaitTimeout, _ = time.ParseDuration("10m")
+
+// Get a task's earlier attempt at a transaction from the queue, and check whether it's due to be attempted again.
+// Returns false if the task should leave the transaction alone for now.
+func checkTxQueue(run *journal.Run, q *txqueue.Queue, ec rocketpool.ExecutionClient, clk clock.Clock, logger log.ColorLogger, inputName string, id string, description string) (*apitypes.TxQueueEntry, bool, error) {
+
+	var queued *apitypes.TxQueueEntry
+	err := run.Input(inputName, &queued, func() (err error) {
+		queued, err = q.Refresh(id, ec)
+		return
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	if queued == nil || queued.State == apitypes.TxQueueState_Mined {
+		// Mined transactions are only kept for the history, so this is a new attempt
+		return nil, true, nil
+	}
+
+	switch queued.State {
+	case apitypes.TxQueueState_Submitted, apitypes.TxQueueState_Retrying:
+		if clk.Now().Before(queued.NextAttempt) {
+			logger.Printlnf("The transaction to %s will be retried in %s (attempt %d so far).", description, clk.Until(queued.NextAttempt).Round(time.Second), queued.Attempts)
+			run.Decide("The transaction to %s is %s and isn't due to be retried yet.", description, queued.State)
+			return queued, false, nil
+		}
+		if queued.State == apitypes.TxQueueState_Submitted {
+			logger.Printlnf("The transaction to %s (%s) still hasn't been mined, replacing it with higher fees...", description, queued.Hash.Hex())
+		} else {
+			logger.Printlnf("Retrying the transaction to %s after %d failed attempt(s); the last error was: %s", description, queued.Attempts, queued.LastError)
+		}
+	}
+	return queued, true, nil
+
+}
+
+// Get the fees for an attempt at a queued transaction, escalating them if earlier attempts failed or got stuck.
+// Fees are escalated up to the manual max fee if one is set, or the task's gas threshold otherwise.
+func getQueuedTxFees(queued *apitypes.TxQueueEntry, logger log.ColorLogger, maxFee *big.Int, priorityFee *big.Int, manualMaxFee *big.Int, gasThreshold float64) (*big.Int, *big.Int) {
+
+	maxFeeCap := manualMaxFee
+	if maxFeeCap == nil || maxFeeCap.Sign() == 0 {
+		maxFeeCap = eth.GweiToWei(gasThreshold)
+	}
+	newMaxFee, newPriorityFee := txqueue.GetRetryFees(queued, maxFee, priorityFee, maxFeeCap)
+	if newMaxFee.Cmp(maxFee) != 0 || newPriorityFee.Cmp(priorityFee) != 0 {
+		logger.Printlnf("Escalating the fees for this attempt to a max fee of %.2f Gwei and a priority fee of %.2f Gwei.", eth.WeiToGwei(newMaxFee), eth.WeiToGwei(newPriorityFee))
+	}
+	return newMaxFee, newPriorityFee
+
+}
+
+// Record that a task is holding off on a transaction, unless the run is a replay
+func setTxQueueWaiting(run *journal.Run, q *txqueue.Queue, logger log.ColorLogger, id string, task string, description string, reason string) {
+	if run.IsReplay() {
+		return
+	}
+	if err := q.SetWaiting(id, task, description, reason); err != nil {
+		logger.Printlnf("WARNING: %s", err.Error())
+	}
+}
+
+// Submit a task's transaction and wait a while for it to be mined, recording it in the queue so it's retried if it fails or gets stuck.
+// Replacements for a stuck transaction reuse its nonce. Returns true if the transaction was mined.
+func submitQueuedTx(q *txqueue.Queue, cfg *config.RocketPoolConfig, rp *rocketpool.RocketPool, logger log.ColorLogger, queued *apitypes.TxQueueEntry, id string, task string, description string, opts *bind.TransactOpts, submit func() (common.Hash, error)) (bool, error) {
+
+	// Get the nonce
+	isReplacement := queued != nil && queued.State == apitypes.TxQueueState_Submitted
+	if isReplacement {
+		opts.Nonce = new(big.Int).SetUint64(queued.Nonce)
+	} else {
+		nonce, err := rp.Client.PendingNonceAt(context.Background(), opts.From)
+		if err != nil {
+			return false, fmt.Errorf("Could not get the node account's nonce: %w", err)
+		}
+		opts.Nonce = new(big.Int).SetUint64(nonce)
+	}
+
+	// Submit the transaction
+	hash, err := submit()
+	if err != nil {
+		var queueErr error
+		if isReplacement {
+			queueErr = q.SetReplacementFailed(id, task, description, err)
+		} else {
+			queueErr = q.SetFailed(id, task, description, err)
+		}
+		if queueErr != nil {
+			logger.Printlnf("WARNING: %s", queueErr.Error())
+		}
+		return false, err
+	}
+	if err := q.SetSubmitted(id, task, description, hash, opts.Nonce.Uint64(), opts.GasFeeCap, opts.GasTipCap); err != nil {
+		logger.Printlnf("WARNING: %s", err.Error())
+	}
+
+	// Wait for it to be mined
+	mined, err := api.PrintAndWaitForTransactionWithTimeout(cfg, hash, rp.Client, logger, queuedTxWaitTimeout)
+	if err != nil {
+		if queueErr := q.SetFailed(id, task, description, err); queueErr != nil {
+			logger.Printlnf("WARNING: %s", queueErr.Error())
+		}
+		return false, err
+	}
+	if !mined {
+		logger.Printlnf("The transaction to %s will be checked again on the next run, and replaced with higher fees if it's still pending.", description)
+		return false, nil
+	}
+	if err := q.SetMined(id); err != nil {
+		logger.Printlnf("WARNING: %s", err.Error())
+	}
+	return true, nil
+
+}
@@ -0,0 +1,57 @@
+package node
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/fatih/color"
+
+	"github.com/rocket-pool/smartnode/shared/services/clock"
+	"github.com/rocket-pool/smartnode/shared/services/journal"
+	"github.com/rocket-pool/smartnode/shared/services/txqueue"
+	apitypes "github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+func TestCheckTxQueueRetriesOnTheClock(t *testing.T) {
+	folder := t.TempDir()
+	clk := clock.NewManualClock(time.Unix(1000000, 0))
+	q := txqueue.NewQueue(filepath.Join(folder, "tx-queue.json"), clk)
+	j := journal.NewJournal(folder, "test")
+	logger := log.NewColorLogger(color.FgGreen)
+	check := func() (*apitypes.TxQueueEntry, bool) {
+		t.Helper()
+		queued, due, err := checkTxQueue(j.StartRun("test"), q, nil, clk, logger, "queued", "test-tx", "test")
+		if err != nil {
+			t.Fatal(err)
+		}
+		return queued, due
+	}
+
+	// A transaction that isn't queued yet is a new attempt
+	if queued, due := check(); queued != nil || !due {
+		t.Fatalf("expected a new attempt for an unqueued transaction, got %v (due: %t)", queued, due)
+	}
+
+	// A failed attempt is only retried once its retry delay has passed on the clock
+	if err := q.SetFailed("test-tx", "test", "test", errors.New("failed")); err != nil {
+		t.Fatal(err)
+	}
+	queued, due := check()
+	if queued == nil || due {
+		t.Fatalf("expected the failed transaction to wait, got %v (due: %t)", queued, due)
+	}
+	if !queued.NextAttempt.Equal(clk.Now().Add(5 * time.Minute)) {
+		t.Fatalf("expected the next attempt at %s, got %s", clk.Now().Add(5*time.Minute), queued.NextAttempt)
+	}
+	clk.Advance(5*time.Minute - time.Second)
+	if _, due := check(); due {
+		t.Fatal("expected the failed transaction to wait until its next attempt")
+	}
+	clk.Advance(time.Second)
+	if queued, due := check(); queued == nil || !due || queued.State != apitypes.TxQueueState_Retrying {
+		t.Fatalf("expected the failed transaction to be retried, got %v (due: %t)", queued, due)
+	}
+}
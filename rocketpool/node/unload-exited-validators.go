@@ -0,0 +1,117 @@
+package node
+
+import (
+	"fmt"
+	"time"
+
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/clock"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/keymanager"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Settings
+const unloadExitedValidatorsInterval = time.Hour
+
+// Unload exited validators task
+type unloadExitedValidators struct {
+	c         *cli.Context
+	log       log.ColorLogger
+	cfg       *config.RocketPoolConfig
+	bc        beacon.Client
+	clock     clock.Clock
+	lastCheck time.Time
+}
+
+// Create unload exited validators task
+func newUnloadExitedValidators(c *cli.Context, logger log.ColorLogger) (*unloadExitedValidators, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	return &unloadExitedValidators{
+		c:     c,
+		log:   logger,
+		cfg:   cfg,
+		bc:    bc,
+		clock: services.GetClock(c),
+	}, nil
+
+}
+
+// Remove the keys of validators that have exited from the validator client through its Keymanager API.
+// Keys are only removed once the exit has been processed, since the validator still has duties until then.
+func (t *unloadExitedValidators) run() error {
+
+	// Check if the task is enabled and due
+	if !t.cfg.IsHotKeyLoadingEnabled() || t.clock.Since(t.lastCheck) < unloadExitedValidatorsInterval {
+		return nil
+	}
+	t.lastCheck = t.clock.Now()
+
+	// Get the keys loaded by the validator client
+	km, err := keymanager.NewClient(t.cfg.Smartnode.GetKeymanagerApiUrl(), t.cfg.Smartnode.GetKeymanagerTokenPath(), t.cfg.Smartnode.GetKeymanagerCertPath())
+	if err != nil {
+		return err
+	}
+	keystores, err := km.ListKeystores()
+	if err != nil {
+		return err
+	}
+	pubkeys := []rptypes.ValidatorPubkey{}
+	for _, keystore := range keystores {
+		if !keystore.ReadOnly {
+			pubkeys = append(pubkeys, keystore.Pubkey)
+		}
+	}
+	if len(pubkeys) == 0 {
+		return nil
+	}
+
+	// Wait for the Beacon client to sync
+	if err := services.WaitBeaconClientSynced(t.c, true); err != nil {
+		return err
+	}
+
+	// Get the ones that have exited
+	head, err := t.bc.GetBeaconHead()
+	if err != nil {
+		return err
+	}
+	statuses, err := t.bc.GetValidatorStatuses(pubkeys, nil)
+	if err != nil {
+		return err
+	}
+	exited := []rptypes.ValidatorPubkey{}
+	for _, pubkey := range pubkeys {
+		status, ok := statuses[pubkey]
+		if ok && status.Exists && status.ExitEpoch <= head.Epoch {
+			exited = append(exited, pubkey)
+		}
+	}
+	if len(exited) == 0 {
+		return nil
+	}
+
+	// Remove them
+	t.log.Printlnf("%d validator(s) have exited, removing their keys from the validator client...", len(exited))
+	if err := km.UnloadKeys(exited); err != nil {
+		return fmt.Errorf("Could not remove the keys of exited validators: %w", err)
+	}
+	t.log.Println("Successfully removed the keys of the exited validators.")
+	return nil
+
+}
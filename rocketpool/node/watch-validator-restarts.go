@@ -0,0 +1,242 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	dockerevents "github.com/docker/docker/api/types/events"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/client"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/clock"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/events"
+	"github.com/rocket-pool/smartnode/shared/services/notifications"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Settings
+const (
+	// The number of log lines saved when the Validator client is stopped
+	validatorCrashLogLines = 200
+
+	// The number of those lines included in the notification
+	validatorCrashNotificationLines = 10
+)
+
+// The ways the Validator client's container can exit
+type validatorExitKind int
+
+const (
+	validatorExit_Stopped validatorExitKind = iota
+	validatorExit_OomKilled
+	validatorExit_Crashed
+)
+
+// Crashes within this window count towards the restart limit
+var validatorCrashWindow, _ = time.ParseDuration("30m")
+
+// How long to wait before watching Docker's events again after the stream fails
+var validatorWatchRetryDelay, _ = time.ParseDuration("1m")
+
+var validatorStopTimeout, _ = time.ParseDuration("30s")
+
+// Watches the Validator client's container for crashes, and stops it if it's crash-looping
+type validatorWatchdog struct {
+	log       log.ColorLogger
+	cfg       *config.RocketPoolConfig
+	d         *client.Client
+	n         *notifications.Notifier
+	clock     clock.Clock
+	container string
+	limit     uint64
+	crashes   []time.Time
+}
+
+// Create the Validator client watchdog
+func newValidatorWatchdog(c *cli.Context, logger log.ColorLogger) (*validatorWatchdog, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	d, err := services.GetDocker(c)
+	if err != nil {
+		return nil, err
+	}
+	n, err := services.GetNotifier(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return the watchdog
+	return &validatorWatchdog{
+		log:       logger,
+		cfg:       cfg,
+		d:         d,
+		n:         n,
+		clock:     services.GetClock(c),
+		container: cfg.Smartnode.GetProjectName() + ValidatorContainerSuffix,
+		limit:     cfg.Smartnode.ValidatorRestartLimit.GetUintOrDefault(config.Network_All),
+	}, nil
+
+}
+
+// Watch Docker's events for the Validator client's container dying; this doesn't return unless the watchdog is disabled
+func (w *validatorWatchdog) run() error {
+
+	// Native mode doesn't run the Validator client in Docker
+	if w.cfg.IsNativeMode || w.limit == 0 {
+		return nil
+	}
+
+	for {
+		messages, errs := w.d.Events(context.Background(), types.EventsOptions{
+			Filters: filters.NewArgs(
+				filters.Arg("type", "container"),
+				filters.Arg("container", w.container),
+				filters.Arg("event", "die"),
+			),
+		})
+		err := w.watch(messages, errs)
+		w.log.Printlnf("WARNING: Stopped watching the Validator client for crashes (%s), trying again in %s...", err.Error(), validatorWatchRetryDelay)
+		w.clock.Sleep(validatorWatchRetryDelay)
+	}
+
+}
+
+// Handle the events from Docker until the stream fails
+func (w *validatorWatchdog) watch(messages <-chan dockerevents.Message, errs <-chan error) error {
+	for {
+		select {
+		case message := <-messages:
+			w.handleCrash(message)
+		case err := <-errs:
+			if err == nil {
+				return fmt.Errorf("the event stream closed")
+			}
+			return err
+		}
+	}
+}
+
+// Get how the Validator client's container exited. Stopping it exits it cleanly or with SIGTERM (143), or with SIGKILL (137) if it doesn't
+// stop in time, so those are normal stops unless the kernel killed it for running out of memory; every other exit code is a crash.
+func getValidatorExitKind(exitCode string, oomKilled bool) validatorExitKind {
+	if oomKilled {
+		return validatorExit_OomKilled
+	}
+	switch exitCode {
+	case "0", "137", "143":
+		return validatorExit_Stopped
+	}
+	return validatorExit_Crashed
+}
+
+// Check if the Validator client's container was killed for running out of memory
+func (w *validatorWatchdog) isOomKilled() bool {
+	container, err := w.d.ContainerInspect(context.Background(), w.container)
+	if err != nil {
+		w.log.Printlnf("WARNING: Could not check if the Validator client ran out of memory: %s", err.Error())
+		return false
+	}
+	return container.State != nil && container.State.OOMKilled
+}
+
+// Record a crash, and stop the Validator client if it's crashed too many times recently
+func (w *validatorWatchdog) handleCrash(event dockerevents.Message) {
+
+	// Ignore normal stops, and report running out of memory separately from crashes
+	exitCode := event.Actor.Attributes["exitCode"]
+	oomKilled := false
+	if exitCode == "137" {
+		oomKilled = w.isOomKilled()
+	}
+	switch getValidatorExitKind(exitCode, oomKilled) {
+	case validatorExit_Stopped:
+		return
+	case validatorExit_OomKilled:
+		w.log.Println("WARNING: The Validator client was killed because it ran out of memory.")
+		message := "Your Validator client was killed because it ran out of memory, and Docker has restarted it. Free up memory on your machine or lower the memory used by your clients in `rocketpool service config`; the node daemon will stop it if this keeps happening."
+		if err := w.n.Notify(notifications.EventType_ValidatorOomKilled, "Validator client out of memory", message); err != nil {
+			w.log.Println(err)
+		}
+	}
+
+	// Only keep the recent crashes
+	now := w.clock.Now()
+	recent := []time.Time{}
+	for _, crash := range w.crashes {
+		if now.Sub(crash) < validatorCrashWindow {
+			recent = append(recent, crash)
+		}
+	}
+	w.crashes = append(recent, now)
+	w.log.Printlnf("WARNING: The Validator client exited with code %s (%d crash(es) in the last %s).", exitCode, len(w.crashes), validatorCrashWindow)
+	if uint64(len(w.crashes)) < w.limit {
+		return
+	}
+
+	// Save the logs before stopping the client
+	logs, err := w.saveLogs()
+	if err != nil {
+		w.log.Printlnf("WARNING: %s", err.Error())
+	}
+
+	// Stop the client so Docker doesn't keep restarting it
+	w.log.Printlnf("The Validator client has crashed %d times in the last %s, stopping it...", len(w.crashes), validatorCrashWindow)
+	if err := w.d.ContainerStop(context.Background(), w.container, &validatorStopTimeout); err != nil {
+		w.log.Println(fmt.Errorf("Could not stop the Validator client: %w", err))
+	} else {
+		w.log.Println("The Validator client has been stopped. Run `rocketpool service start` once you've fixed the problem to start it again.")
+	}
+	w.crashes = []time.Time{}
+
+	// Report it
+	message := fmt.Sprintf("Your Validator client crashed %d times in %s, so the node daemon has stopped it to avoid missing attestations to Doppelganger Protection every time it restarts. Its last logs were saved to %s; run `rocketpool service start` once you've fixed the problem.", w.limit, validatorCrashWindow, w.cfg.Smartnode.GetValidatorCrashLogPath())
+	if logs != "" {
+		message += "\n\n" + logs
+	}
+	events.Publish(events.EventType_Error, "", "The Validator client was stopped after crashing repeatedly")
+	if err := w.n.Notify(notifications.EventType_ValidatorCrashLoop, "Validator client crash-looping", message); err != nil {
+		w.log.Println(err)
+	}
+
+}
+
+// Save the Validator client's last logs to a file, returning the last few lines for the notification
+func (w *validatorWatchdog) saveLogs() (string, error) {
+	reader, err := w.d.ContainerLogs(context.Background(), w.container, types.ContainerLogsOptions{
+		ShowStdout: true,
+		ShowStderr: true,
+		Timestamps: true,
+		Tail:       fmt.Sprint(validatorCrashLogLines),
+	})
+	if err != nil {
+		return "", fmt.Errorf("Could not get the logs of the Validator client: %w", err)
+	}
+	defer reader.Close()
+	logs, err := readContainerLogs(reader)
+	if err != nil {
+		return "", fmt.Errorf("Could not read the logs of the Validator client: %w", err)
+	}
+
+	path := os.ExpandEnv(w.cfg.Smartnode.GetValidatorCrashLogPath())
+	if err := ioutil.WriteFile(path, logs, 0600); err != nil {
+		return "", fmt.Errorf("Could not save the logs of the Validator client to %s: %w", path, err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(string(logs)), "\n")
+	if len(lines) > validatorCrashNotificationLines {
+		lines = lines[len(lines)-validatorCrashNotificationLines:]
+	}
+	return strings.Join(lines, "\n"), nil
+}
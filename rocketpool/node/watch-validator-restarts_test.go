@@ -0,0 +1,25 @@
+package node
+
+import "testing"
+
+func TestValidatorExitKinds(t *testing.T) {
+	tests := []struct {
+		exitCode  string
+		oomKilled bool
+		expected  validatorExitKind
+	}{
+		{exitCode: "0", expected: validatorExit_Stopped},
+		{exitCode: "143", expected: validatorExit_Stopped},
+		{exitCode: "137", expected: validatorExit_Stopped},
+		{exitCode: "137", oomKilled: true, expected: validatorExit_OomKilled},
+		{exitCode: "1", expected: validatorExit_Crashed},
+		{exitCode: "2", expected: validatorExit_Crashed},
+		{exitCode: "139", expected: validatorExit_Crashed},
+		{exitCode: "", expected: validatorExit_Crashed},
+	}
+	for _, test := range tests {
+		if kind := getValidatorExitKind(test.exitCode, test.oomKilled); kind != test.expected {
+			t.Errorf("exit code %q (OOM killed: %t): expected %d, got %d", test.exitCode, test.oomKilled, test.expected, kind)
+		}
+	}
+}
@@ -0,0 +1,394 @@
+package rest
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/health"
+	apitypes "github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Config
+const (
+	RestColor  = color.FgHiCyan
+	ErrorColor = color.FgRed
+
+	// The address the REST API listens on; access to it is controlled by the port mapping
+	listenAddress string = "0.0.0.0"
+)
+
+// Routes that reveal or take the wallet's secrets, change the node account, or sign validator exits, which are never served over HTTP
+var blockedRoutes = map[string]bool{
+	"wallet/set-password":            true,
+	"wallet/init":                    true,
+	"wallet/recover":                 true,
+	"wallet/search-and-recover":      true,
+	"wallet/rebuild":                 true,
+	"wallet/test-recovery":           true,
+	"wallet/test-search-and-recover": true,
+	"wallet/export":                  true,
+	"wallet/set-hardware-account":    true,
+	"wallet/clear-hardware-account":  true,
+	"minipool/exit":                  true,
+	"minipool/schedule-exit":         true,
+	"minipool/get-signed-exits":      true,
+}
+
+// Routes that only read the node's state, which are served by default.
+// Every other route sends transactions or changes the node's files, so it's only served over POST when write routes are allowed.
+var readOnlyRoutes = map[string]bool{
+	"auction/status":                                 true,
+	"auction/lots":                                   true,
+	"auction/can-create-lot":                         true,
+	"auction/can-bid-lot":                            true,
+	"auction/can-claim-lot":                          true,
+	"auction/can-recover-lot":                        true,
+	"debug/export-validators":                        true,
+	"faucet/status":                                  true,
+	"faucet/can-withdraw-rpl":                        true,
+	"minipool/status":                                true,
+	"minipool/can-stake":                             true,
+	"minipool/can-refund":                            true,
+	"minipool/can-dissolve":                          true,
+	"minipool/can-exit":                              true,
+	"minipool/can-begin-reduce-bond":                 true,
+	"minipool/can-reduce-bond":                       true,
+	"minipool/can-close":                             true,
+	"minipool/can-finalize":                          true,
+	"minipool/can-delegate-upgrade":                  true,
+	"minipool/can-delegate-rollback":                 true,
+	"minipool/can-set-use-latest-delegate":           true,
+	"minipool/get-use-latest-delegate":               true,
+	"minipool/get-delegate":                          true,
+	"minipool/get-previous-delegate":                 true,
+	"minipool/get-effective-delegate":                true,
+	"network/node-fee":                               true,
+	"network/rpl-price":                              true,
+	"network/stats":                                  true,
+	"network/timezone-map":                           true,
+	"network/gas-prices":                             true,
+	"network/rate-history":                           true,
+	"node/status":                                    true,
+	"node/gas-threshold-recommendation":              true,
+	"node/sync":                                      true,
+	"node/performance":                               true,
+	"node/can-register":                              true,
+	"node/can-set-withdrawal-address":                true,
+	"node/can-confirm-withdrawal-address":            true,
+	"node/can-set-timezone":                          true,
+	"node/can-join-smoothing-pool":                   true,
+	"node/can-leave-smoothing-pool":                  true,
+	"node/can-swap-rpl":                              true,
+	"node/get-swap-rpl-approval-gas":                 true,
+	"node/swap-rpl-allowance":                        true,
+	"node/can-stake-rpl":                             true,
+	"node/get-stake-rpl-approval-gas":                true,
+	"node/stake-rpl-allowance":                       true,
+	"node/can-withdraw-rpl":                          true,
+	"node/can-deposit":                               true,
+	"node/can-send":                                  true,
+	"node/can-burn":                                  true,
+	"node/can-claim-rpl-rewards":                     true,
+	"node/rewards":                                   true,
+	"node/rewards-history":                           true,
+	"node/todo":                                      true,
+	"node/tx-queue":                                  true,
+	"node/deposit-contract-info":                     true,
+	"node/estimate-set-snapshot-delegate-gas":        true,
+	"node/estimate-clear-snapshot-delegate-gas":      true,
+	"node/tx-doctor-status":                          true,
+	"node/can-speed-up-tx":                           true,
+	"node/can-cancel-tx":                             true,
+	"odao/status":                                    true,
+	"odao/members":                                   true,
+	"odao/proposals":                                 true,
+	"odao/proposal-details":                          true,
+	"odao/can-propose-invite":                        true,
+	"odao/can-propose-leave":                         true,
+	"odao/can-propose-kick":                          true,
+	"odao/can-cancel-proposal":                       true,
+	"odao/can-vote-proposal":                         true,
+	"odao/can-execute-proposal":                      true,
+	"odao/can-join":                                  true,
+	"odao/can-leave":                                 true,
+	"odao/can-propose-members-quorum":                true,
+	"odao/can-propose-members-rplbond":               true,
+	"odao/can-propose-members-minipool-unbonded-max": true,
+	"odao/can-propose-proposal-cooldown":             true,
+	"odao/can-propose-proposal-vote-timespan":        true,
+	"odao/can-propose-proposal-vote-delay-timespan":  true,
+	"odao/can-propose-proposal-execute-timespan":     true,
+	"odao/can-propose-proposal-action-timespan":      true,
+	"odao/can-propose-scrub-period":                  true,
+	"odao/get-member-settings":                       true,
+	"odao/get-proposal-settings":                     true,
+	"odao/get-minipool-settings":                     true,
+	"odao/can-cancel-bond-reduction":                 true,
+	"odao/can-scrub-minipool":                        true,
+	"odao/get-emergency-audit-log":                   true,
+	"queue/status":                                   true,
+	"queue/can-process":                              true,
+	"service/get-ec-status":                          true,
+	"service/get-validator-shards":                   true,
+	"wallet/status":                                  true,
+	"wallet/signing-queue":                           true,
+}
+
+// The global options a request can pass through to its route
+var passthroughFlags = []string{"maxFee", "maxPrioFee", "gasLimit", "nonce"}
+var passthroughBoolFlags = []string{"ignore-sync-check", "force-fallback-ec"}
+
+// A route of the REST API
+type route struct {
+	Path  string `json:"path"`
+	Usage string `json:"usage"`
+	Args  string `json:"args"`
+}
+
+// Register the REST API command
+func RegisterCommands(app *cli.App, name string, aliases []string) {
+	app.Commands = append(app.Commands, cli.Command{
+		Name:    name,
+		Aliases: aliases,
+		Usage:   "Serve the Rocket Pool API routes over HTTP",
+		Action: func(c *cli.Context) error {
+			return run(c)
+		},
+	})
+}
+
+// Run the REST API server
+func run(c *cli.Context) error {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return err
+	}
+	logger := log.NewColorLogger(RestColor)
+
+	// Serve the health check, whether or not the REST API is enabled
+	if err := health.Start(c, config.ApiContainerName, false, log.NewColorLogger(ErrorColor)); err != nil {
+		return err
+	}
+
+	// Wait forever if the REST API is disabled, so the container stays up for the CLI's API calls
+	if cfg.Smartnode.EnableRestApi.Value != true {
+		logger.Println("The REST API is disabled.")
+		select {}
+	}
+	token := cfg.Smartnode.GetRestApiAuthToken()
+	if token == "" {
+		return fmt.Errorf("The REST API is enabled but no auth token is set; please set one with `rocketpool service config`.")
+	}
+
+	// Get the API routes
+	apiCommand := c.App.Command("api")
+	if apiCommand == nil {
+		return fmt.Errorf("The api command is not registered")
+	}
+	executable, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("Error getting the path of the daemon: %w", err)
+	}
+	server := &server{
+		token:       []byte(token),
+		allowWrites: cfg.Smartnode.GetRestApiAllowWrites(),
+		apiCommand:  apiCommand,
+		executable:  executable,
+		settings:    c.GlobalString("settings"),
+		errorLog:    log.NewColorLogger(ErrorColor),
+	}
+
+	// Start the HTTP server
+	port := cfg.Smartnode.GetRestApiPort()
+	logger.Printlnf("Starting REST API on %s:%d.", listenAddress, port)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api", server.authenticate(server.listRoutes))
+	mux.HandleFunc("/api/", server.authenticate(server.callRoute))
+	return http.ListenAndServe(fmt.Sprintf("%s:%d", listenAddress, port), mux)
+
+}
+
+// The REST API server
+type server struct {
+	token       []byte
+	allowWrites bool
+	apiCommand  *cli.Command
+	executable  string
+	settings    string
+	errorLog    log.ColorLogger
+}
+
+// Reject requests without the auth token
+func (s *server) authenticate(handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if subtle.ConstantTimeCompare([]byte(token), s.token) != 1 {
+			writeError(w, http.StatusUnauthorized, errors.New("Invalid or missing auth token"))
+			return
+		}
+		handler(w, r)
+	}
+}
+
+// List the available routes
+func (s *server) listRoutes(w http.ResponseWriter, r *http.Request) {
+	routes := []route{}
+	for _, group := range s.apiCommand.Subcommands {
+		commands := group.Subcommands
+		if len(commands) == 0 {
+			commands = []cli.Command{group}
+		}
+		for _, command := range commands {
+			path := command.Name
+			if command.Name != group.Name {
+				path = fmt.Sprintf("%s/%s", group.Name, command.Name)
+			}
+			if !s.isServed(path) {
+				continue
+			}
+			routes = append(routes, route{
+				Path:  "/api/" + path,
+				Usage: command.Usage,
+				Args:  command.UsageText,
+			})
+		}
+	}
+	writeJSON(w, http.StatusOK, routes)
+}
+
+// Run an API route and return its response.
+// Route arguments are passed in order as repeated `arg` query or form parameters.
+func (s *server) callRoute(w http.ResponseWriter, r *http.Request) {
+
+	// Resolve the route to its canonical name, so aliases can't get around the blocked routes
+	path, err := s.resolveRoute(strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/"), "/"), "/"))
+	if err != nil {
+		writeError(w, http.StatusNotFound, err)
+		return
+	}
+	routePath := strings.Join(path, "/")
+	if !s.isServed(routePath) {
+		writeError(w, http.StatusForbidden, fmt.Errorf("The %s route is not available over the REST API", routePath))
+		return
+	}
+	if !readOnlyRoutes[routePath] && r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("The %s route changes the node's state, so it must be called with POST", routePath))
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("Error parsing the request: %w", err))
+		return
+	}
+
+	// Build the arguments
+	args := []string{"--settings", s.settings}
+	for _, flag := range passthroughFlags {
+		if value := r.Form.Get(flag); value != "" {
+			args = append(args, "--"+flag, value)
+		}
+	}
+	for _, flag := range passthroughBoolFlags {
+		if value := r.Form.Get(flag); value == "true" {
+			args = append(args, "--"+flag)
+		}
+	}
+	args = append(args, "api")
+	args = append(args, path...)
+	args = append(args, r.Form["arg"]...)
+
+	// Run the route the same way the CLI does
+	cmd := exec.CommandContext(r.Context(), s.executable, args...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil && stdout.Len() == 0 {
+		s.errorLog.Printlnf("Error running %s: %s %s", strings.Join(path, " "), err.Error(), stderr.String())
+		writeError(w, http.StatusInternalServerError, fmt.Errorf("Error running %s: %w", strings.Join(path, " "), err))
+		return
+	}
+
+	// Return its response
+	var response apitypes.APIResponse
+	if err := json.Unmarshal(stdout.Bytes(), &response); err != nil {
+		writeError(w, http.StatusInternalServerError, fmt.Errorf("Could not decode the response of %s: %w", strings.Join(path, " "), err))
+		return
+	}
+	status := http.StatusOK
+	if response.Error != "" {
+		status = http.StatusInternalServerError
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(stdout.Bytes())
+
+}
+
+// Check if a route is served; read-only routes always are, and the others are if write routes are allowed and they aren't blocked
+func (s *server) isServed(path string) bool {
+	if blockedRoutes[path] {
+		return false
+	}
+	return readOnlyRoutes[path] || s.allowWrites
+}
+
+// Get the canonical names of a route's group and command
+func (s *server) resolveRoute(parts []string) ([]string, error) {
+	if len(parts) == 0 || len(parts) > 2 {
+		return nil, fmt.Errorf("Unknown route")
+	}
+	var group *cli.Command
+	for i := range s.apiCommand.Subcommands {
+		if s.apiCommand.Subcommands[i].HasName(parts[0]) {
+			group = &s.apiCommand.Subcommands[i]
+			break
+		}
+	}
+	if group == nil {
+		return nil, fmt.Errorf("Unknown route group '%s'", parts[0])
+	}
+	if len(group.Subcommands) == 0 {
+		if len(parts) != 1 {
+			return nil, fmt.Errorf("Unknown route")
+		}
+		return []string{group.Name}, nil
+	}
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("Please specify a command of route group '%s'", group.Name)
+	}
+	for _, command := range group.Subcommands {
+		if command.HasName(parts[1]) {
+			return []string{group.Name, command.Name}, nil
+		}
+	}
+	return nil, fmt.Errorf("Unknown route '%s/%s'", group.Name, parts[1])
+}
+
+// Write a JSON response
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+// Write an error in the same format as the API routes
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, apitypes.APIResponse{
+		Status: "error",
+		Error:  err.Error(),
+	})
+}
@@ -7,7 +7,9 @@ import (
 	"github.com/urfave/cli"
 
 	"github.com/rocket-pool/smartnode/rocketpool/api"
+	"github.com/rocket-pool/smartnode/rocketpool/health"
 	"github.com/rocket-pool/smartnode/rocketpool/node"
+	"github.com/rocket-pool/smartnode/rocketpool/rest"
 	"github.com/rocket-pool/smartnode/rocketpool/watchtower"
 	"github.com/rocket-pool/smartnode/shared"
 	apiutils "github.com/rocket-pool/smartnode/shared/utils/api"
@@ -120,12 +122,18 @@ func main() {
 			Name:  "force-fallback-ec",
 			Usage: "Set this to true if you know the primary EC is offline and want to bypass its health checks, and just use the fallback EC instead",
 		},
+		cli.StringFlag{
+			Name:  "node-address",
+			Usage: "Observe the node at this `address` without loading the wallet. Status commands work as usual, but nothing can be signed",
+		},
 	}
 
 	// Register commands
 	api.RegisterCommands(app, "api", []string{"a"})
 	node.RegisterCommands(app, "node", []string{"n"})
 	watchtower.RegisterCommands(app, "watchtower", []string{"w"})
+	rest.RegisterCommands(app, "rest", []string{"r"})
+	health.RegisterCommands(app, "healthcheck", []string{})
 
 	// Get command being run
 	var commandName string
@@ -0,0 +1,29 @@
+package watchtower
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/rocket-pool/smartnode/shared/services/auditlog"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	apitypes "github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Record an emergency action the watchtower took in the audit log.
+// The transaction has already been submitted by then, so a failure is only logged.
+func recordEmergencyAction(cfg *config.RocketPoolConfig, logger log.ColorLogger, action apitypes.EmergencyAction, nodeAddress common.Address, minipoolAddress common.Address, reason string, hash common.Hash) {
+	err := auditlog.NewLog(os.ExpandEnv(cfg.Smartnode.GetEmergencyAuditLogPath())).Append(apitypes.EmergencyAuditLogEntry{
+		Action:          action,
+		Source:          apitypes.EmergencyActionSource_Watchtower,
+		NodeAddress:     nodeAddress,
+		MinipoolAddress: minipoolAddress,
+		Reason:          reason,
+		TxHash:          hash,
+	})
+	if err != nil {
+		logger.Println(fmt.Errorf("Could not record the action on minipool %s in the emergency audit log: %w", minipoolAddress.Hex(), err))
+	}
+}
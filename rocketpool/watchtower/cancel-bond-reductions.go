@@ -0,0 +1,261 @@
+package watchtower
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/dao/trustednode"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	apitypes "github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/api"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+	"github.com/rocket-pool/smartnode/shared/utils/rp"
+)
+
+// Settings
+const (
+	// A minipool's validator needs at least this balance, in gwei, for its bond to be reduced
+	MinimumBondReductionBalanceGwei uint64 = 32e9
+
+	// The block time used to find how far back the pending bond reductions could have started
+	BondReductionBlockTime = 12 * time.Second
+)
+
+// Cancel bond reductions task
+type cancelBondReductions struct {
+	c        *cli.Context
+	log      log.ColorLogger
+	cfg      *config.RocketPoolConfig
+	w        *wallet.Wallet
+	ec       rocketpool.ExecutionClient
+	rp       *rocketpool.RocketPool
+	bc       beacon.Client
+	simulate bool
+}
+
+// Create cancel bond reductions task
+func newCancelBondReductions(c *cli.Context, logger log.ColorLogger) (*cancelBondReductions, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	return &cancelBondReductions{
+		c:   c,
+		log: logger,
+		cfg: cfg,
+		w:   w,
+		ec:  ec,
+		rp:  rp,
+		bc:  bc,
+	}, nil
+
+}
+
+// Check the validators of minipools that are reducing their bonds, and vote to cancel the reductions of unhealthy ones
+func (t *cancelBondReductions) run() error {
+
+	// Wait for eth clients to sync
+	if err := services.WaitEthClientSynced(t.c, true); err != nil {
+		return err
+	}
+	if err := services.WaitBeaconClientSynced(t.c, true); err != nil {
+		return err
+	}
+
+	// Get node account
+	nodeAccount, err := t.w.GetNodeAccount()
+	if err != nil {
+		return err
+	}
+
+	// Check node trusted status
+	nodeTrusted, err := trustednode.GetMemberExists(t.rp, nodeAccount.Address, nil)
+	if err != nil {
+		return err
+	}
+	if !nodeTrusted {
+		return nil
+	}
+
+	// Get the reduction window, skipping the check if the network doesn't support bond reduction yet
+	windowStart, _, err := rp.GetBondReductionWindow(t.rp, nil)
+	if errors.Is(err, rp.ErrBondReductionNotSupported) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	// Log
+	t.log.Println("Checking for bond reductions to cancel...")
+
+	// Get the minipools that could still be in their check period
+	latestBlock, err := t.ec.HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		return err
+	}
+	fromBlock := big.NewInt(0).Sub(latestBlock.Number, big.NewInt(int64(windowStart/BondReductionBlockTime)+1))
+	if fromBlock.Sign() < 0 {
+		fromBlock.SetUint64(0)
+	}
+	scanner, err := api.GetEventLogScanner(t.cfg, t.rp.Client)
+	if err != nil {
+		return err
+	}
+	addresses, err := rp.GetBondReductionsStartedSince(t.rp, fromBlock, scanner)
+	if err != nil {
+		return err
+	}
+
+	// Only keep the reductions that haven't been cancelled and can't be completed yet
+	latestBlockTime := time.Unix(int64(latestBlock.Time), 0)
+	pending := []common.Address{}
+	for _, address := range addresses {
+		reduction, err := rp.GetBondReduction(t.rp, address, nil)
+		if err != nil {
+			return err
+		}
+		if reduction.Started && !reduction.Cancelled && latestBlockTime.Before(reduction.StartTime.Add(windowStart)) {
+			pending = append(pending, address)
+		}
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+
+	// Check the validators
+	head, err := t.bc.GetBeaconHead()
+	if err != nil {
+		return err
+	}
+	validators, err := rp.GetMinipoolValidators(t.rp, t.bc, pending, nil, nil)
+	if err != nil {
+		return err
+	}
+	var submitErr error
+	for _, address := range pending {
+		reason := getBondReductionCancelReason(validators[address], head.Epoch)
+		if reason == "" {
+			continue
+		}
+		t.log.Printlnf("Minipool %s can't reduce its bond: %s.", address.Hex(), reason)
+		if err := t.voteCancelReduction(address, reason); err != nil {
+			submitErr = fmt.Errorf("Could not vote to cancel the bond reduction of minipool %s: %w", address.Hex(), err)
+			t.log.Println(submitErr)
+		}
+	}
+
+	// Simulations fail if any of the transactions couldn't be prepared
+	if t.simulate {
+		return submitErr
+	}
+	return nil
+
+}
+
+// Get the reason a minipool's validator isn't healthy enough to reduce its bond, or an empty string if it is
+func getBondReductionCancelReason(validator beacon.ValidatorStatus, epoch uint64) string {
+	switch {
+	case !validator.Exists:
+		return "its validator isn't on the Beacon Chain"
+	case validator.Slashed:
+		return "its validator has been slashed"
+	case validator.ActivationEpoch > epoch:
+		return "its validator isn't active yet"
+	case validator.ExitEpoch <= epoch:
+		return "its validator has exited"
+	case validator.Balance < MinimumBondReductionBalanceGwei:
+		return fmt.Sprintf("its validator's balance is %.6f ETH, below 32 ETH", float64(validator.Balance)/1e9)
+	}
+	return ""
+}
+
+// Vote to cancel a minipool's bond reduction
+func (t *cancelBondReductions) voteCancelReduction(minipoolAddress common.Address, reason string) error {
+
+	// Log
+	t.log.Printlnf("Voting to cancel the bond reduction of minipool %s...", minipoolAddress.Hex())
+
+	// Get transactor
+	opts, err := t.w.GetNodeAccountTransactor()
+	if err != nil {
+		return err
+	}
+
+	// Get the gas limit
+	gasInfo, err := rp.EstimateVoteCancelReductionGas(t.rp, minipoolAddress, opts)
+	if err != nil {
+		return fmt.Errorf("Could not estimate the gas required to vote to cancel the bond reduction: %w", err)
+	}
+
+	// Print the transaction instead of submitting it when simulating
+	if t.simulate {
+		bondReducer, err := t.rp.GetContract("rocketMinipoolBondReducer")
+		if err != nil {
+			return err
+		}
+		return printSimulatedTransaction(t.log, bondReducer, "rocketMinipoolBondReducer", "voteCancelReduction", gasInfo, minipoolAddress)
+	}
+
+	// Print the gas info
+	maxFee := getWatchtowerMaxFee(t.c, t.log)
+	if !api.PrintAndCheckGasInfo(gasInfo, false, 0, t.log, maxFee, 0) {
+		return nil
+	}
+
+	// Set the gas settings
+	opts.GasFeeCap = maxFee
+	opts.GasTipCap = eth.GweiToWei(WatchtowerMaxPriorityFee)
+	opts.GasLimit = gasInfo.SafeGasLimit
+
+	// Vote to cancel
+	hash, err := rp.VoteCancelReduction(t.rp, minipoolAddress, opts)
+	if err != nil {
+		return err
+	}
+	recordEmergencyAction(t.cfg, t.log, apitypes.EmergencyAction_CancelBondReduction, opts.From, minipoolAddress, reason, hash)
+
+	// Print TX info and wait for it to be mined
+	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, t.log)
+	if err != nil {
+		return err
+	}
+
+	// Log
+	t.log.Printlnf("Successfully voted to cancel the bond reduction of minipool %s.", minipoolAddress.Hex())
+
+	// Return
+	return nil
+
+}
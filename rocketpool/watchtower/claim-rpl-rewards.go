@@ -12,6 +12,7 @@ import (
 
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/notifications"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	"github.com/rocket-pool/smartnode/shared/utils/api"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
@@ -20,12 +21,15 @@ import (
 
 // Claim RPL rewards task
 type claimRplRewards struct {
-	c       *cli.Context
-	log     log.ColorLogger
-	cfg     *config.RocketPoolConfig
-	w       *wallet.Wallet
-	rp      *rocketpool.RocketPool
-	enabled bool
+	c        *cli.Context
+	log      log.ColorLogger
+	cfg      *config.RocketPoolConfig
+	w        *wallet.Wallet
+	rp       *rocketpool.RocketPool
+	cm       *services.ChainMonitor
+	n        *notifications.Notifier
+	enabled  bool
+	simulate bool
 }
 
 // Create claim RPL rewards task
@@ -44,10 +48,18 @@ func newClaimRplRewards(c *cli.Context, logger log.ColorLogger) (*claimRplReward
 	if err != nil {
 		return nil, err
 	}
+	cm, err := services.GetChainMonitor(c)
+	if err != nil {
+		return nil, err
+	}
+	n, err := services.GetNotifier(c)
+	if err != nil {
+		return nil, err
+	}
 
 	// Check if auto-claiming is disabled
 	isEnabled := true
-	gasThreshold := cfg.Smartnode.RplClaimGasThreshold.Value.(float64)
+	gasThreshold := cfg.Smartnode.GetRplClaimGasThreshold()
 	if gasThreshold == 0 {
 		logger.Println("RPL claim gas threshold is set to 0, automatic claims will be disabled.")
 		isEnabled = false
@@ -60,6 +72,8 @@ func newClaimRplRewards(c *cli.Context, logger log.ColorLogger) (*claimRplReward
 		cfg:     cfg,
 		w:       w,
 		rp:      rp,
+		cm:      cm,
+		n:       n,
 		enabled: isEnabled,
 	}, nil
 
@@ -78,6 +92,12 @@ func (t *claimRplRewards) run() error {
 		return err
 	}
 
+	// Check if tasks that depend on finality are paused
+	if paused, reason := t.cm.IsPaused(); paused {
+		t.log.Printlnf("Skipping the oDAO RPL rewards check because %s.", reason)
+		return nil
+	}
+
 	// Get node account
 	nodeAccount, err := t.w.GetNodeAccount()
 	if err != nil {
@@ -120,8 +140,17 @@ func (t *claimRplRewards) run() error {
 		return fmt.Errorf("Could not estimate the gas required to claim RPL: %w", err)
 	}
 
+	// Print the transaction instead of submitting it when simulating
+	if t.simulate {
+		contract, err := t.rp.GetContract("rocketClaimTrustedNode")
+		if err != nil {
+			return err
+		}
+		return printSimulatedTransaction(t.log, contract, "rocketClaimTrustedNode", "claim", gasInfo)
+	}
+
 	// Print the gas info
-	maxFee := eth.GweiToWei(WatchtowerMaxFee)
+	maxFee := getWatchtowerMaxFee(t.c, t.log)
 	if !api.PrintAndCheckGasInfo(gasInfo, false, 0, t.log, maxFee, 0) {
 		return nil
 	}
@@ -143,8 +172,15 @@ func (t *claimRplRewards) run() error {
 		return err
 	}
 
-	// Log & return
+	// Log
 	t.log.Printlnf("Successfully claimed %.6f RPL in rewards.", math.RoundDown(eth.WeiToEth(rewardsAmountWei), 6))
+
+	// Send a notification
+	if err := t.n.Notify(notifications.EventType_RplClaimed, "Oracle DAO RPL rewards claimed", fmt.Sprintf("Successfully claimed %.6f RPL in oracle DAO rewards.", math.RoundDown(eth.WeiToEth(rewardsAmountWei), 6))); err != nil {
+		t.log.Printlnf("WARNING: %s", err.Error())
+	}
+
+	// Return
 	return nil
 
 }
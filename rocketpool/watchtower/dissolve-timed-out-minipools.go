@@ -28,12 +28,13 @@ const MinipoolStatusBatchSize = 20
 
 // Dissolve timed out minipools task
 type dissolveTimedOutMinipools struct {
-	c   *cli.Context
-	log log.ColorLogger
-	cfg *config.RocketPoolConfig
-	w   *wallet.Wallet
-	ec  rocketpool.ExecutionClient
-	rp  *rocketpool.RocketPool
+	c        *cli.Context
+	log      log.ColorLogger
+	cfg      *config.RocketPoolConfig
+	w        *wallet.Wallet
+	ec       rocketpool.ExecutionClient
+	rp       *rocketpool.RocketPool
+	simulate bool
 }
 
 // Create dissolve timed out minipools task
@@ -108,13 +109,18 @@ func (t *dissolveTimedOutMinipools) run() error {
 	t.log.Printlnf("%d minipool(s) have timed out and will be dissolved...", len(minipools))
 
 	// Dissolve minipools
+	var submitErr error
 	for _, mp := range minipools {
 		if err := t.dissolveMinipool(mp); err != nil {
-			t.log.Println(fmt.Errorf("Could not dissolve minipool %s: %w", mp.Address.Hex(), err))
+			submitErr = fmt.Errorf("Could not dissolve minipool %s: %w", mp.Address.Hex(), err)
+			t.log.Println(submitErr)
 		}
 	}
 
-	// Return
+	// Simulations fail if any of the transactions couldn't be prepared
+	if t.simulate {
+		return submitErr
+	}
 	return nil
 
 }
@@ -226,8 +232,13 @@ func (t *dissolveTimedOutMinipools) dissolveMinipool(mp *minipool.Minipool) erro
 		return fmt.Errorf("Could not estimate the gas required to dissolve the minipool: %w", err)
 	}
 
+	// Print the transaction instead of submitting it when simulating
+	if t.simulate {
+		return printSimulatedTransaction(t.log, mp.Contract, "minipool", "dissolve", gasInfo)
+	}
+
 	// Print the gas info
-	maxFee := eth.GweiToWei(WatchtowerMaxFee)
+	maxFee := getWatchtowerMaxFee(t.c, t.log)
 	if !api.PrintAndCheckGasInfo(gasInfo, false, 0, t.log, maxFee, 0) {
 		return nil
 	}
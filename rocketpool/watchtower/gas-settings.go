@@ -1,6 +1,50 @@
 package watchtower
 
+import (
+	"math/big"
+
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	rpgas "github.com/rocket-pool/smartnode/shared/services/gas"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
 const (
 	WatchtowerMaxFee         float64 = 200
 	WatchtowerMaxPriorityFee float64 = 3
 )
+
+// Get the max fee for a watchtower transaction.
+// This is the gas oracle's fastest suggestion plus the priority fee, capped at WatchtowerMaxFee; if the oracle fails, the cap is used.
+func getWatchtowerMaxFee(c *cli.Context, logger log.ColorLogger) *big.Int {
+
+	maxFee := eth.GweiToWei(WatchtowerMaxFee)
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		logger.Printlnf("WARNING: couldn't get the gas oracle, using a max fee of %.2f gwei: %s", WatchtowerMaxFee, err.Error())
+		return maxFee
+	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		logger.Printlnf("WARNING: couldn't get the gas oracle, using a max fee of %.2f gwei: %s", WatchtowerMaxFee, err.Error())
+		return maxFee
+	}
+
+	// Get the suggestion
+	oracle := rpgas.NewGasOracle(cfg, ec, nil)
+	suggestion, err := rpgas.GetHeadlessMaxFeeWei(oracle)
+	if err != nil {
+		logger.Printlnf("WARNING: couldn't get gas estimates from %s, using a max fee of %.2f gwei: %s", oracle.GetName(), WatchtowerMaxFee, err.Error())
+		return maxFee
+	}
+	suggestion = new(big.Int).Add(suggestion, eth.GweiToWei(WatchtowerMaxPriorityFee))
+	if suggestion.Cmp(maxFee) < 0 {
+		return suggestion
+	}
+	return maxFee
+
+}
@@ -0,0 +1,278 @@
+package watchtower
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/client"
+	"github.com/rocket-pool/rocketpool-go/dao/trustednode"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/clock"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Settings
+const (
+	TreegenWorkerContainerSuffix string = "_treegen"
+
+	// The watchtower command that runs the worker
+	generateTreeCommand string = "generate-tree"
+)
+
+// How long to wait before trying to generate a tree again after the worker failed
+var treegenRetryDelay, _ = time.ParseDuration("1h")
+
+// Generate rewards trees task
+type generateRewardsTrees struct {
+	c     *cli.Context
+	log   log.ColorLogger
+	cfg   *config.RocketPoolConfig
+	w     *wallet.Wallet
+	rp    *rocketpool.RocketPool
+	d     *client.Client
+	clock clock.Clock
+}
+
+// Create generate rewards trees task
+func newGenerateRewardsTrees(c *cli.Context, logger log.ColorLogger) (*generateRewardsTrees, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	d, err := services.GetDocker(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	return &generateRewardsTrees{
+		c:     c,
+		log:   logger,
+		cfg:   cfg,
+		w:     w,
+		rp:    rp,
+		d:     d,
+		clock: services.GetClock(c),
+	}, nil
+
+}
+
+// Start the rewards tree worker for the latest interval if its tree hasn't been generated yet, and check on the one that's running
+func (t *generateRewardsTrees) run() error {
+
+	// Check if the worker is enabled
+	if t.cfg.Smartnode.EnableTreegenWorker.Value != true || t.cfg.IsNativeMode {
+		return nil
+	}
+
+	// Wait for eth client to sync
+	if err := services.WaitEthClientSynced(t.c, true); err != nil {
+		return err
+	}
+
+	// Get node account
+	nodeAccount, err := t.w.GetNodeAccount()
+	if err != nil {
+		return err
+	}
+
+	// Check node trusted status
+	nodeTrusted, err := trustednode.GetMemberExists(t.rp, nodeAccount.Address, nil)
+	if err != nil {
+		return err
+	}
+	if !nodeTrusted {
+		return nil
+	}
+
+	// Get the latest interval that's been snapshotted
+	currentIndex, err := rewards.GetRewardIndex(t.rp, nil)
+	if err != nil {
+		return err
+	}
+	if currentIndex == 0 {
+		return nil
+	}
+	index := currentIndex - 1
+
+	// Check on the worker's last run, cleaning up its container if it's finished
+	network := string(t.cfg.Smartnode.GetNetwork())
+	statusFolder := os.ExpandEnv(t.cfg.Smartnode.GetTreegenStatusPath())
+	status, err := rewards.LoadTreegenStatus(statusFolder, network, index)
+	if err != nil {
+		return err
+	}
+	running, err := t.collectWorker(statusFolder, status)
+	if err != nil {
+		return err
+	}
+	if running {
+		if status != nil && status.State == rewards.TreegenState_Running {
+			t.log.Printlnf("The rewards tree for interval %d is still being generated (started %s ago).", index, t.clock.Since(status.StartTime).Round(time.Second))
+		} else {
+			t.log.Println("The rewards tree worker is still generating the tree for an earlier interval.")
+		}
+		return nil
+	}
+
+	// Check if its tree has already been saved
+	file, err := rewards.LoadRewardsFile(os.ExpandEnv(t.cfg.Smartnode.GetRewardsTreePath()), network, index)
+	if err != nil {
+		return err
+	}
+	if file != nil {
+		return nil
+	}
+
+	// Wait a while before trying again after a failed run
+	if status != nil && status.State == rewards.TreegenState_Failed {
+		if status.MerkleRoot != "" && !status.RootMatches {
+			t.log.Printlnf("The rewards tree generated for interval %d doesn't match the canonical root, so it won't be generated again automatically.", index)
+			t.log.Printlnf("Delete %s to try again.", rewards.GetTreegenStatusPath(statusFolder, network, index))
+			return nil
+		}
+		if t.clock.Since(status.EndTime) < treegenRetryDelay {
+			return nil
+		}
+	}
+
+	// Start the worker
+	t.log.Printlnf("Starting the rewards tree worker for interval %d...", index)
+	return t.startWorker(index)
+
+}
+
+// Check if the rewards tree worker is still running. If its container has exited, it's removed, and the run is recorded as failed if the worker
+// didn't record how it went itself (e.g. because it ran out of memory).
+func (t *generateRewardsTrees) collectWorker(statusFolder string, status *rewards.TreegenStatus) (bool, error) {
+
+	ctx := context.Background()
+	name := t.cfg.Smartnode.GetProjectName() + TreegenWorkerContainerSuffix
+	info, err := t.d.ContainerInspect(ctx, name)
+	if client.IsErrNotFound(err) {
+		info = types.ContainerJSON{}
+	} else if err != nil {
+		return false, fmt.Errorf("error inspecting %s: %w", name, err)
+	}
+
+	// Leave it alone while it's running, even if it's still finishing an older interval
+	if info.ContainerJSONBase != nil && info.State != nil && info.State.Running {
+		return true, nil
+	}
+
+	// Record the run as failed if the worker stopped without finishing it
+	if status != nil && status.State == rewards.TreegenState_Running {
+		status.State = rewards.TreegenState_Failed
+		status.EndTime = t.clock.Now()
+		status.Error = "the worker stopped before it finished"
+		if info.ContainerJSONBase != nil && info.State != nil {
+			if info.State.OOMKilled {
+				status.Error = "the worker ran out of memory; increase the Rewards Tree Worker Memory Limit in `rocketpool service config`"
+			} else {
+				status.Error = fmt.Sprintf("the worker exited with code %d", info.State.ExitCode)
+			}
+		}
+		t.log.Printlnf("The rewards tree worker for interval %d failed: %s.", status.Index, status.Error)
+		if err := rewards.SaveTreegenStatus(statusFolder, *status); err != nil {
+			return false, err
+		}
+	} else if status != nil && status.State == rewards.TreegenState_Failed && info.ContainerJSONBase != nil {
+		t.log.Printlnf("The rewards tree worker for interval %d failed: %s.", status.Index, status.Error)
+	}
+
+	// Clean up the container
+	if info.ContainerJSONBase != nil {
+		if err := t.d.ContainerRemove(ctx, info.ID, types.ContainerRemoveOptions{Force: true}); err != nil {
+			return false, fmt.Errorf("error removing %s: %w", name, err)
+		}
+	}
+	return false, nil
+
+}
+
+// Start the rewards tree worker container for an interval.
+// It runs the same image with the same mounts, network, and arguments as the watchtower, since it needs the same access to the
+// clients and the data folder, but with its own memory limit.
+func (t *generateRewardsTrees) startWorker(index uint64) error {
+
+	ctx := context.Background()
+	watchtowerName := t.cfg.Smartnode.GetProjectName() + "_" + config.WatchtowerContainerName
+	info, err := t.d.ContainerInspect(ctx, watchtowerName)
+	if err != nil {
+		return fmt.Errorf("error inspecting %s: %w", watchtowerName, err)
+	}
+
+	// Run the worker command in place of the watchtower command
+	var cmd []string
+	for i, arg := range info.Config.Cmd {
+		if arg == "watchtower" || arg == "w" {
+			cmd = append(append(cmd, info.Config.Cmd[:i+1]...), generateTreeCommand, fmt.Sprint(index))
+			break
+		}
+	}
+	if cmd == nil {
+		return fmt.Errorf("couldn't find the watchtower command in the arguments of %s", watchtowerName)
+	}
+
+	// Limit its memory without any swap, so it's stopped instead of slowing down the rest of the node
+	hostConfig := &container.HostConfig{
+		Binds:       info.HostConfig.Binds,
+		Mounts:      info.HostConfig.Mounts,
+		NetworkMode: info.HostConfig.NetworkMode,
+		LogConfig:   info.HostConfig.LogConfig,
+	}
+	if limit := t.cfg.Smartnode.GetTreegenMemoryLimit(); limit > 0 {
+		hostConfig.Memory = int64(limit) * 1024 * 1024
+		hostConfig.MemorySwap = hostConfig.Memory
+	}
+
+	// Record the run before starting it, so it isn't mistaken for one that's already finished
+	err = rewards.SaveTreegenStatus(os.ExpandEnv(t.cfg.Smartnode.GetTreegenStatusPath()), rewards.TreegenStatus{
+		Index:     index,
+		Network:   string(t.cfg.Smartnode.GetNetwork()),
+		State:     rewards.TreegenState_Running,
+		StartTime: t.clock.Now(),
+	})
+	if err != nil {
+		return err
+	}
+
+	name := t.cfg.Smartnode.GetProjectName() + TreegenWorkerContainerSuffix
+	created, err := t.d.ContainerCreate(ctx, &container.Config{
+		Image:      info.Config.Image,
+		Entrypoint: info.Config.Entrypoint,
+		Cmd:        cmd,
+		Env:        info.Config.Env,
+		WorkingDir: info.Config.WorkingDir,
+		User:       info.Config.User,
+	}, hostConfig, nil, name)
+	if err != nil {
+		return fmt.Errorf("error creating the rewards tree worker: %w", err)
+	}
+	if err := t.d.ContainerStart(ctx, created.ID, types.ContainerStartOptions{}); err != nil {
+		_ = t.d.ContainerRemove(ctx, created.ID, types.ContainerRemoveOptions{Force: true})
+		return fmt.Errorf("error starting the rewards tree worker: %w", err)
+	}
+	return nil
+
+}
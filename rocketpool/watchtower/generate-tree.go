@@ -0,0 +1,125 @@
+package watchtower
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/utils/api"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Generate the rewards tree for an interval and save it if it matches the canonical root.
+// This is run by the rewards tree worker container, which records how it went in a status file for the watchtower.
+func generateTree(c *cli.Context, index uint64) error {
+
+	logger := log.NewColorLogger(GenerateRewardsTreesColor)
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return err
+	}
+	network := string(cfg.Smartnode.GetNetwork())
+	statusFolder := os.ExpandEnv(cfg.Smartnode.GetTreegenStatusPath())
+	status := rewards.TreegenStatus{
+		Index:     index,
+		Network:   network,
+		State:     rewards.TreegenState_Running,
+		StartTime: time.Now(),
+	}
+	if err := rewards.SaveTreegenStatus(statusFolder, status); err != nil {
+		return err
+	}
+
+	// Record the result whether or not it worked
+	err = generateTreeFile(c, index, &status, logger)
+	status.EndTime = time.Now()
+	if err != nil {
+		status.State = rewards.TreegenState_Failed
+		status.Error = err.Error()
+	} else {
+		status.State = rewards.TreegenState_Done
+	}
+	if saveErr := rewards.SaveTreegenStatus(statusFolder, status); saveErr != nil {
+		logger.Printlnf("Error saving the rewards tree worker status: %s", saveErr.Error())
+	}
+	return err
+
+}
+
+// Generate the rewards tree file for an interval, recording its root in the status
+func generateTreeFile(c *cli.Context, index uint64, status *rewards.TreegenStatus, logger log.ColorLogger) error {
+
+	// Get services
+	if err := services.RequireRocketStorage(c); err != nil {
+		return err
+	}
+	if err := services.RequireEthClientSynced(c); err != nil {
+		return err
+	}
+	if err := services.RequireBeaconClientSynced(c); err != nil {
+		return err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return err
+	}
+
+	// Get the snapshot events for the interval and the one before it
+	scanner, err := api.GetEventLogScanner(cfg, rp.Client)
+	if err != nil {
+		return err
+	}
+	dataCache, err := services.GetCache(c)
+	if err != nil {
+		return err
+	}
+	event, err := rewards.GetRewardsEvent(rp, status.Network, index, scanner, dataCache)
+	if err != nil {
+		return err
+	}
+	var previous *rewards.RewardsEvent
+	if index > 0 {
+		previousEvent, err := rewards.GetRewardsEvent(rp, status.Network, index-1, scanner, dataCache)
+		if err != nil {
+			return err
+		}
+		previous = &previousEvent
+	}
+	status.CanonicalRoot = common.Hash(event.Submission.MerkleRoot).Hex()
+
+	// Generate the tree
+	logger.Printlnf("Generating the rewards tree for interval %d...", index)
+	file, err := rewards.GenerateRewardsFile(rp, bc, status.Network, event, previous, os.ExpandEnv(cfg.Smartnode.GetRewardsSnapshotPath()))
+	if err != nil {
+		return err
+	}
+	status.NodeCount = len(file.NodeRewards)
+	status.MerkleRoot = file.MerkleRoot
+	status.RootMatches = (file.MerkleRoot == status.CanonicalRoot)
+	if !status.RootMatches {
+		return fmt.Errorf("the Merkle root of the generated tree (%s) doesn't match the canonical root (%s)", status.MerkleRoot, status.CanonicalRoot)
+	}
+
+	// Save it
+	if err := rewards.SaveRewardsFile(os.ExpandEnv(cfg.Smartnode.GetRewardsTreePath()), file); err != nil {
+		return err
+	}
+	logger.Printlnf("Saved the rewards tree for interval %d (%d nodes).", index, status.NodeCount)
+	return nil
+
+}
@@ -0,0 +1,117 @@
+package watchtower
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/rocket-pool/rocketpool-go/dao/trustednode"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/settings/protocol"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Details of an oDAO submission used to check for identical pending submissions in the mempool
+type consensusSubmission struct {
+	contractName  string
+	method        string
+	storagePrefix string
+	values        []*big.Int
+}
+
+// Check whether identical submissions from other oDAO members that are already on chain or waiting in the mempool
+// will reach consensus without this node's vote, in which case submitting it would only waste gas
+func isConsensusPending(rp *rocketpool.RocketPool, ec *services.ExecutionClientManager, nodeAddress common.Address, submission consensusSubmission, logger log.ColorLogger) (bool, error) {
+
+	// Get the pending transactions; not every client supports this, so don't treat it as fatal
+	pendingTxs, err := ec.GetPendingTransactions(context.Background())
+	if err != nil {
+		logger.Printlnf("Could not check the mempool for pending submissions (%s), continuing...", err.Error())
+		return false, nil
+	}
+
+	// Get the calldata and storage key components of this node's submission
+	params := make([]interface{}, len(submission.values))
+	valueBufs := make([][]byte, len(submission.values))
+	for i, value := range submission.values {
+		params[i] = value
+		valueBufs[i] = make([]byte, 32)
+		value.FillBytes(valueBufs[i])
+	}
+	contract, err := rp.GetContract(submission.contractName)
+	if err != nil {
+		return false, err
+	}
+	txData, err := contract.ABI.Pack(submission.method, params...)
+	if err != nil {
+		return false, fmt.Errorf("Could not pack %s calldata: %w", submission.method, err)
+	}
+
+	// Find the distinct senders of identical submissions
+	senders := map[common.Address]bool{}
+	for _, tx := range pendingTxs {
+		if tx.To == nil || *tx.To != *contract.Address || tx.From == nodeAddress {
+			continue
+		}
+		if bytes.Equal(tx.Input, txData) {
+			senders[tx.From] = true
+		}
+	}
+	if len(senders) == 0 {
+		return false, nil
+	}
+
+	// Only count senders that are oDAO members and haven't already submitted these values, since other submissions will revert
+	pendingCount := uint64(0)
+	for sender := range senders {
+		isMember, err := trustednode.GetMemberExists(rp, sender, nil)
+		if err != nil {
+			return false, err
+		}
+		if !isMember {
+			continue
+		}
+		keyParts := append([][]byte{[]byte(submission.storagePrefix + ".node"), sender.Bytes()}, valueBufs...)
+		hasSubmitted, err := rp.RocketStorage.GetBool(nil, crypto.Keccak256Hash(keyParts...))
+		if err != nil {
+			return false, err
+		}
+		if !hasSubmitted {
+			pendingCount++
+		}
+	}
+
+	// Get the submissions already on chain and the consensus parameters
+	countKeyParts := append([][]byte{[]byte(submission.storagePrefix + ".count")}, valueBufs...)
+	submittedCount, err := rp.RocketStorage.GetUint(nil, crypto.Keccak256Hash(countKeyParts...))
+	if err != nil {
+		return false, err
+	}
+	memberCount, err := trustednode.GetMemberCount(rp, nil)
+	if err != nil {
+		return false, err
+	}
+	threshold, err := protocol.GetNodeConsensusThreshold(rp, nil)
+	if err != nil {
+		return false, err
+	}
+	if memberCount == 0 {
+		return false, nil
+	}
+
+	// Check if consensus will be reached without this node
+	totalCount := submittedCount.Uint64() + pendingCount
+	consensus := float64(totalCount) / float64(memberCount)
+	if consensus < threshold {
+		return false, nil
+	}
+	logger.Printlnf("Found %d identical pending submissions in the mempool; with %d already submitted, consensus will reach %.2f%% (threshold is %.2f%%).",
+		pendingCount, submittedCount.Uint64(), consensus*100, threshold*100)
+	return true, nil
+
+}
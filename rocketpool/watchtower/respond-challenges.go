@@ -17,11 +17,12 @@ import (
 
 // Respond to challenges task
 type respondChallenges struct {
-	c   *cli.Context
-	log log.ColorLogger
-	cfg *config.RocketPoolConfig
-	w   *wallet.Wallet
-	rp  *rocketpool.RocketPool
+	c        *cli.Context
+	log      log.ColorLogger
+	cfg      *config.RocketPoolConfig
+	w        *wallet.Wallet
+	rp       *rocketpool.RocketPool
+	simulate bool
 }
 
 // Create respond to challenges task
@@ -102,8 +103,17 @@ func (t *respondChallenges) run() error {
 		return fmt.Errorf("Could not estimate the gas required to respond to the challenge: %w", err)
 	}
 
+	// Print the transaction instead of submitting it when simulating
+	if t.simulate {
+		contract, err := t.rp.GetContract("rocketDAONodeTrustedActions")
+		if err != nil {
+			return err
+		}
+		return printSimulatedTransaction(t.log, contract, "rocketDAONodeTrustedActions", "actionChallengeDecide", gasInfo, nodeAccount.Address)
+	}
+
 	// Print the gas info
-	maxFee := eth.GweiToWei(WatchtowerMaxFee)
+	maxFee := getWatchtowerMaxFee(t.c, t.log)
 	if !api.PrintAndCheckGasInfo(gasInfo, false, 0, t.log, maxFee, 0) {
 		return nil
 	}
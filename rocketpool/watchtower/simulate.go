@@ -0,0 +1,148 @@
+package watchtower
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/rocketpool/watchtower/collectors"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// A watchtower task that can be run on its own
+type simulatedTask interface {
+	run() error
+}
+
+// The constructors for the tasks that can be simulated, keyed by the name used on the command line
+var simulatedTaskConstructors = map[string]func(c *cli.Context) (simulatedTask, error){
+	"respond-challenges": func(c *cli.Context) (simulatedTask, error) {
+		task, err := newRespondChallenges(c, log.NewColorLogger(RespondChallengesColor))
+		if err != nil {
+			return nil, err
+		}
+		task.simulate = true
+		return task, nil
+	},
+	"claim-rpl-rewards": func(c *cli.Context) (simulatedTask, error) {
+		task, err := newClaimRplRewards(c, log.NewColorLogger(ClaimRplRewardsColor))
+		if err != nil {
+			return nil, err
+		}
+		task.simulate = true
+		return task, nil
+	},
+	"submit-rpl-price": func(c *cli.Context) (simulatedTask, error) {
+		task, err := newSubmitRplPrice(c, log.NewColorLogger(SubmitRplPriceColor))
+		if err != nil {
+			return nil, err
+		}
+		task.simulate = true
+		return task, nil
+	},
+	"submit-network-balances": func(c *cli.Context) (simulatedTask, error) {
+		task, err := newSubmitNetworkBalances(c, log.NewColorLogger(SubmitNetworkBalancesColor))
+		if err != nil {
+			return nil, err
+		}
+		task.simulate = true
+		return task, nil
+	},
+	"submit-withdrawable-minipools": func(c *cli.Context) (simulatedTask, error) {
+		task, err := newSubmitWithdrawableMinipools(c, log.NewColorLogger(SubmitWithdrawableMinipoolsColor))
+		if err != nil {
+			return nil, err
+		}
+		task.simulate = true
+		return task, nil
+	},
+	"dissolve-timed-out-minipools": func(c *cli.Context) (simulatedTask, error) {
+		task, err := newDissolveTimedOutMinipools(c, log.NewColorLogger(DissolveTimedOutMinipoolsColor))
+		if err != nil {
+			return nil, err
+		}
+		task.simulate = true
+		return task, nil
+	},
+	"submit-scrub-minipools": func(c *cli.Context) (simulatedTask, error) {
+		task, err := newSubmitScrubMinipools(c, log.NewColorLogger(SubmitScrubMinipoolsColor), log.NewColorLogger(ErrorColor), collectors.NewScrubCollector())
+		if err != nil {
+			return nil, err
+		}
+		task.simulate = true
+		return task, nil
+	},
+	"cancel-bond-reductions": func(c *cli.Context) (simulatedTask, error) {
+		task, err := newCancelBondReductions(c, log.NewColorLogger(CancelBondReductionsColor))
+		if err != nil {
+			return nil, err
+		}
+		task.simulate = true
+		return task, nil
+	},
+}
+
+// Get the names of the tasks that can be simulated
+func getSimulatedTaskNames() []string {
+	names := make([]string, 0, len(simulatedTaskConstructors))
+	for name := range simulatedTaskConstructors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Run a single watchtower task against the current chain state without submitting any transactions
+func simulate(c *cli.Context, taskName string) error {
+
+	// Get the task
+	constructor, exists := simulatedTaskConstructors[taskName]
+	if !exists {
+		return fmt.Errorf("Unknown watchtower task '%s'; valid tasks are: %s", taskName, strings.Join(getSimulatedTaskNames(), ", "))
+	}
+
+	// Configure
+	configureHTTP()
+	task, err := constructor(c)
+	if err != nil {
+		return err
+	}
+
+	// Run the task
+	logger := log.NewColorLogger(SimulationColor)
+	logger.Printlnf("Simulating the %s task; no transactions will be submitted.", taskName)
+	if err := task.run(); err != nil {
+		return err
+	}
+	logger.Println("Simulation complete; any transactions the task would have submitted are shown above.")
+	return nil
+
+}
+
+// Print the transaction a simulated task would have submitted
+func printSimulatedTransaction(logger log.ColorLogger, contract *rocketpool.Contract, contractName string, method string, gasInfo rocketpool.GasInfo, params ...interface{}) error {
+
+	// Get the calldata
+	txData, err := contract.ABI.Pack(method, params...)
+	if err != nil {
+		return fmt.Errorf("Could not pack %s calldata: %w", method, err)
+	}
+
+	// Format the arguments
+	args := make([]string, len(params))
+	for i, param := range params {
+		args[i] = fmt.Sprint(param)
+	}
+
+	logger.Println("SIMULATION: this task would submit the following transaction:")
+	logger.Printlnf("\tContract: %s (%s)", contractName, contract.Address.Hex())
+	logger.Printlnf("\tMethod:   %s(%s)", method, strings.Join(args, ", "))
+	logger.Printlnf("\tCalldata: %s", hexutil.Encode(txData))
+	logger.Printlnf("\tGas:      %d estimated, %d safe limit", gasInfo.EstGasLimit, gasInfo.SafeGasLimit)
+	return nil
+
+}
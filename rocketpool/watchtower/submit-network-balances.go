@@ -38,13 +38,15 @@ const ConfirmDistanceBalances = 30
 
 // Submit network balances task
 type submitNetworkBalances struct {
-	c   *cli.Context
-	log log.ColorLogger
-	cfg *config.RocketPoolConfig
-	w   *wallet.Wallet
-	ec  rocketpool.ExecutionClient
-	rp  *rocketpool.RocketPool
-	bc  beacon.Client
+	c        *cli.Context
+	log      log.ColorLogger
+	cfg      *config.RocketPoolConfig
+	w        *wallet.Wallet
+	ec       *services.ExecutionClientManager
+	rp       *rocketpool.RocketPool
+	cm       *services.ChainMonitor
+	bc       beacon.Client
+	simulate bool
 }
 
 // Network balance info
@@ -81,6 +83,10 @@ func newSubmitNetworkBalances(c *cli.Context, logger log.ColorLogger) (*submitNe
 	if err != nil {
 		return nil, err
 	}
+	cm, err := services.GetChainMonitor(c)
+	if err != nil {
+		return nil, err
+	}
 	bc, err := services.GetBeaconClient(c)
 	if err != nil {
 		return nil, err
@@ -94,6 +100,7 @@ func newSubmitNetworkBalances(c *cli.Context, logger log.ColorLogger) (*submitNe
 		w:   w,
 		ec:  ec,
 		rp:  rp,
+		cm:  cm,
 		bc:  bc,
 	}, nil
 
@@ -110,6 +117,12 @@ func (t *submitNetworkBalances) run() error {
 		return err
 	}
 
+	// Check if tasks that depend on finality are paused
+	if paused, reason := t.cm.IsPaused(); paused {
+		t.log.Printlnf("Skipping the network balance submission because %s.", reason)
+		return nil
+	}
+
 	// Get node account
 	nodeAccount, err := t.w.GetNodeAccount()
 	if err != nil {
@@ -161,6 +174,20 @@ func (t *submitNetworkBalances) run() error {
 		return nil
 	}
 
+	// Refuse to submit data derived from state that is too far ahead of finality
+	header, err := t.ec.HeaderByNumber(context.Background(), big.NewInt(int64(blockNumber)))
+	if err != nil {
+		return fmt.Errorf("Error getting the header for block %d: %w", blockNumber, err)
+	}
+	isFinalEnough, reason, err := t.cm.CheckFinalityDelay(header.Time)
+	if err != nil {
+		return err
+	}
+	if !isFinalEnough {
+		t.log.Printlnf("Skipping the network balance submission for block %d because %s.", blockNumber, reason)
+		return nil
+	}
+
 	// Check if a submission needs to be made
 	balancesBlock, err := network.GetBalancesBlock(t.rp, nil)
 	if err != nil {
@@ -215,6 +242,25 @@ func (t *submitNetworkBalances) run() error {
 		t.log.Printlnf("Have previously submitted out-of-date balances for block $d, trying again...", blockNumber)
 	}
 
+	// Check if identical submissions from other members will reach consensus without this node
+	totalEth := big.NewInt(0)
+	totalEth.Add(totalEth, balances.DepositPool)
+	totalEth.Add(totalEth, balances.MinipoolsTotal)
+	totalEth.Add(totalEth, balances.RETHContract)
+	consensusPending, err := isConsensusPending(t.rp, t.ec, nodeAccount.Address, consensusSubmission{
+		contractName:  "rocketNetworkBalances",
+		method:        "submitBalances",
+		storagePrefix: "network.balances.submitted",
+		values:        []*big.Int{big.NewInt(int64(blockNumber)), totalEth, balances.MinipoolsStaking, balances.RETHSupply},
+	}, t.log)
+	if err != nil {
+		return err
+	}
+	if consensusPending {
+		t.log.Println("This node's submission is not required, skipping it.")
+		return nil
+	}
+
 	// Log
 	t.log.Println("Submitting balances...")
 
@@ -577,8 +623,17 @@ func (t *submitNetworkBalances) submitBalances(balances networkBalances) error {
 		return fmt.Errorf("Could not estimate the gas required to submit network balances: %w", err)
 	}
 
+	// Print the transaction instead of submitting it when simulating
+	if t.simulate {
+		contract, err := t.rp.GetContract("rocketNetworkBalances")
+		if err != nil {
+			return err
+		}
+		return printSimulatedTransaction(t.log, contract, "rocketNetworkBalances", "submitBalances", gasInfo, big.NewInt(int64(balances.Block)), totalEth, balances.MinipoolsStaking, balances.RETHSupply)
+	}
+
 	// Print the gas info
-	maxFee := eth.GweiToWei(WatchtowerMaxFee)
+	maxFee := getWatchtowerMaxFee(t.c, t.log)
 	if !api.PrintAndCheckGasInfo(gasInfo, false, 0, t.log, maxFee, 0) {
 		return nil
 	}
@@ -32,13 +32,15 @@ const ConfirmDistancePrices = 30
 
 // Submit RPL price task
 type submitRplPrice struct {
-	c   *cli.Context
-	log log.ColorLogger
-	cfg *config.RocketPoolConfig
-	ec  rocketpool.ExecutionClient
-	w   *wallet.Wallet
-	rp  *rocketpool.RocketPool
-	oio *contracts.OneInchOracle
+	c        *cli.Context
+	log      log.ColorLogger
+	cfg      *config.RocketPoolConfig
+	ec       *services.ExecutionClientManager
+	w        *wallet.Wallet
+	rp       *rocketpool.RocketPool
+	cm       *services.ChainMonitor
+	oio      *contracts.OneInchOracle
+	simulate bool
 }
 
 // Create submit RPL price task
@@ -61,6 +63,10 @@ func newSubmitRplPrice(c *cli.Context, logger log.ColorLogger) (*submitRplPrice,
 	if err != nil {
 		return nil, err
 	}
+	cm, err := services.GetChainMonitor(c)
+	if err != nil {
+		return nil, err
+	}
 	oio, err := services.GetOneInchOracle(c)
 	if err != nil {
 		return nil, err
@@ -74,6 +80,7 @@ func newSubmitRplPrice(c *cli.Context, logger log.ColorLogger) (*submitRplPrice,
 		ec:  ec,
 		w:   w,
 		rp:  rp,
+		cm:  cm,
 		oio: oio,
 	}, nil
 
@@ -87,6 +94,12 @@ func (t *submitRplPrice) run() error {
 		return err
 	}
 
+	// Check if tasks that depend on finality are paused
+	if paused, reason := t.cm.IsPaused(); paused {
+		t.log.Printlnf("Skipping the RPL price submission because %s.", reason)
+		return nil
+	}
+
 	// Get node account
 	nodeAccount, err := t.w.GetNodeAccount()
 	if err != nil {
@@ -138,6 +151,20 @@ func (t *submitRplPrice) run() error {
 		return nil
 	}
 
+	// Refuse to submit data derived from state that is too far ahead of finality
+	header, err := t.ec.HeaderByNumber(context.Background(), big.NewInt(int64(blockNumber)))
+	if err != nil {
+		return fmt.Errorf("Error getting the header for block %d: %w", blockNumber, err)
+	}
+	isFinalEnough, reason, err := t.cm.CheckFinalityDelay(header.Time)
+	if err != nil {
+		return err
+	}
+	if !isFinalEnough {
+		t.log.Printlnf("Skipping the RPL price submission for block %d because %s.", blockNumber, reason)
+		return nil
+	}
+
 	// Check if a submission needs to be made
 	pricesBlock, err := network.GetPricesBlock(t.rp, nil)
 	if err != nil {
@@ -195,6 +222,21 @@ func (t *submitRplPrice) run() error {
 		t.log.Printlnf("Have previously submitted out-of-date prices for block $d, trying again...", blockNumber)
 	}
 
+	// Check if identical submissions from other members will reach consensus without this node
+	consensusPending, err := isConsensusPending(t.rp, t.ec, nodeAccount.Address, consensusSubmission{
+		contractName:  "rocketNetworkPrices",
+		method:        "submitPrices",
+		storagePrefix: "network.prices.submitted",
+		values:        []*big.Int{big.NewInt(int64(blockNumber)), rplPrice, effectiveRplStake},
+	}, t.log)
+	if err != nil {
+		return err
+	}
+	if consensusPending {
+		t.log.Println("This node's submission is not required, skipping it.")
+		return nil
+	}
+
 	// Log
 	t.log.Println("Submitting RPL price...")
 
@@ -294,8 +336,17 @@ func (t *submitRplPrice) submitRplPrice(blockNumber uint64, rplPrice, effectiveR
 		return fmt.Errorf("Could not estimate the gas required to submit RPL price: %w", err)
 	}
 
+	// Print the transaction instead of submitting it when simulating
+	if t.simulate {
+		contract, err := t.rp.GetContract("rocketNetworkPrices")
+		if err != nil {
+			return err
+		}
+		return printSimulatedTransaction(t.log, contract, "rocketNetworkPrices", "submitPrices", gasInfo, big.NewInt(int64(blockNumber)), rplPrice, effectiveRplStake)
+	}
+
 	// Print the gas info
-	maxFee := eth.GweiToWei(WatchtowerMaxFee)
+	maxFee := getWatchtowerMaxFee(t.c, t.log)
 	if !api.PrintAndCheckGasInfo(gasInfo, false, 0, t.log, maxFee, 0) {
 		return nil
 	}
@@ -8,7 +8,6 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/prysmaticlabs/prysm/v2/beacon-chain/core/signing"
 	prdeposit "github.com/prysmaticlabs/prysm/v2/contracts/deposit"
 	"github.com/rocket-pool/rocketpool-go/dao/trustednode"
 	"github.com/rocket-pool/rocketpool-go/minipool"
@@ -23,11 +22,12 @@ import (
 	"github.com/rocket-pool/smartnode/rocketpool/watchtower/collectors"
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/cache"
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	apitypes "github.com/rocket-pool/smartnode/shared/types/api"
 	"github.com/rocket-pool/smartnode/shared/utils/api"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
-	eth2types "github.com/wealdtech/go-eth2-types/v2"
 )
 
 // Settings
@@ -44,12 +44,16 @@ type submitScrubMinipools struct {
 	cfg       *config.RocketPoolConfig
 	w         *wallet.Wallet
 	rp        *rocketpool.RocketPool
+	cm        *services.ChainMonitor
 	ec        rocketpool.ExecutionClient
 	bc        beacon.Client
+	dataCache *cache.Cache
 	it        *iterationData
 	coll      *collectors.ScrubCollector
 	lock      *sync.Mutex
 	isRunning bool
+	simulate  bool
+	checkErr  error
 }
 
 type iterationData struct {
@@ -99,10 +103,18 @@ func newSubmitScrubMinipools(c *cli.Context, logger log.ColorLogger, errorLogger
 	if err != nil {
 		return nil, err
 	}
+	cm, err := services.GetChainMonitor(c)
+	if err != nil {
+		return nil, err
+	}
 	bc, err := services.GetBeaconClient(c)
 	if err != nil {
 		return nil, err
 	}
+	dataCache, err := services.GetCache(c)
+	if err != nil {
+		return nil, err
+	}
 
 	// Return task
 	lock := &sync.Mutex{}
@@ -113,8 +125,10 @@ func newSubmitScrubMinipools(c *cli.Context, logger log.ColorLogger, errorLogger
 		cfg:       cfg,
 		w:         w,
 		rp:        rp,
+		cm:        cm,
 		ec:        ec,
 		bc:        bc,
+		dataCache: dataCache,
 		coll:      coll,
 		lock:      lock,
 		isRunning: false,
@@ -133,6 +147,12 @@ func (t *submitScrubMinipools) run() error {
 		return err
 	}
 
+	// Check if tasks that depend on finality are paused
+	if paused, reason := t.cm.IsPaused(); paused {
+		t.log.Printlnf("Skipping the minipool scrub check because %s.", reason)
+		return nil
+	}
+
 	// Get node account
 	nodeAccount, err := t.w.GetNodeAccount()
 	if err != nil {
@@ -160,104 +180,114 @@ func (t *submitScrubMinipools) run() error {
 	}
 	t.lock.Unlock()
 
-	// Run the check
-	go func() {
-		t.lock.Lock()
-		t.isRunning = true
-		t.lock.Unlock()
-		checkPrefix := "[Minipool Scrub]"
-		t.log.Printlnf("%s Starting scrub check in a separate thread.", checkPrefix)
+	// Run the check; simulations run it in the foreground so they can report what it would submit and whether it failed
+	if t.simulate {
+		t.runScrubCheck()
+		return t.checkErr
+	}
+	go t.runScrubCheck()
 
-		t.it = new(iterationData)
+	// Return
+	return nil
 
-		// Get minipools in prelaunch status
-		minipoolAddresses, err := minipool.GetPrelaunchMinipoolAddresses(t.rp, nil)
-		if err != nil {
-			t.handleError(fmt.Errorf("%s %w", checkPrefix, err))
-			return
-		}
-		t.it.totalMinipools = len(minipoolAddresses)
-		if t.it.totalMinipools == 0 {
-			t.log.Printlnf("%s No minipools in prelaunch.", checkPrefix)
-			t.lock.Lock()
-			t.isRunning = false
-			t.lock.Unlock()
-			return
-		}
+}
 
-		t.it.minipools = make(map[*minipool.Minipool]*minipoolDetails, t.it.totalMinipools)
+// Run the scrub check
+func (t *submitScrubMinipools) runScrubCheck() {
 
-		// Get the correct withdrawal credentials and validator pubkeys for each minipool
-		pubkeys := t.initializeMinipoolDetails(minipoolAddresses)
+	t.lock.Lock()
+	t.isRunning = true
+	t.checkErr = nil
+	t.lock.Unlock()
+	checkPrefix := "[Minipool Scrub]"
+	t.log.Printlnf("%s Starting scrub check in a separate thread.", checkPrefix)
 
-		// Step 1: Verify the Beacon credentials if they exist
-		err = t.verifyBeaconWithdrawalCredentials(pubkeys)
-		if err != nil {
-			t.handleError(fmt.Errorf("%s %w", checkPrefix, err))
-			return
-		}
+	t.it = new(iterationData)
 
-		// If there aren't any minipools left to check, print the final tally and exit
-		if len(t.it.minipools) == 0 {
-			t.printFinalTally(checkPrefix)
-			t.lock.Lock()
-			t.isRunning = false
-			t.lock.Unlock()
-			return
-		}
+	// Get minipools in prelaunch status
+	minipoolAddresses, err := minipool.GetPrelaunchMinipoolAddresses(t.rp, nil)
+	if err != nil {
+		t.handleError(fmt.Errorf("%s %w", checkPrefix, err))
+		return
+	}
+	t.it.totalMinipools = len(minipoolAddresses)
+	if t.it.totalMinipools == 0 {
+		t.log.Printlnf("%s No minipools in prelaunch.", checkPrefix)
+		t.lock.Lock()
+		t.isRunning = false
+		t.lock.Unlock()
+		return
+	}
 
-		// Get various elements needed to do eth1 prestake and deposit contract searches
-		err = t.getEth1SearchArtifacts()
-		if err != nil {
-			t.handleError(fmt.Errorf("%s %w", checkPrefix, err))
-			return
-		}
+	t.it.minipools = make(map[*minipool.Minipool]*minipoolDetails, t.it.totalMinipools)
 
-		// Step 2: Verify the MinipoolPrestaked events
-		t.verifyPrestakeEvents()
+	// Get the correct withdrawal credentials and validator pubkeys for each minipool
+	pubkeys := t.initializeMinipoolDetails(minipoolAddresses)
 
-		// If there aren't any minipools left to check, print the final tally and exit
-		if len(t.it.minipools) == 0 {
-			t.printFinalTally(checkPrefix)
-			t.lock.Lock()
-			t.isRunning = false
-			t.lock.Unlock()
-			return
-		}
+	// Step 1: Verify the Beacon credentials if they exist
+	err = t.verifyBeaconWithdrawalCredentials(pubkeys)
+	if err != nil {
+		t.handleError(fmt.Errorf("%s %w", checkPrefix, err))
+		return
+	}
 
-		// Step 3: Verify the deposit data of the remaining minipools
-		err = t.verifyDeposits()
-		if err != nil {
-			t.handleError(fmt.Errorf("%s %w", checkPrefix, err))
-			return
-		}
+	// If there aren't any minipools left to check, print the final tally and exit
+	if len(t.it.minipools) == 0 {
+		t.printFinalTally(checkPrefix)
+		t.lock.Lock()
+		t.isRunning = false
+		t.lock.Unlock()
+		return
+	}
 
-		// If there aren't any minipools left to check, print the final tally and exit
-		if len(t.it.minipools) == 0 {
-			t.printFinalTally(checkPrefix)
-			t.lock.Lock()
-			t.isRunning = false
-			t.lock.Unlock()
-			return
-		}
+	// Get various elements needed to do eth1 prestake and deposit contract searches
+	err = t.getEth1SearchArtifacts()
+	if err != nil {
+		t.handleError(fmt.Errorf("%s %w", checkPrefix, err))
+		return
+	}
 
-		// Step 4: Scrub all of the undeposited minipools after half the scrub period for safety
-		err = t.checkSafetyScrub()
-		if err != nil {
-			t.handleError(fmt.Errorf("%s %w", checkPrefix, err))
-			return
-		}
+	// Step 2: Verify the MinipoolPrestaked events
+	t.verifyPrestakeEvents()
 
-		// Log and return
+	// If there aren't any minipools left to check, print the final tally and exit
+	if len(t.it.minipools) == 0 {
 		t.printFinalTally(checkPrefix)
-		t.it = nil
 		t.lock.Lock()
 		t.isRunning = false
 		t.lock.Unlock()
-	}()
+		return
+	}
 
-	// Return
-	return nil
+	// Step 3: Verify the deposit data of the remaining minipools
+	err = t.verifyDeposits()
+	if err != nil {
+		t.handleError(fmt.Errorf("%s %w", checkPrefix, err))
+		return
+	}
+
+	// If there aren't any minipools left to check, print the final tally and exit
+	if len(t.it.minipools) == 0 {
+		t.printFinalTally(checkPrefix)
+		t.lock.Lock()
+		t.isRunning = false
+		t.lock.Unlock()
+		return
+	}
+
+	// Step 4: Scrub all of the undeposited minipools after half the scrub period for safety
+	err = t.checkSafetyScrub()
+	if err != nil {
+		t.handleError(fmt.Errorf("%s %w", checkPrefix, err))
+		return
+	}
+
+	// Log and return
+	t.printFinalTally(checkPrefix)
+	t.it = nil
+	t.lock.Lock()
+	t.isRunning = false
+	t.lock.Unlock()
 
 }
 
@@ -266,6 +296,15 @@ func (t *submitScrubMinipools) handleError(err error) {
 	t.errLog.Println("*** Minipool scrub check failed. ***")
 	t.lock.Lock()
 	t.isRunning = false
+	t.checkErr = err
+	t.lock.Unlock()
+}
+
+// Log a scrub vote that couldn't be submitted; simulations report it as a failure of the check
+func (t *submitScrubMinipools) handleScrubVoteError(mp *minipool.Minipool, err error) {
+	t.log.Printlnf("ALERT: Couldn't scrub minipool %s: %s", mp.Address.Hex(), err.Error())
+	t.lock.Lock()
+	t.checkErr = fmt.Errorf("Couldn't scrub minipool %s: %w", mp.Address.Hex(), err)
 	t.lock.Unlock()
 }
 
@@ -349,9 +388,9 @@ func (t *submitScrubMinipools) verifyBeaconWithdrawalCredentials(pubkeys []types
 
 	// Scrub the offending minipools
 	for _, minipool := range minipoolsToScrub {
-		err = t.submitVoteScrubMinipool(minipool)
+		err = t.submitVoteScrubMinipool(minipool, "its validator's withdrawal credentials on the Beacon Chain don't match the minipool")
 		if err != nil {
-			t.log.Printlnf("ALERT: Couldn't scrub minipool %s: %s", minipool.Address.Hex(), err.Error())
+			t.handleScrubVoteError(minipool, err)
 		}
 	}
 
@@ -398,7 +437,7 @@ func (t *submitScrubMinipools) getEth1SearchArtifacts() error {
 	if err != nil {
 		return err
 	}
-	depositDomain, err := signing.ComputeDomain(eth2types.DomainDeposit, eth2Config.GenesisForkVersion, eth2types.ZeroGenesisValidatorsRoot)
+	depositDomain, err := eth2Config.GetDepositDomain()
 	if err != nil {
 		return err
 	}
@@ -408,6 +447,24 @@ func (t *submitScrubMinipools) getEth1SearchArtifacts() error {
 
 }
 
+// Get the MinipoolPrestaked event of a minipool.
+// A minipool only prestakes once, so its event is cached instead of being scanned for on every run.
+func (t *submitScrubMinipools) getPrestakeEvent(mp *minipool.Minipool) (minipool.PrestakeData, error) {
+	key := mp.Address.Hex()
+	var prestakeData minipool.PrestakeData
+	if t.dataCache.Get(cache.Bucket_PrestakeEvents, key, &prestakeData) {
+		return prestakeData, nil
+	}
+	prestakeData, err := mp.GetPrestakeEvent(t.it.eventLogInterval, nil)
+	if err != nil {
+		return minipool.PrestakeData{}, err
+	}
+	if err := t.dataCache.Set(cache.Bucket_PrestakeEvents, key, prestakeData); err != nil {
+		t.log.Printlnf("Error caching prestake event for minipool %s: %s", key, err.Error())
+	}
+	return prestakeData, nil
+}
+
 // Step 2: Verify the MinipoolPrestaked event of each minipool
 func (t *submitScrubMinipools) verifyPrestakeEvents() {
 
@@ -416,7 +473,7 @@ func (t *submitScrubMinipools) verifyPrestakeEvents() {
 	weiPerGwei := big.NewInt(int64(eth.WeiPerGwei))
 	for minipool := range t.it.minipools {
 		// Get the MinipoolPrestaked event
-		prestakeData, err := minipool.GetPrestakeEvent(t.it.eventLogInterval, nil)
+		prestakeData, err := t.getPrestakeEvent(minipool)
 		if err != nil {
 			t.log.Printlnf("Error getting prestake event for minipool %s: %s", minipool.Address.Hex(), err.Error())
 			continue
@@ -453,9 +510,9 @@ func (t *submitScrubMinipools) verifyPrestakeEvents() {
 
 	// Scrub the offending minipools
 	for _, minipool := range minipoolsToScrub {
-		err := t.submitVoteScrubMinipool(minipool)
+		err := t.submitVoteScrubMinipool(minipool, "its prestake deposit had an invalid signature")
 		if err != nil {
-			t.log.Printlnf("ALERT: Couldn't scrub minipool %s: %s", minipool.Address.Hex(), err.Error())
+			t.handleScrubVoteError(minipool, err)
 		}
 	}
 
@@ -533,9 +590,9 @@ func (t *submitScrubMinipools) verifyDeposits() error {
 
 	// Scrub the offending minipools
 	for _, minipool := range minipoolsToScrub {
-		err := t.submitVoteScrubMinipool(minipool)
+		err := t.submitVoteScrubMinipool(minipool, "its validator's deposit had the wrong withdrawal credentials")
 		if err != nil {
-			t.log.Printlnf("ALERT: Couldn't scrub minipool %s: %s", minipool.Address.Hex(), err.Error())
+			t.handleScrubVoteError(minipool, err)
 		}
 	}
 
@@ -600,9 +657,9 @@ func (t *submitScrubMinipools) checkSafetyScrub() error {
 
 	// Scrub the offending minipools
 	for _, minipool := range minipoolsToScrub {
-		err := t.submitVoteScrubMinipool(minipool)
+		err := t.submitVoteScrubMinipool(minipool, "it has been in prelaunch for too long without a valid deposit (safety scrub)")
 		if err != nil {
-			t.log.Printlnf("ALERT: Couldn't scrub minipool %s: %s", minipool.Address.Hex(), err.Error())
+			t.handleScrubVoteError(minipool, err)
 		}
 	}
 
@@ -611,7 +668,7 @@ func (t *submitScrubMinipools) checkSafetyScrub() error {
 }
 
 // Submit minipool scrub status
-func (t *submitScrubMinipools) submitVoteScrubMinipool(mp *minipool.Minipool) error {
+func (t *submitScrubMinipools) submitVoteScrubMinipool(mp *minipool.Minipool, reason string) error {
 
 	// Log
 	t.log.Printlnf("Voting to scrub minipool %s...", mp.Address.Hex())
@@ -628,8 +685,13 @@ func (t *submitScrubMinipools) submitVoteScrubMinipool(mp *minipool.Minipool) er
 		return fmt.Errorf("Could not estimate the gas required to voteScrub the minipool: %w", err)
 	}
 
+	// Print the transaction instead of submitting it when simulating
+	if t.simulate {
+		return printSimulatedTransaction(t.log, mp.Contract, "minipool", "voteScrub", gasInfo)
+	}
+
 	// Print the gas info
-	maxFee := eth.GweiToWei(WatchtowerMaxFee)
+	maxFee := getWatchtowerMaxFee(t.c, t.log)
 	if !api.PrintAndCheckGasInfo(gasInfo, false, 0, t.log, maxFee, 0) {
 		return nil
 	}
@@ -644,6 +706,7 @@ func (t *submitScrubMinipools) submitVoteScrubMinipool(mp *minipool.Minipool) er
 	if err != nil {
 		return err
 	}
+	recordEmergencyAction(t.cfg, t.log, apitypes.EmergencyAction_ScrubMinipool, opts.From, mp.Address, reason, hash)
 
 	// Print TX info and wait for it to be mined
 	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, t.log)
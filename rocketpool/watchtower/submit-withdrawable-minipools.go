@@ -31,12 +31,13 @@ const MinipoolWithdrawableDetailsBatchSize = 20
 
 // Submit withdrawable minipools task
 type submitWithdrawableMinipools struct {
-	c   *cli.Context
-	log log.ColorLogger
-	cfg *config.RocketPoolConfig
-	w   *wallet.Wallet
-	rp  *rocketpool.RocketPool
-	bc  beacon.Client
+	c        *cli.Context
+	log      log.ColorLogger
+	cfg      *config.RocketPoolConfig
+	w        *wallet.Wallet
+	rp       *rocketpool.RocketPool
+	bc       beacon.Client
+	simulate bool
 }
 
 // Withdrawable minipool info
@@ -140,13 +141,18 @@ func (t *submitWithdrawableMinipools) run() error {
 	t.log.Printlnf("%d minipool(s) are withdrawable...", len(minipools))
 
 	// Submit minipools withdrawable status
+	var submitErr error
 	for _, details := range minipools {
 		if err := t.submitWithdrawableMinipool(details); err != nil {
-			t.log.Println(fmt.Errorf("Could not submit minipool %s withdrawable status: %w", details.Address.Hex(), err))
+			submitErr = fmt.Errorf("Could not submit minipool %s withdrawable status: %w", details.Address.Hex(), err)
+			t.log.Println(submitErr)
 		}
 	}
 
-	// Return
+	// Simulations fail if any of the transactions couldn't be prepared
+	if t.simulate {
+		return submitErr
+	}
 	return nil
 
 }
@@ -367,8 +373,17 @@ func (t *submitWithdrawableMinipools) submitWithdrawableMinipool(details minipoo
 		return fmt.Errorf("Could not estimate the gas required to submit minipool withdrawable status: %w", err)
 	}
 
+	// Print the transaction instead of submitting it when simulating
+	if t.simulate {
+		contract, err := t.rp.GetContract("rocketMinipoolStatus")
+		if err != nil {
+			return err
+		}
+		return printSimulatedTransaction(t.log, contract, "rocketMinipoolStatus", "submitMinipoolWithdrawable", gasInfo, details.Address)
+	}
+
 	// Print the gas info
-	maxFee := eth.GweiToWei(WatchtowerMaxFee)
+	maxFee := getWatchtowerMaxFee(t.c, t.log)
 	if !api.PrintAndCheckGasInfo(gasInfo, false, 0, t.log, maxFee, 0) {
 		return nil
 	}
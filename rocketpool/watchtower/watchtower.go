@@ -1,6 +1,7 @@
 package watchtower
 
 import (
+	"fmt"
 	"math/rand"
 	"net/http"
 	"sync"
@@ -11,6 +12,12 @@ import (
 
 	"github.com/rocket-pool/smartnode/rocketpool/watchtower/collectors"
 	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/crash"
+	"github.com/rocket-pool/smartnode/shared/services/events"
+	"github.com/rocket-pool/smartnode/shared/services/health"
+	"github.com/rocket-pool/smartnode/shared/services/notifications"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
 )
 
@@ -18,6 +25,7 @@ import (
 var minTasksInterval, _ = time.ParseDuration("4m")
 var maxTasksInterval, _ = time.ParseDuration("6m")
 var taskCooldown, _ = time.ParseDuration("10s")
+var chainMonitorInterval, _ = time.ParseDuration("30s")
 
 const (
 	MaxConcurrentEth1Requests = 200
@@ -30,9 +38,12 @@ const (
 	DissolveTimedOutMinipoolsColor   = color.FgMagenta
 	ProcessWithdrawalsColor          = color.FgCyan
 	SubmitScrubMinipoolsColor        = color.FgHiGreen
+	CancelBondReductionsColor        = color.FgHiCyan
+	GenerateRewardsTreesColor        = color.FgHiBlue
 	ErrorColor                       = color.FgRed
 	MetricsColor                     = color.FgHiYellow
 	WarningColor                     = color.FgYellow
+	SimulationColor                  = color.FgHiMagenta
 )
 
 // Register watchtower command
@@ -44,6 +55,45 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 		Action: func(c *cli.Context) error {
 			return run(c)
 		},
+		Subcommands: []cli.Command{
+			{
+				Name:      "simulate",
+				Usage:     "Run a single watchtower task against the current chain state and print what it would submit, without submitting anything",
+				UsageText: "rocketpool watchtower simulate task",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+
+					// Run
+					return simulate(c, c.Args().Get(0))
+
+				},
+			},
+			{
+				Name:      generateTreeCommand,
+				Usage:     "Generate the rewards tree for an interval in the rewards tree worker container; this is started by the watchtower",
+				UsageText: "rocketpool watchtower generate-tree index",
+				Hidden:    true,
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					index, err := cliutils.ValidateUint("rewards interval", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					return generateTree(c, index)
+
+				},
+			},
+		},
 	})
 }
 
@@ -53,6 +103,11 @@ func run(c *cli.Context) error {
 	// Configure
 	configureHTTP()
 
+	// Serve the health check before waiting for anything, so Docker knows the daemon is up
+	if err := health.Start(c, config.WatchtowerContainerName, true, log.NewColorLogger(ErrorColor)); err != nil {
+		return err
+	}
+
 	// Wait until node is registered
 	if err := services.WaitNodeRegistered(c, true); err != nil {
 		return err
@@ -64,6 +119,15 @@ func run(c *cli.Context) error {
 	// Initialize error logger
 	errorLog := log.NewColorLogger(ErrorColor)
 
+	// Transactions for a node account on a hardware wallet have to be signed manually, so the watchtower can't do its duties
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return err
+	}
+	if w.IsHardwareNodeAccount() {
+		errorLog.Println("The node account is on a hardware wallet, so the watchtower's duties are disabled. Oracle DAO members need a node account that can sign transactions automatically.")
+	}
+
 	// Initialize tasks
 	respondChallenges, err := newRespondChallenges(c, log.NewColorLogger(RespondChallengesColor))
 	if err != nil {
@@ -97,81 +161,176 @@ func run(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
+	cancelBondReductions, err := newCancelBondReductions(c, log.NewColorLogger(CancelBondReductionsColor))
+	if err != nil {
+		return err
+	}
+	generateRewardsTrees, err := newGenerateRewardsTrees(c, log.NewColorLogger(GenerateRewardsTreesColor))
+	if err != nil {
+		return err
+	}
+
+	// Initialize notifications
+	notifier, err := services.GetDaemonNotifier(c)
+	if err != nil {
+		return err
+	}
 
 	intervalDelta := maxTasksInterval - minTasksInterval
 	secondsDelta := intervalDelta.Seconds()
 
+	// Check the Beacon chain for reorgs and finality stalls before running any tasks
+	chainMonitor, err := services.GetChainMonitor(c)
+	if err != nil {
+		return err
+	}
+	if err := chainMonitor.Check(); err != nil {
+		errorLog.Println(err)
+	}
+
+	// Stream the daemon's events over gRPC if enabled
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return err
+	}
+	events.SetDaemon("watchtower")
+	if cfg.Smartnode.EnableEventStream.Value == true {
+		go func() {
+			if err := events.ServeGrpc("0.0.0.0", cfg.Smartnode.GetEventStreamPort()+1); err != nil {
+				errorLog.Println(err)
+			}
+		}()
+	}
+
+	// Keep scoring the Execution clients between task loops so requests go to the healthiest one
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return err
+	}
+	ec.StartHealthChecks(cfg.GetEcHealthCheckInterval())
+
+	// The daemon's own transactions wait for the private relay instead of handing it off
+	ec.WaitForPrivateRelay()
+
+	// Recover from panics in the daemon's loops so one bad task doesn't take down the whole daemon
+	crashReporter := crash.NewReporter("watchtower", cfg.Smartnode.GetCrashReportPath(), errorLog)
+
+	// Schedule the loops with the daemon's clock
+	clk := services.GetClock(c)
+
 	// Wait group to handle the various threads
 	wg := new(sync.WaitGroup)
 	wg.Add(2)
 
 	// Run task loop
 	go func() {
-		for {
-			// Randomize the next interval
-			randomSeconds := rand.Intn(int(secondsDelta))
-			interval := time.Duration(randomSeconds)*time.Second + minTasksInterval
-
-			// Check the EC status
-			err := services.WaitEthClientSynced(c, false) // Force refresh the primary / fallback EC status
-			if err != nil {
-				errorLog.Println(err)
-			} else {
-				// Run the challenge check
-				if err := respondChallenges.run(); err != nil {
-					errorLog.Println(err)
-				}
-				time.Sleep(taskCooldown)
+		crashReporter.RunLoop("tasks", func() {
+			for {
+				// Randomize the next interval
+				randomSeconds := rand.Intn(int(secondsDelta))
+				interval := cfg.ScaleEcPollingInterval(time.Duration(randomSeconds)*time.Second + minTasksInterval)
 
-				// Run the oDAO rewards check
-				if err := claimRplRewards.run(); err != nil {
+				// Send the alerts held back during a blackout window once it ends
+				if err := notifier.SendQueued(); err != nil {
 					errorLog.Println(err)
 				}
-				time.Sleep(taskCooldown)
 
-				// Run the price submission check
-				if err := submitRplPrice.run(); err != nil {
+				// Check the EC status
+				err := services.WaitEthClientSynced(c, false) // Force refresh the primary / fallback EC status
+				if err != nil {
 					errorLog.Println(err)
-				}
-				time.Sleep(taskCooldown)
+					events.Publish(events.EventType_Error, "", fmt.Sprintf("No Execution client is available: %s", err.Error()))
+					err = notifier.Notify(notifications.EventType_ExecutionClientDown, "Execution client down", fmt.Sprintf("No Execution client is available: %s", err.Error()))
+					if err != nil {
+						errorLog.Println(err)
+					}
+				} else {
+					notifier.Resolve(notifications.EventType_ExecutionClientDown)
 
-				// Run the network balance submission check
-				if err := submitNetworkBalances.run(); err != nil {
-					errorLog.Println(err)
-				}
-				time.Sleep(taskCooldown)
+					// The watchtower's duties all submit transactions automatically
+					if !w.IsHardwareNodeAccount() {
 
-				// Run the withdrawable status submission check
-				if err := submitWithdrawableMinipools.run(); err != nil {
-					errorLog.Println(err)
-				}
-				time.Sleep(taskCooldown)
+						// Run the challenge check
+						if err := events.RunTask("respond-challenges", respondChallenges.run); err != nil {
+							errorLog.Println(err)
+						}
+						clk.Sleep(taskCooldown)
 
-				// Run the minipool dissolve check
-				if err := dissolveTimedOutMinipools.run(); err != nil {
-					errorLog.Println(err)
-				}
-				time.Sleep(taskCooldown)
+						// Run the oDAO rewards check
+						if err := events.RunTask("claim-rpl-rewards", claimRplRewards.run); err != nil {
+							errorLog.Println(err)
+						}
+						clk.Sleep(taskCooldown)
 
-				// Run the withdrawal processing check
-				if err := processWithdrawals.run(); err != nil {
-					errorLog.Println(err)
+						// Run the price submission check
+						if err := events.RunTask("submit-rpl-price", submitRplPrice.run); err != nil {
+							errorLog.Println(err)
+						}
+						clk.Sleep(taskCooldown)
+
+						// Run the network balance submission check
+						if err := events.RunTask("submit-network-balances", submitNetworkBalances.run); err != nil {
+							errorLog.Println(err)
+						}
+						clk.Sleep(taskCooldown)
+
+						// Run the withdrawable status submission check
+						if err := events.RunTask("submit-withdrawable-minipools", submitWithdrawableMinipools.run); err != nil {
+							errorLog.Println(err)
+						}
+						clk.Sleep(taskCooldown)
+
+						// Run the minipool dissolve check
+						if err := events.RunTask("dissolve-timed-out-minipools", dissolveTimedOutMinipools.run); err != nil {
+							errorLog.Println(err)
+						}
+						clk.Sleep(taskCooldown)
+
+						// Run the withdrawal processing check
+						if err := events.RunTask("process-withdrawals", processWithdrawals.run); err != nil {
+							errorLog.Println(err)
+						}
+						clk.Sleep(taskCooldown)
+
+						// Run the minipool scrub check
+						if err := events.RunTask("submit-scrub-minipools", submitScrubMinipools.run); err != nil {
+							errorLog.Println(err)
+						}
+						clk.Sleep(taskCooldown)
+
+						// Run the bond reduction check
+						if err := events.RunTask("cancel-bond-reductions", cancelBondReductions.run); err != nil {
+							errorLog.Println(err)
+						}
+						clk.Sleep(taskCooldown)
+
+						// Run the rewards tree generation check
+						if err := events.RunTask("generate-rewards-trees", generateRewardsTrees.run); err != nil {
+							errorLog.Println(err)
+						}
+					}
 				}
-				time.Sleep(taskCooldown)
+				clk.Sleep(interval)
+			}
+		})
+		wg.Done()
+	}()
 
-				// Run the minipool scrub check
-				if err := submitScrubMinipools.run(); err != nil {
+	// Run chain monitor loop
+	go func() {
+		crashReporter.RunLoop("chain-monitor", func() {
+			for {
+				clk.Sleep(cfg.ScaleEcPollingInterval(chainMonitorInterval))
+				if err := chainMonitor.Check(); err != nil {
 					errorLog.Println(err)
 				}
 			}
-			time.Sleep(interval)
-		}
-		wg.Done()
+		})
 	}()
 
 	// Run metrics loop
 	go func() {
-		err := runMetricsServer(c, log.NewColorLogger(MetricsColor), scrubCollector)
+		err := runMetricsServer(c, log.NewColorLogger(MetricsColor), scrubCollector, crashReporter)
 		if err != nil {
 			errorLog.Println(err)
 		}
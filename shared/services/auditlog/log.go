@@ -0,0 +1,89 @@
+package auditlog
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// An append-only log of the emergency Oracle DAO actions the node has taken, one JSON entry per line.
+// Entries are only ever added, so the log is a complete history of what the node did.
+type Log struct {
+	path string
+	lock sync.Mutex
+}
+
+// Create a new audit log that's stored in the provided file
+func NewLog(path string) *Log {
+	return &Log{
+		path: path,
+	}
+}
+
+// Add an entry to the log
+func (l *Log) Append(entry api.EmergencyAuditLogEntry) error {
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if entry.Time.IsZero() {
+		entry.Time = time.Now()
+	}
+	bytes, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("Could not encode the audit log entry: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(l.path), 0700); err != nil {
+		return fmt.Errorf("Could not create the audit log folder: %w", err)
+	}
+	file, err := os.OpenFile(l.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("Could not open the audit log: %w", err)
+	}
+	defer file.Close()
+	if _, err := file.Write(append(bytes, '\n')); err != nil {
+		return fmt.Errorf("Could not write to the audit log: %w", err)
+	}
+	return nil
+
+}
+
+// Get all of the entries in the log, oldest first
+func (l *Log) List() ([]api.EmergencyAuditLogEntry, error) {
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	file, err := os.Open(l.path)
+	if os.IsNotExist(err) {
+		return []api.EmergencyAuditLogEntry{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Could not open the audit log: %w", err)
+	}
+	defer file.Close()
+
+	entries := []api.EmergencyAuditLogEntry{}
+	scanner := bufio.NewScanner(file)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var entry api.EmergencyAuditLogEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, fmt.Errorf("Could not decode line %d of the audit log: %w", line, err)
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("Could not read the audit log: %w", err)
+	}
+	return entries, nil
+
+}
@@ -1,6 +1,9 @@
 package beacon
 
 import (
+	"bytes"
+	"strings"
+
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/rocket-pool/rocketpool-go/types"
 )
@@ -21,7 +24,15 @@ type Eth2Config struct {
 	GenesisEpoch                 uint64
 	GenesisTime                  uint64
 	SecondsPerEpoch              uint64
+	SlotsPerEpoch                uint64
 	EpochsPerSyncCommitteePeriod uint64
+	DomainDeposit                []byte
+	DomainVoluntaryExit          []byte
+}
+type Fork struct {
+	PreviousVersion []byte
+	CurrentVersion  []byte
+	Epoch           uint64
 }
 type Eth2DepositContract struct {
 	ChainID uint64
@@ -33,6 +44,11 @@ type BeaconHead struct {
 	JustifiedEpoch         uint64
 	PreviousJustifiedEpoch uint64
 }
+type BeaconBlockHeader struct {
+	Slot       uint64
+	Root       common.Hash
+	ParentRoot common.Hash
+}
 type ValidatorStatus struct {
 	Pubkey                     types.ValidatorPubkey
 	Index                      uint64
@@ -46,12 +62,41 @@ type ValidatorStatus struct {
 	WithdrawableEpoch          uint64
 	Exists                     bool
 }
+type Committee struct {
+	Index      uint64
+	Slot       uint64
+	Validators []uint64
+}
+type Attestation struct {
+	Slot            uint64
+	CommitteeIndex  uint64
+	AggregationBits []byte
+}
+type Slashing struct {
+	ValidatorIndex uint64
+	Type           SlashingType
+}
+type BlockProposer struct {
+	ValidatorIndex uint64
+	Graffiti       string
+}
 type Eth1Data struct {
 	DepositRoot  common.Hash
 	DepositCount uint64
 	BlockHash    common.Hash
 }
 
+// The kind of offence a validator was slashed for
+type SlashingType string
+
+const (
+	// Signing two different blocks for the same slot
+	SlashingType_Proposer SlashingType = "proposer"
+
+	// Signing two conflicting attestations, either a double vote or a surround vote
+	SlashingType_Attester SlashingType = "attester"
+)
+
 // Beacon client type
 type BeaconClientType int
 
@@ -83,4 +128,39 @@ type Client interface {
 	ExitValidator(validatorIndex, epoch uint64, signature types.ValidatorSignature) error
 	Close() error
 	GetEth1DataForEth2Block(blockId string) (Eth1Data, error)
+	GetBeaconBlockHeader(blockId string) (BeaconBlockHeader, bool, error)
+	GetBeaconBlockSlashings(blockId string) ([]Slashing, bool, error)
+	GetBeaconBlockProposer(blockId string) (BlockProposer, bool, error)
+	GetBeaconBlockAttestations(blockId string) ([]Attestation, bool, error)
+	GetCommittees(stateId string, epoch uint64) ([]Committee, error)
+}
+
+// Check if the validator at a position in an attestation's committee took part in it.
+// The aggregation bits are an SSZ bitlist, with a bit for each position in the committee starting from the lowest bit of the first byte.
+func (a Attestation) IncludesPosition(position int) bool {
+	byteIndex := position / 8
+	if position < 0 || byteIndex >= len(a.AggregationBits) {
+		return false
+	}
+	return a.AggregationBits[byteIndex]&(1<<uint(position%8)) != 0
+}
+
+// Get the validators an attester slashing slashes, which are the ones that signed both of its conflicting attestations
+func GetAttesterSlashingIndices(attesting1 []uint64, attesting2 []uint64) []uint64 {
+	signed := map[uint64]bool{}
+	for _, index := range attesting1 {
+		signed[index] = true
+	}
+	slashed := []uint64{}
+	for _, index := range attesting2 {
+		if signed[index] {
+			slashed = append(slashed, index)
+		}
+	}
+	return slashed
+}
+
+// Get the text of a block's graffiti, which is padded to 32 bytes with zeros
+func GetGraffitiText(graffiti []byte) string {
+	return strings.ToValidUTF8(string(bytes.TrimRight(graffiti, "\x00")), "")
 }
@@ -0,0 +1,14 @@
+package beacon
+
+import "testing"
+
+func TestAttestationIncludesPosition(t *testing.T) {
+	// A bitlist of 9 bits with positions 0 and 2 set, and its length marker at bit 9
+	attestation := Attestation{AggregationBits: []byte{0x05, 0x02}}
+	expected := map[int]bool{0: true, 1: false, 2: true, 8: false, 16: false, -1: false}
+	for position, included := range expected {
+		if attestation.IncludesPosition(position) != included {
+			t.Errorf("expected position %d to be included: %t", position, included)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package beacon
+
+import (
+	"github.com/rocket-pool/smartnode/shared/types/eth2"
+)
+
+// Get a domain type from a Beacon client's spec, falling back to the standard value if the client didn't report it
+func GetDomainTypeOrDefault(domainType []byte, defaultType [4]byte) []byte {
+	if len(domainType) != len(defaultType) {
+		return defaultType[:]
+	}
+	return domainType
+}
+
+// Get the fork version that was active at an epoch from a fork schedule
+func GetForkVersionAtEpoch(forks []Fork, genesisForkVersion []byte, epoch uint64) []byte {
+	forkVersion := genesisForkVersion
+	var forkEpoch uint64
+	for _, fork := range forks {
+		if fork.Epoch <= epoch && fork.Epoch >= forkEpoch {
+			forkVersion = fork.CurrentVersion
+			forkEpoch = fork.Epoch
+		}
+	}
+	return forkVersion
+}
+
+// Compute a signing domain from its domain type and the fork version that applies to it
+func ComputeDomain(domainType []byte, forkVersion []byte, genesisValidatorsRoot []byte) ([]byte, error) {
+	var dt [4]byte
+	copy(dt[:], domainType)
+	return eth2.ComputeDomain(dt, forkVersion, genesisValidatorsRoot)
+}
+
+// Get the signing domain for deposits, which always uses the genesis fork version so deposits stay valid across forks
+func (config Eth2Config) GetDepositDomain() ([]byte, error) {
+	return ComputeDomain(config.DomainDeposit, config.GenesisForkVersion, eth2.ZeroGenesisValidatorsRoot)
+}
@@ -0,0 +1,150 @@
+package beacon
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/rocket-pool/rocketpool-go/types"
+)
+
+// The error returned when the Beacon node has pruned the historical state or duties a query needs
+var ErrHistoryUnavailable = errors.New("the Beacon node no longer has the historical data for this epoch; it has most likely been pruned")
+
+// Beacon nodes always keep the states and blocks of at least this many recent epochs.
+// A state or block that's missing inside this window isn't pruned history, so it isn't sent to the archive node.
+const historyRetentionEpochs uint64 = 256
+
+// Phrases the Beacon clients use when they can't find the state or block a historical query needs
+var historyUnavailablePhrases = []string{
+	"state not found",
+	"block not found",
+	"not_found: beacon state",
+	"not_found: beacon block",
+	"could not find state",
+	"could not find block",
+	"unknown state",
+	"unknown block",
+	"missing state",
+	"state not available",
+	"not available in the database",
+}
+
+// Check if a failed response to a historical query means the Beacon node couldn't find the state or block it needs.
+// Other failures, including 404s for missing validators or unknown routes, are reported as they are.
+func IsHistoryUnavailableResponse(status int, responseBody []byte) bool {
+	if status != http.StatusNotFound && status != http.StatusBadRequest && status != http.StatusInternalServerError && status != http.StatusServiceUnavailable {
+		return false
+	}
+	body := strings.ToLower(string(responseBody))
+	for _, phrase := range historyUnavailablePhrases {
+		if strings.Contains(body, phrase) {
+			return true
+		}
+	}
+	return false
+}
+
+// A Beacon client that sends historical queries to an archive Beacon node when the main Beacon node has pruned them.
+// Without an archive node, those queries fail with an error explaining how to set one up.
+type HistoryFallbackClient struct {
+	Client
+	archive Client
+}
+
+// Wrap a Beacon client with an archive Beacon client for historical queries; archive can be nil
+func NewHistoryFallbackClient(client Client, archive Client) *HistoryFallbackClient {
+	return &HistoryFallbackClient{
+		Client:  client,
+		archive: archive,
+	}
+}
+
+// Get a validator's status, using the archive node if the Beacon node has pruned the requested epoch
+func (c *HistoryFallbackClient) GetValidatorStatus(pubkey types.ValidatorPubkey, opts *ValidatorStatusOptions) (ValidatorStatus, error) {
+	status, err := c.Client.GetValidatorStatus(pubkey, opts)
+	if err == nil || opts == nil || !c.isPruned(err, opts.Epoch) {
+		return status, err
+	}
+	if c.archive == nil {
+		return ValidatorStatus{}, c.getUnavailableError(err, opts.Epoch)
+	}
+	return c.archive.GetValidatorStatus(pubkey, opts)
+}
+
+// Get the statuses of validators, using the archive node if the Beacon node has pruned the requested epoch
+func (c *HistoryFallbackClient) GetValidatorStatuses(pubkeys []types.ValidatorPubkey, opts *ValidatorStatusOptions) (map[types.ValidatorPubkey]ValidatorStatus, error) {
+	statuses, err := c.Client.GetValidatorStatuses(pubkeys, opts)
+	if err == nil || opts == nil || !c.isPruned(err, opts.Epoch) {
+		return statuses, err
+	}
+	if c.archive == nil {
+		return nil, c.getUnavailableError(err, opts.Epoch)
+	}
+	return c.archive.GetValidatorStatuses(pubkeys, opts)
+}
+
+// Get the sync duties of validators, using the archive node if the Beacon node has pruned the requested epoch
+func (c *HistoryFallbackClient) GetValidatorSyncDuties(indices []uint64, epoch uint64) (map[uint64]bool, error) {
+	duties, err := c.Client.GetValidatorSyncDuties(indices, epoch)
+	if err == nil || !c.isPruned(err, epoch) {
+		return duties, err
+	}
+	if c.archive == nil {
+		return nil, c.getUnavailableError(err, epoch)
+	}
+	return c.archive.GetValidatorSyncDuties(indices, epoch)
+}
+
+// Get the proposer duties of validators, using the archive node if the Beacon node has pruned the requested epoch
+func (c *HistoryFallbackClient) GetValidatorProposerDuties(indices []uint64, epoch uint64) (map[uint64]uint64, error) {
+	duties, err := c.Client.GetValidatorProposerDuties(indices, epoch)
+	if err == nil || !c.isPruned(err, epoch) {
+		return duties, err
+	}
+	if c.archive == nil {
+		return nil, c.getUnavailableError(err, epoch)
+	}
+	return c.archive.GetValidatorProposerDuties(indices, epoch)
+}
+
+// Get the attestation committees for an epoch, using the archive node if the Beacon node has pruned the requested state
+func (c *HistoryFallbackClient) GetCommittees(stateId string, epoch uint64) ([]Committee, error) {
+	committees, err := c.Client.GetCommittees(stateId, epoch)
+	if err == nil || !c.isPruned(err, epoch) {
+		return committees, err
+	}
+	if c.archive == nil {
+		return nil, c.getUnavailableError(err, epoch)
+	}
+	return c.archive.GetCommittees(stateId, epoch)
+}
+
+// Close the connections to both Beacon nodes
+func (c *HistoryFallbackClient) Close() error {
+	err := c.Client.Close()
+	if c.archive != nil {
+		if archiveErr := c.archive.Close(); err == nil {
+			err = archiveErr
+		}
+	}
+	return err
+}
+
+// Check if an error means the Beacon node has pruned an epoch: it couldn't find the state or block, and the epoch is older than the recent ones it always keeps
+func (c *HistoryFallbackClient) isPruned(err error, epoch uint64) bool {
+	if !errors.Is(err, ErrHistoryUnavailable) {
+		return false
+	}
+	head, headErr := c.Client.GetBeaconHead()
+	if headErr != nil {
+		return false
+	}
+	return epoch+historyRetentionEpochs < head.Epoch
+}
+
+// Explain a pruned-history error when there's no archive node to fall back to
+func (c *HistoryFallbackClient) getUnavailableError(err error, epoch uint64) error {
+	return fmt.Errorf("Could not look up epoch %d: %w\nSet the 'Archive Beacon Node URL' in the Smartnode settings (`rocketpool service config`) to an archive Beacon node to look up historical epochs.", epoch, err)
+}
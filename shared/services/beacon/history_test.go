@@ -0,0 +1,66 @@
+package beacon
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+// A Beacon client with a head epoch that fails every committee lookup with a missing state
+type testHistoryClient struct {
+	Client
+	headEpoch uint64
+	lookups   int
+}
+
+func (c *testHistoryClient) GetBeaconHead() (BeaconHead, error) {
+	return BeaconHead{Epoch: c.headEpoch}, nil
+}
+
+func (c *testHistoryClient) GetCommittees(stateId string, epoch uint64) ([]Committee, error) {
+	c.lookups++
+	return nil, fmt.Errorf("Could not get committees for epoch %d: %w", epoch, ErrHistoryUnavailable)
+}
+
+func TestIsHistoryUnavailableResponse(t *testing.T) {
+	tests := []struct {
+		status   int
+		body     string
+		expected bool
+	}{
+		{status: http.StatusNotFound, body: `{"code":404,"message":"NOT_FOUND: beacon state for state_id 3200"}`, expected: true},
+		{status: http.StatusNotFound, body: `{"code":404,"message":"State not found"}`, expected: true},
+		{status: http.StatusNotFound, body: `{"code":404,"message":"Block not found"}`, expected: true},
+		{status: http.StatusBadRequest, body: `{"code":400,"message":"Could not find state for slot 3200"}`, expected: true},
+		{status: http.StatusInternalServerError, body: `{"code":500,"message":"Unknown state"}`, expected: true},
+		{status: http.StatusNotFound, body: `{"code":404,"message":"Validator not found"}`, expected: false},
+		{status: http.StatusNotFound, body: `404 page not found`, expected: false},
+		{status: http.StatusBadRequest, body: `{"code":400,"message":"Invalid epoch"}`, expected: false},
+		{status: http.StatusUnauthorized, body: `{"code":401,"message":"State not found"}`, expected: false},
+	}
+	for _, test := range tests {
+		if IsHistoryUnavailableResponse(test.status, []byte(test.body)) != test.expected {
+			t.Errorf("%d %s: expected %t", test.status, test.body, test.expected)
+		}
+	}
+}
+
+func TestHistoryFallbackOnlyForOldEpochs(t *testing.T) {
+	client := &testHistoryClient{headEpoch: 10000}
+	archive := &testHistoryClient{headEpoch: 10000}
+	fallback := NewHistoryFallbackClient(client, archive)
+
+	// A missing state inside the retention window is returned as it is
+	if _, err := fallback.GetCommittees("320000", 10000-historyRetentionEpochs); err == nil {
+		t.Fatal("expected an error")
+	}
+	if archive.lookups != 0 {
+		t.Fatalf("expected a recent epoch not to be sent to the archive node, got %d lookups", archive.lookups)
+	}
+
+	// A missing state older than the window goes to the archive node
+	fallback.GetCommittees("0", 10000-historyRetentionEpochs-1)
+	if archive.lookups != 1 {
+		t.Fatalf("expected an old epoch to be sent to the archive node, got %d lookups", archive.lookups)
+	}
+}
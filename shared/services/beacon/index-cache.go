@@ -0,0 +1,199 @@
+package beacon
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/rocket-pool/rocketpool-go/types"
+)
+
+// A persistent mapping of validator pubkeys to their indices on the Beacon chain.
+// Indices never change once they're assigned, so the cache only grows as new validators are seen.
+type ValidatorIndexCache struct {
+	path    string
+	indices map[string]uint64
+	loaded  bool
+	lock    sync.Mutex
+}
+
+// Create a new validator index cache stored in the provided file
+func NewValidatorIndexCache(path string) *ValidatorIndexCache {
+	return &ValidatorIndexCache{
+		path:    path,
+		indices: map[string]uint64{},
+	}
+}
+
+// Get the cached indices of the provided validators, and the pubkeys that aren't in the cache
+func (c *ValidatorIndexCache) Get(pubkeys []types.ValidatorPubkey) (map[types.ValidatorPubkey]uint64, []types.ValidatorPubkey) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.load()
+
+	indices := map[types.ValidatorPubkey]uint64{}
+	missing := []types.ValidatorPubkey{}
+	for _, pubkey := range pubkeys {
+		if index, exists := c.indices[pubkey.Hex()]; exists {
+			indices[pubkey] = index
+		} else {
+			missing = append(missing, pubkey)
+		}
+	}
+	return indices, missing
+}
+
+// Add the indices of validators to the cache, saving it if any of them are new
+func (c *ValidatorIndexCache) Add(indices map[types.ValidatorPubkey]uint64) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.load()
+
+	changed := false
+	for pubkey, index := range indices {
+		key := pubkey.Hex()
+		if existing, exists := c.indices[key]; !exists || existing != index {
+			c.indices[key] = index
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+	return c.save()
+}
+
+// Load the cache file the first time the cache is used; a missing or unreadable file leaves the cache empty
+func (c *ValidatorIndexCache) load() {
+	if c.loaded {
+		return
+	}
+	c.loaded = true
+	c.merge()
+}
+
+// Merge the entries in the cache file into the cache
+func (c *ValidatorIndexCache) merge() {
+	bytes, err := ioutil.ReadFile(c.path)
+	if err != nil {
+		return
+	}
+	indices := map[string]uint64{}
+	if err := json.Unmarshal(bytes, &indices); err != nil {
+		return
+	}
+	for pubkey, index := range indices {
+		c.indices[pubkey] = index
+	}
+}
+
+// Save the cache file, keeping any entries other processes have added since it was loaded.
+// It's replaced in one step since the daemons and the api share it.
+func (c *ValidatorIndexCache) save() error {
+	c.merge()
+	bytes, err := json.Marshal(c.indices)
+	if err != nil {
+		return fmt.Errorf("Could not encode the validator index cache: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), 0700); err != nil {
+		return fmt.Errorf("Could not create the validator index cache folder: %w", err)
+	}
+	tempPath := c.path + ".tmp"
+	if err := ioutil.WriteFile(tempPath, bytes, 0600); err != nil {
+		return fmt.Errorf("Could not write the validator index cache: %w", err)
+	}
+	if err := os.Rename(tempPath, c.path); err != nil {
+		return fmt.Errorf("Could not replace the validator index cache: %w", err)
+	}
+	return nil
+}
+
+// A Beacon client that looks validator indices up in a ValidatorIndexCache before querying the Beacon node,
+// and adds every index it sees in validator statuses to the cache
+type IndexCachingClient struct {
+	Client
+	cache *ValidatorIndexCache
+}
+
+// Wrap a Beacon client with a validator index cache
+func NewIndexCachingClient(client Client, cache *ValidatorIndexCache) *IndexCachingClient {
+	return &IndexCachingClient{
+		Client: client,
+		cache:  cache,
+	}
+}
+
+// Get a validator's index, from the cache if possible
+func (c *IndexCachingClient) GetValidatorIndex(pubkey types.ValidatorPubkey) (uint64, error) {
+	indices, missing := c.cache.Get([]types.ValidatorPubkey{pubkey})
+	if len(missing) == 0 {
+		return indices[pubkey], nil
+	}
+	index, err := c.Client.GetValidatorIndex(pubkey)
+	if err != nil {
+		return 0, err
+	}
+	_ = c.cache.Add(map[types.ValidatorPubkey]uint64{pubkey: index})
+	return index, nil
+}
+
+// Get a validator's status, caching its index
+func (c *IndexCachingClient) GetValidatorStatus(pubkey types.ValidatorPubkey, opts *ValidatorStatusOptions) (ValidatorStatus, error) {
+	status, err := c.Client.GetValidatorStatus(pubkey, opts)
+	if err == nil && status.Exists {
+		_ = c.cache.Add(map[types.ValidatorPubkey]uint64{pubkey: status.Index})
+	}
+	return status, err
+}
+
+// Get the statuses of validators, caching their indices
+func (c *IndexCachingClient) GetValidatorStatuses(pubkeys []types.ValidatorPubkey, opts *ValidatorStatusOptions) (map[types.ValidatorPubkey]ValidatorStatus, error) {
+	statuses, err := c.Client.GetValidatorStatuses(pubkeys, opts)
+	if err != nil {
+		return nil, err
+	}
+	c.cacheIndices(statuses)
+	return statuses, nil
+}
+
+// Add the indices of the validators that exist on the Beacon chain to the cache
+func (c *IndexCachingClient) cacheIndices(statuses map[types.ValidatorPubkey]ValidatorStatus) {
+	indices := map[types.ValidatorPubkey]uint64{}
+	for pubkey, status := range statuses {
+		if status.Exists {
+			indices[pubkey] = status.Index
+		}
+	}
+	_ = c.cache.Add(indices)
+}
+
+// Get the indices of validators, only querying the Beacon node for the ones that aren't cached.
+// Validators that aren't on the Beacon chain yet are left out.
+func GetValidatorIndices(bc Client, pubkeys []types.ValidatorPubkey) (map[types.ValidatorPubkey]uint64, error) {
+
+	// Check the cache
+	indices := map[types.ValidatorPubkey]uint64{}
+	missing := pubkeys
+	if cachingClient, ok := bc.(*IndexCachingClient); ok {
+		indices, missing = cachingClient.cache.Get(pubkeys)
+	}
+	if len(missing) == 0 {
+		return indices, nil
+	}
+
+	// Query the rest
+	statuses, err := bc.GetValidatorStatuses(missing, nil)
+	if err != nil {
+		return nil, err
+	}
+	for pubkey, status := range statuses {
+		if status.Exists {
+			indices[pubkey] = status.Index
+		}
+	}
+	return indices, nil
+
+}
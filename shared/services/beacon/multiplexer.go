@@ -0,0 +1,434 @@
+package beacon
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rocket-pool/rocketpool-go/types"
+)
+
+// How long the sync status of each Beacon node is trusted before it's checked again
+const multiplexerSyncCheckInterval = 30 * time.Second
+
+// Phrases in the errors of requests that never reached a Beacon node, so the next one can be tried
+var bnConnectionErrorPhrases = []string{
+	"dial tcp",
+	"connection refused",
+	"connection reset",
+	"no such host",
+	"i/o timeout",
+	"Client.Timeout exceeded",
+	"EOF",
+}
+
+// The request statistics and status of one of the Beacon nodes behind a multiplexer
+type EndpointStatus struct {
+	Name         string
+	Url          string
+	Reachable    bool
+	Synced       bool
+	Active       bool
+	Requests     uint64
+	Failures     uint64
+	TotalLatency time.Duration
+	LastLatency  time.Duration
+}
+
+// A Beacon node behind a multiplexer
+type bnEndpoint struct {
+	name      string
+	url       string
+	client    Client
+	reachable bool
+	synced    bool
+	requests  uint64
+	failures  uint64
+	total     time.Duration
+	last      time.Duration
+}
+
+// This is a signature for a wrapped Beacon client function
+type bnFunction func(Client) (interface{}, error)
+
+// A Beacon client that spreads its requests across a primary Beacon node and any number of fallbacks.
+// Requests go to the first Beacon node in order that's synced, moving on to the next one if a node can't be reached, and the latency of every request is recorded per node.
+type MultiplexedClient struct {
+	endpoints []*bnEndpoint
+	order     []*bnEndpoint
+	lastCheck time.Time
+	lock      sync.Mutex
+}
+
+// Create a multiplexer for a primary Beacon node
+func NewMultiplexedClient(name string, url string, primary Client) *MultiplexedClient {
+	m := &MultiplexedClient{}
+	m.AddEndpoint(name, url, primary)
+	return m
+}
+
+// Add a fallback Beacon node; fallbacks are used in the order they're added
+func (m *MultiplexedClient) AddEndpoint(name string, url string, client Client) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.endpoints = append(m.endpoints, &bnEndpoint{
+		name:      name,
+		url:       url,
+		client:    client,
+		reachable: true,
+		synced:    true,
+	})
+	m.lastCheck = time.Time{}
+}
+
+// Get the status and request statistics of each Beacon node, in the order they were added
+func (m *MultiplexedClient) GetEndpointStatuses() []EndpointStatus {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	var active *bnEndpoint
+	if len(m.order) > 0 {
+		active = m.order[0]
+	}
+	statuses := make([]EndpointStatus, 0, len(m.endpoints))
+	for _, endpoint := range m.endpoints {
+		statuses = append(statuses, EndpointStatus{
+			Name:         endpoint.name,
+			Url:          endpoint.url,
+			Reachable:    endpoint.reachable,
+			Synced:       endpoint.synced,
+			Active:       endpoint == active,
+			Requests:     endpoint.requests,
+			Failures:     endpoint.failures,
+			TotalLatency: endpoint.total,
+			LastLatency:  endpoint.last,
+		})
+	}
+	return statuses
+}
+
+// Get the Beacon nodes in the order they should be tried, checking their sync status again if it's stale
+func (m *MultiplexedClient) getOrder() []*bnEndpoint {
+
+	m.lock.Lock()
+	if time.Since(m.lastCheck) < multiplexerSyncCheckInterval && len(m.order) == len(m.endpoints) {
+		order := m.order
+		m.lock.Unlock()
+		return order
+	}
+	endpoints := m.endpoints
+	m.lock.Unlock()
+
+	// Check the sync status of each one without holding the lock, since it can take a while
+	type syncResult struct {
+		reachable bool
+		synced    bool
+		latency   time.Duration
+	}
+	results := make([]syncResult, len(endpoints))
+	var wg sync.WaitGroup
+	for i, endpoint := range endpoints {
+		wg.Add(1)
+		go func(i int, endpoint *bnEndpoint) {
+			defer wg.Done()
+			start := time.Now()
+			status, err := endpoint.client.GetSyncStatus()
+			results[i] = syncResult{
+				reachable: err == nil,
+				synced:    err == nil && !status.Syncing,
+				latency:   time.Since(start),
+			}
+		}(i, endpoint)
+	}
+	wg.Wait()
+
+	// Synced nodes come first, then ones that are still syncing, then ones that can't be reached
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	synced := []*bnEndpoint{}
+	syncing := []*bnEndpoint{}
+	unreachable := []*bnEndpoint{}
+	for i, endpoint := range endpoints {
+		endpoint.reachable = results[i].reachable
+		endpoint.synced = results[i].synced
+		endpoint.record(results[i].latency, !results[i].reachable)
+		switch {
+		case endpoint.synced:
+			synced = append(synced, endpoint)
+		case endpoint.reachable:
+			syncing = append(syncing, endpoint)
+		default:
+			unreachable = append(unreachable, endpoint)
+		}
+	}
+	m.order = append(append(synced, syncing...), unreachable...)
+	m.lastCheck = time.Now()
+	return m.order
+
+}
+
+// Run a function on the preferred Beacon node, moving on to the next one if it can't be reached
+func (m *MultiplexedClient) run(function bnFunction) (interface{}, error) {
+	var lastErr error
+	for _, endpoint := range m.getOrder() {
+		start := time.Now()
+		result, err := function(endpoint.client)
+		latency := time.Since(start)
+		if err != nil && isBnConnectionError(err) {
+			m.lock.Lock()
+			endpoint.record(latency, true)
+			endpoint.reachable = false
+			endpoint.synced = false
+			m.lastCheck = time.Time{}
+			m.lock.Unlock()
+			lastErr = fmt.Errorf("%s Beacon node: %w", endpoint.name, err)
+			continue
+		}
+		m.lock.Lock()
+		endpoint.record(latency, err != nil)
+		m.lock.Unlock()
+		return result, err
+	}
+	if lastErr == nil {
+		lastErr = errors.New("no Beacon nodes are configured")
+	}
+	return nil, fmt.Errorf("all Beacon nodes failed: %w", lastErr)
+}
+
+// Record the latency of a request to the Beacon node
+func (endpoint *bnEndpoint) record(latency time.Duration, failed bool) {
+	endpoint.requests++
+	endpoint.total += latency
+	endpoint.last = latency
+	if failed {
+		endpoint.failures++
+	}
+}
+
+// Check if an error means the request never reached the Beacon node
+func isBnConnectionError(err error) bool {
+	message := err.Error()
+	for _, phrase := range bnConnectionErrorPhrases {
+		if strings.Contains(message, phrase) {
+			return true
+		}
+	}
+	return false
+}
+
+// Get the client type of the primary Beacon node, since that's the one the Validator client uses
+func (m *MultiplexedClient) GetClientType() BeaconClientType {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.endpoints[0].client.GetClientType()
+}
+
+// Get the sync status of the preferred Beacon node
+func (m *MultiplexedClient) GetSyncStatus() (SyncStatus, error) {
+	result, err := m.run(func(client Client) (interface{}, error) {
+		return client.GetSyncStatus()
+	})
+	if err != nil {
+		return SyncStatus{}, err
+	}
+	return result.(SyncStatus), nil
+}
+
+// Get the Beacon chain's configuration
+func (m *MultiplexedClient) GetEth2Config() (Eth2Config, error) {
+	result, err := m.run(func(client Client) (interface{}, error) {
+		return client.GetEth2Config()
+	})
+	if err != nil {
+		return Eth2Config{}, err
+	}
+	return result.(Eth2Config), nil
+}
+
+// Get the Beacon chain's deposit contract
+func (m *MultiplexedClient) GetEth2DepositContract() (Eth2DepositContract, error) {
+	result, err := m.run(func(client Client) (interface{}, error) {
+		return client.GetEth2DepositContract()
+	})
+	if err != nil {
+		return Eth2DepositContract{}, err
+	}
+	return result.(Eth2DepositContract), nil
+}
+
+// Get the Beacon chain's head
+func (m *MultiplexedClient) GetBeaconHead() (BeaconHead, error) {
+	result, err := m.run(func(client Client) (interface{}, error) {
+		return client.GetBeaconHead()
+	})
+	if err != nil {
+		return BeaconHead{}, err
+	}
+	return result.(BeaconHead), nil
+}
+
+// Get a validator's status
+func (m *MultiplexedClient) GetValidatorStatus(pubkey types.ValidatorPubkey, opts *ValidatorStatusOptions) (ValidatorStatus, error) {
+	result, err := m.run(func(client Client) (interface{}, error) {
+		return client.GetValidatorStatus(pubkey, opts)
+	})
+	if err != nil {
+		return ValidatorStatus{}, err
+	}
+	return result.(ValidatorStatus), nil
+}
+
+// Get the statuses of validators
+func (m *MultiplexedClient) GetValidatorStatuses(pubkeys []types.ValidatorPubkey, opts *ValidatorStatusOptions) (map[types.ValidatorPubkey]ValidatorStatus, error) {
+	result, err := m.run(func(client Client) (interface{}, error) {
+		return client.GetValidatorStatuses(pubkeys, opts)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(map[types.ValidatorPubkey]ValidatorStatus), nil
+}
+
+// Get a validator's index
+func (m *MultiplexedClient) GetValidatorIndex(pubkey types.ValidatorPubkey) (uint64, error) {
+	result, err := m.run(func(client Client) (interface{}, error) {
+		return client.GetValidatorIndex(pubkey)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return result.(uint64), nil
+}
+
+// Get the sync duties of validators
+func (m *MultiplexedClient) GetValidatorSyncDuties(indices []uint64, epoch uint64) (map[uint64]bool, error) {
+	result, err := m.run(func(client Client) (interface{}, error) {
+		return client.GetValidatorSyncDuties(indices, epoch)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(map[uint64]bool), nil
+}
+
+// Get the proposer duties of validators
+func (m *MultiplexedClient) GetValidatorProposerDuties(indices []uint64, epoch uint64) (map[uint64]uint64, error) {
+	result, err := m.run(func(client Client) (interface{}, error) {
+		return client.GetValidatorProposerDuties(indices, epoch)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(map[uint64]uint64), nil
+}
+
+// Get the domain data for a domain type at an epoch
+func (m *MultiplexedClient) GetDomainData(domainType []byte, epoch uint64) ([]byte, error) {
+	result, err := m.run(func(client Client) (interface{}, error) {
+		return client.GetDomainData(domainType, epoch)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.([]byte), nil
+}
+
+// Broadcast a signed exit for a validator
+func (m *MultiplexedClient) ExitValidator(validatorIndex, epoch uint64, signature types.ValidatorSignature) error {
+	_, err := m.run(func(client Client) (interface{}, error) {
+		return nil, client.ExitValidator(validatorIndex, epoch, signature)
+	})
+	return err
+}
+
+// Close the connections to every Beacon node
+func (m *MultiplexedClient) Close() error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	var err error
+	for _, endpoint := range m.endpoints {
+		if closeErr := endpoint.client.Close(); err == nil {
+			err = closeErr
+		}
+	}
+	return err
+}
+
+// Get the Eth1 data for a Beacon block
+func (m *MultiplexedClient) GetEth1DataForEth2Block(blockId string) (Eth1Data, error) {
+	result, err := m.run(func(client Client) (interface{}, error) {
+		return client.GetEth1DataForEth2Block(blockId)
+	})
+	if err != nil {
+		return Eth1Data{}, err
+	}
+	return result.(Eth1Data), nil
+}
+
+// Get the header of a Beacon block
+func (m *MultiplexedClient) GetBeaconBlockHeader(blockId string) (BeaconBlockHeader, bool, error) {
+	var exists bool
+	result, err := m.run(func(client Client) (interface{}, error) {
+		header, found, err := client.GetBeaconBlockHeader(blockId)
+		exists = found
+		return header, err
+	})
+	if err != nil {
+		return BeaconBlockHeader{}, false, err
+	}
+	return result.(BeaconBlockHeader), exists, nil
+}
+
+// Get the slashings included in a Beacon block
+func (m *MultiplexedClient) GetBeaconBlockSlashings(blockId string) ([]Slashing, bool, error) {
+	var exists bool
+	result, err := m.run(func(client Client) (interface{}, error) {
+		slashings, found, err := client.GetBeaconBlockSlashings(blockId)
+		exists = found
+		return slashings, err
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	return result.([]Slashing), exists, nil
+}
+
+// Get the proposer of a Beacon block and the graffiti it used
+func (m *MultiplexedClient) GetBeaconBlockProposer(blockId string) (BlockProposer, bool, error) {
+	var exists bool
+	result, err := m.run(func(client Client) (interface{}, error) {
+		proposer, found, err := client.GetBeaconBlockProposer(blockId)
+		exists = found
+		return proposer, err
+	})
+	if err != nil {
+		return BlockProposer{}, false, err
+	}
+	return result.(BlockProposer), exists, nil
+}
+
+// Get the attestations included in a Beacon block
+func (m *MultiplexedClient) GetBeaconBlockAttestations(blockId string) ([]Attestation, bool, error) {
+	var exists bool
+	result, err := m.run(func(client Client) (interface{}, error) {
+		attestations, found, err := client.GetBeaconBlockAttestations(blockId)
+		exists = found
+		return attestations, err
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	return result.([]Attestation), exists, nil
+}
+
+// Get the attestation committees for an epoch
+func (m *MultiplexedClient) GetCommittees(stateId string, epoch uint64) ([]Committee, error) {
+	result, err := m.run(func(client Client) (interface{}, error) {
+		return client.GetCommittees(stateId, epoch)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.([]Committee), nil
+}
@@ -12,10 +12,10 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/rocket-pool/rocketpool-go/types"
-	eth2types "github.com/wealdtech/go-eth2-types/v2"
 	"golang.org/x/sync/errgroup"
 
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	ssztypes "github.com/rocket-pool/smartnode/shared/types/eth2"
 	"github.com/rocket-pool/smartnode/shared/utils/eth2"
 	hexutil "github.com/rocket-pool/smartnode/shared/utils/hex"
 )
@@ -31,16 +31,19 @@ const (
 	RequestGenesisPath               = "/eth/v1/beacon/genesis"
 	RequestFinalityCheckpointsPath   = "/eth/v1/beacon/states/%s/finality_checkpoints"
 	RequestForkPath                  = "/eth/v1/beacon/states/%s/fork"
+	RequestForkSchedulePath          = "/eth/v1/config/fork_schedule"
 	RequestValidatorsPath            = "/eth/v1/beacon/states/%s/validators"
 	RequestVoluntaryExitPath         = "/eth/v1/beacon/pool/voluntary_exits"
 	RequestBeaconBlockPath           = "/eth/v1/beacon/blocks/%s"
+	RequestBeaconBlockHeaderPath     = "/eth/v1/beacon/headers/%s"
 	RequestValidatorSyncDuties       = "/eth/v1/validator/duties/sync/%s"
 	RequestValidatorProposerDuties   = "/eth/v1/validator/duties/proposer/%s"
+	RequestCommitteesPath            = "/eth/v1/beacon/states/%s/committees?epoch=%d"
 
 	MaxRequestValidatorsCount = 600
 )
 
-// Prysm client
+// Prysm client; it only uses the standard Beacon REST API, so it doesn't depend on Prysm's deprecated gRPC API
 type Client struct {
 	providerAddress string
 }
@@ -116,7 +119,10 @@ func (c *Client) GetEth2Config() (beacon.Eth2Config, error) {
 		GenesisEpoch:                 0,
 		GenesisTime:                  uint64(genesis.Data.GenesisTime),
 		SecondsPerEpoch:              uint64(eth2Config.Data.SecondsPerSlot * eth2Config.Data.SlotsPerEpoch),
+		SlotsPerEpoch:                uint64(eth2Config.Data.SlotsPerEpoch),
 		EpochsPerSyncCommitteePeriod: uint64(eth2Config.Data.EpochsPerSyncCommitteePeriod),
+		DomainDeposit:                beacon.GetDomainTypeOrDefault(eth2Config.Data.DomainDeposit, ssztypes.DomainDeposit),
+		DomainVoluntaryExit:          beacon.GetDomainTypeOrDefault(eth2Config.Data.DomainVoluntaryExit, ssztypes.DomainVoluntaryExit),
 	}, nil
 
 }
@@ -257,6 +263,8 @@ func (c *Client) GetValidatorSyncDuties(indices []uint64, epoch uint64) (map[uin
 
 	if err != nil {
 		return nil, fmt.Errorf("Could not get validator sync duties: %w", err)
+	} else if status != http.StatusOK && beacon.IsHistoryUnavailableResponse(status, responseBody) {
+		return nil, fmt.Errorf("Could not get validator sync duties for epoch %d: %w", epoch, beacon.ErrHistoryUnavailable)
 	} else if status != http.StatusOK {
 		return nil, fmt.Errorf("Could not get validator sync duties: HTTP status %d; response body: '%s'", status, string(responseBody))
 	}
@@ -290,6 +298,8 @@ func (c *Client) GetValidatorProposerDuties(indices []uint64, epoch uint64) (map
 
 	if err != nil {
 		return nil, fmt.Errorf("Could not get validator proposer duties: %w", err)
+	} else if status != http.StatusOK && beacon.IsHistoryUnavailableResponse(status, responseBody) {
+		return nil, fmt.Errorf("Could not get validator proposer duties for epoch %d: %w", epoch, beacon.ErrHistoryUnavailable)
 	} else if status != http.StatusOK {
 		return nil, fmt.Errorf("Could not get validator proposer duties: HTTP status %d; response body: '%s'", status, string(responseBody))
 	}
@@ -339,7 +349,7 @@ func (c *Client) GetDomainData(domainType []byte, epoch uint64) ([]byte, error)
 	// Data
 	var wg errgroup.Group
 	var genesis GenesisResponse
-	var fork ForkResponse
+	var forkSchedule ForkScheduleResponse
 
 	// Get genesis
 	wg.Go(func() error {
@@ -348,10 +358,10 @@ func (c *Client) GetDomainData(domainType []byte, epoch uint64) ([]byte, error)
 		return err
 	})
 
-	// Get fork
+	// Get fork schedule
 	wg.Go(func() error {
 		var err error
-		fork, err = c.getFork("head")
+		forkSchedule, err = c.getForkSchedule()
 		return err
 	})
 
@@ -360,18 +370,19 @@ func (c *Client) GetDomainData(domainType []byte, epoch uint64) ([]byte, error)
 		return []byte{}, err
 	}
 
-	// Get fork version
-	var forkVersion []byte
-	if epoch < uint64(fork.Data.Epoch) {
-		forkVersion = fork.Data.PreviousVersion
-	} else {
-		forkVersion = fork.Data.CurrentVersion
+	// Get the fork version that was active at the epoch
+	forks := make([]beacon.Fork, len(forkSchedule.Data))
+	for i, fork := range forkSchedule.Data {
+		forks[i] = beacon.Fork{
+			PreviousVersion: fork.PreviousVersion,
+			CurrentVersion:  fork.CurrentVersion,
+			Epoch:           uint64(fork.Epoch),
+		}
 	}
+	forkVersion := beacon.GetForkVersionAtEpoch(forks, genesis.Data.GenesisForkVersion, epoch)
 
 	// Compute & return domain
-	var dt [4]byte
-	copy(dt[:], domainType[:])
-	return eth2types.Domain(dt, forkVersion, genesis.Data.GenesisValidatorsRoot), nil
+	return beacon.ComputeDomain(domainType, forkVersion, genesis.Data.GenesisValidatorsRoot)
 
 }
 
@@ -390,10 +401,13 @@ func (c *Client) ExitValidator(validatorIndex, epoch uint64, signature types.Val
 func (c *Client) GetEth1DataForEth2Block(blockId string) (beacon.Eth1Data, error) {
 
 	// Get the Beacon block
-	block, err := c.getBeaconBlock(blockId)
+	block, exists, err := c.getBeaconBlock(blockId)
 	if err != nil {
 		return beacon.Eth1Data{}, err
 	}
+	if !exists {
+		return beacon.Eth1Data{}, fmt.Errorf("Could not get beacon block data: block %s not found", blockId)
+	}
 
 	// Convert the response to the eth1 data struct
 	return beacon.Eth1Data{
@@ -404,6 +418,132 @@ func (c *Client) GetEth1DataForEth2Block(blockId string) (beacon.Eth1Data, error
 
 }
 
+// Get the indices of the validators slashed by a Beacon block's proposer and attester slashings; returns false if there's no block for the provided ID, such as a missed slot
+func (c *Client) GetBeaconBlockSlashings(blockId string) ([]beacon.Slashing, bool, error) {
+
+	// Get the Beacon block
+	block, exists, err := c.getBeaconBlock(blockId)
+	if err != nil || !exists {
+		return nil, exists, err
+	}
+
+	// Get the slashed validators
+	slashed := []beacon.Slashing{}
+	for _, slashing := range block.Data.Message.Body.ProposerSlashings {
+		slashed = append(slashed, beacon.Slashing{
+			ValidatorIndex: uint64(slashing.SignedHeader1.Message.ProposerIndex),
+			Type:           beacon.SlashingType_Proposer,
+		})
+	}
+	for _, slashing := range block.Data.Message.Body.AttesterSlashings {
+		attesting1 := make([]uint64, len(slashing.Attestation1.AttestingIndices))
+		for i, index := range slashing.Attestation1.AttestingIndices {
+			attesting1[i] = uint64(index)
+		}
+		attesting2 := make([]uint64, len(slashing.Attestation2.AttestingIndices))
+		for i, index := range slashing.Attestation2.AttestingIndices {
+			attesting2[i] = uint64(index)
+		}
+		for _, index := range beacon.GetAttesterSlashingIndices(attesting1, attesting2) {
+			slashed = append(slashed, beacon.Slashing{
+				ValidatorIndex: index,
+				Type:           beacon.SlashingType_Attester,
+			})
+		}
+	}
+	return slashed, true, nil
+
+}
+
+// Get the header of a Beacon block; returns false if there's no block for the provided ID, such as a missed slot
+func (c *Client) GetBeaconBlockHeader(blockId string) (beacon.BeaconBlockHeader, bool, error) {
+
+	// Get the header
+	header, exists, err := c.getBeaconBlockHeader(blockId)
+	if err != nil || !exists {
+		return beacon.BeaconBlockHeader{}, exists, err
+	}
+
+	// Convert the response to the header struct
+	return beacon.BeaconBlockHeader{
+		Slot:       uint64(header.Data.Header.Message.Slot),
+		Root:       common.BytesToHash(header.Data.Root),
+		ParentRoot: common.BytesToHash(header.Data.Header.Message.ParentRoot),
+	}, true, nil
+
+}
+
+// Get the proposer of a Beacon block and the graffiti it used; returns false if there's no block for the provided ID, such as a missed slot
+func (c *Client) GetBeaconBlockProposer(blockId string) (beacon.BlockProposer, bool, error) {
+
+	// Get the Beacon block
+	block, exists, err := c.getBeaconBlock(blockId)
+	if err != nil || !exists {
+		return beacon.BlockProposer{}, exists, err
+	}
+	return beacon.BlockProposer{
+		ValidatorIndex: uint64(block.Data.Message.ProposerIndex),
+		Graffiti:       beacon.GetGraffitiText(block.Data.Message.Body.Graffiti),
+	}, true, nil
+
+}
+
+// Get the attestations included in a Beacon block; returns false if there's no block for the provided ID, such as a missed slot
+func (c *Client) GetBeaconBlockAttestations(blockId string) ([]beacon.Attestation, bool, error) {
+
+	// Get the Beacon block
+	block, exists, err := c.getBeaconBlock(blockId)
+	if err != nil || !exists {
+		return nil, exists, err
+	}
+
+	// Get the attestations
+	attestations := make([]beacon.Attestation, len(block.Data.Message.Body.Attestations))
+	for i, attestation := range block.Data.Message.Body.Attestations {
+		attestations[i] = beacon.Attestation{
+			Slot:            uint64(attestation.Data.Slot),
+			CommitteeIndex:  uint64(attestation.Data.Index),
+			AggregationBits: attestation.AggregationBits,
+		}
+	}
+	return attestations, true, nil
+
+}
+
+// Get the attestation committees for an epoch, from the state with the provided ID
+func (c *Client) GetCommittees(stateId string, epoch uint64) ([]beacon.Committee, error) {
+
+	// Get the committees
+	responseBody, status, err := c.getRequest(fmt.Sprintf(RequestCommitteesPath, stateId, epoch))
+	if err != nil {
+		return nil, fmt.Errorf("Could not get committees for epoch %d: %w", epoch, err)
+	} else if status != http.StatusOK && beacon.IsHistoryUnavailableResponse(status, responseBody) {
+		return nil, fmt.Errorf("Could not get committees for epoch %d: %w", epoch, beacon.ErrHistoryUnavailable)
+	} else if status != http.StatusOK {
+		return nil, fmt.Errorf("Could not get committees for epoch %d: HTTP status %d; response body: '%s'", epoch, status, string(responseBody))
+	}
+	var response CommitteesResponse
+	if err := json.Unmarshal(responseBody, &response); err != nil {
+		return nil, fmt.Errorf("Could not decode committees for epoch %d: %w", epoch, err)
+	}
+
+	// Convert the response to the committee structs
+	committees := make([]beacon.Committee, len(response.Data))
+	for i, committee := range response.Data {
+		validators := make([]uint64, len(committee.Validators))
+		for j, index := range committee.Validators {
+			validators[j] = uint64(index)
+		}
+		committees[i] = beacon.Committee{
+			Index:      uint64(committee.Index),
+			Slot:       uint64(committee.Slot),
+			Validators: validators,
+		}
+	}
+	return committees, nil
+
+}
+
 // Get sync status
 func (c *Client) getSyncStatus() (SyncStatusResponse, error) {
 	responseBody, status, err := c.getRequest(RequestSyncStatusPath)
@@ -494,6 +634,21 @@ func (c *Client) getFork(stateId string) (ForkResponse, error) {
 	return fork, nil
 }
 
+// Get the fork schedule
+func (c *Client) getForkSchedule() (ForkScheduleResponse, error) {
+	responseBody, status, err := c.getRequest(RequestForkSchedulePath)
+	if err != nil {
+		return ForkScheduleResponse{}, fmt.Errorf("Could not get fork schedule: %w", err)
+	} else if status != http.StatusOK {
+		return ForkScheduleResponse{}, fmt.Errorf("Could not get fork schedule: HTTP status %d; response body: '%s'", status, string(responseBody))
+	}
+	var forkSchedule ForkScheduleResponse
+	if err := json.Unmarshal(responseBody, &forkSchedule); err != nil {
+		return ForkScheduleResponse{}, fmt.Errorf("Could not decode fork schedule: %w", err)
+	}
+	return forkSchedule, nil
+}
+
 // Get validators
 func (c *Client) getValidators(stateId string, pubkeys []string) (ValidatorsResponse, error) {
 	var query string
@@ -503,6 +658,8 @@ func (c *Client) getValidators(stateId string, pubkeys []string) (ValidatorsResp
 	responseBody, status, err := c.getRequest(fmt.Sprintf(RequestValidatorsPath, stateId) + query)
 	if err != nil {
 		return ValidatorsResponse{}, fmt.Errorf("Could not get validators: %w", err)
+	} else if status != http.StatusOK && stateId != "head" && beacon.IsHistoryUnavailableResponse(status, responseBody) {
+		return ValidatorsResponse{}, fmt.Errorf("Could not get validators at state %s: %w", stateId, beacon.ErrHistoryUnavailable)
 	} else if status != http.StatusOK {
 		return ValidatorsResponse{}, fmt.Errorf("Could not get validators: HTTP status %d; response body: '%s'", status, string(responseBody))
 	}
@@ -575,18 +732,37 @@ func (c *Client) postVoluntaryExit(request VoluntaryExitRequest) error {
 }
 
 // Get the target beacon block
-func (c *Client) getBeaconBlock(blockId string) (BeaconBlockResponse, error) {
+func (c *Client) getBeaconBlock(blockId string) (BeaconBlockResponse, bool, error) {
 	responseBody, status, err := c.getRequest(fmt.Sprintf(RequestBeaconBlockPath, blockId))
 	if err != nil {
-		return BeaconBlockResponse{}, fmt.Errorf("Could not get beacon block data: %w", err)
+		return BeaconBlockResponse{}, false, fmt.Errorf("Could not get beacon block data: %w", err)
+	} else if status == http.StatusNotFound {
+		return BeaconBlockResponse{}, false, nil
 	} else if status != http.StatusOK {
-		return BeaconBlockResponse{}, fmt.Errorf("Could not get beacon block data: HTTP status %d; response body: '%s'", status, string(responseBody))
+		return BeaconBlockResponse{}, false, fmt.Errorf("Could not get beacon block data: HTTP status %d; response body: '%s'", status, string(responseBody))
 	}
 	var beaconBlock BeaconBlockResponse
 	if err := json.Unmarshal(responseBody, &beaconBlock); err != nil {
-		return BeaconBlockResponse{}, fmt.Errorf("Could not decode beacon block data: %w", err)
+		return BeaconBlockResponse{}, false, fmt.Errorf("Could not decode beacon block data: %w", err)
+	}
+	return beaconBlock, true, nil
+}
+
+// Get a beacon block header
+func (c *Client) getBeaconBlockHeader(blockId string) (BeaconBlockHeaderResponse, bool, error) {
+	responseBody, status, err := c.getRequest(fmt.Sprintf(RequestBeaconBlockHeaderPath, blockId))
+	if err != nil {
+		return BeaconBlockHeaderResponse{}, false, fmt.Errorf("Could not get beacon block header: %w", err)
+	} else if status == http.StatusNotFound {
+		return BeaconBlockHeaderResponse{}, false, nil
+	} else if status != http.StatusOK {
+		return BeaconBlockHeaderResponse{}, false, fmt.Errorf("Could not get beacon block header: HTTP status %d; response body: '%s'", status, string(responseBody))
+	}
+	var header BeaconBlockHeaderResponse
+	if err := json.Unmarshal(responseBody, &header); err != nil {
+		return BeaconBlockHeaderResponse{}, false, fmt.Errorf("Could not decode beacon block header: %w", err)
 	}
-	return beaconBlock, nil
+	return header, true, nil
 }
 
 // Make a GET request to the beacon node
@@ -28,9 +28,11 @@ type SyncStatusResponse struct {
 }
 type Eth2ConfigResponse struct {
 	Data struct {
-		SecondsPerSlot               uinteger `json:"SECONDS_PER_SLOT"`
-		SlotsPerEpoch                uinteger `json:"SLOTS_PER_EPOCH"`
-		EpochsPerSyncCommitteePeriod uinteger `json:"EPOCHS_PER_SYNC_COMMITTEE_PERIOD"`
+		SecondsPerSlot               uinteger  `json:"SECONDS_PER_SLOT"`
+		SlotsPerEpoch                uinteger  `json:"SLOTS_PER_EPOCH"`
+		EpochsPerSyncCommitteePeriod uinteger  `json:"EPOCHS_PER_SYNC_COMMITTEE_PERIOD"`
+		DomainDeposit                byteArray `json:"DOMAIN_DEPOSIT"`
+		DomainVoluntaryExit          byteArray `json:"DOMAIN_VOLUNTARY_EXIT"`
 	} `json:"data"`
 }
 type Eth2DepositContractResponse struct {
@@ -66,19 +68,62 @@ type ForkResponse struct {
 		Epoch           uinteger  `json:"epoch"`
 	} `json:"data"`
 }
+type ForkScheduleResponse struct {
+	Data []struct {
+		PreviousVersion byteArray `json:"previous_version"`
+		CurrentVersion  byteArray `json:"current_version"`
+		Epoch           uinteger  `json:"epoch"`
+	} `json:"data"`
+}
 type BeaconBlockResponse struct {
 	Data struct {
 		Message struct {
-			Body struct {
+			ProposerIndex uinteger `json:"proposer_index"`
+			Body          struct {
+				Graffiti byteArray `json:"graffiti"`
 				Eth1Data struct {
 					DepositRoot  byteArray `json:"deposit_root"`
 					DepositCount uinteger  `json:"deposit_count"`
 					BlockHash    byteArray `json:"block_hash"`
 				} `json:"eth1_data"`
+				ProposerSlashings []struct {
+					SignedHeader1 struct {
+						Message struct {
+							ProposerIndex uinteger `json:"proposer_index"`
+						} `json:"message"`
+					} `json:"signed_header_1"`
+				} `json:"proposer_slashings"`
+				AttesterSlashings []struct {
+					Attestation1 struct {
+						AttestingIndices []uinteger `json:"attesting_indices"`
+					} `json:"attestation_1"`
+					Attestation2 struct {
+						AttestingIndices []uinteger `json:"attesting_indices"`
+					} `json:"attestation_2"`
+				} `json:"attester_slashings"`
+				Attestations []struct {
+					AggregationBits byteArray `json:"aggregation_bits"`
+					Data            struct {
+						Slot  uinteger `json:"slot"`
+						Index uinteger `json:"index"`
+					} `json:"data"`
+				} `json:"attestations"`
 			} `json:"body"`
 		} `json:"message"`
 	} `json:"data"`
 }
+type BeaconBlockHeaderResponse struct {
+	Data struct {
+		Root      byteArray `json:"root"`
+		Canonical bool      `json:"canonical"`
+		Header    struct {
+			Message struct {
+				Slot       uinteger  `json:"slot"`
+				ParentRoot byteArray `json:"parent_root"`
+			} `json:"message"`
+		} `json:"header"`
+	} `json:"data"`
+}
 type ValidatorsResponse struct {
 	Data []Validator `json:"data"`
 }
@@ -111,6 +156,13 @@ type ProposerDutiesResponse struct {
 type ProposerDuty struct {
 	ValidatorIndex uinteger `json:"validator_index"`
 }
+type CommitteesResponse struct {
+	Data []struct {
+		Index      uinteger   `json:"index"`
+		Slot       uinteger   `json:"slot"`
+		Validators []uinteger `json:"validators"`
+	} `json:"data"`
+}
 
 // Unsigned integer type
 type uinteger uint64
@@ -0,0 +1,78 @@
+package cache
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+)
+
+// Settings
+const (
+	fileMode os.FileMode = 0644
+
+	// Buckets for the data the daemons cache
+	Bucket_RewardsEvents   string = "rewards-events"
+	Bucket_PrestakeEvents  string = "prestake-events"
+	Bucket_IntervalRewards string = "interval-rewards"
+	Bucket_RewardsClaims   string = "rewards-claims"
+)
+
+// Characters that can't be used in a cache file name
+var unsafeKeyCharacters = regexp.MustCompile(`[^a-zA-Z0-9_.-]`)
+
+// A persistent cache for data that can't change once it's on chain, like past events and the results of calls at old blocks.
+// Each entry is stored in its own file, grouped into a folder per bucket, so the daemons and API commands can share the cache
+// without locking it; entries are written to a temporary file first, so a reader never sees a partial one.
+type Cache struct {
+	folder string
+}
+
+// Create a new cache that stores its entries in the provided folder
+func NewCache(folder string) *Cache {
+	return &Cache{
+		folder: folder,
+	}
+}
+
+// Get an entry, decoding it into value. Returns false if the entry isn't cached or can't be read, so it can be fetched again.
+func (c *Cache) Get(bucket string, key string, value interface{}) bool {
+	if c == nil {
+		return false
+	}
+	bytes, err := ioutil.ReadFile(c.getPath(bucket, key))
+	if err != nil {
+		return false
+	}
+	return json.Unmarshal(bytes, value) == nil
+}
+
+// Store an entry
+func (c *Cache) Set(bucket string, key string, value interface{}) error {
+	if c == nil {
+		return nil
+	}
+	bytes, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("error serializing cache entry %s/%s: %w", bucket, key, err)
+	}
+	path := c.getPath(bucket, key)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("error creating cache folder: %w", err)
+	}
+	tempPath := path + ".tmp"
+	if err := ioutil.WriteFile(tempPath, bytes, fileMode); err != nil {
+		return fmt.Errorf("error writing cache entry %s: %w", tempPath, err)
+	}
+	if err := os.Rename(tempPath, path); err != nil {
+		return fmt.Errorf("error saving cache entry %s: %w", path, err)
+	}
+	return nil
+}
+
+// Get the path of an entry's file
+func (c *Cache) getPath(bucket string, key string) string {
+	return filepath.Join(c.folder, bucket, unsafeKeyCharacters.ReplaceAllString(key, "_")+".json")
+}
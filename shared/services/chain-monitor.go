@@ -0,0 +1,244 @@
+package services
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/fatih/color"
+
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/notifications"
+	"github.com/rocket-pool/smartnode/shared/utils/eth2"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Settings
+const (
+	// The number of recent heads to remember when looking for reorgs
+	maxRecentHeads = 64
+
+	// The number of blocks to walk back from a new head looking for a recent head it descends from
+	maxAncestorSearchDepth = 256
+)
+
+// Watches the Beacon chain for deep reorgs and finality stalls, so the daemons can pause tasks that depend on finality until it recovers
+type ChainMonitor struct {
+	bc                   beacon.Client
+	notifier             *notifications.Notifier
+	logger               log.ColorLogger
+	reorgPauseDepth      uint64
+	finalityStallEpochs  uint64
+	maxUnfinalizedEpochs uint64
+	recentHeads          []beacon.BeaconBlockHeader
+	reorgSlot            uint64
+	reorgPending         bool
+	finalityStalled      bool
+	lock                 sync.Mutex
+}
+
+// Creates a new ChainMonitor instance based on the Rocket Pool config
+func NewChainMonitor(cfg *config.RocketPoolConfig, bc beacon.Client, notifier *notifications.Notifier) *ChainMonitor {
+	return &ChainMonitor{
+		bc:                   bc,
+		notifier:             notifier,
+		logger:               log.NewColorLogger(color.FgHiRed),
+		reorgPauseDepth:      cfg.Smartnode.GetReorgPauseDepth(),
+		finalityStallEpochs:  cfg.Smartnode.GetFinalityStallEpochs(),
+		maxUnfinalizedEpochs: cfg.Smartnode.GetMaxUnfinalizedReportEpochs(),
+		recentHeads:          []beacon.BeaconBlockHeader{},
+	}
+}
+
+// Check if tasks that depend on finality should be paused, and if so, why
+func (m *ChainMonitor) IsPaused() (bool, string) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.isPaused()
+}
+
+// Check if data derived from the state at the provided block time is close enough to finality to be submitted.
+// If it isn't, this returns false and the reason so the caller can log why it's refusing to submit.
+func (m *ChainMonitor) CheckFinalityDelay(blockTime uint64) (bool, string, error) {
+	eth2Config, err := m.bc.GetEth2Config()
+	if err != nil {
+		return false, "", fmt.Errorf("Error getting the Beacon chain config: %w", err)
+	}
+	beaconHead, err := m.bc.GetBeaconHead()
+	if err != nil {
+		return false, "", fmt.Errorf("Error getting the Beacon chain finality status: %w", err)
+	}
+	epoch := eth2.EpochAt(eth2Config, blockTime)
+	if epoch <= beaconHead.FinalizedEpoch {
+		return true, "", nil
+	}
+	delay := epoch - beaconHead.FinalizedEpoch
+	if delay > m.maxUnfinalizedEpochs {
+		return false, fmt.Sprintf("epoch %d is %d epochs ahead of the finalized epoch %d, which is more than the limit of %d", epoch, delay, beaconHead.FinalizedEpoch, m.maxUnfinalizedEpochs), nil
+	}
+	return true, "", nil
+}
+
+// Check the Beacon chain for reorgs and finality stalls, pausing or resuming finality-sensitive tasks as required
+func (m *ChainMonitor) Check() error {
+
+	// Get the current head
+	head, exists, err := m.bc.GetBeaconBlockHeader("head")
+	if err != nil {
+		return fmt.Errorf("Error getting the Beacon chain head: %w", err)
+	}
+	if !exists {
+		return fmt.Errorf("The Beacon client doesn't have a head block yet")
+	}
+
+	// Look for reorgs of the heads seen so far
+	reorgDepth, err := m.checkRecentHeads(head)
+	if err != nil {
+		return err
+	}
+
+	// Get the finality status
+	beaconHead, err := m.bc.GetBeaconHead()
+	if err != nil {
+		return fmt.Errorf("Error getting the Beacon chain finality status: %w", err)
+	}
+	finalizedHeader, finalizedExists, err := m.bc.GetBeaconBlockHeader("finalized")
+	if err != nil {
+		return fmt.Errorf("Error getting the finalized Beacon block: %w", err)
+	}
+
+	// Update the state
+	m.lock.Lock()
+	wasPaused, _ := m.isPaused()
+	deepReorg := m.reorgPauseDepth > 0 && reorgDepth >= m.reorgPauseDepth
+	if deepReorg {
+		m.reorgPending = true
+		m.reorgSlot = head.Slot
+	} else if m.reorgPending && finalizedExists && finalizedHeader.Slot >= m.reorgSlot {
+		m.reorgPending = false
+	}
+	finalityLag := uint64(0)
+	if beaconHead.Epoch > beaconHead.FinalizedEpoch {
+		finalityLag = beaconHead.Epoch - beaconHead.FinalizedEpoch
+	}
+	finalityStalled := m.finalityStallEpochs > 0 && finalityLag > m.finalityStallEpochs
+	m.finalityStalled = finalityStalled
+	isPaused, _ := m.isPaused()
+	m.lock.Unlock()
+
+	// Report the changes
+	if deepReorg {
+		message := fmt.Sprintf("A Beacon chain reorg of %d slots was detected at slot %d. Tasks that depend on finality are paused until the chain finalizes past it.", reorgDepth, head.Slot)
+		m.logger.Printlnf("WARNING: %s", message)
+		m.notify(notifications.EventType_ChainReorg, "Beacon chain reorg detected", message)
+	}
+	if finalityStalled {
+		message := fmt.Sprintf("The Beacon chain hasn't finalized for %d epochs (the current epoch is %d but the finalized epoch is %d). Tasks that depend on finality are paused until it recovers.", finalityLag, beaconHead.Epoch, beaconHead.FinalizedEpoch)
+		if !wasPaused {
+			m.logger.Printlnf("WARNING: %s", message)
+		}
+		m.notify(notifications.EventType_FinalityStalled, "Beacon chain finality stalled", message)
+	}
+	if wasPaused && !isPaused {
+		m.logger.Println("Beacon chain finality has recovered, resuming tasks that depend on finality.")
+		m.notifier.Resolve(notifications.EventType_FinalityStalled)
+		m.notify(notifications.EventType_ChainRecovered, "Beacon chain finality recovered", "Beacon chain finality has recovered, so tasks that depend on finality have resumed.")
+	}
+	return nil
+
+}
+
+// Check which of the recently seen heads have been reorged out of the chain, by walking the new head's parents back to the newest head
+// that's still one of its ancestors. Returns the depth of the reorg in slots, or 0 if there wasn't one.
+func (m *ChainMonitor) checkRecentHeads(head beacon.BeaconBlockHeader) (uint64, error) {
+
+	m.lock.Lock()
+	recentHeads := make([]beacon.BeaconBlockHeader, len(m.recentHeads))
+	copy(recentHeads, m.recentHeads)
+	m.lock.Unlock()
+
+	// Walk back from the new head until reaching a recent head; everything seen after the common ancestor was orphaned
+	recentIndices := make(map[common.Hash]int, len(recentHeads))
+	for i, recentHead := range recentHeads {
+		recentIndices[recentHead.Root] = i
+	}
+	canonicalCount := 0
+	ancestorSlot := uint64(0)
+	if len(recentHeads) > 0 {
+		ancestor := head
+		for steps := 0; ; steps++ {
+			if index, exists := recentIndices[ancestor.Root]; exists {
+				canonicalCount = index + 1
+				ancestorSlot = ancestor.Slot
+				break
+			}
+
+			// The heads seen so far are all older than this, so none of them are ancestors of the new head
+			if ancestor.Slot <= recentHeads[0].Slot {
+				ancestorSlot = ancestor.Slot
+				break
+			}
+
+			// The new head is too far ahead of the heads seen so far to tell if they were orphaned, so start over from it
+			if steps >= maxAncestorSearchDepth {
+				m.logger.Printlnf("WARNING: Couldn't find a common ancestor of the Beacon chain head at slot %d within %d blocks, skipping the reorg check.", head.Slot, maxAncestorSearchDepth)
+				recentHeads = []beacon.BeaconBlockHeader{}
+				break
+			}
+
+			parent, exists, err := m.bc.GetBeaconBlockHeader(ancestor.ParentRoot.Hex())
+			if err != nil {
+				return 0, fmt.Errorf("Error getting the parent of Beacon block %s at slot %d: %w", ancestor.Root.Hex(), ancestor.Slot, err)
+			}
+			if !exists {
+				m.logger.Printlnf("WARNING: The Beacon client doesn't have the parent of block %s at slot %d, skipping the reorg check.", ancestor.Root.Hex(), ancestor.Slot)
+				recentHeads = []beacon.BeaconBlockHeader{}
+				break
+			}
+			ancestor = parent
+		}
+	}
+
+	// Get the depth of the reorg, from the common ancestor to the newest orphaned head
+	reorgDepth := uint64(0)
+	if len(recentHeads) > 0 && canonicalCount < len(recentHeads) {
+		newestOrphan := recentHeads[len(recentHeads)-1]
+		if newestOrphan.Slot > ancestorSlot {
+			reorgDepth = newestOrphan.Slot - ancestorSlot
+		}
+	}
+
+	// Forget the orphaned heads and remember the new one
+	recentHeads = recentHeads[:canonicalCount]
+	if len(recentHeads) == 0 || recentHeads[len(recentHeads)-1].Root != head.Root {
+		recentHeads = append(recentHeads, head)
+	}
+	if len(recentHeads) > maxRecentHeads {
+		recentHeads = recentHeads[len(recentHeads)-maxRecentHeads:]
+	}
+
+	m.lock.Lock()
+	m.recentHeads = recentHeads
+	m.lock.Unlock()
+	return reorgDepth, nil
+
+}
+
+// Check if finality-sensitive tasks should be paused; the lock must be held
+func (m *ChainMonitor) isPaused() (bool, string) {
+	if m.finalityStalled {
+		return true, "Beacon chain finality has stalled"
+	}
+	if m.reorgPending {
+		return true, fmt.Sprintf("the Beacon chain hasn't finalized past the reorg at slot %d yet", m.reorgSlot)
+	}
+	return false, ""
+}
+
+// Send a notification, logging any errors
+func (m *ChainMonitor) notify(eventType notifications.EventType, title string, message string) {
+	if err := m.notifier.Notify(eventType, title, message); err != nil {
+		m.logger.Printlnf("WARNING: %s", err.Error())
+	}
+}
@@ -0,0 +1,78 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/fatih/color"
+
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// A Beacon client that serves block headers by root
+type testHeaderClient struct {
+	beacon.Client
+	headers map[string]beacon.BeaconBlockHeader
+}
+
+func (c *testHeaderClient) GetBeaconBlockHeader(blockId string) (beacon.BeaconBlockHeader, bool, error) {
+	header, exists := c.headers[blockId]
+	return header, exists, nil
+}
+
+// Add a block to the test chain
+func (c *testHeaderClient) addBlock(slot uint64, root byte, parent byte) beacon.BeaconBlockHeader {
+	header := beacon.BeaconBlockHeader{Slot: slot, Root: common.Hash{root}, ParentRoot: common.Hash{parent}}
+	c.headers[header.Root.Hex()] = header
+	return header
+}
+
+func TestCheckRecentHeadsFindsCommonAncestor(t *testing.T) {
+	bc := &testHeaderClient{headers: map[string]beacon.BeaconBlockHeader{}}
+	m := &ChainMonitor{bc: bc, logger: log.NewColorLogger(color.FgHiRed)}
+	check := func(head beacon.BeaconBlockHeader, expectedDepth uint64, expectedHeads ...beacon.BeaconBlockHeader) {
+		t.Helper()
+		depth, err := m.checkRecentHeads(head)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if depth != expectedDepth {
+			t.Fatalf("expected a reorg depth of %d at slot %d, got %d", expectedDepth, head.Slot, depth)
+		}
+		if len(m.recentHeads) != len(expectedHeads) {
+			t.Fatalf("expected %d recent heads, got %v", len(expectedHeads), m.recentHeads)
+		}
+		for i, expected := range expectedHeads {
+			if m.recentHeads[i].Root != expected.Root {
+				t.Fatalf("expected recent head %d to be slot %d, got slot %d", i, expected.Slot, m.recentHeads[i].Slot)
+			}
+		}
+	}
+
+	// The chain grows without reorgs, skipping slot 3
+	a := bc.addBlock(1, 0xa, 0)
+	b := bc.addBlock(2, 0xb, 0xa)
+	c := bc.addBlock(4, 0xc, 0xb)
+	check(a, 0, a)
+	check(b, 0, a, b)
+	check(c, 0, a, b, c)
+
+	// A fork from b orphans c; the new head's parent was never seen as a head
+	bc.addBlock(5, 0xd, 0xb)
+	e := bc.addBlock(6, 0xe, 0xd)
+	check(e, 2, a, b, e)
+
+	// The same head again isn't a reorg
+	check(e, 0, a, b, e)
+
+	// A deep fork from a orphans everything after it
+	bc.addBlock(3, 0xf, 0xa)
+	g := bc.addBlock(7, 0x1, 0xf)
+	check(g, 5, a, g)
+
+	// A fork from before every recent head orphans all of them
+	bc.addBlock(0, 0x3, 0)
+	h := bc.addBlock(8, 0x2, 0x3)
+	check(h, 7, h)
+}
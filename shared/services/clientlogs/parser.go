@@ -0,0 +1,229 @@
+package clientlogs
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+)
+
+// The kinds of events that can be read from a client's logs
+type EventKind string
+
+const (
+	EventKind_SyncMilestone EventKind = "sync"
+	EventKind_PeerDrop      EventKind = "peers"
+	EventKind_Error         EventKind = "error"
+)
+
+// An event read from a client's logs.
+// Consecutive lines matching the same rule are condensed into one event, which keeps the most recent line.
+type Event struct {
+	Kind      EventKind `json:"kind"`
+	Client    string    `json:"client"`
+	Summary   string    `json:"summary"`
+	Line      string    `json:"line"`
+	Count     int       `json:"count"`
+	FirstTime time.Time `json:"firstTime,omitempty"`
+	Time      time.Time `json:"time,omitempty"`
+	rule      int
+}
+
+// A log line pattern and the event it describes.
+// The summary can refer to the pattern's groups, e.g. ${mode}, and to the line's message as {message}.
+type rule struct {
+	kind    EventKind
+	pattern *regexp.Regexp
+	summary string
+}
+
+// Converts a client's log lines into events
+type Parser struct {
+	client string
+	rules  []rule
+}
+
+// Create a parser for an Execution client's logs
+func NewExecutionParser(client config.ExecutionClient) *Parser {
+	var rules []rule
+	switch client {
+	case config.ExecutionClient_Geth, config.ExecutionClient_Erigon:
+		rules = []rule{
+			newRule(EventKind_Error, `(?-i)^(ERROR|CRIT|\[EROR\]|\[CRIT\])|^Fatal:`, "Error: {message}"),
+			newRule(EventKind_PeerDrop, `Looking for peers.*peercount=0\b|\bpeers=0\b`, "No peers connected"),
+			newRule(EventKind_SyncMilestone, `Snap sync complete|Sync completed|Synchronisation completed`, "Sync complete"),
+			newRule(EventKind_SyncMilestone, `Block synchronisation started|Syncing beacon headers|Forkchoice requested sync to new head`, "Sync started"),
+			newRule(EventKind_SyncMilestone, `Rewinding blockchain|Rewound to block`, "Chain rewound"),
+			newRule(EventKind_SyncMilestone, `Generated state snapshot`, "State snapshot generated"),
+		}
+	case config.ExecutionClient_Nethermind:
+		rules = []rule{
+			newRule(EventKind_Error, `(?-i)\|\s*ERROR\s*\||\bException\b`, "Error: {message}"),
+			newRule(EventKind_PeerDrop, `Peers \| with known best block: 0 \||\bpeers:? 0\b`, "No peers connected"),
+			newRule(EventKind_SyncMilestone, `Changing sync (FastSync|SnapSync|StateNodes)?.*to (?P<mode>Full)\b|Fast sync finished|Snap sync finished|Sync mode.*WaitingForBlock`, "Sync complete"),
+			newRule(EventKind_SyncMilestone, `Changing state.*to (?P<mode>FastSync|SnapSync|StateNodes|FastHeaders|Beacon\w*)`, "Sync stage: ${mode}"),
+			newRule(EventKind_SyncMilestone, `Full Pruning (Started|Finished)`, "Full pruning ${1}"),
+		}
+	case config.ExecutionClient_Besu:
+		rules = []rule{
+			newRule(EventKind_Error, `(?-i)\|\s*(ERROR|FATAL)\s*\|`, "Error: {message}"),
+			newRule(EventKind_PeerDrop, `Waiting for (\d+ )?peers|0 peers\b|peer count.*\b0\b`, "No peers connected"),
+			newRule(EventKind_SyncMilestone, `(Fast|Snap|Checkpoint) sync completed|Sync completed|Starting full sync`, "Sync complete"),
+			newRule(EventKind_SyncMilestone, `Starting (fast|snap|checkpoint) sync|Starting world state download`, "Sync started"),
+		}
+	}
+	return &Parser{
+		client: string(client),
+		rules:  rules,
+	}
+}
+
+// Create a parser for a Consensus client's logs
+func NewConsensusParser(client config.ConsensusClient) *Parser {
+	var rules []rule
+	switch client {
+	case config.ConsensusClient_Lighthouse:
+		rules = []rule{
+			newRule(EventKind_Error, `(?-i)\b(ERRO|CRIT)\b`, "Error: {message}"),
+			newRule(EventKind_PeerDrop, `Low peer count|peers: "0"|\bpeers: 0\b`, "Low peer count"),
+			newRule(EventKind_SyncMilestone, `Synced\b.*slot`, "Synced"),
+			newRule(EventKind_SyncMilestone, `Syncing\b.*est_time`, "Syncing"),
+			newRule(EventKind_SyncMilestone, `Loaded checkpoint (block|state)|Starting checkpoint sync`, "Checkpoint sync"),
+			newRule(EventKind_SyncMilestone, `Historical block download complete`, "Historical blocks downloaded"),
+		}
+	case config.ConsensusClient_Prysm:
+		rules = []rule{
+			newRule(EventKind_Error, `level=(error|fatal)`, "Error: {message}"),
+			newRule(EventKind_PeerDrop, `peers=0\b|No peers|Waiting for enough suitable peers`, "No peers connected"),
+			newRule(EventKind_SyncMilestone, `Synced up to slot|Synced new block`, "Synced"),
+			newRule(EventKind_SyncMilestone, `Processing block batch|Starting initial chain sync`, "Syncing"),
+			newRule(EventKind_SyncMilestone, `checkpoint sync|Loading checkpoint`, "Checkpoint sync"),
+		}
+	case config.ConsensusClient_Teku:
+		rules = []rule{
+			newRule(EventKind_Error, `(?-i)\b(ERROR|FATAL)\b`, "Error: {message}"),
+			newRule(EventKind_PeerDrop, `Peers:\s*0\b|peers 0\b`, "No peers connected"),
+			newRule(EventKind_SyncMilestone, `Syncing completed|Slot Event.*Sync: Synced`, "Synced"),
+			newRule(EventKind_SyncMilestone, `Sync Event \*\*\*`, "Syncing"),
+			newRule(EventKind_SyncMilestone, `Loaded initial state|Loading initial state`, "Checkpoint sync"),
+		}
+	case config.ConsensusClient_Nimbus:
+		rules = []rule{
+			newRule(EventKind_Error, `(?-i)^(ERR|FAT)\b`, "Error: {message}"),
+			newRule(EventKind_PeerDrop, `peers=0\b|No peers`, "No peers connected"),
+			newRule(EventKind_SyncMilestone, `sync=synced|Synced to head`, "Synced"),
+			newRule(EventKind_SyncMilestone, `Syncing\b|sync="\d+[dhm]`, "Syncing"),
+			newRule(EventKind_SyncMilestone, `trusted node sync|Downloading checkpoint`, "Checkpoint sync"),
+		}
+	}
+	return &Parser{
+		client: string(client),
+		rules:  rules,
+	}
+}
+
+// Get the name of the client this parser reads
+func (p *Parser) Client() string {
+	return p.client
+}
+
+// Convert a single log line into an event, if it matches one of the client's rules.
+// Lines can start with the timestamp Docker adds when logs are requested with timestamps.
+func (p *Parser) ParseLine(line string) (Event, bool) {
+
+	line, timestamp := splitTimestamp(strings.TrimSpace(line))
+	if line == "" {
+		return Event{}, false
+	}
+	for i, r := range p.rules {
+		match := r.pattern.FindStringSubmatchIndex(line)
+		if match == nil {
+			continue
+		}
+		summary := string(r.pattern.ExpandString(nil, r.summary, line, match))
+		summary = strings.Replace(summary, "{message}", getMessage(line), -1)
+		return Event{
+			Kind:      r.kind,
+			Client:    p.client,
+			Summary:   summary,
+			Line:      line,
+			Count:     1,
+			FirstTime: timestamp,
+			Time:      timestamp,
+			rule:      i,
+		}, true
+	}
+	return Event{}, false
+
+}
+
+// Convert a block of log lines into events, condensing consecutive matches of the same rule
+func (p *Parser) Parse(logs []byte) []Event {
+
+	events := []Event{}
+	for _, line := range strings.Split(string(logs), "\n") {
+		event, matched := p.ParseLine(line)
+		if !matched {
+			continue
+		}
+		if len(events) > 0 {
+			last := &events[len(events)-1]
+			if last.rule == event.rule {
+				last.Count++
+				last.Summary = event.Summary
+				last.Line = event.Line
+				last.Time = event.Time
+				continue
+			}
+		}
+		events = append(events, event)
+	}
+	return events
+
+}
+
+// Compile a rule, which is case-insensitive unless its pattern turns that off with (?-i)
+func newRule(kind EventKind, pattern string, summary string) rule {
+	return rule{
+		kind:    kind,
+		pattern: regexp.MustCompile("(?i)" + pattern),
+		summary: summary,
+	}
+}
+
+// The timestamp Docker puts at the start of each line, and the timestamps, levels, and module names clients put before their messages
+var dockerTimestamp = regexp.MustCompile(`^(\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(\.\d+)?(Z|[+-]\d{2}:\d{2}))\s+`)
+var messagePrefix = regexp.MustCompile(`^(?i)(\[?(INFO|WARN|WRN|ERROR|EROR|ERRO|ERR|CRIT|FATAL|FAT|DEBUG|TRACE)\]?\s*)?(\[[^\]]*\]\s*)?(\w{3} \d{1,2} \d{2}:\d{2}:\d{2}(\.\d+)?\s+)?(\d{4}-\d{2}-\d{2}[ T][\d:.+\-]+\s*(\|\s*)?)?(\d{2}:\d{2}:\d{2}\.\d+\s+)?((INFO|WARN|WRN|ERROR|EROR|ERRO|ERR|CRIT|FATAL|FAT)\s*(-\s*|\|\s*)?)?`)
+var prysmMessage = regexp.MustCompile(`msg="((\\"|[^"])*)"`)
+
+// Split the Docker timestamp off the start of a line
+func splitTimestamp(line string) (string, time.Time) {
+	match := dockerTimestamp.FindStringSubmatch(line)
+	if match == nil {
+		return line, time.Time{}
+	}
+	timestamp, err := time.Parse(time.RFC3339Nano, match[1])
+	if err != nil {
+		return line, time.Time{}
+	}
+	return line[len(match[0]):], timestamp
+}
+
+// Get the message of a log line without the client's timestamp and level, shortened for display
+func getMessage(line string) string {
+	if match := prysmMessage.FindStringSubmatch(line); match != nil {
+		return shorten(strings.Replace(match[1], `\"`, `"`, -1))
+	}
+	return shorten(strings.TrimSpace(messagePrefix.ReplaceAllString(line, "")))
+}
+
+// Shorten a message to a length that fits in a notification or a table row
+func shorten(message string) string {
+	const maxLength = 120
+	message = strings.Join(strings.Fields(message), " ")
+	if len(message) <= maxLength {
+		return message
+	}
+	return message[:maxLength-3] + "..."
+}
@@ -0,0 +1,133 @@
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+// A source of the current time and of timed waits.
+// The daemons' tasks and loops use it instead of the time package directly, so their scheduling can be driven by a manual clock.
+type Clock interface {
+	// Get the current time
+	Now() time.Time
+
+	// Get the time that has passed since t
+	Since(t time.Time) time.Duration
+
+	// Get the time left until t
+	Until(t time.Time) time.Duration
+
+	// Wait for d to pass
+	Sleep(d time.Duration)
+
+	// Get a channel that receives the current time once d has passed
+	After(d time.Duration) <-chan time.Time
+}
+
+// The system clock
+type systemClock struct{}
+
+// Create a clock that uses the system time
+func NewSystemClock() Clock {
+	return systemClock{}
+}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) Since(t time.Time) time.Duration {
+	return time.Since(t)
+}
+
+func (systemClock) Until(t time.Time) time.Duration {
+	return time.Until(t)
+}
+
+func (systemClock) Sleep(d time.Duration) {
+	time.Sleep(d)
+}
+
+func (systemClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// A wait on a manual clock
+type waiter struct {
+	until time.Time
+	ch    chan time.Time
+}
+
+// A clock that only moves when it's advanced, so scheduling logic can be stepped through deterministically.
+// Sleeps and waits on it finish once the clock is advanced past their deadline.
+type ManualClock struct {
+	now     time.Time
+	waiters []waiter
+	lock    sync.Mutex
+}
+
+// Create a manual clock that starts at the provided time
+func NewManualClock(start time.Time) *ManualClock {
+	return &ManualClock{
+		now: start,
+	}
+}
+
+func (c *ManualClock) Now() time.Time {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.now
+}
+
+func (c *ManualClock) Since(t time.Time) time.Duration {
+	return c.Now().Sub(t)
+}
+
+func (c *ManualClock) Until(t time.Time) time.Duration {
+	return t.Sub(c.Now())
+}
+
+func (c *ManualClock) Sleep(d time.Duration) {
+	<-c.After(d)
+}
+
+func (c *ManualClock) After(d time.Duration) <-chan time.Time {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	ch := make(chan time.Time, 1)
+	until := c.now.Add(d)
+	if d <= 0 {
+		ch <- c.now
+		return ch
+	}
+	c.waiters = append(c.waiters, waiter{until: until, ch: ch})
+	return ch
+}
+
+// Get the number of sleeps and waits that haven't finished yet, so a caller can tell when a loop is waiting on the clock
+func (c *ManualClock) Waiters() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return len(c.waiters)
+}
+
+// Move the clock forward, finishing the sleeps and waits that are due
+func (c *ManualClock) Advance(d time.Duration) {
+	c.Set(c.Now().Add(d))
+}
+
+// Move the clock to the provided time, finishing the sleeps and waits that are due
+func (c *ManualClock) Set(t time.Time) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.now = t
+	pending := []waiter{}
+	for _, w := range c.waiters {
+		if w.until.After(t) {
+			pending = append(pending, w)
+		} else {
+			w.ch <- t
+		}
+	}
+	c.waiters = pending
+}
@@ -0,0 +1,157 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Get the value of a bool parameter
+func (param *Parameter) GetBool() (bool, error) {
+	value, ok := param.Value.(bool)
+	if !ok {
+		return false, param.getTypeError("bool")
+	}
+	return value, nil
+}
+
+// Get the value of a string parameter
+func (param *Parameter) GetString() (string, error) {
+	value, ok := param.Value.(string)
+	if !ok {
+		return "", param.getTypeError("string")
+	}
+	return value, nil
+}
+
+// Get the value of a uint16 parameter
+func (param *Parameter) GetUint16() (uint16, error) {
+	value, ok := param.Value.(uint16)
+	if !ok {
+		return 0, param.getTypeError("uint16")
+	}
+	return value, nil
+}
+
+// Get the value of a uint parameter
+func (param *Parameter) GetUint() (uint64, error) {
+	value, ok := param.Value.(uint64)
+	if !ok {
+		return 0, param.getTypeError("uint64")
+	}
+	return value, nil
+}
+
+// Get the value of an int parameter
+func (param *Parameter) GetInt() (int64, error) {
+	value, ok := param.Value.(int64)
+	if !ok {
+		return 0, param.getTypeError("int64")
+	}
+	return value, nil
+}
+
+// Get the value of a float parameter
+func (param *Parameter) GetFloat() (float64, error) {
+	value, ok := param.Value.(float64)
+	if !ok {
+		return 0, param.getTypeError("float64")
+	}
+	return value, nil
+}
+
+// Get the value of a bool parameter, or its default for the provided network if the value is invalid
+func (param *Parameter) GetBoolOrDefault(network Network) bool {
+	if value, err := param.GetBool(); err == nil {
+		return value
+	}
+	defaultValue, _ := param.GetDefault(network)
+	value, _ := defaultValue.(bool)
+	return value
+}
+
+// Get the value of a string parameter, or its default for the provided network if the value is invalid
+func (param *Parameter) GetStringOrDefault(network Network) string {
+	if value, err := param.GetString(); err == nil {
+		return value
+	}
+	defaultValue, _ := param.GetDefault(network)
+	value, _ := defaultValue.(string)
+	return value
+}
+
+// Get the value of a uint16 parameter, or its default for the provided network if the value is invalid
+func (param *Parameter) GetUint16OrDefault(network Network) uint16 {
+	if value, err := param.GetUint16(); err == nil {
+		return value
+	}
+	defaultValue, _ := param.GetDefault(network)
+	value, _ := defaultValue.(uint16)
+	return value
+}
+
+// Get the value of a uint parameter, or its default for the provided network if the value is invalid
+func (param *Parameter) GetUintOrDefault(network Network) uint64 {
+	if value, err := param.GetUint(); err == nil {
+		return value
+	}
+	defaultValue, _ := param.GetDefault(network)
+	value, _ := defaultValue.(uint64)
+	return value
+}
+
+// Get the value of a float parameter, or its default for the provided network if the value is invalid
+func (param *Parameter) GetFloatOrDefault(network Network) float64 {
+	if value, err := param.GetFloat(); err == nil {
+		return value
+	}
+	defaultValue, _ := param.GetDefault(network)
+	value, _ := defaultValue.(float64)
+	return value
+}
+
+// Check that the parameter's value has the type required by the parameter's type
+func (param *Parameter) CheckValueType() error {
+	var err error
+	switch param.Type {
+	case ParameterType_Bool:
+		_, err = param.GetBool()
+	case ParameterType_String:
+		_, err = param.GetString()
+	case ParameterType_Uint16:
+		_, err = param.GetUint16()
+	case ParameterType_Uint:
+		_, err = param.GetUint()
+	case ParameterType_Int:
+		_, err = param.GetInt()
+	case ParameterType_Float:
+		_, err = param.GetFloat()
+	case ParameterType_Choice:
+		if len(param.Options) > 0 && reflect.TypeOf(param.Value) != reflect.TypeOf(param.Options[0].Value) {
+			err = param.getTypeError(reflect.TypeOf(param.Options[0].Value).Name())
+		}
+	}
+	return err
+}
+
+// Create an error for a parameter that has a value of the wrong type
+func (param *Parameter) getTypeError(expectedType string) error {
+	return fmt.Errorf("setting [%s] has value [%v] of type %T, but it must be a %s", param.ID, param.Value, param.Value, expectedType)
+}
+
+// Get the selected Execution client mode
+func (config *RocketPoolConfig) GetExecutionClientMode() Mode {
+	mode, _ := config.ExecutionClientMode.Value.(Mode)
+	return mode
+}
+
+// Get the selected fallback Execution client mode
+func (config *RocketPoolConfig) GetFallbackExecutionClientMode() Mode {
+	mode, _ := config.FallbackExecutionClientMode.Value.(Mode)
+	return mode
+}
+
+// Get the selected Consensus client mode
+func (config *RocketPoolConfig) GetConsensusClientMode() Mode {
+	mode, _ := config.ConsensusClientMode.Value.(Mode)
+	return mode
+}
@@ -0,0 +1,310 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Constants
+const (
+	// The folder in the Rocket Pool directory that holds the generated alerting files
+	AlertingFolder         string = "alerting"
+	AlertRulesFile         string = "rules.yml"
+	AlertmanagerConfigFile string = "alertmanager.yml"
+
+	// The path of the alert rules inside the Prometheus container
+	AlertRulesContainerPath string = "/etc/prometheus/rules.yml"
+
+	// The path of the Alertmanager config inside the Alertmanager container
+	alertmanagerConfigContainerPath string = "/etc/alertmanager/alertmanager.yml"
+
+	alertReceiverName string = "smartnode"
+)
+
+// The default Prometheus alert rules
+const alertRulesTemplate string = `# This file is generated by the Smartnode; any changes will be overwritten.
+groups:
+  - name: smartnode
+    rules:
+      - alert: ValidatorClientOffline
+        expr: up{job="validator"} == 0
+        for: 5m
+        labels:
+          severity: critical
+        annotations:
+          summary: "Validator client is offline"
+          description: "Prometheus hasn't been able to reach your validator client for 5 minutes, so your validators are probably not attesting."
+      - alert: TargetOffline
+        expr: up{job!="validator"} == 0
+        for: 5m
+        labels:
+          severity: warning
+        annotations:
+          summary: "{{"{{"}} $labels.job {{"}}"}} is offline"
+          description: "Prometheus hasn't been able to reach {{"{{"}} $labels.job {{"}}"}} for 5 minutes."
+      - alert: ExecutionClientOutOfSync
+        expr: rocketpool_sync_ec_synced == 0
+        for: 15m
+        labels:
+          severity: critical
+        annotations:
+          summary: "Execution client is out of sync"
+          description: "Your primary execution client has not been synced for 15 minutes."
+      - alert: ConsensusClientOutOfSync
+        expr: rocketpool_sync_cc_synced == 0
+        for: 15m
+        labels:
+          severity: critical
+        annotations:
+          summary: "Consensus client is out of sync"
+          description: "Your consensus client has not been synced for 15 minutes."
+      - alert: DiskNearlyFull
+        expr: node_filesystem_avail_bytes{fstype!~"tmpfs|overlay|squashfs"} / node_filesystem_size_bytes{fstype!~"tmpfs|overlay|squashfs"} * 100 < {{.DiskSpaceThreshold}}
+        for: 10m
+        labels:
+          severity: warning
+        annotations:
+          summary: "Disk is nearly full"
+          description: "{{"{{"}} $labels.mountpoint {{"}}"}} has less than {{.DiskSpaceThreshold}}% free space left."
+      - alert: MissedAttestations
+        expr: delta(rocketpool_node_beacon_balance[30m]) < 0
+        for: 30m
+        labels:
+          severity: warning
+        annotations:
+          summary: "Validators are missing attestations"
+          description: "Your validators' total Beacon Chain balance has been going down for the last hour, which usually means they are missing attestations."
+{{- if .RethMaxDeviation}}
+      - alert: RethRateDeviation
+        expr: abs(rocketpool_reth_deviation) > {{.RethMaxDeviation}}
+        for: 30m
+        labels:
+          severity: warning
+        annotations:
+          summary: "rETH rate deviation"
+          description: "rETH has been trading {{"{{"}} $value | printf \"%.2f\" {{"}}"}}% away from the protocol rate on secondary markets for 30 minutes."
+{{- end}}
+`
+
+var parsedAlertRulesTemplate = template.Must(template.New("rules").Parse(alertRulesTemplate))
+
+// The Docker Compose file for the Alertmanager container
+const alertmanagerComposeTemplate string = `# This file is generated by the Smartnode; any changes will be overwritten.
+# Use the override folder to customize this container instead.
+version: "{{.Version}}"
+services:
+  {{.Service}}:
+    image: {{.Image}}
+    container_name: {{.ProjectName}}_{{.Service}}
+    restart: unless-stopped
+    command:
+      - "--config.file={{.ConfigPath}}"
+      - "--web.listen-address=:{{.Port}}"
+      - "--storage.path=/alertmanager"
+{{- if .OpenPort}}
+    ports:
+      - "{{.Port}}:{{.Port}}/tcp"
+{{- end}}
+    volumes:
+      - "{{.ConfigFile}}:{{.ConfigPath}}:ro"
+      - "alertmanager-data:/alertmanager"
+    networks:
+      - net
+networks:
+  net:
+volumes:
+  alertmanager-data:
+`
+
+var parsedAlertmanagerComposeTemplate = template.Must(template.New("alertmanager").Parse(alertmanagerComposeTemplate))
+
+// The Alertmanager configuration file
+type alertmanagerFile struct {
+	Global    *alertmanagerGlobal    `yaml:"global,omitempty"`
+	Route     alertmanagerRoute      `yaml:"route"`
+	Receivers []alertmanagerReceiver `yaml:"receivers"`
+}
+
+type alertmanagerGlobal struct {
+	SmtpFrom         string `yaml:"smtp_from,omitempty"`
+	SmtpSmarthost    string `yaml:"smtp_smarthost,omitempty"`
+	SmtpAuthUsername string `yaml:"smtp_auth_username,omitempty"`
+	SmtpAuthPassword string `yaml:"smtp_auth_password,omitempty"`
+}
+
+type alertmanagerRoute struct {
+	Receiver       string   `yaml:"receiver"`
+	GroupBy        []string `yaml:"group_by"`
+	GroupWait      string   `yaml:"group_wait"`
+	GroupInterval  string   `yaml:"group_interval"`
+	RepeatInterval string   `yaml:"repeat_interval"`
+}
+
+type alertmanagerReceiver struct {
+	Name           string                `yaml:"name"`
+	SlackConfigs   []alertmanagerSlack   `yaml:"slack_configs,omitempty"`
+	WebhookConfigs []alertmanagerWebhook `yaml:"webhook_configs,omitempty"`
+	EmailConfigs   []alertmanagerEmail   `yaml:"email_configs,omitempty"`
+}
+
+type alertmanagerSlack struct {
+	ApiUrl       string `yaml:"api_url"`
+	SendResolved bool   `yaml:"send_resolved"`
+}
+
+type alertmanagerWebhook struct {
+	Url          string `yaml:"url"`
+	SendResolved bool   `yaml:"send_resolved"`
+}
+
+type alertmanagerEmail struct {
+	To           string `yaml:"to"`
+	SendResolved bool   `yaml:"send_resolved"`
+}
+
+// Generates the Alertmanager configuration file from the alerting settings
+func (config *RocketPoolConfig) GenerateAlertmanagerConfig() ([]byte, error) {
+
+	alertmanager := config.Alertmanager
+	receiver := alertmanagerReceiver{
+		Name: alertReceiverName,
+	}
+	file := alertmanagerFile{
+		Route: alertmanagerRoute{
+			Receiver:       alertReceiverName,
+			GroupBy:        []string{"alertname"},
+			GroupWait:      "30s",
+			GroupInterval:  "5m",
+			RepeatInterval: "4h",
+		},
+	}
+
+	// Add the notification channels that have been set up
+	if slackUrl, _ := alertmanager.SlackWebhookUrl.GetString(); slackUrl != "" {
+		receiver.SlackConfigs = append(receiver.SlackConfigs, alertmanagerSlack{
+			ApiUrl:       slackUrl,
+			SendResolved: true,
+		})
+	}
+	if webhookUrl, _ := alertmanager.WebhookUrl.GetString(); webhookUrl != "" {
+		receiver.WebhookConfigs = append(receiver.WebhookConfigs, alertmanagerWebhook{
+			Url:          webhookUrl,
+			SendResolved: true,
+		})
+	}
+	if emailTo, _ := alertmanager.EmailTo.GetString(); emailTo != "" {
+		file.Global = &alertmanagerGlobal{
+			SmtpFrom:         alertmanager.EmailFrom.GetStringOrDefault(Network_All),
+			SmtpSmarthost:    alertmanager.SmtpHost.GetStringOrDefault(Network_All),
+			SmtpAuthUsername: alertmanager.SmtpUsername.GetStringOrDefault(Network_All),
+			SmtpAuthPassword: alertmanager.SmtpPassword.GetStringOrDefault(Network_All),
+		}
+		receiver.EmailConfigs = append(receiver.EmailConfigs, alertmanagerEmail{
+			To:           emailTo,
+			SendResolved: true,
+		})
+	}
+	file.Receivers = []alertmanagerReceiver{receiver}
+
+	contents, err := yaml.Marshal(file)
+	if err != nil {
+		return nil, fmt.Errorf("error serializing Alertmanager config: %w", err)
+	}
+	header := "# This file is generated by the Smartnode from your alerting settings; any changes will be overwritten.\n"
+	return append([]byte(header), contents...), nil
+
+}
+
+// Generates the Prometheus alert rules
+func (config *RocketPoolConfig) GenerateAlertRules() ([]byte, error) {
+	var rethMaxDeviation float64
+	if config.Smartnode.GetRethPriceSource() != RethPriceSource_Disabled {
+		rethMaxDeviation = config.Smartnode.GetRethMaxDeviation()
+	}
+	var buffer bytes.Buffer
+	err := parsedAlertRulesTemplate.Execute(&buffer, struct {
+		DiskSpaceThreshold uint64
+		RethMaxDeviation   float64
+	}{
+		DiskSpaceThreshold: config.Alertmanager.DiskSpaceThreshold.GetUintOrDefault(Network_All),
+		RethMaxDeviation:   rethMaxDeviation,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error rendering alert rules: %w", err)
+	}
+	return buffer.Bytes(), nil
+}
+
+// Generates the Docker Compose file for the Alertmanager container, which mounts the provided config file
+func (config *RocketPoolConfig) GenerateAlertmanagerComposeFile(configFile string) ([]byte, error) {
+	var buffer bytes.Buffer
+	err := parsedAlertmanagerComposeTemplate.Execute(&buffer, struct {
+		Version     string
+		Service     string
+		Image       string
+		ProjectName string
+		Port        uint16
+		OpenPort    bool
+		ConfigFile  string
+		ConfigPath  string
+	}{
+		Version:     composeFragmentVersion,
+		Service:     AlertmanagerContainerName,
+		Image:       config.Alertmanager.ContainerTag.GetStringOrDefault(Network_All),
+		ProjectName: config.Smartnode.GetProjectName(),
+		Port:        config.Alertmanager.Port.GetUint16OrDefault(Network_All),
+		OpenPort:    config.Alertmanager.OpenPort.Value == true,
+		ConfigFile:  configFile,
+		ConfigPath:  alertmanagerConfigContainerPath,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error rendering Alertmanager compose file: %w", err)
+	}
+	return buffer.Bytes(), nil
+}
+
+// Adds the Alertmanager target and the alert rules to a Prometheus config file
+func (config *RocketPoolConfig) AddAlertingToPrometheusConfig(contents []byte) ([]byte, error) {
+
+	var prometheusConfig yaml.MapSlice
+	if err := yaml.Unmarshal(contents, &prometheusConfig); err != nil {
+		return nil, fmt.Errorf("error parsing Prometheus config: %w", err)
+	}
+
+	alerting := yaml.MapSlice{
+		{Key: "alertmanagers", Value: []yaml.MapSlice{
+			{
+				{Key: "static_configs", Value: []yaml.MapSlice{
+					{
+						{Key: "targets", Value: []string{
+							fmt.Sprintf("%s:%d", AlertmanagerContainerName, config.Alertmanager.Port.GetUint16OrDefault(Network_All)),
+						}},
+					},
+				}},
+			},
+		}},
+	}
+
+	// Replace any existing alerting settings
+	updatedConfig := yaml.MapSlice{}
+	for _, item := range prometheusConfig {
+		if item.Key == "alerting" || item.Key == "rule_files" {
+			continue
+		}
+		updatedConfig = append(updatedConfig, item)
+	}
+	updatedConfig = append(updatedConfig,
+		yaml.MapItem{Key: "alerting", Value: alerting},
+		yaml.MapItem{Key: "rule_files", Value: []string{AlertRulesContainerPath}},
+	)
+
+	updatedContents, err := yaml.Marshal(updatedConfig)
+	if err != nil {
+		return nil, fmt.Errorf("error serializing Prometheus config: %w", err)
+	}
+	return updatedContents, nil
+
+}
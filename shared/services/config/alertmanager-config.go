@@ -0,0 +1,213 @@
+package config
+
+// Constants
+const alertmanagerTag string = "prom/alertmanager:v0.24.0"
+
+// Defaults
+const (
+	defaultAlertmanagerPort          uint16 = 9093
+	defaultAlertmanagerOpenPort      bool   = false
+	defaultAlertmanagerDiskThreshold uint64 = 10
+)
+
+// Configuration for Alertmanager, which sends notifications for the alerts raised by Prometheus
+type AlertmanagerConfig struct {
+	Title string `yaml:"-"`
+
+	// The port to serve the Alertmanager UI and API on
+	Port Parameter `yaml:"port,omitempty"`
+
+	// Toggle for forwarding the port outside of Docker
+	OpenPort Parameter `yaml:"openPort,omitempty"`
+
+	// The Docker Hub tag for Alertmanager
+	ContainerTag Parameter `yaml:"containerTag,omitempty"`
+
+	// The percentage of free disk space below which an alert is raised
+	DiskSpaceThreshold Parameter `yaml:"diskSpaceThreshold,omitempty"`
+
+	// Slack-compatible webhook to send notifications to
+	SlackWebhookUrl Parameter `yaml:"slackWebhookUrl,omitempty"`
+
+	// Generic webhook to send notifications to
+	WebhookUrl Parameter `yaml:"webhookUrl,omitempty"`
+
+	// Email address to send notifications to
+	EmailTo Parameter `yaml:"emailTo,omitempty"`
+
+	// Email address to send notifications from
+	EmailFrom Parameter `yaml:"emailFrom,omitempty"`
+
+	// SMTP server used to send email notifications
+	SmtpHost Parameter `yaml:"smtpHost,omitempty"`
+
+	// Username for the SMTP server
+	SmtpUsername Parameter `yaml:"smtpUsername,omitempty"`
+
+	// Password for the SMTP server
+	SmtpPassword Parameter `yaml:"smtpPassword,omitempty"`
+}
+
+// Generates a new Alertmanager config
+func NewAlertmanagerConfig(config *RocketPoolConfig) *AlertmanagerConfig {
+	return &AlertmanagerConfig{
+		Title: "Alertmanager Settings",
+
+		Port: Parameter{
+			ID:                   "port",
+			Name:                 "Alertmanager Port",
+			Description:          "The port Alertmanager should make its web interface and API available on.",
+			Type:                 ParameterType_Uint16,
+			Default:              map[Network]interface{}{Network_All: defaultAlertmanagerPort},
+			AffectsContainers:    []ContainerID{ContainerID_Alertmanager, ContainerID_Prometheus},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		OpenPort: Parameter{
+			ID:                   "openPort",
+			Name:                 "Expose Alertmanager Port",
+			Description:          "Enable this to expose Alertmanager's port to your local network, so other machines can access its web interface too.",
+			Type:                 ParameterType_Bool,
+			Default:              map[Network]interface{}{Network_All: defaultAlertmanagerOpenPort},
+			AffectsContainers:    []ContainerID{ContainerID_Alertmanager},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		ContainerTag: Parameter{
+			ID:                   "containerTag",
+			Name:                 "Alertmanager Container Tag",
+			Description:          "The tag name of the Alertmanager container you want to use on Docker Hub.",
+			Type:                 ParameterType_String,
+			Default:              map[Network]interface{}{Network_All: alertmanagerTag},
+			AffectsContainers:    []ContainerID{ContainerID_Alertmanager},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   true,
+		},
+
+		DiskSpaceThreshold: Parameter{
+			ID:                   "diskSpaceThreshold",
+			Name:                 "Low Disk Space Threshold",
+			Description:          "Raise an alert when the free space on any of your disks drops below this percentage.",
+			Type:                 ParameterType_Uint,
+			Default:              map[Network]interface{}{Network_All: defaultAlertmanagerDiskThreshold},
+			AffectsContainers:    []ContainerID{ContainerID_Prometheus},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		SlackWebhookUrl: Parameter{
+			ID:                   "slackWebhookUrl",
+			Name:                 "Slack / Discord Webhook URL",
+			Description:          "The URL of a Slack incoming webhook to send alerts to. Discord webhooks can be used too by adding `/slack` to the end of their URL.\n\nLeave this blank if you don't want to use Slack or Discord.",
+			Type:                 ParameterType_String,
+			Default:              map[Network]interface{}{Network_All: ""},
+			AffectsContainers:    []ContainerID{ContainerID_Alertmanager},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+			Sensitive:            true,
+		},
+
+		WebhookUrl: Parameter{
+			ID:                   "webhookUrl",
+			Name:                 "Webhook URL",
+			Description:          "The URL of a generic webhook that Alertmanager will POST its alerts to as JSON.\n\nLeave this blank if you don't want to use a webhook.",
+			Type:                 ParameterType_String,
+			Default:              map[Network]interface{}{Network_All: ""},
+			AffectsContainers:    []ContainerID{ContainerID_Alertmanager},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+			Sensitive:            true,
+		},
+
+		EmailTo: Parameter{
+			ID:                   "emailTo",
+			Name:                 "Email Address",
+			Description:          "The email address to send alerts to.\n\nLeave this blank if you don't want to receive alerts by email.",
+			Type:                 ParameterType_String,
+			Default:              map[Network]interface{}{Network_All: ""},
+			AffectsContainers:    []ContainerID{ContainerID_Alertmanager},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		EmailFrom: Parameter{
+			ID:                   "emailFrom",
+			Name:                 "Sender Email Address",
+			Description:          "The email address alerts should be sent from.",
+			Type:                 ParameterType_String,
+			Default:              map[Network]interface{}{Network_All: ""},
+			AffectsContainers:    []ContainerID{ContainerID_Alertmanager},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		SmtpHost: Parameter{
+			ID:                   "smtpHost",
+			Name:                 "SMTP Server",
+			Description:          "The host name and port of the SMTP server used to send alert emails, such as `smtp.gmail.com:587`.",
+			Type:                 ParameterType_String,
+			Default:              map[Network]interface{}{Network_All: ""},
+			AffectsContainers:    []ContainerID{ContainerID_Alertmanager},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		SmtpUsername: Parameter{
+			ID:                   "smtpUsername",
+			Name:                 "SMTP Username",
+			Description:          "The username to log into the SMTP server with. Leave this blank if the server doesn't require authentication.",
+			Type:                 ParameterType_String,
+			Default:              map[Network]interface{}{Network_All: ""},
+			AffectsContainers:    []ContainerID{ContainerID_Alertmanager},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		SmtpPassword: Parameter{
+			ID:                   "smtpPassword",
+			Name:                 "SMTP Password",
+			Description:          "The password to log into the SMTP server with.",
+			Type:                 ParameterType_String,
+			Default:              map[Network]interface{}{Network_All: ""},
+			AffectsContainers:    []ContainerID{ContainerID_Alertmanager},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+			Sensitive:            true,
+		},
+	}
+}
+
+// Get the parameters for this config
+func (config *AlertmanagerConfig) GetParameters() []*Parameter {
+	return []*Parameter{
+		&config.Port,
+		&config.OpenPort,
+		&config.ContainerTag,
+		&config.DiskSpaceThreshold,
+		&config.SlackWebhookUrl,
+		&config.WebhookUrl,
+		&config.EmailTo,
+		&config.EmailFrom,
+		&config.SmtpHost,
+		&config.SmtpUsername,
+		&config.SmtpPassword,
+	}
+}
+
+// The the title for the config
+func (config *AlertmanagerConfig) GetConfigTitle() string {
+	return config.Title
+}
@@ -70,7 +70,7 @@ func NewBesuConfig(config *RocketPoolConfig, isFallback bool) *BesuConfig {
 			ConsensusClient_Teku,
 		},
 
-		EventLogInterval: nethermindEventLogInterval,
+		EventLogInterval: besuEventLogInterval,
 
 		JvmHeapSize: Parameter{
 			ID:                   "jvmHeapSize",
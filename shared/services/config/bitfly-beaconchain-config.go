@@ -35,6 +35,8 @@ func NewBitflyNodeMetricsConfig(config *RocketPoolConfig) *BitflyNodeMetricsConf
 			Regex:              "^[A-Za-z0-9+/]{28}$",
 			CanBeBlank:         false,
 			OverwriteOnUpgrade: false,
+			Sensitive:          true,
+			MountAsFile:        true,
 		},
 
 		Endpoint: Parameter{
@@ -0,0 +1,426 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+// The Docker Compose file version used by the fragments; this must match the version of the container templates
+const composeFragmentVersion string = "3.7"
+
+// The path of the daemon in the Smartnode image
+const daemonContainerPath string = "/go/bin/rocketpool"
+
+// The path of the Docker socket on the host, which is mounted at the same path in containers that manage other containers
+const dockerSocketPath string = "/var/run/docker.sock"
+
+// The template used to render a container's compose fragment.
+// Docker Compose merges the ports and volumes of every file that defines a service, so these are added on top of the container's template.
+const composeFragmentTemplate string = `# This file is generated by the Smartnode; any changes will be overwritten.
+# Use the override folder to customize this container instead.
+version: "{{.Version}}"
+services:
+  {{.Service}}:
+{{- if .Ports}}
+    ports:
+{{- range .Ports}}
+      - "{{.}}"
+{{- end}}
+{{- end}}
+{{- if .Volumes}}
+    volumes:
+{{- range .Volumes}}
+      - "{{.}}"
+{{- end}}
+{{- end}}
+{{- if .Environment}}
+    environment:
+{{- range .Environment}}
+      - "{{.}}"
+{{- end}}
+{{- end}}
+{{- with .Logging}}
+    logging:
+      driver: "{{.Driver}}"
+      options:
+        max-size: "{{.MaxSize}}"
+        max-file: "{{.MaxFile}}"
+{{- end}}
+{{- with .Healthcheck}}
+    healthcheck:
+      test: [{{range $i, $arg := .Test}}{{if $i}}, {{end}}"{{$arg}}"{{end}}]
+      interval: {{.Interval}}
+      timeout: {{.Timeout}}
+      retries: {{.Retries}}
+      start_period: {{.StartPeriod}}
+{{- end}}
+{{- if .Secrets}}
+    secrets:
+{{- range .Secrets}}
+      - {{.Name}}
+{{- end}}
+secrets:
+{{- range .Secrets}}
+  {{.Name}}:
+    file: "{{.File}}"
+{{- end}}
+{{- end}}
+`
+
+var parsedComposeFragmentTemplate = template.Must(template.New("fragment").Parse(composeFragmentTemplate))
+
+// The deprecated env vars that container templates from before the compose fragments used to add their runtime ports and volumes, by container.
+// They're still generated for templates that haven't been updated, and a container whose template uses one gets those ports or volumes from it instead of its fragment.
+var legacyFragmentEnvVars = map[string]string{
+	Eth1ContainerName:         "EC_OPEN_API_PORTS",
+	Eth1FallbackContainerName: "FALLBACK_EC_OPEN_API_PORTS",
+	Eth2ContainerName:         "BN_OPEN_PORTS",
+	ExporterContainerName:     "EXPORTER_ROOTFS_VOLUME",
+}
+
+// A port on a container that's published to the host
+type PortMapping struct {
+	HostPort      uint16
+	ContainerPort uint16
+	Protocol      string
+}
+
+// A folder on the host that's mounted into a container
+type VolumeMapping struct {
+	Source   string
+	Target   string
+	ReadOnly bool
+}
+
+// An environment variable that's set in a container
+type EnvironmentVariable struct {
+	Name  string
+	Value string
+}
+
+// A Docker healthcheck for a container
+type Healthcheck struct {
+	Test        []string
+	Interval    string
+	Timeout     string
+	Retries     int
+	StartPeriod string
+}
+
+// A file on the host that's mounted into a container as a Docker secret
+type SecretMapping struct {
+	Name string
+	File string
+}
+
+// How Docker rotates a container's logs
+type LogRotation struct {
+	Driver  string
+	MaxSize string
+	MaxFile uint64
+}
+
+// The runtime additions to a container's compose definition that depend on the Smartnode configuration
+type ComposeFragment struct {
+	Version     string
+	Service     string
+	Ports       []PortMapping
+	Volumes     []VolumeMapping
+	Environment []EnvironmentVariable
+	Logging     *LogRotation
+	Healthcheck *Healthcheck
+	Secrets     []SecretMapping
+}
+
+// Creates a new, empty compose fragment for a container
+func NewComposeFragment(service string) *ComposeFragment {
+	return &ComposeFragment{
+		Version:     composeFragmentVersion,
+		Service:     service,
+		Ports:       []PortMapping{},
+		Volumes:     []VolumeMapping{},
+		Environment: []EnvironmentVariable{},
+		Secrets:     []SecretMapping{},
+	}
+}
+
+// Publish a TCP port on the host with the same port number as the container
+func (fragment *ComposeFragment) AddTcpPort(port uint16) {
+	fragment.Ports = append(fragment.Ports, PortMapping{
+		HostPort:      port,
+		ContainerPort: port,
+		Protocol:      "tcp",
+	})
+}
+
+// Mount a host folder into the container
+func (fragment *ComposeFragment) AddVolume(source string, target string, readOnly bool) {
+	fragment.Volumes = append(fragment.Volumes, VolumeMapping{
+		Source:   source,
+		Target:   target,
+		ReadOnly: readOnly,
+	})
+}
+
+// Set an environment variable in the container
+func (fragment *ComposeFragment) AddEnvironmentVariable(name string, value string) {
+	fragment.Environment = append(fragment.Environment, EnvironmentVariable{
+		Name:  name,
+		Value: value,
+	})
+}
+
+// Mount a file from the host into the container as a Docker secret, under /run/secrets/<name>
+func (fragment *ComposeFragment) AddSecret(name string, file string) {
+	fragment.Secrets = append(fragment.Secrets, SecretMapping{
+		Name: name,
+		File: file,
+	})
+}
+
+// Have Docker check a daemon's health with its health check endpoint
+func (fragment *ComposeFragment) SetDaemonHealthcheck(port uint16) {
+	fragment.Healthcheck = &Healthcheck{
+		Test:        []string{"CMD", daemonContainerPath, "healthcheck", fmt.Sprint(port)},
+		Interval:    "1m",
+		Timeout:     "20s",
+		Retries:     3,
+		StartPeriod: "2m",
+	}
+}
+
+// Have Docker rotate the container's logs once they reach the provided size in MiB, keeping the provided number of files
+func (fragment *ComposeFragment) SetLogRotation(maxSizeMib uint64, maxFiles uint64) {
+	fragment.Logging = &LogRotation{
+		Driver:  "json-file",
+		MaxSize: fmt.Sprintf("%dm", maxSizeMib),
+		MaxFile: maxFiles,
+	}
+}
+
+// Check if the fragment doesn't add anything to the container
+func (fragment *ComposeFragment) IsEmpty() bool {
+	return len(fragment.Ports) == 0 && len(fragment.Volumes) == 0 && len(fragment.Environment) == 0 && len(fragment.Secrets) == 0 && fragment.Logging == nil && fragment.Healthcheck == nil
+}
+
+// Render the fragment into a Docker Compose file
+func (fragment *ComposeFragment) Render() ([]byte, error) {
+	var buffer bytes.Buffer
+	err := parsedComposeFragmentTemplate.Execute(&buffer, fragment)
+	if err != nil {
+		return nil, fmt.Errorf("error rendering compose fragment for %s: %w", fragment.Service, err)
+	}
+	return buffer.Bytes(), nil
+}
+
+// Get a copy of the fragment without the ports and volumes that the container's template adds with a deprecated env var
+func (fragment *ComposeFragment) WithoutLegacyMappings() *ComposeFragment {
+	legacy := *fragment
+	if fragment.Service == ExporterContainerName {
+		legacy.Volumes = []VolumeMapping{}
+	} else {
+		legacy.Ports = []PortMapping{}
+	}
+	return &legacy
+}
+
+// Check if a container's template still adds its runtime ports or volumes with a deprecated env var, returning the name of the env var
+func UsesLegacyFragmentEnvVar(container string, contents []byte) (string, bool) {
+	name, exists := legacyFragmentEnvVars[container]
+	if !exists {
+		return "", false
+	}
+	return name, templateUsesEnvVar(contents, name)
+}
+
+// Get the compose string for a port mapping
+func (mapping PortMapping) String() string {
+	return fmt.Sprintf("%d:%d/%s", mapping.HostPort, mapping.ContainerPort, mapping.Protocol)
+}
+
+// Get the compose string for an environment variable
+func (variable EnvironmentVariable) String() string {
+	return fmt.Sprintf("%s=%s", variable.Name, variable.Value)
+}
+
+// Get the compose string for a volume mapping
+func (mapping VolumeMapping) String() string {
+	if mapping.ReadOnly {
+		return fmt.Sprintf("%s:%s:ro", mapping.Source, mapping.Target)
+	}
+	return fmt.Sprintf("%s:%s", mapping.Source, mapping.Target)
+}
+
+// Generates the compose fragments for the containers that have runtime ports or volumes, keyed by container name
+func (config *RocketPoolConfig) GenerateComposeFragments() map[string]*ComposeFragment {
+
+	fragments := map[string]*ComposeFragment{}
+
+	// EC ports
+	if config.GetExecutionClientMode() == Mode_Local && config.ExecutionCommon.OpenRpcPorts.Value == true {
+		fragment := NewComposeFragment(Eth1ContainerName)
+		addExecutionClientPorts(fragment, config.ExecutionClient.Value.(ExecutionClient), config.ExecutionCommon)
+		fragments[Eth1ContainerName] = fragment
+	}
+
+	// Fallback EC ports
+	if config.UseFallbackExecutionClient.Value == true &&
+		config.GetFallbackExecutionClientMode() == Mode_Local &&
+		config.FallbackExecutionCommon.OpenRpcPorts.Value == true {
+		fragment := NewComposeFragment(Eth1FallbackContainerName)
+		addExecutionClientPorts(fragment, config.FallbackExecutionClient.Value.(ExecutionClient), config.FallbackExecutionCommon)
+		fragments[Eth1FallbackContainerName] = fragment
+	}
+
+	// Nethermind's full pruning settings, which it reads straight from its own environment variables
+	if config.GetExecutionClientMode() == Mode_Local && config.ExecutionClient.Value.(ExecutionClient) == ExecutionClient_Nethermind {
+		fragment, exists := fragments[Eth1ContainerName]
+		if !exists {
+			fragment = NewComposeFragment(Eth1ContainerName)
+		}
+		addNethermindFullPruningSettings(fragment, config.Nethermind)
+		if !fragment.IsEmpty() {
+			fragments[Eth1ContainerName] = fragment
+		}
+	}
+
+	// CC ports
+	if config.GetConsensusClientMode() == Mode_Local {
+		fragment := NewComposeFragment(Eth2ContainerName)
+		if config.ConsensusCommon.OpenApiPort.Value == true {
+			fragment.AddTcpPort(config.ConsensusCommon.GetApiPort())
+		}
+		if config.ConsensusClient.Value.(ConsensusClient) == ConsensusClient_Prysm && config.Prysm.OpenRpcPort.Value == true {
+			fragment.AddTcpPort(config.Prysm.RpcPort.Value.(uint16))
+		}
+		if !fragment.IsEmpty() {
+			fragments[Eth2ContainerName] = fragment
+		}
+	}
+
+	// Node exporter volumes
+	if config.EnableMetrics.Value == true && config.Exporter.RootFs.Value == true {
+		fragment := NewComposeFragment(ExporterContainerName)
+		fragment.AddVolume("/", "/rootfs", true)
+		fragments[ExporterContainerName] = fragment
+	}
+
+	// Prometheus alert rules
+	if config.EnableMetrics.Value == true && config.EnableAlerting.Value == true {
+		fragment := NewComposeFragment(PrometheusContainerName)
+		fragment.AddVolume(filepath.Join(config.RocketPoolDirectory, AlertingFolder, AlertRulesFile), AlertRulesContainerPath, true)
+		fragments[PrometheusContainerName] = fragment
+	}
+
+	// Daemon healthchecks
+	if config.Smartnode.EnableHealthCheck.Value == true {
+		for _, daemon := range []string{ApiContainerName, NodeContainerName, WatchtowerContainerName} {
+			fragment := NewComposeFragment(daemon)
+			fragment.SetDaemonHealthcheck(config.Smartnode.GetHealthCheckPort(daemon))
+			fragments[daemon] = fragment
+		}
+	}
+
+	// Docker access for the watchtower to start the rewards tree worker
+	if config.Smartnode.EnableTreegenWorker.Value == true {
+		fragment, exists := fragments[WatchtowerContainerName]
+		if !exists {
+			fragment = NewComposeFragment(WatchtowerContainerName)
+			fragments[WatchtowerContainerName] = fragment
+		}
+		fragment.AddVolume(dockerSocketPath, dockerSocketPath, false)
+	}
+
+	// Sensitive settings, which the Validator client shards need as well since they don't extend the main Validator client's fragment
+	for _, secret := range config.GetContainerSecrets() {
+		containers := []string{}
+		for _, container := range secret.Containers {
+			containers = append(containers, string(container))
+			if container == ContainerID_Validator {
+				containers = append(containers, config.GetValidatorShardContainerNames()...)
+			}
+		}
+		for _, container := range containers {
+			fragment, exists := fragments[container]
+			if !exists {
+				fragment = NewComposeFragment(container)
+				fragments[container] = fragment
+			}
+			fragment.AddSecret(secret.Name, config.GetSecretPath(secret.Name))
+		}
+	}
+
+	// Log rotation for every container
+	if maxSize := config.Smartnode.ContainerLogMaxSize.GetUintOrDefault(Network_All); maxSize > 0 {
+		maxFiles := config.Smartnode.ContainerLogMaxFiles.GetUintOrDefault(Network_All)
+		for _, container := range config.GetComposeContainers() {
+			fragment, exists := fragments[container]
+			if !exists {
+				fragment = NewComposeFragment(container)
+				fragments[container] = fragment
+			}
+			fragment.SetLogRotation(maxSize, maxFiles)
+		}
+	}
+
+	return fragments
+
+}
+
+// Get the deprecated env vars for the container templates that haven't moved their runtime ports and volumes to the compose fragments.
+// These are the quoted compose list entries that the templates append to their own lists.
+func (config *RocketPoolConfig) getLegacyFragmentEnvVars() map[string]string {
+
+	envVars := map[string]string{}
+	for container, fragment := range config.GenerateComposeFragments() {
+		name, exists := legacyFragmentEnvVars[container]
+		if !exists {
+			continue
+		}
+		entries := []string{}
+		if container == ExporterContainerName {
+			for _, volume := range fragment.Volumes {
+				entries = append(entries, fmt.Sprintf("\"%s\"", volume))
+			}
+		} else {
+			for _, port := range fragment.Ports {
+				entries = append(entries, fmt.Sprintf("\"%s\"", port))
+			}
+		}
+		if len(entries) == 0 {
+			continue
+		}
+
+		// The fallback EC's template is the only one that doesn't have a port of its own before these
+		if container == Eth1FallbackContainerName {
+			envVars[name] = strings.Join(entries, ", ")
+		} else {
+			envVars[name] = ", " + strings.Join(entries, ", ")
+		}
+	}
+	return envVars
+
+}
+
+// Add the ports for an Execution client's HTTP and websocket APIs
+func addExecutionClientPorts(fragment *ComposeFragment, client ExecutionClient, common *ExecutionCommonConfig) {
+	fragment.AddTcpPort(common.GetHttpPort())
+
+	// Pocket doesn't have a websocket API
+	if client != ExecutionClient_Pocket {
+		fragment.AddTcpPort(common.GetWsPort())
+	}
+}
+
+// Pass the full pruning settings to Nethermind; unset values are left to Nethermind's defaults
+func addNethermindFullPruningSettings(fragment *ComposeFragment, nethermind *NethermindConfig) {
+	if budget := nethermind.FullPruningMemoryBudget.Value.(uint64); budget > 0 {
+		fragment.AddEnvironmentVariable("NETHERMIND_PRUNINGCONFIG_FULLPRUNINGMEMORYBUDGETMB", fmt.Sprint(budget))
+	}
+	if threads := nethermind.FullPruningThreads.Value.(uint64); threads > 0 {
+		fragment.AddEnvironmentVariable("NETHERMIND_PRUNINGCONFIG_FULLPRUNINGMAXDEGREEOFPARALLELISM", fmt.Sprint(threads))
+	}
+}
@@ -0,0 +1,186 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// The data a container's templates are rendered with
+type TemplateData struct {
+	// The container the template is for
+	Container string
+
+	// The full Smartnode configuration, so templates can read any setting directly
+	Config *RocketPoolConfig
+
+	// The environment variables generated from the configuration
+	Env map[string]string
+}
+
+// Get the containers that make up the Smartnode with this configuration, in the order their compose files are loaded
+func (config *RocketPoolConfig) GetComposeContainers() []string {
+
+	containers := []string{
+		ApiContainerName,
+		NodeContainerName,
+		WatchtowerContainerName,
+		ValidatorContainerName,
+	}
+	containers = append(containers, config.GetValidatorShardContainerNames()...)
+	if config.GetExecutionClientMode() == Mode_Local {
+		containers = append(containers, Eth1ContainerName)
+	}
+	if config.UseFallbackExecutionClient.Value == true && config.GetFallbackExecutionClientMode() == Mode_Local {
+		containers = append(containers, Eth1FallbackContainerName)
+	}
+	if config.GetConsensusClientMode() == Mode_Local {
+		containers = append(containers, Eth2ContainerName)
+	}
+	if config.EnableMetrics.Value == true {
+		containers = append(containers, GrafanaContainerName, ExporterContainerName, PrometheusContainerName)
+		if config.EnableAlerting.Value == true {
+			containers = append(containers, AlertmanagerContainerName)
+		}
+	}
+	if config.UsesChronyContainer() {
+		containers = append(containers, ChronyContainerName)
+	}
+	return containers
+
+}
+
+// Get the ID of the settings that apply to a compose container; the additional Validator client shards share the Validator client's settings
+func GetComposeContainerID(container string) ContainerID {
+	if _, isShard := GetValidatorShardFromContainerName(container); isShard {
+		return ContainerID_Validator
+	}
+	return ContainerID(container)
+}
+
+// Render a template for one of the containers, such as its compose file, into the file it describes.
+// Templates use Go template syntax, with the configuration available as .Config and the generated environment variables as .Env or through the env function.
+// Environment variable references like ${NAME} in templates that predate Go template syntax are rendered as calls to env, so values are never parsed
+// as template syntax themselves.
+func (config *RocketPoolConfig) RenderTemplate(container string, contents []byte, env map[string]string) ([]byte, error) {
+
+	lookup := func(name string) (string, bool) {
+		if value, exists := env[name]; exists {
+			return value, true
+		}
+		return os.LookupEnv(name)
+	}
+	funcs := template.FuncMap{
+		"env": func(name string) string {
+			value, _ := lookup(name)
+			return value
+		},
+		"envOrDefault": func(name string, defaultValue string, allowEmpty bool) string {
+			value, exists := lookup(name)
+			if !exists || (value == "" && !allowEmpty) {
+				return defaultValue
+			}
+			return value
+		},
+	}
+	tmpl, err := template.New(container).Funcs(funcs).Option("missingkey=zero").Parse(convertEnvReferences(string(contents)))
+	if err != nil {
+		return nil, fmt.Errorf("error parsing the %s container template: %w", container, err)
+	}
+
+	var buffer bytes.Buffer
+	err = tmpl.Execute(&buffer, TemplateData{
+		Container: container,
+		Config:    config,
+		Env:       env,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error rendering the %s container template: %w", container, err)
+	}
+	return buffer.Bytes(), nil
+
+}
+
+// Convert the environment variable references in a template to calls to the env template functions.
+// Supports $NAME, ${NAME}, ${NAME:-default} (for unset or empty variables), ${NAME-default} (for unset variables), and $$ for a literal $.
+// Template actions are left as they are, since $ starts a template variable inside them.
+func convertEnvReferences(contents string) string {
+
+	var builder strings.Builder
+	for i := 0; i < len(contents); {
+
+		// Copy template actions
+		if strings.HasPrefix(contents[i:], "{{") {
+			end := strings.Index(contents[i:], "}}")
+			if end == -1 {
+				builder.WriteString(contents[i:])
+				break
+			}
+			builder.WriteString(contents[i : i+end+2])
+			i += end + 2
+			continue
+		}
+		if contents[i] != '$' {
+			builder.WriteByte(contents[i])
+			i++
+			continue
+		}
+
+		// Escaped $
+		if strings.HasPrefix(contents[i:], "$$") {
+			builder.WriteByte('$')
+			i += 2
+			continue
+		}
+
+		// $NAME
+		if name := getEnvVarName(contents[i+1:]); name != "" {
+			builder.WriteString(fmt.Sprintf("{{env %s}}", strconv.Quote(name)))
+			i += 1 + len(name)
+			continue
+		}
+
+		// ${NAME}, ${NAME:-default}, or ${NAME-default}
+		if strings.HasPrefix(contents[i:], "${") {
+			end := strings.Index(contents[i:], "}")
+			name := getEnvVarName(contents[i+2:])
+			if end != -1 && name != "" {
+				expression := contents[i+2 : i+end]
+				switch {
+				case expression == name:
+					builder.WriteString(fmt.Sprintf("{{env %s}}", strconv.Quote(name)))
+				case strings.HasPrefix(expression, name+":-"):
+					builder.WriteString(fmt.Sprintf("{{envOrDefault %s %s false}}", strconv.Quote(name), strconv.Quote(expression[len(name)+2:])))
+				case strings.HasPrefix(expression, name+"-"):
+					builder.WriteString(fmt.Sprintf("{{envOrDefault %s %s true}}", strconv.Quote(name), strconv.Quote(expression[len(name)+1:])))
+				default:
+					builder.WriteString(contents[i : i+end+1])
+				}
+				i += end + 1
+				continue
+			}
+		}
+
+		// Anything else is a literal $
+		builder.WriteByte('$')
+		i++
+
+	}
+	return builder.String()
+
+}
+
+// Get the environment variable name at the start of a string; names start with a letter or underscore, followed by letters, digits, or underscores
+func getEnvVarName(contents string) string {
+	for i, char := range contents {
+		isLetter := (char >= 'a' && char <= 'z') || (char >= 'A' && char <= 'Z') || char == '_'
+		isDigit := char >= '0' && char <= '9'
+		if !isLetter && !(isDigit && i > 0) {
+			return contents[:i]
+		}
+	}
+	return contents
+}
@@ -0,0 +1,43 @@
+package config
+
+import (
+	"testing"
+)
+
+func TestRenderTemplateDoesNotParseValues(t *testing.T) {
+	cfg := NewRocketPoolConfig("", false)
+	env := map[string]string{
+		"GRAFFITI": "{{ .Config }} {{",
+		"EMPTY":    "",
+	}
+	template := `graffiti: ${GRAFFITI}
+bare: $GRAFFITI
+env: {{ env "GRAFFITI" }}
+unset: ${UNSET:-fallback} ${UNSET-fallback}
+empty: ${EMPTY:-fallback} [${EMPTY-fallback}]
+escaped: $$HOME $1
+{{ range $i, $name := .Config.GetComposeContainers }}{{ if eq $i 0 }}first: {{ $name }}{{ end }}{{ end }}
+`
+	expected := `graffiti: {{ .Config }} {{
+bare: {{ .Config }} {{
+env: {{ .Config }} {{
+unset: fallback fallback
+empty: fallback []
+escaped: $HOME $1
+first: ` + ApiContainerName + `
+`
+	contents, err := cfg.RenderTemplate(ApiContainerName, []byte(template), env)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(contents) != expected {
+		t.Fatalf("unexpected rendering:\n%s\nexpected:\n%s", contents, expected)
+	}
+}
+
+func TestRenderTemplateRejectsInvalidSyntax(t *testing.T) {
+	cfg := NewRocketPoolConfig("", false)
+	if _, err := cfg.RenderTemplate(ApiContainerName, []byte("{{ .Config"), nil); err == nil {
+		t.Fatal("expected an error for an unterminated action")
+	}
+}
@@ -0,0 +1,120 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/rocket-pool/smartnode/shared"
+)
+
+// A machine-readable description of every setting in the configuration, for tools that generate settings files
+type ConfigSchema struct {
+	Version  string          `json:"version"`
+	Networks []Network       `json:"networks"`
+	Sections []SectionSchema `json:"sections"`
+}
+
+// The settings in a single section of the settings file
+type SectionSchema struct {
+	Name       string            `json:"name"`
+	Title      string            `json:"title"`
+	Parameters []ParameterSchema `json:"parameters"`
+}
+
+// The description of a single setting
+type ParameterSchema struct {
+	ID                   string                  `json:"id"`
+	Name                 string                  `json:"name"`
+	Description          string                  `json:"description"`
+	Type                 ParameterType           `json:"type"`
+	Default              map[Network]interface{} `json:"default"`
+	MaxLength            int                     `json:"maxLength,omitempty"`
+	Regex                string                  `json:"regex,omitempty"`
+	Advanced             bool                    `json:"advanced"`
+	CanBeBlank           bool                    `json:"canBeBlank"`
+	AffectsContainers    []ContainerID           `json:"affectsContainers"`
+	EnvironmentVariables []string                `json:"environmentVariables,omitempty"`
+	Options              []ParameterOption       `json:"options,omitempty"`
+	DependsOn            []DependencySchema      `json:"dependsOn,omitempty"`
+}
+
+// A setting that must have one of the provided values for a parameter to be relevant
+type DependencySchema struct {
+	Parameter string        `json:"parameter"`
+	Values    []interface{} `json:"values"`
+}
+
+// Get the schema of every setting in the configuration
+func (config *RocketPoolConfig) GetSchema() ConfigSchema {
+
+	// Collect the sections, starting with the root followed by the subconfigs sorted by name
+	subconfigs := config.GetSubconfigs()
+	names := make([]string, 0, len(subconfigs))
+	for name := range subconfigs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	type section struct {
+		name   string
+		title  string
+		params []*Parameter
+	}
+	sections := []section{{name: rootConfigName, title: config.Title, params: config.GetParameters()}}
+	for _, name := range names {
+		subconfig := subconfigs[name]
+		sections = append(sections, section{name: name, title: subconfig.GetConfigTitle(), params: subconfig.GetParameters()})
+	}
+
+	// Map each parameter to its full name so dependencies can refer to it
+	fullNames := map[*Parameter]string{}
+	for _, section := range sections {
+		for _, param := range section.params {
+			fullNames[param] = fmt.Sprintf("%s.%s", section.name, param.ID)
+		}
+	}
+
+	// Build the schema
+	schema := ConfigSchema{
+		Version: fmt.Sprintf("v%s", shared.RocketPoolVersion),
+	}
+	for _, option := range config.Smartnode.Network.Options {
+		if network, ok := option.Value.(Network); ok {
+			schema.Networks = append(schema.Networks, network)
+		}
+	}
+	for _, section := range sections {
+		sectionSchema := SectionSchema{
+			Name:       section.name,
+			Title:      section.title,
+			Parameters: []ParameterSchema{},
+		}
+		for _, param := range section.params {
+			paramSchema := ParameterSchema{
+				ID:                   param.ID,
+				Name:                 param.Name,
+				Description:          param.Description,
+				Type:                 param.Type,
+				Default:              param.Default,
+				MaxLength:            param.MaxLength,
+				Regex:                param.Regex,
+				Advanced:             param.Advanced,
+				CanBeBlank:           param.CanBeBlank,
+				AffectsContainers:    param.AffectsContainers,
+				EnvironmentVariables: param.EnvironmentVariables,
+				Options:              param.Options,
+			}
+			for _, dependency := range param.Dependencies {
+				paramSchema.DependsOn = append(paramSchema.DependsOn, DependencySchema{
+					Parameter: fullNames[dependency.Parameter],
+					Values:    dependency.Values,
+				})
+			}
+			sectionSchema.Parameters = append(sectionSchema.Parameters, paramSchema)
+		}
+		schema.Sections = append(schema.Sections, sectionSchema)
+	}
+
+	return schema
+
+}
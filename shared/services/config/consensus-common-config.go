@@ -69,6 +69,8 @@ func NewConsensusCommonConfig(config *RocketPoolConfig) *ConsensusCommonConfig {
 			EnvironmentVariables: []string{"CHECKPOINT_SYNC_URL"},
 			CanBeBlank:           true,
 			OverwriteOnUpgrade:   false,
+			Sensitive:            true,
+			MountAsFile:          true,
 		},
 
 		P2pPort: Parameter{
@@ -133,6 +135,11 @@ func (config *ConsensusCommonConfig) GetParameters() []*Parameter {
 	}
 }
 
+// Get the HTTP API port
+func (config *ConsensusCommonConfig) GetApiPort() uint16 {
+	return config.ApiPort.GetUint16OrDefault(Network_All)
+}
+
 // The the title for the config
 func (config *ConsensusCommonConfig) GetConfigTitle() string {
 	return config.Title
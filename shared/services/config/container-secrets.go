@@ -0,0 +1,92 @@
+package config
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const (
+	// The folder in the Rocket Pool directory that holds the secrets mounted into the containers
+	SecretsFolder string = "secrets"
+
+	// The folder Docker mounts a container's secrets in
+	secretsContainerFolder string = "/run/secrets"
+)
+
+// A sensitive setting that's mounted into containers as a file instead of being passed to them in an environment variable.
+// The containers get the path of the file in <EnvVar>_FILE.
+type ContainerSecret struct {
+	Name       string
+	EnvVar     string
+	Value      string
+	Containers []ContainerID
+}
+
+// Get the name of the secret for a setting's environment variable
+func getSecretName(envVar string) string {
+	return strings.ToLower(envVar)
+}
+
+// Get the path that a setting's secret is mounted at in the containers
+func getSecretContainerPath(envVar string) string {
+	return path.Join(secretsContainerFolder, getSecretName(envVar))
+}
+
+// Get the path of the file on the host that a secret is written to
+func (config *RocketPoolConfig) GetSecretPath(name string) string {
+	return filepath.Join(config.RocketPoolDirectory, SecretsFolder, name)
+}
+
+// Get the secrets of the settings that are in use with the current configuration, sorted by name
+func (config *RocketPoolConfig) GetContainerSecrets() []ContainerSecret {
+
+	envVars := config.getSettingsEnvVars()
+	params := config.GetParameters()
+	for _, subconfig := range config.GetSubconfigs() {
+		params = append(params, subconfig.GetParameters()...)
+	}
+
+	secrets := []ContainerSecret{}
+	for _, param := range params {
+		if !param.MountAsFile {
+			continue
+		}
+		for _, envVar := range param.EnvironmentVariables {
+			// Settings of clients that aren't in use don't have their environment variables generated
+			if _, exists := envVars[envVar+"_FILE"]; !exists {
+				continue
+			}
+			secrets = append(secrets, ContainerSecret{
+				Name:       getSecretName(envVar),
+				EnvVar:     envVar,
+				Value:      fmt.Sprint(param.Value),
+				Containers: param.AffectsContainers,
+			})
+		}
+	}
+	sort.Slice(secrets, func(i, j int) bool {
+		return secrets[i].Name < secrets[j].Name
+	})
+	return secrets
+
+}
+
+// Check if a container's template still reads a secret from its old environment variable instead of its file
+func UsesLegacySecretEnvVar(secret ContainerSecret, container string, contents []byte) bool {
+	for _, secretContainer := range secret.Containers {
+		if string(secretContainer) == container {
+			return templateUsesEnvVar(contents, secret.EnvVar)
+		}
+	}
+	return false
+}
+
+// Check if a container template uses an environment variable
+func templateUsesEnvVar(contents []byte, name string) bool {
+	pattern := regexp.MustCompile(`\$\{?` + name + `\b`)
+	return pattern.Match(contents)
+}
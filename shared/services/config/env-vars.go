@@ -0,0 +1,243 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// Generates a collection of environment variables based on this config's settings
+func (config *RocketPoolConfig) GenerateEnvironmentVariables() map[string]string {
+
+	envVars := config.getSettingsEnvVars()
+	for name, value := range config.getLegacyFragmentEnvVars() {
+		envVars[name] = value
+	}
+	return envVars
+
+}
+
+// Get the environment variables for the settings of every container that's in use
+func (config *RocketPoolConfig) getSettingsEnvVars() map[string]string {
+
+	envVars := map[string]string{}
+	for _, containerEnvVars := range []map[string]string{
+		config.getSmartnodeEnvVars(),
+		config.getExecutionClientEnvVars(),
+		config.getFallbackExecutionClientEnvVars(),
+		config.getConsensusClientEnvVars(),
+		config.getMetricsEnvVars(),
+		config.getBitflyNodeMetricsEnvVars(),
+		config.getRemoteSignerEnvVars(),
+	} {
+		for name, value := range containerEnvVars {
+			envVars[name] = value
+		}
+	}
+	return envVars
+
+}
+
+// Get the environment variables for the basic variables and root parameters
+func (config *RocketPoolConfig) getSmartnodeEnvVars() map[string]string {
+
+	envVars := map[string]string{}
+	envVars["SMARTNODE_IMAGE"] = config.Smartnode.GetSmartnodeContainerTag()
+	envVars["ROCKETPOOL_FOLDER"] = config.RocketPoolDirectory
+	envVars["DOCKER_NETWORK"], envVars["DOCKER_NETWORK_EXTERNAL"] = config.Smartnode.GetDockerNetwork()
+	addParametersToEnvVars(config.Smartnode.GetParameters(), envVars)
+	addParametersToEnvVars(config.GetParameters(), envVars)
+	return envVars
+
+}
+
+// Get the environment variables for the Execution client
+func (config *RocketPoolConfig) getExecutionClientEnvVars() map[string]string {
+
+	envVars := map[string]string{}
+	if config.GetExecutionClientMode() == Mode_Local {
+		envVars["EC_CLIENT"] = fmt.Sprint(config.ExecutionClient.Value)
+		envVars["EC_HTTP_ENDPOINT"] = fmt.Sprintf("http://%s:%d", Eth1ContainerName, config.ExecutionCommon.HttpPort.Value)
+		envVars["EC_WS_ENDPOINT"] = fmt.Sprintf("ws://%s:%d", Eth1ContainerName, config.ExecutionCommon.WsPort.Value)
+
+		// Common params
+		addParametersToEnvVars(config.ExecutionCommon.GetParameters(), envVars)
+
+		// Client-specific params
+		switch config.ExecutionClient.Value.(ExecutionClient) {
+		case ExecutionClient_Geth:
+			addParametersToEnvVars(config.Geth.GetParameters(), envVars)
+			envVars["EC_STOP_SIGNAL"] = gethStopSignal
+		case ExecutionClient_Nethermind:
+			addParametersToEnvVars(config.Nethermind.GetParameters(), envVars)
+			envVars["EC_STOP_SIGNAL"] = nethermindStopSignal
+		case ExecutionClient_Besu:
+			addParametersToEnvVars(config.Besu.GetParameters(), envVars)
+			envVars["EC_STOP_SIGNAL"] = besuStopSignal
+		case ExecutionClient_Erigon:
+			addParametersToEnvVars(config.Erigon.GetParameters(), envVars)
+			envVars["EC_STOP_SIGNAL"] = erigonStopSignal
+		case ExecutionClient_Infura:
+			addParametersToEnvVars(config.Infura.GetParameters(), envVars)
+			envVars["EC_STOP_SIGNAL"] = powProxyStopSignal
+		case ExecutionClient_Pocket:
+			addParametersToEnvVars(config.Pocket.GetParameters(), envVars)
+			envVars["EC_STOP_SIGNAL"] = powProxyStopSignal
+		}
+	} else {
+		envVars["EC_CLIENT"] = "X" // X is for external / unknown
+		addParametersToEnvVars(config.ExternalExecution.GetParameters(), envVars)
+	}
+
+	// Get the hostname of the Execution client, necessary for Prometheus to work in hybrid mode
+	ecUrl, err := url.Parse(envVars["EC_HTTP_ENDPOINT"])
+	if err == nil && ecUrl != nil {
+		envVars["EC_HOSTNAME"] = ecUrl.Hostname()
+	}
+	return envVars
+
+}
+
+// Get the environment variables for the fallback Execution client
+func (config *RocketPoolConfig) getFallbackExecutionClientEnvVars() map[string]string {
+
+	envVars := map[string]string{}
+	envVars["FALLBACK_EC_CLIENT"] = fmt.Sprint(config.FallbackExecutionClient.Value)
+	if config.UseFallbackExecutionClient.Value == true {
+		if config.GetFallbackExecutionClientMode() == Mode_Local {
+			envVars["FALLBACK_EC_HTTP_ENDPOINT"] = fmt.Sprintf("http://%s:%d", Eth1FallbackContainerName, config.FallbackExecutionCommon.HttpPort.Value)
+			envVars["FALLBACK_EC_WS_ENDPOINT"] = fmt.Sprintf("ws://%s:%d", Eth1FallbackContainerName, config.FallbackExecutionCommon.WsPort.Value)
+
+			// Common params
+			addParametersToEnvVars(config.FallbackExecutionCommon.GetParameters(), envVars)
+
+			// Client-specific params
+			switch config.FallbackExecutionClient.Value.(ExecutionClient) {
+			case ExecutionClient_Infura:
+				addParametersToEnvVars(config.FallbackInfura.GetParameters(), envVars)
+			case ExecutionClient_Pocket:
+				addParametersToEnvVars(config.FallbackPocket.GetParameters(), envVars)
+			}
+		} else {
+			addParametersToEnvVars(config.FallbackExternalExecution.GetParameters(), envVars)
+		}
+	}
+	return envVars
+
+}
+
+// Get the environment variables for the Consensus client
+func (config *RocketPoolConfig) getConsensusClientEnvVars() map[string]string {
+
+	envVars := map[string]string{}
+	if config.GetConsensusClientMode() == Mode_Local {
+		envVars["CC_CLIENT"] = fmt.Sprint(config.ConsensusClient.Value)
+		envVars["CC_API_ENDPOINT"] = fmt.Sprintf("http://%s:%d", Eth2ContainerName, config.ConsensusCommon.ApiPort.Value)
+
+		// Common params
+		addParametersToEnvVars(config.ConsensusCommon.GetParameters(), envVars)
+
+		// Client-specific params
+		switch config.ConsensusClient.Value.(ConsensusClient) {
+		case ConsensusClient_Lighthouse:
+			addParametersToEnvVars(config.Lighthouse.GetParameters(), envVars)
+		case ConsensusClient_Nimbus:
+			addParametersToEnvVars(config.Nimbus.GetParameters(), envVars)
+		case ConsensusClient_Prysm:
+			addParametersToEnvVars(config.Prysm.GetParameters(), envVars)
+			envVars["CC_RPC_ENDPOINT"] = fmt.Sprintf("http://%s:%d", Eth2ContainerName, config.Prysm.RpcPort.Value)
+		case ConsensusClient_Teku:
+			addParametersToEnvVars(config.Teku.GetParameters(), envVars)
+		}
+	} else {
+		envVars["CC_CLIENT"] = fmt.Sprint(config.ExternalConsensusClient.Value)
+
+		switch config.ExternalConsensusClient.Value.(ConsensusClient) {
+		case ConsensusClient_Lighthouse:
+			addParametersToEnvVars(config.ExternalLighthouse.GetParameters(), envVars)
+		case ConsensusClient_Prysm:
+			addParametersToEnvVars(config.ExternalPrysm.GetParameters(), envVars)
+			envVars["VC_BEACON_API_FLAGS"] = config.ExternalPrysm.GetValidatorClientFlags()
+		case ConsensusClient_Teku:
+			addParametersToEnvVars(config.ExternalTeku.GetParameters(), envVars)
+		}
+	}
+
+	// Get the hostname of the Consensus client, necessary for Prometheus to work in hybrid mode
+	ccUrl, err := url.Parse(envVars["CC_API_ENDPOINT"])
+	if err == nil && ccUrl != nil {
+		envVars["CC_HOSTNAME"] = ccUrl.Hostname()
+	}
+	return envVars
+
+}
+
+// Get the environment variables for the metrics containers
+func (config *RocketPoolConfig) getMetricsEnvVars() map[string]string {
+
+	envVars := map[string]string{}
+	if config.EnableMetrics.Value != true {
+		return envVars
+	}
+
+	addParametersToEnvVars(config.Exporter.GetParameters(), envVars)
+	addParametersToEnvVars(config.Prometheus.GetParameters(), envVars)
+	addParametersToEnvVars(config.Grafana.GetParameters(), envVars)
+
+	if config.Exporter.RootFs.Value == true {
+		envVars["EXPORTER_ROOTFS_COMMAND"] = ", \"--path.rootfs=/rootfs\""
+	}
+
+	if config.Prometheus.OpenPort.Value == true {
+		envVars["PROMETHEUS_OPEN_PORTS"] = fmt.Sprintf("%d:%d/tcp", config.Prometheus.Port.Value, config.Prometheus.Port.Value)
+	}
+
+	// Additional metrics flags
+	if config.Exporter.AdditionalFlags.Value.(string) != "" {
+		envVars["EXPORTER_ADDITIONAL_FLAGS"] = fmt.Sprintf(", \"%s\"", config.Exporter.AdditionalFlags.Value.(string))
+	}
+	if config.Prometheus.AdditionalFlags.Value.(string) != "" {
+		envVars["PROMETHEUS_ADDITIONAL_FLAGS"] = fmt.Sprintf(", \"%s\"", config.Prometheus.AdditionalFlags.Value.(string))
+	}
+	return envVars
+
+}
+
+// Get the environment variables for Bitfly Node Metrics
+func (config *RocketPoolConfig) getBitflyNodeMetricsEnvVars() map[string]string {
+
+	envVars := map[string]string{}
+	if config.EnableBitflyNodeMetrics.Value == true {
+		addParametersToEnvVars(config.BitflyNodeMetrics.GetParameters(), envVars)
+	}
+	return envVars
+
+}
+
+// Get the environment variables for the remote signer
+func (config *RocketPoolConfig) getRemoteSignerEnvVars() map[string]string {
+
+	envVars := map[string]string{}
+	if config.UseRemoteSigner.Value == true {
+		addParametersToEnvVars(config.RemoteSigner.GetParameters(), envVars)
+		envVars["VC_REMOTE_SIGNER_FLAGS"] = config.RemoteSigner.GetValidatorClientFlags(config.GetSelectedConsensusClient())
+	}
+	return envVars
+
+}
+
+// Add the parameters to the collection of environment variabes.
+// Parameters that are mounted as files get the path of their file instead of their value.
+func addParametersToEnvVars(params []*Parameter, envVars map[string]string) {
+	for _, param := range params {
+		for _, envVar := range param.EnvironmentVariables {
+			if envVar == "" {
+				continue
+			}
+			if param.MountAsFile {
+				envVars[envVar+"_FILE"] = getSecretContainerPath(envVar)
+			} else {
+				envVars[envVar] = fmt.Sprint(param.Value)
+			}
+		}
+	}
+}
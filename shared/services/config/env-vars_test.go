@@ -0,0 +1,193 @@
+package config
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+)
+
+var updateGoldenFiles = flag.Bool("update", false, "rewrite the golden files with the current output")
+
+// Environment variables whose defaults depend on the machine's memory or architecture or on the Smartnode version, so they're left out of the golden files
+var variableEnvVars = map[string]bool{
+	"SMARTNODE_IMAGE":                    true,
+	"EC_CACHE_SIZE":                      true,
+	"EC_MAX_PEERS":                       true,
+	"EC_CONTAINER_TAG":                   true,
+	"BESU_JVM_HEAP_SIZE":                 true,
+	"NETHERMIND_PRUNE_MEM_SIZE":          true,
+	"FALLBACK_EC_CACHE_SIZE":             true,
+	"FALLBACK_EC_MAX_PEERS":              true,
+	"FALLBACK_EC_CONTAINER_TAG":          true,
+	"FALLBACK_BESU_JVM_HEAP_SIZE":        true,
+	"FALLBACK_NETHERMIND_PRUNE_MEM_SIZE": true,
+	"BN_MAX_PEERS":                       true,
+	"BN_CONTAINER_TAG":                   true,
+	"VC_CONTAINER_TAG":                   true,
+	"TEKU_JVM_HEAP_SIZE":                 true,
+}
+
+// Render the environment variables as sorted NAME=value lines
+func formatEnvVars(envVars map[string]string) string {
+	names := make([]string, 0, len(envVars))
+	for name := range envVars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var builder strings.Builder
+	for _, name := range names {
+		value := envVars[name]
+		if variableEnvVars[name] {
+			value = "<varies>"
+		}
+		fmt.Fprintf(&builder, "%s=%s\n", name, value)
+	}
+	return builder.String()
+}
+
+// Compare the output with a golden file in testdata, or rewrite it if -update is set
+func checkGoldenFile(t *testing.T, name string, actual string) {
+	t.Helper()
+	path := filepath.Join("testdata", "env-vars", name+".env")
+	if *updateGoldenFiles {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(path, []byte(actual), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	expected, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatalf("%s (run the tests with -update to create it)", err.Error())
+	}
+	if string(expected) == actual {
+		return
+	}
+	expectedLines := strings.Split(string(expected), "\n")
+	actualLines := strings.Split(actual, "\n")
+	for i := 0; i < len(expectedLines) || i < len(actualLines); i++ {
+		var expectedLine, actualLine string
+		if i < len(expectedLines) {
+			expectedLine = expectedLines[i]
+		}
+		if i < len(actualLines) {
+			actualLine = actualLines[i]
+		}
+		if expectedLine != actualLine {
+			t.Fatalf("%s doesn't match the output at line %d:\n\texpected: %s\n\tactual:   %s\nRun the tests with -update if the change is intended.", path, i+1, expectedLine, actualLine)
+		}
+	}
+}
+
+// Create a config for the golden files; the defaults are used apart from the settings every client needs
+func newGoldenConfig() *RocketPoolConfig {
+	cfg := NewRocketPoolConfig("/home/node/.rocketpool", false)
+	cfg.ExternalExecution.HttpUrl.Value = "http://192.168.1.30:8545"
+	cfg.ExternalExecution.WsUrl.Value = "ws://192.168.1.30:8546"
+	cfg.ExternalLighthouse.HttpUrl.Value = "http://192.168.1.40:5052"
+	cfg.ExternalPrysm.HttpUrl.Value = "http://192.168.1.40:5052"
+	cfg.ExternalTeku.HttpUrl.Value = "http://192.168.1.40:5052"
+	return cfg
+}
+
+func TestEnvironmentVariablesGolden(t *testing.T) {
+	tests := map[string]func(cfg *RocketPoolConfig){}
+
+	// Each local Execution client with a local Consensus client
+	for _, ec := range []ExecutionClient{ExecutionClient_Geth, ExecutionClient_Nethermind, ExecutionClient_Besu, ExecutionClient_Erigon, ExecutionClient_Infura, ExecutionClient_Pocket} {
+		ec := ec
+		tests[fmt.Sprintf("local-%s-lighthouse", ec)] = func(cfg *RocketPoolConfig) {
+			cfg.ExecutionClient.Value = ec
+			cfg.ConsensusClient.Value = ConsensusClient_Lighthouse
+		}
+	}
+
+	// Each local Consensus client
+	for _, cc := range []ConsensusClient{ConsensusClient_Nimbus, ConsensusClient_Prysm, ConsensusClient_Teku} {
+		cc := cc
+		tests[fmt.Sprintf("local-geth-%s", cc)] = func(cfg *RocketPoolConfig) {
+			cfg.ExecutionClient.Value = ExecutionClient_Geth
+			cfg.ConsensusClient.Value = cc
+		}
+	}
+
+	// Each external Consensus client with an external Execution client
+	for _, cc := range []ConsensusClient{ConsensusClient_Lighthouse, ConsensusClient_Prysm, ConsensusClient_Teku} {
+		cc := cc
+		tests[fmt.Sprintf("external-%s", cc)] = func(cfg *RocketPoolConfig) {
+			cfg.ExecutionClientMode.Value = Mode_External
+			cfg.ConsensusClientMode.Value = Mode_External
+			cfg.ExternalConsensusClient.Value = cc
+		}
+	}
+
+	// Hybrid mode
+	tests["external-ec-local-teku"] = func(cfg *RocketPoolConfig) {
+		cfg.ExecutionClientMode.Value = Mode_External
+		cfg.ConsensusClient.Value = ConsensusClient_Teku
+	}
+
+	// Fallback clients, metrics and a remote signer
+	tests["local-geth-lighthouse-local-fallback"] = func(cfg *RocketPoolConfig) {
+		cfg.UseFallbackExecutionClient.Value = true
+		cfg.FallbackExecutionClientMode.Value = Mode_Local
+		cfg.FallbackExecutionClient.Value = ExecutionClient_Infura
+	}
+	tests["local-geth-lighthouse-external-fallback"] = func(cfg *RocketPoolConfig) {
+		cfg.UseFallbackExecutionClient.Value = true
+		cfg.FallbackExecutionClientMode.Value = Mode_External
+		cfg.FallbackExternalExecution.HttpUrl.Value = "http://192.168.1.31:8545"
+		cfg.FallbackExternalExecution.WsUrl.Value = "ws://192.168.1.31:8546"
+	}
+	tests["local-geth-lighthouse-metrics"] = func(cfg *RocketPoolConfig) {
+		cfg.EnableMetrics.Value = true
+		cfg.EnableBitflyNodeMetrics.Value = true
+	}
+	tests["local-geth-teku-remote-signer"] = func(cfg *RocketPoolConfig) {
+		cfg.ConsensusClient.Value = ConsensusClient_Teku
+		cfg.UseRemoteSigner.Value = true
+		cfg.RemoteSigner.Url.Value = "https://192.168.1.50:9000"
+	}
+
+	for name, configure := range tests {
+		cfg := newGoldenConfig()
+		configure(cfg)
+		t.Run(name, func(t *testing.T) {
+			checkGoldenFile(t, name, formatEnvVars(cfg.GenerateEnvironmentVariables()))
+		})
+	}
+}
+
+func TestExternalPrysmBeaconApiFlags(t *testing.T) {
+	cfg := NewRocketPoolConfig("", false)
+	cfg.ConsensusClientMode.Value = Mode_External
+	cfg.ExternalConsensusClient.Value = ConsensusClient_Prysm
+	cfg.ExternalPrysm.HttpUrl.Value = "http://192.168.1.40:5052"
+
+	// Without a gRPC URL, the VC is told to use the Beacon API
+	envVars := cfg.GenerateEnvironmentVariables()
+	expected := "--enable-beacon-rest-api --beacon-rest-api-provider=http://192.168.1.40:5052"
+	if flags := envVars["VC_BEACON_API_FLAGS"]; flags != expected {
+		t.Fatalf("expected the Beacon API flags %q, got %q", expected, flags)
+	}
+	if url := envVars["CC_RPC_ENDPOINT"]; url != "" {
+		t.Fatalf("expected no gRPC URL, got %q", url)
+	}
+
+	// With one, it keeps using gRPC
+	cfg.ExternalPrysm.GrpcUrl.Value = "http://192.168.1.40:5053"
+	envVars = cfg.GenerateEnvironmentVariables()
+	if flags := envVars["VC_BEACON_API_FLAGS"]; flags != "" {
+		t.Fatalf("expected no Beacon API flags with a gRPC URL, got %q", flags)
+	}
+	if url := envVars["CC_RPC_ENDPOINT"]; url != "http://192.168.1.40:5053" {
+		t.Fatalf("expected the gRPC URL to be passed to the VC, got %q", url)
+	}
+}
@@ -0,0 +1,195 @@
+package config
+
+import (
+	"fmt"
+	"runtime"
+
+	"github.com/pbnjay/memory"
+)
+
+// Constants
+const (
+	erigonTagAmd64         string = "thorax/erigon:v2022.07.03"
+	erigonTagArm64         string = "thorax/erigon:v2022.07.03"
+	erigonEventLogInterval int    = 25000
+	erigonStopSignal       string = "SIGINT"
+)
+
+// Configuration for Erigon
+type ErigonConfig struct {
+	Title string `yaml:"-"`
+
+	// Common parameters that Erigon doesn't support and should be hidden
+	UnsupportedCommonParams []string `yaml:"-"`
+
+	// Compatible consensus clients
+	CompatibleConsensusClients []ConsensusClient `yaml:"-"`
+
+	// The max number of events to query in a single event log query
+	EventLogInterval int `yaml:"-"`
+
+	// Size of Erigon's database cache
+	CacheSize Parameter `yaml:"cacheSize,omitempty"`
+
+	// Max number of P2P peers to connect to
+	MaxPeers Parameter `yaml:"maxPeers,omitempty"`
+
+	// The pruning mode
+	PruneMode Parameter `yaml:"pruneMode,omitempty"`
+
+	// The Docker Hub tag for Erigon
+	ContainerTag Parameter `yaml:"containerTag,omitempty"`
+
+	// Custom command line flags
+	AdditionalFlags Parameter `yaml:"additionalFlags,omitempty"`
+}
+
+// Generates a new Erigon configuration
+func NewErigonConfig(config *RocketPoolConfig, isFallback bool) *ErigonConfig {
+
+	prefix := ""
+	if isFallback {
+		prefix = "FALLBACK_"
+	}
+
+	title := "Erigon Settings"
+	if isFallback {
+		title = "Fallback Erigon Settings"
+	}
+
+	return &ErigonConfig{
+		Title: title,
+
+		UnsupportedCommonParams: []string{},
+
+		CompatibleConsensusClients: []ConsensusClient{
+			ConsensusClient_Lighthouse,
+			ConsensusClient_Nimbus,
+			ConsensusClient_Prysm,
+			ConsensusClient_Teku,
+		},
+
+		EventLogInterval: erigonEventLogInterval,
+
+		CacheSize: Parameter{
+			ID:                   "cache",
+			Name:                 "Cache Size",
+			Description:          "The amount of RAM (in MB) you want Erigon's database cache to use. The default is based on how much total RAM your system has but you can adjust it manually.",
+			Type:                 ParameterType_Uint,
+			Default:              map[Network]interface{}{Network_All: calculateErigonCache()},
+			AffectsContainers:    []ContainerID{ContainerID_Eth1},
+			EnvironmentVariables: []string{prefix + "EC_CACHE_SIZE"},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		MaxPeers: Parameter{
+			ID:                   "maxPeers",
+			Name:                 "Max Peers",
+			Description:          "The maximum number of peers Erigon should connect to. This can be lowered to improve performance on low-power systems or constrained networks. We recommend keeping it at 12 or higher.",
+			Type:                 ParameterType_Uint16,
+			Default:              map[Network]interface{}{Network_All: calculateErigonPeers()},
+			AffectsContainers:    []ContainerID{ContainerID_Eth1},
+			EnvironmentVariables: []string{prefix + "EC_MAX_PEERS"},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		PruneMode: Parameter{
+			ID:                   "pruneMode",
+			Name:                 "Pruning Mode",
+			Description:          "Choose how much historical data Erigon should keep.\n\n[orange]NOTE: Erigon can't change its pruning mode once it has started syncing, so you will have to resync it if you change this setting later.",
+			Type:                 ParameterType_Choice,
+			Default:              map[Network]interface{}{Network_All: ErigonPruneMode_Full},
+			AffectsContainers:    []ContainerID{ContainerID_Eth1},
+			EnvironmentVariables: []string{prefix + "ERIGON_PRUNE_MODE"},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+			Options: []ParameterOption{{
+				Name:        "Full",
+				Description: "Prune historical state, receipts, transaction lookups and call traces that are older than the last 90,000 blocks. This is the recommended mode for a Rocket Pool node.",
+				Value:       ErigonPruneMode_Full,
+			}, {
+				Name:        "Archive",
+				Description: "Keep all of the historical data of the chain. This requires significantly more disk space.",
+				Value:       ErigonPruneMode_Archive,
+			}},
+		},
+
+		ContainerTag: Parameter{
+			ID:                   "containerTag",
+			Name:                 "Container Tag",
+			Description:          "The tag name of the Erigon container you want to use on Docker Hub.",
+			Type:                 ParameterType_String,
+			Default:              map[Network]interface{}{Network_All: getErigonTag()},
+			AffectsContainers:    []ContainerID{ContainerID_Eth1},
+			EnvironmentVariables: []string{prefix + "EC_CONTAINER_TAG"},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   true,
+		},
+
+		AdditionalFlags: Parameter{
+			ID:                   "additionalFlags",
+			Name:                 "Additional Flags",
+			Description:          "Additional custom command line flags you want to pass to Erigon, to take advantage of other settings that the Smartnode's configuration doesn't cover.",
+			Type:                 ParameterType_String,
+			Default:              map[Network]interface{}{Network_All: ""},
+			AffectsContainers:    []ContainerID{ContainerID_Eth1},
+			EnvironmentVariables: []string{prefix + "EC_ADDITIONAL_FLAGS"},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+	}
+}
+
+// Calculate the recommended size for Erigon's cache based on the amount of system RAM
+func calculateErigonCache() uint64 {
+	totalMemoryGB := memory.TotalMemory() / 1024 / 1024 / 1024
+
+	if totalMemoryGB == 0 {
+		return 0
+	} else if totalMemoryGB < 9 {
+		return 512
+	} else if totalMemoryGB < 13 {
+		return 1024
+	} else if totalMemoryGB < 17 {
+		return 2048
+	} else {
+		return 4096
+	}
+}
+
+// Calculate the default number of Erigon peers
+func calculateErigonPeers() uint16 {
+	if runtime.GOARCH == "arm64" {
+		return 25
+	}
+	return 50
+}
+
+// Get the container tag for Erigon based on the current architecture
+func getErigonTag() string {
+	if runtime.GOARCH == "arm64" {
+		return erigonTagArm64
+	} else if runtime.GOARCH == "amd64" {
+		return erigonTagAmd64
+	} else {
+		panic(fmt.Sprintf("Erigon doesn't support architecture %s", runtime.GOARCH))
+	}
+}
+
+// Get the parameters for this config
+func (config *ErigonConfig) GetParameters() []*Parameter {
+	return []*Parameter{
+		&config.CacheSize,
+		&config.MaxPeers,
+		&config.PruneMode,
+		&config.ContainerTag,
+		&config.AdditionalFlags,
+	}
+}
+
+// The the title for the config
+func (config *ErigonConfig) GetConfigTitle() string {
+	return config.Title
+}
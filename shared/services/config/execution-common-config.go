@@ -138,6 +138,16 @@ func (config *ExecutionCommonConfig) GetParameters() []*Parameter {
 	}
 }
 
+// Get the HTTP API port
+func (config *ExecutionCommonConfig) GetHttpPort() uint16 {
+	return config.HttpPort.GetUint16OrDefault(Network_All)
+}
+
+// Get the Websocket API port
+func (config *ExecutionCommonConfig) GetWsPort() uint16 {
+	return config.WsPort.GetUint16OrDefault(Network_All)
+}
+
 // The the title for the config
 func (config *ExecutionCommonConfig) GetConfigTitle() string {
 	return config.Title
@@ -1,5 +1,10 @@
 package config
 
+import (
+	"fmt"
+	"strings"
+)
+
 // Configuration for external Execution clients
 type ExternalExecutionConfig struct {
 	Title string `yaml:"-"`
@@ -9,6 +14,21 @@ type ExternalExecutionConfig struct {
 
 	// The URL of the websocket endpoint
 	WsUrl Parameter `yaml:"wsUrl,omitempty"`
+
+	// Toggle for throttling the requests sent to the client, for rate-limited providers
+	RateLimitMode Parameter `yaml:"rateLimitMode,omitempty"`
+
+	// The most requests that can be sent to the client at once in rate-limit mode
+	MaxConcurrentRequests Parameter `yaml:"maxConcurrentRequests,omitempty"`
+
+	// How much longer the daemons wait between polls in rate-limit mode
+	PollingMultiplier Parameter `yaml:"pollingMultiplier,omitempty"`
+
+	// Other HTTP endpoints for the same chain that the daemons can send requests to
+	AdditionalHttpUrls Parameter `yaml:"additionalHttpUrls,omitempty"`
+
+	// How requests are spread across the HTTP endpoints
+	RoutingMode Parameter `yaml:"routingMode,omitempty"`
 }
 
 // Configuration for external Consensus clients
@@ -29,6 +49,9 @@ type ExternalLighthouseConfig struct {
 
 	// Custom command line flags for the VC
 	AdditionalVcFlags Parameter `yaml:"additionalVcFlags,omitempty"`
+
+	// The file in the validators folder that holds the token for the VC's Keymanager API
+	KeymanagerTokenFile Parameter `yaml:"keymanagerTokenFile,omitempty"`
 }
 
 // Configuration for an external Prysm clients
@@ -44,8 +67,8 @@ type ExternalPrysmConfig struct {
 	// Toggle for enabling doppelganger detection
 	DoppelgangerDetection Parameter `yaml:"doppelgangerDetection,omitempty"`
 
-	// The URL of the JSON-RPC endpoint for the Validator client
-	JsonRpcUrl Parameter `yaml:"jsonRpcUrl,omitempty"`
+	// The URL of the legacy gRPC endpoint for the Validator client; if blank, it uses the Beacon API at HttpUrl
+	GrpcUrl Parameter `yaml:"grpcUrl,omitempty"`
 
 	// The Docker Hub tag for Prysm's VC
 	ContainerTag Parameter `yaml:"containerTag,omitempty"`
@@ -69,6 +92,12 @@ type ExternalTekuConfig struct {
 
 	// Custom command line flags for the VC
 	AdditionalVcFlags Parameter `yaml:"additionalVcFlags,omitempty"`
+
+	// The file in the validators folder that holds the token for the VC's Keymanager API
+	KeymanagerTokenFile Parameter `yaml:"keymanagerTokenFile,omitempty"`
+
+	// The file in the validators folder that holds the TLS certificate for the VC's Keymanager API
+	KeymanagerCertFile Parameter `yaml:"keymanagerCertFile,omitempty"`
 }
 
 // Generates a new ExternalExecutionConfig configuration
@@ -94,6 +123,7 @@ func NewExternalExecutionConfig(config *RocketPoolConfig, isFallback bool) *Exte
 			EnvironmentVariables: []string{prefix + "EC_HTTP_ENDPOINT"},
 			CanBeBlank:           false,
 			OverwriteOnUpgrade:   false,
+			Sensitive:            true,
 		},
 
 		WsUrl: Parameter{
@@ -106,6 +136,80 @@ func NewExternalExecutionConfig(config *RocketPoolConfig, isFallback bool) *Exte
 			EnvironmentVariables: []string{prefix + "EC_WS_ENDPOINT"},
 			CanBeBlank:           false,
 			OverwriteOnUpgrade:   false,
+			Sensitive:            true,
+		},
+
+		RateLimitMode: Parameter{
+			ID:                   "rateLimitMode",
+			Name:                 "Rate-Limit Friendly Mode",
+			Description:          "Enable this if your client is a hosted provider that limits how many requests you can make, so the Smartnode doesn't get throttled or run through your plan's quota.\n\nIt caps the number of requests sent at once, combines the status checks into batch requests, and makes the daemons check the chain less often. Commands and tasks will take longer as a result.",
+			Type:                 ParameterType_Bool,
+			Default:              map[Network]interface{}{Network_All: false},
+			AffectsContainers:    []ContainerID{ContainerID_Api, ContainerID_Node, ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		MaxConcurrentRequests: Parameter{
+			ID:                   "maxConcurrentRequests",
+			Name:                 "Max Concurrent Requests",
+			Description:          "The most requests the Smartnode will send to your client at the same time when Rate-Limit Friendly Mode is enabled. Any others wait until an earlier one finishes.",
+			Type:                 ParameterType_Uint,
+			Default:              map[Network]interface{}{Network_All: uint64(4)},
+			AffectsContainers:    []ContainerID{ContainerID_Api, ContainerID_Node, ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+			Advanced:             true,
+		},
+
+		PollingMultiplier: Parameter{
+			ID:                   "pollingMultiplier",
+			Name:                 "Polling Interval Multiplier",
+			Description:          "How many times longer the daemons wait between their task runs, chain checks, and sync polls when Rate-Limit Friendly Mode is enabled.",
+			Type:                 ParameterType_Uint,
+			Default:              map[Network]interface{}{Network_All: uint64(3)},
+			AffectsContainers:    []ContainerID{ContainerID_Node, ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+			Advanced:             true,
+		},
+
+		AdditionalHttpUrls: Parameter{
+			ID:                   "additionalHttpUrls",
+			Name:                 "Additional HTTP URLs",
+			Description:          "A comma-separated list of other HTTP RPC endpoints for the same chain, such as a second provider or another machine running a client.\n\nThe Smartnode checks the health of each one and routes around the ones that are down or out of sync, so a single flaky provider doesn't stop your node's automatic tasks. Only the Smartnode's own daemons use these; your Consensus client still uses the HTTP URL above.",
+			Type:                 ParameterType_String,
+			Default:              map[Network]interface{}{Network_All: ""},
+			AffectsContainers:    []ContainerID{ContainerID_Api, ContainerID_Node, ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+			Sensitive:            true,
+		},
+
+		RoutingMode: Parameter{
+			ID:                   "routingMode",
+			Name:                 "Routing Mode",
+			Description:          "How the Smartnode spreads its requests across the HTTP URL and the Additional HTTP URLs.",
+			Type:                 ParameterType_Choice,
+			Default:              map[Network]interface{}{Network_All: EcRoutingMode_Fallback},
+			AffectsContainers:    []ContainerID{ContainerID_Api, ContainerID_Node, ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+			Advanced:             true,
+			Options: []ParameterOption{{
+				Name:        "Primary / Fallback",
+				Description: "Send every request to the first healthy endpoint in the order they're listed, starting with the HTTP URL.",
+				Value:       EcRoutingMode_Fallback,
+			}, {
+				Name:        "Round Robin",
+				Description: "Take turns sending requests to each healthy endpoint, which spreads the load across providers with request limits.",
+				Value:       EcRoutingMode_RoundRobin,
+			}},
 		},
 	}
 }
@@ -125,6 +229,7 @@ func NewExternalLighthouseConfig(config *RocketPoolConfig) *ExternalLighthouseCo
 			EnvironmentVariables: []string{"CC_API_ENDPOINT"},
 			CanBeBlank:           false,
 			OverwriteOnUpgrade:   false,
+			Sensitive:            true,
 		},
 
 		Graffiti: Parameter{
@@ -175,6 +280,19 @@ func NewExternalLighthouseConfig(config *RocketPoolConfig) *ExternalLighthouseCo
 			CanBeBlank:           true,
 			OverwriteOnUpgrade:   false,
 		},
+
+		KeymanagerTokenFile: Parameter{
+			ID:                   "keymanagerTokenFile",
+			Name:                 "Keymanager API Token File",
+			Description:          "The file in your validators folder that holds the token for Lighthouse's Keymanager API. The Smartnode uses the API to load new validator keys without restarting Lighthouse, if you've enabled that in the Smartnode settings.\n\nLeave this blank to use the token the Smartnode generates (`keymanager-token`).",
+			Type:                 ParameterType_String,
+			Default:              map[Network]interface{}{Network_All: ""},
+			AffectsContainers:    []ContainerID{ContainerID_Api, ContainerID_Node, ContainerID_Validator},
+			EnvironmentVariables: []string{"VC_KEYMANAGER_TOKEN_FILE"},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+			Advanced:             true,
+		},
 	}
 }
 
@@ -193,18 +311,21 @@ func NewExternalPrysmConfig(config *RocketPoolConfig) *ExternalPrysmConfig {
 			EnvironmentVariables: []string{"CC_API_ENDPOINT"},
 			CanBeBlank:           false,
 			OverwriteOnUpgrade:   false,
+			Sensitive:            true,
 		},
 
-		JsonRpcUrl: Parameter{
-			ID:                   "jsonRpcUrl",
-			Name:                 "JSON-RPC URL",
-			Description:          "The URL of the JSON-RPC API endpoint for your external client. Prysm's validator client will need this in order to connect to it.\nNOTE: If you are running it on the same machine as the Smartnode, addresses like `localhost` and `127.0.0.1` will not work due to Docker limitations. Enter your machine's LAN IP address instead.",
+		GrpcUrl: Parameter{
+			ID:                   "grpcUrl",
+			Name:                 "gRPC URL (Legacy)",
+			Description:          "The URL of the gRPC API endpoint for your external client, for older versions of Prysm that don't support connecting their validator client over the Beacon API.\nLeave this blank to have Prysm's validator client connect over the HTTP URL above instead; Prysm is deprecating its gRPC API, so this is recommended.\nNOTE: If you are running it on the same machine as the Smartnode, addresses like `localhost` and `127.0.0.1` will not work due to Docker limitations. Enter your machine's LAN IP address instead.",
 			Type:                 ParameterType_String,
 			Default:              map[Network]interface{}{Network_All: ""},
-			AffectsContainers:    []ContainerID{ContainerID_Eth1},
+			AffectsContainers:    []ContainerID{ContainerID_Validator},
 			EnvironmentVariables: []string{"CC_RPC_ENDPOINT"},
-			CanBeBlank:           false,
+			CanBeBlank:           true,
 			OverwriteOnUpgrade:   false,
+			Advanced:             true,
+			Sensitive:            true,
 		},
 
 		Graffiti: Parameter{
@@ -273,6 +394,7 @@ func NewExternalTekuConfig(config *RocketPoolConfig) *ExternalTekuConfig {
 			EnvironmentVariables: []string{"CC_API_ENDPOINT"},
 			CanBeBlank:           false,
 			OverwriteOnUpgrade:   false,
+			Sensitive:            true,
 		},
 
 		Graffiti: Parameter{
@@ -311,6 +433,32 @@ func NewExternalTekuConfig(config *RocketPoolConfig) *ExternalTekuConfig {
 			CanBeBlank:           true,
 			OverwriteOnUpgrade:   false,
 		},
+
+		KeymanagerTokenFile: Parameter{
+			ID:                   "keymanagerTokenFile",
+			Name:                 "Keymanager API Token File",
+			Description:          "The file in your validators folder that holds the token for Teku's Keymanager API. The Smartnode uses the API to load new validator keys without restarting Teku, if you've enabled that in the Smartnode settings.\n\nLeave this blank to use the token the Smartnode generates (`keymanager-token`).",
+			Type:                 ParameterType_String,
+			Default:              map[Network]interface{}{Network_All: ""},
+			AffectsContainers:    []ContainerID{ContainerID_Api, ContainerID_Node, ContainerID_Validator},
+			EnvironmentVariables: []string{"VC_KEYMANAGER_TOKEN_FILE"},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+			Advanced:             true,
+		},
+
+		KeymanagerCertFile: Parameter{
+			ID:                   "keymanagerCertFile",
+			Name:                 "Keymanager API Certificate File",
+			Description:          "The file in your validators folder that holds the TLS certificate Teku serves its Keymanager API with, in PEM format. If it's set, the Smartnode connects to the API over HTTPS and only trusts this certificate.\n\nLeave this blank if Teku serves the API without TLS.",
+			Type:                 ParameterType_String,
+			Default:              map[Network]interface{}{Network_All: ""},
+			AffectsContainers:    []ContainerID{ContainerID_Api, ContainerID_Node, ContainerID_Validator},
+			EnvironmentVariables: []string{"VC_KEYMANAGER_CERT_FILE"},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+			Advanced:             true,
+		},
 	}
 }
 
@@ -319,6 +467,11 @@ func (config *ExternalExecutionConfig) GetParameters() []*Parameter {
 	return []*Parameter{
 		&config.HttpUrl,
 		&config.WsUrl,
+		&config.RateLimitMode,
+		&config.MaxConcurrentRequests,
+		&config.PollingMultiplier,
+		&config.AdditionalHttpUrls,
+		&config.RoutingMode,
 	}
 }
 
@@ -330,6 +483,7 @@ func (config *ExternalLighthouseConfig) GetParameters() []*Parameter {
 		&config.DoppelgangerDetection,
 		&config.ContainerTag,
 		&config.AdditionalVcFlags,
+		&config.KeymanagerTokenFile,
 	}
 }
 
@@ -337,7 +491,7 @@ func (config *ExternalLighthouseConfig) GetParameters() []*Parameter {
 func (config *ExternalPrysmConfig) GetParameters() []*Parameter {
 	return []*Parameter{
 		&config.HttpUrl,
-		&config.JsonRpcUrl,
+		&config.GrpcUrl,
 		&config.Graffiti,
 		&config.DoppelgangerDetection,
 		&config.ContainerTag,
@@ -345,6 +499,15 @@ func (config *ExternalPrysmConfig) GetParameters() []*Parameter {
 	}
 }
 
+// Get the flags that tell Prysm's VC how to connect to the external client.
+// Without a legacy gRPC URL, the VC has to be told explicitly to use the Beacon API since it still defaults to gRPC.
+func (config *ExternalPrysmConfig) GetValidatorClientFlags() string {
+	if config.GrpcUrl.Value != "" {
+		return ""
+	}
+	return fmt.Sprintf("--enable-beacon-rest-api --beacon-rest-api-provider=%s", config.HttpUrl.Value)
+}
+
 // Get the parameters for this config
 func (config *ExternalTekuConfig) GetParameters() []*Parameter {
 	return []*Parameter{
@@ -352,37 +515,89 @@ func (config *ExternalTekuConfig) GetParameters() []*Parameter {
 		&config.Graffiti,
 		&config.ContainerTag,
 		&config.AdditionalVcFlags,
+		&config.KeymanagerTokenFile,
+		&config.KeymanagerCertFile,
+	}
+}
+
+// Get the HTTP API url from the config
+func (config *ExternalExecutionConfig) GetHttpUrl() string {
+	return config.HttpUrl.GetStringOrDefault(Network_All)
+}
+
+// Get all of the HTTP API urls from the config, starting with the main one
+func (config *ExternalExecutionConfig) GetHttpUrls() []string {
+	urls := []string{config.GetHttpUrl()}
+	for _, url := range strings.Split(config.AdditionalHttpUrls.GetStringOrDefault(Network_All), ",") {
+		url = strings.TrimSpace(url)
+		if url != "" {
+			urls = append(urls, url)
+		}
+	}
+	return urls
+}
+
+// Get how requests are spread across the HTTP endpoints
+func (config *ExternalExecutionConfig) GetRoutingMode() EcRoutingMode {
+	mode, ok := config.RoutingMode.Value.(EcRoutingMode)
+	if !ok {
+		return EcRoutingMode_Fallback
+	}
+	return mode
+}
+
+// Get the most requests that can be sent to the client at once, or 0 if they aren't limited
+func (config *ExternalExecutionConfig) GetMaxConcurrentRequests() uint64 {
+	if !config.RateLimitMode.GetBoolOrDefault(Network_All) {
+		return 0
+	}
+	maxRequests := config.MaxConcurrentRequests.GetUintOrDefault(Network_All)
+	if maxRequests == 0 {
+		maxRequests = 1
+	}
+	return maxRequests
+}
+
+// Get how many times longer the daemons should wait between polls
+func (config *ExternalExecutionConfig) GetPollingMultiplier() uint64 {
+	if !config.RateLimitMode.GetBoolOrDefault(Network_All) {
+		return 1
+	}
+	multiplier := config.PollingMultiplier.GetUintOrDefault(Network_All)
+	if multiplier == 0 {
+		multiplier = 1
 	}
+	return multiplier
 }
 
 // Get the Docker container name of the validator client
 func (config *ExternalLighthouseConfig) GetValidatorImage() string {
-	return config.ContainerTag.Value.(string)
+	return config.ContainerTag.GetStringOrDefault(Network_All)
 }
 
 // Get the Docker container name of the validator client
 func (config *ExternalPrysmConfig) GetValidatorImage() string {
-	return config.ContainerTag.Value.(string)
+	return config.ContainerTag.GetStringOrDefault(Network_All)
 }
 
 // Get the Docker container name of the validator client
 func (config *ExternalTekuConfig) GetValidatorImage() string {
-	return config.ContainerTag.Value.(string)
+	return config.ContainerTag.GetStringOrDefault(Network_All)
 }
 
 // Get the API url from the config
 func (config *ExternalLighthouseConfig) GetApiUrl() string {
-	return config.HttpUrl.Value.(string)
+	return config.HttpUrl.GetStringOrDefault(Network_All)
 }
 
 // Get the API url from the config
 func (config *ExternalPrysmConfig) GetApiUrl() string {
-	return config.HttpUrl.Value.(string)
+	return config.HttpUrl.GetStringOrDefault(Network_All)
 }
 
 // Get the API url from the config
 func (config *ExternalTekuConfig) GetApiUrl() string {
-	return config.HttpUrl.Value.(string)
+	return config.HttpUrl.GetStringOrDefault(Network_All)
 }
 
 // Get the name of the client
@@ -15,6 +15,12 @@ type GrafanaConfig struct {
 
 	// The Docker Hub tag for Grafana
 	ContainerTag Parameter `yaml:"containerTag,omitempty"`
+
+	// Toggle for adding annotations to the dashboards when the Smartnode does something important
+	EnableAnnotations Parameter `yaml:"enableAnnotations,omitempty"`
+
+	// The service account token used to add annotations
+	AnnotationsToken Parameter `yaml:"annotationsToken,omitempty"`
 }
 
 // Generates a new Grafana config
@@ -45,6 +51,31 @@ func NewGrafanaConfig(config *RocketPoolConfig) *GrafanaConfig {
 			CanBeBlank:           false,
 			OverwriteOnUpgrade:   true,
 		},
+
+		EnableAnnotations: Parameter{
+			ID:                   "enableAnnotations",
+			Name:                 "Enable Annotations",
+			Description:          "Enable this to have the Smartnode mark important events on your Grafana dashboards, such as containers being restarted, settings being changed, rewards being claimed, and problems like your Execution client going down. This gives your graphs context about what was happening at the time.",
+			Type:                 ParameterType_Bool,
+			Default:              map[Network]interface{}{Network_All: false},
+			AffectsContainers:    []ContainerID{ContainerID_Node, ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		AnnotationsToken: Parameter{
+			ID:                   "annotationsToken",
+			Name:                 "Annotations Token",
+			Description:          "The token of a Grafana service account with the Editor role, which the Smartnode will use to add annotations. You can create one in Grafana under Configuration > Service accounts.",
+			Type:                 ParameterType_String,
+			Default:              map[Network]interface{}{Network_All: ""},
+			AffectsContainers:    []ContainerID{ContainerID_Node, ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+			Sensitive:            true,
+		},
 	}
 }
 
@@ -53,6 +84,8 @@ func (config *GrafanaConfig) GetParameters() []*Parameter {
 	return []*Parameter{
 		&config.Port,
 		&config.ContainerTag,
+		&config.EnableAnnotations,
+		&config.AnnotationsToken,
 	}
 }
 
@@ -33,8 +33,10 @@ type InfuraConfig struct {
 func NewInfuraConfig(config *RocketPoolConfig, isFallback bool) *InfuraConfig {
 
 	prefix := ""
+	ecContainerID := ContainerID_Eth1
 	if isFallback {
 		prefix = "FALLBACK_"
+		ecContainerID = ContainerID_Eth1Fallback
 	}
 
 	title := "Infura Settings"
@@ -61,10 +63,12 @@ func NewInfuraConfig(config *RocketPoolConfig, isFallback bool) *InfuraConfig {
 			Type:                 ParameterType_String,
 			Default:              map[Network]interface{}{Network_All: ""},
 			Regex:                "^[0-9a-fA-F]{32}$",
-			AffectsContainers:    []ContainerID{ContainerID_Eth1},
+			AffectsContainers:    []ContainerID{ecContainerID},
 			EnvironmentVariables: []string{prefix + "INFURA_PROJECT_ID"},
 			CanBeBlank:           false,
 			OverwriteOnUpgrade:   false,
+			Sensitive:            true,
+			MountAsFile:          true,
 		},
 
 		ContainerTag: Parameter{
@@ -73,7 +77,7 @@ func NewInfuraConfig(config *RocketPoolConfig, isFallback bool) *InfuraConfig {
 			Description:          "The tag name of the Rocket Pool EC Proxy container you want to use on Docker Hub.\nYou should leave this as the default unless you have a good reason to change it.",
 			Type:                 ParameterType_String,
 			Default:              map[Network]interface{}{Network_All: powProxyTag},
-			AffectsContainers:    []ContainerID{ContainerID_Eth1},
+			AffectsContainers:    []ContainerID{ecContainerID},
 			EnvironmentVariables: []string{prefix + "EC_CONTAINER_TAG"},
 			CanBeBlank:           false,
 			OverwriteOnUpgrade:   true,
@@ -85,7 +89,7 @@ func NewInfuraConfig(config *RocketPoolConfig, isFallback bool) *InfuraConfig {
 			Description:          "Additional custom command line flags you want to pass to the EC Proxy, to take advantage of other settings that the Smartnode's configuration doesn't cover.",
 			Type:                 ParameterType_String,
 			Default:              map[Network]interface{}{Network_All: ""},
-			AffectsContainers:    []ContainerID{ContainerID_Eth1},
+			AffectsContainers:    []ContainerID{ecContainerID},
 			EnvironmentVariables: []string{prefix + "EC_ADDITIONAL_FLAGS"},
 			CanBeBlank:           true,
 			OverwriteOnUpgrade:   false,
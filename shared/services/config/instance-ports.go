@@ -0,0 +1,43 @@
+package config
+
+import (
+	"strings"
+)
+
+// Get the settings for the ports this configuration uses, across all of its sections
+func (config *RocketPoolConfig) GetPortParameters() []*Parameter {
+	params := []*Parameter{}
+	addPorts := func(sectionParams []*Parameter) {
+		for _, param := range sectionParams {
+			if param.Type == ParameterType_Uint16 && strings.HasSuffix(strings.ToLower(param.ID), "port") {
+				params = append(params, param)
+			}
+		}
+	}
+	addPorts(config.GetParameters())
+	for _, subconfig := range config.GetSubconfigs() {
+		addPorts(subconfig.GetParameters())
+	}
+	return params
+}
+
+// Get the ports this configuration uses, including the ones derived from other port settings
+func (config *RocketPoolConfig) GetPorts() map[uint16]bool {
+	network := config.Smartnode.GetNetwork()
+	ports := map[uint16]bool{}
+	for _, param := range config.GetPortParameters() {
+		ports[param.GetUint16OrDefault(network)] = true
+	}
+	for _, daemon := range []string{NodeContainerName, WatchtowerContainerName, ApiContainerName} {
+		ports[config.Smartnode.GetHealthCheckPort(daemon)] = true
+	}
+	return ports
+}
+
+// Move all of the ports by the provided offset, so this configuration can run next to another Smartnode instance on the same machine
+func (config *RocketPoolConfig) OffsetPorts(offset uint16) {
+	network := config.Smartnode.GetNetwork()
+	for _, param := range config.GetPortParameters() {
+		param.Value = param.GetUint16OrDefault(network) + offset
+	}
+}
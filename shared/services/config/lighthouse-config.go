@@ -21,6 +21,9 @@ type LighthouseConfig struct {
 
 	// Custom command line flags for the VC
 	AdditionalVcFlags Parameter `yaml:"additionalVcFlags,omitempty"`
+
+	// The file in the validators folder that holds the token for the VC's Keymanager API
+	KeymanagerTokenFile Parameter `yaml:"keymanagerTokenFile,omitempty"`
 }
 
 // Generates a new Lighthouse configuration
@@ -75,6 +78,19 @@ func NewLighthouseConfig(config *RocketPoolConfig) *LighthouseConfig {
 			CanBeBlank:           true,
 			OverwriteOnUpgrade:   false,
 		},
+
+		KeymanagerTokenFile: Parameter{
+			ID:                   "keymanagerTokenFile",
+			Name:                 "Keymanager API Token File",
+			Description:          "The file in your validators folder that holds the token for Lighthouse's Keymanager API. The Smartnode uses the API to load new validator keys without restarting Lighthouse, if you've enabled that in the Smartnode settings.\n\nLeave this blank to use the token the Smartnode generates (`keymanager-token`).",
+			Type:                 ParameterType_String,
+			Default:              map[Network]interface{}{Network_All: ""},
+			AffectsContainers:    []ContainerID{ContainerID_Api, ContainerID_Node, ContainerID_Validator},
+			EnvironmentVariables: []string{"VC_KEYMANAGER_TOKEN_FILE"},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+			Advanced:             true,
+		},
 	}
 }
 
@@ -85,6 +101,7 @@ func (config *LighthouseConfig) GetParameters() []*Parameter {
 		&config.ContainerTag,
 		&config.AdditionalBnFlags,
 		&config.AdditionalVcFlags,
+		&config.KeymanagerTokenFile,
 	}
 }
 
@@ -95,7 +112,7 @@ func (config *LighthouseConfig) GetUnsupportedCommonParams() []string {
 
 // Get the Docker container name of the validator client
 func (config *LighthouseConfig) GetValidatorImage() string {
-	return config.ContainerTag.Value.(string)
+	return config.ContainerTag.GetStringOrDefault(Network_All)
 }
 
 // Get the name of the client
@@ -25,6 +25,10 @@ func UpdateConfig(serializedConfig map[string]map[string]string) error {
 	if err != nil {
 		return err
 	}
+	v144, err := parseVersion("1.4.4")
+	if err != nil {
+		return err
+	}
 
 	// Create the collection of upgraders
 	upgraders := []ConfigUpgrader{
@@ -32,13 +36,18 @@ func UpdateConfig(serializedConfig map[string]map[string]string) error {
 			Version:     v131,
 			UpgradeFunc: upgradeFromV131,
 		},
+		{
+			Version:     v144,
+			UpgradeFunc: upgradeFromV144,
+		},
 	}
 
-	// Find the index of the provided config's version
+	// Find the index of the first upgrade the provided config's version needs
 	targetIndex := -1
 	for i, upgrader := range upgraders {
 		if configVersion.LessThanOrEqual(upgrader.Version) {
 			targetIndex = i
+			break
 		}
 	}
 
@@ -0,0 +1,85 @@
+package migration
+
+import "testing"
+
+// Get a serialized config from the provided version with the settings the upgrades change
+func newTestConfig(version string) map[string]map[string]string {
+	return map[string]map[string]string{
+		"root": {
+			"version": version,
+		},
+		"geth": {
+			"p2pPort":       "30304",
+			"ethstatsLabel": "label",
+			"ethstatsLogin": "login",
+		},
+		"executionCommon": {},
+		"externalPrysm": {
+			"httpUrl":    "http://192.168.1.40:5052",
+			"jsonRpcUrl": "192.168.1.40:5053",
+		},
+	}
+}
+
+func TestUpgradeFromV144(t *testing.T) {
+	// The old JSON-RPC URL becomes the legacy gRPC URL
+	serializedConfig := newTestConfig("v1.4.4")
+	if err := upgradeFromV144(serializedConfig); err != nil {
+		t.Fatal(err)
+	}
+	prysm := serializedConfig["externalPrysm"]
+	if prysm["grpcUrl"] != "192.168.1.40:5053" {
+		t.Fatalf("expected the JSON-RPC URL to be migrated, got %q", prysm["grpcUrl"])
+	}
+	if _, exists := prysm["jsonRpcUrl"]; exists {
+		t.Fatal("expected the JSON-RPC URL to be removed")
+	}
+	if prysm["httpUrl"] != "http://192.168.1.40:5052" {
+		t.Fatalf("expected the HTTP URL to be left alone, got %q", prysm["httpUrl"])
+	}
+
+	// A gRPC URL that's already set isn't overwritten
+	serializedConfig = newTestConfig("v1.4.4")
+	serializedConfig["externalPrysm"]["grpcUrl"] = ""
+	if err := upgradeFromV144(serializedConfig); err != nil {
+		t.Fatal(err)
+	}
+	if url, exists := serializedConfig["externalPrysm"]["grpcUrl"]; !exists || url != "" {
+		t.Fatalf("expected the existing gRPC URL to be kept, got %q", url)
+	}
+
+	// Configs without an external Prysm section are left alone
+	serializedConfig = map[string]map[string]string{"root": {"version": "v1.4.4"}}
+	if err := upgradeFromV144(serializedConfig); err != nil {
+		t.Fatal(err)
+	}
+	if _, exists := serializedConfig["externalPrysm"]; exists {
+		t.Fatal("expected no external Prysm section to be added")
+	}
+}
+
+func TestUpdateConfigAppliesEveryUpgradeFromTheConfigVersion(t *testing.T) {
+	tests := []struct {
+		version     string
+		expectV131  bool
+		expectV144  bool
+		description string
+	}{
+		{"v1.3.0", true, true, "a config from before v1.3.1 gets both upgrades"},
+		{"v1.3.1", true, true, "a config from v1.3.1 gets both upgrades"},
+		{"v1.4.0", false, true, "a config from between the upgrades only gets the later one"},
+		{"v1.4.4", false, true, "a config from v1.4.4 gets its upgrade"},
+		{"v1.5.0", false, false, "a current config isn't upgraded"},
+	}
+	for _, test := range tests {
+		serializedConfig := newTestConfig(test.version)
+		if err := UpdateConfig(serializedConfig); err != nil {
+			t.Fatalf("%s: %s", test.description, err.Error())
+		}
+		_, appliedV131 := serializedConfig["executionCommon"]["p2pPort"]
+		_, appliedV144 := serializedConfig["externalPrysm"]["grpcUrl"]
+		if appliedV131 != test.expectV131 || appliedV144 != test.expectV144 {
+			t.Errorf("%s: expected the v1.3.1 upgrade to be applied: %t and the v1.4.4 upgrade: %t, got %t and %t", test.description, test.expectV131, test.expectV144, appliedV131, appliedV144)
+		}
+	}
+}
@@ -0,0 +1,21 @@
+package migration
+
+func upgradeFromV144(serializedConfig map[string]map[string]string) error {
+	// v1.4.4 required a JSON-RPC URL for external Prysm clients, which was really the URL of Prysm's gRPC API.
+	// The VC can use the Beacon API now, so the URL is kept as the optional legacy gRPC URL to leave existing setups as they were.
+	externalPrysmSettings, exists := serializedConfig["externalPrysm"]
+	if !exists {
+		return nil
+	}
+	jsonRpcUrl, exists := externalPrysmSettings["jsonRpcUrl"]
+	if !exists {
+		return nil
+	}
+	if _, exists := externalPrysmSettings["grpcUrl"]; !exists {
+		externalPrysmSettings["grpcUrl"] = jsonRpcUrl
+	}
+	delete(externalPrysmSettings, "jsonRpcUrl")
+	serializedConfig["externalPrysm"] = externalPrysmSettings
+
+	return nil
+}
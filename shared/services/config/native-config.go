@@ -17,6 +17,17 @@ type NativeConfig struct {
 
 	// The command for restarting the validator container in native mode
 	ValidatorRestartCommand Parameter `yaml:"validatorRestartCommand,omitempty"`
+
+	// The URL of the VC's Keymanager API
+	ValidatorKeymanagerUrl Parameter `yaml:"validatorKeymanagerUrl,omitempty"`
+
+	// The user the systemd services run as
+	ServiceUser Parameter `yaml:"serviceUser,omitempty"`
+
+	// The commands that run the clients as systemd services
+	EcCommand Parameter `yaml:"ecCommand,omitempty"`
+	CcCommand Parameter `yaml:"ccCommand,omitempty"`
+	VcCommand Parameter `yaml:"vcCommand,omitempty"`
 }
 
 // Generates a new Smartnode configuration
@@ -35,6 +46,7 @@ func NewNativeConfig(config *RocketPoolConfig) *NativeConfig {
 			EnvironmentVariables: []string{},
 			CanBeBlank:           false,
 			OverwriteOnUpgrade:   false,
+			Sensitive:            true,
 		},
 
 		ConsensusClient: Parameter{
@@ -76,6 +88,7 @@ func NewNativeConfig(config *RocketPoolConfig) *NativeConfig {
 			EnvironmentVariables: []string{},
 			CanBeBlank:           false,
 			OverwriteOnUpgrade:   false,
+			Sensitive:            true,
 		},
 
 		ValidatorRestartCommand: Parameter{
@@ -89,6 +102,66 @@ func NewNativeConfig(config *RocketPoolConfig) *NativeConfig {
 			CanBeBlank:           false,
 			OverwriteOnUpgrade:   false,
 		},
+
+		ValidatorKeymanagerUrl: Parameter{
+			ID:                   "validatorKeymanagerUrl",
+			Name:                 "VC Keymanager API URL",
+			Description:          "The URL of your Validator Client's Keymanager API (e.g. http://localhost:5062). The Smartnode uses this to import and export your slashing protection history. Put the API token in a file called `keymanager-token` in your validators folder.\n\nLeave this blank if your Validator Client doesn't have the Keymanager API enabled.",
+			Type:                 ParameterType_String,
+			Default:              map[Network]interface{}{Network_All: ""},
+			AffectsContainers:    []ContainerID{},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		ServiceUser: Parameter{
+			ID:                   "serviceUser",
+			Name:                 "Service User",
+			Description:          "The user account that the systemd services created by `rocketpool service install --native` run as.",
+			Type:                 ParameterType_String,
+			Default:              map[Network]interface{}{Network_All: "rp"},
+			AffectsContainers:    []ContainerID{},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		EcCommand: Parameter{
+			ID:                   "ecCommand",
+			Name:                 "EC Command",
+			Description:          "The full command, including the absolute path of the binary and its arguments, that runs your Execution client. If set, `rocketpool service install --native` creates a systemd service for it.\n\nLeave this blank if you manage your Execution client yourself.",
+			Type:                 ParameterType_String,
+			Default:              map[Network]interface{}{Network_All: ""},
+			AffectsContainers:    []ContainerID{},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		CcCommand: Parameter{
+			ID:                   "ccCommand",
+			Name:                 "CC Command",
+			Description:          "The full command, including the absolute path of the binary and its arguments, that runs your Consensus client's Beacon Node. If set, `rocketpool service install --native` creates a systemd service for it.\n\nLeave this blank if you manage your Consensus client yourself.",
+			Type:                 ParameterType_String,
+			Default:              map[Network]interface{}{Network_All: ""},
+			AffectsContainers:    []ContainerID{},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		VcCommand: Parameter{
+			ID:                   "vcCommand",
+			Name:                 "VC Command",
+			Description:          "The full command, including the absolute path of the binary and its arguments, that runs your Validator client. If set, `rocketpool service install --native` creates a systemd service for it.\n\nLeave this blank if you manage your Validator client yourself.",
+			Type:                 ParameterType_String,
+			Default:              map[Network]interface{}{Network_All: ""},
+			AffectsContainers:    []ContainerID{},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
 	}
 
 }
@@ -100,6 +173,11 @@ func (config *NativeConfig) GetParameters() []*Parameter {
 		&config.ConsensusClient,
 		&config.CcHttpUrl,
 		&config.ValidatorRestartCommand,
+		&config.ValidatorKeymanagerUrl,
+		&config.ServiceUser,
+		&config.EcCommand,
+		&config.CcCommand,
+		&config.VcCommand,
 	}
 }
 
@@ -0,0 +1,168 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+// The names of the systemd services that run the Smartnode in Native mode
+const (
+	NativeNodeServiceName       string = "rp-node"
+	NativeWatchtowerServiceName string = "rp-watchtower"
+	NativeValidatorServiceName  string = "rp-validator"
+	NativeEth1ServiceName       string = "rp-eth1"
+	NativeEth2ServiceName       string = "rp-eth2"
+)
+
+// The template used to render a systemd service file
+const nativeUnitTemplate string = `# This file is generated by the Smartnode; any changes will be overwritten the next time 'rocketpool service install --native' is run.
+[Unit]
+Description={{.Description}}
+{{- range .After}}
+After={{.}}
+{{- end}}
+{{- range .Wants}}
+Wants={{.}}
+{{- end}}
+
+[Service]
+Type=simple
+User={{.User}}
+Restart=always
+RestartSec=5
+ExecStart={{.Command}}
+
+[Install]
+WantedBy=multi-user.target
+`
+
+var parsedNativeUnitTemplate = template.Must(template.New("unit").Parse(nativeUnitTemplate))
+
+// A systemd service that runs part of the Smartnode in Native mode
+type NativeUnit struct {
+	Name        string
+	Description string
+	User        string
+	Command     string
+	After       []string
+	Wants       []string
+}
+
+// Get the name of the unit's service file
+func (unit *NativeUnit) GetFilename() string {
+	return unit.Name + ".service"
+}
+
+// Render the unit into a systemd service file
+func (unit *NativeUnit) Render() ([]byte, error) {
+	var buffer bytes.Buffer
+	err := parsedNativeUnitTemplate.Execute(&buffer, unit)
+	if err != nil {
+		return nil, fmt.Errorf("error rendering systemd unit for %s: %w", unit.Name, err)
+	}
+	return buffer.Bytes(), nil
+}
+
+// Quote an argument for a systemd command line, so spaces, quotes, specifiers and variables in it are taken literally
+func quoteUnitArg(arg string) string {
+	arg = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "%", "%%", "$", "$$").Replace(arg)
+	return `"` + arg + `"`
+}
+
+// Check that a client command starts with the absolute path of its binary, since systemd doesn't search the PATH for it
+func checkNativeCommand(name string, command string) error {
+	binary := command
+	if strings.HasPrefix(command, `"`) || strings.HasPrefix(command, "'") {
+		binary = strings.TrimLeft(command, `"'`)
+	} else if fields := strings.Fields(command); len(fields) > 0 {
+		binary = fields[0]
+	}
+	if !filepath.IsAbs(binary) {
+		return fmt.Errorf("the %s command must start with the absolute path of its binary, but it starts with [%s]", name, binary)
+	}
+	return nil
+}
+
+// Generates the systemd services for Native mode from the Native settings.
+// The node and watchtower always run from the daemon; the client services are only generated when their commands are set.
+// The daemon and settings paths are made absolute and quoted; the client commands are used as they are, so they must already start with an absolute path.
+func (config *RocketPoolConfig) GenerateNativeUnits(daemonPath string, settingsPath string) ([]*NativeUnit, error) {
+
+	user := config.Native.ServiceUser.Value.(string)
+	daemonPath, err := filepath.Abs(daemonPath)
+	if err != nil {
+		return nil, fmt.Errorf("error getting the absolute path of the daemon: %w", err)
+	}
+	settingsPath, err = filepath.Abs(settingsPath)
+	if err != nil {
+		return nil, fmt.Errorf("error getting the absolute path of the settings file: %w", err)
+	}
+	daemonCommand := fmt.Sprintf("%s --settings %s", quoteUnitArg(daemonPath), quoteUnitArg(settingsPath))
+
+	// The clients the Smartnode depends on, if they're managed here
+	clientServices := []string{"network-online.target"}
+	units := []*NativeUnit{}
+	if command := config.Native.EcCommand.Value.(string); command != "" {
+		if err := checkNativeCommand("Execution client", command); err != nil {
+			return nil, err
+		}
+		units = append(units, &NativeUnit{
+			Name:        NativeEth1ServiceName,
+			Description: "Rocket Pool Execution Client",
+			User:        user,
+			Command:     command,
+			After:       []string{"network-online.target"},
+			Wants:       []string{"network-online.target"},
+		})
+		clientServices = append(clientServices, NativeEth1ServiceName+".service")
+	}
+	if command := config.Native.CcCommand.Value.(string); command != "" {
+		if err := checkNativeCommand("Consensus client", command); err != nil {
+			return nil, err
+		}
+		units = append(units, &NativeUnit{
+			Name:        NativeEth2ServiceName,
+			Description: "Rocket Pool Consensus Client",
+			User:        user,
+			Command:     command,
+			After:       clientServices,
+			Wants:       []string{"network-online.target"},
+		})
+		clientServices = append(clientServices, NativeEth2ServiceName+".service")
+	}
+	if command := config.Native.VcCommand.Value.(string); command != "" {
+		if err := checkNativeCommand("Validator client", command); err != nil {
+			return nil, err
+		}
+		units = append(units, &NativeUnit{
+			Name:        NativeValidatorServiceName,
+			Description: "Rocket Pool Validator Client",
+			User:        user,
+			Command:     command,
+			After:       clientServices,
+			Wants:       []string{"network-online.target"},
+		})
+	}
+
+	// The Smartnode daemons
+	units = append(units, &NativeUnit{
+		Name:        NativeNodeServiceName,
+		Description: "Rocket Pool Node",
+		User:        user,
+		Command:     daemonCommand + " node",
+		After:       clientServices,
+		Wants:       []string{"network-online.target"},
+	}, &NativeUnit{
+		Name:        NativeWatchtowerServiceName,
+		Description: "Rocket Pool Watchtower",
+		User:        user,
+		Command:     daemonCommand + " watchtower",
+		After:       clientServices,
+		Wants:       []string{"network-online.target"},
+	})
+	return units, nil
+
+}
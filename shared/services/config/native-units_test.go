@@ -0,0 +1,63 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNativeUnitCommands(t *testing.T) {
+	cfg := NewRocketPoolConfig("/home/node/.rocketpool", true)
+	cfg.Native.ServiceUser.Value = "rp"
+	cfg.Native.EcCommand.Value = "/usr/bin/geth --http"
+	units, err := cfg.GenerateNativeUnits("/opt/rocket pool/rocketpoold", "/home/node/.rocketpool/100%$HOME/user-settings.yml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The daemon and settings paths are quoted, with the specifiers and variables escaped
+	expected := `ExecStart="/opt/rocket pool/rocketpoold" --settings "/home/node/.rocketpool/100%%$$HOME/user-settings.yml" node` + "\n"
+	found := false
+	for _, unit := range units {
+		if unit.Name != NativeNodeServiceName {
+			continue
+		}
+		found = true
+		contents, err := unit.Render()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(string(contents), expected) {
+			t.Fatalf("expected the node unit to contain %q, got:\n%s", expected, contents)
+		}
+	}
+	if !found {
+		t.Fatal("expected a node unit")
+	}
+
+	// Relative paths are made absolute
+	units, err = cfg.GenerateNativeUnits("rocketpoold", "user-settings.yml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, unit := range units {
+		if unit.Name == NativeNodeServiceName && !strings.HasPrefix(unit.Command, `"/`) {
+			t.Fatalf("expected the daemon path to be absolute, got %s", unit.Command)
+		}
+	}
+
+	// Client commands have to start with an absolute path
+	for command, valid := range map[string]bool{
+		"/usr/bin/lighthouse bn":          true,
+		`"/opt/my clients/lighthouse" bn`: true,
+		"lighthouse bn":                   false,
+		"./lighthouse bn":                 false,
+	} {
+		cfg.Native.CcCommand.Value = command
+		_, err := cfg.GenerateNativeUnits("/usr/bin/rocketpoold", "/home/node/.rocketpool/user-settings.yml")
+		if valid && err != nil {
+			t.Errorf("%q: %s", command, err.Error())
+		} else if !valid && (err == nil || !strings.Contains(err.Error(), "absolute path")) {
+			t.Errorf("%q: expected an error about the absolute path, got %v", command, err)
+		}
+	}
+}
@@ -37,6 +37,12 @@ type NethermindConfig struct {
 	// Nethermind's memory for pruning
 	PruneMemSize Parameter `yaml:"pruneMemSize,omitempty"`
 
+	// Nethermind's memory for full pruning
+	FullPruningMemoryBudget Parameter `yaml:"fullPruningMemoryBudget,omitempty"`
+
+	// The number of threads Nethermind uses for full pruning
+	FullPruningThreads Parameter `yaml:"fullPruningThreads,omitempty"`
+
 	// The Docker Hub tag for Nethermind
 	ContainerTag Parameter `yaml:"containerTag,omitempty"`
 
@@ -107,6 +113,30 @@ func NewNethermindConfig(config *RocketPoolConfig, isFallback bool) *NethermindC
 			OverwriteOnUpgrade:   false,
 		},
 
+		FullPruningMemoryBudget: Parameter{
+			ID:                   "fullPruningMemoryBudget",
+			Name:                 "Full Pruning Memory Budget",
+			Description:          "The amount of RAM (in MB) Nethermind can use to speed up a full prune, which is started with `rocketpool service prune-eth1`. Higher values make pruning faster.\n\nThe default value for this will be calculated dynamically based on your system's available RAM, but you can adjust it manually.",
+			Type:                 ParameterType_Uint,
+			Default:              map[Network]interface{}{Network_All: calculateNethermindFullPruningMemoryBudget()},
+			AffectsContainers:    []ContainerID{ContainerID_Eth1},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		FullPruningThreads: Parameter{
+			ID:                   "fullPruningThreads",
+			Name:                 "Full Pruning Threads",
+			Description:          "The number of CPU threads Nethermind can use for a full prune. More threads make pruning faster, but leave less room for Nethermind to keep up with the chain while it prunes.\n\nUse 0 to let Nethermind decide based on your CPU.",
+			Type:                 ParameterType_Uint,
+			Default:              map[Network]interface{}{Network_All: uint64(0)},
+			AffectsContainers:    []ContainerID{ContainerID_Eth1},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
 		ContainerTag: Parameter{
 			ID:                   "containerTag",
 			Name:                 "Container Tag",
@@ -175,6 +205,23 @@ func calculateNethermindPruneMemSize() uint64 {
 	}
 }
 
+// Calculate the recommended memory budget for Nethermind's full pruning based on the amount of system RAM
+func calculateNethermindFullPruningMemoryBudget() uint64 {
+	totalMemoryGB := memory.TotalMemory() / 1024 / 1024 / 1024
+
+	if totalMemoryGB == 0 {
+		return 0
+	} else if totalMemoryGB < 9 {
+		return 1024
+	} else if totalMemoryGB < 17 {
+		return 2048
+	} else if totalMemoryGB < 33 {
+		return 4096
+	} else {
+		return 8192
+	}
+}
+
 // Calculate the default number of Nethermind peers
 func calculateNethermindPeers() uint16 {
 	if runtime.GOARCH == "arm64" {
@@ -200,6 +247,8 @@ func (config *NethermindConfig) GetParameters() []*Parameter {
 		&config.CacheSize,
 		&config.MaxPeers,
 		&config.PruneMemSize,
+		&config.FullPruningMemoryBudget,
+		&config.FullPruningThreads,
 		&config.ContainerTag,
 		&config.AdditionalFlags,
 	}
@@ -0,0 +1,210 @@
+package config
+
+import (
+	"embed"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"gopkg.in/yaml.v2"
+)
+
+// The folder in the Rocket Pool directory that holds network manifest overrides
+const NetworkManifestFolderName string = "networks"
+
+// The manifests for the networks the Smartnode supports out of the box
+//
+//go:embed networks/*.yml
+var embeddedNetworkManifests embed.FS
+
+// The per-network constants the Smartnode needs to operate on a network
+type NetworkManifest struct {
+	Network     Network `yaml:"network"`
+	Name        string  `yaml:"name"`
+	Description string  `yaml:"description"`
+	ChainID     uint    `yaml:"chainID"`
+	TxWatchUrl  string  `yaml:"txWatchUrl"`
+	StakeUrl    string  `yaml:"stakeUrl"`
+	Contracts   struct {
+		Storage            string `yaml:"storage"`
+		OneInchOracle      string `yaml:"oneInchOracle"`
+		RplToken           string `yaml:"rplToken"`
+		RplFaucet          string `yaml:"rplFaucet"`
+		SnapshotDelegation string `yaml:"snapshotDelegation"`
+		Multicall          string `yaml:"multicall"`
+	} `yaml:"contracts"`
+
+	// Defaults for the settings that have network-specific values, keyed by `<section>.<setting ID>` (for example `smartnode.privateRelayUrl`)
+	Defaults map[string]string `yaml:"defaults"`
+}
+
+// Load the embedded network manifests, then apply any overrides or additional networks in the provided folder.
+// Any values set in an override file replace the embedded values for that network.
+func LoadNetworkManifests(overrideFolder string) ([]*NetworkManifest, error) {
+
+	manifestMap := map[Network]*NetworkManifest{}
+
+	// Load the embedded manifests
+	entries, err := embeddedNetworkManifests.ReadDir("networks")
+	if err != nil {
+		return nil, fmt.Errorf("error reading embedded network manifests: %w", err)
+	}
+	for _, entry := range entries {
+		bytes, err := embeddedNetworkManifests.ReadFile("networks/" + entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("error reading embedded network manifest [%s]: %w", entry.Name(), err)
+		}
+		manifest := new(NetworkManifest)
+		if err := yaml.Unmarshal(bytes, manifest); err != nil {
+			return nil, fmt.Errorf("error parsing embedded network manifest [%s]: %w", entry.Name(), err)
+		}
+		manifestMap[manifest.Network] = manifest
+	}
+
+	// Apply the overrides
+	if overrideFolder != "" {
+		files, err := ioutil.ReadDir(overrideFolder)
+		if err != nil && !os.IsNotExist(err) {
+			return nil, fmt.Errorf("error reading network manifest folder [%s]: %w", overrideFolder, err)
+		}
+		for _, file := range files {
+			if file.IsDir() || !(strings.HasSuffix(file.Name(), ".yml") || strings.HasSuffix(file.Name(), ".yaml")) {
+				continue
+			}
+			path := filepath.Join(overrideFolder, file.Name())
+			bytes, err := ioutil.ReadFile(path)
+			if err != nil {
+				return nil, fmt.Errorf("error reading network manifest [%s]: %w", path, err)
+			}
+
+			// Find the network this file is for, then layer it on top of the existing manifest
+			var header struct {
+				Network Network `yaml:"network"`
+			}
+			if err := yaml.Unmarshal(bytes, &header); err != nil {
+				return nil, fmt.Errorf("error parsing network manifest [%s]: %w", path, err)
+			}
+			if header.Network == "" {
+				header.Network = Network(strings.TrimSuffix(file.Name(), filepath.Ext(file.Name())))
+			}
+			manifest, exists := manifestMap[header.Network]
+			if !exists {
+				manifest = &NetworkManifest{Network: header.Network}
+				manifestMap[header.Network] = manifest
+			}
+			if err := yaml.Unmarshal(bytes, manifest); err != nil {
+				return nil, fmt.Errorf("error parsing network manifest [%s]: %w", path, err)
+			}
+			manifest.Network = header.Network
+		}
+	}
+
+	// Validate the manifests
+	manifests := []*NetworkManifest{}
+	for _, manifest := range manifestMap {
+		if err := manifest.Validate(); err != nil {
+			return nil, err
+		}
+		manifests = append(manifests, manifest)
+	}
+
+	// Sort by chain ID so mainnet always comes first
+	sort.Slice(manifests, func(i, j int) bool {
+		return manifests[i].ChainID < manifests[j].ChainID
+	})
+	return manifests, nil
+
+}
+
+// Check that a network manifest has everything the Smartnode needs
+func (manifest *NetworkManifest) Validate() error {
+
+	if manifest.Network == "" || manifest.Network == Network_All || manifest.Network == Network_Unknown {
+		return fmt.Errorf("network manifest has an invalid network ID [%s]", manifest.Network)
+	}
+	if manifest.Name == "" {
+		return fmt.Errorf("network manifest for [%s] is missing a name", manifest.Network)
+	}
+	if manifest.ChainID == 0 {
+		return fmt.Errorf("network manifest for [%s] is missing a chain ID", manifest.Network)
+	}
+
+	// Check the URLs
+	for name, value := range map[string]string{
+		"txWatchUrl": manifest.TxWatchUrl,
+		"stakeUrl":   manifest.StakeUrl,
+	} {
+		if value == "" {
+			return fmt.Errorf("network manifest for [%s] is missing %s", manifest.Network, name)
+		}
+		if _, err := url.ParseRequestURI(value); err != nil {
+			return fmt.Errorf("network manifest for [%s] has an invalid %s [%s]: %w", manifest.Network, name, value, err)
+		}
+	}
+
+	// Check the contract addresses; only RocketStorage is required, everything else can be blank if it doesn't exist on the network
+	if manifest.Contracts.Storage == "" {
+		return fmt.Errorf("network manifest for [%s] is missing the RocketStorage address", manifest.Network)
+	}
+	for name, value := range map[string]string{
+		"storage":            manifest.Contracts.Storage,
+		"oneInchOracle":      manifest.Contracts.OneInchOracle,
+		"rplToken":           manifest.Contracts.RplToken,
+		"rplFaucet":          manifest.Contracts.RplFaucet,
+		"snapshotDelegation": manifest.Contracts.SnapshotDelegation,
+		"multicall":          manifest.Contracts.Multicall,
+	} {
+		if value != "" && !common.IsHexAddress(value) {
+			return fmt.Errorf("network manifest for [%s] has an invalid %s address [%s]", manifest.Network, name, value)
+		}
+	}
+
+	return nil
+
+}
+
+// Give the parameters a default for every network that only exists in a manifest, so their values are never left unset on it.
+// The defaults in a network's manifest replace the built-in ones; anything without a default gets the empty value for its type.
+func (config *RocketPoolConfig) applyManifestDefaults() error {
+
+	// Get every parameter along with its manifest key
+	params := map[string]*Parameter{}
+	for _, param := range config.GetParameters() {
+		params["root."+param.ID] = param
+	}
+	for name, subconfig := range config.GetSubconfigs() {
+		for _, param := range subconfig.GetParameters() {
+			params[name+"."+param.ID] = param
+		}
+	}
+
+	for network, manifest := range config.Smartnode.networkManifests {
+		for key := range manifest.Defaults {
+			if _, exists := params[key]; !exists {
+				return fmt.Errorf("network manifest for [%s] has a default for unknown setting [%s]", network, key)
+			}
+		}
+		for key, param := range params {
+			if value, exists := manifest.Defaults[key]; exists {
+				defaultValue, err := param.parseValue(value)
+				if err != nil {
+					return fmt.Errorf("network manifest for [%s] has an invalid default for [%s]: %w", network, key, err)
+				}
+				param.Default[network] = defaultValue
+				continue
+			}
+			_, hasNetworkDefault := param.Default[network]
+			_, hasCommonDefault := param.Default[Network_All]
+			if !hasNetworkDefault && !hasCommonDefault {
+				param.Default[network] = param.getEmptyValue()
+			}
+		}
+	}
+	return nil
+
+}
@@ -0,0 +1,78 @@
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const devnetManifest string = `network: devnet
+name: Devnet
+chainID: 1337
+txWatchUrl: https://explorer.devnet.example/tx
+stakeUrl: https://stake.devnet.example
+contracts:
+  storage: "0x1d8f8f00cfa6758d7bE78336684788Fb0ee0Fa46"
+defaults:
+  smartnode.privateRelayUrl: https://relay.devnet.example
+  smartnode.privateRelayTimeout: "42"
+`
+
+// Create a config with an extra network that only exists in a manifest override
+func newDevnetConfig(t *testing.T, manifest string) *RocketPoolConfig {
+	dir, err := ioutil.TempDir("", "rp-manifest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		os.RemoveAll(dir)
+	})
+	folder := filepath.Join(dir, NetworkManifestFolderName)
+	if err := os.MkdirAll(folder, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(folder, "devnet.yml"), []byte(manifest), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return NewRocketPoolConfig(dir, false)
+}
+
+func TestManifestOnlyNetworkHasDefaults(t *testing.T) {
+	cfg := newDevnetConfig(t, devnetManifest)
+	if err := cfg.Smartnode.GetNetworkManifestError(); err != nil {
+		t.Fatalf("unexpected manifest error: %s", err.Error())
+	}
+
+	cfg.ChangeNetwork(Network("devnet"))
+
+	// Every parameter has a value of its own type
+	params := cfg.GetParameters()
+	for _, subconfig := range cfg.GetSubconfigs() {
+		params = append(params, subconfig.GetParameters()...)
+	}
+	for _, param := range params {
+		if param.Value == nil {
+			t.Errorf("parameter %s has no value on the devnet", param.ID)
+		}
+	}
+
+	// The bare assertions used for these don't panic
+	_ = cfg.Smartnode.DockerNetwork.Value.(string)
+	_ = cfg.Smartnode.DockerNetworkSubnet.Value.(string)
+
+	// The manifest's defaults are used where it has them
+	if url := cfg.Smartnode.PrivateRelayUrl.Value.(string); url != "https://relay.devnet.example" {
+		t.Errorf("expected the manifest's relay URL, got [%s]", url)
+	}
+	if timeout := cfg.Smartnode.GetPrivateRelayTimeout(); timeout != 42 {
+		t.Errorf("expected the manifest's relay timeout, got %d", timeout)
+	}
+}
+
+func TestManifestDefaultForUnknownSetting(t *testing.T) {
+	cfg := newDevnetConfig(t, devnetManifest+"  smartnode.notASetting: foo\n")
+	if cfg.Smartnode.GetNetworkManifestError() == nil {
+		t.Fatal("expected an error for a default of an unknown setting")
+	}
+}
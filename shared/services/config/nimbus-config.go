@@ -82,7 +82,7 @@ func (config *NimbusConfig) GetUnsupportedCommonParams() []string {
 
 // Get the Docker container name of the validator client
 func (config *NimbusConfig) GetValidatorImage() string {
-	return config.ContainerTag.Value.(string)
+	return config.ContainerTag.GetStringOrDefault(Network_All)
 }
 
 // Get the name of the client
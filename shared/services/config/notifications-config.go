@@ -0,0 +1,146 @@
+package config
+
+// Defaults
+const (
+	defaultNotificationsLowDiskThreshold uint64 = 10
+	defaultNotificationsCooldown         uint64 = 60
+)
+
+// Configuration for the notifications sent by the node and watchtower daemons
+type NotificationsConfig struct {
+	Title string `yaml:"-"`
+
+	// A label to identify this node in notifications
+	NodeLabel Parameter `yaml:"nodeLabel,omitempty"`
+
+	// Generic webhook to send notifications to
+	WebhookUrl Parameter `yaml:"webhookUrl,omitempty"`
+
+	// Discord webhook to send notifications to
+	DiscordWebhookUrl Parameter `yaml:"discordWebhookUrl,omitempty"`
+
+	// Telegram bot token
+	TelegramBotToken Parameter `yaml:"telegramBotToken,omitempty"`
+
+	// Telegram chat to send notifications to
+	TelegramChatId Parameter `yaml:"telegramChatId,omitempty"`
+
+	// The percentage of free disk space below which a notification is sent
+	LowDiskThreshold Parameter `yaml:"lowDiskThreshold,omitempty"`
+
+	// The number of minutes to wait before repeating a notification about an ongoing problem
+	Cooldown Parameter `yaml:"cooldown,omitempty"`
+}
+
+// Generates a new notifications config
+func NewNotificationsConfig(config *RocketPoolConfig) *NotificationsConfig {
+	return &NotificationsConfig{
+		Title: "Notification Settings",
+
+		NodeLabel: Parameter{
+			ID:                   "nodeLabel",
+			Name:                 "Node Label",
+			Description:          "A name for this node that will be included in every notification, so you can tell your nodes apart if you run more than one.\n\nLeave this blank to use the node address.",
+			Type:                 ParameterType_String,
+			Default:              map[Network]interface{}{Network_All: ""},
+			AffectsContainers:    []ContainerID{ContainerID_Node, ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		WebhookUrl: Parameter{
+			ID:                   "webhookUrl",
+			Name:                 "Webhook URL",
+			Description:          "The URL of a webhook that each notification will be POSTed to as JSON.\n\nLeave this blank if you don't want to use a webhook.",
+			Type:                 ParameterType_String,
+			Default:              map[Network]interface{}{Network_All: ""},
+			AffectsContainers:    []ContainerID{ContainerID_Node, ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+			Sensitive:            true,
+		},
+
+		DiscordWebhookUrl: Parameter{
+			ID:                   "discordWebhookUrl",
+			Name:                 "Discord Webhook URL",
+			Description:          "The URL of a Discord channel webhook to send notifications to. You can create one in the channel's Integrations settings.\n\nLeave this blank if you don't want to use Discord.",
+			Type:                 ParameterType_String,
+			Default:              map[Network]interface{}{Network_All: ""},
+			AffectsContainers:    []ContainerID{ContainerID_Node, ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+			Sensitive:            true,
+		},
+
+		TelegramBotToken: Parameter{
+			ID:                   "telegramBotToken",
+			Name:                 "Telegram Bot Token",
+			Description:          "The token of the Telegram bot that will send notifications, which you get from @BotFather when you create the bot.\n\nLeave this blank if you don't want to use Telegram.",
+			Type:                 ParameterType_String,
+			Default:              map[Network]interface{}{Network_All: ""},
+			AffectsContainers:    []ContainerID{ContainerID_Node, ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+			Sensitive:            true,
+		},
+
+		TelegramChatId: Parameter{
+			ID:                   "telegramChatId",
+			Name:                 "Telegram Chat ID",
+			Description:          "The ID of the Telegram chat the bot should send notifications to.",
+			Type:                 ParameterType_String,
+			Default:              map[Network]interface{}{Network_All: ""},
+			AffectsContainers:    []ContainerID{ContainerID_Node, ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		LowDiskThreshold: Parameter{
+			ID:                   "lowDiskThreshold",
+			Name:                 "Low Disk Space Threshold",
+			Description:          "Send a notification when the free space on the disk holding the Smartnode's data folder drops below this percentage.\n\nSet this to 0 to disable low disk space notifications.",
+			Type:                 ParameterType_Uint,
+			Default:              map[Network]interface{}{Network_All: defaultNotificationsLowDiskThreshold},
+			AffectsContainers:    []ContainerID{ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		Cooldown: Parameter{
+			ID:                   "cooldown",
+			Name:                 "Repeat Interval",
+			Description:          "The number of minutes to wait before sending another notification about a problem that hasn't gone away, such as your Execution client being down.",
+			Type:                 ParameterType_Uint,
+			Default:              map[Network]interface{}{Network_All: defaultNotificationsCooldown},
+			AffectsContainers:    []ContainerID{ContainerID_Node, ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+			Advanced:             true,
+		},
+	}
+}
+
+// Get the parameters for this config
+func (config *NotificationsConfig) GetParameters() []*Parameter {
+	return []*Parameter{
+		&config.NodeLabel,
+		&config.WebhookUrl,
+		&config.DiscordWebhookUrl,
+		&config.TelegramBotToken,
+		&config.TelegramChatId,
+		&config.LowDiskThreshold,
+		&config.Cooldown,
+	}
+}
+
+// The the title for the config
+func (config *NotificationsConfig) GetConfigTitle() string {
+	return config.Title
+}
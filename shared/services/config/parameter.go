@@ -21,15 +21,54 @@ type Parameter struct {
 	EnvironmentVariables []string                `yaml:"environmentVariables,omitempty"`
 	CanBeBlank           bool                    `yaml:"canBeBlank,omitempty"`
 	OverwriteOnUpgrade   bool                    `yaml:"overwriteOnUpgrade,omitempty"`
+	Sensitive            bool                    `yaml:"sensitive,omitempty"`
+	MountAsFile          bool                    `yaml:"-"`
 	Options              []ParameterOption       `yaml:"options,omitempty"`
+	Dependencies         []ParameterDependency   `yaml:"-"`
 	Value                interface{}             `yaml:"-"`
 }
 
+// A condition on the value of another parameter that must be met for a parameter to be relevant
+type ParameterDependency struct {
+	Parameter *Parameter
+	Values    []interface{}
+}
+
 // A single option in a choice parameter
 type ParameterOption struct {
-	Name        string      `yaml:"name,omitempty"`
-	Description string      `yaml:"description,omitempty"`
-	Value       interface{} `yaml:"value,omitempty"`
+	Name        string      `yaml:"name,omitempty" json:"name"`
+	Description string      `yaml:"description,omitempty" json:"description"`
+	Value       interface{} `yaml:"value,omitempty" json:"value"`
+}
+
+// Declare that this parameter is only relevant when the provided parameter is set to one of the provided values
+func (param *Parameter) AddDependency(dependency *Parameter, values ...interface{}) {
+	param.Dependencies = append(param.Dependencies, ParameterDependency{
+		Parameter: dependency,
+		Values:    values,
+	})
+}
+
+// Check if this parameter is relevant based on the current values of the parameters it depends on.
+// Irrelevant parameters should be hidden from the user and ignored during validation.
+func (param *Parameter) IsRelevant() bool {
+	for _, dependency := range param.Dependencies {
+		if !dependency.Parameter.IsRelevant() {
+			return false
+		}
+
+		matches := false
+		for _, value := range dependency.Values {
+			if dependency.Parameter.Value == value {
+				matches = true
+				break
+			}
+		}
+		if !matches {
+			return false
+		}
+	}
+	return true
 }
 
 // Apply a network change to a parameter
@@ -124,6 +163,46 @@ func (param *Parameter) deserialize(serializedParams map[string]string, network
 	return nil
 }
 
+// Convert a serialized value to this parameter's type
+func (param *Parameter) parseValue(value string) (interface{}, error) {
+	parsed := Parameter{
+		ID:         param.ID,
+		Type:       param.Type,
+		Default:    map[Network]interface{}{Network_All: param.getEmptyValue()},
+		CanBeBlank: true,
+		Regex:      param.Regex,
+		MaxLength:  param.MaxLength,
+		Options:    param.Options,
+	}
+	if err := parsed.deserialize(map[string]string{param.ID: value}, Network_All); err != nil {
+		return nil, err
+	}
+	return parsed.Value, nil
+}
+
+// Get the empty value for this parameter's type; choices use their first option
+func (param *Parameter) getEmptyValue() interface{} {
+	switch param.Type {
+	case ParameterType_Int:
+		return int64(0)
+	case ParameterType_Uint:
+		return uint64(0)
+	case ParameterType_Uint16:
+		return uint16(0)
+	case ParameterType_Bool:
+		return false
+	case ParameterType_Choice:
+		if len(param.Options) > 0 {
+			return param.Options[0].Value
+		}
+		return nil
+	case ParameterType_Float:
+		return float64(0)
+	default:
+		return ""
+	}
+}
+
 // Set the value to the default for the provided config's network
 func (param *Parameter) setToDefault(network Network) error {
 	defaultSetting, err := param.GetDefault(network)
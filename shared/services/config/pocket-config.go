@@ -32,8 +32,10 @@ type PocketConfig struct {
 func NewPocketConfig(config *RocketPoolConfig, isFallback bool) *PocketConfig {
 
 	prefix := ""
+	ecContainerID := ContainerID_Eth1
 	if isFallback {
 		prefix = "FALLBACK_"
+		ecContainerID = ContainerID_Eth1Fallback
 	}
 
 	title := "Pocket Settings"
@@ -65,10 +67,12 @@ func NewPocketConfig(config *RocketPoolConfig, isFallback bool) *PocketConfig {
 				Network_Prater:  defaultPocketGatewayPrater,
 			},
 			Regex:                "(^$|^(lb\\/)?[0-9a-zA-Z]{24,}$)",
-			AffectsContainers:    []ContainerID{ContainerID_Eth1},
+			AffectsContainers:    []ContainerID{ecContainerID},
 			EnvironmentVariables: []string{prefix + "POCKET_GATEWAY_ID"},
 			CanBeBlank:           false,
 			OverwriteOnUpgrade:   false,
+			Sensitive:            true,
+			MountAsFile:          true,
 		},
 
 		ContainerTag: Parameter{
@@ -77,7 +81,7 @@ func NewPocketConfig(config *RocketPoolConfig, isFallback bool) *PocketConfig {
 			Description:          "The tag name of the Rocket Pool EC Proxy container you want to use on Docker Hub.\nYou should leave this as the default unless you have a good reason to change it.",
 			Type:                 ParameterType_String,
 			Default:              map[Network]interface{}{Network_All: powProxyTag},
-			AffectsContainers:    []ContainerID{ContainerID_Eth1},
+			AffectsContainers:    []ContainerID{ecContainerID},
 			EnvironmentVariables: []string{prefix + "EC_CONTAINER_TAG"},
 			CanBeBlank:           false,
 			OverwriteOnUpgrade:   true,
@@ -89,7 +93,7 @@ func NewPocketConfig(config *RocketPoolConfig, isFallback bool) *PocketConfig {
 			Description:          "Additional custom command line flags you want to pass to the EC Proxy, to take advantage of other settings that the Smartnode's configuration doesn't cover.",
 			Type:                 ParameterType_String,
 			Default:              map[Network]interface{}{Network_All: ""},
-			AffectsContainers:    []ContainerID{ContainerID_Eth1},
+			AffectsContainers:    []ContainerID{ecContainerID},
 			EnvironmentVariables: []string{prefix + "EC_ADDITIONAL_FLAGS"},
 			CanBeBlank:           true,
 			OverwriteOnUpgrade:   false,
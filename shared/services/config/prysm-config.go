@@ -179,7 +179,7 @@ func (config *PrysmConfig) GetUnsupportedCommonParams() []string {
 
 // Get the Docker container name of the validator client
 func (config *PrysmConfig) GetValidatorImage() string {
-	return config.VcContainerTag.Value.(string)
+	return config.VcContainerTag.GetStringOrDefault(Network_All)
 }
 
 // Get the name of the client
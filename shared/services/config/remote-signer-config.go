@@ -0,0 +1,152 @@
+package config
+
+import (
+	"fmt"
+	"path/filepath"
+)
+
+// Constants
+const (
+	remoteSignerFolder     string = "remote-signer"
+	remoteSignerPubkeyPath string = "/api/v1/eth2/publicKeys"
+)
+
+// Configuration for a remote signer (Web3Signer) that holds the validator keys instead of the local keystore
+type RemoteSignerConfig struct {
+	Title string `yaml:"-"`
+
+	// The URL of the remote signer
+	Url Parameter `yaml:"url,omitempty"`
+
+	// The CA certificate used to verify the remote signer's TLS certificate
+	CaCertFile Parameter `yaml:"caCertFile,omitempty"`
+
+	// The client certificate used to authenticate with the remote signer
+	ClientCertFile Parameter `yaml:"clientCertFile,omitempty"`
+
+	// The key for the client certificate
+	ClientKeyFile Parameter `yaml:"clientKeyFile,omitempty"`
+
+	// The parent config
+	parent *RocketPoolConfig `yaml:"-"`
+}
+
+// Generates a new remote signer configuration
+func NewRemoteSignerConfig(config *RocketPoolConfig) *RemoteSignerConfig {
+	return &RemoteSignerConfig{
+		Title:  "Remote Signer Settings",
+		parent: config,
+
+		Url: Parameter{
+			ID:                   "url",
+			Name:                 "Remote Signer URL",
+			Description:          "The URL of your Web3Signer instance, including the port (e.g. `http://192.168.1.20:9000`).\n\nThe Smartnode will import new validator keys into it using its Keymanager API, so Web3Signer must be started with `--key-manager-api-enabled`.",
+			Type:                 ParameterType_String,
+			Default:              map[Network]interface{}{Network_All: ""},
+			AffectsContainers:    []ContainerID{ContainerID_Api, ContainerID_Node, ContainerID_Validator},
+			EnvironmentVariables: []string{"REMOTE_SIGNER_URL"},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+			Sensitive:            true,
+		},
+
+		CaCertFile: Parameter{
+			ID:                   "caCertFile",
+			Name:                 "CA Certificate File",
+			Description:          fmt.Sprintf("The name of the PEM file with the CA certificate that signed your remote signer's TLS certificate. Put this file in the `%s` folder inside your data folder.\n\nLeave this blank if your remote signer doesn't use TLS, or if its certificate is trusted by the system.", remoteSignerFolder),
+			Type:                 ParameterType_String,
+			Default:              map[Network]interface{}{Network_All: ""},
+			AffectsContainers:    []ContainerID{ContainerID_Api, ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		ClientCertFile: Parameter{
+			ID:                   "clientCertFile",
+			Name:                 "Client Certificate File",
+			Description:          fmt.Sprintf("The name of the PEM file with the client certificate the Smartnode should use to authenticate with your remote signer. Put this file in the `%s` folder inside your data folder.\n\nLeave this blank if your remote signer doesn't require client authentication.", remoteSignerFolder),
+			Type:                 ParameterType_String,
+			Default:              map[Network]interface{}{Network_All: ""},
+			AffectsContainers:    []ContainerID{ContainerID_Api, ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		ClientKeyFile: Parameter{
+			ID:                   "clientKeyFile",
+			Name:                 "Client Key File",
+			Description:          fmt.Sprintf("The name of the PEM file with the private key for the client certificate. Put this file in the `%s` folder inside your data folder.", remoteSignerFolder),
+			Type:                 ParameterType_String,
+			Default:              map[Network]interface{}{Network_All: ""},
+			AffectsContainers:    []ContainerID{ContainerID_Api, ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+	}
+}
+
+// Get the parameters for this config
+func (config *RemoteSignerConfig) GetParameters() []*Parameter {
+	return []*Parameter{
+		&config.Url,
+		&config.CaCertFile,
+		&config.ClientCertFile,
+		&config.ClientKeyFile,
+	}
+}
+
+// Get the URL of the remote signer
+func (config *RemoteSignerConfig) GetUrl() string {
+	return config.Url.GetStringOrDefault(Network_All)
+}
+
+// Get the path of the CA certificate, or an empty string if it isn't set
+func (config *RemoteSignerConfig) GetCaCertPath() string {
+	return config.getFilePath(config.CaCertFile.GetStringOrDefault(Network_All))
+}
+
+// Get the path of the client certificate, or an empty string if it isn't set
+func (config *RemoteSignerConfig) GetClientCertPath() string {
+	return config.getFilePath(config.ClientCertFile.GetStringOrDefault(Network_All))
+}
+
+// Get the path of the client certificate's key, or an empty string if it isn't set
+func (config *RemoteSignerConfig) GetClientKeyPath() string {
+	return config.getFilePath(config.ClientKeyFile.GetStringOrDefault(Network_All))
+}
+
+// Get the URL the validator clients can use to get the list of keys the remote signer holds
+func (config *RemoteSignerConfig) GetPubkeysUrl() string {
+	return config.GetUrl() + remoteSignerPubkeyPath
+}
+
+// Get the flags that make the selected validator client use the remote signer.
+// Lighthouse doesn't have flags for this; it reads the remote keys from its validator definitions file instead.
+func (config *RemoteSignerConfig) GetValidatorClientFlags(client ConsensusClient) string {
+	url := config.GetUrl()
+	switch client {
+	case ConsensusClient_Prysm:
+		return fmt.Sprintf("--validators-external-signer-url=%s --validators-external-signer-public-keys=%s", url, config.GetPubkeysUrl())
+	case ConsensusClient_Teku:
+		return fmt.Sprintf("--validators-external-signer-url=%s --validators-external-signer-public-keys=external-signer", url)
+	default:
+		return ""
+	}
+}
+
+// Get the path of a file in the remote signer folder within the data folder
+func (config *RemoteSignerConfig) getFilePath(name string) string {
+	if name == "" {
+		return ""
+	}
+	dataFolder := filepath.Dir(config.parent.Smartnode.GetWalletPath())
+	return filepath.Join(dataFolder, remoteSignerFolder, name)
+}
+
+// The the title for the config
+func (config *RemoteSignerConfig) GetConfigTitle() string {
+	return config.Title
+}
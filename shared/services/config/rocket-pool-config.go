@@ -3,24 +3,26 @@ package config
 import (
 	"fmt"
 	"io/ioutil"
-	"net/url"
+	"net"
 	"os"
 	"path/filepath"
 	"reflect"
 	"strconv"
+	"time"
 
 	"github.com/alessio/shellescape"
 	"github.com/pbnjay/memory"
 	"github.com/rocket-pool/smartnode/shared"
 	"github.com/rocket-pool/smartnode/shared/services/config/migration"
-	"gopkg.in/yaml.v2"
 )
 
 // Constants
 const (
 	rootConfigName string = "root"
 
+	AlertmanagerContainerName string = "alertmanager"
 	ApiContainerName          string = "api"
+	ChronyContainerName       string = "chrony"
 	Eth1ContainerName         string = "eth1"
 	Eth1FallbackContainerName string = "eth1-fallback"
 	Eth2ContainerName         string = "eth2"
@@ -74,6 +76,13 @@ type RocketPoolConfig struct {
 	ExporterMetricsPort     Parameter `yaml:"exporterMetricsPort,omitempty"`
 	WatchtowerMetricsPort   Parameter `yaml:"watchtowerMetricsPort,omitempty"`
 	EnableBitflyNodeMetrics Parameter `yaml:"enableBitflyNodeMetrics,omitempty"`
+	EnableAlerting          Parameter `yaml:"enableAlerting,omitempty"`
+
+	// Validator key settings
+	UseRemoteSigner Parameter `yaml:"useRemoteSigner,omitempty"`
+
+	// Notification settings
+	EnableNotifications Parameter `yaml:"enableNotifications,omitempty"`
 
 	// The Smartnode configuration
 	Smartnode *SmartnodeConfig `yaml:"smartnode"`
@@ -83,6 +92,7 @@ type RocketPoolConfig struct {
 	Geth              *GethConfig              `yaml:"geth,omitempty"`
 	Nethermind        *NethermindConfig        `yaml:"nethermind,omitempty"`
 	Besu              *BesuConfig              `yaml:"besu,omitempty"`
+	Erigon            *ErigonConfig            `yaml:"erigon,omitempty"`
 	Infura            *InfuraConfig            `yaml:"infura,omitempty"`
 	Pocket            *PocketConfig            `yaml:"pocket,omitempty"`
 	ExternalExecution *ExternalExecutionConfig `yaml:"externalExecution,omitempty"`
@@ -108,6 +118,16 @@ type RocketPoolConfig struct {
 	Prometheus        *PrometheusConfig        `yaml:"prometheus,omitempty"`
 	Exporter          *ExporterConfig          `yaml:"exporter,omitempty"`
 	BitflyNodeMetrics *BitflyNodeMetricsConfig `yaml:"bitflyNodeMetrics,omitempty"`
+	Alertmanager      *AlertmanagerConfig      `yaml:"alertmanager,omitempty"`
+
+	// Remote signer
+	RemoteSigner *RemoteSignerConfig `yaml:"remoteSigner,omitempty"`
+
+	// Notifications
+	Notifications *NotificationsConfig `yaml:"notifications,omitempty"`
+
+	// Schedule
+	Schedule *ScheduleConfig `yaml:"schedule,omitempty"`
 
 	// Native mode
 	Native *NativeConfig `yaml:"native,omitempty"`
@@ -129,9 +149,9 @@ func LoadFromFile(path string) (*RocketPoolConfig, error) {
 	}
 
 	// Attempt to parse it out into a settings map
-	var settings map[string]map[string]string
-	if err := yaml.Unmarshal(configBytes, &settings); err != nil {
-		return nil, fmt.Errorf("could not parse settings file: %w", err)
+	settings, err := UnmarshalSettings(configBytes)
+	if err != nil {
+		return nil, err
 	}
 
 	// Deserialize it into a config object
@@ -145,6 +165,46 @@ func LoadFromFile(path string) (*RocketPoolConfig, error) {
 
 }
 
+// Load configuration settings from a file that was written for another installation (e.g. by a deployment tool),
+// replacing its installation-specific settings with the provided ones
+func ImportFromFile(path string, rpDir string, isNativeMode bool) (*RocketPoolConfig, error) {
+
+	// Read the file
+	configBytes, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read Rocket Pool settings file at %s: %w", shellescape.Quote(path), err)
+	}
+
+	// Attempt to parse it out into a settings map
+	settings, err := UnmarshalSettings(configBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	// Use this installation's directory and mode
+	if settings == nil {
+		settings = map[string]map[string]string{}
+	}
+	if settings[rootConfigName] == nil {
+		settings[rootConfigName] = map[string]string{}
+	}
+	settings[rootConfigName]["rpDir"] = rpDir
+	settings[rootConfigName]["isNative"] = fmt.Sprint(isNativeMode)
+	if _, exists := settings[rootConfigName]["version"]; !exists {
+		settings[rootConfigName]["version"] = fmt.Sprintf("v%s", shared.RocketPoolVersion)
+	}
+
+	// Deserialize it into a config object
+	cfg := NewRocketPoolConfig(rpDir, isNativeMode)
+	err = cfg.Deserialize(settings)
+	if err != nil {
+		return nil, fmt.Errorf("could not deserialize settings file: %w", err)
+	}
+
+	return cfg, nil
+
+}
+
 // Creates a new Rocket Pool configuration instance
 func NewRocketPoolConfig(rpDir string, isNativeMode bool) *RocketPoolConfig {
 
@@ -196,6 +256,10 @@ func NewRocketPoolConfig(rpDir string, isNativeMode bool) *RocketPoolConfig {
 				Name:        "Besu",
 				Description: getAugmentedEcDescription(ExecutionClient_Besu, "Hyperledger Besu is a robust full Ethereum protocol client. It uses a novel system called \"Bonsai Trees\" to store its chain data efficiently, which allows it to access block states from the past and does not require pruning. Besu is fully open source and written in Java."),
 				Value:       ExecutionClient_Besu,
+			}, {
+				Name:        "Erigon",
+				Description: getAugmentedEcDescription(ExecutionClient_Erigon, "Erigon is an efficient implementation of the Ethereum protocol written in Go. It uses a novel staged sync architecture and a flat database layout that keeps its disk usage low and syncs quickly, and it can prune itself automatically. Erigon is fully open source."),
+				Value:       ExecutionClient_Erigon,
 			}, {
 				Name:        "*Infura",
 				Description: "Use infura.io as a light client for Eth 1.0. Not recommended for use in production.\n\n[orange]*WARNING: Infura is deprecated and will NOT BE COMPATIBLE with the upcoming Ethereum Merge. It will be removed in a future version of the Smartnode. We strongly recommend you choose a Full Execution client instead.",
@@ -264,7 +328,7 @@ func NewRocketPoolConfig(rpDir string, isNativeMode bool) *RocketPoolConfig {
 		ReconnectDelay: Parameter{
 			ID:                   "reconnectDelay",
 			Name:                 "Reconnect Delay",
-			Description:          "The delay to wait after the primary Execution client fails before trying to reconnect to it. An example format is \"10h20m30s\" - this would make it 10 hours, 20 minutes, and 30 seconds.",
+			Description:          "How often the daemons check the health of each of your Execution clients, so requests go back to one that has recovered. An example format is \"10h20m30s\" - this would make it 10 hours, 20 minutes, and 30 seconds.",
 			Type:                 ParameterType_String,
 			Default:              map[Network]interface{}{Network_All: "60s"},
 			AffectsContainers:    []ContainerID{ContainerID_Api, ContainerID_Node, ContainerID_Watchtower},
@@ -372,6 +436,42 @@ func NewRocketPoolConfig(rpDir string, isNativeMode bool) *RocketPoolConfig {
 			OverwriteOnUpgrade:   false,
 		},
 
+		EnableAlerting: Parameter{
+			ID:                   "enableAlerting",
+			Name:                 "Enable Alerting",
+			Description:          "Enable Alertmanager and the Smartnode's default Prometheus alert rules, so you're notified when your validator client goes offline, your clients fall out of sync, your disk is nearly full, or your validators start missing attestations.",
+			Type:                 ParameterType_Bool,
+			Default:              map[Network]interface{}{Network_All: false},
+			AffectsContainers:    []ContainerID{ContainerID_Alertmanager, ContainerID_Prometheus},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		UseRemoteSigner: Parameter{
+			ID:                   "useRemoteSigner",
+			Name:                 "Use Remote Signer",
+			Description:          "Enable this to keep your validator keys in a Web3Signer instance instead of on this machine. New validator keys will be imported into the remote signer, and your Validator Client will ask it to sign its duties.\n\nThis is supported by Lighthouse, Prysm, and Teku. Make sure the remote signer has slashing protection enabled.",
+			Type:                 ParameterType_Bool,
+			Default:              map[Network]interface{}{Network_All: false},
+			AffectsContainers:    []ContainerID{ContainerID_Api, ContainerID_Node, ContainerID_Validator},
+			EnvironmentVariables: []string{"USE_REMOTE_SIGNER"},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		EnableNotifications: Parameter{
+			ID:                   "enableNotifications",
+			Name:                 "Enable Notifications",
+			Description:          "Enable this to have the Smartnode's node and watchtower daemons send notifications about important events, such as minipools being staked, rewards being claimed, the fallback Execution client being used, your Execution client going down, or your disk running low on space.\n\nNotifications can be sent to a webhook, Discord, and Telegram.",
+			Type:                 ParameterType_Bool,
+			Default:              map[Network]interface{}{Network_All: false},
+			AffectsContainers:    []ContainerID{ContainerID_Node, ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
 		EcMetricsPort: Parameter{
 			ID:                   "ecMetricsPort",
 			Name:                 "Execution Client Metrics Port",
@@ -455,6 +555,7 @@ func NewRocketPoolConfig(rpDir string, isNativeMode bool) *RocketPoolConfig {
 	config.Geth = NewGethConfig(config, false)
 	config.Nethermind = NewNethermindConfig(config, false)
 	config.Besu = NewBesuConfig(config, false)
+	config.Erigon = NewErigonConfig(config, false)
 	config.Infura = NewInfuraConfig(config, false)
 	config.Pocket = NewPocketConfig(config, false)
 	config.ExternalExecution = NewExternalExecutionConfig(config, false)
@@ -474,7 +575,17 @@ func NewRocketPoolConfig(rpDir string, isNativeMode bool) *RocketPoolConfig {
 	config.Prometheus = NewPrometheusConfig(config)
 	config.Exporter = NewExporterConfig(config)
 	config.BitflyNodeMetrics = NewBitflyNodeMetricsConfig(config)
+	config.Alertmanager = NewAlertmanagerConfig(config)
+	config.RemoteSigner = NewRemoteSignerConfig(config)
+	config.Notifications = NewNotificationsConfig(config)
+	config.Schedule = NewScheduleConfig(config)
 	config.Native = NewNativeConfig(config)
+	config.setupDependencies()
+
+	// Fill in the defaults for the networks that only exist in a manifest override
+	if err := config.applyManifestDefaults(); err != nil && config.Smartnode.networkManifestError == nil {
+		config.Smartnode.networkManifestError = err
+	}
 
 	// Apply the default values for mainnet
 	config.Smartnode.Network.Value = config.Smartnode.Network.Options[0].Value
@@ -483,6 +594,72 @@ func NewRocketPoolConfig(rpDir string, isNativeMode bool) *RocketPoolConfig {
 	return config
 }
 
+// Declare which parameters are only relevant for certain settings of other parameters
+func (config *RocketPoolConfig) setupDependencies() {
+
+	// Execution client
+	config.ExecutionClient.AddDependency(&config.ExecutionClientMode, Mode_Local)
+	addDependencies(config.ExecutionCommon.GetParameters(), &config.ExecutionClientMode, Mode_Local)
+	addDependencies(config.Geth.GetParameters(), &config.ExecutionClient, ExecutionClient_Geth)
+	addDependencies(config.Nethermind.GetParameters(), &config.ExecutionClient, ExecutionClient_Nethermind)
+	addDependencies(config.Besu.GetParameters(), &config.ExecutionClient, ExecutionClient_Besu)
+	addDependencies(config.Erigon.GetParameters(), &config.ExecutionClient, ExecutionClient_Erigon)
+	addDependencies(config.Infura.GetParameters(), &config.ExecutionClient, ExecutionClient_Infura)
+	addDependencies(config.Pocket.GetParameters(), &config.ExecutionClient, ExecutionClient_Pocket)
+	addDependencies(config.ExternalExecution.GetParameters(), &config.ExecutionClientMode, Mode_External)
+
+	// Fallback execution client
+	config.FallbackExecutionClientMode.AddDependency(&config.UseFallbackExecutionClient, true)
+	config.FallbackExecutionClient.AddDependency(&config.FallbackExecutionClientMode, Mode_Local)
+	config.ReconnectDelay.AddDependency(&config.UseFallbackExecutionClient, true)
+	addDependencies(config.FallbackExecutionCommon.GetParameters(), &config.FallbackExecutionClientMode, Mode_Local)
+	addDependencies(config.FallbackInfura.GetParameters(), &config.FallbackExecutionClient, ExecutionClient_Infura)
+	addDependencies(config.FallbackPocket.GetParameters(), &config.FallbackExecutionClient, ExecutionClient_Pocket)
+	addDependencies(config.FallbackExternalExecution.GetParameters(), &config.FallbackExecutionClientMode, Mode_External)
+
+	// Consensus client
+	config.ConsensusClient.AddDependency(&config.ConsensusClientMode, Mode_Local)
+	config.ExternalConsensusClient.AddDependency(&config.ConsensusClientMode, Mode_External)
+	addDependencies(config.ConsensusCommon.GetParameters(), &config.ConsensusClientMode, Mode_Local)
+	addDependencies(config.Lighthouse.GetParameters(), &config.ConsensusClient, ConsensusClient_Lighthouse)
+	addDependencies(config.Nimbus.GetParameters(), &config.ConsensusClient, ConsensusClient_Nimbus)
+	addDependencies(config.Prysm.GetParameters(), &config.ConsensusClient, ConsensusClient_Prysm)
+	addDependencies(config.Teku.GetParameters(), &config.ConsensusClient, ConsensusClient_Teku)
+	addDependencies(config.ExternalLighthouse.GetParameters(), &config.ExternalConsensusClient, ConsensusClient_Lighthouse)
+	addDependencies(config.ExternalPrysm.GetParameters(), &config.ExternalConsensusClient, ConsensusClient_Prysm)
+	addDependencies(config.ExternalTeku.GetParameters(), &config.ExternalConsensusClient, ConsensusClient_Teku)
+
+	// Metrics
+	addDependencies([]*Parameter{
+		&config.EcMetricsPort,
+		&config.BnMetricsPort,
+		&config.VcMetricsPort,
+		&config.NodeMetricsPort,
+		&config.ExporterMetricsPort,
+		&config.WatchtowerMetricsPort,
+	}, &config.EnableMetrics, true)
+	addDependencies(config.Grafana.GetParameters(), &config.EnableMetrics, true)
+	addDependencies(config.Prometheus.GetParameters(), &config.EnableMetrics, true)
+	addDependencies(config.Exporter.GetParameters(), &config.EnableMetrics, true)
+	addDependencies(config.BitflyNodeMetrics.GetParameters(), &config.EnableBitflyNodeMetrics, true)
+	config.EnableAlerting.AddDependency(&config.EnableMetrics, true)
+	addDependencies(config.Alertmanager.GetParameters(), &config.EnableAlerting, true)
+
+	// Remote signer
+	addDependencies(config.RemoteSigner.GetParameters(), &config.UseRemoteSigner, true)
+
+	// Notifications
+	addDependencies(config.Notifications.GetParameters(), &config.EnableNotifications, true)
+
+}
+
+// Make each of the provided parameters depend on the provided parameter having one of the provided values
+func addDependencies(params []*Parameter, dependency *Parameter, values ...interface{}) {
+	for _, param := range params {
+		param.AddDependency(dependency, values...)
+	}
+}
+
 // Get a more verbose client description, including warnings
 func getAugmentedEcDescription(client ExecutionClient, originalDescription string) string {
 
@@ -537,12 +714,15 @@ func (config *RocketPoolConfig) GetParameters() []*Parameter {
 		&config.ExternalConsensusClient,
 		&config.EnableMetrics,
 		&config.EnableBitflyNodeMetrics,
+		&config.EnableAlerting,
 		&config.EcMetricsPort,
 		&config.BnMetricsPort,
 		&config.VcMetricsPort,
 		&config.NodeMetricsPort,
 		&config.ExporterMetricsPort,
 		&config.WatchtowerMetricsPort,
+		&config.UseRemoteSigner,
+		&config.EnableNotifications,
 	}
 }
 
@@ -554,6 +734,7 @@ func (config *RocketPoolConfig) GetSubconfigs() map[string]Config {
 		"geth":                      config.Geth,
 		"nethermind":                config.Nethermind,
 		"besu":                      config.Besu,
+		"erigon":                    config.Erigon,
 		"infura":                    config.Infura,
 		"pocket":                    config.Pocket,
 		"externalExecution":         config.ExternalExecution,
@@ -573,6 +754,10 @@ func (config *RocketPoolConfig) GetSubconfigs() map[string]Config {
 		"prometheus":                config.Prometheus,
 		"exporter":                  config.Exporter,
 		"bitflyNodeMetrics":         config.BitflyNodeMetrics,
+		"alertmanager":              config.Alertmanager,
+		"remoteSigner":              config.RemoteSigner,
+		"notifications":             config.Notifications,
+		"schedule":                  config.Schedule,
 		"native":                    config.Native,
 	}
 }
@@ -620,6 +805,8 @@ func (config *RocketPoolConfig) GetIncompatibleConsensusClients() ([]ParameterOp
 			compatibleConsensusClients = config.Nethermind.CompatibleConsensusClients
 		case ExecutionClient_Besu:
 			compatibleConsensusClients = config.Besu.CompatibleConsensusClients
+		case ExecutionClient_Erigon:
+			compatibleConsensusClients = config.Erigon.CompatibleConsensusClients
 		case ExecutionClient_Infura:
 			compatibleConsensusClients = config.Infura.CompatibleConsensusClients
 		case ExecutionClient_Pocket:
@@ -731,6 +918,77 @@ func (config *RocketPoolConfig) GetSelectedConsensusClientConfig() (ConsensusCon
 	}
 }
 
+// Scale a daemon polling interval for the primary Execution client, which is widened if it's an external client in rate-limit mode
+func (config *RocketPoolConfig) ScaleEcPollingInterval(interval time.Duration) time.Duration {
+	if config.IsNativeMode || config.GetExecutionClientMode() != Mode_External {
+		return interval
+	}
+	return interval * time.Duration(config.ExternalExecution.GetPollingMultiplier())
+}
+
+// Get how often the daemons check the health of the Execution clients, which is widened like the other polling intervals
+func (config *RocketPoolConfig) GetEcHealthCheckInterval() time.Duration {
+	interval, err := time.ParseDuration(config.ReconnectDelay.GetStringOrDefault(Network_All))
+	if err != nil || interval <= 0 {
+		interval, _ = time.ParseDuration(config.ReconnectDelay.Default[Network_All].(string))
+	}
+	return config.ScaleEcPollingInterval(interval)
+}
+
+// Get the selected Consensus client, which also determines the Validator client
+func (config *RocketPoolConfig) GetSelectedConsensusClient() ConsensusClient {
+	var client ConsensusClient
+	if config.GetConsensusClientMode() == Mode_External {
+		client, _ = config.ExternalConsensusClient.Value.(ConsensusClient)
+	} else {
+		client, _ = config.ConsensusClient.Value.(ConsensusClient)
+	}
+	return client
+}
+
+// Get the token and certificate files for the selected Validator client's Keymanager API, relative to the validators folder.
+// Blank values mean the defaults are used.
+func (config *RocketPoolConfig) getKeymanagerFiles() (string, string) {
+	if config.IsNativeMode {
+		return "", ""
+	}
+
+	external := (config.GetConsensusClientMode() == Mode_External)
+	switch config.GetSelectedConsensusClient() {
+	case ConsensusClient_Lighthouse:
+		if external {
+			return config.ExternalLighthouse.KeymanagerTokenFile.GetStringOrDefault(Network_All), ""
+		}
+		return config.Lighthouse.KeymanagerTokenFile.GetStringOrDefault(Network_All), ""
+	case ConsensusClient_Teku:
+		if external {
+			return config.ExternalTeku.KeymanagerTokenFile.GetStringOrDefault(Network_All), config.ExternalTeku.KeymanagerCertFile.GetStringOrDefault(Network_All)
+		}
+		return config.Teku.KeymanagerTokenFile.GetStringOrDefault(Network_All), config.Teku.KeymanagerCertFile.GetStringOrDefault(Network_All)
+	default:
+		return "", ""
+	}
+}
+
+// Check if new validator keys should be loaded through the Validator client's Keymanager API instead of by restarting it.
+// This needs a Validator client that supports it and a single Validator client, since the API can only load keys into the main one, and locally stored keys, since it loads their keystores.
+func (config *RocketPoolConfig) IsHotKeyLoadingEnabled() bool {
+	if !config.Smartnode.GetHotKeyLoading() || config.GetValidatorShardCount() > 1 || config.UseRemoteSigner.Value == true {
+		return false
+	}
+
+	var client ConsensusClient
+	if config.IsNativeMode {
+		if config.Native.ValidatorKeymanagerUrl.GetStringOrDefault(Network_All) == "" {
+			return false
+		}
+		client, _ = config.Native.ConsensusClient.Value.(ConsensusClient)
+	} else {
+		client = config.GetSelectedConsensusClient()
+	}
+	return client == ConsensusClient_Lighthouse || client == ConsensusClient_Teku
+}
+
 // Check if doppelganger protection is enabled
 func (config *RocketPoolConfig) IsDoppelgangerEnabled() (bool, error) {
 	if config.IsNativeMode {
@@ -856,185 +1114,6 @@ func (config *RocketPoolConfig) Deserialize(masterMap map[string]map[string]stri
 	return nil
 }
 
-// Generates a collection of environment variables based on this config's settings
-func (config *RocketPoolConfig) GenerateEnvironmentVariables() map[string]string {
-
-	envVars := map[string]string{}
-
-	// Basic variables and root parameters
-	envVars["SMARTNODE_IMAGE"] = config.Smartnode.GetSmartnodeContainerTag()
-	envVars["ROCKETPOOL_FOLDER"] = config.RocketPoolDirectory
-	addParametersToEnvVars(config.Smartnode.GetParameters(), envVars)
-	addParametersToEnvVars(config.GetParameters(), envVars)
-
-	// EC parameters
-	if config.ExecutionClientMode.Value.(Mode) == Mode_Local {
-		envVars["EC_CLIENT"] = fmt.Sprint(config.ExecutionClient.Value)
-		envVars["EC_HTTP_ENDPOINT"] = fmt.Sprintf("http://%s:%d", Eth1ContainerName, config.ExecutionCommon.HttpPort.Value)
-		envVars["EC_WS_ENDPOINT"] = fmt.Sprintf("ws://%s:%d", Eth1ContainerName, config.ExecutionCommon.WsPort.Value)
-
-		// Handle open API ports
-		if config.ExecutionCommon.OpenRpcPorts.Value == true {
-			switch config.ExecutionClient.Value.(ExecutionClient) {
-			case ExecutionClient_Pocket:
-				ecHttpPort := config.ExecutionCommon.HttpPort.Value.(uint16)
-				envVars["EC_OPEN_API_PORTS"] = fmt.Sprintf(", \"%d:%d/tcp\"", ecHttpPort, ecHttpPort)
-			default:
-				ecHttpPort := config.ExecutionCommon.HttpPort.Value.(uint16)
-				ecWsPort := config.ExecutionCommon.WsPort.Value.(uint16)
-				envVars["EC_OPEN_API_PORTS"] = fmt.Sprintf(", \"%d:%d/tcp\", \"%d:%d/tcp\"", ecHttpPort, ecHttpPort, ecWsPort, ecWsPort)
-			}
-		}
-
-		// Common params
-		addParametersToEnvVars(config.ExecutionCommon.GetParameters(), envVars)
-
-		// Client-specific params
-		switch config.ExecutionClient.Value.(ExecutionClient) {
-		case ExecutionClient_Geth:
-			addParametersToEnvVars(config.Geth.GetParameters(), envVars)
-			envVars["EC_STOP_SIGNAL"] = gethStopSignal
-		case ExecutionClient_Nethermind:
-			addParametersToEnvVars(config.Nethermind.GetParameters(), envVars)
-			envVars["EC_STOP_SIGNAL"] = nethermindStopSignal
-		case ExecutionClient_Besu:
-			addParametersToEnvVars(config.Besu.GetParameters(), envVars)
-			envVars["EC_STOP_SIGNAL"] = besuStopSignal
-		case ExecutionClient_Infura:
-			addParametersToEnvVars(config.Infura.GetParameters(), envVars)
-			envVars["EC_STOP_SIGNAL"] = powProxyStopSignal
-		case ExecutionClient_Pocket:
-			addParametersToEnvVars(config.Pocket.GetParameters(), envVars)
-			envVars["EC_STOP_SIGNAL"] = powProxyStopSignal
-		}
-	} else {
-		envVars["EC_CLIENT"] = "X" // X is for external / unknown
-		addParametersToEnvVars(config.ExternalExecution.GetParameters(), envVars)
-	}
-	// Get the hostname of the Execution client, necessary for Prometheus to work in hybrid mode
-	ecUrl, err := url.Parse(envVars["EC_HTTP_ENDPOINT"])
-	if err == nil && ecUrl != nil {
-		envVars["EC_HOSTNAME"] = ecUrl.Hostname()
-	}
-
-	// Fallback EC parameters
-	envVars["FALLBACK_EC_CLIENT"] = fmt.Sprint(config.FallbackExecutionClient.Value)
-	if config.UseFallbackExecutionClient.Value == true {
-		if config.FallbackExecutionClientMode.Value.(Mode) == Mode_Local {
-			envVars["FALLBACK_EC_HTTP_ENDPOINT"] = fmt.Sprintf("http://%s:%d", Eth1FallbackContainerName, config.FallbackExecutionCommon.HttpPort.Value)
-			envVars["FALLBACK_EC_WS_ENDPOINT"] = fmt.Sprintf("ws://%s:%d", Eth1FallbackContainerName, config.FallbackExecutionCommon.WsPort.Value)
-
-			// Handle open API ports
-			if config.FallbackExecutionCommon.OpenRpcPorts.Value == true {
-				switch config.FallbackExecutionClient.Value.(ExecutionClient) {
-				case ExecutionClient_Pocket:
-					ecHttpPort := config.FallbackExecutionCommon.HttpPort.Value.(uint16)
-					envVars["FALLBACK_EC_OPEN_API_PORTS"] = fmt.Sprintf("\"%d:%d/tcp\"", ecHttpPort, ecHttpPort)
-				default:
-					ecHttpPort := config.FallbackExecutionCommon.HttpPort.Value.(uint16)
-					ecWsPort := config.FallbackExecutionCommon.WsPort.Value.(uint16)
-					envVars["FALLBACK_EC_OPEN_API_PORTS"] = fmt.Sprintf("\"%d:%d/tcp\", \"%d:%d/tcp\"", ecHttpPort, ecHttpPort, ecWsPort, ecWsPort)
-				}
-			}
-
-			// Common params
-			addParametersToEnvVars(config.FallbackExecutionCommon.GetParameters(), envVars)
-
-			// Client-specific params
-			switch config.FallbackExecutionClient.Value.(ExecutionClient) {
-			case ExecutionClient_Infura:
-				addParametersToEnvVars(config.FallbackInfura.GetParameters(), envVars)
-			case ExecutionClient_Pocket:
-				addParametersToEnvVars(config.FallbackPocket.GetParameters(), envVars)
-			}
-		} else {
-			addParametersToEnvVars(config.FallbackExternalExecution.GetParameters(), envVars)
-		}
-	}
-
-	// CC parameters
-	if config.ConsensusClientMode.Value.(Mode) == Mode_Local {
-		envVars["CC_CLIENT"] = fmt.Sprint(config.ConsensusClient.Value)
-		envVars["CC_API_ENDPOINT"] = fmt.Sprintf("http://%s:%d", Eth2ContainerName, config.ConsensusCommon.ApiPort.Value)
-
-		// Handle open API ports
-		bnOpenPorts := ""
-		if config.ConsensusCommon.OpenApiPort.Value == true {
-			ccApiPort := config.ConsensusCommon.ApiPort.Value.(uint16)
-			bnOpenPorts += fmt.Sprintf(", \"%d:%d/tcp\"", ccApiPort, ccApiPort)
-		}
-		if config.ConsensusClient.Value.(ConsensusClient) == ConsensusClient_Prysm && config.Prysm.OpenRpcPort.Value == true {
-			prysmRpcPort := config.Prysm.RpcPort.Value.(uint16)
-			bnOpenPorts += fmt.Sprintf(", \"%d:%d/tcp\"", prysmRpcPort, prysmRpcPort)
-		}
-		envVars["BN_OPEN_PORTS"] = bnOpenPorts
-
-		// Common params
-		addParametersToEnvVars(config.ConsensusCommon.GetParameters(), envVars)
-
-		// Client-specific params
-		switch config.ConsensusClient.Value.(ConsensusClient) {
-		case ConsensusClient_Lighthouse:
-			addParametersToEnvVars(config.Lighthouse.GetParameters(), envVars)
-		case ConsensusClient_Nimbus:
-			addParametersToEnvVars(config.Nimbus.GetParameters(), envVars)
-		case ConsensusClient_Prysm:
-			addParametersToEnvVars(config.Prysm.GetParameters(), envVars)
-			envVars["CC_RPC_ENDPOINT"] = fmt.Sprintf("http://%s:%d", Eth2ContainerName, config.Prysm.RpcPort.Value)
-		case ConsensusClient_Teku:
-			addParametersToEnvVars(config.Teku.GetParameters(), envVars)
-		}
-	} else {
-		envVars["CC_CLIENT"] = fmt.Sprint(config.ExternalConsensusClient.Value)
-
-		switch config.ExternalConsensusClient.Value.(ConsensusClient) {
-		case ConsensusClient_Lighthouse:
-			addParametersToEnvVars(config.ExternalLighthouse.GetParameters(), envVars)
-		case ConsensusClient_Prysm:
-			addParametersToEnvVars(config.ExternalPrysm.GetParameters(), envVars)
-		case ConsensusClient_Teku:
-			addParametersToEnvVars(config.ExternalTeku.GetParameters(), envVars)
-		}
-	}
-	// Get the hostname of the Consensus client, necessary for Prometheus to work in hybrid mode
-	ccUrl, err := url.Parse(envVars["CC_API_ENDPOINT"])
-	if err == nil && ccUrl != nil {
-		envVars["CC_HOSTNAME"] = ccUrl.Hostname()
-	}
-
-	// Metrics
-	if config.EnableMetrics.Value == true {
-		addParametersToEnvVars(config.Exporter.GetParameters(), envVars)
-		addParametersToEnvVars(config.Prometheus.GetParameters(), envVars)
-		addParametersToEnvVars(config.Grafana.GetParameters(), envVars)
-
-		if config.Exporter.RootFs.Value == true {
-			envVars["EXPORTER_ROOTFS_COMMAND"] = ", \"--path.rootfs=/rootfs\""
-			envVars["EXPORTER_ROOTFS_VOLUME"] = ", \"/:/rootfs:ro\""
-		}
-
-		if config.Prometheus.OpenPort.Value == true {
-			envVars["PROMETHEUS_OPEN_PORTS"] = fmt.Sprintf("%d:%d/tcp", config.Prometheus.Port.Value, config.Prometheus.Port.Value)
-		}
-
-		// Additional metrics flags
-		if config.Exporter.AdditionalFlags.Value.(string) != "" {
-			envVars["EXPORTER_ADDITIONAL_FLAGS"] = fmt.Sprintf(", \"%s\"", config.Exporter.AdditionalFlags.Value.(string))
-		}
-		if config.Prometheus.AdditionalFlags.Value.(string) != "" {
-			envVars["PROMETHEUS_ADDITIONAL_FLAGS"] = fmt.Sprintf(", \"%s\"", config.Prometheus.AdditionalFlags.Value.(string))
-		}
-	}
-
-	// Bitfly Node Metrics
-	if config.EnableBitflyNodeMetrics.Value == true {
-		addParametersToEnvVars(config.BitflyNodeMetrics.GetParameters(), envVars)
-	}
-
-	return envVars
-
-}
-
 // The the title for the config
 func (config *RocketPoolConfig) GetConfigTitle() string {
 	return config.Title
@@ -1099,6 +1178,11 @@ func (config *RocketPoolConfig) GetChanges(oldConfig *RocketPoolConfig) (map[str
 func (config *RocketPoolConfig) Validate() []string {
 	errors := []string{}
 
+	// Check for broken network manifest overrides
+	if err := config.Smartnode.GetNetworkManifestError(); err != nil {
+		errors = append(errors, fmt.Sprintf("The network manifests in %s could not be loaded, so the built-in network settings are being used instead:\n\t%s", filepath.Join(config.RocketPoolDirectory, NetworkManifestFolderName), err.Error()))
+	}
+
 	// Check for client incompatibility
 	badClients, badFallbackClients := config.GetIncompatibleConsensusClients()
 	if config.ConsensusClientMode.Value == Mode_Local {
@@ -1117,22 +1201,147 @@ func (config *RocketPoolConfig) Validate() []string {
 		}
 	}
 
-	// Check for illegal blank strings
-	/* TODO - this needs to be smarter and ignore irrelevant settings
+	// Check the Execution client health check interval
+	if interval, err := time.ParseDuration(config.ReconnectDelay.GetStringOrDefault(Network_All)); config.UseFallbackExecutionClient.Value == true && (err != nil || interval <= 0) {
+		errors = append(errors, fmt.Sprintf("'%s' is not a valid Reconnect Delay; it must be a duration like \"60s\" or \"5m\".", config.ReconnectDelay.Value))
+	}
+
+	// Check the log rotation settings
+	if config.Smartnode.ContainerLogMaxSize.GetUintOrDefault(Network_All) > 0 && config.Smartnode.ContainerLogMaxFiles.GetUintOrDefault(Network_All) == 0 {
+		errors = append(errors, "Docker needs to keep at least one log file for each container. Please set the Container Log Max Files to 1 or more, or set the Container Log Max Size to 0 to use Docker's own logging settings.")
+	}
+
+	// Check the time sync settings
+	if timeSyncMode := config.Smartnode.GetTimeSyncMode(); timeSyncMode != TimeSyncMode_Disabled {
+		servers := config.Smartnode.GetNtpServers()
+		if len(servers) == 0 {
+			errors = append(errors, "Time sync needs at least one NTP server. Please add one to the NTP Servers setting, or disable time sync.")
+		}
+		for _, server := range servers {
+			if !ntpServerRegex.MatchString(server) {
+				errors = append(errors, fmt.Sprintf("'%s' is not a valid NTP server; it must be a hostname or IP address.", server))
+			}
+		}
+		if timeSyncMode == TimeSyncMode_Chrony && config.IsNativeMode {
+			errors = append(errors, "The Chrony container isn't available in Native mode. Please set up a time sync service on your system and set Time Sync to Check Host Clock instead.")
+		}
+	}
+
+	// Check that the rewards tree worker can be started
+	if config.Smartnode.EnableTreegenWorker.Value == true && config.IsNativeMode {
+		errors = append(errors, "The rewards tree worker container isn't available in Native mode. Please generate rewards trees with `rocketpool network generate-rewards-tree` instead.")
+	}
+
+	// Check that the custom rETH price source has a URL
+	if config.Smartnode.GetRethPriceSource() == RethPriceSource_Url && config.Smartnode.GetRethPriceUrl() == "" {
+		errors = append(errors, "The rETH Price Source is set to Custom URL, but no rETH Price URL was provided.")
+	}
+
+	// Check that the Validator client can be sharded
+	if shards := config.Smartnode.ValidatorClientShards.GetUintOrDefault(Network_All); shards != 1 {
+		switch {
+		case shards == 0 || shards > MaxValidatorClientShards:
+			errors = append(errors, fmt.Sprintf("The number of Validator client shards must be between 1 and %d.", MaxValidatorClientShards))
+		case config.IsNativeMode:
+			errors = append(errors, "Validator client shards aren't available in Native mode, since the Smartnode doesn't run your Validator client. Please set the number of shards to 1.")
+		case config.GetSelectedConsensusClient() == ConsensusClient_Nimbus:
+			errors = append(errors, "Nimbus runs its Validator client inside the Beacon node, so it can't be split into shards. Please set the number of Validator client shards to 1.")
+		case config.UseRemoteSigner.Value == true:
+			errors = append(errors, "Validator client shards can't be used with a remote signer yet. Please set the number of Validator client shards to 1 or disable the remote signer.")
+		}
+	}
+
+	// Check that the Validator client supports the remote signer
+	if config.UseRemoteSigner.Value == true {
+		if config.GetSelectedConsensusClient() == ConsensusClient_Nimbus {
+			errors = append(errors, "Nimbus does not support remote signers. Please select a different Consensus client or disable the remote signer.")
+		}
+		if (config.RemoteSigner.ClientCertFile.Value == "") != (config.RemoteSigner.ClientKeyFile.Value == "") {
+			errors = append(errors, "The remote signer's client certificate and client key must be set together.")
+		}
+	}
+
+	// Check that email alerts have everything they need to be sent
+	if config.EnableMetrics.Value == true && config.EnableAlerting.Value == true && config.Alertmanager.EmailTo.Value != "" {
+		if config.Alertmanager.EmailFrom.Value == "" || config.Alertmanager.SmtpHost.Value == "" {
+			errors = append(errors, "Email alerts require a sender email address and an SMTP server.")
+		}
+	}
+
+	// Check that notifications have somewhere to go
+	if config.EnableNotifications.Value == true {
+		notifications := config.Notifications
+		if notifications.WebhookUrl.Value == "" && notifications.DiscordWebhookUrl.Value == "" && notifications.TelegramBotToken.Value == "" {
+			errors = append(errors, "Notifications are enabled, but no webhook, Discord webhook, or Telegram bot has been set.")
+		}
+		if (notifications.TelegramBotToken.Value == "") != (notifications.TelegramChatId.Value == "") {
+			errors = append(errors, "The Telegram bot token and chat ID must be set together.")
+		}
+	}
+
+	// Check the Docker network subnets
+	if config.Smartnode.DockerNetwork.Value == "" {
+		if subnet := config.Smartnode.DockerNetworkSubnet.Value.(string); subnet != "" {
+			if ip, _, err := net.ParseCIDR(subnet); err != nil || ip.To4() == nil {
+				errors = append(errors, fmt.Sprintf("The Docker network subnet '%s' is not a valid IPv4 subnet in CIDR notation.", subnet))
+			}
+		}
+		if config.Smartnode.EnableIpv6.Value == true {
+			subnet := config.Smartnode.DockerNetworkIpv6Subnet.Value.(string)
+			if subnet == "" {
+				errors = append(errors, "IPv6 is enabled on the Docker network, but no IPv6 subnet has been set.")
+			} else if ip, _, err := net.ParseCIDR(subnet); err != nil || ip.To4() != nil {
+				errors = append(errors, fmt.Sprintf("The Docker network IPv6 subnet '%s' is not a valid IPv6 subnet in CIDR notation.", subnet))
+			}
+		}
+	}
+
+	// Check the snapshot list
+	if _, err := config.Smartnode.GetEcSnapshotUrls(); err != nil {
+		errors = append(errors, fmt.Sprintf("The EC snapshot URLs can't be used: %s", err.Error()))
+	}
+
+	// Check the blackout windows
+	if _, err := config.Schedule.GetBlackoutWindows(); err != nil {
+		errors = append(errors, fmt.Sprintf("The blackout windows can't be used: %s", err.Error()))
+	}
+	if _, err := config.Schedule.GetLocation(); err != nil {
+		errors = append(errors, fmt.Sprintf("The blackout windows' timezone can't be used: %s", err.Error()))
+	}
+
+	// Check that the settings encryption key can be derived
+	if config.Smartnode.EncryptSensitiveSettings.Value == true {
+		if _, err := config.getSettingsEncryptionSecret(); err != nil {
+			errors = append(errors, fmt.Sprintf("Sensitive settings can't be encrypted: %s", err.Error()))
+		}
+	}
+
+	// Check for illegal blank strings, ignoring settings that aren't relevant to the selected configuration
 	for _, param := range config.GetParameters() {
-		if param.Type == ParameterType_String && !param.CanBeBlank && param.Value == "" {
+		if err := param.CheckValueType(); err != nil {
+			errors = append(errors, err.Error())
+			continue
+		}
+		if param.Type == ParameterType_String && !param.CanBeBlank && param.Value == "" && param.IsRelevant() {
 			errors = append(errors, fmt.Sprintf("[%s] cannot be blank.", param.Name))
 		}
 	}
 
 	for name, subconfig := range config.GetSubconfigs() {
+		// Native mode only uses the Smartnode and native settings
+		if (name == "native") != config.IsNativeMode && name != "smartnode" {
+			continue
+		}
 		for _, param := range subconfig.GetParameters() {
-			if param.Type == ParameterType_String && !param.CanBeBlank && param.Value == "" {
+			if err := param.CheckValueType(); err != nil {
+				errors = append(errors, fmt.Sprintf("[%s] %s", name, err.Error()))
+				continue
+			}
+			if param.Type == ParameterType_String && !param.CanBeBlank && param.Value == "" && param.IsRelevant() {
 				errors = append(errors, fmt.Sprintf("[%s - %s] cannot be blank.", name, param.Name))
 			}
 		}
 	}
-	*/
 
 	return errors
 }
@@ -1158,17 +1367,6 @@ func (config *RocketPoolConfig) applyAllDefaults() error {
 	return nil
 }
 
-// Add the parameters to the collection of environment variabes
-func addParametersToEnvVars(params []*Parameter, envVars map[string]string) {
-	for _, param := range params {
-		for _, envVar := range param.EnvironmentVariables {
-			if envVar != "" {
-				envVars[envVar] = fmt.Sprint(param.Value)
-			}
-		}
-	}
-}
-
 // Get all of the changed settings between an old and new config
 func getChangedSettingsMap(oldConfig *RocketPoolConfig, newConfig *RocketPoolConfig) map[string][]ChangedSetting {
 	changedSettings := map[string][]ChangedSetting{}
@@ -1221,8 +1419,8 @@ func getAffectedContainers(param *Parameter, cfg *RocketPoolConfig) map[Containe
 	}
 
 	// Nimbus doesn't operate in split mode, so all of the VC parameters need to get redirected to the BN instead
-	if cfg.ConsensusClientMode.Value.(Mode) == Mode_Local &&
-		cfg.ConsensusClient.Value.(ConsensusClient) == ConsensusClient_Nimbus {
+	if cfg.GetConsensusClientMode() == Mode_Local &&
+		cfg.GetSelectedConsensusClient() == ConsensusClient_Nimbus {
 		for _, container := range param.AffectsContainers {
 			if container == ContainerID_Validator {
 				affectedContainers[ContainerID_Eth2] = true
@@ -0,0 +1,261 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// The abbreviations of the days of the week, in time.Weekday order
+var weekdayNames = []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+
+// Configuration for the times the Smartnode's automation runs
+type ScheduleConfig struct {
+	Title string `yaml:"-"`
+
+	// The windows during which non-critical automation is deferred and alerts are held back
+	BlackoutWindows Parameter `yaml:"blackoutWindows,omitempty"`
+
+	// The timezone the blackout windows are in
+	Timezone Parameter `yaml:"timezone,omitempty"`
+
+	// Toggle for sending the alerts held back during a blackout window once it ends, instead of dropping them
+	QueueAlerts Parameter `yaml:"queueAlerts,omitempty"`
+}
+
+// A daily or weekly period during which non-critical automation is deferred
+type BlackoutWindow struct {
+	// The days the window starts on, indexed by time.Weekday
+	Days [7]bool
+
+	// The start and end of the window, in minutes after midnight; windows that end before they start run past midnight
+	Start int
+	End   int
+}
+
+// Generates a new schedule config
+func NewScheduleConfig(config *RocketPoolConfig) *ScheduleConfig {
+	return &ScheduleConfig{
+		Title: "Schedule Settings",
+
+		BlackoutWindows: Parameter{
+			ID:                   "blackoutWindows",
+			Name:                 "Blackout Windows",
+			Description:          "A comma-separated list of times when the Smartnode should hold off on non-critical automation, such as claiming rewards and pruning old rewards trees, and hold back non-critical alerts. Use this for hours with expensive electricity or planned ISP maintenance.\n\nEach window is an optional day or range of days followed by a time range, e.g. `Mon-Fri 17:00-21:00, Sun 02:00-04:00, 23:30-00:30`. Windows without days apply every day, and windows can run past midnight.\n\nMinipool staking, scheduled exits, slashing checks, and critical alerts (slashings, validator crash loops, execution client outages, finality stalls, and low execution client disk space) are never deferred.",
+			Type:                 ParameterType_String,
+			Default:              map[Network]interface{}{Network_All: ""},
+			AffectsContainers:    []ContainerID{ContainerID_Node, ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		Timezone: Parameter{
+			ID:                   "timezone",
+			Name:                 "Timezone",
+			Description:          "The timezone the blackout windows are in, in the format 'Country/City' or 'UTC'.\n\nLeave this blank to use UTC.",
+			Type:                 ParameterType_String,
+			Default:              map[Network]interface{}{Network_All: ""},
+			AffectsContainers:    []ContainerID{ContainerID_Node, ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		QueueAlerts: Parameter{
+			ID:                   "queueAlerts",
+			Name:                 "Queue Alerts",
+			Description:          "Enable this to send the non-critical alerts that were held back during a blackout window once it ends.\n\nDisable it to drop them instead.",
+			Type:                 ParameterType_Bool,
+			Default:              map[Network]interface{}{Network_All: true},
+			AffectsContainers:    []ContainerID{ContainerID_Node, ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+	}
+}
+
+// Get the parameters for this config
+func (config *ScheduleConfig) GetParameters() []*Parameter {
+	return []*Parameter{
+		&config.BlackoutWindows,
+		&config.Timezone,
+		&config.QueueAlerts,
+	}
+}
+
+// The the title for the config
+func (config *ScheduleConfig) GetConfigTitle() string {
+	return config.Title
+}
+
+// Get the timezone the blackout windows are in
+func (config *ScheduleConfig) GetLocation() (*time.Location, error) {
+	timezone := config.Timezone.GetStringOrDefault(Network_All)
+	if timezone == "" {
+		return time.UTC, nil
+	}
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, fmt.Errorf("unknown timezone '%s': %w", timezone, err)
+	}
+	return location, nil
+}
+
+// Get the blackout windows
+func (config *ScheduleConfig) GetBlackoutWindows() ([]BlackoutWindow, error) {
+	return ParseBlackoutWindows(config.BlackoutWindows.GetStringOrDefault(Network_All))
+}
+
+// Check if a time falls in one of the blackout windows.
+// Invalid settings are reported by the config validation, so they're treated as having no windows here.
+func (config *ScheduleConfig) IsBlackout(t time.Time) bool {
+	windows, err := config.GetBlackoutWindows()
+	if err != nil || len(windows) == 0 {
+		return false
+	}
+	location, err := config.GetLocation()
+	if err != nil {
+		return false
+	}
+	t = t.In(location)
+	for _, window := range windows {
+		if window.Contains(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// Check if a time, in the schedule's timezone, falls in the window
+func (window BlackoutWindow) Contains(t time.Time) bool {
+	minute := t.Hour()*60 + t.Minute()
+	day := t.Weekday()
+	if window.Start < window.End {
+		return window.Days[day] && minute >= window.Start && minute < window.End
+	}
+
+	// Windows that run past midnight belong to the day they start on
+	if minute >= window.Start {
+		return window.Days[day]
+	}
+	if minute < window.End {
+		return window.Days[(day+6)%7]
+	}
+	return false
+}
+
+// Parse a comma-separated list of blackout windows, such as `Mon-Fri 17:00-21:00, 23:30-00:30`
+func ParseBlackoutWindows(value string) ([]BlackoutWindow, error) {
+	windows := []BlackoutWindow{}
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		window, err := parseBlackoutWindow(entry)
+		if err != nil {
+			return nil, fmt.Errorf("invalid blackout window '%s': %w", entry, err)
+		}
+		windows = append(windows, window)
+	}
+	return windows, nil
+}
+
+// Parse a single blackout window
+func parseBlackoutWindow(entry string) (BlackoutWindow, error) {
+	window := BlackoutWindow{}
+	fields := strings.Fields(entry)
+	var times string
+	switch len(fields) {
+	case 1:
+		for i := range window.Days {
+			window.Days[i] = true
+		}
+		times = fields[0]
+	case 2:
+		days, err := parseWeekdays(fields[0])
+		if err != nil {
+			return BlackoutWindow{}, err
+		}
+		window.Days = days
+		times = fields[1]
+	default:
+		return BlackoutWindow{}, fmt.Errorf("expected an optional day range and a time range")
+	}
+
+	bounds := strings.Split(times, "-")
+	if len(bounds) != 2 {
+		return BlackoutWindow{}, fmt.Errorf("expected a time range like 17:00-21:00")
+	}
+	var err error
+	if window.Start, err = parseTimeOfDay(bounds[0]); err != nil {
+		return BlackoutWindow{}, err
+	}
+	if window.End, err = parseTimeOfDay(bounds[1]); err != nil {
+		return BlackoutWindow{}, err
+	}
+	if window.Start == window.End {
+		return BlackoutWindow{}, fmt.Errorf("the window starts and ends at the same time")
+	}
+	return window, nil
+}
+
+// Parse a day or range of days, like `Sat` or `Mon-Fri`; ranges can wrap around the end of the week
+func parseWeekdays(value string) ([7]bool, error) {
+	days := [7]bool{}
+	if strings.EqualFold(value, "daily") {
+		for i := range days {
+			days[i] = true
+		}
+		return days, nil
+	}
+
+	bounds := strings.Split(value, "-")
+	if len(bounds) > 2 {
+		return days, fmt.Errorf("expected a day like Sat or a range of days like Mon-Fri")
+	}
+	first, err := parseWeekday(bounds[0])
+	if err != nil {
+		return days, err
+	}
+	last := first
+	if len(bounds) == 2 {
+		if last, err = parseWeekday(bounds[1]); err != nil {
+			return days, err
+		}
+	}
+	for day := first; ; day = (day + 1) % 7 {
+		days[day] = true
+		if day == last {
+			break
+		}
+	}
+	return days, nil
+}
+
+// Parse the name of a day of the week
+func parseWeekday(value string) (int, error) {
+	value = strings.ToLower(value)
+	if len(value) >= 3 {
+		for i, name := range weekdayNames {
+			if strings.HasPrefix(value, name) {
+				return i, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("unknown day '%s'", value)
+}
+
+// Parse a time of day like 17:00 into minutes after midnight; 24:00 is the end of the day
+func parseTimeOfDay(value string) (int, error) {
+	if value == "24:00" {
+		return 0, nil
+	}
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time '%s', expected HH:MM", value)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
@@ -0,0 +1,198 @@
+package config
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/mitchellh/go-homedir"
+	"golang.org/x/crypto/scrypt"
+)
+
+// Constants
+const (
+	settingsPassphraseFile    string = "settings-passphrase"
+	walletPasswordFile        string = "password"
+	encryptedValuePrefix      string = "enc:"
+	settingsEncryptionSaltKey string = "encryptionSalt"
+
+	settingsSaltLength int = 16
+	settingsKeyLength  int = 32
+	settingsScryptN    int = 1 << 15
+	settingsScryptR    int = 8
+	settingsScryptP    int = 1
+)
+
+// Encrypts sensitive settings with a key derived from the configured secret
+type settingsCipher struct {
+	salt []byte
+	aead cipher.AEAD
+}
+
+// Create a cipher for the sensitive settings using the provided salt, or a new random one if it's nil
+func (config *RocketPoolConfig) newSettingsCipher(salt []byte) (*settingsCipher, error) {
+
+	// Get the secret
+	secret, err := config.getSettingsEncryptionSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	// Create a new salt if required
+	if salt == nil {
+		salt = make([]byte, settingsSaltLength)
+		if _, err := rand.Read(salt); err != nil {
+			return nil, fmt.Errorf("error generating settings encryption salt: %w", err)
+		}
+	}
+
+	// Derive the key
+	key, err := scrypt.Key(secret, salt, settingsScryptN, settingsScryptR, settingsScryptP, settingsKeyLength)
+	if err != nil {
+		return nil, fmt.Errorf("error deriving settings encryption key: %w", err)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	return &settingsCipher{
+		salt: salt,
+		aead: aead,
+	}, nil
+
+}
+
+// Encrypt a setting's value; the parameter ID is authenticated so values can't be swapped between settings
+func (c *settingsCipher) encrypt(id string, value string) (string, error) {
+	nonce := make([]byte, c.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("error generating nonce: %w", err)
+	}
+	ciphertext := c.aead.Seal(nonce, nonce, []byte(value), []byte(id))
+	return encryptedValuePrefix + base64.StdEncoding.EncodeToString(ciphertext), nil
+}
+
+// Decrypt a setting's value
+func (c *settingsCipher) decrypt(id string, value string) (string, error) {
+	ciphertext, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(value, encryptedValuePrefix))
+	if err != nil {
+		return "", fmt.Errorf("setting [%s] is not correctly encrypted: %w", id, err)
+	}
+	nonceSize := c.aead.NonceSize()
+	if len(ciphertext) < nonceSize {
+		return "", fmt.Errorf("setting [%s] is not correctly encrypted", id)
+	}
+	plaintext, err := c.aead.Open(nil, ciphertext[:nonceSize], ciphertext[nonceSize:], []byte(id))
+	if err != nil {
+		return "", fmt.Errorf("could not decrypt setting [%s]; the encryption key may have changed", id)
+	}
+	return string(plaintext), nil
+}
+
+// Get the secret the settings encryption key is derived from.
+// The Smartnode's containers and the CLI see the data folder at different paths, so this checks both.
+func (config *RocketPoolConfig) getSettingsEncryptionSecret() ([]byte, error) {
+
+	// Get the name of the secret file
+	var filename string
+	source, _ := config.Smartnode.SettingsKeySource.Value.(SettingsKeySource)
+	switch source {
+	case SettingsKeySource_WalletPassword:
+		filename = walletPasswordFile
+	case SettingsKeySource_Passphrase:
+		filename = settingsPassphraseFile
+	default:
+		return nil, fmt.Errorf("unknown settings encryption key source [%v]", config.Smartnode.SettingsKeySource.Value)
+	}
+
+	// Get the folders it could be in
+	folders := []string{}
+	dataPath, err := homedir.Expand(config.Smartnode.GetDataPath())
+	if err == nil {
+		folders = append(folders, dataPath)
+	}
+	if !config.IsNativeMode {
+		folders = append(folders, filepath.Dir(config.Smartnode.passwordPath))
+	}
+
+	// Read the first one that exists
+	var readErr error
+	for _, folder := range folders {
+		secret, err := ioutil.ReadFile(filepath.Join(folder, filename))
+		if err != nil {
+			if readErr == nil {
+				readErr = err
+			}
+			continue
+		}
+		secret = []byte(strings.TrimSpace(string(secret)))
+		if len(secret) == 0 {
+			return nil, fmt.Errorf("the settings encryption secret in %s is empty", filepath.Join(folder, filename))
+		}
+		return secret, nil
+	}
+	return nil, fmt.Errorf("could not read the settings encryption secret [%s]: %w", filename, readErr)
+
+}
+
+// Decrypts the sensitive settings of a serialized settings file in place
+func decryptSettings(settings map[string]map[string]string) error {
+
+	// Check if there's anything to decrypt
+	isEncrypted := false
+	plainSettings := map[string]map[string]string{}
+	for sectionName, section := range settings {
+		plainSection := map[string]string{}
+		for id, value := range section {
+			if strings.HasPrefix(value, encryptedValuePrefix) {
+				isEncrypted = true
+				continue
+			}
+			plainSection[id] = value
+		}
+		plainSettings[sectionName] = plainSection
+	}
+	if !isEncrypted {
+		return nil
+	}
+
+	// Load the unencrypted settings to find the key
+	keyConfig := NewRocketPoolConfig("", false)
+	if err := keyConfig.Deserialize(plainSettings); err != nil {
+		return fmt.Errorf("error loading settings encryption details: %w", err)
+	}
+	salt, err := base64.StdEncoding.DecodeString(settings[rootConfigName][settingsEncryptionSaltKey])
+	if err != nil || len(salt) == 0 {
+		return fmt.Errorf("settings file has encrypted settings but no valid encryption salt")
+	}
+	settingsCipher, err := keyConfig.newSettingsCipher(salt)
+	if err != nil {
+		return err
+	}
+
+	// Decrypt the settings
+	for _, section := range settings {
+		for id, value := range section {
+			if !strings.HasPrefix(value, encryptedValuePrefix) {
+				continue
+			}
+			plaintext, err := settingsCipher.decrypt(id, value)
+			if err != nil {
+				return err
+			}
+			section[id] = plaintext
+		}
+	}
+	return nil
+
+}
@@ -0,0 +1,308 @@
+package config
+
+import (
+	"encoding/base64"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/rocket-pool/smartnode/shared"
+	"gopkg.in/yaml.v2"
+)
+
+// Constants
+const (
+	// The current version of the settings file format.
+	// Version 1 files are a flat map of sections to string values; version 2 files nest the client sections in groups,
+	// use typed values, and have comments describing each setting.
+	SettingsFormatVersion int = 2
+
+	formatVersionKey string = "formatVersion"
+	settingsIndent   string = "  "
+)
+
+// A section of the config within a group of a settings file
+type settingsSection struct {
+	key  string
+	name string
+}
+
+// A group of related config sections, which are nested under the group's key in a settings file
+type settingsGroup struct {
+	key      string
+	title    string
+	sections []settingsSection
+}
+
+// The groups of a settings file; sections that aren't in a group are written at the top level
+var settingsGroups = []settingsGroup{
+	{
+		key:   "execution",
+		title: "Execution Client",
+		sections: []settingsSection{
+			{key: "common", name: "executionCommon"},
+			{key: "geth", name: "geth"},
+			{key: "nethermind", name: "nethermind"},
+			{key: "besu", name: "besu"},
+			{key: "erigon", name: "erigon"},
+			{key: "infura", name: "infura"},
+			{key: "pocket", name: "pocket"},
+			{key: "external", name: "externalExecution"},
+		},
+	},
+	{
+		key:   "fallbackExecution",
+		title: "Fallback Execution Client",
+		sections: []settingsSection{
+			{key: "common", name: "fallbackExecutionCommon"},
+			{key: "infura", name: "fallbackInfura"},
+			{key: "pocket", name: "fallbackPocket"},
+			{key: "external", name: "fallbackExternalExecution"},
+		},
+	},
+	{
+		key:   "consensus",
+		title: "Consensus Client",
+		sections: []settingsSection{
+			{key: "common", name: "consensusCommon"},
+			{key: "lighthouse", name: "lighthouse"},
+			{key: "nimbus", name: "nimbus"},
+			{key: "prysm", name: "prysm"},
+			{key: "teku", name: "teku"},
+			{key: "externalLighthouse", name: "externalLighthouse"},
+			{key: "externalPrysm", name: "externalPrysm"},
+			{key: "externalTeku", name: "externalTeku"},
+		},
+	},
+	{
+		key:   "metrics",
+		title: "Monitoring / Metrics",
+		sections: []settingsSection{
+			{key: "grafana", name: "grafana"},
+			{key: "prometheus", name: "prometheus"},
+			{key: "exporter", name: "exporter"},
+			{key: "alertmanager", name: "alertmanager"},
+			{key: "bitflyNodeMetrics", name: "bitflyNodeMetrics"},
+		},
+	},
+}
+
+// Serializes the config into a settings file, with a comment describing each setting.
+// Sensitive settings are encrypted if settings encryption is enabled.
+func (config *RocketPoolConfig) MarshalSettings() ([]byte, error) {
+
+	// Set up encryption
+	var settingsCipher *settingsCipher
+	if config.Smartnode.EncryptSensitiveSettings.Value == true {
+		var err error
+		settingsCipher, err = config.newSettingsCipher(nil)
+		if err != nil {
+			return nil, fmt.Errorf("error setting up settings encryption: %w", err)
+		}
+	}
+
+	builder := strings.Builder{}
+	builder.WriteString("# Rocket Pool Smartnode settings\n")
+	builder.WriteString("# This file is generated by `rocketpool service config`.\n")
+	builder.WriteString(fmt.Sprintf("%s: %d\n", formatVersionKey, SettingsFormatVersion))
+
+	// Write the root section, including the installation details
+	builder.WriteString(fmt.Sprintf("\n# %s\n%s:\n", config.Title, rootConfigName))
+	builder.WriteString(fmt.Sprintf("%srpDir: %s\n", settingsIndent, strconv.Quote(config.RocketPoolDirectory)))
+	builder.WriteString(fmt.Sprintf("%sisNative: %t\n", settingsIndent, config.IsNativeMode))
+	builder.WriteString(fmt.Sprintf("%sversion: %s\n", settingsIndent, strconv.Quote(fmt.Sprintf("v%s", shared.RocketPoolVersion))))
+	if settingsCipher != nil {
+		builder.WriteString(fmt.Sprintf("%s%s: %s\n", settingsIndent, settingsEncryptionSaltKey, strconv.Quote(base64.StdEncoding.EncodeToString(settingsCipher.salt))))
+	}
+	for _, param := range config.GetParameters() {
+		if err := writeSetting(&builder, param, settingsIndent, settingsCipher); err != nil {
+			return nil, err
+		}
+	}
+
+	// Write the groups, with their sections nested under them
+	subconfigs := config.GetSubconfigs()
+	grouped := map[string]bool{}
+	for _, group := range settingsGroups {
+		builder.WriteString(fmt.Sprintf("\n# %s\n%s:\n", group.title, group.key))
+		for i, section := range group.sections {
+			grouped[section.name] = true
+			subconfig, exists := subconfigs[section.name]
+			if !exists {
+				continue
+			}
+			if i > 0 {
+				builder.WriteString("\n")
+			}
+			builder.WriteString(fmt.Sprintf("%s# %s\n%s%s:\n", settingsIndent, subconfig.GetConfigTitle(), settingsIndent, section.key))
+			for _, param := range subconfig.GetParameters() {
+				if err := writeSetting(&builder, param, settingsIndent+settingsIndent, settingsCipher); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	// Write the other sections at the top level in a stable order
+	names := make([]string, 0, len(subconfigs))
+	for name := range subconfigs {
+		if !grouped[name] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		subconfig := subconfigs[name]
+		builder.WriteString(fmt.Sprintf("\n# %s\n%s:\n", subconfig.GetConfigTitle(), name))
+		for _, param := range subconfig.GetParameters() {
+			if err := writeSetting(&builder, param, settingsIndent, settingsCipher); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return []byte(builder.String()), nil
+
+}
+
+// Parses a settings file of any format version into the serialized form used by Deserialize()
+func UnmarshalSettings(bytes []byte) (map[string]map[string]string, error) {
+
+	settings, err := UnmarshalPlainSettings(bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	// Decrypt any encrypted settings
+	if err := decryptSettings(settings); err != nil {
+		return nil, err
+	}
+
+	return settings, nil
+
+}
+
+// Parses a settings file of any format version into the serialized form used by Deserialize(), leaving encrypted settings as they are
+func UnmarshalPlainSettings(bytes []byte) (map[string]map[string]string, error) {
+
+	var file map[string]interface{}
+	if err := yaml.Unmarshal(bytes, &file); err != nil {
+		return nil, fmt.Errorf("could not parse settings file: %w", err)
+	}
+
+	// Check the format version; files without one predate versioning
+	if formatVersion, exists := file[formatVersionKey]; exists {
+		version, ok := formatVersion.(int)
+		if !ok {
+			return nil, fmt.Errorf("settings file has an invalid format version [%v]", formatVersion)
+		}
+		if version > SettingsFormatVersion {
+			return nil, fmt.Errorf("settings file format version %d is newer than this version of the Smartnode supports (%d); please upgrade the Smartnode", version, SettingsFormatVersion)
+		}
+		delete(file, formatVersionKey)
+	}
+
+	// Convert every value back into a string, which works for the typed values of version 2 and the strings of version 1.
+	// The sections of each group are flattened back into top-level sections.
+	settings := map[string]map[string]string{}
+	for sectionName, section := range file {
+		sectionMap, ok := section.(map[interface{}]interface{})
+		if !ok {
+			if section == nil {
+				continue
+			}
+			return nil, fmt.Errorf("settings file section [%s] is not a map of settings", sectionName)
+		}
+		if group := getSettingsGroup(sectionName); group != nil {
+			for key, groupSection := range sectionMap {
+				name := group.getSectionName(fmt.Sprint(key))
+				if name == "" {
+					// This section was removed, so there's nothing to load it into
+					continue
+				}
+				groupSectionMap, ok := groupSection.(map[interface{}]interface{})
+				if !ok {
+					if groupSection == nil {
+						continue
+					}
+					return nil, fmt.Errorf("settings file section [%s.%v] is not a map of settings", sectionName, key)
+				}
+				settings[name] = getSettingsStrings(groupSectionMap)
+			}
+			continue
+		}
+		settings[sectionName] = getSettingsStrings(sectionMap)
+	}
+
+	return settings, nil
+
+}
+
+// Get the settings group with the provided key, or nil if there isn't one
+func getSettingsGroup(key string) *settingsGroup {
+	for i := range settingsGroups {
+		if settingsGroups[i].key == key {
+			return &settingsGroups[i]
+		}
+	}
+	return nil
+}
+
+// Get the name of the config section with the provided key in the group, or an empty string if it doesn't have one
+func (group *settingsGroup) getSectionName(key string) string {
+	for _, section := range group.sections {
+		if section.key == key {
+			return section.name
+		}
+	}
+	return ""
+}
+
+// Convert the values of a section of a settings file into strings
+func getSettingsStrings(section map[interface{}]interface{}) map[string]string {
+	params := map[string]string{}
+	for key, value := range section {
+		if value == nil {
+			params[fmt.Sprint(key)] = ""
+		} else {
+			params[fmt.Sprint(key)] = fmt.Sprint(value)
+		}
+	}
+	return params
+}
+
+// Writes a single setting and its description to a settings file at the provided indent, encrypting it if it's sensitive and a cipher is provided
+func writeSetting(builder *strings.Builder, param *Parameter, indent string, settingsCipher *settingsCipher) error {
+
+	builder.WriteString(fmt.Sprintf("%s# %s\n", indent, param.Name))
+	for _, line := range strings.Split(strings.TrimSpace(param.Description), "\n") {
+		if line == "" {
+			builder.WriteString(fmt.Sprintf("%s#\n", indent))
+		} else {
+			builder.WriteString(fmt.Sprintf("%s# %s\n", indent, line))
+		}
+	}
+
+	// Quote strings so they aren't interpreted as other types; everything else can be written as-is
+	var value string
+	if param.Value == nil {
+		value = `""`
+	} else if reflect.TypeOf(param.Value).Kind() == reflect.String {
+		value = fmt.Sprint(param.Value)
+		if param.Sensitive && settingsCipher != nil && value != "" {
+			var err error
+			value, err = settingsCipher.encrypt(param.ID, value)
+			if err != nil {
+				return fmt.Errorf("error encrypting setting [%s]: %w", param.ID, err)
+			}
+		}
+		value = strconv.Quote(value)
+	} else {
+		value = fmt.Sprint(param.Value)
+	}
+	builder.WriteString(fmt.Sprintf("%s%s: %s\n", indent, param.ID, value))
+	return nil
+
+}
@@ -0,0 +1,175 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/rocket-pool/smartnode/shared"
+)
+
+// Create a config with a non-default setting in the root and in sections of each kind
+func newSettingsTestConfig() *RocketPoolConfig {
+	cfg := NewRocketPoolConfig("/home/node/.rocketpool", false)
+	cfg.ExecutionClient.Value = ExecutionClient_Nethermind
+	cfg.ConsensusClient.Value = ConsensusClient_Teku
+	cfg.UseFallbackExecutionClient.Value = true
+	cfg.Smartnode.Network.Value = Network_Prater
+	cfg.ExecutionCommon.P2pPort.Value = uint16(30304)
+	cfg.Nethermind.AdditionalFlags.Value = "--Pruning.Mode Hybrid"
+	cfg.FallbackExternalExecution.HttpUrl.Value = "http://192.168.1.31:8545"
+	cfg.Teku.JvmHeapSize.Value = uint64(6144)
+	cfg.ExternalPrysm.GrpcUrl.Value = "192.168.1.40:5053"
+	cfg.Grafana.Port.Value = uint16(3200)
+	cfg.RemoteSigner.Url.Value = "https://192.168.1.50:9000"
+	cfg.Native.EcHttpUrl.Value = "http://127.0.0.1:8545"
+	return cfg
+}
+
+func TestSettingsFileRoundTrip(t *testing.T) {
+	cfg := newSettingsTestConfig()
+	bytes, err := cfg.MarshalSettings()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The file loads back into the same settings
+	settings, err := UnmarshalSettings(bytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(settings, cfg.Serialize()) {
+		t.Fatalf("the settings changed in the round trip:\n\texpected: %v\n\tactual:   %v", cfg.Serialize(), settings)
+	}
+	loaded := NewRocketPoolConfig("/home/node/.rocketpool", false)
+	if err := loaded.Deserialize(settings); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(loaded.Serialize(), cfg.Serialize()) {
+		t.Fatal("the loaded config doesn't match the saved one")
+	}
+	if loaded.Teku.JvmHeapSize.Value != uint64(6144) || loaded.Nethermind.AdditionalFlags.Value != "--Pruning.Mode Hybrid" {
+		t.Fatalf("expected the typed settings to keep their values, got %v and %v", loaded.Teku.JvmHeapSize.Value, loaded.Nethermind.AdditionalFlags.Value)
+	}
+
+	// Saving it again produces the same file
+	secondBytes, err := loaded.MarshalSettings()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(secondBytes) != string(bytes) {
+		t.Fatal("expected saving the loaded config to produce the same file")
+	}
+}
+
+func TestSettingsFileLayout(t *testing.T) {
+	bytes, err := newSettingsTestConfig().MarshalSettings()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var file map[string]interface{}
+	if err := yaml.Unmarshal(bytes, &file); err != nil {
+		t.Fatal(err)
+	}
+	if file[formatVersionKey] != SettingsFormatVersion {
+		t.Fatalf("expected format version %d, got %v", SettingsFormatVersion, file[formatVersionKey])
+	}
+
+	// The client sections are nested in their groups, and the rest are at the top level
+	for _, group := range settingsGroups {
+		groupMap, ok := file[group.key].(map[interface{}]interface{})
+		if !ok {
+			t.Fatalf("expected a %s group, got %v", group.key, file[group.key])
+		}
+		for _, section := range group.sections {
+			if _, exists := groupMap[section.key]; !exists {
+				t.Errorf("expected the %s section to be nested under %s.%s", section.name, group.key, section.key)
+			}
+			if _, exists := file[section.name]; exists && section.name != group.key {
+				t.Errorf("expected the %s section not to be at the top level", section.name)
+			}
+		}
+	}
+	for _, name := range []string{rootConfigName, "smartnode", "remoteSigner", "notifications", "schedule", "native"} {
+		if _, ok := file[name].(map[interface{}]interface{}); !ok {
+			t.Errorf("expected the %s section at the top level", name)
+		}
+	}
+
+	// Values keep their types, and settings are described
+	execution := file["execution"].(map[interface{}]interface{})
+	common := execution["common"].(map[interface{}]interface{})
+	if port, ok := common["p2pPort"].(int); !ok || port != 30304 {
+		t.Fatalf("expected the P2P port to be written as a number, got %#v", common["p2pPort"])
+	}
+	if !strings.Contains(string(bytes), "    # "+NewRocketPoolConfig("", false).ExecutionCommon.P2pPort.Name+"\n") {
+		t.Fatal("expected each setting in a group to have a comment with its name")
+	}
+}
+
+func TestSettingsFileV1Migration(t *testing.T) {
+	// Version 1 files are the flat serialized settings
+	cfg := newSettingsTestConfig()
+	v1Bytes, err := yaml.Marshal(cfg.Serialize())
+	if err != nil {
+		t.Fatal(err)
+	}
+	settings, err := UnmarshalSettings(v1Bytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(settings, cfg.Serialize()) {
+		t.Fatalf("the settings of the version 1 file changed:\n\texpected: %v\n\tactual:   %v", cfg.Serialize(), settings)
+	}
+
+	// Saving a loaded version 1 file writes the current format with the same settings
+	loaded := NewRocketPoolConfig("/home/node/.rocketpool", false)
+	if err := loaded.Deserialize(settings); err != nil {
+		t.Fatal(err)
+	}
+	v2Bytes, err := loaded.MarshalSettings()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(v2Bytes), fmt.Sprintf("%s: %d\n", formatVersionKey, SettingsFormatVersion)) {
+		t.Fatal("expected the saved file to have the current format version")
+	}
+	migrated, err := UnmarshalSettings(v2Bytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(migrated, cfg.Serialize()) {
+		t.Fatal("the settings changed when the version 1 file was saved in the current format")
+	}
+
+	// Version 1 files from before the upgrades are migrated too
+	old := cfg.Serialize()
+	old[rootConfigName]["version"] = "v1.4.0"
+	old["externalPrysm"]["jsonRpcUrl"] = old["externalPrysm"]["grpcUrl"]
+	delete(old["externalPrysm"], "grpcUrl")
+	oldBytes, err := yaml.Marshal(old)
+	if err != nil {
+		t.Fatal(err)
+	}
+	settings, err = UnmarshalSettings(oldBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	loaded = NewRocketPoolConfig("/home/node/.rocketpool", false)
+	if err := loaded.Deserialize(settings); err != nil {
+		t.Fatal(err)
+	}
+	if loaded.ExternalPrysm.GrpcUrl.Value != "192.168.1.40:5053" {
+		t.Fatalf("expected the old Prysm URL to be migrated, got %v", loaded.ExternalPrysm.GrpcUrl.Value)
+	}
+}
+
+func TestSettingsFileRejectsNewerFormats(t *testing.T) {
+	bytes := []byte(fmt.Sprintf("%s: %d\nroot:\n  version: \"v%s\"\n", formatVersionKey, SettingsFormatVersion+1, shared.RocketPoolVersion))
+	if _, err := UnmarshalSettings(bytes); err == nil || !strings.Contains(err.Error(), "newer than this version") {
+		t.Fatalf("expected an error for a newer format version, got %v", err)
+	}
+}
@@ -1,7 +1,11 @@
 package config
 
 import (
+	"fmt"
+	"net/url"
 	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/rocket-pool/smartnode/shared"
 )
@@ -12,6 +16,8 @@ const (
 	powProxyTag         string = "rocketpool/smartnode-pow-proxy:v" + shared.RocketPoolVersion
 	pruneProvisionerTag string = "rocketpool/eth1-prune-provision:v0.0.1"
 	ecMigratorTag       string = "rocketpool/ec-migrator:v1.0.0"
+	snapshotLoaderTag   string = "alpine:3.16"
+	chronyTag           string = "alpine:3.16"
 	NetworkID           string = "network"
 	ProjectNameID       string = "projectName"
 	SnapshotID          string = "rocketpool-dao.eth"
@@ -19,6 +25,14 @@ const (
 
 // Defaults
 const defaultProjectName string = "rocketpool"
+const defaultDockerNetwork string = "net"
+const defaultKeymanagerApiPort uint16 = 5062
+const defaultKeymanagerTokenFile string = "keymanager-token"
+const defaultRestApiPort uint16 = 8280
+const defaultEventStreamPort uint16 = 8281
+const defaultHealthCheckPort uint16 = 8285
+const defaultEventLogWorkers uint64 = 4
+const defaultTreegenMemoryLimit uint64 = 8192
 
 // Configuration for the Smartnode
 type SmartnodeConfig struct {
@@ -37,6 +51,24 @@ type SmartnodeConfig struct {
 	// The path of the data folder where everything is stored
 	DataPath Parameter `yaml:"dataPath,omitempty"`
 
+	// The name of an existing Docker network to put the containers on, instead of the Smartnode's own network
+	DockerNetwork Parameter `yaml:"dockerNetwork,omitempty"`
+
+	// The IPv4 subnet of the Smartnode's Docker network
+	DockerNetworkSubnet Parameter `yaml:"dockerNetworkSubnet,omitempty"`
+
+	// Toggle for IPv6 on the Smartnode's Docker network
+	EnableIpv6 Parameter `yaml:"enableIpv6,omitempty"`
+
+	// The IPv6 subnet of the Smartnode's Docker network
+	DockerNetworkIpv6Subnet Parameter `yaml:"dockerNetworkIpv6Subnet,omitempty"`
+
+	// The size a container's log file can grow to before Docker starts a new one, in MiB
+	ContainerLogMaxSize Parameter `yaml:"containerLogMaxSize,omitempty"`
+
+	// The number of log files Docker keeps for each container
+	ContainerLogMaxFiles Parameter `yaml:"containerLogMaxFiles,omitempty"`
+
 	// Which network we're on
 	Network Parameter `yaml:"network,omitempty"`
 
@@ -46,24 +78,195 @@ type SmartnodeConfig struct {
 	// Manual priority fee override
 	PriorityFee Parameter `yaml:"priorityFee,omitempty"`
 
+	// The source of max fee suggestions
+	GasOracle Parameter `yaml:"gasOracle,omitempty"`
+
+	// The API key for Blocknative's gas price suggestions
+	BlocknativeApiKey Parameter `yaml:"blocknativeApiKey,omitempty"`
+
 	// Threshold for auto RPL claims
 	RplClaimGasThreshold Parameter `yaml:"rplClaimGasThreshold,omitempty"`
 
 	// Threshold for auto minipool stakes
 	MinipoolStakeGasThreshold Parameter `yaml:"minipoolStakeGasThreshold,omitempty"`
 
+	// Toggle for automatically claiming Merkle rewards
+	AutoClaimEnabled Parameter `yaml:"autoClaimEnabled,omitempty"`
+
+	// The percentage of automatically claimed RPL to restake
+	AutoRestakePercent Parameter `yaml:"autoRestakePercent,omitempty"`
+
+	// The number of times the Validator client can crash in a short time before the node daemon stops it
+	ValidatorRestartLimit Parameter `yaml:"validatorRestartLimit,omitempty"`
+
+	// The number of Validator clients the validator keys are split across
+	ValidatorClientShards Parameter `yaml:"validatorClientShards,omitempty"`
+
+	// What to do when a surge of slashings is seen on the network
+	SlashingSafeMode Parameter `yaml:"slashingSafeMode,omitempty"`
+
+	// The number of validators slashed within an hour that counts as a surge
+	SlashingSafeModeThreshold Parameter `yaml:"slashingSafeModeThreshold,omitempty"`
+
+	// How long the Validator client is paused for after a surge, in minutes
+	SlashingSafeModeDuration Parameter `yaml:"slashingSafeModeDuration,omitempty"`
+
+	// How the system clock is kept accurate
+	TimeSyncMode Parameter `yaml:"timeSyncMode,omitempty"`
+
+	// The NTP servers the clock is checked against or synchronized with
+	NtpServers Parameter `yaml:"ntpServers,omitempty"`
+
+	// The largest difference from NTP time that's tolerated, in milliseconds
+	MaxClockOffset Parameter `yaml:"maxClockOffset,omitempty"`
+
+	// The Chrony container tag
+	ChronyContainerTag Parameter `yaml:"chronyContainerTag,omitempty"`
+
+	// The free space on the Execution client's chain data volume, in GiB, below which the node daemon steps in
+	EcLowDiskThreshold Parameter `yaml:"ecLowDiskThreshold,omitempty"`
+
+	// Toggle for pruning Geth automatically when its chain data volume runs low on space
+	EcAutoPrune Parameter `yaml:"ecAutoPrune,omitempty"`
+
+	// The trusted Execution client snapshots to sync from
+	EcSnapshotUrls Parameter `yaml:"ecSnapshotUrls,omitempty"`
+
+	// The URL of the Execution client endpoint that heavy read workloads like event scans are sent to
+	ReadEcUrl Parameter `yaml:"readEcUrl,omitempty"`
+
+	// The URL of the endpoint heavy reads are sent to when the read endpoint is unavailable
+	FallbackReadEcUrl Parameter `yaml:"fallbackReadEcUrl,omitempty"`
+
+	// Extra Execution client endpoints that requests can be routed to when the primary and fallback clients are unhealthy
+	ExtraEcUrls Parameter `yaml:"extraEcUrls,omitempty"`
+
+	// Toggle for submitting transactions through a private relay
+	UsePrivateRelay Parameter `yaml:"usePrivateRelay,omitempty"`
+
+	// The URL of the private relay
+	PrivateRelayUrl Parameter `yaml:"privateRelayUrl,omitempty"`
+
+	// The time to wait for a privately relayed transaction before sending it to the public mempool
+	PrivateRelayTimeout Parameter `yaml:"privateRelayTimeout,omitempty"`
+
+	// Toggle for using a transaction sponsor when the node wallet can't pay for gas
+	UseTxSponsor Parameter `yaml:"useTxSponsor,omitempty"`
+
+	// The URL of the transaction sponsor
+	TxSponsorUrl Parameter `yaml:"txSponsorUrl,omitempty"`
+
+	// Toggle for encrypting sensitive settings in the settings file
+	EncryptSensitiveSettings Parameter `yaml:"encryptSensitiveSettings,omitempty"`
+
+	// The source of the key used to encrypt sensitive settings
+	SettingsKeySource Parameter `yaml:"settingsKeySource,omitempty"`
+
+	// The depth of a Beacon chain reorg, in slots, that pauses finality-sensitive tasks
+	ReorgPauseDepth Parameter `yaml:"reorgPauseDepth,omitempty"`
+
+	// The number of epochs finality can lag behind the head before finality-sensitive tasks are paused
+	FinalityStallEpochs Parameter `yaml:"finalityStallEpochs,omitempty"`
+
+	// The maximum number of epochs the data in a watchtower submission can be ahead of the finalized epoch
+	MaxUnfinalizedReportEpochs Parameter `yaml:"maxUnfinalizedReportEpochs,omitempty"`
+
+	// The port of the Validator Client's Keymanager API
+	KeymanagerApiPort Parameter `yaml:"keymanagerApiPort,omitempty"`
+
+	// Toggle for loading new validator keys through the Validator Client's Keymanager API instead of restarting it
+	HotKeyLoading Parameter `yaml:"hotKeyLoading,omitempty"`
+
+	// Toggle for serving the API routes over HTTP
+	EnableRestApi Parameter `yaml:"enableRestApi,omitempty"`
+
+	// The port to serve the REST API on
+	RestApiPort Parameter `yaml:"restApiPort,omitempty"`
+
+	// The token REST API clients must authenticate with
+	RestApiAuthToken Parameter `yaml:"restApiAuthToken,omitempty"`
+
+	// Toggle for serving the REST API routes that send transactions or change the node's files
+	RestApiAllowWrites Parameter `yaml:"restApiAllowWrites,omitempty"`
+
+	// The number of recent rewards intervals to keep the tree files of
+	RewardsTreeRetention Parameter `yaml:"rewardsTreeRetention,omitempty"`
+
+	// Toggle for streaming the daemons' events over gRPC
+	EnableEventStream Parameter `yaml:"enableEventStream,omitempty"`
+
+	// The port the node daemon streams its events on
+	EventStreamPort Parameter `yaml:"eventStreamPort,omitempty"`
+
+	// Toggle for the daemons' health check endpoints and their Docker healthchecks
+	EnableHealthCheck Parameter `yaml:"enableHealthCheck,omitempty"`
+
+	// The port the node daemon serves its health check on; the watchtower and API daemons use the next ports up
+	HealthCheckPort Parameter `yaml:"healthCheckPort,omitempty"`
+
+	// The attestation effectiveness (in percent) below which validators are flagged as underperforming
+	PerformanceThreshold Parameter `yaml:"performanceThreshold,omitempty"`
+
+	// The number of epochs validator performance is measured over
+	PerformanceWindow Parameter `yaml:"performanceWindow,omitempty"`
+
+	// The timezone the CLI displays times in
+	DisplayTimezone Parameter `yaml:"displayTimezone,omitempty"`
+
+	// Whether the CLI shows a summary of the node's pending items before each command
+	ShowPendingItems Parameter `yaml:"showPendingItems,omitempty"`
+
+	// The IPFS gateways to download rewards tree files from, in order
+	IpfsGateways Parameter `yaml:"ipfsGateways,omitempty"`
+
+	// A custom URL to download rewards tree files from if the other sources fail
+	RewardsTreeMirrorUrl Parameter `yaml:"rewardsTreeMirrorUrl,omitempty"`
+
+	// The URL of an archive Beacon node that historical queries are sent to when the main Beacon node has pruned them
+	ArchiveCcUrl Parameter `yaml:"archiveCcUrl,omitempty"`
+
+	// The URL of an archive Execution client that queries of old state are sent to
+	ArchiveEcUrl Parameter `yaml:"archiveEcUrl,omitempty"`
+
+	// The number of blocks to request event logs for at a time, overriding the Execution client's default
+	EventLogChunkSize Parameter `yaml:"eventLogChunkSize,omitempty"`
+
+	// The number of event log ranges to request at the same time
+	EventLogWorkers Parameter `yaml:"eventLogWorkers,omitempty"`
+
+	// Whether the watchtower generates the rewards tree of each interval in a separate worker container
+	EnableTreegenWorker Parameter `yaml:"enableTreegenWorker,omitempty"`
+
+	// The most memory the rewards tree worker container can use, in MiB
+	TreegenMemoryLimit Parameter `yaml:"treegenMemoryLimit,omitempty"`
+
+	// Where the secondary-market rETH rate is read from
+	RethPriceSource Parameter `yaml:"rethPriceSource,omitempty"`
+
+	// The URL the secondary-market rETH rate is read from when the source is a custom URL
+	RethPriceUrl Parameter `yaml:"rethPriceUrl,omitempty"`
+
+	// The largest difference between the secondary-market and protocol rETH rates that's tolerated, as a percentage
+	RethMaxDeviation Parameter `yaml:"rethMaxDeviation,omitempty"`
+
+	// How many days of rETH and RPL rate history the node daemon keeps
+	RateHistoryRetention Parameter `yaml:"rateHistoryRetention,omitempty"`
+
+	// The URL of a fallback Beacon node the daemons use when the main one is offline or out of sync
+	FallbackCcUrl Parameter `yaml:"fallbackCcUrl,omitempty"`
+
+	// The client the fallback Beacon node runs
+	FallbackCcClient Parameter `yaml:"fallbackCcClient,omitempty"`
+
 	///////////////////////////
 	// Non-editable settings //
 	///////////////////////////
 
-	// The URL to provide the user so they can follow pending transactions
-	txWatchUrl map[Network]string `yaml:"-"`
+	// The manifests with the constants for each network, such as the chain ID and contract addresses
+	networkManifests map[Network]*NetworkManifest `yaml:"-"`
 
-	// The URL to use for staking rETH
-	stakeUrl map[Network]string `yaml:"-"`
-
-	// The map of networks to execution chain IDs
-	chainID map[Network]uint `yaml:"-"`
+	// The error encountered while loading the network manifests, if any
+	networkManifestError error `yaml:"-"`
 
 	// The path within the daemon Docker container of the wallet file
 	walletPath string `yaml:"-"`
@@ -74,32 +277,94 @@ type SmartnodeConfig struct {
 	// The path within the daemon Docker container of the validator key folder
 	validatorKeychainPath string `yaml:"-"`
 
+	// The path within the daemon Docker container of the folder with the validator keys of each additional Validator client shard
+	validatorShardsPath string `yaml:"-"`
+
+	// The path within the daemon Docker container of the file that records which shard each validator key belongs to
+	validatorShardAssignmentsPath string `yaml:"-"`
+
+	// The path within the daemon Docker container of the slashing protection file to import
+	slashingProtectionImportPath string `yaml:"-"`
+
+	// The path within the daemon Docker container of the queue of transactions waiting to be signed by a hardware wallet
+	signingQueuePath string `yaml:"-"`
+
+	// The path within the daemon Docker container of the file that tracks the node account's signed transactions
+	nonceTrackerPath string `yaml:"-"`
+
+	// The path within the daemon Docker container of the validator index cache
+	validatorIndexCachePath string `yaml:"-"`
+
+	// The path within the daemon Docker container of the queue of automatic transactions that are being retried
+	txQueuePath string `yaml:"-"`
+
+	// The path of the file with the signed exits the node daemon is waiting to broadcast
+	exitQueuePath string `yaml:"-"`
+
+	// The path of the file with the transactions the CLI sent to the private relay, which the node daemon watches until they're included
+	privateRelayQueuePath string `yaml:"-"`
+
+	// The path of the file the slashing safe mode keeps its Validator client pause in, so it survives restarts
+	slashingSafeModePath string `yaml:"-"`
+
+	// The path of the file the Validator client's last logs are saved to when it's stopped for crash-looping
+	validatorCrashLogPath string `yaml:"-"`
+
+	// The path of the folder the daemons write crash reports to when one of their loops panics
+	crashReportPath string `yaml:"-"`
+
+	// The path of the log of emergency Oracle DAO actions the node has taken
+	emergencyAuditLogPath string `yaml:"-"`
+
 	// The path that custom validator keys will be stored (ones for minipools that aren't derived from the node wallet)
 	customKeyRecoverPath string `yaml:"-"`
 
 	// The path of the password file for custom validator keys
 	customKeyPasswordFilePath string `yaml:"-"`
 
-	// The contract address of RocketStorage
-	storageAddress map[Network]string `yaml:"-"`
+	// The path within the daemon Docker container of the task journal folder
+	journalPath string `yaml:"-"`
 
-	// The contract address of the 1inch oracle
-	oneInchOracleAddress map[Network]string `yaml:"-"`
+	// The path within the daemon Docker container of the rewards tree folder
+	rewardsTreePath string `yaml:"-"`
 
-	// The contract address of the RPL token
-	rplTokenAddress map[Network]string `yaml:"-"`
+	// The path within the daemon Docker container of the folder that on-chain data that can't change is cached in
+	cachePath string `yaml:"-"`
 
-	// The contract address of the RPL faucet
-	rplFaucetAddress map[Network]string `yaml:"-"`
+	// The path within the daemon Docker container of the folder the chain state that rewards trees are generated from is cached in
+	rewardsSnapshotPath string `yaml:"-"`
 
-	// The contract address for Snapshot delegation
-	snapshotDelegationAddress map[Network]string `yaml:"-"`
+	// The path within the daemon Docker container of the folder the rewards tree worker writes its status files to
+	treegenStatusPath string `yaml:"-"`
+
+	// The path within the daemon Docker container of the file the rETH and RPL rate history is recorded in
+	rateHistoryPath string `yaml:"-"`
 }
 
 // Generates a new Smartnode configuration
 func NewSmartnodeConfig(config *RocketPoolConfig) *SmartnodeConfig {
 
-	return &SmartnodeConfig{
+	// Load the network manifests, falling back to the embedded ones if the overrides are broken
+	manifests, manifestErr := LoadNetworkManifests(filepath.Join(config.RocketPoolDirectory, NetworkManifestFolderName))
+	if manifestErr != nil {
+		var err error
+		manifests, err = LoadNetworkManifests("")
+		if err != nil {
+			panic(fmt.Sprintf("Error loading the embedded network manifests: %s", err.Error()))
+		}
+	}
+	manifestMap := map[Network]*NetworkManifest{}
+	networkOptions := []ParameterOption{}
+	for _, manifest := range manifests {
+		manifestMap[manifest.Network] = manifest
+		networkOptions = append(networkOptions, ParameterOption{
+			Name:        manifest.Name,
+			Description: manifest.Description,
+			Value:       manifest.Network,
+		})
+	}
+
+	snConfig := &SmartnodeConfig{
 		Title:  "Smartnode Settings",
 		parent: config,
 
@@ -115,6 +380,84 @@ func NewSmartnodeConfig(config *RocketPoolConfig) *SmartnodeConfig {
 			OverwriteOnUpgrade:   false,
 		},
 
+		DockerNetwork: Parameter{
+			ID:                   "dockerNetwork",
+			Name:                 "Docker Network",
+			Description:          "The name of an existing Docker network (such as a macvlan or custom bridge network) to put the Smartnode's containers on. It must be created with `docker network create` before the Smartnode is started.\n\nLeave this blank to have the Smartnode create and manage its own network.",
+			Type:                 ParameterType_String,
+			Default:              map[Network]interface{}{Network_All: ""},
+			AffectsContainers:    []ContainerID{ContainerID_Api, ContainerID_Node, ContainerID_Watchtower, ContainerID_Eth1, ContainerID_Eth2, ContainerID_Validator, ContainerID_Grafana, ContainerID_Prometheus, ContainerID_Exporter},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+			Advanced:             true,
+		},
+
+		DockerNetworkSubnet: Parameter{
+			ID:                   "dockerNetworkSubnet",
+			Name:                 "Docker Network Subnet",
+			Description:          "The IPv4 subnet of the Smartnode's own Docker network in CIDR notation, e.g. `172.30.0.0/16`. Set this if Docker's automatic choice conflicts with your LAN.\n\nLeave this blank to let Docker choose. This is ignored when an existing Docker Network is used.",
+			Type:                 ParameterType_String,
+			Default:              map[Network]interface{}{Network_All: ""},
+			AffectsContainers:    []ContainerID{ContainerID_Api, ContainerID_Node, ContainerID_Watchtower, ContainerID_Eth1, ContainerID_Eth2, ContainerID_Validator, ContainerID_Grafana, ContainerID_Prometheus, ContainerID_Exporter},
+			EnvironmentVariables: []string{"DOCKER_NETWORK_SUBNET"},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+			Advanced:             true,
+		},
+
+		EnableIpv6: Parameter{
+			ID:                   "enableIpv6",
+			Name:                 "Enable IPv6",
+			Description:          "Enable IPv6 on the Smartnode's own Docker network, so your clients can reach dual-stack peers. Docker's daemon must have IPv6 enabled as well.\n\nThis is ignored when an existing Docker Network is used.",
+			Type:                 ParameterType_Bool,
+			Default:              map[Network]interface{}{Network_All: false},
+			AffectsContainers:    []ContainerID{ContainerID_Api, ContainerID_Node, ContainerID_Watchtower, ContainerID_Eth1, ContainerID_Eth2, ContainerID_Validator, ContainerID_Grafana, ContainerID_Prometheus, ContainerID_Exporter},
+			EnvironmentVariables: []string{"DOCKER_NETWORK_IPV6"},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+			Advanced:             true,
+		},
+
+		DockerNetworkIpv6Subnet: Parameter{
+			ID:                   "dockerNetworkIpv6Subnet",
+			Name:                 "Docker Network IPv6 Subnet",
+			Description:          "The IPv6 subnet of the Smartnode's own Docker network in CIDR notation, e.g. `fd00:5250::/64`. Required when IPv6 is enabled.",
+			Type:                 ParameterType_String,
+			Default:              map[Network]interface{}{Network_All: ""},
+			AffectsContainers:    []ContainerID{ContainerID_Api, ContainerID_Node, ContainerID_Watchtower, ContainerID_Eth1, ContainerID_Eth2, ContainerID_Validator, ContainerID_Grafana, ContainerID_Prometheus, ContainerID_Exporter},
+			EnvironmentVariables: []string{"DOCKER_NETWORK_IPV6_SUBNET"},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+			Advanced:             true,
+		},
+
+		ContainerLogMaxSize: Parameter{
+			ID:                   "containerLogMaxSize",
+			Name:                 "Container Log Max Size",
+			Description:          "The size, in MiB, that each container's log file can grow to before Docker starts a new one. Docker keeps container logs forever by default, which can fill up your disk on a node that's been running for a long time.\n\nSet this to 0 to use Docker's own logging settings instead.",
+			Type:                 ParameterType_Uint,
+			Default:              map[Network]interface{}{Network_All: uint64(20)},
+			AffectsContainers:    []ContainerID{ContainerID_Api, ContainerID_Node, ContainerID_Watchtower, ContainerID_Eth1, ContainerID_Eth1Fallback, ContainerID_Eth2, ContainerID_Validator, ContainerID_Grafana, ContainerID_Prometheus, ContainerID_Exporter, ContainerID_Alertmanager},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+			Advanced:             true,
+		},
+
+		ContainerLogMaxFiles: Parameter{
+			ID:                   "containerLogMaxFiles",
+			Name:                 "Container Log Max Files",
+			Description:          "The number of log files Docker keeps for each container, including the one it's writing to. The oldest file is deleted when a new one is started, so each container's logs take up at most this many times the Container Log Max Size.",
+			Type:                 ParameterType_Uint,
+			Default:              map[Network]interface{}{Network_All: uint64(5)},
+			AffectsContainers:    []ContainerID{ContainerID_Api, ContainerID_Node, ContainerID_Watchtower, ContainerID_Eth1, ContainerID_Eth1Fallback, ContainerID_Eth2, ContainerID_Validator, ContainerID_Grafana, ContainerID_Prometheus, ContainerID_Exporter, ContainerID_Alertmanager},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+			Advanced:             true,
+		},
+
 		DataPath: Parameter{
 			ID:                   "dataPath",
 			Name:                 "Data Path",
@@ -137,15 +480,7 @@ func NewSmartnodeConfig(config *RocketPoolConfig) *SmartnodeConfig {
 			EnvironmentVariables: []string{"NETWORK"},
 			CanBeBlank:           false,
 			OverwriteOnUpgrade:   false,
-			Options: []ParameterOption{{
-				Name:        "Ethereum Mainnet",
-				Description: "This is the real Ethereum main network, using real ETH and real RPL to make real validators.",
-				Value:       Network_Mainnet,
-			}, {
-				Name:        "Prater Testnet",
-				Description: "This is the Prater test network, using free fake ETH and free fake RPL to make fake validators.\nUse this if you want to practice running the Smartnode in a free, safe environment before moving to mainnet.",
-				Value:       Network_Prater,
-			}},
+			Options:              networkOptions,
 		},
 
 		ManualMaxFee: Parameter{
@@ -172,6 +507,48 @@ func NewSmartnodeConfig(config *RocketPoolConfig) *SmartnodeConfig {
 			OverwriteOnUpgrade:   false,
 		},
 
+		GasOracle: Parameter{
+			ID:                   "gasOracle",
+			Name:                 "Gas Oracle",
+			Description:          "Choose where the Smartnode gets its max fee suggestions from, both for the prompts in the CLI and for automated transactions. If it can't get suggestions from your choice, it will fall back to Etherscan.",
+			Type:                 ParameterType_Choice,
+			Default:              map[Network]interface{}{Network_All: GasOracle_Etherchain},
+			AffectsContainers:    []ContainerID{ContainerID_Api, ContainerID_Node, ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+			Options: []ParameterOption{{
+				Name:        "Etherchain",
+				Description: "Use the suggestions from Etherchain's gas price oracle.",
+				Value:       GasOracle_Etherchain,
+			}, {
+				Name:        "Etherscan",
+				Description: "Use the suggestions from Etherscan's gas tracker.",
+				Value:       GasOracle_Etherscan,
+			}, {
+				Name:        "Blocknative",
+				Description: "Use Blocknative's suggestions for the next block, which are based on its view of the mempool. This requires a Blocknative API key.",
+				Value:       GasOracle_Blocknative,
+			}, {
+				Name:        "Fee History",
+				Description: "Estimate the max fee from the base fees of recent blocks, using your own Execution client's `eth_feeHistory` data. This doesn't rely on any third-party services.",
+				Value:       GasOracle_FeeHistory,
+			}},
+		},
+
+		BlocknativeApiKey: Parameter{
+			ID:                   "blocknativeApiKey",
+			Name:                 "Blocknative API Key",
+			Description:          "Your API key for Blocknative's gas platform.",
+			Type:                 ParameterType_String,
+			Default:              map[Network]interface{}{Network_All: ""},
+			AffectsContainers:    []ContainerID{ContainerID_Api, ContainerID_Node, ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+			Sensitive:            true,
+		},
+
 		RplClaimGasThreshold: Parameter{
 			ID:                   "rplClaimGasThreshold",
 			Name:                 "RPL Claim Gas Threshold",
@@ -197,97 +574,1164 @@ func NewSmartnodeConfig(config *RocketPoolConfig) *SmartnodeConfig {
 			OverwriteOnUpgrade:   false,
 		},
 
-		txWatchUrl: map[Network]string{
-			Network_Mainnet: "https://etherscan.io/tx",
-			Network_Prater:  "https://goerli.etherscan.io/tx",
+		AutoClaimEnabled: Parameter{
+			ID:                   "autoClaimEnabled",
+			Name:                 "Auto-Claim Rewards",
+			Description:          "Enable this to have your node claim its RPL and smoothing pool rewards from every finished rewards interval automatically, once the gas price is below the RPL Claim Gas Threshold and the rewards are worth more than the gas.",
+			Type:                 ParameterType_Bool,
+			Default:              map[Network]interface{}{Network_All: false},
+			AffectsContainers:    []ContainerID{ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
 		},
 
-		stakeUrl: map[Network]string{
-			Network_Mainnet: "https://stake.rocketpool.net",
-			Network_Prater:  "https://testnet.rocketpool.net",
+		AutoRestakePercent: Parameter{
+			ID:                   "autoRestakePercent",
+			Name:                 "Auto-Restake Percent",
+			Description:          "The percentage (0 to 100) of the RPL your node claims automatically that is staked again in the same transaction. The rest is sent to your withdrawal address.",
+			Type:                 ParameterType_Float,
+			Default:              map[Network]interface{}{Network_All: float64(0)},
+			AffectsContainers:    []ContainerID{ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
 		},
 
-		chainID: map[Network]uint{
-			Network_Mainnet: 1, // Mainnet
-			Network_Prater:  5, // Goerli
+		ValidatorRestartLimit: Parameter{
+			ID:                   "validatorRestartLimit",
+			Name:                 "Validator Restart Limit",
+			Description:          "The number of times your Validator client can crash within half an hour before the node daemon stops restarting it. Validator clients with Doppelganger Protection miss several epochs of attestations every time they start, so a client that keeps crashing can cost you much more than one that's left stopped.\n\nWhen the limit is hit, the node daemon saves the client's last logs, sends a notification, and stops the client; run `rocketpool service start` once you've fixed the problem to start it again. Set this to 0 to let Docker keep restarting it.",
+			Type:                 ParameterType_Uint,
+			Default:              map[Network]interface{}{Network_All: uint64(5)},
+			AffectsContainers:    []ContainerID{ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
 		},
 
-		walletPath: "/.rocketpool/data/wallet",
+		ValidatorClientShards: Parameter{
+			ID:                   "validatorClientShards",
+			Name:                 "Validator Client Shards",
+			Description:          "The number of Validator clients to split your validator keys across. With more than one, a crash or a bad update only takes part of your validators offline at a time, which helps on nodes with a very large number of minipools.\n\nEach new key is assigned to the shard with the fewest keys, and stays there for good since each shard has its own slashing protection database. You can add shards at any time, but you can't remove a shard that still has keys in it. Use `rocketpool service validator-shards` to see which keys are in each shard.\n\nThis isn't available in Native mode, with Nimbus, or with a remote signer.",
+			Type:                 ParameterType_Uint,
+			Default:              map[Network]interface{}{Network_All: uint64(1)},
+			AffectsContainers:    []ContainerID{ContainerID_Validator},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+			Advanced:             true,
+		},
 
-		passwordPath: "/.rocketpool/data/password",
+		SlashingSafeMode: Parameter{
+			ID:                   "slashingSafeMode",
+			Name:                 "Slashing Safe Mode",
+			Description:          "What the node daemon does when it sees a surge of slashings on the network. Mass slashings are almost always caused by a bug in one of the clients, and validators running the same client can be slashed too if they keep performing their duties until it's fixed.\n\nThe Beacon Chain doesn't record which client a validator runs, so every slashing on the network counts towards the surge.",
+			Type:                 ParameterType_Choice,
+			Default:              map[Network]interface{}{Network_All: SlashingSafeMode_Alert},
+			AffectsContainers:    []ContainerID{ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+			Options: []ParameterOption{{
+				Name:        "Disabled",
+				Description: "Don't watch for slashings.",
+				Value:       SlashingSafeMode_Disabled,
+			}, {
+				Name:        "Alert",
+				Description: "Send a notification with instructions, but keep the Validator client running.",
+				Value:       SlashingSafeMode_Alert,
+			}, {
+				Name:        "Pause",
+				Description: "Send a notification and stop the Validator client for the Slashing Safe Mode Duration, so it doesn't propose or attest while the cause is found. Missing duties costs much less than being slashed. This is only supported for the Docker containers of clients with a separate validator process.",
+				Value:       SlashingSafeMode_Pause,
+			}},
+		},
 
-		validatorKeychainPath: "/.rocketpool/data/validators",
+		SlashingSafeModeThreshold: Parameter{
+			ID:                   "slashingSafeModeThreshold",
+			Name:                 "Slashing Safe Mode Threshold",
+			Description:          "The number of validators that have to be slashed within an hour for the node daemon to treat it as a surge.\n\nSlashed validators that can be identified (from the graffiti of the blocks they proposed) as running a different client than yours don't count towards it.",
+			Type:                 ParameterType_Uint,
+			Default:              map[Network]interface{}{Network_All: uint64(10)},
+			AffectsContainers:    []ContainerID{ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+			Advanced:             true,
+		},
 
-		customKeyRecoverPath: "/.rocketpool/data/custom-keys",
+		SlashingSafeModeDuration: Parameter{
+			ID:                   "slashingSafeModeDuration",
+			Name:                 "Slashing Safe Mode Duration",
+			Description:          "How long, in minutes, the Validator client is stopped for after a surge of slashings when Slashing Safe Mode is set to Pause. The node daemon starts it again afterwards, unless the surge is still going on.",
+			Type:                 ParameterType_Uint,
+			Default:              map[Network]interface{}{Network_All: uint64(120)},
+			AffectsContainers:    []ContainerID{ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+			Advanced:             true,
+		},
 
-		customKeyPasswordFilePath: "/.rocketpool/data/custom-key-passwords",
+		TimeSyncMode: Parameter{
+			ID:                   "timeSyncMode",
+			Name:                 "Time Sync",
+			Description:          "Your validators need an accurate clock to attest on time; a clock that's even a second or two off can cost you attestations and block proposals. Most operating systems keep the clock in sync out of the box, but the time sync service is sometimes missing or misconfigured.\n\nChoose how the Smartnode helps keep your clock accurate.",
+			Type:                 ParameterType_Choice,
+			Default:              map[Network]interface{}{Network_All: TimeSyncMode_Check},
+			AffectsContainers:    []ContainerID{ContainerID_Node, ContainerID_Chrony},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+			Options: []ParameterOption{{
+				Name:        "Disabled",
+				Description: "Don't check the system clock.",
+				Value:       TimeSyncMode_Disabled,
+			}, {
+				Name:        "Check Host Clock",
+				Description: "Periodically compare the system clock with the NTP servers, and send a notification if it drifts past the Max Clock Offset. Use this if your operating system's time sync service (such as systemd-timesyncd, chrony, or ntpd) is already set up.",
+				Value:       TimeSyncMode_Check,
+			}, {
+				Name:        "Chrony Container",
+				Description: "Run Chrony in a container that keeps the system clock synchronized with the NTP servers, and check the clock as well. Disable your operating system's own time sync service first so the two don't fight over the clock. This isn't supported in Native Mode.",
+				Value:       TimeSyncMode_Chrony,
+			}},
+		},
 
-		storageAddress: map[Network]string{
-			Network_Mainnet: "0x1d8f8f00cfa6758d7bE78336684788Fb0ee0Fa46",
-			Network_Prater:  "0xd8Cd47263414aFEca62d6e2a3917d6600abDceB3",
+		NtpServers: Parameter{
+			ID:                   "ntpServers",
+			Name:                 "NTP Servers",
+			Description:          "A comma-separated list of the NTP servers the system clock is checked against and, if the Chrony container is enabled, synchronized with.",
+			Type:                 ParameterType_String,
+			Default:              map[Network]interface{}{Network_All: "pool.ntp.org"},
+			AffectsContainers:    []ContainerID{ContainerID_Node, ContainerID_Chrony},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+			Advanced:             true,
 		},
 
-		oneInchOracleAddress: map[Network]string{
-			Network_Mainnet: "0x07D91f5fb9Bf7798734C3f606dB065549F6893bb",
-			Network_Prater:  "0x4eDC966Df24264C9C817295a0753804EcC46Dd22",
+		MaxClockOffset: Parameter{
+			ID:                   "maxClockOffset",
+			Name:                 "Max Clock Offset",
+			Description:          "How far, in milliseconds, the system clock can be from NTP time before the node daemon sends a notification.",
+			Type:                 ParameterType_Uint,
+			Default:              map[Network]interface{}{Network_All: uint64(500)},
+			AffectsContainers:    []ContainerID{ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+			Advanced:             true,
 		},
 
-		rplTokenAddress: map[Network]string{
-			Network_Mainnet: "0xb4efd85c19999d84251304bda99e90b92300bd93",
-			Network_Prater:  "0xb4efd85c19999d84251304bda99e90b92300bd93",
+		ChronyContainerTag: Parameter{
+			ID:                   "chronyContainerTag",
+			Name:                 "Chrony Container Tag",
+			Description:          "The tag name of the Alpine image on Docker Hub that the Chrony container installs Chrony into.",
+			Type:                 ParameterType_String,
+			Default:              map[Network]interface{}{Network_All: chronyTag},
+			AffectsContainers:    []ContainerID{ContainerID_Chrony},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   true,
+			Advanced:             true,
 		},
 
-		rplFaucetAddress: map[Network]string{
-			Network_Mainnet: "",
-			Network_Prater:  "0x95D6b8E2106E3B30a72fC87e2B56ce15E37853F9",
+		EcLowDiskThreshold: Parameter{
+			ID:                   "ecLowDiskThreshold",
+			Name:                 "EC Low Disk Space Threshold",
+			Description:          "The node daemon watches the free space on the disk holding your Execution client's chain data, and steps in when it drops below this many GiB. It sends a notification, or prunes Geth if EC Auto-Prune is enabled.\n\nSet this to 0 to disable it. This only applies to an Execution client managed by the Smartnode.",
+			Type:                 ParameterType_Uint,
+			Default:              map[Network]interface{}{Network_All: uint64(100)},
+			AffectsContainers:    []ContainerID{ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+			Advanced:             true,
 		},
 
-		snapshotDelegationAddress: map[Network]string{
-			Network_Mainnet: "0x469788fE6E9E9681C6ebF3bF78e7Fd26Fc015446",
-			Network_Prater:  "0xD0897D68Cd66A710dDCecDe30F7557972181BEDc",
+		EcAutoPrune: Parameter{
+			ID:                   "ecAutoPrune",
+			Name:                 "EC Auto-Prune",
+			Description:          "Enable this to have the node daemon run the `rocketpool service prune-eth1` flow automatically when Geth's disk space drops below the EC Low Disk Space Threshold.\n\nGeth is offline while it prunes, so this is only done when you have a fallback Execution client configured and there's still enough free space to prune (50 GiB).",
+			Type:                 ParameterType_Bool,
+			Default:              map[Network]interface{}{Network_All: false},
+			AffectsContainers:    []ContainerID{ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+			Advanced:             true,
 		},
-	}
 
-}
+		EcSnapshotUrls: Parameter{
+			ID:                   "ecSnapshotUrls",
+			Name:                 "EC Snapshot URLs",
+			Description:          "A comma-separated list of trusted Execution client database snapshots that `rocketpool service sync-from-snapshot` can download instead of syncing from scratch, in the format `client=url`, e.g. `geth=https://example.com/geth-mainnet.tar.zst`. Geth, Erigon and Nethermind are supported.\n\nEach snapshot must be a tar archive (optionally compressed with zstd, lz4 or gzip) laid out like the client's chain data folder, with its SHA-256 checksum at the same URL with `.sha256` on the end.\n\nOnly use snapshots from sources you trust.",
+			Type:                 ParameterType_String,
+			Default:              map[Network]interface{}{Network_Mainnet: "", Network_Prater: ""},
+			AffectsContainers:    []ContainerID{},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+			Advanced:             true,
+		},
 
-// Get the parameters for this config
-func (config *SmartnodeConfig) GetParameters() []*Parameter {
-	return []*Parameter{
-		&config.Network,
-		&config.ProjectName,
-		&config.DataPath,
-		&config.ManualMaxFee,
-		&config.PriorityFee,
-		&config.RplClaimGasThreshold,
-		&config.MinipoolStakeGasThreshold,
-	}
-}
+		ReadEcUrl: Parameter{
+			ID:                   "readEcUrl",
+			Name:                 "Read Execution Client URL",
+			Description:          "The URL of an Execution client HTTP endpoint to send the Smartnode's heavy read workloads to, such as scanning for contract events and building rewards trees. Transactions and the calls the Smartnode relies on for its duties stay on your primary Execution client.\n\nThe Smartnode checks that the endpoint is on the same chain and supports event queries before using it, and goes back to the primary client if it doesn't. Leave this blank to send everything to your primary client.",
+			Type:                 ParameterType_String,
+			Default:              map[Network]interface{}{Network_All: ""},
+			AffectsContainers:    []ContainerID{ContainerID_Api, ContainerID_Node, ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+			Advanced:             true,
+			Sensitive:            true,
+		},
 
-// Getters for the non-editable parameters
+		FallbackReadEcUrl: Parameter{
+			ID:                   "fallbackReadEcUrl",
+			Name:                 "Fallback Read Execution Client URL",
+			Description:          "The URL of a second endpoint for heavy read workloads, used when the Read Execution Client URL is unavailable. If both are unavailable, the reads go to your primary Execution client.",
+			Type:                 ParameterType_String,
+			Default:              map[Network]interface{}{Network_All: ""},
+			AffectsContainers:    []ContainerID{ContainerID_Api, ContainerID_Node, ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+			Advanced:             true,
+			Sensitive:            true,
+		},
 
-func (config *SmartnodeConfig) GetTxWatchUrl() string {
-	return config.txWatchUrl[config.Network.Value.(Network)]
-}
+		ExtraEcUrls: Parameter{
+			ID:                   "extraEcUrls",
+			Name:                 "Extra Execution Client URLs",
+			Description:          "A comma-separated list of extra Execution client HTTP endpoints for the Smartnode to use when your primary and fallback clients are unhealthy, such as an RPC provider you trust.\n\nThe Smartnode scores every endpoint on whether it's synced, how far behind the chain head it is, how quickly it responds, and how often it's failed recently, and sends each request to the best one. Your primary client is preferred, then your fallback client, then these.",
+			Type:                 ParameterType_String,
+			Default:              map[Network]interface{}{Network_All: ""},
+			AffectsContainers:    []ContainerID{ContainerID_Api, ContainerID_Node, ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+			Advanced:             true,
+			Sensitive:            true,
+		},
 
-func (config *SmartnodeConfig) GetStakeUrl() string {
-	return config.stakeUrl[config.Network.Value.(Network)]
-}
+		UsePrivateRelay: Parameter{
+			ID:                   "usePrivateRelay",
+			Name:                 "Use Private Relay",
+			Description:          "Enable this to submit all of the Smartnode's transactions (including automated ones) through a private relay such as Flashbots Protect instead of broadcasting them to the public mempool, which protects them from front-running.\n\nIf a transaction isn't included before the Private Relay Timeout, it will be sent to the public mempool instead.",
+			Type:                 ParameterType_Bool,
+			Default:              map[Network]interface{}{Network_All: false},
+			AffectsContainers:    []ContainerID{ContainerID_Api, ContainerID_Node, ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
 
-func (config *SmartnodeConfig) GetChainID() uint {
-	return config.chainID[config.Network.Value.(Network)]
-}
+		PrivateRelayUrl: Parameter{
+			ID:                   "privateRelayUrl",
+			Name:                 "Private Relay URL",
+			Description:          "The URL of the private relay's RPC endpoint that transactions will be submitted to.",
+			Type:                 ParameterType_String,
+			Default:              map[Network]interface{}{Network_Mainnet: "https://rpc.flashbots.net", Network_Prater: "https://rpc-goerli.flashbots.net"},
+			AffectsContainers:    []ContainerID{ContainerID_Api, ContainerID_Node, ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+			Sensitive:            true,
+		},
 
-func (config *SmartnodeConfig) GetWalletPath() string {
-	if config.parent.IsNativeMode {
-		return filepath.Join(config.DataPath.Value.(string), "wallet")
-	} else {
-		return config.walletPath
-	}
-}
+		PrivateRelayTimeout: Parameter{
+			ID:                   "privateRelayTimeout",
+			Name:                 "Private Relay Timeout",
+			Description:          "The number of seconds to wait for a transaction sent to the private relay to be included in a block. If it hasn't been included by then, it will be sent to the public mempool.\n\nTransactions sent from the CLI return as soon as the relay accepts them, and the node daemon sends them to the public mempool if they time out.",
+			Type:                 ParameterType_Uint,
+			Default:              map[Network]interface{}{Network_All: uint64(180)},
+			AffectsContainers:    []ContainerID{ContainerID_Api, ContainerID_Node, ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		UseTxSponsor: Parameter{
+			ID:                   "useTxSponsor",
+			Name:                 "Use Transaction Sponsor",
+			Description:          "Enable this to have a sponsor (relayer) cover the gas cost of critical automated transactions, such as staking a minipool before it gets dissolved, when your node wallet doesn't have enough ETH to pay for them.\n\nOnly transactions that call specific Rocket Pool methods and don't send any ETH will ever be given to the sponsor.",
+			Type:                 ParameterType_Bool,
+			Default:              map[Network]interface{}{Network_All: false},
+			AffectsContainers:    []ContainerID{ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		TxSponsorUrl: Parameter{
+			ID:                   "txSponsorUrl",
+			Name:                 "Transaction Sponsor URL",
+			Description:          "The URL of the transaction sponsor's endpoint. The sponsor will receive the signed transaction along with the amount of ETH required to pay for its gas, fund your node wallet, and then broadcast it.",
+			Type:                 ParameterType_String,
+			Default:              map[Network]interface{}{Network_All: ""},
+			AffectsContainers:    []ContainerID{ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+			Sensitive:            true,
+		},
+
+		EncryptSensitiveSettings: Parameter{
+			ID:                   "encryptSensitiveSettings",
+			Name:                 "Encrypt Sensitive Settings",
+			Description:          "Enable this to encrypt sensitive settings, such as API keys and URLs of external clients that may contain credentials, in your settings file. The Smartnode's services will decrypt them when they start.",
+			Type:                 ParameterType_Bool,
+			Default:              map[Network]interface{}{Network_All: false},
+			AffectsContainers:    []ContainerID{ContainerID_Api, ContainerID_Node, ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		SettingsKeySource: Parameter{
+			ID:                   "settingsKeySource",
+			Name:                 "Encryption Key Source",
+			Description:          "Choose what the key used to encrypt your sensitive settings is derived from.",
+			Type:                 ParameterType_Choice,
+			Default:              map[Network]interface{}{Network_All: SettingsKeySource_WalletPassword},
+			AffectsContainers:    []ContainerID{ContainerID_Api, ContainerID_Node, ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+			Options: []ParameterOption{{
+				Name:        "Wallet Password",
+				Description: "Derive the key from your node wallet's password. The password file must be readable by the user running `rocketpool service config`.",
+				Value:       SettingsKeySource_WalletPassword,
+			}, {
+				Name:        "Separate Passphrase",
+				Description: fmt.Sprintf("Derive the key from a passphrase stored in the `%s` file inside your data folder. Create this file before saving your settings.", settingsPassphraseFile),
+				Value:       SettingsKeySource_Passphrase,
+			}},
+		},
+
+		ReorgPauseDepth: Parameter{
+			ID:                   "reorgPauseDepth",
+			Name:                 "Reorg Pause Depth",
+			Description:          "The depth of a Beacon Chain reorg, in slots, that will make the node and watchtower daemons pause tasks that depend on finality, such as claiming rewards and scrub checks, until the chain has finalized past the reorg.\n\nSet this to 0 to ignore reorgs.",
+			Type:                 ParameterType_Uint,
+			Default:              map[Network]interface{}{Network_All: uint64(3)},
+			AffectsContainers:    []ContainerID{ContainerID_Node, ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+			Advanced:             true,
+		},
+
+		FinalityStallEpochs: Parameter{
+			ID:                   "finalityStallEpochs",
+			Name:                 "Finality Stall Epochs",
+			Description:          "The number of epochs that the Beacon Chain's finalized epoch can fall behind its current epoch before the node and watchtower daemons pause tasks that depend on finality. Finality normally lags by 2 epochs.\n\nSet this to 0 to ignore finality stalls.",
+			Type:                 ParameterType_Uint,
+			Default:              map[Network]interface{}{Network_All: uint64(4)},
+			AffectsContainers:    []ContainerID{ContainerID_Node, ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+			Advanced:             true,
+		},
+
+		MaxUnfinalizedReportEpochs: Parameter{
+			ID:                   "maxUnfinalizedReportEpochs",
+			Name:                 "Max Unfinalized Report Epochs",
+			Description:          "The number of epochs that the block an Oracle DAO submission (such as the network balances or RPL price) is based on can be ahead of the Beacon Chain's finalized epoch. The watchtower will refuse to submit data derived from state that is further ahead of finality than this, since it could still be reorged.\n\nSet this to 0 to only submit data from finalized epochs.",
+			Type:                 ParameterType_Uint,
+			Default:              map[Network]interface{}{Network_All: uint64(3)},
+			AffectsContainers:    []ContainerID{ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+			Advanced:             true,
+		},
+
+		KeymanagerApiPort: Parameter{
+			ID:                   "keymanagerApiPort",
+			Name:                 "Keymanager API Port",
+			Description:          "The port your Validator Client should serve its Keymanager API on. The Smartnode uses this to import and export your validators' slashing protection history when you switch clients. It is only exposed to the Smartnode's containers.",
+			Type:                 ParameterType_Uint16,
+			Default:              map[Network]interface{}{Network_All: defaultKeymanagerApiPort},
+			AffectsContainers:    []ContainerID{ContainerID_Api, ContainerID_Validator},
+			EnvironmentVariables: []string{"VC_KEYMANAGER_API_PORT"},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+			Advanced:             true,
+		},
+
+		HotKeyLoading: Parameter{
+			ID:                   "hotKeyLoading",
+			Name:                 "Load Keys Without Restarting",
+			Description:          "Load the validator keys for newly staked minipools into your Validator Client through its Keymanager API, instead of restarting it. Validator Clients with Doppelganger Protection miss several attestations every time they restart, so this avoids them.\n\nThis is only supported by Lighthouse and Teku, and not when your validator keys are split across several Validator Clients. If a key can't be loaded, the Smartnode restarts the Validator Client instead.",
+			Type:                 ParameterType_Bool,
+			Default:              map[Network]interface{}{Network_All: false},
+			AffectsContainers:    []ContainerID{ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+			Advanced:             true,
+		},
+
+		EnableRestApi: Parameter{
+			ID:                   "enableRestApi",
+			Name:                 "Enable REST API",
+			Description:          "Enable this to have the api container serve the same routes the `rocketpool` CLI uses over HTTP, so dashboards and other tools can query your node's status, minipools, and rewards.\n\nRequests must include the REST API Auth Token. Only the routes that read your node's state are served unless you allow write routes, and routes that reveal or change your wallet's secrets are never available.",
+			Type:                 ParameterType_Bool,
+			Default:              map[Network]interface{}{Network_All: false},
+			AffectsContainers:    []ContainerID{ContainerID_Api},
+			EnvironmentVariables: []string{"ENABLE_REST_API"},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		RestApiPort: Parameter{
+			ID:                   "restApiPort",
+			Name:                 "REST API Port",
+			Description:          "The port the REST API should be served on.",
+			Type:                 ParameterType_Uint16,
+			Default:              map[Network]interface{}{Network_All: defaultRestApiPort},
+			AffectsContainers:    []ContainerID{ContainerID_Api},
+			EnvironmentVariables: []string{"REST_API_PORT"},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		RestApiAuthToken: Parameter{
+			ID:                   "restApiAuthToken",
+			Name:                 "REST API Auth Token",
+			Description:          "The token REST API clients must send with every request, in an `Authorization: Bearer <token>` header. Use a long, random value; if you allow write routes, anyone with it can send transactions from your node wallet.",
+			Type:                 ParameterType_String,
+			Default:              map[Network]interface{}{Network_All: ""},
+			AffectsContainers:    []ContainerID{ContainerID_Api},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+			Sensitive:            true,
+		},
+
+		RestApiAllowWrites: Parameter{
+			ID:                   "restApiAllowWrites",
+			Name:                 "Allow REST API Write Routes",
+			Description:          "By default, the REST API only serves the routes that read your node's status. Enable this to also serve the routes that send transactions from your node wallet or change the Smartnode's files. They must be called with POST.",
+			Type:                 ParameterType_Bool,
+			Default:              map[Network]interface{}{Network_All: false},
+			AffectsContainers:    []ContainerID{ContainerID_Api},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		RewardsTreeRetention: Parameter{
+			ID:                   "rewardsTreeRetention",
+			Name:                 "Rewards Tree Retention",
+			Description:          "The number of recent rewards intervals to keep the rewards tree files of. Older files are deleted once your node has claimed its rewards for them, since they're only needed to build claims.\n\nOracle DAO nodes always keep every file. Set this to 0 to keep every file.",
+			Type:                 ParameterType_Uint,
+			Default:              map[Network]interface{}{Network_All: uint64(0)},
+			AffectsContainers:    []ContainerID{ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+			Advanced:             true,
+		},
+
+		EnableEventStream: Parameter{
+			ID:                   "enableEventStream",
+			Name:                 "Enable Event Stream",
+			Description:          "Enable this to have the node and watchtower daemons stream their events, such as tasks starting and finishing, transactions being submitted, and errors, over gRPC. Monitoring tools can subscribe to the stream instead of scraping the daemons' logs.\n\nThe service is defined in `shared/services/events/events.proto` in the Smartnode repository.",
+			Type:                 ParameterType_Bool,
+			Default:              map[Network]interface{}{Network_All: false},
+			AffectsContainers:    []ContainerID{ContainerID_Node, ContainerID_Watchtower},
+			EnvironmentVariables: []string{"ENABLE_EVENT_STREAM"},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		EventStreamPort: Parameter{
+			ID:                   "eventStreamPort",
+			Name:                 "Event Stream Port",
+			Description:          "The port the node daemon should stream its events on. The watchtower streams its events on the next port up.",
+			Type:                 ParameterType_Uint16,
+			Default:              map[Network]interface{}{Network_All: defaultEventStreamPort},
+			AffectsContainers:    []ContainerID{ContainerID_Node, ContainerID_Watchtower},
+			EnvironmentVariables: []string{"EVENT_STREAM_PORT"},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		EnableHealthCheck: Parameter{
+			ID:                   "enableHealthCheck",
+			Name:                 "Enable Health Checks",
+			Description:          "Enable this to have the node, watchtower, and API daemons serve a `/healthz` endpoint reporting the sync status of your clients, whether the wallet is available, and when each task last ran.\n\nDocker uses it to mark the containers as unhealthy when they can't reach your clients or their tasks have stopped running, so orchestrators can restart them automatically.",
+			Type:                 ParameterType_Bool,
+			Default:              map[Network]interface{}{Network_All: true},
+			AffectsContainers:    []ContainerID{ContainerID_Api, ContainerID_Node, ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+			Advanced:             true,
+		},
+
+		HealthCheckPort: Parameter{
+			ID:                   "healthCheckPort",
+			Name:                 "Health Check Port",
+			Description:          "The port the node daemon should serve its health check on. The watchtower and API daemons serve theirs on the next two ports up.",
+			Type:                 ParameterType_Uint16,
+			Default:              map[Network]interface{}{Network_All: defaultHealthCheckPort},
+			AffectsContainers:    []ContainerID{ContainerID_Api, ContainerID_Node, ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+			Advanced:             true,
+		},
+
+		PerformanceThreshold: Parameter{
+			ID:                   "performanceThreshold",
+			Name:                 "Validator Performance Threshold",
+			Description:          "Validators that earn less than this percentage of the ideal attestation rewards over the performance window are flagged as underperforming in `rocketpool node performance`, and you'll be alerted about them if notifications are enabled.\n\nSet this to 0 to disable the alerts.",
+			Type:                 ParameterType_Float,
+			Default:              map[Network]interface{}{Network_All: float64(80)},
+			AffectsContainers:    []ContainerID{ContainerID_Api, ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		PerformanceWindow: Parameter{
+			ID:                   "performanceWindow",
+			Name:                 "Validator Performance Window",
+			Description:          "The number of epochs validator performance is measured over. The default of 1575 epochs is about one week; shorter windows react faster but are thrown off more by single missed attestations.",
+			Type:                 ParameterType_Uint,
+			Default:              map[Network]interface{}{Network_All: uint64(1575)},
+			AffectsContainers:    []ContainerID{ContainerID_Api, ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+			Advanced:             true,
+		},
+
+		DisplayTimezone: Parameter{
+			ID:                   "displayTimezone",
+			Name:                 "Display Timezone",
+			Description:          "The timezone the CLI displays times in, in the format 'Country/City' or 'UTC'. Leave it blank to use your machine's local timezone.\n\nYou can also show times in UTC for a single command with the `--utc` flag, e.g. to compare them with the daemon logs.",
+			Type:                 ParameterType_String,
+			Default:              map[Network]interface{}{Network_All: ""},
+			AffectsContainers:    []ContainerID{},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		ShowPendingItems: Parameter{
+			ID:                   "showPendingItems",
+			Name:                 "Show Pending Items",
+			Description:          "Enable this to have the CLI show a one-line summary of the things that need your attention before each command, such as unclaimed rewards, minipools that are ready to stake, settings changes that haven't been applied, and available updates.\n\nRun `rocketpool todo` to see the full list at any time.",
+			Type:                 ParameterType_Bool,
+			Default:              map[Network]interface{}{Network_All: false},
+			AffectsContainers:    []ContainerID{},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		IpfsGateways: Parameter{
+			ID:                   "ipfsGateways",
+			Name:                 "IPFS Gateways",
+			Description:          "A comma-separated list of the IPFS gateways to download rewards tree files from, tried in order. If none of them work, the files are downloaded from Rocket Pool's GitHub mirror and then from the Rewards Tree Mirror URL.",
+			Type:                 ParameterType_String,
+			Default:              map[Network]interface{}{Network_All: "https://ipfs.io,https://dweb.link,https://cloudflare-ipfs.com"},
+			AffectsContainers:    []ContainerID{ContainerID_Api, ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+			Advanced:             true,
+		},
+
+		RewardsTreeMirrorUrl: Parameter{
+			ID:                   "rewardsTreeMirrorUrl",
+			Name:                 "Rewards Tree Mirror URL",
+			Description:          "A custom URL (such as an S3 bucket or your own HTTP server) to download rewards tree files from if the IPFS gateways and the GitHub mirror can't be reached. Use `{network}`, `{index}`, and `{filename}` as placeholders, e.g. `https://example.com/rewards-trees/{network}/{filename}`.\n\nEvery downloaded file is checked against the interval's canonical Merkle root, so a mirror can't alter your rewards.",
+			Type:                 ParameterType_String,
+			Default:              map[Network]interface{}{Network_All: ""},
+			AffectsContainers:    []ContainerID{ContainerID_Api, ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+			Advanced:             true,
+		},
+
+		ArchiveCcUrl: Parameter{
+			ID:                   "archiveCcUrl",
+			Name:                 "Archive Beacon Node URL",
+			Description:          "The URL of the HTTP API of an archive Beacon node (one that keeps every historical state), e.g. `http://192.168.1.45:5052`.\n\nMost Beacon nodes prune old states, so they can't answer questions about the validators at epochs far in the past. When your Beacon node reports that it no longer has the state a query needs, the Smartnode will send it here instead. Leave this blank to skip those queries with an explanation instead.",
+			Type:                 ParameterType_String,
+			Default:              map[Network]interface{}{Network_All: ""},
+			AffectsContainers:    []ContainerID{ContainerID_Api, ContainerID_Node, ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+			Advanced:             true,
+		},
+
+		ArchiveEcUrl: Parameter{
+			ID:                   "archiveEcUrl",
+			Name:                 "Archive Execution Client URL",
+			Description:          "The URL of the HTTP API of an archive Execution client (one that keeps the state of every block), e.g. `http://192.168.1.45:8545`.\n\nFull Execution clients only keep the state of recent blocks, but rewards calculations and some watchtower checks need to read the contracts at older ones. Queries for blocks older than a full client keeps are sent here automatically, as are any your Execution clients report they no longer have. Leave this blank to send everything to your Execution clients; queries they can't serve will fail with an explanation.",
+			Type:                 ParameterType_String,
+			Default:              map[Network]interface{}{Network_All: ""},
+			AffectsContainers:    []ContainerID{ContainerID_Api, ContainerID_Node, ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+			Advanced:             true,
+			Sensitive:            true,
+		},
+
+		EventLogChunkSize: Parameter{
+			ID:                   "eventLogChunkSize",
+			Name:                 "Event Log Chunk Size",
+			Description:          "The number of blocks the Smartnode asks your Execution client for event logs over in a single request. Set this to 0 to use your client's default.\n\nIf a request is rejected because its response would be too large, the Smartnode halves the number of blocks and tries again, so you only need to change this if your provider has a much lower limit than usual.",
+			Type:                 ParameterType_Uint,
+			Default:              map[Network]interface{}{Network_All: uint64(0)},
+			AffectsContainers:    []ContainerID{ContainerID_Api, ContainerID_Node, ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+			Advanced:             true,
+		},
+
+		EventLogWorkers: Parameter{
+			ID:                   "eventLogWorkers",
+			Name:                 "Event Log Workers",
+			Description:          "The number of event log requests the Smartnode sends to your Execution client at the same time when it scans a long range of blocks. More makes scans over the whole history of the Rocket Pool contracts much faster, but puts more load on your client; rate-limited providers may need fewer.",
+			Type:                 ParameterType_Uint,
+			Default:              map[Network]interface{}{Network_All: uint64(defaultEventLogWorkers)},
+			AffectsContainers:    []ContainerID{ContainerID_Api, ContainerID_Node, ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+			Advanced:             true,
+		},
+
+		EnableTreegenWorker: Parameter{
+			ID:                   "enableTreegenWorker",
+			Name:                 "Generate Rewards Trees",
+			Description:          "Oracle DAO members can enable this to have the watchtower generate the rewards tree of each interval once it's been snapshotted, and save it if it matches the canonical Merkle root.\n\nGenerating a tree can take several GB of memory, so it runs in a short-lived worker container with its own memory limit instead of the watchtower itself. The watchtower needs access to Docker to start it.",
+			Type:                 ParameterType_Bool,
+			Default:              map[Network]interface{}{Network_All: false},
+			AffectsContainers:    []ContainerID{ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+			Advanced:             true,
+		},
+
+		TreegenMemoryLimit: Parameter{
+			ID:                   "treegenMemoryLimit",
+			Name:                 "Rewards Tree Worker Memory Limit",
+			Description:          "The most memory, in MiB, the worker container that generates rewards trees can use. If it runs out, Docker stops the worker instead of the watchtower, and the watchtower tries again later. Set this to 0 for no limit.",
+			Type:                 ParameterType_Uint,
+			Default:              map[Network]interface{}{Network_All: uint64(defaultTreegenMemoryLimit)},
+			AffectsContainers:    []ContainerID{ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+			Advanced:             true,
+		},
+
+		RethPriceSource: Parameter{
+			ID:                   "rethPriceSource",
+			Name:                 "rETH Price Source",
+			Description:          "rETH can trade above or below the protocol's exchange rate on secondary markets. The node daemon compares the two, shows them on the Grafana dashboard, and sends a notification when they drift apart by more than the rETH Max Deviation, which can help you time new deposits.\n\nChoose where the secondary-market rate comes from.",
+			Type:                 ParameterType_Choice,
+			Default:              map[Network]interface{}{Network_All: RethPriceSource_OneInch},
+			AffectsContainers:    []ContainerID{ContainerID_Node, ContainerID_Prometheus},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+			Options: []ParameterOption{{
+				Name:        "Disabled",
+				Description: "Don't track the secondary-market rate.",
+				Value:       RethPriceSource_Disabled,
+			}, {
+				Name:        "1inch Oracle",
+				Description: "Use the on-chain 1inch offchain oracle, which the Oracle DAO also uses for the RPL price. It's read through your own Execution client.",
+				Value:       RethPriceSource_OneInch,
+			}, {
+				Name:        "Custom URL",
+				Description: "Read the rate from an HTTP endpoint of your choice, such as your own price feed.",
+				Value:       RethPriceSource_Url,
+			}},
+		},
+
+		RethPriceUrl: Parameter{
+			ID:                   "rethPriceUrl",
+			Name:                 "rETH Price URL",
+			Description:          "The URL to read the secondary-market rETH rate from when the rETH Price Source is Custom URL. It must return the price of 1 rETH in ETH as a plain number, such as `1.0412`.",
+			Type:                 ParameterType_String,
+			Default:              map[Network]interface{}{Network_All: ""},
+			AffectsContainers:    []ContainerID{ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+			Advanced:             true,
+		},
+
+		RethMaxDeviation: Parameter{
+			ID:                   "rethMaxDeviation",
+			Name:                 "rETH Max Deviation",
+			Description:          "How far, as a percentage, the secondary-market rETH rate can be above or below the protocol rate before the node daemon sends a notification. Set this to 0 to only track the rates without any notifications.",
+			Type:                 ParameterType_Float,
+			Default:              map[Network]interface{}{Network_All: float64(2)},
+			AffectsContainers:    []ContainerID{ContainerID_Node, ContainerID_Prometheus},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+			Advanced:             true,
+		},
+
+		RateHistoryRetention: Parameter{
+			ID:                   "rateHistoryRetention",
+			Name:                 "Rate History Retention",
+			Description:          "The node daemon records the rETH exchange rate and the RPL price every hour, so dashboards can chart them with the `network rate-history` API route. This is how many days of history it keeps. Set this to 0 to stop recording it.",
+			Type:                 ParameterType_Uint,
+			Default:              map[Network]interface{}{Network_All: uint64(30)},
+			AffectsContainers:    []ContainerID{ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+			Advanced:             true,
+		},
+
+		FallbackCcUrl: Parameter{
+			ID:                   "fallbackCcUrl",
+			Name:                 "Fallback Beacon Node URL",
+			Description:          "The URL of the HTTP API of a fallback Beacon node, e.g. `http://192.168.1.45:5052`.\n\nThe Smartnode's daemons check which of your Beacon nodes are synced and send their requests to your main Beacon node whenever it's ready, switching to this one while it's offline or out of sync. This doesn't change which Beacon node your Validator client uses. Leave this blank if you don't have a fallback Beacon node.",
+			Type:                 ParameterType_String,
+			Default:              map[Network]interface{}{Network_All: ""},
+			AffectsContainers:    []ContainerID{ContainerID_Api, ContainerID_Node, ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+			Advanced:             true,
+		},
+
+		FallbackCcClient: Parameter{
+			ID:                   "fallbackCcClient",
+			Name:                 "Fallback Beacon Node Client",
+			Description:          "Select which Consensus client your fallback Beacon node runs.",
+			Type:                 ParameterType_Choice,
+			Default:              map[Network]interface{}{Network_All: ConsensusClient_Lighthouse},
+			AffectsContainers:    []ContainerID{ContainerID_Api, ContainerID_Node, ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+			Advanced:             true,
+			Options: []ParameterOption{{
+				Name:        "Lighthouse",
+				Description: "Select this if your fallback Beacon node runs Lighthouse.",
+				Value:       ConsensusClient_Lighthouse,
+			}, {
+				Name:        "Nimbus",
+				Description: "Select this if your fallback Beacon node runs Nimbus.",
+				Value:       ConsensusClient_Nimbus,
+			}, {
+				Name:        "Prysm",
+				Description: "Select this if your fallback Beacon node runs Prysm.",
+				Value:       ConsensusClient_Prysm,
+			}, {
+				Name:        "Teku",
+				Description: "Select this if your fallback Beacon node runs Teku.",
+				Value:       ConsensusClient_Teku,
+			}},
+		},
+
+		walletPath: "/.rocketpool/data/wallet",
+
+		passwordPath: "/.rocketpool/data/password",
+
+		validatorKeychainPath: "/.rocketpool/data/validators",
+
+		validatorShardsPath: "/.rocketpool/data/validator-shards",
+
+		validatorShardAssignmentsPath: "/.rocketpool/data/validator-shards/assignments.json",
+
+		slashingProtectionImportPath: "/.rocketpool/data/slashing-protection-import.json",
+
+		signingQueuePath: "/.rocketpool/data/signing-queue",
+
+		nonceTrackerPath: "/.rocketpool/data/sent-transactions.json",
+
+		validatorIndexCachePath: "/.rocketpool/data/validator-indices.json",
+
+		txQueuePath: "/.rocketpool/data/tx-queue.json",
+
+		exitQueuePath:         "/.rocketpool/data/exit-queue.json",
+		privateRelayQueuePath: "/.rocketpool/data/private-relay.json",
+		slashingSafeModePath:  "/.rocketpool/data/slashing-safe-mode.json",
+
+		validatorCrashLogPath: "/.rocketpool/data/validator-crash.log",
+
+		crashReportPath: "/.rocketpool/data/crash-reports",
+
+		emergencyAuditLogPath: "/.rocketpool/data/emergency-audit.log",
+
+		customKeyRecoverPath: "/.rocketpool/data/custom-keys",
+
+		customKeyPasswordFilePath: "/.rocketpool/data/custom-key-passwords",
+
+		journalPath: "/.rocketpool/data/journal",
+
+		rewardsTreePath: "/.rocketpool/data/rewards-trees",
+
+		cachePath: "/.rocketpool/data/cache",
+
+		rewardsSnapshotPath: "/.rocketpool/data/rewards-snapshots",
+
+		treegenStatusPath: "/.rocketpool/data/treegen",
+
+		rateHistoryPath: "/.rocketpool/data/rate-history.json",
+
+		networkManifests: manifestMap,
+
+		networkManifestError: manifestErr,
+	}
+
+	// The relay and sponsor settings are only used when they're enabled
+	snConfig.BlocknativeApiKey.AddDependency(&snConfig.GasOracle, GasOracle_Blocknative)
+	snConfig.PrivateRelayUrl.AddDependency(&snConfig.UsePrivateRelay, true)
+	snConfig.PrivateRelayTimeout.AddDependency(&snConfig.UsePrivateRelay, true)
+	snConfig.TxSponsorUrl.AddDependency(&snConfig.UseTxSponsor, true)
+	snConfig.SettingsKeySource.AddDependency(&snConfig.EncryptSensitiveSettings, true)
+	snConfig.RestApiPort.AddDependency(&snConfig.EnableRestApi, true)
+	snConfig.RestApiAuthToken.AddDependency(&snConfig.EnableRestApi, true)
+	snConfig.RestApiAllowWrites.AddDependency(&snConfig.EnableRestApi, true)
+	snConfig.EventStreamPort.AddDependency(&snConfig.EnableEventStream, true)
+
+	return snConfig
+
+}
+
+// Get the parameters for this config
+func (config *SmartnodeConfig) GetParameters() []*Parameter {
+	return []*Parameter{
+		&config.Network,
+		&config.ProjectName,
+		&config.DataPath,
+		&config.DockerNetwork,
+		&config.DockerNetworkSubnet,
+		&config.EnableIpv6,
+		&config.DockerNetworkIpv6Subnet,
+		&config.ContainerLogMaxSize,
+		&config.ContainerLogMaxFiles,
+		&config.ManualMaxFee,
+		&config.PriorityFee,
+		&config.GasOracle,
+		&config.BlocknativeApiKey,
+		&config.RplClaimGasThreshold,
+		&config.MinipoolStakeGasThreshold,
+		&config.AutoClaimEnabled,
+		&config.AutoRestakePercent,
+		&config.ValidatorRestartLimit,
+		&config.ValidatorClientShards,
+		&config.SlashingSafeMode,
+		&config.SlashingSafeModeThreshold,
+		&config.SlashingSafeModeDuration,
+		&config.TimeSyncMode,
+		&config.NtpServers,
+		&config.MaxClockOffset,
+		&config.ChronyContainerTag,
+		&config.EcLowDiskThreshold,
+		&config.EcAutoPrune,
+		&config.EcSnapshotUrls,
+		&config.ReadEcUrl,
+		&config.FallbackReadEcUrl,
+		&config.ExtraEcUrls,
+		&config.UsePrivateRelay,
+		&config.PrivateRelayUrl,
+		&config.PrivateRelayTimeout,
+		&config.UseTxSponsor,
+		&config.TxSponsorUrl,
+		&config.EncryptSensitiveSettings,
+		&config.SettingsKeySource,
+		&config.ReorgPauseDepth,
+		&config.FinalityStallEpochs,
+		&config.MaxUnfinalizedReportEpochs,
+		&config.KeymanagerApiPort,
+		&config.HotKeyLoading,
+		&config.EnableRestApi,
+		&config.RestApiPort,
+		&config.RestApiAuthToken,
+		&config.RestApiAllowWrites,
+		&config.RewardsTreeRetention,
+		&config.EnableEventStream,
+		&config.EventStreamPort,
+		&config.EnableHealthCheck,
+		&config.HealthCheckPort,
+		&config.PerformanceThreshold,
+		&config.PerformanceWindow,
+		&config.DisplayTimezone,
+		&config.ShowPendingItems,
+		&config.IpfsGateways,
+		&config.RewardsTreeMirrorUrl,
+		&config.ArchiveCcUrl,
+		&config.ArchiveEcUrl,
+		&config.EventLogChunkSize,
+		&config.EventLogWorkers,
+		&config.EnableTreegenWorker,
+		&config.TreegenMemoryLimit,
+		&config.RethPriceSource,
+		&config.RethPriceUrl,
+		&config.RethMaxDeviation,
+		&config.RateHistoryRetention,
+		&config.FallbackCcUrl,
+		&config.FallbackCcClient,
+	}
+}
+
+// Getters for the editable parameters, which fall back to their defaults if a settings file contains an invalid value
+
+func (config *SmartnodeConfig) GetNetwork() Network {
+	network, ok := config.Network.Value.(Network)
+	if !ok {
+		return Network_Unknown
+	}
+	return network
+}
+
+func (config *SmartnodeConfig) GetProjectName() string {
+	return config.ProjectName.GetStringOrDefault(config.GetNetwork())
+}
+
+func (config *SmartnodeConfig) GetDataPath() string {
+	return config.DataPath.GetStringOrDefault(config.GetNetwork())
+}
+
+func (config *SmartnodeConfig) GetManualMaxFee() float64 {
+	return config.ManualMaxFee.GetFloatOrDefault(config.GetNetwork())
+}
+
+func (config *SmartnodeConfig) GetPriorityFee() float64 {
+	return config.PriorityFee.GetFloatOrDefault(config.GetNetwork())
+}
+
+func (config *SmartnodeConfig) GetGasOracle() GasOracle {
+	oracle, ok := config.GasOracle.Value.(GasOracle)
+	if !ok {
+		return GasOracle_Etherchain
+	}
+	return oracle
+}
+
+func (config *SmartnodeConfig) GetBlocknativeApiKey() string {
+	return config.BlocknativeApiKey.GetStringOrDefault(config.GetNetwork())
+}
+
+func (config *SmartnodeConfig) GetRplClaimGasThreshold() float64 {
+	return config.RplClaimGasThreshold.GetFloatOrDefault(config.GetNetwork())
+}
+
+func (config *SmartnodeConfig) GetMinipoolStakeGasThreshold() float64 {
+	return config.MinipoolStakeGasThreshold.GetFloatOrDefault(config.GetNetwork())
+}
+
+func (config *SmartnodeConfig) GetAutoClaimEnabled() bool {
+	return config.AutoClaimEnabled.GetBoolOrDefault(config.GetNetwork())
+}
+
+func (config *SmartnodeConfig) GetAutoRestakePercent() float64 {
+	percent := config.AutoRestakePercent.GetFloatOrDefault(config.GetNetwork())
+	if percent < 0 {
+		return 0
+	}
+	if percent > 100 {
+		return 100
+	}
+	return percent
+}
+
+// Get the URLs of the endpoints heavy reads are sent to, in the order they should be tried
+func (config *SmartnodeConfig) GetReadEcUrls() []string {
+	urls := []string{}
+	for _, param := range []*Parameter{&config.ReadEcUrl, &config.FallbackReadEcUrl} {
+		url := strings.TrimSpace(param.GetStringOrDefault(config.GetNetwork()))
+		if url != "" {
+			urls = append(urls, url)
+		}
+	}
+	return urls
+}
+
+func (config *SmartnodeConfig) GetExtraEcUrls() []string {
+	urls := []string{}
+	for _, url := range strings.Split(config.ExtraEcUrls.GetStringOrDefault(config.GetNetwork()), ",") {
+		url = strings.TrimSpace(url)
+		if url != "" {
+			urls = append(urls, url)
+		}
+	}
+	return urls
+}
+
+func (config *SmartnodeConfig) GetPrivateRelayUrl() string {
+	return config.PrivateRelayUrl.GetStringOrDefault(config.GetNetwork())
+}
+
+func (config *SmartnodeConfig) GetPrivateRelayTimeout() uint64 {
+	return config.PrivateRelayTimeout.GetUintOrDefault(config.GetNetwork())
+}
+
+func (config *SmartnodeConfig) GetReorgPauseDepth() uint64 {
+	return config.ReorgPauseDepth.GetUintOrDefault(config.GetNetwork())
+}
+
+func (config *SmartnodeConfig) GetFinalityStallEpochs() uint64 {
+	return config.FinalityStallEpochs.GetUintOrDefault(config.GetNetwork())
+}
+
+func (config *SmartnodeConfig) GetKeymanagerApiPort() uint16 {
+	return config.KeymanagerApiPort.GetUint16OrDefault(config.GetNetwork())
+}
+
+func (config *SmartnodeConfig) GetHotKeyLoading() bool {
+	return config.HotKeyLoading.GetBoolOrDefault(config.GetNetwork())
+}
+
+func (config *SmartnodeConfig) GetRestApiPort() uint16 {
+	return config.RestApiPort.GetUint16OrDefault(config.GetNetwork())
+}
+
+func (config *SmartnodeConfig) GetRestApiAuthToken() string {
+	return config.RestApiAuthToken.GetStringOrDefault(config.GetNetwork())
+}
+
+func (config *SmartnodeConfig) GetRestApiAllowWrites() bool {
+	return config.RestApiAllowWrites.GetBoolOrDefault(config.GetNetwork())
+}
+
+func (config *SmartnodeConfig) GetRewardsTreeRetention() uint64 {
+	return config.RewardsTreeRetention.GetUintOrDefault(config.GetNetwork())
+}
+
+func (config *SmartnodeConfig) GetEventStreamPort() uint16 {
+	return config.EventStreamPort.GetUint16OrDefault(config.GetNetwork())
+}
+
+// Get the port a daemon serves its health check on
+func (config *SmartnodeConfig) GetHealthCheckPort(daemon string) uint16 {
+	port := config.HealthCheckPort.GetUint16OrDefault(config.GetNetwork())
+	switch daemon {
+	case WatchtowerContainerName:
+		return port + 1
+	case ApiContainerName:
+		return port + 2
+	default:
+		return port
+	}
+}
+
+func (config *SmartnodeConfig) GetPerformanceThreshold() float64 {
+	return config.PerformanceThreshold.GetFloatOrDefault(config.GetNetwork())
+}
+
+func (config *SmartnodeConfig) GetPerformanceWindow() uint64 {
+	return config.PerformanceWindow.GetUintOrDefault(config.GetNetwork())
+}
+
+func (config *SmartnodeConfig) GetDisplayTimezone() string {
+	return config.DisplayTimezone.GetStringOrDefault(config.GetNetwork())
+}
+
+func (config *SmartnodeConfig) GetShowPendingItems() bool {
+	return config.ShowPendingItems.GetBoolOrDefault(config.GetNetwork())
+}
+
+func (config *SmartnodeConfig) GetIpfsGateways() []string {
+	gateways := []string{}
+	for _, gateway := range strings.Split(config.IpfsGateways.GetStringOrDefault(config.GetNetwork()), ",") {
+		gateway = strings.TrimSpace(gateway)
+		if gateway != "" {
+			gateways = append(gateways, strings.TrimSuffix(gateway, "/"))
+		}
+	}
+	return gateways
+}
+
+// Get the name of the Docker network the containers use, and whether it's an existing network the Smartnode doesn't manage
+func (config *SmartnodeConfig) GetDockerNetwork() (string, string) {
+	if network := config.DockerNetwork.Value.(string); network != "" {
+		return network, "true"
+	}
+	return defaultDockerNetwork, "false"
+}
+
+func (config *SmartnodeConfig) GetRewardsTreeMirrorUrl() string {
+	return config.RewardsTreeMirrorUrl.GetStringOrDefault(config.GetNetwork())
+}
+
+func (config *SmartnodeConfig) GetArchiveCcUrl() string {
+	return config.ArchiveCcUrl.GetStringOrDefault(config.GetNetwork())
+}
+
+func (config *SmartnodeConfig) GetArchiveEcUrl() string {
+	return strings.TrimSpace(config.ArchiveEcUrl.GetStringOrDefault(config.GetNetwork()))
+}
+
+func (config *SmartnodeConfig) GetEventLogChunkSize() uint64 {
+	return config.EventLogChunkSize.GetUintOrDefault(Network_All)
+}
+
+func (config *SmartnodeConfig) GetEventLogWorkers() uint64 {
+	workers := config.EventLogWorkers.GetUintOrDefault(Network_All)
+	if workers < 1 {
+		return 1
+	}
+	return workers
+}
+
+func (config *SmartnodeConfig) GetFallbackCcUrl() string {
+	return config.FallbackCcUrl.GetStringOrDefault(config.GetNetwork())
+}
+
+func (config *SmartnodeConfig) GetMaxUnfinalizedReportEpochs() uint64 {
+	return config.MaxUnfinalizedReportEpochs.GetUintOrDefault(config.GetNetwork())
+}
+
+func (config *SmartnodeConfig) GetTxSponsorUrl() string {
+	return config.TxSponsorUrl.GetStringOrDefault(config.GetNetwork())
+}
+
+// Getters for the non-editable parameters
+
+func (config *SmartnodeConfig) GetTxWatchUrl() string {
+	return config.getNetworkManifest().TxWatchUrl
+}
+
+func (config *SmartnodeConfig) GetStakeUrl() string {
+	return config.getNetworkManifest().StakeUrl
+}
+
+func (config *SmartnodeConfig) GetChainID() uint {
+	return config.getNetworkManifest().ChainID
+}
+
+func (config *SmartnodeConfig) GetWalletPath() string {
+	if config.parent.IsNativeMode {
+		return filepath.Join(config.GetDataPath(), "wallet")
+	} else {
+		return config.walletPath
+	}
+}
 
 func (config *SmartnodeConfig) GetPasswordPath() string {
 	if config.parent.IsNativeMode {
-		return filepath.Join(config.DataPath.Value.(string), "password")
+		return filepath.Join(config.GetDataPath(), "password")
 	} else {
 		return config.passwordPath
 	}
@@ -295,15 +1739,181 @@ func (config *SmartnodeConfig) GetPasswordPath() string {
 
 func (config *SmartnodeConfig) GetValidatorKeychainPath() string {
 	if config.parent.IsNativeMode {
-		return filepath.Join(config.DataPath.Value.(string), "validators")
+		return filepath.Join(config.GetDataPath(), "validators")
 	} else {
 		return config.validatorKeychainPath
 	}
 }
 
+func (config *SmartnodeConfig) GetValidatorShardsPath() string {
+	if config.parent.IsNativeMode {
+		return filepath.Join(config.GetDataPath(), "validator-shards")
+	} else {
+		return config.validatorShardsPath
+	}
+}
+
+func (config *SmartnodeConfig) GetValidatorShardAssignmentsPath() string {
+	if config.parent.IsNativeMode {
+		return filepath.Join(config.GetDataPath(), "validator-shards", "assignments.json")
+	} else {
+		return config.validatorShardAssignmentsPath
+	}
+}
+
+// Get the path of the token for the Validator Client's Keymanager API
+func (config *SmartnodeConfig) GetKeymanagerTokenPath() string {
+	tokenFile, _ := config.parent.getKeymanagerFiles()
+	if tokenFile == "" {
+		tokenFile = defaultKeymanagerTokenFile
+	}
+	return filepath.Join(config.GetValidatorKeychainPath(), tokenFile)
+}
+
+// Get the path of the TLS certificate the Validator Client serves its Keymanager API with, or an empty string if it doesn't use TLS
+func (config *SmartnodeConfig) GetKeymanagerCertPath() string {
+	_, certFile := config.parent.getKeymanagerFiles()
+	if certFile == "" {
+		return ""
+	}
+	return filepath.Join(config.GetValidatorKeychainPath(), certFile)
+}
+
+func (config *SmartnodeConfig) GetSlashingProtectionImportPath() string {
+	if config.parent.IsNativeMode {
+		return filepath.Join(config.GetDataPath(), "slashing-protection-import.json")
+	} else {
+		return config.slashingProtectionImportPath
+	}
+}
+
+func (config *SmartnodeConfig) GetSigningQueuePath() string {
+	if config.parent.IsNativeMode {
+		return filepath.Join(config.GetDataPath(), "signing-queue")
+	} else {
+		return config.signingQueuePath
+	}
+}
+
+func (config *SmartnodeConfig) GetNonceTrackerPath() string {
+	if config.parent.IsNativeMode {
+		return filepath.Join(config.GetDataPath(), "sent-transactions.json")
+	} else {
+		return config.nonceTrackerPath
+	}
+}
+
+func (config *SmartnodeConfig) GetValidatorIndexCachePath() string {
+	if config.parent.IsNativeMode {
+		return filepath.Join(config.GetDataPath(), "validator-indices.json")
+	} else {
+		return config.validatorIndexCachePath
+	}
+}
+
+func (config *SmartnodeConfig) GetTxQueuePath() string {
+	if config.parent.IsNativeMode {
+		return filepath.Join(config.GetDataPath(), "tx-queue.json")
+	} else {
+		return config.txQueuePath
+	}
+}
+
+func (config *SmartnodeConfig) GetExitQueuePath() string {
+	if config.parent.IsNativeMode {
+		return filepath.Join(config.GetDataPath(), "exit-queue.json")
+	} else {
+		return config.exitQueuePath
+	}
+}
+
+func (config *SmartnodeConfig) GetPrivateRelayQueuePath() string {
+	if config.parent.IsNativeMode {
+		return filepath.Join(config.GetDataPath(), "private-relay.json")
+	} else {
+		return config.privateRelayQueuePath
+	}
+}
+
+func (config *SmartnodeConfig) GetSlashingSafeModePath() string {
+	if config.parent.IsNativeMode {
+		return filepath.Join(config.GetDataPath(), "slashing-safe-mode.json")
+	} else {
+		return config.slashingSafeModePath
+	}
+}
+
+func (config *SmartnodeConfig) GetCrashReportPath() string {
+	if config.parent.IsNativeMode {
+		return filepath.Join(config.GetDataPath(), "crash-reports")
+	} else {
+		return config.crashReportPath
+	}
+}
+
+func (config *SmartnodeConfig) GetEmergencyAuditLogPath() string {
+	if config.parent.IsNativeMode {
+		return filepath.Join(config.GetDataPath(), "emergency-audit.log")
+	} else {
+		return config.emergencyAuditLogPath
+	}
+}
+
+func (config *SmartnodeConfig) GetSlashingSafeMode() SlashingSafeMode {
+	mode, ok := config.SlashingSafeMode.Value.(SlashingSafeMode)
+	if !ok {
+		return SlashingSafeMode_Alert
+	}
+	return mode
+}
+
+func (config *SmartnodeConfig) GetTimeSyncMode() TimeSyncMode {
+	mode, ok := config.TimeSyncMode.Value.(TimeSyncMode)
+	if !ok {
+		return TimeSyncMode_Check
+	}
+	return mode
+}
+
+func (config *SmartnodeConfig) GetNtpServers() []string {
+	servers := []string{}
+	for _, server := range strings.Split(config.NtpServers.GetStringOrDefault(Network_All), ",") {
+		server = strings.TrimSpace(server)
+		if server != "" {
+			servers = append(servers, server)
+		}
+	}
+	return servers
+}
+
+func (config *SmartnodeConfig) GetMaxClockOffset() time.Duration {
+	return time.Duration(config.MaxClockOffset.GetUintOrDefault(Network_All)) * time.Millisecond
+}
+
+func (config *SmartnodeConfig) GetValidatorCrashLogPath() string {
+	if config.parent.IsNativeMode {
+		return filepath.Join(config.GetDataPath(), "validator-crash.log")
+	} else {
+		return config.validatorCrashLogPath
+	}
+}
+
+// Get the URL of the Validator Client's Keymanager API
+func (config *SmartnodeConfig) GetKeymanagerApiUrl() string {
+	if config.parent.IsNativeMode {
+		return config.parent.Native.ValidatorKeymanagerUrl.GetStringOrDefault(config.GetNetwork())
+	} else {
+		scheme := "http"
+		if config.GetKeymanagerCertPath() != "" {
+			scheme = "https"
+		}
+		return fmt.Sprintf("%s://%s:%d", scheme, ValidatorContainerName, config.GetKeymanagerApiPort())
+	}
+}
+
 func (config *SmartnodeConfig) GetCustomKeyPath() string {
 	if config.parent.IsNativeMode {
-		return filepath.Join(config.DataPath.Value.(string), "custom-keys")
+		return filepath.Join(config.GetDataPath(), "custom-keys")
 	} else {
 		return config.customKeyRecoverPath
 	}
@@ -311,30 +1921,106 @@ func (config *SmartnodeConfig) GetCustomKeyPath() string {
 
 func (config *SmartnodeConfig) GetCustomKeyPasswordFilePath() string {
 	if config.parent.IsNativeMode {
-		return filepath.Join(config.DataPath.Value.(string), "custom-key-passwords")
+		return filepath.Join(config.GetDataPath(), "custom-key-passwords")
 	} else {
 		return config.customKeyPasswordFilePath
 	}
 }
 
+func (config *SmartnodeConfig) GetJournalPath() string {
+	if config.parent.IsNativeMode {
+		return filepath.Join(config.GetDataPath(), "journal")
+	} else {
+		return config.journalPath
+	}
+}
+
+func (config *SmartnodeConfig) GetRewardsTreePath() string {
+	if config.parent.IsNativeMode {
+		return filepath.Join(config.GetDataPath(), "rewards-trees")
+	} else {
+		return config.rewardsTreePath
+	}
+}
+
+func (config *SmartnodeConfig) GetCachePath() string {
+	if config.parent.IsNativeMode {
+		return filepath.Join(config.GetDataPath(), "cache")
+	} else {
+		return config.cachePath
+	}
+}
+
+func (config *SmartnodeConfig) GetRewardsSnapshotPath() string {
+	if config.parent.IsNativeMode {
+		return filepath.Join(config.GetDataPath(), "rewards-snapshots")
+	} else {
+		return config.rewardsSnapshotPath
+	}
+}
+
+func (config *SmartnodeConfig) GetRateHistoryPath() string {
+	if config.parent.IsNativeMode {
+		return filepath.Join(config.GetDataPath(), "rate-history.json")
+	} else {
+		return config.rateHistoryPath
+	}
+}
+
+func (config *SmartnodeConfig) GetTreegenStatusPath() string {
+	if config.parent.IsNativeMode {
+		return filepath.Join(config.GetDataPath(), "treegen")
+	} else {
+		return config.treegenStatusPath
+	}
+}
+
+func (config *SmartnodeConfig) GetTreegenMemoryLimit() uint64 {
+	return config.TreegenMemoryLimit.GetUintOrDefault(Network_All)
+}
+
+func (config *SmartnodeConfig) GetRethPriceSource() RethPriceSource {
+	source, ok := config.RethPriceSource.Value.(RethPriceSource)
+	if !ok {
+		return RethPriceSource_OneInch
+	}
+	return source
+}
+
+func (config *SmartnodeConfig) GetRethPriceUrl() string {
+	return config.RethPriceUrl.GetStringOrDefault(Network_All)
+}
+
+func (config *SmartnodeConfig) GetRethMaxDeviation() float64 {
+	return config.RethMaxDeviation.GetFloatOrDefault(Network_All)
+}
+
+func (config *SmartnodeConfig) GetRateHistoryRetention() uint64 {
+	return config.RateHistoryRetention.GetUintOrDefault(Network_All)
+}
+
 func (config *SmartnodeConfig) GetStorageAddress() string {
-	return config.storageAddress[config.Network.Value.(Network)]
+	return config.getNetworkManifest().Contracts.Storage
 }
 
 func (config *SmartnodeConfig) GetOneInchOracleAddress() string {
-	return config.oneInchOracleAddress[config.Network.Value.(Network)]
+	return config.getNetworkManifest().Contracts.OneInchOracle
 }
 
 func (config *SmartnodeConfig) GetRplTokenAddress() string {
-	return config.rplTokenAddress[config.Network.Value.(Network)]
+	return config.getNetworkManifest().Contracts.RplToken
 }
 
 func (config *SmartnodeConfig) GetRplFaucetAddress() string {
-	return config.rplFaucetAddress[config.Network.Value.(Network)]
+	return config.getNetworkManifest().Contracts.RplFaucet
 }
 
 func (config *SmartnodeConfig) GetSnapshotDelegationAddress() string {
-	return config.snapshotDelegationAddress[config.Network.Value.(Network)]
+	return config.getNetworkManifest().Contracts.SnapshotDelegation
+}
+
+func (config *SmartnodeConfig) GetMulticallAddress() string {
+	return config.getNetworkManifest().Contracts.Multicall
 }
 
 func (config *SmartnodeConfig) GetSmartnodeContainerTag() string {
@@ -353,6 +2039,37 @@ func (config *SmartnodeConfig) GetEcMigratorContainerTag() string {
 	return ecMigratorTag
 }
 
+func (config *SmartnodeConfig) GetSnapshotLoaderContainerTag() string {
+	return snapshotLoaderTag
+}
+
+// Get the trusted snapshot URL for each Execution client
+func (config *SmartnodeConfig) GetEcSnapshotUrls() (map[ExecutionClient]string, error) {
+	urls := map[ExecutionClient]string{}
+	for _, entry := range strings.Split(config.EcSnapshotUrls.GetStringOrDefault(config.GetNetwork()), ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		elements := strings.SplitN(entry, "=", 2)
+		if len(elements) != 2 {
+			return nil, fmt.Errorf("invalid snapshot '%s', expected the format client=url", entry)
+		}
+		client := ExecutionClient(strings.ToLower(strings.TrimSpace(elements[0])))
+		switch client {
+		case ExecutionClient_Geth, ExecutionClient_Erigon, ExecutionClient_Nethermind:
+		default:
+			return nil, fmt.Errorf("invalid snapshot '%s': snapshots are only supported for Geth, Erigon and Nethermind", entry)
+		}
+		snapshotUrl, err := url.Parse(strings.TrimSpace(elements[1]))
+		if err != nil || (snapshotUrl.Scheme != "http" && snapshotUrl.Scheme != "https") {
+			return nil, fmt.Errorf("invalid snapshot '%s': '%s' is not an HTTP or HTTPS URL", entry, elements[1])
+		}
+		urls[client] = snapshotUrl.String()
+	}
+	return urls, nil
+}
+
 func (config *SmartnodeConfig) GetVotingSnapshotID() [32]byte {
 	// So the contract wants a Keccak'd hash of the voting ID, but Snapshot's service wants ASCII so it can display the ID in plain text; we have to do this to make it play nicely with Snapshot
 	buffer := [32]byte{}
@@ -361,6 +2078,20 @@ func (config *SmartnodeConfig) GetVotingSnapshotID() [32]byte {
 	return buffer
 }
 
+// Get the error encountered while loading the network manifest overrides, if any
+func (config *SmartnodeConfig) GetNetworkManifestError() error {
+	return config.networkManifestError
+}
+
+// Get the manifest for the selected network, or an empty one if the network is unknown
+func (config *SmartnodeConfig) getNetworkManifest() *NetworkManifest {
+	manifest, exists := config.networkManifests[config.GetNetwork()]
+	if !exists {
+		return &NetworkManifest{}
+	}
+	return manifest
+}
+
 // The the title for the config
 func (config *SmartnodeConfig) GetConfigTitle() string {
 	return config.Title
@@ -26,6 +26,12 @@ type TekuConfig struct {
 
 	// Custom command line flags for the VC
 	AdditionalVcFlags Parameter `yaml:"additionalVcFlags,omitempty"`
+
+	// The file in the validators folder that holds the token for the VC's Keymanager API
+	KeymanagerTokenFile Parameter `yaml:"keymanagerTokenFile,omitempty"`
+
+	// The file in the validators folder that holds the TLS certificate for the VC's Keymanager API
+	KeymanagerCertFile Parameter `yaml:"keymanagerCertFile,omitempty"`
 }
 
 // Generates a new Teku configuration
@@ -96,6 +102,32 @@ func NewTekuConfig(config *RocketPoolConfig) *TekuConfig {
 			CanBeBlank:           true,
 			OverwriteOnUpgrade:   false,
 		},
+
+		KeymanagerTokenFile: Parameter{
+			ID:                   "keymanagerTokenFile",
+			Name:                 "Keymanager API Token File",
+			Description:          "The file in your validators folder that holds the token for Teku's Keymanager API. The Smartnode uses the API to load new validator keys without restarting Teku, if you've enabled that in the Smartnode settings.\n\nLeave this blank to use the token the Smartnode generates (`keymanager-token`).",
+			Type:                 ParameterType_String,
+			Default:              map[Network]interface{}{Network_All: ""},
+			AffectsContainers:    []ContainerID{ContainerID_Api, ContainerID_Node, ContainerID_Validator},
+			EnvironmentVariables: []string{"VC_KEYMANAGER_TOKEN_FILE"},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+			Advanced:             true,
+		},
+
+		KeymanagerCertFile: Parameter{
+			ID:                   "keymanagerCertFile",
+			Name:                 "Keymanager API Certificate File",
+			Description:          "The file in your validators folder that holds the TLS certificate Teku serves its Keymanager API with, in PEM format. If it's set, the Smartnode connects to the API over HTTPS and only trusts this certificate.\n\nLeave this blank if Teku serves the API without TLS.",
+			Type:                 ParameterType_String,
+			Default:              map[Network]interface{}{Network_All: ""},
+			AffectsContainers:    []ContainerID{ContainerID_Api, ContainerID_Node, ContainerID_Validator},
+			EnvironmentVariables: []string{"VC_KEYMANAGER_CERT_FILE"},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+			Advanced:             true,
+		},
 	}
 }
 
@@ -107,6 +139,8 @@ func (config *TekuConfig) GetParameters() []*Parameter {
 		&config.ContainerTag,
 		&config.AdditionalBnFlags,
 		&config.AdditionalVcFlags,
+		&config.KeymanagerTokenFile,
+		&config.KeymanagerCertFile,
 	}
 }
 
@@ -126,7 +160,7 @@ func (config *TekuConfig) GetUnsupportedCommonParams() []string {
 
 // Get the Docker container name of the validator client
 func (config *TekuConfig) GetValidatorImage() string {
-	return config.ContainerTag.Value.(string)
+	return config.ContainerTag.GetStringOrDefault(Network_All)
 }
 
 // Get the name of the client
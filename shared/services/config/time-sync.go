@@ -0,0 +1,62 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"text/template"
+)
+
+// NTP servers are passed to Chrony on its command line, so only hostnames and IP addresses are allowed
+var ntpServerRegex = regexp.MustCompile(`^[A-Za-z0-9.:\-]+$`)
+
+// The Docker Compose file for the Chrony container.
+// Chrony runs in the foreground with its settings passed as arguments, and needs SYS_TIME to adjust the host's clock.
+const chronyComposeTemplate string = `# This file is generated by the Smartnode; any changes will be overwritten.
+version: "{{.Version}}"
+services:
+  {{.Service}}:
+    image: {{.Image}}
+    container_name: {{.ProjectName}}_{{.Service}}
+    restart: unless-stopped
+    cap_add:
+      - SYS_TIME
+    entrypoint: ["sh", "-c"]
+    command:
+      - >-
+        apk add --no-cache chrony &&
+        exec chronyd -d{{range .Servers}} "server {{.}} iburst"{{end}} "makestep 1.0 3" "driftfile /var/lib/chrony/chrony.drift"
+    networks:
+      - net
+networks:
+  net:
+`
+
+var parsedChronyComposeTemplate = template.Must(template.New("chrony").Parse(chronyComposeTemplate))
+
+// Check if the Smartnode runs the Chrony container to keep the system clock synchronized
+func (config *RocketPoolConfig) UsesChronyContainer() bool {
+	return !config.IsNativeMode && config.Smartnode.GetTimeSyncMode() == TimeSyncMode_Chrony
+}
+
+// Generate the compose file for the Chrony container
+func (config *RocketPoolConfig) GenerateChronyComposeFile() ([]byte, error) {
+	var buffer bytes.Buffer
+	err := parsedChronyComposeTemplate.Execute(&buffer, struct {
+		Version     string
+		Service     string
+		Image       string
+		ProjectName string
+		Servers     []string
+	}{
+		Version:     composeFragmentVersion,
+		Service:     ChronyContainerName,
+		Image:       config.Smartnode.ChronyContainerTag.GetStringOrDefault(Network_All),
+		ProjectName: config.Smartnode.GetProjectName(),
+		Servers:     config.Smartnode.GetNtpServers(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error rendering Chrony compose file: %w", err)
+	}
+	return buffer.Bytes(), nil
+}
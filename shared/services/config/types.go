@@ -6,6 +6,13 @@ type Mode string
 type ParameterType string
 type ExecutionClient string
 type ConsensusClient string
+type ErigonPruneMode string
+type SettingsKeySource string
+type GasOracle string
+type EcRoutingMode string
+type SlashingSafeMode string
+type TimeSyncMode string
+type RethPriceSource string
 
 // Enum to describe which container(s) a parameter impacts, so the Smartnode knows which
 // ones to restart upon a settings change
@@ -21,6 +28,8 @@ const (
 	ContainerID_Grafana      ContainerID = "grafana"
 	ContainerID_Prometheus   ContainerID = "prometheus"
 	ContainerID_Exporter     ContainerID = "exporter"
+	ContainerID_Alertmanager ContainerID = "alertmanager"
+	ContainerID_Chrony       ContainerID = "chrony"
 )
 
 // Enum to describe which network the system is on
@@ -57,6 +66,7 @@ const (
 	ExecutionClient_Geth       ExecutionClient = "geth"
 	ExecutionClient_Nethermind ExecutionClient = "nethermind"
 	ExecutionClient_Besu       ExecutionClient = "besu"
+	ExecutionClient_Erigon     ExecutionClient = "erigon"
 	ExecutionClient_Infura     ExecutionClient = "infura"
 	ExecutionClient_Pocket     ExecutionClient = "pocket"
 )
@@ -70,6 +80,60 @@ const (
 	ConsensusClient_Teku       ConsensusClient = "teku"
 )
 
+// Enum to describe Erigon's pruning modes
+const (
+	ErigonPruneMode_Unknown ErigonPruneMode = ""
+	ErigonPruneMode_Full    ErigonPruneMode = "full"
+	ErigonPruneMode_Archive ErigonPruneMode = "archive"
+)
+
+// Enum to describe where the key for encrypting sensitive settings comes from
+const (
+	SettingsKeySource_Unknown        SettingsKeySource = ""
+	SettingsKeySource_WalletPassword SettingsKeySource = "walletPassword"
+	SettingsKeySource_Passphrase     SettingsKeySource = "passphrase"
+)
+
+// Enum to describe where the Smartnode gets its max fee suggestions from
+const (
+	GasOracle_Unknown     GasOracle = ""
+	GasOracle_Etherchain  GasOracle = "etherchain"
+	GasOracle_Etherscan   GasOracle = "etherscan"
+	GasOracle_Blocknative GasOracle = "blocknative"
+	GasOracle_FeeHistory  GasOracle = "feeHistory"
+)
+
+// Enum to describe how requests are spread across multiple external Execution client endpoints
+const (
+	EcRoutingMode_Unknown    EcRoutingMode = ""
+	EcRoutingMode_Fallback   EcRoutingMode = "fallback"
+	EcRoutingMode_RoundRobin EcRoutingMode = "roundRobin"
+)
+
+// Enum to describe what the node daemon does when it sees a surge of slashings on the network
+const (
+	SlashingSafeMode_Unknown  SlashingSafeMode = ""
+	SlashingSafeMode_Disabled SlashingSafeMode = "disabled"
+	SlashingSafeMode_Alert    SlashingSafeMode = "alert"
+	SlashingSafeMode_Pause    SlashingSafeMode = "pause"
+)
+
+// Enum to describe how the Smartnode keeps the system clock accurate
+const (
+	TimeSyncMode_Unknown  TimeSyncMode = ""
+	TimeSyncMode_Disabled TimeSyncMode = "disabled"
+	TimeSyncMode_Check    TimeSyncMode = "check"
+	TimeSyncMode_Chrony   TimeSyncMode = "chrony"
+)
+
+// Enum to describe where the secondary-market rETH rate is read from
+const (
+	RethPriceSource_Unknown  RethPriceSource = ""
+	RethPriceSource_Disabled RethPriceSource = "disabled"
+	RethPriceSource_OneInch  RethPriceSource = "1inch"
+	RethPriceSource_Url      RethPriceSource = "url"
+)
+
 type Config interface {
 	GetConfigTitle() string
 	GetParameters() []*Parameter
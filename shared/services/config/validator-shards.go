@@ -0,0 +1,123 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// Constants
+const (
+	// The prefix of the names of the additional Validator client containers; the main Validator client is shard 0
+	ValidatorShardContainerPrefix string = ValidatorContainerName + "_shard"
+
+	// The most Validator clients the keys can be split across
+	MaxValidatorClientShards uint64 = 16
+
+	// The folder in the data folder that holds each additional shard's keys
+	validatorShardsFolder string = "validator-shards"
+
+	// The path of the validator keys inside the Validator client containers
+	validatorKeychainContainerPath string = "/validators"
+)
+
+// The Docker Compose file for an additional Validator client shard.
+// It runs the same service as the main Validator client, with its own container and key folder.
+const validatorShardComposeTemplate string = `# This file is generated by the Smartnode; any changes will be overwritten.
+version: "{{.Version}}"
+services:
+  {{.Service}}:
+    extends:
+      file: {{.BaseFile}}
+      service: {{.BaseService}}
+    container_name: {{.ProjectName}}_{{.Service}}
+    volumes:
+      - "{{.KeychainPath}}:{{.KeychainContainerPath}}"
+`
+
+var parsedValidatorShardComposeTemplate = template.Must(template.New("validator-shard").Parse(validatorShardComposeTemplate))
+
+// Get the number of Validator clients the validator keys are split across
+func (config *RocketPoolConfig) GetValidatorShardCount() uint64 {
+	shards := config.Smartnode.ValidatorClientShards.GetUintOrDefault(Network_All)
+	if shards < 1 || config.IsNativeMode {
+		return 1
+	}
+	return shards
+}
+
+// Get the names of the additional Validator client containers, in shard order
+func (config *RocketPoolConfig) GetValidatorShardContainerNames() []string {
+	names := []string{}
+	for shard := uint64(1); shard < config.GetValidatorShardCount(); shard++ {
+		names = append(names, GetValidatorShardContainerName(shard))
+	}
+	return names
+}
+
+// Get the name of the Validator client container for a shard
+func GetValidatorShardContainerName(shard uint64) string {
+	if shard == 0 {
+		return ValidatorContainerName
+	}
+	return fmt.Sprintf("%s%d", ValidatorShardContainerPrefix, shard)
+}
+
+// Get the shard a Validator client container runs, if it's one of the additional shards
+func GetValidatorShardFromContainerName(container string) (uint64, bool) {
+	if !strings.HasPrefix(container, ValidatorShardContainerPrefix) {
+		return 0, false
+	}
+	shard, err := strconv.ParseUint(strings.TrimPrefix(container, ValidatorShardContainerPrefix), 10, 64)
+	if err != nil || shard == 0 {
+		return 0, false
+	}
+	return shard, true
+}
+
+// Get the path of a shard's validator key folder within the daemon Docker container; shard 0 uses the regular validator key folder
+func (config *RocketPoolConfig) GetValidatorShardKeychainPath(shard uint64) string {
+	if shard == 0 {
+		return config.Smartnode.GetValidatorKeychainPath()
+	}
+	return filepath.Join(config.Smartnode.GetValidatorShardsPath(), fmt.Sprint(shard))
+}
+
+// Get the host path of a shard's validator key folder, which is mounted into its Validator client container
+func (config *RocketPoolConfig) GetValidatorShardKeychainHostPath(shard uint64) string {
+	dataPath := os.ExpandEnv(config.Smartnode.DataPath.Value.(string))
+	if shard == 0 {
+		return filepath.Join(dataPath, "validators")
+	}
+	return filepath.Join(dataPath, validatorShardsFolder, fmt.Sprint(shard))
+}
+
+// Generate the compose file for an additional Validator client shard
+func (config *RocketPoolConfig) GenerateValidatorShardComposeFile(shard uint64) ([]byte, error) {
+	var buffer bytes.Buffer
+	err := parsedValidatorShardComposeTemplate.Execute(&buffer, struct {
+		Version               string
+		Service               string
+		BaseFile              string
+		BaseService           string
+		ProjectName           string
+		KeychainPath          string
+		KeychainContainerPath string
+	}{
+		Version:               composeFragmentVersion,
+		Service:               GetValidatorShardContainerName(shard),
+		BaseFile:              ValidatorContainerName + ".yml",
+		BaseService:           ValidatorContainerName,
+		ProjectName:           config.Smartnode.GetProjectName(),
+		KeychainPath:          config.GetValidatorShardKeychainHostPath(shard),
+		KeychainContainerPath: validatorKeychainContainerPath,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error rendering the compose file for Validator client shard %d: %w", shard, err)
+	}
+	return buffer.Bytes(), nil
+}
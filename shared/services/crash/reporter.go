@@ -0,0 +1,106 @@
+package crash
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/rocket-pool/smartnode/shared/services/events"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Settings
+const (
+	restartDelay     = 30 * time.Second
+	reportTimeFormat = "20060102-150405"
+)
+
+// Recovers from panics in a daemon's loops, writing a crash report with the stack trace and restarting the loop
+// instead of letting the panic take down the whole daemon
+type Reporter struct {
+	daemon  string
+	path    string
+	logger  log.ColorLogger
+	crashes *prometheus.CounterVec
+}
+
+// Create a new crash reporter for a daemon, which writes its reports to the provided folder
+func NewReporter(daemon string, path string, logger log.ColorLogger) *Reporter {
+	return &Reporter{
+		daemon: daemon,
+		path:   path,
+		logger: logger,
+		crashes: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "rocketpool",
+			Subsystem: "daemon",
+			Name:      "crashes_total",
+			Help:      "The number of times each of the daemon's loops has panicked and been restarted",
+		}, []string{"daemon", "loop"}),
+	}
+}
+
+// Run a loop, restarting it after a delay whenever it panics; this only returns once the loop returns normally
+func (r *Reporter) RunLoop(loop string, run func()) {
+	for {
+		if !r.runOnce(loop, run) {
+			return
+		}
+		time.Sleep(restartDelay)
+		r.logger.Printlnf("Restarting the %s loop.", loop)
+	}
+}
+
+// Describe the crash metrics
+func (r *Reporter) Describe(channel chan<- *prometheus.Desc) {
+	r.crashes.Describe(channel)
+}
+
+// Collect the crash metrics
+func (r *Reporter) Collect(channel chan<- prometheus.Metric) {
+	r.crashes.Collect(channel)
+}
+
+// Run a loop, and report whether it panicked
+func (r *Reporter) runOnce(loop string, run func()) (crashed bool) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			crashed = true
+			r.report(loop, recovered, debug.Stack())
+		}
+	}()
+	run()
+	return false
+}
+
+// Record a panic in the logs, the metrics, the event stream, and a crash report file
+func (r *Reporter) report(loop string, recovered interface{}, stack []byte) {
+	r.crashes.WithLabelValues(r.daemon, loop).Inc()
+	message := fmt.Sprintf("The %s loop crashed: %v", loop, recovered)
+	events.Publish(events.EventType_Error, loop, message)
+
+	reportPath, err := r.writeReport(loop, recovered, stack)
+	if err != nil {
+		r.logger.Printlnf("%s\n%s\nThe crash report could not be saved: %s", message, string(stack), err.Error())
+		return
+	}
+	r.logger.Printlnf("%s\nThe stack trace was saved to %s.", message, reportPath)
+}
+
+// Write a crash report file, returning its path
+func (r *Reporter) writeReport(loop string, recovered interface{}, stack []byte) (string, error) {
+	if err := os.MkdirAll(r.path, 0700); err != nil {
+		return "", fmt.Errorf("error creating crash report folder: %w", err)
+	}
+	now := time.Now().UTC()
+	reportPath := filepath.Join(r.path, fmt.Sprintf("%s-%s-%s.txt", r.daemon, loop, now.Format(reportTimeFormat)))
+	contents := fmt.Sprintf("Daemon: %s\nLoop: %s\nTime: %s\nPanic: %v\n\n%s", r.daemon, loop, now.Format(time.RFC3339), recovered, string(stack))
+	if err := ioutil.WriteFile(reportPath, []byte(contents), 0600); err != nil {
+		return "", fmt.Errorf("error writing crash report: %w", err)
+	}
+	return reportPath, nil
+}
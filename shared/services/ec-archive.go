@@ -0,0 +1,90 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// The number of recent blocks a full Execution client is expected to keep the state of; queries of older blocks go straight to the archive node
+const ecRecentStateBlocks uint64 = 128
+
+// The error returned when none of the Execution clients have the state a query needs
+var ErrEcHistoryUnavailable = errors.New("the Execution client no longer has the state for this block; it has most likely been pruned")
+
+// Phrases the Execution clients use when they can't serve the state of an old block
+var ecStateUnavailablePhrases = []string{
+	"missing trie node",
+	"header not found",
+	"historical state",
+	"state is not available",
+	"state not available",
+	"world state unavailable",
+	"old data not available",
+	"pruned",
+}
+
+// Check if an error means the Execution client doesn't have the state a query needs any more
+func isEcStateUnavailable(err error) bool {
+	if errors.Is(err, ErrEcHistoryUnavailable) {
+		return true
+	}
+	message := strings.ToLower(err.Error())
+	for _, phrase := range ecStateUnavailablePhrases {
+		if strings.Contains(message, phrase) {
+			return true
+		}
+	}
+	return false
+}
+
+// Run a query of the state at a block, sending it to the archive node if the block is older than a full client keeps or the other clients have pruned it.
+// Queries of the latest state go to the primary and fallback clients, and queries pinned to a block go to the read endpoints first.
+// Without an archive node, queries the other clients can't serve fail with an error explaining how to set one up.
+func (p *ExecutionClientManager) runHistoricalFunction(blockNumber *big.Int, function clientFunction) (interface{}, error) {
+
+	// Negative block numbers are tags like "latest" and "pending"
+	if blockNumber == nil || blockNumber.Sign() < 0 {
+		return p.runFunction(function)
+	}
+
+	// Send old blocks to the archive node first
+	triedArchive := false
+	var archiveErr error
+	if p.isOldBlock(blockNumber.Uint64()) {
+		var result interface{}
+		result, archiveErr = p.archive.run(function)
+		if archiveErr == nil || !(isDisconnected(archiveErr) || isEcStateUnavailable(archiveErr)) {
+			return result, archiveErr
+		}
+		triedArchive = true
+		p.logger.Printlnf("WARNING: The archive execution client couldn't serve block %s (%s), trying the other clients...", blockNumber.String(), archiveErr.Error())
+	}
+
+	// Try the other clients; queries pinned to a block are heavy reads, so they go to the read endpoints first when they're configured
+	result, err := p.runReadFunction(function)
+	if err == nil || !isEcStateUnavailable(err) {
+		return result, err
+	}
+	if p.archive == nil {
+		return nil, fmt.Errorf("Could not look up the state at block %s: %w\nSet the 'Archive Execution Client URL' in the Smartnode settings (`rocketpool service config`) to an archive Execution client to look up historical state.", blockNumber.String(), ErrEcHistoryUnavailable)
+	}
+	if !triedArchive {
+		result, archiveErr = p.archive.run(function)
+		if archiveErr == nil || !(isDisconnected(archiveErr) || isEcStateUnavailable(archiveErr)) {
+			return result, archiveErr
+		}
+	}
+	return nil, fmt.Errorf("Could not look up the state at block %s: neither your Execution clients (%s) nor your archive Execution client (%s) could serve it: %w", blockNumber.String(), err.Error(), archiveErr.Error(), ErrEcHistoryUnavailable)
+
+}
+
+// Check if a block is older than a full Execution client keeps the state of, going by the latest chain head the health checks have seen
+func (p *ExecutionClientManager) isOldBlock(blockNumber uint64) bool {
+	if p.archive == nil {
+		return false
+	}
+	head := p.getBestHead()
+	return head > ecRecentStateBlocks && blockNumber < head-ecRecentStateBlocks
+}
@@ -2,32 +2,81 @@ package services
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"math"
 	"math/big"
+	"os"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/fatih/color"
+	"github.com/rocket-pool/smartnode/shared/services/clock"
 	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/gas/feehistory"
+	"github.com/rocket-pool/smartnode/shared/services/notifications"
 	"github.com/rocket-pool/smartnode/shared/types/api"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
 )
 
 // This is a proxy for multiple ETH clients, providing natural fallback support if one of them fails.
+// Each endpoint is given a health score from its sync status, chain head, latency, and recent failures, and requests go to the healthiest client.
 type ExecutionClientManager struct {
-	primaryEcUrl    string
-	fallbackEcUrl   string
-	primaryEc       *ethclient.Client
-	fallbackEc      *ethclient.Client
+	primary         *ecPool
+	fallback        *ecPool
+	read            *ecPool
+	readReady       bool
+	archive         *ecPool
+	extra           *ecPool
+	extraReady      bool
+	healthChecks    sync.Once
+	lock            sync.Mutex
+	chainID         uint
+	relayEc         *ethclient.Client
+	relayTimeout    time.Duration
+	relayQueuePath  string
+	waitForRelay    bool
 	logger          log.ColorLogger
 	primaryReady    bool
 	fallbackReady   bool
 	ignoreSyncCheck bool
+	notifier        *notifications.Notifier
+	clock           clock.Clock
+}
+
+// The interval to check whether a privately relayed transaction has been included
+const privateRelayPollInterval = 6 * time.Second
+
+// The penalty added to the health score of each client after the primary, so a healthy primary is preferred over a slightly faster fallback
+const ecClientTierPenalty float64 = 500
+
+// A client the manager routes requests to
+type routedPool struct {
+	pool  *ecPool
+	ready *bool
+}
+
+// A transaction that is waiting in an execution client's mempool
+type PendingTransaction struct {
+	Hash      common.Hash     `json:"hash"`
+	From      common.Address  `json:"from"`
+	To        *common.Address `json:"to"`
+	Nonce     hexutil.Uint64  `json:"nonce"`
+	Gas       hexutil.Uint64  `json:"gas"`
+	GasPrice  *hexutil.Big    `json:"gasPrice"`
+	GasFeeCap *hexutil.Big    `json:"maxFeePerGas"`
+	GasTipCap *hexutil.Big    `json:"maxPriorityFeePerGas"`
+	Value     *hexutil.Big    `json:"value"`
+	Input     hexutil.Bytes   `json:"input"`
 }
 
 // This is a signature for a wrapped ethclient.Client function
@@ -36,48 +85,106 @@ type clientFunction func(*ethclient.Client) (interface{}, error)
 // Creates a new ExecutionClientManager instance based on the Rocket Pool config
 func NewExecutionClientManager(cfg *config.RocketPoolConfig) (*ExecutionClientManager, error) {
 
-	var primaryEcUrl string
-	var fallbackEcUrl string
+	var primaryEcUrls []string
+	var fallbackEcUrls []string
+	var primaryMaxRequests uint64
+	var fallbackMaxRequests uint64
+	primaryRouting := config.EcRoutingMode_Fallback
+	fallbackRouting := config.EcRoutingMode_Fallback
+	logger := log.NewColorLogger(color.FgYellow)
 
-	// Get the primary EC url
+	// Get the primary EC urls
 	if cfg.IsNativeMode {
-		primaryEcUrl = cfg.Native.EcHttpUrl.Value.(string)
-	} else if cfg.ExecutionClientMode.Value.(config.Mode) == config.Mode_Local {
-		primaryEcUrl = fmt.Sprintf("http://%s:%d", config.Eth1ContainerName, cfg.ExecutionCommon.HttpPort.Value)
+		primaryEcUrls = []string{cfg.Native.EcHttpUrl.Value.(string)}
+	} else if cfg.GetExecutionClientMode() == config.Mode_Local {
+		primaryEcUrls = []string{fmt.Sprintf("http://%s:%d", config.Eth1ContainerName, cfg.ExecutionCommon.HttpPort.Value)}
 	} else {
-		primaryEcUrl = cfg.ExternalExecution.HttpUrl.Value.(string)
+		primaryEcUrls = cfg.ExternalExecution.GetHttpUrls()
+		primaryMaxRequests = cfg.ExternalExecution.GetMaxConcurrentRequests()
+		primaryRouting = cfg.ExternalExecution.GetRoutingMode()
 	}
 
-	// Get the fallback EC url, if applicable
+	// Get the fallback EC urls, if applicable
 	if cfg.UseFallbackExecutionClient.Value == true {
-		if cfg.FallbackExecutionClientMode.Value.(config.Mode) == config.Mode_Local {
-			fallbackEcUrl = fmt.Sprintf("http://%s:%d", config.Eth1FallbackContainerName, cfg.FallbackExecutionCommon.HttpPort.Value)
+		if cfg.GetFallbackExecutionClientMode() == config.Mode_Local {
+			fallbackEcUrls = []string{fmt.Sprintf("http://%s:%d", config.Eth1FallbackContainerName, cfg.FallbackExecutionCommon.HttpPort.Value)}
 		} else {
-			fallbackEcUrl = cfg.FallbackExternalExecution.HttpUrl.Value.(string)
+			fallbackEcUrls = cfg.FallbackExternalExecution.GetHttpUrls()
+			fallbackMaxRequests = cfg.FallbackExternalExecution.GetMaxConcurrentRequests()
+			fallbackRouting = cfg.FallbackExternalExecution.GetRoutingMode()
 		}
 	}
 
-	primaryEc, err := ethclient.Dial(primaryEcUrl)
+	primary, err := newEcPool("primary", primaryEcUrls, primaryMaxRequests, primaryRouting, logger)
 	if err != nil {
-		return nil, fmt.Errorf("error connecting to primary EC at [%s]: %w", primaryEcUrl, err)
+		return nil, err
 	}
 
-	var fallbackEc *ethclient.Client
-	if fallbackEcUrl != "" {
-		fallbackEc, err = ethclient.Dial(fallbackEcUrl)
+	var fallback *ecPool
+	if len(fallbackEcUrls) > 0 && fallbackEcUrls[0] != "" {
+		fallback, err = newEcPool("fallback", fallbackEcUrls, fallbackMaxRequests, fallbackRouting, logger)
 		if err != nil {
-			return nil, fmt.Errorf("error connecting to fallback EC at [%s]: %w", fallbackEcUrl, err)
+			return nil, err
 		}
 	}
 
+	// Connect to the endpoints for heavy reads, if applicable
+	var read *ecPool
+	if readEcUrls := cfg.Smartnode.GetReadEcUrls(); len(readEcUrls) > 0 {
+		read, err = newEcPool("read", readEcUrls, 0, config.EcRoutingMode_Fallback, logger)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Connect to the archive node for historical state, if applicable
+	var archive *ecPool
+	if archiveEcUrl := cfg.Smartnode.GetArchiveEcUrl(); archiveEcUrl != "" {
+		archive, err = newEcPool("archive", []string{archiveEcUrl}, 0, config.EcRoutingMode_Fallback, logger)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Connect to the extra endpoints, if applicable
+	var extra *ecPool
+	if extraEcUrls := cfg.Smartnode.GetExtraEcUrls(); len(extraEcUrls) > 0 {
+		extra, err = newEcPool("extra", extraEcUrls, 0, config.EcRoutingMode_Fallback, logger)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Connect to the private relay, if applicable
+	var relayEc *ethclient.Client
+	var relayTimeout time.Duration
+	var relayQueuePath string
+	if cfg.Smartnode.UsePrivateRelay.Value == true {
+		relayUrl := cfg.Smartnode.GetPrivateRelayUrl()
+		relayEc, err = ethclient.Dial(relayUrl)
+		if err != nil {
+			return nil, fmt.Errorf("error connecting to private relay at [%s]: %w", relayUrl, err)
+		}
+		relayTimeout = time.Duration(cfg.Smartnode.GetPrivateRelayTimeout()) * time.Second
+		relayQueuePath = os.ExpandEnv(cfg.Smartnode.GetPrivateRelayQueuePath())
+	}
+
 	return &ExecutionClientManager{
-		primaryEcUrl:  primaryEcUrl,
-		fallbackEcUrl: fallbackEcUrl,
-		primaryEc:     primaryEc,
-		fallbackEc:    fallbackEc,
-		logger:        log.NewColorLogger(color.FgYellow),
-		primaryReady:  true,
-		fallbackReady: fallbackEc != nil,
+		primary:        primary,
+		fallback:       fallback,
+		read:           read,
+		readReady:      read != nil,
+		archive:        archive,
+		extra:          extra,
+		extraReady:     extra != nil,
+		chainID:        cfg.Smartnode.GetChainID(),
+		relayEc:        relayEc,
+		relayTimeout:   relayTimeout,
+		relayQueuePath: relayQueuePath,
+		logger:         logger,
+		primaryReady:   true,
+		fallbackReady:  fallback != nil,
+		clock:          daemonClock,
 	}, nil
 
 }
@@ -89,7 +196,7 @@ func NewExecutionClientManager(cfg *config.RocketPoolConfig) (*ExecutionClientMa
 // CodeAt returns the code of the given account. This is needed to differentiate
 // between contract internal errors and the local chain being out of sync.
 func (p *ExecutionClientManager) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error) {
-	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+	result, err := p.runHistoricalFunction(blockNumber, func(client *ethclient.Client) (interface{}, error) {
 		return client.CodeAt(ctx, contract, blockNumber)
 	})
 	if err != nil {
@@ -101,7 +208,7 @@ func (p *ExecutionClientManager) CodeAt(ctx context.Context, contract common.Add
 // CallContract executes an Ethereum contract call with the specified data as the
 // input.
 func (p *ExecutionClientManager) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
-	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+	result, err := p.runHistoricalFunction(blockNumber, func(client *ethclient.Client) (interface{}, error) {
 		return client.CallContract(ctx, call, blockNumber)
 	})
 	if err != nil {
@@ -116,10 +223,18 @@ func (p *ExecutionClientManager) CallContract(ctx context.Context, call ethereum
 
 // HeaderByNumber returns a block header from the current canonical chain. If number is
 // nil, the latest known header is returned.
+// Headers of specific blocks are read from the read endpoints when they're configured.
 func (p *ExecutionClientManager) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
-	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+	function := func(client *ethclient.Client) (interface{}, error) {
 		return client.HeaderByNumber(ctx, number)
-	})
+	}
+	var result interface{}
+	var err error
+	if number == nil || number.Sign() < 0 {
+		result, err = p.runFunction(function)
+	} else {
+		result, err = p.runReadFunction(function)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -188,10 +303,32 @@ func (p *ExecutionClientManager) EstimateGas(ctx context.Context, call ethereum.
 }
 
 // SendTransaction injects the transaction into the pending pool for execution.
+// If a private relay is enabled, the transaction is sent there first and only broadcast
+// to the public mempool if it isn't included before the relay timeout.
+// The daemons wait for the relay themselves; everything else returns as soon as the relay accepts it and leaves the wait to the node daemon.
 func (p *ExecutionClientManager) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	if p.relayEc != nil {
+		var err error
+		if p.waitForRelay {
+			err = p.sendPrivateTransaction(ctx, tx)
+		} else {
+			err = p.submitPrivateTransaction(ctx, tx)
+		}
+		if err == nil {
+			return nil
+		}
+		p.logger.Printlnf("WARNING: Private relay submission of transaction %s failed (%s), sending it to the public mempool...", tx.Hash().Hex(), err.Error())
+	}
+
 	_, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
 		return nil, client.SendTransaction(ctx, tx)
 	})
+	if err != nil && p.relayEc != nil {
+		// The relay may have gotten the transaction included right after the timeout
+		if _, receiptErr := p.TransactionReceipt(ctx, tx.Hash()); receiptErr == nil {
+			return nil
+		}
+	}
 	return err
 }
 
@@ -203,8 +340,9 @@ func (p *ExecutionClientManager) SendTransaction(ctx context.Context, tx *types.
 // returning all the results in one batch.
 //
 // TODO(karalabe): Deprecate when the subscription one can return past data too.
+// Event scans can cover a lot of blocks, so they go to the read endpoints when they're configured.
 func (p *ExecutionClientManager) FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error) {
-	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+	result, err := p.runReadFunction(func(client *ethclient.Client) (interface{}, error) {
 		return client.FilterLogs(ctx, query)
 	})
 	if err != nil {
@@ -259,7 +397,7 @@ func (p *ExecutionClientManager) BlockNumber(ctx context.Context) (uint64, error
 // BalanceAt returns the wei balance of the given account.
 // The block number can be nil, in which case the balance is taken from the latest known block.
 func (p *ExecutionClientManager) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
-	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+	result, err := p.runHistoricalFunction(blockNumber, func(client *ethclient.Client) (interface{}, error) {
 		return client.BalanceAt(ctx, account, blockNumber)
 	})
 	if err != nil {
@@ -289,7 +427,7 @@ func (p *ExecutionClientManager) TransactionByHash(ctx context.Context, hash com
 // NonceAt returns the account nonce of the given account.
 // The block number can be nil, in which case the nonce is taken from the latest known block.
 func (p *ExecutionClientManager) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
-	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+	result, err := p.runHistoricalFunction(blockNumber, func(client *ethclient.Client) (interface{}, error) {
 		return client.NonceAt(ctx, account, blockNumber)
 	})
 	if err != nil {
@@ -310,14 +448,125 @@ func (p *ExecutionClientManager) SyncProgress(ctx context.Context) (*ethereum.Sy
 	return result.(*ethereum.SyncProgress), err
 }
 
+// GetPendingTransactions retrieves the transactions in the active client's mempool that
+// are ready for inclusion. This relies on the txpool namespace, which is not available
+// on every client or provider.
+func (p *ExecutionClientManager) GetPendingTransactions(ctx context.Context) ([]PendingTransaction, error) {
+	var content map[string]map[string]map[string]PendingTransaction
+	if err := p.callDirect(ctx, &content, "txpool_content"); err != nil {
+		return nil, err
+	}
+
+	// Queued transactions are blocked by a nonce gap, so only include the pending ones
+	txs := []PendingTransaction{}
+	for _, accountTxs := range content["pending"] {
+		for _, tx := range accountTxs {
+			txs = append(txs, tx)
+		}
+	}
+	return txs, nil
+}
+
+// GetAccountTxPoolContent retrieves the pending and queued transactions from the given
+// account in the active client's mempool. Queued transactions can't be included until
+// the nonce gap in front of them has been filled.
+func (p *ExecutionClientManager) GetAccountTxPoolContent(ctx context.Context, account common.Address) ([]PendingTransaction, []PendingTransaction, error) {
+	var content map[string]map[string]PendingTransaction
+	if err := p.callDirect(ctx, &content, "txpool_contentFrom", account); err != nil {
+		return nil, nil, err
+	}
+
+	pending := []PendingTransaction{}
+	for _, tx := range content["pending"] {
+		pending = append(pending, tx)
+	}
+	queued := []PendingTransaction{}
+	for _, tx := range content["queued"] {
+		queued = append(queued, tx)
+	}
+	return pending, queued, nil
+}
+
+// FeeHistory retrieves the base fees of the recent blocks, and the priority fees paid in them at the
+// given percentiles, from the active client.
+func (p *ExecutionClientManager) FeeHistory(ctx context.Context, blockCount uint64, rewardPercentiles []float64) (*feehistory.FeeHistory, error) {
+	var history feehistory.FeeHistory
+	if err := p.callDirect(ctx, &history, "eth_feeHistory", hexutil.EncodeUint64(blockCount), "latest", rewardPercentiles); err != nil {
+		return nil, err
+	}
+	return &history, nil
+}
+
+// FeeHistoryAt retrieves the base fees of the blocks ending at the given block, and the priority fees
+// paid in them at the given percentiles, from the active client.
+func (p *ExecutionClientManager) FeeHistoryAt(ctx context.Context, blockCount uint64, newestBlock uint64, rewardPercentiles []float64) (*feehistory.FeeHistory, error) {
+	var history feehistory.FeeHistory
+	if err := p.callDirect(ctx, &history, "eth_feeHistory", hexutil.EncodeUint64(blockCount), hexutil.EncodeUint64(newestBlock), rewardPercentiles); err != nil {
+		return nil, err
+	}
+	return &history, nil
+}
+
 /// ==================
 /// Internal functions
 /// ==================
 
+// Calls a method the ethclient doesn't expose, such as the txpool namespace, directly on the active client.
+func (p *ExecutionClientManager) callDirect(ctx context.Context, result interface{}, method string, args ...interface{}) error {
+
+	// Get the URL of the client currently in use
+	endpoint := p.getActiveEndpoint()
+	if endpoint == nil {
+		return fmt.Errorf("no execution clients were ready")
+	}
+
+	client, err := rpc.DialContext(ctx, endpoint.url)
+	if err != nil {
+		return fmt.Errorf("error connecting to execution client: %w", err)
+	}
+	defer client.Close()
+
+	if err := client.CallContext(ctx, result, method, args...); err != nil {
+		return fmt.Errorf("error calling %s: %w", method, err)
+	}
+	return nil
+
+}
+
+// Sends a transaction to the private relay and waits for it to be included in a block
+func (p *ExecutionClientManager) sendPrivateTransaction(ctx context.Context, tx *types.Transaction) error {
+
+	if err := p.relayEc.SendTransaction(ctx, tx); err != nil {
+		return err
+	}
+
+	// The relay doesn't broadcast the transaction, so it can't be tracked in the mempool; watch for the receipt instead
+	deadline := p.clock.Now().Add(p.relayTimeout)
+	for {
+		remaining := p.clock.Until(deadline)
+		if remaining <= 0 {
+			return fmt.Errorf("transaction was not included within %s", p.relayTimeout)
+		}
+		wait := privateRelayPollInterval
+		if remaining < wait {
+			wait = remaining
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-p.clock.After(wait):
+		}
+		if _, err := p.TransactionReceipt(ctx, tx.Hash()); err == nil {
+			return nil
+		}
+	}
+
+}
+
 func (p *ExecutionClientManager) CheckStatus(alwaysCheckFallback bool) *api.ExecutionClientManagerStatus {
 
 	status := &api.ExecutionClientManagerStatus{
-		FallbackEnabled: p.fallbackEc != nil,
+		FallbackEnabled: p.fallback != nil,
 	}
 
 	// Ignore the sync check and just use the predefined settings if requested
@@ -328,27 +577,131 @@ func (p *ExecutionClientManager) CheckStatus(alwaysCheckFallback bool) *api.Exec
 			status.FallbackEcStatus.IsWorking = p.fallbackReady
 			status.FallbackEcStatus.IsSynced = p.fallbackReady
 		}
+		if p.read != nil {
+			status.ReadEcEnabled = true
+			status.ReadEcStatus.IsWorking = p.readReady
+			status.ReadEcStatus.IsSynced = p.readReady
+		}
+		if p.extra != nil {
+			status.ExtraEcEnabled = true
+			status.ExtraEcStatus.IsWorking = p.extraReady
+			status.ExtraEcStatus.IsSynced = p.extraReady
+		}
 		return status
 	}
 
 	// Get the primary EC status
-	status.PrimaryEcStatus = checkClientStatus(p.primaryEc)
+	status.PrimaryEcStatus = p.primary.checkStatus(p.checkClientStatus)
 
 	// Get the fallback EC status if applicable
 	if status.FallbackEnabled {
 		if alwaysCheckFallback || !status.PrimaryEcStatus.IsSynced {
-			status.FallbackEcStatus = checkClientStatus(p.fallbackEc)
+			status.FallbackEcStatus = p.fallback.checkStatus(p.checkClientStatus)
 		}
 	}
 
+	// Get the extra endpoints' status if applicable
+	if p.extra != nil {
+		status.ExtraEcEnabled = true
+		if alwaysCheckFallback || !status.PrimaryEcStatus.IsSynced {
+			status.ExtraEcStatus = p.extra.checkStatus(p.checkClientStatus)
+		}
+	}
+
+	// Get the read endpoints' status if applicable
+	if p.read != nil {
+		status.ReadEcEnabled = true
+		status.ReadEcStatus = p.read.checkStatus(p.checkReadEndpoint)
+		p.readReady = (status.ReadEcStatus.IsWorking && status.ReadEcStatus.IsSynced)
+	}
+
 	// Flag the ready clients
+	p.lock.Lock()
 	p.primaryReady = (status.PrimaryEcStatus.IsWorking && status.PrimaryEcStatus.IsSynced)
 	p.fallbackReady = (status.FallbackEnabled && status.FallbackEcStatus.IsWorking && status.FallbackEcStatus.IsSynced)
+	p.extraReady = (status.ExtraEcEnabled && status.ExtraEcStatus.IsWorking && status.ExtraEcStatus.IsSynced)
+	primaryReady := p.primaryReady
+	otherReady := p.fallbackReady || p.extraReady
+	p.lock.Unlock()
+
+	// Report the health of each endpoint
+	head := p.getBestHead()
+	for _, pool := range []*ecPool{p.primary, p.fallback, p.extra} {
+		if pool != nil {
+			status.Endpoints = append(status.Endpoints, pool.getEndpointStatuses(head)...)
+		}
+	}
+
+	// Report which client is being used
+	if primaryReady {
+		p.notifier.Resolve(notifications.EventType_FallbackActivated)
+	} else if otherReady {
+		reason := status.PrimaryEcStatus.Error
+		if reason == "" {
+			reason = "not synced"
+		}
+		p.notifyFallbackActivated(reason)
+	}
 
 	return status
 
 }
 
+// Check the status of a client endpoint, batching the checks into one request if its requests are limited
+func (p *ExecutionClientManager) checkClientStatus(endpoint *ecEndpoint) api.ExecutionClientStatus {
+	if endpoint.limiter == nil {
+		return checkClientStatus(endpoint.ec)
+	}
+	endpoint.limiter <- struct{}{}
+	defer func() { <-endpoint.limiter }()
+	return checkClientStatusBatched(endpoint.rpc)
+}
+
+// Check the status of a read endpoint, including whether it can serve the reads that are sent to it
+func (p *ExecutionClientManager) checkReadEndpoint(endpoint *ecEndpoint) api.ExecutionClientStatus {
+	status := p.checkClientStatus(endpoint)
+	if !status.IsWorking || !status.IsSynced {
+		return status
+	}
+	if err := p.checkReadCapabilities(endpoint.ec); err != nil {
+		status.IsWorking = false
+		status.IsSynced = false
+		status.Error = err.Error()
+	}
+	return status
+}
+
+// Make sure a read endpoint is on the same chain as the Smartnode and supports event queries
+func (p *ExecutionClientManager) checkReadCapabilities(client *ethclient.Client) error {
+
+	ctx := context.Background()
+	if p.chainID != 0 {
+		chainID, err := client.ChainID(ctx)
+		if err != nil {
+			return fmt.Errorf("Chain ID check failed with [%s]", err.Error())
+		}
+		if chainID.Uint64() != uint64(p.chainID) {
+			return fmt.Errorf("Endpoint is on chain %d, but the Smartnode is configured for chain %d", chainID.Uint64(), p.chainID)
+		}
+	}
+
+	// Query the events of the latest block for an address that doesn't emit any
+	latestHeader, err := client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("Latest block check failed with [%s]", err.Error())
+	}
+	_, err = client.FilterLogs(ctx, ethereum.FilterQuery{
+		FromBlock: latestHeader.Number,
+		ToBlock:   latestHeader.Number,
+		Addresses: []common.Address{{}},
+	})
+	if err != nil {
+		return fmt.Errorf("Endpoint doesn't support event queries [%s]", err.Error())
+	}
+	return nil
+
+}
+
 // Check the client status
 func checkClientStatus(client *ethclient.Client) api.ExecutionClientStatus {
 
@@ -405,51 +758,237 @@ func checkClientStatus(client *ethclient.Client) api.ExecutionClientStatus {
 
 }
 
-// Attempts to run a function progressively through each client until one succeeds or they all fail.
-func (p *ExecutionClientManager) runFunction(function clientFunction) (interface{}, error) {
+// Check the client status with a single batch request for its sync progress and latest block, for rate-limited clients
+func checkClientStatusBatched(rpcClient *rpc.Client) api.ExecutionClientStatus {
 
-	// Check if we can use the primary
-	if p.primaryReady {
-		// Try to run the function on the primary
-		result, err := function(p.primaryEc)
-		if err != nil {
-			if isDisconnected(err) {
-				// If it's disconnected, log it and try the fallback
-				p.logger.Printlnf("WARNING: Primary execution client disconnected (%s), using fallback...", err.Error())
-				p.primaryReady = false
-				return p.runFunction(function)
-			} else {
-				// If it's a different error, just return it
-				return nil, err
+	status := api.ExecutionClientStatus{}
+
+	var syncing json.RawMessage
+	var latestHeader *types.Header
+	batch := []rpc.BatchElem{
+		{Method: "eth_syncing", Result: &syncing},
+		{Method: "eth_getBlockByNumber", Args: []interface{}{"latest", false}, Result: &latestHeader},
+	}
+	err := rpcClient.BatchCallContext(context.Background(), batch)
+	if err == nil {
+		err = batch[0].Error
+	}
+	if err != nil {
+		status.Error = fmt.Sprintf("Sync progress check failed with [%s]", err.Error())
+		return status
+	}
+	status.IsWorking = true
+
+	// The result is false if the client isn't syncing, or its progress if it is
+	var isSyncing bool
+	if json.Unmarshal(syncing, &isSyncing) == nil && !isSyncing {
+		if batch[1].Error != nil || latestHeader == nil {
+			status.IsWorking = false
+			status.Error = "Error checking if client's sync progress is up to date: [could not get the latest block]"
+			if batch[1].Error != nil {
+				status.Error = fmt.Sprintf("Error checking if client's sync progress is up to date: [%s]", batch[1].Error.Error())
 			}
-		} else {
-			// If there's no error, return the result
-			return result, nil
+			return status
 		}
-	} else if p.fallbackReady {
-		// Try to run the function on the fallback
-		result, err := function(p.fallbackEc)
-		if err != nil {
-			if isDisconnected(err) {
-				// If it's disconnected, log it and try the fallback
-				p.logger.Printlnf("WARNING: Fallback execution client disconnected (%s)", err.Error())
-				p.fallbackReady = false
-				return nil, fmt.Errorf("all execution clients failed")
-			} else {
-				// If it's a different error, just return it
-				return nil, err
+		blockTime := time.Unix(int64(latestHeader.Time), 0)
+		if time.Since(blockTime) >= ethClientRecentBlockThreshold {
+			status.Error = fmt.Sprintf("Client claims to have finished syncing, but its last block was from %s ago. It likely doesn't have enough peers", time.Since(blockTime))
+			return status
+		}
+		status.IsSynced = true
+		status.SyncProgress = 1
+		return status
+	}
+	var progress struct {
+		CurrentBlock hexutil.Uint64 `json:"currentBlock"`
+		HighestBlock hexutil.Uint64 `json:"highestBlock"`
+	}
+	if err := json.Unmarshal(syncing, &progress); err != nil {
+		status.IsWorking = false
+		status.Error = fmt.Sprintf("Sync progress check failed with [%s]", err.Error())
+		return status
+	}
+	if progress.HighestBlock > 0 {
+		status.SyncProgress = math.Min(float64(progress.CurrentBlock)/float64(progress.HighestBlock), 1)
+	}
+	return status
+
+}
+
+// Send a batch of requests to the active client in one round trip, which counts as a single request against its limit
+func (p *ExecutionClientManager) BatchCallContext(ctx context.Context, batch []rpc.BatchElem) error {
+	endpoint := p.getActiveEndpoint()
+	if endpoint == nil {
+		return fmt.Errorf("no execution clients were ready")
+	}
+	if endpoint.limiter != nil {
+		endpoint.limiter <- struct{}{}
+		defer func() { <-endpoint.limiter }()
+	}
+	return endpoint.rpc.BatchCallContext(ctx, batch)
+}
+
+// Get the endpoint of the client currently in use that the next request should go to
+func (p *ExecutionClientManager) getActiveEndpoint() *ecEndpoint {
+	for _, routed := range p.getRoutedPools() {
+		if endpoint := routed.pool.getActive(); endpoint != nil {
+			return endpoint
+		}
+	}
+	return nil
+}
+
+// Get the ready clients in the order requests should try them, from the best health score to the worst
+func (p *ExecutionClientManager) getRoutedPools() []routedPool {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	candidates := []routedPool{
+		{pool: p.primary, ready: &p.primaryReady},
+		{pool: p.fallback, ready: &p.fallbackReady},
+		{pool: p.extra, ready: &p.extraReady},
+	}
+	head := p.getBestHead()
+	routed := make([]routedPool, 0, len(candidates))
+	scores := map[*ecPool]float64{}
+	for tier, candidate := range candidates {
+		if candidate.pool == nil || !*candidate.ready {
+			continue
+		}
+		score, hasReady := candidate.pool.getBestScore(head)
+		if !hasReady {
+			continue
+		}
+		scores[candidate.pool] = score + float64(tier)*ecClientTierPenalty
+		routed = append(routed, candidate)
+	}
+	sort.SliceStable(routed, func(i, j int) bool {
+		return scores[routed[i].pool] < scores[routed[j].pool]
+	})
+	return routed
+}
+
+// Get the highest chain head any of the clients has reported
+func (p *ExecutionClientManager) getBestHead() uint64 {
+	var head uint64
+	for _, pool := range []*ecPool{p.primary, p.fallback, p.extra} {
+		if pool == nil {
+			continue
+		}
+		if poolHead := pool.getBestHead(); poolHead > head {
+			head = poolHead
+		}
+	}
+	return head
+}
+
+// Wait for transactions sent to the private relay to be included before returning from SendTransaction.
+// This is only used by the daemons, so the CLI doesn't block for the whole relay timeout.
+func (p *ExecutionClientManager) WaitForPrivateRelay() {
+	p.waitForRelay = true
+}
+
+// Check the health of every client in the background on an interval, so requests go back to the ones that have recovered between the daemons' task loops.
+// This is only used by the daemons.
+func (p *ExecutionClientManager) StartHealthChecks(interval time.Duration) {
+	if p.ignoreSyncCheck || interval <= 0 {
+		return
+	}
+	p.healthChecks.Do(func() {
+		go func() {
+			for {
+				p.clock.Sleep(interval)
+				p.CheckStatus(true)
 			}
-		} else {
-			// If there's no error, return the result
-			return result, nil
+		}()
+	})
+}
+
+// Create the semaphore that caps the concurrent requests to a client, or nil if they aren't capped
+func newRequestLimiter(maxRequests uint64) chan struct{} {
+	if maxRequests == 0 {
+		return nil
+	}
+	return make(chan struct{}, maxRequests)
+}
+
+// Run a function on a client, waiting for a free request slot first if its requests are limited
+func runLimited(function clientFunction, client *ethclient.Client, limiter chan struct{}) (interface{}, error) {
+	if limiter != nil {
+		limiter <- struct{}{}
+		defer func() { <-limiter }()
+	}
+	return function(client)
+}
+
+// Set the notifier used to report switching to the fallback client; this is only used by the daemons
+func (p *ExecutionClientManager) SetNotifier(notifier *notifications.Notifier) {
+	p.notifier = notifier
+}
+
+// Report that the fallback client has been activated
+func (p *ExecutionClientManager) notifyFallbackActivated(reason string) {
+	if !p.notifier.IsEnabled() {
+		return
+	}
+	go func() {
+		err := p.notifier.Notify(notifications.EventType_FallbackActivated, "Fallback Execution client activated", fmt.Sprintf("The primary Execution client is unavailable (%s), so the fallback client is being used.", reason))
+		if err != nil {
+			p.logger.Printlnf("WARNING: %s", err.Error())
 		}
-	} else {
+	}()
+}
+
+// Attempts to run a function on each client in order of health until one succeeds or they all fail.
+func (p *ExecutionClientManager) runFunction(function clientFunction) (interface{}, error) {
+
+	routed := p.getRoutedPools()
+	if len(routed) == 0 {
 		return nil, fmt.Errorf("no execution clients were ready")
 	}
+	for _, client := range routed {
+		result, err := client.pool.run(function)
+		if err == nil || !isDisconnected(err) {
+			// If there's no error or it's a different error, return it
+			return result, err
+		}
+
+		// If it's disconnected, log it and try the next best client
+		p.logger.Printlnf("WARNING: %s execution client disconnected (%s), retrying on the next healthiest client...", strings.Title(client.pool.label), err.Error())
+		p.lock.Lock()
+		*client.ready = false
+		otherReady := p.fallbackReady || p.extraReady
+		p.lock.Unlock()
+		if client.pool == p.primary && otherReady {
+			p.notifyFallbackActivated("disconnected")
+		}
+	}
+	return nil, fmt.Errorf("all execution clients failed")
+
+}
+
+// Runs a heavy read function on the read endpoints, falling back to the primary and fallback clients if they aren't configured or fail.
+func (p *ExecutionClientManager) runReadFunction(function clientFunction) (interface{}, error) {
+
+	if p.read != nil && p.readReady {
+		result, err := p.read.run(function)
+		if err == nil {
+			return result, nil
+		}
+
+		// Stop using the read endpoints until the next status check if they're down
+		if isDisconnected(err) {
+			p.logger.Printlnf("WARNING: Read execution client endpoints disconnected (%s), using the primary client for reads...", err.Error())
+			p.readReady = false
+		} else {
+			p.logger.Printlnf("WARNING: Read execution client endpoint failed (%s), retrying on the primary client...", err.Error())
+		}
+	}
+	return p.runFunction(function)
 
 }
 
 // Returns true if the error was a connection failure and a backup client is available
 func isDisconnected(err error) bool {
-	return strings.Contains(err.Error(), "dial tcp")
+	return strings.Contains(err.Error(), "dial tcp") || errors.Is(err, errNoReadyEndpoints)
 }
@@ -0,0 +1,337 @@
+package services
+
+import (
+	"context"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/fatih/color"
+
+	"github.com/rocket-pool/smartnode/shared/services/clock"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// An execution client that accepts transactions and only has receipts for the ones that have been marked as mined
+type testEthService struct {
+	lock          sync.Mutex
+	mined         map[common.Hash]bool
+	receiptChecks int
+	sent          int
+}
+
+func (s *testEthService) SendRawTransaction(data hexutil.Bytes) (common.Hash, error) {
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(data); err != nil {
+		return common.Hash{}, err
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.sent++
+	return tx.Hash(), nil
+}
+
+func (s *testEthService) GetTransactionCount(address common.Address, block string) (hexutil.Uint64, error) {
+	return 0, nil
+}
+
+func (s *testEthService) GetTransactionReceipt(hash common.Hash) (*types.Receipt, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.receiptChecks++
+	if !s.mined[hash] {
+		return nil, nil
+	}
+	return &types.Receipt{Status: types.ReceiptStatusSuccessful, TxHash: hash, Logs: []*types.Log{}}, nil
+}
+
+func (s *testEthService) Call(args map[string]interface{}, block string) (hexutil.Bytes, error) {
+	return hexutil.Bytes{1}, nil
+}
+
+func (s *testEthService) GetBalance(address common.Address, block string) (*hexutil.Big, error) {
+	return (*hexutil.Big)(big.NewInt(1)), nil
+}
+
+func (s *testEthService) GetBlockByNumber(number string, full bool) (*types.Header, error) {
+	return &types.Header{Number: big.NewInt(10), Difficulty: big.NewInt(0)}, nil
+}
+
+func (s *testEthService) getSent() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.sent
+}
+
+func (s *testEthService) getReceiptChecks() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.receiptChecks
+}
+
+func (s *testEthService) setMined(hash common.Hash) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.mined[hash] = true
+}
+
+// Serve a test execution client over HTTP, counting the requests it gets
+func newTestEcServer(t *testing.T, service *testEthService, requests *int32) *httptest.Server {
+	server := rpc.NewServer()
+	if err := server.RegisterName("eth", service); err != nil {
+		t.Fatal(err)
+	}
+	httpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(requests, 1)
+		server.ServeHTTP(w, r)
+	}))
+	t.Cleanup(httpServer.Close)
+	return httpServer
+}
+
+// Create a manager that uses the test execution client as its primary client and private relay
+func newTestEcManager(t *testing.T, url string, clk clock.Clock, relayTimeout time.Duration) *ExecutionClientManager {
+	logger := log.NewColorLogger(color.FgYellow)
+	primary, err := newEcPool("primary", []string{url}, 0, config.EcRoutingMode_Fallback, logger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	relayEc, err := ethclient.Dial(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &ExecutionClientManager{
+		primary:      primary,
+		primaryReady: true,
+		relayEc:      relayEc,
+		relayTimeout: relayTimeout,
+		logger:       logger,
+		clock:        clk,
+	}
+}
+
+// Wait for a condition that's reached by another goroutine
+func waitFor(t *testing.T, description string, condition func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", description)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestPrivateTransactionPollsOnTheClock(t *testing.T) {
+	service := &testEthService{mined: map[common.Hash]bool{}}
+	var requests int32
+	server := newTestEcServer(t, service, &requests)
+	clk := clock.NewManualClock(time.Unix(1000000, 0))
+	p := newTestEcManager(t, server.URL, clk, time.Minute)
+
+	tx := types.NewTransaction(0, common.Address{1}, big.NewInt(0), 21000, big.NewInt(1), nil)
+	result := make(chan error, 1)
+	go func() {
+		result <- p.sendPrivateTransaction(context.Background(), tx)
+	}()
+
+	// The receipt is only checked once the poll interval has passed on the clock
+	waitFor(t, "the first poll", func() bool { return clk.Waiters() == 1 })
+	if checks := service.getReceiptChecks(); checks != 0 {
+		t.Fatalf("expected no receipt checks before the poll interval, got %d", checks)
+	}
+	clk.Advance(privateRelayPollInterval)
+	waitFor(t, "the second poll", func() bool { return service.getReceiptChecks() == 1 && clk.Waiters() == 1 })
+
+	// It finishes on the poll after the transaction is mined
+	service.setMined(tx.Hash())
+	clk.Advance(privateRelayPollInterval)
+	select {
+	case err := <-result:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the transaction to be included")
+	}
+	if checks := service.getReceiptChecks(); checks != 2 {
+		t.Fatalf("expected 2 receipt checks, got %d", checks)
+	}
+}
+
+func TestPrivateTransactionTimesOutOnTheClock(t *testing.T) {
+	service := &testEthService{mined: map[common.Hash]bool{}}
+	var requests int32
+	server := newTestEcServer(t, service, &requests)
+	clk := clock.NewManualClock(time.Unix(1000000, 0))
+	p := newTestEcManager(t, server.URL, clk, 10*time.Second)
+
+	tx := types.NewTransaction(0, common.Address{1}, big.NewInt(0), 21000, big.NewInt(1), nil)
+	result := make(chan error, 1)
+	go func() {
+		result <- p.sendPrivateTransaction(context.Background(), tx)
+	}()
+
+	// The last poll is cut short so it happens at the timeout
+	waitFor(t, "the first poll", func() bool { return clk.Waiters() == 1 })
+	clk.Advance(privateRelayPollInterval)
+	waitFor(t, "the last poll", func() bool { return service.getReceiptChecks() == 1 && clk.Waiters() == 1 })
+	clk.Advance(10*time.Second - privateRelayPollInterval)
+	select {
+	case err := <-result:
+		if err == nil || !strings.Contains(err.Error(), "was not included within 10s") {
+			t.Fatalf("expected a timeout error, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the relay timeout")
+	}
+	if checks := service.getReceiptChecks(); checks != 2 {
+		t.Fatalf("expected 2 receipt checks, got %d", checks)
+	}
+}
+
+func TestPrivateTransactionFromTheCliIsRebroadcastByTheDaemon(t *testing.T) {
+	service := &testEthService{mined: map[common.Hash]bool{}}
+	var requests int32
+	server := newTestEcServer(t, service, &requests)
+	clk := clock.NewManualClock(time.Unix(1000000, 0))
+	p := newTestEcManager(t, server.URL, clk, time.Minute)
+	p.relayQueuePath = filepath.Join(t.TempDir(), "private-relay.json")
+
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx, err := types.SignTx(types.NewTransaction(0, common.Address{1}, big.NewInt(0), 21000, big.NewInt(1), nil), types.LatestSignerForChainID(big.NewInt(1)), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Outside the daemons, the transaction is only sent to the relay and there's no waiting for its receipt
+	if err := p.SendTransaction(context.Background(), tx); err != nil {
+		t.Fatal(err)
+	}
+	if sent, checks := service.getSent(), service.getReceiptChecks(); sent != 1 || checks != 0 {
+		t.Fatalf("expected 1 send and no receipt checks, got %d sends and %d checks", sent, checks)
+	}
+
+	// The daemon leaves it with the relay until the timeout, then sends it to the public mempool
+	if err := p.RebroadcastPrivateTransactions(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if sent := service.getSent(); sent != 1 {
+		t.Fatalf("expected the transaction to wait for the relay timeout, got %d sends", sent)
+	}
+	clk.Advance(time.Minute)
+	if err := p.RebroadcastPrivateTransactions(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if sent := service.getSent(); sent != 2 {
+		t.Fatalf("expected the transaction to be sent to the public mempool, got %d sends", sent)
+	}
+
+	// It's only rebroadcast once
+	clk.Advance(time.Minute)
+	if err := p.RebroadcastPrivateTransactions(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if sent := service.getSent(); sent != 2 {
+		t.Fatalf("expected the transaction to be rebroadcast once, got %d sends", sent)
+	}
+}
+
+func TestHealthChecksRunOnTheClock(t *testing.T) {
+	service := &testEthService{mined: map[common.Hash]bool{}}
+	var requests int32
+	server := newTestEcServer(t, service, &requests)
+	clk := clock.NewManualClock(time.Unix(1000000, 0))
+	p := newTestEcManager(t, server.URL, clk, time.Minute)
+
+	p.StartHealthChecks(time.Minute)
+	p.StartHealthChecks(time.Minute)
+	waitFor(t, "the health check loop", func() bool { return clk.Waiters() == 1 })
+	if count := atomic.LoadInt32(&requests); count != 0 {
+		t.Fatalf("expected no status checks before the interval, got %d requests", count)
+	}
+
+	// Each interval on the clock runs one status check, and only one loop is started
+	for i := 0; i < 2; i++ {
+		before := atomic.LoadInt32(&requests)
+		clk.Advance(time.Minute)
+		waitFor(t, "the status check", func() bool { return atomic.LoadInt32(&requests) > before && clk.Waiters() == 1 })
+	}
+}
+
+func TestPinnedReadsUseTheReadEndpoints(t *testing.T) {
+	var primaryRequests, readRequests int32
+	primaryServer := newTestEcServer(t, &testEthService{mined: map[common.Hash]bool{}}, &primaryRequests)
+	readServer := newTestEcServer(t, &testEthService{mined: map[common.Hash]bool{}}, &readRequests)
+	p := newTestEcManager(t, primaryServer.URL, clock.NewManualClock(time.Unix(1000000, 0)), time.Minute)
+	read, err := newEcPool("read", []string{readServer.URL}, 0, config.EcRoutingMode_Fallback, p.logger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.read = read
+	p.readReady = true
+
+	ctx := context.Background()
+	block := big.NewInt(10)
+	reads := map[string]func(blockNumber *big.Int) error{
+		"CallContract": func(blockNumber *big.Int) error {
+			_, err := p.CallContract(ctx, ethereum.CallMsg{To: &common.Address{1}}, blockNumber)
+			return err
+		},
+		"BalanceAt": func(blockNumber *big.Int) error {
+			_, err := p.BalanceAt(ctx, common.Address{1}, blockNumber)
+			return err
+		},
+		"HeaderByNumber": func(blockNumber *big.Int) error {
+			_, err := p.HeaderByNumber(ctx, blockNumber)
+			return err
+		},
+	}
+	for name, read := range reads {
+
+		// Reads pinned to a block go to the read endpoints
+		primaryBefore, readBefore := atomic.LoadInt32(&primaryRequests), atomic.LoadInt32(&readRequests)
+		if err := read(block); err != nil {
+			t.Fatalf("%s: %s", name, err.Error())
+		}
+		if atomic.LoadInt32(&readRequests) != readBefore+1 || atomic.LoadInt32(&primaryRequests) != primaryBefore {
+			t.Errorf("%s: expected the pinned read to go to the read endpoints", name)
+		}
+
+		// Reads of the latest state stay on the primary client
+		primaryBefore, readBefore = atomic.LoadInt32(&primaryRequests), atomic.LoadInt32(&readRequests)
+		if err := read(nil); err != nil {
+			t.Fatalf("%s: %s", name, err.Error())
+		}
+		if atomic.LoadInt32(&primaryRequests) != primaryBefore+1 || atomic.LoadInt32(&readRequests) != readBefore {
+			t.Errorf("%s: expected the latest read to go to the primary client", name)
+		}
+	}
+
+	// The primary client is used if the read endpoints are down
+	readServer.Close()
+	primaryBefore := atomic.LoadInt32(&primaryRequests)
+	if _, err := p.BalanceAt(ctx, common.Address{1}, block); err != nil {
+		t.Fatal(err)
+	}
+	if atomic.LoadInt32(&primaryRequests) != primaryBefore+1 {
+		t.Fatal("expected the pinned read to fall back to the primary client")
+	}
+}
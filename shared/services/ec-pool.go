@@ -0,0 +1,326 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Returned when none of a pool's endpoints are healthy
+var errNoReadyEndpoints = errors.New("no execution client endpoints were ready")
+
+// Health scoring settings; scores are in milliseconds of latency, so the penalties are what each problem is worth in latency
+const (
+	// How much each new latency sample counts towards an endpoint's average
+	ecLatencyWeight float64 = 0.3
+
+	// The penalty for each block an endpoint is behind the best chain head seen
+	ecBlockLagPenalty float64 = 1000
+
+	// The penalty for each recent failure; it halves with every successful request
+	ecFailurePenalty float64 = 250
+
+	// The penalty for each place an endpoint is down the configured order, so the order breaks ties between healthy endpoints
+	ecOrderPenalty float64 = 50
+)
+
+// One HTTP endpoint of an Execution client
+type ecEndpoint struct {
+	url      string
+	name     string
+	ec       *ethclient.Client
+	rpc      *rpc.Client
+	limiter  chan struct{}
+	ready    bool
+	index    int
+	latency  float64
+	head     uint64
+	failures float64
+}
+
+// A set of endpoints for the same chain that requests are routed across, skipping the ones that are down or out of sync.
+// Most setups only have one endpoint per client; extra ones come from the external client's Additional HTTP URLs.
+type ecPool struct {
+	label       string
+	endpoints   []*ecEndpoint
+	roundRobin  bool
+	next        int
+	lock        sync.Mutex
+	logger      log.ColorLogger
+	multiplexed bool
+}
+
+// Connect to each of a client's endpoints
+func newEcPool(label string, urls []string, maxRequests uint64, mode config.EcRoutingMode, logger log.ColorLogger) (*ecPool, error) {
+	pool := &ecPool{
+		label:       label,
+		roundRobin:  (mode == config.EcRoutingMode_RoundRobin),
+		logger:      logger,
+		multiplexed: len(urls) > 1,
+	}
+	for i, ecUrl := range urls {
+		rpcClient, err := rpc.Dial(ecUrl)
+		if err != nil {
+			return nil, fmt.Errorf("error connecting to %s EC at [%s]: %w", label, ecUrl, err)
+		}
+		pool.endpoints = append(pool.endpoints, &ecEndpoint{
+			url:     ecUrl,
+			name:    getEndpointName(ecUrl, i),
+			ec:      ethclient.NewClient(rpcClient),
+			rpc:     rpcClient,
+			limiter: newRequestLimiter(maxRequests),
+			ready:   true,
+			index:   i,
+		})
+	}
+	return pool, nil
+}
+
+// Get the ready endpoints in the order requests should try them
+func (pool *ecPool) getOrder() []*ecEndpoint {
+	pool.lock.Lock()
+	defer pool.lock.Unlock()
+
+	ready := make([]*ecEndpoint, 0, len(pool.endpoints))
+	for _, endpoint := range pool.endpoints {
+		if endpoint.ready {
+			ready = append(ready, endpoint)
+		}
+	}
+	if len(ready) < 2 {
+		return ready
+	}
+	if !pool.roundRobin {
+		// Try the healthiest endpoint first
+		head := pool.getBestHeadLocked()
+		sort.SliceStable(ready, func(i, j int) bool {
+			return ready[i].getScore(head) < ready[j].getScore(head)
+		})
+		return ready
+	}
+
+	// Start from the next endpoint in turn, keeping the others after it as fallbacks
+	start := pool.next % len(ready)
+	pool.next++
+	order := make([]*ecEndpoint, 0, len(ready))
+	order = append(order, ready[start:]...)
+	return append(order, ready[:start]...)
+}
+
+// Get the endpoint the next request should go to, or nil if none are ready
+func (pool *ecPool) getActive() *ecEndpoint {
+	order := pool.getOrder()
+	if len(order) == 0 {
+		return nil
+	}
+	return order[0]
+}
+
+// Get the client of the pool's first endpoint, which is the one its status describes when none of them are healthy
+func (pool *ecPool) getMainClient() *ethclient.Client {
+	return pool.endpoints[0].ec
+}
+
+// Run a function on the pool's endpoints, moving on to the next one when an endpoint is disconnected
+func (pool *ecPool) run(function clientFunction) (interface{}, error) {
+	order := pool.getOrder()
+	if len(order) == 0 {
+		return nil, errNoReadyEndpoints
+	}
+	var err error
+	for _, endpoint := range order {
+		var result interface{}
+		start := time.Now()
+		result, err = runLimited(function, endpoint.ec, endpoint.limiter)
+		pool.record(endpoint, time.Since(start), err != nil && isDisconnected(err))
+		if err == nil || !isDisconnected(err) {
+			return result, err
+		}
+		if pool.multiplexed {
+			pool.setReady(endpoint, false, err.Error())
+		}
+	}
+	return nil, err
+}
+
+// Check the health of each endpoint, returning the status of the one requests will go to first
+func (pool *ecPool) checkStatus(checker func(*ecEndpoint) api.ExecutionClientStatus) api.ExecutionClientStatus {
+
+	statuses := make([]api.ExecutionClientStatus, len(pool.endpoints))
+	var wg sync.WaitGroup
+	for i, endpoint := range pool.endpoints {
+		wg.Add(1)
+		go func(i int, endpoint *ecEndpoint) {
+			defer wg.Done()
+			statuses[i] = checker(endpoint)
+			if statuses[i].IsWorking {
+				pool.probe(endpoint)
+			}
+		}(i, endpoint)
+	}
+	wg.Wait()
+
+	// Flag the healthy endpoints
+	for i, endpoint := range pool.endpoints {
+		status := statuses[i]
+		pool.setReady(endpoint, status.IsWorking && status.IsSynced, status.Error)
+	}
+	for _, status := range statuses {
+		if status.IsWorking && status.IsSynced {
+			return status
+		}
+	}
+
+	// None of them are healthy, so report why
+	if !pool.multiplexed {
+		return statuses[0]
+	}
+	errs := make([]string, len(statuses))
+	for i, status := range statuses {
+		errs[i] = fmt.Sprintf("%s: %s", pool.endpoints[i].name, status.Error)
+	}
+	status := statuses[0]
+	status.Error = strings.Join(errs, "; ")
+	return status
+
+}
+
+// Set whether an endpoint is ready, logging the change when the pool has more than one
+func (pool *ecPool) setReady(endpoint *ecEndpoint, ready bool, reason string) {
+	pool.lock.Lock()
+	defer pool.lock.Unlock()
+
+	if endpoint.ready == ready {
+		return
+	}
+	endpoint.ready = ready
+	if !pool.multiplexed {
+		return
+	}
+	if ready {
+		pool.logger.Printlnf("The %s execution client endpoint %s is healthy again.", pool.label, endpoint.name)
+	} else {
+		pool.logger.Printlnf("WARNING: The %s execution client endpoint %s is unavailable (%s), routing requests to the other endpoints...", pool.label, endpoint.name, reason)
+	}
+}
+
+// Get the chain head and latency of an endpoint for its health score
+func (pool *ecPool) probe(endpoint *ecEndpoint) {
+	start := time.Now()
+	head, err := runLimited(func(client *ethclient.Client) (interface{}, error) {
+		return client.BlockNumber(context.Background())
+	}, endpoint.ec, endpoint.limiter)
+	pool.record(endpoint, time.Since(start), err != nil)
+	if err == nil {
+		pool.lock.Lock()
+		endpoint.head = head.(uint64)
+		pool.lock.Unlock()
+	}
+}
+
+// Record the outcome of a request to an endpoint
+func (pool *ecPool) record(endpoint *ecEndpoint, latency time.Duration, failed bool) {
+	pool.lock.Lock()
+	defer pool.lock.Unlock()
+
+	if failed {
+		endpoint.failures++
+		return
+	}
+	endpoint.failures /= 2
+	sample := float64(latency.Milliseconds())
+	if endpoint.latency == 0 {
+		endpoint.latency = sample
+	} else {
+		endpoint.latency += (sample - endpoint.latency) * ecLatencyWeight
+	}
+}
+
+// Get the best health score of the pool's ready endpoints against a chain head, and whether any are ready
+func (pool *ecPool) getBestScore(head uint64) (float64, bool) {
+	pool.lock.Lock()
+	defer pool.lock.Unlock()
+
+	var best float64
+	found := false
+	for _, endpoint := range pool.endpoints {
+		if !endpoint.ready {
+			continue
+		}
+		if score := endpoint.getScore(head); !found || score < best {
+			best = score
+			found = true
+		}
+	}
+	return best, found
+}
+
+// Get the highest chain head any of the pool's endpoints has reported
+func (pool *ecPool) getBestHead() uint64 {
+	pool.lock.Lock()
+	defer pool.lock.Unlock()
+	return pool.getBestHeadLocked()
+}
+
+// Get the highest chain head any of the pool's endpoints has reported; the pool's lock must be held
+func (pool *ecPool) getBestHeadLocked() uint64 {
+	var head uint64
+	for _, endpoint := range pool.endpoints {
+		if endpoint.head > head {
+			head = endpoint.head
+		}
+	}
+	return head
+}
+
+// Get the health of each endpoint in the pool
+func (pool *ecPool) getEndpointStatuses(head uint64) []api.ExecutionClientEndpointStatus {
+	pool.lock.Lock()
+	defer pool.lock.Unlock()
+
+	statuses := make([]api.ExecutionClientEndpointStatus, 0, len(pool.endpoints))
+	for _, endpoint := range pool.endpoints {
+		status := api.ExecutionClientEndpointStatus{
+			Client:    pool.label,
+			Name:      endpoint.name,
+			Ready:     endpoint.ready,
+			Score:     endpoint.getScore(head),
+			LatencyMs: endpoint.latency,
+			Failures:  endpoint.failures,
+		}
+		if head > endpoint.head {
+			status.BlocksBehind = head - endpoint.head
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
+
+// Get an endpoint's health score against the best chain head seen; lower is better
+func (endpoint *ecEndpoint) getScore(head uint64) float64 {
+	score := endpoint.latency + endpoint.failures*ecFailurePenalty + float64(endpoint.index)*ecOrderPenalty
+	if head > endpoint.head {
+		score += float64(head-endpoint.head) * ecBlockLagPenalty
+	}
+	return score
+}
+
+// Get a name for an endpoint that's safe to log, since URLs often contain API keys
+func getEndpointName(ecUrl string, index int) string {
+	parsed, err := url.Parse(ecUrl)
+	if err != nil || parsed.Host == "" {
+		return fmt.Sprintf("#%d", index+1)
+	}
+	return fmt.Sprintf("#%d (%s)", index+1, parsed.Hostname())
+}
@@ -0,0 +1,128 @@
+package events
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// The types of events the daemons publish
+type EventType string
+
+const (
+	EventType_TaskStarted          EventType = "task-started"
+	EventType_TaskCompleted        EventType = "task-completed"
+	EventType_TaskFailed           EventType = "task-failed"
+	EventType_TransactionSubmitted EventType = "tx-submitted"
+	EventType_TransactionMined     EventType = "tx-mined"
+	EventType_Error                EventType = "error"
+	EventType_ClientLog            EventType = "client-log"
+)
+
+// Settings
+const (
+	// The number of events buffered for each subscriber; events are dropped for subscribers that fall further behind
+	subscriberBufferSize int = 256
+)
+
+// Something that happened in a daemon
+type Event struct {
+	Type    EventType
+	Time    time.Time
+	Daemon  string
+	Task    string
+	Message string
+	TxHash  string
+}
+
+// Delivers the events published by a daemon to its subscribers
+type Bus struct {
+	daemon      string
+	subscribers map[chan Event]bool
+	lock        sync.Mutex
+}
+
+// The bus of this process, which the daemons and the utilities they share publish to
+var defaultBus = NewBus("")
+
+// Create a new event bus for a daemon
+func NewBus(daemon string) *Bus {
+	return &Bus{
+		daemon:      daemon,
+		subscribers: map[chan Event]bool{},
+	}
+}
+
+// Set the name of the daemon running in this process, which is included in every event
+func SetDaemon(daemon string) {
+	defaultBus.lock.Lock()
+	defer defaultBus.lock.Unlock()
+	defaultBus.daemon = daemon
+}
+
+// Get the event bus of this process
+func GetBus() *Bus {
+	return defaultBus
+}
+
+// Publish an event to the bus of this process
+func Publish(eventType EventType, task string, message string) {
+	defaultBus.Publish(Event{
+		Type:    eventType,
+		Task:    task,
+		Message: message,
+	})
+}
+
+// Publish a transaction event to the bus of this process
+func PublishTransaction(eventType EventType, hash common.Hash) {
+	defaultBus.Publish(Event{
+		Type:   eventType,
+		TxHash: hash.Hex(),
+	})
+}
+
+// Run a task, publishing when it starts and finishes to the bus of this process
+func RunTask(task string, run func() error) error {
+	Publish(EventType_TaskStarted, task, "")
+	if err := run(); err != nil {
+		Publish(EventType_TaskFailed, task, err.Error())
+		return err
+	}
+	Publish(EventType_TaskCompleted, task, "")
+	return nil
+}
+
+// Publish an event to every subscriber
+func (b *Bus) Publish(event Event) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if event.Time.IsZero() {
+		event.Time = time.Now().UTC()
+	}
+	event.Daemon = b.daemon
+	for subscriber := range b.subscribers {
+		select {
+		case subscriber <- event:
+		default:
+		}
+	}
+}
+
+// Subscribe to the bus's events.
+// Returns the channel the events are delivered on, and a function that ends the subscription.
+func (b *Bus) Subscribe() (<-chan Event, func()) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	subscriber := make(chan Event, subscriberBufferSize)
+	b.subscribers[subscriber] = true
+	return subscriber, func() {
+		b.lock.Lock()
+		defer b.lock.Unlock()
+		if b.subscribers[subscriber] {
+			delete(b.subscribers, subscriber)
+			close(subscriber)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package events
+
+import (
+	"fmt"
+	"net"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// The gRPC service the daemons stream their events over; see events.proto for its definition.
+// Events are sent as google.protobuf.Struct messages so clients don't need generated code for them.
+type EventsServer interface {
+	Subscribe(*emptypb.Empty, grpc.ServerStream) error
+}
+
+var eventsServiceDesc = grpc.ServiceDesc{
+	ServiceName: "rocketpool.events.Events",
+	HandlerType: (*EventsServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Subscribe",
+			Handler:       subscribeHandler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "events.proto",
+}
+
+// Streams the events of a bus to gRPC clients
+type eventsServer struct {
+	bus *Bus
+}
+
+// Serve the events of this process's bus over gRPC; this blocks until the server stops
+func ServeGrpc(address string, port uint16) error {
+	listener, err := net.Listen("tcp", fmt.Sprintf("%s:%d", address, port))
+	if err != nil {
+		return fmt.Errorf("Could not listen for event stream clients on %s:%d: %w", address, port, err)
+	}
+	server := grpc.NewServer()
+	server.RegisterService(&eventsServiceDesc, &eventsServer{bus: defaultBus})
+	return server.Serve(listener)
+}
+
+// Send every event to the client until it disconnects
+func (s *eventsServer) Subscribe(_ *emptypb.Empty, stream grpc.ServerStream) error {
+	events, unsubscribe := s.bus.Subscribe()
+	defer unsubscribe()
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case event, ok := <-events:
+			if !ok {
+				return nil
+			}
+			message, err := structpb.NewStruct(map[string]interface{}{
+				"type":    string(event.Type),
+				"time":    event.Time.Format(time.RFC3339Nano),
+				"daemon":  event.Daemon,
+				"task":    event.Task,
+				"message": event.Message,
+				"txHash":  event.TxHash,
+			})
+			if err != nil {
+				return err
+			}
+			if err := stream.SendMsg(message); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func subscribeHandler(srv interface{}, stream grpc.ServerStream) error {
+	request := new(emptypb.Empty)
+	if err := stream.RecvMsg(request); err != nil {
+		return err
+	}
+	return srv.(EventsServer).Subscribe(request, stream)
+}
@@ -0,0 +1,216 @@
+package exitqueue
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/types"
+
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/filelock"
+)
+
+// Broadcast exits are kept for a while so they still show up in the minipool status
+var broadcastRetention, _ = time.ParseDuration("168h")
+
+// A signed voluntary exit that the node daemon holds until its epoch
+type Entry struct {
+	MinipoolAddress common.Address           `json:"minipoolAddress"`
+	ValidatorPubkey types.ValidatorPubkey    `json:"validatorPubkey"`
+	ValidatorIndex  uint64                   `json:"validatorIndex"`
+	Epoch           uint64                   `json:"epoch"`
+	Signature       types.ValidatorSignature `json:"signature"`
+	State           api.ScheduledExitState   `json:"state"`
+	Attempts        uint64                   `json:"attempts"`
+	LastError       string                   `json:"lastError"`
+	Created         time.Time                `json:"created"`
+	Updated         time.Time                `json:"updated"`
+}
+
+// Get the details of the exit that are safe to show, leaving out the signature
+func (e Entry) GetDetails() *api.ScheduledExitDetails {
+	return &api.ScheduledExitDetails{
+		Epoch:     e.Epoch,
+		State:     e.State,
+		Attempts:  e.Attempts,
+		LastError: e.LastError,
+		Updated:   e.Updated,
+	}
+}
+
+// A durable queue of the signed voluntary exits that are waiting to be broadcast.
+// The api and node processes both modify it, so every read-modify-write holds a lock on the file as well as the mutex.
+// The signatures can exit the validators as soon as their epochs arrive, so the file is only readable by the node's user.
+type Queue struct {
+	path string
+	lock sync.Mutex
+}
+
+// Create a new exit queue that's stored in the provided file
+func NewQueue(path string) *Queue {
+	return &Queue{
+		path: path,
+	}
+}
+
+// Get all of the exits in the queue, earliest epoch first
+func (q *Queue) List() ([]Entry, error) {
+
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	fileLock, err := filelock.Lock(q.path)
+	if err != nil {
+		return nil, err
+	}
+	defer fileLock.Unlock()
+
+	queued, err := q.load()
+	if err != nil {
+		return nil, err
+	}
+	exits := make([]Entry, 0, len(queued))
+	for _, exit := range queued {
+		exits = append(exits, exit)
+	}
+	sort.Slice(exits, func(i, j int) bool {
+		return exits[i].Epoch < exits[j].Epoch
+	})
+	return exits, nil
+
+}
+
+// Get the exits that are due to be broadcast at an epoch, including the ones that failed earlier
+func (q *Queue) GetDue(epoch uint64) ([]Entry, error) {
+	exits, err := q.List()
+	if err != nil {
+		return nil, err
+	}
+	due := []Entry{}
+	for _, exit := range exits {
+		if exit.State != api.ScheduledExitState_Broadcast && exit.Epoch <= epoch {
+			due = append(due, exit)
+		}
+	}
+	return due, nil
+}
+
+// Add a signed exit to the queue, replacing any exit that was already scheduled for the minipool
+func (q *Queue) Schedule(minipoolAddress common.Address, pubkey types.ValidatorPubkey, index uint64, epoch uint64, signature types.ValidatorSignature) error {
+
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	fileLock, err := filelock.Lock(q.path)
+	if err != nil {
+		return err
+	}
+	defer fileLock.Unlock()
+
+	queued, err := q.load()
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	queued[minipoolAddress.Hex()] = Entry{
+		MinipoolAddress: minipoolAddress,
+		ValidatorPubkey: pubkey,
+		ValidatorIndex:  index,
+		Epoch:           epoch,
+		Signature:       signature,
+		State:           api.ScheduledExitState_Scheduled,
+		Created:         now,
+		Updated:         now,
+	}
+	return q.save(queued)
+
+}
+
+// Record that an exit has been broadcast
+func (q *Queue) SetBroadcast(minipoolAddress common.Address) error {
+	return q.update(minipoolAddress, func(exit *Entry) {
+		exit.State = api.ScheduledExitState_Broadcast
+		exit.Attempts++
+		exit.LastError = ""
+	})
+}
+
+// Record that an exit couldn't be broadcast, so it's tried again on the next run
+func (q *Queue) SetFailed(minipoolAddress common.Address, err error) error {
+	return q.update(minipoolAddress, func(exit *Entry) {
+		exit.State = api.ScheduledExitState_Failed
+		exit.Attempts++
+		exit.LastError = err.Error()
+	})
+}
+
+// Update an exit in the queue, if it's there
+func (q *Queue) update(minipoolAddress common.Address, modify func(exit *Entry)) error {
+
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	fileLock, err := filelock.Lock(q.path)
+	if err != nil {
+		return err
+	}
+	defer fileLock.Unlock()
+
+	queued, err := q.load()
+	if err != nil {
+		return err
+	}
+	exit, exists := queued[minipoolAddress.Hex()]
+	if !exists {
+		return nil
+	}
+	modify(&exit)
+	exit.Updated = time.Now()
+	queued[minipoolAddress.Hex()] = exit
+	return q.save(queued)
+
+}
+
+// Load the queue
+func (q *Queue) load() (map[string]Entry, error) {
+	bytes, err := ioutil.ReadFile(q.path)
+	if os.IsNotExist(err) {
+		return map[string]Entry{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Could not read the exit queue file: %w", err)
+	}
+	queued := map[string]Entry{}
+	if err := json.Unmarshal(bytes, &queued); err != nil {
+		return nil, fmt.Errorf("Could not decode the exit queue file: %w", err)
+	}
+	return queued, nil
+}
+
+// Save the queue, dropping exits that were broadcast a while ago
+func (q *Queue) save(queued map[string]Entry) error {
+	for id, exit := range queued {
+		if exit.State == api.ScheduledExitState_Broadcast && time.Since(exit.Updated) > broadcastRetention {
+			delete(queued, id)
+		}
+	}
+	bytes, err := json.Marshal(queued)
+	if err != nil {
+		return fmt.Errorf("Could not encode the exit queue file: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(q.path), 0700); err != nil {
+		return fmt.Errorf("Could not create the exit queue folder: %w", err)
+	}
+	tempPath := q.path + ".tmp"
+	if err := ioutil.WriteFile(tempPath, bytes, 0600); err != nil {
+		return fmt.Errorf("Could not write the exit queue file: %w", err)
+	}
+	if err := os.Rename(tempPath, q.path); err != nil {
+		return fmt.Errorf("Could not replace the exit queue file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+package exitqueue
+
+import (
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/types"
+)
+
+func TestQueuesSharingAFileKeepEveryExit(t *testing.T) {
+	// Each queue stands in for a separate process, so only the file lock keeps them from overwriting each other's exits
+	path := filepath.Join(t.TempDir(), "exit-queue.json")
+	var wg sync.WaitGroup
+	errs := make(chan error, 32)
+	for i := 0; i < 32; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			address := common.HexToAddress(fmt.Sprintf("0x%040x", i+1))
+			errs <- NewQueue(path).Schedule(address, types.ValidatorPubkey{}, uint64(i), uint64(i), types.ValidatorSignature{})
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	exits, err := NewQueue(path).List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(exits) != 32 {
+		t.Fatalf("expected 32 exits, got %d", len(exits))
+	}
+}
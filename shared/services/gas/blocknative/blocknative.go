@@ -0,0 +1,81 @@
+package blocknative
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+)
+
+const blockPricesUrl string = "https://api.blocknative.com/gasprices/blockprices"
+
+// Standard response
+type blockPricesResponse struct {
+	BlockPrices []struct {
+		BaseFeePerGas   float64 `json:"baseFeePerGas"`
+		EstimatedPrices []struct {
+			Confidence           uint64  `json:"confidence"`
+			MaxPriorityFeePerGas float64 `json:"maxPriorityFeePerGas"`
+			MaxFeePerGas         float64 `json:"maxFeePerGas"`
+		} `json:"estimatedPrices"`
+	} `json:"blockPrices"`
+}
+
+// The max fees needed for a transaction to be included in the next block, with different levels of confidence
+type GasFeeSuggestion struct {
+	BaseFeeGwei float64
+
+	// Max fees excluding their priority fee, by the confidence percentage they're given for
+	MaxFeeGwei map[uint64]float64
+}
+
+// Get gas prices
+func GetGasPrices(apiKey string) (GasFeeSuggestion, error) {
+
+	// Send request
+	request, err := http.NewRequest(http.MethodGet, blockPricesUrl, nil)
+	if err != nil {
+		return GasFeeSuggestion{}, err
+	}
+	request.Header.Set("Authorization", apiKey)
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return GasFeeSuggestion{}, err
+	}
+	defer func() {
+		_ = response.Body.Close()
+	}()
+
+	// Check the response code
+	if response.StatusCode != http.StatusOK {
+		return GasFeeSuggestion{}, fmt.Errorf("request failed with code %d", response.StatusCode)
+	}
+
+	// Get response
+	body, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return GasFeeSuggestion{}, err
+	}
+
+	// Deserialize response
+	var bpResponse blockPricesResponse
+	if err := json.Unmarshal(body, &bpResponse); err != nil {
+		return GasFeeSuggestion{}, fmt.Errorf("Could not decode Blocknative block prices response: %w", err)
+	}
+	if len(bpResponse.BlockPrices) == 0 {
+		return GasFeeSuggestion{}, fmt.Errorf("Blocknative didn't return any block prices")
+	}
+
+	blockPrices := bpResponse.BlockPrices[0]
+	suggestion := GasFeeSuggestion{
+		BaseFeeGwei: blockPrices.BaseFeePerGas,
+		MaxFeeGwei:  map[uint64]float64{},
+	}
+	for _, price := range blockPrices.EstimatedPrices {
+		suggestion.MaxFeeGwei[price.Confidence] = price.MaxFeePerGas - price.MaxPriorityFeePerGas
+	}
+
+	// Return
+	return suggestion, nil
+
+}
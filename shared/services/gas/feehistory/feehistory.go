@@ -0,0 +1,84 @@
+package feehistory
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// The number of recent blocks the estimates are based on
+const blockCount uint64 = 20
+
+// The result of an eth_feeHistory call
+type FeeHistory struct {
+	OldestBlock   *hexutil.Big     `json:"oldestBlock"`
+	BaseFeePerGas []*hexutil.Big   `json:"baseFeePerGas"`
+	GasUsedRatio  []float64        `json:"gasUsedRatio"`
+	Reward        [][]*hexutil.Big `json:"reward,omitempty"`
+}
+
+// An Execution client that supports eth_feeHistory
+type Client interface {
+	FeeHistory(ctx context.Context, blockCount uint64, rewardPercentiles []float64) (*FeeHistory, error)
+}
+
+// Max fees (excluding the priority fee) derived from the base fees of recent blocks
+type GasFeeSuggestion struct {
+	RapidWei    *big.Int
+	FastWei     *big.Int
+	StandardWei *big.Int
+	SlowWei     *big.Int
+}
+
+// Get gas prices
+func GetGasPrices(client Client) (GasFeeSuggestion, error) {
+
+	// Get the base fees of the recent blocks; the last one is the base fee of the next block
+	history, err := client.FeeHistory(context.Background(), blockCount, []float64{})
+	if err != nil {
+		return GasFeeSuggestion{}, fmt.Errorf("Could not get the fee history: %w", err)
+	}
+	if len(history.BaseFeePerGas) < 2 {
+		return GasFeeSuggestion{}, fmt.Errorf("The fee history didn't include any blocks")
+	}
+	nextBaseFee := history.BaseFeePerGas[len(history.BaseFeePerGas)-1].ToInt()
+	recentBaseFees := []*big.Int{}
+	for _, baseFee := range history.BaseFeePerGas[:len(history.BaseFeePerGas)-1] {
+		recentBaseFees = append(recentBaseFees, baseFee.ToInt())
+	}
+	sort.Slice(recentBaseFees, func(i, j int) bool {
+		return recentBaseFees[i].Cmp(recentBaseFees[j]) < 0
+	})
+	highestBaseFee := recentBaseFees[len(recentBaseFees)-1]
+	medianBaseFee := recentBaseFees[len(recentBaseFees)/2]
+
+	// Rapid covers the base fee rising for 6 full blocks in a row
+	rapid := new(big.Int).Mul(nextBaseFee, big.NewInt(2))
+
+	// Fast covers the recent peak, and at least 2 full blocks
+	fast := new(big.Int).Div(new(big.Int).Mul(nextBaseFee, big.NewInt(5)), big.NewInt(4))
+	if highestBaseFee.Cmp(fast) > 0 {
+		fast = new(big.Int).Set(highestBaseFee)
+	}
+
+	// Standard covers 1 full block
+	standard := new(big.Int).Div(new(big.Int).Mul(nextBaseFee, big.NewInt(9)), big.NewInt(8))
+
+	// Slow waits for the base fee to come back to its recent median
+	slow := new(big.Int).Set(medianBaseFee)
+	if slow.Cmp(standard) > 0 {
+		slow = new(big.Int).Set(standard)
+	}
+
+	// Return
+	return GasFeeSuggestion{
+		RapidWei:    rapid,
+		FastWei:     fast,
+		StandardWei: standard,
+		SlowWei:     slow,
+	}, nil
+
+}
@@ -0,0 +1,60 @@
+package feehistory
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+)
+
+// The most blocks the Execution clients return from a single eth_feeHistory call
+const maxFeeHistoryBlocks uint64 = 1024
+
+// An Execution client that can get the fee history ending at any block, not just the latest one
+type HistoricalClient interface {
+	BlockNumber(ctx context.Context) (uint64, error)
+	FeeHistoryAt(ctx context.Context, blockCount uint64, newestBlock uint64, rewardPercentiles []float64) (*FeeHistory, error)
+}
+
+// Sample the base fees of blocks spread evenly over a recent period, ending at the chain head.
+// Each of the samples is a run of consecutive blocks, so the result reflects the daily highs and lows without fetching every block.
+func SampleBaseFees(client HistoricalClient, periodBlocks uint64, samples uint64, blocksPerSample uint64) ([]*big.Int, error) {
+
+	if samples == 0 || blocksPerSample == 0 {
+		return nil, fmt.Errorf("At least one block has to be sampled")
+	}
+	if blocksPerSample > maxFeeHistoryBlocks {
+		blocksPerSample = maxFeeHistoryBlocks
+	}
+	head, err := client.BlockNumber(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("Could not get the latest block: %w", err)
+	}
+	if periodBlocks > head {
+		periodBlocks = head
+	}
+	step := periodBlocks / samples
+	if step < blocksPerSample {
+		step = blocksPerSample
+	}
+
+	baseFees := []*big.Int{}
+	for i := uint64(0); i < samples && i*step < periodBlocks; i++ {
+		history, err := client.FeeHistoryAt(context.Background(), blocksPerSample, head-i*step, []float64{})
+		if err != nil {
+			return nil, fmt.Errorf("Could not get the fee history ending at block %d: %w", head-i*step, err)
+		}
+
+		// The last base fee is the one for the block after the range
+		if len(history.BaseFeePerGas) < 2 {
+			continue
+		}
+		for _, baseFee := range history.BaseFeePerGas[:len(history.BaseFeePerGas)-1] {
+			baseFees = append(baseFees, baseFee.ToInt())
+		}
+	}
+	if len(baseFees) == 0 {
+		return nil, fmt.Errorf("The fee history didn't include any blocks")
+	}
+	return baseFees, nil
+
+}
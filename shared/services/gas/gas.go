@@ -7,8 +7,6 @@ import (
 
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
-	"github.com/rocket-pool/smartnode/shared/services/gas/etherchain"
-	"github.com/rocket-pool/smartnode/shared/services/gas/etherscan"
 	rpsvc "github.com/rocket-pool/smartnode/shared/services/rocketpool"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
 	"github.com/rocket-pool/smartnode/shared/utils/math"
@@ -33,7 +31,7 @@ func AssignMaxFeeAndLimit(gasInfo rocketpool.GasInfo, rp *rpsvc.Client, headless
 
 	// Get the max fee - prioritize the CLI arguments, default to the config file setting
 	if maxFeeGwei == 0 {
-		maxFee := eth.GweiToWei(cfg.Smartnode.ManualMaxFee.Value.(float64))
+		maxFee := eth.GweiToWei(cfg.Smartnode.GetManualMaxFee())
 		if maxFee != nil && maxFee.Uint64() != 0 {
 			maxFeeGwei = eth.WeiToGwei(maxFee)
 		}
@@ -41,7 +39,7 @@ func AssignMaxFeeAndLimit(gasInfo rocketpool.GasInfo, rp *rpsvc.Client, headless
 
 	// Get the priority fee - prioritize the CLI arguments, default to the config file setting
 	if maxPriorityFeeGwei == 0 {
-		maxPriorityFee := eth.GweiToWei(cfg.Smartnode.PriorityFee.Value.(float64))
+		maxPriorityFee := eth.GweiToWei(cfg.Smartnode.GetPriorityFee())
 		if maxPriorityFee == nil || maxPriorityFee.Uint64() == 0 {
 			fmt.Printf("%sNOTE: max priority fee not set or set to 0, defaulting to 2 gwei%s\n", colorYellow, colorReset)
 			maxPriorityFeeGwei = 2
@@ -66,30 +64,22 @@ func AssignMaxFeeAndLimit(gasInfo rocketpool.GasInfo, rp *rpsvc.Client, headless
 		fmt.Printf("Total cost: %.4f to %.4f ETH%s\n", lowLimit, highLimit, colorReset)
 
 	} else {
+		oracle := NewGasOracle(cfg, nil, rp)
 		if headless {
-			maxFeeWei, err := GetHeadlessMaxFeeWei()
+			maxFeeWei, err := GetHeadlessMaxFeeWei(oracle)
 			if err != nil {
 				return err
 			}
 			maxFeeGwei = eth.WeiToGwei(maxFeeWei)
 		} else {
-			// Try to get the latest gas prices from Etherchain
-			etherchainData, err := etherchain.GetGasPrices()
-			if err == nil {
-				// Print the Etherchain data and ask for an amount
-				maxFeeGwei = handleEtherchainGasPrices(etherchainData, gasInfo, maxPriorityFeeGwei, gasLimit)
-
-			} else {
-				// Fallback to Etherscan
-				fmt.Printf("%sWarning: couldn't get gas estimates from Etherchain - %s\nFalling back to Etherscan%s\n", colorYellow, err.Error(), colorReset)
-				etherscanData, err := etherscan.GetGasPrices()
-				if err == nil {
-					// Print the Etherscan data and ask for an amount
-					maxFeeGwei = handleEtherscanGasPrices(etherscanData, gasInfo, maxPriorityFeeGwei, gasLimit)
-				} else {
-					return fmt.Errorf("Error getting gas price suggestions: %w", err)
-				}
+			// Get the latest gas prices from the configured oracle
+			gasSuggestion, err := oracle.GetGasPrices()
+			if err != nil {
+				return err
 			}
+
+			// Print the suggestions and ask for an amount
+			maxFeeGwei = handleGasPrices(oracle.GetName(), gasSuggestion, gasInfo, maxPriorityFeeGwei, gasLimit)
 		}
 		fmt.Printf("%sUsing a max fee of %.2f gwei and a priority fee of %.2f gwei.\n%s", colorBlue, maxFeeGwei, maxPriorityFeeGwei, colorReset)
 	}
@@ -108,79 +98,49 @@ func AssignMaxFeeAndLimit(gasInfo rocketpool.GasInfo, rp *rpsvc.Client, headless
 }
 
 // Get the suggested max fee for service operations
-func GetHeadlessMaxFeeWei() (*big.Int, error) {
-	etherchainData, err := etherchain.GetGasPrices()
-	if err == nil {
-		return etherchainData.RapidWei, nil
-	} else {
-		fmt.Printf("%sWarning: couldn't get gas estimates from Etherchain - %s\nFalling back to Etherscan%s\n", colorYellow, err.Error(), colorReset)
-		etherscanData, err := etherscan.GetGasPrices()
-		if err == nil {
-			return eth.GweiToWei(etherscanData.FastGwei), nil
-		} else {
-			return nil, fmt.Errorf("Error getting gas price suggestions: %w", err)
-		}
+func GetHeadlessMaxFeeWei(oracle GasOracle) (*big.Int, error) {
+	gasSuggestion, err := oracle.GetGasPrices()
+	if err != nil {
+		return nil, err
+	}
+	if gasSuggestion.RapidWei != nil {
+		return gasSuggestion.RapidWei, nil
 	}
+	return gasSuggestion.FastWei, nil
 }
 
-func handleEtherchainGasPrices(gasSuggestion etherchain.GasFeeSuggestion, gasInfo rocketpool.GasInfo, priorityFee float64, gasLimit uint64) float64 {
+// Get the max fee for one of the suggested speeds, and the total cost range of the transaction with it
+func getSuggestionCosts(suggestionWei *big.Int, gasInfo rocketpool.GasInfo, priorityFee float64, gasLimit uint64) (float64, float64, float64) {
 
-	rapidGwei := math.RoundUp(eth.WeiToGwei(gasSuggestion.RapidWei)+priorityFee, 0)
-	rapidEth := eth.WeiToEth(gasSuggestion.RapidWei)
+	maxFeeGwei := math.RoundUp(eth.WeiToGwei(suggestionWei)+priorityFee, 0)
+	maxFeeEth := eth.WeiToEth(suggestionWei)
 
-	var rapidLowLimit float64
-	var rapidHighLimit float64
+	var lowLimit float64
+	var highLimit float64
 	if gasLimit == 0 {
-		rapidLowLimit = rapidEth * float64(gasInfo.EstGasLimit)
-		rapidHighLimit = rapidEth * float64(gasInfo.SafeGasLimit)
+		lowLimit = maxFeeEth * float64(gasInfo.EstGasLimit)
+		highLimit = maxFeeEth * float64(gasInfo.SafeGasLimit)
 	} else {
-		rapidLowLimit = rapidEth * float64(gasLimit)
-		rapidHighLimit = rapidLowLimit
+		lowLimit = maxFeeEth * float64(gasLimit)
+		highLimit = lowLimit
 	}
+	return maxFeeGwei, lowLimit, highLimit
 
-	fastGwei := math.RoundUp(eth.WeiToGwei(gasSuggestion.FastWei)+priorityFee, 0)
-	fastEth := eth.WeiToEth(gasSuggestion.FastWei)
-
-	var fastLowLimit float64
-	var fastHighLimit float64
-	if gasLimit == 0 {
-		fastLowLimit = fastEth * float64(gasInfo.EstGasLimit)
-		fastHighLimit = fastEth * float64(gasInfo.SafeGasLimit)
-	} else {
-		fastLowLimit = fastEth * float64(gasLimit)
-		fastHighLimit = fastLowLimit
-	}
-
-	standardGwei := math.RoundUp(eth.WeiToGwei(gasSuggestion.StandardWei)+priorityFee, 0)
-	standardEth := eth.WeiToEth(gasSuggestion.StandardWei)
-
-	var standardLowLimit float64
-	var standardHighLimit float64
-	if gasLimit == 0 {
-		standardLowLimit = standardEth * float64(gasInfo.EstGasLimit)
-		standardHighLimit = standardEth * float64(gasInfo.SafeGasLimit)
-	} else {
-		standardLowLimit = standardEth * float64(gasLimit)
-		standardHighLimit = standardLowLimit
-	}
+}
 
-	slowGwei := math.RoundUp(eth.WeiToGwei(gasSuggestion.SlowWei)+priorityFee, 0)
-	slowEth := eth.WeiToEth(gasSuggestion.SlowWei)
+func handleGasPrices(oracleName string, gasSuggestion GasFeeSuggestion, gasInfo rocketpool.GasInfo, priorityFee float64, gasLimit uint64) float64 {
 
-	var slowLowLimit float64
-	var slowHighLimit float64
-	if gasLimit == 0 {
-		slowLowLimit = slowEth * float64(gasInfo.EstGasLimit)
-		slowHighLimit = slowEth * float64(gasInfo.SafeGasLimit)
-	} else {
-		slowLowLimit = slowEth * float64(gasLimit)
-		slowHighLimit = slowLowLimit
-	}
+	fastGwei, fastLowLimit, fastHighLimit := getSuggestionCosts(gasSuggestion.FastWei, gasInfo, priorityFee, gasLimit)
+	standardGwei, standardLowLimit, standardHighLimit := getSuggestionCosts(gasSuggestion.StandardWei, gasInfo, priorityFee, gasLimit)
+	slowGwei, slowLowLimit, slowHighLimit := getSuggestionCosts(gasSuggestion.SlowWei, gasInfo, priorityFee, gasLimit)
 
 	fmt.Printf("%s+============== Suggested Gas Prices ==============+\n", colorBlue)
 	fmt.Println("| Avg Wait Time |  Max Fee  |    Total Gas Cost    |")
-	fmt.Printf("| %-13s | %-9s | %.4f to %.4f ETH |\n",
-		gasSuggestion.RapidTime, fmt.Sprintf("%d gwei", int(rapidGwei)), rapidLowLimit, rapidHighLimit)
+	if gasSuggestion.RapidWei != nil {
+		rapidGwei, rapidLowLimit, rapidHighLimit := getSuggestionCosts(gasSuggestion.RapidWei, gasInfo, priorityFee, gasLimit)
+		fmt.Printf("| %-13s | %-9s | %.4f to %.4f ETH |\n",
+			gasSuggestion.RapidTime, fmt.Sprintf("%d gwei", int(rapidGwei)), rapidLowLimit, rapidHighLimit)
+	}
 	fmt.Printf("| %-13s | %-9s | %.4f to %.4f ETH |\n",
 		gasSuggestion.FastTime, fmt.Sprintf("%d gwei", int(fastGwei)), fastLowLimit, fastHighLimit)
 	fmt.Printf("| %-13s | %-9s | %.4f to %.4f ETH |\n",
@@ -189,85 +149,7 @@ func handleEtherchainGasPrices(gasSuggestion etherchain.GasFeeSuggestion, gasInf
 		gasSuggestion.SlowTime, fmt.Sprintf("%d gwei", int(slowGwei)), slowLowLimit, slowHighLimit)
 	fmt.Printf("+==================================================+\n\n%s", colorReset)
 
-	fmt.Printf("These prices include a maximum priority fee of %.2f gwei.\n", priorityFee)
-
-	for {
-		desiredPrice := cliutils.Prompt(
-			fmt.Sprintf("Please enter your max fee (including the priority fee) or leave blank for the default of %d gwei:", int(fastGwei)),
-			"^(?:[1-9]\\d*|0)?(?:\\.\\d+)?$",
-			"Not a valid gas price, try again:")
-
-		if desiredPrice == "" {
-			return fastGwei
-		}
-
-		desiredPriceFloat, err := strconv.ParseFloat(desiredPrice, 64)
-		if err != nil {
-			fmt.Println("Not a valid gas price (%s), try again.", err.Error())
-			continue
-		}
-		if desiredPriceFloat <= 0 {
-			fmt.Println("Max fee must be greater than zero.")
-			continue
-		}
-
-		return desiredPriceFloat
-	}
-
-}
-
-func handleEtherscanGasPrices(gasSuggestion etherscan.GasFeeSuggestion, gasInfo rocketpool.GasInfo, priorityFee float64, gasLimit uint64) float64 {
-
-	fastGwei := math.RoundUp(gasSuggestion.FastGwei+priorityFee, 0)
-	fastEth := gasSuggestion.FastGwei / eth.WeiPerGwei
-
-	var fastLowLimit float64
-	var fastHighLimit float64
-	if gasLimit == 0 {
-		fastLowLimit = fastEth * float64(gasInfo.EstGasLimit)
-		fastHighLimit = fastEth * float64(gasInfo.SafeGasLimit)
-	} else {
-		fastLowLimit = fastEth * float64(gasLimit)
-		fastHighLimit = fastLowLimit
-	}
-
-	standardGwei := math.RoundUp(gasSuggestion.StandardGwei+priorityFee, 0)
-	standardEth := gasSuggestion.StandardGwei / eth.WeiPerGwei
-
-	var standardLowLimit float64
-	var standardHighLimit float64
-	if gasLimit == 0 {
-		standardLowLimit = standardEth * float64(gasInfo.EstGasLimit)
-		standardHighLimit = standardEth * float64(gasInfo.SafeGasLimit)
-	} else {
-		standardLowLimit = standardEth * float64(gasLimit)
-		standardHighLimit = standardLowLimit
-	}
-
-	slowGwei := math.RoundUp(gasSuggestion.SlowGwei+priorityFee, 0)
-	slowEth := gasSuggestion.SlowGwei / eth.WeiPerGwei
-
-	var slowLowLimit float64
-	var slowHighLimit float64
-	if gasLimit == 0 {
-		slowLowLimit = slowEth * float64(gasInfo.EstGasLimit)
-		slowHighLimit = slowEth * float64(gasInfo.SafeGasLimit)
-	} else {
-		slowLowLimit = slowEth * float64(gasLimit)
-		slowHighLimit = slowLowLimit
-	}
-
-	fmt.Printf("%s+============ Suggested Gas Prices ============+\n", colorBlue)
-	fmt.Println("|   Speed   |  Max Fee  |    Total Gas Cost    |")
-	fmt.Printf("| Fast      | %-9s | %.4f to %.4f ETH |\n",
-		fmt.Sprintf("%d gwei", int(fastGwei)), fastLowLimit, fastHighLimit)
-	fmt.Printf("| Standard  | %-9s | %.4f to %.4f ETH |\n",
-		fmt.Sprintf("%d gwei", int(standardGwei)), standardLowLimit, standardHighLimit)
-	fmt.Printf("| Slow      | %-9s | %.4f to %.4f ETH |\n",
-		fmt.Sprintf("%d gwei", int(slowGwei)), slowLowLimit, slowHighLimit)
-	fmt.Printf("+==============================================+\n\n%s", colorReset)
-
-	fmt.Printf("These prices include a maximum priority fee of %.2f gwei.\n", priorityFee)
+	fmt.Printf("These prices are from %s and include a maximum priority fee of %.2f gwei.\n", oracleName, priorityFee)
 
 	for {
 		desiredPrice := cliutils.Prompt(
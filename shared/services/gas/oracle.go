@@ -0,0 +1,230 @@
+package gas
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/gas/blocknative"
+	"github.com/rocket-pool/smartnode/shared/services/gas/etherchain"
+	"github.com/rocket-pool/smartnode/shared/services/gas/etherscan"
+	"github.com/rocket-pool/smartnode/shared/services/gas/feehistory"
+	rpsvc "github.com/rocket-pool/smartnode/shared/services/rocketpool"
+)
+
+// Suggested max fees, excluding the priority fee, for different transaction speeds.
+// Sources that don't provide a rapid suggestion leave RapidWei nil.
+type GasFeeSuggestion struct {
+	RapidWei  *big.Int
+	RapidTime string
+
+	FastWei  *big.Int
+	FastTime string
+
+	StandardWei  *big.Int
+	StandardTime string
+
+	SlowWei  *big.Int
+	SlowTime string
+}
+
+// A source of max fee suggestions
+type GasOracle interface {
+	GetName() string
+	GetGasPrices() (GasFeeSuggestion, error)
+}
+
+// Create the gas oracle selected in the config, falling back to Etherscan if it fails.
+// The Execution client is only needed for fee history estimates; if it's nil, they're requested from the api daemon through rp instead.
+func NewGasOracle(cfg *config.RocketPoolConfig, ec feehistory.Client, rp *rpsvc.Client) GasOracle {
+	var oracle GasOracle
+	switch cfg.Smartnode.GetGasOracle() {
+	case config.GasOracle_Etherscan:
+		return &etherscanOracle{}
+	case config.GasOracle_Blocknative:
+		oracle = &blocknativeOracle{apiKey: cfg.Smartnode.GetBlocknativeApiKey()}
+	case config.GasOracle_FeeHistory:
+		if ec != nil {
+			oracle = &feeHistoryOracle{client: ec}
+		} else {
+			oracle = &apiOracle{rp: rp}
+		}
+	default:
+		oracle = &etherchainOracle{}
+	}
+	return &fallbackOracle{
+		primary:  oracle,
+		fallback: &etherscanOracle{},
+	}
+}
+
+// Etherchain's gas price oracle
+type etherchainOracle struct{}
+
+func (o *etherchainOracle) GetName() string {
+	return "Etherchain"
+}
+
+func (o *etherchainOracle) GetGasPrices() (GasFeeSuggestion, error) {
+	data, err := etherchain.GetGasPrices()
+	if err != nil {
+		return GasFeeSuggestion{}, err
+	}
+	return GasFeeSuggestion{
+		RapidWei:     data.RapidWei,
+		RapidTime:    data.RapidTime,
+		FastWei:      data.FastWei,
+		FastTime:     data.FastTime,
+		StandardWei:  data.StandardWei,
+		StandardTime: data.StandardTime,
+		SlowWei:      data.SlowWei,
+		SlowTime:     data.SlowTime,
+	}, nil
+}
+
+// Etherscan's gas tracker
+type etherscanOracle struct{}
+
+func (o *etherscanOracle) GetName() string {
+	return "Etherscan"
+}
+
+func (o *etherscanOracle) GetGasPrices() (GasFeeSuggestion, error) {
+	data, err := etherscan.GetGasPrices()
+	if err != nil {
+		return GasFeeSuggestion{}, err
+	}
+	return GasFeeSuggestion{
+		FastWei:      eth.GweiToWei(data.FastGwei),
+		FastTime:     "Fast",
+		StandardWei:  eth.GweiToWei(data.StandardGwei),
+		StandardTime: "Standard",
+		SlowWei:      eth.GweiToWei(data.SlowGwei),
+		SlowTime:     "Slow",
+	}, nil
+}
+
+// Blocknative's next block estimates
+type blocknativeOracle struct {
+	apiKey string
+}
+
+func (o *blocknativeOracle) GetName() string {
+	return "Blocknative"
+}
+
+func (o *blocknativeOracle) GetGasPrices() (GasFeeSuggestion, error) {
+	if o.apiKey == "" {
+		return GasFeeSuggestion{}, fmt.Errorf("no Blocknative API key is set")
+	}
+	data, err := blocknative.GetGasPrices(o.apiKey)
+	if err != nil {
+		return GasFeeSuggestion{}, err
+	}
+	suggestion := GasFeeSuggestion{}
+	for _, level := range []struct {
+		confidence uint64
+		wei        **big.Int
+		time       *string
+	}{
+		{99, &suggestion.RapidWei, &suggestion.RapidTime},
+		{95, &suggestion.FastWei, &suggestion.FastTime},
+		{90, &suggestion.StandardWei, &suggestion.StandardTime},
+		{70, &suggestion.SlowWei, &suggestion.SlowTime},
+	} {
+		maxFee, exists := data.MaxFeeGwei[level.confidence]
+		if !exists {
+			return GasFeeSuggestion{}, fmt.Errorf("Blocknative didn't return an estimate with %d%% confidence", level.confidence)
+		}
+		*level.wei = eth.GweiToWei(maxFee)
+		*level.time = fmt.Sprintf("Next block, %d%%", level.confidence)
+	}
+	return suggestion, nil
+}
+
+// Estimates from the Execution client's fee history
+type feeHistoryOracle struct {
+	client feehistory.Client
+}
+
+func (o *feeHistoryOracle) GetName() string {
+	return "Fee History"
+}
+
+func (o *feeHistoryOracle) GetGasPrices() (GasFeeSuggestion, error) {
+	data, err := feehistory.GetGasPrices(o.client)
+	if err != nil {
+		return GasFeeSuggestion{}, err
+	}
+	return GasFeeSuggestion{
+		RapidWei:     data.RapidWei,
+		RapidTime:    "Next block",
+		FastWei:      data.FastWei,
+		FastTime:     "1-2 Blocks",
+		StandardWei:  data.StandardWei,
+		StandardTime: "A few blocks",
+		SlowWei:      data.SlowWei,
+		SlowTime:     "When fees drop",
+	}, nil
+}
+
+// Suggestions from the api daemon's gas oracle, for oracles that need the Execution client
+type apiOracle struct {
+	rp   *rpsvc.Client
+	name string
+}
+
+func (o *apiOracle) GetName() string {
+	if o.name == "" {
+		return "Fee History"
+	}
+	return o.name
+}
+
+func (o *apiOracle) GetGasPrices() (GasFeeSuggestion, error) {
+	response, err := o.rp.GetGasPrices()
+	if err != nil {
+		return GasFeeSuggestion{}, err
+	}
+	o.name = response.OracleName
+	return GasFeeSuggestion{
+		RapidWei:     response.RapidWei,
+		RapidTime:    response.RapidTime,
+		FastWei:      response.FastWei,
+		FastTime:     response.FastTime,
+		StandardWei:  response.StandardWei,
+		StandardTime: response.StandardTime,
+		SlowWei:      response.SlowWei,
+		SlowTime:     response.SlowTime,
+	}, nil
+}
+
+// An oracle that falls back to another one if it fails
+type fallbackOracle struct {
+	primary  GasOracle
+	fallback GasOracle
+	failed   bool
+}
+
+func (o *fallbackOracle) GetName() string {
+	if o.failed {
+		return fmt.Sprintf("%s (%s was unavailable)", o.fallback.GetName(), o.primary.GetName())
+	}
+	return o.primary.GetName()
+}
+
+func (o *fallbackOracle) GetGasPrices() (GasFeeSuggestion, error) {
+	suggestion, err := o.primary.GetGasPrices()
+	if err == nil {
+		o.failed = false
+		return suggestion, nil
+	}
+	suggestion, fallbackErr := o.fallback.GetGasPrices()
+	if fallbackErr != nil {
+		return GasFeeSuggestion{}, fmt.Errorf("Error getting gas price suggestions from %s (%s) and %s (%s)", o.primary.GetName(), err.Error(), o.fallback.GetName(), fallbackErr.Error())
+	}
+	o.failed = true
+	return suggestion, nil
+}
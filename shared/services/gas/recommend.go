@@ -0,0 +1,78 @@
+package gas
+
+import (
+	"math"
+	"math/big"
+	"sort"
+
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+)
+
+// The automatic transactions compare the gas estimator's Rapid suggestion against their thresholds, which is twice the base fee
+const rapidBaseFeeMultiplier float64 = 2
+
+// Nodes with at least this many minipools earn enough RPL that claiming it is worth paying typical gas prices
+const (
+	mediumNodeMinipools uint64 = 3
+	largeNodeMinipools  uint64 = 10
+)
+
+// The spread of the base fees over a period, in gwei
+type BaseFeeSummary struct {
+	LowGwei    float64 `json:"lowGwei"`
+	MedianGwei float64 `json:"medianGwei"`
+	HighGwei   float64 `json:"highGwei"`
+}
+
+// Recommended thresholds for the automatic transactions, in gwei
+type GasThresholdRecommendation struct {
+	RplClaimGasThreshold      float64 `json:"rplClaimGasThreshold"`
+	MinipoolStakeGasThreshold float64 `json:"minipoolStakeGasThreshold"`
+}
+
+// Summarize the base fees of a set of blocks; the low and high are the 20th and 80th percentiles, so brief spikes and dips don't skew them
+func SummarizeBaseFees(baseFees []*big.Int) BaseFeeSummary {
+	if len(baseFees) == 0 {
+		return BaseFeeSummary{}
+	}
+	sorted := make([]float64, len(baseFees))
+	for i, baseFee := range baseFees {
+		sorted[i] = eth.WeiToGwei(baseFee)
+	}
+	sort.Float64s(sorted)
+	percentile := func(p float64) float64 {
+		return sorted[int(p*float64(len(sorted)-1))]
+	}
+	return BaseFeeSummary{
+		LowGwei:    percentile(0.2),
+		MedianGwei: percentile(0.5),
+		HighGwei:   percentile(0.8),
+	}
+}
+
+// Recommend the gas thresholds for a node based on its minipool count and the recent base fees.
+// Staking a minipool sooner starts its rewards sooner, so the stake threshold lets it through on most days. Claiming RPL costs the same no matter
+// how much is being claimed, so nodes with fewer minipools wait for cheaper gas to claim.
+func RecommendGasThresholds(minipoolCount uint64, baseFees BaseFeeSummary) GasThresholdRecommendation {
+	claimBaseFee := baseFees.LowGwei
+	if minipoolCount >= largeNodeMinipools {
+		claimBaseFee = baseFees.HighGwei
+	} else if minipoolCount >= mediumNodeMinipools {
+		claimBaseFee = baseFees.MedianGwei
+	}
+	return GasThresholdRecommendation{
+		RplClaimGasThreshold:      roundThreshold(claimBaseFee * rapidBaseFeeMultiplier),
+		MinipoolStakeGasThreshold: roundThreshold(baseFees.HighGwei * rapidBaseFeeMultiplier),
+	}
+}
+
+// Round a threshold up to a whole number of gwei, and to a multiple of 5 once it's large enough that the precision doesn't matter
+func roundThreshold(gwei float64) float64 {
+	if gwei <= 1 {
+		return 1
+	}
+	if gwei > 20 {
+		return math.Ceil(gwei/5) * 5
+	}
+	return math.Ceil(gwei)
+}
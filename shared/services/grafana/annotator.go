@@ -0,0 +1,88 @@
+package grafana
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+)
+
+// Settings
+const (
+	annotationsPath string        = "/api/annotations"
+	requestTimeout  time.Duration = 10 * time.Second
+	annotationTag   string        = "rocketpool"
+)
+
+// A Grafana annotation
+type annotation struct {
+	Time int64    `json:"time"`
+	Tags []string `json:"tags"`
+	Text string   `json:"text"`
+}
+
+// Adds annotations to the Grafana dashboards through Grafana's HTTP API
+type Annotator struct {
+	enabled bool
+	url     string
+	token   string
+	client  *http.Client
+}
+
+// Creates a new Annotator based on the Rocket Pool config.
+// The host is the name Grafana can be reached at, which is different for the daemons and the CLI.
+func NewAnnotator(cfg *config.RocketPoolConfig, host string) *Annotator {
+	token := cfg.Grafana.AnnotationsToken.GetStringOrDefault(config.Network_All)
+	return &Annotator{
+		enabled: !cfg.IsNativeMode && cfg.EnableMetrics.Value == true && cfg.Grafana.EnableAnnotations.Value == true && token != "",
+		url:     fmt.Sprintf("http://%s:%d%s", host, cfg.Grafana.Port.GetUint16OrDefault(config.Network_All), annotationsPath),
+		token:   token,
+		client:  &http.Client{Timeout: requestTimeout},
+	}
+}
+
+// Check if annotations are enabled
+func (a *Annotator) IsEnabled() bool {
+	return a != nil && a.enabled
+}
+
+// Add an annotation to the dashboards at the current time.
+// Every annotation is tagged with "rocketpool" in addition to the provided tags.
+func (a *Annotator) Annotate(text string, tags ...string) error {
+
+	if !a.IsEnabled() {
+		return nil
+	}
+
+	body, err := json.Marshal(annotation{
+		Time: time.Now().UnixNano() / int64(time.Millisecond),
+		Tags: append([]string{annotationTag}, tags...),
+		Text: text,
+	})
+	if err != nil {
+		return err
+	}
+	request, err := http.NewRequest(http.MethodPost, a.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Authorization", "Bearer "+a.token)
+
+	response, err := a.client.Do(request)
+	if err != nil {
+		return fmt.Errorf("Error adding Grafana annotation: %w", err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		responseBody, _ := ioutil.ReadAll(response.Body)
+		return fmt.Errorf("Error adding Grafana annotation: request failed with code %d: %s", response.StatusCode, strings.TrimSpace(string(responseBody)))
+	}
+	return nil
+
+}
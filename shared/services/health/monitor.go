@@ -0,0 +1,246 @@
+package health
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/events"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Settings
+const (
+	// The path the health check is served on
+	HealthCheckPath string = "/healthz"
+
+	// The address the health check listens on
+	listenAddress string = "0.0.0.0"
+
+	// How long a daemon with tasks can go between them before it's considered stuck
+	staleTaskThreshold = 30 * time.Minute
+
+	// How long a single task can run before it's considered stuck; some, like generating rewards trees, take a long time
+	stuckTaskThreshold = 6 * time.Hour
+)
+
+// When a task last started, finished, and failed
+type TaskStatus struct {
+	LastStarted   time.Time `json:"lastStarted,omitempty"`
+	LastCompleted time.Time `json:"lastCompleted,omitempty"`
+	LastFailed    time.Time `json:"lastFailed,omitempty"`
+	LastError     string    `json:"lastError,omitempty"`
+}
+
+// The status of a client the daemon depends on
+type ClientStatus struct {
+	Reachable bool    `json:"reachable"`
+	Synced    bool    `json:"synced"`
+	Progress  float64 `json:"progress"`
+	Error     string  `json:"error,omitempty"`
+}
+
+// The response of the health check
+type Status struct {
+	Healthy         bool                  `json:"healthy"`
+	Daemon          string                `json:"daemon"`
+	Problems        []string              `json:"problems"`
+	ExecutionClient ClientStatus          `json:"executionClient"`
+	ConsensusClient ClientStatus          `json:"consensusClient"`
+	WalletAvailable bool                  `json:"walletAvailable"`
+	Tasks           map[string]TaskStatus `json:"tasks"`
+}
+
+// Serves a daemon's health check, tracking its tasks through the event bus
+type Monitor struct {
+	c        *cli.Context
+	daemon   string
+	hasTasks bool
+	lastRun  time.Time
+	tasks    map[string]TaskStatus
+	lock     sync.Mutex
+}
+
+// Create a health monitor for a daemon; daemons without tasks are only checked for their clients
+func NewMonitor(c *cli.Context, daemon string, hasTasks bool) *Monitor {
+	monitor := &Monitor{
+		c:        c,
+		daemon:   daemon,
+		hasTasks: hasTasks,
+		tasks:    map[string]TaskStatus{},
+	}
+	if hasTasks {
+		eventChannel, _ := events.GetBus().Subscribe()
+		go monitor.trackTasks(eventChannel)
+	}
+	return monitor
+}
+
+// Start serving a daemon's health check in the background, if health checks are enabled
+func Start(c *cli.Context, daemon string, hasTasks bool, errorLog log.ColorLogger) error {
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return err
+	}
+	if cfg.Smartnode.EnableHealthCheck.Value != true {
+		return nil
+	}
+	monitor := NewMonitor(c, daemon, hasTasks)
+	go func() {
+		if err := monitor.Serve(cfg.Smartnode.GetHealthCheckPort(daemon)); err != nil {
+			errorLog.Println(err)
+		}
+	}()
+	return nil
+}
+
+// Serve the health check on the provided port
+func (m *Monitor) Serve(port uint16) error {
+	mux := http.NewServeMux()
+	mux.HandleFunc(HealthCheckPath, m.handleHealthCheck)
+	if err := http.ListenAndServe(fmt.Sprintf("%s:%d", listenAddress, port), mux); err != nil {
+		return fmt.Errorf("Error serving the health check: %w", err)
+	}
+	return nil
+}
+
+// Get the daemon's current health
+func (m *Monitor) GetStatus() Status {
+
+	status := Status{
+		Daemon:   m.daemon,
+		Problems: []string{},
+	}
+
+	// Check the Execution client
+	ec, err := services.GetEthClient(m.c)
+	if err != nil {
+		status.ExecutionClient.Error = err.Error()
+	} else {
+		ecStatus := ec.CheckStatus(false)
+		activeStatus := ecStatus.PrimaryEcStatus
+		if !activeStatus.IsWorking && ecStatus.FallbackEnabled {
+			activeStatus = ecStatus.FallbackEcStatus
+		}
+		status.ExecutionClient = ClientStatus{
+			Reachable: activeStatus.IsWorking,
+			Synced:    activeStatus.IsWorking && activeStatus.IsSynced,
+			Progress:  activeStatus.SyncProgress,
+			Error:     activeStatus.Error,
+		}
+	}
+	if !status.ExecutionClient.Reachable {
+		status.Problems = append(status.Problems, "No Execution client is reachable")
+	}
+
+	// Check the Beacon node
+	bc, err := services.GetBeaconClient(m.c)
+	if err != nil {
+		status.ConsensusClient.Error = err.Error()
+	} else if syncStatus, err := bc.GetSyncStatus(); err != nil {
+		status.ConsensusClient.Error = err.Error()
+	} else {
+		status.ConsensusClient = ClientStatus{
+			Reachable: true,
+			Synced:    !syncStatus.Syncing,
+			Progress:  syncStatus.Progress,
+		}
+		if !syncStatus.Syncing {
+			status.ConsensusClient.Progress = 1
+		}
+	}
+	if !status.ConsensusClient.Reachable {
+		status.Problems = append(status.Problems, "The Beacon node is not reachable")
+	}
+
+	// Check the wallet
+	if w, err := services.GetWallet(m.c); err == nil {
+		status.WalletAvailable = w.IsInitialized() || w.IsObserver()
+	}
+
+	// Check that the tasks are still running once they've started; daemons can wait a long time for the node to be registered first
+	m.lock.Lock()
+	status.Tasks = make(map[string]TaskStatus, len(m.tasks))
+	for task, taskStatus := range m.tasks {
+		status.Tasks[task] = taskStatus
+	}
+	lastRun := m.lastRun
+	m.lock.Unlock()
+	if m.hasTasks && !lastRun.IsZero() {
+		for task, taskStatus := range status.Tasks {
+			if taskStatus.isRunning() && time.Since(taskStatus.LastStarted) > stuckTaskThreshold {
+				status.Problems = append(status.Problems, fmt.Sprintf("The %s task has been running for %s", task, time.Since(taskStatus.LastStarted).Round(time.Second)))
+			}
+		}
+		if since := time.Since(lastRun); since > staleTaskThreshold && !status.hasRunningTask() {
+			status.Problems = append(status.Problems, fmt.Sprintf("No tasks have run for %s", since.Round(time.Second)))
+		}
+	}
+
+	status.Healthy = len(status.Problems) == 0
+	return status
+
+}
+
+// Check if any of the daemon's tasks are running
+func (status Status) hasRunningTask() bool {
+	for _, taskStatus := range status.Tasks {
+		if taskStatus.isRunning() {
+			return true
+		}
+	}
+	return false
+}
+
+// Check if a task has started but hasn't finished yet
+func (status TaskStatus) isRunning() bool {
+	return status.LastStarted.After(status.LastCompleted) && status.LastStarted.After(status.LastFailed)
+}
+
+// Respond to a health check request
+func (m *Monitor) handleHealthCheck(w http.ResponseWriter, r *http.Request) {
+	status := m.GetStatus()
+	code := http.StatusOK
+	if !status.Healthy {
+		code = http.StatusServiceUnavailable
+	}
+	body, err := json.Marshal(status)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	w.Write(body)
+}
+
+// Record when each task runs
+func (m *Monitor) trackTasks(eventChannel <-chan events.Event) {
+	for event := range eventChannel {
+		if event.Task == "" {
+			continue
+		}
+		m.lock.Lock()
+		taskStatus := m.tasks[event.Task]
+		switch event.Type {
+		case events.EventType_TaskStarted:
+			taskStatus.LastStarted = event.Time
+		case events.EventType_TaskCompleted:
+			taskStatus.LastCompleted = event.Time
+		case events.EventType_TaskFailed:
+			taskStatus.LastFailed = event.Time
+			taskStatus.LastError = event.Message
+		default:
+			m.lock.Unlock()
+			continue
+		}
+		m.tasks[event.Task] = taskStatus
+		m.lastRun = event.Time
+		m.lock.Unlock()
+	}
+}
@@ -0,0 +1,122 @@
+package journal
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Settings
+const (
+	// The size a journal file can grow to before it's rotated
+	maxJournalSize int64 = 20 * 1024 * 1024
+
+	// The longest line a journal file can have
+	maxEntrySize int = 16 * 1024 * 1024
+)
+
+// A record of a single run of an automated task
+type Entry struct {
+	Task      string                     `json:"task"`
+	Time      time.Time                  `json:"time"`
+	Inputs    map[string]json.RawMessage `json:"inputs"`
+	Decisions []string                   `json:"decisions"`
+	Error     string                     `json:"error,omitempty"`
+}
+
+// An append-only file that records the inputs and decisions of each task run by a daemon
+type Journal struct {
+	path string
+}
+
+// Creates a new journal for a daemon, stored in the provided folder
+func NewJournal(folder string, daemon string) *Journal {
+	return &Journal{
+		path: filepath.Join(os.ExpandEnv(folder), fmt.Sprintf("%s.jsonl", daemon)),
+	}
+}
+
+// Get the path of the journal file
+func (j *Journal) GetPath() string {
+	return j.path
+}
+
+// Start recording a new run of a task
+func (j *Journal) StartRun(task string) *Run {
+	return &Run{
+		journal: j,
+		entry: Entry{
+			Task:      task,
+			Time:      time.Now().UTC(),
+			Inputs:    map[string]json.RawMessage{},
+			Decisions: []string{},
+		},
+	}
+}
+
+// Get the recorded runs of a task, oldest first
+func (j *Journal) GetEntries(task string) ([]Entry, error) {
+
+	entries := []Entry{}
+	for _, path := range []string{j.path + ".old", j.path} {
+		file, err := os.Open(path)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error opening journal %s: %w", path, err)
+		}
+
+		scanner := bufio.NewScanner(file)
+		scanner.Buffer(make([]byte, 0, 64*1024), maxEntrySize)
+		for scanner.Scan() {
+			var entry Entry
+			if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+				// Skip entries that were cut off by a crash
+				continue
+			}
+			if entry.Task == task {
+				entries = append(entries, entry)
+			}
+		}
+		err = scanner.Err()
+		file.Close()
+		if err != nil {
+			return nil, fmt.Errorf("error reading journal %s: %w", path, err)
+		}
+	}
+	return entries, nil
+
+}
+
+// Append an entry to the journal file, rotating it first if it's too large
+func (j *Journal) write(entry Entry) error {
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("error serializing journal entry: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(j.path), 0755); err != nil {
+		return fmt.Errorf("error creating journal folder: %w", err)
+	}
+	if info, err := os.Stat(j.path); err == nil && info.Size() > maxJournalSize {
+		if err := os.Rename(j.path, j.path+".old"); err != nil {
+			return fmt.Errorf("error rotating journal: %w", err)
+		}
+	}
+
+	file, err := os.OpenFile(j.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("error opening journal: %w", err)
+	}
+	defer file.Close()
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("error writing journal entry: %w", err)
+	}
+	return nil
+
+}
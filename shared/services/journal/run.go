@@ -0,0 +1,109 @@
+package journal
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// A single run of a task, which is either being recorded into the journal or replayed from an earlier entry
+type Run struct {
+	journal  *Journal
+	entry    Entry
+	recorded *Entry
+}
+
+// Creates a run that replays a recorded entry; its inputs come from the entry and it doesn't act on anything
+func NewReplay(recorded Entry) *Run {
+	return &Run{
+		entry: Entry{
+			Task:      recorded.Task,
+			Time:      time.Now().UTC(),
+			Inputs:    map[string]json.RawMessage{},
+			Decisions: []string{},
+		},
+		recorded: &recorded,
+	}
+}
+
+// Check if this run is a replay of a recorded entry
+func (r *Run) IsReplay() bool {
+	return r.recorded != nil
+}
+
+// Get one of the task's inputs.
+// When recording, fetch is called to load the input into value and the result is saved in the journal.
+// When replaying, value is loaded from the recorded entry instead.
+func (r *Run) Input(name string, value interface{}, fetch func() error) error {
+
+	if r.IsReplay() {
+		recordedValue, exists := r.recorded.Inputs[name]
+		if !exists {
+			return fmt.Errorf("the journal entry doesn't have a recorded value for %s", name)
+		}
+		if err := json.Unmarshal(recordedValue, value); err != nil {
+			return fmt.Errorf("error loading the recorded value for %s: %w", name, err)
+		}
+		r.entry.Inputs[name] = recordedValue
+		return nil
+	}
+
+	if err := fetch(); err != nil {
+		return err
+	}
+	bytes, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("error recording %s: %w", name, err)
+	}
+	r.entry.Inputs[name] = bytes
+	return nil
+
+}
+
+// Get one of the task's settings, such as a gas threshold.
+// When recording, value is saved in the journal; when replaying, it's replaced with the recorded setting.
+func (r *Run) Setting(name string, value interface{}) error {
+	return r.Input(name, value, func() error {
+		return nil
+	})
+}
+
+// Record a decision the task made
+func (r *Run) Decide(format string, args ...interface{}) {
+	r.entry.Decisions = append(r.entry.Decisions, fmt.Sprintf(format, args...))
+}
+
+// Record that the task is about to act, such as by submitting a transaction.
+// Returns false if this run is a replay, in which case the task must not act.
+func (r *Run) Act(format string, args ...interface{}) bool {
+	r.Decide(format, args...)
+	return !r.IsReplay()
+}
+
+// Get the decisions made during this run
+func (r *Run) GetDecisions() []string {
+	return r.entry.Decisions
+}
+
+// Get the decisions made during the recorded run, if this is a replay
+func (r *Run) GetRecordedDecisions() []string {
+	if !r.IsReplay() {
+		return nil
+	}
+	return r.recorded.Decisions
+}
+
+// Finish the run, saving it to the journal if it's being recorded.
+// Returns the task's error, or the error saving the entry if the task succeeded.
+func (r *Run) Finish(err error) error {
+	if err != nil {
+		r.entry.Error = err.Error()
+	}
+	if r.IsReplay() {
+		return err
+	}
+	if writeErr := r.journal.write(r.entry); writeErr != nil && err == nil {
+		return fmt.Errorf("Error saving the %s run to the task journal: %w", r.entry.Task, writeErr)
+	}
+	return err
+}
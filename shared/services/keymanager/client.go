@@ -0,0 +1,293 @@
+package keymanager
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
+	eth2types "github.com/wealdtech/go-eth2-types/v2"
+	eth2ks "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+
+	keystore "github.com/rocket-pool/smartnode/shared/services/wallet/keystore"
+)
+
+// Config
+const (
+	KeystoresPath      = "/eth/v1/keystores"
+	RequestContentType = "application/json"
+	RequestTimeout     = 2 * time.Minute
+
+	ImportStatusImported  = "imported"
+	ImportStatusDuplicate = "duplicate"
+	DeleteStatusDeleted   = "deleted"
+	DeleteStatusNotActive = "not_active"
+	DeleteStatusNotFound  = "not_found"
+)
+
+// A client for a Validator Client's Keymanager API
+type Client struct {
+	url       string
+	token     string
+	client    *http.Client
+	encryptor *eth2ks.Encryptor
+}
+
+// A key loaded by the Validator Client
+type Keystore struct {
+	Pubkey   rptypes.ValidatorPubkey `json:"validating_pubkey"`
+	Path     string                  `json:"derivation_path"`
+	ReadOnly bool                    `json:"readonly"`
+}
+
+// An encrypted keystore for a validator key, along with its password
+type StoredKeystore struct {
+	Pubkey   rptypes.ValidatorPubkey
+	Keystore string
+	Password string
+}
+
+// The result of importing or deleting a single key
+type KeyStatus struct {
+	Status  string `json:"status"`
+	Message string `json:"message"`
+}
+
+// Encrypted validator key store
+type validatorKey struct {
+	Crypto  map[string]interface{}  `json:"crypto"`
+	Version uint                    `json:"version"`
+	UUID    uuid.UUID               `json:"uuid"`
+	Path    string                  `json:"path"`
+	Pubkey  rptypes.ValidatorPubkey `json:"pubkey"`
+}
+
+// Request / response types
+type listResponse struct {
+	Data []Keystore `json:"data"`
+}
+type importRequest struct {
+	Keystores          []string `json:"keystores"`
+	Passwords          []string `json:"passwords"`
+	SlashingProtection string   `json:"slashing_protection,omitempty"`
+}
+type deleteRequest struct {
+	Pubkeys []rptypes.ValidatorPubkey `json:"pubkeys"`
+}
+type statusResponse struct {
+	Data               []KeyStatus `json:"data"`
+	SlashingProtection string      `json:"slashing_protection"`
+}
+type errorResponse struct {
+	Message string `json:"message"`
+}
+
+// Create a new Keymanager API client.
+// The API token is read from the provided file. If a certificate file is provided, it's the only certificate trusted for the API's TLS connection.
+func NewClient(url string, tokenPath string, certPath string) (*Client, error) {
+	if url == "" {
+		return nil, fmt.Errorf("The Keymanager API URL for your Validator Client is not set.")
+	}
+	token, err := ioutil.ReadFile(os.ExpandEnv(tokenPath))
+	if err != nil {
+		return nil, fmt.Errorf("Could not read the Keymanager API token from %s: %w", tokenPath, err)
+	}
+	httpClient := &http.Client{
+		Timeout: RequestTimeout,
+	}
+	if certPath != "" {
+		cert, err := ioutil.ReadFile(os.ExpandEnv(certPath))
+		if err != nil {
+			return nil, fmt.Errorf("Could not read the Keymanager API certificate from %s: %w", certPath, err)
+		}
+		certPool := x509.NewCertPool()
+		if !certPool.AppendCertsFromPEM(cert) {
+			return nil, fmt.Errorf("The Keymanager API certificate in %s is not a valid PEM certificate", certPath)
+		}
+		httpClient.Transport = &http.Transport{
+			TLSClientConfig: &tls.Config{
+				RootCAs: certPool,
+			},
+		}
+	}
+	return &Client{
+		url:       strings.TrimSuffix(url, "/"),
+		token:     strings.TrimSpace(string(token)),
+		client:    httpClient,
+		encryptor: eth2ks.New(eth2ks.WithCipher("scrypt")),
+	}, nil
+}
+
+// Get the keys loaded by the Validator Client
+func (c *Client) ListKeystores() ([]Keystore, error) {
+	var response listResponse
+	if err := c.request(http.MethodGet, nil, &response); err != nil {
+		return nil, fmt.Errorf("Could not list the Validator Client's keys: %w", err)
+	}
+	return response.Data, nil
+}
+
+// Remove keys from the Validator Client, so it stops validating with them.
+// Returns the status of each key and the slashing protection history for them in EIP-3076 format.
+func (c *Client) DeleteKeystores(pubkeys []rptypes.ValidatorPubkey) ([]KeyStatus, string, error) {
+	var response statusResponse
+	if err := c.request(http.MethodDelete, deleteRequest{Pubkeys: pubkeys}, &response); err != nil {
+		return nil, "", fmt.Errorf("Could not remove the Validator Client's keys: %w", err)
+	}
+	if len(response.Data) != len(pubkeys) {
+		return nil, "", fmt.Errorf("The Validator Client returned %d results for %d removed keys", len(response.Data), len(pubkeys))
+	}
+	return response.Data, response.SlashingProtection, nil
+}
+
+// Unload keys from the Validator Client, so it stops validating with them.
+// Keys it doesn't have count as unloaded; an error is returned if any of the others couldn't be removed.
+func (c *Client) UnloadKeys(pubkeys []rptypes.ValidatorPubkey) error {
+	statuses, _, err := c.DeleteKeystores(pubkeys)
+	if err != nil {
+		return err
+	}
+	for i, status := range statuses {
+		if status.Status != DeleteStatusDeleted && status.Status != DeleteStatusNotActive && status.Status != DeleteStatusNotFound {
+			return fmt.Errorf("The Validator Client could not remove key %s (%s): %s", pubkeys[i].Hex(), status.Status, status.Message)
+		}
+	}
+	return nil
+}
+
+// Import keys into the Validator Client along with their slashing protection history in EIP-3076 format.
+// Returns the status of each key.
+func (c *Client) ImportKeys(keys []*eth2types.BLSPrivateKey, slashingProtection string) ([]KeyStatus, error) {
+
+	// Encrypt the keys
+	keystores := []StoredKeystore{}
+	for _, key := range keys {
+		keystoreString, password, err := c.encryptKey(key)
+		if err != nil {
+			return nil, err
+		}
+		keystores = append(keystores, StoredKeystore{
+			Pubkey:   rptypes.BytesToValidatorPubkey(key.PublicKey().Marshal()),
+			Keystore: keystoreString,
+			Password: password,
+		})
+	}
+
+	// Import them
+	return c.importKeystores(keystores, slashingProtection)
+
+}
+
+// Load keystores that are already stored on disk into the Validator Client without any slashing protection history, so it starts validating with them.
+// Returns the status of each key; keys it already has count as loaded, and an error is returned if any of the others couldn't be imported.
+func (c *Client) LoadKeystores(keystores []StoredKeystore) ([]KeyStatus, error) {
+	statuses, err := c.importKeystores(keystores, "")
+	if err != nil {
+		return nil, err
+	}
+	for i, status := range statuses {
+		if status.Status != ImportStatusImported && status.Status != ImportStatusDuplicate {
+			return statuses, fmt.Errorf("The Validator Client could not import key %s (%s): %s", keystores[i].Pubkey.Hex(), status.Status, status.Message)
+		}
+	}
+	return statuses, nil
+}
+
+// Import encrypted keystores into the Validator Client and return the status of each key
+func (c *Client) importKeystores(keystores []StoredKeystore, slashingProtection string) ([]KeyStatus, error) {
+	request := importRequest{
+		Keystores:          []string{},
+		Passwords:          []string{},
+		SlashingProtection: slashingProtection,
+	}
+	for _, ks := range keystores {
+		request.Keystores = append(request.Keystores, ks.Keystore)
+		request.Passwords = append(request.Passwords, ks.Password)
+	}
+	var response statusResponse
+	if err := c.request(http.MethodPost, request, &response); err != nil {
+		return nil, fmt.Errorf("Could not import keys into the Validator Client: %w", err)
+	}
+	if len(response.Data) != len(keystores) {
+		return nil, fmt.Errorf("The Validator Client returned %d results for %d imported keys", len(response.Data), len(keystores))
+	}
+	return response.Data, nil
+}
+
+// Encrypt a key into a keystore with a new random password
+func (c *Client) encryptKey(key *eth2types.BLSPrivateKey) (string, string, error) {
+	pubkey := rptypes.BytesToValidatorPubkey(key.PublicKey().Marshal())
+	password, err := keystore.GenerateRandomPassword()
+	if err != nil {
+		return "", "", fmt.Errorf("Could not generate random password: %w", err)
+	}
+	encryptedKey, err := c.encryptor.Encrypt(key.Marshal(), password)
+	if err != nil {
+		return "", "", fmt.Errorf("Could not encrypt validator key %s: %w", pubkey.Hex(), err)
+	}
+	keystoreBytes, err := json.Marshal(validatorKey{
+		Crypto:  encryptedKey,
+		Version: c.encryptor.Version(),
+		UUID:    uuid.New(),
+		Pubkey:  pubkey,
+	})
+	if err != nil {
+		return "", "", fmt.Errorf("Could not encode validator key %s: %w", pubkey.Hex(), err)
+	}
+	return string(keystoreBytes), password, nil
+}
+
+// Make a request to the keystores endpoint and decode the response
+func (c *Client) request(method string, requestBody interface{}, response interface{}) error {
+
+	// Build the request
+	var body []byte
+	if requestBody != nil {
+		var err error
+		body, err = json.Marshal(requestBody)
+		if err != nil {
+			return err
+		}
+	}
+	request, err := http.NewRequest(method, c.url+KeystoresPath, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Authorization", "Bearer "+c.token)
+	if requestBody != nil {
+		request.Header.Set("Content-Type", RequestContentType)
+	}
+
+	// Send it
+	httpResponse, err := c.client.Do(request)
+	if err != nil {
+		return fmt.Errorf("Could not reach the Validator Client's Keymanager API: %w", err)
+	}
+	defer func() {
+		_ = httpResponse.Body.Close()
+	}()
+	responseBody, err := ioutil.ReadAll(httpResponse.Body)
+	if err != nil {
+		return err
+	}
+
+	// Check the response
+	if httpResponse.StatusCode != http.StatusOK {
+		var errResp errorResponse
+		_ = json.Unmarshal(responseBody, &errResp)
+		return fmt.Errorf("The Keymanager API returned code %d: %s", httpResponse.StatusCode, errResp.Message)
+	}
+	if err := json.Unmarshal(responseBody, response); err != nil {
+		return fmt.Errorf("Could not decode the Keymanager API response: %w", err)
+	}
+	return nil
+
+}
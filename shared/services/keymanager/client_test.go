@@ -0,0 +1,272 @@
+package keymanager
+
+import (
+	"encoding/json"
+	"encoding/pem"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
+	eth2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+const testToken string = "api-token"
+
+// Write a file into a temporary folder and return its path
+func writeTestFile(t *testing.T, name string, contents []byte) string {
+	dir, err := ioutil.TempDir("", "rp-keymanager")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		os.RemoveAll(dir)
+	})
+	path := filepath.Join(dir, name)
+	if err := ioutil.WriteFile(path, contents, 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// Create a client for a test server, trusting the server's certificate if it uses TLS
+func newTestClient(t *testing.T, server *httptest.Server) *Client {
+	tokenPath := writeTestFile(t, "api-token.txt", []byte(testToken+"\n"))
+	certPath := ""
+	if server.TLS != nil {
+		certPath = writeTestFile(t, "cert.pem", pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+	}
+	client, err := NewClient(server.URL, tokenPath, certPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+// Create a test server for the keystores endpoint that checks the API token and replies with the given statuses
+func newStatusServer(t *testing.T, method string, statuses []KeyStatus, requestBody interface{}) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != KeystoresPath || r.Method != method {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if auth := r.Header.Get("Authorization"); auth != "Bearer "+testToken {
+			w.WriteHeader(http.StatusUnauthorized)
+			_ = json.NewEncoder(w).Encode(errorResponse{Message: "bad token"})
+			return
+		}
+		if requestBody != nil {
+			if err := json.NewDecoder(r.Body).Decode(requestBody); err != nil {
+				t.Errorf("could not decode request: %s", err.Error())
+			}
+		}
+		_ = json.NewEncoder(w).Encode(statusResponse{Data: statuses, SlashingProtection: "{}"})
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// Get a pubkey for a test key
+func testPubkey(b byte) rptypes.ValidatorPubkey {
+	var pubkey rptypes.ValidatorPubkey
+	pubkey[0] = b
+	return pubkey
+}
+
+// Get stored keystores for test keys
+func testKeystores(count int) []StoredKeystore {
+	keystores := []StoredKeystore{}
+	for i := 0; i < count; i++ {
+		keystores = append(keystores, StoredKeystore{
+			Pubkey:   testPubkey(byte(i + 1)),
+			Keystore: `{"version":4}`,
+			Password: "password",
+		})
+	}
+	return keystores
+}
+
+func TestRequestSendsToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if auth := r.Header.Get("Authorization"); auth != "Bearer "+testToken {
+			t.Errorf("unexpected Authorization header %q", auth)
+			w.WriteHeader(http.StatusUnauthorized)
+			_ = json.NewEncoder(w).Encode(errorResponse{Message: "bad token"})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(listResponse{Data: []Keystore{{Pubkey: testPubkey(1)}}})
+	}))
+	defer server.Close()
+
+	keystores, err := newTestClient(t, server).ListKeystores()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(keystores) != 1 || keystores[0].Pubkey != testPubkey(1) {
+		t.Fatalf("unexpected keystores %v", keystores)
+	}
+}
+
+func TestRejectedTokenIsAnError(t *testing.T) {
+	server := newStatusServer(t, http.MethodGet, nil, nil)
+	client := newTestClient(t, server)
+	client.token = "wrong-token"
+
+	_, err := client.ListKeystores()
+	if err == nil || !strings.Contains(err.Error(), "401") || !strings.Contains(err.Error(), "bad token") {
+		t.Fatalf("expected the API's rejection, got %v", err)
+	}
+}
+
+func TestTlsTrustsOnlyTheProvidedCertificate(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(listResponse{Data: []Keystore{}})
+	}))
+	defer server.Close()
+
+	// The server's own certificate is trusted
+	if _, err := newTestClient(t, server).ListKeystores(); err != nil {
+		t.Fatalf("expected the provided certificate to be trusted: %s", err.Error())
+	}
+
+	// Without it, the connection fails
+	tokenPath := writeTestFile(t, "api-token.txt", []byte(testToken))
+	client, err := NewClient(server.URL, tokenPath, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.ListKeystores(); err == nil {
+		t.Fatal("expected an untrusted certificate to be rejected")
+	}
+
+	// So does a certificate that isn't a PEM certificate
+	certPath := writeTestFile(t, "cert.pem", []byte("not a certificate"))
+	if _, err := NewClient(server.URL, tokenPath, certPath); err == nil {
+		t.Fatal("expected an invalid certificate file to be rejected")
+	}
+}
+
+func TestLoadKeystores(t *testing.T) {
+	keystores := testKeystores(2)
+
+	// Imported and duplicate keys are loaded, and the stored keystores are sent as they are
+	var request importRequest
+	server := newStatusServer(t, http.MethodPost, []KeyStatus{{Status: ImportStatusImported}, {Status: ImportStatusDuplicate}}, &request)
+	statuses, err := newTestClient(t, server).LoadKeystores(keystores)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(statuses) != 2 || statuses[0].Status != ImportStatusImported || statuses[1].Status != ImportStatusDuplicate {
+		t.Fatalf("unexpected statuses %v", statuses)
+	}
+	if len(request.Keystores) != 2 || request.Keystores[0] != keystores[0].Keystore || request.Passwords[1] != keystores[1].Password {
+		t.Fatalf("unexpected request %v", request)
+	}
+	if request.SlashingProtection != "" {
+		t.Fatalf("expected no slashing protection history, got %s", request.SlashingProtection)
+	}
+
+	// A key that failed is reported along with the statuses
+	server = newStatusServer(t, http.MethodPost, []KeyStatus{{Status: ImportStatusImported}, {Status: "error", Message: "bad keystore"}}, nil)
+	statuses, err = newTestClient(t, server).LoadKeystores(keystores)
+	if err == nil || !strings.Contains(err.Error(), keystores[1].Pubkey.Hex()) || !strings.Contains(err.Error(), "bad keystore") {
+		t.Fatalf("expected an error for the failed key, got %v", err)
+	}
+	if len(statuses) != 2 || statuses[0].Status != ImportStatusImported {
+		t.Fatalf("expected the statuses of every key, got %v", statuses)
+	}
+
+	// A result for every key is required
+	server = newStatusServer(t, http.MethodPost, []KeyStatus{{Status: ImportStatusImported}}, nil)
+	if _, err := newTestClient(t, server).LoadKeystores(keystores); err == nil {
+		t.Fatal("expected an error for a missing result")
+	}
+}
+
+func TestUnloadKeys(t *testing.T) {
+	pubkeys := []rptypes.ValidatorPubkey{testPubkey(1), testPubkey(2), testPubkey(3)}
+
+	// Deleted, inactive and missing keys are unloaded
+	var request deleteRequest
+	server := newStatusServer(t, http.MethodDelete, []KeyStatus{{Status: DeleteStatusDeleted}, {Status: DeleteStatusNotActive}, {Status: DeleteStatusNotFound}}, &request)
+	if err := newTestClient(t, server).UnloadKeys(pubkeys); err != nil {
+		t.Fatal(err)
+	}
+	if len(request.Pubkeys) != 3 || request.Pubkeys[2] != pubkeys[2] {
+		t.Fatalf("unexpected request %v", request)
+	}
+
+	// A key that couldn't be removed is an error
+	server = newStatusServer(t, http.MethodDelete, []KeyStatus{{Status: DeleteStatusDeleted}, {Status: "error", Message: "read-only key"}, {Status: DeleteStatusDeleted}}, nil)
+	err := newTestClient(t, server).UnloadKeys(pubkeys)
+	if err == nil || !strings.Contains(err.Error(), pubkeys[1].Hex()) || !strings.Contains(err.Error(), "read-only key") {
+		t.Fatalf("expected an error for the key that wasn't removed, got %v", err)
+	}
+}
+
+func TestDeleteKeystores(t *testing.T) {
+	pubkeys := []rptypes.ValidatorPubkey{testPubkey(1), testPubkey(2)}
+
+	server := newStatusServer(t, http.MethodDelete, []KeyStatus{{Status: DeleteStatusDeleted}, {Status: "error", Message: "failed"}}, nil)
+	statuses, slashingProtection, err := newTestClient(t, server).DeleteKeystores(pubkeys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(statuses) != 2 || statuses[1].Status != "error" || statuses[1].Message != "failed" {
+		t.Fatalf("unexpected statuses %v", statuses)
+	}
+	if slashingProtection != "{}" {
+		t.Fatalf("unexpected slashing protection history %s", slashingProtection)
+	}
+
+	server = newStatusServer(t, http.MethodDelete, []KeyStatus{{Status: DeleteStatusDeleted}}, nil)
+	if _, _, err := newTestClient(t, server).DeleteKeystores(pubkeys); err == nil {
+		t.Fatal("expected an error for a missing result")
+	}
+}
+
+func TestImportKeys(t *testing.T) {
+	if err := eth2types.InitBLS(); err != nil {
+		t.Fatal(err)
+	}
+	key, err := eth2types.GenerateBLSPrivateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	pubkey := rptypes.BytesToValidatorPubkey(key.PublicKey().Marshal())
+
+	var request importRequest
+	server := newStatusServer(t, http.MethodPost, []KeyStatus{{Status: ImportStatusImported}}, &request)
+	statuses, err := newTestClient(t, server).ImportKeys([]*eth2types.BLSPrivateKey{key}, `{"data":[]}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(statuses) != 1 || statuses[0].Status != ImportStatusImported {
+		t.Fatalf("unexpected statuses %v", statuses)
+	}
+
+	// The key is sent as an encrypted keystore with its password, along with the history
+	if len(request.Keystores) != 1 || len(request.Passwords) != 1 || request.Passwords[0] == "" {
+		t.Fatalf("unexpected request %v", request)
+	}
+	var keystore validatorKey
+	if err := json.Unmarshal([]byte(request.Keystores[0]), &keystore); err != nil {
+		t.Fatal(err)
+	}
+	if keystore.Pubkey != pubkey {
+		t.Fatalf("expected keystore for %s, got %s", pubkey.Hex(), keystore.Pubkey.Hex())
+	}
+	decrypted, err := newTestClient(t, server).encryptor.Decrypt(keystore.Crypto, request.Passwords[0])
+	if err != nil {
+		t.Fatalf("could not decrypt the keystore with its password: %s", err.Error())
+	}
+	if string(decrypted) != string(key.Marshal()) {
+		t.Fatal("the keystore doesn't hold the imported key")
+	}
+	if request.SlashingProtection != `{"data":[]}` {
+		t.Fatalf("unexpected slashing protection history %s", request.SlashingProtection)
+	}
+}
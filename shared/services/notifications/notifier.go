@@ -0,0 +1,335 @@
+package notifications
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/grafana"
+)
+
+// Settings
+const (
+	requestTimeout  = 15 * time.Second
+	maxQueuedEvents = 100
+)
+
+// The kinds of events the daemons send notifications about
+type EventType string
+
+const (
+	EventType_MinipoolStaked      EventType = "minipoolStaked"
+	EventType_RplClaimed          EventType = "rplClaimed"
+	EventType_FallbackActivated   EventType = "fallbackActivated"
+	EventType_ExecutionClientDown EventType = "executionClientDown"
+	EventType_LowDiskSpace        EventType = "lowDiskSpace"
+	EventType_ChainReorg          EventType = "chainReorg"
+	EventType_FinalityStalled     EventType = "finalityStalled"
+	EventType_ChainRecovered      EventType = "chainRecovered"
+	EventType_Underperforming     EventType = "validatorsUnderperforming"
+	EventType_ClientError         EventType = "clientError"
+	EventType_ClientPeersLost     EventType = "clientPeersLost"
+	EventType_ClientSyncMilestone EventType = "clientSyncMilestone"
+	EventType_ValidatorCrashLoop  EventType = "validatorCrashLoop"
+	EventType_ValidatorOomKilled  EventType = "validatorOomKilled"
+	EventType_SlashingSurge       EventType = "slashingSurge"
+	EventType_ValidatorSlashed    EventType = "validatorSlashed"
+	EventType_EcLowDiskSpace      EventType = "ecLowDiskSpace"
+	EventType_EcPruneStarted      EventType = "ecPruneStarted"
+	EventType_ClockDrift          EventType = "clockDrift"
+	EventType_RethDeviation       EventType = "rethDeviation"
+)
+
+// How urgent a notification is
+type Severity string
+
+const (
+	Severity_Info     Severity = "info"
+	Severity_Warning  Severity = "warning"
+	Severity_Critical Severity = "critical"
+)
+
+// The severity of each event; the ones that aren't listed are warnings
+var eventSeverities = map[EventType]Severity{
+	EventType_MinipoolStaked:      Severity_Info,
+	EventType_RplClaimed:          Severity_Info,
+	EventType_ChainRecovered:      Severity_Info,
+	EventType_ClientSyncMilestone: Severity_Info,
+	EventType_EcPruneStarted:      Severity_Info,
+	EventType_ValidatorSlashed:    Severity_Critical,
+	EventType_ValidatorCrashLoop:  Severity_Critical,
+	EventType_SlashingSurge:       Severity_Critical,
+	EventType_ExecutionClientDown: Severity_Critical,
+	EventType_FinalityStalled:     Severity_Critical,
+	EventType_EcLowDiskSpace:      Severity_Critical,
+}
+
+// Events about ongoing problems; these are only repeated once the cooldown has passed
+var repeatingEvents = map[EventType]bool{
+	EventType_FallbackActivated:   true,
+	EventType_ExecutionClientDown: true,
+	EventType_LowDiskSpace:        true,
+	EventType_FinalityStalled:     true,
+	EventType_Underperforming:     true,
+	EventType_ClientError:         true,
+	EventType_ClientPeersLost:     true,
+	EventType_SlashingSurge:       true,
+	EventType_EcLowDiskSpace:      true,
+	EventType_ClockDrift:          true,
+	EventType_RethDeviation:       true,
+	EventType_ValidatorOomKilled:  true,
+}
+
+// A notification about an event
+type Event struct {
+	Type     EventType `json:"type"`
+	Severity Severity  `json:"severity"`
+	Node     string    `json:"node"`
+	Title    string    `json:"title"`
+	Message  string    `json:"message"`
+	Time     time.Time `json:"time"`
+}
+
+// Sends notifications to the webhooks, Discord, and Telegram, as configured, and marks them on the Grafana dashboards
+type Notifier struct {
+	enabled           bool
+	annotator         *grafana.Annotator
+	nodeLabel         string
+	webhookUrl        string
+	discordWebhookUrl string
+	telegramBotToken  string
+	telegramChatId    string
+	cooldown          time.Duration
+	schedule          *config.ScheduleConfig
+	queueAlerts       bool
+	client            *http.Client
+	lastSent          map[EventType]time.Time
+	queued            []Event
+	lock              sync.Mutex
+}
+
+// Creates a new Notifier based on the Rocket Pool config
+func NewNotifier(cfg *config.RocketPoolConfig) *Notifier {
+	notifications := cfg.Notifications
+	return &Notifier{
+		enabled:           cfg.EnableNotifications.Value == true,
+		annotator:         grafana.NewAnnotator(cfg, config.GrafanaContainerName),
+		nodeLabel:         notifications.NodeLabel.GetStringOrDefault(config.Network_All),
+		webhookUrl:        notifications.WebhookUrl.GetStringOrDefault(config.Network_All),
+		discordWebhookUrl: notifications.DiscordWebhookUrl.GetStringOrDefault(config.Network_All),
+		telegramBotToken:  notifications.TelegramBotToken.GetStringOrDefault(config.Network_All),
+		telegramChatId:    notifications.TelegramChatId.GetStringOrDefault(config.Network_All),
+		cooldown:          time.Duration(notifications.Cooldown.GetUintOrDefault(config.Network_All)) * time.Minute,
+		schedule:          cfg.Schedule,
+		queueAlerts:       cfg.Schedule.QueueAlerts.Value == true,
+		client:            &http.Client{Timeout: requestTimeout},
+		lastSent:          map[EventType]time.Time{},
+	}
+}
+
+// Check if notifications or Grafana annotations are enabled
+func (n *Notifier) IsEnabled() bool {
+	return n != nil && (n.enabled || n.annotator.IsEnabled())
+}
+
+// Set the label used to identify this node if one wasn't configured
+func (n *Notifier) SetDefaultNodeLabel(label string) {
+	if n == nil {
+		return
+	}
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	if n.nodeLabel == "" {
+		n.nodeLabel = label
+	}
+}
+
+// Send a notification about an event to every configured destination.
+// Notifications about ongoing problems are skipped if one was sent recently.
+func (n *Notifier) Notify(eventType EventType, title string, message string) error {
+
+	if !n.IsEnabled() {
+		return nil
+	}
+
+	// Check the cooldown
+	n.lock.Lock()
+	now := time.Now()
+	if repeatingEvents[eventType] {
+		if lastSent, exists := n.lastSent[eventType]; exists && now.Sub(lastSent) < n.cooldown {
+			n.lock.Unlock()
+			return nil
+		}
+		n.lastSent[eventType] = now
+	}
+	event := Event{
+		Type:     eventType,
+		Severity: getSeverity(eventType),
+		Node:     n.nodeLabel,
+		Title:    title,
+		Message:  message,
+		Time:     now.UTC(),
+	}
+
+	// Hold non-critical notifications back during blackout windows; they're still marked on the dashboards
+	held := n.enabled && event.Severity != Severity_Critical && n.schedule.IsBlackout(now)
+	if held && n.queueAlerts && len(n.queued) < maxQueuedEvents {
+		n.queued = append(n.queued, event)
+	}
+	n.lock.Unlock()
+
+	// Send it everywhere
+	errs := []string{}
+	if err := n.annotator.Annotate(formatEvent(event, ""), string(eventType)); err != nil {
+		errs = append(errs, fmt.Sprintf("Grafana: %s", err.Error()))
+	}
+	if !n.enabled || held {
+		return joinErrors(eventType, errs)
+	}
+	errs = append(errs, n.send(event)...)
+	return joinErrors(eventType, errs)
+
+}
+
+// Send the notifications that were held back during a blackout window, once it's over
+func (n *Notifier) SendQueued() error {
+
+	if !n.IsEnabled() {
+		return nil
+	}
+	n.lock.Lock()
+	if len(n.queued) == 0 || n.schedule.IsBlackout(time.Now()) {
+		n.lock.Unlock()
+		return nil
+	}
+	queued := n.queued
+	n.queued = nil
+	n.lock.Unlock()
+
+	errs := []string{}
+	for _, event := range queued {
+		event.Title = fmt.Sprintf("%s (delayed from %s)", event.Title, event.Time.Format(time.RFC822))
+		errs = append(errs, n.send(event)...)
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("Error sending queued notifications: %s", strings.Join(errs, "; "))
+	}
+	return nil
+
+}
+
+// Send an event to the webhooks, Discord, and Telegram, returning the errors from each destination
+func (n *Notifier) send(event Event) []string {
+	errs := []string{}
+	if n.webhookUrl != "" {
+		if err := n.sendWebhook(event); err != nil {
+			errs = append(errs, fmt.Sprintf("webhook: %s", err.Error()))
+		}
+	}
+	if n.discordWebhookUrl != "" {
+		if err := n.sendDiscord(event); err != nil {
+			errs = append(errs, fmt.Sprintf("Discord: %s", err.Error()))
+		}
+	}
+	if n.telegramBotToken != "" && n.telegramChatId != "" {
+		if err := n.sendTelegram(event); err != nil {
+			errs = append(errs, fmt.Sprintf("Telegram: %s", err.Error()))
+		}
+	}
+	return errs
+}
+
+// Clear the cooldown of an ongoing problem once it has been resolved, so it's reported again if it comes back
+func (n *Notifier) Resolve(eventType EventType) {
+	if !n.IsEnabled() {
+		return
+	}
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	delete(n.lastSent, eventType)
+}
+
+// Send an event to the generic webhook
+func (n *Notifier) sendWebhook(event Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	return n.post(n.webhookUrl, body)
+}
+
+// Send an event to the Discord webhook
+func (n *Notifier) sendDiscord(event Event) error {
+	body, err := json.Marshal(map[string]string{
+		"content": formatEvent(event, "**"),
+	})
+	if err != nil {
+		return err
+	}
+	return n.post(n.discordWebhookUrl, body)
+}
+
+// Send an event to the Telegram chat
+func (n *Notifier) sendTelegram(event Event) error {
+	body, err := json.Marshal(map[string]string{
+		"chat_id": n.telegramChatId,
+		"text":    formatEvent(event, ""),
+	})
+	if err != nil {
+		return err
+	}
+	return n.post(fmt.Sprintf("https://api.telegram.org/bot%s/sendMessage", url.PathEscape(n.telegramBotToken)), body)
+}
+
+// POST a JSON body to a URL.
+// The URLs contain secrets, so they're left out of any errors.
+func (n *Notifier) post(targetUrl string, body []byte) error {
+	response, err := n.client.Post(targetUrl, "application/json", bytes.NewReader(body))
+	if err != nil {
+		if urlErr, ok := err.(*url.Error); ok {
+			return urlErr.Err
+		}
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		responseBody, _ := ioutil.ReadAll(response.Body)
+		return fmt.Errorf("request failed with code %d: %s", response.StatusCode, strings.TrimSpace(string(responseBody)))
+	}
+	return nil
+}
+
+// Combine the errors from sending a notification to each destination
+func joinErrors(eventType EventType, errs []string) error {
+	if len(errs) > 0 {
+		return fmt.Errorf("Error sending %s notification: %s", eventType, strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// Get the severity of an event
+func getSeverity(eventType EventType) Severity {
+	if severity, exists := eventSeverities[eventType]; exists {
+		return severity
+	}
+	return Severity_Warning
+}
+
+// Format an event as a chat message, using the provided markup to emphasize the title
+func formatEvent(event Event, emphasis string) string {
+	title := event.Title
+	if event.Severity == Severity_Critical {
+		title = "CRITICAL: " + title
+	}
+	if event.Node != "" {
+		title = fmt.Sprintf("[%s] %s", event.Node, title)
+	}
+	return fmt.Sprintf("%s%s%s\n%s", emphasis, title, emphasis, event.Message)
+}
@@ -0,0 +1,27 @@
+package notifications
+
+import (
+	"testing"
+)
+
+func TestCriticalEventsBypassBlackouts(t *testing.T) {
+	critical := []EventType{
+		EventType_ValidatorSlashed,
+		EventType_ValidatorCrashLoop,
+		EventType_SlashingSurge,
+		EventType_ExecutionClientDown,
+		EventType_FinalityStalled,
+		EventType_EcLowDiskSpace,
+	}
+	for _, eventType := range critical {
+		if severity := getSeverity(eventType); severity != Severity_Critical {
+			t.Errorf("expected %s to be critical, got %s", eventType, severity)
+		}
+	}
+	if severity := getSeverity(EventType_ClientPeersLost); severity != Severity_Warning {
+		t.Errorf("expected unlisted events to be warnings, got %s", severity)
+	}
+	if severity := getSeverity(EventType_MinipoolStaked); severity != Severity_Info {
+		t.Errorf("expected %s to be info, got %s", EventType_MinipoolStaked, severity)
+	}
+}
@@ -0,0 +1,163 @@
+package services
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+
+	"github.com/rocket-pool/smartnode/shared/utils/filelock"
+)
+
+// A transaction that was sent to the private relay without waiting for it to be included
+type relayedTransaction struct {
+	Hash      common.Hash `json:"hash"`
+	Tx        string      `json:"tx"`
+	Submitted time.Time   `json:"submitted"`
+}
+
+// Send a transaction to the private relay and record it, so the node daemon can send it to the public mempool if it isn't included in time
+func (p *ExecutionClientManager) submitPrivateTransaction(ctx context.Context, tx *types.Transaction) error {
+
+	if err := p.relayEc.SendTransaction(ctx, tx); err != nil {
+		return err
+	}
+	txBytes, err := tx.MarshalBinary()
+	if err != nil {
+		return fmt.Errorf("Could not serialize transaction: %w", err)
+	}
+
+	fileLock, err := filelock.Lock(p.relayQueuePath)
+	if err != nil {
+		return err
+	}
+	defer fileLock.Unlock()
+	relayed, err := p.loadRelayedTransactions()
+	if err != nil {
+		return err
+	}
+	relayed = append(relayed, relayedTransaction{
+		Hash:      tx.Hash(),
+		Tx:        hex.EncodeToString(txBytes),
+		Submitted: p.clock.Now(),
+	})
+	return p.saveRelayedTransactions(relayed)
+
+}
+
+// Check the transactions the CLI sent to the private relay, sending the ones that weren't included before the relay timeout to the public mempool.
+// This is only used by the node daemon.
+func (p *ExecutionClientManager) RebroadcastPrivateTransactions(ctx context.Context) error {
+
+	if p.relayEc == nil {
+		return nil
+	}
+	fileLock, err := filelock.Lock(p.relayQueuePath)
+	if err != nil {
+		return err
+	}
+	defer fileLock.Unlock()
+	relayed, err := p.loadRelayedTransactions()
+	if err != nil || len(relayed) == 0 {
+		return err
+	}
+
+	waiting := []relayedTransaction{}
+	var sendErr error
+	for _, relayedTx := range relayed {
+
+		// Drop the transactions that were included
+		if _, err := p.TransactionReceipt(ctx, relayedTx.Hash); err == nil {
+			continue
+		}
+		txBytes, err := hex.DecodeString(relayedTx.Tx)
+		if err != nil {
+			p.logger.Printlnf("WARNING: Could not decode privately relayed transaction %s: %s", relayedTx.Hash.Hex(), err.Error())
+			continue
+		}
+		tx := new(types.Transaction)
+		if err := tx.UnmarshalBinary(txBytes); err != nil {
+			p.logger.Printlnf("WARNING: Could not deserialize privately relayed transaction %s: %s", relayedTx.Hash.Hex(), err.Error())
+			continue
+		}
+
+		// Drop the transactions that were replaced by another one with the same nonce
+		from, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+		if err != nil {
+			p.logger.Printlnf("WARNING: Could not get the sender of privately relayed transaction %s: %s", relayedTx.Hash.Hex(), err.Error())
+			continue
+		}
+		nonce, err := p.NonceAt(ctx, from, nil)
+		if err != nil {
+			waiting = append(waiting, relayedTx)
+			sendErr = err
+			continue
+		}
+		if nonce > tx.Nonce() {
+			continue
+		}
+
+		// Send the rest to the public mempool once they've timed out
+		if p.clock.Since(relayedTx.Submitted) < p.relayTimeout {
+			waiting = append(waiting, relayedTx)
+			continue
+		}
+		p.logger.Printlnf("Privately relayed transaction %s was not included within %s, sending it to the public mempool...", relayedTx.Hash.Hex(), p.relayTimeout)
+		if _, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+			return nil, client.SendTransaction(ctx, tx)
+		}); err != nil {
+			waiting = append(waiting, relayedTx)
+			sendErr = fmt.Errorf("Could not send privately relayed transaction %s to the public mempool: %w", relayedTx.Hash.Hex(), err)
+		}
+
+	}
+
+	if err := p.saveRelayedTransactions(waiting); err != nil {
+		return err
+	}
+	return sendErr
+
+}
+
+// Load the transactions that were sent to the private relay
+func (p *ExecutionClientManager) loadRelayedTransactions() ([]relayedTransaction, error) {
+	bytes, err := ioutil.ReadFile(p.relayQueuePath)
+	if os.IsNotExist(err) {
+		return []relayedTransaction{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Could not read the private relay file: %w", err)
+	}
+	var relayed []relayedTransaction
+	if err := json.Unmarshal(bytes, &relayed); err != nil {
+		return nil, fmt.Errorf("Could not decode the private relay file: %w", err)
+	}
+	return relayed, nil
+}
+
+// Save the transactions that were sent to the private relay
+func (p *ExecutionClientManager) saveRelayedTransactions(relayed []relayedTransaction) error {
+	bytes, err := json.Marshal(relayed)
+	if err != nil {
+		return fmt.Errorf("Could not encode the private relay file: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(p.relayQueuePath), 0700); err != nil {
+		return fmt.Errorf("Could not create the private relay folder: %w", err)
+	}
+	tempPath := p.relayQueuePath + ".tmp"
+	if err := ioutil.WriteFile(tempPath, bytes, 0600); err != nil {
+		return fmt.Errorf("Could not write the private relay file: %w", err)
+	}
+	if err := os.Rename(tempPath, p.relayQueuePath); err != nil {
+		return fmt.Errorf("Could not replace the private relay file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package ratehistory
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// The rETH exchange rate and RPL price samples the node daemon has recorded, oldest first.
+// The node daemon adds to it and the API routes read it, so it's replaced in one step whenever it's saved.
+type History struct {
+	path string
+	lock sync.Mutex
+}
+
+// Create a new rate history that's stored in the provided file
+func NewHistory(path string) *History {
+	return &History{
+		path: path,
+	}
+}
+
+// Get the samples recorded since the provided time, oldest first
+func (h *History) List(since time.Time) ([]api.RateSample, error) {
+
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	samples, err := h.load()
+	if err != nil {
+		return nil, err
+	}
+	recent := []api.RateSample{}
+	for _, sample := range samples {
+		if !sample.Time.Before(since) {
+			recent = append(recent, sample)
+		}
+	}
+	return recent, nil
+
+}
+
+// Get the latest sample, or nil if none have been recorded
+func (h *History) GetLatest() (*api.RateSample, error) {
+
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	samples, err := h.load()
+	if err != nil {
+		return nil, err
+	}
+	if len(samples) == 0 {
+		return nil, nil
+	}
+	return &samples[len(samples)-1], nil
+
+}
+
+// Record a sample, dropping the ones that are older than the retention period
+func (h *History) Add(sample api.RateSample, retention time.Duration) error {
+
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	samples, err := h.load()
+	if err != nil {
+		return err
+	}
+	cutoff := sample.Time.Add(-retention)
+	kept := []api.RateSample{}
+	for _, existing := range samples {
+		if existing.Time.After(cutoff) && existing.Time.Before(sample.Time) {
+			kept = append(kept, existing)
+		}
+	}
+	return h.save(append(kept, sample))
+
+}
+
+// Load the history
+func (h *History) load() ([]api.RateSample, error) {
+	bytes, err := ioutil.ReadFile(h.path)
+	if os.IsNotExist(err) {
+		return []api.RateSample{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Could not read the rate history file: %w", err)
+	}
+	samples := []api.RateSample{}
+	if err := json.Unmarshal(bytes, &samples); err != nil {
+		return nil, fmt.Errorf("Could not decode the rate history file: %w", err)
+	}
+	return samples, nil
+}
+
+// Save the history
+func (h *History) save(samples []api.RateSample) error {
+	bytes, err := json.Marshal(samples)
+	if err != nil {
+		return fmt.Errorf("Could not encode the rate history file: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(h.path), 0755); err != nil {
+		return fmt.Errorf("Could not create the rate history folder: %w", err)
+	}
+	tempPath := h.path + ".tmp"
+	if err := ioutil.WriteFile(tempPath, bytes, 0644); err != nil {
+		return fmt.Errorf("Could not write the rate history file: %w", err)
+	}
+	if err := os.Rename(tempPath, h.path); err != nil {
+		return fmt.Errorf("Could not replace the rate history file: %w", err)
+	}
+	return nil
+}
@@ -43,6 +43,9 @@ func RequireNodePassword(c *cli.Context) error {
 }
 
 func RequireNodeWallet(c *cli.Context) error {
+	if _, observing, err := getObservedNodeAddress(c); err != nil || observing {
+		return err
+	}
 	if err := RequireNodePassword(c); err != nil {
 		return err
 	}
@@ -378,13 +381,13 @@ func checkExecutionClientStatus(ecMgr *ExecutionClientManager) (bool, rocketpool
 	// Is the primary working and syncing? If so, wait for it
 	if mgrStatus.PrimaryEcStatus.IsWorking && mgrStatus.PrimaryEcStatus.Error == "" {
 		log.Printf("Fallback execution client is not configured or unavailable, waiting for primary execution client to finish syncing (%.2f%%)\n", mgrStatus.PrimaryEcStatus.SyncProgress*100)
-		return false, ecMgr.primaryEc, nil
+		return false, ecMgr.primary.getMainClient(), nil
 	}
 
 	// Is the fallback working and syncing? If so, wait for it
 	if mgrStatus.FallbackEnabled && mgrStatus.FallbackEcStatus.IsWorking && mgrStatus.FallbackEcStatus.Error == "" {
 		log.Printf("Primary execution client is unavailable (%s), waiting for the fallback execution client to finish syncing (%.2f%%)\n", mgrStatus.PrimaryEcStatus.Error, mgrStatus.FallbackEcStatus.SyncProgress*100)
-		return false, ecMgr.fallbackEc, nil
+		return false, ecMgr.fallback.getMainClient(), nil
 	}
 
 	// If neither client is working, report the errors
@@ -407,6 +410,11 @@ func waitEthClientSynced(c *cli.Context, verbose bool, timeout int64) (bool, err
 	if err != nil {
 		return false, err
 	}
+	cfg, err := GetConfig(c)
+	if err != nil {
+		return false, err
+	}
+	pollInterval := cfg.ScaleEcPollingInterval(ethClientSyncPollInterval)
 
 	synced, clientToCheck, err := checkExecutionClientStatus(ecMgr)
 	if err != nil {
@@ -473,7 +481,7 @@ func waitEthClientSynced(c *cli.Context, verbose bool, timeout int64) (bool, err
 		}
 
 		// Pause before next poll
-		time.Sleep(ethClientSyncPollInterval)
+		time.Sleep(pollInterval)
 
 	}
 
@@ -0,0 +1,173 @@
+package rewards
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
+
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+)
+
+// The attestation record of the smoothing pool's minipools during an interval
+type attestationPerformance struct {
+	Minipools              map[common.Address]*minipoolPerformance `json:"minipools"`
+	TotalScore             *big.Int                                `json:"totalScore"`
+	SuccessfulAttestations uint64                                  `json:"successfulAttestations"`
+}
+
+// The attestation record of a single minipool during an interval
+type minipoolPerformance struct {
+	GoodAttestations   uint64   `json:"goodAttestations"`
+	MissedAttestations uint64   `json:"missedAttestations"`
+	Score              *big.Int `json:"score"`
+}
+
+// An attestation duty of a minipool that hasn't been seen in a block yet
+type attestationDuty struct {
+	minipool generatorMinipoolInfo
+}
+
+// The slot and committee of an attestation
+type attestationKey struct {
+	slot      uint64
+	committee uint64
+}
+
+// Get the attestation record of the minipools of the nodes in the smoothing pool, from the interval's first slot up to its consensus snapshot slot.
+// A minipool only has a duty while its node is in the pool, and an attestation only counts if it's included in a block within an epoch of its slot.
+func getAttestationPerformance(bc beacon.Client, event RewardsEvent, startSlot uint64, endSlot uint64, nodes []generatorNodeInfo) (*attestationPerformance, error) {
+
+	performance := &attestationPerformance{
+		Minipools:  map[common.Address]*minipoolPerformance{},
+		TotalScore: big.NewInt(0),
+	}
+	eth2Config, err := bc.GetEth2Config()
+	if err != nil {
+		return nil, err
+	}
+	if eth2Config.SlotsPerEpoch == 0 {
+		return nil, fmt.Errorf("The Beacon node reported 0 slots per epoch")
+	}
+	secondsPerSlot := eth2Config.SecondsPerEpoch / eth2Config.SlotsPerEpoch
+	genesisTime := time.Unix(int64(eth2Config.GenesisTime), 0)
+	getSlotTime := func(slot uint64) time.Time {
+		return genesisTime.Add(time.Duration(slot*secondsPerSlot) * time.Second)
+	}
+
+	// The first interval starts at its start time instead of after a previous snapshot
+	if startSlot == 0 && event.IntervalStartTime.After(genesisTime) {
+		startSlot = uint64(event.IntervalStartTime.Sub(genesisTime).Seconds()) / secondsPerSlot
+	}
+
+	// Get the validator of each minipool
+	pubkeys := []rptypes.ValidatorPubkey{}
+	for _, nodeInfo := range nodes {
+		for _, mp := range nodeInfo.Minipools {
+			pubkeys = append(pubkeys, mp.Pubkey)
+		}
+	}
+	if len(pubkeys) == 0 {
+		return performance, nil
+	}
+	statuses, err := bc.GetValidatorStatuses(pubkeys, &beacon.ValidatorStatusOptions{Epoch: endSlot / eth2Config.SlotsPerEpoch})
+	if err != nil {
+		return nil, fmt.Errorf("Could not get the smoothing pool's validators: %w", err)
+	}
+	type validatorInfo struct {
+		minipool generatorMinipoolInfo
+		optIn    time.Time
+		optOut   time.Time
+	}
+	validators := map[uint64]validatorInfo{}
+	for _, nodeInfo := range nodes {
+		optIn, optOut, eligible := nodeInfo.getSmoothingPoolWindow(event)
+		if !eligible {
+			continue
+		}
+		for _, mp := range nodeInfo.Minipools {
+			status, exists := statuses[mp.Pubkey]
+			if !exists || !status.Exists {
+				continue
+			}
+			validators[status.Index] = validatorInfo{
+				minipool: mp,
+				optIn:    optIn,
+				optOut:   optOut,
+			}
+		}
+	}
+
+	// Record the duties of the minipools from the committees of each epoch
+	duties := map[attestationKey]map[int]attestationDuty{}
+	for epoch := startSlot / eth2Config.SlotsPerEpoch; epoch <= endSlot/eth2Config.SlotsPerEpoch; epoch++ {
+		committees, err := bc.GetCommittees(fmt.Sprint(epoch*eth2Config.SlotsPerEpoch), epoch)
+		if err != nil {
+			return nil, err
+		}
+		for _, committee := range committees {
+			if committee.Slot < startSlot || committee.Slot > endSlot {
+				continue
+			}
+			slotTime := getSlotTime(committee.Slot)
+			for position, index := range committee.Validators {
+				validator, exists := validators[index]
+				if !exists || slotTime.Before(validator.optIn) || slotTime.After(validator.optOut) {
+					continue
+				}
+				key := attestationKey{slot: committee.Slot, committee: committee.Index}
+				if duties[key] == nil {
+					duties[key] = map[int]attestationDuty{}
+				}
+				duties[key][position] = attestationDuty{minipool: validator.minipool}
+				getMinipoolPerformance(performance, validator.minipool.Address).MissedAttestations++
+			}
+		}
+	}
+
+	// Credit the duties that were attested to in time
+	for slot := startSlot; slot <= endSlot+eth2Config.SlotsPerEpoch; slot++ {
+		attestations, exists, err := bc.GetBeaconBlockAttestations(fmt.Sprint(slot))
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			continue
+		}
+		for _, attestation := range attestations {
+			if attestation.Slot > slot || slot-attestation.Slot > eth2Config.SlotsPerEpoch {
+				continue
+			}
+			key := attestationKey{slot: attestation.Slot, committee: attestation.CommitteeIndex}
+			for position, duty := range duties[key] {
+				if !attestation.IncludesPosition(position) {
+					continue
+				}
+				delete(duties[key], position)
+				mpPerformance := getMinipoolPerformance(performance, duty.minipool.Address)
+				mpPerformance.MissedAttestations--
+				mpPerformance.GoodAttestations++
+				score := getAttestationScore(duty.minipool)
+				mpPerformance.Score.Add(mpPerformance.Score, score)
+				performance.TotalScore.Add(performance.TotalScore, score)
+				performance.SuccessfulAttestations++
+			}
+		}
+	}
+	return performance, nil
+
+}
+
+// Get the attestation record of a minipool, creating it if it doesn't exist yet
+func getMinipoolPerformance(performance *attestationPerformance, address common.Address) *minipoolPerformance {
+	mpPerformance, exists := performance.Minipools[address]
+	if !exists {
+		mpPerformance = &minipoolPerformance{
+			Score: big.NewInt(0),
+		}
+		performance.Minipools[address] = mpPerformance
+	}
+	return mpPerformance
+}
@@ -0,0 +1,213 @@
+package rewards
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+
+	"github.com/rocket-pool/smartnode/shared/services/cache"
+	"github.com/rocket-pool/smartnode/shared/utils/eventlogs"
+)
+
+// The reward network of the rewards that are claimed from the Mainnet distributor; the others are claimed on layer 2 networks
+const MainnetRewardNetwork uint64 = 0
+
+// Claims in the most recent blocks are scanned for again next time, in case they're reorged out
+const claimScanConfirmations uint64 = 64
+
+// The rewards a node claimed from an interval
+type ClaimedRewards struct {
+	Index       uint64   `json:"index"`
+	Rpl         *big.Int `json:"rpl"`
+	Eth         *big.Int `json:"eth"`
+	BlockNumber uint64   `json:"blockNumber"`
+}
+
+// The claims of a node that have been scanned for, up to a block
+type cachedClaims struct {
+	ScannedBlock uint64           `json:"scannedBlock"`
+	Claims       []ClaimedRewards `json:"claims"`
+}
+
+// Check if a node has claimed its rewards for an interval
+func IsClaimed(rp *rocketpool.RocketPool, index uint64, nodeAddress common.Address, opts *bind.CallOpts) (bool, error) {
+	distributor, err := rp.GetContract("rocketMerkleDistributorMainnet")
+	if err != nil {
+		return false, err
+	}
+	isClaimed := new(bool)
+	if err := distributor.Call(opts, isClaimed, "isClaimed", new(big.Int).SetUint64(index), nodeAddress); err != nil {
+		return false, fmt.Errorf("Could not get the claim status of interval %d for node %s: %w", index, nodeAddress.Hex(), err)
+	}
+	return *isClaimed, nil
+}
+
+// Get the rewards a node has claimed from each interval, from the distributor's RewardsClaimed events.
+// If a cache is provided, only the blocks since the last scan are scanned for new claims.
+func GetClaimedRewards(rp *rocketpool.RocketPool, nodeAddress common.Address, scanner *eventlogs.Scanner, dataCache *cache.Cache) (map[uint64]ClaimedRewards, error) {
+
+	// Get the claims that have already been scanned for
+	key := fmt.Sprintf("%s-%s", rp.RocketStorageContract.Address.Hex(), nodeAddress.Hex())
+	var cached cachedClaims
+	var fromBlock *big.Int
+	if dataCache.Get(cache.Bucket_RewardsClaims, key, &cached) {
+		fromBlock = new(big.Int).SetUint64(cached.ScannedBlock + 1)
+	}
+	claims := map[uint64]ClaimedRewards{}
+	for _, claim := range cached.Claims {
+		claims[claim.Index] = claim
+	}
+
+	distributor, err := rp.GetContract("rocketMerkleDistributorMainnet")
+	if err != nil {
+		return nil, err
+	}
+	claimedEvent, exists := distributor.ABI.Events["RewardsClaimed"]
+	if !exists {
+		return nil, fmt.Errorf("The distributor contract doesn't have the RewardsClaimed event; has the Redstone upgrade been deployed yet?")
+	}
+	latestBlock, err := rp.Client.BlockNumber(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("Could not get the latest block number: %w", err)
+	}
+
+	// Get the new claim events, including the ones from older versions of the contract
+	logs, err := scanner.FilterContractLogs(rp, "rocketMerkleDistributorMainnet", eth.FilterQuery{
+		Topics:    [][]common.Hash{{claimedEvent.ID}, {nodeAddress.Hash()}},
+		FromBlock: fromBlock,
+		ToBlock:   new(big.Int).SetUint64(latestBlock),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Could not get the rewards claims of node %s: %w", nodeAddress.Hex(), err)
+	}
+	for _, log := range logs {
+		values, err := claimedEvent.Inputs.NonIndexed().Unpack(log.Data)
+		if err != nil {
+			return nil, fmt.Errorf("Could not decode the rewards claim in transaction %s: %w", log.TxHash.Hex(), err)
+		}
+		if len(values) != 3 {
+			return nil, fmt.Errorf("The rewards claim in transaction %s has %d values but 3 were expected", log.TxHash.Hex(), len(values))
+		}
+		indices, okIndices := values[0].([]*big.Int)
+		amountsRpl, okRpl := values[1].([]*big.Int)
+		amountsEth, okEth := values[2].([]*big.Int)
+		if !okIndices || !okRpl || !okEth || len(amountsRpl) != len(indices) || len(amountsEth) != len(indices) {
+			return nil, fmt.Errorf("The rewards claim in transaction %s is invalid", log.TxHash.Hex())
+		}
+		for i, index := range indices {
+			claims[index.Uint64()] = ClaimedRewards{
+				Index:       index.Uint64(),
+				Rpl:         amountsRpl[i],
+				Eth:         amountsEth[i],
+				BlockNumber: log.BlockNumber,
+			}
+		}
+	}
+
+	// Cache the claims up to the confirmed blocks; the claims can still be used if this fails
+	scannedBlock := cached.ScannedBlock
+	if latestBlock > claimScanConfirmations && latestBlock-claimScanConfirmations > scannedBlock {
+		scannedBlock = latestBlock - claimScanConfirmations
+	}
+	confirmed := cachedClaims{
+		ScannedBlock: scannedBlock,
+		Claims:       []ClaimedRewards{},
+	}
+	for _, claim := range claims {
+		if claim.BlockNumber <= scannedBlock {
+			confirmed.Claims = append(confirmed.Claims, claim)
+		}
+	}
+	sort.Slice(confirmed.Claims, func(i, j int) bool { return confirmed.Claims[i].Index < confirmed.Claims[j].Index })
+	_ = dataCache.Set(cache.Bucket_RewardsClaims, key, confirmed)
+	return claims, nil
+
+}
+
+// Get the index of the current rewards interval, which is the number of intervals that have finished
+func GetRewardIndex(rp *rocketpool.RocketPool, opts *bind.CallOpts) (uint64, error) {
+	rocketRewardsPool, err := rp.GetContract("rocketRewardsPool")
+	if err != nil {
+		return 0, err
+	}
+	index := new(*big.Int)
+	if err := rocketRewardsPool.Call(opts, index, "getRewardIndex"); err != nil {
+		return 0, fmt.Errorf("Could not get the current rewards interval: %w", err)
+	}
+	return (*index).Uint64(), nil
+}
+
+// The rewards a node is claiming from a set of intervals, with the Merkle proofs for each
+type ClaimParams struct {
+	Indices     []*big.Int
+	AmountsRpl  []*big.Int
+	AmountsEth  []*big.Int
+	MerkleProof [][]common.Hash
+}
+
+// Get the parameters for claiming a node's rewards from the provided tree files
+func GetClaimParams(nodeAddress common.Address, files []*RewardsFile) (ClaimParams, error) {
+	params := ClaimParams{}
+	for _, file := range files {
+		nodeRewards, exists := file.NodeRewards[nodeAddress]
+		if !exists {
+			return ClaimParams{}, fmt.Errorf("node %s doesn't have any rewards in interval %d", nodeAddress.Hex(), file.Index)
+		}
+		rpl := big.NewInt(0)
+		eth := big.NewInt(0)
+		if nodeRewards.CollateralRpl != nil {
+			rpl.Add(rpl, &nodeRewards.CollateralRpl.Int)
+		}
+		if nodeRewards.OracleDaoRpl != nil {
+			rpl.Add(rpl, &nodeRewards.OracleDaoRpl.Int)
+		}
+		if nodeRewards.SmoothingPoolEth != nil {
+			eth.Set(&nodeRewards.SmoothingPoolEth.Int)
+		}
+		proof := make([]common.Hash, len(nodeRewards.MerkleProof))
+		for i, sibling := range nodeRewards.MerkleProof {
+			proof[i] = common.HexToHash(sibling)
+		}
+		params.Indices = append(params.Indices, new(big.Int).SetUint64(file.Index))
+		params.AmountsRpl = append(params.AmountsRpl, rpl)
+		params.AmountsEth = append(params.AmountsEth, eth)
+		params.MerkleProof = append(params.MerkleProof, proof)
+	}
+	return params, nil
+}
+
+// Estimate the gas of claiming rewards, restaking stakeAmount of the claimed RPL
+func EstimateClaimAndStakeGas(rp *rocketpool.RocketPool, nodeAddress common.Address, params ClaimParams, stakeAmount *big.Int, opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+	distributor, err := rp.GetContract("rocketMerkleDistributorMainnet")
+	if err != nil {
+		return rocketpool.GasInfo{}, err
+	}
+	if stakeAmount == nil || stakeAmount.Sign() == 0 {
+		return distributor.GetTransactionGasInfo(opts, "claim", nodeAddress, params.Indices, params.AmountsRpl, params.AmountsEth, params.MerkleProof)
+	}
+	return distributor.GetTransactionGasInfo(opts, "claimAndStake", nodeAddress, params.Indices, params.AmountsRpl, params.AmountsEth, params.MerkleProof, stakeAmount)
+}
+
+// Claim rewards, restaking stakeAmount of the claimed RPL
+func ClaimAndStake(rp *rocketpool.RocketPool, nodeAddress common.Address, params ClaimParams, stakeAmount *big.Int, opts *bind.TransactOpts) (common.Hash, error) {
+	distributor, err := rp.GetContract("rocketMerkleDistributorMainnet")
+	if err != nil {
+		return common.Hash{}, err
+	}
+	var hash common.Hash
+	if stakeAmount == nil || stakeAmount.Sign() == 0 {
+		hash, err = distributor.Transact(opts, "claim", nodeAddress, params.Indices, params.AmountsRpl, params.AmountsEth, params.MerkleProof)
+	} else {
+		hash, err = distributor.Transact(opts, "claimAndStake", nodeAddress, params.Indices, params.AmountsRpl, params.AmountsEth, params.MerkleProof, stakeAmount)
+	}
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("Could not claim rewards: %w", err)
+	}
+	return hash, nil
+}
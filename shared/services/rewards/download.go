@@ -0,0 +1,160 @@
+package rewards
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+)
+
+// Settings
+const (
+	githubMirrorUrl string = "https://github.com/rocket-pool/rewards-trees/raw/main/%s/%s"
+
+	// The most a rewards tree file download is allowed to be, to protect against misbehaving sources
+	maxRewardsFileSize int64 = 1 << 30
+)
+
+var rewardsFileDownloadTimeout, _ = time.ParseDuration("2m")
+
+// A place a rewards tree file can be downloaded from
+type RewardsFileSource struct {
+	Name       string
+	Url        string
+	Compressed bool
+}
+
+// Get the sources to download the rewards tree file for an interval from, in the order they should be tried:
+// the configured IPFS gateways, Rocket Pool's GitHub mirror, and then the custom mirror URL if there is one
+func GetRewardsFileSources(cfg *config.RocketPoolConfig, event RewardsEvent) []RewardsFileSource {
+
+	network := string(cfg.Smartnode.GetNetwork())
+	filename := getRewardsFileName(network, event.Index)
+	sources := []RewardsFileSource{}
+
+	// IPFS gateways serve the compressed file from the directory the Oracle DAO pinned
+	cid := event.Submission.MerkleTreeCID
+	if cid != "" {
+		for _, gateway := range cfg.Smartnode.GetIpfsGateways() {
+			sources = append(sources, RewardsFileSource{
+				Name:       gateway,
+				Url:        fmt.Sprintf("%s/ipfs/%s/%s", gateway, cid, filename+compressedExtension),
+				Compressed: true,
+			})
+		}
+	}
+
+	// The GitHub mirror serves the uncompressed file
+	sources = append(sources, RewardsFileSource{
+		Name: "GitHub mirror",
+		Url:  fmt.Sprintf(githubMirrorUrl, network, filename+uncompressedExtension),
+	})
+
+	// The custom mirror serves whichever format its URL names
+	if mirrorUrl := cfg.Smartnode.GetRewardsTreeMirrorUrl(); mirrorUrl != "" {
+		url := strings.NewReplacer(
+			"{network}", network,
+			"{index}", strconv.FormatUint(event.Index, 10),
+			"{filename}", filename+compressedExtension,
+		).Replace(mirrorUrl)
+		sources = append(sources, RewardsFileSource{
+			Name:       "custom mirror",
+			Url:        url,
+			Compressed: strings.HasSuffix(url, compressedExtension),
+		})
+	}
+	return sources
+
+}
+
+// Download the rewards tree file for an interval, trying each source in order until one provides a file that matches the canonical Merkle root.
+// Returns the file and the source it came from.
+func DownloadRewardsFile(cfg *config.RocketPoolConfig, event RewardsEvent) (*RewardsFile, RewardsFileSource, error) {
+
+	network := string(cfg.Smartnode.GetNetwork())
+	errs := []string{}
+	for _, source := range GetRewardsFileSources(cfg, event) {
+		file, err := downloadRewardsFileFrom(source)
+		if err == nil {
+			err = VerifyRewardsFile(file, network, event)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %s", source.Name, err.Error()))
+			continue
+		}
+		return file, source, nil
+	}
+	return nil, RewardsFileSource{}, fmt.Errorf("Could not download the rewards tree file for interval %d from any source:\n\t%s", event.Index, strings.Join(errs, "\n\t"))
+
+}
+
+// Verify that a rewards tree file is the canonical one for an interval by rebuilding its Merkle tree and comparing the root with the one the Oracle DAO submitted,
+// and that the proofs in it are the ones the tree produces
+func VerifyRewardsFile(file *RewardsFile, network string, event RewardsEvent) error {
+
+	if file.Index != event.Index {
+		return fmt.Errorf("the file is for interval %d instead of %d", file.Index, event.Index)
+	}
+	if file.Network != network {
+		return fmt.Errorf("the file is for network %s instead of %s", file.Network, network)
+	}
+
+	// Rebuild the tree from the node rewards
+	tree, leaves := newRewardsTree(file.NodeRewards)
+	root := tree.root()
+	canonicalRoot := common.Hash(event.Submission.MerkleRoot)
+	if root != canonicalRoot {
+		return fmt.Errorf("the file's Merkle root is %s but the canonical root is %s", root.Hex(), canonicalRoot.Hex())
+	}
+	if file.MerkleRoot != "" && !strings.EqualFold(file.MerkleRoot, canonicalRoot.Hex()) {
+		return fmt.Errorf("the file claims a Merkle root of %s but its rewards produce %s", file.MerkleRoot, canonicalRoot.Hex())
+	}
+
+	// Claims are made with the proofs in the file, so they have to match the tree too
+	for address, leaf := range leaves {
+		proof := tree.proof(leaf)
+		fileProof := file.NodeRewards[address].MerkleProof
+		if len(fileProof) != len(proof) {
+			return fmt.Errorf("the file's Merkle proof for node %s has %d hashes instead of %d", address.Hex(), len(fileProof), len(proof))
+		}
+		for i, sibling := range proof {
+			if !strings.EqualFold(fileProof[i], sibling.Hex()) {
+				return fmt.Errorf("the file's Merkle proof for node %s doesn't match its rewards", address.Hex())
+			}
+		}
+	}
+	return nil
+
+}
+
+// Download a rewards tree file from a source
+func downloadRewardsFileFrom(source RewardsFileSource) (*RewardsFile, error) {
+
+	client := http.Client{
+		Timeout: rewardsFileDownloadTimeout,
+	}
+	response, err := client.Get(source.Url)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s returned status %s", source.Url, response.Status)
+	}
+	body, err := ioutil.ReadAll(io.LimitReader(response.Body, maxRewardsFileSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s: %w", source.Url, err)
+	}
+	if int64(len(body)) > maxRewardsFileSize {
+		return nil, fmt.Errorf("%s is larger than the %d byte limit", source.Url, maxRewardsFileSize)
+	}
+	return decodeRewardsFile(body, source.Compressed)
+
+}
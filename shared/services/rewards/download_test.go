@@ -0,0 +1,123 @@
+package rewards
+
+import (
+	"math/big"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// The Merkle root of the test rewards file, computed separately from this package's tree code
+const testRewardsFileRoot string = "0x549c5b54ff2761e44dc420042dd5eba39d2807608ad1369fb44dbecbb381abc8"
+
+// Load a fresh copy of the test rewards file and the snapshot event it belongs to
+func loadTestRewardsFile(t *testing.T) (*RewardsFile, RewardsEvent) {
+	t.Helper()
+	file, err := ReadRewardsFile(filepath.Join("testdata", "rp-rewards-prater-3.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	event := RewardsEvent{Index: 3}
+	event.Submission.MerkleRoot = common.HexToHash(testRewardsFileRoot)
+	return file, event
+}
+
+func TestVerifyRewardsFile(t *testing.T) {
+	file, event := loadTestRewardsFile(t)
+	if err := VerifyRewardsFile(file, "prater", event); err != nil {
+		t.Fatal(err)
+	}
+
+	// Every node with rewards has a proof that leads to the canonical root
+	tree, leaves := newRewardsTree(file.NodeRewards)
+	if tree.root().Hex() != testRewardsFileRoot {
+		t.Fatalf("expected the rebuilt root to be %s, got %s", testRewardsFileRoot, tree.root().Hex())
+	}
+	if len(leaves) != 5 {
+		t.Fatalf("expected 5 nodes with rewards, got %d", len(leaves))
+	}
+	for address, leaf := range leaves {
+		proof := []common.Hash{}
+		for _, sibling := range file.NodeRewards[address].MerkleProof {
+			proof = append(proof, common.HexToHash(sibling))
+		}
+		if root := getProofRoot(leaf, proof); root.Hex() != testRewardsFileRoot {
+			t.Errorf("the file's proof for %s leads to %s instead of the root", address.Hex(), root.Hex())
+		}
+	}
+
+	// A round trip through the compressed format keeps it verifiable
+	folder := t.TempDir()
+	if err := SaveRewardsFile(folder, file); err != nil {
+		t.Fatal(err)
+	}
+	saved, err := LoadRewardsFile(folder, "prater", 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := VerifyRewardsFile(saved, "prater", event); err != nil {
+		t.Fatalf("expected the saved file to verify: %s", err.Error())
+	}
+}
+
+func TestVerifyRewardsFileRejectsMismatches(t *testing.T) {
+	node := common.HexToAddress("0x0a1b2c3d4e5f60718293a4b5c6d7e8f901a2b3c4")
+	tests := map[string]struct {
+		modify   func(file *RewardsFile, event *RewardsEvent) string
+		expected string
+	}{
+		"wrong interval": {
+			modify:   func(file *RewardsFile, event *RewardsEvent) string { event.Index = 4; return "prater" },
+			expected: "interval 3 instead of 4",
+		},
+		"wrong network": {
+			modify:   func(file *RewardsFile, event *RewardsEvent) string { return "mainnet" },
+			expected: "network prater instead of mainnet",
+		},
+		"changed rewards": {
+			modify: func(file *RewardsFile, event *RewardsEvent) string {
+				file.NodeRewards[node].CollateralRpl.Add(&file.NodeRewards[node].CollateralRpl.Int, big.NewInt(1))
+				return "prater"
+			},
+			expected: "but the canonical root is",
+		},
+		"changed reward network": {
+			modify: func(file *RewardsFile, event *RewardsEvent) string {
+				file.NodeRewards[node].RewardNetwork = 1
+				return "prater"
+			},
+			expected: "but the canonical root is",
+		},
+		"wrong claimed root": {
+			modify: func(file *RewardsFile, event *RewardsEvent) string {
+				file.MerkleRoot = common.Hash{1}.Hex()
+				return "prater"
+			},
+			expected: "claims a Merkle root",
+		},
+		"wrong proof": {
+			modify: func(file *RewardsFile, event *RewardsEvent) string {
+				file.NodeRewards[node].MerkleProof[1] = common.Hash{1}.Hex()
+				return "prater"
+			},
+			expected: "proof for node",
+		},
+		"missing proof": {
+			modify: func(file *RewardsFile, event *RewardsEvent) string {
+				file.NodeRewards[node].MerkleProof = nil
+				return "prater"
+			},
+			expected: "has 0 hashes instead of 3",
+		},
+	}
+	for name, test := range tests {
+		file, event := loadTestRewardsFile(t)
+		network := test.modify(file, &event)
+		err := VerifyRewardsFile(file, network, event)
+		if err == nil || !strings.Contains(err.Error(), test.expected) {
+			t.Errorf("%s: expected an error containing %q, got %v", name, test.expected, err)
+		}
+	}
+}
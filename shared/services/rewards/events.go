@@ -0,0 +1,112 @@
+package rewards
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+
+	"github.com/rocket-pool/smartnode/shared/services/cache"
+	"github.com/rocket-pool/smartnode/shared/utils/eventlogs"
+)
+
+// The rewards submission that the Oracle DAO reached consensus on for an interval
+type RewardSubmission struct {
+	RewardIndex     *big.Int
+	ExecutionBlock  *big.Int
+	ConsensusBlock  *big.Int
+	MerkleRoot      [32]byte
+	MerkleTreeCID   string
+	IntervalsPassed *big.Int
+	TreasuryRPL     *big.Int
+	TrustedNodeRPL  []*big.Int
+	NodeRPL         []*big.Int
+	NodeETH         []*big.Int
+	UserETH         *big.Int
+}
+
+// The event emitted when the rewards for an interval were snapshotted
+type RewardsEvent struct {
+	Index             uint64
+	Submission        RewardSubmission
+	IntervalStartTime time.Time
+	IntervalEndTime   time.Time
+	SubmissionTime    time.Time
+}
+
+// Get the snapshot event for a rewards interval, which holds its canonical Merkle root and the amounts it distributed.
+// Snapshot events can't change, so they're only scanned for once if a cache is provided; they're cached by network and rewards pool
+// address so the events of different networks and deployments don't collide.
+func GetRewardsEvent(rp *rocketpool.RocketPool, network string, index uint64, scanner *eventlogs.Scanner, dataCache *cache.Cache) (RewardsEvent, error) {
+
+	rocketRewardsPool, err := rp.GetContract("rocketRewardsPool")
+	if err != nil {
+		return RewardsEvent{}, err
+	}
+	key := fmt.Sprintf("%s-%s-%d", network, rocketRewardsPool.Address.Hex(), index)
+	var cached RewardsEvent
+	if dataCache.Get(cache.Bucket_RewardsEvents, key, &cached) {
+		return cached, nil
+	}
+	snapshotEvent, exists := rocketRewardsPool.ABI.Events["RewardSnapshot"]
+	if !exists {
+		return RewardsEvent{}, fmt.Errorf("The rewards pool contract doesn't have the RewardSnapshot event; has the Redstone upgrade been deployed yet?")
+	}
+
+	// Get the event logs, including the ones from older versions of the contract
+	indexHash := common.BigToHash(new(big.Int).SetUint64(index))
+	logs, err := scanner.FilterContractLogs(rp, "rocketRewardsPool", eth.FilterQuery{
+		Topics: [][]common.Hash{{snapshotEvent.ID}, {indexHash}},
+	})
+	if err != nil {
+		return RewardsEvent{}, fmt.Errorf("Could not get the rewards snapshot event for interval %d: %w", index, err)
+	}
+	if len(logs) == 0 {
+		return RewardsEvent{}, fmt.Errorf("Interval %d hasn't been snapshotted yet", index)
+	}
+
+	// Decode it
+	values, err := snapshotEvent.Inputs.Unpack(logs[0].Data)
+	if err != nil {
+		return RewardsEvent{}, fmt.Errorf("Could not decode the rewards snapshot event for interval %d: %w", index, err)
+	}
+	if len(values) != 4 {
+		return RewardsEvent{}, fmt.Errorf("The rewards snapshot event for interval %d has %d values but 4 were expected", index, len(values))
+	}
+	submission, err := convertRewardSubmission(values[0])
+	if err != nil {
+		return RewardsEvent{}, fmt.Errorf("Could not decode the rewards submission for interval %d: %w", index, err)
+	}
+	event := RewardsEvent{
+		Index:      index,
+		Submission: submission,
+	}
+	times := []*time.Time{&event.IntervalStartTime, &event.IntervalEndTime, &event.SubmissionTime}
+	for i, t := range times {
+		value, ok := values[i+1].(*big.Int)
+		if !ok {
+			return RewardsEvent{}, fmt.Errorf("The rewards snapshot event for interval %d has an invalid timestamp", index)
+		}
+		*t = time.Unix(value.Int64(), 0)
+	}
+
+	// Cache it; the event can still be used if this fails
+	_ = dataCache.Set(cache.Bucket_RewardsEvents, key, event)
+	return event, nil
+
+}
+
+// Convert the tuple decoded from a rewards snapshot event into a submission
+func convertRewardSubmission(value interface{}) (submission RewardSubmission, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+	converted := abi.ConvertType(value, new(RewardSubmission)).(*RewardSubmission)
+	return *converted, nil
+}
@@ -0,0 +1,159 @@
+package rewards
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// Settings
+const (
+	fileMode os.FileMode = 0644
+
+	// Rewards tree files are stored compressed; uncompressed files from older versions are still read
+	compressedExtension   string = ".json.zst"
+	uncompressedExtension string = ".json"
+)
+
+var rewardsFilePattern = regexp.MustCompile(`^rp-rewards-(.+)-(\d+)\.json(\.zst)?$`)
+
+// A rewards tree file in the rewards tree folder
+type StoredRewardsFile struct {
+	Index uint64
+	Path  string
+}
+
+// Get the path of the compressed rewards tree file for an interval
+func GetRewardsFilePath(folder string, network string, index uint64) string {
+	return filepath.Join(folder, getRewardsFileName(network, index)+compressedExtension)
+}
+
+// Save a rewards tree file for an interval, compressed with zstd.
+// Any uncompressed copy of the file is removed.
+func SaveRewardsFile(folder string, file *RewardsFile) error {
+
+	// Serialize it
+	fileBytes, err := json.Marshal(file)
+	if err != nil {
+		return fmt.Errorf("error serializing rewards tree file for interval %d: %w", file.Index, err)
+	}
+	encoder, err := zstd.NewWriter(nil)
+	if err != nil {
+		return fmt.Errorf("error creating zstd encoder: %w", err)
+	}
+	compressed := encoder.EncodeAll(fileBytes, nil)
+	encoder.Close()
+
+	// Write it
+	if err := os.MkdirAll(folder, 0755); err != nil {
+		return fmt.Errorf("error creating rewards tree folder: %w", err)
+	}
+	path := GetRewardsFilePath(folder, file.Network, file.Index)
+	if err := ioutil.WriteFile(path, compressed, fileMode); err != nil {
+		return fmt.Errorf("error writing rewards tree file %s: %w", path, err)
+	}
+	legacyPath := filepath.Join(folder, getRewardsFileName(file.Network, file.Index)+uncompressedExtension)
+	if err := os.Remove(legacyPath); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("error removing uncompressed rewards tree file %s: %w", legacyPath, err)
+	}
+	return nil
+
+}
+
+// Load the rewards tree file for an interval.
+// Compressed files are decompressed transparently; returns nil if the folder doesn't have a file for the interval.
+func LoadRewardsFile(folder string, network string, index uint64) (*RewardsFile, error) {
+	for _, extension := range []string{compressedExtension, uncompressedExtension} {
+		path := filepath.Join(folder, getRewardsFileName(network, index)+extension)
+		file, err := ReadRewardsFile(path)
+		if os.IsNotExist(err) {
+			continue
+		}
+		return file, err
+	}
+	return nil, nil
+}
+
+// Read a rewards tree file, decompressing it if it's compressed
+func ReadRewardsFile(path string) (*RewardsFile, error) {
+
+	// Read the file
+	fileBytes, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	file, err := decodeRewardsFile(fileBytes, filepath.Ext(path) == filepath.Ext(compressedExtension))
+	if err != nil {
+		return nil, fmt.Errorf("error reading rewards tree file %s: %w", path, err)
+	}
+	return file, nil
+
+}
+
+// Deserialize a rewards tree file, decompressing it first if it's compressed
+func decodeRewardsFile(fileBytes []byte, compressed bool) (*RewardsFile, error) {
+
+	// Decompress it
+	if compressed {
+		decoder, err := zstd.NewReader(nil)
+		if err != nil {
+			return nil, fmt.Errorf("error creating zstd decoder: %w", err)
+		}
+		fileBytes, err = decoder.DecodeAll(fileBytes, nil)
+		decoder.Close()
+		if err != nil {
+			return nil, fmt.Errorf("error decompressing rewards tree file: %w", err)
+		}
+	}
+
+	// Deserialize it
+	var file RewardsFile
+	if err := json.Unmarshal(fileBytes, &file); err != nil {
+		return nil, fmt.Errorf("error decoding rewards tree file: %w", err)
+	}
+	return &file, nil
+
+}
+
+// Get the rewards tree files for a network in the folder, sorted by interval.
+// An interval with both a compressed and an uncompressed file is listed once for each.
+func ListRewardsFiles(folder string, network string) ([]StoredRewardsFile, error) {
+	entries, err := ioutil.ReadDir(folder)
+	if os.IsNotExist(err) {
+		return []StoredRewardsFile{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading rewards tree folder %s: %w", folder, err)
+	}
+	files := []StoredRewardsFile{}
+	for _, entry := range entries {
+		matches := rewardsFilePattern.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || matches == nil || matches[1] != network {
+			continue
+		}
+		index, err := strconv.ParseUint(matches[2], 10, 64)
+		if err != nil {
+			continue
+		}
+		files = append(files, StoredRewardsFile{
+			Index: index,
+			Path:  filepath.Join(folder, entry.Name()),
+		})
+	}
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].Index < files[j].Index
+	})
+	return files, nil
+}
+
+// Get the name of the rewards tree file for an interval, without its extension
+func getRewardsFileName(network string, index uint64) string {
+	return fmt.Sprintf("rp-rewards-%s-%d", network, index)
+}
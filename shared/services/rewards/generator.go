@@ -0,0 +1,536 @@
+package rewards
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/node"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+)
+
+// Settings
+const (
+	nodeDetailsBatchSize       = 20
+	minipoolDepositSize  int64 = 32
+)
+
+// The state of a node at the end of an interval that its rewards are calculated from
+type generatorNodeInfo struct {
+	Address          common.Address
+	RegistrationTime time.Time
+	RewardNetwork    uint64
+	EffectiveStake   *big.Int
+	SmoothingPool    generatorSmoothingPoolInfo
+	Minipools        []generatorMinipoolInfo
+}
+
+// A node's smoothing pool membership at the end of an interval
+type generatorSmoothingPoolInfo struct {
+	OptedIn bool
+	Changed time.Time
+}
+
+// A staking minipool of a node that was in the smoothing pool during an interval
+type generatorMinipoolInfo struct {
+	Address     common.Address
+	Pubkey      rptypes.ValidatorPubkey
+	NodeFee     *big.Int
+	NodeDeposit *big.Int
+}
+
+// Generate the rewards tree file for an interval the way the Oracle DAO does, by replaying the chain's state at the interval's snapshot block:
+//   - The interval's pending RPL is split between node operators, the Oracle DAO, and the protocol DAO by their reward percentages.
+//     Each node's share of the node operator RPL is weighted by its effective RPL stake, scaled down if it registered less than an interval before the snapshot;
+//     each Oracle DAO member's share is weighted by the time it was a member for, up to an interval. The protocol DAO gets what's left after rounding down.
+//   - Nodes claim on the reward network they chose, or on Mainnet if that network isn't enabled.
+//   - The smoothing pool's balance is split by attestation performance: every attestation a minipool of an opted-in node made during the interval
+//     (included within an epoch) scores its node operator's share of the validator's rewards. The pool stakers get what's left.
+//   - The Merkle tree is built the way the canonical trees are.
+//
+// The returned file's Merkle root should be compared with the canonical root from the event before the file is used.
+// The chain state the tree is built from, including the attestation duties and results, is cached in snapshotCacheFolder by network and interval,
+// so generating the same interval again doesn't fetch it again; pass a blank folder to skip the cache.
+func GenerateRewardsFile(rp *rocketpool.RocketPool, bc beacon.Client, network string, event RewardsEvent, previous *RewardsEvent, snapshotCacheFolder string) (*RewardsFile, error) {
+
+	submission := event.Submission
+	file := &RewardsFile{
+		RewardsFileVersion: RewardsFileVersion,
+		Index:              event.Index,
+		Network:            network,
+		StartTime:          event.IntervalStartTime,
+		EndTime:            event.IntervalEndTime,
+		ConsensusEndBlock:  submission.ConsensusBlock.Uint64(),
+		ExecutionEndBlock:  submission.ExecutionBlock.Uint64(),
+		IntervalsPassed:    submission.IntervalsPassed.Uint64(),
+		TotalRewards: &TotalRewards{
+			ProtocolDaoRpl:               NewQuotedBigInt(0),
+			TotalCollateralRpl:           NewQuotedBigInt(0),
+			TotalOracleDaoRpl:            NewQuotedBigInt(0),
+			TotalSmoothingPoolEth:        NewQuotedBigInt(0),
+			PoolStakerSmoothingPoolEth:   NewQuotedBigInt(0),
+			NodeOperatorSmoothingPoolEth: NewQuotedBigInt(0),
+		},
+		NetworkRewards: map[uint64]*NetworkRewardsInfo{},
+		NodeRewards:    map[common.Address]*NodeRewardsInfo{},
+	}
+	if previous != nil {
+		file.ConsensusStartBlock = previous.Submission.ConsensusBlock.Uint64() + 1
+		file.ExecutionStartBlock = previous.Submission.ExecutionBlock.Uint64() + 1
+	}
+
+	// Get the state at the start and end of the interval
+	snapshot, err := getIntervalSnapshot(rp, network, event, previous, snapshotCacheFolder)
+	if err != nil {
+		return nil, err
+	}
+	getNodeRewards := func(address common.Address, rewardNetwork uint64) *NodeRewardsInfo {
+		nodeRewards, exists := file.NodeRewards[address]
+		if !exists {
+			nodeRewards = &NodeRewardsInfo{
+				RewardNetwork:    rewardNetwork,
+				CollateralRpl:    NewQuotedBigInt(0),
+				OracleDaoRpl:     NewQuotedBigInt(0),
+				SmoothingPoolEth: NewQuotedBigInt(0),
+			}
+			file.NodeRewards[address] = nodeRewards
+		}
+		return nodeRewards
+	}
+	rewardNetworks := map[common.Address]uint64{}
+	for _, nodeInfo := range snapshot.Nodes {
+		rewardNetworks[nodeInfo.Address] = nodeInfo.RewardNetwork
+	}
+
+	// Split the RPL
+	collateralRpl, oracleDaoRpl := calculateRplRewards(snapshot)
+	for address, amount := range collateralRpl {
+		getNodeRewards(address, rewardNetworks[address]).CollateralRpl.Set(amount)
+		file.TotalRewards.TotalCollateralRpl.Add(&file.TotalRewards.TotalCollateralRpl.Int, amount)
+	}
+	for address, amount := range oracleDaoRpl {
+		getNodeRewards(address, rewardNetworks[address]).OracleDaoRpl.Set(amount)
+		file.TotalRewards.TotalOracleDaoRpl.Add(&file.TotalRewards.TotalOracleDaoRpl.Int, amount)
+	}
+	file.TotalRewards.ProtocolDaoRpl.Sub(snapshot.PendingRpl, &file.TotalRewards.TotalCollateralRpl.Int)
+	file.TotalRewards.ProtocolDaoRpl.Sub(&file.TotalRewards.ProtocolDaoRpl.Int, &file.TotalRewards.TotalOracleDaoRpl.Int)
+
+	// Split the smoothing pool
+	file.TotalRewards.TotalSmoothingPoolEth.Set(snapshot.SmoothingPoolBalance)
+	file.TotalRewards.PoolStakerSmoothingPoolEth.Set(snapshot.SmoothingPoolBalance)
+	if snapshot.SmoothingPoolBalance.Sign() > 0 {
+		performance, err := getIntervalAttestationPerformance(bc, event, snapshot, snapshotCacheFolder)
+		if err != nil {
+			return nil, err
+		}
+		for address, amount := range calculateSmoothingPoolRewards(snapshot.SmoothingPoolBalance, snapshot.Nodes, performance) {
+			getNodeRewards(address, rewardNetworks[address]).SmoothingPoolEth.Set(amount)
+			file.TotalRewards.NodeOperatorSmoothingPoolEth.Add(&file.TotalRewards.NodeOperatorSmoothingPoolEth.Int, amount)
+		}
+		file.TotalRewards.PoolStakerSmoothingPoolEth.Sub(snapshot.SmoothingPoolBalance, &file.TotalRewards.NodeOperatorSmoothingPoolEth.Int)
+	}
+
+	// Get the totals for each network
+	for _, nodeRewards := range file.NodeRewards {
+		networkRewards, exists := file.NetworkRewards[nodeRewards.RewardNetwork]
+		if !exists {
+			networkRewards = &NetworkRewardsInfo{
+				CollateralRpl:    NewQuotedBigInt(0),
+				OracleDaoRpl:     NewQuotedBigInt(0),
+				SmoothingPoolEth: NewQuotedBigInt(0),
+			}
+			file.NetworkRewards[nodeRewards.RewardNetwork] = networkRewards
+		}
+		networkRewards.CollateralRpl.Add(&networkRewards.CollateralRpl.Int, &nodeRewards.CollateralRpl.Int)
+		networkRewards.OracleDaoRpl.Add(&networkRewards.OracleDaoRpl.Int, &nodeRewards.OracleDaoRpl.Int)
+		networkRewards.SmoothingPoolEth.Add(&networkRewards.SmoothingPoolEth.Int, &nodeRewards.SmoothingPoolEth.Int)
+	}
+
+	// Build the Merkle tree
+	tree, leaves := newRewardsTree(file.NodeRewards)
+	for address, nodeRewards := range file.NodeRewards {
+		proof := tree.proof(leaves[address])
+		nodeRewards.MerkleProof = make([]string, len(proof))
+		for i, sibling := range proof {
+			nodeRewards.MerkleProof[i] = sibling.Hex()
+		}
+	}
+	file.MerkleRoot = tree.root().Hex()
+	return file, nil
+
+}
+
+// Split an interval's pending RPL between the node operators and the Oracle DAO members.
+// Shares are rounded down, and the rounding is left to the protocol DAO.
+func calculateRplRewards(snapshot *intervalSnapshot) (map[common.Address]*big.Int, map[common.Address]*big.Int) {
+
+	intervalSeconds := int64(snapshot.IntervalDuration.Seconds())
+	one := eth.EthToWei(1)
+
+	// Weight each node's collateral by its effective stake, scaled down if it registered less than an interval before the snapshot
+	totalNodeRpl := new(big.Int).Mul(snapshot.PendingRpl, snapshot.NodeOperatorPercent)
+	totalNodeRpl.Div(totalNodeRpl, one)
+	nodeStakes := map[common.Address]*big.Int{}
+	totalStake := big.NewInt(0)
+	for _, nodeInfo := range snapshot.Nodes {
+		if nodeInfo.EffectiveStake == nil || nodeInfo.EffectiveStake.Sign() <= 0 {
+			continue
+		}
+		stake := new(big.Int).Set(nodeInfo.EffectiveStake)
+		nodeAge := snapshot.SnapshotTime.Sub(nodeInfo.RegistrationTime)
+		if nodeAge < snapshot.IntervalDuration {
+			stake.Mul(stake, big.NewInt(int64(nodeAge.Seconds())))
+			stake.Div(stake, big.NewInt(intervalSeconds))
+		}
+		nodeStakes[nodeInfo.Address] = stake
+		totalStake.Add(totalStake, stake)
+	}
+	collateralRpl := map[common.Address]*big.Int{}
+	if totalStake.Sign() > 0 {
+		for address, stake := range nodeStakes {
+			amount := new(big.Int).Mul(stake, totalNodeRpl)
+			amount.Div(amount, totalStake)
+			if amount.Sign() > 0 {
+				collateralRpl[address] = amount
+			}
+		}
+	}
+
+	// Weight each Oracle DAO member by the time it was a member for, up to an interval
+	totalOracleDaoRpl := new(big.Int).Mul(snapshot.PendingRpl, snapshot.OracleDaoPercent)
+	totalOracleDaoRpl.Div(totalOracleDaoRpl, one)
+	memberTimes := map[common.Address]*big.Int{}
+	totalTime := big.NewInt(0)
+	for i, member := range snapshot.OracleDaoMembers {
+		participation := big.NewInt(intervalSeconds)
+		membership := snapshot.SnapshotTime.Sub(snapshot.OracleDaoJoinTimes[i])
+		if membership < snapshot.IntervalDuration {
+			participation = big.NewInt(int64(membership.Seconds()))
+		}
+		memberTimes[member] = participation
+		totalTime.Add(totalTime, participation)
+	}
+	oracleDaoRpl := map[common.Address]*big.Int{}
+	if totalTime.Sign() > 0 {
+		for member, participation := range memberTimes {
+			amount := new(big.Int).Mul(participation, totalOracleDaoRpl)
+			amount.Div(amount, totalTime)
+			if amount.Sign() > 0 {
+				oracleDaoRpl[member] = amount
+			}
+		}
+	}
+
+	return collateralRpl, oracleDaoRpl
+
+}
+
+// Split the smoothing pool's balance between the nodes by the attestation scores of their minipools.
+// The node operators' part is the balance scaled by their average score per successful attestation; each minipool then gets its share of that part by score.
+func calculateSmoothingPoolRewards(balance *big.Int, nodes []generatorNodeInfo, performance *attestationPerformance) map[common.Address]*big.Int {
+
+	rewards := map[common.Address]*big.Int{}
+	if performance.TotalScore.Sign() == 0 || performance.SuccessfulAttestations == 0 {
+		return rewards
+	}
+	nodeOperatorShare := new(big.Int).Mul(balance, performance.TotalScore)
+	nodeOperatorShare.Div(nodeOperatorShare, new(big.Int).SetUint64(performance.SuccessfulAttestations))
+	nodeOperatorShare.Div(nodeOperatorShare, eth.EthToWei(1))
+
+	for _, nodeInfo := range nodes {
+		nodeEth := big.NewInt(0)
+		for _, mp := range nodeInfo.Minipools {
+			mpPerformance, exists := performance.Minipools[mp.Address]
+			if !exists || mpPerformance.GoodAttestations+mpPerformance.MissedAttestations == 0 {
+				continue
+			}
+			minipoolEth := new(big.Int).Mul(nodeOperatorShare, mpPerformance.Score)
+			minipoolEth.Div(minipoolEth, performance.TotalScore)
+			nodeEth.Add(nodeEth, minipoolEth)
+		}
+		if nodeEth.Sign() > 0 {
+			rewards[nodeInfo.Address] = nodeEth
+		}
+	}
+	return rewards
+
+}
+
+// Get the score of a successful attestation by a minipool, which is the node operator's share of the validator's rewards:
+// its bond as a fraction of the validator's balance, plus the commission on the rest
+func getAttestationScore(mp generatorMinipoolInfo) *big.Int {
+	one := eth.EthToWei(1)
+	score := new(big.Int).Sub(one, mp.NodeFee)
+	score.Mul(score, mp.NodeDeposit)
+	score.Div(score, eth.EthToWei(float64(minipoolDepositSize)))
+	return score.Add(score, mp.NodeFee)
+}
+
+// Get the state of every node that affects its rewards for an interval
+func getGeneratorNodeInfo(rp *rocketpool.RocketPool, event RewardsEvent, opts *bind.CallOpts) ([]generatorNodeInfo, error) {
+
+	addresses, err := node.GetNodeAddresses(rp, opts)
+	if err != nil {
+		return nil, fmt.Errorf("Could not get the node addresses: %w", err)
+	}
+
+	// Load the details in batches
+	nodes := make([]generatorNodeInfo, len(addresses))
+	validNetworks := newRewardNetworkValidator(rp, opts)
+	for bsi := 0; bsi < len(addresses); bsi += nodeDetailsBatchSize {
+
+		// Get batch start & end index
+		nsi := bsi
+		nei := bsi + nodeDetailsBatchSize
+		if nei > len(addresses) {
+			nei = len(addresses)
+		}
+
+		// Load details
+		var wg errgroup.Group
+		for ni := nsi; ni < nei; ni++ {
+			ni := ni
+			wg.Go(func() error {
+				nodeInfo, err := getNodeInfoForInterval(rp, addresses[ni], event, opts)
+				if err == nil {
+					nodes[ni] = nodeInfo
+				}
+				return err
+			})
+		}
+		if err := wg.Wait(); err != nil {
+			return nil, err
+		}
+
+	}
+
+	// Nodes on a reward network that isn't enabled claim on Mainnet
+	for i := range nodes {
+		valid, err := validNetworks.isEnabled(nodes[i].RewardNetwork)
+		if err != nil {
+			return nil, err
+		}
+		if !valid {
+			nodes[i].RewardNetwork = 0
+		}
+	}
+
+	// Keep them in address order, so the snapshot is the same however the node list is read
+	sort.Slice(nodes, func(i, j int) bool {
+		return bytes.Compare(nodes[i].Address.Bytes(), nodes[j].Address.Bytes()) < 0
+	})
+	return nodes, nil
+
+}
+
+// Get the state of a node that affects its rewards for an interval
+func getNodeInfoForInterval(rp *rocketpool.RocketPool, nodeAddress common.Address, event RewardsEvent, opts *bind.CallOpts) (generatorNodeInfo, error) {
+
+	nodeInfo := generatorNodeInfo{
+		Address: nodeAddress,
+	}
+
+	// Get the node's registration, collateral, and smoothing pool status
+	var wg errgroup.Group
+	wg.Go(func() error {
+		var err error
+		nodeInfo.RegistrationTime, err = getNodeRegistrationTime(rp, nodeAddress, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		nodeInfo.RewardNetwork, err = getNodeRewardNetwork(rp, nodeAddress, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		nodeInfo.EffectiveStake, err = node.GetNodeEffectiveRPLStake(rp, nodeAddress, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		nodeInfo.SmoothingPool.OptedIn, err = GetSmoothingPoolRegistrationState(rp, nodeAddress, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		nodeInfo.SmoothingPool.Changed, err = GetSmoothingPoolRegistrationChanged(rp, nodeAddress, opts)
+		return err
+	})
+	if err := wg.Wait(); err != nil {
+		return generatorNodeInfo{}, fmt.Errorf("Could not get the details of node %s: %w", nodeAddress.Hex(), err)
+	}
+
+	// Only the minipools of nodes that were in the smoothing pool during the interval matter
+	if _, _, eligible := nodeInfo.getSmoothingPoolWindow(event); !eligible {
+		return nodeInfo, nil
+	}
+	addresses, err := minipool.GetNodeMinipoolAddresses(rp, nodeAddress, opts)
+	if err != nil {
+		return generatorNodeInfo{}, fmt.Errorf("Could not get the minipools of node %s: %w", nodeAddress.Hex(), err)
+	}
+	for _, address := range addresses {
+		mpInfo, staking, err := getMinipoolInfoForInterval(rp, address, opts)
+		if err != nil {
+			return generatorNodeInfo{}, err
+		}
+		if staking {
+			nodeInfo.Minipools = append(nodeInfo.Minipools, mpInfo)
+		}
+	}
+	return nodeInfo, nil
+
+}
+
+// Get the part of an interval a node was in the smoothing pool for; returns false if it wasn't in the pool at all.
+// A node that's in the pool now has been since it last opted in, and one that opted out during the interval was in it until then.
+func (nodeInfo generatorNodeInfo) getSmoothingPoolWindow(event RewardsEvent) (time.Time, time.Time, bool) {
+	if nodeInfo.SmoothingPool.OptedIn {
+		return nodeInfo.SmoothingPool.Changed, event.IntervalEndTime, true
+	}
+	if nodeInfo.SmoothingPool.Changed.After(event.IntervalStartTime) {
+		return event.IntervalStartTime, nodeInfo.SmoothingPool.Changed, true
+	}
+	return time.Time{}, time.Time{}, false
+}
+
+// Get the details of a minipool that decide its attestation score; returns false if it isn't staking
+func getMinipoolInfoForInterval(rp *rocketpool.RocketPool, minipoolAddress common.Address, opts *bind.CallOpts) (generatorMinipoolInfo, bool, error) {
+
+	mp, err := minipool.NewMinipool(rp, minipoolAddress)
+	if err != nil {
+		return generatorMinipoolInfo{}, false, err
+	}
+	status, err := mp.GetStatus(opts)
+	if err != nil {
+		return generatorMinipoolInfo{}, false, fmt.Errorf("Could not get the status of minipool %s: %w", minipoolAddress.Hex(), err)
+	}
+	if status != rptypes.Staking {
+		return generatorMinipoolInfo{}, false, nil
+	}
+
+	mpInfo := generatorMinipoolInfo{
+		Address: minipoolAddress,
+	}
+	var wg errgroup.Group
+	wg.Go(func() error {
+		var err error
+		mpInfo.Pubkey, err = minipool.GetMinipoolPubkey(rp, minipoolAddress, opts)
+		return err
+	})
+	wg.Go(func() error {
+		nodeFee := new(*big.Int)
+		if err := mp.Contract.Call(opts, nodeFee, "getNodeFee"); err != nil {
+			return err
+		}
+		mpInfo.NodeFee = *nodeFee
+		return nil
+	})
+	wg.Go(func() error {
+		var err error
+		mpInfo.NodeDeposit, err = mp.GetNodeDepositBalance(opts)
+		return err
+	})
+	if err := wg.Wait(); err != nil {
+		return generatorMinipoolInfo{}, false, fmt.Errorf("Could not get the details of minipool %s: %w", minipoolAddress.Hex(), err)
+	}
+	return mpInfo, true, nil
+
+}
+
+// Get the time a node registered with the network
+func getNodeRegistrationTime(rp *rocketpool.RocketPool, nodeAddress common.Address, opts *bind.CallOpts) (time.Time, error) {
+	rocketNodeManager, err := rp.GetContract("rocketNodeManager")
+	if err != nil {
+		return time.Time{}, err
+	}
+	registrationTime := new(*big.Int)
+	if err := rocketNodeManager.Call(opts, registrationTime, "getNodeRegistrationTime", nodeAddress); err != nil {
+		return time.Time{}, fmt.Errorf("Could not get the registration time of node %s: %w", nodeAddress.Hex(), err)
+	}
+	return time.Unix((*registrationTime).Int64(), 0), nil
+}
+
+// Get the network a node claims its rewards on
+func getNodeRewardNetwork(rp *rocketpool.RocketPool, nodeAddress common.Address, opts *bind.CallOpts) (uint64, error) {
+	rocketNodeManager, err := rp.GetContract("rocketNodeManager")
+	if err != nil {
+		return 0, err
+	}
+	network := new(*big.Int)
+	if err := rocketNodeManager.Call(opts, network, "getRewardNetwork", nodeAddress); err != nil {
+		return 0, fmt.Errorf("Could not get the reward network of node %s: %w", nodeAddress.Hex(), err)
+	}
+	return (*network).Uint64(), nil
+}
+
+// Checks which reward networks are enabled, looking each one up once
+type rewardNetworkValidator struct {
+	rp      *rocketpool.RocketPool
+	opts    *bind.CallOpts
+	enabled map[uint64]bool
+}
+
+// Create a reward network validator for the state at the provided block
+func newRewardNetworkValidator(rp *rocketpool.RocketPool, opts *bind.CallOpts) *rewardNetworkValidator {
+	return &rewardNetworkValidator{
+		rp:   rp,
+		opts: opts,
+		enabled: map[uint64]bool{
+			0: true,
+		},
+	}
+}
+
+// Check if rewards can be claimed on a network
+func (v *rewardNetworkValidator) isEnabled(network uint64) (bool, error) {
+	if enabled, exists := v.enabled[network]; exists {
+		return enabled, nil
+	}
+	rocketDAONodeTrustedSettingsRewards, err := v.rp.GetContract("rocketDAONodeTrustedSettingsRewards")
+	if err != nil {
+		return false, err
+	}
+	enabled := new(bool)
+	if err := rocketDAONodeTrustedSettingsRewards.Call(v.opts, enabled, "getNetworkEnabled", new(big.Int).SetUint64(network)); err != nil {
+		return false, fmt.Errorf("Could not check if reward network %d is enabled: %w", network, err)
+	}
+	v.enabled[network] = *enabled
+	return *enabled, nil
+}
+
+// Get the interval's RPL that hasn't been distributed yet
+func getPendingRplRewards(rp *rocketpool.RocketPool, opts *bind.CallOpts) (*big.Int, error) {
+	rocketRewardsPool, err := rp.GetContract("rocketRewardsPool")
+	if err != nil {
+		return nil, err
+	}
+	pending := new(*big.Int)
+	if err := rocketRewardsPool.Call(opts, pending, "getPendingRPLRewards"); err != nil {
+		return nil, fmt.Errorf("Could not get the pending RPL rewards: %w", err)
+	}
+	return *pending, nil
+}
+
+// Get the fraction of each interval's RPL a claiming contract gets, in Wei of 1 ETH
+func getClaimingContractPercent(rp *rocketpool.RocketPool, claimingContract string, opts *bind.CallOpts) (*big.Int, error) {
+	rocketRewardsPool, err := rp.GetContract("rocketRewardsPool")
+	if err != nil {
+		return nil, err
+	}
+	percent := new(*big.Int)
+	if err := rocketRewardsPool.Call(opts, percent, "getClaimingContractPerc", claimingContract); err != nil {
+		return nil, fmt.Errorf("Could not get the RPL rewards percentage of %s: %w", claimingContract, err)
+	}
+	return *percent, nil
+}
@@ -0,0 +1,270 @@
+package rewards
+
+import (
+	"math/big"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+)
+
+const testIntervalDuration = 28 * 24 * time.Hour
+
+// Parse a wei amount
+func testWei(t *testing.T, amount string) *big.Int {
+	value, ok := new(big.Int).SetString(amount, 10)
+	if !ok {
+		t.Fatalf("invalid amount %s", amount)
+	}
+	return value
+}
+
+// Get an address for a test node or minipool
+func testAddress(b byte) common.Address {
+	var address common.Address
+	address[0] = b
+	return address
+}
+
+func TestCalculateRplRewards(t *testing.T) {
+	snapshotTime := time.Unix(1660000000, 0)
+	snapshot := &intervalSnapshot{
+		SnapshotTime:        snapshotTime,
+		IntervalDuration:    testIntervalDuration,
+		PendingRpl:          eth.EthToWei(1000),
+		NodeOperatorPercent: eth.EthToWei(0.7),
+		OracleDaoPercent:    eth.EthToWei(0.2),
+		Nodes: []generatorNodeInfo{
+			// Registered before the interval, so its full stake counts
+			{Address: testAddress(1), RegistrationTime: snapshotTime.Add(-2 * testIntervalDuration), EffectiveStake: eth.EthToWei(100)},
+			// Registered halfway through the interval, so half of its stake counts
+			{Address: testAddress(2), RegistrationTime: snapshotTime.Add(-testIntervalDuration / 2), EffectiveStake: eth.EthToWei(100)},
+			// Nodes without an effective stake don't get any collateral rewards
+			{Address: testAddress(3), RegistrationTime: snapshotTime.Add(-2 * testIntervalDuration), EffectiveStake: big.NewInt(0)},
+			{Address: testAddress(4), RegistrationTime: snapshotTime.Add(-2 * testIntervalDuration)},
+		},
+		OracleDaoMembers:   []common.Address{testAddress(5), testAddress(6)},
+		OracleDaoJoinTimes: []time.Time{snapshotTime.Add(-3 * testIntervalDuration), snapshotTime.Add(-testIntervalDuration / 4)},
+	}
+
+	collateralRpl, oracleDaoRpl := calculateRplRewards(snapshot)
+
+	// 700 RPL split 100:50, rounded down
+	expectedCollateral := map[common.Address]*big.Int{
+		testAddress(1): testWei(t, "466666666666666666666"),
+		testAddress(2): testWei(t, "233333333333333333333"),
+	}
+	if len(collateralRpl) != len(expectedCollateral) {
+		t.Fatalf("expected collateral rewards for %d nodes, got %v", len(expectedCollateral), collateralRpl)
+	}
+	for address, expected := range expectedCollateral {
+		if collateralRpl[address] == nil || collateralRpl[address].Cmp(expected) != 0 {
+			t.Errorf("expected %s collateral RPL for %s, got %s", expected, address.Hex(), collateralRpl[address])
+		}
+	}
+
+	// 200 RPL split by membership time, capped at an interval: 4:1
+	expectedOracleDao := map[common.Address]*big.Int{
+		testAddress(5): eth.EthToWei(160),
+		testAddress(6): eth.EthToWei(40),
+	}
+	if len(oracleDaoRpl) != len(expectedOracleDao) {
+		t.Fatalf("expected Oracle DAO rewards for %d members, got %v", len(expectedOracleDao), oracleDaoRpl)
+	}
+	for address, expected := range expectedOracleDao {
+		if oracleDaoRpl[address] == nil || oracleDaoRpl[address].Cmp(expected) != 0 {
+			t.Errorf("expected %s Oracle DAO RPL for %s, got %s", expected, address.Hex(), oracleDaoRpl[address])
+		}
+	}
+
+	// The protocol DAO's 100 RPL gets the wei lost to rounding
+	protocolDaoRpl := new(big.Int).Set(snapshot.PendingRpl)
+	for _, amount := range collateralRpl {
+		protocolDaoRpl.Sub(protocolDaoRpl, amount)
+	}
+	for _, amount := range oracleDaoRpl {
+		protocolDaoRpl.Sub(protocolDaoRpl, amount)
+	}
+	if expected := testWei(t, "100000000000000000001"); protocolDaoRpl.Cmp(expected) != 0 {
+		t.Fatalf("expected %s protocol DAO RPL, got %s", expected, protocolDaoRpl)
+	}
+}
+
+func TestGetAttestationScore(t *testing.T) {
+	tests := []struct {
+		fee      float64
+		deposit  float64
+		expected *big.Int
+	}{
+		{fee: 0.1, deposit: 16, expected: eth.EthToWei(0.55)},
+		{fee: 0.15, deposit: 8, expected: eth.EthToWei(0.3625)},
+		{fee: 0.2, deposit: 32, expected: eth.EthToWei(1)},
+	}
+	for _, test := range tests {
+		score := getAttestationScore(generatorMinipoolInfo{NodeFee: eth.EthToWei(test.fee), NodeDeposit: eth.EthToWei(test.deposit)})
+		if score.Cmp(test.expected) != 0 {
+			t.Errorf("expected a score of %s for a %.2f fee and %.0f ETH bond, got %s", test.expected, test.fee, test.deposit, score)
+		}
+	}
+}
+
+func TestCalculateSmoothingPoolRewards(t *testing.T) {
+	nodes := []generatorNodeInfo{
+		{Address: testAddress(1), Minipools: []generatorMinipoolInfo{{Address: testAddress(11)}}},
+		{Address: testAddress(2), Minipools: []generatorMinipoolInfo{{Address: testAddress(12)}}},
+		{Address: testAddress(3), Minipools: []generatorMinipoolInfo{{Address: testAddress(13)}}},
+	}
+	performance := &attestationPerformance{
+		Minipools: map[common.Address]*minipoolPerformance{
+			// 10 attestations scoring 0.55 and 0.3625
+			testAddress(11): {GoodAttestations: 10, Score: eth.EthToWei(5.5)},
+			testAddress(12): {GoodAttestations: 10, Score: eth.EthToWei(3.625)},
+			// Missed every attestation
+			testAddress(13): {MissedAttestations: 10, Score: big.NewInt(0)},
+		},
+		TotalScore:             eth.EthToWei(9.125),
+		SuccessfulAttestations: 20,
+	}
+
+	// The node operators get 10 ETH * 9.125 / 20 = 4.5625 ETH, split 5.5:3.625
+	rewards := calculateSmoothingPoolRewards(eth.EthToWei(10), nodes, performance)
+	expected := map[common.Address]*big.Int{
+		testAddress(1): eth.EthToWei(2.75),
+		testAddress(2): eth.EthToWei(1.8125),
+	}
+	if len(rewards) != len(expected) {
+		t.Fatalf("expected smoothing pool rewards for %d nodes, got %v", len(expected), rewards)
+	}
+	for address, amount := range expected {
+		if rewards[address] == nil || rewards[address].Cmp(amount) != 0 {
+			t.Errorf("expected %s ETH for %s, got %s", amount, address.Hex(), rewards[address])
+		}
+	}
+
+	// Nothing is distributed without any successful attestations
+	if rewards := calculateSmoothingPoolRewards(eth.EthToWei(10), nodes, &attestationPerformance{TotalScore: big.NewInt(0)}); len(rewards) != 0 {
+		t.Fatalf("expected no rewards, got %v", rewards)
+	}
+}
+
+// A Beacon client that serves a fixed set of validators, committees and blocks
+type testBeaconClient struct {
+	beacon.Client
+	eth2Config   beacon.Eth2Config
+	validators   map[rptypes.ValidatorPubkey]uint64
+	committees   map[uint64][]beacon.Committee
+	attestations map[uint64][]beacon.Attestation
+}
+
+func (c *testBeaconClient) GetEth2Config() (beacon.Eth2Config, error) {
+	return c.eth2Config, nil
+}
+
+func (c *testBeaconClient) GetValidatorStatuses(pubkeys []rptypes.ValidatorPubkey, opts *beacon.ValidatorStatusOptions) (map[rptypes.ValidatorPubkey]beacon.ValidatorStatus, error) {
+	statuses := map[rptypes.ValidatorPubkey]beacon.ValidatorStatus{}
+	for _, pubkey := range pubkeys {
+		index, exists := c.validators[pubkey]
+		statuses[pubkey] = beacon.ValidatorStatus{Pubkey: pubkey, Index: index, Exists: exists}
+	}
+	return statuses, nil
+}
+
+func (c *testBeaconClient) GetCommittees(stateId string, epoch uint64) ([]beacon.Committee, error) {
+	return c.committees[epoch], nil
+}
+
+func (c *testBeaconClient) GetBeaconBlockAttestations(blockId string) ([]beacon.Attestation, bool, error) {
+	slot, err := strconv.ParseUint(blockId, 10, 64)
+	if err != nil {
+		return nil, false, err
+	}
+	attestations, exists := c.attestations[slot]
+	return attestations, exists, nil
+}
+
+// Get a test interval covering slots 4 to 11, with a node in the smoothing pool for all of it, one that left at slot 8, and one that left before it
+func newTestAttestationInterval() (RewardsEvent, []generatorNodeInfo, *testBeaconClient) {
+	genesisTime := time.Unix(1606824023, 0)
+	slotTime := func(slot int64) time.Time {
+		return genesisTime.Add(time.Duration(slot*12) * time.Second)
+	}
+	pubkey := func(b byte) rptypes.ValidatorPubkey {
+		var pubkey rptypes.ValidatorPubkey
+		pubkey[0] = b
+		return pubkey
+	}
+
+	event := RewardsEvent{IntervalStartTime: slotTime(4), IntervalEndTime: slotTime(12)}
+	nodes := []generatorNodeInfo{
+		// In the pool for the whole interval
+		{Address: testAddress(1), SmoothingPool: generatorSmoothingPoolInfo{OptedIn: true, Changed: slotTime(0)}, Minipools: []generatorMinipoolInfo{
+			{Address: testAddress(11), Pubkey: pubkey(1), NodeFee: eth.EthToWei(0.1), NodeDeposit: eth.EthToWei(16)},
+		}},
+		// Opted out at slot 8
+		{Address: testAddress(2), SmoothingPool: generatorSmoothingPoolInfo{OptedIn: false, Changed: slotTime(8)}, Minipools: []generatorMinipoolInfo{
+			{Address: testAddress(12), Pubkey: pubkey(2), NodeFee: eth.EthToWei(0.15), NodeDeposit: eth.EthToWei(8)},
+		}},
+		// Opted out before the interval
+		{Address: testAddress(3), SmoothingPool: generatorSmoothingPoolInfo{OptedIn: false, Changed: slotTime(2)}, Minipools: []generatorMinipoolInfo{
+			{Address: testAddress(13), Pubkey: pubkey(3), NodeFee: eth.EthToWei(0.1), NodeDeposit: eth.EthToWei(16)},
+		}},
+	}
+	bc := &testBeaconClient{
+		eth2Config: beacon.Eth2Config{GenesisTime: uint64(genesisTime.Unix()), SecondsPerEpoch: 48, SlotsPerEpoch: 4},
+		validators: map[rptypes.ValidatorPubkey]uint64{pubkey(1): 10, pubkey(2): 20, pubkey(3): 30},
+		committees: map[uint64][]beacon.Committee{
+			1: {
+				{Slot: 5, Index: 0, Validators: []uint64{99, 10, 20}},
+				{Slot: 6, Index: 1, Validators: []uint64{30}},
+			},
+			2: {
+				{Slot: 9, Index: 0, Validators: []uint64{10, 20}},
+			},
+		},
+		attestations: map[uint64][]beacon.Attestation{
+			// The first minipool attested to slot 5 in time
+			6: {{Slot: 5, CommitteeIndex: 0, AggregationBits: []byte{0x0a}}, {Slot: 6, CommitteeIndex: 1, AggregationBits: []byte{0x03}}},
+			// The second one attested to slot 5 more than an epoch later
+			10: {{Slot: 5, CommitteeIndex: 0, AggregationBits: []byte{0x0c}}},
+			// Both attested to slot 9 within an epoch, after the second one left the pool
+			13: {{Slot: 9, CommitteeIndex: 0, AggregationBits: []byte{0x07}}},
+		},
+	}
+	return event, nodes, bc
+}
+
+// Check the attestation performance of the test interval
+func checkTestAttestationPerformance(t *testing.T, performance *attestationPerformance) {
+	t.Helper()
+	if performance.SuccessfulAttestations != 2 {
+		t.Fatalf("expected 2 successful attestations, got %d", performance.SuccessfulAttestations)
+	}
+	if expected := eth.EthToWei(1.1); performance.TotalScore.Cmp(expected) != 0 {
+		t.Fatalf("expected a total score of %s, got %s", expected, performance.TotalScore)
+	}
+	if len(performance.Minipools) != 2 {
+		t.Fatalf("expected the performance of 2 minipools, got %v", performance.Minipools)
+	}
+	first := performance.Minipools[testAddress(11)]
+	if first == nil || first.GoodAttestations != 2 || first.MissedAttestations != 0 || first.Score.Cmp(eth.EthToWei(1.1)) != 0 {
+		t.Errorf("unexpected performance for the first minipool: %+v", first)
+	}
+	second := performance.Minipools[testAddress(12)]
+	if second == nil || second.GoodAttestations != 0 || second.MissedAttestations != 1 || second.Score.Sign() != 0 {
+		t.Errorf("unexpected performance for the second minipool: %+v", second)
+	}
+}
+
+func TestGetAttestationPerformance(t *testing.T) {
+	event, nodes, bc := newTestAttestationInterval()
+	performance, err := getAttestationPerformance(bc, event, 4, 11, nodes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkTestAttestationPerformance(t, performance)
+}
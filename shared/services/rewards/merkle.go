@@ -0,0 +1,138 @@
+package rewards
+
+import (
+	"bytes"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// A Merkle tree over the rewards of each node, laid out the way the canonical trees are built:
+// the leaves are ordered by their encoded data (so by node address), the tree is padded to a power of two with empty leaves,
+// and each pair is sorted before it's hashed, which is how the Merkle distributor verifies claims
+type merkleTree struct {
+	levels [][]common.Hash
+	index  map[common.Hash]int
+}
+
+// Get the leaf for a node's rewards, which is the hash of abi.encodePacked(address, network, rpl, eth)
+func getRewardsLeaf(nodeAddress common.Address, network uint64, rpl *QuotedBigInt, eth *QuotedBigInt) common.Hash {
+	return crypto.Keccak256Hash(getRewardsLeafData(nodeAddress, network, rpl, eth))
+}
+
+// Get the encoded data of a node's rewards leaf, which is abi.encodePacked(address, network, rpl, eth)
+func getRewardsLeafData(nodeAddress common.Address, network uint64, rpl *QuotedBigInt, eth *QuotedBigInt) []byte {
+	data := make([]byte, 0, common.AddressLength+3*common.HashLength)
+	data = append(data, nodeAddress.Bytes()...)
+	data = append(data, common.BigToHash(new(big.Int).SetUint64(network)).Bytes()...)
+	data = append(data, common.BigToHash(&rpl.Int).Bytes()...)
+	data = append(data, common.BigToHash(&eth.Int).Bytes()...)
+	return data
+}
+
+// Build the Merkle tree for the rewards of each node; nodes without any rewards don't get a leaf.
+// Returns the tree and the leaf of each node in it.
+func newRewardsTree(nodeRewards map[common.Address]*NodeRewardsInfo) (*merkleTree, map[common.Address]common.Hash) {
+
+	// Encode the leaf data of each node
+	leafData := [][]byte{}
+	addresses := []common.Address{}
+	for address, rewards := range nodeRewards {
+		rpl := NewQuotedBigInt(0)
+		eth := NewQuotedBigInt(0)
+		if rewards.CollateralRpl != nil {
+			rpl.Add(&rpl.Int, &rewards.CollateralRpl.Int)
+		}
+		if rewards.OracleDaoRpl != nil {
+			rpl.Add(&rpl.Int, &rewards.OracleDaoRpl.Int)
+		}
+		if rewards.SmoothingPoolEth != nil {
+			eth.Set(&rewards.SmoothingPoolEth.Int)
+		}
+		if rpl.Sign() == 0 && eth.Sign() == 0 {
+			continue
+		}
+		leafData = append(leafData, getRewardsLeafData(address, rewards.RewardNetwork, rpl, eth))
+		addresses = append(addresses, address)
+	}
+
+	// Order the leaves by their data
+	order := make([]int, len(leafData))
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(i, j int) bool {
+		return bytes.Compare(leafData[order[i]], leafData[order[j]]) < 0
+	})
+	leaves := make([]common.Hash, len(order))
+	nodeLeaves := make(map[common.Address]common.Hash, len(order))
+	for i, dataIndex := range order {
+		leaves[i] = crypto.Keccak256Hash(leafData[dataIndex])
+		nodeLeaves[addresses[dataIndex]] = leaves[i]
+	}
+	return newMerkleTree(leaves), nodeLeaves
+
+}
+
+// Build a Merkle tree from its leaves, which are kept in the order they're provided in
+func newMerkleTree(leaves []common.Hash) *merkleTree {
+
+	// Pad the leaves to a power of two
+	padded := make([]common.Hash, len(leaves))
+	copy(padded, leaves)
+	size := 1
+	for size < len(padded) {
+		size *= 2
+	}
+	for len(padded) < size {
+		padded = append(padded, common.Hash{})
+	}
+
+	// Hash each level into the next
+	tree := &merkleTree{
+		levels: [][]common.Hash{padded},
+		index:  map[common.Hash]int{},
+	}
+	for i, leaf := range leaves {
+		tree.index[leaf] = i
+	}
+	for level := padded; len(level) > 1; {
+		next := make([]common.Hash, len(level)/2)
+		for i := range next {
+			next[i] = hashPair(level[2*i], level[2*i+1])
+		}
+		tree.levels = append(tree.levels, next)
+		level = next
+	}
+	return tree
+
+}
+
+// Get the root of the tree
+func (t *merkleTree) root() common.Hash {
+	return t.levels[len(t.levels)-1][0]
+}
+
+// Get the proof for a leaf, which is its sibling at each level of the tree
+func (t *merkleTree) proof(leaf common.Hash) []common.Hash {
+	position, exists := t.index[leaf]
+	if !exists {
+		return nil
+	}
+	proof := []common.Hash{}
+	for _, level := range t.levels[:len(t.levels)-1] {
+		proof = append(proof, level[position^1])
+		position /= 2
+	}
+	return proof
+}
+
+// Hash a pair of nodes in sorted order
+func hashPair(a common.Hash, b common.Hash) common.Hash {
+	if bytes.Compare(a.Bytes(), b.Bytes()) > 0 {
+		a, b = b, a
+	}
+	return crypto.Keccak256Hash(a.Bytes(), b.Bytes())
+}
@@ -0,0 +1,86 @@
+package rewards
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Fold a proof into the root it proves a leaf against
+func getProofRoot(leaf common.Hash, proof []common.Hash) common.Hash {
+	root := leaf
+	for _, sibling := range proof {
+		root = hashPair(root, sibling)
+	}
+	return root
+}
+
+// Get the rewards of a test node
+func testNodeRewards(network uint64, rpl int64, eth int64) *NodeRewardsInfo {
+	return &NodeRewardsInfo{
+		RewardNetwork:    network,
+		CollateralRpl:    NewQuotedBigInt(rpl),
+		OracleDaoRpl:     NewQuotedBigInt(0),
+		SmoothingPoolEth: NewQuotedBigInt(eth),
+	}
+}
+
+func TestRewardsTreeLayout(t *testing.T) {
+	nodeRewards := map[common.Address]*NodeRewardsInfo{
+		testAddress(3): testNodeRewards(0, 30, 0),
+		testAddress(1): testNodeRewards(1, 10, 5),
+		testAddress(2): testNodeRewards(0, 0, 20),
+		// Nodes without rewards don't get a leaf
+		testAddress(4): testNodeRewards(0, 0, 0),
+	}
+	tree, leaves := newRewardsTree(nodeRewards)
+	if len(leaves) != 3 {
+		t.Fatalf("expected 3 leaves, got %d", len(leaves))
+	}
+	if _, exists := leaves[testAddress(4)]; exists {
+		t.Fatal("expected no leaf for a node without rewards")
+	}
+
+	// The leaves are ordered by address and padded to a power of two
+	leaf1 := getRewardsLeaf(testAddress(1), 1, NewQuotedBigInt(10), NewQuotedBigInt(5))
+	leaf2 := getRewardsLeaf(testAddress(2), 0, NewQuotedBigInt(0), NewQuotedBigInt(20))
+	leaf3 := getRewardsLeaf(testAddress(3), 0, NewQuotedBigInt(30), NewQuotedBigInt(0))
+	expectedRoot := hashPair(hashPair(leaf1, leaf2), hashPair(leaf3, common.Hash{}))
+	if tree.root() != expectedRoot {
+		t.Fatalf("expected root %s, got %s", expectedRoot.Hex(), tree.root().Hex())
+	}
+
+	// Every proof verifies against the root
+	for address, leaf := range leaves {
+		proof := tree.proof(leaf)
+		if len(proof) != 2 {
+			t.Fatalf("expected a proof of 2 hashes for %s, got %d", address.Hex(), len(proof))
+		}
+		if root := getProofRoot(leaf, proof); root != tree.root() {
+			t.Errorf("the proof for %s leads to %s instead of the root", address.Hex(), root.Hex())
+		}
+	}
+	if proof := tree.proof(common.Hash{1}); proof != nil {
+		t.Fatalf("expected no proof for an unknown leaf, got %v", proof)
+	}
+}
+
+func TestRewardsTreeIsOrderIndependent(t *testing.T) {
+	first, _ := newRewardsTree(map[common.Address]*NodeRewardsInfo{
+		testAddress(1): testNodeRewards(0, 10, 0),
+		testAddress(2): testNodeRewards(0, 20, 0),
+	})
+	second, _ := newRewardsTree(map[common.Address]*NodeRewardsInfo{
+		testAddress(2): testNodeRewards(0, 20, 0),
+		testAddress(1): testNodeRewards(0, 10, 0),
+	})
+	if first.root() != second.root() {
+		t.Fatal("expected the same root regardless of the map's order")
+	}
+
+	// A single leaf is its own root
+	single, leaves := newRewardsTree(map[common.Address]*NodeRewardsInfo{testAddress(1): testNodeRewards(0, 10, 0)})
+	if single.root() != leaves[testAddress(1)] {
+		t.Fatal("expected a single leaf to be the root")
+	}
+}
@@ -0,0 +1,72 @@
+package rewards
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+)
+
+// Check if a node is opted into the smoothing pool
+func GetSmoothingPoolRegistrationState(rp *rocketpool.RocketPool, nodeAddress common.Address, opts *bind.CallOpts) (bool, error) {
+	rocketNodeManager, err := rp.GetContract("rocketNodeManager")
+	if err != nil {
+		return false, err
+	}
+	state := new(bool)
+	if err := rocketNodeManager.Call(opts, state, "getSmoothingPoolRegistrationState", nodeAddress); err != nil {
+		return false, fmt.Errorf("Could not get the smoothing pool registration state of node %s: %w", nodeAddress.Hex(), err)
+	}
+	return *state, nil
+}
+
+// Get the time a node last opted into or out of the smoothing pool
+func GetSmoothingPoolRegistrationChanged(rp *rocketpool.RocketPool, nodeAddress common.Address, opts *bind.CallOpts) (time.Time, error) {
+	rocketNodeManager, err := rp.GetContract("rocketNodeManager")
+	if err != nil {
+		return time.Time{}, err
+	}
+	changed := new(*big.Int)
+	if err := rocketNodeManager.Call(opts, changed, "getSmoothingPoolRegistrationChanged", nodeAddress); err != nil {
+		return time.Time{}, fmt.Errorf("Could not get the smoothing pool registration change time of node %s: %w", nodeAddress.Hex(), err)
+	}
+	return time.Unix((*changed).Int64(), 0), nil
+}
+
+// Estimate the gas of opting into or out of the smoothing pool
+func EstimateSetSmoothingPoolRegistrationStateGas(rp *rocketpool.RocketPool, state bool, opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+	rocketNodeManager, err := rp.GetContract("rocketNodeManager")
+	if err != nil {
+		return rocketpool.GasInfo{}, err
+	}
+	return rocketNodeManager.GetTransactionGasInfo(opts, "setSmoothingPoolRegistrationState", state)
+}
+
+// Opt into or out of the smoothing pool
+func SetSmoothingPoolRegistrationState(rp *rocketpool.RocketPool, state bool, opts *bind.TransactOpts) (common.Hash, error) {
+	rocketNodeManager, err := rp.GetContract("rocketNodeManager")
+	if err != nil {
+		return common.Hash{}, err
+	}
+	hash, err := rocketNodeManager.Transact(opts, "setSmoothingPoolRegistrationState", state)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("Could not set the smoothing pool registration state: %w", err)
+	}
+	return hash, nil
+}
+
+// Get the penalty rate applied to a minipool's rewards for breaking the fee recipient rules, as a fraction of 1 ETH
+func GetMinipoolPenaltyRate(rp *rocketpool.RocketPool, minipoolAddress common.Address, opts *bind.CallOpts) (*big.Int, error) {
+	rocketMinipoolPenalty, err := rp.GetContract("rocketMinipoolPenalty")
+	if err != nil {
+		return nil, err
+	}
+	rate := new(*big.Int)
+	if err := rocketMinipoolPenalty.Call(opts, rate, "getPenaltyRate", minipoolAddress); err != nil {
+		return nil, fmt.Errorf("Could not get the penalty rate of minipool %s: %w", minipoolAddress.Hex(), err)
+	}
+	return *rate, nil
+}
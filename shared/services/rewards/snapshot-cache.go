@@ -0,0 +1,236 @@
+package rewards
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/klauspost/compress/zstd"
+	"github.com/rocket-pool/rocketpool-go/dao/trustednode"
+	rprewards "github.com/rocket-pool/rocketpool-go/rewards"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+)
+
+// The version of the interval snapshot format; snapshots with any other version are fetched again
+const intervalSnapshotVersion int = 3
+
+// The chain state an interval's rewards tree is generated from.
+// Fetching it means reading every node and minipool at the interval's snapshot block, and every committee and block of the interval from the Beacon chain,
+// so it's cached on disk to make re-running the generation (e.g. to verify a tree or dispute one) quick.
+type intervalSnapshot struct {
+	Version              int                 `json:"version"`
+	Network              string              `json:"network"`
+	Index                uint64              `json:"index"`
+	ExecutionStartBlock  uint64              `json:"executionStartBlock"`
+	ExecutionEndBlock    uint64              `json:"executionEndBlock"`
+	ConsensusStartSlot   uint64              `json:"consensusStartSlot"`
+	ConsensusEndSlot     uint64              `json:"consensusEndSlot"`
+	SnapshotTime         time.Time           `json:"snapshotTime"`
+	IntervalDuration     time.Duration       `json:"intervalDuration"`
+	PendingRpl           *big.Int            `json:"pendingRpl"`
+	NodeOperatorPercent  *big.Int            `json:"nodeOperatorPercent"`
+	OracleDaoPercent     *big.Int            `json:"oracleDaoPercent"`
+	SmoothingPoolBalance *big.Int            `json:"smoothingPoolBalance"`
+	Nodes                []generatorNodeInfo `json:"nodes"`
+	OracleDaoMembers     []common.Address    `json:"oracleDaoMembers"`
+	OracleDaoJoinTimes   []time.Time         `json:"oracleDaoJoinTimes"`
+
+	// The attestation duties and results of the smoothing pool's minipools, which are only fetched if the pool has a balance to split
+	AttestationPerformance *attestationPerformance `json:"attestationPerformance,omitempty"`
+}
+
+// Get the chain state for an interval, from the cache folder if it's been fetched before.
+// Nothing is cached if the folder is blank.
+func getIntervalSnapshot(rp *rocketpool.RocketPool, network string, event RewardsEvent, previous *RewardsEvent, cacheFolder string) (*intervalSnapshot, error) {
+
+	var startBlock uint64
+	var startSlot uint64
+	if previous != nil {
+		startBlock = previous.Submission.ExecutionBlock.Uint64()
+		startSlot = previous.Submission.ConsensusBlock.Uint64() + 1
+	}
+	endBlock := event.Submission.ExecutionBlock.Uint64()
+	endSlot := event.Submission.ConsensusBlock.Uint64()
+
+	// Use the cached snapshot if it was taken at the same blocks
+	if cacheFolder != "" {
+		snapshot, err := loadIntervalSnapshot(cacheFolder, network, event.Index)
+		if err == nil && snapshot != nil &&
+			snapshot.Version == intervalSnapshotVersion &&
+			snapshot.ExecutionStartBlock == startBlock &&
+			snapshot.ExecutionEndBlock == endBlock &&
+			snapshot.ConsensusStartSlot == startSlot &&
+			snapshot.ConsensusEndSlot == endSlot {
+			return snapshot, nil
+		}
+	}
+
+	// Get the state at the snapshot block
+	opts := &bind.CallOpts{BlockNumber: event.Submission.ExecutionBlock}
+	snapshot := &intervalSnapshot{
+		Version:             intervalSnapshotVersion,
+		Network:             network,
+		Index:               event.Index,
+		ExecutionStartBlock: startBlock,
+		ExecutionEndBlock:   endBlock,
+		ConsensusStartSlot:  startSlot,
+		ConsensusEndSlot:    endSlot,
+	}
+	header, err := rp.Client.HeaderByNumber(context.Background(), event.Submission.ExecutionBlock)
+	if err != nil {
+		return nil, fmt.Errorf("Could not get the header of snapshot block %d: %w", endBlock, err)
+	}
+	snapshot.SnapshotTime = time.Unix(int64(header.Time), 0)
+
+	var wg errgroup.Group
+	wg.Go(func() error {
+		var err error
+		snapshot.IntervalDuration, err = rprewards.GetClaimIntervalTime(rp, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		snapshot.PendingRpl, err = getPendingRplRewards(rp, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		snapshot.NodeOperatorPercent, err = getClaimingContractPercent(rp, "rocketClaimNode", opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		snapshot.OracleDaoPercent, err = getClaimingContractPercent(rp, "rocketClaimTrustedNode", opts)
+		return err
+	})
+	wg.Go(func() error {
+		rocketSmoothingPool, err := rp.GetContract("rocketSmoothingPool")
+		if err != nil {
+			return err
+		}
+		snapshot.SmoothingPoolBalance, err = rp.Client.BalanceAt(context.Background(), *rocketSmoothingPool.Address, event.Submission.ExecutionBlock)
+		if err != nil {
+			return fmt.Errorf("Could not get the smoothing pool balance: %w", err)
+		}
+		return nil
+	})
+	if err := wg.Wait(); err != nil {
+		return nil, err
+	}
+	if snapshot.IntervalDuration <= 0 {
+		return nil, fmt.Errorf("The rewards interval time at block %d is %s", endBlock, snapshot.IntervalDuration)
+	}
+
+	snapshot.Nodes, err = getGeneratorNodeInfo(rp, event, opts)
+	if err != nil {
+		return nil, err
+	}
+	snapshot.OracleDaoMembers, err = trustednode.GetMemberAddresses(rp, opts)
+	if err != nil {
+		return nil, fmt.Errorf("Could not get the Oracle DAO members: %w", err)
+	}
+	snapshot.OracleDaoJoinTimes = make([]time.Time, len(snapshot.OracleDaoMembers))
+	for i, member := range snapshot.OracleDaoMembers {
+		joined, err := trustednode.GetMemberJoinedTime(rp, member, opts)
+		if err != nil {
+			return nil, fmt.Errorf("Could not get the join time of Oracle DAO member %s: %w", member.Hex(), err)
+		}
+		snapshot.OracleDaoJoinTimes[i] = time.Unix(int64(joined), 0)
+	}
+
+	// Cache it; the tree can still be generated if this fails
+	if cacheFolder != "" {
+		_ = saveIntervalSnapshot(cacheFolder, snapshot)
+	}
+	return snapshot, nil
+
+}
+
+// Get the attestation performance of the smoothing pool's minipools during an interval, from its snapshot if it's been fetched before.
+// Fetching it scans every committee and block of the interval, so it's added to the cached snapshot.
+func getIntervalAttestationPerformance(bc beacon.Client, event RewardsEvent, snapshot *intervalSnapshot, cacheFolder string) (*attestationPerformance, error) {
+
+	if snapshot.AttestationPerformance != nil {
+		return snapshot.AttestationPerformance, nil
+	}
+	performance, err := getAttestationPerformance(bc, event, snapshot.ConsensusStartSlot, snapshot.ConsensusEndSlot, snapshot.Nodes)
+	if err != nil {
+		return nil, err
+	}
+	snapshot.AttestationPerformance = performance
+
+	// Cache it; the tree can still be generated if this fails
+	if cacheFolder != "" {
+		_ = saveIntervalSnapshot(cacheFolder, snapshot)
+	}
+	return performance, nil
+
+}
+
+// Get the path of the cached chain state for an interval
+func GetIntervalSnapshotPath(folder string, network string, index uint64) string {
+	return filepath.Join(folder, fmt.Sprintf("rp-snapshot-%s-%d%s", network, index, compressedExtension))
+}
+
+// Load the cached chain state for an interval; returns nil if it hasn't been cached
+func loadIntervalSnapshot(folder string, network string, index uint64) (*intervalSnapshot, error) {
+	path := GetIntervalSnapshotPath(folder, network, index)
+	compressed, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading interval snapshot %s: %w", path, err)
+	}
+	decoder, err := zstd.NewReader(nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating zstd decoder: %w", err)
+	}
+	snapshotBytes, err := decoder.DecodeAll(compressed, nil)
+	decoder.Close()
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing interval snapshot %s: %w", path, err)
+	}
+	snapshot := new(intervalSnapshot)
+	if err := json.Unmarshal(snapshotBytes, snapshot); err != nil {
+		return nil, fmt.Errorf("error decoding interval snapshot %s: %w", path, err)
+	}
+	return snapshot, nil
+}
+
+// Save the chain state for an interval to the cache folder, compressed with zstd
+func saveIntervalSnapshot(folder string, snapshot *intervalSnapshot) error {
+	snapshotBytes, err := json.Marshal(snapshot)
+	if err != nil {
+		return fmt.Errorf("error serializing interval snapshot %d: %w", snapshot.Index, err)
+	}
+	encoder, err := zstd.NewWriter(nil)
+	if err != nil {
+		return fmt.Errorf("error creating zstd encoder: %w", err)
+	}
+	compressed := encoder.EncodeAll(snapshotBytes, nil)
+	encoder.Close()
+
+	if err := os.MkdirAll(folder, 0755); err != nil {
+		return fmt.Errorf("error creating interval snapshot folder: %w", err)
+	}
+	path := GetIntervalSnapshotPath(folder, snapshot.Network, snapshot.Index)
+	tempPath := path + ".tmp"
+	if err := ioutil.WriteFile(tempPath, compressed, fileMode); err != nil {
+		return fmt.Errorf("error writing interval snapshot %s: %w", tempPath, err)
+	}
+	if err := os.Rename(tempPath, path); err != nil {
+		return fmt.Errorf("error saving interval snapshot %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package rewards
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+)
+
+// A Beacon client that can't be reached
+type unreachableBeaconClient struct {
+	beacon.Client
+}
+
+func (c *unreachableBeaconClient) GetEth2Config() (beacon.Eth2Config, error) {
+	return beacon.Eth2Config{}, errors.New("the Beacon client can't be reached")
+}
+
+func TestAttestationPerformanceIsCachedWithTheSnapshot(t *testing.T) {
+	folder, err := ioutil.TempDir("", "rp-snapshot")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(folder)
+
+	event, nodes, bc := newTestAttestationInterval()
+	event.Index = 3
+	snapshot := &intervalSnapshot{
+		Version:            intervalSnapshotVersion,
+		Network:            "mainnet",
+		Index:              event.Index,
+		ConsensusStartSlot: 4,
+		ConsensusEndSlot:   11,
+		Nodes:              nodes,
+	}
+
+	// The first run fetches the duties and attestations and caches them
+	performance, err := getIntervalAttestationPerformance(bc, event, snapshot, folder)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkTestAttestationPerformance(t, performance)
+
+	// Later runs load them from the cache without the Beacon client
+	cached, err := loadIntervalSnapshot(folder, "mainnet", event.Index)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cached == nil || cached.AttestationPerformance == nil {
+		t.Fatal("expected the attestation performance to be cached")
+	}
+	performance, err = getIntervalAttestationPerformance(&unreachableBeaconClient{}, event, cached, folder)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkTestAttestationPerformance(t, performance)
+
+	// The cache is kept per network and interval
+	for _, other := range []struct {
+		network string
+		index   uint64
+	}{{"prater", event.Index}, {"mainnet", event.Index + 1}} {
+		snapshot, err := loadIntervalSnapshot(folder, other.network, other.index)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if snapshot != nil {
+			t.Fatalf("expected no snapshot for interval %d on %s", other.index, other.network)
+		}
+	}
+}
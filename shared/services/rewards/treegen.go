@@ -0,0 +1,77 @@
+package rewards
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// The state of a rewards tree generation run
+type TreegenState string
+
+const (
+	TreegenState_Running TreegenState = "running"
+	TreegenState_Done    TreegenState = "done"
+	TreegenState_Failed  TreegenState = "failed"
+)
+
+// The status of the rewards tree worker's run for an interval.
+// The worker writes it to the data folder so the watchtower can see how it went once the worker's container has exited.
+type TreegenStatus struct {
+	Index         uint64       `json:"index"`
+	Network       string       `json:"network"`
+	State         TreegenState `json:"state"`
+	StartTime     time.Time    `json:"startTime"`
+	EndTime       time.Time    `json:"endTime,omitempty"`
+	NodeCount     int          `json:"nodeCount,omitempty"`
+	MerkleRoot    string       `json:"merkleRoot,omitempty"`
+	CanonicalRoot string       `json:"canonicalRoot,omitempty"`
+	RootMatches   bool         `json:"rootMatches"`
+	Error         string       `json:"error,omitempty"`
+}
+
+// Get the path of the rewards tree worker's status file for an interval
+func GetTreegenStatusPath(folder string, network string, index uint64) string {
+	return filepath.Join(folder, fmt.Sprintf("treegen-%s-%d.json", network, index))
+}
+
+// Load the rewards tree worker's status for an interval; returns nil if the worker hasn't run for it
+func LoadTreegenStatus(folder string, network string, index uint64) (*TreegenStatus, error) {
+	path := GetTreegenStatusPath(folder, network, index)
+	bytes, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading rewards tree worker status %s: %w", path, err)
+	}
+	status := new(TreegenStatus)
+	if err := json.Unmarshal(bytes, status); err != nil {
+		return nil, fmt.Errorf("error decoding rewards tree worker status %s: %w", path, err)
+	}
+	return status, nil
+}
+
+// Save the rewards tree worker's status for an interval.
+// It's written to a temporary file first so the watchtower never reads a partial one.
+func SaveTreegenStatus(folder string, status TreegenStatus) error {
+	bytes, err := json.MarshalIndent(status, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error serializing rewards tree worker status for interval %d: %w", status.Index, err)
+	}
+	if err := os.MkdirAll(folder, 0755); err != nil {
+		return fmt.Errorf("error creating rewards tree worker status folder: %w", err)
+	}
+	path := GetTreegenStatusPath(folder, status.Network, status.Index)
+	tempPath := path + ".tmp"
+	if err := ioutil.WriteFile(tempPath, bytes, fileMode); err != nil {
+		return fmt.Errorf("error writing rewards tree worker status %s: %w", tempPath, err)
+	}
+	if err := os.Rename(tempPath, path); err != nil {
+		return fmt.Errorf("error saving rewards tree worker status %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package rewards
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// The rewards tree file format version this package reads and writes
+const RewardsFileVersion uint64 = 1
+
+// A big integer that is serialized as a quoted decimal string, since JSON numbers can't hold Wei amounts safely
+type QuotedBigInt struct {
+	big.Int
+}
+
+// Create a new quoted big integer
+func NewQuotedBigInt(x int64) *QuotedBigInt {
+	q := QuotedBigInt{}
+	q.SetInt64(x)
+	return &q
+}
+
+func (b *QuotedBigInt) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(b.String())), nil
+}
+
+func (b *QuotedBigInt) UnmarshalJSON(p []byte) error {
+	var value string
+	if err := json.Unmarshal(p, &value); err != nil {
+		return fmt.Errorf("error decoding quoted big integer: %w", err)
+	}
+	if _, success := b.SetString(value, 10); !success {
+		return fmt.Errorf("%s is not a valid integer", value)
+	}
+	return nil
+}
+
+// The totals of the rewards distributed by an interval
+type TotalRewards struct {
+	ProtocolDaoRpl               *QuotedBigInt `json:"protocolDaoRpl"`
+	TotalCollateralRpl           *QuotedBigInt `json:"totalCollateralRpl"`
+	TotalOracleDaoRpl            *QuotedBigInt `json:"totalOracleDaoRpl"`
+	TotalSmoothingPoolEth        *QuotedBigInt `json:"totalSmoothingPoolEth"`
+	PoolStakerSmoothingPoolEth   *QuotedBigInt `json:"poolStakerSmoothingPoolEth"`
+	NodeOperatorSmoothingPoolEth *QuotedBigInt `json:"nodeOperatorSmoothingPoolEth"`
+}
+
+// The rewards for a single node in an interval
+type NodeRewardsInfo struct {
+	RewardNetwork    uint64        `json:"rewardNetwork"`
+	CollateralRpl    *QuotedBigInt `json:"collateralRpl"`
+	OracleDaoRpl     *QuotedBigInt `json:"oracleDaoRpl"`
+	SmoothingPoolEth *QuotedBigInt `json:"smoothingPoolEth"`
+	MerkleProof      []string      `json:"merkleProof"`
+}
+
+// The rewards distributed on a single network (Mainnet or a layer 2) in an interval
+type NetworkRewardsInfo struct {
+	CollateralRpl    *QuotedBigInt `json:"collateralRpl"`
+	OracleDaoRpl     *QuotedBigInt `json:"oracleDaoRpl"`
+	SmoothingPoolEth *QuotedBigInt `json:"smoothingPoolEth"`
+}
+
+// A rewards tree file, which holds the rewards of every node for an interval and the Merkle proofs to claim them with
+type RewardsFile struct {
+	RewardsFileVersion         uint64                              `json:"rewardsFileVersion"`
+	Index                      uint64                              `json:"index"`
+	Network                    string                              `json:"network"`
+	StartTime                  time.Time                           `json:"startTime,omitempty"`
+	EndTime                    time.Time                           `json:"endTime,omitempty"`
+	ConsensusStartBlock        uint64                              `json:"consensusStartBlock,omitempty"`
+	ConsensusEndBlock          uint64                              `json:"consensusEndBlock"`
+	ExecutionStartBlock        uint64                              `json:"executionStartBlock,omitempty"`
+	ExecutionEndBlock          uint64                              `json:"executionEndBlock"`
+	IntervalsPassed            uint64                              `json:"intervalsPassed"`
+	MerkleRoot                 string                              `json:"merkleRoot,omitempty"`
+	MinipoolPerformanceFileCID string                              `json:"minipoolPerformanceFileCid,omitempty"`
+	TotalRewards               *TotalRewards                       `json:"totalRewards"`
+	NetworkRewards             map[uint64]*NetworkRewardsInfo      `json:"networkRewards"`
+	NodeRewards                map[common.Address]*NodeRewardsInfo `json:"nodeRewards"`
+}
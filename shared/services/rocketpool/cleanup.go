@@ -0,0 +1,196 @@
+package rocketpool
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/alessio/shellescape"
+	"github.com/dustin/go-humanize"
+)
+
+// A Docker container, volume or image that the Rocket Pool service no longer uses
+type DockerResource struct {
+	ID     string
+	Name   string
+	Size   uint64
+	Reason string
+}
+
+// The Docker resources left behind by client switches and upgrades
+type ServiceCleanup struct {
+	Containers []DockerResource
+	Volumes    []DockerResource
+	Images     []DockerResource
+}
+
+// Get the total disk space the resources use
+func (s ServiceCleanup) GetTotalSize() uint64 {
+	total := uint64(0)
+	for _, resources := range [][]DockerResource{s.Containers, s.Volumes, s.Images} {
+		for _, resource := range resources {
+			total += resource.Size
+		}
+	}
+	return total
+}
+
+// Find the stopped containers, dangling volumes and old images of the Rocket Pool service that are no longer used.
+// Only resources belonging to the service's Docker project, or images of the repositories it uses, are included.
+func (c *Client) GetServiceCleanup(composeFiles []string, projectName string) (ServiceCleanup, error) {
+
+	cleanup := ServiceCleanup{
+		Containers: []DockerResource{},
+		Volumes:    []DockerResource{},
+		Images:     []DockerResource{},
+	}
+	projectFilter := shellescape.Quote(fmt.Sprintf("label=com.docker.compose.project=%s", projectName))
+
+	// Get the services and the repositories of their images
+	composeCfg, err := c.getComposeConfig(composeFiles)
+	if err != nil {
+		return ServiceCleanup{}, err
+	}
+	configuredImages := map[string]bool{}
+	repositories := map[string]bool{}
+	for _, service := range composeCfg.Services {
+		configuredImages[service.Image] = true
+		repositories[getImageRepository(service.Image)] = true
+	}
+
+	// Find stopped containers of services that are no longer part of the configuration
+	containers, err := c.readOutput(fmt.Sprintf("docker ps -a --no-trunc --filter %s --format '{{.ID}}\t{{.Names}}\t{{.State}}\t{{.Label \"com.docker.compose.service\"}}\t{{.Image}}'", projectFilter))
+	if err != nil {
+		return ServiceCleanup{}, fmt.Errorf("Error listing containers: %w", err)
+	}
+	usedImages := map[string]bool{}
+	for _, line := range strings.Split(strings.TrimSpace(string(containers)), "\n") {
+		fields := strings.Split(line, "\t")
+		if len(fields) != 5 {
+			continue
+		}
+		id, name, state, service, image := fields[0], fields[1], fields[2], fields[3], fields[4]
+		repositories[getImageRepository(image)] = true
+		if _, exists := composeCfg.Services[service]; exists || state == "running" {
+			usedImages[image] = true
+			continue
+		}
+		cleanup.Containers = append(cleanup.Containers, DockerResource{
+			ID:     id,
+			Name:   name,
+			Reason: fmt.Sprintf("%s, and its service is no longer configured", state),
+		})
+	}
+
+	// Get the IDs of the images every remaining container uses, including ones outside the project
+	removedContainers := map[string]bool{}
+	for _, container := range cleanup.Containers {
+		removedContainers[container.ID] = true
+	}
+	containerImages, err := c.readOutput("docker ps -aq --no-trunc | xargs -r docker inspect --format='{{.Id}} {{.Image}}'")
+	if err != nil {
+		return ServiceCleanup{}, fmt.Errorf("Error getting the images of containers: %w", err)
+	}
+	usedImageIDs := map[string]bool{}
+	for _, line := range strings.Split(strings.TrimSpace(string(containerImages)), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 2 && !removedContainers[fields[0]] {
+			usedImageIDs[fields[1]] = true
+		}
+	}
+
+	// Find dangling volumes
+	volumes, err := c.readOutput(fmt.Sprintf("docker volume ls -q --filter dangling=true --filter %s", projectFilter))
+	if err != nil {
+		return ServiceCleanup{}, fmt.Errorf("Error listing volumes: %w", err)
+	}
+	volumeSizes, err := c.getVolumeSizes()
+	if err != nil {
+		return ServiceCleanup{}, err
+	}
+	for _, volume := range strings.Fields(string(volumes)) {
+		cleanup.Volumes = append(cleanup.Volumes, DockerResource{
+			ID:     volume,
+			Name:   volume,
+			Size:   volumeSizes[volume],
+			Reason: "not used by any container",
+		})
+	}
+
+	// Find images of the service's repositories that no container uses and that aren't configured
+	images, err := c.readOutput("docker images --no-trunc --format '{{.ID}}\t{{.Repository}}\t{{.Tag}}\t{{.Size}}'")
+	if err != nil {
+		return ServiceCleanup{}, fmt.Errorf("Error listing images: %w", err)
+	}
+	for _, line := range strings.Split(strings.TrimSpace(string(images)), "\n") {
+		fields := strings.Split(line, "\t")
+		if len(fields) != 4 {
+			continue
+		}
+		id, repository, tag, size := fields[0], fields[1], fields[2], fields[3]
+		if !repositories[repository] || usedImageIDs[id] {
+			continue
+		}
+		name := fmt.Sprintf("%s:%s", repository, tag)
+		if configuredImages[name] || usedImages[name] {
+			continue
+		}
+		reason := "not used by any container"
+		if tag == "<none>" {
+			name = fmt.Sprintf("%s (untagged)", repository)
+			reason = "replaced by a newer pull of the same tag"
+		}
+		sizeBytes, _ := humanize.ParseBytes(size)
+		cleanup.Images = append(cleanup.Images, DockerResource{
+			ID:     id,
+			Name:   name,
+			Size:   sizeBytes,
+			Reason: reason,
+		})
+	}
+
+	return cleanup, nil
+
+}
+
+// Removes an image
+func (c *Client) RemoveImage(image string) (string, error) {
+
+	cmd := fmt.Sprintf("docker rmi %s", shellescape.Quote(image))
+	output, err := c.readOutput(cmd)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(output)), nil
+
+}
+
+// Get the disk usage of every volume
+func (c *Client) getVolumeSizes() (map[string]uint64, error) {
+	output, err := c.readOutput("docker system df -v --format='{{range .Volumes}}{{.Name}}\t{{.Size}}\n{{end}}'")
+	if err != nil {
+		return nil, fmt.Errorf("Error getting volume sizes: %w", err)
+	}
+	sizes := map[string]uint64{}
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		fields := strings.Split(line, "\t")
+		if len(fields) != 2 {
+			continue
+		}
+		size, err := humanize.ParseBytes(fields[1])
+		if err == nil {
+			sizes[fields[0]] = size
+		}
+	}
+	return sizes, nil
+}
+
+// Get the repository of an image name, without its tag or digest
+func getImageRepository(image string) string {
+	if index := strings.Index(image, "@"); index >= 0 {
+		image = image[:index]
+	}
+	if index := strings.LastIndex(image, ":"); index > strings.LastIndex(image, "/") {
+		image = image[:index]
+	}
+	return image
+}
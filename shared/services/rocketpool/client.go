@@ -11,15 +11,17 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
 	"time"
 
-	"github.com/a8m/envsubst"
 	"github.com/fatih/color"
 	"github.com/urfave/cli"
 	"golang.org/x/crypto/ssh"
+	"gopkg.in/yaml.v2"
 
 	"github.com/alessio/shellescape"
 	"github.com/blang/semver/v4"
@@ -38,11 +40,41 @@ const (
 	LegacyBackupFolder       string = "old_config_backup"
 	SettingsFile             string = "user-settings.yml"
 	BackupSettingsFile       string = "user-settings-backup.yml"
+	AppliedSettingsFile      string = "user-settings-applied.yml"
 	LegacyConfigFile         string = "config.yml"
 	LegacySettingsFile       string = "settings.yml"
 	PrometheusConfigTemplate string = "prometheus.tmpl"
 	PrometheusFile           string = "prometheus.yml"
 
+	ProfilesDir string = "profiles"
+
+	// The script the snapshot loader runs: it clears the chain data, then downloads and unpacks the snapshot while hashing it
+	ecSnapshotLoaderScript string = `set -euo pipefail
+apk add --no-cache curl tar zstd lz4 > /dev/null
+echo "Removing the existing chain data..."
+find /ethclient -mindepth 1 -delete
+mkfifo /tmp/snapshot
+sha256sum < /tmp/snapshot > /tmp/snapshot.sha256 &
+hasher=$!
+( while sleep 60; do echo "Unpacked $(du -sh /ethclient | cut -f 1) so far..."; done ) &
+reporter=$!
+trap 'echo "Removing the partially unpacked data..." >&2; find /ethclient -mindepth 1 -delete' EXIT
+echo "Downloading and unpacking the snapshot..."
+curl -fsSL --retry 5 "$SNAPSHOT_URL" | tee /tmp/snapshot | tar -x $TAR_FLAGS -C /ethclient
+wait $hasher
+kill $reporter
+actual=$(cut -d ' ' -f 1 /tmp/snapshot.sha256)
+if [ "$actual" != "$SNAPSHOT_SHA256" ]; then
+	echo "The snapshot's checksum is $actual, but it should be $SNAPSHOT_SHA256." >&2
+	exit 1
+fi
+trap - EXIT
+echo "The snapshot's checksum matches."`
+
+	// New instances move all of their ports by a multiple of this so they don't collide with the existing ones
+	instancePortOffset   uint16 = 100
+	maxInstancePortSteps uint16 = 50
+
 	APIContainerSuffix string = "_api"
 	APIBinPath         string = "/go/bin/rocketpool"
 
@@ -50,8 +82,9 @@ const (
 	overrideDir  string = "override"
 	runtimeDir   string = "runtime"
 
-	templateSuffix    string = ".tmpl"
-	composeFileSuffix string = ".yml"
+	templateSuffix     string = ".tmpl"
+	composeFileSuffix  string = ".yml"
+	fragmentFileSuffix string = ".runtime.yml"
 
 	nethermindPruneStarterCommand string = "dotnet /setup/NethermindPruneStarter/NethermindPruneStarter.dll"
 	nethermindAdminUrl            string = "http://127.0.0.1:7434"
@@ -74,17 +107,46 @@ type Client struct {
 	debugPrint         bool
 	ignoreSyncCheck    bool
 	forceFallbackEc    bool
+	profile            string
+	nodeAddress        string
 }
 
+// Profile names are used in paths and Docker project names, so they're restricted to characters that are safe in both
+var profileNamePattern = regexp.MustCompile("^[a-z0-9][a-z0-9_-]*$")
+
 // Create new Rocket Pool client from CLI context
 func NewClientFromCtx(c *cli.Context) (*Client, error) {
-	return NewClient(c.GlobalString("config-path"),
+	client, err := NewClient(c.GlobalString("config-path"),
 		c.GlobalString("daemon-path"),
 		c.GlobalFloat64("maxFee"),
 		c.GlobalFloat64("maxPrioFee"),
 		c.GlobalUint64("gasLimit"),
 		c.GlobalString("nonce"),
 		c.GlobalBool("debug"))
+	if err != nil {
+		return nil, err
+	}
+	client.profile = c.GlobalString("profile")
+	client.nodeAddress = c.GlobalString("node-address")
+	return client, nil
+}
+
+// Get the config path of a profile, which is kept in the profiles folder of the base config path
+func GetProfileConfigPath(configPath string, profile string) (string, error) {
+	if !profileNamePattern.MatchString(profile) {
+		return "", fmt.Errorf("Invalid profile name '%s'; profile names can only contain lowercase letters, numbers, dashes and underscores.", profile)
+	}
+	return filepath.Join(configPath, ProfilesDir, profile), nil
+}
+
+// Get the default Docker project name for a profile, so its containers don't collide with the ones of other profiles
+func GetProfileProjectName(profile string) string {
+	return fmt.Sprintf("rocketpool-%s", profile)
+}
+
+// Get the profile the client is using, or an empty string for the default configuration
+func (c *Client) GetProfile() string {
+	return c.profile
 }
 
 // Create new Rocket Pool client
@@ -145,11 +207,69 @@ func (c *Client) LoadConfig() (*config.RocketPoolConfig, bool, error) {
 	isNew := false
 	if cfg == nil {
 		cfg = config.NewRocketPoolConfig(c.configPath, c.daemonPath != "")
+		c.avoidOtherInstances(cfg)
 		isNew = true
 	}
 	return cfg, isNew, nil
 }
 
+// Give a new configuration a Docker project name and ports that don't collide with the ones used by the other Smartnode instances on this machine,
+// so they can all run side by side
+func (c *Client) avoidOtherInstances(cfg *config.RocketPoolConfig) {
+
+	// Get the project names and ports the other instances use
+	others := c.getOtherInstances()
+	takenNames := map[string]bool{}
+	usedPorts := map[uint16]bool{}
+	for _, other := range others {
+		takenNames[other.cfg.Smartnode.GetProjectName()] = true
+		for port := range other.cfg.GetPorts() {
+			usedPorts[port] = true
+		}
+	}
+
+	// Name the project after the instance
+	path, err := getInstancePath(c.configPath)
+	defaultPath, defaultErr := getInstancePath(DefaultConfigPath)
+	isDefault := err == nil && defaultErr == nil && path == defaultPath
+	cfg.Smartnode.ProjectName.Value = getInstanceProjectName(c.configPath, isDefault, c.profile, takenNames)
+	if len(usedPorts) == 0 {
+		return
+	}
+
+	// Find the smallest offset that doesn't collide with any of them
+	ports := cfg.GetPorts()
+	for step := uint16(0); step <= maxInstancePortSteps; step++ {
+		offset := step * instancePortOffset
+		collides := false
+		for port := range ports {
+			if usedPorts[port+offset] {
+				collides = true
+				break
+			}
+		}
+		if !collides {
+			cfg.OffsetPorts(offset)
+			return
+		}
+	}
+
+}
+
+// Get the time the settings file was last saved
+func (c *Client) GetConfigSaveTime() (time.Time, error) {
+	settingsFilePath := filepath.Join(c.configPath, SettingsFile)
+	expandedPath, err := homedir.Expand(settingsFilePath)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("error expanding settings file path: %w", err)
+	}
+	info, err := os.Stat(expandedPath)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return info.ModTime(), nil
+}
+
 // Load the backup config
 func (c *Client) LoadBackupConfig() (*config.RocketPoolConfig, error) {
 	settingsFilePath := filepath.Join(c.configPath, BackupSettingsFile)
@@ -161,6 +281,27 @@ func (c *Client) LoadBackupConfig() (*config.RocketPoolConfig, error) {
 	return rp.LoadConfigFromFile(expandedPath)
 }
 
+// Load the config the service was last started with
+func (c *Client) LoadAppliedConfig() (*config.RocketPoolConfig, error) {
+	settingsFilePath := filepath.Join(c.configPath, AppliedSettingsFile)
+	expandedPath, err := homedir.Expand(settingsFilePath)
+	if err != nil {
+		return nil, fmt.Errorf("error expanding applied settings file path: %w", err)
+	}
+
+	return rp.LoadConfigFromFile(expandedPath)
+}
+
+// Record the config the service was started with, so the next start knows what changed
+func (c *Client) SaveAppliedConfig(cfg *config.RocketPoolConfig) error {
+	settingsFilePath := filepath.Join(c.configPath, AppliedSettingsFile)
+	expandedPath, err := homedir.Expand(settingsFilePath)
+	if err != nil {
+		return err
+	}
+	return rp.SaveConfig(cfg, expandedPath)
+}
+
 // Save the config
 func (c *Client) SaveConfig(cfg *config.RocketPoolConfig) error {
 	settingsFilePath := filepath.Join(c.configPath, SettingsFile)
@@ -168,7 +309,15 @@ func (c *Client) SaveConfig(cfg *config.RocketPoolConfig) error {
 	if err != nil {
 		return err
 	}
-	return rp.SaveConfig(cfg, expandedPath)
+	if err := rp.SaveConfig(cfg, expandedPath); err != nil {
+		return err
+	}
+
+	// Record the instance so new ones can avoid its project name and ports
+	if err := c.registerInstance(); err != nil {
+		fmt.Printf("%sWARNING: Couldn't add this configuration to the list of Smartnode instances on this machine (%s). New instances may use the same ports as this one.%s\n", colorYellow, err.Error(), colorReset)
+	}
+	return nil
 }
 
 // Remove the upgrade flag file
@@ -219,8 +368,8 @@ func (c *Client) LoadLegacyConfigFromBackup() (*config.RocketPoolConfig, error)
 	return newCfg, nil
 }
 
-// Load the Prometheus template, do an environment variable substitution, and save it
-func (c *Client) UpdatePrometheusConfiguration(settings map[string]string) error {
+// Load the Prometheus template, render it with the config, and save it
+func (c *Client) UpdatePrometheusConfiguration(cfg *config.RocketPoolConfig) error {
 	prometheusTemplatePath, err := homedir.Expand(fmt.Sprintf("%s/%s", c.configPath, PrometheusConfigTemplate))
 	if err != nil {
 		return fmt.Errorf("Error expanding Prometheus template path: %w", err)
@@ -231,22 +380,14 @@ func (c *Client) UpdatePrometheusConfiguration(settings map[string]string) error
 		return fmt.Errorf("Error expanding Prometheus config file path: %w", err)
 	}
 
-	// Set the environment variables defined in the user settings for metrics
-	oldValues := map[string]string{}
-	for varName, varValue := range settings {
-		oldValues[varName] = os.Getenv(varName)
-		os.Setenv(varName, varValue)
-	}
-
-	// Read and substitute the template
-	contents, err := envsubst.ReadFile(prometheusTemplatePath)
+	// Render the template
+	contents, err := ioutil.ReadFile(prometheusTemplatePath)
 	if err != nil {
-		return fmt.Errorf("Error reading and substituting Prometheus configuration template: %w", err)
+		return fmt.Errorf("Error reading Prometheus configuration template: %w", err)
 	}
-
-	// Unset the env vars
-	for name, value := range oldValues {
-		os.Setenv(name, value)
+	contents, err = cfg.RenderTemplate(config.PrometheusContainerName, contents, cfg.GenerateEnvironmentVariables())
+	if err != nil {
+		return fmt.Errorf("Error rendering Prometheus configuration template: %w", err)
 	}
 
 	// Write the actual Prometheus config file
@@ -262,6 +403,61 @@ func (c *Client) UpdatePrometheusConfiguration(settings map[string]string) error
 	return nil
 }
 
+// Generates the Alertmanager config and the Prometheus alert rules, and adds them to the Prometheus config.
+// This must be called after UpdatePrometheusConfiguration.
+func (c *Client) UpdateAlertingConfiguration(cfg *config.RocketPoolConfig) error {
+	alertingPath, err := homedir.Expand(filepath.Join(c.configPath, config.AlertingFolder))
+	if err != nil {
+		return fmt.Errorf("Error expanding alerting folder path: %w", err)
+	}
+	err = os.MkdirAll(alertingPath, 0775)
+	if err != nil {
+		return fmt.Errorf("Could not create alerting folder %s: %w", shellescape.Quote(alertingPath), err)
+	}
+
+	// Write the Alertmanager config
+	alertmanagerConfig, err := cfg.GenerateAlertmanagerConfig()
+	if err != nil {
+		return err
+	}
+	alertmanagerConfigPath := filepath.Join(alertingPath, config.AlertmanagerConfigFile)
+	err = ioutil.WriteFile(alertmanagerConfigPath, alertmanagerConfig, 0644)
+	if err != nil {
+		return fmt.Errorf("Could not write Alertmanager config file to %s: %w", shellescape.Quote(alertmanagerConfigPath), err)
+	}
+
+	// Write the alert rules
+	rules, err := cfg.GenerateAlertRules()
+	if err != nil {
+		return err
+	}
+	rulesPath := filepath.Join(alertingPath, config.AlertRulesFile)
+	err = ioutil.WriteFile(rulesPath, rules, 0664)
+	if err != nil {
+		return fmt.Errorf("Could not write alert rules file to %s: %w", shellescape.Quote(rulesPath), err)
+	}
+
+	// Point Prometheus at Alertmanager and the rules
+	prometheusConfigPath, err := homedir.Expand(fmt.Sprintf("%s/%s", c.configPath, PrometheusFile))
+	if err != nil {
+		return fmt.Errorf("Error expanding Prometheus config file path: %w", err)
+	}
+	prometheusConfig, err := ioutil.ReadFile(prometheusConfigPath)
+	if err != nil {
+		return fmt.Errorf("Could not read Prometheus config file %s: %w", shellescape.Quote(prometheusConfigPath), err)
+	}
+	prometheusConfig, err = cfg.AddAlertingToPrometheusConfig(prometheusConfig)
+	if err != nil {
+		return err
+	}
+	err = ioutil.WriteFile(prometheusConfigPath, prometheusConfig, 0664)
+	if err != nil {
+		return fmt.Errorf("Could not write Prometheus config file to %s: %w", shellescape.Quote(prometheusConfigPath), err)
+	}
+
+	return nil
+}
+
 // Migrate a legacy configuration (pre-v1.3) to a modern post-v1.3 one
 func (c *Client) MigrateLegacyConfig(legacyConfigFilePath string, legacySettingsFilePath string) (*config.RocketPoolConfig, error) {
 
@@ -400,7 +596,7 @@ func (c *Client) MigrateLegacyConfig(legacyConfigFilePath string, legacySettings
 			port := cfg.Prysm.RpcPort.Value.(uint16)
 			cfg.Prysm.RpcPort.Value = uint16(port)
 			externalPrysmUrl := strings.Replace(ccProvider, fmt.Sprintf(":%d", ccPort), fmt.Sprintf(":%d", port), 1)
-			cfg.ExternalPrysm.JsonRpcUrl.Value = externalPrysmUrl
+			cfg.ExternalPrysm.GrpcUrl.Value = externalPrysmUrl
 		}
 	}
 
@@ -614,6 +810,19 @@ func (c *Client) StartService(composeFiles []string) error {
 	return c.printOutput(cmd)
 }
 
+// Start or recreate some of the Rocket Pool service's containers, leaving the others alone
+func (c *Client) StartServiceContainers(composeFiles []string, containers []string) error {
+	sanitizedContainers := make([]string, len(containers))
+	for i, container := range containers {
+		sanitizedContainers[i] = shellescape.Quote(container)
+	}
+	cmd, err := c.compose(composeFiles, fmt.Sprintf("up -d --no-deps %s", strings.Join(sanitizedContainers, " ")))
+	if err != nil {
+		return err
+	}
+	return c.printOutput(cmd)
+}
+
 // Pause the Rocket Pool service
 func (c *Client) PauseService(composeFiles []string) error {
 	cmd, err := c.compose(composeFiles, "stop")
@@ -726,6 +935,120 @@ func (c *Client) IncrementCustomNonce() {
 	c.customNonce.Add(c.customNonce, big.NewInt(1))
 }
 
+// The image a service's container is running, along with the image the service is configured to use
+type ServiceImageStatus struct {
+	Service          string
+	Container        string
+	ConfiguredImage  string
+	ContainerImage   string
+	ContainerImageID string
+	ContainerDigest  string
+	LocalImageID     string
+}
+
+// The parts of the compose config needed to find each service's image
+type composeConfig struct {
+	Services map[string]struct {
+		Image         string `yaml:"image"`
+		ContainerName string `yaml:"container_name"`
+	} `yaml:"services"`
+}
+
+// Get the images of the Rocket Pool service containers, along with the images their services are configured to use
+func (c *Client) GetServiceImageStatus(composeFiles []string, projectName string) ([]ServiceImageStatus, error) {
+
+	// Get the configured images
+	composeCfg, err := c.getComposeConfig(composeFiles)
+	if err != nil {
+		return nil, err
+	}
+	services := []string{}
+	for service := range composeCfg.Services {
+		services = append(services, service)
+	}
+	sort.Strings(services)
+
+	// Get the image each container is running
+	statuses := []ServiceImageStatus{}
+	for _, service := range services {
+		serviceCfg := composeCfg.Services[service]
+		status := ServiceImageStatus{
+			Service:         service,
+			Container:       serviceCfg.ContainerName,
+			ConfiguredImage: serviceCfg.Image,
+		}
+		if status.Container == "" {
+			status.Container = fmt.Sprintf("%s_%s", projectName, service)
+		}
+
+		// Get the container's image; it's left blank if the container doesn't exist
+		containerImage, err := c.readOutput(fmt.Sprintf("docker container inspect --format='{{.Config.Image}} {{.Image}}' %s", shellescape.Quote(status.Container)))
+		if err == nil {
+			fields := strings.Fields(string(containerImage))
+			if len(fields) == 2 {
+				status.ContainerImage = fields[0]
+				status.ContainerImageID = fields[1]
+			}
+		}
+
+		// Get the digest of the container's image
+		if status.ContainerImageID != "" {
+			repoDigests, err := c.readOutput(fmt.Sprintf("docker image inspect --format='{{join .RepoDigests \" \"}}' %s", shellescape.Quote(status.ContainerImageID)))
+			if err == nil {
+				status.ContainerDigest = getImageDigest(status.ContainerImage, strings.Fields(string(repoDigests)))
+			}
+		}
+
+		// Get the image the configured tag currently points to locally
+		if status.ConfiguredImage != "" {
+			localImageID, err := c.readOutput(fmt.Sprintf("docker image inspect --format='{{.Id}}' %s", shellescape.Quote(status.ConfiguredImage)))
+			if err == nil {
+				status.LocalImageID = strings.TrimSpace(string(localImageID))
+			}
+		}
+
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+
+}
+
+// Get the resolved compose config of the Rocket Pool service
+func (c *Client) getComposeConfig(composeFiles []string) (composeConfig, error) {
+	cmd, err := c.compose(composeFiles, "config")
+	if err != nil {
+		return composeConfig{}, err
+	}
+	configBytes, err := c.readOutput(cmd)
+	if err != nil {
+		return composeConfig{}, fmt.Errorf("Error reading the compose config: %w", err)
+	}
+	var composeCfg composeConfig
+	if err := yaml.Unmarshal(configBytes, &composeCfg); err != nil {
+		return composeConfig{}, fmt.Errorf("Error parsing the compose config: %w", err)
+	}
+	return composeCfg, nil
+}
+
+// Get the digest of an image from its repo digests, preferring the one for the image's own repository
+func getImageDigest(image string, repoDigests []string) string {
+	repository := getImageRepository(image)
+	digest := ""
+	for _, repoDigest := range repoDigests {
+		elements := strings.SplitN(repoDigest, "@", 2)
+		if len(elements) != 2 {
+			continue
+		}
+		if elements[0] == repository {
+			return elements[1]
+		}
+		if digest == "" {
+			digest = elements[1]
+		}
+	}
+	return digest
+}
+
 // Get the current Docker image used by the given container
 func (c *Client) GetDockerImage(container string) (string, error) {
 
@@ -770,6 +1093,65 @@ func (c *Client) GetDockerContainerShutdownTime(container string) (time.Time, er
 
 }
 
+// Get the time that the given container last started
+func (c *Client) GetDockerContainerStartTime(container string) (time.Time, error) {
+
+	cmd := fmt.Sprintf("docker container inspect --format={{.State.StartedAt}} %s", container)
+	startTimeBytes, err := c.readOutput(cmd)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	startTime, err := time.Parse(time.RFC3339, strings.TrimSpace(string(startTimeBytes)))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("Error parsing container %s start time [%s]: %w", container, string(startTimeBytes), err)
+	}
+
+	return startTime, nil
+
+}
+
+// Get the most recent lines of the given container's logs, including what it printed to stderr
+func (c *Client) GetContainerLogs(container string, tail uint64) ([]byte, error) {
+
+	cmd := fmt.Sprintf("docker logs --tail %d %s 2>&1", tail, container)
+	logs, err := c.readOutput(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("Error getting the logs of container %s: %w", container, err)
+	}
+	return logs, nil
+
+}
+
+// Get the most recent lines of the given container's logs like GetContainerLogs, with the time Docker received each line at the start of it.
+// If since isn't empty, only the lines logged after it (a timestamp or a duration like 1h) are returned.
+func (c *Client) GetTimestampedContainerLogs(container string, tail uint64, since string) ([]byte, error) {
+
+	sinceFlag := ""
+	if since != "" {
+		sinceFlag = fmt.Sprintf("--since %s ", shellescape.Quote(since))
+	}
+	cmd := fmt.Sprintf("docker logs --timestamps --tail %d %s%s 2>&1", tail, sinceFlag, container)
+	logs, err := c.readOutput(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("Error getting the logs of container %s: %w", container, err)
+	}
+	return logs, nil
+
+}
+
+// Run a JavaScript expression in the console of a Geth container over its IPC socket, returning what it printed
+func (c *Client) RunGethConsoleCommand(container string, ipcPath string, expression string) (string, error) {
+
+	cmd := fmt.Sprintf("docker exec %s geth attach --exec %s %s 2>&1", container, shellescape.Quote(expression), ipcPath)
+	output, err := c.readOutput(cmd)
+	if err != nil {
+		return strings.TrimSpace(string(output)), fmt.Errorf("Error running a Geth console command in container %s: %w", container, err)
+	}
+	return strings.TrimSpace(string(output)), nil
+
+}
+
 // Shut down a container
 func (c *Client) StopContainer(container string) (string, error) {
 
@@ -840,6 +1222,31 @@ func (c *Client) GetClientVolumeName(container string, volumeTarget string) (str
 	return strings.TrimSpace(string(output)), nil
 }
 
+// Gets the disk usage of each of the folders in a container matching the given pattern, in bytes
+func (c *Client) GetContainerFolderSizes(container string, pattern string) (map[string]uint64, error) {
+
+	cmd := fmt.Sprintf("docker exec %s sh -c %s", shellescape.Quote(container), shellescape.Quote(fmt.Sprintf("du -sk %s 2>/dev/null || true", pattern)))
+	output, err := c.readOutput(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	sizes := map[string]uint64{}
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		size, err := strconv.ParseUint(fields[0], 10, 64)
+		if err != nil {
+			continue
+		}
+		sizes[fields[1]] = size * 1024
+	}
+	return sizes, nil
+
+}
+
 // Gets the disk usage of the given volume
 func (c *Client) GetVolumeSize(volumeName string) (string, error) {
 
@@ -851,6 +1258,14 @@ func (c *Client) GetVolumeSize(volumeName string) (string, error) {
 	return strings.TrimSpace(string(output)), nil
 }
 
+// Import a slashing protection interchange file into a stopped Validator client's database with the client's own tool.
+// The key folder is mounted at /validators and the file at importPath, the same way the Validator client container mounts them.
+func (c *Client) RunSlashingProtectionImport(image string, entrypoint string, keychainPath string, importFile string, importPath string, args []string) error {
+	cmd := fmt.Sprintf("docker run --rm -v %s:/validators -v %s:%s:ro --entrypoint %s %s %s",
+		shellescape.Quote(keychainPath), shellescape.Quote(importFile), importPath, shellescape.Quote(entrypoint), shellescape.Quote(image), strings.Join(args, " "))
+	return c.printOutput(cmd)
+}
+
 // Runs the prune provisioner
 func (c *Client) RunPruneProvisioner(container string, volume string, image string) error {
 
@@ -880,6 +1295,57 @@ func (c *Client) RunNethermindPruneStarter(container string) error {
 	return nil
 }
 
+// Runs a watchtower task in simulation mode, printing the transactions it would submit
+func (c *Client) SimulateWatchtowerTask(task string) error {
+	var cmd string
+	if c.daemonPath == "" {
+		containerName, err := c.getAPIContainerName()
+		if err != nil {
+			return err
+		}
+		cmd = fmt.Sprintf("docker exec %s %s watchtower simulate %s", shellescape.Quote(containerName), shellescape.Quote(APIBinPath), shellescape.Quote(task))
+	} else {
+		cmd = fmt.Sprintf("%s --settings %s watchtower simulate %s",
+			c.daemonPath,
+			shellescape.Quote(fmt.Sprintf("%s/%s", c.configPath, SettingsFile)),
+			shellescape.Quote(task))
+	}
+	return c.printOutput(cmd)
+}
+
+// Replay a recorded run of a node daemon task from the task journal, printing the results to stdout
+func (c *Client) ReplayNodeTask(task string, run uint64) error {
+	var cmd string
+	if c.daemonPath == "" {
+		containerName, err := c.getAPIContainerName()
+		if err != nil {
+			return err
+		}
+		cmd = fmt.Sprintf("docker exec %s %s node replay --run %d %s", shellescape.Quote(containerName), shellescape.Quote(APIBinPath), run, shellescape.Quote(task))
+	} else {
+		cmd = fmt.Sprintf("%s --settings %s node replay --run %d %s",
+			c.daemonPath,
+			shellescape.Quote(fmt.Sprintf("%s/%s", c.configPath, SettingsFile)),
+			run,
+			shellescape.Quote(task))
+	}
+	return c.printOutput(cmd)
+}
+
+// Downloads a snapshot into the Execution client's chain data volume, replacing what's there.
+// The snapshot is unpacked while it's downloaded so it doesn't need room for two copies; if its checksum doesn't match, the data is removed.
+func (c *Client) RunEcSnapshotLoader(container string, volume string, image string, snapshotUrl string, checksum string, tarFlags string) error {
+	cmd := fmt.Sprintf("docker run --rm --name %s -v %s:/ethclient -e SNAPSHOT_URL=%s -e SNAPSHOT_SHA256=%s -e TAR_FLAGS=%s %s sh -c %s",
+		shellescape.Quote(container),
+		shellescape.Quote(volume),
+		shellescape.Quote(snapshotUrl),
+		shellescape.Quote(strings.ToLower(checksum)),
+		shellescape.Quote(tarFlags),
+		shellescape.Quote(image),
+		shellescape.Quote(ecSnapshotLoaderScript))
+	return c.printOutput(cmd)
+}
+
 // Runs the EC migrator
 func (c *Client) RunEcMigrator(container string, volume string, targetDir string, mode string, image string) error {
 	cmd := fmt.Sprintf("docker run --rm --name %s -v %s:/ethclient -v %s:/mnt/external -e EC_MIGRATE_MODE='%s' %s", container, volume, targetDir, mode, image)
@@ -1149,24 +1615,19 @@ func (c *Client) compose(composeFiles []string, args string) (string, error) {
 	}
 
 	// Check config
-	if cfg.ExecutionClientMode.Value.(config.Mode) == config.Mode_Unknown {
+	if cfg.GetExecutionClientMode() == config.Mode_Unknown {
 		return "", fmt.Errorf("You haven't selected local or external mode for your Execution (ETH1) client.\nPlease run 'rocketpool service config' before running this command.")
-	} else if cfg.ExecutionClientMode.Value.(config.Mode) == config.Mode_Local && cfg.ExecutionClient.Value.(config.ExecutionClient) == config.ExecutionClient_Unknown {
+	} else if cfg.GetExecutionClientMode() == config.Mode_Local && cfg.ExecutionClient.Value.(config.ExecutionClient) == config.ExecutionClient_Unknown {
 		return "", errors.New("No Execution (ETH1) client selected. Please run 'rocketpool service config' before running this command.")
 	}
-	if cfg.ConsensusClientMode.Value.(config.Mode) == config.Mode_Unknown {
+	if cfg.GetConsensusClientMode() == config.Mode_Unknown {
 		return "", fmt.Errorf("You haven't selected local or external mode for your Consensus (ETH2) client.\nPlease run 'rocketpool service config' before running this command.")
-	} else if cfg.ConsensusClientMode.Value.(config.Mode) == config.Mode_Local && cfg.ConsensusClient.Value.(config.ConsensusClient) == config.ConsensusClient_Unknown {
+	} else if cfg.GetConsensusClientMode() == config.Mode_Local && cfg.ConsensusClient.Value.(config.ConsensusClient) == config.ConsensusClient_Unknown {
 		return "", errors.New("No Consensus (ETH2) client selected. Please run 'rocketpool service config' before running this command.")
 	}
 
 	// Make sure the selected CC is compatible with the selected EC
-	var consensusClient config.ConsensusClient
-	if cfg.ConsensusClientMode.Value.(config.Mode) == config.Mode_Local {
-		consensusClient = cfg.ConsensusClient.Value.(config.ConsensusClient)
-	} else {
-		consensusClient = cfg.ExternalConsensusClient.Value.(config.ConsensusClient)
-	}
+	consensusClient := cfg.GetSelectedConsensusClient()
 	badClients, badFallbackClients := cfg.GetIncompatibleConsensusClients()
 	for _, badClient := range badClients {
 		if consensusClient == badClient.Value {
@@ -1179,26 +1640,8 @@ func (c *Client) compose(composeFiles []string, args string) (string, error) {
 		}
 	}
 
-	// Get the external IP address
-	var externalIP string
-	consensus := externalip.DefaultConsensus(nil, nil)
-	ip, err := consensus.ExternalIP()
-	if err != nil {
-		fmt.Println("Warning: couldn't get external IP address; if you're using Nimbus or Besu, it may have trouble finding peers:")
-		fmt.Println(err.Error())
-	} else {
-		if ip.To4() == nil {
-			fmt.Println("Warning: external IP address is v6; if you're using Nimbus or Besu, it may have trouble finding peers:")
-		}
-		externalIP = ip.String()
-	}
-
 	// Set up environment variables and deploy the template config files
-	settings := cfg.GenerateEnvironmentVariables()
-	settings["EXTERNAL_IP"] = shellescape.Quote(externalIP)
-	settings["ROCKET_POOL_VERSION"] = fmt.Sprintf("v%s", shared.RocketPoolVersion)
-
-	// Deploy the templates and run environment variable substitution on them
+	settings := c.getComposeSettings(cfg)
 	deployedContainers, err := c.deployTemplates(cfg, expandedConfigPath, settings)
 	if err != nil {
 		return "", fmt.Errorf("error deploying Docker templates: %w", err)
@@ -1221,6 +1664,76 @@ func (c *Client) compose(composeFiles []string, args string) (string, error) {
 
 }
 
+// A compose file rendered for one of the Smartnode's containers
+type ComposeFile struct {
+	Container string
+	Filename  string
+	Contents  []byte
+}
+
+// Render the compose files for the current configuration without deploying them, so they can be reviewed
+func (c *Client) PreviewComposeFiles() ([]ComposeFile, error) {
+
+	// Cancel if running in non-docker mode
+	if c.daemonPath != "" {
+		return nil, errors.New("command unavailable in Native Mode (with '--daemon-path' option specified)")
+	}
+
+	// Get the expanded config path
+	expandedConfigPath, err := homedir.Expand(c.configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	// Load config
+	cfg, isNew, err := c.LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+	if isNew {
+		return nil, fmt.Errorf("Settings file not found. Please run `rocketpool service config` to set up your Smartnode first.")
+	}
+
+	// Render the compose files
+	settings := c.getComposeSettings(cfg)
+	templatesFolder := filepath.Join(expandedConfigPath, templatesDir)
+	fragments := cfg.GenerateComposeFragments()
+	files := []ComposeFile{}
+	for _, container := range cfg.GetComposeContainers() {
+		containerFiles, err := renderContainerComposeFiles(cfg, expandedConfigPath, templatesFolder, container, settings, fragments)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, containerFiles...)
+	}
+	return files, nil
+
+}
+
+// Get the environment variables the compose files are rendered with
+func (c *Client) getComposeSettings(cfg *config.RocketPoolConfig) map[string]string {
+
+	// Get the external IP address
+	var externalIP string
+	consensus := externalip.DefaultConsensus(nil, nil)
+	ip, err := consensus.ExternalIP()
+	if err != nil {
+		fmt.Println("Warning: couldn't get external IP address; if you're using Nimbus or Besu, it may have trouble finding peers:")
+		fmt.Println(err.Error())
+	} else {
+		if ip.To4() == nil {
+			fmt.Println("Warning: external IP address is v6; if you're using Nimbus or Besu, it may have trouble finding peers:")
+		}
+		externalIP = ip.String()
+	}
+
+	settings := cfg.GenerateEnvironmentVariables()
+	settings["EXTERNAL_IP"] = shellescape.Quote(externalIP)
+	settings["ROCKET_POOL_VERSION"] = fmt.Sprintf("v%s", shared.RocketPoolVersion)
+	return settings
+
+}
+
 // Deploys all of the appropriate docker-compose template files and provisions them based on the provided configuration
 func (c *Client) deployTemplates(cfg *config.RocketPoolConfig, rocketpoolDir string, settings map[string]string) ([]string, error) {
 
@@ -1247,174 +1760,163 @@ func (c *Client) deployTemplates(cfg *config.RocketPoolConfig, rocketpoolDir str
 		return []string{}, fmt.Errorf("error creating runtime folder [%s]: %w", runtimeFolder, err)
 	}
 
-	// Set the environment variables for substitution
-	oldValues := map[string]string{}
-	for varName, varValue := range settings {
-		oldValues[varName] = os.Getenv(varName)
-		os.Setenv(varName, varValue)
+	// Write the sensitive settings that are mounted into the containers
+	err = deploySecrets(cfg, rocketpoolDir)
+	if err != nil {
+		return []string{}, err
 	}
-	defer func() {
-		// Unset the env vars
-		for name, value := range oldValues {
-			os.Setenv(name, value)
-		}
-	}()
 
-	// Read and substitute the templates
+	// Get the runtime ports and volumes for each container
+	fragments := cfg.GenerateComposeFragments()
+
+	// Render each container's compose files, followed by its override file
 	deployedContainers := []string{}
+	for _, container := range cfg.GetComposeContainers() {
+		files, err := renderContainerComposeFiles(cfg, rocketpoolDir, templatesFolder, container, settings, fragments)
+		if err != nil {
+			return []string{}, err
+		}
+		for _, file := range files {
+			composePath := filepath.Join(runtimeFolder, file.Filename)
+			err = ioutil.WriteFile(composePath, file.Contents, 0664)
+			if err != nil {
+				return []string{}, fmt.Errorf("could not write %s container file to %s: %w", container, composePath, err)
+			}
+			deployedContainers = append(deployedContainers, composePath)
+		}
 
-	// API
-	contents, err := envsubst.ReadFile(filepath.Join(templatesFolder, config.ApiContainerName+templateSuffix))
-	if err != nil {
-		return []string{}, fmt.Errorf("error reading and substituting API container template: %w", err)
-	}
-	apiComposePath := filepath.Join(runtimeFolder, config.ApiContainerName+composeFileSuffix)
-	err = ioutil.WriteFile(apiComposePath, contents, 0664)
-	if err != nil {
-		return []string{}, fmt.Errorf("could not write API container file to %s: %w", apiComposePath, err)
+		// Older installations don't have an override file for Alertmanager or Chrony, and the Validator client shards are customized through the Validator client's
+		overridePath := filepath.Join(overrideFolder, container+composeFileSuffix)
+		if _, isShard := config.GetValidatorShardFromContainerName(container); isShard {
+			continue
+		}
+		if container == config.AlertmanagerContainerName || container == config.ChronyContainerName {
+			if _, err := os.Stat(overridePath); err != nil {
+				continue
+			}
+		}
+		deployedContainers = append(deployedContainers, overridePath)
 	}
-	deployedContainers = append(deployedContainers, apiComposePath)
-	deployedContainers = append(deployedContainers, filepath.Join(overrideFolder, config.ApiContainerName+composeFileSuffix))
 
-	// Node
-	contents, err = envsubst.ReadFile(filepath.Join(templatesFolder, config.NodeContainerName+templateSuffix))
+	// Create the custom keys dir
+	customKeyDir, err := homedir.Expand(filepath.Join(cfg.Smartnode.GetDataPath(), "custom-keys"))
 	if err != nil {
-		return []string{}, fmt.Errorf("error reading and substituting node container template: %w", err)
+		fmt.Printf("%sWARNING: Couldn't expand the custom validator key directory (%s). You will not be able to recover any minipool keys you created outside of the Smartnode until you create the folder manually.%s\n", colorYellow, err.Error(), colorReset)
+		return deployedContainers, nil
 	}
-	nodeComposePath := filepath.Join(runtimeFolder, config.NodeContainerName+composeFileSuffix)
-	err = ioutil.WriteFile(nodeComposePath, contents, 0664)
+	err = os.MkdirAll(customKeyDir, 0775)
 	if err != nil {
-		return []string{}, fmt.Errorf("could not write node container file to %s: %w", nodeComposePath, err)
+		fmt.Printf("%sWARNING: Couldn't create the custom validator key directory (%s). You will not be able to recover any minipool keys you created outside of the Smartnode until you create the folder [%s] manually.%s\n", colorYellow, err.Error(), customKeyDir, colorReset)
 	}
-	deployedContainers = append(deployedContainers, nodeComposePath)
-	deployedContainers = append(deployedContainers, filepath.Join(overrideFolder, config.NodeContainerName+composeFileSuffix))
 
-	// Watchtower
-	contents, err = envsubst.ReadFile(filepath.Join(templatesFolder, config.WatchtowerContainerName+templateSuffix))
-	if err != nil {
-		return []string{}, fmt.Errorf("error reading and substituting watchtower container template: %w", err)
-	}
-	watchtowerComposePath := filepath.Join(runtimeFolder, config.WatchtowerContainerName+composeFileSuffix)
-	err = ioutil.WriteFile(watchtowerComposePath, contents, 0664)
-	if err != nil {
-		return []string{}, fmt.Errorf("could not write watchtower container file to %s: %w", watchtowerComposePath, err)
-	}
-	deployedContainers = append(deployedContainers, watchtowerComposePath)
-	deployedContainers = append(deployedContainers, filepath.Join(overrideFolder, config.WatchtowerContainerName+composeFileSuffix))
+	return deployedContainers, nil
 
-	// Validator
-	contents, err = envsubst.ReadFile(filepath.Join(templatesFolder, config.ValidatorContainerName+templateSuffix))
-	if err != nil {
-		return []string{}, fmt.Errorf("error reading and substituting validator container template: %w", err)
-	}
-	validatorComposePath := filepath.Join(runtimeFolder, config.ValidatorContainerName+composeFileSuffix)
-	err = ioutil.WriteFile(validatorComposePath, contents, 0664)
-	if err != nil {
-		return []string{}, fmt.Errorf("could not write validator container file to %s: %w", validatorComposePath, err)
-	}
-	deployedContainers = append(deployedContainers, validatorComposePath)
-	deployedContainers = append(deployedContainers, filepath.Join(overrideFolder, config.ValidatorContainerName+composeFileSuffix))
+}
 
-	// Check the EC mode to see if it needs to be deployed
-	if cfg.ExecutionClientMode.Value.(config.Mode) == config.Mode_Local {
-		contents, err = envsubst.ReadFile(filepath.Join(templatesFolder, config.Eth1ContainerName+templateSuffix))
+// Render a container's compose file from its template, along with its runtime compose fragment if it has one
+func renderContainerComposeFiles(cfg *config.RocketPoolConfig, rocketpoolDir string, templatesFolder string, container string, settings map[string]string, fragments map[string]*config.ComposeFragment) ([]ComposeFile, error) {
+
+	// Alertmanager's, Chrony's, and the Validator client shards' compose files are generated entirely from the config
+	var contents []byte
+	var err error
+	if shard, isShard := config.GetValidatorShardFromContainerName(container); isShard {
+		contents, err = cfg.GenerateValidatorShardComposeFile(shard)
 		if err != nil {
-			return []string{}, fmt.Errorf("error reading and substituting execution client container template: %w", err)
+			return nil, err
 		}
-		eth1ComposePath := filepath.Join(runtimeFolder, config.Eth1ContainerName+composeFileSuffix)
-		err = ioutil.WriteFile(eth1ComposePath, contents, 0664)
+	} else if container == config.AlertmanagerContainerName {
+		alertmanagerConfigPath := filepath.Join(rocketpoolDir, config.AlertingFolder, config.AlertmanagerConfigFile)
+		contents, err = cfg.GenerateAlertmanagerComposeFile(alertmanagerConfigPath)
 		if err != nil {
-			return []string{}, fmt.Errorf("could not write execution client container file to %s: %w", eth1ComposePath, err)
+			return nil, err
 		}
-		deployedContainers = append(deployedContainers, eth1ComposePath)
-		deployedContainers = append(deployedContainers, filepath.Join(overrideFolder, config.Eth1ContainerName+composeFileSuffix))
-	}
-
-	// Check the Fallback EC mode
-	if cfg.UseFallbackExecutionClient.Value == true && cfg.FallbackExecutionClientMode.Value.(config.Mode) == config.Mode_Local {
-		contents, err = envsubst.ReadFile(filepath.Join(templatesFolder, config.Eth1FallbackContainerName+templateSuffix))
+	} else if container == config.ChronyContainerName {
+		contents, err = cfg.GenerateChronyComposeFile()
 		if err != nil {
-			return []string{}, fmt.Errorf("error reading and substituting fallback execution client container template: %w", err)
+			return nil, err
 		}
-		eth1FallbackComposePath := filepath.Join(runtimeFolder, config.Eth1FallbackContainerName+composeFileSuffix)
-		err = ioutil.WriteFile(eth1FallbackComposePath, contents, 0664)
+	} else {
+		contents, err = ioutil.ReadFile(filepath.Join(templatesFolder, container+templateSuffix))
 		if err != nil {
-			return []string{}, fmt.Errorf("could not write fallback execution client container file to %s: %w", eth1FallbackComposePath, err)
+			return nil, fmt.Errorf("error reading %s container template: %w", container, err)
 		}
-		deployedContainers = append(deployedContainers, eth1FallbackComposePath)
-		deployedContainers = append(deployedContainers, filepath.Join(overrideFolder, config.Eth1FallbackContainerName+composeFileSuffix))
-	}
 
-	// Check the Consensus mode
-	if cfg.ConsensusClientMode.Value.(config.Mode) == config.Mode_Local {
-		contents, err = envsubst.ReadFile(filepath.Join(templatesFolder, config.Eth2ContainerName+templateSuffix))
-		if err != nil {
-			return []string{}, fmt.Errorf("error reading and substituting consensus client container template: %w", err)
+		// Templates from older installations add their runtime ports and volumes themselves, so they're left out of the fragment
+		if name, usesLegacyEnvVar := config.UsesLegacyFragmentEnvVar(container, contents); usesLegacyEnvVar {
+			fmt.Printf("%sWARNING: The %s container template uses the deprecated %s variable, which will be removed in a future release. Run `rocketpool service install -d` to update your templates.%s\n", colorYellow, container, name, colorReset)
+			if fragment, exists := fragments[container]; exists {
+				fragments[container] = fragment.WithoutLegacyMappings()
+			}
 		}
-		eth2ComposePath := filepath.Join(runtimeFolder, config.Eth2ContainerName+composeFileSuffix)
-		err = ioutil.WriteFile(eth2ComposePath, contents, 0664)
+		contents, err = cfg.RenderTemplate(container, contents, getLegacySecretSettings(cfg, container, contents, settings))
 		if err != nil {
-			return []string{}, fmt.Errorf("could not write consensus client container file to %s: %w", eth2ComposePath, err)
+			return nil, err
 		}
-		deployedContainers = append(deployedContainers, eth2ComposePath)
-		deployedContainers = append(deployedContainers, filepath.Join(overrideFolder, config.Eth2ContainerName+composeFileSuffix))
 	}
+	files := []ComposeFile{{
+		Container: container,
+		Filename:  container + composeFileSuffix,
+		Contents:  contents,
+	}}
 
-	// Check the metrics containers
-	if cfg.EnableMetrics.Value == true {
-		// Grafana
-		contents, err = envsubst.ReadFile(filepath.Join(templatesFolder, config.GrafanaContainerName+templateSuffix))
+	// Add the fragment
+	fragment, exists := fragments[container]
+	if exists && !fragment.IsEmpty() {
+		contents, err := fragment.Render()
 		if err != nil {
-			return []string{}, fmt.Errorf("error reading and substituting Grafana container template: %w", err)
+			return nil, err
 		}
-		grafanaComposePath := filepath.Join(runtimeFolder, config.GrafanaContainerName+composeFileSuffix)
-		err = ioutil.WriteFile(grafanaComposePath, contents, 0664)
-		if err != nil {
-			return []string{}, fmt.Errorf("could not write Grafana container file to %s: %w", grafanaComposePath, err)
-		}
-		deployedContainers = append(deployedContainers, grafanaComposePath)
-		deployedContainers = append(deployedContainers, filepath.Join(overrideFolder, config.GrafanaContainerName+composeFileSuffix))
+		files = append(files, ComposeFile{
+			Container: container,
+			Filename:  container + fragmentFileSuffix,
+			Contents:  contents,
+		})
+	}
+	return files, nil
 
-		// Node exporter
-		contents, err = envsubst.ReadFile(filepath.Join(templatesFolder, config.ExporterContainerName+templateSuffix))
-		if err != nil {
-			return []string{}, fmt.Errorf("error reading and substituting Node Exporter container template: %w", err)
-		}
-		exporterComposePath := filepath.Join(runtimeFolder, config.ExporterContainerName+composeFileSuffix)
-		err = ioutil.WriteFile(exporterComposePath, contents, 0664)
-		if err != nil {
-			return []string{}, fmt.Errorf("could not write Node Exporter container file to %s: %w", exporterComposePath, err)
-		}
-		deployedContainers = append(deployedContainers, exporterComposePath)
-		deployedContainers = append(deployedContainers, filepath.Join(overrideFolder, config.ExporterContainerName+composeFileSuffix))
+}
 
-		// Prometheus
-		contents, err = envsubst.ReadFile(filepath.Join(templatesFolder, config.PrometheusContainerName+templateSuffix))
-		if err != nil {
-			return []string{}, fmt.Errorf("error reading and substituting Prometheus container template: %w", err)
-		}
-		prometheusComposePath := filepath.Join(runtimeFolder, config.PrometheusContainerName+composeFileSuffix)
-		err = ioutil.WriteFile(prometheusComposePath, contents, 0664)
+// Write the secrets of the sensitive settings to the secrets folder, replacing the old ones
+func deploySecrets(cfg *config.RocketPoolConfig, rocketpoolDir string) error {
+	secretsFolder := filepath.Join(rocketpoolDir, config.SecretsFolder)
+	err := os.RemoveAll(secretsFolder)
+	if err != nil {
+		return fmt.Errorf("error deleting secrets folder [%s]: %w", secretsFolder, err)
+	}
+	err = os.Mkdir(secretsFolder, 0700)
+	if err != nil {
+		return fmt.Errorf("error creating secrets folder [%s]: %w", secretsFolder, err)
+	}
+	for _, secret := range cfg.GetContainerSecrets() {
+		secretPath := filepath.Join(secretsFolder, secret.Name)
+		err = ioutil.WriteFile(secretPath, []byte(secret.Value), 0600)
 		if err != nil {
-			return []string{}, fmt.Errorf("could not write Prometheus container file to %s: %w", prometheusComposePath, err)
+			return fmt.Errorf("error writing secret %s: %w", secret.Name, err)
 		}
-		deployedContainers = append(deployedContainers, prometheusComposePath)
-		deployedContainers = append(deployedContainers, filepath.Join(overrideFolder, config.PrometheusContainerName+composeFileSuffix))
 	}
+	return nil
+}
 
-	// Create the custom keys dir
-	customKeyDir, err := homedir.Expand(filepath.Join(cfg.Smartnode.DataPath.Value.(string), "custom-keys"))
-	if err != nil {
-		fmt.Printf("%sWARNING: Couldn't expand the custom validator key directory (%s). You will not be able to recover any minipool keys you created outside of the Smartnode until you create the folder manually.%s\n", colorYellow, err.Error(), colorReset)
-		return deployedContainers, nil
+// Get the settings to render a container template with, adding the values of the secrets that a template from an older installation still reads from environment variables
+func getLegacySecretSettings(cfg *config.RocketPoolConfig, container string, contents []byte, settings map[string]string) map[string]string {
+	var legacySettings map[string]string
+	for _, secret := range cfg.GetContainerSecrets() {
+		if !config.UsesLegacySecretEnvVar(secret, container, contents) {
+			continue
+		}
+		if legacySettings == nil {
+			legacySettings = map[string]string{}
+			for name, value := range settings {
+				legacySettings[name] = value
+			}
+		}
+		legacySettings[secret.EnvVar] = secret.Value
+		fmt.Printf("%sWARNING: The %s container template passes the %s setting to the container in plain text, which will stop working in a future release. Run `rocketpool service install -d` to update your templates so it's mounted as a file instead.%s\n", colorYellow, container, secret.EnvVar, colorReset)
 	}
-	err = os.MkdirAll(customKeyDir, 0775)
-	if err != nil {
-		fmt.Printf("%sWARNING: Couldn't create the custom validator key directory (%s). You will not be able to recover any minipool keys you created outside of the Smartnode until you create the folder [%s] manually.%s\n", colorYellow, err.Error(), customKeyDir, colorReset)
+	if legacySettings == nil {
+		return settings
 	}
-
-	return deployedContainers, nil
-
+	return legacySettings
 }
 
 // Call the Rocket Pool API
@@ -1429,15 +1931,16 @@ func (c *Client) callAPI(args string, otherArgs ...string) ([]byte, error) {
 		if err != nil {
 			return []byte{}, err
 		}
-		cmd = fmt.Sprintf("docker exec %s %s %s %s %s %s api %s", shellescape.Quote(containerName), shellescape.Quote(APIBinPath), ignoreSyncCheckFlag, forceFallbackECFlag, c.getGasOpts(), c.getCustomNonce(), args)
+		cmd = fmt.Sprintf("docker exec %s %s %s %s %s %s %s api %s", shellescape.Quote(containerName), shellescape.Quote(APIBinPath), ignoreSyncCheckFlag, forceFallbackECFlag, c.getGasOpts(), c.getCustomNonce(), c.getNodeAddressOpts(), args)
 	} else {
-		cmd = fmt.Sprintf("%s --settings %s %s %s %s %s api %s",
+		cmd = fmt.Sprintf("%s --settings %s %s %s %s %s %s api %s",
 			c.daemonPath,
 			shellescape.Quote(fmt.Sprintf("%s/%s", c.configPath, SettingsFile)),
 			ignoreSyncCheckFlag,
 			forceFallbackECFlag,
 			c.getGasOpts(),
 			c.getCustomNonce(),
+			c.getNodeAddressOpts(),
 			args)
 	}
 
@@ -1462,13 +1965,13 @@ func (c *Client) callAPIWithEnvVars(envVars map[string]string, args string, othe
 		if err != nil {
 			return []byte{}, err
 		}
-		cmd = fmt.Sprintf("docker exec %s %s %s %s %s %s %s api %s", envArgs, shellescape.Quote(containerName), shellescape.Quote(APIBinPath), ignoreSyncCheckFlag, forceFallbackECFlag, c.getGasOpts(), c.getCustomNonce(), args)
+		cmd = fmt.Sprintf("docker exec %s %s %s %s %s %s %s %s api %s", envArgs, shellescape.Quote(containerName), shellescape.Quote(APIBinPath), ignoreSyncCheckFlag, forceFallbackECFlag, c.getGasOpts(), c.getCustomNonce(), c.getNodeAddressOpts(), args)
 	} else {
 		envArgs := ""
 		for key, value := range envVars {
 			envArgs += fmt.Sprintf("%s=%s ", key, shellescape.Quote(value))
 		}
-		cmd = fmt.Sprintf("%s %s --settings %s %s %s %s %s api %s",
+		cmd = fmt.Sprintf("%s %s --settings %s %s %s %s %s %s api %s",
 			envArgs,
 			c.daemonPath,
 			shellescape.Quote(fmt.Sprintf("%s/%s", c.configPath, SettingsFile)),
@@ -1476,6 +1979,7 @@ func (c *Client) callAPIWithEnvVars(envVars map[string]string, args string, othe
 			forceFallbackECFlag,
 			c.getGasOpts(),
 			c.getCustomNonce(),
+			c.getNodeAddressOpts(),
 			args)
 	}
 
@@ -1546,7 +2050,7 @@ func (c *Client) getAPIContainerName() (string, error) {
 	if cfg.Smartnode.ProjectName.Value == "" {
 		return "", errors.New("Rocket Pool docker project name not set")
 	}
-	return cfg.Smartnode.ProjectName.Value.(string) + APIContainerSuffix, nil
+	return cfg.Smartnode.GetProjectName() + APIContainerSuffix, nil
 }
 
 // Get gas price & limit flags
@@ -1567,6 +2071,14 @@ func (c *Client) getCustomNonce() string {
 	return nonce
 }
 
+// Get the flag for observing a node address instead of loading the wallet
+func (c *Client) getNodeAddressOpts() string {
+	if c.nodeAddress == "" {
+		return ""
+	}
+	return fmt.Sprintf("--node-address %s", shellescape.Quote(c.nodeAddress))
+}
+
 // Get the first downloader available to the system
 func (c *Client) getDownloader() (string, error) {
 
@@ -0,0 +1,55 @@
+package rocketpool
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/alessio/shellescape"
+)
+
+// A Docker volume of the Rocket Pool service
+type ServiceVolume struct {
+	Name       string
+	Size       uint64
+	Containers []string
+}
+
+// Get the volumes of the Rocket Pool service's Docker project, their disk usage, and the containers that mount them
+func (c *Client) GetServiceVolumes(projectName string) ([]ServiceVolume, error) {
+
+	// Get the project's volumes
+	projectFilter := shellescape.Quote(fmt.Sprintf("label=com.docker.compose.project=%s", projectName))
+	names, err := c.readOutput(fmt.Sprintf("docker volume ls -q --filter %s", projectFilter))
+	if err != nil {
+		return nil, fmt.Errorf("Error listing volumes: %w", err)
+	}
+	sizes, err := c.getVolumeSizes()
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the containers that mount each one
+	volumes := []ServiceVolume{}
+	for _, name := range strings.Fields(string(names)) {
+		containers, err := c.readOutput(fmt.Sprintf("docker ps -a --filter %s --format '{{.Names}}'", shellescape.Quote("volume="+name)))
+		if err != nil {
+			return nil, fmt.Errorf("Error getting the containers that use volume %s: %w", name, err)
+		}
+		volumes = append(volumes, ServiceVolume{
+			Name:       name,
+			Size:       sizes[name],
+			Containers: strings.Fields(string(containers)),
+		})
+	}
+	return volumes, nil
+
+}
+
+// Get the folder Docker stores its volumes and images in
+func (c *Client) GetDockerRootDir() (string, error) {
+	output, err := c.readOutput("docker info --format '{{.DockerRootDir}}'")
+	if err != nil {
+		return "", fmt.Errorf("Error getting the Docker root folder: %w", err)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
@@ -0,0 +1,169 @@
+package rocketpool
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/mitchellh/go-homedir"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/utils/rp"
+)
+
+const (
+	// The config path of the default Smartnode instance
+	DefaultConfigPath string = "~/.rocketpool"
+
+	// The file that lists the config paths of the other Smartnode instances on this machine
+	instancesFile string = "~/.rocketpool-instances"
+
+	defaultProjectName string = "rocketpool"
+)
+
+// Characters that can't be used in Docker project names
+var invalidProjectNameChars = regexp.MustCompile("[^a-z0-9_-]+")
+
+// A Smartnode instance on this machine
+type instance struct {
+	path string
+	cfg  *config.RocketPoolConfig
+}
+
+// Get the Smartnode instances on this machine that have been configured: the default one, every one that's been configured with a
+// different config path, and the profiles of each of them
+func getInstances() []instance {
+
+	// Get the config paths of the instances
+	paths := []string{}
+	if defaultPath, err := getInstancePath(DefaultConfigPath); err == nil {
+		paths = append(paths, defaultPath)
+	}
+	if registryPath, err := homedir.Expand(instancesFile); err == nil {
+		if contents, err := ioutil.ReadFile(registryPath); err == nil {
+			for _, line := range strings.Split(string(contents), "\n") {
+				if line = strings.TrimSpace(line); line != "" {
+					paths = append(paths, line)
+				}
+			}
+		}
+	}
+	for _, path := range paths {
+		profilePaths, _ := filepath.Glob(filepath.Join(path, ProfilesDir, "*"))
+		paths = append(paths, profilePaths...)
+	}
+
+	// Load their configs
+	instances := []instance{}
+	seen := map[string]bool{}
+	for _, path := range paths {
+		if seen[path] {
+			continue
+		}
+		seen[path] = true
+		cfg, err := rp.LoadConfigFromFile(filepath.Join(path, SettingsFile))
+		if err != nil || cfg == nil {
+			continue
+		}
+		instances = append(instances, instance{path: path, cfg: cfg})
+	}
+	return instances
+
+}
+
+// Get the absolute config path of an instance
+func getInstancePath(configPath string) (string, error) {
+	expandedPath, err := homedir.Expand(configPath)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Abs(expandedPath)
+}
+
+// Get the other Smartnode instances on this machine
+func (c *Client) getOtherInstances() []instance {
+	path, err := getInstancePath(c.configPath)
+	if err != nil {
+		return []instance{}
+	}
+	others := []instance{}
+	for _, instance := range getInstances() {
+		if instance.path != path {
+			others = append(others, instance)
+		}
+	}
+	return others
+}
+
+// Record the client's config path as one of the Smartnode instances on this machine, so new instances don't collide with it.
+// The default config path and profiles are found without being recorded.
+func (c *Client) registerInstance() error {
+
+	path, err := getInstancePath(c.configPath)
+	if err != nil {
+		return err
+	}
+	defaultPath, err := getInstancePath(DefaultConfigPath)
+	if err != nil {
+		return err
+	}
+	if path == defaultPath || c.profile != "" {
+		return nil
+	}
+
+	registryPath, err := homedir.Expand(instancesFile)
+	if err != nil {
+		return err
+	}
+	contents, err := ioutil.ReadFile(registryPath)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("error reading the Smartnode instance list: %w", err)
+	}
+	paths := []string{}
+	for _, line := range strings.Split(string(contents), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			if line == path {
+				return nil
+			}
+			paths = append(paths, line)
+		}
+	}
+	paths = append(paths, path)
+	sort.Strings(paths)
+	if err := ioutil.WriteFile(registryPath, []byte(strings.Join(paths, "\n")+"\n"), 0644); err != nil {
+		return fmt.Errorf("error saving the Smartnode instance list: %w", err)
+	}
+	return nil
+
+}
+
+// Get the Docker project name for a new instance, so its containers don't collide with the ones of the other instances.
+// The default instance is "rocketpool", profiles are named after the profile, and other instances are named after their config folder;
+// a number is added if another instance already uses the name.
+func getInstanceProjectName(configPath string, isDefault bool, profile string, taken map[string]bool) string {
+
+	name := defaultProjectName
+	if profile != "" {
+		name = GetProfileProjectName(profile)
+	} else if !isDefault {
+		folder := strings.ToLower(filepath.Base(filepath.Clean(configPath)))
+		folder = strings.Trim(invalidProjectNameChars.ReplaceAllString(folder, "-"), "-_")
+		if folder != "" && folder != defaultProjectName {
+			name = folder
+			if !strings.HasPrefix(name, defaultProjectName) {
+				name = fmt.Sprintf("%s-%s", defaultProjectName, name)
+			}
+		}
+	}
+
+	uniqueName := name
+	for i := 2; taken[uniqueName]; i++ {
+		uniqueName = fmt.Sprintf("%s-%d", name, i)
+	}
+	return uniqueName
+
+}
@@ -0,0 +1,30 @@
+package rocketpool
+
+import (
+	"testing"
+)
+
+func TestGetInstanceProjectName(t *testing.T) {
+	tests := []struct {
+		configPath string
+		isDefault  bool
+		profile    string
+		taken      map[string]bool
+		expected   string
+	}{
+		{configPath: "/home/node/.rocketpool", isDefault: true, expected: "rocketpool"},
+		{configPath: "/home/node/.rocketpool", isDefault: true, profile: "holesky", expected: "rocketpool-holesky"},
+		{configPath: "/home/node/.rocketpool-testnet", expected: "rocketpool-testnet"},
+		{configPath: "/srv/Second Node/", expected: "rocketpool-second-node"},
+		{configPath: "/srv/rocketpool", taken: map[string]bool{"rocketpool": true}, expected: "rocketpool-2"},
+		{configPath: "/srv/.rocketpool-testnet", taken: map[string]bool{"rocketpool-testnet": true, "rocketpool-testnet-2": true}, expected: "rocketpool-testnet-3"},
+		{configPath: "/other/profiles/base", profile: "holesky", taken: map[string]bool{"rocketpool-holesky": true}, expected: "rocketpool-holesky-2"},
+		{configPath: "/", expected: "rocketpool"},
+	}
+	for _, test := range tests {
+		name := getInstanceProjectName(test.configPath, test.isDefault, test.profile, test.taken)
+		if name != test.expected {
+			t.Errorf("expected project name %s for %s (profile '%s'), got %s", test.expected, test.configPath, test.profile, name)
+		}
+	}
+}
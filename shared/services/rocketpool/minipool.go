@@ -184,6 +184,102 @@ func (c *Client) ExitMinipool(address common.Address) (api.ExitMinipoolResponse,
 	return response, nil
 }
 
+// Sign a minipool's exit and have the node daemon broadcast it at an epoch
+func (c *Client) ScheduleExitMinipool(address common.Address, epoch uint64) (api.ScheduleExitMinipoolResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("minipool schedule-exit %s %d", address.Hex(), epoch))
+	if err != nil {
+		return api.ScheduleExitMinipoolResponse{}, fmt.Errorf("Could not schedule minipool exit: %w", err)
+	}
+	var response api.ScheduleExitMinipoolResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.ScheduleExitMinipoolResponse{}, fmt.Errorf("Could not decode schedule minipool exit response: %w", err)
+	}
+	if response.Error != "" {
+		return api.ScheduleExitMinipoolResponse{}, fmt.Errorf("Could not schedule minipool exit: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Get signed exits for all of the node's staking minipools, so they can be exported
+func (c *Client) GetSignedExitMessages() (api.GetSignedExitMessagesResponse, error) {
+	responseBytes, err := c.callAPI("minipool get-signed-exits")
+	if err != nil {
+		return api.GetSignedExitMessagesResponse{}, fmt.Errorf("Could not get signed exit messages: %w", err)
+	}
+	var response api.GetSignedExitMessagesResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.GetSignedExitMessagesResponse{}, fmt.Errorf("Could not decode get signed exit messages response: %w", err)
+	}
+	if response.Error != "" {
+		return api.GetSignedExitMessagesResponse{}, fmt.Errorf("Could not get signed exit messages: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Check whether a minipool can begin reducing its bond
+func (c *Client) CanBeginReduceBondAmount(address common.Address, newBond *big.Int) (api.CanBeginReduceBondAmountResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("minipool can-begin-reduce-bond %s %s", address.Hex(), newBond.String()))
+	if err != nil {
+		return api.CanBeginReduceBondAmountResponse{}, fmt.Errorf("Could not get can begin reduce bond status: %w", err)
+	}
+	var response api.CanBeginReduceBondAmountResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.CanBeginReduceBondAmountResponse{}, fmt.Errorf("Could not decode can begin reduce bond response: %w", err)
+	}
+	if response.Error != "" {
+		return api.CanBeginReduceBondAmountResponse{}, fmt.Errorf("Could not get can begin reduce bond status: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Begin reducing a minipool's bond
+func (c *Client) BeginReduceBondAmount(address common.Address, newBond *big.Int) (api.BeginReduceBondAmountResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("minipool begin-reduce-bond %s %s", address.Hex(), newBond.String()))
+	if err != nil {
+		return api.BeginReduceBondAmountResponse{}, fmt.Errorf("Could not begin reducing minipool bond: %w", err)
+	}
+	var response api.BeginReduceBondAmountResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.BeginReduceBondAmountResponse{}, fmt.Errorf("Could not decode begin reduce bond response: %w", err)
+	}
+	if response.Error != "" {
+		return api.BeginReduceBondAmountResponse{}, fmt.Errorf("Could not begin reducing minipool bond: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Check whether a minipool can complete its bond reduction
+func (c *Client) CanReduceBondAmount(address common.Address) (api.CanReduceBondAmountResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("minipool can-reduce-bond %s", address.Hex()))
+	if err != nil {
+		return api.CanReduceBondAmountResponse{}, fmt.Errorf("Could not get can reduce bond status: %w", err)
+	}
+	var response api.CanReduceBondAmountResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.CanReduceBondAmountResponse{}, fmt.Errorf("Could not decode can reduce bond response: %w", err)
+	}
+	if response.Error != "" {
+		return api.CanReduceBondAmountResponse{}, fmt.Errorf("Could not get can reduce bond status: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Complete a minipool's bond reduction
+func (c *Client) ReduceBondAmount(address common.Address) (api.ReduceBondAmountResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("minipool reduce-bond %s", address.Hex()))
+	if err != nil {
+		return api.ReduceBondAmountResponse{}, fmt.Errorf("Could not reduce minipool bond: %w", err)
+	}
+	var response api.ReduceBondAmountResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.ReduceBondAmountResponse{}, fmt.Errorf("Could not decode reduce bond response: %w", err)
+	}
+	if response.Error != "" {
+		return api.ReduceBondAmountResponse{}, fmt.Errorf("Could not reduce minipool bond: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Check whether a minipool can be closed
 func (c *Client) CanCloseMinipool(address common.Address) (api.CanCloseMinipoolResponse, error) {
 	responseBytes, err := c.callAPI(fmt.Sprintf("minipool can-close %s", address.Hex()))
@@ -0,0 +1,121 @@
+package rocketpool
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/alessio/shellescape"
+	"github.com/mitchellh/go-homedir"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+)
+
+// The folder the Native mode systemd services are installed to
+const NativeUnitPath string = "/etc/systemd/system"
+
+// Install the systemd services that run the Smartnode in Native mode, and enable them so they start on boot
+func (c *Client) InstallNativeServices() ([]*config.NativeUnit, error) {
+
+	units, err := c.getNativeUnits()
+	if err != nil {
+		return nil, err
+	}
+
+	// Write the service files
+	names := make([]string, len(units))
+	for i, unit := range units {
+		contents, err := unit.Render()
+		if err != nil {
+			return nil, err
+		}
+		unitPath := filepath.Join(NativeUnitPath, unit.GetFilename())
+		cmd := fmt.Sprintf("printf '%%s' %s | sudo tee %s > /dev/null", shellescape.Quote(string(contents)), shellescape.Quote(unitPath))
+		if _, err := c.readOutput(cmd); err != nil {
+			return nil, fmt.Errorf("error writing %s: %w", unitPath, err)
+		}
+		names[i] = shellescape.Quote(unit.Name)
+	}
+
+	// Load and enable them
+	if err := c.printOutput("sudo systemctl daemon-reload"); err != nil {
+		return nil, fmt.Errorf("error reloading systemd: %w", err)
+	}
+	if err := c.printOutput(fmt.Sprintf("sudo systemctl enable %s", strings.Join(names, " "))); err != nil {
+		return nil, fmt.Errorf("error enabling the Smartnode services: %w", err)
+	}
+	return units, nil
+
+}
+
+// Start the Native mode systemd services
+func (c *Client) StartNativeServices() error {
+	return c.runNativeServiceCommand("start")
+}
+
+// Stop the Native mode systemd services
+func (c *Client) StopNativeServices() error {
+	return c.runNativeServiceCommand("stop")
+}
+
+// Print the status of the Native mode systemd services
+func (c *Client) PrintNativeServiceStatus() error {
+	names, err := c.getNativeUnitNames()
+	if err != nil {
+		return err
+	}
+	return c.printOutput(fmt.Sprintf("systemctl status --no-pager %s", names))
+}
+
+// Run a systemctl command on all of the Native mode systemd services
+func (c *Client) runNativeServiceCommand(command string) error {
+	names, err := c.getNativeUnitNames()
+	if err != nil {
+		return err
+	}
+	return c.printOutput(fmt.Sprintf("sudo systemctl %s %s", command, names))
+}
+
+// Get the names of the Native mode systemd services, ready to pass to systemctl
+func (c *Client) getNativeUnitNames() (string, error) {
+	units, err := c.getNativeUnits()
+	if err != nil {
+		return "", err
+	}
+	names := make([]string, len(units))
+	for i, unit := range units {
+		names[i] = shellescape.Quote(unit.Name)
+	}
+	return strings.Join(names, " "), nil
+}
+
+// Generate the Native mode systemd services from the config
+func (c *Client) getNativeUnits() ([]*config.NativeUnit, error) {
+
+	// Cancel if not running in Native mode
+	if c.daemonPath == "" {
+		return nil, errors.New("command only available in Native Mode (with '--daemon-path' option specified)")
+	}
+
+	// Load config
+	cfg, isNew, err := c.LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+	if isNew {
+		return nil, fmt.Errorf("Settings file not found. Please run `rocketpool service config` to set up your Smartnode before installing its services.")
+	}
+
+	// Get the paths the services use
+	daemonPath, err := homedir.Expand(c.daemonPath)
+	if err != nil {
+		return nil, fmt.Errorf("error expanding daemon path [%s]: %w", c.daemonPath, err)
+	}
+	settingsPath, err := homedir.Expand(filepath.Join(c.configPath, SettingsFile))
+	if err != nil {
+		return nil, fmt.Errorf("error expanding settings file path: %w", err)
+	}
+	return cfg.GenerateNativeUnits(daemonPath, settingsPath)
+
+}
@@ -80,3 +80,60 @@ func (c *Client) TimezoneMap() (api.NetworkTimezonesResponse, error) {
 	}
 	return response, nil
 }
+
+// Get the max fee suggestions of the configured gas oracle
+func (c *Client) GetGasPrices() (api.GasPricesResponse, error) {
+	responseBytes, err := c.callAPI("network gas-prices")
+	if err != nil {
+		return api.GasPricesResponse{}, fmt.Errorf("Could not get gas prices: %w", err)
+	}
+	var response api.GasPricesResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.GasPricesResponse{}, fmt.Errorf("Could not decode gas prices response: %w", err)
+	}
+	if response.Error != "" {
+		return api.GasPricesResponse{}, fmt.Errorf("Could not get gas prices: %s", response.Error)
+	}
+	if response.FastWei == nil {
+		response.FastWei = big.NewInt(0)
+	}
+	if response.StandardWei == nil {
+		response.StandardWei = big.NewInt(0)
+	}
+	if response.SlowWei == nil {
+		response.SlowWei = big.NewInt(0)
+	}
+	return response, nil
+}
+
+// Generate the rewards tree for an interval from the chain's state and verify it against the canonical root, saving it if it matches and execute is set
+func (c *Client) GenerateRewardsTree(index uint64, execute bool) (api.NetworkGenerateRewardsTreeResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("network generate-rewards-tree %d %t", index, execute))
+	if err != nil {
+		return api.NetworkGenerateRewardsTreeResponse{}, fmt.Errorf("Could not generate rewards tree: %w", err)
+	}
+	var response api.NetworkGenerateRewardsTreeResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NetworkGenerateRewardsTreeResponse{}, fmt.Errorf("Could not decode generate rewards tree response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NetworkGenerateRewardsTreeResponse{}, fmt.Errorf("Could not generate rewards tree: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Download the rewards tree for an interval from the configured sources, verifying it against the canonical root
+func (c *Client) DownloadRewardsTree(index uint64) (api.NetworkDownloadRewardsTreeResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("network download-rewards-tree %d", index))
+	if err != nil {
+		return api.NetworkDownloadRewardsTreeResponse{}, fmt.Errorf("Could not download rewards tree: %w", err)
+	}
+	var response api.NetworkDownloadRewardsTreeResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NetworkDownloadRewardsTreeResponse{}, fmt.Errorf("Could not decode download rewards tree response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NetworkDownloadRewardsTreeResponse{}, fmt.Errorf("Could not download rewards tree: %s", response.Error)
+	}
+	return response, nil
+}
@@ -188,6 +188,70 @@ func (c *Client) SetNodeTimezone(timezoneLocation string) (api.SetNodeTimezoneRe
 	return response, nil
 }
 
+// Check whether the node can join the smoothing pool
+func (c *Client) CanJoinSmoothingPool() (api.CanSetSmoothingPoolStatusResponse, error) {
+	responseBytes, err := c.callAPI("node can-join-smoothing-pool")
+	if err != nil {
+		return api.CanSetSmoothingPoolStatusResponse{}, fmt.Errorf("Could not get can join the smoothing pool status: %w", err)
+	}
+	var response api.CanSetSmoothingPoolStatusResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.CanSetSmoothingPoolStatusResponse{}, fmt.Errorf("Could not decode can join the smoothing pool response: %w", err)
+	}
+	if response.Error != "" {
+		return api.CanSetSmoothingPoolStatusResponse{}, fmt.Errorf("Could not get can join the smoothing pool status: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Join the smoothing pool
+func (c *Client) JoinSmoothingPool() (api.SetSmoothingPoolStatusResponse, error) {
+	responseBytes, err := c.callAPI("node join-smoothing-pool")
+	if err != nil {
+		return api.SetSmoothingPoolStatusResponse{}, fmt.Errorf("Could not join the smoothing pool: %w", err)
+	}
+	var response api.SetSmoothingPoolStatusResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.SetSmoothingPoolStatusResponse{}, fmt.Errorf("Could not decode join the smoothing pool response: %w", err)
+	}
+	if response.Error != "" {
+		return api.SetSmoothingPoolStatusResponse{}, fmt.Errorf("Could not join the smoothing pool: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Check whether the node can leave the smoothing pool
+func (c *Client) CanLeaveSmoothingPool() (api.CanSetSmoothingPoolStatusResponse, error) {
+	responseBytes, err := c.callAPI("node can-leave-smoothing-pool")
+	if err != nil {
+		return api.CanSetSmoothingPoolStatusResponse{}, fmt.Errorf("Could not get can leave the smoothing pool status: %w", err)
+	}
+	var response api.CanSetSmoothingPoolStatusResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.CanSetSmoothingPoolStatusResponse{}, fmt.Errorf("Could not decode can leave the smoothing pool response: %w", err)
+	}
+	if response.Error != "" {
+		return api.CanSetSmoothingPoolStatusResponse{}, fmt.Errorf("Could not get can leave the smoothing pool status: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Leave the smoothing pool
+func (c *Client) LeaveSmoothingPool() (api.SetSmoothingPoolStatusResponse, error) {
+	responseBytes, err := c.callAPI("node leave-smoothing-pool")
+	if err != nil {
+		return api.SetSmoothingPoolStatusResponse{}, fmt.Errorf("Could not leave the smoothing pool: %w", err)
+	}
+	var response api.SetSmoothingPoolStatusResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.SetSmoothingPoolStatusResponse{}, fmt.Errorf("Could not decode leave the smoothing pool response: %w", err)
+	}
+	if response.Error != "" {
+		return api.SetSmoothingPoolStatusResponse{}, fmt.Errorf("Could not leave the smoothing pool: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Check whether the node can swap RPL tokens
 func (c *Client) CanNodeSwapRpl(amountWei *big.Int) (api.CanNodeSwapRplResponse, error) {
 	responseBytes, err := c.callAPI(fmt.Sprintf("node can-swap-rpl %s", amountWei.String()))
@@ -524,6 +588,38 @@ func (c *Client) NodeSync() (api.NodeSyncProgressResponse, error) {
 	return response, nil
 }
 
+// Get the recommended gas thresholds for the automatic RPL claims and minipool stakes
+func (c *Client) GetGasThresholdRecommendation() (api.GasThresholdRecommendationResponse, error) {
+	responseBytes, err := c.callAPI("node gas-threshold-recommendation")
+	if err != nil {
+		return api.GasThresholdRecommendationResponse{}, fmt.Errorf("Could not get gas threshold recommendation: %w", err)
+	}
+	var response api.GasThresholdRecommendationResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.GasThresholdRecommendationResponse{}, fmt.Errorf("Could not decode gas threshold recommendation response: %w", err)
+	}
+	if response.Error != "" {
+		return api.GasThresholdRecommendationResponse{}, fmt.Errorf("Could not get gas threshold recommendation: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Get the attestation performance of the node's validators
+func (c *Client) NodePerformance() (api.NodePerformanceResponse, error) {
+	responseBytes, err := c.callAPI("node performance")
+	if err != nil {
+		return api.NodePerformanceResponse{}, fmt.Errorf("Could not get node performance: %w", err)
+	}
+	var response api.NodePerformanceResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodePerformanceResponse{}, fmt.Errorf("Could not decode node performance response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodePerformanceResponse{}, fmt.Errorf("Could not get node performance: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Check whether the node has RPL rewards available to claim
 func (c *Client) CanNodeClaimRpl() (api.CanNodeClaimRplResponse, error) {
 	responseBytes, err := c.callAPI("node can-claim-rpl-rewards")
@@ -572,6 +668,60 @@ func (c *Client) NodeRewards() (api.NodeRewardsResponse, error) {
 	return response, nil
 }
 
+// Get the node's realized rewards and APR for each past rewards interval
+func (c *Client) NodeRewardsHistory() (api.NodeRewardsHistoryResponse, error) {
+	responseBytes, err := c.callAPI("node rewards-history")
+	if err != nil {
+		return api.NodeRewardsHistoryResponse{}, fmt.Errorf("Could not get node rewards history: %w", err)
+	}
+	var response api.NodeRewardsHistoryResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeRewardsHistoryResponse{}, fmt.Errorf("Could not decode node rewards history response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeRewardsHistoryResponse{}, fmt.Errorf("Could not get node rewards history: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Get the node's unclaimed rewards and the minipools that are ready to stake
+func (c *Client) NodeTodo() (api.NodeTodoResponse, error) {
+	responseBytes, err := c.callAPI("node todo")
+	if err != nil {
+		return api.NodeTodoResponse{}, fmt.Errorf("Could not get node pending items: %w", err)
+	}
+	var response api.NodeTodoResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeTodoResponse{}, fmt.Errorf("Could not decode node pending items response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeTodoResponse{}, fmt.Errorf("Could not get node pending items: %s", response.Error)
+	}
+	if response.UnclaimedRpl == nil {
+		response.UnclaimedRpl = big.NewInt(0)
+	}
+	if response.UnclaimedEth == nil {
+		response.UnclaimedEth = big.NewInt(0)
+	}
+	return response, nil
+}
+
+// Get the transactions that the node daemon is submitting or retrying
+func (c *Client) NodeTxQueue() (api.NodeTxQueueResponse, error) {
+	responseBytes, err := c.callAPI("node tx-queue")
+	if err != nil {
+		return api.NodeTxQueueResponse{}, fmt.Errorf("Could not get node transaction queue: %w", err)
+	}
+	var response api.NodeTxQueueResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeTxQueueResponse{}, fmt.Errorf("Could not decode node transaction queue response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeTxQueueResponse{}, fmt.Errorf("Could not get node transaction queue: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Get the deposit contract info for Rocket Pool and the Beacon Client
 func (c *Client) DepositContractInfo() (api.DepositContractInfoResponse, error) {
 	responseBytes, err := c.callAPI("node deposit-contract-info")
@@ -651,3 +801,127 @@ func (c *Client) ClearSnapshotDelegate() (api.ClearSnapshotDelegateResponse, err
 	}
 	return response, nil
 }
+
+// Get the node account's nonces and transactions in the mempool
+func (c *Client) NodeTxDoctorStatus() (api.NodeTxDoctorStatusResponse, error) {
+	responseBytes, err := c.callAPI("node tx-doctor-status")
+	if err != nil {
+		return api.NodeTxDoctorStatusResponse{}, fmt.Errorf("Could not get node transaction status: %w", err)
+	}
+	var response api.NodeTxDoctorStatusResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeTxDoctorStatusResponse{}, fmt.Errorf("Could not decode node transaction status response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeTxDoctorStatusResponse{}, fmt.Errorf("Could not get node transaction status: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Fill a missing nonce with a self-transfer
+func (c *Client) NodeFillNonceGap(nonce uint64) (api.NodeFillNonceGapResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("node fill-nonce-gap %d", nonce))
+	if err != nil {
+		return api.NodeFillNonceGapResponse{}, fmt.Errorf("Could not fill nonce gap: %w", err)
+	}
+	var response api.NodeFillNonceGapResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeFillNonceGapResponse{}, fmt.Errorf("Could not decode fill nonce gap response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeFillNonceGapResponse{}, fmt.Errorf("Could not fill nonce gap: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Resubmit a pending transaction with a higher fee
+func (c *Client) NodeReplaceTx(nonce uint64) (api.NodeReplaceTxResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("node replace-tx %d", nonce))
+	if err != nil {
+		return api.NodeReplaceTxResponse{}, fmt.Errorf("Could not replace transaction: %w", err)
+	}
+	var response api.NodeReplaceTxResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeReplaceTxResponse{}, fmt.Errorf("Could not decode replace transaction response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeReplaceTxResponse{}, fmt.Errorf("Could not replace transaction: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Check whether a pending node transaction can be sped up
+func (c *Client) CanSpeedUpTx(hash common.Hash) (api.NodeCanReplaceTxResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("node can-speed-up-tx %s", hash.Hex()))
+	if err != nil {
+		return api.NodeCanReplaceTxResponse{}, fmt.Errorf("Could not check if the transaction can be sped up: %w", err)
+	}
+	var response api.NodeCanReplaceTxResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeCanReplaceTxResponse{}, fmt.Errorf("Could not decode can speed up tx response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeCanReplaceTxResponse{}, fmt.Errorf("Could not check if the transaction can be sped up: %s", response.Error)
+	}
+	if response.MinMaxFee == nil {
+		response.MinMaxFee = big.NewInt(0)
+	}
+	if response.MinMaxPriorityFee == nil {
+		response.MinMaxPriorityFee = big.NewInt(0)
+	}
+	return response, nil
+}
+
+// Resubmit a pending node transaction with a higher max fee
+func (c *Client) SpeedUpTx(hash common.Hash) (api.NodeReplaceTxResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("node speed-up-tx %s", hash.Hex()))
+	if err != nil {
+		return api.NodeReplaceTxResponse{}, fmt.Errorf("Could not speed up transaction: %w", err)
+	}
+	var response api.NodeReplaceTxResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeReplaceTxResponse{}, fmt.Errorf("Could not decode speed up tx response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeReplaceTxResponse{}, fmt.Errorf("Could not speed up transaction: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Check whether a pending node transaction can be cancelled
+func (c *Client) CanCancelTx(hash common.Hash) (api.NodeCanReplaceTxResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("node can-cancel-tx %s", hash.Hex()))
+	if err != nil {
+		return api.NodeCanReplaceTxResponse{}, fmt.Errorf("Could not check if the transaction can be cancelled: %w", err)
+	}
+	var response api.NodeCanReplaceTxResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeCanReplaceTxResponse{}, fmt.Errorf("Could not decode can cancel tx response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeCanReplaceTxResponse{}, fmt.Errorf("Could not check if the transaction can be cancelled: %s", response.Error)
+	}
+	if response.MinMaxFee == nil {
+		response.MinMaxFee = big.NewInt(0)
+	}
+	if response.MinMaxPriorityFee == nil {
+		response.MinMaxPriorityFee = big.NewInt(0)
+	}
+	return response, nil
+}
+
+// Cancel a pending node transaction
+func (c *Client) CancelTx(hash common.Hash) (api.NodeReplaceTxResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("node cancel-tx %s", hash.Hex()))
+	if err != nil {
+		return api.NodeReplaceTxResponse{}, fmt.Errorf("Could not cancel transaction: %w", err)
+	}
+	var response api.NodeReplaceTxResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeReplaceTxResponse{}, fmt.Errorf("Could not decode cancel tx response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeReplaceTxResponse{}, fmt.Errorf("Could not cancel transaction: %s", response.Error)
+	}
+	return response, nil
+}
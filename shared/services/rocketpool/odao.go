@@ -739,3 +739,83 @@ func (c *Client) GetTNDAOMinipoolSettings() (api.GetTNDAOMinipoolSettingsRespons
 	}
 	return response, nil
 }
+
+// Check whether the node can vote to cancel a minipool's bond reduction
+func (c *Client) CanCancelBondReduction(minipoolAddress common.Address) (api.CanCancelBondReductionResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("odao can-cancel-bond-reduction %s", minipoolAddress.Hex()))
+	if err != nil {
+		return api.CanCancelBondReductionResponse{}, fmt.Errorf("Could not get can cancel bond reduction status: %w", err)
+	}
+	var response api.CanCancelBondReductionResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.CanCancelBondReductionResponse{}, fmt.Errorf("Could not decode can-cancel-bond-reduction response: %w", err)
+	}
+	if response.Error != "" {
+		return api.CanCancelBondReductionResponse{}, fmt.Errorf("Could not get can cancel bond reduction status: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Vote to cancel a minipool's bond reduction
+func (c *Client) CancelBondReduction(minipoolAddress common.Address, reason string) (api.CancelBondReductionResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("odao cancel-bond-reduction %s", minipoolAddress.Hex()), reason)
+	if err != nil {
+		return api.CancelBondReductionResponse{}, fmt.Errorf("Could not vote to cancel bond reduction: %w", err)
+	}
+	var response api.CancelBondReductionResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.CancelBondReductionResponse{}, fmt.Errorf("Could not decode cancel-bond-reduction response: %w", err)
+	}
+	if response.Error != "" {
+		return api.CancelBondReductionResponse{}, fmt.Errorf("Could not vote to cancel bond reduction: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Check whether the node can vote to scrub a minipool
+func (c *Client) CanScrubMinipool(minipoolAddress common.Address) (api.CanScrubMinipoolResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("odao can-scrub-minipool %s", minipoolAddress.Hex()))
+	if err != nil {
+		return api.CanScrubMinipoolResponse{}, fmt.Errorf("Could not get can scrub minipool status: %w", err)
+	}
+	var response api.CanScrubMinipoolResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.CanScrubMinipoolResponse{}, fmt.Errorf("Could not decode can-scrub-minipool response: %w", err)
+	}
+	if response.Error != "" {
+		return api.CanScrubMinipoolResponse{}, fmt.Errorf("Could not get can scrub minipool status: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Vote to scrub a minipool
+func (c *Client) ScrubMinipool(minipoolAddress common.Address, reason string) (api.ScrubMinipoolResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("odao scrub-minipool %s", minipoolAddress.Hex()), reason)
+	if err != nil {
+		return api.ScrubMinipoolResponse{}, fmt.Errorf("Could not vote to scrub minipool: %w", err)
+	}
+	var response api.ScrubMinipoolResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.ScrubMinipoolResponse{}, fmt.Errorf("Could not decode scrub-minipool response: %w", err)
+	}
+	if response.Error != "" {
+		return api.ScrubMinipoolResponse{}, fmt.Errorf("Could not vote to scrub minipool: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Get the log of emergency actions the node has taken
+func (c *Client) GetEmergencyAuditLog() (api.GetEmergencyAuditLogResponse, error) {
+	responseBytes, err := c.callAPI("odao get-emergency-audit-log")
+	if err != nil {
+		return api.GetEmergencyAuditLogResponse{}, fmt.Errorf("Could not get emergency audit log: %w", err)
+	}
+	var response api.GetEmergencyAuditLogResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.GetEmergencyAuditLogResponse{}, fmt.Errorf("Could not decode get-emergency-audit-log response: %w", err)
+	}
+	if response.Error != "" {
+		return api.GetEmergencyAuditLogResponse{}, fmt.Errorf("Could not get emergency audit log: %s", response.Error)
+	}
+	return response, nil
+}
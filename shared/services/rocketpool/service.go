@@ -39,3 +39,19 @@ func (c *Client) GetExecutionClientStatus() (api.ExecutionClientStatusResponse,
 	}
 	return response, nil
 }
+
+// Gets the validator keys assigned to each Validator client shard
+func (c *Client) GetValidatorShards() (api.ValidatorShardsResponse, error) {
+	responseBytes, err := c.callAPI("service get-validator-shards")
+	if err != nil {
+		return api.ValidatorShardsResponse{}, fmt.Errorf("Could not get validator shards: %w", err)
+	}
+	var response api.ValidatorShardsResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.ValidatorShardsResponse{}, fmt.Errorf("Could not decode validator shards response: %w", err)
+	}
+	if response.Error != "" {
+		return api.ValidatorShardsResponse{}, fmt.Errorf("Could not get validator shards: %s", response.Error)
+	}
+	return response, nil
+}
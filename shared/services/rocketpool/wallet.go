@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 
+	"github.com/alessio/shellescape"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/rocket-pool/smartnode/shared/types/api"
 )
@@ -57,11 +58,12 @@ func (c *Client) InitWallet(derivationPath string) (api.InitWalletResponse, erro
 }
 
 // Recover wallet
-func (c *Client) RecoverWallet(mnemonic string, skipValidatorKeyRecovery bool, derivationPath string, walletIndex uint) (api.RecoverWalletResponse, error) {
+func (c *Client) RecoverWallet(mnemonic string, skipValidatorKeyRecovery bool, derivationPath string, walletIndex uint, searchValidatorPaths bool, validatorKeyPaths string, validatorKeyIndexRange string) (api.RecoverWalletResponse, error) {
 	command := "wallet recover "
 	if skipValidatorKeyRecovery {
 		command += "--skip-validator-key-recovery "
 	}
+	command += getValidatorKeySearchFlags(searchValidatorPaths, validatorKeyPaths, validatorKeyIndexRange)
 	if walletIndex != 0 {
 		command += fmt.Sprintf("--wallet-index %d ", walletIndex)
 	}
@@ -81,12 +83,36 @@ func (c *Client) RecoverWallet(mnemonic string, skipValidatorKeyRecovery bool, d
 	return response, nil
 }
 
+// Recover the validator keys for a node's minipools without saving the node wallet
+func (c *Client) RecoverValidatorKeys(mnemonic string, derivationPath string, walletIndex uint, searchValidatorPaths bool, validatorKeyPaths string, validatorKeyIndexRange string) (api.RecoverWalletResponse, error) {
+	command := "wallet recover-validator-keys "
+	if walletIndex != 0 {
+		command += fmt.Sprintf("--wallet-index %d ", walletIndex)
+	}
+	command += getValidatorKeySearchFlags(searchValidatorPaths, validatorKeyPaths, validatorKeyIndexRange)
+	command += "--derivation-path"
+
+	responseBytes, err := c.callAPI(command, derivationPath, mnemonic)
+	if err != nil {
+		return api.RecoverWalletResponse{}, fmt.Errorf("Could not recover validator keys: %w", err)
+	}
+	var response api.RecoverWalletResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.RecoverWalletResponse{}, fmt.Errorf("Could not decode recover validator keys response: %w", err)
+	}
+	if response.Error != "" {
+		return api.RecoverWalletResponse{}, fmt.Errorf("Could not recover validator keys: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Search and recover wallet
-func (c *Client) SearchAndRecoverWallet(mnemonic string, address common.Address, skipValidatorKeyRecovery bool) (api.SearchAndRecoverWalletResponse, error) {
+func (c *Client) SearchAndRecoverWallet(mnemonic string, address common.Address, skipValidatorKeyRecovery bool, searchValidatorPaths bool, validatorKeyPaths string, validatorKeyIndexRange string) (api.SearchAndRecoverWalletResponse, error) {
 	command := "wallet search-and-recover "
 	if skipValidatorKeyRecovery {
 		command += "--skip-validator-key-recovery "
 	}
+	command += getValidatorKeySearchFlags(searchValidatorPaths, validatorKeyPaths, validatorKeyIndexRange)
 
 	responseBytes, err := c.callAPI(command, mnemonic, address.Hex())
 	if err != nil {
@@ -179,3 +205,146 @@ func (c *Client) ExportWallet() (api.ExportWalletResponse, error) {
 	}
 	return response, nil
 }
+
+// Export the slashing protection history from the Validator Client, removing its keys
+func (c *Client) ExportSlashingProtection() (api.ExportSlashingProtectionResponse, error) {
+	responseBytes, err := c.callAPI("wallet export-slashing-protection")
+	if err != nil {
+		return api.ExportSlashingProtectionResponse{}, fmt.Errorf("Could not export slashing protection: %w", err)
+	}
+	var response api.ExportSlashingProtectionResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.ExportSlashingProtectionResponse{}, fmt.Errorf("Could not decode export slashing protection response: %w", err)
+	}
+	if response.Error != "" {
+		return api.ExportSlashingProtectionResponse{}, fmt.Errorf("Could not export slashing protection: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Import the staged slashing protection file into the Validator Client
+func (c *Client) ImportSlashingProtection() (api.ImportSlashingProtectionResponse, error) {
+	responseBytes, err := c.callAPI("wallet import-slashing-protection")
+	if err != nil {
+		return api.ImportSlashingProtectionResponse{}, fmt.Errorf("Could not import slashing protection: %w", err)
+	}
+	var response api.ImportSlashingProtectionResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.ImportSlashingProtectionResponse{}, fmt.Errorf("Could not decode import slashing protection response: %w", err)
+	}
+	if response.Error != "" {
+		return api.ImportSlashingProtectionResponse{}, fmt.Errorf("Could not import slashing protection: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Use an account on a hardware wallet as the node account
+func (c *Client) SetHardwareWalletAccount(walletType string, address common.Address, derivationPath string) (api.SetHardwareWalletAccountResponse, error) {
+	responseBytes, err := c.callAPI("wallet set-hardware-account", walletType, address.Hex(), derivationPath)
+	if err != nil {
+		return api.SetHardwareWalletAccountResponse{}, fmt.Errorf("Could not set hardware wallet account: %w", err)
+	}
+	var response api.SetHardwareWalletAccountResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.SetHardwareWalletAccountResponse{}, fmt.Errorf("Could not decode set hardware wallet account response: %w", err)
+	}
+	if response.Error != "" {
+		return api.SetHardwareWalletAccountResponse{}, fmt.Errorf("Could not set hardware wallet account: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Go back to using the account derived from the mnemonic as the node account
+func (c *Client) ClearHardwareWalletAccount() (api.ClearHardwareWalletAccountResponse, error) {
+	responseBytes, err := c.callAPI("wallet clear-hardware-account")
+	if err != nil {
+		return api.ClearHardwareWalletAccountResponse{}, fmt.Errorf("Could not clear hardware wallet account: %w", err)
+	}
+	var response api.ClearHardwareWalletAccountResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.ClearHardwareWalletAccountResponse{}, fmt.Errorf("Could not decode clear hardware wallet account response: %w", err)
+	}
+	if response.Error != "" {
+		return api.ClearHardwareWalletAccountResponse{}, fmt.Errorf("Could not clear hardware wallet account: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Get the transactions waiting to be signed by the hardware wallet
+func (c *Client) SigningQueue() (api.SigningQueueResponse, error) {
+	responseBytes, err := c.callAPI("wallet signing-queue")
+	if err != nil {
+		return api.SigningQueueResponse{}, fmt.Errorf("Could not get signing queue: %w", err)
+	}
+	var response api.SigningQueueResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.SigningQueueResponse{}, fmt.Errorf("Could not decode signing queue response: %w", err)
+	}
+	if response.Error != "" {
+		return api.SigningQueueResponse{}, fmt.Errorf("Could not get signing queue: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Get a queued transaction that's ready to be signed by the hardware wallet
+func (c *Client) PrepareQueuedTransaction(id string) (api.PrepareQueuedTransactionResponse, error) {
+	responseBytes, err := c.callAPI("wallet prepare-queued-tx", id)
+	if err != nil {
+		return api.PrepareQueuedTransactionResponse{}, fmt.Errorf("Could not prepare queued transaction: %w", err)
+	}
+	var response api.PrepareQueuedTransactionResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.PrepareQueuedTransactionResponse{}, fmt.Errorf("Could not decode prepare queued transaction response: %w", err)
+	}
+	if response.Error != "" {
+		return api.PrepareQueuedTransactionResponse{}, fmt.Errorf("Could not prepare queued transaction: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Submit a queued transaction that has been signed by the hardware wallet
+func (c *Client) SubmitQueuedTransaction(id string, signedTx string) (api.SubmitQueuedTransactionResponse, error) {
+	responseBytes, err := c.callAPI("wallet submit-queued-tx", id, signedTx)
+	if err != nil {
+		return api.SubmitQueuedTransactionResponse{}, fmt.Errorf("Could not submit queued transaction: %w", err)
+	}
+	var response api.SubmitQueuedTransactionResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.SubmitQueuedTransactionResponse{}, fmt.Errorf("Could not decode submit queued transaction response: %w", err)
+	}
+	if response.Error != "" {
+		return api.SubmitQueuedTransactionResponse{}, fmt.Errorf("Could not submit queued transaction: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Remove a transaction from the signing queue
+func (c *Client) RemoveQueuedTransaction(id string) (api.RemoveQueuedTransactionResponse, error) {
+	responseBytes, err := c.callAPI("wallet remove-queued-tx", id)
+	if err != nil {
+		return api.RemoveQueuedTransactionResponse{}, fmt.Errorf("Could not remove queued transaction: %w", err)
+	}
+	var response api.RemoveQueuedTransactionResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.RemoveQueuedTransactionResponse{}, fmt.Errorf("Could not decode remove queued transaction response: %w", err)
+	}
+	if response.Error != "" {
+		return api.RemoveQueuedTransactionResponse{}, fmt.Errorf("Could not remove queued transaction: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Get the flags for searching other derivation paths for validator keys during wallet recovery
+func getValidatorKeySearchFlags(searchValidatorPaths bool, validatorKeyPaths string, validatorKeyIndexRange string) string {
+	flags := ""
+	if searchValidatorPaths {
+		flags += "--search-validator-paths "
+	}
+	if validatorKeyPaths != "" {
+		flags += fmt.Sprintf("--validator-key-paths %s ", shellescape.Quote(validatorKeyPaths))
+	}
+	if validatorKeyIndexRange != "" {
+		flags += fmt.Sprintf("--validator-key-index-range %s ", shellescape.Quote(validatorKeyIndexRange))
+	}
+	return flags
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"math/big"
 	"os"
+	"path/filepath"
 	"sync"
 
 	"github.com/docker/docker/client"
@@ -17,14 +18,20 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services/beacon/nimbus"
 	"github.com/rocket-pool/smartnode/shared/services/beacon/prysm"
 	"github.com/rocket-pool/smartnode/shared/services/beacon/teku"
+	"github.com/rocket-pool/smartnode/shared/services/cache"
+	"github.com/rocket-pool/smartnode/shared/services/clock"
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	"github.com/rocket-pool/smartnode/shared/services/contracts"
+	"github.com/rocket-pool/smartnode/shared/services/notifications"
 	"github.com/rocket-pool/smartnode/shared/services/passwords"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/services/wallet/keystore"
 	lhkeystore "github.com/rocket-pool/smartnode/shared/services/wallet/keystore/lighthouse"
 	nmkeystore "github.com/rocket-pool/smartnode/shared/services/wallet/keystore/nimbus"
 	prkeystore "github.com/rocket-pool/smartnode/shared/services/wallet/keystore/prysm"
 	tkkeystore "github.com/rocket-pool/smartnode/shared/services/wallet/keystore/teku"
+	w3skeystore "github.com/rocket-pool/smartnode/shared/services/wallet/keystore/web3signer"
+	"github.com/rocket-pool/smartnode/shared/utils/multicall"
 	"github.com/rocket-pool/smartnode/shared/utils/rp"
 )
 
@@ -47,7 +54,12 @@ var (
 	rplFaucet          *contracts.RPLFaucet
 	snapshotDelegation *contracts.SnapshotDelegation
 	beaconClient       beacon.Client
+	beaconMultiplexer  *beacon.MultiplexedClient
 	docker             *client.Client
+	notifier           *notifications.Notifier
+	chainMonitor       *ChainMonitor
+	dataCache          *cache.Cache
+	daemonClock        clock.Clock = clock.NewSystemClock()
 
 	initCfg                sync.Once
 	initPasswordManager    sync.Once
@@ -59,6 +71,9 @@ var (
 	initSnapshotDelegation sync.Once
 	initBeaconClient       sync.Once
 	initDocker             sync.Once
+	initNotifier           sync.Once
+	initChainMonitor       sync.Once
+	initDataCache          sync.Once
 )
 
 //
@@ -155,10 +170,100 @@ func GetBeaconClient(c *cli.Context) (beacon.Client, error) {
 	return getBeaconClient(cfg)
 }
 
+// Get the multiplexer behind the Beacon client, which has the status and request statistics of each Beacon node
+func GetBeaconMultiplexer(c *cli.Context) (*beacon.MultiplexedClient, error) {
+	if _, err := GetBeaconClient(c); err != nil {
+		return nil, err
+	}
+	return beaconMultiplexer, nil
+}
+
 func GetDocker(c *cli.Context) (*client.Client, error) {
 	return getDocker()
 }
 
+func GetNotifier(c *cli.Context) (*notifications.Notifier, error) {
+	cfg, err := getConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	return getNotifier(cfg), nil
+}
+
+func GetCache(c *cli.Context) (*cache.Cache, error) {
+	cfg, err := getConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	return getCache(cfg), nil
+}
+
+// Get a new multicaller for batching contract calls, or nil if the network doesn't have a Multicall3 contract.
+// Multicallers hold the calls queued on them, so each caller gets its own.
+func GetMultiCaller(c *cli.Context) (*multicall.MultiCaller, error) {
+	cfg, err := getConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	ec, err := getEthClient(c, cfg)
+	if err != nil {
+		return nil, err
+	}
+	multicallAddress := cfg.Smartnode.GetMulticallAddress()
+	if multicallAddress == "" {
+		return nil, nil
+	}
+	return multicall.NewMultiCaller(ec, common.HexToAddress(multicallAddress))
+}
+
+func GetClock(c *cli.Context) clock.Clock {
+	return daemonClock
+}
+
+// Replace the clock the daemons' tasks are given, so their scheduling can be controlled.
+// This has to be called before any of the tasks are created.
+func SetClock(clk clock.Clock) {
+	daemonClock = clk
+}
+
+func GetChainMonitor(c *cli.Context) (*ChainMonitor, error) {
+	cfg, err := getConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := getBeaconClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return getChainMonitor(cfg, bc), nil
+}
+
+// Get the notifier for a daemon, labelled with the node address and connected to the EC manager so fallback switches are reported
+func GetDaemonNotifier(c *cli.Context) (*notifications.Notifier, error) {
+	n, err := GetNotifier(c)
+	if err != nil {
+		return nil, err
+	}
+	if !n.IsEnabled() {
+		return n, nil
+	}
+	w, err := GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	n.SetDefaultNodeLabel(nodeAccount.Address.Hex())
+	ec, err := GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+	ec.SetNotifier(n)
+	return n, nil
+}
+
 //
 // Service instance getters
 //
@@ -188,7 +293,7 @@ func getWallet(c *cli.Context, cfg *config.RocketPoolConfig, pm *passwords.Passw
 		var maxFee *big.Int
 		maxFeeFloat := c.GlobalFloat64("maxFee")
 		if maxFeeFloat == 0 {
-			maxFeeFloat = cfg.Smartnode.ManualMaxFee.Value.(float64)
+			maxFeeFloat = cfg.Smartnode.GetManualMaxFee()
 		}
 		if maxFeeFloat != 0 {
 			maxFee = eth.GweiToWei(maxFeeFloat)
@@ -197,7 +302,7 @@ func getWallet(c *cli.Context, cfg *config.RocketPoolConfig, pm *passwords.Passw
 		var maxPriorityFee *big.Int
 		maxPriorityFeeFloat := c.GlobalFloat64("maxPrioFee")
 		if maxPriorityFeeFloat == 0 {
-			maxPriorityFeeFloat = cfg.Smartnode.PriorityFee.Value.(float64)
+			maxPriorityFeeFloat = cfg.Smartnode.GetPriorityFee()
 		}
 		if maxPriorityFeeFloat != 0 {
 			maxPriorityFee = eth.GweiToWei(maxPriorityFeeFloat)
@@ -205,14 +310,68 @@ func getWallet(c *cli.Context, cfg *config.RocketPoolConfig, pm *passwords.Passw
 
 		chainId := cfg.Smartnode.GetChainID()
 
+		// Observe the provided node address without loading the wallet, so its status can be read on machines without its keys
+		var observedAddress common.Address
+		var observing bool
+		observedAddress, observing, err = getObservedNodeAddress(c)
+		if err != nil {
+			return
+		}
+		if observing {
+			nodeWallet = wallet.NewObserverWallet(chainId, observedAddress)
+			return
+		}
+
 		nodeWallet, err = wallet.NewWallet(os.ExpandEnv(cfg.Smartnode.GetWalletPath()), chainId, maxFee, maxPriorityFee, 0, pm)
 		if err != nil {
 			return
 		}
-		lighthouseKeystore := lhkeystore.NewKeystore(os.ExpandEnv(cfg.Smartnode.GetValidatorKeychainPath()), pm)
-		nimbusKeystore := nmkeystore.NewKeystore(os.ExpandEnv(cfg.Smartnode.GetValidatorKeychainPath()), pm)
-		prysmKeystore := prkeystore.NewKeystore(os.ExpandEnv(cfg.Smartnode.GetValidatorKeychainPath()), pm)
-		tekuKeystore := tkkeystore.NewKeystore(os.ExpandEnv(cfg.Smartnode.GetValidatorKeychainPath()), pm)
+		nodeWallet.SetSigningQueue(wallet.NewSigningQueue(os.ExpandEnv(cfg.Smartnode.GetSigningQueuePath())))
+		nodeWallet.SetNonceTracker(wallet.NewNonceTracker(os.ExpandEnv(cfg.Smartnode.GetNonceTrackerPath())))
+		keychainPath := os.ExpandEnv(cfg.Smartnode.GetValidatorKeychainPath())
+
+		// Validator keys are imported into the remote signer instead of being stored locally when it's enabled
+		if cfg.UseRemoteSigner.Value == true {
+			signerUrl := cfg.RemoteSigner.GetUrl()
+			var web3signerKeystore *w3skeystore.Keystore
+			web3signerKeystore, err = w3skeystore.NewKeystore(
+				signerUrl,
+				os.ExpandEnv(cfg.RemoteSigner.GetCaCertPath()),
+				os.ExpandEnv(cfg.RemoteSigner.GetClientCertPath()),
+				os.ExpandEnv(cfg.RemoteSigner.GetClientKeyPath()),
+			)
+			if err != nil {
+				return
+			}
+			nodeWallet.AddKeystore("web3signer", web3signerKeystore)
+			if cfg.GetSelectedConsensusClient() == config.ConsensusClient_Lighthouse {
+				nodeWallet.AddKeystore("lighthouse", lhkeystore.NewRemoteKeystore(keychainPath, signerUrl))
+			}
+			return
+		}
+
+		// Validator keys are split across the keystores of each Validator client shard when there's more than one
+		if shards := cfg.GetValidatorShardCount(); shards > 1 {
+			assignments := wallet.NewShardAssignments(os.ExpandEnv(cfg.Smartnode.GetValidatorShardAssignmentsPath()), shards)
+			nodeWallet.SetShardAssignments(assignments)
+			shardKeystores := map[string][]keystore.Keystore{}
+			for shard := uint64(0); shard < shards; shard++ {
+				shardPath := os.ExpandEnv(cfg.GetValidatorShardKeychainPath(shard))
+				shardKeystores["lighthouse"] = append(shardKeystores["lighthouse"], lhkeystore.NewKeystore(shardPath, pm))
+				shardKeystores["nimbus"] = append(shardKeystores["nimbus"], nmkeystore.NewKeystore(shardPath, pm))
+				shardKeystores["prysm"] = append(shardKeystores["prysm"], prkeystore.NewKeystore(shardPath, pm))
+				shardKeystores["teku"] = append(shardKeystores["teku"], tkkeystore.NewKeystore(shardPath, pm))
+			}
+			for name, keystores := range shardKeystores {
+				nodeWallet.AddKeystore(name, wallet.NewShardedKeystore(keystores, assignments))
+			}
+			return
+		}
+
+		lighthouseKeystore := lhkeystore.NewKeystore(keychainPath, pm)
+		nimbusKeystore := nmkeystore.NewKeystore(keychainPath, pm)
+		prysmKeystore := prkeystore.NewKeystore(keychainPath, pm)
+		tekuKeystore := tkkeystore.NewKeystore(keychainPath, pm)
 		nodeWallet.AddKeystore("lighthouse", lighthouseKeystore)
 		nodeWallet.AddKeystore("nimbus", nimbusKeystore)
 		nodeWallet.AddKeystore("prysm", prysmKeystore)
@@ -221,6 +380,18 @@ func getWallet(c *cli.Context, cfg *config.RocketPoolConfig, pm *passwords.Passw
 	return nodeWallet, err
 }
 
+// Get the node address to observe instead of loading the wallet, if one was provided
+func getObservedNodeAddress(c *cli.Context) (common.Address, bool, error) {
+	address := c.GlobalString("node-address")
+	if address == "" {
+		return common.Address{}, false, nil
+	}
+	if !common.IsHexAddress(address) {
+		return common.Address{}, false, fmt.Errorf("Invalid node address '%s'", address)
+	}
+	return common.HexToAddress(address), true, nil
+}
+
 func getEthClient(c *cli.Context, cfg *config.RocketPoolConfig) (*ExecutionClientManager, error) {
 	var err error
 	initEthClientProxy.Do(func() {
@@ -279,38 +450,72 @@ func getBeaconClient(cfg *config.RocketPoolConfig) (beacon.Client, error) {
 		if cfg.IsNativeMode {
 			provider = cfg.Native.CcHttpUrl.Value.(string)
 			selectedCC = cfg.Native.ConsensusClient.Value.(config.ConsensusClient)
-		} else if cfg.ConsensusClientMode.Value.(config.Mode) == config.Mode_Local {
-			provider = fmt.Sprintf("http://%s:%d", BnContainerName, cfg.ConsensusCommon.ApiPort.Value.(uint16))
-			selectedCC = cfg.ConsensusClient.Value.(config.ConsensusClient)
-		} else if cfg.ConsensusClientMode.Value.(config.Mode) == config.Mode_External {
+		} else if cfg.GetConsensusClientMode() == config.Mode_Local {
+			provider = fmt.Sprintf("http://%s:%d", BnContainerName, cfg.ConsensusCommon.GetApiPort())
+			selectedCC = cfg.GetSelectedConsensusClient()
+		} else if cfg.GetConsensusClientMode() == config.Mode_External {
 			var selectedConsensusConfig config.ConsensusConfig
 			selectedConsensusConfig, err = cfg.GetSelectedConsensusClientConfig()
 			if err != nil {
 				return
 			}
 			provider = selectedConsensusConfig.(config.ExternalConsensusConfig).GetApiUrl()
-			selectedCC = cfg.ExternalConsensusClient.Value.(config.ConsensusClient)
+			selectedCC = cfg.GetSelectedConsensusClient()
 		} else {
 			err = fmt.Errorf("Unknown Consensus client mode '%v'", cfg.ConsensusClientMode.Value)
 		}
 
-		switch selectedCC {
-		case config.ConsensusClient_Lighthouse:
-			beaconClient = lighthouse.NewClient(provider)
-		case config.ConsensusClient_Nimbus:
-			beaconClient = nimbus.NewClient(provider)
-		case config.ConsensusClient_Prysm:
-			beaconClient = prysm.NewClient(provider)
-		case config.ConsensusClient_Teku:
-			beaconClient = teku.NewClient(provider)
-		default:
-			err = fmt.Errorf("Unknown Consensus client '%v' selected", cfg.ConsensusClient.Value)
+		var primaryClient beacon.Client
+		primaryClient, err = newBeaconClient(selectedCC, provider)
+		if err != nil {
+			return
+		}
+
+		// Spread requests across the primary and fallback Beacon nodes, using whichever is synced
+		beaconMultiplexer = beacon.NewMultiplexedClient("primary", provider, primaryClient)
+		if fallbackUrl := cfg.Smartnode.GetFallbackCcUrl(); fallbackUrl != "" {
+			var fallbackClient beacon.Client
+			fallbackClient, err = newBeaconClient(cfg.Smartnode.FallbackCcClient.Value.(config.ConsensusClient), fallbackUrl)
+			if err != nil {
+				return
+			}
+			beaconMultiplexer.AddEndpoint("fallback", fallbackUrl, fallbackClient)
 		}
+		beaconClient = beaconMultiplexer
+
+		// Send historical queries the Beacon node has pruned to the archive node, if there is one
+		var archiveClient beacon.Client
+		if archiveUrl := cfg.Smartnode.GetArchiveCcUrl(); archiveUrl != "" {
+			archiveClient, err = newBeaconClient(selectedCC, archiveUrl)
+			if err != nil {
+				return
+			}
+		}
+		beaconClient = beacon.NewHistoryFallbackClient(beaconClient, archiveClient)
+
+		// Look validator indices up in the index cache before querying the Beacon node
+		beaconClient = beacon.NewIndexCachingClient(beaconClient, beacon.NewValidatorIndexCache(os.ExpandEnv(cfg.Smartnode.GetValidatorIndexCachePath())))
 
 	})
 	return beaconClient, err
 }
 
+// Create a Beacon client for the provided Consensus client type
+func newBeaconClient(selectedCC config.ConsensusClient, provider string) (beacon.Client, error) {
+	switch selectedCC {
+	case config.ConsensusClient_Lighthouse:
+		return lighthouse.NewClient(provider), nil
+	case config.ConsensusClient_Nimbus:
+		return nimbus.NewClient(provider), nil
+	case config.ConsensusClient_Prysm:
+		return prysm.NewClient(provider), nil
+	case config.ConsensusClient_Teku:
+		return teku.NewClient(provider), nil
+	default:
+		return nil, fmt.Errorf("Unknown Consensus client '%v' selected", selectedCC)
+	}
+}
+
 func getDocker() (*client.Client, error) {
 	var err error
 	initDocker.Do(func() {
@@ -318,3 +523,24 @@ func getDocker() (*client.Client, error) {
 	})
 	return docker, err
 }
+
+func getNotifier(cfg *config.RocketPoolConfig) *notifications.Notifier {
+	initNotifier.Do(func() {
+		notifier = notifications.NewNotifier(cfg)
+	})
+	return notifier
+}
+
+func getCache(cfg *config.RocketPoolConfig) *cache.Cache {
+	initDataCache.Do(func() {
+		dataCache = cache.NewCache(filepath.Join(os.ExpandEnv(cfg.Smartnode.GetCachePath()), string(cfg.Smartnode.GetNetwork())))
+	})
+	return dataCache
+}
+
+func getChainMonitor(cfg *config.RocketPoolConfig, bc beacon.Client) *ChainMonitor {
+	initChainMonitor.Do(func() {
+		chainMonitor = NewChainMonitor(cfg, bc, getNotifier(cfg))
+	})
+	return chainMonitor
+}
@@ -0,0 +1,157 @@
+package sponsor
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Config
+const (
+	RequestContentType = "application/json"
+)
+
+// A destination contract and method that may be submitted through the sponsor
+type AllowedCall struct {
+	To       common.Address
+	Selector [4]byte
+}
+
+// The request sent to the sponsor
+type sponsorRequest struct {
+	ChainID       *hexutil.Big   `json:"chainId"`
+	From          common.Address `json:"from"`
+	SignedTx      hexutil.Bytes  `json:"signedTx"`
+	RequiredFunds *hexutil.Big   `json:"requiredFunds"`
+}
+
+// The sponsor's response
+type sponsorResponse struct {
+	TxHash common.Hash `json:"txHash"`
+	Error  string      `json:"error"`
+}
+
+// Client for a sponsor that funds the gas of allow-listed node transactions and then relays them.
+// A transaction will only be submitted if it calls an allowed method on an allowed contract and doesn't send any ETH.
+type Client struct {
+	url     string
+	chainID *big.Int
+	allowed []AllowedCall
+}
+
+// Create a new sponsor client
+func NewClient(url string, chainID *big.Int) *Client {
+	return &Client{
+		url:     url,
+		chainID: chainID,
+		allowed: []AllowedCall{},
+	}
+}
+
+// Allow a method on a contract to be submitted through the sponsor
+func (c *Client) Allow(to common.Address, method abi.Method) {
+	call := AllowedCall{To: to}
+	copy(call.Selector[:], method.ID)
+	c.allowed = append(c.allowed, call)
+}
+
+// Prepare transactor options so a contract binding signs a transaction without sending it.
+// The returned function provides the signed transaction once the binding has been called.
+func (c *Client) Capture(opts *bind.TransactOpts) func() *types.Transaction {
+	var signedTx *types.Transaction
+	signer := opts.Signer
+	opts.NoSend = true
+	opts.Signer = func(address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+		signed, err := signer(address, tx)
+		if err == nil {
+			signedTx = signed
+		}
+		return signed, err
+	}
+	return func() *types.Transaction {
+		return signedTx
+	}
+}
+
+// Submit a signed transaction to the sponsor, which funds its gas cost and broadcasts it
+func (c *Client) Submit(tx *types.Transaction, from common.Address) (common.Hash, error) {
+
+	// Verify the transaction against the allow list
+	if err := c.checkAllowed(tx); err != nil {
+		return common.Hash{}, fmt.Errorf("Transaction %s cannot be sponsored: %w", tx.Hash().Hex(), err)
+	}
+
+	// Get the request body
+	requiredFunds := new(big.Int).Mul(tx.GasFeeCap(), new(big.Int).SetUint64(tx.Gas()))
+	txBytes, err := tx.MarshalBinary()
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("Could not serialize transaction: %w", err)
+	}
+	requestBody, err := json.Marshal(sponsorRequest{
+		ChainID:       (*hexutil.Big)(c.chainID),
+		From:          from,
+		SignedTx:      txBytes,
+		RequiredFunds: (*hexutil.Big)(requiredFunds),
+	})
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	// Send request
+	response, err := http.Post(c.url, RequestContentType, bytes.NewReader(requestBody))
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("Could not reach the transaction sponsor: %w", err)
+	}
+	defer func() {
+		_ = response.Body.Close()
+	}()
+	body, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	// Check the response
+	var sponsorResp sponsorResponse
+	if err := json.Unmarshal(body, &sponsorResp); err != nil {
+		return common.Hash{}, fmt.Errorf("Could not decode the transaction sponsor response: %w", err)
+	}
+	if response.StatusCode != http.StatusOK {
+		return common.Hash{}, fmt.Errorf("The transaction sponsor rejected the transaction with code %d: %s", response.StatusCode, sponsorResp.Error)
+	}
+	if sponsorResp.TxHash != tx.Hash() {
+		return common.Hash{}, fmt.Errorf("The transaction sponsor returned hash %s instead of %s", sponsorResp.TxHash.Hex(), tx.Hash().Hex())
+	}
+	return sponsorResp.TxHash, nil
+
+}
+
+// Check that a transaction calls an allowed method on an allowed contract
+func (c *Client) checkAllowed(tx *types.Transaction) error {
+	if tx.ChainId().Cmp(c.chainID) != 0 {
+		return fmt.Errorf("chain ID %s does not match the expected chain ID %s", tx.ChainId().String(), c.chainID.String())
+	}
+	if tx.To() == nil {
+		return fmt.Errorf("contract deployments are not allowed")
+	}
+	if tx.Value().Sign() != 0 {
+		return fmt.Errorf("transactions that send ETH are not allowed")
+	}
+	if len(tx.Data()) < 4 {
+		return fmt.Errorf("transactions without a method call are not allowed")
+	}
+	for _, call := range c.allowed {
+		if call.To == *tx.To() && bytes.Equal(call.Selector[:], tx.Data()[:4]) {
+			return nil
+		}
+	}
+	return fmt.Errorf("method %s on %s is not on the allow list", hexutil.Encode(tx.Data()[:4]), tx.To().Hex())
+}
@@ -0,0 +1,336 @@
+package txqueue
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+
+	"github.com/rocket-pool/smartnode/shared/services/clock"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Retry settings
+var baseRetryDelay, _ = time.ParseDuration("5m")
+var maxRetryDelay, _ = time.ParseDuration("1h")
+var minedRetention, _ = time.ParseDuration("24h")
+
+// Each failed attempt raises a transaction's fees by this fraction of the original fees, in eighths
+const feeEscalationEighths int64 = 1
+
+// Execution clients only accept a replacement transaction if its fees are at least this percentage of the original's
+const replacementFeePercent int64 = 110
+
+// A durable queue of the transactions that the node daemon's tasks are trying to get mined.
+// Tasks record their transactions here so failed or stuck ones are retried with backoff and escalating fees across runs and restarts.
+type Queue struct {
+	path  string
+	clock clock.Clock
+	lock  sync.Mutex
+}
+
+// Create a new transaction queue that's stored in the provided file, scheduling retries with the provided clock
+func NewQueue(path string, clk clock.Clock) *Queue {
+	return &Queue{
+		path:  path,
+		clock: clk,
+	}
+}
+
+// Get all of the transactions in the queue, oldest first
+func (q *Queue) List() ([]api.TxQueueEntry, error) {
+
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	queued, err := q.load()
+	if err != nil {
+		return nil, err
+	}
+	txs := make([]api.TxQueueEntry, 0, len(queued))
+	for _, tx := range queued {
+		txs = append(txs, tx)
+	}
+	sort.Slice(txs, func(i, j int) bool {
+		return txs[i].Created.Before(txs[j].Created)
+	})
+	return txs, nil
+
+}
+
+// Get a queued transaction, checking whether it's been mined if it was submitted; returns nil if it isn't in the queue
+func (q *Queue) Refresh(id string, ec rocketpool.ExecutionClient) (*api.TxQueueEntry, error) {
+
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	queued, err := q.load()
+	if err != nil {
+		return nil, err
+	}
+	tx, exists := queued[id]
+	if !exists {
+		return nil, nil
+	}
+	if tx.State != api.TxQueueState_Submitted {
+		return &tx, nil
+	}
+
+	// Check the submitted transaction
+	receipt, err := ec.TransactionReceipt(context.Background(), tx.Hash)
+	if err == ethereum.NotFound {
+		return &tx, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Could not get the receipt for queued transaction %s: %w", tx.Hash.Hex(), err)
+	}
+	if receipt.Status == 0 {
+		setFailed(&tx, q.clock.Now(), fmt.Errorf("Transaction %s failed with status 0", tx.Hash.Hex()))
+	} else {
+		tx.State = api.TxQueueState_Mined
+		tx.Updated = q.clock.Now()
+	}
+	queued[id] = tx
+	if err := q.save(queued); err != nil {
+		return nil, err
+	}
+	return &tx, nil
+
+}
+
+// Record that a task is holding off on a transaction, usually because gas is too high
+func (q *Queue) SetWaiting(id string, task string, description string, reason string) error {
+	return q.update(id, task, description, func(tx *api.TxQueueEntry) {
+		if tx.State != api.TxQueueState_Retrying && tx.State != api.TxQueueState_Submitted {
+			tx.State = api.TxQueueState_Waiting
+			tx.LastError = reason
+		}
+	})
+}
+
+// Record that a transaction has been submitted
+func (q *Queue) SetSubmitted(id string, task string, description string, hash common.Hash, nonce uint64, maxFee *big.Int, priorityFee *big.Int) error {
+	return q.update(id, task, description, func(tx *api.TxQueueEntry) {
+		if tx.State == api.TxQueueState_Submitted {
+			// Replacing a transaction that got stuck counts as another attempt
+			tx.Attempts++
+		}
+		tx.State = api.TxQueueState_Submitted
+		tx.Hash = hash
+		tx.Nonce = nonce
+		tx.MaxFee = maxFee
+		tx.PriorityFee = priorityFee
+		tx.NextAttempt = q.clock.Now().Add(getRetryDelay(tx.Attempts + 1))
+	})
+}
+
+// Record that a transaction couldn't be submitted or failed on-chain, scheduling its next attempt
+func (q *Queue) SetFailed(id string, task string, description string, err error) error {
+	return q.update(id, task, description, func(tx *api.TxQueueEntry) {
+		setFailed(tx, q.clock.Now(), err)
+	})
+}
+
+// Record that a replacement for a pending transaction couldn't be submitted.
+// The original transaction is still pending, so it stays submitted and the replacement is retried with higher fees.
+func (q *Queue) SetReplacementFailed(id string, task string, description string, err error) error {
+	return q.update(id, task, description, func(tx *api.TxQueueEntry) {
+		setFailed(tx, q.clock.Now(), err)
+		tx.State = api.TxQueueState_Submitted
+	})
+}
+
+// Record that a transaction has been mined
+func (q *Queue) SetMined(id string) error {
+
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	queued, err := q.load()
+	if err != nil {
+		return err
+	}
+	tx, exists := queued[id]
+	if !exists {
+		return nil
+	}
+	tx.State = api.TxQueueState_Mined
+	tx.Updated = q.clock.Now()
+	tx.LastError = ""
+	queued[id] = tx
+	return q.save(queued)
+
+}
+
+// Remove the transactions for a task that are no longer needed, keeping the ones in keep and anything that's been mined
+func (q *Queue) Prune(task string, keep []string) error {
+
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	queued, err := q.load()
+	if err != nil {
+		return err
+	}
+	keepIDs := map[string]bool{}
+	for _, id := range keep {
+		keepIDs[id] = true
+	}
+	pruned := false
+	for id, tx := range queued {
+		if tx.Task == task && tx.State != api.TxQueueState_Mined && !keepIDs[id] {
+			delete(queued, id)
+			pruned = true
+		}
+	}
+	if !pruned {
+		return nil
+	}
+	return q.save(queued)
+
+}
+
+// Get the fees to use for the next attempt at a queued transaction.
+// Each failed attempt raises the fees by an eighth of the current suggestion, and replacing a pending transaction raises them by at least the amount Execution clients require.
+// The max fee is never escalated above maxFeeCap, unless the current suggestion is already higher.
+func GetRetryFees(tx *api.TxQueueEntry, maxFee *big.Int, priorityFee *big.Int, maxFeeCap *big.Int) (*big.Int, *big.Int) {
+
+	if tx == nil || tx.State == api.TxQueueState_Mined {
+		return maxFee, priorityFee
+	}
+
+	// Escalate the fees for each failed attempt
+	escalate := func(fee *big.Int) *big.Int {
+		escalated := new(big.Int).Mul(fee, big.NewInt(8+feeEscalationEighths*int64(tx.Attempts)))
+		return escalated.Div(escalated, big.NewInt(8))
+	}
+	newMaxFee := escalate(maxFee)
+	newPriorityFee := escalate(priorityFee)
+
+	// Make sure a replacement for a pending transaction will be accepted
+	if tx.State == api.TxQueueState_Submitted {
+		bump := func(fee *big.Int, previous *big.Int) *big.Int {
+			if previous == nil {
+				return fee
+			}
+			minimum := new(big.Int).Mul(previous, big.NewInt(replacementFeePercent))
+			minimum.Div(minimum, big.NewInt(100))
+			if fee.Cmp(minimum) < 0 {
+				return minimum
+			}
+			return fee
+		}
+		newMaxFee = bump(newMaxFee, tx.MaxFee)
+		newPriorityFee = bump(newPriorityFee, tx.PriorityFee)
+	}
+
+	// Cap the fees
+	if maxFeeCap != nil && maxFeeCap.Cmp(maxFee) < 0 {
+		maxFeeCap = maxFee
+	}
+	if maxFeeCap != nil && maxFeeCap.Sign() > 0 && newMaxFee.Cmp(maxFeeCap) > 0 {
+		newMaxFee = new(big.Int).Set(maxFeeCap)
+	}
+	if newPriorityFee.Cmp(newMaxFee) > 0 {
+		newPriorityFee = new(big.Int).Set(newMaxFee)
+	}
+	return newMaxFee, newPriorityFee
+
+}
+
+// Get the delay before the next attempt at a transaction, which doubles with each attempt
+func getRetryDelay(attempts uint64) time.Duration {
+	delay := baseRetryDelay
+	for i := uint64(1); i < attempts && delay < maxRetryDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxRetryDelay {
+		delay = maxRetryDelay
+	}
+	return delay
+}
+
+// Record a failed attempt at a transaction
+func setFailed(tx *api.TxQueueEntry, now time.Time, err error) {
+	tx.State = api.TxQueueState_Retrying
+	tx.Attempts++
+	tx.LastError = err.Error()
+	tx.Updated = now
+	tx.NextAttempt = tx.Updated.Add(getRetryDelay(tx.Attempts))
+}
+
+// Update a transaction in the queue, adding it if it isn't there yet
+func (q *Queue) update(id string, task string, description string, modify func(tx *api.TxQueueEntry)) error {
+
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	queued, err := q.load()
+	if err != nil {
+		return err
+	}
+	tx, exists := queued[id]
+	if !exists || tx.State == api.TxQueueState_Mined {
+		tx = api.TxQueueEntry{
+			ID:      id,
+			Task:    task,
+			Created: q.clock.Now(),
+		}
+	}
+	tx.Description = description
+	modify(&tx)
+	tx.Updated = q.clock.Now()
+	queued[id] = tx
+	return q.save(queued)
+
+}
+
+// Load the queue
+func (q *Queue) load() (map[string]api.TxQueueEntry, error) {
+	bytes, err := ioutil.ReadFile(q.path)
+	if os.IsNotExist(err) {
+		return map[string]api.TxQueueEntry{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Could not read the transaction queue file: %w", err)
+	}
+	queued := map[string]api.TxQueueEntry{}
+	if err := json.Unmarshal(bytes, &queued); err != nil {
+		return nil, fmt.Errorf("Could not decode the transaction queue file: %w", err)
+	}
+	return queued, nil
+}
+
+// Save the queue, dropping transactions that were mined a while ago
+func (q *Queue) save(queued map[string]api.TxQueueEntry) error {
+	for id, tx := range queued {
+		if tx.State == api.TxQueueState_Mined && q.clock.Since(tx.Updated) > minedRetention {
+			delete(queued, id)
+		}
+	}
+	bytes, err := json.Marshal(queued)
+	if err != nil {
+		return fmt.Errorf("Could not encode the transaction queue file: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(q.path), 0700); err != nil {
+		return fmt.Errorf("Could not create the transaction queue folder: %w", err)
+	}
+	tempPath := q.path + ".tmp"
+	if err := ioutil.WriteFile(tempPath, bytes, 0600); err != nil {
+		return fmt.Errorf("Could not write the transaction queue file: %w", err)
+	}
+	if err := os.Rename(tempPath, q.path); err != nil {
+		return fmt.Errorf("Could not replace the transaction queue file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,147 @@
+package wallet
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Hardware wallet types that can hold the node account
+const (
+	HardwareWalletType_Ledger string = "ledger"
+	HardwareWalletType_Trezor string = "trezor"
+)
+
+// Returned when something needs the node account's key while the wallet is only observing a node
+var ErrObserverMode = errors.New("The Smartnode is observing a node address without a wallet loaded, so it can't sign transactions or access private keys.")
+
+// A backend that holds the node account's key
+type nodeBackend interface {
+
+	// Get the node account
+	getAccount() (accounts.Account, error)
+
+	// Create a transactor that signs transactions for the node account
+	newTransactor() (*bind.TransactOpts, error)
+
+	// Get the node account's private key, if the backend allows it to leave
+	getPrivateKey() (*ecdsa.PrivateKey, error)
+}
+
+// The node account on a hardware wallet, as stored in the wallet file
+type hardwareAccount struct {
+	Type           string         `json:"type"`
+	Address        common.Address `json:"address"`
+	DerivationPath string         `json:"derivationPath"`
+}
+
+// The node account derived from the wallet's mnemonic
+type localBackend struct {
+	w *Wallet
+}
+
+func (b *localBackend) getAccount() (accounts.Account, error) {
+
+	// Get private key
+	privateKey, path, err := b.w.getNodePrivateKey()
+	if err != nil {
+		return accounts.Account{}, err
+	}
+
+	// Get public key
+	publicKey := privateKey.Public()
+	publicKeyECDSA, ok := publicKey.(*ecdsa.PublicKey)
+	if !ok {
+		return accounts.Account{}, errors.New("Could not get node public key")
+	}
+
+	// Create & return account
+	return accounts.Account{
+		Address: crypto.PubkeyToAddress(*publicKeyECDSA),
+		URL: accounts.URL{
+			Scheme: "",
+			Path:   path,
+		},
+	}, nil
+
+}
+
+func (b *localBackend) newTransactor() (*bind.TransactOpts, error) {
+	privateKey, _, err := b.w.getNodePrivateKey()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewKeyedTransactorWithChainID(privateKey, b.w.chainID)
+}
+
+func (b *localBackend) getPrivateKey() (*ecdsa.PrivateKey, error) {
+	privateKey, _, err := b.w.getNodePrivateKey()
+	return privateKey, err
+}
+
+// The node account on a hardware wallet.
+// The daemon can't reach the device, so transactions are added to the signing queue for the CLI to sign instead of being sent.
+type hardwareBackend struct {
+	account *hardwareAccount
+	queue   *SigningQueue
+}
+
+func (b *hardwareBackend) getAccount() (accounts.Account, error) {
+	return accounts.Account{
+		Address: b.account.Address,
+		URL: accounts.URL{
+			Scheme: b.account.Type,
+			Path:   b.account.DerivationPath,
+		},
+	}, nil
+}
+
+func (b *hardwareBackend) newTransactor() (*bind.TransactOpts, error) {
+	if b.queue == nil {
+		return nil, errors.New("The node account is on a hardware wallet, but there is no signing queue for its transactions")
+	}
+	from := b.account.Address
+	return &bind.TransactOpts{
+		From: from,
+		Signer: func(address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+			if address != from {
+				return nil, bind.ErrNotAuthorized
+			}
+			queued, err := b.queue.Add(tx)
+			if err != nil {
+				return nil, err
+			}
+			return nil, fmt.Errorf("%w (queue ID %s)", ErrTransactionQueued, queued.ID)
+		},
+	}, nil
+}
+
+func (b *hardwareBackend) getPrivateKey() (*ecdsa.PrivateKey, error) {
+	return nil, fmt.Errorf("The node account is on a %s hardware wallet, so its private key can't be used directly", b.account.Type)
+}
+
+// A node address that's being observed without a wallet, for monitoring a node from another machine.
+// Everything that only needs the address works; everything that needs the key returns ErrObserverMode.
+type observerBackend struct {
+	address common.Address
+}
+
+func (b *observerBackend) getAccount() (accounts.Account, error) {
+	return accounts.Account{
+		Address: b.address,
+	}, nil
+}
+
+func (b *observerBackend) newTransactor() (*bind.TransactOpts, error) {
+	return nil, ErrObserverMode
+}
+
+func (b *observerBackend) getPrivateKey() (*ecdsa.PrivateKey, error) {
+	return nil, ErrObserverMode
+}
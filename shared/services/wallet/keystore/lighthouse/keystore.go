@@ -79,15 +79,15 @@ func (ks *Keystore) StoreValidatorKey(key *eth2types.BLSPrivateKey, derivationPa
 		Pubkey:  pubkey,
 	}
 
+	// Get key and secret file paths
+	keyFilePath, secretFilePath := GetValidatorKeyPaths(ks.keystorePath, pubkey)
+
 	// Encode key store
 	keyStoreBytes, err := json.Marshal(keyStore)
 	if err != nil {
 		return fmt.Errorf("Could not encode validator key: %w", err)
 	}
 
-	// Get secret file path
-	secretFilePath := filepath.Join(ks.keystorePath, KeystoreDir, SecretsDir, hexutil.AddPrefix(pubkey.Hex()))
-
 	// Create secrets dir
 	if err := os.MkdirAll(filepath.Dir(secretFilePath), DirMode); err != nil {
 		return fmt.Errorf("Could not create validator secrets folder: %w", err)
@@ -98,9 +98,6 @@ func (ks *Keystore) StoreValidatorKey(key *eth2types.BLSPrivateKey, derivationPa
 		return fmt.Errorf("Could not write validator secret to disk: %w", err)
 	}
 
-	// Get key file path
-	keyFilePath := filepath.Join(ks.keystorePath, KeystoreDir, ValidatorsDir, hexutil.AddPrefix(pubkey.Hex()), KeyFileName)
-
 	// Create key dir
 	if err := os.MkdirAll(filepath.Dir(keyFilePath), DirMode); err != nil {
 		return fmt.Errorf("Could not create validator key folder: %w", err)
@@ -115,3 +112,10 @@ func (ks *Keystore) StoreValidatorKey(key *eth2types.BLSPrivateKey, derivationPa
 	return nil
 
 }
+
+// Get the paths of a validator key's key store and secret files
+func GetValidatorKeyPaths(keystorePath string, pubkey rptypes.ValidatorPubkey) (string, string) {
+	keyFilePath := filepath.Join(keystorePath, KeystoreDir, ValidatorsDir, hexutil.AddPrefix(pubkey.Hex()), KeyFileName)
+	secretFilePath := filepath.Join(keystorePath, KeystoreDir, SecretsDir, hexutil.AddPrefix(pubkey.Hex()))
+	return keyFilePath, secretFilePath
+}
@@ -0,0 +1,91 @@
+package lighthouse
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
+	eth2types "github.com/wealdtech/go-eth2-types/v2"
+	"gopkg.in/yaml.v2"
+
+	hexutil "github.com/rocket-pool/smartnode/shared/utils/hex"
+)
+
+// Config
+const (
+	DefinitionsFileName = "validator_definitions.yml"
+	Web3SignerType      = "web3signer"
+)
+
+// Lighthouse remote keystore, which registers validator keys held by a remote signer with Lighthouse.
+// The keys themselves are never written to disk.
+type RemoteKeystore struct {
+	keystorePath string
+	signerUrl    string
+}
+
+// Create new lighthouse remote keystore
+func NewRemoteKeystore(keystorePath string, signerUrl string) *RemoteKeystore {
+	return &RemoteKeystore{
+		keystorePath: keystorePath,
+		signerUrl:    signerUrl,
+	}
+}
+
+// Store a validator key by adding it to the Lighthouse validator definitions
+func (ks *RemoteKeystore) StoreValidatorKey(key *eth2types.BLSPrivateKey, derivationPath string) error {
+
+	// Get validator pubkey
+	pubkey := hexutil.AddPrefix(rptypes.BytesToValidatorPubkey(key.PublicKey().Marshal()).Hex())
+
+	// Read the existing definitions, which Lighthouse also manages, so they're kept as-is
+	definitionsPath := filepath.Join(ks.keystorePath, KeystoreDir, ValidatorsDir, DefinitionsFileName)
+	definitions := []yaml.MapSlice{}
+	bytes, err := ioutil.ReadFile(definitionsPath)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("Could not read validator definitions: %w", err)
+	}
+	if err == nil {
+		if err := yaml.Unmarshal(bytes, &definitions); err != nil {
+			return fmt.Errorf("Could not parse validator definitions: %w", err)
+		}
+	}
+
+	// Check if the key has already been registered
+	for _, definition := range definitions {
+		for _, item := range definition {
+			if item.Key == "voting_public_key" && item.Value == pubkey {
+				return nil
+			}
+		}
+	}
+
+	// Add the key
+	definitions = append(definitions, yaml.MapSlice{
+		{Key: "enabled", Value: true},
+		{Key: "voting_public_key", Value: pubkey},
+		{Key: "description", Value: fmt.Sprintf("Rocket Pool validator %s", derivationPath)},
+		{Key: "type", Value: Web3SignerType},
+		{Key: "url", Value: ks.signerUrl},
+	})
+	bytes, err = yaml.Marshal(definitions)
+	if err != nil {
+		return fmt.Errorf("Could not encode validator definitions: %w", err)
+	}
+
+	// Create key dir
+	if err := os.MkdirAll(filepath.Dir(definitionsPath), DirMode); err != nil {
+		return fmt.Errorf("Could not create validator key folder: %w", err)
+	}
+
+	// Write the definitions to disk
+	if err := ioutil.WriteFile(definitionsPath, bytes, FileMode); err != nil {
+		return fmt.Errorf("Could not write validator definitions to disk: %w", err)
+	}
+
+	// Return
+	return nil
+
+}
@@ -22,6 +22,7 @@ const (
 	KeystoreDir   = "teku"
 	SecretsDir    = "passwords"
 	ValidatorsDir = "keys"
+	KeyManagerDir = "validator/key-manager/local"
 	DirMode       = 0700
 	FileMode      = 0600
 )
@@ -57,6 +58,11 @@ func (ks *Keystore) StoreValidatorKey(key *eth2types.BLSPrivateKey, derivationPa
 	// Get validator pubkey
 	pubkey := rptypes.BytesToValidatorPubkey(key.PublicKey().Marshal())
 
+	// Teku keeps the keys imported through its Keymanager API in its own store and loads them from there, so don't store a second copy
+	if _, err := os.Stat(getKeyManagerKeyPath(ks.keystorePath, pubkey)); err == nil {
+		return nil
+	}
+
 	// Create a new password
 	password, err := keystore.GenerateRandomPassword()
 	if err != nil {
@@ -78,15 +84,15 @@ func (ks *Keystore) StoreValidatorKey(key *eth2types.BLSPrivateKey, derivationPa
 		Pubkey:  pubkey,
 	}
 
+	// Get key and secret file paths
+	keyFilePath, secretFilePath := GetValidatorKeyPaths(ks.keystorePath, pubkey)
+
 	// Encode key store
 	keyStoreBytes, err := json.Marshal(keyStore)
 	if err != nil {
 		return fmt.Errorf("Could not encode validator key: %w", err)
 	}
 
-	// Get secret file path
-	secretFilePath := filepath.Join(ks.keystorePath, KeystoreDir, SecretsDir, hexutil.AddPrefix(pubkey.Hex())+".txt")
-
 	// Create secrets dir
 	if err := os.MkdirAll(filepath.Dir(secretFilePath), DirMode); err != nil {
 		return fmt.Errorf("Could not create validator secrets folder: %w", err)
@@ -97,9 +103,6 @@ func (ks *Keystore) StoreValidatorKey(key *eth2types.BLSPrivateKey, derivationPa
 		return fmt.Errorf("Could not write validator secret to disk: %w", err)
 	}
 
-	// Get key file path
-	keyFilePath := filepath.Join(ks.keystorePath, KeystoreDir, ValidatorsDir, hexutil.AddPrefix(pubkey.Hex())+".json")
-
 	// Create key dir
 	if err := os.MkdirAll(filepath.Dir(keyFilePath), DirMode); err != nil {
 		return fmt.Errorf("Could not create validator key folder: %w", err)
@@ -114,3 +117,15 @@ func (ks *Keystore) StoreValidatorKey(key *eth2types.BLSPrivateKey, derivationPa
 	return nil
 
 }
+
+// Get the paths of a validator key's key store and secret files
+func GetValidatorKeyPaths(keystorePath string, pubkey rptypes.ValidatorPubkey) (string, string) {
+	keyFilePath := filepath.Join(keystorePath, KeystoreDir, ValidatorsDir, hexutil.AddPrefix(pubkey.Hex())+".json")
+	secretFilePath := filepath.Join(keystorePath, KeystoreDir, SecretsDir, hexutil.AddPrefix(pubkey.Hex())+".txt")
+	return keyFilePath, secretFilePath
+}
+
+// Get the path of a validator key in Teku's Keymanager API store
+func getKeyManagerKeyPath(keystorePath string, pubkey rptypes.ValidatorPubkey) string {
+	return filepath.Join(keystorePath, KeystoreDir, KeyManagerDir, pubkey.Hex()+".json")
+}
@@ -0,0 +1,170 @@
+package web3signer
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
+	eth2types "github.com/wealdtech/go-eth2-types/v2"
+	eth2ks "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+
+	keystore "github.com/rocket-pool/smartnode/shared/services/wallet/keystore"
+)
+
+// Config
+const (
+	KeystoresPath      = "/eth/v1/keystores"
+	RequestContentType = "application/json"
+	RequestTimeout     = 30 * time.Second
+
+	ImportStatusImported  = "imported"
+	ImportStatusDuplicate = "duplicate"
+)
+
+// Web3Signer keystore, which imports validator keys into a remote signer using its Keymanager API
+type Keystore struct {
+	url       string
+	client    *http.Client
+	encryptor *eth2ks.Encryptor
+}
+
+// Encrypted validator key store
+type validatorKey struct {
+	Crypto  map[string]interface{}  `json:"crypto"`
+	Version uint                    `json:"version"`
+	UUID    uuid.UUID               `json:"uuid"`
+	Path    string                  `json:"path"`
+	Pubkey  rptypes.ValidatorPubkey `json:"pubkey"`
+}
+
+// Keymanager API import request
+type importRequest struct {
+	Keystores []string `json:"keystores"`
+	Passwords []string `json:"passwords"`
+}
+
+// Keymanager API import response
+type importResponse struct {
+	Data []struct {
+		Status  string `json:"status"`
+		Message string `json:"message"`
+	} `json:"data"`
+	Message string `json:"message"`
+}
+
+// Create new web3signer keystore.
+// The CA certificate, client certificate and client key paths are optional and may be left blank.
+func NewKeystore(url string, caCertPath string, clientCertPath string, clientKeyPath string) (*Keystore, error) {
+
+	// Set up TLS
+	tlsConfig := &tls.Config{}
+	if caCertPath != "" {
+		caCert, err := ioutil.ReadFile(caCertPath)
+		if err != nil {
+			return nil, fmt.Errorf("Could not read remote signer CA certificate: %w", err)
+		}
+		certPool := x509.NewCertPool()
+		if !certPool.AppendCertsFromPEM(caCert) {
+			return nil, fmt.Errorf("Could not parse remote signer CA certificate %s", caCertPath)
+		}
+		tlsConfig.RootCAs = certPool
+	}
+	if clientCertPath != "" {
+		clientCert, err := tls.LoadX509KeyPair(clientCertPath, clientKeyPath)
+		if err != nil {
+			return nil, fmt.Errorf("Could not load remote signer client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{clientCert}
+	}
+
+	return &Keystore{
+		url: strings.TrimSuffix(url, "/"),
+		client: &http.Client{
+			Timeout: RequestTimeout,
+			Transport: &http.Transport{
+				TLSClientConfig: tlsConfig,
+			},
+		},
+		encryptor: eth2ks.New(eth2ks.WithCipher("scrypt")),
+	}, nil
+
+}
+
+// Store a validator key
+func (ks *Keystore) StoreValidatorKey(key *eth2types.BLSPrivateKey, derivationPath string) error {
+
+	// Get validator pubkey
+	pubkey := rptypes.BytesToValidatorPubkey(key.PublicKey().Marshal())
+
+	// Create a new password
+	password, err := keystore.GenerateRandomPassword()
+	if err != nil {
+		return fmt.Errorf("Could not generate random password: %w", err)
+	}
+
+	// Encrypt key
+	encryptedKey, err := ks.encryptor.Encrypt(key.Marshal(), password)
+	if err != nil {
+		return fmt.Errorf("Could not encrypt validator key: %w", err)
+	}
+
+	// Encode key store
+	keyStoreBytes, err := json.Marshal(validatorKey{
+		Crypto:  encryptedKey,
+		Version: ks.encryptor.Version(),
+		UUID:    uuid.New(),
+		Path:    derivationPath,
+		Pubkey:  pubkey,
+	})
+	if err != nil {
+		return fmt.Errorf("Could not encode validator key: %w", err)
+	}
+
+	// Import it into the remote signer
+	requestBody, err := json.Marshal(importRequest{
+		Keystores: []string{string(keyStoreBytes)},
+		Passwords: []string{password},
+	})
+	if err != nil {
+		return err
+	}
+	response, err := ks.client.Post(ks.url+KeystoresPath, RequestContentType, bytes.NewReader(requestBody))
+	if err != nil {
+		return fmt.Errorf("Could not reach the remote signer: %w", err)
+	}
+	defer func() {
+		_ = response.Body.Close()
+	}()
+	body, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return err
+	}
+
+	// Check the response
+	var importResp importResponse
+	if err := json.Unmarshal(body, &importResp); err != nil {
+		return fmt.Errorf("Could not decode the remote signer response: %w", err)
+	}
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("The remote signer rejected validator key %s with code %d: %s", pubkey.Hex(), response.StatusCode, importResp.Message)
+	}
+	if len(importResp.Data) != 1 {
+		return fmt.Errorf("The remote signer returned %d results for 1 imported validator key", len(importResp.Data))
+	}
+	status := importResp.Data[0]
+	if status.Status != ImportStatusImported && status.Status != ImportStatusDuplicate {
+		return fmt.Errorf("The remote signer could not import validator key %s (%s): %s", pubkey.Hex(), status.Status, status.Message)
+	}
+
+	// Return
+	return nil
+
+}
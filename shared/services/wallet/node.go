@@ -10,6 +10,8 @@ import (
 	"github.com/btcsuite/btcutil/hdkeychain"
 	"github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 )
 
@@ -17,31 +19,12 @@ import (
 func (w *Wallet) GetNodeAccount() (accounts.Account, error) {
 
 	// Check wallet is initialized
-	if !w.IsInitialized() {
+	if !w.IsInitialized() && !w.IsObserver() {
 		return accounts.Account{}, errors.New("Wallet is not initialized")
 	}
 
-	// Get private key
-	privateKey, path, err := w.getNodePrivateKey()
-	if err != nil {
-		return accounts.Account{}, err
-	}
-
-	// Get public key
-	publicKey := privateKey.Public()
-	publicKeyECDSA, ok := publicKey.(*ecdsa.PublicKey)
-	if !ok {
-		return accounts.Account{}, errors.New("Could not get node public key")
-	}
-
-	// Create & return account
-	return accounts.Account{
-		Address: crypto.PubkeyToAddress(*publicKeyECDSA),
-		URL: accounts.URL{
-			Scheme: "",
-			Path:   path,
-		},
-	}, nil
+	// Get & return account
+	return w.getNodeBackend().getAccount()
 
 }
 
@@ -49,23 +32,35 @@ func (w *Wallet) GetNodeAccount() (accounts.Account, error) {
 func (w *Wallet) GetNodeAccountTransactor() (*bind.TransactOpts, error) {
 
 	// Check wallet is initialized
-	if !w.IsInitialized() {
+	if !w.IsInitialized() && !w.IsObserver() {
 		return nil, errors.New("Wallet is not initialized")
 	}
 
-	// Get private key
-	privateKey, _, err := w.getNodePrivateKey()
+	// Create & return transactor
+	transactor, err := w.getNodeBackend().newTransactor()
 	if err != nil {
 		return nil, err
 	}
-
-	// Create & return transactor
-	transactor, err := bind.NewKeyedTransactorWithChainID(privateKey, w.chainID)
 	transactor.GasFeeCap = w.maxFee
 	transactor.GasTipCap = w.maxPriorityFee
 	transactor.GasLimit = w.gasLimit
 	transactor.Context = context.Background()
-	return transactor, err
+
+	// Record every transaction the node signs
+	if w.nonceTracker != nil && !w.IsHardwareNodeAccount() {
+		signer := transactor.Signer
+		transactor.Signer = func(address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+			signedTx, err := signer(address, tx)
+			if err != nil {
+				return nil, err
+			}
+			if err := w.nonceTracker.Record(signedTx); err != nil {
+				return nil, err
+			}
+			return signedTx, nil
+		}
+	}
+	return transactor, nil
 
 }
 
@@ -73,12 +68,12 @@ func (w *Wallet) GetNodeAccountTransactor() (*bind.TransactOpts, error) {
 func (w *Wallet) GetNodePrivateKeyBytes() ([]byte, error) {
 
 	// Check wallet is initialized
-	if !w.IsInitialized() {
+	if !w.IsInitialized() && !w.IsObserver() {
 		return nil, errors.New("Wallet is not initialized")
 	}
 
 	// Get private key
-	privateKey, _, err := w.getNodePrivateKey()
+	privateKey, err := w.getNodeBackend().getPrivateKey()
 	if err != nil {
 		return nil, err
 	}
@@ -88,6 +83,88 @@ func (w *Wallet) GetNodePrivateKeyBytes() ([]byte, error) {
 
 }
 
+// Check if the node account is on a hardware wallet
+func (w *Wallet) IsHardwareNodeAccount() bool {
+	return w.ws != nil && w.ws.HardwareAccount != nil
+}
+
+// Get the type of hardware wallet the node account is on, or an empty string if it's derived from the mnemonic
+func (w *Wallet) GetHardwareWalletType() string {
+	if !w.IsHardwareNodeAccount() {
+		return ""
+	}
+	return w.ws.HardwareAccount.Type
+}
+
+// Use an account on a hardware wallet as the node account instead of the one derived from the mnemonic.
+// The validator keys are still derived from the mnemonic.
+func (w *Wallet) SetHardwareNodeAccount(walletType string, address common.Address, derivationPath string) error {
+
+	// Check wallet is initialized
+	if !w.IsInitialized() {
+		return errors.New("Wallet is not initialized")
+	}
+
+	// Check the wallet type
+	if walletType != HardwareWalletType_Ledger && walletType != HardwareWalletType_Trezor {
+		return fmt.Errorf("Unknown hardware wallet type '%s'", walletType)
+	}
+
+	// Set the account
+	w.ws.HardwareAccount = &hardwareAccount{
+		Type:           walletType,
+		Address:        address,
+		DerivationPath: derivationPath,
+	}
+	return nil
+
+}
+
+// Go back to using the account derived from the mnemonic as the node account
+func (w *Wallet) ClearHardwareNodeAccount() {
+	if w.ws != nil {
+		w.ws.HardwareAccount = nil
+	}
+}
+
+// Set the queue that transactions are added to when the node account is on a hardware wallet
+func (w *Wallet) SetSigningQueue(queue *SigningQueue) {
+	w.signingQueue = queue
+}
+
+// Get the queue that transactions are added to when the node account is on a hardware wallet
+func (w *Wallet) GetSigningQueue() *SigningQueue {
+	return w.signingQueue
+}
+
+// Set the tracker that records the transactions the node account signs
+func (w *Wallet) SetNonceTracker(tracker *NonceTracker) {
+	w.nonceTracker = tracker
+}
+
+// Get the tracker that records the transactions the node account signs
+func (w *Wallet) GetNonceTracker() *NonceTracker {
+	return w.nonceTracker
+}
+
+// Get the backend that holds the node account's key
+func (w *Wallet) getNodeBackend() nodeBackend {
+	if w.IsObserver() {
+		return &observerBackend{
+			address: *w.observedAddress,
+		}
+	}
+	if w.IsHardwareNodeAccount() {
+		return &hardwareBackend{
+			account: w.ws.HardwareAccount,
+			queue:   w.signingQueue,
+		}
+	}
+	return &localBackend{
+		w: w,
+	}
+}
+
 // Get the node private key
 func (w *Wallet) getNodePrivateKey() (*ecdsa.PrivateKey, string, error) {
 
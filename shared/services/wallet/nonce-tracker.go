@@ -0,0 +1,146 @@
+package wallet
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/rocket-pool/smartnode/shared/utils/filelock"
+)
+
+// The number of signed transactions the tracker remembers
+const maxTrackedTransactions int = 256
+
+// Records the transactions the node account signs, by hash and nonce.
+// Pending transactions can be sped up or cancelled from this record even after the Execution client has dropped them from its mempool.
+// The api, node and watchtower processes all sign with the node account, so the record is locked on disk as well as in memory.
+type NonceTracker struct {
+	path string
+	lock sync.Mutex
+}
+
+// A transaction signed by the node account
+type TrackedTransaction struct {
+	Hash   common.Hash `json:"hash"`
+	Nonce  uint64      `json:"nonce"`
+	Signed time.Time   `json:"signed"`
+	Tx     string      `json:"tx"`
+}
+
+// Create a new nonce tracker that stores its record in the provided file
+func NewNonceTracker(path string) *NonceTracker {
+	return &NonceTracker{
+		path: path,
+	}
+}
+
+// Record a signed transaction
+func (t *NonceTracker) Record(tx *types.Transaction) error {
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	fileLock, err := filelock.Lock(t.path)
+	if err != nil {
+		return err
+	}
+	defer fileLock.Unlock()
+
+	// Serialize the transaction
+	txBytes, err := tx.MarshalBinary()
+	if err != nil {
+		return fmt.Errorf("Could not serialize transaction: %w", err)
+	}
+
+	// Add it to the record, dropping the oldest transactions if it's full
+	tracked, err := t.load()
+	if err != nil {
+		return err
+	}
+	tracked = append(tracked, TrackedTransaction{
+		Hash:   tx.Hash(),
+		Nonce:  tx.Nonce(),
+		Signed: time.Now(),
+		Tx:     hex.EncodeToString(txBytes),
+	})
+	if len(tracked) > maxTrackedTransactions {
+		tracked = tracked[len(tracked)-maxTrackedTransactions:]
+	}
+	return t.save(tracked)
+
+}
+
+// Get a recorded transaction by its hash; returns nil if it isn't in the record
+func (t *NonceTracker) Get(hash common.Hash) (*types.Transaction, error) {
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	fileLock, err := filelock.Lock(t.path)
+	if err != nil {
+		return nil, err
+	}
+	defer fileLock.Unlock()
+
+	tracked, err := t.load()
+	if err != nil {
+		return nil, err
+	}
+	for _, trackedTx := range tracked {
+		if trackedTx.Hash != hash {
+			continue
+		}
+		txBytes, err := hex.DecodeString(trackedTx.Tx)
+		if err != nil {
+			return nil, fmt.Errorf("Could not decode tracked transaction %s: %w", hash.Hex(), err)
+		}
+		tx := new(types.Transaction)
+		if err := tx.UnmarshalBinary(txBytes); err != nil {
+			return nil, fmt.Errorf("Could not deserialize tracked transaction %s: %w", hash.Hex(), err)
+		}
+		return tx, nil
+	}
+	return nil, nil
+
+}
+
+// Load the record
+func (t *NonceTracker) load() ([]TrackedTransaction, error) {
+	bytes, err := ioutil.ReadFile(t.path)
+	if os.IsNotExist(err) {
+		return []TrackedTransaction{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Could not read the nonce tracker file: %w", err)
+	}
+	var tracked []TrackedTransaction
+	if err := json.Unmarshal(bytes, &tracked); err != nil {
+		return nil, fmt.Errorf("Could not decode the nonce tracker file: %w", err)
+	}
+	return tracked, nil
+}
+
+// Save the record
+func (t *NonceTracker) save(tracked []TrackedTransaction) error {
+	bytes, err := json.Marshal(tracked)
+	if err != nil {
+		return fmt.Errorf("Could not encode the nonce tracker file: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(t.path), 0700); err != nil {
+		return fmt.Errorf("Could not create the nonce tracker folder: %w", err)
+	}
+	tempPath := t.path + ".tmp"
+	if err := ioutil.WriteFile(tempPath, bytes, FileMode); err != nil {
+		return fmt.Errorf("Could not write the nonce tracker file: %w", err)
+	}
+	if err := os.Rename(tempPath, t.path); err != nil {
+		return fmt.Errorf("Could not replace the nonce tracker file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package wallet
+
+import (
+	"math/big"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func TestNonceTrackersSharingAFileKeepEveryTransaction(t *testing.T) {
+	// Each tracker stands in for a separate process, so only the file lock keeps them from overwriting each other's records
+	path := filepath.Join(t.TempDir(), "nonce-tracker.json")
+	txs := []*types.Transaction{}
+	for nonce := uint64(0); nonce < 32; nonce++ {
+		txs = append(txs, types.NewTransaction(nonce, common.Address{1}, big.NewInt(0), 21000, big.NewInt(1), nil))
+	}
+	var wg sync.WaitGroup
+	errs := make(chan error, len(txs))
+	for _, tx := range txs {
+		wg.Add(1)
+		go func(tx *types.Transaction) {
+			defer wg.Done()
+			errs <- NewNonceTracker(path).Record(tx)
+		}(tx)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	tracker := NewNonceTracker(path)
+	for _, tx := range txs {
+		tracked, err := tracker.Get(tx.Hash())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if tracked == nil || tracked.Nonce() != tx.Nonce() {
+			t.Fatalf("expected the transaction with nonce %d to be recorded, got %v", tx.Nonce(), tracked)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package wallet
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Returned instead of a signed transaction when the node account is on a hardware wallet
+var ErrTransactionQueued = errors.New("The node account is on a hardware wallet, so the transaction has been added to the signing queue. Run `rocketpool wallet sign-queue` with your hardware wallet connected to sign and submit it.")
+
+// Transactions for the node account that are waiting to be signed by a hardware wallet.
+// Each transaction is stored in its own file in the queue folder.
+type SigningQueue struct {
+	path string
+}
+
+// A transaction in the signing queue
+type QueuedTransaction struct {
+	ID      string    `json:"id"`
+	Created time.Time `json:"created"`
+	Tx      string    `json:"tx"`
+}
+
+// Create a new signing queue in the provided folder
+func NewSigningQueue(path string) *SigningQueue {
+	return &SigningQueue{
+		path: path,
+	}
+}
+
+// Add an unsigned transaction to the queue
+func (q *SigningQueue) Add(tx *types.Transaction) (QueuedTransaction, error) {
+
+	// Serialize the transaction
+	txBytes, err := tx.MarshalBinary()
+	if err != nil {
+		return QueuedTransaction{}, fmt.Errorf("Could not serialize transaction: %w", err)
+	}
+	queued := QueuedTransaction{
+		ID:      tx.Hash().Hex()[2:10],
+		Created: time.Now(),
+		Tx:      hex.EncodeToString(txBytes),
+	}
+
+	// Save it
+	if err := os.MkdirAll(q.path, 0700); err != nil {
+		return QueuedTransaction{}, fmt.Errorf("Could not create the signing queue folder: %w", err)
+	}
+	queuedBytes, err := json.Marshal(queued)
+	if err != nil {
+		return QueuedTransaction{}, fmt.Errorf("Could not encode queued transaction: %w", err)
+	}
+	if err := ioutil.WriteFile(q.getFilePath(queued.ID), queuedBytes, FileMode); err != nil {
+		return QueuedTransaction{}, fmt.Errorf("Could not write queued transaction to disk: %w", err)
+	}
+	return queued, nil
+
+}
+
+// Get the transactions in the queue, oldest first
+func (q *SigningQueue) List() ([]QueuedTransaction, error) {
+
+	// Get the queue files
+	files, err := ioutil.ReadDir(q.path)
+	if os.IsNotExist(err) {
+		return []QueuedTransaction{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Could not read the signing queue folder: %w", err)
+	}
+
+	// Read them
+	queue := []QueuedTransaction{}
+	for _, file := range files {
+		if file.IsDir() || filepath.Ext(file.Name()) != ".json" {
+			continue
+		}
+		queued, err := q.Get(strings.TrimSuffix(file.Name(), ".json"))
+		if err != nil {
+			return nil, err
+		}
+		queue = append(queue, queued)
+	}
+	sort.Slice(queue, func(i, j int) bool {
+		return queue[i].Created.Before(queue[j].Created)
+	})
+	return queue, nil
+
+}
+
+// Get a transaction from the queue
+func (q *SigningQueue) Get(id string) (QueuedTransaction, error) {
+	queuedBytes, err := ioutil.ReadFile(q.getFilePath(id))
+	if os.IsNotExist(err) {
+		return QueuedTransaction{}, fmt.Errorf("There is no transaction with ID %s in the signing queue", id)
+	}
+	if err != nil {
+		return QueuedTransaction{}, fmt.Errorf("Could not read queued transaction %s: %w", id, err)
+	}
+	var queued QueuedTransaction
+	if err := json.Unmarshal(queuedBytes, &queued); err != nil {
+		return QueuedTransaction{}, fmt.Errorf("Could not decode queued transaction %s: %w", id, err)
+	}
+	return queued, nil
+}
+
+// Remove a transaction from the queue
+func (q *SigningQueue) Remove(id string) error {
+	if err := os.Remove(q.getFilePath(id)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("Could not remove queued transaction %s: %w", id, err)
+	}
+	return nil
+}
+
+// Get the path of a queued transaction's file
+func (q *SigningQueue) getFilePath(id string) string {
+	return filepath.Join(q.path, filepath.Base(id)+".json")
+}
+
+// Decode the unsigned transaction
+func (t QueuedTransaction) Transaction() (*types.Transaction, error) {
+	txBytes, err := hex.DecodeString(t.Tx)
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing queued transaction %s: %w", t.ID, err)
+	}
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(txBytes); err != nil {
+		return nil, fmt.Errorf("Error decoding queued transaction %s: %w", t.ID, err)
+	}
+	return tx, nil
+}
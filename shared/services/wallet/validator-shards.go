@@ -0,0 +1,178 @@
+package wallet
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
+	eth2types "github.com/wealdtech/go-eth2-types/v2"
+
+	"github.com/rocket-pool/smartnode/shared/services/wallet/keystore"
+)
+
+// Records which Validator client shard each validator key belongs to.
+// A key never moves once it's been assigned, since each shard keeps its own slashing protection database; keys that were stored before
+// sharding was enabled belong to the main Validator client (shard 0).
+type ShardAssignments struct {
+	path   string
+	shards uint64
+	lock   sync.Mutex
+}
+
+// Create a new shard assignment record that stores its assignments in the provided file
+func NewShardAssignments(path string, shards uint64) *ShardAssignments {
+	return &ShardAssignments{
+		path:   path,
+		shards: shards,
+	}
+}
+
+// Assign a newly created validator key to the shard with the fewest keys. keyCount is the number of keys the wallet created before this one,
+// so the keys from before sharding was enabled can be counted towards the main Validator client.
+func (a *ShardAssignments) Assign(pubkey rptypes.ValidatorPubkey, keyCount uint) (uint64, error) {
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	assignments, err := a.load()
+	if err != nil {
+		return 0, err
+	}
+	if shard, exists := assignments[pubkey.Hex()]; exists {
+		return a.checkShard(pubkey, shard)
+	}
+
+	// Count the keys in each shard
+	counts := make([]uint, a.shards)
+	var recorded uint
+	for _, shard := range assignments {
+		if shard < a.shards {
+			counts[shard]++
+		}
+		recorded++
+	}
+	if keyCount > recorded {
+		counts[0] += keyCount - recorded
+	}
+
+	// Pick the emptiest one
+	var selected uint64
+	for shard := range counts {
+		if counts[shard] < counts[selected] {
+			selected = uint64(shard)
+		}
+	}
+	assignments[pubkey.Hex()] = selected
+	return selected, a.save(assignments)
+
+}
+
+// Get the shard a validator key belongs to, assigning it to the main Validator client if it hasn't been assigned yet
+func (a *ShardAssignments) GetShard(pubkey rptypes.ValidatorPubkey) (uint64, error) {
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	assignments, err := a.load()
+	if err != nil {
+		return 0, err
+	}
+	if shard, exists := assignments[pubkey.Hex()]; exists {
+		return a.checkShard(pubkey, shard)
+	}
+	assignments[pubkey.Hex()] = 0
+	return 0, a.save(assignments)
+
+}
+
+// Get the shard of every assigned validator key, by pubkey
+func (a *ShardAssignments) GetAssignments() (map[string]uint64, error) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	return a.load()
+}
+
+// Get the number of shards the keys are split across
+func (a *ShardAssignments) GetShardCount() uint64 {
+	return a.shards
+}
+
+// Make sure a key's shard still exists
+func (a *ShardAssignments) checkShard(pubkey rptypes.ValidatorPubkey, shard uint64) (uint64, error) {
+	if shard >= a.shards {
+		return 0, fmt.Errorf("Validator key %s belongs to Validator client shard %d, but only %d shards are configured. Increase the number of shards in `rocketpool service config` to use it.", pubkey.Hex(), shard, a.shards)
+	}
+	return shard, nil
+}
+
+// Load the assignments
+func (a *ShardAssignments) load() (map[string]uint64, error) {
+	bytes, err := ioutil.ReadFile(a.path)
+	if os.IsNotExist(err) {
+		return map[string]uint64{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Could not read the validator shard assignments file: %w", err)
+	}
+	assignments := map[string]uint64{}
+	if err := json.Unmarshal(bytes, &assignments); err != nil {
+		return nil, fmt.Errorf("Could not decode the validator shard assignments file: %w", err)
+	}
+	return assignments, nil
+}
+
+// Save the assignments
+func (a *ShardAssignments) save(assignments map[string]uint64) error {
+	bytes, err := json.MarshalIndent(assignments, "", "  ")
+	if err != nil {
+		return fmt.Errorf("Could not encode the validator shard assignments file: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(a.path), 0700); err != nil {
+		return fmt.Errorf("Could not create the validator shard assignments folder: %w", err)
+	}
+	if err := ioutil.WriteFile(a.path, bytes, FileMode); err != nil {
+		return fmt.Errorf("Could not write the validator shard assignments file: %w", err)
+	}
+	return nil
+}
+
+// A keystore that stores each validator key in the keystore of the shard it's assigned to
+type ShardedKeystore struct {
+	shards      []keystore.Keystore
+	assignments *ShardAssignments
+}
+
+// Create a new sharded keystore from the keystores of each shard, in shard order
+func NewShardedKeystore(shards []keystore.Keystore, assignments *ShardAssignments) *ShardedKeystore {
+	return &ShardedKeystore{
+		shards:      shards,
+		assignments: assignments,
+	}
+}
+
+// Store a validator key in its shard's keystore
+func (ks *ShardedKeystore) StoreValidatorKey(key *eth2types.BLSPrivateKey, derivationPath string) error {
+	pubkey := rptypes.BytesToValidatorPubkey(key.PublicKey().Marshal())
+	shard, err := ks.assignments.GetShard(pubkey)
+	if err != nil {
+		return err
+	}
+	if shard >= uint64(len(ks.shards)) {
+		return fmt.Errorf("Validator client shard %d doesn't have a keystore", shard)
+	}
+	return ks.shards[shard].StoreValidatorKey(key, derivationPath)
+}
+
+// Set the record of which Validator client shard each validator key belongs to, if the keys are split across several
+func (w *Wallet) SetShardAssignments(assignments *ShardAssignments) {
+	w.shardAssignments = assignments
+}
+
+// Get the record of which Validator client shard each validator key belongs to; returns nil if the keys aren't split
+func (w *Wallet) GetShardAssignments() *ShardAssignments {
+	return w.shardAssignments
+}
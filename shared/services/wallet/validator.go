@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"strings"
 	"sync"
 
 	rptypes "github.com/rocket-pool/rocketpool-go/types"
@@ -15,8 +16,25 @@ import (
 const (
 	ValidatorKeyPath               = "m/12381/3600/%d/0/0"
 	MaxValidatorKeyRecoverAttempts = 100
+	DefaultValidatorKeySearchLimit = 1000
 )
 
+// Documented validator key derivation paths that other staking tools use, where %d is the key's index.
+// These are tried after the Smartnode's own path when searching for keys that weren't created by the Smartnode.
+// EIP-2333 only has hardened derivation, so the Ledger Live and MyEtherWallet layouts are written without the hardened markers.
+var KnownValidatorKeyPaths = []string{
+	"m/12381/3600/%d/0", // The EIP-2334 withdrawal key for the index, which has been used as the signing key by mistake
+	"m/44/60/%d/0/0",    // The Ledger Live layout, as in LedgerLiveNodeKeyPath
+	"m/44/60/0/%d",      // The legacy MyEtherWallet layout, as in MyEtherWalletNodeKeyPath
+}
+
+// A validator key found by searching derivation paths
+type FoundValidatorKey struct {
+	Pubkey rptypes.ValidatorPubkey
+	Path   string
+	Key    *eth2types.BLSPrivateKey
+}
+
 // Get the number of validator keys recorded in the wallet
 func (w *Wallet) GetValidatorKeyCount() (uint, error) {
 
@@ -107,6 +125,13 @@ func (w *Wallet) CreateValidatorKey() (*eth2types.BLSPrivateKey, error) {
 		return nil, err
 	}
 
+	// Assign it to a Validator client shard
+	if w.shardAssignments != nil {
+		if _, err := w.shardAssignments.Assign(rptypes.BytesToValidatorPubkey(key.PublicKey().Marshal()), index); err != nil {
+			return nil, err
+		}
+	}
+
 	// Update keystores
 	err = w.StoreValidatorKey(key, path)
 	if err != nil {
@@ -236,6 +261,63 @@ func (w *Wallet) TestRecoverValidatorKey(pubkey rptypes.ValidatorPubkey) error {
 
 }
 
+// Search for validator keys on a set of derivation path templates, trying every index from startIndex up to (but not including) endIndex on each one
+// Keys found on the Smartnode's own path move the wallet's next account index past them, like regular recovery does
+func (w *Wallet) SearchValidatorKeys(pubkeys []rptypes.ValidatorPubkey, pathTemplates []string, startIndex uint, endIndex uint) ([]FoundValidatorKey, error) {
+
+	// Check wallet is initialized
+	if !w.IsInitialized() {
+		return nil, errors.New("Wallet is not initialized")
+	}
+
+	// Check the path templates
+	for _, pathTemplate := range pathTemplates {
+		if strings.Count(pathTemplate, "%d") != 1 {
+			return nil, fmt.Errorf("Validator key path [%s] must contain exactly one %%d for the key index", pathTemplate)
+		}
+	}
+
+	// Initialize BLS support
+	if err := initializeBLS(); err != nil {
+		return nil, fmt.Errorf("Could not initialize BLS library: %w", err)
+	}
+
+	// Derive each key once and check it against all of the remaining pubkeys
+	remaining := map[string]rptypes.ValidatorPubkey{}
+	for _, pubkey := range pubkeys {
+		remaining[string(pubkey.Bytes())] = pubkey
+	}
+	found := []FoundValidatorKey{}
+	for _, pathTemplate := range pathTemplates {
+		for index := startIndex; index < endIndex && len(remaining) > 0; index++ {
+			derivationPath := fmt.Sprintf(pathTemplate, index)
+			key, err := eth2util.PrivateKeyFromSeedAndPath(w.seed, derivationPath)
+			if err != nil {
+				return nil, fmt.Errorf("Could not get the validator private key at path [%s]: %w", derivationPath, err)
+			}
+			pubkey, exists := remaining[string(key.PublicKey().Marshal())]
+			if !exists {
+				continue
+			}
+			found = append(found, FoundValidatorKey{
+				Pubkey: pubkey,
+				Path:   derivationPath,
+				Key:    key,
+			})
+			delete(remaining, string(pubkey.Bytes()))
+
+			// Update account index
+			if pathTemplate == ValidatorKeyPath && index+1 > w.ws.NextAccount {
+				w.ws.NextAccount = index + 1
+			}
+		}
+	}
+
+	// Return
+	return found, nil
+
+}
+
 // Get a validator private key by index
 func (w *Wallet) getValidatorPrivateKey(index uint) (*eth2types.BLSPrivateKey, string, error) {
 
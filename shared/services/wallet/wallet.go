@@ -10,6 +10,7 @@ import (
 
 	"github.com/btcsuite/btcd/chaincfg"
 	"github.com/btcsuite/btcutil/hdkeychain"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/google/uuid"
 	"github.com/tyler-smith/go-bip39"
@@ -56,6 +57,18 @@ type Wallet struct {
 	// Keystores
 	keystores map[string]keystore.Keystore
 
+	// The node address being observed when no wallet is loaded
+	observedAddress *common.Address
+
+	// Transactions waiting to be signed when the node account is on a hardware wallet
+	signingQueue *SigningQueue
+
+	// Transactions the node account has signed, so they can be replaced
+	nonceTracker *NonceTracker
+
+	// The Validator client shard each validator key belongs to, if the keys are split across several
+	shardAssignments *ShardAssignments
+
 	// Desired gas price & limit from config
 	maxFee         *big.Int
 	maxPriorityFee *big.Int
@@ -64,13 +77,14 @@ type Wallet struct {
 
 // Encrypted wallet store
 type walletStore struct {
-	Crypto         map[string]interface{} `json:"crypto"`
-	Name           string                 `json:"name"`
-	Version        uint                   `json:"version"`
-	UUID           uuid.UUID              `json:"uuid"`
-	DerivationPath string                 `json:"derivationPath,omitempty"`
-	WalletIndex    uint                   `json:"walletIndex,omitempty"`
-	NextAccount    uint                   `json:"next_account"`
+	Crypto          map[string]interface{} `json:"crypto"`
+	Name            string                 `json:"name"`
+	Version         uint                   `json:"version"`
+	UUID            uuid.UUID              `json:"uuid"`
+	DerivationPath  string                 `json:"derivationPath,omitempty"`
+	WalletIndex     uint                   `json:"walletIndex,omitempty"`
+	NextAccount     uint                   `json:"next_account"`
+	HardwareAccount *hardwareAccount       `json:"hardwareAccount,omitempty"`
 }
 
 // Create new wallet
@@ -100,6 +114,23 @@ func NewWallet(walletPath string, chainId uint, maxFee *big.Int, maxPriorityFee
 
 }
 
+// Create a wallet that observes a node address without loading the wallet file, so the node's status can be read without its keys.
+// It acts as an uninitialized wallet, except that the node account's address is available.
+func NewObserverWallet(chainId uint, address common.Address) *Wallet {
+	return &Wallet{
+		chainID:             big.NewInt(int64(chainId)),
+		validatorKeys:       map[uint]*eth2types.BLSPrivateKey{},
+		validatorKeyIndices: map[string]uint{},
+		keystores:           map[string]keystore.Keystore{},
+		observedAddress:     &address,
+	}
+}
+
+// Check if the wallet is only observing a node address
+func (w *Wallet) IsObserver() bool {
+	return w.observedAddress != nil
+}
+
 // Gets the wallet's chain ID
 func (w *Wallet) GetChainID() *big.Int {
 	copy := big.NewInt(0).Set(w.chainID)
@@ -256,7 +287,7 @@ func (w *Wallet) Save() error {
 // Signs a serialized TX using the wallet's private key
 func (w *Wallet) Sign(serializedTx []byte) ([]byte, error) {
 	// Get private key
-	privateKey, _, err := w.getNodePrivateKey()
+	privateKey, err := w.getNodeBackend().getPrivateKey()
 	if err != nil {
 		return nil, err
 	}
@@ -333,6 +364,11 @@ func (w *Wallet) loadStore() (bool, error) {
 // Initialize the encrypted wallet store from a mnemonic
 func (w *Wallet) initializeStore(derivationPath string, walletIndex uint, mnemonic string) error {
 
+	// Observer wallets have nowhere to store the new wallet
+	if w.IsObserver() {
+		return ErrObserverMode
+	}
+
 	// Generate seed
 	w.seed = bip39.NewSeed(mnemonic, "")
 
@@ -37,6 +37,7 @@ type MinipoolDetails struct {
 	PreviousDelegate    common.Address         `json:"previousDelegate"`
 	EffectiveDelegate   common.Address         `json:"effectiveDelegate"`
 	TimeUntilDissolve   time.Duration          `json:"timeUntilDissolve"`
+	ScheduledExit       *ScheduledExitDetails  `json:"scheduledExit"`
 }
 type ValidatorDetails struct {
 	Exists      bool     `json:"exists"`
@@ -83,6 +84,49 @@ type ExitMinipoolResponse struct {
 	Error  string `json:"error"`
 }
 
+type ScheduleExitMinipoolResponse struct {
+	Status       string `json:"status"`
+	Error        string `json:"error"`
+	CurrentEpoch uint64 `json:"currentEpoch"`
+}
+
+type GetSignedExitMessagesResponse struct {
+	Status string              `json:"status"`
+	Error  string              `json:"error"`
+	Epoch  uint64              `json:"epoch"`
+	Exits  []SignedExitMessage `json:"exits"`
+}
+
+// A minipool validator's signed voluntary exit, which can be broadcast to any Beacon node to exit it
+type SignedExitMessage struct {
+	MinipoolAddress common.Address           `json:"minipoolAddress"`
+	ValidatorPubkey types.ValidatorPubkey    `json:"validatorPubkey"`
+	ValidatorIndex  uint64                   `json:"validatorIndex"`
+	Epoch           uint64                   `json:"epoch"`
+	Signature       types.ValidatorSignature `json:"signature"`
+}
+
+// The state of a signed exit that the node daemon is holding
+type ScheduledExitState string
+
+const (
+	// Waiting for its epoch
+	ScheduledExitState_Scheduled ScheduledExitState = "scheduled"
+	// Broadcast to the Beacon Chain
+	ScheduledExitState_Broadcast ScheduledExitState = "broadcast"
+	// Couldn't be broadcast, and will be tried again on the next run
+	ScheduledExitState_Failed ScheduledExitState = "failed"
+)
+
+// A minipool exit that the node daemon will broadcast at an epoch
+type ScheduledExitDetails struct {
+	Epoch     uint64             `json:"epoch"`
+	State     ScheduledExitState `json:"state"`
+	Attempts  uint64             `json:"attempts"`
+	LastError string             `json:"lastError"`
+	Updated   time.Time          `json:"updated"`
+}
+
 type CanProcessWithdrawalResponse struct {
 	Status        string             `json:"status"`
 	Error         string             `json:"error"`
@@ -118,9 +162,10 @@ type CanCloseMinipoolResponse struct {
 	GasInfo       rocketpool.GasInfo `json:"gasInfo"`
 }
 type CloseMinipoolResponse struct {
-	Status string      `json:"status"`
-	Error  string      `json:"error"`
-	TxHash common.Hash `json:"txHash"`
+	Status         string      `json:"status"`
+	Error          string      `json:"error"`
+	TxHash         common.Hash `json:"txHash"`
+	KeyUnloadError string      `json:"keyUnloadError,omitempty"`
 }
 
 type CanFinaliseMinipoolResponse struct {
@@ -212,3 +257,48 @@ type GetVanityArtifactsResponse struct {
 	MinipoolManagerAddress common.Address `json:"minipoolManagerAddress"`
 	InitHash               common.Hash    `json:"initHash"`
 }
+
+type CanBeginReduceBondAmountResponse struct {
+	Status               string             `json:"status"`
+	Error                string             `json:"error"`
+	CanReduce            bool               `json:"canReduce"`
+	NotSupported         bool               `json:"notSupported"`
+	InvalidStatus        bool               `json:"invalidStatus"`
+	InvalidBond          bool               `json:"invalidBond"`
+	AlreadyStarted       bool               `json:"alreadyStarted"`
+	InsufficientRplStake bool               `json:"insufficientRplStake"`
+	CurrentBond          *big.Int           `json:"currentBond"`
+	RplStake             *big.Int           `json:"rplStake"`
+	MinimumRplStake      *big.Int           `json:"minimumRplStake"`
+	WindowStart          time.Duration      `json:"windowStart"`
+	WindowLength         time.Duration      `json:"windowLength"`
+	GasInfo              rocketpool.GasInfo `json:"gasInfo"`
+}
+type BeginReduceBondAmountResponse struct {
+	Status string      `json:"status"`
+	Error  string      `json:"error"`
+	TxHash common.Hash `json:"txHash"`
+}
+
+type CanReduceBondAmountResponse struct {
+	Status               string             `json:"status"`
+	Error                string             `json:"error"`
+	CanReduce            bool               `json:"canReduce"`
+	NotSupported         bool               `json:"notSupported"`
+	NotStarted           bool               `json:"notStarted"`
+	Cancelled            bool               `json:"cancelled"`
+	WindowClosed         bool               `json:"windowClosed"`
+	InsufficientRplStake bool               `json:"insufficientRplStake"`
+	CurrentBond          *big.Int           `json:"currentBond"`
+	NewBond              *big.Int           `json:"newBond"`
+	RplStake             *big.Int           `json:"rplStake"`
+	MinimumRplStake      *big.Int           `json:"minimumRplStake"`
+	WindowOpenTime       time.Time          `json:"windowOpenTime"`
+	WindowCloseTime      time.Time          `json:"windowCloseTime"`
+	GasInfo              rocketpool.GasInfo `json:"gasInfo"`
+}
+type ReduceBondAmountResponse struct {
+	Status string      `json:"status"`
+	Error  string      `json:"error"`
+	TxHash common.Hash `json:"txHash"`
+}
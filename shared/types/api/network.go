@@ -2,6 +2,7 @@ package api
 
 import (
 	"math/big"
+	"time"
 )
 
 type NodeFeeResponse struct {
@@ -50,3 +51,57 @@ type NetworkTimezonesResponse struct {
 	TimezoneTotal  uint64            `json:"timezoneTotal"`
 	NodeTotal      uint64            `json:"nodeTotal"`
 }
+
+type GasPricesResponse struct {
+	Status       string   `json:"status"`
+	Error        string   `json:"error"`
+	OracleName   string   `json:"oracleName"`
+	RapidWei     *big.Int `json:"rapidWei"`
+	RapidTime    string   `json:"rapidTime"`
+	FastWei      *big.Int `json:"fastWei"`
+	FastTime     string   `json:"fastTime"`
+	StandardWei  *big.Int `json:"standardWei"`
+	StandardTime string   `json:"standardTime"`
+	SlowWei      *big.Int `json:"slowWei"`
+	SlowTime     string   `json:"slowTime"`
+}
+
+type NetworkGenerateRewardsTreeResponse struct {
+	Status            string `json:"status"`
+	Error             string `json:"error"`
+	Index             uint64 `json:"index"`
+	ExecutionEndBlock uint64 `json:"executionEndBlock"`
+	ConsensusEndBlock uint64 `json:"consensusEndBlock"`
+	NodeCount         int    `json:"nodeCount"`
+	MerkleRoot        string `json:"merkleRoot"`
+	CanonicalRoot     string `json:"canonicalRoot"`
+	RootMatches       bool   `json:"rootMatches"`
+	Saved             bool   `json:"saved"`
+	Path              string `json:"path"`
+}
+
+type NetworkDownloadRewardsTreeResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error"`
+	Index  uint64 `json:"index"`
+	Source string `json:"source"`
+	Url    string `json:"url"`
+	Path   string `json:"path"`
+}
+
+// A sample of the rETH exchange rate and RPL price recorded by the node daemon
+type RateSample struct {
+	Time     time.Time `json:"time"`
+	Block    uint64    `json:"block"`
+	RethRate float64   `json:"rethRate"`
+	// The secondary-market rETH rate; zero if it isn't tracked or couldn't be retrieved
+	RethMarketRate float64 `json:"rethMarketRate"`
+	RplPrice       float64 `json:"rplPrice"`
+}
+
+type NetworkRateHistoryResponse struct {
+	Status        string       `json:"status"`
+	Error         string       `json:"error"`
+	RetentionDays uint64       `json:"retentionDays"`
+	Samples       []RateSample `json:"samples"`
+}
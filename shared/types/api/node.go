@@ -41,6 +41,25 @@ type NodeStatusResponse struct {
 		CloseAvailable      int `json:"closeAvailable"`
 		Finalised           int `json:"finalised"`
 	} `json:"minipoolCounts"`
+	SmoothingPool NodeSmoothingPoolStatus `json:"smoothingPool"`
+}
+
+type NodeSmoothingPoolStatus struct {
+	// Set if the smoothing pool status couldn't be retrieved, e.g. because it isn't deployed on this network yet
+	Error      string         `json:"error"`
+	Address    common.Address `json:"address"`
+	Registered bool           `json:"registered"`
+	Changed    time.Time      `json:"changed"`
+	// The node can only opt in or out once per rewards interval
+	NextChangeTime time.Time `json:"nextChangeTime"`
+	// Opting out takes effect when the current rewards interval ends
+	OptOutPending      bool                `json:"optOutPending"`
+	IntervalEnd        time.Time           `json:"intervalEnd"`
+	PenalizedMinipools []PenalizedMinipool `json:"penalizedMinipools"`
+}
+type PenalizedMinipool struct {
+	Address     common.Address `json:"address"`
+	PenaltyRate *big.Int       `json:"penaltyRate"`
 }
 
 type CanRegisterNodeResponse struct {
@@ -105,6 +124,23 @@ type SetNodeTimezoneResponse struct {
 	TxHash common.Hash `json:"txHash"`
 }
 
+type CanSetSmoothingPoolStatusResponse struct {
+	Status         string             `json:"status"`
+	Error          string             `json:"error"`
+	CanSet         bool               `json:"canSet"`
+	AlreadySet     bool               `json:"alreadySet"`
+	InCooldown     bool               `json:"inCooldown"`
+	NextChangeTime time.Time          `json:"nextChangeTime"`
+	IntervalEnd    time.Time          `json:"intervalEnd"`
+	PoolAddress    common.Address     `json:"poolAddress"`
+	GasInfo        rocketpool.GasInfo `json:"gasInfo"`
+}
+type SetSmoothingPoolStatusResponse struct {
+	Status string      `json:"status"`
+	Error  string      `json:"error"`
+	TxHash common.Hash `json:"txHash"`
+}
+
 type CanNodeSwapRplResponse struct {
 	Status              string             `json:"status"`
 	Error               string             `json:"error"`
@@ -269,6 +305,31 @@ type NodeRewardsResponse struct {
 	TxHash                      common.Hash   `json:"txHash"`
 }
 
+type NodeRewardsHistoryResponse struct {
+	Status    string                `json:"status"`
+	Error     string                `json:"error"`
+	Intervals []NodeIntervalRewards `json:"intervals"`
+}
+type NodeIntervalRewards struct {
+	Index            uint64    `json:"index"`
+	StartTime        time.Time `json:"startTime"`
+	EndTime          time.Time `json:"endTime"`
+	Claimed          bool      `json:"claimed"`
+	RplRewards       *big.Int  `json:"rplRewards"`
+	RplStake         *big.Int  `json:"rplStake"`
+	RplApr           float64   `json:"rplApr"`
+	SmoothingPoolEth *big.Int  `json:"smoothingPoolEth"`
+	BeaconRewards    *big.Int  `json:"beaconRewards"`
+	NodeDeposit      *big.Int  `json:"nodeDeposit"`
+	EthApr           float64   `json:"ethApr"`
+	// Set if the Beacon balances at the interval's boundaries couldn't be retrieved, in which case only the RPL APR is available
+	BalancesError string `json:"balancesError,omitempty"`
+	// The node's share of the ETH the Smoothing Pool paid to node operators in the interval, as a percentage
+	SmoothingPoolShare float64 `json:"smoothingPoolShare"`
+	// Set if the node hasn't claimed the interval and doesn't have its tree file, so its rewards aren't known
+	TreeMissing bool `json:"treeMissing,omitempty"`
+}
+
 type DepositContractInfoResponse struct {
 	Status                string         `json:"status"`
 	Error                 string         `json:"error"`
@@ -306,3 +367,143 @@ type ClearSnapshotDelegateResponse struct {
 	Error  string      `json:"error"`
 	TxHash common.Hash `json:"txHash"`
 }
+
+type NodeTxDoctorTransaction struct {
+	Nonce          uint64          `json:"nonce"`
+	Hash           common.Hash     `json:"hash"`
+	To             *common.Address `json:"to"`
+	MaxFee         *big.Int        `json:"maxFee"`
+	MaxPriorityFee *big.Int        `json:"maxPriorityFee"`
+	Queued         bool            `json:"queued"`
+	Stuck          bool            `json:"stuck"`
+}
+type NodeTxDoctorStatusResponse struct {
+	Status          string                    `json:"status"`
+	Error           string                    `json:"error"`
+	TxPoolAvailable bool                      `json:"txPoolAvailable"`
+	LatestNonce     uint64                    `json:"latestNonce"`
+	PendingNonce    uint64                    `json:"pendingNonce"`
+	BaseFee         *big.Int                  `json:"baseFee"`
+	MissingNonces   []uint64                  `json:"missingNonces"`
+	Transactions    []NodeTxDoctorTransaction `json:"transactions"`
+	GasInfo         rocketpool.GasInfo        `json:"gasInfo"`
+}
+type NodeFillNonceGapResponse struct {
+	Status string      `json:"status"`
+	Error  string      `json:"error"`
+	TxHash common.Hash `json:"txHash"`
+}
+type NodeReplaceTxResponse struct {
+	Status string      `json:"status"`
+	Error  string      `json:"error"`
+	TxHash common.Hash `json:"txHash"`
+}
+type NodeCanReplaceTxResponse struct {
+	Status                 string             `json:"status"`
+	Error                  string             `json:"error"`
+	Nonce                  uint64             `json:"nonce"`
+	To                     *common.Address    `json:"to"`
+	Value                  *big.Int           `json:"value"`
+	OriginalMaxFee         *big.Int           `json:"originalMaxFee"`
+	OriginalMaxPriorityFee *big.Int           `json:"originalMaxPriorityFee"`
+	MinMaxFee              *big.Int           `json:"minMaxFee"`
+	MinMaxPriorityFee      *big.Int           `json:"minMaxPriorityFee"`
+	GasInfo                rocketpool.GasInfo `json:"gasInfo"`
+}
+
+type NodePerformanceResponse struct {
+	Status     string                 `json:"status"`
+	Error      string                 `json:"error"`
+	StartEpoch uint64                 `json:"startEpoch"`
+	EndEpoch   uint64                 `json:"endEpoch"`
+	Threshold  float64                `json:"threshold"`
+	Validators []ValidatorPerformance `json:"validators"`
+}
+
+// What a node operator should do about a validator's performance
+type PerformanceAdvice string
+
+const (
+	PerformanceAdvice_Ok               PerformanceAdvice = "ok"
+	PerformanceAdvice_InsufficientData PerformanceAdvice = "insufficientData"
+	PerformanceAdvice_Inactive         PerformanceAdvice = "inactive"
+	PerformanceAdvice_Remediate        PerformanceAdvice = "remediate"
+	PerformanceAdvice_Exit             PerformanceAdvice = "exit"
+	PerformanceAdvice_Slashed          PerformanceAdvice = "slashed"
+)
+
+type ValidatorPerformance struct {
+	MinipoolAddress common.Address          `json:"minipoolAddress"`
+	Pubkey          rptypes.ValidatorPubkey `json:"pubkey"`
+	Index           uint64                  `json:"index"`
+	StartEpoch      uint64                  `json:"startEpoch"`
+	Epochs          uint64                  `json:"epochs"`
+	// Balance change over the window, in gwei
+	ActualRewards int64 `json:"actualRewards"`
+	// Ideal attestation rewards over the window, in gwei
+	ExpectedRewards uint64 `json:"expectedRewards"`
+	// Actual rewards as a percentage of the expected rewards
+	Effectiveness float64 `json:"effectiveness"`
+	// The expected rewards that were missed, extrapolated to a year, in ETH
+	AnnualOpportunityCost float64           `json:"annualOpportunityCost"`
+	Advice                PerformanceAdvice `json:"advice"`
+}
+
+type NodeTxQueueResponse struct {
+	Status       string         `json:"status"`
+	Error        string         `json:"error"`
+	Transactions []TxQueueEntry `json:"transactions"`
+}
+
+// The state of a transaction in the node daemon's retry queue
+type TxQueueState string
+
+const (
+	// Waiting for gas to drop below the threshold
+	TxQueueState_Waiting TxQueueState = "waiting"
+	// Submitted and waiting to be mined
+	TxQueueState_Submitted TxQueueState = "submitted"
+	// Failed, and will be retried once its backoff has passed
+	TxQueueState_Retrying TxQueueState = "retrying"
+	// Mined successfully
+	TxQueueState_Mined TxQueueState = "mined"
+)
+
+// A transaction that one of the node daemon's tasks is trying to get mined
+type TxQueueEntry struct {
+	ID          string       `json:"id"`
+	Task        string       `json:"task"`
+	Description string       `json:"description"`
+	State       TxQueueState `json:"state"`
+	Attempts    uint64       `json:"attempts"`
+	Created     time.Time    `json:"created"`
+	Updated     time.Time    `json:"updated"`
+	NextAttempt time.Time    `json:"nextAttempt"`
+	Hash        common.Hash  `json:"hash"`
+	Nonce       uint64       `json:"nonce"`
+	MaxFee      *big.Int     `json:"maxFee"`
+	PriorityFee *big.Int     `json:"priorityFee"`
+	LastError   string       `json:"lastError"`
+}
+
+type NodeTodoResponse struct {
+	Status             string           `json:"status"`
+	Error              string           `json:"error"`
+	UnclaimedIntervals []uint64         `json:"unclaimedIntervals"`
+	UnclaimedRpl       *big.Int         `json:"unclaimedRpl"`
+	UnclaimedEth       *big.Int         `json:"unclaimedEth"`
+	StakeableMinipools []common.Address `json:"stakeableMinipools"`
+}
+
+type GasThresholdRecommendationResponse struct {
+	Status                    string  `json:"status"`
+	Error                     string  `json:"error"`
+	MinipoolCount             uint64  `json:"minipoolCount"`
+	SampledBlocks             uint64  `json:"sampledBlocks"`
+	SampledDays               uint64  `json:"sampledDays"`
+	BaseFeeLowGwei            float64 `json:"baseFeeLowGwei"`
+	BaseFeeMedianGwei         float64 `json:"baseFeeMedianGwei"`
+	BaseFeeHighGwei           float64 `json:"baseFeeHighGwei"`
+	RplClaimGasThreshold      float64 `json:"rplClaimGasThreshold"`
+	MinipoolStakeGasThreshold float64 `json:"minipoolStakeGasThreshold"`
+}
@@ -2,6 +2,7 @@ package api
 
 import (
 	"math/big"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/rocket-pool/rocketpool-go/dao"
@@ -286,3 +287,65 @@ type GetTNDAOMinipoolSettingsResponse struct {
 	Error       string `json:"error"`
 	ScrubPeriod uint64 `json:"scrubPeriod"`
 }
+
+type CanCancelBondReductionResponse struct {
+	Status           string             `json:"status"`
+	Error            string             `json:"error"`
+	CanCancel        bool               `json:"canCancel"`
+	NotSupported     bool               `json:"notSupported"`
+	NotStarted       bool               `json:"notStarted"`
+	AlreadyCancelled bool               `json:"alreadyCancelled"`
+	NewBond          *big.Int           `json:"newBond"`
+	StartTime        time.Time          `json:"startTime"`
+	GasInfo          rocketpool.GasInfo `json:"gasInfo"`
+}
+type CancelBondReductionResponse struct {
+	Status string      `json:"status"`
+	Error  string      `json:"error"`
+	TxHash common.Hash `json:"txHash"`
+}
+
+type CanScrubMinipoolResponse struct {
+	Status        string             `json:"status"`
+	Error         string             `json:"error"`
+	CanScrub      bool               `json:"canScrub"`
+	InvalidStatus bool               `json:"invalidStatus"`
+	GasInfo       rocketpool.GasInfo `json:"gasInfo"`
+}
+type ScrubMinipoolResponse struct {
+	Status string      `json:"status"`
+	Error  string      `json:"error"`
+	TxHash common.Hash `json:"txHash"`
+}
+
+// The emergency actions that are recorded in the audit log
+type EmergencyAction string
+
+const (
+	EmergencyAction_CancelBondReduction EmergencyAction = "cancelBondReduction"
+	EmergencyAction_ScrubMinipool       EmergencyAction = "scrubMinipool"
+)
+
+// Who took an emergency action
+type EmergencyActionSource string
+
+const (
+	EmergencyActionSource_Operator   EmergencyActionSource = "operator"
+	EmergencyActionSource_Watchtower EmergencyActionSource = "watchtower"
+)
+
+// A record of an emergency action the node took
+type EmergencyAuditLogEntry struct {
+	Time            time.Time             `json:"time"`
+	Action          EmergencyAction       `json:"action"`
+	Source          EmergencyActionSource `json:"source"`
+	NodeAddress     common.Address        `json:"nodeAddress"`
+	MinipoolAddress common.Address        `json:"minipoolAddress"`
+	Reason          string                `json:"reason"`
+	TxHash          common.Hash           `json:"txHash"`
+}
+type GetEmergencyAuditLogResponse struct {
+	Status  string                   `json:"status"`
+	Error   string                   `json:"error"`
+	Entries []EmergencyAuditLogEntry `json:"entries"`
+}
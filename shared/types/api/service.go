@@ -19,6 +19,24 @@ type ExecutionClientManagerStatus struct {
 	PrimaryEcStatus  ExecutionClientStatus `json:"primaryEcStatus"`
 	FallbackEnabled  bool                  `json:"fallbackEnabled"`
 	FallbackEcStatus ExecutionClientStatus `json:"fallbackEcStatus"`
+	ReadEcEnabled    bool                  `json:"readEcEnabled"`
+	ReadEcStatus     ExecutionClientStatus `json:"readEcStatus"`
+	ExtraEcEnabled   bool                  `json:"extraEcEnabled"`
+	ExtraEcStatus    ExecutionClientStatus `json:"extraEcStatus"`
+
+	// The health of every endpoint the manager routes requests to, by client
+	Endpoints []ExecutionClientEndpointStatus `json:"endpoints"`
+}
+
+// The health score of one Execution client endpoint; lower scores are better
+type ExecutionClientEndpointStatus struct {
+	Client       string  `json:"client"`
+	Name         string  `json:"name"`
+	Ready        bool    `json:"ready"`
+	Score        float64 `json:"score"`
+	LatencyMs    float64 `json:"latencyMs"`
+	BlocksBehind uint64  `json:"blocksBehind"`
+	Failures     float64 `json:"failures"`
 }
 
 type ExecutionClientStatusResponse struct {
@@ -26,3 +44,18 @@ type ExecutionClientStatusResponse struct {
 	Error         string                       `json:"error"`
 	ManagerStatus ExecutionClientManagerStatus `json:"managerStatus"`
 }
+
+// The validator keys a Validator client shard runs
+type ValidatorShard struct {
+	Shard     uint64   `json:"shard"`
+	Container string   `json:"container"`
+	Pubkeys   []string `json:"pubkeys"`
+}
+
+type ValidatorShardsResponse struct {
+	Status     string           `json:"status"`
+	Error      string           `json:"error"`
+	ShardCount uint64           `json:"shardCount"`
+	Shards     []ValidatorShard `json:"shards"`
+	Orphaned   []ValidatorShard `json:"orphaned"`
+}
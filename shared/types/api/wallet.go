@@ -1,6 +1,8 @@
 package api
 
 import (
+	"time"
+
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/google/uuid"
 	"github.com/rocket-pool/rocketpool-go/types"
@@ -22,6 +24,7 @@ type WalletStatusResponse struct {
 	PasswordSet       bool           `json:"passwordSet"`
 	WalletInitialized bool           `json:"walletInitialized"`
 	AccountAddress    common.Address `json:"accountAddress"`
+	HardwareWallet    string         `json:"hardwareWallet"`
 }
 
 type SetPasswordResponse struct {
@@ -37,20 +40,28 @@ type InitWalletResponse struct {
 }
 
 type RecoverWalletResponse struct {
-	Status         string                  `json:"status"`
-	Error          string                  `json:"error"`
-	AccountAddress common.Address          `json:"accountAddress"`
-	ValidatorKeys  []types.ValidatorPubkey `json:"validatorKeys"`
+	Status            string                  `json:"status"`
+	Error             string                  `json:"error"`
+	AccountAddress    common.Address          `json:"accountAddress"`
+	ValidatorKeys     []types.ValidatorPubkey `json:"validatorKeys"`
+	ValidatorKeyPaths []ValidatorKeyPath      `json:"validatorKeyPaths"`
+}
+
+// The derivation path a validator key was found on when searching for it
+type ValidatorKeyPath struct {
+	Pubkey types.ValidatorPubkey `json:"pubkey"`
+	Path   string                `json:"path"`
 }
 
 type SearchAndRecoverWalletResponse struct {
-	Status         string                  `json:"status"`
-	Error          string                  `json:"error"`
-	FoundWallet    bool                    `json:"foundWallet"`
-	AccountAddress common.Address          `json:"accountAddress"`
-	DerivationPath string                  `json:"derivationPath"`
-	Index          uint                    `json:"index"`
-	ValidatorKeys  []types.ValidatorPubkey `json:"validatorKeys"`
+	Status            string                  `json:"status"`
+	Error             string                  `json:"error"`
+	FoundWallet       bool                    `json:"foundWallet"`
+	AccountAddress    common.Address          `json:"accountAddress"`
+	DerivationPath    string                  `json:"derivationPath"`
+	Index             uint                    `json:"index"`
+	ValidatorKeys     []types.ValidatorPubkey `json:"validatorKeys"`
+	ValidatorKeyPaths []ValidatorKeyPath      `json:"validatorKeyPaths"`
 }
 
 type RebuildWalletResponse struct {
@@ -73,3 +84,68 @@ type TestMnemonicResponse struct {
 	CurrentAddress   common.Address `json:"currentAddress"`
 	RecoveredAddress common.Address `json:"recoveredAddress"`
 }
+
+type SlashingProtectionKeyStatus struct {
+	Pubkey  types.ValidatorPubkey `json:"pubkey"`
+	Status  string                `json:"status"`
+	Message string                `json:"message"`
+}
+
+type ExportSlashingProtectionResponse struct {
+	Status             string                        `json:"status"`
+	Error              string                        `json:"error"`
+	Keys               []SlashingProtectionKeyStatus `json:"keys"`
+	SlashingProtection string                        `json:"slashingProtection"`
+}
+
+type ImportSlashingProtectionResponse struct {
+	Status      string                        `json:"status"`
+	Error       string                        `json:"error"`
+	Keys        []SlashingProtectionKeyStatus `json:"keys"`
+	MissingKeys []types.ValidatorPubkey       `json:"missingKeys"`
+}
+
+type SetHardwareWalletAccountResponse struct {
+	Status         string         `json:"status"`
+	Error          string         `json:"error"`
+	AccountAddress common.Address `json:"accountAddress"`
+}
+
+type ClearHardwareWalletAccountResponse struct {
+	Status         string         `json:"status"`
+	Error          string         `json:"error"`
+	AccountAddress common.Address `json:"accountAddress"`
+}
+
+type QueuedTransaction struct {
+	ID      string    `json:"id"`
+	Created time.Time `json:"created"`
+	Tx      string    `json:"tx"`
+}
+
+type SigningQueueResponse struct {
+	Status       string              `json:"status"`
+	Error        string              `json:"error"`
+	Transactions []QueuedTransaction `json:"transactions"`
+}
+
+type PrepareQueuedTransactionResponse struct {
+	Status         string         `json:"status"`
+	Error          string         `json:"error"`
+	Tx             string         `json:"tx"`
+	ChainID        uint64         `json:"chainId"`
+	AccountAddress common.Address `json:"accountAddress"`
+	HardwareWallet string         `json:"hardwareWallet"`
+	DerivationPath string         `json:"derivationPath"`
+}
+
+type SubmitQueuedTransactionResponse struct {
+	Status string      `json:"status"`
+	Error  string      `json:"error"`
+	TxHash common.Hash `json:"txHash"`
+}
+
+type RemoveQueuedTransactionResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error"`
+}
@@ -0,0 +1,50 @@
+package eth2
+
+import (
+	"fmt"
+
+	ssz "github.com/ferranbt/fastssz"
+)
+
+// The standard types of signing domains, used when the Beacon client doesn't report them
+var (
+	DomainDeposit       = [4]byte{0x03, 0x00, 0x00, 0x00}
+	DomainVoluntaryExit = [4]byte{0x04, 0x00, 0x00, 0x00}
+)
+
+// Deposits are signed with an empty genesis validators root, so they're valid before genesis
+var ZeroGenesisValidatorsRoot = make([]byte, 32)
+
+// Compute the signing domain for a domain type, fork version and genesis validators root
+func ComputeDomain(domainType [4]byte, forkVersion []byte, genesisValidatorsRoot []byte) ([]byte, error) {
+
+	// Get the fork data root
+	forkData := ForkData{
+		CurrentVersion:        forkVersion,
+		GenesisValidatorsRoot: genesisValidatorsRoot,
+	}
+	forkDataRoot, err := forkData.HashTreeRoot()
+	if err != nil {
+		return nil, fmt.Errorf("error getting fork data root: %w", err)
+	}
+
+	// The domain is the domain type followed by the start of the fork data root
+	domain := make([]byte, 0, 32)
+	domain = append(domain, domainType[:]...)
+	domain = append(domain, forkDataRoot[:28]...)
+	return domain, nil
+
+}
+
+// Compute the root that's signed for an object in a signing domain
+func ComputeSigningRoot(object ssz.HashRoot, domain []byte) ([32]byte, error) {
+	objectRoot, err := object.HashTreeRoot()
+	if err != nil {
+		return [32]byte{}, fmt.Errorf("error getting object root: %w", err)
+	}
+	signingRoot := SigningRoot{
+		ObjectRoot: objectRoot[:],
+		Domain:     domain,
+	}
+	return signingRoot.HashTreeRoot()
+}
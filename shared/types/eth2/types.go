@@ -21,6 +21,12 @@ type SigningRoot struct {
 	Domain     []byte `json:"domain" ssz-size:"32"`
 }
 
+// Fork data, used to compute signing domains
+type ForkData struct {
+	CurrentVersion        []byte `json:"current_version" ssz-size:"4"`
+	GenesisValidatorsRoot []byte `json:"genesis_validators_root" ssz-size:"32"`
+}
+
 // Voluntary exit transaction
 type VoluntaryExit struct {
 	Epoch          uint64 `json:"epoch"`
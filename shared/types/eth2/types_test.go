@@ -0,0 +1,256 @@
+package eth2
+
+import (
+	"encoding/hex"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	ssz "github.com/ferranbt/fastssz"
+	"github.com/golang/snappy"
+	"gopkg.in/yaml.v2"
+)
+
+// An SSZ object that can be loaded from the values of a test case
+type sszObject interface {
+	ssz.Marshaler
+	ssz.Unmarshaler
+	ssz.HashRoot
+}
+
+// The encoded types, by their names in the consensus specs, and how to build them from the values of a test case
+var sszTypes = map[string]func(t *testing.T, value map[string]interface{}) sszObject{
+	"DepositMessage": func(t *testing.T, value map[string]interface{}) sszObject {
+		return &DepositDataNoSignature{
+			PublicKey:             getBytesValue(t, value, "pubkey"),
+			WithdrawalCredentials: getBytesValue(t, value, "withdrawal_credentials"),
+			Amount:                getUintValue(t, value, "amount"),
+		}
+	},
+	"DepositData": func(t *testing.T, value map[string]interface{}) sszObject {
+		return &DepositData{
+			PublicKey:             getBytesValue(t, value, "pubkey"),
+			WithdrawalCredentials: getBytesValue(t, value, "withdrawal_credentials"),
+			Amount:                getUintValue(t, value, "amount"),
+			Signature:             getBytesValue(t, value, "signature"),
+		}
+	},
+	"SigningData": func(t *testing.T, value map[string]interface{}) sszObject {
+		return &SigningRoot{
+			ObjectRoot: getBytesValue(t, value, "object_root"),
+			Domain:     getBytesValue(t, value, "domain"),
+		}
+	},
+	"ForkData": func(t *testing.T, value map[string]interface{}) sszObject {
+		return &ForkData{
+			CurrentVersion:        getBytesValue(t, value, "current_version"),
+			GenesisValidatorsRoot: getBytesValue(t, value, "genesis_validators_root"),
+		}
+	},
+	"VoluntaryExit": func(t *testing.T, value map[string]interface{}) sszObject {
+		return &VoluntaryExit{
+			Epoch:          getUintValue(t, value, "epoch"),
+			ValidatorIndex: getUintValue(t, value, "validator_index"),
+		}
+	},
+}
+
+// Get a hex-encoded byte field from the values of a test case
+func getBytesValue(t *testing.T, value map[string]interface{}, name string) []byte {
+	t.Helper()
+	str, ok := value[name].(string)
+	if !ok {
+		t.Fatalf("expected %s to be a hex string, got %#v", name, value[name])
+	}
+	bytes, err := hex.DecodeString(strings.TrimPrefix(str, "0x"))
+	if err != nil {
+		t.Fatalf("error decoding %s: %s", name, err.Error())
+	}
+	return bytes
+}
+
+// Get a uint64 field from the values of a test case
+func getUintValue(t *testing.T, value map[string]interface{}, name string) uint64 {
+	t.Helper()
+	switch number := value[name].(type) {
+	case int:
+		return uint64(number)
+	case uint64:
+		return number
+	}
+	t.Fatalf("expected %s to be a uint64, got %#v", name, value[name])
+	return 0
+}
+
+// Decode a hex-encoded 32 byte root
+func getRoot(t *testing.T, str string) []byte {
+	t.Helper()
+	root, err := hex.DecodeString(strings.TrimPrefix(str, "0x"))
+	if err != nil || len(root) != 32 {
+		t.Fatalf("invalid root %s", str)
+	}
+	return root
+}
+
+// Run the test cases in the consensus specs' ssz_static layout (<type>/<suite>/<case>/{value.yaml,serialized.ssz_snappy,roots.yaml}).
+// The test data was generated with an independent SSZ implementation, and the official vectors can be dropped in the same layout.
+func TestSszStatic(t *testing.T) {
+	for typeName, newObject := range sszTypes {
+		cases, err := filepath.Glob(filepath.Join("testdata", "ssz_static", typeName, "*", "case_*"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(cases) == 0 {
+			t.Errorf("no test cases for %s", typeName)
+		}
+		for _, casePath := range cases {
+			name := strings.TrimPrefix(casePath, filepath.Join("testdata", "ssz_static")+string(filepath.Separator))
+			t.Run(name, func(t *testing.T) {
+				testSszCase(t, casePath, newObject)
+			})
+		}
+	}
+}
+
+// Check that an object encodes to the serialized bytes of a test case, decodes from them, and has its root
+func testSszCase(t *testing.T, casePath string, newObject func(t *testing.T, value map[string]interface{}) sszObject) {
+
+	// Load the test case
+	valueBytes, err := ioutil.ReadFile(filepath.Join(casePath, "value.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var value map[string]interface{}
+	if err := yaml.Unmarshal(valueBytes, &value); err != nil {
+		t.Fatal(err)
+	}
+	compressed, err := ioutil.ReadFile(filepath.Join(casePath, "serialized.ssz_snappy"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	serialized, err := snappy.Decode(nil, compressed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rootsBytes, err := ioutil.ReadFile(filepath.Join(casePath, "roots.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var roots struct {
+		Root string `yaml:"root"`
+	}
+	if err := yaml.Unmarshal(rootsBytes, &roots); err != nil {
+		t.Fatal(err)
+	}
+	expectedRoot := getRoot(t, roots.Root)
+
+	// Encoding
+	object := newObject(t, value)
+	encoded, err := object.MarshalSSZ()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hex.EncodeToString(encoded) != hex.EncodeToString(serialized) {
+		t.Fatalf("expected the encoding to be %x, got %x", serialized, encoded)
+	}
+	if object.SizeSSZ() != len(serialized) {
+		t.Fatalf("expected the size to be %d, got %d", len(serialized), object.SizeSSZ())
+	}
+
+	// Decoding
+	decoded := reflect.New(reflect.TypeOf(object).Elem()).Interface().(sszObject)
+	if err := decoded.UnmarshalSSZ(serialized); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(decoded, object) {
+		t.Fatalf("expected the decoded object to be %+v, got %+v", object, decoded)
+	}
+	if err := decoded.UnmarshalSSZ(serialized[:len(serialized)-1]); err == nil {
+		t.Fatal("expected an error decoding truncated bytes")
+	}
+
+	// Hash tree root
+	root, err := object.HashTreeRoot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hex.EncodeToString(root[:]) != hex.EncodeToString(expectedRoot) {
+		t.Fatalf("expected the root to be %x, got %x", expectedRoot, root)
+	}
+
+}
+
+func TestComputeDomain(t *testing.T) {
+	tests := map[string]struct {
+		domainType            [4]byte
+		forkVersion           string
+		genesisValidatorsRoot string
+		expected              string
+	}{
+		// The published mainnet deposit domain
+		"mainnet deposit": {
+			domainType:            DomainDeposit,
+			forkVersion:           "00000000",
+			genesisValidatorsRoot: hex.EncodeToString(ZeroGenesisValidatorsRoot),
+			expected:              "03000000f5a5fd42d16a20302798ef6ed309979b43003d2320d9f0e8ea9831a9",
+		},
+		"prater deposit": {
+			domainType:            DomainDeposit,
+			forkVersion:           "00001020",
+			genesisValidatorsRoot: hex.EncodeToString(ZeroGenesisValidatorsRoot),
+			expected:              "03000000e4be9393b074ca1f3e4aabd585ca4bea101170ccfaf71b89ce5c5c38",
+		},
+		"mainnet bellatrix exit": {
+			domainType:            DomainVoluntaryExit,
+			forkVersion:           "02000000",
+			genesisValidatorsRoot: "4b363db94e286120d76eb905340fdd4e54bfe9f06bf33ff6cf5ad27f511bfe95",
+			expected:              "040000004a26c58b08add8089b75caa540848881a8d4f0af0be83417a85c0f45",
+		},
+	}
+	for name, test := range tests {
+		forkVersion, _ := hex.DecodeString(test.forkVersion)
+		genesisValidatorsRoot, _ := hex.DecodeString(test.genesisValidatorsRoot)
+		domain, err := ComputeDomain(test.domainType, forkVersion, genesisValidatorsRoot)
+		if err != nil {
+			t.Errorf("%s: %s", name, err.Error())
+		} else if hex.EncodeToString(domain) != test.expected {
+			t.Errorf("%s: expected the domain to be %s, got %x", name, test.expected, domain)
+		}
+	}
+}
+
+func TestComputeSigningRoot(t *testing.T) {
+	mainnetDepositDomain, _ := hex.DecodeString("03000000f5a5fd42d16a20302798ef6ed309979b43003d2320d9f0e8ea9831a9")
+	exitDomain, _ := hex.DecodeString("040000004a26c58b08add8089b75caa540848881a8d4f0af0be83417a85c0f45")
+	withdrawalCredentials, _ := hex.DecodeString("010000000000000000000000" + strings.Repeat("b2", 20))
+	tests := map[string]struct {
+		object   ssz.HashRoot
+		domain   []byte
+		expected string
+	}{
+		"deposit": {
+			object: &DepositDataNoSignature{
+				PublicKey:             []byte(strings.Repeat("\xa1", 48)),
+				WithdrawalCredentials: withdrawalCredentials,
+				Amount:                16000000000,
+			},
+			domain:   mainnetDepositDomain,
+			expected: "7b55fd2f1df4d643c5e0035e62ecedc0b413bdf7607eb336e4e513a363a4330e",
+		},
+		"voluntary exit": {
+			object:   &VoluntaryExit{Epoch: 194048, ValidatorIndex: 123456},
+			domain:   exitDomain,
+			expected: "0bfb7b5932726aa671135ea13e6da93386d5fcfc6c452911517ee7fef0906467",
+		},
+	}
+	for name, test := range tests {
+		root, err := ComputeSigningRoot(test.object, test.domain)
+		if err != nil {
+			t.Errorf("%s: %s", name, err.Error())
+		} else if hex.EncodeToString(root[:]) != test.expected {
+			t.Errorf("%s: expected the signing root to be %s, got %x", name, test.expected, root)
+		}
+	}
+}
@@ -1,16 +1,20 @@
 package api
 
 import (
+	"context"
 	"fmt"
 	"math/big"
 	"time"
 
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
 	"github.com/rocket-pool/rocketpool-go/settings/protocol"
 	"github.com/rocket-pool/rocketpool-go/utils"
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
 	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/events"
+	"github.com/rocket-pool/smartnode/shared/utils/eventlogs"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
 	"github.com/rocket-pool/smartnode/shared/utils/math"
 )
@@ -18,6 +22,13 @@ import (
 // The fraction of the timeout period to trigger overdue transactions
 const TimeoutSafetyFactor int = 2
 
+// The number of escalation steps and the largest multiple of the base priority fee used for overdue transactions
+const PriorityFeeEscalationSteps int = 6
+const MaxPriorityFeeMultiplier int = 4
+
+// How often to check whether a TX has been mined when waiting with a timeout
+var transactionPollInterval, _ = time.ParseDuration("12s")
+
 // Print the gas price and cost of a TX
 func PrintAndCheckGasInfo(gasInfo rocketpool.GasInfo, checkThreshold bool, gasThresholdGwei float64, logger log.ColorLogger, maxFeeWei *big.Int, gasLimit uint64) bool {
 
@@ -57,28 +68,75 @@ func PrintAndCheckGasInfo(gasInfo rocketpool.GasInfo, checkThreshold bool, gasTh
 // Print a TX's details to the logger and waits for it to be mined.
 func PrintAndWaitForTransaction(cfg *config.RocketPoolConfig, hash common.Hash, ec rocketpool.ExecutionClient, logger log.ColorLogger) error {
 
+	printTransaction(cfg, hash, logger)
+
+	// Wait for the TX to be mined
+	if _, err := utils.WaitForTransaction(ec, hash); err != nil {
+		return fmt.Errorf("Error mining transaction: %w", err)
+	}
+	events.PublishTransaction(events.EventType_TransactionMined, hash)
+
+	return nil
+
+}
+
+// Print a TX's details to the logger and waits up to timeout for it to be mined.
+// Returns false if the TX is still pending after the timeout.
+func PrintAndWaitForTransactionWithTimeout(cfg *config.RocketPoolConfig, hash common.Hash, ec rocketpool.ExecutionClient, logger log.ColorLogger, timeout time.Duration) (bool, error) {
+
+	printTransaction(cfg, hash, logger)
+
+	// Poll for the TX receipt until the timeout
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	ticker := time.NewTicker(transactionPollInterval)
+	defer ticker.Stop()
+	for {
+		receipt, err := ec.TransactionReceipt(ctx, hash)
+		if err == nil {
+			if receipt.Status == 0 {
+				return false, fmt.Errorf("Error mining transaction: Transaction failed with status 0")
+			}
+			events.PublishTransaction(events.EventType_TransactionMined, hash)
+			return true, nil
+		}
+		if err != ethereum.NotFound && ctx.Err() == nil {
+			return false, fmt.Errorf("Error getting transaction receipt: %w", err)
+		}
+		select {
+		case <-ctx.Done():
+			logger.Printlnf("The transaction still hasn't been mined after %s.", timeout)
+			return false, nil
+		case <-ticker.C:
+		}
+	}
+
+}
+
+// Print a submitted TX's details to the logger
+func printTransaction(cfg *config.RocketPoolConfig, hash common.Hash, logger log.ColorLogger) {
+
 	txWatchUrl := cfg.Smartnode.GetTxWatchUrl()
 	hashString := hash.String()
 
 	logger.Printlnf("Transaction has been submitted with hash %s.", hashString)
+	events.PublishTransaction(events.EventType_TransactionSubmitted, hash)
 	if txWatchUrl != "" {
 		logger.Printlnf("You may follow its progress by visiting:")
 		logger.Printlnf("%s/%s\n", txWatchUrl, hashString)
 	}
 	logger.Println("Waiting for the transaction to be mined...")
 
-	// Wait for the TX to be mined
-	if _, err := utils.WaitForTransaction(ec, hash); err != nil {
-		return fmt.Errorf("Error mining transaction: %w", err)
-	}
-
-	return nil
-
 }
 
 // Gets the event log interval supported by the selected eth1 client
 func GetEventLogInterval(cfg *config.RocketPoolConfig) (*big.Int, error) {
 
+	// Use the configured chunk size if there is one
+	if chunkSize := cfg.Smartnode.GetEventLogChunkSize(); chunkSize > 0 {
+		return new(big.Int).SetUint64(chunkSize), nil
+	}
+
 	// Get event log interval
 	var eventLogInterval *big.Int = nil
 	ecMode := cfg.ExecutionClientMode.Value
@@ -96,6 +154,8 @@ func GetEventLogInterval(cfg *config.RocketPoolConfig) (*big.Int, error) {
 			eventLogInterval = big.NewInt(int64(cfg.Nethermind.EventLogInterval))
 		case config.ExecutionClient_Besu:
 			eventLogInterval = big.NewInt(int64(cfg.Besu.EventLogInterval))
+		case config.ExecutionClient_Erigon:
+			eventLogInterval = big.NewInt(int64(cfg.Erigon.EventLogInterval))
 		case config.ExecutionClient_Infura:
 			eventLogInterval = big.NewInt(int64(cfg.Infura.EventLogInterval))
 		case config.ExecutionClient_Pocket:
@@ -112,6 +172,15 @@ func GetEventLogInterval(cfg *config.RocketPoolConfig) (*big.Int, error) {
 
 }
 
+// Gets a scanner for event logs that starts with the event log interval supported by the selected eth1 client
+func GetEventLogScanner(cfg *config.RocketPoolConfig, ec rocketpool.ExecutionClient) (*eventlogs.Scanner, error) {
+	eventLogInterval, err := GetEventLogInterval(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return eventlogs.NewScanner(ec, eventLogInterval.Uint64(), int(cfg.Smartnode.GetEventLogWorkers())), nil
+}
+
 // True if a transaction is due and needs to bypass the gas threshold
 func IsTransactionDue(rp *rocketpool.RocketPool, startTime time.Time) (bool, time.Duration, error) {
 
@@ -127,3 +196,36 @@ func IsTransactionDue(rp *rocketpool.RocketPool, startTime time.Time) (bool, tim
 	return isDue, timeUntilDue, nil
 
 }
+
+// Get the priority fee for an overdue transaction.
+// The fee is raised in steps from the base fee up to MaxPriorityFeeMultiplier times the base fee as the timeout approaches, capped at the max fee.
+func GetEscalatedPriorityFee(rp *rocketpool.RocketPool, startTime time.Time, basePriorityFee *big.Int, maxFee *big.Int) (*big.Int, error) {
+
+	// Get the dissolve timeout
+	timeout, err := protocol.GetMinipoolLaunchTimeout(rp, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the number of steps that have passed since the transaction became due
+	dueTime := timeout / time.Duration(TimeoutSafetyFactor)
+	escalationPeriod := timeout - dueTime
+	overdueTime := time.Since(startTime) - dueTime
+	if overdueTime <= 0 || escalationPeriod <= 0 {
+		return basePriorityFee, nil
+	}
+	step := int(overdueTime * time.Duration(PriorityFeeEscalationSteps) / escalationPeriod)
+	if step > PriorityFeeEscalationSteps {
+		step = PriorityFeeEscalationSteps
+	}
+
+	// priorityFee = base + base * (multiplier - 1) * step / steps
+	increase := new(big.Int).Mul(basePriorityFee, big.NewInt(int64((MaxPriorityFeeMultiplier-1)*step)))
+	increase.Div(increase, big.NewInt(int64(PriorityFeeEscalationSteps)))
+	priorityFee := new(big.Int).Add(basePriorityFee, increase)
+	if maxFee != nil && priorityFee.Cmp(maxFee) > 0 {
+		priorityFee.Set(maxFee)
+	}
+	return priorityFee, nil
+
+}
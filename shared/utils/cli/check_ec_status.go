@@ -34,6 +34,13 @@ func CheckExecutionClientStatus(rp *rocketpool.Client) error {
 		return nil
 	}
 
+	// Extra ECs are good; the API will try the fallback first and move on to them if it's down
+	if mgrStatus.ExtraEcEnabled && mgrStatus.ExtraEcStatus.IsSynced {
+		fmt.Printf("%sNOTE: primary and fallback execution clients are unavailable, using the extra execution client endpoints...%s\n\n", colorYellow, colorReset)
+		rp.SetEcStatusFlags(true, true)
+		return nil
+	}
+
 	// Is the primary working and syncing?
 	if mgrStatus.PrimaryEcStatus.IsWorking && mgrStatus.PrimaryEcStatus.Error == "" {
 		if mgrStatus.FallbackEnabled && mgrStatus.FallbackEcStatus.Error != "" {
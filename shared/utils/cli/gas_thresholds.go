@@ -0,0 +1,55 @@
+package cli
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Recommendations within this fraction of the current thresholds aren't worth offering
+const gasThresholdTolerance float64 = 0.1
+
+// Print a gas threshold recommendation next to the current settings, and return whether it's different enough from them to be worth applying
+func PrintGasThresholdRecommendation(cfg *config.RocketPoolConfig, recommendation api.GasThresholdRecommendationResponse) bool {
+
+	claimThreshold := cfg.Smartnode.GetRplClaimGasThreshold()
+	stakeThreshold := cfg.Smartnode.GetMinipoolStakeGasThreshold()
+
+	fmt.Printf("Over the last %d days, the base fee was below %.1f gwei 20%% of the time, below %.1f gwei half of the time, and below %.1f gwei 80%% of the time (from %d sampled blocks).\n",
+		recommendation.SampledDays, recommendation.BaseFeeLowGwei, recommendation.BaseFeeMedianGwei, recommendation.BaseFeeHighGwei, recommendation.SampledBlocks)
+	fmt.Printf("Your node has %d minipool(s).\n\n", recommendation.MinipoolCount)
+	fmt.Println("Setting                       Current     Recommended")
+	fmt.Printf("RPL Claim Gas Threshold       %-8.0f    %.0f gwei\n", claimThreshold, recommendation.RplClaimGasThreshold)
+	fmt.Printf("Minipool Stake Gas Threshold  %-8.0f    %.0f gwei\n\n", stakeThreshold, recommendation.MinipoolStakeGasThreshold)
+	fmt.Println("Claiming RPL costs the same no matter how much you claim, so smaller nodes are recommended to wait for cheaper gas. Staking a minipool sooner starts its rewards sooner, so the stake threshold lets it through on most days.")
+
+	return !isWithinTolerance(claimThreshold, recommendation.RplClaimGasThreshold) || !isWithinTolerance(stakeThreshold, recommendation.MinipoolStakeGasThreshold)
+
+}
+
+// Apply a gas threshold recommendation to the config, returning the containers that need to be restarted for it to take effect
+func ApplyGasThresholdRecommendation(cfg *config.RocketPoolConfig, recommendation api.GasThresholdRecommendationResponse) []config.ContainerID {
+	cfg.Smartnode.RplClaimGasThreshold.Value = recommendation.RplClaimGasThreshold
+	cfg.Smartnode.MinipoolStakeGasThreshold.Value = recommendation.MinipoolStakeGasThreshold
+	containers := []config.ContainerID{}
+	seen := map[config.ContainerID]bool{}
+	for _, param := range []*config.Parameter{&cfg.Smartnode.RplClaimGasThreshold, &cfg.Smartnode.MinipoolStakeGasThreshold} {
+		for _, container := range param.AffectsContainers {
+			if !seen[container] {
+				seen[container] = true
+				containers = append(containers, container)
+			}
+		}
+	}
+	return containers
+}
+
+// Check if a recommended value is close enough to the current one
+func isWithinTolerance(current float64, recommended float64) bool {
+	if recommended == 0 {
+		return current == 0
+	}
+	return math.Abs(current-recommended)/recommended <= gasThresholdTolerance
+}
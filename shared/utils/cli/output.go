@@ -0,0 +1,101 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// The output formats supported by the CLI
+const (
+	OutputFormat_Text string = "text"
+	OutputFormat_Json string = "json"
+)
+
+// The stream JSON output is written to; this is only set when JSON output is enabled
+var jsonOutput *os.File
+
+// Configure the CLI's output for the selected format.
+// In JSON mode, all of the regular text output is sent to stderr so only the JSON response is printed to stdout.
+func SetOutputFormat(format string) error {
+	switch format {
+	case OutputFormat_Text:
+		return nil
+	case OutputFormat_Json:
+		if jsonOutput == nil {
+			jsonOutput = os.Stdout
+			os.Stdout = os.Stderr
+		}
+		return nil
+	default:
+		return fmt.Errorf("Invalid output format '%s'; valid formats are '%s' and '%s'.", format, OutputFormat_Text, OutputFormat_Json)
+	}
+}
+
+// Check if the CLI should print JSON instead of formatted text
+func IsJsonOutput() bool {
+	return jsonOutput != nil
+}
+
+// Restrict JSON output to the commands that support it, by their full names (e.g. "node status").
+// Their usage says they support it, and the other commands fail in JSON mode instead of printing text with no JSON response.
+func RestrictJsonOutput(commands []cli.Command, supported map[string]bool) {
+	restrictJsonOutput(commands, supported, "")
+}
+
+// Restrict JSON output for a level of the command tree
+func restrictJsonOutput(commands []cli.Command, supported map[string]bool, prefix string) {
+	for i := range commands {
+		command := &commands[i]
+		name := strings.TrimSpace(prefix + " " + command.Name)
+		if command.Subcommands != nil {
+			restrictJsonOutput(command.Subcommands, supported, name)
+		}
+		if command.Action == nil {
+			continue
+		}
+		if supported[name] {
+			command.Usage += " (supports --output json)"
+			continue
+		}
+		action := command.Action
+		command.Action = func(c *cli.Context) error {
+			if IsJsonOutput() {
+				return fmt.Errorf("The '%s' command doesn't support JSON output; run it without '--output %s'.", name, OutputFormat_Json)
+			}
+			return cli.HandleAction(action, c)
+		}
+	}
+}
+
+// Print a command's response as JSON
+func PrintJson(response interface{}) error {
+	responseBytes, err := json.MarshalIndent(response, "", "    ")
+	if err != nil {
+		return fmt.Errorf("Could not encode the response as JSON: %w", err)
+	}
+	fmt.Fprintln(getJsonOutput(), string(responseBytes))
+	return nil
+}
+
+// Print an error as JSON, in the same format the daemon's API uses
+func PrintJsonError(err error) {
+	responseBytes, _ := json.MarshalIndent(api.APIResponse{
+		Status: "error",
+		Error:  err.Error(),
+	}, "", "    ")
+	fmt.Fprintln(getJsonOutput(), string(responseBytes))
+}
+
+// Get the stream to write JSON output to
+func getJsonOutput() *os.File {
+	if jsonOutput == nil {
+		return os.Stdout
+	}
+	return jsonOutput
+}
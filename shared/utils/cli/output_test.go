@@ -0,0 +1,67 @@
+package cli
+
+import (
+	"flag"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/urfave/cli"
+)
+
+func TestRestrictJsonOutput(t *testing.T) {
+	ran := map[string]bool{}
+	newAction := func(name string) func(c *cli.Context) error {
+		return func(c *cli.Context) error {
+			ran[name] = true
+			return nil
+		}
+	}
+	commands := []cli.Command{
+		{Name: "status", Usage: "Get the status", Action: newAction("status")},
+		{Name: "send", Usage: "Send tokens", Action: newAction("send")},
+		{Name: "proposals", Usage: "Manage proposals", Subcommands: []cli.Command{
+			{Name: "list", Usage: "List the proposals", Action: newAction("proposals list")},
+			{Name: "vote", Usage: "Vote on a proposal", Action: newAction("proposals vote")},
+		}},
+	}
+	RestrictJsonOutput(commands, map[string]bool{"status": true, "proposals list": true})
+
+	// The supported commands are documented
+	if !strings.HasSuffix(commands[0].Usage, "(supports --output json)") || !strings.HasSuffix(commands[2].Subcommands[0].Usage, "(supports --output json)") {
+		t.Fatal("expected the usage of the supported commands to say they support JSON output")
+	}
+	if strings.Contains(commands[1].Usage, "json") || strings.Contains(commands[2].Usage, "json") {
+		t.Fatal("expected the usage of the other commands to be unchanged")
+	}
+
+	// Every command runs in text mode
+	c := cli.NewContext(cli.NewApp(), flag.NewFlagSet("test", flag.ContinueOnError), nil)
+	run := func(command cli.Command) error {
+		return cli.HandleAction(command.Action, c)
+	}
+	for _, command := range []cli.Command{commands[0], commands[1], commands[2].Subcommands[0], commands[2].Subcommands[1]} {
+		if err := run(command); err != nil {
+			t.Fatalf("%s: %s", command.Name, err.Error())
+		}
+	}
+
+	// Only the supported commands run in JSON mode
+	jsonOutput = os.Stdout
+	defer func() {
+		jsonOutput = nil
+	}()
+	ran = map[string]bool{}
+	if err := run(commands[0]); err != nil || !ran["status"] {
+		t.Fatalf("expected the status command to run, got %v", err)
+	}
+	if err := run(commands[2].Subcommands[0]); err != nil || !ran["proposals list"] {
+		t.Fatalf("expected the proposals list command to run, got %v", err)
+	}
+	if err := run(commands[1]); err == nil || !strings.Contains(err.Error(), "'send' command doesn't support JSON output") || ran["send"] {
+		t.Fatalf("expected the send command to fail without running, got %v", err)
+	}
+	if err := run(commands[2].Subcommands[1]); err == nil || !strings.Contains(err.Error(), "'proposals vote'") || ran["proposals vote"] {
+		t.Fatalf("expected the proposals vote command to fail without running, got %v", err)
+	}
+}
@@ -0,0 +1,209 @@
+package cli
+
+import (
+	"fmt"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// A unit an amount can be entered in
+type AmountUnit string
+
+const (
+	AmountUnit_Wei  AmountUnit = "wei"
+	AmountUnit_Gwei AmountUnit = "gwei"
+	AmountUnit_Eth  AmountUnit = "eth"
+)
+
+// The number of wei in each unit; token names are accepted as aliases for ether-scale amounts, since all of
+// the Rocket Pool tokens have 18 decimals
+var amountUnitScales = map[string]*big.Int{
+	"wei":   big.NewInt(1),
+	"gwei":  big.NewInt(1e9),
+	"eth":   big.NewInt(1e18),
+	"ether": big.NewInt(1e18),
+	"rpl":   big.NewInt(1e18),
+	"fsrpl": big.NewInt(1e18),
+	"reth":  big.NewInt(1e18),
+}
+
+// The length of each unit a duration can be entered in
+var durationUnits = map[string]time.Duration{
+	"ms":      time.Millisecond,
+	"s":       time.Second,
+	"sec":     time.Second,
+	"secs":    time.Second,
+	"second":  time.Second,
+	"seconds": time.Second,
+	"m":       time.Minute,
+	"min":     time.Minute,
+	"mins":    time.Minute,
+	"minute":  time.Minute,
+	"minutes": time.Minute,
+	"h":       time.Hour,
+	"hr":      time.Hour,
+	"hrs":     time.Hour,
+	"hour":    time.Hour,
+	"hours":   time.Hour,
+	"d":       24 * time.Hour,
+	"day":     24 * time.Hour,
+	"days":    24 * time.Hour,
+	"w":       7 * 24 * time.Hour,
+	"week":    7 * 24 * time.Hour,
+	"weeks":   7 * 24 * time.Hour,
+}
+
+var (
+	amountPattern        = regexp.MustCompile(`^([+-]?[0-9][0-9.,'_ ]*?)(?:e([+-]?[0-9]+))?\s*([a-z]*)$`)
+	durationPartPattern  = regexp.MustCompile(`^([0-9]+(?:[.,][0-9]+)?)\s*([a-z]+)\s*`)
+	thousandsGroupLength = 3
+)
+
+// Parse an amount into wei. Amounts can have a unit (wei, gwei, eth / ether, or a token name), and are in the
+// default unit otherwise. Both dots and commas are accepted as the decimal separator, along with thousands
+// separators and scientific notation, e.g. `1,5 eth`, `1.5e18 wei`, `1,000.25` or `1'000,25`.
+func ParseAmount(value string, defaultUnit AmountUnit) (*big.Int, error) {
+
+	// Split the number from its unit
+	matches := amountPattern.FindStringSubmatch(strings.ToLower(strings.TrimSpace(value)))
+	if matches == nil {
+		return nil, fmt.Errorf("'%s' is not a number", value)
+	}
+	unit := matches[3]
+	if unit == "" {
+		unit = string(defaultUnit)
+	}
+	scale, exists := amountUnitScales[unit]
+	if !exists {
+		return nil, fmt.Errorf("unknown unit '%s' - valid units are wei, gwei, and eth", matches[3])
+	}
+
+	// Parse the number exactly, so amounts in wei don't lose any precision
+	number, err := normalizeDecimal(matches[1])
+	if err != nil {
+		return nil, err
+	}
+	if matches[2] != "" {
+		number += "e" + matches[2]
+	}
+	amount, success := new(big.Rat).SetString(number)
+	if !success {
+		return nil, fmt.Errorf("'%s' is not a number", value)
+	}
+	amount.Mul(amount, new(big.Rat).SetInt(scale))
+	if !amount.IsInt() {
+		return nil, fmt.Errorf("'%s' is not a whole number of wei", value)
+	}
+	return amount.Num(), nil
+
+}
+
+// Parse a decimal number, such as a percentage. Like amounts, both dots and commas are accepted as the decimal separator,
+// along with thousands separators, e.g. `0,5`, `1.25` or `1'000.5`.
+func ParseDecimal(value string) (float64, error) {
+	number, err := normalizeDecimal(strings.TrimSpace(value))
+	if err != nil {
+		return 0, err
+	}
+	val, err := strconv.ParseFloat(number, 64)
+	if err != nil {
+		return 0, fmt.Errorf("'%s' is not a number", value)
+	}
+	return val, nil
+}
+
+// Parse a duration. On top of Go's duration format (e.g. `1h30m45s`), this accepts days and weeks, spaces,
+// full unit names, and commas as the decimal separator, e.g. `36h`, `1.5d`, `2 weeks` or `1 day 12 hours`.
+func ParseDuration(value string) (time.Duration, error) {
+
+	remaining := strings.ToLower(strings.TrimSpace(value))
+	if remaining == "" {
+		return 0, fmt.Errorf("no duration was provided")
+	}
+	if _, err := strconv.ParseFloat(strings.Replace(remaining, ",", ".", 1), 64); err == nil {
+		return 0, fmt.Errorf("'%s' is missing a unit, e.g. 36h or 2d", value)
+	}
+
+	var duration time.Duration
+	for remaining != "" {
+		matches := durationPartPattern.FindStringSubmatch(remaining)
+		if matches == nil {
+			return 0, fmt.Errorf("'%s' is not a duration - expected e.g. 1h30m, 36h or 2d", value)
+		}
+		unit, exists := durationUnits[matches[2]]
+		if !exists {
+			return 0, fmt.Errorf("unknown unit '%s' in duration '%s'", matches[2], value)
+		}
+		number, err := strconv.ParseFloat(strings.Replace(matches[1], ",", ".", 1), 64)
+		if err != nil {
+			return 0, fmt.Errorf("'%s' is not a duration - expected e.g. 1h30m, 36h or 2d", value)
+		}
+		duration += time.Duration(number * float64(unit))
+		remaining = remaining[len(matches[0]):]
+	}
+	return duration, nil
+
+}
+
+// Convert a number written with any common decimal and thousands separators into one with a dot as the
+// decimal separator and no thousands separators
+func normalizeDecimal(number string) (string, error) {
+
+	digits := strings.NewReplacer("_", "", "'", "", " ", "").Replace(number)
+	lastDot := strings.LastIndex(digits, ".")
+	lastComma := strings.LastIndex(digits, ",")
+	dots := strings.Count(digits, ".")
+	commas := strings.Count(digits, ",")
+
+	// Find the decimal separator; a lone separator is a decimal one unless it's a comma that could just as easily
+	// be a thousands separator
+	var decimalSeparator, thousandsSeparator string
+	switch {
+	case dots > 0 && commas > 0:
+		if lastDot > lastComma {
+			decimalSeparator, thousandsSeparator = ".", ","
+		} else {
+			decimalSeparator, thousandsSeparator = ",", "."
+		}
+	case dots == 1:
+		decimalSeparator = "."
+	case dots > 1:
+		thousandsSeparator = "."
+	case commas == 1:
+		integer := strings.TrimLeft(digits[:lastComma], "+-")
+		if len(digits)-lastComma-1 == thousandsGroupLength && integer != "0" {
+			return "", fmt.Errorf("'%s' is ambiguous - write %s or %s instead", number, strings.Replace(digits, ",", "", 1), strings.Replace(digits, ",", ".", 1))
+		}
+		decimalSeparator = ","
+	case commas > 1:
+		thousandsSeparator = ","
+	}
+	if decimalSeparator != "" && strings.Count(digits, decimalSeparator) > 1 {
+		return "", fmt.Errorf("'%s' has more than one decimal separator", number)
+	}
+
+	// Check the thousands separators are in the right places
+	integer := digits
+	fraction := ""
+	if decimalSeparator != "" {
+		index := strings.LastIndex(digits, decimalSeparator)
+		integer, fraction = digits[:index], digits[index+1:]
+	}
+	if thousandsSeparator != "" {
+		groups := strings.Split(integer, thousandsSeparator)
+		for i, group := range groups {
+			if (i > 0 && len(group) != thousandsGroupLength) || strings.TrimLeft(group, "+-") == "" {
+				return "", fmt.Errorf("'%s' has misplaced thousands separators", number)
+			}
+		}
+		integer = strings.Join(groups, "")
+	}
+	if fraction == "" {
+		return integer, nil
+	}
+	return integer + "." + fraction, nil
+
+}
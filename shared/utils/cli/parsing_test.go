@@ -0,0 +1,154 @@
+package cli
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseAmount(t *testing.T) {
+	tests := []struct {
+		value       string
+		defaultUnit AmountUnit
+		expected    string
+		err         string
+	}{
+		// Units
+		{value: "1", defaultUnit: AmountUnit_Eth, expected: "1000000000000000000"},
+		{value: "1", defaultUnit: AmountUnit_Wei, expected: "1"},
+		{value: "1.5 gwei", defaultUnit: AmountUnit_Eth, expected: "1500000000"},
+		{value: "2 ETH", defaultUnit: AmountUnit_Wei, expected: "2000000000000000000"},
+		{value: "2ether", defaultUnit: AmountUnit_Wei, expected: "2000000000000000000"},
+		{value: "0.25 rpl", defaultUnit: AmountUnit_Wei, expected: "250000000000000000"},
+		{value: "1 rETH", defaultUnit: AmountUnit_Wei, expected: "1000000000000000000"},
+		{value: "1 dai", defaultUnit: AmountUnit_Eth, err: "unknown unit 'dai'"},
+
+		// Decimal separators
+		{value: "1.5", defaultUnit: AmountUnit_Eth, expected: "1500000000000000000"},
+		{value: "1,5", defaultUnit: AmountUnit_Eth, expected: "1500000000000000000"},
+		{value: "0,001", defaultUnit: AmountUnit_Eth, expected: "1000000000000000"},
+		{value: "1,25 eth", defaultUnit: AmountUnit_Wei, expected: "1250000000000000000"},
+		{value: "1.2.3", defaultUnit: AmountUnit_Eth, err: "misplaced thousands separators"},
+
+		// Thousands separators
+		{value: "1,000.25", defaultUnit: AmountUnit_Eth, expected: "1000250000000000000000"},
+		{value: "1.000,25", defaultUnit: AmountUnit_Eth, expected: "1000250000000000000000"},
+		{value: "1'000,25", defaultUnit: AmountUnit_Eth, expected: "1000250000000000000000"},
+		{value: "1 000.5", defaultUnit: AmountUnit_Eth, expected: "1000500000000000000000"},
+		{value: "1_000_000 wei", defaultUnit: AmountUnit_Eth, expected: "1000000"},
+		{value: "1,000,000", defaultUnit: AmountUnit_Wei, expected: "1000000"},
+		{value: "10,00,000", defaultUnit: AmountUnit_Wei, err: "misplaced thousands separators"},
+		{value: "1,000.5.5", defaultUnit: AmountUnit_Eth, err: "more than one decimal separator"},
+		{value: "1.000,5,5", defaultUnit: AmountUnit_Eth, err: "more than one decimal separator"},
+
+		// A lone comma followed by three digits could be either separator
+		{value: "1,000", defaultUnit: AmountUnit_Eth, err: "ambiguous - write 1000 or 1.000 instead"},
+		{value: "-12,345", defaultUnit: AmountUnit_Wei, err: "ambiguous"},
+		{value: "0,125", defaultUnit: AmountUnit_Eth, expected: "125000000000000000"},
+		{value: "1,0000", defaultUnit: AmountUnit_Eth, expected: "1000000000000000000"},
+
+		// Scientific notation
+		{value: "1.5e18", defaultUnit: AmountUnit_Wei, expected: "1500000000000000000"},
+		{value: "1.5e18 wei", defaultUnit: AmountUnit_Eth, expected: "1500000000000000000"},
+		{value: "2e-3 eth", defaultUnit: AmountUnit_Wei, expected: "2000000000000000"},
+
+		// Precision
+		{value: "123456789.123456789123456789", defaultUnit: AmountUnit_Eth, expected: "123456789123456789123456789"},
+		{value: "0.5", defaultUnit: AmountUnit_Wei, err: "not a whole number of wei"},
+		{value: "1e-19 eth", defaultUnit: AmountUnit_Wei, err: "not a whole number of wei"},
+
+		// Invalid numbers
+		{value: "", defaultUnit: AmountUnit_Eth, err: "not a number"},
+		{value: "eth", defaultUnit: AmountUnit_Eth, err: "not a number"},
+		{value: "one eth", defaultUnit: AmountUnit_Eth, err: "not a number"},
+		{value: "0x10", defaultUnit: AmountUnit_Wei, err: "not a number"},
+	}
+	for _, test := range tests {
+		amount, err := ParseAmount(test.value, test.defaultUnit)
+		if test.err != "" {
+			if err == nil || !strings.Contains(err.Error(), test.err) {
+				t.Errorf("%q: expected an error containing %q, got %v (%v)", test.value, test.err, err, amount)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q: %s", test.value, err.Error())
+			continue
+		}
+		expected, _ := new(big.Int).SetString(test.expected, 10)
+		if amount.Cmp(expected) != 0 {
+			t.Errorf("%q: expected %s, got %s", test.value, test.expected, amount.String())
+		}
+	}
+}
+
+func TestParseDecimal(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected float64
+		err      string
+	}{
+		{value: "5", expected: 5},
+		{value: " 2.5 ", expected: 2.5},
+		{value: "2,5", expected: 2.5},
+		{value: "0,125", expected: 0.125},
+		{value: "1,000.5", expected: 1000.5},
+		{value: "1.000,5", expected: 1000.5},
+		{value: "1,000", err: "ambiguous"},
+		{value: "1,2,3", err: "misplaced thousands separators"},
+		{value: "", err: "not a number"},
+		{value: "five", err: "not a number"},
+	}
+	for _, test := range tests {
+		val, err := ParseDecimal(test.value)
+		if test.err != "" {
+			if err == nil || !strings.Contains(err.Error(), test.err) {
+				t.Errorf("%q: expected an error containing %q, got %v (%v)", test.value, test.err, err, val)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q: %s", test.value, err.Error())
+		} else if val != test.expected {
+			t.Errorf("%q: expected %v, got %v", test.value, test.expected, val)
+		}
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected time.Duration
+		err      string
+	}{
+		{value: "1h30m45s", expected: time.Hour + 30*time.Minute + 45*time.Second},
+		{value: "36h", expected: 36 * time.Hour},
+		{value: "1.5d", expected: 36 * time.Hour},
+		{value: "1,5d", expected: 36 * time.Hour},
+		{value: "2 weeks", expected: 14 * 24 * time.Hour},
+		{value: "1 day 12 hours", expected: 36 * time.Hour},
+		{value: "90 Minutes", expected: 90 * time.Minute},
+		{value: "250ms", expected: 250 * time.Millisecond},
+		{value: "36", err: "missing a unit"},
+		{value: "1,5", err: "missing a unit"},
+		{value: "", err: "no duration"},
+		{value: "2 fortnights", err: "unknown unit 'fortnights'"},
+		{value: "h", err: "not a duration"},
+		{value: "1h and 5m", err: "not a duration"},
+	}
+	for _, test := range tests {
+		duration, err := ParseDuration(test.value)
+		if test.err != "" {
+			if err == nil || !strings.Contains(err.Error(), test.err) {
+				t.Errorf("%q: expected an error containing %q, got %v (%v)", test.value, test.err, err, duration)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q: %s", test.value, err.Error())
+		} else if duration != test.expected {
+			t.Errorf("%q: expected %s, got %s", test.value, test.expected, duration)
+		}
+	}
+}
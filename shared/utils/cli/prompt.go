@@ -3,6 +3,7 @@ package cli
 import (
 	"bufio"
 	"fmt"
+	"math/big"
 	"os"
 	"regexp"
 	"strconv"
@@ -28,6 +29,32 @@ func Prompt(initialPrompt string, expectedFormat string, incorrectFormatPrompt s
 
 }
 
+// Prompt for an amount, in the default unit unless the user provides one; returns the amount in wei
+func PromptAmount(initialPrompt string, defaultUnit AmountUnit) *big.Int {
+
+	// Print initial prompt
+	fmt.Println(initialPrompt)
+
+	// Get a valid amount
+	scanner := bufio.NewScanner(os.Stdin)
+	for scanner.Scan() {
+		amount, err := ParseAmount(scanner.Text(), defaultUnit)
+		if err == nil && amount.Sign() >= 0 {
+			fmt.Println("")
+			return amount
+		}
+		fmt.Println("")
+		if err != nil {
+			fmt.Printf("Invalid amount: %s\n", err.Error())
+		} else {
+			fmt.Println("Invalid amount: it can't be negative")
+		}
+	}
+	fmt.Println("")
+	return big.NewInt(0)
+
+}
+
 // Prompt for confirmation
 func Confirm(initialPrompt string) bool {
 	response := Prompt(fmt.Sprintf("%s [y/n]", initialPrompt), "(?i)^(y|yes|n|no)$", "Please answer 'y' or 'n'")
@@ -0,0 +1,47 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"time"
+	_ "time/tzdata"
+)
+
+// The layouts the CLI displays times and dates with
+const (
+	DateTimeLayout string = "2006-01-02 15:04:05 MST"
+	DateLayout     string = "2006-01-02"
+)
+
+// The timezone the CLI displays times in
+var displayLocation *time.Location = time.Local
+
+// Set the timezone the CLI displays times in.
+// The UTC override takes precedence over the configured timezone; if neither is set, the machine's local timezone is used.
+func SetDisplayTimezone(timezone string, utc bool) {
+	if utc {
+		displayLocation = time.UTC
+		return
+	}
+	if timezone == "" {
+		displayLocation = time.Local
+		return
+	}
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "The configured display timezone '%s' is invalid (%s), so local times will be shown instead.\n", timezone, err.Error())
+		displayLocation = time.Local
+		return
+	}
+	displayLocation = location
+}
+
+// Format a time for display in the CLI's timezone
+func FormatTime(t time.Time) string {
+	return t.In(displayLocation).Format(DateTimeLayout)
+}
+
+// Format the date of a time for display in the CLI's timezone
+func FormatDate(t time.Time) string {
+	return t.In(displayLocation).Format(DateLayout)
+}
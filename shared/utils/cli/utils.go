@@ -72,7 +72,7 @@ func printTransactionHashImpl(rp *rocketpool.Client, hash common.Hash, finalMess
 
 // Convert a Unix datetime to a string, or `---` if it's zero
 func GetDateTimeString(dateTime uint64) string {
-	timeString := time.Unix(int64(dateTime), 0).Format(time.RFC822)
+	timeString := FormatTime(time.Unix(int64(dateTime), 0))
 	if dateTime == 0 {
 		timeString = "---"
 	}
@@ -140,7 +140,7 @@ func PrintNetwork(rp *rocketpool.Client) error {
 		return fmt.Errorf("Settings file not found. Please run `rocketpool service config` to set up your Smartnode.")
 	}
 
-	currentNetwork := cfg.Smartnode.Network.Value.(config.Network)
+	currentNetwork := cfg.Smartnode.GetNetwork()
 	switch currentNetwork {
 	case config.Network_Mainnet:
 		fmt.Printf("Your Smartnode is currently using the %sEthereum Mainnet.%s\n\n", colorGreen, colorReset)
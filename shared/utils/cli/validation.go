@@ -7,8 +7,10 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
 	"github.com/tyler-smith/go-bip39"
 	"github.com/urfave/cli"
 
@@ -71,27 +73,49 @@ func ValidateAddress(name, value string) (common.Address, error) {
 	return common.HexToAddress(value), nil
 }
 
-// Validate a wei amount
+// Validate a comma-separated list of addresses
+func ValidateAddresses(name, value string) ([]common.Address, error) {
+	addresses := []common.Address{}
+	for _, element := range strings.Split(value, ",") {
+		element = strings.TrimSpace(element)
+		if !common.IsHexAddress(element) {
+			return nil, fmt.Errorf("Invalid %s '%s'", name, value)
+		}
+		addresses = append(addresses, common.HexToAddress(element))
+	}
+	return addresses, nil
+}
+
+// Validate a wei amount; amounts can also be given in gwei or ether with a unit, e.g. '1.5 eth'
 func ValidateWeiAmount(name, value string) (*big.Int, error) {
-	val := new(big.Int)
-	if _, ok := val.SetString(value, 10); !ok {
-		return nil, fmt.Errorf("Invalid %s '%s'", name, value)
+	val, err := ParseAmount(value, AmountUnit_Wei)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid %s '%s': %w", name, value, err)
 	}
 	return val, nil
 }
 
-// Validate an ether amount
+// Validate an ether amount; amounts can also be given in wei or gwei with a unit, e.g. '1.5e18 wei'
 func ValidateEthAmount(name, value string) (float64, error) {
-	val, err := strconv.ParseFloat(value, 64)
+	val, err := ParseAmount(value, AmountUnit_Eth)
 	if err != nil {
-		return 0, fmt.Errorf("Invalid %s '%s'", name, value)
+		return 0, fmt.Errorf("Invalid %s '%s': %w", name, value, err)
+	}
+	return eth.WeiToEth(val), nil
+}
+
+// Validate a duration, e.g. '1h30m', '36h' or '2d'
+func ValidateDuration(name, value string) (time.Duration, error) {
+	val, err := ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("Invalid %s '%s': %w", name, value, err)
 	}
 	return val, nil
 }
 
 // Validate a fraction
 func ValidateFraction(name, value string) (float64, error) {
-	val, err := strconv.ParseFloat(value, 64)
+	val, err := ParseDecimal(value)
 	if err != nil || val < 0 || val > 1 {
 		return 0, fmt.Errorf("Invalid %s '%s' - must be a number between 0 and 1", name, value)
 	}
@@ -100,7 +124,7 @@ func ValidateFraction(name, value string) (float64, error) {
 
 // Validate a percentage
 func ValidatePercentage(name, value string) (float64, error) {
-	val, err := strconv.ParseFloat(value, 64)
+	val, err := ParseDecimal(value)
 	if err != nil || val < 0 || val > 100 {
 		return 0, fmt.Errorf("Invalid %s '%s' - must be a number between 0 and 100", name, value)
 	}
@@ -177,6 +201,18 @@ func ValidateDepositWeiAmount(name, value string) (*big.Int, error) {
 	return val, nil
 }
 
+// Validate a positive duration
+func ValidatePositiveDuration(name, value string) (time.Duration, error) {
+	val, err := ValidateDuration(name, value)
+	if err != nil {
+		return 0, err
+	}
+	if val <= 0 {
+		return 0, fmt.Errorf("Invalid %s '%s' - must be greater than 0", name, value)
+	}
+	return val, nil
+}
+
 // Validate a positive ether amount
 func ValidatePositiveEthAmount(name, value string) (float64, error) {
 	val, err := ValidateEthAmount(name, value)
@@ -0,0 +1,137 @@
+package eth2
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	rputils "github.com/rocket-pool/smartnode/shared/utils/rp"
+)
+
+// Beacon chain reward constants
+const (
+	baseRewardFactor          uint64 = 64
+	effectiveBalanceIncrement uint64 = 1e9
+	weightDenominator         uint64 = 64
+	attestationWeight         uint64 = 14 + 26 + 14 // Timely source, target and head
+)
+
+// Validators need to be active for this many epochs before their performance is judged
+const minPerformanceEpochs uint64 = 10
+
+// Get the attestation performance of the minipools' validators over the last windowEpochs epochs.
+// Validators earning less than threshold percent of the ideal attestation rewards are flagged; ones that lost balance are advised to exit if they can't be fixed.
+func GetValidatorPerformance(rp *rocketpool.RocketPool, bc beacon.Client, addresses []common.Address, windowEpochs uint64, threshold float64) (uint64, uint64, []api.ValidatorPerformance, error) {
+
+	// Get the window
+	eth2Config, err := bc.GetEth2Config()
+	if err != nil {
+		return 0, 0, nil, err
+	}
+	head, err := bc.GetBeaconHead()
+	if err != nil {
+		return 0, 0, nil, err
+	}
+	endEpoch := head.FinalizedEpoch
+	startEpoch := uint64(0)
+	if endEpoch > windowEpochs {
+		startEpoch = endEpoch - windowEpochs
+	}
+
+	// Get the ideal attestation rewards per epoch, per ETH of effective balance
+	depositContract, err := bc.GetEth2DepositContract()
+	if err != nil {
+		return 0, 0, nil, err
+	}
+	rewardPerIncrement, err := getBaseRewardPerIncrement(rp, depositContract.Address)
+	if err != nil {
+		return 0, 0, nil, err
+	}
+	epochsPerYear := float64(365*24*60*60) / float64(eth2Config.SecondsPerEpoch)
+
+	// Get the validators at the start and end of the window
+	startValidators, err := rputils.GetMinipoolValidators(rp, bc, addresses, nil, &beacon.ValidatorStatusOptions{Epoch: startEpoch})
+	if err != nil {
+		return 0, 0, nil, err
+	}
+	endValidators, err := rputils.GetMinipoolValidators(rp, bc, addresses, nil, &beacon.ValidatorStatusOptions{Epoch: endEpoch})
+	if err != nil {
+		return 0, 0, nil, err
+	}
+
+	// Measure each validator
+	performance := []api.ValidatorPerformance{}
+	for _, address := range addresses {
+		end := endValidators[address]
+		if !end.Exists {
+			continue
+		}
+		validator := api.ValidatorPerformance{
+			MinipoolAddress: address,
+			Pubkey:          end.Pubkey,
+			Index:           end.Index,
+			StartEpoch:      startEpoch,
+		}
+
+		// Validators that activated during the window start from their deposit
+		startBalance := end.EffectiveBalance
+		if start := startValidators[address]; start.Exists && start.ActivationEpoch <= startEpoch {
+			startBalance = start.Balance
+		} else if end.ActivationEpoch > startEpoch {
+			validator.StartEpoch = end.ActivationEpoch
+		}
+
+		switch {
+		case end.Slashed:
+			validator.Advice = api.PerformanceAdvice_Slashed
+		case end.ActivationEpoch > endEpoch || end.ExitEpoch <= endEpoch:
+			validator.Advice = api.PerformanceAdvice_Inactive
+		case endEpoch-validator.StartEpoch < minPerformanceEpochs:
+			validator.Epochs = endEpoch - validator.StartEpoch
+			validator.Advice = api.PerformanceAdvice_InsufficientData
+		default:
+			validator.Epochs = endEpoch - validator.StartEpoch
+			validator.ActualRewards = int64(end.Balance) - int64(startBalance)
+			validator.ExpectedRewards = end.EffectiveBalance / effectiveBalanceIncrement * rewardPerIncrement * attestationWeight / weightDenominator * validator.Epochs
+			if validator.ExpectedRewards > 0 {
+				validator.Effectiveness = float64(validator.ActualRewards) / float64(validator.ExpectedRewards) * 100
+			}
+			missedRewards := float64(validator.ExpectedRewards) - float64(validator.ActualRewards)
+			if missedRewards > 0 {
+				validator.AnnualOpportunityCost = missedRewards / float64(validator.Epochs) * epochsPerYear / 1e9
+			}
+			if validator.ActualRewards <= 0 {
+				validator.Advice = api.PerformanceAdvice_Exit
+			} else if validator.Effectiveness < threshold {
+				validator.Advice = api.PerformanceAdvice_Remediate
+			} else {
+				validator.Advice = api.PerformanceAdvice_Ok
+			}
+		}
+		performance = append(performance, validator)
+	}
+
+	// Return
+	return startEpoch, endEpoch, performance, nil
+
+}
+
+// Get the base reward per increment of effective balance, in gwei.
+// The total active balance isn't available from every Beacon client, so it's estimated from the ETH held by the deposit contract.
+func getBaseRewardPerIncrement(rp *rocketpool.RocketPool, depositContractAddress common.Address) (uint64, error) {
+	depositBalance, err := rp.Client.BalanceAt(context.Background(), depositContractAddress, nil)
+	if err != nil {
+		return 0, fmt.Errorf("Could not get the balance of the deposit contract: %w", err)
+	}
+	totalActiveBalanceGwei := new(big.Int).Div(depositBalance, big.NewInt(1e9)).Uint64()
+	if totalActiveBalanceGwei == 0 {
+		return 0, fmt.Errorf("The deposit contract at %s is empty", depositContractAddress.Hex())
+	}
+	return effectiveBalanceIncrement * baseRewardFactor / uint64(math.Sqrt(float64(totalActiveBalanceGwei))), nil
+}
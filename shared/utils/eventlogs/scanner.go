@@ -0,0 +1,265 @@
+package eventlogs
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+)
+
+// Phrases in the errors providers return when a log request covers too many blocks or would return too many logs
+var responseTooLargePhrases = []string{
+	"response too large",
+	"response size exceeded",
+	"query returned more than",
+	"too many results",
+	"block range is too wide",
+	"block range too large",
+	"exceed maximum block range",
+	"query timeout exceeded",
+}
+
+// Scans ranges of blocks for event logs in chunks.
+// It starts with the chunk size the Execution client supports, halves it whenever the client says a response is too large,
+// and requests several chunks at the same time. The reduced chunk size is kept for later scans.
+type Scanner struct {
+	client    rocketpool.ExecutionClient
+	chunkSize uint64
+	workers   int
+	lock      sync.Mutex
+}
+
+// The logs of one chunk of a scan
+type chunkLogs struct {
+	start uint64
+	logs  []types.Log
+}
+
+// Create a new scanner that starts with the provided chunk size and requests up to the provided number of chunks at a time
+func NewScanner(client rocketpool.ExecutionClient, chunkSize uint64, workers int) *Scanner {
+	if chunkSize < 1 {
+		chunkSize = 1
+	}
+	if workers < 1 {
+		workers = 1
+	}
+	return &Scanner{
+		client:    client,
+		chunkSize: chunkSize,
+		workers:   workers,
+	}
+}
+
+// Get the number of blocks the scanner currently requests at a time
+func (s *Scanner) GetChunkSize() uint64 {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.chunkSize
+}
+
+// Get the logs matching a filter query. The whole chain is scanned if the query doesn't have a start block, and blocks up to the
+// latest one are scanned if it doesn't have an end block. Logs are returned in the order they were emitted.
+func (s *Scanner) FilterLogs(query ethereum.FilterQuery) ([]types.Log, error) {
+
+	// Queries for a single block don't need to be split
+	if query.BlockHash != nil {
+		return s.client.FilterLogs(context.Background(), query)
+	}
+
+	// Get the range to scan
+	var from uint64
+	if query.FromBlock != nil {
+		from = query.FromBlock.Uint64()
+	}
+	var to uint64
+	if query.ToBlock != nil {
+		to = query.ToBlock.Uint64()
+	} else {
+		latestBlock, err := s.client.BlockNumber(context.Background())
+		if err != nil {
+			return nil, fmt.Errorf("error getting the latest block number: %w", err)
+		}
+		to = latestBlock
+	}
+	if from > to {
+		return []types.Log{}, nil
+	}
+
+	// Hand out chunks to the workers, sizing each one with the current chunk size so they shrink as soon as one is too large
+	var lock sync.Mutex
+	next := from
+	finished := false
+	var scanErr error
+	results := []chunkLogs{}
+	takeChunk := func() (uint64, uint64, bool) {
+		lock.Lock()
+		defer lock.Unlock()
+		if finished || scanErr != nil {
+			return 0, 0, false
+		}
+		start := next
+		end := start + s.GetChunkSize() - 1
+		if end >= to || end < start {
+			end = to
+			finished = true
+		} else {
+			next = end + 1
+		}
+		return start, end, true
+	}
+
+	// Don't start more workers than there are chunks
+	workers := s.workers
+	if chunks := (to-from)/s.GetChunkSize() + 1; chunks < uint64(workers) {
+		workers = int(chunks)
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				start, end, ok := takeChunk()
+				if !ok {
+					return
+				}
+				logs, err := s.getChunk(query, start, end)
+				lock.Lock()
+				if err != nil {
+					if scanErr == nil {
+						scanErr = err
+					}
+				} else {
+					results = append(results, chunkLogs{start: start, logs: logs})
+				}
+				lock.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	if scanErr != nil {
+		return nil, scanErr
+	}
+
+	// Put the chunks back in order
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].start < results[j].start
+	})
+	logs := []types.Log{}
+	for _, result := range results {
+		logs = append(logs, result.logs...)
+	}
+	return logs, nil
+
+}
+
+// Get the logs matching a filter query from one of the contracts Rocket Pool has used for a contract name, including every older
+// version of it that's since been upgraded. The scan starts at the block Rocket Pool was deployed on if the query doesn't have a start block.
+func (s *Scanner) FilterContractLogs(rp *rocketpool.RocketPool, contractName string, q eth.FilterQuery) ([]types.Log, error) {
+
+	// Get the block Rocket Pool was deployed on
+	fromBlock := q.FromBlock
+	if fromBlock == nil && q.BlockHash == nil {
+		deployBlock, err := rp.RocketStorage.GetUint(nil, crypto.Keccak256Hash([]byte("deploy.block")))
+		if err != nil {
+			return nil, fmt.Errorf("error getting the Rocket Pool deployment block: %w", err)
+		}
+		fromBlock = deployBlock
+	}
+
+	// Get every address the contract has been deployed at
+	rocketDaoNodeTrustedUpgrade, err := rp.GetContract("rocketDAONodeTrustedUpgrade")
+	if err != nil {
+		return nil, err
+	}
+	upgradeLogs, err := s.FilterLogs(ethereum.FilterQuery{
+		Addresses: []common.Address{*rocketDaoNodeTrustedUpgrade.Address},
+		Topics:    [][]common.Hash{{rocketDaoNodeTrustedUpgrade.ABI.Events["ContractUpgraded"].ID}, {crypto.Keccak256Hash([]byte(contractName))}},
+		FromBlock: fromBlock,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error getting the upgrades of contract %s: %w", contractName, err)
+	}
+	addresses := []common.Address{}
+	for _, log := range upgradeLogs {
+		if len(log.Topics) > 2 {
+			addresses = append(addresses, common.HexToAddress(log.Topics[2].Hex()))
+		}
+	}
+	currentAddress, err := rp.GetAddress(contractName)
+	if err != nil {
+		return nil, err
+	}
+	addresses = append(addresses, *currentAddress)
+
+	// Get the logs from all of them
+	return s.FilterLogs(ethereum.FilterQuery{
+		Addresses: addresses,
+		Topics:    q.Topics,
+		FromBlock: fromBlock,
+		ToBlock:   q.ToBlock,
+		BlockHash: q.BlockHash,
+	})
+
+}
+
+// Get the logs for a chunk of blocks, splitting it in half and shrinking the chunk size if the response is too large
+func (s *Scanner) getChunk(query ethereum.FilterQuery, start uint64, end uint64) ([]types.Log, error) {
+
+	query.FromBlock = new(big.Int).SetUint64(start)
+	query.ToBlock = new(big.Int).SetUint64(end)
+	logs, err := s.client.FilterLogs(context.Background(), query)
+	if err == nil {
+		return logs, nil
+	}
+	if !isResponseTooLarge(err) || start == end {
+		return nil, fmt.Errorf("error getting the event logs for blocks %d to %d: %w", start, end, err)
+	}
+
+	// Split the chunk and get each half
+	s.shrink(end - start + 1)
+	middle := start + (end-start)/2
+	firstLogs, err := s.getChunk(query, start, middle)
+	if err != nil {
+		return nil, err
+	}
+	secondLogs, err := s.getChunk(query, middle+1, end)
+	if err != nil {
+		return nil, err
+	}
+	return append(firstLogs, secondLogs...), nil
+
+}
+
+// Halve the chunk size after a chunk of the provided size was too large, unless another chunk has already shrunk it further
+func (s *Scanner) shrink(size uint64) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	halved := size / 2
+	if halved < 1 {
+		halved = 1
+	}
+	if halved < s.chunkSize {
+		s.chunkSize = halved
+	}
+}
+
+// Check if an error means the log request covered too many blocks or logs
+func isResponseTooLarge(err error) bool {
+	message := strings.ToLower(err.Error())
+	for _, phrase := range responseTooLargePhrases {
+		if strings.Contains(message, phrase) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,39 @@
+package filelock
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// An exclusive lock on a file that's shared by the api, node and watchtower processes
+type FileLock struct {
+	file *os.File
+}
+
+// Take an exclusive lock on a file, blocking until any other process holding it releases it.
+// The lock is held on a separate <path>.lock file so the data file itself can be replaced atomically.
+func Lock(path string) (*FileLock, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, fmt.Errorf("Could not create the folder for %s: %w", path, err)
+	}
+	file, err := os.OpenFile(path+".lock", os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("Could not open the lock file for %s: %w", path, err)
+	}
+	if err := lockFile(file); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("Could not lock %s: %w", path, err)
+	}
+	return &FileLock{file: file}, nil
+}
+
+// Release the lock
+func (l *FileLock) Unlock() error {
+	unlockErr := unlockFile(l.file)
+	closeErr := l.file.Close()
+	if unlockErr != nil {
+		return unlockErr
+	}
+	return closeErr
+}
@@ -0,0 +1,189 @@
+package multicall
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"golang.org/x/sync/errgroup"
+)
+
+// Settings
+const (
+	// The number of calls to batch into a single request
+	BatchSize int = 100
+
+	// The number of batches to request at the same time
+	Workers int = 4
+)
+
+// The parts of the Multicall3 ABI the multicaller uses
+const multicall3Abi string = `[
+	{"inputs":[{"components":[{"internalType":"address","name":"target","type":"address"},{"internalType":"bool","name":"allowFailure","type":"bool"},{"internalType":"bytes","name":"callData","type":"bytes"}],"internalType":"struct Multicall3.Call3[]","name":"calls","type":"tuple[]"}],"name":"aggregate3","outputs":[{"components":[{"internalType":"bool","name":"success","type":"bool"},{"internalType":"bytes","name":"returnData","type":"bytes"}],"internalType":"struct Multicall3.Result[]","name":"returnData","type":"tuple[]"}],"stateMutability":"payable","type":"function"},
+	{"inputs":[{"internalType":"address","name":"addr","type":"address"}],"name":"getEthBalance","outputs":[{"internalType":"uint256","name":"balance","type":"uint256"}],"stateMutability":"view","type":"function"}
+]`
+
+// A call in an aggregate3 request
+type call3 struct {
+	Target       common.Address
+	AllowFailure bool
+	CallData     []byte
+}
+
+// The result of a call in an aggregate3 request
+type result struct {
+	Success    bool
+	ReturnData []byte
+}
+
+// A queued call and where to put its result
+type queuedCall struct {
+	target common.Address
+	abi    *abi.ABI
+	method string
+	data   []byte
+	output interface{}
+}
+
+// Batches contract calls into aggregate3 requests to the Multicall3 contract, so reading many values takes a handful of requests
+// instead of one per value. Calls are queued with AddCall, then all made at once with Execute.
+type MultiCaller struct {
+	client  rocketpool.ExecutionClient
+	address common.Address
+	abi     abi.ABI
+	calls   []queuedCall
+	err     error
+}
+
+// Create a new multicaller that uses the Multicall3 contract at the provided address
+func NewMultiCaller(client rocketpool.ExecutionClient, address common.Address) (*MultiCaller, error) {
+	multicallAbi, err := abi.JSON(strings.NewReader(multicall3Abi))
+	if err != nil {
+		return nil, fmt.Errorf("error parsing the Multicall3 ABI: %w", err)
+	}
+	return &MultiCaller{
+		client:  client,
+		address: address,
+		abi:     multicallAbi,
+	}, nil
+}
+
+// Queue a call to a contract method. Its result is unpacked into output, which must be a pointer, when the calls are executed.
+// Any error queuing the call is returned by Execute.
+func (mc *MultiCaller) AddCall(contract *rocketpool.Contract, output interface{}, method string, args ...interface{}) {
+	mc.addCall(*contract.Address, contract.ABI, output, method, args...)
+}
+
+// Queue a query for the ETH balance of an address
+func (mc *MultiCaller) AddEthBalance(address common.Address, output **big.Int) {
+	mc.addCall(mc.address, &mc.abi, output, "getEthBalance", address)
+}
+
+// Get the number of queued calls
+func (mc *MultiCaller) Count() int {
+	return len(mc.calls)
+}
+
+// Make all of the queued calls at the block in opts, in batches of BatchSize, and unpack their results.
+// The queue is cleared afterwards so the multicaller can be reused.
+func (mc *MultiCaller) Execute(opts *bind.CallOpts) error {
+
+	calls, err := mc.calls, mc.err
+	mc.calls, mc.err = nil, nil
+	if err != nil {
+		return err
+	}
+
+	var blockNumber *big.Int
+	if opts != nil {
+		blockNumber = opts.BlockNumber
+	}
+
+	// Request the batches, a few at a time
+	var wg errgroup.Group
+	semaphore := make(chan struct{}, Workers)
+	for start := 0; start < len(calls); start += BatchSize {
+		end := start + BatchSize
+		if end > len(calls) {
+			end = len(calls)
+		}
+		batch := calls[start:end]
+		wg.Go(func() error {
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+			return mc.executeBatch(batch, blockNumber)
+		})
+	}
+	return wg.Wait()
+
+}
+
+// Queue a call to a method on a target
+func (mc *MultiCaller) addCall(target common.Address, contractAbi *abi.ABI, output interface{}, method string, args ...interface{}) {
+	if mc.err != nil {
+		return
+	}
+	data, err := contractAbi.Pack(method, args...)
+	if err != nil {
+		mc.err = fmt.Errorf("error packing call to %s on %s: %w", method, target.Hex(), err)
+		return
+	}
+	mc.calls = append(mc.calls, queuedCall{
+		target: target,
+		abi:    contractAbi,
+		method: method,
+		data:   data,
+		output: output,
+	})
+}
+
+// Make a batch of calls in a single aggregate3 request
+func (mc *MultiCaller) executeBatch(batch []queuedCall, blockNumber *big.Int) error {
+
+	// Failures are allowed so the one that failed can be reported
+	requests := make([]call3, len(batch))
+	for i, call := range batch {
+		requests[i] = call3{
+			Target:       call.target,
+			AllowFailure: true,
+			CallData:     call.data,
+		}
+	}
+	data, err := mc.abi.Pack("aggregate3", requests)
+	if err != nil {
+		return fmt.Errorf("error packing multicall: %w", err)
+	}
+	response, err := mc.client.CallContract(context.Background(), ethereum.CallMsg{
+		To:   &mc.address,
+		Data: data,
+	}, blockNumber)
+	if err != nil {
+		return fmt.Errorf("error making multicall: %w", err)
+	}
+	values, err := mc.abi.Unpack("aggregate3", response)
+	if err != nil {
+		return fmt.Errorf("error unpacking multicall response: %w", err)
+	}
+	results := *abi.ConvertType(values[0], new([]result)).(*[]result)
+	if len(results) != len(batch) {
+		return fmt.Errorf("multicall returned %d results for %d calls", len(results), len(batch))
+	}
+
+	// Unpack the results
+	for i, call := range batch {
+		if !results[i].Success {
+			return fmt.Errorf("call to %s on %s failed", call.method, call.target.Hex())
+		}
+		if err := call.abi.UnpackIntoInterface(call.output, call.method, results[i].ReturnData); err != nil {
+			return fmt.Errorf("error unpacking the result of %s on %s: %w", call.method, call.target.Hex(), err)
+		}
+	}
+	return nil
+
+}
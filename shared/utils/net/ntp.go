@@ -0,0 +1,72 @@
+package net
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"time"
+)
+
+// The seconds between the NTP epoch (1900) and the Unix epoch (1970)
+const ntpEpochOffset = 2208988800
+
+// The size of an NTP packet without extensions
+const ntpPacketSize = 48
+
+// Get how far the local clock is from an NTP server's, using a single SNTP request.
+// A positive offset means the local clock is behind the server.
+func GetClockOffset(server string, timeout time.Duration) (time.Duration, error) {
+
+	conn, err := net.DialTimeout("udp", DefaultPort(server, "123"), timeout)
+	if err != nil {
+		return 0, fmt.Errorf("could not connect to NTP server %s: %w", server, err)
+	}
+	defer conn.Close()
+	if err := conn.SetDeadline(time.Now().Add(timeout)); err != nil {
+		return 0, err
+	}
+
+	// Send a version 4 client request with the local transmit time
+	request := make([]byte, ntpPacketSize)
+	request[0] = 0x23
+	sent := time.Now()
+	putNtpTime(request[40:], sent)
+	if _, err := conn.Write(request); err != nil {
+		return 0, fmt.Errorf("could not send request to NTP server %s: %w", server, err)
+	}
+
+	response := make([]byte, ntpPacketSize)
+	n, err := conn.Read(response)
+	received := time.Now()
+	if err != nil {
+		return 0, fmt.Errorf("could not read response from NTP server %s: %w", server, err)
+	}
+	if n < ntpPacketSize {
+		return 0, fmt.Errorf("NTP server %s sent a short response", server)
+	}
+	if stratum := response[1]; stratum == 0 || stratum > 15 {
+		return 0, fmt.Errorf("NTP server %s isn't synchronized (stratum %d)", server, stratum)
+	}
+
+	// The offset is the average of the differences on the way there and back, which cancels out the network delay
+	serverReceived := getNtpTime(response[32:])
+	serverSent := getNtpTime(response[40:])
+	return (serverReceived.Sub(sent) + serverSent.Sub(received)) / 2, nil
+
+}
+
+// Write a time in NTP's 64-bit fixed-point format
+func putNtpTime(buffer []byte, t time.Time) {
+	seconds := uint64(t.Unix()) + ntpEpochOffset
+	fraction := uint64(t.Nanosecond()) << 32 / uint64(time.Second)
+	binary.BigEndian.PutUint32(buffer[0:], uint32(seconds))
+	binary.BigEndian.PutUint32(buffer[4:], uint32(fraction))
+}
+
+// Read a time in NTP's 64-bit fixed-point format
+func getNtpTime(buffer []byte) time.Time {
+	seconds := int64(binary.BigEndian.Uint32(buffer[0:])) - ntpEpochOffset
+	fraction := uint64(binary.BigEndian.Uint32(buffer[4:]))
+	nanoseconds := fraction * uint64(time.Second) >> 32
+	return time.Unix(seconds, int64(nanoseconds))
+}
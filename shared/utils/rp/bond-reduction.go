@@ -0,0 +1,237 @@
+package rp
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/network"
+	"github.com/rocket-pool/rocketpool-go/node"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/settings/protocol"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+
+	"github.com/rocket-pool/smartnode/shared/utils/eventlogs"
+)
+
+// Returned when the network's contracts don't support reducing minipool bonds yet
+var ErrBondReductionNotSupported = errors.New("Minipool bond reduction isn't supported by the Rocket Pool contracts on this network yet.")
+
+// The bond reduction a minipool has started
+type BondReduction struct {
+	Started   bool
+	StartTime time.Time
+	NewBond   *big.Int
+	Cancelled bool
+}
+
+// Get the bond reduction a minipool has started
+func GetBondReduction(rp *rocketpool.RocketPool, minipoolAddress common.Address, opts *bind.CallOpts) (BondReduction, error) {
+	bondReducer, err := getBondReducer(rp)
+	if err != nil {
+		return BondReduction{}, err
+	}
+	startTime := new(*big.Int)
+	if err := bondReducer.Call(opts, startTime, "getReduceBondTime", minipoolAddress); err != nil {
+		return BondReduction{}, fmt.Errorf("Could not get the bond reduction start time of minipool %s: %w", minipoolAddress.Hex(), err)
+	}
+	newBond := new(*big.Int)
+	if err := bondReducer.Call(opts, newBond, "getReduceBondValue", minipoolAddress); err != nil {
+		return BondReduction{}, fmt.Errorf("Could not get the new bond of minipool %s: %w", minipoolAddress.Hex(), err)
+	}
+	cancelled := new(bool)
+	if err := bondReducer.Call(opts, cancelled, "getReduceBondCancelled", minipoolAddress); err != nil {
+		return BondReduction{}, fmt.Errorf("Could not get the bond reduction cancellation status of minipool %s: %w", minipoolAddress.Hex(), err)
+	}
+	return BondReduction{
+		Started:   (*startTime).Sign() > 0,
+		StartTime: time.Unix((*startTime).Int64(), 0),
+		NewBond:   *newBond,
+		Cancelled: *cancelled,
+	}, nil
+}
+
+// Check if a minipool's bond reduction window is open, so the reduction it started can be completed
+func CanReduceBondAmount(rp *rocketpool.RocketPool, minipoolAddress common.Address, opts *bind.CallOpts) (bool, error) {
+	bondReducer, err := getBondReducer(rp)
+	if err != nil {
+		return false, err
+	}
+	canReduce := new(bool)
+	if err := bondReducer.Call(opts, canReduce, "canReduceBondAmount", minipoolAddress); err != nil {
+		return false, fmt.Errorf("Could not check if minipool %s can reduce its bond: %w", minipoolAddress.Hex(), err)
+	}
+	return *canReduce, nil
+}
+
+// Get how long after starting a bond reduction the window to complete it opens, and how long it stays open
+func GetBondReductionWindow(rp *rocketpool.RocketPool, opts *bind.CallOpts) (time.Duration, time.Duration, error) {
+	minipoolSettings, err := rp.GetContract("rocketDAOProtocolSettingsMinipool")
+	if err != nil {
+		return 0, 0, err
+	}
+	if _, exists := minipoolSettings.ABI.Methods["getBondReductionWindowStart"]; !exists {
+		return 0, 0, ErrBondReductionNotSupported
+	}
+	windowStart := new(*big.Int)
+	if err := minipoolSettings.Call(opts, windowStart, "getBondReductionWindowStart"); err != nil {
+		return 0, 0, fmt.Errorf("Could not get the bond reduction window start: %w", err)
+	}
+	windowLength := new(*big.Int)
+	if err := minipoolSettings.Call(opts, windowLength, "getBondReductionWindowLength"); err != nil {
+		return 0, 0, fmt.Errorf("Could not get the bond reduction window length: %w", err)
+	}
+	return time.Duration((*windowStart).Int64()) * time.Second, time.Duration((*windowLength).Int64()) * time.Second, nil
+}
+
+// Get the minimum RPL stake a node will need once one of its minipools has reduced its bond from currentBond to newBond
+func GetMinimumRplStakeAfterReduction(rp *rocketpool.RocketPool, nodeAddress common.Address, currentBond *big.Int, newBond *big.Int, opts *bind.CallOpts) (*big.Int, error) {
+	minimumStake, err := node.GetNodeMinimumRPLStake(rp, nodeAddress, opts)
+	if err != nil {
+		return nil, err
+	}
+	minimumPerMinipoolStake, err := protocol.GetMinimumPerMinipoolStake(rp, opts)
+	if err != nil {
+		return nil, err
+	}
+	rplPrice, err := network.GetRPLPrice(rp, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// The ETH the protocol provides for the minipool goes up by as much as the bond goes down, and has to be collateralized at the same rate
+	additionalBorrowedEth := big.NewInt(0).Sub(currentBond, newBond)
+	additionalStake := big.NewInt(0).Mul(additionalBorrowedEth, eth.EthToWei(minimumPerMinipoolStake))
+	additionalStake.Div(additionalStake, rplPrice)
+	return additionalStake.Add(additionalStake, minimumStake), nil
+}
+
+// Estimate the gas of starting a minipool's bond reduction
+func EstimateBeginReduceBondAmountGas(rp *rocketpool.RocketPool, minipoolAddress common.Address, newBond *big.Int, opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+	bondReducer, err := getBondReducer(rp)
+	if err != nil {
+		return rocketpool.GasInfo{}, err
+	}
+	return bondReducer.GetTransactionGasInfo(opts, "beginReduceBondAmount", minipoolAddress, newBond)
+}
+
+// Start a minipool's bond reduction, which the Oracle DAO can cancel before the reduction window opens
+func BeginReduceBondAmount(rp *rocketpool.RocketPool, minipoolAddress common.Address, newBond *big.Int, opts *bind.TransactOpts) (common.Hash, error) {
+	bondReducer, err := getBondReducer(rp)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	hash, err := bondReducer.Transact(opts, "beginReduceBondAmount", minipoolAddress, newBond)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("Could not begin the bond reduction of minipool %s: %w", minipoolAddress.Hex(), err)
+	}
+	return hash, nil
+}
+
+// Estimate the gas of completing a minipool's bond reduction
+func EstimateReduceBondAmountGas(rp *rocketpool.RocketPool, minipoolAddress common.Address, opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+	mp, err := getBondReducingMinipool(rp, minipoolAddress)
+	if err != nil {
+		return rocketpool.GasInfo{}, err
+	}
+	return mp.GetTransactionGasInfo(opts, "reduceBondAmount")
+}
+
+// Complete a minipool's bond reduction once its reduction window is open
+func ReduceBondAmount(rp *rocketpool.RocketPool, minipoolAddress common.Address, opts *bind.TransactOpts) (common.Hash, error) {
+	mp, err := getBondReducingMinipool(rp, minipoolAddress)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	hash, err := mp.Transact(opts, "reduceBondAmount")
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("Could not reduce the bond of minipool %s: %w", minipoolAddress.Hex(), err)
+	}
+	return hash, nil
+}
+
+// Estimate the gas of an Oracle DAO member voting to cancel a minipool's bond reduction
+func EstimateVoteCancelReductionGas(rp *rocketpool.RocketPool, minipoolAddress common.Address, opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+	bondReducer, err := getBondReducer(rp)
+	if err != nil {
+		return rocketpool.GasInfo{}, err
+	}
+	return bondReducer.GetTransactionGasInfo(opts, "voteCancelReduction", minipoolAddress)
+}
+
+// Vote to cancel a minipool's bond reduction as an Oracle DAO member
+func VoteCancelReduction(rp *rocketpool.RocketPool, minipoolAddress common.Address, opts *bind.TransactOpts) (common.Hash, error) {
+	bondReducer, err := getBondReducer(rp)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	hash, err := bondReducer.Transact(opts, "voteCancelReduction", minipoolAddress)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("Could not vote to cancel the bond reduction of minipool %s: %w", minipoolAddress.Hex(), err)
+	}
+	return hash, nil
+}
+
+// Get the minipools that started a bond reduction since a block
+func GetBondReductionsStartedSince(rp *rocketpool.RocketPool, fromBlock *big.Int, scanner *eventlogs.Scanner) ([]common.Address, error) {
+	bondReducer, err := getBondReducer(rp)
+	if err != nil {
+		return nil, err
+	}
+	beginEvent, exists := bondReducer.ABI.Events["BeginBondReduction"]
+	if !exists {
+		return nil, ErrBondReductionNotSupported
+	}
+	logs, err := scanner.FilterContractLogs(rp, "rocketMinipoolBondReducer", eth.FilterQuery{
+		FromBlock: fromBlock,
+		Topics:    [][]common.Hash{{beginEvent.ID}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Could not get the bond reduction events: %w", err)
+	}
+
+	// The minipool is the event's first indexed topic
+	minipools := []common.Address{}
+	seen := map[common.Address]bool{}
+	for _, log := range logs {
+		if len(log.Topics) < 2 {
+			continue
+		}
+		address := common.BytesToAddress(log.Topics[1].Bytes())
+		if !seen[address] {
+			seen[address] = true
+			minipools = append(minipools, address)
+		}
+	}
+	return minipools, nil
+}
+
+// Get the bond reducer contract, if the network has one
+func getBondReducer(rp *rocketpool.RocketPool) (*rocketpool.Contract, error) {
+	address, err := rp.GetAddress("rocketMinipoolBondReducer")
+	if err != nil {
+		return nil, err
+	}
+	if *address == (common.Address{}) {
+		return nil, ErrBondReductionNotSupported
+	}
+	return rp.GetContract("rocketMinipoolBondReducer")
+}
+
+// Get a minipool contract with the delegate ABI that can complete bond reductions
+func getBondReducingMinipool(rp *rocketpool.RocketPool, minipoolAddress common.Address) (*rocketpool.Contract, error) {
+	if _, err := getBondReducer(rp); err != nil {
+		return nil, err
+	}
+	mp, err := rp.MakeContract("rocketMinipoolDelegate", minipoolAddress)
+	if err != nil {
+		return nil, err
+	}
+	if _, exists := mp.ABI.Methods["reduceBondAmount"]; !exists {
+		return nil, ErrBondReductionNotSupported
+	}
+	return mp, nil
+}
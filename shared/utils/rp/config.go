@@ -8,7 +8,6 @@ import (
 
 	"github.com/alessio/shellescape"
 	"github.com/rocket-pool/smartnode/shared/services/config"
-	"gopkg.in/yaml.v2"
 )
 
 const (
@@ -33,8 +32,7 @@ func LoadConfigFromFile(path string) (*config.RocketPoolConfig, error) {
 // Saves a config and removes the upgrade flag file
 func SaveConfig(cfg *config.RocketPoolConfig, path string) error {
 
-	settings := cfg.Serialize()
-	configBytes, err := yaml.Marshal(settings)
+	configBytes, err := cfg.MarshalSettings()
 	if err != nil {
 		return fmt.Errorf("could not serialize settings file: %w", err)
 	}
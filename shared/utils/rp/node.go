@@ -29,19 +29,16 @@ func GetNodeValidatorIndices(rp *rocketpool.RocketPool, ec rocketpool.ExecutionC
 		return nil, err
 	}
 
-	// Get validator statuses by pubkeys
-	statuses, err := bc.GetValidatorStatuses(pubkeys, nil)
+	// Get the validator indices, from the cache where possible
+	indices, err := beacon.GetValidatorIndices(bc, pubkeys)
 	if err != nil {
-		return nil, fmt.Errorf("Error getting validator statuses: %w", err)
+		return nil, fmt.Errorf("Error getting validator indices: %w", err)
 	}
 
-	// Enumerate validators statuses and fill indices array
-	validatorIndices := make([]uint64, len(statuses)+1)
-
-	i := 0
-	for _, status := range statuses {
-		validatorIndices[i] = status.Index
-		i++
+	// Fill the indices array
+	validatorIndices := make([]uint64, 0, len(indices))
+	for _, index := range indices {
+		validatorIndices = append(validatorIndices, index)
 	}
 
 	return validatorIndices, nil
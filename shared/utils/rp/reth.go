@@ -0,0 +1,102 @@
+package rp
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/tokens"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/contracts"
+)
+
+// Settings
+const rethPriceRequestTimeout = 15 * time.Second
+
+// The rETH exchange rate set by the protocol and the rate it trades at on secondary markets, in ETH per rETH
+type RethRates struct {
+	ProtocolRate float64 `json:"protocolRate"`
+	MarketRate   float64 `json:"marketRate"`
+
+	// How far the market rate is above (positive) or below (negative) the protocol rate, as a percentage
+	Deviation float64 `json:"deviation"`
+}
+
+// Get the protocol rETH rate, and the secondary-market rate from the configured price source
+func GetRethRates(rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, oio *contracts.OneInchOracle) (RethRates, error) {
+
+	protocolRate, err := tokens.GetRETHExchangeRate(rp, nil)
+	if err != nil {
+		return RethRates{}, err
+	}
+
+	var marketRate float64
+	switch source := cfg.Smartnode.GetRethPriceSource(); source {
+	case config.RethPriceSource_OneInch:
+		marketRate, err = getOneInchRethRate(rp, cfg, oio)
+	case config.RethPriceSource_Url:
+		marketRate, err = getUrlRethRate(cfg.Smartnode.GetRethPriceUrl())
+	default:
+		return RethRates{}, fmt.Errorf("rETH price source [%s] can't be used to get the secondary-market rate", source)
+	}
+	if err != nil {
+		return RethRates{}, err
+	}
+
+	rates := RethRates{
+		ProtocolRate: protocolRate,
+		MarketRate:   marketRate,
+	}
+	if protocolRate > 0 {
+		rates.Deviation = (marketRate - protocolRate) / protocolRate * 100
+	}
+	return rates, nil
+
+}
+
+// Get the secondary-market rETH rate from the 1inch offchain oracle
+func getOneInchRethRate(rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, oio *contracts.OneInchOracle) (float64, error) {
+	if cfg.Smartnode.GetOneInchOracleAddress() == "" {
+		return 0, fmt.Errorf("the 1inch oracle isn't available on this network")
+	}
+	rethAddress, err := rp.GetAddress("rocketTokenRETH")
+	if err != nil {
+		return 0, err
+	}
+	rate, err := oio.GetRateToEth(nil, *rethAddress, true)
+	if err != nil {
+		return 0, fmt.Errorf("Could not get the rETH rate from the 1inch oracle: %w", err)
+	}
+	return eth.WeiToEth(rate), nil
+}
+
+// Get the secondary-market rETH rate from a URL that returns it as a plain number
+func getUrlRethRate(url string) (float64, error) {
+	client := http.Client{Timeout: rethPriceRequestTimeout}
+	response, err := client.Get(url)
+	if err != nil {
+		return 0, fmt.Errorf("Could not get the rETH rate from %s: %w", url, err)
+	}
+	defer response.Body.Close()
+	body, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return 0, fmt.Errorf("Could not read the rETH rate from %s: %w", url, err)
+	}
+	if response.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("Could not get the rETH rate from %s: request failed with code %d", url, response.StatusCode)
+	}
+	rate, err := strconv.ParseFloat(strings.TrimSpace(string(body)), 64)
+	if err != nil {
+		return 0, fmt.Errorf("%s didn't return a valid rETH rate: %w", url, err)
+	}
+	if rate <= 0 {
+		return 0, fmt.Errorf("%s returned an invalid rETH rate of %f", url, rate)
+	}
+	return rate, nil
+}
@@ -21,19 +21,12 @@ func GetDepositData(validatorKey *eth2types.BLSPrivateKey, withdrawalCredentials
 		Amount:                DepositAmount,
 	}
 
-	// Get signing root
-	or, err := dd.HashTreeRoot()
+	// Get signing root with domain
+	domain, err := eth2Config.GetDepositDomain()
 	if err != nil {
 		return eth2.DepositData{}, common.Hash{}, err
 	}
-
-	sr := eth2.SigningRoot{
-		ObjectRoot: or[:],
-		Domain:     eth2types.Domain(eth2types.DomainDeposit, eth2Config.GenesisForkVersion, eth2types.ZeroGenesisValidatorsRoot),
-	}
-
-	// Get signing root with domain
-	srHash, err := sr.HashTreeRoot()
+	srHash, err := eth2.ComputeSigningRoot(&dd, domain)
 	if err != nil {
 		return eth2.DepositData{}, common.Hash{}, err
 	}
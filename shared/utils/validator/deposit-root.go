@@ -0,0 +1,67 @@
+package validator
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/rocket-pool/smartnode/shared/types/eth2"
+)
+
+// Check a deposit data root against one computed independently of the SSZ library, the same way the deposit contract does.
+// The deposit contract rejects deposits whose root doesn't match their data, so a serialization bug would otherwise only show up as a failed transaction.
+func VerifyDepositDataRoot(depositData eth2.DepositData, depositDataRoot common.Hash) error {
+	expectedRoot, err := computeDepositDataRoot(depositData)
+	if err != nil {
+		return err
+	}
+	if expectedRoot != depositDataRoot {
+		return fmt.Errorf("the deposit data root %s does not match the root %s computed from the deposit data", depositDataRoot.Hex(), expectedRoot.Hex())
+	}
+	return nil
+}
+
+// Compute the root of deposit data following the deposit contract's implementation
+func computeDepositDataRoot(depositData eth2.DepositData) (common.Hash, error) {
+
+	// Check the field lengths
+	if len(depositData.PublicKey) != 48 {
+		return common.Hash{}, fmt.Errorf("the deposit data pubkey is %d bytes instead of 48", len(depositData.PublicKey))
+	}
+	if len(depositData.WithdrawalCredentials) != 32 {
+		return common.Hash{}, fmt.Errorf("the deposit data withdrawal credentials are %d bytes instead of 32", len(depositData.WithdrawalCredentials))
+	}
+	if len(depositData.Signature) != 96 {
+		return common.Hash{}, fmt.Errorf("the deposit data signature is %d bytes instead of 96", len(depositData.Signature))
+	}
+
+	// The pubkey is padded to two chunks
+	pubkeyRoot := sha256.Sum256(append(append([]byte{}, depositData.PublicKey...), make([]byte, 16)...))
+
+	// The signature is three chunks, padded to four
+	signature := depositData.Signature
+	signatureRoot := hashPair(
+		sha256.Sum256(signature[:64]),
+		sha256.Sum256(append(append([]byte{}, signature[64:]...), make([]byte, 32)...)),
+	)
+
+	// The amount is a little-endian uint64 padded to a chunk
+	var amount [32]byte
+	binary.LittleEndian.PutUint64(amount[:], depositData.Amount)
+
+	// Merkleize the four fields
+	var withdrawalCredentials [32]byte
+	copy(withdrawalCredentials[:], depositData.WithdrawalCredentials)
+	return common.Hash(hashPair(
+		hashPair(pubkeyRoot, withdrawalCredentials),
+		hashPair(amount, signatureRoot),
+	)), nil
+
+}
+
+// Hash two chunks together
+func hashPair(left [32]byte, right [32]byte) [32]byte {
+	return sha256.Sum256(append(left[:], right[:]...))
+}
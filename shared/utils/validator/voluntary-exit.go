@@ -15,19 +15,8 @@ func GetSignedExitMessage(validatorKey *eth2types.BLSPrivateKey, validatorIndex
 		ValidatorIndex: validatorIndex,
 	}
 
-	// Get object root
-	or, err := exitMessage.HashTreeRoot()
-	if err != nil {
-		return types.ValidatorSignature{}, err
-	}
-
 	// Get signing root
-	sr := eth2.SigningRoot{
-		ObjectRoot: or[:],
-		Domain:     signatureDomain,
-	}
-
-	srHash, err := sr.HashTreeRoot()
+	srHash, err := eth2.ComputeSigningRoot(&exitMessage, signatureDomain)
 	if err != nil {
 		return types.ValidatorSignature{}, err
 	}
@@ -6,6 +6,7 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -22,17 +23,83 @@ import (
 	"gopkg.in/yaml.v2"
 )
 
+// Settings for searching other derivation paths for validator keys that aren't on the Smartnode's own path
+type ValidatorKeySearch struct {
+	PathTemplates []string
+	StartIndex    uint
+	EndIndex      uint
+}
+
+// Get the validator key search settings from a recover command's flags, or nil if a search wasn't requested
+func GetValidatorKeySearch(c *cli.Context) (*ValidatorKeySearch, error) {
+
+	customPaths := c.String("validator-key-paths")
+	if !c.Bool("search-validator-paths") && customPaths == "" {
+		return nil, nil
+	}
+
+	// Get the path templates, with the Smartnode's own path first
+	search := &ValidatorKeySearch{
+		PathTemplates: []string{wallet.ValidatorKeyPath},
+		StartIndex:    0,
+		EndIndex:      wallet.DefaultValidatorKeySearchLimit,
+	}
+	if c.Bool("search-validator-paths") {
+		search.PathTemplates = append(search.PathTemplates, wallet.KnownValidatorKeyPaths...)
+	}
+	if customPaths != "" {
+		for _, path := range strings.Split(customPaths, ",") {
+			path = strings.TrimSpace(path)
+			if strings.Count(path, "%d") != 1 {
+				return nil, fmt.Errorf("invalid validator key path [%s]: it must contain exactly one %%d for the key index", path)
+			}
+			search.PathTemplates = append(search.PathTemplates, path)
+		}
+	}
+
+	// Get the index range
+	if indexRange := c.String("validator-key-index-range"); indexRange != "" {
+		bounds := strings.Split(indexRange, "-")
+		if len(bounds) != 2 {
+			return nil, fmt.Errorf("invalid validator key index range [%s]: it must be in the form start-end", indexRange)
+		}
+		start, err := strconv.ParseUint(strings.TrimSpace(bounds[0]), 10, 0)
+		if err != nil {
+			return nil, fmt.Errorf("invalid validator key index range start [%s]: %w", bounds[0], err)
+		}
+		end, err := strconv.ParseUint(strings.TrimSpace(bounds[1]), 10, 0)
+		if err != nil {
+			return nil, fmt.Errorf("invalid validator key index range end [%s]: %w", bounds[1], err)
+		}
+		if end < start {
+			return nil, fmt.Errorf("invalid validator key index range [%s]: the end is before the start", indexRange)
+		}
+		search.StartIndex = uint(start)
+		search.EndIndex = uint(end) + 1
+	}
+
+	return search, nil
+
+}
+
 func RecoverMinipoolKeys(c *cli.Context, rp *rocketpool.RocketPool, address common.Address, w *wallet.Wallet, testOnly bool) ([]types.ValidatorPubkey, error) {
+	pubkeys, _, err := RecoverMinipoolKeysWithSearch(c, rp, address, w, testOnly, nil)
+	return pubkeys, err
+}
+
+// Recover the node's minipool keys, searching the given derivation paths for the ones that aren't custom keys or on the Smartnode's own path.
+// Returns the node's validating pubkeys and the paths that the searched keys were found on.
+func RecoverMinipoolKeysWithSearch(c *cli.Context, rp *rocketpool.RocketPool, address common.Address, w *wallet.Wallet, testOnly bool, search *ValidatorKeySearch) ([]types.ValidatorPubkey, []api.ValidatorKeyPath, error) {
 
 	cfg, err := services.GetConfig(c)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	// Get node's validating pubkeys
 	pubkeys, err := minipool.GetNodeValidatingMinipoolPubkeys(rp, address, nil)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	pubkeyMap := map[types.ValidatorPubkey]bool{}
 	for _, pubkey := range pubkeys {
@@ -47,13 +114,13 @@ func RecoverMinipoolKeys(c *cli.Context, rp *rocketpool.RocketPool, address comm
 		// Get the custom keystore files
 		files, err := ioutil.ReadDir(customKeyDir)
 		if err != nil {
-			return nil, fmt.Errorf("error enumerating custom keystores: %w", err)
+			return nil, nil, fmt.Errorf("error enumerating custom keystores: %w", err)
 		}
 
 		// Initialize the BLS library
 		err = eth2types.InitBLS()
 		if err != nil {
-			return nil, fmt.Errorf("error initializing BLS: %w", err)
+			return nil, nil, fmt.Errorf("error initializing BLS: %w", err)
 		}
 
 		if len(files) > 0 {
@@ -62,12 +129,12 @@ func RecoverMinipoolKeys(c *cli.Context, rp *rocketpool.RocketPool, address comm
 			passwordFile := cfg.Smartnode.GetCustomKeyPasswordFilePath()
 			fileBytes, err := ioutil.ReadFile(passwordFile)
 			if err != nil {
-				return nil, fmt.Errorf("%d custom keystores were found but the password file could not be loaded: %w", len(files), err)
+				return nil, nil, fmt.Errorf("%d custom keystores were found but the password file could not be loaded: %w", len(files), err)
 			}
 			passwords := map[string]string{}
 			err = yaml.Unmarshal(fileBytes, &passwords)
 			if err != nil {
-				return nil, fmt.Errorf("error unmarshalling custom keystore password file: %w", err)
+				return nil, nil, fmt.Errorf("error unmarshalling custom keystore password file: %w", err)
 			}
 
 			// Process every custom key
@@ -75,14 +142,14 @@ func RecoverMinipoolKeys(c *cli.Context, rp *rocketpool.RocketPool, address comm
 				// Read the file
 				bytes, err := ioutil.ReadFile(filepath.Join(customKeyDir, file.Name()))
 				if err != nil {
-					return nil, fmt.Errorf("error reading custom keystore %s: %w", file.Name(), err)
+					return nil, nil, fmt.Errorf("error reading custom keystore %s: %w", file.Name(), err)
 				}
 
 				// Deserialize it
 				keystore := api.ValidatorKeystore{}
 				err = json.Unmarshal(bytes, &keystore)
 				if err != nil {
-					return nil, fmt.Errorf("error deserializing custom keystore %s: %w", file.Name(), err)
+					return nil, nil, fmt.Errorf("error deserializing custom keystore %s: %w", file.Name(), err)
 				}
 
 				// Check if it's one of the pubkeys for the minipool
@@ -96,18 +163,18 @@ func RecoverMinipoolKeys(c *cli.Context, rp *rocketpool.RocketPool, address comm
 				formattedPubkey := strings.ToUpper(hexutils.RemovePrefix(keystore.Pubkey.Hex()))
 				password, exists := passwords[formattedPubkey]
 				if !exists {
-					return nil, fmt.Errorf("custom keystore for pubkey %s needs a password, but none was provided", keystore.Pubkey.Hex())
+					return nil, nil, fmt.Errorf("custom keystore for pubkey %s needs a password, but none was provided", keystore.Pubkey.Hex())
 				}
 
 				// Get the encryption function it uses
 				kdf, exists := keystore.Crypto["kdf"]
 				if !exists {
-					return nil, fmt.Errorf("error processing custom keystore %s: \"crypto\" didn't contain a subkey named \"kdf\"", file.Name())
+					return nil, nil, fmt.Errorf("error processing custom keystore %s: \"crypto\" didn't contain a subkey named \"kdf\"", file.Name())
 				}
 				kdfMap := kdf.(map[string]interface{})
 				function, exists := kdfMap["function"]
 				if !exists {
-					return nil, fmt.Errorf("error processing custom keystore %s: \"crypto.kdf\" didn't contain a subkey named \"function\"", file.Name())
+					return nil, nil, fmt.Errorf("error processing custom keystore %s: \"crypto.kdf\" didn't contain a subkey named \"function\"", file.Name())
 				}
 				functionString := function.(string)
 
@@ -115,24 +182,24 @@ func RecoverMinipoolKeys(c *cli.Context, rp *rocketpool.RocketPool, address comm
 				encryptor := eth2ks.New(eth2ks.WithCipher(functionString))
 				decryptedKey, err := encryptor.Decrypt(keystore.Crypto, password)
 				if err != nil {
-					return nil, fmt.Errorf("error decrypting keystore for validator %s: %w", keystore.Pubkey.Hex(), err)
+					return nil, nil, fmt.Errorf("error decrypting keystore for validator %s: %w", keystore.Pubkey.Hex(), err)
 				}
 				privateKey, err := eth2types.BLSPrivateKeyFromBytes(decryptedKey)
 				if err != nil {
-					return nil, fmt.Errorf("error recreating private key for validator %s: %w", keystore.Pubkey.Hex(), err)
+					return nil, nil, fmt.Errorf("error recreating private key for validator %s: %w", keystore.Pubkey.Hex(), err)
 				}
 
 				// Verify the private key matches the public key
 				reconstructedPubkey := types.BytesToValidatorPubkey(privateKey.PublicKey().Marshal())
 				if reconstructedPubkey != keystore.Pubkey {
-					return nil, fmt.Errorf("private keystore file %s claims to be for validator %s but it's for validator %s", file.Name(), keystore.Pubkey.Hex(), reconstructedPubkey.Hex())
+					return nil, nil, fmt.Errorf("private keystore file %s claims to be for validator %s but it's for validator %s", file.Name(), keystore.Pubkey.Hex(), reconstructedPubkey.Hex())
 				}
 
 				// Store the key
 				if !testOnly {
 					err = w.StoreValidatorKey(privateKey, keystore.Path)
 					if err != nil {
-						return nil, fmt.Errorf("error storing private keystore for %s: %w", reconstructedPubkey.Hex(), err)
+						return nil, nil, fmt.Errorf("error storing private keystore for %s: %w", reconstructedPubkey.Hex(), err)
 					}
 				}
 
@@ -143,17 +210,72 @@ func RecoverMinipoolKeys(c *cli.Context, rp *rocketpool.RocketPool, address comm
 	}
 
 	// Recover remaining validator keys normally
+	if search == nil {
+		for pubkey := range pubkeyMap {
+			if testOnly {
+				err = w.TestRecoverValidatorKey(pubkey)
+			} else {
+				err = w.RecoverValidatorKey(pubkey)
+			}
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+		return pubkeys, nil, nil
+	}
+
+	// Look for the remaining keys on the Smartnode's own path as usual first, then across all of the searched paths
+	remaining := make([]types.ValidatorPubkey, 0, len(pubkeyMap))
 	for pubkey := range pubkeyMap {
-		if testOnly {
-			err = w.TestRecoverValidatorKey(pubkey)
-		} else {
-			err = w.RecoverValidatorKey(pubkey)
+		remaining = append(remaining, pubkey)
+	}
+	count, err := w.GetValidatorKeyCount()
+	if err != nil {
+		return nil, nil, err
+	}
+	found, err := w.SearchValidatorKeys(remaining, []string{wallet.ValidatorKeyPath}, 0, count+wallet.MaxValidatorKeyRecoverAttempts)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, key := range found {
+		delete(pubkeyMap, key.Pubkey)
+	}
+	if len(pubkeyMap) > 0 {
+		remaining = make([]types.ValidatorPubkey, 0, len(pubkeyMap))
+		for pubkey := range pubkeyMap {
+			remaining = append(remaining, pubkey)
 		}
+		searched, err := w.SearchValidatorKeys(remaining, search.PathTemplates, search.StartIndex, search.EndIndex)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
+		}
+		for _, key := range searched {
+			delete(pubkeyMap, key.Pubkey)
+		}
+		found = append(found, searched...)
+	}
+	if len(pubkeyMap) > 0 {
+		missing := make([]string, 0, len(pubkeyMap))
+		for pubkey := range pubkeyMap {
+			missing = append(missing, pubkey.Hex())
+		}
+		return nil, nil, fmt.Errorf("could not find the keys for validators %s on any of the paths [%s] with indices %d to %d", strings.Join(missing, ", "), strings.Join(search.PathTemplates, ", "), search.StartIndex, search.EndIndex-1)
+	}
+
+	// Store the keys and report where they were found
+	paths := make([]api.ValidatorKeyPath, 0, len(found))
+	for _, key := range found {
+		if !testOnly {
+			if err := w.StoreValidatorKey(key.Key, key.Path); err != nil {
+				return nil, nil, fmt.Errorf("error storing private keystore for %s: %w", key.Pubkey.Hex(), err)
+			}
 		}
+		paths = append(paths, api.ValidatorKeyPath{
+			Pubkey: key.Pubkey,
+			Path:   key.Path,
+		})
 	}
 
-	return pubkeys, nil
+	return pubkeys, paths, nil
 
 }